@@ -0,0 +1,199 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/backup"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// reloadableSettings are the settings a config reload applies to the
+// running server, by koanf path. A change to any other setting is reported
+// as requiring a restart.
+var reloadableSettings = map[string]bool{
+	"logging.level":                   true,
+	"logging.format":                  true,
+	"logging.caller":                  true,
+	"logging.sample_first":            true,
+	"logging.sample_thereafter":       true,
+	"sync.interval":                   true,
+	"sync.batch_size":                 true,
+	"api.cache_ttl":                   true,
+	"api.query_timeout":               true,
+	"security.rate_limit_reqs":        true,
+	"security.rate_limit_window":      true,
+	"security.rate_limit_disabled":    true,
+	"detection.trust_score_decrement": true,
+	"detection.trust_score_recovery":  true,
+	"backup.schedule_enabled":         true,
+	"backup.interval":                 true,
+	"backup.preferred_hour":           true,
+	"backup.type":                     true,
+}
+
+// systemActor is the audit actor for reloads triggered by SIGHUP.
+var systemActor = audit.Actor{ID: "system", Type: "system", Name: "SIGHUP"}
+
+// configReloader implements config hot-reload: it loads the configuration
+// again, applies changed settings listed in reloadableSettings through the
+// registered appliers, and reports every other change as requiring a
+// restart. It is triggered by SIGHUP and POST /api/v1/admin/config/reload.
+type configReloader struct {
+	mu       sync.Mutex
+	running  *config.Config
+	load     func() (*config.Config, error)
+	appliers []settingApplier
+
+	auditLogger *audit.Logger
+}
+
+// settingApplier applies the settings under a koanf path prefix, such as
+// "sync.", from the reloaded configuration.
+type settingApplier struct {
+	prefix string
+	apply  func(cfg *config.Config)
+}
+
+// newConfigReloader creates a reloader for the configuration the server
+// started with. load reads and validates the configuration, normally
+// config.Load.
+func newConfigReloader(running *config.Config, load func() (*config.Config, error)) *configReloader {
+	return &configReloader{running: running, load: load}
+}
+
+// onChange registers apply to run when any setting under prefix changes.
+// Appliers run in registration order with the new running configuration.
+func (r *configReloader) onChange(prefix string, apply func(cfg *config.Config)) {
+	r.appliers = append(r.appliers, settingApplier{prefix: prefix, apply: apply})
+}
+
+// setAuditLogger records applied changes as config.changed audit events.
+func (r *configReloader) setAuditLogger(logger *audit.Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.auditLogger = logger
+}
+
+// Running returns the configuration the server is running with: the
+// startup configuration plus every setting applied by a reload since.
+func (r *configReloader) Running() *config.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.running
+}
+
+// Reload loads the configuration again and applies the reloadable changes.
+// If the new configuration does not load or validate, nothing is applied.
+func (r *configReloader) Reload(ctx context.Context, actor audit.Actor, source audit.Source) (*config.ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded, err := r.load()
+	if err != nil {
+		// Validation errors can quote values; mask the secrets we know of
+		return nil, errors.New(scrubSecrets(r.running, err.Error()))
+	}
+
+	result := &config.ReloadResult{
+		Applied:         []config.Change{},
+		RequiresRestart: []config.Change{},
+	}
+	var keys []string
+	for _, change := range config.Diff(r.running, loaded) {
+		if reloadableSettings[change.Key] {
+			result.Applied = append(result.Applied, change)
+			keys = append(keys, change.Key)
+		} else {
+			result.RequiresRestart = append(result.RequiresRestart, change)
+		}
+	}
+
+	if len(keys) > 0 {
+		r.running = r.running.WithSettings(loaded, keys)
+		for _, applier := range r.appliers {
+			if anyHasPrefix(keys, applier.prefix) {
+				applier.apply(r.running)
+			}
+		}
+	}
+
+	for _, change := range result.Applied {
+		// Logged at warn so changes are recorded at any level
+		logging.Warn().
+			Str("key", change.Key).
+			Interface("old", change.Old).
+			Interface("new", change.New).
+			Str("actor", actor.Name).
+			Msg("Configuration setting changed")
+		if r.auditLogger != nil {
+			r.auditLogger.LogConfigChange(ctx, actor, source, change.Key,
+				fmt.Sprint(change.Old), fmt.Sprint(change.New))
+		}
+	}
+	for _, change := range result.RequiresRestart {
+		logging.Warn().Str("key", change.Key).Msg("Configuration change requires a restart to take effect")
+	}
+	logging.Info().
+		Int("applied", len(result.Applied)).
+		Int("requires_restart", len(result.RequiresRestart)).
+		Msg("Configuration reloaded")
+
+	return result, nil
+}
+
+// reloadOnSIGHUP reloads the configuration on every SIGHUP until ctx is
+// done.
+func (r *configReloader) reloadOnSIGHUP(ctx context.Context) {
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hupCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hupCh:
+				logging.Info().Msg("Received SIGHUP, reloading configuration")
+				if _, err := r.Reload(ctx, systemActor, audit.Source{}); err != nil {
+					logging.Error().Err(err).Msg("Configuration reload failed, keeping the running configuration")
+				}
+			}
+		}
+	}()
+}
+
+// backupSchedule returns the backup schedule set by cfg. PreSyncBackup is
+// not part of the layered configuration and is kept from current.
+func backupSchedule(cfg *config.Config, current backup.ScheduleConfig) backup.ScheduleConfig {
+	return backup.ScheduleConfig{
+		Enabled:       cfg.Backup.ScheduleEnabled,
+		Interval:      cfg.Backup.Interval,
+		PreferredHour: cfg.Backup.PreferredHour,
+		BackupType:    backup.BackupType(cfg.Backup.Type),
+		PreSyncBackup: current.PreSyncBackup,
+	}
+}
+
+// anyHasPrefix reports whether any key starts with prefix.
+func anyHasPrefix(keys []string, prefix string) bool {
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
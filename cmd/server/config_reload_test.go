@@ -0,0 +1,105 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+func TestConfigReloader_Reload(t *testing.T) {
+	running := &config.Config{}
+	running.Sync.Interval = 5 * time.Minute
+	running.Sync.BatchSize = 1000
+	running.Server.Port = 3857
+
+	loaded := *running
+	loaded.Sync.Interval = time.Minute
+	loaded.Sync.BatchSize = 500
+	loaded.Server.Port = 8080
+
+	reloader := newConfigReloader(running, func() (*config.Config, error) {
+		next := loaded
+		return &next, nil
+	})
+	var syncApplied, apiApplied int
+	var gotInterval time.Duration
+	reloader.onChange("sync.", func(cfg *config.Config) {
+		syncApplied++
+		gotInterval = cfg.Sync.Interval
+	})
+	reloader.onChange("api.", func(*config.Config) { apiApplied++ })
+
+	result, err := reloader.Reload(context.Background(), systemActor, audit.Source{})
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := changedKeys(result.Applied); got != "sync.batch_size,sync.interval" {
+		t.Errorf("applied = %s, want sync.batch_size,sync.interval", got)
+	}
+	if got := changedKeys(result.RequiresRestart); got != "server.port" {
+		t.Errorf("requires_restart = %s, want server.port", got)
+	}
+	if syncApplied != 1 || gotInterval != time.Minute {
+		t.Errorf("sync applier ran %d times with interval %v, want once with 1m", syncApplied, gotInterval)
+	}
+	if apiApplied != 0 {
+		t.Errorf("api applier ran %d times without an api change", apiApplied)
+	}
+	if cfg := reloader.Running(); cfg.Sync.Interval != time.Minute || cfg.Server.Port != 3857 {
+		t.Errorf("running config has interval %v and port %d, want 1m and 3857", cfg.Sync.Interval, cfg.Server.Port)
+	}
+
+	// The pending restart is reported again; applied settings are not
+	result, err = reloader.Reload(context.Background(), systemActor, audit.Source{})
+	if err != nil {
+		t.Fatalf("second Reload() error = %v", err)
+	}
+	if len(result.Applied) != 0 || changedKeys(result.RequiresRestart) != "server.port" {
+		t.Errorf("second reload = %+v, want only server.port requiring a restart", result)
+	}
+	if syncApplied != 1 {
+		t.Errorf("sync applier ran again without a change")
+	}
+}
+
+func TestConfigReloader_LoadErrorKeepsRunningConfig(t *testing.T) {
+	const jwtSecret = "jwt-secret-that-is-long-enough-abcd"
+	running := &config.Config{Security: config.SecurityConfig{JWTSecret: jwtSecret}}
+	running.Sync.Interval = 5 * time.Minute
+
+	reloader := newConfigReloader(running, func() (*config.Config, error) {
+		return nil, errors.New("JWT_SECRET " + jwtSecret + " is weak")
+	})
+	applied := false
+	reloader.onChange("sync.", func(*config.Config) { applied = true })
+
+	_, err := reloader.Reload(context.Background(), systemActor, audit.Source{})
+	if err == nil {
+		t.Fatal("Reload() succeeded with an invalid configuration")
+	}
+	if strings.Contains(err.Error(), jwtSecret) {
+		t.Errorf("error leaks the JWT secret: %v", err)
+	}
+	if applied || reloader.Running() != running {
+		t.Error("failed reload changed the running configuration")
+	}
+}
+
+// changedKeys joins the keys of changes with commas.
+func changedKeys(changes []config.Change) string {
+	keys := make([]string, len(changes))
+	for i, change := range changes {
+		keys[i] = change.Key
+	}
+	return strings.Join(keys, ",")
+}
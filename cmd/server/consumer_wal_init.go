@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 
 	"github.com/tomtom215/cartographus/internal/database"
@@ -175,10 +174,10 @@ func (c *DuckDBRecoveryCallback) TransactionIDExists(ctx context.Context, transa
 // InsertEvent inserts the event payload into DuckDB with the transaction ID.
 // The payload is the raw MediaEvent JSON.
 func (c *DuckDBRecoveryCallback) InsertEvent(ctx context.Context, payload []byte, transactionID string) error {
-	// Parse the MediaEvent from payload
-	var event eventprocessor.MediaEvent
-	if err := json.Unmarshal(payload, &event); err != nil {
-		return fmt.Errorf("unmarshal event: %w", err)
+	// Parse the MediaEvent from payload, upgrading older schema versions
+	event, err := eventprocessor.MigrateEvent(payload)
+	if err != nil {
+		return err
 	}
 
 	// Set the transaction ID
@@ -193,8 +192,8 @@ func (c *DuckDBRecoveryCallback) InsertEvent(ctx context.Context, payload []byte
 // Called when max retries are exceeded.
 func (c *DuckDBRecoveryCallback) InsertFailedEvent(ctx context.Context, entry *wal.ConsumerWALEntry, reason string) error {
 	// Parse the MediaEvent from payload to extract metadata
-	var event eventprocessor.MediaEvent
-	if err := json.Unmarshal(entry.EventPayload, &event); err != nil {
+	event, err := eventprocessor.MigrateEvent(entry.EventPayload)
+	if err != nil {
 		// If we can't parse, store what we can
 		event.EventID = entry.ID
 		event.Source = "unknown"
@@ -117,6 +117,15 @@ The server handles graceful shutdown on SIGINT and SIGTERM:
  5. Flushes pending writes and closes database
  6. Reports any services that failed to stop
 
+SIGHUP reloads the configuration without a restart, as does
+POST /api/v1/admin/config/reload. Changes to the log level and format,
+sync interval and batch size, cache TTL, rate limits, detection trust score
+amounts and backup schedule are applied and audited as config.changed;
+changes to anything else, such as the database path, ports or auth mode,
+are reported as requiring a restart:
+
+	kill -HUP $(pidof cartographus)
+
 # Usage Examples
 
 Development (no auth):
@@ -143,6 +152,16 @@ Docker:
 	  -p 3857:3857 \
 	  ghcr.io/tomtom215/cartographus
 
+# Validating Configuration
+
+The --validate-config flag checks the configuration without starting the
+server. It loads it the same way (defaults, config file, environment), runs
+every validation check rather than stopping at the first failure, pings each
+enabled media server with a 5 second timeout, and prints a report with
+secrets masked. The exit status is 1 if anything failed:
+
+	./cartographus --validate-config
+
 # Port 3857
 
 The default port 3857 references EPSG:3857 (Web Mercator projection),
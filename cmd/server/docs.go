@@ -87,6 +87,10 @@
 // @tag.name Realtime
 // @tag.description Real-time WebSocket connections for live playback notifications and statistics updates
 //
+// @tag.name Spatial Analytics
+// @tag.description Geospatial queries (H3 hexagons, connection arcs, viewport and proximity search) backed by the DuckDB spatial extension
+// @tag.name Detection
+// @tag.description Anomaly detection alerts, rules, user trust scores and trusted locations
 // @tag.name Admin
 // @tag.description Administrative operations requiring authentication (sync management, system configuration)
 //
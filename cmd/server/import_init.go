@@ -3,21 +3,21 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // https://github.com/tomtom215/cartographus
 
-//go:build nats
-
 package main
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/dgraph-io/badger/v4"
+	"github.com/go-chi/chi/v5"
 	"github.com/tomtom215/cartographus/internal/api"
 	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/database"
 	tautulliimport "github.com/tomtom215/cartographus/internal/import"
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/supervisor"
 	"github.com/tomtom215/cartographus/internal/supervisor/services"
+	ws "github.com/tomtom215/cartographus/internal/websocket"
 )
 
 // importerAdapter wraps tautulliimport.Importer to implement services.ImporterInterface.
@@ -48,26 +48,37 @@ type ImportComponents struct {
 	progress tautulliimport.ProgressTracker
 	service  *services.ImportService
 	handlers *api.ImportHandlers
+
+	jellystat *tautulliimport.JellystatImporter
 }
 
 // InitImport initializes the Tautulli database import functionality.
 // It creates the importer, progress tracker, supervisor service, and API handlers.
 //
+// The event path is selected automatically: events are published to NATS
+// when a publisher is available, otherwise (standard builds, or NATS
+// disabled) they are inserted directly into the database.
+//
 // Parameters:
 //   - cfg: Application configuration with import settings
-//   - natsComponents: NATS components providing the event publisher
+//   - natsComponents: NATS components providing the event publisher (nil if disabled)
 //   - tree: Supervisor tree for adding the import service
 //   - router: API router for registering import endpoints
+//   - wsHub: WebSocket hub for broadcasting import progress (optional)
+//   - db: Database for direct-mode inserts and resolving Jellyfin user IDs
 //
 // Returns nil if import is disabled in configuration.
-func InitImport(cfg *config.Config, natsComponents *NATSComponents, tree *supervisor.SupervisorTree, router *api.Router) (*ImportComponents, error) {
+func InitImport(cfg *config.Config, natsComponents *NATSComponents, tree *supervisor.SupervisorTree, router *api.Router, wsHub *ws.Hub, db *database.DB) (*ImportComponents, error) {
 	if !cfg.Import.Enabled {
 		logging.Info().Msg("Tautulli database import disabled (IMPORT_ENABLED=false)")
 		return nil, nil
 	}
 
-	if natsComponents == nil {
-		logging.Info().Msg("Tautulli database import requires NATS to be enabled")
+	// Get event publisher from NATS components
+	// Without one, events go straight to the database (direct mode)
+	publisher := natsComponents.ImportPublisher()
+	if publisher == nil && db == nil {
+		logging.Warn().Msg("Tautulli database import requires NATS or a database for direct inserts")
 		return nil, nil
 	}
 
@@ -78,34 +89,40 @@ func InitImport(cfg *config.Config, natsComponents *NATSComponents, tree *superv
 	// Create progress tracker
 	// Use BadgerDB if WAL is enabled for persistent progress across restarts
 	// Otherwise fall back to in-memory progress
-	var progress tautulliimport.ProgressTracker
+	var progress, jellystatProgress tautulliimport.ProgressTracker
 	if badgerDB := natsComponents.BadgerDB(); badgerDB != nil {
-		if db, ok := badgerDB.(*badger.DB); ok {
-			progress = tautulliimport.NewBadgerProgress(db)
+		if bdb, ok := badgerDB.(*badger.DB); ok {
+			progress = tautulliimport.NewBadgerProgress(bdb)
+			jellystatProgress = tautulliimport.NewBadgerProgressWithKey(bdb, tautulliimport.JellystatProgressKey)
 			components.progress = progress
 			logging.Info().Msg("Import progress tracker created (BadgerDB - persistent)")
 		} else {
 			progress = tautulliimport.NewInMemoryProgress()
+			jellystatProgress = tautulliimport.NewInMemoryProgress()
 			components.progress = progress
 			logging.Warn().Msg("Import progress tracker created (in-memory - BadgerDB type assertion failed)")
 		}
 	} else {
 		progress = tautulliimport.NewInMemoryProgress()
+		jellystatProgress = tautulliimport.NewInMemoryProgress()
 		components.progress = progress
 		logging.Info().Msg("Import progress tracker created (in-memory - WAL not enabled)")
 	}
 
-	// Get event publisher from NATS components
-	// The publisher implements tautulliimport.EventPublisher interface
-	publisher := natsComponents.publisher
-
 	// Create the importer
 	importer := tautulliimport.NewImporter(&cfg.Import, publisher, progress)
 	components.importer = importer
+	if publisher == nil {
+		importer.SetEventStore(db)
+	}
+	if wsHub != nil {
+		importer.SetBroadcaster(wsHub)
+	}
 	logging.Info().
 		Str("db_path", cfg.Import.DBPath).
 		Int("batch_size", cfg.Import.BatchSize).
 		Bool("dry_run", cfg.Import.DryRun).
+		Bool("direct_mode", importer.DirectMode()).
 		Msg("Importer created")
 
 	// Create import service for supervisor
@@ -114,21 +131,39 @@ func InitImport(cfg *config.Config, natsComponents *NATSComponents, tree *superv
 	importService := services.NewImportService(adapter, cfg.Import.AutoStart)
 	components.service = importService
 
-	// Add to supervisor tree (messaging layer)
-	tree.AddMessagingService(importService)
+	// Add to supervisor tree (messaging layer with NATS, data layer in direct mode)
+	if publisher != nil {
+		tree.AddMessagingService(importService)
+	} else {
+		tree.AddDataService(importService)
+	}
 	if cfg.Import.AutoStart {
 		logging.Info().Msg("Import service added to supervisor tree (auto-start enabled)")
 	} else {
 		logging.Info().Msg("Import service added to supervisor tree (on-demand mode)")
 	}
 
+	// Create the Jellystat/Playback Reporting importer (on demand via API only)
+	jellystatImporter := tautulliimport.NewJellystatImporter(&cfg.Import, publisher, jellystatProgress)
+	components.jellystat = jellystatImporter
+	if publisher == nil {
+		jellystatImporter.SetEventStore(db)
+	}
+	if wsHub != nil {
+		jellystatImporter.SetBroadcaster(wsHub)
+	}
+	if db != nil {
+		jellystatImporter.SetUserResolver(db)
+	}
+
 	// Create API handlers
 	handlers := api.NewImportHandlers(importer, progress)
 	components.handlers = handlers
+	jellystatHandlers := api.NewJellystatImportHandlers(jellystatImporter, jellystatProgress)
 
 	// Register import routes via the route registrar
-	router.SetImportRouteRegistrar(func(mux *http.ServeMux) {
-		router.RegisterImportRoutes(mux, handlers)
+	router.SetImportRouteRegistrar(func(r chi.Router) {
+		router.RegisterImportRoutes(r, handlers, jellystatHandlers)
 		logging.Info().Msg("Import API routes registered")
 	})
 
@@ -144,6 +179,14 @@ func (c *ImportComponents) Importer() *tautulliimport.Importer {
 	return c.importer
 }
 
+// JellystatImporter returns the Jellystat importer instance.
+func (c *ImportComponents) JellystatImporter() *tautulliimport.JellystatImporter {
+	if c == nil {
+		return nil
+	}
+	return c.jellystat
+}
+
 // Progress returns the progress tracker.
 func (c *ImportComponents) Progress() tautulliimport.ProgressTracker {
 	if c == nil {
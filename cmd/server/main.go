@@ -63,6 +63,10 @@
 //   - Closes sync manager and database connections
 //   - Shuts down NATS components if enabled
 //
+// SIGHUP reloads the configuration and applies the settings that can change
+// at runtime (see config_reload.go); other changes are logged as requiring
+// a restart.
+//
 // # Example Usage
 //
 // Standalone mode with Plex (no Tautulli):
@@ -107,6 +111,10 @@
 //	  -p 3857:3857 \
 //	  ghcr.io/tomtom215/cartographus
 //
+// Check the configuration and media server connectivity, then exit:
+//
+//	./cartographus --validate-config
+//
 // # Port 3857
 //
 // The default port 3857 references EPSG:3857 (Web Mercator projection),
@@ -115,7 +123,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -123,6 +133,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 	_ "github.com/tomtom215/cartographus/docs" // Import generated swagger docs
 	"github.com/tomtom215/cartographus/internal/api"
@@ -133,17 +144,36 @@ import (
 	"github.com/tomtom215/cartographus/internal/database"
 	"github.com/tomtom215/cartographus/internal/detection"
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
 	"github.com/tomtom215/cartographus/internal/supervisor"
 	"github.com/tomtom215/cartographus/internal/supervisor/services"
 	"github.com/tomtom215/cartographus/internal/sync"
+	"github.com/tomtom215/cartographus/internal/tracing"
 	"github.com/tomtom215/cartographus/internal/vpn"
 	ws "github.com/tomtom215/cartographus/internal/websocket"
 )
 
 //nolint:gocyclo // Main initialization function with sequential setup steps
 func main() {
-	// Load configuration first to get logging settings
-	cfg, err := config.Load()
+	validateConfig := flag.Bool("validate-config", false, "validate the configuration, probe enabled media servers, print a report and exit")
+	migrateOnly := flag.Bool("migrate-only", false, "apply pending database migrations and exit without starting services")
+	flag.Parse()
+
+	if *validateConfig {
+		// Keep the report readable; only warnings and errors are logged
+		logging.Init(logging.Config{Level: "warn", Format: "console"})
+		os.Exit(runValidateConfig(context.Background(), os.Stdout, validateConfigProbeTimeout))
+	}
+
+	// Load configuration first to get logging settings; a fresh install
+	// waits in setup mode here until the wizard writes config.yaml
+	cfg, err := loadConfig()
+	if errors.Is(err, errSetupInterrupted) {
+		logging.Info().Msg("Setup interrupted, exiting")
+		return
+	}
 	if err != nil {
 		// Use default logger for config errors (config not yet available)
 		logging.Fatal().Err(err).Msg("Failed to load configuration")
@@ -154,10 +184,35 @@ func main() {
 		Level:  cfg.Logging.Level,
 		Format: cfg.Logging.Format,
 		Caller: cfg.Logging.Caller,
+
+		SampleFirst:      cfg.Logging.SampleFirst,
+		SampleThereafter: cfg.Logging.SampleThereafter,
 	})
 
 	logging.Info().Msg("Starting Cartographus with supervisor tree")
 
+	// Register the application metrics served at /metrics
+	if err := metrics.Init(); err != nil {
+		logging.Fatal().Err(err).Msg("Failed to register Prometheus metrics")
+	}
+
+	// Initialize OpenTelemetry tracing before the database so the driver can be
+	// instrumented. No-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set.
+	shutdownTracing, err := tracing.Init(context.Background())
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to initialize tracing, continuing without it")
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logging.Warn().Err(err).Msg("Error flushing trace spans")
+		}
+	}()
+	if tracing.Enabled() {
+		logging.Info().Msg("OpenTelemetry tracing enabled")
+	}
+
 	// Log configuration status - show Tautulli status based on Enabled flag
 	if cfg.Tautulli.Enabled {
 		logging.Info().
@@ -173,6 +228,11 @@ func main() {
 			Msg("Configuration loaded (standalone mode)")
 	}
 
+	// Install the IP anonymizer before anything can store a client address
+	if err := initPrivacy(&cfg.Privacy); err != nil {
+		logging.Fatal().Err(err).Msg("Failed to configure IP anonymization")
+	}
+
 	// Initialize database with server location for spatial optimizations
 	db, err := database.New(&cfg.Database, cfg.Server.Latitude, cfg.Server.Longitude)
 	if err != nil {
@@ -185,6 +245,18 @@ func main() {
 	}()
 	logging.Info().Msg("Database initialized successfully")
 
+	if *migrateOnly {
+		version, err := db.GetCurrentSchemaVersion()
+		if err != nil {
+			if closeErr := db.Close(); closeErr != nil {
+				logging.Error().Err(closeErr).Msg("Error closing database")
+			}
+			logging.Fatal().Err(err).Msg("Failed to read schema version")
+		}
+		logging.Info().Int("schema_version", version).Msg("Database migrations complete (--migrate-only), exiting")
+		return
+	}
+
 	// Seed mock data if enabled (for CI/CD screenshot tests)
 	if cfg.Database.SeedMockData {
 		logging.Info().Msg("Mock data seeding enabled (SEED_MOCK_DATA=true)")
@@ -235,12 +307,34 @@ func main() {
 	tree, err := supervisor.NewSupervisorTree(slogLogger, supervisor.TreeConfig{
 		FailureThreshold: 5,
 		FailureBackoff:   15 * time.Second,
-		ShutdownTimeout:  10 * time.Second,
+		// Longer than the HTTP drain, so the supervisor does not abandon
+		// the HTTP service while requests are still finishing
+		ShutdownTimeout: cfg.Server.ShutdownTimeout + 5*time.Second,
 	})
 	if err != nil {
 		logging.Fatal().Err(err).Msg("Failed to create supervisor tree")
 	}
 
+	// Export database size, memory, row count and pool gauges (DB_METRICS_INTERVAL)
+	if cfg.Database.MetricsInterval > 0 {
+		tree.AddDataService(database.NewMetricsCollector(db, cfg.Database.MetricsInterval))
+		logging.Info().Dur("interval", cfg.Database.MetricsInterval).Msg("Database metrics collector added to supervisor tree")
+	}
+
+	// Probe the connection pool and recycle broken connections (DB_HEALTH_CHECK_INTERVAL)
+	if cfg.Database.HealthCheckInterval > 0 {
+		tree.AddDataService(database.NewPoolHealthChecker(db, cfg.Database.HealthCheckInterval))
+		logging.Info().Dur("interval", cfg.Database.HealthCheckInterval).Msg("Database pool health checker added to supervisor tree")
+	}
+
+	// Pre-generate low-zoom vector tiles at startup and after each sync (DB_TILE_WARM_ENABLED)
+	var tileWarmer *database.TileWarmer
+	if cfg.Database.TileWarmEnabled && db.IsSpatialAvailable() {
+		tileWarmer = database.NewTileWarmer(db, cfg.Database.TileWarmMaxZoom)
+		tree.AddDataService(tileWarmer)
+		logging.Info().Int("max_zoom", cfg.Database.TileWarmMaxZoom).Msg("Vector tile cache warmer added to supervisor tree")
+	}
+
 	// Create WebSocket hub for real-time updates (before sync manager)
 	// This must be created early so the sync manager can use it for Plex WebSocket broadcasts (v1.39)
 	wsHub := ws.NewHub()
@@ -249,6 +343,12 @@ func main() {
 	// The database implements UserResolver for mapping external user IDs to internal IDs (v2.0)
 	syncManager := sync.NewManager(db, db, tautulliClient, cfg, wsHub)
 
+	// Quarantine directly-persisted events with implausible timestamps
+	syncManager.SetQuarantineStore(db)
+
+	// Persist per-source watermarks so syncs only fetch newer history
+	syncManager.SetWatermarkStore(db)
+
 	// Create Jellyfin managers (v2.1: multi-server support)
 	// The database is passed as UserResolver for mapping Jellyfin UUIDs to internal user IDs
 	var jellyfinManagers []*sync.JellyfinManager
@@ -376,13 +476,53 @@ func main() {
 
 	handler := api.NewHandler(db, syncManager, tautulliClient, cfg, jwtManager, wsHub)
 
-	// Register sync completion callback to clear cache and broadcast updates after each sync
-	syncManager.SetOnSyncCompleted(handler.OnSyncCompleted)
+	// Export the performance monitor's per-endpoint percentiles on /metrics
+	if err := prometheus.Register(handler.PerformanceMonitor()); err != nil {
+		logging.Warn().Err(err).Msg("Failed to register performance monitor metrics")
+	}
+
+	// Register sync completion callback to clear cache, broadcast updates and
+	// re-warm the tile cache after each sync
+	onSyncCompleted := handler.OnSyncCompleted
+	if tileWarmer != nil {
+		onSyncCompleted = func(newRecords int, durationMs int64) {
+			handler.OnSyncCompleted(newRecords, durationMs)
+			tileWarmer.Trigger()
+		}
+	}
+	syncManager.SetOnSyncCompleted(onSyncCompleted)
+
+	// Register media source probes for /api/v1/sources/health
+	sourceCheckers := []sync.SourceHealthChecker{syncManager}
+	for _, jfMgr := range jellyfinManagers {
+		sourceCheckers = append(sourceCheckers, jfMgr)
+	}
+	for _, embyMgr := range embyManagers {
+		sourceCheckers = append(sourceCheckers, embyMgr)
+	}
+	handler.SetSourceHealthCheckers(sourceCheckers...)
+
+	// Register live session sources for /api/v1/now-playing
+	nowPlayingProviders := []sync.NowPlayingProvider{syncManager}
+	for _, jfMgr := range jellyfinManagers {
+		nowPlayingProviders = append(nowPlayingProviders, jfMgr)
+	}
+	for _, embyMgr := range embyManagers {
+		nowPlayingProviders = append(nowPlayingProviders, embyMgr)
+	}
+	handler.SetNowPlayingProviders(syncManager, nowPlayingProviders...)
+
+	// Report crash-looping and backed-off services on /health/ready
+	handler.SetReadinessChecker(tree)
 
 	// === DETECTION ENGINE INITIALIZATION (ADR-0020) ===
 	// Initialize detection system for anomaly detection and security monitoring
 	// Must be initialized before NATS so detection handler can subscribe to events
-	detectionEngine, detectionHandlers := initDetection(ctx, db, wsHub, cfg)
+	detectionEngine, detectionHandlers, vpnUpdater := initDetection(ctx, db, wsHub, cfg)
+	if vpnUpdater != nil {
+		handler.SetVPNUpdater(vpnUpdater)
+		handler.SetVPNRanges(vpnUpdater.Service())
+	}
 	if detectionEngine != nil {
 		logging.Info().Msg("Detection engine initialized successfully")
 
@@ -393,6 +533,12 @@ func main() {
 			recoveryAmount = 1 // Default to 1 point per day
 		}
 		detectionEngine.StartTrustScoreRecovery(ctx, recoveryAmount, 24*time.Hour)
+
+		// Forward critical Plex buffer health alerts to the notifiers
+		if cfg.Plex.BufferHealthNotify {
+			syncManager.SetOnBufferCritical(bufferCriticalNotifier(detectionEngine))
+			logging.Info().Msg("Buffer health critical alerts wired to detection notifiers")
+		}
 	}
 
 	// Initialize NATS event processing (optional - requires build with -tags nats)
@@ -424,11 +570,14 @@ func main() {
 	}
 
 	// Initialize backup manager for backup/restore functionality
+	var backupManager *backup.Manager
 	backupCfg, err := backup.LoadConfig()
 	if err != nil {
 		logging.Warn().Err(err).Msg("Failed to load backup configuration, backups disabled")
 	} else if backupCfg.Enabled {
-		backupManager, err := backup.NewManager(backupCfg, db)
+		// The schedule can also come from config.yaml and be reloaded
+		backupCfg.Schedule = backupSchedule(cfg, backupCfg.Schedule)
+		backupManager, err = backup.NewManager(backupCfg, db)
 		if err != nil {
 			logging.Warn().Err(err).Msg("Failed to initialize backup manager")
 		} else {
@@ -451,7 +600,7 @@ func main() {
 
 				// Register pre-sync backup callback if enabled
 				if backupCfg.Schedule.PreSyncBackup {
-					originalCallback := handler.OnSyncCompleted
+					originalCallback := onSyncCompleted
 					syncManager.SetOnSyncCompleted(func(newRecords int, durationMs int64) {
 						// Create pre-sync snapshot before processing
 						if _, err := backupManager.CreatePreSyncBackup(context.Background()); err != nil {
@@ -476,16 +625,30 @@ func main() {
 		logging.Info().Msg("Detection routes configured")
 	}
 
+	// Runtime diagnostics (/debug) are mounted only when explicitly enabled
+	var diagnosticsHandlers *api.DiagnosticsHandlers
+	switch {
+	case cfg.Server.DiagnosticsEnabled:
+		diagnosticsHandlers = api.NewDiagnosticsHandlers()
+		router.ConfigureDiagnostics(diagnosticsHandlers)
+		logging.Warn().Msg("Diagnostics routes enabled at /debug (admin only)")
+	case cfg.Server.PprofEnabled:
+		diagnosticsHandlers = api.NewDiagnosticsHandlers()
+		router.ConfigurePprof(diagnosticsHandlers)
+		logging.Warn().Msg("pprof routes enabled at /debug/pprof (admin only)")
+	}
+
 	// === AUDIT LOGGING SYSTEM INITIALIZATION ===
 	// Initialize DuckDB-backed audit store for persistent security audit trail.
 	// This addresses CRITICAL-001: Audit events not persisted to database.
+	var auditLogger *audit.Logger
 	auditStore := audit.NewDuckDBStore(db.Conn())
 	if err := auditStore.CreateTable(ctx); err != nil {
 		logging.Warn().Err(err).Msg("Failed to create audit events table - audit logging disabled")
 	} else {
 		// Create audit logger with default config
 		auditConfig := audit.DefaultConfig()
-		auditLogger := audit.NewLogger(auditStore, auditConfig)
+		auditLogger = audit.NewLogger(auditStore, auditConfig)
 		defer func() {
 			if err := auditLogger.Close(); err != nil {
 				logging.Error().Err(err).Msg("Error closing audit logger")
@@ -498,19 +661,85 @@ func main() {
 		// Configure audit handlers for the router
 		auditHandlers := api.NewAuditHandlers(auditLogger, auditStore)
 		router.ConfigureAudit(auditHandlers)
+		if detectionHandlers != nil {
+			detectionHandlers.SetAuditLogger(auditLogger)
+		}
+		if diagnosticsHandlers != nil {
+			diagnosticsHandlers.SetAuditLogger(auditLogger)
+		}
+		handler.SetAuditLogger(auditLogger)
 		logging.Info().Msg("Audit logging initialized with DuckDB persistence")
 	}
 
-	// Initialize Tautulli database import (optional - requires build with -tags nats)
+	// === DATA RETENTION ===
+	// Dry run at /api/v1/admin/db/retention/dry-run; pruning with RETENTION_ENABLED
+	initRetention(cfg, db, tree, handler, tileWarmer, auditLogger)
+
+	// === CONFIG HOT-RELOAD ===
+	// SIGHUP or POST /api/v1/admin/config/reload applies changed settings
+	// listed in reloadableSettings without a restart
+	reloader := newConfigReloader(cfg, config.Load)
+	reloader.onChange("logging.", func(c *config.Config) {
+		logging.Init(logging.Config{
+			Level:  c.Logging.Level,
+			Format: c.Logging.Format,
+			Caller: c.Logging.Caller,
+
+			SampleFirst:      c.Logging.SampleFirst,
+			SampleThereafter: c.Logging.SampleThereafter,
+		})
+	})
+	reloader.onChange("sync.", func(c *config.Config) {
+		syncManager.SetSyncSettings(c.Sync.Interval, c.Sync.BatchSize)
+	})
+	reloader.onChange("api.", func(c *config.Config) {
+		handler.SetCacheTTL(c.API.CacheTTL)
+		handler.SetQueryTimeout(c.API.QueryTimeout)
+	})
+	reloader.onChange("security.", func(c *config.Config) {
+		router.SetRateLimit(c.Security.RateLimitReqs, c.Security.RateLimitWindow, c.Security.RateLimitDisabled)
+	})
+	if detectionEngine != nil {
+		reloader.onChange("detection.", func(c *config.Config) {
+			detectionEngine.SetTrustScoreSettings(c.Detection.TrustScoreDecrement, c.Detection.TrustScoreRecovery)
+		})
+	}
+	if backupManager != nil {
+		reloader.onChange("backup.", func(c *config.Config) {
+			schedule := backupSchedule(c, backupManager.GetScheduleConfig())
+			if err := backupManager.SetScheduleConfig(ctx, schedule); err != nil {
+				logging.Error().Err(err).Msg("Failed to apply reloaded backup schedule")
+			}
+		})
+	}
+	if auditLogger != nil {
+		reloader.setAuditLogger(auditLogger)
+	}
+	handler.SetConfigReloader(reloader)
+	reloader.reloadOnSIGHUP(ctx)
+
+	// Initialize Tautulli database import (optional - publishes to NATS when available, otherwise inserts directly)
 	// This must be called before router.Setup() to register import routes
-	_, err = InitImport(cfg, natsComponents, tree, router)
+	_, err = InitImport(cfg, natsComponents, tree, router, wsHub, db)
 	if err != nil {
 		logging.Fatal().Err(err).Msg("Failed to initialize import")
 	}
 
+	// Initialize recommendation engine (if enabled)
+	// This must be called before router.SetupChi() to register recommendation routes
+	if recommendComponents := initRecommend(cfg, db, syncManager, zerolog.Nop(), tree); recommendComponents != nil {
+		recommendHandler := api.NewRecommendHandlerWithEngine(recommendComponents.Engine, db)
+		recommendHandler.SetTrainingCoordinator(recommendComponents.Coordinator)
+		router.ConfigureRecommend(recommendHandler)
+		logging.Info().Msg("Recommendation routes configured")
+	}
+
+	// The drainer turns new requests away during shutdown while in-flight
+	// ones finish (HTTP_SHUTDOWN_TIMEOUT)
+	drainer := services.NewRequestDrainer()
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
-		Handler:      router.SetupChi(), // ADR-0016: Chi router for route grouping
+		Handler:      drainer.Middleware(router.SetupChi()), // ADR-0016: Chi router for route grouping
 		ReadTimeout:  cfg.Server.Timeout,
 		WriteTimeout: cfg.Server.Timeout,
 		IdleTimeout:  60 * time.Second,
@@ -525,15 +754,19 @@ func main() {
 		tree.AddMessagingService(services.NewDetectionService(detectionEngine))
 		logging.Info().Msg("Detection engine added to supervisor tree")
 	}
+	if vpnUpdater != nil && cfg.VPN.AutoUpdate {
+		tree.AddMessagingService(services.NewVPNUpdaterService(vpnUpdater))
+		logging.Info().Dur("interval", cfg.VPN.UpdateInterval).Msg("VPN updater added to supervisor tree")
+	}
 	logging.Info().Msg("WebSocket hub and sync manager added to supervisor tree")
 
-	// Initialize recommendation engine (if enabled)
-	_ = initRecommend(cfg, zerolog.Nop(), tree)
-
 	// Initialize newsletter scheduler (if enabled)
 	// Provides cron-based automatic newsletter delivery
 	nopLogger := zerolog.Nop()
-	_ = initNewsletter(cfg, db, &nopLogger, tree)
+	if newsletterComponents := initNewsletter(cfg, db, &nopLogger, tree); newsletterComponents != nil {
+		// Live template previews resolve content the same way sends do
+		handler.SetNewsletterContentResolver(newsletterComponents.ContentResolver)
+	}
 
 	// Add all Jellyfin/Emby managers to supervisor tree (v2.1: multi-server support)
 	for _, jfMgr := range jellyfinManagers {
@@ -550,7 +783,7 @@ func main() {
 	}
 
 	// API layer services
-	tree.AddAPIService(services.NewHTTPServerService(server, 10*time.Second))
+	tree.AddAPIService(services.NewHTTPServerServiceWithDrainer(server, drainer, cfg.Server.ShutdownTimeout))
 	logging.Info().Str("addr", server.Addr).Msg("HTTP server service added")
 
 	// === START SUPERVISOR TREE ===
@@ -605,13 +838,16 @@ func main() {
 //   - Device IP velocity (rapid IP changes)
 //   - Simultaneous locations (active streams from different locations)
 //   - Geographic restrictions (country blocklist/allowlist)
+//   - New devices (first stream from a device ID the user never used)
+//
+// The VPN data updater is returned when VPN detection is enabled.
 //
-// Returns nil, nil if initialization fails (non-fatal - app continues without detection).
-func initDetection(ctx context.Context, db *database.DB, broadcaster detection.AlertBroadcaster, cfg *config.Config) (*detection.Engine, *api.DetectionHandlers) {
+// Returns nil, nil, nil if initialization fails (non-fatal - app continues without detection).
+func initDetection(ctx context.Context, db *database.DB, broadcaster detection.AlertBroadcaster, cfg *config.Config) (*detection.Engine, *api.DetectionHandlers, *vpn.Updater) {
 	// Check if detection is disabled
 	if !cfg.Detection.Enabled {
 		logging.Info().Msg("Detection engine disabled (DETECTION_ENABLED=false)")
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// Create the DuckDB store for detection data
@@ -621,11 +857,12 @@ func initDetection(ctx context.Context, db *database.DB, broadcaster detection.A
 	if err := store.InitSchema(ctx); err != nil {
 		logging.Warn().Err(err).Msg("Failed to initialize detection schema")
 		logging.Info().Msg("Detection system disabled - continuing without anomaly detection")
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// Create detection engine
 	engine := detection.NewEngine(store, store, store, broadcaster)
+	engine.SetAllowlist(store) // Trusted locations suppress alerts
 
 	// Register all detectors
 	engine.RegisterDetector(detection.NewImpossibleTravelDetector(store))
@@ -634,8 +871,11 @@ func initDetection(ctx context.Context, db *database.DB, broadcaster detection.A
 	engine.RegisterDetector(detection.NewSimultaneousLocationsDetector(store))
 	engine.RegisterDetector(detection.NewGeoRestrictionDetector(store))
 	engine.RegisterDetector(detection.NewUserAgentAnomalyDetector(store))
+	engine.RegisterDetector(detection.NewAccountSharingDetector(store))
+	engine.RegisterDetector(detection.NewNewDeviceDetector(store))
 
 	// Initialize VPN service for VPN usage detection
+	var vpnUpdater *vpn.Updater
 	if cfg.VPN.Enabled {
 		vpnConfig := &vpn.Config{
 			Enabled:        cfg.VPN.Enabled,
@@ -657,12 +897,33 @@ func initDetection(ctx context.Context, db *database.DB, broadcaster detection.A
 						logging.Warn().Err(err).Str("file", cfg.VPN.DataFile).Msg("Failed to import VPN data file")
 					}
 				}
-				// Register VPN usage detector
+				if cfg.VPN.DatacenterFile != "" {
+					if _, err := vpnSvc.ImportDatacenterFromFile(ctx, cfg.VPN.DatacenterFile); err != nil {
+						logging.Warn().Err(err).Str("file", cfg.VPN.DatacenterFile).Msg("Failed to import datacenter range file")
+					}
+				}
+				// Updates from VPN_UPDATE_URL: scheduled (VPN_AUTO_UPDATE) or forced by an admin
+				updaterConfig := vpn.DefaultUpdaterConfig()
+				updaterConfig.Enabled = cfg.VPN.AutoUpdate
+				updaterConfig.SourceURL = cfg.VPN.UpdateURL
+				updaterConfig.UpdateInterval = cfg.VPN.UpdateInterval
+				updaterConfig.DatacenterURL = cfg.VPN.DatacenterURL
+				vpnUpdater = vpn.NewUpdaterWithStore(vpnSvc, vpn.NewDuckDBStore(db.Conn()), updaterConfig)
+				if err := vpnUpdater.LoadStatus(ctx); err != nil {
+					logging.Warn().Err(err).Msg("Failed to load VPN update status")
+				}
+
+				// Register VPN usage and datacenter stream detectors
 				engine.RegisterDetector(detection.NewVPNUsageDetector(vpnSvc))
+				engine.RegisterDetector(detection.NewDatacenterStreamDetector(vpnSvc))
+
+				// Tag stored playback events with their connection type
+				db.SetConnectionClassifier(vpnSvc)
 				stats := vpnSvc.GetStats()
 				logging.Info().
 					Int("providers", stats.TotalProviders).
 					Int("ips", stats.TotalIPs).
+					Int("datacenter_ranges", stats.DatacenterRanges).
 					Msg("VPN detection service initialized")
 			}
 		}
@@ -677,7 +938,7 @@ func initDetection(ctx context.Context, db *database.DB, broadcaster detection.A
 			Enabled:     cfg.Detection.Discord.Enabled,
 			RateLimitMs: cfg.Detection.Discord.RateLimitMs,
 		})
-		engine.RegisterNotifier(discordNotifier)
+		engine.RegisterNotifierWithMinSeverity(discordNotifier, notifierMinSeverity(cfg.Detection.Discord.MinSeverity))
 		logging.Info().Int("rate_limit_ms", cfg.Detection.Discord.RateLimitMs).Msg("Discord notifier registered")
 	}
 
@@ -689,13 +950,45 @@ func initDetection(ctx context.Context, db *database.DB, broadcaster detection.A
 			Enabled:     cfg.Detection.Webhook.Enabled,
 			RateLimitMs: cfg.Detection.Webhook.RateLimitMs,
 		})
-		engine.RegisterNotifier(webhookNotifier)
+		engine.RegisterNotifierWithMinSeverity(webhookNotifier, notifierMinSeverity(cfg.Detection.Webhook.MinSeverity))
 		logging.Info().
 			Str("url", cfg.Detection.Webhook.WebhookURL).
 			Int("rate_limit_ms", cfg.Detection.Webhook.RateLimitMs).
 			Msg("Webhook notifier registered")
 	}
 
+	// Register Slack notifier if configured
+	if cfg.Detection.Slack.Enabled && cfg.Detection.Slack.WebhookURL != "" {
+		slackNotifier := detection.NewSlackNotifier(detection.SlackConfig{
+			WebhookURL:  cfg.Detection.Slack.WebhookURL,
+			Enabled:     cfg.Detection.Slack.Enabled,
+			RateLimitMs: cfg.Detection.Slack.RateLimitMs,
+		})
+		engine.RegisterNotifierWithMinSeverity(slackNotifier, notifierMinSeverity(cfg.Detection.Slack.MinSeverity))
+		logging.Info().Int("rate_limit_ms", cfg.Detection.Slack.RateLimitMs).Msg("Slack notifier registered")
+	}
+
+	// Register email notifier if configured
+	if cfg.Detection.Email.Enabled {
+		emailNotifier := detection.NewEmailNotifier(detection.EmailConfig{
+			SMTPHost:     cfg.Detection.Email.SMTPHost,
+			SMTPPort:     cfg.Detection.Email.SMTPPort,
+			SMTPUsername: cfg.Detection.Email.SMTPUsername,
+			SMTPPassword: cfg.Detection.Email.SMTPPassword,
+			UseTLS:       cfg.Detection.Email.UseTLS,
+			From:         cfg.Detection.Email.From,
+			To:           cfg.Detection.Email.To,
+			Enabled:      cfg.Detection.Email.Enabled,
+			RateLimitMs:  cfg.Detection.Email.RateLimitMs,
+		})
+		engine.RegisterNotifierWithMinSeverity(emailNotifier, notifierMinSeverity(cfg.Detection.Email.MinSeverity))
+		logging.Info().
+			Str("smtp_host", cfg.Detection.Email.SMTPHost).
+			Int("recipients", len(cfg.Detection.Email.To)).
+			Int("rate_limit_ms", cfg.Detection.Email.RateLimitMs).
+			Msg("Email notifier registered")
+	}
+
 	// Load detector configurations from database
 	rules, err := store.ListRules(ctx)
 	if err != nil {
@@ -718,6 +1011,57 @@ func initDetection(ctx context.Context, db *database.DB, broadcaster detection.A
 
 	// Create API handlers
 	handlers := api.NewDetectionHandlers(store, store, store, engine)
+	handlers.SetAllowlistStore(store)
+	handlers.SetDeviceStore(store)
+
+	return engine, handlers, vpnUpdater
+}
+
+// initPrivacy installs the PRIVACY_IP_MODE anonymizer for every component
+// that stores client IP addresses.
+func initPrivacy(cfg *config.PrivacyConfig) error {
+	mode, err := privacy.ParseIPMode(cfg.IPMode)
+	if err != nil {
+		return err
+	}
+	anonymizer, err := privacy.NewIPAnonymizer(mode, cfg.IPHashKey)
+	if err != nil {
+		return err
+	}
+	privacy.SetIPAnonymizer(anonymizer)
+	if mode != privacy.IPModeFull {
+		logging.Info().Str("mode", string(mode)).Msg("Client IP addresses are anonymized before storage")
+	}
+	return nil
+}
 
-	return engine, handlers
+// bufferCriticalNotifier returns a callback that sends critical buffer
+// health events to the detection notifiers as critical alerts.
+func bufferCriticalNotifier(engine *detection.Engine) sync.BufferCriticalFunc {
+	return func(ctx context.Context, event *models.BufferHealthCriticalEvent) {
+		metadata, err := json.Marshal(event)
+		if err != nil {
+			logging.Warn().Err(err).Msg("Failed to encode buffer health alert metadata")
+		}
+		engine.Notify(ctx, &detection.Alert{
+			RuleType:  detection.RuleTypeBufferHealth,
+			Username:  event.Username,
+			Severity:  detection.SeverityCritical,
+			Title:     "Buffer health critical",
+			Message:   event.Message,
+			Metadata:  metadata,
+			CreatedAt: event.Timestamp,
+		})
+	}
+}
+
+// notifierMinSeverity converts a *_MIN_SEVERITY setting. Values are checked
+// by config validation, so a parse failure falls back to all severities.
+func notifierMinSeverity(value string) detection.Severity {
+	severity, err := detection.ParseSeverity(value)
+	if err != nil {
+		logging.Warn().Err(err).Msg("Ignoring invalid notifier minimum severity")
+		return ""
+	}
+	return severity
 }
@@ -21,8 +21,10 @@ import (
 	"github.com/tomtom215/cartographus/internal/database"
 	"github.com/tomtom215/cartographus/internal/detection"
 	"github.com/tomtom215/cartographus/internal/eventprocessor"
+	tautulliimport "github.com/tomtom215/cartographus/internal/import"
 	"github.com/tomtom215/cartographus/internal/logging"
 	intsync "github.com/tomtom215/cartographus/internal/sync"
+	"github.com/tomtom215/cartographus/internal/validation"
 	ws "github.com/tomtom215/cartographus/internal/websocket"
 )
 
@@ -158,6 +160,9 @@ func InitNATS(cfg *config.Config, syncManager *intsync.Manager, wsHub *ws.Hub, h
 
 	// Step 4: Create Publisher
 	publisherCfg := eventprocessor.DefaultPublisherConfig(natsURL)
+	if cfg.NATS.TopicPartitioning != "" {
+		publisherCfg.Partitioning = eventprocessor.TopicPartitioning(cfg.NATS.TopicPartitioning)
+	}
 	publisher, err := eventprocessor.NewPublisher(publisherCfg, nil)
 	if err != nil {
 		components.Shutdown(context.Background())
@@ -198,6 +203,10 @@ func InitNATS(cfg *config.Config, syncManager *intsync.Manager, wsHub *ws.Hub, h
 	syncManager.SetEventPublisher(eventPublisher)
 	logging.Info().Msg("Event publisher wired to sync manager")
 
+	// Critical buffer health events go straight to the publisher; they are
+	// alerts, not playback events, so they bypass the WAL
+	syncManager.SetBufferAlertPublisher(publisher)
+
 	// Wire handler with same publisher for Plex webhooks
 	if handler != nil {
 		handler.SetEventPublisher(eventPublisher)
@@ -222,10 +231,11 @@ func InitNATS(cfg *config.Config, syncManager *intsync.Manager, wsHub *ws.Hub, h
 	}
 
 	// Create poison queue publisher if enabled
-	// Use the underlying Watermill publisher for poison queue middleware
+	// Use the underlying Watermill publisher for poison queue middleware,
+	// wrapped so poisoned copies are not deduplicated against the originals
 	var poisonPub message.Publisher
 	if cfg.NATS.RouterPoisonQueueEnabled && publisher != nil {
-		poisonPub = publisher.WatermillPublisher()
+		poisonPub = eventprocessor.NewPoisonPublisher(publisher.WatermillPublisher())
 	}
 
 	router, err := eventprocessor.NewRouter(&routerCfg, poisonPub, nil)
@@ -315,6 +325,7 @@ func InitNATS(cfg *config.Config, syncManager *intsync.Manager, wsHub *ws.Hub, h
 			DeduplicationWindow:     cfg.NATS.RouterDeduplicationTTL,
 			MaxDeduplicationEntries: 10000,
 			EnableCrossSourceDedup:  true, // Dedup across Plex/Tautulli/Jellyfin
+			AckAfterFlush:           true, // Ack only once the event is in DuckDB
 		}
 		duckdbHandler, err := eventprocessor.NewDuckDBHandler(duckdbAppender, duckdbHandlerCfg, nil)
 		if err != nil {
@@ -323,7 +334,17 @@ func InitNATS(cfg *config.Config, syncManager *intsync.Manager, wsHub *ws.Hub, h
 		}
 		components.duckdbHandler = duckdbHandler
 
-		// Create subscriber for DuckDB handler
+		// Quarantine events with implausible started_at instead of inserting them
+		timestampWindow, err := validation.NewTimestampWindow(cfg.Sync.TimestampMinDate, cfg.Sync.TimestampMaxFuture)
+		if err != nil {
+			components.Shutdown(context.Background())
+			return nil, fmt.Errorf("create timestamp window: %w", err)
+		}
+		duckdbHandler.SetQuarantine(db, timestampWindow)
+
+		// Create subscriber for DuckDB handler. Handlers hold their ack until
+		// the batch is flushed, so acks are deferred and a full batch can be
+		// pending at once.
 		duckdbSubscriberCfg := eventprocessor.SubscriberConfig{
 			URL:              natsURL,
 			DurableName:      cfg.NATS.DurableName + "-duckdb",
@@ -331,13 +352,15 @@ func InitNATS(cfg *config.Config, syncManager *intsync.Manager, wsHub *ws.Hub, h
 			SubscribersCount: 1,
 			AckWaitTimeout:   60 * time.Second,
 			MaxDeliver:       5,
-			MaxAckPending:    1000,
+			MaxAckPending:    max(1000, appenderCfg.BatchSize),
 			CloseTimeout:     30 * time.Second,
 			MaxReconnects:    -1,
 			ReconnectWait:    2 * time.Second,
 			// Bind to existing stream to avoid AutoProvision trying to create
 			// a stream from the wildcard topic name (playback.>)
-			StreamName: streamCfg.Name,
+			StreamName:       streamCfg.Name,
+			PoisonQueueTopic: routerCfg.PoisonQueueTopic,
+			DeferredAck:      true,
 		}
 		duckdbSubscriber, err := eventprocessor.NewSubscriber(&duckdbSubscriberCfg, nil)
 		if err != nil {
@@ -611,3 +634,13 @@ func (c *NATSComponents) EventPublisher() intsync.EventPublisher {
 	}
 	return c.eventPublisher
 }
+
+// ImportPublisher returns the publisher used by the history importers.
+// Returns nil if NATS is not initialized, so the importers fall back to
+// direct database inserts.
+func (c *NATSComponents) ImportPublisher() tautulliimport.EventPublisher {
+	if c == nil || c.publisher == nil {
+		return nil
+	}
+	return c.publisher
+}
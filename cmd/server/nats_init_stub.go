@@ -14,6 +14,7 @@ import (
 	"github.com/tomtom215/cartographus/internal/config"
 	"github.com/tomtom215/cartographus/internal/database"
 	"github.com/tomtom215/cartographus/internal/detection"
+	tautulliimport "github.com/tomtom215/cartographus/internal/import"
 	"github.com/tomtom215/cartographus/internal/logging"
 	intsync "github.com/tomtom215/cartographus/internal/sync"
 	ws "github.com/tomtom215/cartographus/internal/websocket"
@@ -53,3 +54,9 @@ func (c *NATSComponents) BadgerDB() interface{} {
 func (c *NATSComponents) EventPublisher() intsync.EventPublisher {
 	return nil
 }
+
+// ImportPublisher returns nil for non-NATS builds; the history importers
+// insert events directly into the database instead.
+func (c *NATSComponents) ImportPublisher() tautulliimport.EventPublisher {
+	return nil
+}
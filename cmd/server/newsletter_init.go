@@ -12,7 +12,9 @@ import (
 
 	"github.com/tomtom215/cartographus/internal/config"
 	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/detection"
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
 	"github.com/tomtom215/cartographus/internal/newsletter"
 	"github.com/tomtom215/cartographus/internal/newsletter/delivery"
 	"github.com/tomtom215/cartographus/internal/newsletter/scheduler"
@@ -58,6 +60,15 @@ func initNewsletter(cfg *config.Config, db *database.DB, logger *zerolog.Logger,
 	}
 	contentResolver := newsletter.NewContentResolver(db, logger, contentResolverConfig)
 
+	// Register content blocks that schedules can add via config.blocks
+	blockSources := newsletter.BlockSources{Content: db, Locations: db}
+	if cfg.Detection.Enabled {
+		blockSources.Alerts = detection.NewDuckDBStore(db.Conn())
+	}
+	blockRegistry := newsletter.NewBlockRegistry(logger)
+	newsletter.RegisterBuiltinBlocks(blockRegistry, blockSources)
+	contentResolver.SetBlockRegistry(blockRegistry)
+
 	// Create template engine for rendering newsletters
 	templateEngine := newsletter.NewTemplateEngine()
 
@@ -70,6 +81,11 @@ func initNewsletter(cfg *config.Config, db *database.DB, logger *zerolog.Logger,
 	}
 	deliveryManager := delivery.NewManager(logger, deliveryManagerConfig)
 
+	// Server-wide SMTP settings apply to schedules without their own email config
+	if smtpConfig := cfg.Newsletter.SMTP.ChannelConfig(); smtpConfig != nil {
+		deliveryManager.SetDefaultChannelConfig(models.DeliveryChannelEmail, smtpConfig)
+	}
+
 	// Set up in-app notification store for in-app delivery channel
 	// Note: This requires db to implement InAppNotificationStore
 	// deliveryManager.SetInAppStore(db)
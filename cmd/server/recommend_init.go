@@ -10,17 +10,20 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/database"
 	"github.com/tomtom215/cartographus/internal/recommend"
 	"github.com/tomtom215/cartographus/internal/recommend/algorithms"
 	"github.com/tomtom215/cartographus/internal/recommend/reranking"
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
 	"github.com/tomtom215/cartographus/internal/supervisor"
 	"github.com/tomtom215/cartographus/internal/supervisor/services"
 )
 
 // RecommendComponents holds all recommendation-related components.
 type RecommendComponents struct {
-	Engine  *recommend.Engine
-	Service *services.RecommendService
+	Engine      *recommend.Engine
+	Coordinator *recommend.TrainingCoordinator
+	Service     *services.RecommendTrainingService
 }
 
 // algorithmRegistrar holds dependencies for algorithm registration.
@@ -31,11 +34,13 @@ type algorithmRegistrar struct {
 	logger       zerolog.Logger
 }
 
-// initRecommend initializes the recommendation engine if enabled.
+// initRecommend initializes the recommendation engine if enabled, using db
+// for training data and candidate lookups. Scheduled training waits for
+// syncStatus to report no sync in progress.
 // Returns nil if recommendations are disabled in config.
 //
 //nolint:gocritic // hugeParam: logger passed by value for zerolog chaining
-func initRecommend(cfg *config.Config, logger zerolog.Logger, tree *supervisor.SupervisorTree) *RecommendComponents {
+func initRecommend(cfg *config.Config, db *database.DB, syncStatus recommend.SyncStatus, logger zerolog.Logger, tree *supervisor.SupervisorTree) *RecommendComponents {
 	// Check if recommendations are disabled
 	if !cfg.Recommend.Enabled {
 		logger.Info().Msg("Recommendation engine disabled (RECOMMEND_ENABLED=false)")
@@ -55,6 +60,8 @@ func initRecommend(cfg *config.Config, logger zerolog.Logger, tree *supervisor.S
 		logger.Error().Err(err).Msg("failed to create recommendation engine")
 		return nil
 	}
+	dataProvider := database.NewRecommendationDataProvider(db)
+	engine.SetDataProvider(dataProvider)
 
 	// Register algorithms based on configuration
 	registrar := &algorithmRegistrar{
@@ -68,58 +75,80 @@ func initRecommend(cfg *config.Config, logger zerolog.Logger, tree *supervisor.S
 	// Register rerankers
 	registerRerankers(engine, cfg, logger)
 
-	// Create service for Suture
-	serviceCfg := services.RecommendServiceConfig{
-		TrainOnStartup:  cfg.Recommend.TrainOnStartup,
-		TrainInterval:   cfg.Recommend.TrainInterval,
-		MinInteractions: cfg.Recommend.MinInteractions,
+	// Create training coordinator with resource guardrails
+	coordinator := recommend.NewTrainingCoordinator(engine, recommend.CoordinatorConfig{
+		Interval:           cfg.Recommend.TrainInterval,
+		TrainOnStartup:     cfg.Recommend.TrainOnStartup,
+		MinNewInteractions: cfg.Recommend.TrainMinNewInteractions,
+		MaxPoolUtilization: cfg.Recommend.TrainMaxPoolUtilization,
+		RetainVersions:     cfg.Recommend.ModelRetainVersions,
+	}, logger)
+	if syncStatus != nil {
+		coordinator.SetSyncStatus(syncStatus)
+	}
+	coordinator.SetPoolMonitor(db)
+	coordinator.SetInteractionCounter(dataProvider)
+
+	store, err := storage.NewStore(cfg.Recommend.ModelPath)
+	if err != nil {
+		logger.Warn().Err(err).Str("path", cfg.Recommend.ModelPath).Msg("model persistence disabled")
+	} else {
+		coordinator.SetModelStore(store)
 	}
-	service := services.NewRecommendService(engine, serviceCfg, logger)
 
 	// Add to supervisor tree
+	service := services.NewRecommendTrainingService(coordinator)
 	tree.AddMessagingService(service)
 	logger.Info().
 		Int("algorithms", len(cfg.Recommend.Algorithms)).
-		Msg("recommendation service added to supervisor tree")
+		Int("train_parallelism", cfg.Recommend.TrainParallelism).
+		Msg("recommendation training service added to supervisor tree")
 
 	return &RecommendComponents{
-		Engine:  engine,
-		Service: service,
+		Engine:      engine,
+		Coordinator: coordinator,
+		Service:     service,
 	}
 }
 
 // buildEngineConfig creates the engine configuration from app config.
+// Settings not exposed in app config keep their recommend.DefaultConfig values.
 func buildEngineConfig(cfg *config.Config) *recommend.Config {
-	return &recommend.Config{
-		Seed: 42, // Deterministic for reproducibility
-		Weights: recommend.AlgorithmWeights{
-			CoVisit:    1.0,
-			Content:    0.8,
-			Popularity: 0.5,
-			EASE:       1.2,
-			ALS:        1.0,
-			UserCF:     0.7,
-			ItemCF:     0.7,
-			FPMC:       0.6,
-		},
-		Limits: recommend.LimitsConfig{
-			DefaultK:      10,
-			MaxK:          100,
-			MaxCandidates: cfg.Recommend.MaxCandidates,
-		},
-		Cache: recommend.CacheConfig{
-			Enabled:           true,
-			TTL:               cfg.Recommend.CacheTTL,
-			MaxEntries:        10000,
-			InvalidateOnTrain: true,
-		},
-		Training: recommend.TrainingConfig{
-			MinInteractions: cfg.Recommend.MinInteractions,
-		},
-		Diversity: recommend.DiversityConfig{
-			MMRLambda: cfg.Recommend.DiversityLambda,
-		},
+	engineCfg := recommend.DefaultConfig()
+	engineCfg.Seed = 42 // Deterministic for reproducibility
+	engineCfg.Weights = recommend.AlgorithmWeights{
+		CoVisit:    1.0,
+		Content:    0.8,
+		Popularity: popularityWeight(cfg.Recommend.Algorithms),
+		EASE:       1.2,
+		ALS:        1.0,
+		UserCF:     0.7,
+		ItemCF:     0.7,
+		FPMC:       0.6,
 	}
+	engineCfg.Limits.DefaultK = 10
+	engineCfg.Limits.MaxK = 100
+	engineCfg.Limits.MaxCandidates = cfg.Recommend.MaxCandidates
+	engineCfg.Cache = recommend.CacheConfig{
+		Enabled:           true,
+		TTL:               cfg.Recommend.CacheTTL,
+		MaxEntries:        10000,
+		InvalidateOnTrain: true,
+	}
+	engineCfg.Training.MinInteractions = cfg.Recommend.MinInteractions
+	engineCfg.Training.Parallelism = cfg.Recommend.TrainParallelism
+	engineCfg.Diversity.MMRLambda = cfg.Recommend.DiversityLambda
+	return engineCfg
+}
+
+// popularityWeight returns the blend weight for the popularity algorithm.
+// Popularity is always registered as the cold-start fallback, but only
+// contributes to personalized results when listed in RECOMMEND_ALGORITHMS.
+func popularityWeight(algs []string) float64 {
+	if buildAlgorithmSet(algs)["popularity"] {
+		return 0.5
+	}
+	return 0
 }
 
 // buildAlgorithmSet converts algorithm slice to set for O(1) lookup.
@@ -146,6 +175,7 @@ func (r *algorithmRegistrar) registerLightweightAlgorithms() {
 	if r.algorithmSet["covisit"] {
 		r.engine.RegisterAlgorithm(algorithms.NewCoVisitation(algorithms.CoVisitConfig{
 			MinCoOccurrence:    2,
+			SessionGapMinutes:  360,
 			SessionWindowHours: 24,
 			MaxPairs:           100000,
 		}))
@@ -163,14 +193,14 @@ func (r *algorithmRegistrar) registerLightweightAlgorithms() {
 		r.logger.Debug().Msg("registered content-based algorithm")
 	}
 
-	if r.algorithmSet["popularity"] {
-		r.engine.RegisterAlgorithm(algorithms.NewPopularity(algorithms.PopularityConfig{
-			UseTimeDecay:  true,
-			DecayHalfLife: 30,
-			MaxItems:      10000,
-		}))
-		r.logger.Debug().Msg("registered popularity algorithm")
-	}
+	// Popularity is always registered: ModePopular serves it to cold-start
+	// users. Its blend weight is zero unless it is explicitly enabled.
+	r.engine.RegisterAlgorithm(algorithms.NewPopularity(algorithms.PopularityConfig{
+		UseTimeDecay:  true,
+		DecayHalfLife: 30,
+		MaxItems:      10000,
+	}))
+	r.logger.Debug().Bool("blended", r.algorithmSet["popularity"]).Msg("registered popularity algorithm")
 }
 
 // registerMatrixFactorization registers Phase 2 algorithms.
@@ -0,0 +1,44 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+func TestBuildEngineConfig(t *testing.T) {
+	cfg := &config.Config{
+		Recommend: config.RecommendConfig{
+			Algorithms:      []string{"covisit", "content"},
+			CacheTTL:        2 * time.Minute,
+			MaxCandidates:   500,
+			MinInteractions: 50,
+			DiversityLambda: 0.7,
+		},
+	}
+
+	engineCfg := buildEngineConfig(cfg)
+	if _, err := recommend.NewEngine(engineCfg, zerolog.Nop()); err != nil {
+		t.Fatalf("NewEngine(buildEngineConfig()) error = %v", err)
+	}
+	if engineCfg.Cache.TTL != 2*time.Minute || engineCfg.Limits.MaxCandidates != 500 {
+		t.Errorf("cache TTL = %v, max candidates = %d; want app config values", engineCfg.Cache.TTL, engineCfg.Limits.MaxCandidates)
+	}
+
+	// Popularity is the cold-start fallback only, unless explicitly enabled
+	if engineCfg.Weights.Popularity != 0 {
+		t.Errorf("popularity weight = %v, want 0 when not listed", engineCfg.Weights.Popularity)
+	}
+	cfg.Recommend.Algorithms = append(cfg.Recommend.Algorithms, "popularity")
+	if w := buildEngineConfig(cfg).Weights.Popularity; w <= 0 {
+		t.Errorf("popularity weight = %v, want > 0 when listed", w)
+	}
+}
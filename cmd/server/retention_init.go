@@ -0,0 +1,88 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tomtom215/cartographus/internal/api"
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/supervisor"
+)
+
+// initRetention creates the retention pruner. Its dry run is always served
+// at /api/v1/admin/db/retention/dry-run so limits can be previewed before
+// enabling them; the pruning job is only supervised with RETENTION_ENABLED.
+//
+// After every run the affected caches are invalidated (the pruner itself
+// bumps the tile cache data version) and the run is audited with its row
+// counts, when audit logging is available.
+func initRetention(cfg *config.Config, db *database.DB, tree *supervisor.SupervisorTree, handler *api.Handler,
+	tileWarmer *database.TileWarmer, auditLogger *audit.Logger) {
+	pruner := database.NewPruner(db, cfg.Retention)
+	handler.SetRetentionPlanner(pruner)
+
+	if !cfg.Retention.Enabled {
+		logging.Info().Msg("Retention pruning disabled (RETENTION_ENABLED=false)")
+		return
+	}
+
+	pruner.SetOnPruned(func(result *database.RetentionResult, err error) {
+		if result.DeletedRows() > 0 {
+			handler.OnDataPruned()
+			if tileWarmer != nil {
+				tileWarmer.Trigger()
+			}
+		}
+		if auditLogger != nil {
+			auditRetentionRun(auditLogger, result, err)
+		}
+	})
+
+	tree.AddDataService(pruner)
+	logging.Info().
+		Int("window_start_hour", cfg.Retention.WindowStartHour).
+		Int("window_end_hour", cfg.Retention.WindowEndHour).
+		Str("archive_dir", cfg.Retention.ArchiveDir).
+		Msg("Retention pruner added to supervisor tree")
+}
+
+// auditRetentionRun records a prune run, with the rows deleted per table,
+// as an admin action of the system.
+func auditRetentionRun(auditLogger *audit.Logger, result *database.RetentionResult, err error) {
+	deleted := map[string]int64{"geolocations": result.OrphanedGeolocations}
+	for _, t := range result.Tables {
+		deleted[t.Table] = t.DeletedRows
+	}
+	metadata := map[string]interface{}{
+		"deleted_rows": deleted,
+		"complete":     result.Complete,
+		"duration_ms":  result.FinishedAt.Sub(result.StartedAt).Milliseconds(),
+	}
+
+	description := fmt.Sprintf("Retention pruning deleted %d rows", result.DeletedRows())
+	if err != nil {
+		metadata["error"] = err.Error()
+		metadataJSON, _ := json.Marshal(metadata) //nolint:errcheck // plain map of strings and numbers
+		auditLogger.Log(&audit.Event{
+			Type:        audit.EventTypeAdminAction,
+			Severity:    audit.SeverityError,
+			Outcome:     audit.OutcomeFailure,
+			Actor:       audit.SystemActor(),
+			Action:      "retention.prune",
+			Description: description + " before failing",
+			Metadata:    metadataJSON,
+		})
+		return
+	}
+	auditLogger.LogAdminAction(context.Background(), audit.SystemActor(), audit.Source{},
+		"retention.prune", description, metadata)
+}
@@ -0,0 +1,92 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/api"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/sync"
+)
+
+// errSetupInterrupted is returned by loadConfig when the process is asked to
+// stop while waiting in setup mode.
+var errSetupInterrupted = errors.New("setup interrupted")
+
+// loadConfig loads and validates the configuration. On a fresh install, with
+// no media server and no auth mode configured, it first serves the setup
+// wizard until the user completes it and the settings are written to the
+// config file, then loads the configuration again so startup continues
+// normally without a restart.
+func loadConfig() (*config.Config, error) {
+	cfg, path, err := config.LoadUnvalidated()
+	if err != nil {
+		return nil, err
+	}
+	required, err := config.SetupRequired(cfg, path)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		logging.Init(logging.Config{Level: cfg.Logging.Level, Format: cfg.Logging.Format})
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		if err := runSetupMode(ctx, cfg, config.SetupConfigPath(path)); err != nil {
+			return nil, err
+		}
+	}
+	return config.Load()
+}
+
+// runSetupMode serves only the setup wizard API on the configured address
+// until setup completes or ctx is canceled.
+func runSetupMode(ctx context.Context, cfg *config.Config, configPath string) error {
+	wizard := api.NewSetupWizard(configPath, cfg.Server.Environment, sync.IdentifyServer)
+	wizard.SetPlexTV(sync.PlexTVClientConfig{ClientID: cfg.Plex.OAuthClientID})
+	server := &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
+		Handler:           wizard.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+	logging.Warn().
+		Str("addr", server.Addr).
+		Str("config_path", configPath).
+		Msg("No media server or auth mode configured; starting in setup mode. Complete setup at /api/v1/setup")
+
+	var result error
+	select {
+	case <-wizard.Done():
+	case <-ctx.Done():
+		result = errSetupInterrupted
+	case err := <-serveErr:
+		return fmt.Errorf("setup server failed: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logging.Warn().Err(err).Msg("Error shutting down setup server")
+	}
+	if result == nil {
+		logging.Info().Msg("Setup complete; starting Cartographus")
+	}
+	return result
+}
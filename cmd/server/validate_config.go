@@ -0,0 +1,105 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/sync"
+)
+
+// validateConfigProbeTimeout bounds each media server connectivity probe
+// run by --validate-config.
+const validateConfigProbeTimeout = 5 * time.Second
+
+// minScrubbedSecretLen is the shortest secret masked in the report; shorter
+// values would be masked out of ordinary words.
+const minScrubbedSecretLen = 8
+
+// runValidateConfig implements --validate-config: it loads the
+// configuration, runs every validation check, probes each enabled media
+// server, and writes a report to w. It returns the process exit code, 1 if
+// anything failed.
+func runValidateConfig(ctx context.Context, w io.Writer, timeout time.Duration) int {
+	cfg, path, err := config.LoadUnvalidated()
+	if err != nil {
+		fmt.Fprintf(w, "Configuration could not be loaded: %v\n", err)
+		return 1
+	}
+
+	errs := cfg.ValidateAll()
+	probes := sync.ProbeConfiguredSources(ctx, cfg, timeout)
+	if !writeConfigReport(w, cfg, path, errs, probes) {
+		return 1
+	}
+	return 0
+}
+
+// writeConfigReport writes the --validate-config report and returns whether
+// the configuration passed. Configured secrets are masked wherever they
+// appear, since probe errors can quote request URLs.
+func writeConfigReport(w io.Writer, cfg *config.Config, path string, errs []error, probes []models.SourceHealth) bool {
+	var b strings.Builder
+
+	if path == "" {
+		path = "none (defaults and environment only)"
+	}
+	fmt.Fprintf(&b, "Config file: %s\n\n", path)
+
+	fmt.Fprintln(&b, "Validation:")
+	if len(errs) == 0 {
+		fmt.Fprintln(&b, "  OK")
+	}
+	for _, err := range errs {
+		fmt.Fprintf(&b, "  ERROR  %v\n", err)
+	}
+
+	fmt.Fprintln(&b, "\nMedia servers:")
+	if len(probes) == 0 {
+		fmt.Fprintln(&b, "  none enabled")
+	}
+	unreachable := 0
+	for _, probe := range probes {
+		name := probe.Platform
+		if probe.ServerID != "" {
+			name += " (" + probe.ServerID + ")"
+		}
+		if probe.Reachable {
+			fmt.Fprintf(&b, "  OK     %s reachable in %dms\n", name, probe.LatencyMs)
+			continue
+		}
+		unreachable++
+		fmt.Fprintf(&b, "  ERROR  %s unreachable: %s\n", name, probe.Error)
+	}
+
+	problems := len(errs) + unreachable
+	if problems == 0 {
+		fmt.Fprintln(&b, "\nConfiguration is valid.")
+	} else {
+		fmt.Fprintf(&b, "\nConfiguration has %d problem(s).\n", problems)
+	}
+
+	_, _ = io.WriteString(w, scrubSecrets(cfg, b.String()))
+	return problems == 0
+}
+
+// scrubSecrets masks every secret configured in cfg wherever it appears in
+// text.
+func scrubSecrets(cfg *config.Config, text string) string {
+	for _, secret := range cfg.SecretValues() {
+		if len(secret) < minScrubbedSecretLen {
+			continue
+		}
+		text = strings.ReplaceAll(text, secret, config.RedactSecret(secret))
+	}
+	return text
+}
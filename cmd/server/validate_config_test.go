@@ -0,0 +1,90 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestWriteConfigReport_MasksSecrets(t *testing.T) {
+	const apiKey = "tautulli-api-key-0123456789"
+	const jwtSecret = "jwt-secret-that-is-long-enough-abcd"
+	cfg := &config.Config{
+		Tautulli: config.TautulliConfig{Enabled: true, APIKey: apiKey},
+		Security: config.SecurityConfig{JWTSecret: jwtSecret},
+	}
+	errs := []error{errors.New("JWT_SECRET " + jwtSecret + " is weak")}
+	probes := []models.SourceHealth{{
+		Platform: "tautulli",
+		ServerID: "tautulli-1",
+		Error:    `Get "http://tautulli:8181/api/v2?apikey=` + apiKey + `&cmd=arnold": connection refused`,
+	}}
+
+	var out strings.Builder
+	if writeConfigReport(&out, cfg, "/config/config.yaml", errs, probes) {
+		t.Error("report passed with a validation error and an unreachable server")
+	}
+	report := out.String()
+
+	for _, secret := range cfg.SecretValues() {
+		if strings.Contains(report, secret) {
+			t.Errorf("report leaks %q:\n%s", secret, report)
+		}
+	}
+	for _, want := range []string{"/config/config.yaml", "apikey=****6789", "tautulli (tautulli-1) unreachable", "2 problem(s)"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestRunValidateConfig(t *testing.T) {
+	plex := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plex.Close()
+
+	t.Setenv("CONFIG_PATH", t.TempDir()+"/missing.yaml")
+	t.Setenv("AUTH_MODE", "none")
+	t.Setenv("ENABLE_PLEX_SYNC", "true")
+	t.Setenv("PLEX_URL", plex.URL)
+	t.Setenv("PLEX_TOKEN", "plex-token-0123456789")
+	t.Setenv("PLEX_SERVER_ID", "plex-1")
+
+	var out strings.Builder
+	if code := runValidateConfig(context.Background(), &out, time.Second); code != 0 {
+		t.Fatalf("exit code = %d, want 0:\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "plex (plex-1) reachable") {
+		t.Errorf("report does not show the Plex probe:\n%s", out.String())
+	}
+
+	// A validation failure and an unreachable server both fail the check
+	t.Setenv("AUTH_MODE", "jwt")
+	t.Setenv("JWT_SECRET", "")
+	plex.Close()
+
+	out.Reset()
+	if code := runValidateConfig(context.Background(), &out, time.Second); code != 1 {
+		t.Fatalf("exit code = %d, want 1:\n%s", code, out.String())
+	}
+	report := out.String()
+	if !strings.Contains(report, "JWT_SECRET is required") || !strings.Contains(report, "plex (plex-1) unreachable") {
+		t.Errorf("report does not list both problems:\n%s", report)
+	}
+	if strings.Contains(report, "plex-token-0123456789") {
+		t.Errorf("report leaks the Plex token:\n%s", report)
+	}
+}
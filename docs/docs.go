@@ -1,8 +1,3 @@
-// Cartographus - Media Server Analytics and Geographic Visualization
-// Copyright 2026 Tom F. (tomtom215)
-// SPDX-License-Identifier: AGPL-3.0-or-later
-// https://github.com/tomtom215/cartographus
-
 // Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
@@ -27,41 +22,80 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticates user with username and password, returns JWT token in HTTP-only cookie",
-                "consumes": [
-                    "application/json"
+        "/admin/config": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns the merged configuration (defaults, file, environment) with tokens, API keys, passwords and other secrets masked to their last 4 characters.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Authenticate user",
-                "parameters": [
-                    {
-                        "description": "Login credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
+                "summary": "Get the effective configuration",
+                "responses": {
+                    "200": {
+                        "description": "Effective configuration",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "password": {
-                                    "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
                                 },
-                                "username": {
-                                    "type": "string"
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        }
+                                    }
                                 }
-                            }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Configuration not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
                         }
                     }
+                }
+            }
+        },
+        "/admin/config/reload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Re-reads the config file and environment and applies runtime-changeable settings. Changes to other settings, such as the database path, ports or auth mode, are reported as requiring a restart.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reload the configuration",
                 "responses": {
                     "200": {
-                        "description": "Authentication successful",
+                        "description": "Reload result",
                         "schema": {
                             "allOf": [
                                 {
@@ -71,15 +105,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "expires_at": {
-                                                    "type": "string"
-                                                },
-                                                "token": {
-                                                    "type": "string"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/config.ReloadResult"
                                         }
                                     }
                                 }
@@ -87,25 +113,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "New configuration is invalid; nothing was applied",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "403": {
-                        "description": "Authentication disabled",
+                        "description": "Forbidden - admin only",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "Config reload not available",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -113,28 +139,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/export/locations/geojson": {
+        "/admin/db/slow-queries": {
             "get": {
-                "description": "Exports location statistics in GeoJSON format for use in GIS applications and mapping tools",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns recent DuckDB statements that exceeded DB_SLOW_QUERY_THRESHOLD, newest first.",
                 "produces": [
-                    "application/geo+json"
+                    "application/json"
                 ],
                 "tags": [
-                    "Export"
+                    "Admin"
                 ],
-                "summary": "Export locations as GeoJSON",
+                "summary": "List slow database queries",
                 "responses": {
                     "200": {
-                        "description": "GeoJSON file download",
+                        "description": "Slow queries",
                         "schema": {
-                            "type": "file"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.SlowQueriesResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database not available",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -142,142 +194,196 @@ const docTemplate = `{
                 }
             }
         },
-        "/export/playbacks/csv": {
-            "get": {
-                "description": "Exports complete playback history with all metadata to CSV format for external analysis",
-                "consumes": [
+        "/admin/import/cancel": {
+            "post": {
+                "description": "Cancels a running or paused Tautulli database import at the next batch boundary.\nThe progress checkpoint is retained so a later import with resume=true continues where this one stopped.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Cancel import",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No import in progress",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/import/jellystat": {
+            "post": {
+                "description": "Imports Jellyfin playback history from a Jellystat CSV export, a Jellystat pg_dump,\nor a Playback Reporting plugin export. Accepts either a JSON body with file_path\nor a multipart upload with a \"file\" part. The file format is validated before the import starts.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
                 "produces": [
-                    "text/csv"
+                    "application/json"
                 ],
                 "tags": [
-                    "Export"
+                    "import"
                 ],
-                "summary": "Export playback history as CSV",
+                "summary": "Start Jellystat import",
                 "parameters": [
                     {
-                        "maximum": 100000,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10000,
-                        "description": "Maximum number of records to export (1-100000)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Import options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.JellystatImportRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "CSV file download",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/api.ImportResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Invalid request or unrecognized export format",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Import already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal error",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "$ref": "#/definitions/api.ImportResponse"
                         }
                     }
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Returns comprehensive health status including database connectivity, Tautulli connectivity, last sync time, and uptime",
-                "consumes": [
+        "/admin/import/pause": {
+            "post": {
+                "description": "Pauses a running Tautulli database import at the next batch boundary",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Pause import",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No import in progress or already paused",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/import/resume": {
+            "post": {
+                "description": "Resumes a paused Tautulli database import",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "import"
                 ],
-                "summary": "Get system health status",
+                "summary": "Resume import",
                 "responses": {
                     "200": {
-                        "description": "Health status retrieved successfully",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.HealthStatus"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No import in progress or not paused",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
                         }
                     }
                 }
             }
         },
-        "/health/live": {
+        "/admin/import/status": {
             "get": {
-                "description": "Returns 200 OK if the process is alive, regardless of external dependencies. Used for Kubernetes liveness probes.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the current status of a Tautulli database import, including\nrows read, imported, deduplicated, errored, throughput, and ETA",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "import"
                 ],
-                "summary": "Kubernetes liveness probe",
+                "summary": "Get import status",
                 "responses": {
                     "200": {
-                        "description": "Service is alive",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "$ref": "#/definitions/api.ImportResponse"
                         }
                     }
                 }
             }
         },
-        "/health/ready": {
+        "/admin/import/verify": {
             "get": {
-                "description": "Returns 200 OK only if the service is ready to handle traffic (database and Tautulli are both connected). Returns 503 if not ready.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Compares the saved import progress against the Tautulli database: the gap between\nthe highest session_history ID and the last processed ID, and processed vs source rows.\ncomplete is true when no source records remain and the last run had no errors.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "import"
                 ],
-                "summary": "Kubernetes readiness probe",
+                "summary": "Verify import completeness",
                 "responses": {
                     "200": {
-                        "description": "Service is ready",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "$ref": "#/definitions/api.ImportResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service is not ready",
+                    "409": {
+                        "description": "Import in progress",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Verification failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
                         }
                     }
                 }
             }
         },
-        "/locations": {
-            "get": {
-                "description": "Returns aggregated playback statistics by geographic location with support for 14+ filter dimensions",
+        "/admin/log-level": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the global log level at runtime. Not persisted across restarts.",
                 "consumes": [
                     "application/json"
                 ],
@@ -285,129 +391,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "Admin"
                 ],
-                "summary": "Get location statistics",
+                "summary": "Change the log level",
                 "parameters": [
                     {
-                        "maximum": 1000,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 100,
-                        "description": "Maximum number of locations to return (1-1000)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"2025-01-01T00:00:00Z\"",
-                        "description": "Start date filter (RFC3339 format)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"2025-12-31T23:59:59Z\"",
-                        "description": "End date filter (RFC3339 format)",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 3650,
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Filter by last N days (1-3650, alternative to start_date)",
-                        "name": "days",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"user1,user2\"",
-                        "description": "Comma-separated list of usernames",
-                        "name": "users",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"movie,episode\"",
-                        "description": "Comma-separated list of media types",
-                        "name": "media_types",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Android,iOS\"",
-                        "description": "Comma-separated list of platforms",
-                        "name": "platforms",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Plex for Android,Plex Web\"",
-                        "description": "Comma-separated list of players",
-                        "name": "players",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"transcode,direct play\"",
-                        "description": "Comma-separated transcode decisions",
-                        "name": "transcode_decisions",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"1080,720\"",
-                        "description": "Comma-separated video resolutions",
-                        "name": "video_resolutions",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"h264,hevc\"",
-                        "description": "Comma-separated video codecs",
-                        "name": "video_codecs",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"aac,ac3\"",
-                        "description": "Comma-separated audio codecs",
-                        "name": "audio_codecs",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Movies,TV Shows\"",
-                        "description": "Comma-separated library names",
-                        "name": "libraries",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"PG,PG-13,R\"",
-                        "description": "Comma-separated content ratings",
-                        "name": "content_ratings",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"2023,2024\"",
-                        "description": "Comma-separated release years",
-                        "name": "years",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"lan,wan\"",
-                        "description": "Comma-separated location types",
-                        "name": "location_types",
-                        "in": "query"
+                        "description": "New log level",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.LogLevelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Location statistics retrieved successfully",
+                        "description": "Level changed",
                         "schema": {
                             "allOf": [
                                 {
@@ -417,10 +417,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.LocationStats"
-                                            }
+                                            "$ref": "#/definitions/api.LogLevelResponse"
                                         }
                                     }
                                 }
@@ -428,13 +425,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Invalid log level",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -442,9 +445,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/media-types": {
-            "get": {
-                "description": "Returns a list of all unique media types (movie, episode, track, etc.) found in playback history",
+        "/admin/notifications/test-email": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a test message through SMTP and reports the result.",
                 "consumes": [
                     "application/json"
                 ],
@@ -452,12 +460,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "Admin"
+                ],
+                "summary": "Send a test email",
+                "parameters": [
+                    {
+                        "description": "Recipient and optional SMTP settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TestEmailRequest"
+                        }
+                    }
                 ],
-                "summary": "Get list of unique media types",
                 "responses": {
                     "200": {
-                        "description": "List of media types retrieved successfully",
+                        "description": "Test result",
                         "schema": {
                             "allOf": [
                                 {
@@ -467,18 +486,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "type": "string"
-                                            }
+                                            "$ref": "#/definitions/models.TestEmailResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Invalid request or SMTP settings",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -486,9 +514,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/playbacks": {
+        "/admin/replay/checkpoints": {
             "get": {
-                "description": "Returns paginated playback event history with detailed metadata including user info, media details, quality metrics, and geolocation",
+                "description": "Returns all replay checkpoints with optional status filter",
                 "consumes": [
                     "application/json"
                 ],
@@ -496,59 +524,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "Admin",
+                    "Replay"
                 ],
-                "summary": "Get playback history",
+                "summary": "List replay checkpoints",
                 "parameters": [
                     {
-                        "maximum": 1000,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 100,
-                        "description": "Number of results per page (1-1000)",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Filter by status (running, completed, error, canceled)",
+                        "name": "status",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.CheckpointResponse"
+                            }
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/replay/checkpoints/cleanup": {
+            "post": {
+                "description": "Removes completed/error/canceled checkpoints older than specified duration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin",
+                    "Replay"
+                ],
+                "summary": "Cleanup old checkpoints",
+                "parameters": [
                     {
-                        "maximum": 1000000,
-                        "minimum": 0,
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Number of results to skip (0-1000000)",
-                        "name": "offset",
+                        "type": "string",
+                        "default": "168h",
+                        "description": "Duration string (e.g., '24h', '7d')",
+                        "name": "older_than",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Playback events retrieved successfully",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.PlaybackEvent"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer",
+                                "format": "int64"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -556,9 +604,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/stats": {
+        "/admin/replay/checkpoints/last": {
             "get": {
-                "description": "Returns overall playback statistics including total playbacks, unique users, unique locations, date range, and last sync time",
+                "description": "Returns the most recent checkpoint for a specific stream",
                 "consumes": [
                     "application/json"
                 ],
@@ -566,30 +614,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "Admin",
+                    "Replay"
+                ],
+                "summary": "Get last checkpoint for stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stream name",
+                        "name": "stream",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get overall statistics",
                 "responses": {
                     "200": {
-                        "description": "Statistics retrieved successfully",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.Stats"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.CheckpointResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -597,14 +649,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/sync": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Manually triggers a sync with Tautulli to fetch latest playback data. Requires JWT authentication.",
+        "/admin/replay/checkpoints/{id}": {
+            "get": {
+                "description": "Returns a specific replay checkpoint",
                 "consumes": [
                     "application/json"
                 ],
@@ -612,40 +659,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Admin",
+                    "Replay"
+                ],
+                "summary": "Get replay checkpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Checkpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Trigger data synchronization",
                 "responses": {
-                    "202": {
-                        "description": "Sync triggered successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "$ref": "#/definitions/api.CheckpointResponse"
                         }
                     },
-                    "409": {
-                        "description": "Sync already in progress",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users": {
-            "get": {
-                "description": "Returns a list of all unique usernames that have playback history",
+            },
+            "delete": {
+                "description": "Removes a specific replay checkpoint",
                 "consumes": [
                     "application/json"
                 ],
@@ -653,12 +702,274 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Core"
+                    "Admin",
+                    "Replay"
                 ],
-                "summary": "Get list of unique users",
-                "responses": {
-                    "200": {
-                        "description": "List of usernames retrieved successfully",
+                "summary": "Delete replay checkpoint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Checkpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/replay/start": {
+            "post": {
+                "description": "Initiates an event replay from the specified starting point",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin",
+                    "Replay"
+                ],
+                "summary": "Start event replay",
+                "parameters": [
+                    {
+                        "description": "Replay configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ReplayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReplayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/seed": {
+            "post": {
+                "description": "Seeds the database with realistic mock data for screenshots and demos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Seed database with mock data",
+                "responses": {
+                    "200": {
+                        "description": "Seeding successful",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden in production environment",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Seeding failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/servers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns status information for all media servers configured via environment variables.\nIncludes connection status, last sync time, and configuration details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get status of all configured media servers",
+                "responses": {
+                    "200": {
+                        "description": "Server status retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MediaServerListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new media server configuration. Credentials are encrypted at rest.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create a new media server",
+                "parameters": [
+                    {
+                        "description": "Server configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateMediaServerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Server created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MediaServerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Server ID conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/servers/db": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists media servers stored in the database (added via UI).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List database servers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by platform (plex, jellyfin, emby, tautulli)",
+                        "name": "platform",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by enabled status",
+                        "name": "enabled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Server list",
                         "schema": {
                             "allOf": [
                                 {
@@ -670,7 +981,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "type": "string"
+                                                "$ref": "#/definitions/models.MediaServerResponse"
                                             }
                                         }
                                     }
@@ -678,8 +989,14 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -687,348 +1004,23866 @@ const docTemplate = `{
                 }
             }
         },
-        "/ws": {
-            "get": {
-                "description": "Establishes a WebSocket connection for real-time playback notifications and statistics updates",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Realtime"
-                ],
-                "summary": "Establish WebSocket connection",
-                "responses": {
-                    "101": {
-                        "description": "Switching Protocols",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
+        "/admin/servers/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tests connectivity to a media server using provided credentials.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Test server connectivity",
+                "parameters": [
+                    {
+                        "description": "Connection details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MediaServerTestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Connection test result",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MediaServerTestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/servers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a media server configuration by ID. Credentials are masked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get a media server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Server details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MediaServerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Server not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a media server configuration. Only UI-added servers can be modified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update a media server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMediaServerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Server updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MediaServerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - cannot modify env-var servers",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Server not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a media server configuration. Only UI-added servers can be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a media server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Server deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - cannot delete env-var servers",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Server not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sessions/stitch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recomputes the logical viewing session of every playback with the current SYNC_SESSION_STITCH_GAP.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restitch logical viewing sessions",
+                "responses": {
+                    "200": {
+                        "description": "Playbacks whose logical session changed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.SessionStitchResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session stitching is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Stitching failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/watch-parties/{id}/false-positive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Leaves the party out of watch party analytics and feeds the confidence threshold.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Mark a watch party as a false positive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watch party ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watch party marked",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid watch party ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Watch party not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports the party in watch party analytics again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear the false positive mark of a watch party",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watch party ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mark cleared",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid watch party ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin only",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Watch party not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/abandonment": {
+            "get": {
+                "description": "Returns completion and drop-off rates by media type, genre and content",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get content abandonment",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ContentAbandonmentAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/approximate": {
+            "get": {
+                "description": "Returns approximate distinct counts (using HyperLogLog) and percentiles (using KLL sketches).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get approximate analytics statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date filter (RFC3339 format)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date filter (RFC3339 format)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of usernames to filter",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of media types (movie, episode, track)",
+                        "name": "media_types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Approximate statistics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.ApproximateStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/approximate/distinct": {
+            "get": {
+                "description": "Returns approximate distinct count using HyperLogLog with ~2% error bound.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get approximate distinct count for a column",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Column name (username, title, ip_address, city, country, platform, player, rating_key, media_type)",
+                        "name": "column",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date filter (RFC3339 format)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date filter (RFC3339 format)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of usernames to filter",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of media types",
+                        "name": "media_types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Approximate distinct count",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.ApproximateDistinctResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/approximate/percentile": {
+            "get": {
+                "description": "Returns approximate percentile using KLL sketches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get approximate percentile for a column",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Column name (duration, percent_complete, paused_counter)",
+                        "name": "column",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentile value between 0 and 1 (e.g., 0.50 for median, 0.95 for p95)",
+                        "name": "percentile",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date filter (RFC3339 format)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date filter (RFC3339 format)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of usernames to filter",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of media types",
+                        "name": "media_types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Approximate percentile",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.ApproximatePercentileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/audio": {
+            "get": {
+                "description": "Returns audio codec and channel distributions, downmix events and surround and lossless adoption",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get audio analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AudioAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/bandwidth": {
+            "get": {
+                "description": "Returns bandwidth usage by transcode decision, resolution, codec and user, with daily trends",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get bandwidth analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BandwidthAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/binge": {
+            "get": {
+                "description": "Returns binge sessions (3+ episodes of a show within 6 hours) and per-user and per-show totals",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get binge-watching analytics",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count resumed playbacks as one logical viewing session",
+                        "name": "stitched",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BingeAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/bitrate": {
+            "get": {
+                "description": "Returns source and transcoded bitrate distributions and bandwidth-constrained sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get bitrate analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BitrateAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/bump-chart": {
+            "get": {
+                "description": "Returns how content rankings change over time for bump charts (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get content ranking changes",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BumpChartAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/calendar-heatmap": {
+            "get": {
+                "description": "Returns daily playback activity for calendar heatmaps",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get calendar heatmap",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CalendarHeatmapAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/cohort-retention": {
+            "get": {
+                "description": "Returns retention for users grouped by the week or month of their first playback",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get cohort retention",
+                "parameters": [
+                    {
+                        "maximum": 52,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 12,
+                        "description": "Number of weeks tracked per cohort",
+                        "name": "max_weeks",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Smallest cohort included in the report",
+                        "name": "min_cohort_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "week",
+                        "description": "Cohort bucket",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CohortRetentionAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/comparative": {
+            "get": {
+                "description": "Compares playback metrics between the current and the previous period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get period comparison",
+                "parameters": [
+                    {
+                        "enum": [
+                            "week",
+                            "month",
+                            "quarter",
+                            "year",
+                            "custom"
+                        ],
+                        "type": "string",
+                        "default": "week",
+                        "description": "Comparison period",
+                        "name": "comparison_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ComparativeAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/concurrent-streams": {
+            "get": {
+                "description": "Returns peak and average concurrent streams over time with transcode breakdowns",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get concurrent streams analytics",
+                "parameters": [
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Time bucket",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ConcurrentStreamsAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Query timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/connection-security": {
+            "get": {
+                "description": "Returns secure, insecure and relayed connection counts by user and platform",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get connection security analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ConnectionSecurityAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/container": {
+            "get": {
+                "description": "Returns media container distribution and remux activity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get container analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ContainerAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/content-discovery": {
+            "get": {
+                "description": "Returns time from library addition to first watch and discovery rates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get content discovery analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ContentDiscoveryAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/content-flow": {
+            "get": {
+                "description": "Returns show to season to episode viewing journeys for Sankey charts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get content flow",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ContentFlowAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/data-quality": {
+            "get": {
+                "description": "Returns per-field completeness, daily trends, issues and a per-source breakdown",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get data quality report",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DataQualityReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/device-migration": {
+            "get": {
+                "description": "Returns how users move between platforms and devices over time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get device migration analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DeviceMigrationAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/frame-rate": {
+            "get": {
+                "description": "Returns source frame rate distribution and frame rate conversions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get frame rate analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FrameRateAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/geographic": {
+            "get": {
+                "description": "Returns top cities and countries with media type, platform, player, quality and library distributions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get geographic analytics",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count resumed playbacks as one logical viewing session",
+                        "name": "stitched",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GeographicResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/hardware-transcode": {
+            "get": {
+                "description": "Returns hardware acceleration usage by decoder, encoder and full pipeline",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get hardware transcode analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/database.HardwareTranscodeStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/hardware-transcode/trends": {
+            "get": {
+                "description": "Returns daily hardware transcode counts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get hardware transcode trends",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/database.HWTranscodeTrend"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/hdr": {
+            "get": {
+                "description": "Returns HDR format distribution and tone-mapping activity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get HDR analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HDRAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/hdr-content": {
+            "get": {
+                "description": "Returns HDR playback counts by format, color space and color primaries",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get HDR content analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/database.HDRContentStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/library": {
+            "get": {
+                "description": "Returns playback statistics for a single library section",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get library analytics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Library section ID",
+                        "name": "section_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LibraryAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/library-utilization": {
+            "get": {
+                "description": "Returns hierarchical library usage for treemap charts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get library utilization",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LibraryUtilizationAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/pause-patterns": {
+            "get": {
+                "description": "Returns pause frequency and duration and the content paused most often",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get pause pattern analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PausePatternAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/popular": {
+            "get": {
+                "description": "Returns the most watched movies, shows and episodes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get popular content",
+                "parameters": [
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of items per list",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count resumed playbacks as one logical viewing session",
+                        "name": "stitched",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PopularAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/qoe": {
+            "get": {
+                "description": "Returns QoE scores, trends and issues by platform and transcode decision",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get quality of experience dashboard",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QoEDashboard"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/resolution-mismatch": {
+            "get": {
+                "description": "Returns sessions where the stream resolution differs from the source resolution",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get resolution mismatch analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ResolutionMismatchAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/subtitles": {
+            "get": {
+                "description": "Returns subtitle usage by language, codec and user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get subtitle analytics",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SubtitleAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/temporal-heatmap": {
+            "get": {
+                "description": "Returns playback locations bucketed by time for animated heatmaps",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get temporal heatmap",
+                "parameters": [
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Time bucket",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TemporalHeatmapResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/transcode-reasons": {
+            "get": {
+                "description": "Returns why sessions were transcoded and hourly transcode concurrency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get transcode reasons",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/database.TranscodeReasonAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/trends": {
+            "get": {
+                "description": "Returns playback counts over time; the interval (hour, day, week or month) is chosen from the date range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get playback trends",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TrendsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/user-engagement": {
+            "get": {
+                "description": "Returns per-user engagement metrics and viewing patterns by hour and day",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get user engagement",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of users",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserEngagementAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/user-network": {
+            "get": {
+                "description": "Returns users as nodes and shared sessions or shared content as edges",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get user network graph",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 2,
+                        "description": "Minimum shared sessions for an edge",
+                        "name": "min_shared_sessions",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1,
+                        "minimum": 0,
+                        "type": "number",
+                        "default": 0.3,
+                        "description": "Minimum content overlap ratio for an edge",
+                        "name": "min_content_overlap",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserNetworkGraph"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/user-overlap": {
+            "get": {
+                "description": "Returns content similarity between pairs of users for chord charts (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get user content overlap",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserContentOverlapAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/user-profile": {
+            "get": {
+                "description": "Returns multi-dimensional engagement scores for radar charts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get user engagement profiles",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserProfileAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/users": {
+            "get": {
+                "description": "Returns the most active users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get top users",
+                "parameters": [
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of users",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UsersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/watch-parties": {
+            "get": {
+                "description": "Returns groups of users watching the same content at the same time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get watch parties",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WatchPartyAnalytics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticates user with username and password, returns JWT token in HTTP-only cookie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Authenticate user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "password": {
+                                    "type": "string"
+                                },
+                                "username": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authentication successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "expires_at": {
+                                                    "type": "string"
+                                                },
+                                                "token": {
+                                                    "type": "string"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Authentication disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/alerts": {
+            "get": {
+                "description": "Returns detection alerts, newest first by default",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "List detection alerts",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of alerts",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of alerts to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only alerts for this user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only acknowledged (true) or unacknowledged (false) alerts",
+                        "name": "acknowledged",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "critical",
+                            "warning",
+                            "info"
+                        ],
+                        "type": "string",
+                        "description": "Alert severity",
+                        "name": "severity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rule that raised the alert",
+                        "name": "rule_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Earliest alert time (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Latest alert time (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort column",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort direction",
+                        "name": "order_direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DetectionAlertList"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/alerts/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "Get a detection alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/detection.Alert"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid alert ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Alert not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/allowlist": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "List trusted locations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only entries that apply to this user",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.AllowlistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Allowlist not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/metrics": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "Get detection engine metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/detection.EngineMetrics"
+                        }
+                    },
+                    "503": {
+                        "description": "Detection engine not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/rules": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "List detection rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DetectionRuleList"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/rules/{type}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "Get a detection rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "impossible_travel",
+                        "description": "Rule type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/detection.Rule"
+                        }
+                    },
+                    "404": {
+                        "description": "Rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/stats": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "Get detection alert statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DetectionAlertStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/users/low-trust": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "List low-trust users",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Trust score threshold",
+                        "name": "threshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.LowTrustUserList"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/users/{id}/devices": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "List a user's devices",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserDevicesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Device inventory not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/users/{id}/trust": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "Get a user's trust score",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/detection.TrustScore"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/detection/users/{id}/trust/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Detection"
+                ],
+                "summary": "Get a user's trust score history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of changes",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TrustHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Detection store error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/locations/geojson": {
+            "get": {
+                "description": "Exports location statistics in GeoJSON format for use in GIS applications and mapping tools",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Export locations as GeoJSON",
+                "responses": {
+                    "200": {
+                        "description": "GeoJSON file download",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/playbacks/geojson": {
+            "get": {
+                "description": "Streams a GeoJSON FeatureCollection with one point per geolocated playback, newest first.\nproperties selects the playback fields included in each feature (default: started_at, username, title, media_type, platform, player, percent_complete).",
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Export playbacks as GeoJSON",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated playback fields, e.g. username,title,started_at",
+                        "name": "properties",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GeoJSON FeatureCollection",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or unknown property",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns comprehensive health status including database connectivity, Tautulli connectivity, last sync time, and uptime",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get system health status",
+                "responses": {
+                    "200": {
+                        "description": "Health status retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Returns 200 OK if the process is alive, regardless of external dependencies. Used for Kubernetes liveness probes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Kubernetes liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Service is alive",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/nats": {
+            "get": {
+                "description": "Returns comprehensive health status of all NATS components including consumer, publisher, DLQ handler, and appender",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get NATS health status",
+                "responses": {
+                    "200": {
+                        "description": "NATS health status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/eventprocessor.OverallHealth"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "NATS is unhealthy",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/nats/component": {
+            "get": {
+                "description": "Returns health status of a specific NATS component (consumer, publisher, dlq, appender)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get specific NATS component health",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Component name (consumer, publisher, dlq, appender)",
+                        "name": "component",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Component health status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/eventprocessor.ComponentHealth"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing component parameter",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Returns 200 OK only if the service is ready to handle traffic (database and Tautulli are both connected, and no supervised service is crash-looping or in backoff). Returns 503 if not ready.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Kubernetes readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Service is ready",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service is not ready",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/setup": {
+            "get": {
+                "description": "Returns comprehensive setup status including data source configurations,\nconnectivity checks, and recommendations for first-time users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get setup status for onboarding wizard",
+                "responses": {
+                    "200": {
+                        "description": "Setup status retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SetupStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/import": {
+            "delete": {
+                "description": "Stops a running Tautulli database import",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Stop import",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "No import in progress",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/progress": {
+            "delete": {
+                "description": "Clears saved import progress to allow a fresh import",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Clear import progress",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Import in progress",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/status": {
+            "get": {
+                "description": "Returns the current status of a Tautulli database import, including\nrows read, imported, deduplicated, errored, throughput, and ETA",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Get import status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/tautulli": {
+            "post": {
+                "description": "Starts importing playback history from a Tautulli SQLite database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Start Tautulli database import",
+                "parameters": [
+                    {
+                        "description": "Import options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Import already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/validate": {
+            "post": {
+                "description": "Validates a Tautulli database file without importing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Validate Tautulli database",
+                "parameters": [
+                    {
+                        "description": "Database path",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidateResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Returns aggregated playback statistics by geographic location with support for 14+ filter dimensions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get location statistics",
+                "parameters": [
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of locations to return (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location statistics retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LocationStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media-types": {
+            "get": {
+                "description": "Returns a list of all unique media types (movie, episode, track, etc.) found in playback history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get list of unique media types",
+                "responses": {
+                    "200": {
+                        "description": "List of media types retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/now-playing": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Polls the session API of each configured Plex, Jellyfin and Emby server and returns the active\nsessions with user, title, progress, player, quality, transcode details and geolocation.\nResults are cached for 10 seconds. Sessions of a server that could not be polled are carried\nover from its last successful poll with stale set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get sessions playing now",
+                "responses": {
+                    "200": {
+                        "description": "Active sessions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.NowPlayingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/playbacks": {
+            "get": {
+                "description": "Returns paginated playback event history with cursor-based pagination for efficient large dataset handling. Supports both cursor-based (recommended) and offset-based (legacy) pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get playback history",
+                "parameters": [
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Number of results per page (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor for next page (from previous response's next_cursor). Use this instead of offset for efficient pagination.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000000,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "LEGACY: Number of results to skip (0-1000000). Prefer cursor for large datasets.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include total_count in the pagination metadata (runs an extra COUNT query)",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Playback events retrieved successfully with pagination info",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PlaybacksResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/fuzzy": {
+            "get": {
+                "description": "Performs fuzzy string matching search against playback history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search playback content with fuzzy matching",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (1-200 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum similarity score (0-100, default 70)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum results (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.FuzzySearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/users": {
+            "get": {
+                "description": "Performs fuzzy string matching search against usernames and friendly names.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search users with fuzzy matching",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (1-200 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum similarity score (0-100, default 70)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum results (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User search results",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.FuzzyUserSearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/server-info": {
+            "get": {
+                "description": "Returns the physical location of the Plex server for visualization purposes (configured via SERVER_LATITUDE and SERVER_LONGITUDE environment variables)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get server location",
+                "responses": {
+                    "200": {
+                        "description": "Server location retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "has_location": {
+                                                    "type": "boolean"
+                                                },
+                                                "latitude": {
+                                                    "type": "number",
+                                                    "format": "float64"
+                                                },
+                                                "longitude": {
+                                                    "type": "number",
+                                                    "format": "float64"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sources/health": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pings each configured media server (Tautulli, Plex, Jellyfin, Emby) and returns\nper-server reachability, latency, last successful sync time, and circuit breaker state.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get media source health",
+                "responses": {
+                    "200": {
+                        "description": "Source health retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SourcesHealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/spatial/arcs": {
+            "get": {
+                "description": "Returns playback locations with geodesic distances and arc weights for visualization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spatial Analytics"
+                ],
+                "summary": "Get distance-weighted connection arcs",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Distance-weighted arcs retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ArcStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/spatial/hexagons": {
+            "get": {
+                "description": "Returns playback counts aggregated into H3 hexagons",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spatial Analytics"
+                ],
+                "summary": "Get H3 hexagon aggregation",
+                "parameters": [
+                    {
+                        "maximum": 8,
+                        "minimum": 6,
+                        "type": "integer",
+                        "default": 7,
+                        "description": "H3 resolution (6=country, 7=city, 8=neighborhood)",
+                        "name": "resolution",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.H3HexagonStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/spatial/nearby": {
+            "get": {
+                "description": "Returns playback locations within a specified radius using spatial index for fast proximity search",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spatial Analytics"
+                ],
+                "summary": "Get nearby locations",
+                "parameters": [
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "description": "Center latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "description": "Center longitude",
+                        "name": "lon",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 20000,
+                        "minimum": 1,
+                        "type": "number",
+                        "default": 100,
+                        "description": "Search radius in kilometers",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Nearby locations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LocationStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/spatial/temporal-density": {
+            "get": {
+                "description": "Returns playback density over time and space with rolling averages for smooth animation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spatial Analytics"
+                ],
+                "summary": "Get temporal-spatial density",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "hour",
+                        "description": "Time interval (hour, day, week, month)",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 8,
+                        "minimum": 6,
+                        "type": "integer",
+                        "default": 7,
+                        "description": "H3 resolution (6=country, 7=city, 8=neighborhood)",
+                        "name": "resolution",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Temporal-spatial density retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TemporalSpatialPoint"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/spatial/viewport": {
+            "get": {
+                "description": "Returns playback locations within a bounding box using spatial index for fast pan/zoom",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Spatial Analytics"
+                ],
+                "summary": "Get locations in viewport",
+                "parameters": [
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "description": "Western longitude boundary",
+                        "name": "west",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "description": "Southern latitude boundary",
+                        "name": "south",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 180,
+                        "minimum": -180,
+                        "type": "number",
+                        "description": "Eastern longitude boundary",
+                        "name": "east",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 90,
+                        "minimum": -90,
+                        "type": "number",
+                        "description": "Northern latitude boundary",
+                        "name": "north",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Viewport locations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LocationStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Manually triggers a sync with Tautulli to fetch latest playback data. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Trigger data synchronization",
+                "responses": {
+                    "202": {
+                        "description": "Sync triggered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sync already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync/plex/historical": {
+            "post": {
+                "description": "Starts syncing playback history from Plex servers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Start Plex historical sync",
+                "parameters": [
+                    {
+                        "description": "Sync options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.PlexHistoricalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PlexHistoricalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/api.PlexHistoricalResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sync already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/api.PlexHistoricalResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/api.PlexHistoricalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync/status": {
+            "get": {
+                "description": "Returns the combined status of all sync operations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Get sync status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SyncStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tautulli/concurrent-streams-by-stream-type": {
+            "get": {
+                "description": "Returns concurrent streams data organized by stream type from Tautulli",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tautulli Analytics"
+                ],
+                "summary": "Get concurrent streams data by stream type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Time range in days",
+                        "name": "time_range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Concurrent streams by stream type retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/tautulli.TautulliConcurrentStreamsByStreamTypeData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tautulli/plays-by-date": {
+            "get": {
+                "description": "Returns time series playback data organized by date from Tautulli",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tautulli Analytics"
+                ],
+                "summary": "Get playback data by date",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Time range in days",
+                        "name": "time_range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "plays",
+                        "description": "Y-axis type (plays or duration)",
+                        "name": "y_axis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Group data (0 or 1)",
+                        "name": "grouping",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plays by date retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/tautulli.TautulliPlaysByDateData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tautulli/plays-by-dayofweek": {
+            "get": {
+                "description": "Returns playback data organized by day of week from Tautulli",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tautulli Analytics"
+                ],
+                "summary": "Get playback data by day of week",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Time range in days",
+                        "name": "time_range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "plays",
+                        "description": "Y-axis type (plays or duration)",
+                        "name": "y_axis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Group data (0 or 1)",
+                        "name": "grouping",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plays by day of week retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/tautulli.TautulliPlaysByDayOfWeekData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tautulli/plays-by-hourofday": {
+            "get": {
+                "description": "Returns playback data organized by hour of day from Tautulli",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tautulli Analytics"
+                ],
+                "summary": "Get playback data by hour of day",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Time range in days",
+                        "name": "time_range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "plays",
+                        "description": "Y-axis type (plays or duration)",
+                        "name": "y_axis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Group data (0 or 1)",
+                        "name": "grouping",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plays by hour of day retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/tautulli.TautulliPlaysByHourOfDayData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tautulli/plays-by-stream-type": {
+            "get": {
+                "description": "Returns playback data organized by stream type (Direct Play, Direct Stream, Transcode) from Tautulli",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tautulli Analytics"
+                ],
+                "summary": "Get playback data by stream type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Time range in days",
+                        "name": "time_range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "plays",
+                        "description": "Y-axis type (plays or duration)",
+                        "name": "y_axis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Group data (0 or 1)",
+                        "name": "grouping",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plays by stream type retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/tautulli.TautulliPlaysByStreamTypeData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Returns a list of all unique usernames that have playback history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Core"
+                ],
+                "summary": "Get list of unique users",
+                "responses": {
+                    "200": {
+                        "description": "List of usernames retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/watch-parties": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists watch parties detected after each sync, with participants and confidence. The default confidence threshold rises as admins mark false positives.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "List detected watch parties",
+                "parameters": [
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of parties",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of parties to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Lowest confidence listed (0-1, default: current threshold)",
+                        "name": "min_confidence",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also list parties marked as false positives",
+                        "name": "include_false_positives",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "aac",
+                            "ac3"
+                        ],
+                        "description": "Audio codecs",
+                        "name": "audio_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vpn",
+                            "datacenter"
+                        ],
+                        "description": "Connection types (residential, vpn, datacenter, unknown)",
+                        "name": "connection_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "PG",
+                            "PG-13",
+                            "R"
+                        ],
+                        "description": "Content ratings",
+                        "name": "content_ratings",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3650,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Last N days, ignored when start_date is set",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End of the date range (RFC3339)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Movies",
+                            "TV Shows"
+                        ],
+                        "description": "Library names",
+                        "name": "libraries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "lan",
+                            "wan"
+                        ],
+                        "description": "Location types",
+                        "name": "location_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "movie",
+                            "episode"
+                        ],
+                        "description": "Media types",
+                        "name": "media_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Android",
+                            "iOS"
+                        ],
+                        "description": "Client platforms",
+                        "name": "platforms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "Plex Web"
+                        ],
+                        "description": "Client players",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "plex-home"
+                        ],
+                        "description": "Media server IDs",
+                        "name": "server_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start of the date range (RFC3339)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "transcode",
+                            "direct play"
+                        ],
+                        "description": "Transcode decisions",
+                        "name": "transcode_decisions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "user1",
+                            "user2"
+                        ],
+                        "description": "Usernames",
+                        "name": "users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "h264",
+                            "hevc"
+                        ],
+                        "description": "Video codecs",
+                        "name": "video_codecs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "1080",
+                            "720"
+                        ],
+                        "description": "Video resolutions",
+                        "name": "video_resolutions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            2023,
+                            2024
+                        ],
+                        "description": "Release years",
+                        "name": "years",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watch parties",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api.WatchPartyListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "description": "Establishes a WebSocket connection for real-time playback notifications and statistics updates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Realtime"
+                ],
+                "summary": "Establish WebSocket connection",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "WebSocket hub not available",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.AllowlistResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/detection.TrustedLocation"
+                    }
+                }
+            }
+        },
+        "api.ApproximateDistinctResponse": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "is_approximate": {
+                    "description": "true if DataSketches was used",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.ApproximatePercentileResponse": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "is_approximate": {
+                    "description": "true if DataSketches was used",
+                    "type": "boolean"
+                },
+                "percentile": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.ApproximateStatsResponse": {
+            "type": "object",
+            "properties": {
+                "datasketches_available": {
+                    "description": "true if DataSketches extension is available",
+                    "type": "boolean"
+                },
+                "stats": {
+                    "$ref": "#/definitions/database.ApproximateStats"
+                }
+            }
+        },
+        "api.CheckpointResponse": {
+            "type": "object",
+            "properties": {
+                "consumer_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_sequence": {
+                    "type": "integer"
+                },
+                "last_timestamp": {
+                    "type": "string"
+                },
+                "processed_count": {
+                    "type": "integer"
+                },
+                "replay_mode": {
+                    "type": "string"
+                },
+                "start_sequence": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stream_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.DetectionAlertList": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/detection.Alert"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.DetectionAlertStats": {
+            "type": "object",
+            "properties": {
+                "by_rule_type": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "by_severity": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unacknowledged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.DetectionRuleList": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/detection.Rule"
+                    }
+                }
+            }
+        },
+        "api.FuzzySearchResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "fuzzy_search": {
+                    "description": "true if RapidFuzz was used",
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.FuzzySearchResult"
+                    }
+                }
+            }
+        },
+        "api.FuzzyUserSearchResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "fuzzy_search": {
+                    "description": "true if RapidFuzz was used",
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.UserSearchResult"
+                    }
+                }
+            }
+        },
+        "api.ImportRequest": {
+            "type": "object",
+            "properties": {
+                "db_path": {
+                    "description": "DBPath overrides the configured database path (optional).",
+                    "type": "string"
+                },
+                "dry_run": {
+                    "description": "DryRun validates without actually importing.",
+                    "type": "boolean"
+                },
+                "resume": {
+                    "description": "Resume continues from the last saved progress.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "stats": {
+                    "$ref": "#/definitions/tautulliimport.ProgressSummary"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "verify": {
+                    "$ref": "#/definitions/tautulliimport.VerifyResult"
+                }
+            }
+        },
+        "api.JellystatImportRequest": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "description": "FilePath is the path of an export file on the server.",
+                    "type": "string"
+                },
+                "resume": {
+                    "description": "Resume continues from the last saved progress for the same file.",
+                    "type": "boolean"
+                },
+                "server_id": {
+                    "description": "ServerID is the Jellyfin server the export was taken from (optional).",
+                    "type": "string"
+                }
+            }
+        },
+        "api.LogLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "description": "Level is the new global level. Accepts the same values as LOG_LEVEL.",
+                    "type": "string",
+                    "enum": [
+                        "trace",
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ]
+                }
+            }
+        },
+        "api.LogLevelResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "previous_level": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.LowTrustUserList": {
+            "type": "object",
+            "properties": {
+                "threshold": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/detection.TrustScore"
+                    }
+                }
+            }
+        },
+        "api.PlexHistoricalRequest": {
+            "type": "object",
+            "properties": {
+                "days_back": {
+                    "type": "integer"
+                },
+                "library_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.PlexHistoricalResponse": {
+            "type": "object",
+            "properties": {
+                "correlation_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.ReplayRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "DryRun previews replay without writing to database.",
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "Mode specifies how to determine the starting point.\nOptions: \"new\", \"all\", \"sequence\", \"time\", \"last_acked\"",
+                    "type": "string"
+                },
+                "start_sequence": {
+                    "description": "StartSequence is the sequence number to start from (for mode \"sequence\").",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "StartTime is the ISO8601 timestamp to start from (for mode \"time\").",
+                    "type": "string"
+                },
+                "stop_sequence": {
+                    "description": "StopSequence is the optional end sequence (0 = no limit).",
+                    "type": "integer"
+                },
+                "stop_time": {
+                    "description": "StopTime is the optional ISO8601 end timestamp.",
+                    "type": "string"
+                },
+                "stream_name": {
+                    "description": "StreamName is the NATS stream to replay from.",
+                    "type": "string"
+                },
+                "topic": {
+                    "description": "Topic is the NATS topic to subscribe to.",
+                    "type": "string"
+                },
+                "verify_transaction_id": {
+                    "description": "VerifyTransactionID enables transaction ID verification.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.ReplayResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SessionStitchResult": {
+            "type": "object",
+            "properties": {
+                "gap": {
+                    "description": "Gap is the SYNC_SESSION_STITCH_GAP the history was stitched with",
+                    "type": "string"
+                },
+                "playbacks_updated": {
+                    "description": "PlaybacksUpdated is the number of playbacks whose logical session changed",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.SlowQueriesResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.SlowQuery"
+                    }
+                },
+                "threshold_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.SyncError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "record_id": {
+                    "type": "integer"
+                },
+                "recoverable": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SyncProgress": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "elapsed_seconds": {
+                    "type": "number"
+                },
+                "error_count": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SyncError"
+                    }
+                },
+                "estimated_remaining_seconds": {
+                    "type": "number"
+                },
+                "imported_records": {
+                    "type": "integer"
+                },
+                "last_processed_id": {
+                    "type": "integer"
+                },
+                "processed_records": {
+                    "type": "integer"
+                },
+                "progress_percent": {
+                    "type": "number"
+                },
+                "records_per_second": {
+                    "type": "number"
+                },
+                "skipped_records": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "idle, running, completed, error, canceled",
+                    "type": "string"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.SyncStatusResponse": {
+            "type": "object",
+            "properties": {
+                "plex_historical": {
+                    "$ref": "#/definitions/api.SyncProgress"
+                },
+                "server_syncs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.SyncProgress"
+                    }
+                },
+                "tautulli_import": {
+                    "$ref": "#/definitions/api.SyncProgress"
+                }
+            }
+        },
+        "api.TrustHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/detection.TrustScoreChange"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.UserDevicesResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/detection.UserDevice"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ValidateDateRange": {
+            "type": "object",
+            "properties": {
+                "earliest": {
+                    "type": "string"
+                },
+                "latest": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ValidateRequest": {
+            "type": "object",
+            "properties": {
+                "db_path": {
+                    "description": "DBPath is the path to the Tautulli SQLite database.",
+                    "type": "string"
+                }
+            }
+        },
+        "api.ValidateResponse": {
+            "type": "object",
+            "properties": {
+                "date_range": {
+                    "$ref": "#/definitions/api.ValidateDateRange"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "media_types": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "total_records": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.WatchPartyListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "min_confidence": {
+                    "description": "MinConfidence is min_confidence, or the feedback-adjusted threshold",
+                    "type": "number"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "parties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchParty"
+                    }
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config.Change": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "new": {},
+                "old": {}
+            }
+        },
+        "config.ReloadResult": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.Change"
+                    }
+                },
+                "requires_restart": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.Change"
+                    }
+                }
+            }
+        },
+        "database.ApproximateStats": {
+            "type": "object",
+            "properties": {
+                "error_bound": {
+                    "description": "Estimated error bound for HLL (typically \u003c2%)",
+                    "type": "number"
+                },
+                "is_approximate": {
+                    "description": "Metadata",
+                    "type": "boolean"
+                },
+                "query_time_ms": {
+                    "type": "integer"
+                },
+                "total_playbacks": {
+                    "description": "Total count (exact)",
+                    "type": "integer"
+                },
+                "unique_ips": {
+                    "type": "integer"
+                },
+                "unique_platforms": {
+                    "type": "integer"
+                },
+                "unique_players": {
+                    "type": "integer"
+                },
+                "unique_titles": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "description": "Approximate distinct counts using HyperLogLog",
+                    "type": "integer"
+                },
+                "watch_time_p50": {
+                    "description": "Approximate percentiles using KLL sketches",
+                    "type": "number"
+                },
+                "watch_time_p75": {
+                    "description": "75th percentile",
+                    "type": "number"
+                },
+                "watch_time_p90": {
+                    "description": "90th percentile",
+                    "type": "number"
+                },
+                "watch_time_p95": {
+                    "description": "95th percentile",
+                    "type": "number"
+                },
+                "watch_time_p99": {
+                    "description": "99th percentile",
+                    "type": "number"
+                }
+            }
+        },
+        "database.ColorPrimariesStats": {
+            "type": "object",
+            "properties": {
+                "percentage": {
+                    "type": "number"
+                },
+                "primaries": {
+                    "description": "e.g., \"bt2020\", \"bt709\"",
+                    "type": "string"
+                },
+                "session_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.ColorSpaceStats": {
+            "type": "object",
+            "properties": {
+                "color_space": {
+                    "description": "e.g., \"bt2020nc\", \"bt709\"",
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "session_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.FuzzySearchResult": {
+            "type": "object",
+            "properties": {
+                "grandparent_title": {
+                    "description": "Show/artist title",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "rating_key for the content",
+                    "type": "string"
+                },
+                "media_type": {
+                    "description": "movie, episode, track",
+                    "type": "string"
+                },
+                "parent_title": {
+                    "description": "Season/album title",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Fuzzy match score (0-100)",
+                    "type": "integer"
+                },
+                "thumb": {
+                    "description": "Thumbnail URL",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Primary title",
+                    "type": "string"
+                },
+                "year": {
+                    "description": "Release year",
+                    "type": "integer"
+                }
+            }
+        },
+        "database.HDRContentStats": {
+            "type": "object",
+            "properties": {
+                "color_primaries": {
+                    "description": "Color primaries distribution",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.ColorPrimariesStats"
+                    }
+                },
+                "color_spaces": {
+                    "description": "Color space distribution",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.ColorSpaceStats"
+                    }
+                },
+                "hdr_formats": {
+                    "description": "HDR format breakdown",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.HDRFormatStats"
+                    }
+                },
+                "hdr_percentage": {
+                    "type": "number"
+                },
+                "hdr_sessions": {
+                    "type": "integer"
+                },
+                "sdr_sessions": {
+                    "type": "integer"
+                },
+                "total_sessions": {
+                    "description": "Overview counts",
+                    "type": "integer"
+                }
+            }
+        },
+        "database.HDRFormatStats": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "e.g., \"HDR10\", \"HDR10+\", \"Dolby Vision\", \"HLG\"",
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "session_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.HWCodecStats": {
+            "type": "object",
+            "properties": {
+                "codec_name": {
+                    "description": "e.g., \"nvdec\", \"qsv\", \"vaapi\"",
+                    "type": "string"
+                },
+                "codec_title": {
+                    "description": "e.g., \"NVIDIA NVDEC\", \"Intel Quick Sync\"",
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "session_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.HWTranscodeTrend": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "full_pipeline_sessions": {
+                    "type": "integer"
+                },
+                "hw_percentage": {
+                    "type": "number"
+                },
+                "hw_sessions": {
+                    "type": "integer"
+                },
+                "total_sessions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.HardwareTranscodeStats": {
+            "type": "object",
+            "properties": {
+                "decoder_stats": {
+                    "description": "Decoder statistics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.HWCodecStats"
+                    }
+                },
+                "direct_play_sessions": {
+                    "type": "integer"
+                },
+                "encoder_stats": {
+                    "description": "Encoder statistics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.HWCodecStats"
+                    }
+                },
+                "full_pipeline_count": {
+                    "description": "Full pipeline (both HW decode and encode)",
+                    "type": "integer"
+                },
+                "full_pipeline_pct": {
+                    "type": "number"
+                },
+                "hw_percentage": {
+                    "type": "number"
+                },
+                "hw_transcode_sessions": {
+                    "type": "integer"
+                },
+                "sw_transcode_sessions": {
+                    "type": "integer"
+                },
+                "throttled_percentage": {
+                    "type": "number"
+                },
+                "throttled_sessions": {
+                    "description": "Throttling statistics",
+                    "type": "integer"
+                },
+                "total_sessions": {
+                    "description": "Overview counts",
+                    "type": "integer"
+                }
+            }
+        },
+        "database.SlowQuery": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "filter": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "plan": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "database.TranscodeHourBucket": {
+            "type": "object",
+            "properties": {
+                "active_sessions": {
+                    "type": "integer"
+                },
+                "concurrent_transcodes": {
+                    "type": "integer"
+                },
+                "hour": {
+                    "type": "string"
+                }
+            }
+        },
+        "database.TranscodeReasonAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_concurrent_transcodes": {
+                    "description": "Concurrency across hours with playback activity",
+                    "type": "number"
+                },
+                "hourly": {
+                    "description": "Hourly concurrent transcodes within the date range",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.TranscodeHourBucket"
+                    }
+                },
+                "peak_concurrent_transcodes": {
+                    "type": "integer"
+                },
+                "peak_time": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "description": "Reasons attributes every transcode session to exactly one reason",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.TranscodeReasonStats"
+                    }
+                },
+                "total_sessions": {
+                    "type": "integer"
+                },
+                "transcode_percentage": {
+                    "type": "number"
+                },
+                "transcode_sessions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.TranscodeReasonStats": {
+            "type": "object",
+            "properties": {
+                "avg_stream_bitrate_kbps": {
+                    "type": "number"
+                },
+                "percentage": {
+                    "description": "Of transcode sessions",
+                    "type": "number"
+                },
+                "reason": {
+                    "description": "One of the TranscodeReason constants",
+                    "type": "string"
+                },
+                "session_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.UserSearchResult": {
+            "type": "object",
+            "properties": {
+                "friendly_name": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Fuzzy match score (0-100)",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "user_thumb": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "detection.Alert": {
+            "type": "object",
+            "properties": {
+                "acknowledged": {
+                    "type": "boolean"
+                },
+                "acknowledged_at": {
+                    "type": "string"
+                },
+                "acknowledged_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "machine_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "rule_type": {
+                    "$ref": "#/definitions/detection.RuleType"
+                },
+                "server_id": {
+                    "description": "v2.1: Multi-server support - server that triggered alert",
+                    "type": "string"
+                },
+                "severity": {
+                    "$ref": "#/definitions/detection.Severity"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "detection.DetectorMetrics": {
+            "type": "object",
+            "properties": {
+                "alerts_generated": {
+                    "type": "integer"
+                },
+                "avg_processing_ms": {
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "events_checked": {
+                    "type": "integer"
+                },
+                "last_triggered_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "detection.EngineMetrics": {
+            "type": "object",
+            "properties": {
+                "alerts_generated": {
+                    "type": "integer"
+                },
+                "alerts_suppressed": {
+                    "description": "Alerts dropped because the event matched a trusted location",
+                    "type": "integer"
+                },
+                "detection_errors": {
+                    "type": "integer"
+                },
+                "detector_metrics": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/detection.DetectorMetrics"
+                    }
+                },
+                "events_processed": {
+                    "type": "integer"
+                },
+                "last_processed_at": {
+                    "type": "string"
+                },
+                "processing_time_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "detection.Geolocation": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "detection.Rule": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rule_type": {
+                    "$ref": "#/definitions/detection.RuleType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "detection.RuleType": {
+            "type": "string",
+            "enum": [
+                "impossible_travel",
+                "concurrent_streams",
+                "device_velocity",
+                "geo_restriction",
+                "simultaneous_locations",
+                "buffer_health",
+                "vpn_usage",
+                "account_sharing",
+                "datacenter_stream",
+                "new_device",
+                "user_agent_anomaly"
+            ],
+            "x-enum-varnames": [
+                "RuleTypeImpossibleTravel",
+                "RuleTypeConcurrentStreams",
+                "RuleTypeDeviceVelocity",
+                "RuleTypeGeoRestriction",
+                "RuleTypeSimultaneousLocations",
+                "RuleTypeBufferHealth",
+                "RuleTypeVPNUsage",
+                "RuleTypeAccountSharing",
+                "RuleTypeDatacenterStream",
+                "RuleTypeNewDevice",
+                "RuleTypeUserAgentAnomaly"
+            ]
+        },
+        "detection.Severity": {
+            "type": "string",
+            "enum": [
+                "info",
+                "warning",
+                "critical"
+            ],
+            "x-enum-varnames": [
+                "SeverityInfo",
+                "SeverityWarning",
+                "SeverityCritical"
+            ]
+        },
+        "detection.TrustScore": {
+            "type": "object",
+            "properties": {
+                "last_violation_at": {
+                    "type": "string"
+                },
+                "restricted": {
+                    "description": "Auto-restricted at score \u003c 50",
+                    "type": "boolean"
+                },
+                "score": {
+                    "description": "0-100",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "violations_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "detection.TrustScoreChange": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "description": "Admin username, for manual changes",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "previous_score": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "$ref": "#/definitions/detection.TrustScoreChangeReason"
+                },
+                "rule_type": {
+                    "description": "Rule that fired, for violations",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/detection.RuleType"
+                        }
+                    ]
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "detection.TrustScoreChangeReason": {
+            "type": "string",
+            "enum": [
+                "violation",
+                "recovery",
+                "override",
+                "reset"
+            ],
+            "x-enum-comments": {
+                "TrustChangeOverride": "Set manually by an admin",
+                "TrustChangeRecovery": "Daily recovery job",
+                "TrustChangeReset": "Reset to 100 by an admin",
+                "TrustChangeViolation": "Detection rule violation"
+            },
+            "x-enum-descriptions": [
+                "Detection rule violation",
+                "Daily recovery job",
+                "Set manually by an admin",
+                "Reset to 100 by an admin"
+            ],
+            "x-enum-varnames": [
+                "TrustChangeViolation",
+                "TrustChangeRecovery",
+                "TrustChangeOverride",
+                "TrustChangeReset"
+            ]
+        },
+        "detection.TrustedLocation": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "description": "CIDR matches the event's IP address, e.g. \"203.0.113.0/24\". A bare IP\nis stored as a single-address prefix.",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "Country matches the event's geolocated country, compared\ncase-insensitively. Use the value shown in alerts, e.g. \"Canada\".",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserID scopes the entry to one user. Zero applies it to all users.",
+                    "type": "integer"
+                }
+            }
+        },
+        "detection.UserDevice": {
+            "type": "object",
+            "properties": {
+                "device": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "first_session_key": {
+                    "type": "string"
+                },
+                "flagged": {
+                    "description": "Flagged marks a device an admin has reviewed as suspicious.",
+                    "type": "boolean"
+                },
+                "last_ip_address": {
+                    "type": "string"
+                },
+                "last_location": {
+                    "description": "LastLocation is the geolocation of LastIPAddress, when known.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/detection.Geolocation"
+                        }
+                    ]
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is a custom name set by an admin; empty until renamed.",
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "player": {
+                    "description": "Client details from the most recent session.",
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "session_count": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "eventprocessor.ComponentHealth": {
+            "type": "object",
+            "properties": {
+                "degraded": {
+                    "type": "boolean"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "error": {
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "last_check": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "eventprocessor.OverallHealth": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/eventprocessor.ComponentHealth"
+                    }
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "healthy",
+                        "degraded",
+                        "unhealthy"
+                    ]
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/models.APIError"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.Metadata"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AbandonedContent": {
+            "type": "object",
+            "properties": {
+                "abandonments": {
+                    "description": "\u003c 90%",
+                    "type": "integer"
+                },
+                "average_completion": {
+                    "description": "Mean %",
+                    "type": "number"
+                },
+                "completion_rate": {
+                    "description": "%",
+                    "type": "number"
+                },
+                "completions": {
+                    "description": "\u003e= 90%",
+                    "type": "integer"
+                },
+                "genres": {
+                    "type": "string"
+                },
+                "grandparent_title": {
+                    "description": "For episodes",
+                    "type": "string"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "median_drop_off_point": {
+                    "description": "Median abandonment %",
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_starts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ArcStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "description": "Average completion percentage",
+                    "type": "number"
+                },
+                "city": {
+                    "description": "User city",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "User country",
+                    "type": "string"
+                },
+                "distance_km": {
+                    "description": "Geodesic distance in kilometers",
+                    "type": "number"
+                },
+                "playback_count": {
+                    "description": "Number of playbacks from this location",
+                    "type": "integer"
+                },
+                "server_latitude": {
+                    "description": "Server location latitude",
+                    "type": "number"
+                },
+                "server_longitude": {
+                    "description": "Server location longitude",
+                    "type": "number"
+                },
+                "unique_users": {
+                    "description": "Unique users from this location",
+                    "type": "integer"
+                },
+                "user_latitude": {
+                    "description": "User location latitude",
+                    "type": "number"
+                },
+                "user_longitude": {
+                    "description": "User location longitude",
+                    "type": "number"
+                },
+                "weight": {
+                    "description": "Arc visual weight (playback * distance factor)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.AudioAnalytics": {
+            "type": "object",
+            "properties": {
+                "atmos_playbacks": {
+                    "type": "integer"
+                },
+                "avg_bitrate_kbps": {
+                    "type": "number"
+                },
+                "channel_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AudioChannelDistribution"
+                    }
+                },
+                "codec_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AudioCodecDistribution"
+                    }
+                },
+                "downmix_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AudioDownmixEvent"
+                    }
+                },
+                "lossless_adoption_percent": {
+                    "type": "number"
+                },
+                "surround_sound_adoption_percent": {
+                    "type": "number"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AudioChannelDistribution": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "channels": {
+                    "type": "string"
+                },
+                "layout": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AudioCodecDistribution": {
+            "type": "object",
+            "properties": {
+                "avg_bitrate_kbps": {
+                    "type": "number"
+                },
+                "codec": {
+                    "type": "string"
+                },
+                "is_lossless": {
+                    "type": "boolean"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AudioDownmixEvent": {
+            "type": "object",
+            "properties": {
+                "occurrence_count": {
+                    "type": "integer"
+                },
+                "platforms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_channels": {
+                    "type": "string"
+                },
+                "stream_channels": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BandwidthAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_bandwidth_mbps": {
+                    "type": "number"
+                },
+                "by_codec": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BandwidthByCodec"
+                    }
+                },
+                "by_resolution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BandwidthByResolution"
+                    }
+                },
+                "by_transcode": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BandwidthByTranscode"
+                    }
+                },
+                "direct_play_bandwidth_gb": {
+                    "type": "number"
+                },
+                "peak_bandwidth_mbps": {
+                    "type": "number"
+                },
+                "top_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BandwidthByUser"
+                    }
+                },
+                "total_bandwidth_gb": {
+                    "type": "number"
+                },
+                "transcode_bandwidth_gb": {
+                    "type": "number"
+                },
+                "trends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BandwidthTrend"
+                    }
+                }
+            }
+        },
+        "models.BandwidthByCodec": {
+            "type": "object",
+            "properties": {
+                "audio_codec": {
+                    "type": "string"
+                },
+                "avg_bandwidth_mbps": {
+                    "type": "number"
+                },
+                "bandwidth_gb": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "video_codec": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BandwidthByResolution": {
+            "type": "object",
+            "properties": {
+                "avg_bandwidth_mbps": {
+                    "type": "number"
+                },
+                "bandwidth_gb": {
+                    "type": "number"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "resolution": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BandwidthByTranscode": {
+            "type": "object",
+            "properties": {
+                "avg_bandwidth_mbps": {
+                    "type": "number"
+                },
+                "bandwidth_gb": {
+                    "type": "number"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "transcode_decision": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BandwidthByUser": {
+            "type": "object",
+            "properties": {
+                "avg_bandwidth_mbps": {
+                    "type": "number"
+                },
+                "bandwidth_gb": {
+                    "type": "number"
+                },
+                "direct_play_count": {
+                    "type": "integer"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "transcode_count": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BandwidthTrend": {
+            "type": "object",
+            "properties": {
+                "avg_mbps": {
+                    "type": "number"
+                },
+                "bandwidth_gb": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BingeAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_binge_duration_minutes": {
+                    "type": "number"
+                },
+                "avg_episodes_per_binge": {
+                    "type": "number"
+                },
+                "binges_by_day": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BingesByDayOfWeek"
+                    }
+                },
+                "recent_binge_sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BingeSession"
+                    }
+                },
+                "top_binge_shows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BingeShowStats"
+                    }
+                },
+                "top_binge_watchers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BingeUserStats"
+                    }
+                },
+                "total_binge_sessions": {
+                    "type": "integer"
+                },
+                "total_episodes_binged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BingeSession": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "episode_count": {
+                    "type": "integer"
+                },
+                "first_episode_time": {
+                    "type": "string"
+                },
+                "last_episode_time": {
+                    "type": "string"
+                },
+                "show_name": {
+                    "type": "string"
+                },
+                "total_duration_minutes": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BingeShowStats": {
+            "type": "object",
+            "properties": {
+                "avg_episodes_per_binge": {
+                    "type": "number"
+                },
+                "binge_count": {
+                    "type": "integer"
+                },
+                "show_name": {
+                    "type": "string"
+                },
+                "total_episodes": {
+                    "type": "integer"
+                },
+                "unique_watchers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BingeUserStats": {
+            "type": "object",
+            "properties": {
+                "avg_episodes_per_binge": {
+                    "type": "number"
+                },
+                "binge_count": {
+                    "type": "integer"
+                },
+                "favorite_show": {
+                    "type": "string"
+                },
+                "total_episodes": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BingesByDayOfWeek": {
+            "type": "object",
+            "properties": {
+                "avg_episodes": {
+                    "type": "integer"
+                },
+                "binge_count": {
+                    "type": "integer"
+                },
+                "day_of_week": {
+                    "description": "0 = Sunday, 6 = Saturday",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BitrateAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_source_bitrate": {
+                    "description": "Overall statistics",
+                    "type": "integer"
+                },
+                "avg_transcode_bitrate": {
+                    "description": "Kbps - average transcoded stream bitrate",
+                    "type": "integer"
+                },
+                "bandwidth_utilization": {
+                    "description": "Bandwidth analysis",
+                    "type": "number"
+                },
+                "bitrate_by_resolution": {
+                    "description": "Breakdown by resolution",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BitrateByResolutionItem"
+                    }
+                },
+                "bitrate_timeseries": {
+                    "description": "Time series (for charts with LTTB downsampling)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BitrateTimeSeriesItem"
+                    }
+                },
+                "constrained_sessions": {
+                    "description": "Count of sessions where bitrate \u003e bandwidth",
+                    "type": "integer"
+                },
+                "median_bitrate": {
+                    "description": "Kbps - median bitrate (50th percentile)",
+                    "type": "integer"
+                },
+                "peak_bitrate": {
+                    "description": "Kbps - highest bitrate observed",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BitrateByResolutionItem": {
+            "type": "object",
+            "properties": {
+                "avg_bitrate": {
+                    "description": "Kbps - average bitrate for this resolution",
+                    "type": "integer"
+                },
+                "resolution": {
+                    "description": "\"4K\", \"1080p\", \"720p\", \"SD\", \"Unknown\"",
+                    "type": "string"
+                },
+                "session_count": {
+                    "description": "Number of sessions at this resolution",
+                    "type": "integer"
+                },
+                "transcode_rate": {
+                    "description": "% (0-100) - percentage of sessions transcoded",
+                    "type": "number"
+                }
+            }
+        },
+        "models.BitrateTimeSeriesItem": {
+            "type": "object",
+            "properties": {
+                "active_sessions": {
+                    "description": "Number of concurrent sessions",
+                    "type": "integer"
+                },
+                "avg_bitrate": {
+                    "description": "Kbps - average bitrate during this period",
+                    "type": "integer"
+                },
+                "peak_bitrate": {
+                    "description": "Kbps - peak bitrate during this period",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "RFC3339 timestamp",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BumpChartAnalytics": {
+            "type": "object",
+            "properties": {
+                "exits": {
+                    "description": "Content that exited top 10",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RankingEntry"
+                    }
+                },
+                "newEntries": {
+                    "description": "Content that entered top 10",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RankingEntry"
+                    }
+                },
+                "periods": {
+                    "description": "Time period labels",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rankings": {
+                    "description": "Rankings per period",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.RankingEntry"
+                        }
+                    }
+                },
+                "topMovers": {
+                    "description": "Biggest rank changes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContentMover"
+                    }
+                },
+                "totalPeriods": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CalendarDayActivity": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "ISO date (YYYY-MM-DD)",
+                    "type": "string"
+                },
+                "intensity": {
+                    "description": "0-1 normalized activity level",
+                    "type": "number"
+                },
+                "playCount": {
+                    "description": "Number of plays",
+                    "type": "integer"
+                },
+                "watchTime": {
+                    "description": "Seconds watched",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CalendarHeatmapAnalytics": {
+            "type": "object",
+            "properties": {
+                "activeDays": {
+                    "description": "Days with any activity",
+                    "type": "integer"
+                },
+                "avgDaily": {
+                    "description": "Average daily watch time",
+                    "type": "number"
+                },
+                "currentStreak": {
+                    "description": "Current streak",
+                    "type": "integer"
+                },
+                "days": {
+                    "description": "365 days of activity",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CalendarDayActivity"
+                    }
+                },
+                "longestStreak": {
+                    "description": "Consecutive active days",
+                    "type": "integer"
+                },
+                "maxDaily": {
+                    "description": "Max watch time in a day",
+                    "type": "integer"
+                },
+                "totalPlayCount": {
+                    "type": "integer"
+                },
+                "totalWatchTime": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ChannelConfig": {
+            "type": "object",
+            "properties": {
+                "discord_avatar_url": {
+                    "type": "string"
+                },
+                "discord_username": {
+                    "type": "string"
+                },
+                "discord_webhook_url": {
+                    "description": "Discord configuration",
+                    "type": "string"
+                },
+                "slack_channel": {
+                    "type": "string"
+                },
+                "slack_icon_emoji": {
+                    "type": "string"
+                },
+                "slack_username": {
+                    "type": "string"
+                },
+                "slack_webhook_url": {
+                    "description": "Slack configuration",
+                    "type": "string"
+                },
+                "smtp_from": {
+                    "type": "string"
+                },
+                "smtp_from_name": {
+                    "type": "string"
+                },
+                "smtp_host": {
+                    "description": "Email configuration",
+                    "type": "string"
+                },
+                "smtp_password": {
+                    "description": "Encrypted at rest",
+                    "type": "string"
+                },
+                "smtp_port": {
+                    "type": "integer"
+                },
+                "smtp_tls_mode": {
+                    "description": "starttls, implicit, or none; overrides UseTLS when set",
+                    "type": "string"
+                },
+                "smtp_user": {
+                    "type": "string"
+                },
+                "telegram_bot_token": {
+                    "description": "Telegram configuration",
+                    "type": "string"
+                },
+                "telegram_chat_id": {
+                    "type": "string"
+                },
+                "telegram_parse_mode": {
+                    "description": "HTML, Markdown, MarkdownV2",
+                    "type": "string"
+                },
+                "use_tls": {
+                    "description": "Upgrade the connection with STARTTLS",
+                    "type": "boolean"
+                },
+                "webhook_auth": {
+                    "description": "Basic auth header value",
+                    "type": "string"
+                },
+                "webhook_headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "webhook_method": {
+                    "description": "POST, PUT",
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "description": "Generic webhook configuration",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CityStats": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CodecStats": {
+            "type": "object",
+            "properties": {
+                "audio_codec": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "video_codec": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CohortData": {
+            "type": "object",
+            "properties": {
+                "average_retention": {
+                    "description": "AverageRetention is the mean retention rate across all tracked weeks (excluding week 0)",
+                    "type": "number"
+                },
+                "churn_rate": {
+                    "description": "ChurnRate is 100 - AverageRetention",
+                    "type": "number"
+                },
+                "cohort_start_date": {
+                    "description": "CohortStartDate is the first day of the cohort week",
+                    "type": "string"
+                },
+                "cohort_week": {
+                    "description": "CohortWeek is the ISO week when this cohort first appeared (YYYY-Www format)",
+                    "type": "string"
+                },
+                "initial_users": {
+                    "description": "InitialUsers is the count of unique users who first played in this week",
+                    "type": "integer"
+                },
+                "retention": {
+                    "description": "Retention is a map of week offsets (0, 1, 2, ...) to retention data\nWeek 0 is always 100% (the cohort definition week)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WeekRetention"
+                    }
+                }
+            }
+        },
+        "models.CohortQueryMetadata": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "Cached indicates if this result was served from cache",
+                    "type": "boolean"
+                },
+                "cohort_granularity": {
+                    "description": "CohortGranularity is the cohort grouping period (\"week\" or \"month\")",
+                    "type": "string"
+                },
+                "data_range_end": {
+                    "description": "DataRangeEnd is the latest data point used",
+                    "type": "string"
+                },
+                "data_range_start": {
+                    "description": "DataRangeStart is the earliest data point used",
+                    "type": "string"
+                },
+                "event_count": {
+                    "description": "EventCount is the total number of playback events analyzed",
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "description": "GeneratedAt is when this analysis was generated",
+                    "type": "string"
+                },
+                "max_weeks_tracked": {
+                    "description": "MaxWeeksTracked is the maximum number of weeks tracked per cohort",
+                    "type": "integer"
+                },
+                "query_hash": {
+                    "description": "QueryHash is a deterministic hash of the query parameters for reproducibility",
+                    "type": "string"
+                },
+                "query_time_ms": {
+                    "description": "QueryTimeMs is how long the query took to execute",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CohortRetentionAnalytics": {
+            "type": "object",
+            "properties": {
+                "cohorts": {
+                    "description": "Cohorts contains all cohort data with retention percentages",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CohortData"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata provides query provenance information",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CohortQueryMetadata"
+                        }
+                    ]
+                },
+                "retention_curve": {
+                    "description": "RetentionCurve provides week-by-week average retention for visualization",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RetentionPoint"
+                    }
+                },
+                "summary": {
+                    "description": "Summary provides aggregate retention statistics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CohortRetentionSummary"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.CohortRetentionSummary": {
+            "type": "object",
+            "properties": {
+                "best_performing_cohort": {
+                    "description": "BestPerformingCohort is the cohort week with highest average retention",
+                    "type": "string"
+                },
+                "median_retention_week1": {
+                    "description": "MedianRetentionWeek1 is the median retention at week 1 (more robust than mean)",
+                    "type": "number"
+                },
+                "overall_average_retention": {
+                    "description": "OverallAverageRetention is the average retention rate across all cohorts and weeks",
+                    "type": "number"
+                },
+                "retention_trend": {
+                    "description": "RetentionTrend indicates if retention is \"improving\", \"declining\", or \"stable\"",
+                    "type": "string"
+                },
+                "total_cohorts": {
+                    "description": "TotalCohorts is the number of weekly cohorts analyzed",
+                    "type": "integer"
+                },
+                "total_users_tracked": {
+                    "description": "TotalUsersTracked is the sum of all initial cohort users",
+                    "type": "integer"
+                },
+                "week12_retention": {
+                    "description": "Week12Retention is the average retention rate at week 12 (3 months) across all cohorts",
+                    "type": "number"
+                },
+                "week1_retention": {
+                    "description": "Week1Retention is the average retention rate at week 1 across all cohorts",
+                    "type": "number"
+                },
+                "week4_retention": {
+                    "description": "Week4Retention is the average retention rate at week 4 across all cohorts",
+                    "type": "number"
+                },
+                "worst_performing_cohort": {
+                    "description": "WorstPerformingCohort is the cohort week with lowest average retention",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ComparativeAnalytics": {
+            "type": "object",
+            "properties": {
+                "comparison_type": {
+                    "description": "\"week\", \"month\", \"quarter\", \"year\", \"custom\"",
+                    "type": "string"
+                },
+                "current_period": {
+                    "$ref": "#/definitions/models.PeriodMetrics"
+                },
+                "key_insights": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "metrics_comparison": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComparativeMetrics"
+                    }
+                },
+                "overall_trend": {
+                    "description": "\"growing\", \"declining\", \"stable\"",
+                    "type": "string"
+                },
+                "previous_period": {
+                    "$ref": "#/definitions/models.PeriodMetrics"
+                },
+                "top_content_comparison": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TopContentComparison"
+                    }
+                },
+                "top_user_comparison": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TopUserComparison"
+                    }
+                }
+            }
+        },
+        "models.ComparativeMetrics": {
+            "type": "object",
+            "properties": {
+                "absolute_change": {
+                    "type": "number"
+                },
+                "current_value": {
+                    "type": "number"
+                },
+                "current_value_formatted": {
+                    "type": "string"
+                },
+                "growth_direction": {
+                    "description": "\"up\", \"down\", \"stable\"",
+                    "type": "string"
+                },
+                "is_improvement": {
+                    "type": "boolean"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "percentage_change": {
+                    "type": "number"
+                },
+                "previous_value": {
+                    "type": "number"
+                },
+                "previous_value_formatted": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompletionBucket": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "bucket": {
+                    "type": "string"
+                },
+                "max_percent": {
+                    "type": "integer"
+                },
+                "min_percent": {
+                    "type": "integer"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ConcurrentStreamsAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_concurrent": {
+                    "type": "number"
+                },
+                "by_day_of_week": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConcurrentStreamsByDayOfWeek"
+                    }
+                },
+                "by_hour_of_day": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConcurrentStreamsByHour"
+                    }
+                },
+                "by_transcode_decision": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConcurrentStreamsByType"
+                    }
+                },
+                "capacity_recommendation": {
+                    "type": "string"
+                },
+                "peak_concurrent": {
+                    "type": "integer"
+                },
+                "peak_time": {
+                    "type": "string"
+                },
+                "time_series_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConcurrentStreamsTimeBucket"
+                    }
+                },
+                "total_sessions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ConcurrentStreamsByDayOfWeek": {
+            "type": "object",
+            "properties": {
+                "avg_concurrent": {
+                    "type": "number"
+                },
+                "day_of_week": {
+                    "description": "0=Sunday, 6=Saturday",
+                    "type": "integer"
+                },
+                "peak_concurrent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ConcurrentStreamsByHour": {
+            "type": "object",
+            "properties": {
+                "avg_concurrent": {
+                    "type": "number"
+                },
+                "hour": {
+                    "description": "0-23",
+                    "type": "integer"
+                },
+                "peak_concurrent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ConcurrentStreamsByType": {
+            "type": "object",
+            "properties": {
+                "avg_concurrent": {
+                    "type": "number"
+                },
+                "max_concurrent": {
+                    "type": "integer"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "transcode_decision": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ConcurrentStreamsTimeBucket": {
+            "type": "object",
+            "properties": {
+                "concurrent_count": {
+                    "type": "integer"
+                },
+                "direct_play": {
+                    "type": "integer"
+                },
+                "direct_stream": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "transcode": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ConnectionLocalStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ConnectionRelayStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ConnectionSecurityAnalytics": {
+            "type": "object",
+            "properties": {
+                "by_platform": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlatformConnectionStats"
+                    }
+                },
+                "by_user": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserConnectionStats"
+                    }
+                },
+                "insecure_connections": {
+                    "type": "integer"
+                },
+                "local_connections": {
+                    "$ref": "#/definitions/models.ConnectionLocalStats"
+                },
+                "relayed_connections": {
+                    "$ref": "#/definitions/models.ConnectionRelayStats"
+                },
+                "secure_connections": {
+                    "type": "integer"
+                },
+                "secure_percent": {
+                    "type": "number"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContainerAnalytics": {
+            "type": "object",
+            "properties": {
+                "direct_play_rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "format_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerDistribution"
+                    }
+                },
+                "platform_compatibility": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlatformContainer"
+                    }
+                },
+                "remux_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerRemux"
+                    }
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContainerDistribution": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string"
+                },
+                "direct_play_rate_percent": {
+                    "type": "number"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContainerRemux": {
+            "type": "object",
+            "properties": {
+                "occurrence_count": {
+                    "type": "integer"
+                },
+                "platforms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_container": {
+                    "type": "string"
+                },
+                "stream_container": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ContentAbandonmentAnalytics": {
+            "type": "object",
+            "properties": {
+                "abandonment_by_genre": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GenreAbandonment"
+                    }
+                },
+                "completion_by_media_type": {
+                    "description": "Movie vs TV vs Music",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MediaTypeCompletion"
+                    }
+                },
+                "drop_off_distribution": {
+                    "description": "0-25%, 25-50%, 50-75%, 75-100%",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DropOffBucket"
+                    }
+                },
+                "first_episode_abandonment": {
+                    "description": "Shows abandoned after pilot",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FirstEpisodeDropOff"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/models.ContentAbandonmentSummary"
+                },
+                "top_abandoned": {
+                    "description": "Highest abandonment rates",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AbandonedContent"
+                    }
+                }
+            }
+        },
+        "models.ContentAbandonmentSummary": {
+            "type": "object",
+            "properties": {
+                "abandoned_playbacks": {
+                    "description": "\u003c 90% completion",
+                    "type": "integer"
+                },
+                "average_completion": {
+                    "description": "Mean percent_complete",
+                    "type": "number"
+                },
+                "completed_playbacks": {
+                    "description": "\u003e= 90% completion",
+                    "type": "integer"
+                },
+                "completion_rate": {
+                    "description": "% that reached 90%+",
+                    "type": "number"
+                },
+                "median_drop_off_point": {
+                    "description": "Median abandonment point",
+                    "type": "number"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentCompletionStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CompletionBucket"
+                    }
+                },
+                "fully_watched": {
+                    "type": "integer"
+                },
+                "fully_watched_pct": {
+                    "type": "number"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentDiscoveryAnalytics": {
+            "type": "object",
+            "properties": {
+                "early_adopters": {
+                    "description": "EarlyAdopters contains users who discover content quickly",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EarlyAdopter"
+                    }
+                },
+                "library_stats": {
+                    "description": "LibraryStats contains per-library discovery statistics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LibraryDiscoveryStats"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata contains query execution information for observability",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ContentDiscoveryMetadata"
+                        }
+                    ]
+                },
+                "recently_discovered": {
+                    "description": "RecentlyDiscovered contains recently watched new content",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContentDiscoveryItem"
+                    }
+                },
+                "stale_content": {
+                    "description": "StaleContent contains content added but never watched",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StaleContent"
+                    }
+                },
+                "summary": {
+                    "description": "Summary contains aggregate discovery statistics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ContentDiscoverySummary"
+                        }
+                    ]
+                },
+                "time_buckets": {
+                    "description": "TimeBuckets contains discovery distribution by time to first watch",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DiscoveryTimeBucket"
+                    }
+                },
+                "trends": {
+                    "description": "Trends contains discovery patterns over time",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DiscoveryTrend"
+                    }
+                }
+            }
+        },
+        "models.ContentDiscoveryItem": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "description": "AddedAt is when the content was added to the library",
+                    "type": "string"
+                },
+                "avg_completion": {
+                    "description": "AvgCompletion is the average completion rate",
+                    "type": "number"
+                },
+                "discovery_velocity": {
+                    "description": "DiscoveryVelocity indicates how quickly the content was discovered (fast/medium/slow/not_discovered)",
+                    "type": "string"
+                },
+                "first_watched_at": {
+                    "description": "FirstWatchedAt is when the content was first watched (nil if never watched)",
+                    "type": "string"
+                },
+                "genres": {
+                    "description": "Genres are the content genres",
+                    "type": "string"
+                },
+                "library_name": {
+                    "description": "LibraryName is the library containing this content",
+                    "type": "string"
+                },
+                "media_type": {
+                    "description": "MediaType is the type of content (movie, episode, track)",
+                    "type": "string"
+                },
+                "rating_key": {
+                    "description": "RatingKey is the unique identifier for the content",
+                    "type": "string"
+                },
+                "time_to_first_watch_hours": {
+                    "description": "TimeToFirstWatchHours is the hours between added and first watch (nil if never watched)",
+                    "type": "number"
+                },
+                "title": {
+                    "description": "Title is the content title",
+                    "type": "string"
+                },
+                "total_playbacks": {
+                    "description": "TotalPlaybacks is the number of times this content has been played",
+                    "type": "integer"
+                },
+                "unique_viewers": {
+                    "description": "UniqueViewers is the number of unique users who watched this content",
+                    "type": "integer"
+                },
+                "year": {
+                    "description": "Year is the release year of the content",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentDiscoveryMetadata": {
+            "type": "object",
+            "properties": {
+                "data_range_end": {
+                    "description": "DataRangeEnd is the end of the analyzed date range",
+                    "type": "string"
+                },
+                "data_range_start": {
+                    "description": "DataRangeStart is the start of the analyzed date range",
+                    "type": "string"
+                },
+                "early_discovery_threshold_hours": {
+                    "description": "EarlyDiscoveryThresholdHours is the hours threshold for \"early\" discovery",
+                    "type": "integer"
+                },
+                "execution_time_ms": {
+                    "description": "ExecutionTimeMS is the query execution time in milliseconds",
+                    "type": "integer"
+                },
+                "query_hash": {
+                    "description": "QueryHash is a deterministic hash of the query parameters for caching",
+                    "type": "string"
+                },
+                "stale_content_threshold_days": {
+                    "description": "StaleContentThresholdDays is the days threshold for \"stale\" content",
+                    "type": "integer"
+                },
+                "total_events_analyzed": {
+                    "description": "TotalEventsAnalyzed is the number of playback events analyzed",
+                    "type": "integer"
+                },
+                "unique_content_analyzed": {
+                    "description": "UniqueContentAnalyzed is the number of unique content items analyzed",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentDiscoverySummary": {
+            "type": "object",
+            "properties": {
+                "avg_time_to_discovery_hours": {
+                    "description": "AvgTimeToDiscoveryHours is the overall average time to first watch",
+                    "type": "number"
+                },
+                "early_discovery_rate": {
+                    "description": "EarlyDiscoveryRate is percentage discovered within 24 hours of addition",
+                    "type": "number"
+                },
+                "fastest_discovery_hours": {
+                    "description": "FastestDiscoveryHours is the quickest time to first watch",
+                    "type": "number"
+                },
+                "median_time_to_discovery_hours": {
+                    "description": "MedianTimeToDiscoveryHours is the overall median time to first watch",
+                    "type": "number"
+                },
+                "overall_discovery_rate": {
+                    "description": "OverallDiscoveryRate is the percentage of content that has been watched",
+                    "type": "number"
+                },
+                "recent_additions_count": {
+                    "description": "RecentAdditionsCount is content added in last 30 days",
+                    "type": "integer"
+                },
+                "recent_discovered_count": {
+                    "description": "RecentDiscoveredCount is recent additions that have been watched",
+                    "type": "integer"
+                },
+                "slowest_discovery_days": {
+                    "description": "SlowestDiscoveryDays is the longest time to first watch (in days)",
+                    "type": "integer"
+                },
+                "total_content_with_added_at": {
+                    "description": "TotalContentWithAddedAt is content items with valid added_at data",
+                    "type": "integer"
+                },
+                "total_discovered": {
+                    "description": "TotalDiscovered is content items that have been watched at least once",
+                    "type": "integer"
+                },
+                "total_never_watched": {
+                    "description": "TotalNeverWatched is content items never watched",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentFlowAnalytics": {
+            "type": "object",
+            "properties": {
+                "dropOffRate": {
+                    "description": "% who start but don't finish",
+                    "type": "number"
+                },
+                "journeys": {
+                    "description": "Top viewing paths",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContentFlowJourney"
+                    }
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SankeyLink"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SankeyNode"
+                    }
+                },
+                "totalFlows": {
+                    "description": "Total viewing journeys",
+                    "type": "integer"
+                },
+                "totalShows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentFlowJourney": {
+            "type": "object",
+            "properties": {
+                "avgCompletion": {
+                    "description": "0-100",
+                    "type": "number"
+                },
+                "episodeNumber": {
+                    "type": "integer"
+                },
+                "episodeTitle": {
+                    "type": "string"
+                },
+                "seasonNumber": {
+                    "type": "integer"
+                },
+                "showTitle": {
+                    "type": "string"
+                },
+                "watchCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentFormatStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "dynamic_range": {
+                    "type": "string"
+                },
+                "most_watched_title": {
+                    "type": "string"
+                },
+                "unique_content": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContentMover": {
+            "type": "object",
+            "properties": {
+                "contentId": {
+                    "type": "string"
+                },
+                "contentTitle": {
+                    "type": "string"
+                },
+                "endRank": {
+                    "type": "integer"
+                },
+                "rankChange": {
+                    "description": "Positive = improved",
+                    "type": "integer"
+                },
+                "startRank": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CountryStats": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateMediaServerRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "platform",
+                "token",
+                "url"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "plex",
+                        "jellyfin",
+                        "emby",
+                        "tautulli"
+                    ]
+                },
+                "realtime_enabled": {
+                    "type": "boolean"
+                },
+                "session_polling_enabled": {
+                    "type": "boolean"
+                },
+                "session_polling_interval": {
+                    "type": "string"
+                },
+                "settings": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "token": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhooks_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.DailyQualityTrend": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Date for this data point",
+                    "type": "string"
+                },
+                "event_count": {
+                    "description": "EventCount for this day",
+                    "type": "integer"
+                },
+                "invalid_rate": {
+                    "description": "InvalidRate for this day",
+                    "type": "number"
+                },
+                "new_issues": {
+                    "description": "NewIssues detected on this day",
+                    "type": "integer"
+                },
+                "null_rate": {
+                    "description": "NullRate for this day",
+                    "type": "number"
+                },
+                "overall_score": {
+                    "description": "OverallScore for this day",
+                    "type": "number"
+                }
+            }
+        },
+        "models.DataQualityIssue": {
+            "type": "object",
+            "properties": {
+                "affected_records": {
+                    "description": "AffectedRecords is the count of records with this issue",
+                    "type": "integer"
+                },
+                "auto_resolvable": {
+                    "description": "AutoResolvable indicates if the system can auto-fix this issue",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "Description provides details about the issue",
+                    "type": "string"
+                },
+                "example_values": {
+                    "description": "ExampleValues provides sample problematic values (for debugging)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "field": {
+                    "description": "Field is the affected field name (if applicable)",
+                    "type": "string"
+                },
+                "first_detected": {
+                    "description": "FirstDetected is when this issue was first seen",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is a unique identifier for this issue",
+                    "type": "string"
+                },
+                "impact_percentage": {
+                    "description": "ImpactPercentage is AffectedRecords / TotalRecords * 100",
+                    "type": "number"
+                },
+                "last_seen": {
+                    "description": "LastSeen is when this issue was last observed",
+                    "type": "string"
+                },
+                "recommendation": {
+                    "description": "Recommendation provides guidance for resolution",
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "Severity is \"critical\", \"warning\", or \"info\"",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title is a human-readable issue summary",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type categorizes the issue\nTypes: \"null_required\", \"invalid_value\", \"future_date\", \"duplicate\",\n       \"orphaned_geo\", \"inconsistent\", \"outlier\", \"missing_relation\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DataQualityMetadata": {
+            "type": "object",
+            "properties": {
+                "analyzed_tables": {
+                    "description": "AnalyzedTables lists tables that were checked",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cached": {
+                    "description": "Cached indicates if from cache",
+                    "type": "boolean"
+                },
+                "data_range_end": {
+                    "description": "DataRangeEnd is the latest data analyzed",
+                    "type": "string"
+                },
+                "data_range_start": {
+                    "description": "DataRangeStart is the earliest data analyzed",
+                    "type": "string"
+                },
+                "generated_at": {
+                    "description": "GeneratedAt is when this report was generated",
+                    "type": "string"
+                },
+                "query_hash": {
+                    "description": "QueryHash for reproducibility",
+                    "type": "string"
+                },
+                "query_time_ms": {
+                    "description": "QueryTimeMs is execution time",
+                    "type": "integer"
+                },
+                "rules_applied": {
+                    "description": "RulesApplied lists quality rules that were checked",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.DataQualityReport": {
+            "type": "object",
+            "properties": {
+                "daily_trends": {
+                    "description": "DailyTrends shows data quality trends over time",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DailyQualityTrend"
+                    }
+                },
+                "field_quality": {
+                    "description": "FieldQuality provides per-field completeness and validity metrics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldQualityMetric"
+                    }
+                },
+                "issues": {
+                    "description": "Issues lists specific data quality problems detected",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DataQualityIssue"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata provides query provenance",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DataQualityMetadata"
+                        }
+                    ]
+                },
+                "source_breakdown": {
+                    "description": "SourceBreakdown shows quality by data source",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SourceQuality"
+                    }
+                },
+                "summary": {
+                    "description": "Summary provides overall data quality health",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DataQualitySummary"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.DataQualitySummary": {
+            "type": "object",
+            "properties": {
+                "completeness_score": {
+                    "description": "CompletenessScore measures presence of required fields (0-100)",
+                    "type": "number"
+                },
+                "consistency_score": {
+                    "description": "ConsistencyScore measures data consistency across records (0-100)",
+                    "type": "number"
+                },
+                "critical_issue_count": {
+                    "description": "CriticalIssueCount is issues with severity \"critical\"",
+                    "type": "integer"
+                },
+                "duplicate_rate": {
+                    "description": "DuplicateRate is the percentage of potential duplicate records",
+                    "type": "number"
+                },
+                "future_date_rate": {
+                    "description": "FutureDateRate is the percentage of events with future timestamps",
+                    "type": "number"
+                },
+                "grade": {
+                    "description": "Grade is a letter grade (A, B, C, D, F) based on OverallScore",
+                    "type": "string"
+                },
+                "invalid_value_rate": {
+                    "description": "InvalidValueRate is the percentage of fields with invalid values",
+                    "type": "number"
+                },
+                "issue_count": {
+                    "description": "IssueCount is the total number of issues detected",
+                    "type": "integer"
+                },
+                "null_field_rate": {
+                    "description": "NullFieldRate is the percentage of null/empty critical fields",
+                    "type": "number"
+                },
+                "orphaned_geo_rate": {
+                    "description": "OrphanedGeoRate is the percentage of events with missing geolocation",
+                    "type": "number"
+                },
+                "overall_score": {
+                    "description": "OverallScore is a composite quality score (0-100)\nFormula: Weighted average of completeness, validity, and consistency",
+                    "type": "number"
+                },
+                "total_events": {
+                    "description": "TotalEvents is the count of events analyzed",
+                    "type": "integer"
+                },
+                "trend_direction": {
+                    "description": "TrendDirection indicates if quality is \"improving\", \"declining\", or \"stable\"",
+                    "type": "string"
+                },
+                "validity_score": {
+                    "description": "ValidityScore measures correctness of field values (0-100)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.DeviceMigration": {
+            "type": "object",
+            "properties": {
+                "from_platform": {
+                    "description": "FromPlatform is the platform the user was previously using",
+                    "type": "string"
+                },
+                "is_permanent_switch": {
+                    "description": "IsPermanentSwitch indicates if the user abandoned the previous platform",
+                    "type": "boolean"
+                },
+                "migration_date": {
+                    "description": "MigrationDate is when the switch was first detected",
+                    "type": "string"
+                },
+                "sessions_after_migration": {
+                    "description": "SessionsAfterMigration is how many sessions the user has had on the new platform",
+                    "type": "integer"
+                },
+                "sessions_before_migration": {
+                    "description": "SessionsBeforeMigration is how many sessions the user had on the previous platform",
+                    "type": "integer"
+                },
+                "to_platform": {
+                    "description": "ToPlatform is the platform the user switched to",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserID is the internal identifier for the user",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Username is the display name of the user",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeviceMigrationAnalytics": {
+            "type": "object",
+            "properties": {
+                "adoption_trends": {
+                    "description": "AdoptionTrends contains platform adoption trends over time",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlatformAdoptionTrend"
+                    }
+                },
+                "common_transitions": {
+                    "description": "CommonTransitions contains the most common platform transition paths",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlatformTransition"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata contains query execution information for observability",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceMigrationMetadata"
+                        }
+                    ]
+                },
+                "platform_distribution": {
+                    "description": "PlatformDistribution contains current platform usage distribution",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlatformStats"
+                    }
+                },
+                "recent_migrations": {
+                    "description": "RecentMigrations contains the most recent platform migration events",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeviceMigration"
+                    }
+                },
+                "summary": {
+                    "description": "Summary contains aggregate device migration statistics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceMigrationSummary"
+                        }
+                    ]
+                },
+                "top_user_profiles": {
+                    "description": "TopUserProfiles contains detailed profiles for users with notable migration patterns",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserDeviceProfile"
+                    }
+                }
+            }
+        },
+        "models.DeviceMigrationMetadata": {
+            "type": "object",
+            "properties": {
+                "data_range_end": {
+                    "description": "DataRangeEnd is the end of the analyzed date range",
+                    "type": "string"
+                },
+                "data_range_start": {
+                    "description": "DataRangeStart is the start of the analyzed date range",
+                    "type": "string"
+                },
+                "execution_time_ms": {
+                    "description": "ExecutionTimeMS is the query execution time in milliseconds",
+                    "type": "integer"
+                },
+                "migration_window_days": {
+                    "description": "MigrationWindowDays is the threshold used for detecting migrations",
+                    "type": "integer"
+                },
+                "query_hash": {
+                    "description": "QueryHash is a deterministic hash of the query parameters for caching",
+                    "type": "string"
+                },
+                "total_events_analyzed": {
+                    "description": "TotalEventsAnalyzed is the number of playback events analyzed",
+                    "type": "integer"
+                },
+                "unique_platforms_found": {
+                    "description": "UniquePlatformsFound is the number of distinct platforms in the data",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DeviceMigrationSummary": {
+            "type": "object",
+            "properties": {
+                "avg_platforms_per_user": {
+                    "description": "AvgPlatformsPerUser is the average number of platforms per user",
+                    "type": "number"
+                },
+                "declining_platforms": {
+                    "description": "DecliningSplatforms are platforms with decreasing usage",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fastest_growing_platform": {
+                    "description": "FastestGrowingPlatform is the platform with highest recent adoption rate",
+                    "type": "string"
+                },
+                "most_common_primary_platform": {
+                    "description": "MostCommonPrimaryPlatform is the platform most often used as primary",
+                    "type": "string"
+                },
+                "multi_device_percentage": {
+                    "description": "MultiDevicePercentage is the percentage of users using multiple platforms",
+                    "type": "number"
+                },
+                "multi_device_users": {
+                    "description": "MultiDeviceUsers is the count of users using 2+ platforms",
+                    "type": "integer"
+                },
+                "total_migrations": {
+                    "description": "TotalMigrations is the total number of platform switches detected",
+                    "type": "integer"
+                },
+                "total_users": {
+                    "description": "TotalUsers is the total number of users analyzed",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DiscoveryTimeBucket": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "description": "Bucket is the time bucket label (e.g., \"0-24h\", \"1-7d\", \"7-30d\", \"30d+\")",
+                    "type": "string"
+                },
+                "bucket_max_hours": {
+                    "description": "BucketMaxHours is the maximum hours in this bucket",
+                    "type": "integer"
+                },
+                "bucket_min_hours": {
+                    "description": "BucketMinHours is the minimum hours in this bucket",
+                    "type": "integer"
+                },
+                "content_count": {
+                    "description": "ContentCount is the number of content items discovered in this time window",
+                    "type": "integer"
+                },
+                "percentage": {
+                    "description": "Percentage is this bucket's share of all discovered content",
+                    "type": "number"
+                }
+            }
+        },
+        "models.DiscoveryTrend": {
+            "type": "object",
+            "properties": {
+                "avg_time_to_discovery_hours": {
+                    "description": "AvgTimeToDiscoveryHours is the average discovery time for this period",
+                    "type": "number"
+                },
+                "content_added": {
+                    "description": "ContentAdded is the number of content items added in this period",
+                    "type": "integer"
+                },
+                "content_discovered": {
+                    "description": "ContentDiscovered is the number of newly added items watched for the first time",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "Date is the date bucket for this trend",
+                    "type": "string"
+                },
+                "discovery_rate": {
+                    "description": "DiscoveryRate is the percentage of new content watched in this period",
+                    "type": "number"
+                }
+            }
+        },
+        "models.DropOffBucket": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "description": "\"0-25%\", \"25-50%\", \"50-75%\", \"75-90%\", \"90-100%\"",
+                    "type": "string"
+                },
+                "max_percent": {
+                    "type": "integer"
+                },
+                "min_percent": {
+                    "type": "integer"
+                },
+                "percentage_of_total": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DurationByMediaType": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "avg_duration_minutes": {
+                    "type": "integer"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "total_duration_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DurationStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion_percent": {
+                    "type": "number"
+                },
+                "avg_duration_minutes": {
+                    "type": "integer"
+                },
+                "duration_by_media_type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DurationByMediaType"
+                    }
+                },
+                "fully_watched_count": {
+                    "type": "integer"
+                },
+                "fully_watched_percent": {
+                    "type": "number"
+                },
+                "median_duration_minutes": {
+                    "type": "integer"
+                },
+                "partially_watched_count": {
+                    "type": "integer"
+                },
+                "total_duration_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DynamicRangeDistribution": {
+            "type": "object",
+            "properties": {
+                "dynamic_range": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EarlyAdopter": {
+            "type": "object",
+            "properties": {
+                "avg_time_to_discovery_hours": {
+                    "description": "AvgTimeToDiscoveryHours is their average time from content addition to first watch",
+                    "type": "number"
+                },
+                "early_discovery_count": {
+                    "description": "EarlyDiscoveryCount is how many times they watched content within 24h of addition",
+                    "type": "integer"
+                },
+                "early_discovery_rate": {
+                    "description": "EarlyDiscoveryRate is the percentage of their watches that are early discoveries",
+                    "type": "number"
+                },
+                "favorite_library": {
+                    "description": "FavoriteLibrary is the library they discover from most",
+                    "type": "string"
+                },
+                "first_seen_at": {
+                    "description": "FirstSeenAt is when this user was first seen",
+                    "type": "string"
+                },
+                "total_discoveries": {
+                    "description": "TotalDiscoveries is total content items they've watched",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserID is the internal identifier for the user",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Username is the display name of the user",
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldQualityMetric": {
+            "type": "object",
+            "properties": {
+                "cardinality": {
+                    "description": "Cardinality is UniqueCount / NonNullCount (diversity measure)",
+                    "type": "number"
+                },
+                "category": {
+                    "description": "Category groups related fields (\"identity\", \"content\", \"quality\", \"network\", \"temporal\")",
+                    "type": "string"
+                },
+                "field_name": {
+                    "description": "FieldName is the database column name",
+                    "type": "string"
+                },
+                "invalid_count": {
+                    "description": "InvalidCount is records with invalid values",
+                    "type": "integer"
+                },
+                "invalid_rate": {
+                    "description": "InvalidRate is InvalidCount / TotalRecords * 100",
+                    "type": "number"
+                },
+                "is_required": {
+                    "description": "IsRequired indicates if this field should never be null",
+                    "type": "boolean"
+                },
+                "null_count": {
+                    "description": "NullCount is records with null/empty values",
+                    "type": "integer"
+                },
+                "null_rate": {
+                    "description": "NullRate is NullCount / TotalRecords * 100",
+                    "type": "number"
+                },
+                "quality_score": {
+                    "description": "QualityScore is overall field quality (0-100)",
+                    "type": "number"
+                },
+                "status": {
+                    "description": "Status is \"healthy\", \"warning\", or \"critical\" based on quality",
+                    "type": "string"
+                },
+                "total_records": {
+                    "description": "TotalRecords analyzed for this field",
+                    "type": "integer"
+                },
+                "unique_count": {
+                    "description": "UniqueCount is distinct non-null values",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FirstEpisodeDropOff": {
+            "type": "object",
+            "properties": {
+                "drop_off_rate": {
+                    "description": "% who didn't continue",
+                    "type": "number"
+                },
+                "first_episode_title": {
+                    "type": "string"
+                },
+                "pilot_completions": {
+                    "type": "integer"
+                },
+                "pilot_starts": {
+                    "type": "integer"
+                },
+                "series_continuations": {
+                    "description": "Users who watched episode 2+",
+                    "type": "integer"
+                },
+                "show_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FrameRateAnalytics": {
+            "type": "object",
+            "properties": {
+                "by_media_type": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.FrameRateDistribution"
+                        }
+                    }
+                },
+                "conversion_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FrameRateConversion"
+                    }
+                },
+                "frame_rate_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FrameRateDistribution"
+                    }
+                },
+                "high_frame_rate_adoption_percent": {
+                    "description": "60fps+",
+                    "type": "number"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FrameRateConversion": {
+            "type": "object",
+            "properties": {
+                "occurrence_count": {
+                    "type": "integer"
+                },
+                "platforms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_fps": {
+                    "type": "string"
+                },
+                "stream_fps": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FrameRateDistribution": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "frame_rate": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GenreAbandonment": {
+            "type": "object",
+            "properties": {
+                "average_completion": {
+                    "type": "number"
+                },
+                "completed_playbacks": {
+                    "type": "integer"
+                },
+                "completion_rate": {
+                    "type": "number"
+                },
+                "genre": {
+                    "type": "string"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GeographicResponse": {
+            "type": "object",
+            "properties": {
+                "codec_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CodecStats"
+                    }
+                },
+                "content_completion_stats": {
+                    "$ref": "#/definitions/models.ContentCompletionStats"
+                },
+                "duration_stats": {
+                    "$ref": "#/definitions/models.DurationStats"
+                },
+                "library_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LibraryStats"
+                    }
+                },
+                "media_type_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MediaTypeStats"
+                    }
+                },
+                "platform_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlatformStats"
+                    }
+                },
+                "player_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerStats"
+                    }
+                },
+                "rating_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingStats"
+                    }
+                },
+                "resolution_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResolutionStats"
+                    }
+                },
+                "top_cities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CityStats"
+                    }
+                },
+                "top_countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountryStats"
+                    }
+                },
+                "transcode_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TranscodeStats"
+                    }
+                },
+                "viewing_hours_heatmap": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ViewingHoursHeatmap"
+                    }
+                },
+                "year_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.YearStats"
+                    }
+                }
+            }
+        },
+        "models.H3HexagonStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "description": "Average completion percentage",
+                    "type": "number"
+                },
+                "h3_index": {
+                    "description": "H3 hexagon index",
+                    "type": "integer"
+                },
+                "latitude": {
+                    "description": "Hexagon center latitude",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Hexagon center longitude",
+                    "type": "number"
+                },
+                "playback_count": {
+                    "description": "Total playbacks in hexagon",
+                    "type": "integer"
+                },
+                "total_watch_minutes": {
+                    "description": "Total watch time in minutes",
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "description": "Unique users in hexagon",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HDRAnalytics": {
+            "type": "object",
+            "properties": {
+                "content_by_format": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContentFormatStats"
+                    }
+                },
+                "format_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DynamicRangeDistribution"
+                    }
+                },
+                "hdr_adoption_rate_percent": {
+                    "type": "number"
+                },
+                "hdr_capable_devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HDRDeviceStats"
+                    }
+                },
+                "tone_mapping_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ToneMappingEvent"
+                    }
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HDRDeviceStats": {
+            "type": "object",
+            "properties": {
+                "hdr_capable": {
+                    "type": "boolean"
+                },
+                "hdr_playbacks": {
+                    "type": "integer"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "sdr_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HealthStatus": {
+            "type": "object",
+            "properties": {
+                "database_connected": {
+                    "type": "boolean"
+                },
+                "last_sync_time": {
+                    "type": "string"
+                },
+                "mode": {
+                    "description": "\"standalone\" (no Tautulli) or \"tautulli\" (with Tautulli)",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tautulli_connected": {
+                    "type": "boolean"
+                },
+                "uptime_seconds": {
+                    "type": "number"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HighPauseContent": {
+            "type": "object",
+            "properties": {
+                "average_pauses": {
+                    "type": "number"
+                },
+                "completion_rate": {
+                    "type": "number"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "potential_quality_issue": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LibraryAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "content_health": {
+                    "$ref": "#/definitions/models.LibraryHealthMetrics"
+                },
+                "library_id": {
+                    "type": "integer"
+                },
+                "library_name": {
+                    "type": "string"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "most_watched_item": {
+                    "type": "string"
+                },
+                "plays_by_day": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlaybackTrend"
+                    }
+                },
+                "quality_distribution": {
+                    "$ref": "#/definitions/models.LibraryQualityStats"
+                },
+                "top_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LibraryUserStats"
+                    }
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                },
+                "total_watch_time_minutes": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                },
+                "unwatched_items": {
+                    "type": "integer"
+                },
+                "watched_items": {
+                    "type": "integer"
+                },
+                "watched_percentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.LibraryDiscoveryStats": {
+            "type": "object",
+            "properties": {
+                "avg_time_to_discovery_hours": {
+                    "description": "AvgTimeToDiscoveryHours is the average time from add to first watch",
+                    "type": "number"
+                },
+                "discovery_rate": {
+                    "description": "DiscoveryRate is the percentage of items watched",
+                    "type": "number"
+                },
+                "early_discovery_rate": {
+                    "description": "EarlyDiscoveryRate is percentage discovered within 24 hours",
+                    "type": "number"
+                },
+                "library_name": {
+                    "description": "LibraryName is the name of the library",
+                    "type": "string"
+                },
+                "median_time_to_discovery_hours": {
+                    "description": "MedianTimeToDiscoveryHours is the median time from add to first watch",
+                    "type": "number"
+                },
+                "total_items": {
+                    "description": "TotalItems is the total content items in the library with added_at data",
+                    "type": "integer"
+                },
+                "unwatched_items": {
+                    "description": "UnwatchedItems is the number of items never watched",
+                    "type": "integer"
+                },
+                "watched_items": {
+                    "description": "WatchedItems is the number of items that have been watched at least once",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LibraryHealthMetrics": {
+            "type": "object",
+            "properties": {
+                "engagement_score": {
+                    "description": "Completion rate",
+                    "type": "number"
+                },
+                "growth_rate_percent": {
+                    "description": "Change vs previous period",
+                    "type": "number"
+                },
+                "popularity_score": {
+                    "description": "Plays per item",
+                    "type": "number"
+                },
+                "stale_content_count": {
+                    "description": "Not watched in 90 days",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LibraryQualityStats": {
+            "type": "object",
+            "properties": {
+                "4k_content_count": {
+                    "type": "integer"
+                },
+                "avg_bitrate_kbps": {
+                    "type": "number"
+                },
+                "hdr_content_count": {
+                    "type": "integer"
+                },
+                "surround_sound_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LibraryStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "library_name": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "section_id": {
+                    "type": "integer"
+                },
+                "total_duration_minutes": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LibraryUserStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "plays": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "watch_time_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LibraryUtilizationAnalytics": {
+            "type": "object",
+            "properties": {
+                "mostPopularPath": {
+                    "description": "Most-watched hierarchy path",
+                    "type": "string"
+                },
+                "root": {
+                    "description": "Hierarchical data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TreemapNode"
+                        }
+                    ]
+                },
+                "topUnwatched": {
+                    "description": "Content with zero plays",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "totalContent": {
+                    "description": "Total content items",
+                    "type": "integer"
+                },
+                "totalWatchTime": {
+                    "description": "Total watch time seconds",
+                    "type": "integer"
+                },
+                "utilizationRate": {
+                    "description": "% of content watched",
+                    "type": "number"
+                },
+                "utilizedContent": {
+                    "description": "Content with at least 1 play",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LocationStats": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "first_seen": {
+                    "type": "string"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MediaServerListResponse": {
+            "type": "object",
+            "properties": {
+                "connected_count": {
+                    "type": "integer"
+                },
+                "error_count": {
+                    "type": "integer"
+                },
+                "last_checked": {
+                    "type": "string"
+                },
+                "servers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MediaServerStatus"
+                    }
+                },
+                "syncing_count": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MediaServerResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "immutable": {
+                    "description": "true for env-var servers",
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_error_at": {
+                    "type": "string"
+                },
+                "last_sync_at": {
+                    "type": "string"
+                },
+                "last_sync_status": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "realtime_enabled": {
+                    "type": "boolean"
+                },
+                "server_id": {
+                    "type": "string"
+                },
+                "session_polling_enabled": {
+                    "type": "boolean"
+                },
+                "session_polling_interval": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "connected, syncing, error, disabled",
+                    "type": "string"
+                },
+                "token_masked": {
+                    "description": "\"****...last4\"",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Decrypted and shown",
+                    "type": "string"
+                },
+                "webhooks_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.MediaServerStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "immutable": {
+                    "description": "true for env-var servers (cannot be edited in UI)",
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_error_at": {
+                    "type": "string"
+                },
+                "last_sync_at": {
+                    "type": "string"
+                },
+                "last_sync_status": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "platform": {
+                    "description": "plex, jellyfin, emby, tautulli",
+                    "type": "string"
+                },
+                "realtime_enabled": {
+                    "type": "boolean"
+                },
+                "server_version": {
+                    "type": "string"
+                },
+                "session_polling_enabled": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "description": "Always \"env\" for now",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "connected, disconnected, syncing, error, disabled",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Masked for display",
+                    "type": "string"
+                },
+                "webhooks_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.MediaServerTestRequest": {
+            "type": "object",
+            "required": [
+                "platform",
+                "token",
+                "url"
+            ],
+            "properties": {
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "plex",
+                        "jellyfin",
+                        "emby",
+                        "tautulli"
+                    ]
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MediaServerTestResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "server_name": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MediaTypeCompletion": {
+            "type": "object",
+            "properties": {
+                "average_completion": {
+                    "type": "number"
+                },
+                "completed_playbacks": {
+                    "type": "integer"
+                },
+                "completion_rate": {
+                    "type": "number"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MediaTypeStats": {
+            "type": "object",
+            "properties": {
+                "media_type": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Metadata": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.PaginationInfo"
+                },
+                "query_time_ms": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NetworkMetadata": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "Cached indicates if from cache",
+                    "type": "boolean"
+                },
+                "clustering_algorithm": {
+                    "description": "ClusteringAlgorithm used (e.g., \"louvain\", \"label_propagation\")",
+                    "type": "string"
+                },
+                "data_range_end": {
+                    "description": "DataRangeEnd is the latest data analyzed",
+                    "type": "string"
+                },
+                "data_range_start": {
+                    "description": "DataRangeStart is the earliest data analyzed",
+                    "type": "string"
+                },
+                "event_count": {
+                    "description": "EventCount is total events analyzed",
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "description": "GeneratedAt is when this was generated",
+                    "type": "string"
+                },
+                "min_content_overlap": {
+                    "description": "MinContentOverlap is the threshold for content similarity edges",
+                    "type": "number"
+                },
+                "min_shared_sessions": {
+                    "description": "MinSharedSessions is the threshold for creating an edge",
+                    "type": "integer"
+                },
+                "query_hash": {
+                    "description": "QueryHash for reproducibility",
+                    "type": "string"
+                },
+                "query_time_ms": {
+                    "description": "QueryTimeMs is execution time",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.NetworkSummary": {
+            "type": "object",
+            "properties": {
+                "avg_connections_per_user": {
+                    "description": "AvgConnectionsPerUser is mean degree centrality",
+                    "type": "number"
+                },
+                "isolated_users": {
+                    "description": "IsolatedUsers is the count of users with no connections",
+                    "type": "integer"
+                },
+                "largest_cluster_size": {
+                    "description": "LargestClusterSize is the user count in the biggest cluster",
+                    "type": "integer"
+                },
+                "max_connections_count": {
+                    "description": "MaxConnectionsCount is their connection count",
+                    "type": "integer"
+                },
+                "max_connections_user": {
+                    "description": "MaxConnectionsUser is the most connected user",
+                    "type": "string"
+                },
+                "network_density": {
+                    "description": "NetworkDensity is total edges / possible edges\nDensity close to 1 means highly connected, close to 0 means sparse",
+                    "type": "number"
+                },
+                "network_type": {
+                    "description": "NetworkType describes the overall structure\nTypes: \"fragmented\" (many small clusters), \"centralized\" (hub-spoke),\n       \"distributed\" (even connections), \"hierarchical\" (nested clusters)",
+                    "type": "string"
+                },
+                "shared_session_count": {
+                    "description": "SharedSessionCount is total co-viewing events detected",
+                    "type": "integer"
+                },
+                "total_clusters": {
+                    "description": "TotalClusters is the number of detected communities",
+                    "type": "integer"
+                },
+                "total_connections": {
+                    "description": "TotalConnections is the count of relationships (edges)",
+                    "type": "integer"
+                },
+                "total_users": {
+                    "description": "TotalUsers is the count of users (nodes) in the network",
+                    "type": "integer"
+                },
+                "watch_party_count": {
+                    "description": "WatchPartyCount is detected watch party events",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.NowPlayingResponse": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "type": "string"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NowPlayingSession"
+                    }
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NowPlayingSourceStatus"
+                    }
+                },
+                "stale_count": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.NowPlayingSession": {
+            "type": "object",
+            "properties": {
+                "audio_codec": {
+                    "type": "string"
+                },
+                "bitrate": {
+                    "description": "Kbps",
+                    "type": "integer"
+                },
+                "container": {
+                    "type": "string"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "geolocation": {
+                    "$ref": "#/definitions/models.Geolocation"
+                },
+                "grandparent_title": {
+                    "description": "Show/Artist",
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "media_type": {
+                    "description": "movie, episode, track",
+                    "type": "string"
+                },
+                "parent_title": {
+                    "description": "Season/Album",
+                    "type": "string"
+                },
+                "platform": {
+                    "description": "Client platform or application",
+                    "type": "string"
+                },
+                "player": {
+                    "description": "Device friendly name",
+                    "type": "string"
+                },
+                "position_seconds": {
+                    "type": "integer"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "progress_percent": {
+                    "type": "integer"
+                },
+                "rating_key": {
+                    "type": "string"
+                },
+                "server_id": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "plex, jellyfin, emby",
+                    "type": "string"
+                },
+                "stale": {
+                    "description": "Stale is set when the source could not be polled and the session is\ncarried over from its last successful poll.",
+                    "type": "boolean"
+                },
+                "state": {
+                    "description": "playing, paused, buffering",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "transcode": {
+                    "$ref": "#/definitions/models.NowPlayingTranscode"
+                },
+                "transcode_decision": {
+                    "description": "direct play, copy/direct stream, transcode",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "Media server's user ID",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "video_codec": {
+                    "type": "string"
+                },
+                "video_resolution": {
+                    "description": "Source resolution (4k, 1080, 720, sd)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.NowPlayingSourceStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "last_polled_at": {
+                    "description": "Last successful poll; omitted until one succeeds",
+                    "type": "string"
+                },
+                "server_id": {
+                    "type": "string"
+                },
+                "session_count": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "stale": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.NowPlayingTranscode": {
+            "type": "object",
+            "properties": {
+                "audio_codec": {
+                    "type": "string"
+                },
+                "audio_decision": {
+                    "type": "string"
+                },
+                "container": {
+                    "type": "string"
+                },
+                "hardware_accel": {
+                    "description": "e.g. nvenc, qsv, vaapi",
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "speed": {
+                    "description": "1.5 = 1.5x realtime (Plex only)",
+                    "type": "number"
+                },
+                "throttled": {
+                    "type": "boolean"
+                },
+                "transcode_reasons": {
+                    "description": "Jellyfin/Emby only",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "video_codec": {
+                    "type": "string"
+                },
+                "video_decision": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PaginationInfo": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "next_offset": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "prev_offset": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "description": "Optional, expensive for large datasets",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PauseDistribution": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "pause_bucket": {
+                    "description": "\"0-2\", \"3-5\", \"6-10\", \"11+\"",
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PausePatternAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_pauses_per_session": {
+                    "type": "number"
+                },
+                "high_pause_content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HighPauseContent"
+                    }
+                },
+                "pause_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PauseDistribution"
+                    }
+                },
+                "pause_timing_heatmap": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PauseTimingBucket"
+                    }
+                },
+                "quality_indicators": {
+                    "$ref": "#/definitions/models.PauseQualityMetrics"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                },
+                "user_pause_patterns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserPauseStats"
+                    }
+                }
+            }
+        },
+        "models.PauseQualityMetrics": {
+            "type": "object",
+            "properties": {
+                "high_engagement_threshold_pauses": {
+                    "description": "\u003c 2 pauses = high engagement",
+                    "type": "number"
+                },
+                "low_engagement_count": {
+                    "description": "\u003e 10 pauses",
+                    "type": "integer"
+                },
+                "potential_issues_detected": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PauseTimingBucket": {
+            "type": "object",
+            "properties": {
+                "duration_percent": {
+                    "description": "0-100 in 10% increments",
+                    "type": "integer"
+                },
+                "pause_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PeriodMetrics": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "avg_session_minutes": {
+                    "type": "number"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "unique_content": {
+                    "type": "integer"
+                },
+                "unique_locations": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                },
+                "watch_time_minutes": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PlatformAdoptionTrend": {
+            "type": "object",
+            "properties": {
+                "active_users": {
+                    "description": "ActiveUsers is the count of users who used this platform in this period",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "Date is the date bucket for this trend data",
+                    "type": "string"
+                },
+                "market_share": {
+                    "description": "MarketShare is this platform's share of total sessions in this period",
+                    "type": "number"
+                },
+                "new_users": {
+                    "description": "NewUsers is the count of users who first used this platform in this period",
+                    "type": "integer"
+                },
+                "platform": {
+                    "description": "Platform is the name of the platform",
+                    "type": "string"
+                },
+                "returning_users": {
+                    "description": "ReturningUsers is the count of users who returned to this platform",
+                    "type": "integer"
+                },
+                "session_count": {
+                    "description": "SessionCount is the total sessions on this platform in this period",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlatformConnectionStats": {
+            "type": "object",
+            "properties": {
+                "platform": {
+                    "type": "string"
+                },
+                "relay_rate_percent": {
+                    "type": "number"
+                },
+                "secure_rate_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PlatformContainer": {
+            "type": "object",
+            "properties": {
+                "direct_play_rate_percent": {
+                    "type": "number"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "supported_formats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transcode_required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.PlatformMismatch": {
+            "type": "object",
+            "properties": {
+                "mismatch_count": {
+                    "type": "integer"
+                },
+                "mismatch_rate_percent": {
+                    "type": "number"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlatformStats": {
+            "type": "object",
+            "properties": {
+                "platform": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlatformTransition": {
+            "type": "object",
+            "properties": {
+                "avg_days_before_switch": {
+                    "description": "AvgDaysBeforeSwitch is the average days on source platform before switching",
+                    "type": "number"
+                },
+                "from_platform": {
+                    "description": "FromPlatform is the source platform",
+                    "type": "string"
+                },
+                "return_rate": {
+                    "description": "ReturnRate is the percentage of users who returned to the source platform",
+                    "type": "number"
+                },
+                "to_platform": {
+                    "description": "ToPlatform is the destination platform",
+                    "type": "string"
+                },
+                "transition_count": {
+                    "description": "TransitionCount is how many times this transition has occurred",
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "description": "UniqueUsers is how many unique users made this transition",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlaybackEvent": {
+            "type": "object",
+            "required": [
+                "id",
+                "media_type",
+                "session_key",
+                "source",
+                "started_at",
+                "title"
+            ],
+            "properties": {
+                "actors": {
+                    "description": "Actor names",
+                    "type": "string"
+                },
+                "added_at": {
+                    "description": "Media metadata fields (v1.44 - API Coverage Expansion)",
+                    "type": "string"
+                },
+                "allow_guest": {
+                    "description": "0 or 1 - Allow guest access flag (v1.46 - API Coverage Expansion)",
+                    "type": "integer"
+                },
+                "art": {
+                    "type": "string"
+                },
+                "aspect_ratio": {
+                    "type": "string"
+                },
+                "audience_rating": {
+                    "description": "Audience rating (0.0-10.0)",
+                    "type": "string"
+                },
+                "audio_bitrate": {
+                    "type": "integer"
+                },
+                "audio_bitrate_mode": {
+                    "type": "string"
+                },
+                "audio_channel_layout": {
+                    "type": "string"
+                },
+                "audio_channels": {
+                    "description": "Source audio details",
+                    "type": "string"
+                },
+                "audio_codec": {
+                    "description": "Source audio fields",
+                    "type": "string"
+                },
+                "audio_decision": {
+                    "type": "string"
+                },
+                "audio_language": {
+                    "type": "string"
+                },
+                "audio_language_code": {
+                    "description": "3-letter ISO code (eng, jpn, etc.)",
+                    "type": "string"
+                },
+                "audio_profile": {
+                    "type": "string"
+                },
+                "audio_sample_rate": {
+                    "type": "integer"
+                },
+                "bandwidth": {
+                    "description": "Overall bandwidth",
+                    "type": "integer"
+                },
+                "bandwidth_lan": {
+                    "description": "LAN bandwidth limit (kbps)",
+                    "type": "integer"
+                },
+                "bandwidth_wan": {
+                    "description": "WAN bandwidth limit (kbps)",
+                    "type": "integer"
+                },
+                "banner": {
+                    "description": "Banner image URL",
+                    "type": "string"
+                },
+                "bitrate": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "channel_call_sign": {
+                    "description": "Live TV channel call sign (e.g., \"NBC\")",
+                    "type": "string"
+                },
+                "channel_identifier": {
+                    "description": "Live TV channel identifier",
+                    "type": "string"
+                },
+                "channel_stream": {
+                    "description": "Live TV channel stream number",
+                    "type": "integer"
+                },
+                "collections": {
+                    "description": "Collections (comma-separated)",
+                    "type": "string"
+                },
+                "container": {
+                    "description": "Container and subtitle",
+                    "type": "string"
+                },
+                "content_rating": {
+                    "type": "string"
+                },
+                "correlation_key": {
+                    "description": "Cross-source deduplication (v1.47 - NATS JetStream Phase 2, enhanced v2.0)\nFormat: {source}:{server_id}:{user_id}:{rating_key}:{machine_id}:{time_bucket}\nEnables deduplication across Plex webhook, Tautulli sync, Jellyfin, and Emby events",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_user": {
+                    "description": "0 or 1 - Deleted user flag",
+                    "type": "integer"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "directors": {
+                    "description": "Cast and crew (comma-separated strings)",
+                    "type": "string"
+                },
+                "do_notify": {
+                    "description": "0 or 1 - Notification flag",
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "User email",
+                    "type": "string"
+                },
+                "file": {
+                    "description": "Full file path",
+                    "type": "string"
+                },
+                "file_size": {
+                    "description": "File metadata",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "friendly_name": {
+                    "description": "Display name",
+                    "type": "string"
+                },
+                "full_title": {
+                    "description": "Formatted full title",
+                    "type": "string"
+                },
+                "genres": {
+                    "description": "Genres",
+                    "type": "string"
+                },
+                "grandparent_art": {
+                    "type": "string"
+                },
+                "grandparent_guid": {
+                    "type": "string"
+                },
+                "grandparent_rating_key": {
+                    "type": "string"
+                },
+                "grandparent_thumb": {
+                    "type": "string"
+                },
+                "grandparent_title": {
+                    "type": "string"
+                },
+                "group_count": {
+                    "description": "Grouped playback fields (v1.46 - API Coverage Expansion)",
+                    "type": "integer"
+                },
+                "group_ids": {
+                    "description": "Comma-separated grouped IDs",
+                    "type": "string"
+                },
+                "guid": {
+                    "description": "External IDs (IMDB, TVDB, TMDB)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "description": "Network/IP information",
+                    "type": "string"
+                },
+                "ip_address_public": {
+                    "description": "Public IP for accurate geolocation",
+                    "type": "string"
+                },
+                "is_admin": {
+                    "description": "User permission/status fields (v1.44 - API Coverage Expansion)",
+                    "type": "integer"
+                },
+                "is_allow_sync": {
+                    "description": "0 or 1 - Sync permission flag",
+                    "type": "integer"
+                },
+                "is_home_user": {
+                    "description": "0 or 1 - Home user flag",
+                    "type": "integer"
+                },
+                "is_restricted": {
+                    "description": "0 or 1 - Restricted user flag",
+                    "type": "integer"
+                },
+                "keep_history": {
+                    "description": "0 or 1 - History retention flag",
+                    "type": "integer"
+                },
+                "labels": {
+                    "description": "Labels (comma-separated)",
+                    "type": "string"
+                },
+                "last_viewed_at": {
+                    "description": "Unix timestamp of last watch",
+                    "type": "string"
+                },
+                "library_name": {
+                    "type": "string"
+                },
+                "live": {
+                    "description": "Live TV fields (v1.45 - API Coverage Expansion)",
+                    "type": "integer"
+                },
+                "live_uuid": {
+                    "description": "Live TV session UUID",
+                    "type": "string"
+                },
+                "local": {
+                    "description": "0 or 1 flag",
+                    "type": "integer"
+                },
+                "location": {
+                    "description": "\"lan\" or \"wan\"",
+                    "type": "string"
+                },
+                "location_type": {
+                    "type": "string"
+                },
+                "machine_id": {
+                    "description": "Unique device identifier",
+                    "type": "string"
+                },
+                "media_index": {
+                    "description": "Episode number (for binge detection)",
+                    "type": "integer"
+                },
+                "media_type": {
+                    "description": "Media identification",
+                    "type": "string"
+                },
+                "network_bandwidth": {
+                    "description": "Kbps - measured network bandwidth",
+                    "type": "integer"
+                },
+                "optimized_version": {
+                    "type": "integer"
+                },
+                "original_title": {
+                    "description": "Original non-localized title",
+                    "type": "string"
+                },
+                "originally_available_at": {
+                    "description": "Release date",
+                    "type": "string"
+                },
+                "parent_guid": {
+                    "description": "Additional GUID fields for external ID matching",
+                    "type": "string"
+                },
+                "parent_media_index": {
+                    "description": "Season number (for binge detection)",
+                    "type": "integer"
+                },
+                "parent_rating_key": {
+                    "type": "string"
+                },
+                "parent_thumb": {
+                    "description": "Thumbnails and art",
+                    "type": "string"
+                },
+                "parent_title": {
+                    "type": "string"
+                },
+                "paused_counter": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "percent_complete": {
+                    "description": "Playback metrics",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "platform": {
+                    "description": "Client/Player information",
+                    "type": "string"
+                },
+                "platform_name": {
+                    "type": "string"
+                },
+                "platform_version": {
+                    "type": "string"
+                },
+                "play_duration": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "player": {
+                    "type": "string"
+                },
+                "plex_key": {
+                    "description": "Plex metadata rating key for correlation",
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "product_version": {
+                    "type": "string"
+                },
+                "quality_profile": {
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "Critic rating (0.0-10.0)",
+                    "type": "string"
+                },
+                "rating_key": {
+                    "description": "Metadata enrichment fields (CRITICAL for binge detection and analytics)",
+                    "type": "string"
+                },
+                "relay": {
+                    "description": "0 or 1 flag",
+                    "type": "integer"
+                },
+                "relayed": {
+                    "description": "0 or 1 flag",
+                    "type": "integer"
+                },
+                "section_id": {
+                    "description": "Library metadata",
+                    "type": "integer"
+                },
+                "secure": {
+                    "description": "Connection security and network",
+                    "type": "integer"
+                },
+                "server_id": {
+                    "description": "Multi-server support (v2.0 - Phase 0.6)\nServerID uniquely identifies the source server instance, enabling:\n- Multiple servers of the same type (e.g., multiple Plex servers)\n- Cross-server deduplication\n- Per-server analytics and filtering",
+                    "type": "string"
+                },
+                "session_key": {
+                    "description": "Session identification",
+                    "type": "string"
+                },
+                "shared_libraries": {
+                    "description": "User library access fields (v1.45 - API Coverage Expansion)",
+                    "type": "string"
+                },
+                "sort_title": {
+                    "description": "Sort title for ordering (v1.45 - API Coverage Expansion)",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Data source tracking (v1.37) - Enables hybrid Plex + Tautulli architecture",
+                    "type": "string"
+                },
+                "source_bitrate": {
+                    "description": "Bitrate analytics fields (v1.42) - 3-level tracking for network bottleneck identification",
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "Playback state at end of session (null for stopped)",
+                    "type": "string"
+                },
+                "stopped_at": {
+                    "type": "string"
+                },
+                "stream_aspect_ratio": {
+                    "description": "Output stream aspect ratio (v1.46 - API Coverage Expansion)",
+                    "type": "string"
+                },
+                "stream_audio_bitrate": {
+                    "type": "integer"
+                },
+                "stream_audio_bitrate_mode": {
+                    "description": "cbr, vbr",
+                    "type": "string"
+                },
+                "stream_audio_channel_layout": {
+                    "type": "string"
+                },
+                "stream_audio_channels": {
+                    "type": "string"
+                },
+                "stream_audio_codec": {
+                    "type": "string"
+                },
+                "stream_audio_decision": {
+                    "type": "string"
+                },
+                "stream_audio_language": {
+                    "type": "string"
+                },
+                "stream_audio_language_code": {
+                    "description": "3-letter ISO code",
+                    "type": "string"
+                },
+                "stream_audio_profile": {
+                    "type": "string"
+                },
+                "stream_audio_sample_rate": {
+                    "description": "Hz",
+                    "type": "integer"
+                },
+                "stream_bitrate": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "stream_container": {
+                    "description": "Stream output fields (transcoded output quality)",
+                    "type": "string"
+                },
+                "stream_subtitle_codec": {
+                    "description": "Stream subtitle output fields (v1.44 - API Coverage Expansion)",
+                    "type": "string"
+                },
+                "stream_subtitle_container": {
+                    "type": "string"
+                },
+                "stream_subtitle_decision": {
+                    "type": "string"
+                },
+                "stream_subtitle_forced": {
+                    "type": "integer"
+                },
+                "stream_subtitle_format": {
+                    "type": "string"
+                },
+                "stream_subtitle_language": {
+                    "type": "string"
+                },
+                "stream_subtitle_language_code": {
+                    "description": "3-letter ISO code",
+                    "type": "string"
+                },
+                "stream_subtitle_location": {
+                    "type": "string"
+                },
+                "stream_video_bit_depth": {
+                    "type": "integer"
+                },
+                "stream_video_bitrate": {
+                    "type": "integer"
+                },
+                "stream_video_codec": {
+                    "type": "string"
+                },
+                "stream_video_codec_level": {
+                    "type": "string"
+                },
+                "stream_video_decision": {
+                    "type": "string"
+                },
+                "stream_video_dynamic_range": {
+                    "type": "string"
+                },
+                "stream_video_framerate": {
+                    "type": "string"
+                },
+                "stream_video_full_resolution": {
+                    "description": "e.g., \"1080p\"",
+                    "type": "string"
+                },
+                "stream_video_height": {
+                    "type": "integer"
+                },
+                "stream_video_language": {
+                    "type": "string"
+                },
+                "stream_video_language_code": {
+                    "description": "3-letter ISO code",
+                    "type": "string"
+                },
+                "stream_video_profile": {
+                    "type": "string"
+                },
+                "stream_video_resolution": {
+                    "type": "string"
+                },
+                "stream_video_scan_type": {
+                    "type": "string"
+                },
+                "stream_video_width": {
+                    "type": "integer"
+                },
+                "studio": {
+                    "description": "Production studio",
+                    "type": "string"
+                },
+                "subtitle_codec": {
+                    "type": "string"
+                },
+                "subtitle_container": {
+                    "description": "Legacy field",
+                    "type": "string"
+                },
+                "subtitle_container_fmt": {
+                    "description": "Container format",
+                    "type": "string"
+                },
+                "subtitle_decision": {
+                    "type": "string"
+                },
+                "subtitle_forced": {
+                    "type": "integer"
+                },
+                "subtitle_format": {
+                    "description": "Subtitle format (srt, ass, pgs)",
+                    "type": "string"
+                },
+                "subtitle_language": {
+                    "type": "string"
+                },
+                "subtitle_language_code": {
+                    "description": "3-letter ISO code (eng, spa, etc.)",
+                    "type": "string"
+                },
+                "subtitle_location": {
+                    "description": "embedded, external, agent",
+                    "type": "string"
+                },
+                "subtitles": {
+                    "description": "0 or 1 flag",
+                    "type": "integer"
+                },
+                "summary": {
+                    "description": "Media description/synopsis",
+                    "type": "string"
+                },
+                "synced_version": {
+                    "type": "integer"
+                },
+                "tagline": {
+                    "description": "Movie tagline",
+                    "type": "string"
+                },
+                "throttled": {
+                    "description": "0 or 1 - Playback throttled status (v1.45 - API Coverage Expansion)",
+                    "type": "integer"
+                },
+                "thumb": {
+                    "description": "Thumbnail URL",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "description": "Exactly-once delivery (v2.1 - ADR-0023: Consumer-Side WAL)\nFormat: {source}:{event_id}:{timestamp_nano}\nUsed by Consumer WAL for idempotent DuckDB inserts during crash recovery",
+                    "type": "string"
+                },
+                "transcode_audio_channels": {
+                    "type": "integer"
+                },
+                "transcode_audio_codec": {
+                    "type": "string"
+                },
+                "transcode_bitrate": {
+                    "description": "Kbps - transcoded stream bitrate",
+                    "type": "integer"
+                },
+                "transcode_container": {
+                    "type": "string"
+                },
+                "transcode_decision": {
+                    "description": "Transcode decision fields",
+                    "type": "string"
+                },
+                "transcode_hw_decode": {
+                    "description": "HW decode codec used",
+                    "type": "string"
+                },
+                "transcode_hw_decode_title": {
+                    "description": "HW decoder name (e.g., \"Intel Quick Sync\")",
+                    "type": "string"
+                },
+                "transcode_hw_decoding": {
+                    "type": "integer"
+                },
+                "transcode_hw_encode": {
+                    "description": "HW encode codec used",
+                    "type": "string"
+                },
+                "transcode_hw_encode_title": {
+                    "description": "HW encoder name (e.g., \"NVIDIA NVENC\")",
+                    "type": "string"
+                },
+                "transcode_hw_encoding": {
+                    "type": "integer"
+                },
+                "transcode_hw_full_pipeline": {
+                    "type": "integer"
+                },
+                "transcode_hw_requested": {
+                    "type": "integer"
+                },
+                "transcode_key": {
+                    "description": "Hardware transcode fields (CRITICAL for GPU utilization monitoring)",
+                    "type": "string"
+                },
+                "transcode_progress": {
+                    "description": "0-100",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "transcode_speed": {
+                    "description": "Speed multiplier (e.g., \"2.5\")",
+                    "type": "string"
+                },
+                "transcode_throttled": {
+                    "type": "integer"
+                },
+                "transcode_video_codec": {
+                    "type": "string"
+                },
+                "transcode_video_height": {
+                    "description": "Transcoded video height (v1.46 - API Coverage Expansion)",
+                    "type": "integer"
+                },
+                "transcode_video_width": {
+                    "description": "Transcoded video width (v1.46 - API Coverage Expansion)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "Unix timestamp of last metadata update",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "User information",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "user_rating": {
+                    "description": "User's personal rating (0.0-10.0)",
+                    "type": "string"
+                },
+                "user_thumb": {
+                    "description": "User avatar URL",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "video_bit_depth": {
+                    "type": "integer"
+                },
+                "video_bitrate": {
+                    "type": "integer"
+                },
+                "video_chroma_subsampling": {
+                    "type": "string"
+                },
+                "video_codec": {
+                    "type": "string"
+                },
+                "video_codec_level": {
+                    "type": "string"
+                },
+                "video_color_primaries": {
+                    "description": "HDR/Color metadata (CRITICAL for HDR10/HDR10+/Dolby Vision detection)",
+                    "type": "string"
+                },
+                "video_color_range": {
+                    "type": "string"
+                },
+                "video_color_space": {
+                    "type": "string"
+                },
+                "video_color_trc": {
+                    "type": "string"
+                },
+                "video_decision": {
+                    "type": "string"
+                },
+                "video_dynamic_range": {
+                    "description": "Source video details",
+                    "type": "string"
+                },
+                "video_framerate": {
+                    "type": "string"
+                },
+                "video_full_resolution": {
+                    "description": "Full resolution string (e.g., \"1080p\")",
+                    "type": "string"
+                },
+                "video_height": {
+                    "type": "integer"
+                },
+                "video_language": {
+                    "type": "string"
+                },
+                "video_language_code": {
+                    "description": "3-letter ISO code (eng, jpn, etc.)",
+                    "type": "string"
+                },
+                "video_profile": {
+                    "type": "string"
+                },
+                "video_ref_frames": {
+                    "type": "integer"
+                },
+                "video_resolution": {
+                    "description": "Source video fields",
+                    "type": "string"
+                },
+                "video_scan_type": {
+                    "type": "string"
+                },
+                "video_width": {
+                    "type": "integer"
+                },
+                "watched_status": {
+                    "description": "0 = unwatched, 1 = watched",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "writers": {
+                    "description": "Writer names",
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlaybackTrend": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlaybacksResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlaybackEvent"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.PaginationInfo"
+                }
+            }
+        },
+        "models.PlayerStats": {
+            "type": "object",
+            "properties": {
+                "playback_count": {
+                    "type": "integer"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PopularAnalytics": {
+            "type": "object",
+            "properties": {
+                "top_episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PopularContent"
+                    }
+                },
+                "top_movies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PopularContent"
+                    }
+                },
+                "top_shows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PopularContent"
+                    }
+                }
+            }
+        },
+        "models.PopularContent": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "content_rating": {
+                    "type": "string"
+                },
+                "first_played": {
+                    "type": "string"
+                },
+                "grandparent_title": {
+                    "type": "string"
+                },
+                "last_played": {
+                    "type": "string"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "parent_title": {
+                    "type": "string"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_watch_time_minutes": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.QoEByPlatform": {
+            "type": "object",
+            "properties": {
+                "avg_bitrate_mbps": {
+                    "description": "AvgBitrateMbps for this platform",
+                    "type": "number"
+                },
+                "avg_completion": {
+                    "description": "AvgCompletion for this platform",
+                    "type": "number"
+                },
+                "direct_play_rate": {
+                    "description": "DirectPlayRate for this platform",
+                    "type": "number"
+                },
+                "ebvs_rate": {
+                    "description": "EBVSRate for this platform",
+                    "type": "number"
+                },
+                "platform": {
+                    "description": "Platform name (e.g., \"iOS\", \"Android\", \"Roku\", \"Web\")",
+                    "type": "string"
+                },
+                "qoe_grade": {
+                    "description": "QoEGrade for this platform",
+                    "type": "string"
+                },
+                "qoe_score": {
+                    "description": "QoEScore for this platform",
+                    "type": "number"
+                },
+                "quality_degrade_rate": {
+                    "description": "QualityDegradeRate for this platform",
+                    "type": "number"
+                },
+                "session_count": {
+                    "description": "SessionCount for this platform",
+                    "type": "integer"
+                },
+                "session_percentage": {
+                    "description": "SessionPercentage is this platform's share of total sessions",
+                    "type": "number"
+                },
+                "transcode_rate": {
+                    "description": "TranscodeRate for this platform",
+                    "type": "number"
+                }
+            }
+        },
+        "models.QoEByTranscode": {
+            "type": "object",
+            "properties": {
+                "avg_bitrate_mbps": {
+                    "description": "AvgBitrateMbps for this decision type",
+                    "type": "number"
+                },
+                "avg_completion": {
+                    "description": "AvgCompletion for this decision type",
+                    "type": "number"
+                },
+                "ebvs_rate": {
+                    "description": "EBVSRate for this decision type",
+                    "type": "number"
+                },
+                "qoe_score": {
+                    "description": "QoEScore for this decision type",
+                    "type": "number"
+                },
+                "session_count": {
+                    "description": "SessionCount for this decision type",
+                    "type": "integer"
+                },
+                "session_percentage": {
+                    "description": "SessionPercentage is this decision's share of total sessions",
+                    "type": "number"
+                },
+                "transcode_decision": {
+                    "description": "TranscodeDecision is the decision type (\"direct play\", \"transcode\", \"copy\")",
+                    "type": "string"
+                }
+            }
+        },
+        "models.QoEDashboard": {
+            "type": "object",
+            "properties": {
+                "by_platform": {
+                    "description": "ByPlatform breaks down QoE by device/platform",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QoEByPlatform"
+                    }
+                },
+                "by_transcode_decision": {
+                    "description": "ByTranscodeDecision shows QoE differences between direct play and transcode",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QoEByTranscode"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata provides query provenance",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.QoEQueryMetadata"
+                        }
+                    ]
+                },
+                "summary": {
+                    "description": "Summary provides high-level QoE health indicators",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.QoESummary"
+                        }
+                    ]
+                },
+                "top_issues": {
+                    "description": "TopIssues lists the most impactful quality issues",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QoEIssue"
+                    }
+                },
+                "trends": {
+                    "description": "Trends provides time-series QoE metrics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QoETrendPoint"
+                    }
+                }
+            }
+        },
+        "models.QoEIssue": {
+            "type": "object",
+            "properties": {
+                "affected_sessions": {
+                    "description": "AffectedSessions is the count of sessions impacted",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "Description provides details about the issue",
+                    "type": "string"
+                },
+                "impact_percentage": {
+                    "description": "ImpactPercentage is the percentage of total sessions affected",
+                    "type": "number"
+                },
+                "issue_type": {
+                    "description": "IssueType categorizes the issue\nTypes: \"high_ebvs\", \"quality_degradation\", \"high_transcode\", \"high_pause\", \"low_completion\"",
+                    "type": "string"
+                },
+                "recommendation": {
+                    "description": "Recommendation provides actionable guidance",
+                    "type": "string"
+                },
+                "related_dimension": {
+                    "description": "RelatedDimension is the dimension most correlated with this issue (e.g., \"platform:Roku\")",
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "Severity is \"critical\", \"warning\", or \"info\"",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title is a human-readable issue title",
+                    "type": "string"
+                }
+            }
+        },
+        "models.QoEQueryMetadata": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "Cached indicates if from cache",
+                    "type": "boolean"
+                },
+                "data_range_end": {
+                    "description": "DataRangeEnd is the latest data analyzed",
+                    "type": "string"
+                },
+                "data_range_start": {
+                    "description": "DataRangeStart is the earliest data analyzed",
+                    "type": "string"
+                },
+                "event_count": {
+                    "description": "EventCount is total events analyzed",
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "description": "GeneratedAt is when this was generated",
+                    "type": "string"
+                },
+                "query_hash": {
+                    "description": "QueryHash for reproducibility",
+                    "type": "string"
+                },
+                "query_time_ms": {
+                    "description": "QueryTimeMs is execution time",
+                    "type": "integer"
+                },
+                "trend_interval": {
+                    "description": "TrendInterval is the granularity of trend data (\"hour\", \"day\")",
+                    "type": "string"
+                }
+            }
+        },
+        "models.QoESummary": {
+            "type": "object",
+            "properties": {
+                "avg_bitrate_mbps": {
+                    "description": "AvgBitrateMbps is the average streaming bitrate in Mbps",
+                    "type": "number"
+                },
+                "avg_completion": {
+                    "description": "AvgCompletion is the average completion percentage for all sessions",
+                    "type": "number"
+                },
+                "avg_pause_count": {
+                    "description": "AvgPauseCount is the average number of pauses per session",
+                    "type": "number"
+                },
+                "bitrate_p50_mbps": {
+                    "description": "BitrateP50Mbps is the median bitrate (50th percentile)",
+                    "type": "number"
+                },
+                "bitrate_p95_mbps": {
+                    "description": "BitrateP95Mbps is the 95th percentile bitrate",
+                    "type": "number"
+                },
+                "direct_play_count": {
+                    "description": "DirectPlayCount is the absolute count of direct play sessions",
+                    "type": "integer"
+                },
+                "direct_play_rate": {
+                    "description": "DirectPlayRate is the percentage of sessions playing directly without transcoding",
+                    "type": "number"
+                },
+                "ebvs_count": {
+                    "description": "EBVSCount is the absolute count of EBVS events",
+                    "type": "integer"
+                },
+                "ebvs_rate": {
+                    "description": "EBVSRate is Exit Before Video Starts rate (percent_complete = 0 AND play_duration \u003c 10s)\nIndustry benchmark: \u003c2% is excellent, \u003e5% indicates issues",
+                    "type": "number"
+                },
+                "high_completion_rate": {
+                    "description": "HighCompletionRate is the percentage of sessions with \u003e80% completion",
+                    "type": "number"
+                },
+                "pause_rate": {
+                    "description": "PauseRate is the percentage of sessions with pause events (paused_counter \u003e 0)\nHigh pause rates may indicate buffering or quality issues",
+                    "type": "number"
+                },
+                "qoe_grade": {
+                    "description": "QoEGrade is a letter grade (A, B, C, D, F) based on QoEScore",
+                    "type": "string"
+                },
+                "qoe_score": {
+                    "description": "QoEScore is a composite score (0-100) based on weighted metrics\nFormula: 100 - (EBVS_penalty + QualityDegrade_penalty + Pause_penalty)",
+                    "type": "number"
+                },
+                "quality_degrade_count": {
+                    "description": "QualityDegradeCount is the absolute count of degraded sessions",
+                    "type": "integer"
+                },
+                "quality_degrade_rate": {
+                    "description": "QualityDegradeRate is the percentage of sessions where stream quality \u003c source quality\nIndicates transcoding or bandwidth limitations",
+                    "type": "number"
+                },
+                "relayed_rate": {
+                    "description": "RelayedRate is the percentage of sessions using relay connections\nRelay indicates inability to establish direct connection",
+                    "type": "number"
+                },
+                "secure_connection_rate": {
+                    "description": "SecureConnectionRate is the percentage of sessions using secure connections",
+                    "type": "number"
+                },
+                "total_sessions": {
+                    "description": "TotalSessions is the count of playback sessions analyzed",
+                    "type": "integer"
+                },
+                "transcode_count": {
+                    "description": "TranscodeCount is the absolute count of transcoded sessions",
+                    "type": "integer"
+                },
+                "transcode_rate": {
+                    "description": "TranscodeRate is the percentage of sessions requiring transcoding",
+                    "type": "number"
+                }
+            }
+        },
+        "models.QoETrendPoint": {
+            "type": "object",
+            "properties": {
+                "avg_bitrate_mbps": {
+                    "description": "AvgBitrateMbps for this time period",
+                    "type": "number"
+                },
+                "avg_completion": {
+                    "description": "AvgCompletion for this time period",
+                    "type": "number"
+                },
+                "ebvs_rate": {
+                    "description": "EBVSRate for this time period",
+                    "type": "number"
+                },
+                "qoe_score": {
+                    "description": "QoEScore for this time period",
+                    "type": "number"
+                },
+                "quality_degrade_rate": {
+                    "description": "QualityDegradeRate for this time period",
+                    "type": "number"
+                },
+                "session_count": {
+                    "description": "SessionCount is the number of sessions in this time bucket",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the time bucket for these metrics",
+                    "type": "string"
+                },
+                "transcode_rate": {
+                    "description": "TranscodeRate for this time period",
+                    "type": "number"
+                }
+            }
+        },
+        "models.RankingEntry": {
+            "type": "object",
+            "properties": {
+                "contentId": {
+                    "type": "string"
+                },
+                "contentTitle": {
+                    "type": "string"
+                },
+                "period": {
+                    "description": "Week/Month identifier",
+                    "type": "string"
+                },
+                "playCount": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "1-based rank",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RatingStats": {
+            "type": "object",
+            "properties": {
+                "content_rating": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ResolutionMismatch": {
+            "type": "object",
+            "properties": {
+                "affected_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "common_platforms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "source_resolution": {
+                    "type": "string"
+                },
+                "stream_resolution": {
+                    "type": "string"
+                },
+                "transcode_required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ResolutionMismatchAnalytics": {
+            "type": "object",
+            "properties": {
+                "direct_play_count": {
+                    "type": "integer"
+                },
+                "mismatch_by_platform": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlatformMismatch"
+                    }
+                },
+                "mismatch_rate_percent": {
+                    "type": "number"
+                },
+                "mismatched_playbacks": {
+                    "type": "integer"
+                },
+                "mismatches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResolutionMismatch"
+                    }
+                },
+                "top_downgrade_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserDowngradeStats"
+                    }
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                },
+                "transcode_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ResolutionStats": {
+            "type": "object",
+            "properties": {
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "video_resolution": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RetentionPoint": {
+            "type": "object",
+            "properties": {
+                "average_retention": {
+                    "description": "AverageRetention is the mean retention rate across all cohorts at this week",
+                    "type": "number"
+                },
+                "cohorts_with_data": {
+                    "description": "CohortsWithData is the number of cohorts that have data for this week offset",
+                    "type": "integer"
+                },
+                "max_retention": {
+                    "description": "MaxRetention is the highest retention rate among cohorts at this week",
+                    "type": "number"
+                },
+                "median_retention": {
+                    "description": "MedianRetention is the median retention rate (more robust to outliers)",
+                    "type": "number"
+                },
+                "min_retention": {
+                    "description": "MinRetention is the lowest retention rate among cohorts at this week",
+                    "type": "number"
+                },
+                "week_offset": {
+                    "description": "WeekOffset is the number of weeks since cohort formation",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SankeyLink": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "description": "Source node ID",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Target node ID",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Flow volume (watch count)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SankeyNode": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "description": "0=Show, 1=Season, 2=Episode",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Unique identifier",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Display name",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Total watch count through this node",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SetupDataAvailability": {
+            "type": "object",
+            "properties": {
+                "has_geolocations": {
+                    "type": "boolean"
+                },
+                "has_playbacks": {
+                    "type": "boolean"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SetupDataSourceStatus": {
+            "type": "object",
+            "properties": {
+                "configured": {
+                    "type": "boolean"
+                },
+                "connected": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SetupDataSources": {
+            "type": "object",
+            "properties": {
+                "emby": {
+                    "$ref": "#/definitions/models.SetupMediaServerStatus"
+                },
+                "jellyfin": {
+                    "$ref": "#/definitions/models.SetupMediaServerStatus"
+                },
+                "nats": {
+                    "$ref": "#/definitions/models.SetupOptionalFeature"
+                },
+                "plex": {
+                    "$ref": "#/definitions/models.SetupMediaServerStatus"
+                },
+                "tautulli": {
+                    "$ref": "#/definitions/models.SetupDataSourceStatus"
+                }
+            }
+        },
+        "models.SetupDatabaseStatus": {
+            "type": "object",
+            "properties": {
+                "connected": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.SetupMediaServerStatus": {
+            "type": "object",
+            "properties": {
+                "configured": {
+                    "type": "boolean"
+                },
+                "connected": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "server_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SetupOptionalFeature": {
+            "type": "object",
+            "properties": {
+                "connected": {
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SetupStatus": {
+            "type": "object",
+            "properties": {
+                "data_available": {
+                    "$ref": "#/definitions/models.SetupDataAvailability"
+                },
+                "data_sources": {
+                    "$ref": "#/definitions/models.SetupDataSources"
+                },
+                "database": {
+                    "$ref": "#/definitions/models.SetupDatabaseStatus"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.SourceHealth": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "circuit_breaker_state": {
+                    "description": "closed, half-open, open",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "last_successful_sync": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "platform": {
+                    "description": "plex, jellyfin, emby, tautulli",
+                    "type": "string"
+                },
+                "reachable": {
+                    "type": "boolean"
+                },
+                "server_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SourceQuality": {
+            "type": "object",
+            "properties": {
+                "event_count": {
+                    "description": "EventCount from this source",
+                    "type": "integer"
+                },
+                "event_percentage": {
+                    "description": "EventPercentage is this source's share of total events",
+                    "type": "number"
+                },
+                "invalid_rate": {
+                    "description": "InvalidRate for this source",
+                    "type": "number"
+                },
+                "null_rate": {
+                    "description": "NullRate for this source",
+                    "type": "number"
+                },
+                "quality_score": {
+                    "description": "QualityScore for this source",
+                    "type": "number"
+                },
+                "server_id": {
+                    "description": "ServerID if applicable",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the data source (\"plex\", \"jellyfin\", \"emby\", \"tautulli\")",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is \"healthy\", \"warning\", or \"critical\"",
+                    "type": "string"
+                },
+                "top_issue": {
+                    "description": "TopIssue is the most impactful issue for this source",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SourcesHealthResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "reachable_count": {
+                    "type": "integer"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SourceHealth"
+                    }
+                },
+                "status": {
+                    "description": "healthy, degraded, unhealthy, unconfigured",
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "unreachable_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StaleContent": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "description": "AddedAt is when the content was added",
+                    "type": "string"
+                },
+                "content_rating": {
+                    "description": "ContentRating is the content's rating (G, PG, R, etc.)",
+                    "type": "string"
+                },
+                "days_since_added": {
+                    "description": "DaysSinceAdded is the number of days since the content was added",
+                    "type": "integer"
+                },
+                "genres": {
+                    "description": "Genres are the content genres",
+                    "type": "string"
+                },
+                "library_name": {
+                    "description": "LibraryName is the library containing this content",
+                    "type": "string"
+                },
+                "media_type": {
+                    "description": "MediaType is the type of content",
+                    "type": "string"
+                },
+                "rating_key": {
+                    "description": "RatingKey is the unique identifier for the content",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title is the content title",
+                    "type": "string"
+                },
+                "year": {
+                    "description": "Year is the release year",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SubtitleAnalytics": {
+            "type": "object",
+            "properties": {
+                "codec_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubtitleCodecStats"
+                    }
+                },
+                "language_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubtitleLanguageStats"
+                    }
+                },
+                "playbacks_with_subtitles": {
+                    "type": "integer"
+                },
+                "playbacks_without_subtitles": {
+                    "type": "integer"
+                },
+                "subtitle_usage_rate_percent": {
+                    "type": "number"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                },
+                "user_preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserSubtitlePreference"
+                    }
+                }
+            }
+        },
+        "models.SubtitleCodecStats": {
+            "type": "object",
+            "properties": {
+                "codec": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SubtitleLanguageStats": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TemporalHeatmapBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Total playbacks in this bucket",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "label": {
+                    "description": "Human-readable label (e.g., \"Mon 2PM\", \"Jan 15\")",
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemporalHeatmapPoint"
+                    }
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TemporalHeatmapPoint": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "weight": {
+                    "description": "Playback count for intensity",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TemporalHeatmapResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemporalHeatmapBucket"
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "\"hour\", \"day\", \"week\", \"month\"",
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TemporalSpatialPoint": {
+            "type": "object",
+            "properties": {
+                "cumulative_playbacks": {
+                    "description": "Cumulative playbacks up to this time",
+                    "type": "integer"
+                },
+                "h3_index": {
+                    "description": "H3 hexagon index",
+                    "type": "integer"
+                },
+                "latitude": {
+                    "description": "Hexagon center latitude",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Hexagon center longitude",
+                    "type": "number"
+                },
+                "playback_count": {
+                    "description": "Playbacks in this time-space bucket",
+                    "type": "integer"
+                },
+                "rolling_avg_playbacks": {
+                    "description": "7-period rolling average",
+                    "type": "number"
+                },
+                "time_bucket": {
+                    "description": "Time bucket (hour/day/week/month)",
+                    "type": "string"
+                },
+                "unique_users": {
+                    "description": "Unique users in this time-space bucket",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TestEmailRequest": {
+            "type": "object",
+            "required": [
+                "to"
+            ],
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/models.ChannelConfig"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TestEmailResponse": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error_code": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "smtp_host": {
+                    "type": "string"
+                },
+                "smtp_port": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "tls_mode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ToneMappingEvent": {
+            "type": "object",
+            "properties": {
+                "affected_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "occurrence_count": {
+                    "type": "integer"
+                },
+                "platforms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_format": {
+                    "type": "string"
+                },
+                "stream_format": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TopContentComparison": {
+            "type": "object",
+            "properties": {
+                "count_change": {
+                    "type": "integer"
+                },
+                "count_change_pct": {
+                    "type": "number"
+                },
+                "current_count": {
+                    "type": "integer"
+                },
+                "current_rank": {
+                    "type": "integer"
+                },
+                "previous_count": {
+                    "type": "integer"
+                },
+                "previous_rank": {
+                    "type": "integer"
+                },
+                "rank_change": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trending": {
+                    "description": "\"rising\", \"falling\", \"stable\", \"new\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TopUserComparison": {
+            "type": "object",
+            "properties": {
+                "current_rank": {
+                    "type": "integer"
+                },
+                "current_watch_time": {
+                    "type": "number"
+                },
+                "previous_rank": {
+                    "type": "integer"
+                },
+                "previous_watch_time": {
+                    "type": "number"
+                },
+                "rank_change": {
+                    "type": "integer"
+                },
+                "trending": {
+                    "description": "\"rising\", \"falling\", \"stable\", \"new\"",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "watch_time_change": {
+                    "type": "number"
+                },
+                "watch_time_change_pct": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.TranscodeStats": {
+            "type": "object",
+            "properties": {
+                "percentage": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "transcode_decision": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TreemapMetrics": {
+            "type": "object",
+            "properties": {
+                "avgCompletion": {
+                    "type": "number"
+                },
+                "playCount": {
+                    "type": "integer"
+                },
+                "totalDuration": {
+                    "description": "Media duration in seconds",
+                    "type": "integer"
+                },
+                "uniqueViewers": {
+                    "type": "integer"
+                },
+                "utilizationPct": {
+                    "description": "Watched/Total duration %",
+                    "type": "number"
+                }
+            }
+        },
+        "models.TreemapNode": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TreemapNode"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "itemType": {
+                    "description": "\"library\", \"section\", \"genre\", \"content\"",
+                    "type": "string"
+                },
+                "metrics": {
+                    "$ref": "#/definitions/models.TreemapMetrics"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Watch time in seconds or play count",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TrendsResponse": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "type": "string"
+                },
+                "playback_trends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlaybackTrend"
+                    }
+                }
+            }
+        },
+        "models.UpdateMediaServerRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "realtime_enabled": {
+                    "type": "boolean"
+                },
+                "session_polling_enabled": {
+                    "type": "boolean"
+                },
+                "session_polling_interval": {
+                    "type": "string"
+                },
+                "settings": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "token": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhooks_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.UserActivity": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "playback_count": {
+                    "type": "integer"
+                },
+                "total_duration_minutes": {
+                    "type": "integer"
+                },
+                "unique_media": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserCluster": {
+            "type": "object",
+            "properties": {
+                "avg_watch_hours": {
+                    "description": "AvgWatchHours is the average watch time per user in this cluster",
+                    "type": "number"
+                },
+                "characteristic_type": {
+                    "description": "CharacteristicType describes the cluster (e.g., \"Family\", \"Power Users\", \"Casual\")",
+                    "type": "string"
+                },
+                "cluster_color": {
+                    "description": "ClusterColor is the assigned color for visualization (hex)",
+                    "type": "string"
+                },
+                "density": {
+                    "description": "Density is the internal connectivity (edges / possible edges)",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "ID is the cluster identifier",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name is an auto-generated or user-defined cluster name",
+                    "type": "string"
+                },
+                "top_content": {
+                    "description": "TopContent is the most popular content within this cluster",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "top_genres": {
+                    "description": "TopGenres are the most popular genres within this cluster",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_count": {
+                    "description": "UserCount is the number of users in this cluster",
+                    "type": "integer"
+                },
+                "user_ids": {
+                    "description": "UserIDs lists all user IDs in this cluster",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.UserConnectionStats": {
+            "type": "object",
+            "properties": {
+                "local_rate_percent": {
+                    "type": "number"
+                },
+                "relay_rate_percent": {
+                    "type": "number"
+                },
+                "secure_rate_percent": {
+                    "type": "number"
+                },
+                "total_streams": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserContentOverlapAnalytics": {
+            "type": "object",
+            "properties": {
+                "avgOverlap": {
+                    "description": "Average overlap across all pairs",
+                    "type": "number"
+                },
+                "clusterCount": {
+                    "description": "Detected viewing clusters",
+                    "type": "integer"
+                },
+                "matrix": {
+                    "$ref": "#/definitions/models.UserOverlapMatrix"
+                },
+                "topPairs": {
+                    "description": "Top overlapping user pairs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserOverlapPair"
+                    }
+                },
+                "totalConnections": {
+                    "description": "Non-zero matrix cells",
+                    "type": "integer"
+                },
+                "totalUsers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UserDeviceProfile": {
+            "type": "object",
+            "properties": {
+                "days_since_first_seen": {
+                    "description": "DaysSinceFirstSeen is the number of days since the user was first seen",
+                    "type": "integer"
+                },
+                "days_since_last_seen": {
+                    "description": "DaysSinceLastSeen is the number of days since the user was last seen",
+                    "type": "integer"
+                },
+                "first_seen_at": {
+                    "description": "FirstSeenAt is when the user was first seen on any platform",
+                    "type": "string"
+                },
+                "is_multi_device": {
+                    "description": "IsMultiDevice indicates if the user uses multiple platforms",
+                    "type": "boolean"
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is when the user was last seen on any platform",
+                    "type": "string"
+                },
+                "platform_history": {
+                    "description": "PlatformHistory contains the user's usage of each platform",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserPlatformUsage"
+                    }
+                },
+                "primary_platform": {
+                    "description": "PrimaryPlatform is the platform with the most sessions",
+                    "type": "string"
+                },
+                "primary_platform_percentage": {
+                    "description": "PrimaryPlatformPercentage is the percentage of sessions on the primary platform",
+                    "type": "number"
+                },
+                "total_migrations": {
+                    "description": "TotalMigrations is the number of platform switches",
+                    "type": "integer"
+                },
+                "total_platforms_used": {
+                    "description": "TotalPlatformsUsed is the count of unique platforms this user has ever used",
+                    "type": "integer"
+                },
+                "total_sessions": {
+                    "description": "TotalSessions is the total number of playback sessions across all platforms",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserID is the internal identifier for the user",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Username is the display name of the user",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserDowngradeStats": {
+            "type": "object",
+            "properties": {
+                "common_reason": {
+                    "type": "string"
+                },
+                "downgrade_count": {
+                    "type": "integer"
+                },
+                "downgrade_rate_percent": {
+                    "type": "number"
+                },
+                "total_playbacks": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserEdge": {
+            "type": "object",
+            "properties": {
+                "connection_type": {
+                    "description": "ConnectionType categorizes the relationship\nTypes: \"shared_session\" (same IP, same time), \"content_overlap\" (similar taste),\n       \"watch_party\" (synchronized viewing), \"sequential\" (back-to-back same content)",
+                    "type": "string"
+                },
+                "content_overlap": {
+                    "description": "ContentOverlap is the Jaccard similarity of content watched (0-1)",
+                    "type": "number"
+                },
+                "edge_width": {
+                    "description": "EdgeWidth is computed for visualization (based on weight)",
+                    "type": "number"
+                },
+                "first_interaction": {
+                    "description": "FirstInteraction is when these users first had a connection",
+                    "type": "string"
+                },
+                "last_interaction": {
+                    "description": "LastInteraction is the most recent connection event",
+                    "type": "string"
+                },
+                "shared_sessions": {
+                    "description": "SharedSessions is the count of co-viewing events (if type is shared_session/watch_party)",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source is the ID of the first user",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Target is the ID of the second user",
+                    "type": "string"
+                },
+                "top_shared_content": {
+                    "description": "TopSharedContent lists titles commonly watched by both users",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weight": {
+                    "description": "Weight represents the strength of the connection (higher = stronger)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.UserEngagement": {
+            "type": "object",
+            "properties": {
+                "activity_score": {
+                    "type": "number"
+                },
+                "average_session_minutes": {
+                    "type": "number"
+                },
+                "avg_completion_rate": {
+                    "type": "number"
+                },
+                "days_since_first_seen": {
+                    "type": "integer"
+                },
+                "days_since_last_seen": {
+                    "type": "integer"
+                },
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "fully_watched_count": {
+                    "type": "integer"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "most_watched_title": {
+                    "type": "string"
+                },
+                "most_watched_type": {
+                    "type": "string"
+                },
+                "return_visitor_rate": {
+                    "type": "number"
+                },
+                "total_content_items": {
+                    "type": "integer"
+                },
+                "total_sessions": {
+                    "type": "integer"
+                },
+                "total_watch_time_minutes": {
+                    "type": "number"
+                },
+                "unique_content_items": {
+                    "type": "integer"
+                },
+                "unique_locations": {
+                    "type": "integer"
+                },
+                "unique_platforms": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserEngagementAnalytics": {
+            "type": "object",
+            "properties": {
+                "most_active_day": {
+                    "type": "integer"
+                },
+                "most_active_hour": {
+                    "type": "integer"
+                },
+                "summary": {
+                    "$ref": "#/definitions/models.UserEngagementSummary"
+                },
+                "top_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserEngagement"
+                    }
+                },
+                "viewing_patterns_by_day": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ViewingPatternByDay"
+                    }
+                },
+                "viewing_patterns_by_hour": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ViewingPatternByHour"
+                    }
+                }
+            }
+        },
+        "models.UserEngagementSummary": {
+            "type": "object",
+            "properties": {
+                "active_users": {
+                    "type": "integer"
+                },
+                "avg_completion_rate": {
+                    "type": "number"
+                },
+                "avg_session_minutes": {
+                    "type": "number"
+                },
+                "avg_user_watch_time_minutes": {
+                    "type": "number"
+                },
+                "return_visitor_rate": {
+                    "type": "number"
+                },
+                "total_sessions": {
+                    "type": "integer"
+                },
+                "total_users": {
+                    "type": "integer"
+                },
+                "total_watch_time_minutes": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.UserNetworkGraph": {
+            "type": "object",
+            "properties": {
+                "clusters": {
+                    "description": "Clusters represent detected user communities",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserCluster"
+                    }
+                },
+                "edges": {
+                    "description": "Edges represent relationships between users",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserEdge"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata provides query provenance",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NetworkMetadata"
+                        }
+                    ]
+                },
+                "nodes": {
+                    "description": "Nodes represent individual users",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserNode"
+                    }
+                },
+                "summary": {
+                    "description": "Summary provides aggregate network statistics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NetworkSummary"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.UserNode": {
+            "type": "object",
+            "properties": {
+                "cluster_id": {
+                    "description": "ClusterID is the detected community/cluster this user belongs to",
+                    "type": "integer"
+                },
+                "connection_count": {
+                    "description": "ConnectionCount is the number of edges connected to this node",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID is the unique user identifier",
+                    "type": "string"
+                },
+                "is_central": {
+                    "description": "IsCentral indicates if this user is a hub in the network",
+                    "type": "boolean"
+                },
+                "last_active": {
+                    "description": "LastActive is when this user last played content",
+                    "type": "string"
+                },
+                "node_color": {
+                    "description": "NodeColor is the assigned color based on cluster (hex format)",
+                    "type": "string"
+                },
+                "node_size": {
+                    "description": "NodeSize is a computed size based on activity (for visualization)",
+                    "type": "number"
+                },
+                "playback_count": {
+                    "description": "PlaybackCount is total playbacks by this user",
+                    "type": "integer"
+                },
+                "top_genre": {
+                    "description": "TopGenre is this user's most-watched genre",
+                    "type": "string"
+                },
+                "total_watch_hours": {
+                    "description": "TotalWatchHours is cumulative watch time in hours",
+                    "type": "number"
+                },
+                "username": {
+                    "description": "Username for display",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserOverlapMatrix": {
+            "type": "object",
+            "properties": {
+                "matrix": {
+                    "description": "NxN overlap values (0-1 normalized)",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
                         }
                     }
+                },
+                "users": {
+                    "description": "User names (matrix row/column labels)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.APIError": {
+        },
+        "models.UserOverlapPair": {
             "type": "object",
             "properties": {
-                "code": {
+                "overlapPercent": {
+                    "description": "Jaccard similarity",
+                    "type": "number"
+                },
+                "sharedItems": {
+                    "description": "Number of items both watched",
+                    "type": "integer"
+                },
+                "sharedWatchTime": {
+                    "description": "Total shared watch time (seconds)",
+                    "type": "integer"
+                },
+                "topSharedGenre": {
+                    "description": "Most common shared genre",
                     "type": "string"
                 },
-                "details": {
-                    "type": "object",
-                    "additionalProperties": true
+                "user1Id": {
+                    "type": "string"
                 },
-                "message": {
+                "user1Name": {
+                    "type": "string"
+                },
+                "user2Id": {
+                    "type": "string"
+                },
+                "user2Name": {
                     "type": "string"
                 }
             }
         },
-        "models.APIResponse": {
+        "models.UserPauseStats": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
-                    "$ref": "#/definitions/models.APIError"
+                "avg_pauses": {
+                    "type": "number"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/models.Metadata"
+                "binge_watcher": {
+                    "description": "Low pause rate indicates binging",
+                    "type": "boolean"
                 },
-                "status": {
+                "total_sessions": {
+                    "type": "integer"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "models.CountryStats": {
+        "models.UserPlatformUsage": {
             "type": "object",
             "properties": {
-                "country": {
+                "first_used": {
+                    "description": "FirstUsed is when the user first used this platform",
                     "type": "string"
                 },
-                "playback_count": {
+                "is_active": {
+                    "description": "IsActive indicates if the platform was used in the last 30 days",
+                    "type": "boolean"
+                },
+                "is_primary": {
+                    "description": "IsPrimary indicates if this is the user's primary platform",
+                    "type": "boolean"
+                },
+                "last_used": {
+                    "description": "LastUsed is when the user last used this platform",
+                    "type": "string"
+                },
+                "percentage": {
+                    "description": "Percentage is this platform's share of the user's total sessions",
+                    "type": "number"
+                },
+                "platform": {
+                    "description": "Platform is the name of the platform (e.g., \"Android\", \"iOS\", \"Web\")",
+                    "type": "string"
+                },
+                "session_count": {
+                    "description": "SessionCount is the total number of sessions on this platform",
                     "type": "integer"
                 },
-                "unique_users": {
+                "total_watch_time_minutes": {
+                    "description": "TotalWatchTimeMinutes is the total watch time on this platform",
+                    "type": "number"
+                }
+            }
+        },
+        "models.UserProfileAnalytics": {
+            "type": "object",
+            "properties": {
+                "averageProfile": {
+                    "description": "Average scores across all users",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "axes": {
+                    "description": "Dimension labels",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "profiles": {
+                    "description": "User scores",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserProfileScore"
+                    }
+                },
+                "topPerformers": {
+                    "description": "Users with highest overall scores",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserProfileScore"
+                    }
+                },
+                "totalUsers": {
                     "type": "integer"
                 }
             }
         },
-        "models.HealthStatus": {
+        "models.UserProfileScore": {
             "type": "object",
             "properties": {
-                "database_connected": {
-                    "type": "boolean"
+                "rank": {
+                    "description": "Overall engagement rank",
+                    "type": "integer"
                 },
-                "last_sync_time": {
+                "scores": {
+                    "description": "Values 0-100 for each axis",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "userId": {
                     "type": "string"
                 },
-                "status": {
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "models.UserSubtitlePreference": {
+            "type": "object",
+            "properties": {
+                "preferred_languages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "tautulli_connected": {
-                    "type": "boolean"
+                "subtitle_usage_count": {
+                    "type": "integer"
                 },
-                "uptime_seconds": {
+                "subtitle_usage_rate_percent": {
                     "type": "number"
                 },
-                "version": {
+                "total_playbacks": {
+                    "type": "integer"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "models.LocationStats": {
+        "models.UsersResponse": {
+            "type": "object",
+            "properties": {
+                "top_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserActivity"
+                    }
+                }
+            }
+        },
+        "models.ViewingHoursHeatmap": {
+            "type": "object",
+            "properties": {
+                "day_of_week": {
+                    "type": "integer"
+                },
+                "hour": {
+                    "type": "integer"
+                },
+                "playback_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ViewingPatternByDay": {
             "type": "object",
             "properties": {
                 "avg_completion": {
                     "type": "number"
                 },
-                "city": {
+                "day_name": {
                     "type": "string"
                 },
-                "country": {
+                "day_of_week": {
+                    "description": "0 = Sunday, 6 = Saturday",
+                    "type": "integer"
+                },
+                "session_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                },
+                "watch_time_minutes": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ViewingPatternByHour": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "hour_of_day": {
+                    "description": "0-23",
+                    "type": "integer"
+                },
+                "session_count": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                },
+                "watch_time_minutes": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.WatchParty": {
+            "type": "object",
+            "properties": {
+                "avg_completion": {
+                    "type": "number"
+                },
+                "confidence": {
+                    "description": "0-1: how likely the users watched together",
+                    "type": "number"
+                },
+                "ended_at": {
                     "type": "string"
                 },
-                "first_seen": {
+                "false_positive": {
+                    "description": "Marked by an admin as not a watch party",
+                    "type": "boolean"
+                },
+                "grandparent_title": {
                     "type": "string"
                 },
-                "last_seen": {
+                "id": {
+                    "description": "ID of the party's first playback",
                     "type": "string"
                 },
-                "latitude": {
+                "location_name": {
+                    "type": "string"
+                },
+                "media_type": {
+                    "type": "string"
+                },
+                "parent_title": {
+                    "type": "string"
+                },
+                "participant_count": {
+                    "type": "integer"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchPartyParticipant"
+                    }
+                },
+                "party_time": {
+                    "type": "string"
+                },
+                "same_location": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_duration_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WatchPartyAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg_participants_per_party": {
                     "type": "number"
                 },
-                "longitude": {
-                    "type": "number"
+                "parties_by_day": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchPartyByDay"
+                    }
+                },
+                "recent_watch_parties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchParty"
+                    }
+                },
+                "same_location_parties": {
+                    "type": "integer"
+                },
+                "top_content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchPartyContentStats"
+                    }
+                },
+                "top_social_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchPartyUserStats"
+                    }
                 },
-                "playback_count": {
+                "total_participants": {
                     "type": "integer"
                 },
-                "region": {
-                    "type": "string"
-                },
-                "unique_users": {
+                "total_watch_parties": {
                     "type": "integer"
                 }
             }
         },
-        "models.Metadata": {
+        "models.WatchPartyByDay": {
             "type": "object",
             "properties": {
-                "query_time_ms": {
+                "avg_participants": {
+                    "type": "number"
+                },
+                "day_of_week": {
+                    "description": "0 = Sunday, 6 = Saturday",
                     "type": "integer"
                 },
-                "timestamp": {
-                    "type": "string"
+                "party_count": {
+                    "type": "integer"
                 }
             }
         },
-        "models.PlaybackEvent": {
+        "models.WatchPartyContentStats": {
             "type": "object",
             "properties": {
-                "audio_bitrate": {
-                    "type": "integer"
+                "avg_participants_per_party": {
+                    "type": "number"
                 },
-                "audio_channel_layout": {
+                "grandparent_title": {
                     "type": "string"
                 },
-                "audio_channels": {
-                    "description": "Audio details",
+                "media_type": {
                     "type": "string"
                 },
-                "audio_codec": {
+                "parent_title": {
                     "type": "string"
                 },
-                "audio_language": {
+                "party_count": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
                 },
-                "audio_sample_rate": {
+                "total_participants": {
                     "type": "integer"
                 },
-                "bitrate": {
+                "unique_users": {
                     "type": "integer"
-                },
-                "container": {
-                    "description": "Container and subtitle",
+                }
+            }
+        },
+        "models.WatchPartyParticipant": {
+            "type": "object",
+            "properties": {
+                "city": {
                     "type": "string"
                 },
-                "content_rating": {
+                "country": {
                     "type": "string"
                 },
-                "created_at": {
+                "ip_address": {
                     "type": "string"
                 },
-                "file_size": {
-                    "description": "File metadata",
+                "percent_complete": {
                     "type": "integer"
                 },
-                "grandparent_title": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "ip_address": {
-                    "type": "string"
+                "play_duration_minutes": {
+                    "type": "integer"
                 },
-                "library_name": {
+                "started_at": {
                     "type": "string"
                 },
-                "local": {
-                    "description": "0 or 1 flag",
+                "user_id": {
                     "type": "integer"
                 },
-                "location_type": {
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "models.WatchPartyUserStats": {
+            "type": "object",
+            "properties": {
+                "avg_party_size": {
+                    "type": "number"
                 },
-                "media_type": {
+                "favorite_content": {
                     "type": "string"
                 },
-                "parent_title": {
-                    "type": "string"
+                "party_count": {
+                    "type": "integer"
                 },
-                "paused_counter": {
+                "same_location_parties": {
                     "type": "integer"
                 },
-                "percent_complete": {
+                "total_co_watchers": {
                     "type": "integer"
                 },
-                "platform": {
-                    "type": "string"
+                "user_id": {
+                    "type": "integer"
                 },
-                "play_duration": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WeekRetention": {
+            "type": "object",
+            "properties": {
+                "active_users": {
+                    "description": "ActiveUsers is the count of users from this cohort active in this week",
                     "type": "integer"
                 },
-                "player": {
+                "retention_rate": {
+                    "description": "RetentionRate is (ActiveUsers / InitialUsers) * 100",
+                    "type": "number"
+                },
+                "week_date": {
+                    "description": "WeekDate is the actual date of this retention week",
                     "type": "string"
                 },
-                "relayed": {
-                    "description": "0 or 1 flag",
+                "week_offset": {
+                    "description": "WeekOffset is the number of weeks since cohort formation (0 = same week)",
                     "type": "integer"
-                },
-                "section_id": {
+                }
+            }
+        },
+        "models.YearStats": {
+            "type": "object",
+            "properties": {
+                "playback_count": {
                     "type": "integer"
                 },
-                "secure": {
-                    "description": "Connection security",
+                "year": {
                     "type": "integer"
+                }
+            }
+        },
+        "tautulli.TautulliConcurrentStreamsByStreamTypeData": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Array of date strings",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "session_key": {
-                    "type": "string"
-                },
-                "started_at": {
-                    "type": "string"
+                "series": {
+                    "description": "Including \"Total Concurrent Streams\"",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tautulli.TautulliStreamTypeSeries"
+                    }
+                }
+            }
+        },
+        "tautulli.TautulliPlaysByDateData": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Array of date strings",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "stopped_at": {
-                    "type": "string"
+                "series": {
+                    "description": "Series data by media type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tautulli.TautulliPlaysByDateSeries"
+                    }
+                }
+            }
+        },
+        "tautulli.TautulliPlaysByDateSeries": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Array of counts (int or float)",
+                    "type": "array",
+                    "items": {}
                 },
-                "stream_audio_channels": {
+                "name": {
+                    "description": "\"Movies\", \"TV\", \"Music\", \"Live TV\"",
                     "type": "string"
+                }
+            }
+        },
+        "tautulli.TautulliPlaysByDayOfWeekData": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "[\"Sunday\", \"Monday\", ...]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "stream_audio_codec": {
-                    "type": "string"
+                "series": {
+                    "description": "Same structure as plays by date",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tautulli.TautulliPlaysByDateSeries"
+                    }
+                }
+            }
+        },
+        "tautulli.TautulliPlaysByHourOfDayData": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "[\"00\", \"01\", ..., \"23\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "stream_audio_decision": {
-                    "type": "string"
+                "series": {
+                    "description": "Same structure as plays by date",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tautulli.TautulliPlaysByDateSeries"
+                    }
+                }
+            }
+        },
+        "tautulli.TautulliPlaysByStreamTypeData": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Array of date strings",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "stream_bitrate": {
-                    "type": "integer"
+                "series": {
+                    "description": "Direct Play, Direct Stream, Transcode",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tautulli.TautulliStreamTypeSeries"
+                    }
+                }
+            }
+        },
+        "tautulli.TautulliStreamTypeSeries": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Array of counts",
+                    "type": "array",
+                    "items": {}
                 },
-                "stream_container": {
+                "name": {
+                    "description": "\"Direct Play\", \"Direct Stream\", \"Transcode\"",
                     "type": "string"
-                },
-                "stream_video_decision": {
+                }
+            }
+        },
+        "tautulliimport.ProgressSummary": {
+            "type": "object",
+            "properties": {
+                "current_session_key": {
                     "type": "string"
                 },
-                "stream_video_resolution": {
-                    "description": "Stream quality fields (what was actually streamed vs source)",
-                    "type": "string"
+                "deduplicated": {
+                    "type": "integer"
                 },
-                "subtitle_codec": {
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "subtitle_language": {
+                "elapsed_seconds": {
+                    "type": "number"
+                },
+                "error": {
                     "type": "string"
                 },
-                "subtitles": {
-                    "description": "0 or 1 flag",
+                "errors": {
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "estimated_remaining_seconds": {
+                    "type": "number"
                 },
-                "transcode_decision": {
+                "import_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "imported": {
                     "type": "integer"
                 },
-                "username": {
-                    "type": "string"
-                },
-                "video_bit_depth": {
+                "last_processed_id": {
                     "type": "integer"
                 },
-                "video_bitrate": {
+                "processed": {
                     "type": "integer"
                 },
-                "video_codec": {
-                    "type": "string"
+                "progress": {
+                    "type": "number"
                 },
-                "video_dynamic_range": {
-                    "description": "Video details",
-                    "type": "string"
+                "records_per_second": {
+                    "type": "number"
                 },
-                "video_framerate": {
-                    "type": "string"
+                "skipped": {
+                    "type": "integer"
                 },
-                "video_height": {
+                "source_records": {
                     "type": "integer"
                 },
-                "video_resolution": {
+                "start_time": {
                     "type": "string"
                 },
-                "video_width": {
-                    "type": "integer"
+                "status": {
+                    "type": "string"
                 },
-                "year": {
+                "total_records": {
                     "type": "integer"
                 }
             }
         },
-        "models.Stats": {
+        "tautulliimport.VerifyResult": {
             "type": "object",
             "properties": {
-                "database_size_bytes": {
+                "complete": {
+                    "description": "Complete is true when every source record was processed and the most\nrecent run had no publish errors.",
+                    "type": "boolean"
+                },
+                "deduplicated": {
                     "type": "integer"
                 },
-                "last_sync_time": {
-                    "type": "string"
+                "errors": {
+                    "type": "integer"
+                },
+                "has_progress": {
+                    "description": "HasProgress is false when no progress was saved for SourcePath.",
+                    "type": "boolean"
                 },
-                "recent_activity": {
+                "id_gap": {
+                    "description": "IDGap is SourceMaxID minus LastProcessedID.",
                     "type": "integer"
                 },
-                "top_countries": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.CountryStats"
-                    }
+                "imported": {
+                    "description": "Imported, Skipped, Deduplicated, and Errors are the counts of the\nmost recent run, which only covers part of the source when resumed.",
+                    "type": "integer"
                 },
-                "total_playbacks": {
+                "last_processed_id": {
+                    "description": "LastProcessedID is the last record ID in the saved progress.",
                     "type": "integer"
                 },
-                "unique_locations": {
+                "missing_records": {
+                    "description": "MissingRecords is the number of source records above LastProcessedID.",
                     "type": "integer"
                 },
-                "unique_users": {
+                "processed_records": {
+                    "description": "ProcessedRecords is the number of source records at or below\nLastProcessedID, across all runs of a resumed import.",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "source_max_id": {
+                    "description": "SourceMaxID is the highest record ID in the source.",
+                    "type": "integer"
+                },
+                "source_path": {
+                    "description": "SourcePath is the database that was verified.",
+                    "type": "string"
+                },
+                "source_records": {
+                    "description": "SourceRecords is the number of records in the source.",
                     "type": "integer"
                 }
             }
@@ -1040,7 +24875,7 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "token",
             "in": "cookie",
-            "x-logo": "{\"url\": \"https://raw.githubusercontent.com/tomtom215/cartographus/main/web/public/icons/icon.svg\", \"altText\": \"Cartographus Logo\"}"
+            "x-logo": "{\"url\": \"https://raw.githubusercontent.com/tomtom215/cartographus/main/web/public/icons/icon.svg\", \"altText\": \"Tautulli Maps Logo\"}"
         }
     }
 }`
@@ -1051,7 +24886,7 @@ var SwaggerInfo = &swag.Spec{
 	Host:             "localhost:3857",
 	BasePath:         "/api/v1",
 	Schemes:          []string{"http", "https"},
-	Title:            "Cartographus API",
+	Title:            "Tautulli Maps API",
 	Description:      "Geographic visualization and analytics platform for Plex Media Server playback activity\n\n## Features\n\n- **Interactive WebGL Map**: Visualize playback locations with clustering for 10,000+ points\n- **3D Globe View**: WebGL-accelerated 3D globe visualization\n- **32 Analytics Charts**: Powered by Apache ECharts across 5 themed sections\n- **Real-time Updates**: WebSocket-based live notifications\n- **Advanced Filtering**: 14+ filter dimensions (users, media types, platforms, codecs)\n- **Data Export**: CSV and GeoJSON export capabilities\n- **Progressive Web App**: Offline support with service worker\n\n## Authentication\n\nMost endpoints require JWT authentication via HTTP-only cookie.\nUse `/api/v1/auth/login` to obtain a token, which will be automatically included in subsequent requests.\n\n## Rate Limiting\n\nDefault rate limit: 100 requests per minute per IP address.\nRate limit headers are included in responses: `X-RateLimit-Limit`, `X-RateLimit-Remaining`, `X-RateLimit-Reset`.\n\n## Performance\n\n- API response times (p95): <100ms\n- Map rendering: 60 FPS\n- Data sync (10k events): <30s\n- In-memory caching: 5-minute TTL for analytics\n\n## Error Responses\n\nAll error responses follow this format:\n```json\n{\n\"status\": \"error\",\n\"data\": null,\n\"error\": {\n\"code\": \"ERROR_CODE\",\n\"message\": \"Human-readable error message\",\n\"details\": {}\n},\n\"metadata\": {\n\"timestamp\": \"2025-11-18T12:34:56Z\"\n}\n}\n```",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
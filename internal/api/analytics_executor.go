@@ -7,7 +7,6 @@ package api
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -23,7 +22,8 @@ import (
 //  2. Check cache for existing results (5-minute TTL)
 //  3. Execute query if cache miss
 //  4. Cache the result for subsequent requests
-//  5. Respond with JSON including metadata (query time, cached status)
+//  5. Respond with JSON including metadata (query time, cached status) and an
+//     ETag, answering a matching If-None-Match with 304 Not Modified
 //
 // This executor is used by 11 analytics handlers and eliminates ~400 lines of
 // repetitive cache-checking and response-building code across handlers_analytics.go.
@@ -75,7 +75,7 @@ type AnalyticsQueryFunc func(ctx context.Context, filter database.LocationStatsF
 //   - Builds a LocationStatsFilter from query parameters
 //   - Generates a cache key from prefix + filter
 //   - Returns cached data if available (with Cached: true in metadata)
-//   - Executes queryFunc on cache miss
+//   - Executes queryFunc on cache miss, named after the prefix in the slow query log
 //   - Caches successful results with 5-minute TTL
 //   - Responds with JSON including query time metrics
 //
@@ -96,7 +96,7 @@ func (e *AnalyticsQueryExecutor) ExecuteSimple(
 ) {
 	// Check if database is available (protects against nil pointer in queryFunc)
 	if e.handler.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
@@ -108,8 +108,8 @@ func (e *AnalyticsQueryExecutor) ExecuteSimple(
 
 	// Check cache first (only if cache is available)
 	if e.handler.cache != nil {
-		if cached, found := e.handler.cache.Get(cacheKey); found {
-			respondJSON(w, http.StatusOK, &models.APIResponse{
+		if cached, found := e.handler.cache.GetContext(r.Context(), cacheKey); found {
+			e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 				Status: "success",
 				Data:   cached,
 				Metadata: models.Metadata{
@@ -123,20 +123,25 @@ func (e *AnalyticsQueryExecutor) ExecuteSimple(
 	}
 
 	// Execute query
-	data, err := queryFunc(r.Context(), filter)
+	queryCtx, done, err := e.handler.beginQuery(r)
+	defer done()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR",
-			fmt.Sprintf("Failed to execute query: %s", cacheKeyPrefix), err)
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
+		return
+	}
+	data, err := queryFunc(database.WithQueryLabel(queryCtx, cacheKeyPrefix, &filter), filter)
+	if err != nil {
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
 		return
 	}
 
 	// Cache the result (only if cache is available)
 	if e.handler.cache != nil {
-		e.handler.cache.Set(cacheKey, data)
+		e.handler.cache.SetContext(r.Context(), cacheKey, data)
 	}
 
 	// Respond with data
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   data,
 		Metadata: models.Metadata{
@@ -179,7 +184,7 @@ func (e *AnalyticsQueryExecutor) ExecuteUserScoped(
 ) {
 	// Check if database is available
 	if e.handler.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
@@ -208,8 +213,8 @@ func (e *AnalyticsQueryExecutor) ExecuteUserScoped(
 
 	// Check cache first
 	if e.handler.cache != nil {
-		if cached, found := e.handler.cache.Get(cacheKey); found {
-			respondJSON(w, http.StatusOK, &models.APIResponse{
+		if cached, found := e.handler.cache.GetContext(r.Context(), cacheKey); found {
+			e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 				Status: "success",
 				Data:   cached,
 				Metadata: models.Metadata{
@@ -223,19 +228,24 @@ func (e *AnalyticsQueryExecutor) ExecuteUserScoped(
 	}
 
 	// Execute query
-	data, err := queryFunc(r.Context(), filter)
+	queryCtx, done, err := e.handler.beginQuery(r)
+	defer done()
+	if err != nil {
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
+		return
+	}
+	data, err := queryFunc(database.WithQueryLabel(queryCtx, cacheKeyPrefix, &filter), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR",
-			fmt.Sprintf("Failed to execute query: %s", cacheKeyPrefix), err)
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
 		return
 	}
 
 	// Cache the result
 	if e.handler.cache != nil {
-		e.handler.cache.Set(cacheKey, data)
+		e.handler.cache.SetContext(r.Context(), cacheKey, data)
 	}
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   data,
 		Metadata: models.Metadata{
@@ -277,18 +287,18 @@ func (e *AnalyticsQueryExecutor) ExecuteAdminOnly(
 	// RBAC: Require admin role (check BEFORE database availability)
 	hctx := GetHandlerContext(r)
 	if hctx == nil || !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 	if !hctx.IsAdmin {
-		respondError(w, http.StatusForbidden, "ADMIN_REQUIRED",
+		respondError(w, r, http.StatusForbidden, "ADMIN_REQUIRED",
 			"Admin role required to access this analytics endpoint", nil)
 		return
 	}
 
 	// Check if database is available (after RBAC passes)
 	if e.handler.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
@@ -299,8 +309,8 @@ func (e *AnalyticsQueryExecutor) ExecuteAdminOnly(
 	cacheKey := cache.GenerateKey(cacheKeyPrefix, filter)
 
 	if e.handler.cache != nil {
-		if cached, found := e.handler.cache.Get(cacheKey); found {
-			respondJSON(w, http.StatusOK, &models.APIResponse{
+		if cached, found := e.handler.cache.GetContext(r.Context(), cacheKey); found {
+			e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 				Status: "success",
 				Data:   cached,
 				Metadata: models.Metadata{
@@ -313,18 +323,23 @@ func (e *AnalyticsQueryExecutor) ExecuteAdminOnly(
 		}
 	}
 
-	data, err := queryFunc(r.Context(), filter)
+	queryCtx, done, err := e.handler.beginQuery(r)
+	defer done()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR",
-			fmt.Sprintf("Failed to execute query: %s", cacheKeyPrefix), err)
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
+		return
+	}
+	data, err := queryFunc(database.WithQueryLabel(queryCtx, cacheKeyPrefix, &filter), filter)
+	if err != nil {
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
 		return
 	}
 
 	if e.handler.cache != nil {
-		e.handler.cache.Set(cacheKey, data)
+		e.handler.cache.SetContext(r.Context(), cacheKey, data)
 	}
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   data,
 		Metadata: models.Metadata{
@@ -361,7 +376,7 @@ func (e *AnalyticsQueryExecutor) ExecuteAdminOnly(
 //	// Validate limit parameter
 //	limit := getIntParam(r, "limit", 10)
 //	if limit < 1 || limit > 100 {
-//	    respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid limit", nil)
+//	    respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid limit", nil)
 //	    return
 //	}
 //
@@ -381,7 +396,7 @@ func (e *AnalyticsQueryExecutor) ExecuteWithParam(
 ) {
 	// Check if database is available (protects against nil pointer in queryFunc)
 	if e.handler.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
@@ -396,8 +411,8 @@ func (e *AnalyticsQueryExecutor) ExecuteWithParam(
 
 	// Check cache first (only if cache is available)
 	if e.handler.cache != nil {
-		if cached, found := e.handler.cache.Get(cacheKey); found {
-			respondJSON(w, http.StatusOK, &models.APIResponse{
+		if cached, found := e.handler.cache.GetContext(r.Context(), cacheKey); found {
+			e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 				Status: "success",
 				Data:   cached,
 				Metadata: models.Metadata{
@@ -411,20 +426,25 @@ func (e *AnalyticsQueryExecutor) ExecuteWithParam(
 	}
 
 	// Execute query
-	data, err := queryFunc(r.Context(), filter, param)
+	queryCtx, done, err := e.handler.beginQuery(r)
+	defer done()
+	if err != nil {
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
+		return
+	}
+	data, err := queryFunc(database.WithQueryLabel(queryCtx, cacheKeyPrefix, &filter), filter, param)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR",
-			fmt.Sprintf("Failed to execute query: %s", cacheKeyPrefix), err)
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
 		return
 	}
 
 	// Cache the result (only if cache is available)
 	if e.handler.cache != nil {
-		e.handler.cache.Set(cacheKey, data)
+		e.handler.cache.SetContext(r.Context(), cacheKey, data)
 	}
 
 	// Respond with data
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   data,
 		Metadata: models.Metadata{
@@ -462,7 +482,7 @@ func (e *AnalyticsQueryExecutor) ExecuteWithParamUserScoped(
 	param interface{},
 ) {
 	if e.handler.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
@@ -487,8 +507,8 @@ func (e *AnalyticsQueryExecutor) ExecuteWithParamUserScoped(
 	}{filter, param, userScope})
 
 	if e.handler.cache != nil {
-		if cached, found := e.handler.cache.Get(cacheKey); found {
-			respondJSON(w, http.StatusOK, &models.APIResponse{
+		if cached, found := e.handler.cache.GetContext(r.Context(), cacheKey); found {
+			e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 				Status: "success",
 				Data:   cached,
 				Metadata: models.Metadata{
@@ -501,18 +521,23 @@ func (e *AnalyticsQueryExecutor) ExecuteWithParamUserScoped(
 		}
 	}
 
-	data, err := queryFunc(r.Context(), filter, param)
+	queryCtx, done, err := e.handler.beginQuery(r)
+	defer done()
+	if err != nil {
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
+		return
+	}
+	data, err := queryFunc(database.WithQueryLabel(queryCtx, cacheKeyPrefix, &filter), filter, param)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR",
-			fmt.Sprintf("Failed to execute query: %s", cacheKeyPrefix), err)
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
 		return
 	}
 
 	if e.handler.cache != nil {
-		e.handler.cache.Set(cacheKey, data)
+		e.handler.cache.SetContext(r.Context(), cacheKey, data)
 	}
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	e.handler.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   data,
 		Metadata: models.Metadata{
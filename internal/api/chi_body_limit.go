@@ -0,0 +1,122 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// uploadMaxBodySize is the body limit for routes that accept file uploads.
+const uploadMaxBodySize int64 = 500 << 20
+
+// uploadReadTimeout is how long upload routes may take to receive their body.
+// The server-wide HTTP_TIMEOUT is too short for large files on slow links.
+const uploadReadTimeout = 10 * time.Minute
+
+// BodyLimit is the request body policy for a route.
+type BodyLimit struct {
+	// MaxBytes is the largest accepted body. Larger bodies get 413.
+	MaxBytes int64
+
+	// ReadTimeout, if set, replaces the server read deadline so the body
+	// must be received within this duration of the request starting.
+	ReadTimeout time.Duration
+}
+
+// BodySizeLimiter enforces a maximum request body size, protecting the API
+// from memory-exhaustion through oversized POST/PUT bodies.
+//
+// Requests that declare a Content-Length over the limit are rejected with
+// 413 REQUEST_TOO_LARGE before the handler runs. Bodies without a length
+// (chunked) are wrapped in http.MaxBytesReader, so reads fail once the limit
+// is exceeded.
+//
+// Routes that legitimately accept larger payloads, such as backup and import
+// uploads, are given their own BodyLimit with Override.
+type BodySizeLimiter struct {
+	defaultLimit BodyLimit
+	overrides    map[string]BodyLimit // keyed by "METHOD /path"
+}
+
+// NewBodySizeLimiter creates a limiter with the given default body size.
+// A non-positive maxBytes uses config.DefaultMaxBodySize.
+func NewBodySizeLimiter(maxBytes int64) *BodySizeLimiter {
+	if maxBytes <= 0 {
+		maxBytes = config.DefaultMaxBodySize
+	}
+	return &BodySizeLimiter{
+		defaultLimit: BodyLimit{MaxBytes: maxBytes},
+		overrides:    make(map[string]BodyLimit),
+	}
+}
+
+// Override sets the body limit for one route, matched by method and exact
+// request path.
+//
+// Thread Safety: Not safe for concurrent use; call before serving requests.
+func (l *BodySizeLimiter) Override(method, path string, limit BodyLimit) *BodySizeLimiter {
+	l.overrides[method+" "+path] = limit
+	return l
+}
+
+// LimitFor returns the body limit that applies to a request.
+func (l *BodySizeLimiter) LimitFor(r *http.Request) BodyLimit {
+	if limit, ok := l.overrides[r.Method+" "+r.URL.Path]; ok {
+		return limit
+	}
+	return l.defaultLimit
+}
+
+// Middleware returns a Chi-compatible middleware enforcing the limits.
+func (l *BodySizeLimiter) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limit := l.LimitFor(r)
+			if r.ContentLength > limit.MaxBytes {
+				logging.Warn().
+					Str("path", r.URL.Path).
+					Int64("content_length", r.ContentLength).
+					Int64("limit", limit.MaxBytes).
+					Msg("Rejected oversized request body")
+				respondError(w, r, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE",
+					fmt.Sprintf("Request body exceeds the %d byte limit", limit.MaxBytes), nil)
+				return
+			}
+
+			if limit.ReadTimeout > 0 {
+				// Best effort: not every ResponseWriter supports deadlines
+				_ = http.NewResponseController(w).SetReadDeadline(time.Now().Add(limit.ReadTimeout))
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit.MaxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bodySizeLimiter builds the limiter for SetupChi from HTTP_MAX_BODY_SIZE,
+// with larger limits for the routes that accept file uploads.
+func (router *Router) bodySizeLimiter() *BodySizeLimiter {
+	var maxBytes int64
+	if router.handler != nil && router.handler.config != nil {
+		maxBytes = router.handler.config.Server.MaxBodySize
+	}
+
+	upload := BodyLimit{MaxBytes: uploadMaxBodySize, ReadTimeout: uploadReadTimeout}
+	return NewBodySizeLimiter(maxBytes).
+		Override(http.MethodPost, "/api/v1/backups/upload", upload).
+		Override(http.MethodPost, "/api/v1/admin/import/jellystat", upload)
+}
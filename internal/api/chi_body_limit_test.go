@@ -0,0 +1,144 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// readBodyHandler reads the whole body, answering 200 or 400 on a read error.
+var readBodyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if _, err := io.ReadAll(r.Body); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+})
+
+func postBody(handler http.Handler, path string, size int) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(strings.Repeat("x", size)))
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBodySizeLimiter_UnderAndOverLimit(t *testing.T) {
+	handler := NewBodySizeLimiter(1024).Middleware()(readBodyHandler)
+
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{"just under", 1023, http.StatusOK},
+		{"at limit", 1024, http.StatusOK},
+		{"just over", 1025, http.StatusRequestEntityTooLarge},
+		{"far over", 1 << 20, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postBody(handler, "/api/v1/detection/rules", tt.size)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestBodySizeLimiter_JSONError(t *testing.T) {
+	handler := NewBodySizeLimiter(16).Middleware()(readBodyHandler)
+	rec := postBody(handler, "/api/v1/backup", 17)
+
+	var resp models.APIResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Status != "error" || resp.Error == nil || resp.Error.Code != "REQUEST_TOO_LARGE" {
+		t.Errorf("response = %+v, want REQUEST_TOO_LARGE error", resp)
+	}
+	if !strings.Contains(resp.Error.Message, "16 byte limit") {
+		t.Errorf("message = %q, want the limit mentioned", resp.Error.Message)
+	}
+}
+
+func TestBodySizeLimiter_ChunkedBodyIsCutOff(t *testing.T) {
+	handler := NewBodySizeLimiter(1024).Middleware()(readBodyHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/backup", strings.NewReader(strings.Repeat("x", 2048)))
+	req.ContentLength = -1 // Unknown length, as with Transfer-Encoding: chunked
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want handler to see *http.MaxBytesError", rec.Code)
+	}
+}
+
+func TestBodySizeLimiter_Override(t *testing.T) {
+	limiter := NewBodySizeLimiter(1024).
+		Override(http.MethodPost, "/api/v1/backups/upload", BodyLimit{MaxBytes: 4096})
+	handler := limiter.Middleware()(readBodyHandler)
+
+	if rec := postBody(handler, "/api/v1/backups/upload", 4096); rec.Code != http.StatusOK {
+		t.Errorf("override route under its limit: status = %d, want 200", rec.Code)
+	}
+	if rec := postBody(handler, "/api/v1/backups/upload", 4097); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("override route over its limit: status = %d, want 413", rec.Code)
+	}
+	if rec := postBody(handler, "/api/v1/backups/validate", 2048); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("other route: status = %d, want default limit 413", rec.Code)
+	}
+
+	// The override applies to the method it was registered for
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/backups/upload", strings.NewReader(strings.Repeat("x", 2048)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("PUT on override path: status = %d, want 413", rec.Code)
+	}
+}
+
+func TestBodySizeLimiter_NoBody(t *testing.T) {
+	handler := NewBodySizeLimiter(1).Middleware()(readBodyHandler)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 for bodyless request", rec.Code)
+	}
+}
+
+func TestRouter_BodySizeLimiterFromConfig(t *testing.T) {
+	router := &Router{handler: &Handler{config: &config.Config{Server: config.ServerConfig{MaxBodySize: 2048}}}}
+	limiter := router.bodySizeLimiter()
+
+	if got := limiter.LimitFor(httptest.NewRequest(http.MethodPost, "/api/v1/detection/rules", nil)); got.MaxBytes != 2048 {
+		t.Errorf("default MaxBytes = %d, want HTTP_MAX_BODY_SIZE 2048", got.MaxBytes)
+	}
+	upload := limiter.LimitFor(httptest.NewRequest(http.MethodPost, "/api/v1/backups/upload", nil))
+	if upload.MaxBytes != uploadMaxBodySize || upload.ReadTimeout != uploadReadTimeout {
+		t.Errorf("upload limit = %+v, want %d bytes within %v", upload, uploadMaxBodySize, uploadReadTimeout)
+	}
+
+	if got := (&Router{}).bodySizeLimiter().LimitFor(httptest.NewRequest(http.MethodPost, "/", nil)); got.MaxBytes != config.DefaultMaxBodySize {
+		t.Errorf("MaxBytes without config = %d, want %d", got.MaxBytes, config.DefaultMaxBodySize)
+	}
+}
@@ -10,6 +10,7 @@ package api
 import (
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
@@ -60,6 +61,24 @@ func DefaultChiMiddlewareConfig() *ChiMiddlewareConfig {
 type ChiMiddleware struct {
 	config *ChiMiddlewareConfig
 	cors   func(http.Handler) http.Handler
+
+	// rateLimit holds the settings RateLimit enforces; SetRateLimit
+	// replaces them at runtime
+	rateLimit atomic.Pointer[rateLimitSettings]
+}
+
+// rateLimitSettings is one generation of rate limit settings. Limiters are
+// rebuilt when the pointer changes.
+type rateLimitSettings struct {
+	requests int
+	window   time.Duration
+	disabled bool
+}
+
+// rateLimitedHandler is a handler wrapped in a limiter built for settings.
+type rateLimitedHandler struct {
+	settings *rateLimitSettings
+	handler  http.Handler
 }
 
 // NewChiMiddleware creates a new Chi middleware factory with the given configuration.
@@ -78,10 +97,16 @@ func NewChiMiddleware(config *ChiMiddlewareConfig) *ChiMiddleware {
 		MaxAge:           config.CORSMaxAge,
 	})
 
-	return &ChiMiddleware{
+	m := &ChiMiddleware{
 		config: config,
 		cors:   corsHandler,
 	}
+	m.rateLimit.Store(&rateLimitSettings{
+		requests: config.RateLimitRequests,
+		window:   config.RateLimitWindow,
+		disabled: config.RateLimitDisabled,
+	})
+	return m
 }
 
 // CORS returns a Chi-compatible CORS middleware using go-chi/cors.
@@ -92,14 +117,9 @@ func (m *ChiMiddleware) CORS() func(http.Handler) http.Handler {
 
 // RateLimit returns a Chi-compatible rate limiting middleware using go-chi/httprate.
 // This is a production-hardened replacement for the custom rate limiting middleware.
+// It follows SetRateLimit: after a change the limiter is rebuilt on the next
+// request, starting every client with a fresh allowance.
 func (m *ChiMiddleware) RateLimit() func(http.Handler) http.Handler {
-	if m.config.RateLimitDisabled {
-		// Return a no-op middleware when rate limiting is disabled
-		return func(next http.Handler) http.Handler {
-			return next
-		}
-	}
-
 	// Use IP-based rate limiting by default, or custom key function if provided
 	keyFunc := m.config.RateLimitKeyFunc
 	if keyFunc == nil {
@@ -115,11 +135,40 @@ func (m *ChiMiddleware) RateLimit() func(http.Handler) http.Handler {
 		opts = append(opts, httprate.WithLimitHandler(m.config.RateLimitOnLimit))
 	}
 
-	return httprate.Limit(
-		m.config.RateLimitRequests,
-		m.config.RateLimitWindow,
-		opts...,
-	)
+	return func(next http.Handler) http.Handler {
+		var current atomic.Pointer[rateLimitedHandler]
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			settings := m.rateLimit.Load()
+			if settings.disabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limited := current.Load()
+			if limited == nil || limited.settings != settings {
+				built := &rateLimitedHandler{
+					settings: settings,
+					handler:  httprate.Limit(settings.requests, settings.window, opts...)(next),
+				}
+				if current.CompareAndSwap(limited, built) {
+					limited = built
+				} else {
+					limited = current.Load() // Another request rebuilt it first
+				}
+			}
+			limited.handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetRateLimit changes the limits enforced by RateLimit middleware without a
+// restart. Counters start over under the new limits.
+func (m *ChiMiddleware) SetRateLimit(requests int, window time.Duration, disabled bool) {
+	m.rateLimit.Store(&rateLimitSettings{
+		requests: requests,
+		window:   window,
+		disabled: disabled,
+	})
 }
 
 // RateLimitByIP returns a rate limiter that uses IP-based key extraction.
@@ -439,7 +488,7 @@ func RequireAdminMiddleware() func(http.Handler) http.Handler {
 					Str("path", r.URL.Path).
 					Str("method", r.Method).
 					Msg("Access denied: admin role required")
-				RespondAuthError(w, err)
+				RespondAuthError(w, r, err)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -470,7 +519,7 @@ func RequireEditorMiddleware() func(http.Handler) http.Handler {
 					Str("path", r.URL.Path).
 					Str("method", r.Method).
 					Msg("Access denied: editor role required")
-				RespondAuthError(w, err)
+				RespondAuthError(w, r, err)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -497,7 +546,7 @@ func RequireAuthMiddleware() func(http.Handler) http.Handler {
 					Str("path", r.URL.Path).
 					Str("method", r.Method).
 					Msg("Access denied: authentication required")
-				RespondAuthError(w, ErrNotAuthenticated)
+				RespondAuthError(w, r, ErrNotAuthenticated)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -386,6 +386,45 @@ func TestChiMiddleware_RateLimit_DifferentIPs(t *testing.T) {
 	}
 }
 
+func TestChiMiddleware_SetRateLimit(t *testing.T) {
+	m := NewChiMiddleware(&ChiMiddlewareConfig{
+		RateLimitRequests: 2,
+		RateLimitWindow:   time.Minute,
+	})
+	handler := m.RateLimit()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	allowed := func(n int) int {
+		ok := 0
+		for i := 0; i < n; i++ {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = "192.168.1.1:12345"
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code == http.StatusOK {
+				ok++
+			}
+		}
+		return ok
+	}
+
+	if got := allowed(5); got != 2 {
+		t.Errorf("allowed %d requests, want 2", got)
+	}
+
+	// Raised limit applies to middleware that is already mounted
+	m.SetRateLimit(4, time.Minute, false)
+	if got := allowed(5); got != 4 {
+		t.Errorf("after raising the limit, allowed %d requests, want 4", got)
+	}
+
+	m.SetRateLimit(4, time.Minute, true)
+	if got := allowed(10); got != 10 {
+		t.Errorf("with rate limiting disabled, allowed %d requests, want 10", got)
+	}
+}
+
 func TestChiMiddleware_RateLimitByIP(t *testing.T) {
 	config := &ChiMiddlewareConfig{
 		RateLimitDisabled: false,
@@ -0,0 +1,204 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// backupRequestTimeout bounds backup and restore requests, which run
+// synchronously and can take far longer than an analytics query.
+const backupRequestTimeout = 30 * time.Minute
+
+// vpnUpdateRequestTimeout bounds a forced VPN data update: the download
+// with its retries, then the database writes.
+const vpnUpdateRequestTimeout = 5 * time.Minute
+
+// RequestTimeouts enforces a deadline on each request's context, so a slow
+// handler and the database queries it runs are canceled instead of tying up
+// server resources after the client has given up.
+//
+// The deadline reaches the database through r.Context(): QueryContext and
+// ExecContext abort the query once it passes. If the handler has not written a
+// response by then, the client gets 504 REQUEST_TIMEOUT instead of whatever
+// error the canceled query produced.
+//
+// Routes that legitimately run longer, such as backups, are given their own
+// timeout with Override. A zero timeout disables the deadline for long-lived
+// connections like the WebSocket.
+type RequestTimeouts struct {
+	defaultTimeout time.Duration
+	overrides      map[string]time.Duration // keyed by "METHOD /path"
+}
+
+// NewRequestTimeouts creates request timeouts with the given default.
+// A non-positive timeout uses config.DefaultRequestTimeout.
+func NewRequestTimeouts(timeout time.Duration) *RequestTimeouts {
+	if timeout <= 0 {
+		timeout = config.DefaultRequestTimeout
+	}
+	return &RequestTimeouts{
+		defaultTimeout: timeout,
+		overrides:      make(map[string]time.Duration),
+	}
+}
+
+// Override sets the timeout for one route, matched by method and exact
+// request path. A zero timeout means no deadline.
+//
+// Thread Safety: Not safe for concurrent use; call before serving requests.
+func (t *RequestTimeouts) Override(method, path string, timeout time.Duration) *RequestTimeouts {
+	t.overrides[method+" "+path] = timeout
+	return t
+}
+
+// TimeoutFor returns the timeout that applies to a request.
+func (t *RequestTimeouts) TimeoutFor(r *http.Request) time.Duration {
+	if timeout, ok := t.overrides[r.Method+" "+r.URL.Path]; ok {
+		return timeout
+	}
+	return t.defaultTimeout
+}
+
+// Middleware returns a Chi-compatible middleware enforcing the timeouts.
+func (t *RequestTimeouts) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := t.TimeoutFor(r)
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutResponseWriter{
+				ResponseWriter: w,
+				r:              r,
+				ctx:            ctx,
+				header:         w.Header().Clone(),
+				timeout:        timeout,
+			}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				if !tw.wroteHeader {
+					tw.WriteHeader(http.StatusOK) // Replaced by the timeout response
+				}
+				if tw.timedOut {
+					logging.Warn().
+						Str("method", r.Method).
+						Str("path", r.URL.Path).
+						Dur("timeout", timeout).
+						Msg("Request timed out")
+				}
+			}
+		})
+	}
+}
+
+// timeoutResponseWriter replaces the handler's response with 504 when the
+// handler starts responding after the deadline has passed. Headers set by the
+// handler are held back until it writes, so they do not leak into the timeout
+// response.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+	r           *http.Request // negotiates the timeout error format
+	ctx         context.Context
+	header      http.Header
+	timeout     time.Duration
+	wroteHeader bool
+	timedOut    bool
+}
+
+// Header returns the handler's header map.
+func (w *timeoutResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader sends the handler's status, or the timeout response if the
+// deadline has already passed.
+func (w *timeoutResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		w.timedOut = true
+		respondError(w.ResponseWriter, w.r, http.StatusGatewayTimeout, "REQUEST_TIMEOUT",
+			fmt.Sprintf("Request exceeded the %s time limit", w.timeout), nil)
+		return
+	}
+
+	dst := w.ResponseWriter.Header()
+	for k, v := range w.header {
+		dst[k] = v
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes the handler's body, discarding it after a timeout response.
+func (w *timeoutResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher for streaming handlers.
+func (w *timeoutResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.timedOut {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *timeoutResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// requestTimeouts builds the timeouts for SetupChi from HTTP_REQUEST_TIMEOUT,
+// with longer timeouts for backup routes and none for the WebSocket or the
+// CPU profile and trace (those run for the requested ?seconds).
+func (router *Router) requestTimeouts() *RequestTimeouts {
+	var timeout time.Duration
+	if router.handler != nil && router.handler.config != nil {
+		timeout = router.handler.config.Server.RequestTimeout
+	}
+
+	return NewRequestTimeouts(timeout).
+		Override(http.MethodGet, "/api/v1/ws", 0).
+		Override(http.MethodPost, "/api/v1/backup", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/backup/", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/backup/quick", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/backup/schedule/trigger", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/backup/retention/apply", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/backups/restore", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/backups/upload", backupRequestTimeout).
+		Override(http.MethodGet, "/api/v1/backups/download", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/admin/import/jellystat", backupRequestTimeout).
+		Override(http.MethodPost, "/api/v1/admin/vpn/update", vpnUpdateRequestTimeout).
+		Override(http.MethodGet, "/debug/pprof/profile", 0).
+		Override(http.MethodGet, "/debug/pprof/trace", 0)
+}
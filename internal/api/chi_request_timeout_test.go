@@ -0,0 +1,192 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_ "github.com/duckdb/duckdb-go/v2"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func decodeTimeoutResponse(t *testing.T, rec *httptest.ResponseRecorder) models.APIResponse {
+	t.Helper()
+	var resp models.APIResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestRequestTimeouts_CancelsDatabaseQuery(t *testing.T) {
+	conn, err := sql.Open("duckdb", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open duckdb: %v", err)
+	}
+	defer conn.Close()
+
+	// A cross join that would run for hours unless canceled
+	queryErr := make(chan error, 1)
+	slowHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sum int64
+		err := conn.QueryRowContext(r.Context(),
+			"SELECT sum(a.range * b.range) FROM range(1000000000) a, range(1000000000) b").Scan(&sum)
+		queryErr <- err
+		if err != nil {
+			respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to query", err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := NewRequestTimeouts(100 * time.Millisecond).Middleware()(slowHandler)
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/trends", nil))
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("request took %v, want the query canceled near the deadline", elapsed)
+	}
+	if err := <-queryErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("query error = %v, want context.DeadlineExceeded from the request deadline", err)
+	}
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want 504", rec.Code)
+	}
+	if resp := decodeTimeoutResponse(t, rec); resp.Error == nil || resp.Error.Code != "REQUEST_TIMEOUT" {
+		t.Errorf("response = %+v, want REQUEST_TIMEOUT error", resp)
+	}
+}
+
+func TestRequestTimeouts_HandlerReturnsWithoutWriting(t *testing.T) {
+	handler := NewRequestTimeouts(10 * time.Millisecond).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", "attachment; filename=export.csv")
+		<-r.Context().Done()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/export/playbacks/csv", nil))
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want 504", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != "" {
+		t.Errorf("Content-Disposition = %q, want handler headers dropped from timeout response", got)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+}
+
+func TestRequestTimeouts_FastHandlerPassesThrough(t *testing.T) {
+	var deadline time.Time
+	handler := NewRequestTimeouts(time.Minute).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, _ = r.Context().Deadline()
+		w.Header().Set("X-Handler", "yes")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("done"))
+		w.(http.Flusher).Flush()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/detection/rules", nil))
+
+	if rec.Code != http.StatusCreated || rec.Body.String() != "done" || rec.Header().Get("X-Handler") != "yes" {
+		t.Errorf("got %d %q headers %v, want handler response unchanged", rec.Code, rec.Body.String(), rec.Header())
+	}
+	if !rec.Flushed {
+		t.Error("Flush was not passed through")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("context deadline in %v, want within the 1m timeout", remaining)
+	}
+}
+
+func TestRequestTimeouts_ResponseStartedBeforeDeadline(t *testing.T) {
+	handler := NewRequestTimeouts(10 * time.Millisecond).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		<-r.Context().Done()
+		if _, err := w.Write([]byte("partial")); err != nil {
+			t.Errorf("Write after deadline: %v, want streamed response to continue", err)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stream/locations-geojson", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want the already-sent 200", rec.Code)
+	}
+}
+
+func TestRequestTimeouts_Override(t *testing.T) {
+	timeouts := NewRequestTimeouts(time.Second).
+		Override(http.MethodGet, "/api/v1/ws", 0).
+		Override(http.MethodPost, "/api/v1/backups/restore", time.Hour)
+
+	var hasDeadline bool
+	handler := timeouts.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/ws", nil))
+	if hasDeadline {
+		t.Error("zero override should leave the request without a deadline")
+	}
+
+	if got := timeouts.TimeoutFor(httptest.NewRequest(http.MethodPost, "/api/v1/backups/restore", nil)); got != time.Hour {
+		t.Errorf("override timeout = %v, want 1h", got)
+	}
+	if got := timeouts.TimeoutFor(httptest.NewRequest(http.MethodGet, "/api/v1/backups/restore", nil)); got != time.Second {
+		t.Errorf("other method timeout = %v, want default 1s", got)
+	}
+}
+
+func TestRouter_RequestTimeoutsFromConfig(t *testing.T) {
+	router := &Router{handler: &Handler{config: &config.Config{Server: config.ServerConfig{RequestTimeout: 5 * time.Second}}}}
+	timeouts := router.requestTimeouts()
+
+	if got := timeouts.TimeoutFor(httptest.NewRequest(http.MethodGet, "/api/v1/analytics/trends", nil)); got != 5*time.Second {
+		t.Errorf("default timeout = %v, want HTTP_REQUEST_TIMEOUT 5s", got)
+	}
+	if got := timeouts.TimeoutFor(httptest.NewRequest(http.MethodPost, "/api/v1/backup", nil)); got != backupRequestTimeout {
+		t.Errorf("backup timeout = %v, want %v", got, backupRequestTimeout)
+	}
+	if got := timeouts.TimeoutFor(httptest.NewRequest(http.MethodGet, "/api/v1/ws", nil)); got != 0 {
+		t.Errorf("WebSocket timeout = %v, want none", got)
+	}
+
+	if got := (&Router{}).requestTimeouts().TimeoutFor(httptest.NewRequest(http.MethodGet, "/", nil)); got != config.DefaultRequestTimeout {
+		t.Errorf("timeout without config = %v, want %v", got, config.DefaultRequestTimeout)
+	}
+}
+
+func TestRequestTimeouts_ClientCancelIsNotTimeout(t *testing.T) {
+	handler := NewRequestTimeouts(time.Minute).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		if !errors.Is(r.Context().Err(), context.Canceled) {
+			t.Errorf("ctx.Err() = %v, want context.Canceled", r.Context().Err())
+		}
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil).WithContext(ctx))
+
+	if rec.Code == http.StatusGatewayTimeout {
+		t.Error("client cancellation should not produce a timeout response")
+	}
+}
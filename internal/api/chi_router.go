@@ -7,12 +7,14 @@
 package api
 
 import (
+	"expvar"
 	"net/http"
+	"net/http/pprof"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/middleware"
 )
 
@@ -25,6 +27,17 @@ func chiMiddleware(mw func(http.HandlerFunc) http.HandlerFunc) func(http.Handler
 	}
 }
 
+// accessLogger builds the access log middleware from the LOG_ACCESS_*
+// settings. Without a config every request is logged.
+func (router *Router) accessLogger() *middleware.AccessLogger {
+	var cfg middleware.AccessLogConfig
+	if router.handler != nil && router.handler.config != nil {
+		cfg.SuccessSampleRate = router.handler.config.Logging.AccessSampleRate
+		cfg.SlowThreshold = router.handler.config.Logging.AccessSlowThreshold
+	}
+	return middleware.NewAccessLogger(cfg)
+}
+
 // SetupChi configures all HTTP routes using Chi router.
 // This replaces the http.ServeMux-based Setup() method.
 func (router *Router) SetupChi() http.Handler {
@@ -34,11 +47,15 @@ func (router *Router) SetupChi() http.Handler {
 	// Global Middleware Stack
 	// ========================
 	// Applied to ALL routes in order
-	r.Use(RequestIDWithLogging())      // Add X-Request-ID header with logging context
-	r.Use(E2EDebugLogging())           // E2E diagnostic logging (enabled via E2E_DEBUG=true)
-	r.Use(chimiddleware.RealIP)        // Extract real IP from X-Forwarded-For
-	r.Use(chimiddleware.Recoverer)     // Recover from panics
-	r.Use(router.chiMiddleware.CORS()) // CORS must be global to handle OPTIONS preflight
+	r.Use(RequestIDWithLogging())                          // Add X-Request-ID header with logging context
+	r.Use(chiMiddleware(middleware.Tracing))               // OpenTelemetry server span per request (no-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set)
+	r.Use(E2EDebugLogging())                               // E2E diagnostic logging (enabled via E2E_DEBUG=true)
+	r.Use(chimiddleware.RealIP)                            // Extract real IP from X-Forwarded-For
+	r.Use(chiMiddleware(router.accessLogger().Middleware)) // One log line per request (sampled per LOG_ACCESS_*); bodies at debug level
+	r.Use(chimiddleware.Recoverer)                         // Recover from panics
+	r.Use(router.chiMiddleware.CORS())                     // CORS must be global to handle OPTIONS preflight
+	r.Use(router.bodySizeLimiter().Middleware())           // 413 for bodies over HTTP_MAX_BODY_SIZE (uploads allow more)
+	r.Use(router.requestTimeouts().Middleware())           // 504 and query cancellation after HTTP_REQUEST_TIMEOUT
 
 	// ========================
 	// Health Endpoints
@@ -89,7 +106,11 @@ func (router *Router) SetupChi() http.Handler {
 		r.Get("/users", router.handler.Users)
 		r.Get("/media-types", router.handler.MediaTypes)
 		r.Get("/server-info", router.handler.ServerInfo)
+		r.Get("/sources/health", router.handler.SourcesHealth)
+		r.Get("/now-playing", router.handler.NowPlaying)
+		r.Get("/watch-parties", router.handler.WatchPartiesList)
 		r.Get("/ws", router.handler.WebSocket)
+		r.Post("/batch", newBatchExecutor(router.handler.batchEndpoints(), router.handler.acquireAnalyticsSlot).Batch) // Several GET endpoints in one request
 	})
 
 	// ========================
@@ -128,6 +149,7 @@ func (router *Router) SetupChi() http.Handler {
 		r.Get("/library", router.handler.AnalyticsLibrary)
 		r.Get("/hardware-transcode", router.handler.AnalyticsHardwareTranscode)
 		r.Get("/hardware-transcode/trends", router.handler.AnalyticsHardwareTranscodeTrends)
+		r.Get("/transcode-reasons", router.handler.AnalyticsTranscodeReasons)
 		r.Get("/hdr-content", router.handler.AnalyticsHDRContent)
 
 		// Enhanced analytics (production-grade insights)
@@ -158,6 +180,8 @@ func (router *Router) SetupChi() http.Handler {
 			r.Get("/content/{id}", router.handler.CrossPlatformContentStats)
 			r.Get("/summary", router.handler.CrossPlatformSummary)
 		})
+
+		r.Post("/batch", newAnalyticsBatchExecutor(router.handler).Batch) // Several analytics queries in one request
 	})
 
 	// ========================
@@ -371,6 +395,16 @@ func (router *Router) SetupChi() http.Handler {
 		r.Post("/terminate-session", router.handler.TautulliTerminateSession)
 	})
 
+	// ========================
+	// Tautulli-Compatible API
+	// ========================
+	// Answers Tautulli API v2 commands from local data (TAUTULLI_COMPAT_API).
+	// Accepts TAUTULLI_COMPAT_API_KEY as apikey, or regular authentication.
+	if router.handler != nil && router.handler.config != nil && router.handler.config.Tautulli.CompatAPI {
+		r.With(router.chiMiddleware.RateLimit()).Get("/api/v2",
+			router.handler.TautulliCompatAuth(router.middleware.Authenticate, router.handler.TautulliCompatAPI))
+	}
+
 	// ========================
 	// Export Endpoints
 	// ========================
@@ -383,6 +417,7 @@ func (router *Router) SetupChi() http.Handler {
 		r.Get("/geoparquet", router.handler.ExportGeoParquet)
 		r.Get("/geojson", router.handler.ExportGeoJSON)
 		r.Get("/playbacks/csv", router.handler.ExportPlaybacksCSV)
+		r.Get("/playbacks/geojson", router.handler.ExportPlaybacksGeoJSON)
 		r.Get("/locations/geojson", router.handler.ExportLocationsGeoJSON)
 	})
 
@@ -551,9 +586,45 @@ func (router *Router) SetupChi() http.Handler {
 	router.registerChiDedupeRoutes(r)
 
 	// ========================
-	// Import Routes (NATS)
+	// Quarantined Events
+	// ========================
+	// Events held back for implausible timestamps (admin review and reprocess)
+	router.registerChiQuarantineRoutes(r)
+
+	// ========================
+	// User Data Erasure and Export
+	// ========================
+	// Data subject requests: erase or export everything stored about a user
+	router.registerChiUserDataRoutes(r)
+
+	// ========================
+	// Privacy
+	// ========================
+	// Rewrite stored client IP addresses after enabling PRIVACY_IP_MODE
+	router.registerChiPrivacyRoutes(r)
+
+	// ========================
+	// Session Stitching
+	// ========================
+	// Restitch all playback history into logical viewing sessions
+	router.registerChiSessionStitchRoutes(r)
+
 	// ========================
-	// Registered dynamically when NATS is enabled
+	// Watch Party Feedback
+	// ========================
+	// Marking detected watch parties as false positives
+	router.registerChiWatchPartyRoutes(r)
+
+	// ========================
+	// VPN Data Updates
+	// ========================
+	// Update history and forced refresh of the gluetun VPN dataset
+	router.registerChiVPNRoutes(r)
+
+	// ========================
+	// Import Routes
+	// ========================
+	// Registered dynamically when import is enabled
 	if router.importRouteRegistrar != nil {
 		router.registerChiImportRoutes(r)
 	}
@@ -571,10 +642,19 @@ func (router *Router) SetupChi() http.Handler {
 		router.registerChiZeroTrustRoutes(r)
 	}
 
+	// ========================
+	// Runtime Diagnostics
+	// ========================
+	// pprof, expvar, goroutine dump, runtime summary (DIAGNOSTICS_ENABLED);
+	// pprof alone with ENABLE_PPROF
+	if router.diagnosticsHandlers != nil {
+		router.registerChiDiagnosticsRoutes(r)
+	}
+
 	// ========================
 	// Observability
 	// ========================
-	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/metrics", metrics.Handler())
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
 		httpSwagger.DeepLinking(true),
@@ -596,16 +676,10 @@ func (router *Router) SetupChi() http.Handler {
 }
 
 // registerChiImportRoutes adds import routes using Chi router.
-// This is called when NATS is enabled.
+// This is called when NATS is enabled; the routes themselves are supplied
+// by the importRouteRegistrar set during import initialization.
 func (router *Router) registerChiImportRoutes(r chi.Router) {
-	// Note: Import routes are registered via importRouteRegistrar
-	// which still uses the old mux pattern. For full Chi integration,
-	// import_handlers would need to be updated to work with Chi.
-	// For now, we mount import routes at a sub-path.
-
-	// The importRouteRegistrar expects *http.ServeMux, so we need
-	// a different approach. We'll register the handlers directly here
-	// if import handlers are available.
+	router.importRouteRegistrar(r)
 }
 
 // registerChiSyncRoutes adds sync routes for the Data Sync UI.
@@ -770,6 +844,68 @@ func (router *Router) registerChiZeroTrustRoutes(r chi.Router) {
 		})
 	})
 
+	// ========================
+	// Notification Settings
+	// ========================
+	// POST /api/v1/admin/notifications/test-email - Verify SMTP settings
+	r.Route("/api/v1/admin/notifications", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Post("/test-email", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.NotificationTestEmail)).ServeHTTP)
+	})
+
+	// ========================
+	// Runtime Logging
+	// ========================
+	// PUT /api/v1/admin/log-level - Change the global log level without a restart
+	r.Route("/api/v1/admin/log-level", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Put("/", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.SetLogLevel)).ServeHTTP)
+	})
+
+	// ========================
+	// Effective Configuration
+	// ========================
+	// GET /api/v1/admin/config - Effective configuration with secrets masked
+	// POST /api/v1/admin/config/reload - Apply changed settings without a restart
+	r.Route("/api/v1/admin/config", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Get("/", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.AdminConfig)).ServeHTTP)
+		r.Post("/reload", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.ReloadConfig)).ServeHTTP)
+	})
+
+	// ========================
+	// Database Diagnostics
+	// ========================
+	// GET /api/v1/admin/db/slow-queries - Recent statements over DB_SLOW_QUERY_THRESHOLD
+	// GET /api/v1/admin/db/retention/dry-run - What the RETENTION_* limits would prune
+	r.Route("/api/v1/admin/db", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Get("/slow-queries", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.SlowQueries)).ServeHTTP)
+		r.Get("/retention/dry-run", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.RetentionDryRun)).ServeHTTP)
+	})
+
 	// ========================
 	// Mock Data Seeding (CI/Development only)
 	// ========================
@@ -789,7 +925,7 @@ func (router *Router) registerChiZeroTrustRoutes(r chi.Router) {
 func (router *Router) handleChiRevokeSession(w http.ResponseWriter, req *http.Request) {
 	sessionID := chi.URLParam(req, "id")
 	if sessionID == "" {
-		http.Error(w, "Session ID required", http.StatusBadRequest)
+		respondError(w, req, http.StatusBadRequest, "INVALID_REQUEST", "Session ID required", nil)
 		return
 	}
 	router.flowHandlers.RevokeSession(w, req, sessionID)
@@ -799,7 +935,7 @@ func (router *Router) handleChiRevokeSession(w http.ResponseWriter, req *http.Re
 func (router *Router) handleChiRolePermissions(w http.ResponseWriter, req *http.Request) {
 	role := chi.URLParam(req, "role")
 	if role == "" {
-		http.Error(w, "Role required", http.StatusBadRequest)
+		respondError(w, req, http.StatusBadRequest, "INVALID_REQUEST", "Role required", nil)
 		return
 	}
 	router.policyHandlers.GetRolePermissions(w, req, role)
@@ -878,6 +1014,17 @@ func (router *Router) registerChiNewsletterRoutes(r chi.Router) {
 
 		r.Get("/stats", router.handler.NewsletterStats)
 		r.Get("/audit", router.handler.NewsletterAuditLog)
+		r.Get("/delivery-log", router.handler.NewsletterDeliveryLog)
+	})
+
+	// Newsletter Feeds (viewer): RSS of deliveries, iCalendar of upcoming runs
+	r.Route("/api/v1/newsletter/feeds", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Get("/deliveries.rss", router.handler.NewsletterDeliveriesFeed)
+		r.Get("/schedules.ics", router.handler.NewsletterSchedulesCalendar)
 	})
 
 	// User Newsletter Preferences (authenticated users can manage their own)
@@ -914,6 +1061,109 @@ func (router *Router) registerChiDedupeRoutes(r chi.Router) {
 	})
 }
 
+// registerChiQuarantineRoutes adds admin routes for events quarantined by
+// timestamp validation.
+func (router *Router) registerChiQuarantineRoutes(r chi.Router) {
+	r.Route("/api/v1/admin/quarantine", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(chiPathValue) // Bridge Chi URL params to r.PathValue()
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Get("/", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.QuarantineList)).ServeHTTP)
+		r.Get("/{id}", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.QuarantineGet)).ServeHTTP)
+		r.Post("/{id}/reprocess", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.QuarantineReprocess)).ServeHTTP)
+	})
+}
+
+// registerChiUserDataRoutes adds admin routes that erase or export
+// everything stored about one internal user.
+func (router *Router) registerChiUserDataRoutes(r chi.Router) {
+	r.Route("/api/v1/admin/users", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(chiPathValue) // Bridge Chi URL params to r.PathValue()
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Post("/{id}/erase", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.UserErase)).ServeHTTP)
+		r.Get("/{id}/export", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.UserExport)).ServeHTTP)
+	})
+}
+
+// registerChiPrivacyRoutes adds the admin route that applies
+// PRIVACY_IP_MODE to IP addresses stored before it was enabled.
+func (router *Router) registerChiPrivacyRoutes(r chi.Router) {
+	r.Route("/api/v1/admin/privacy", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Post("/anonymize-ips", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.PrivacyAnonymizeIPs)).ServeHTTP)
+	})
+}
+
+// registerChiSessionStitchRoutes adds the admin route that restitches all
+// playback history into logical viewing sessions.
+func (router *Router) registerChiSessionStitchRoutes(r chi.Router) {
+	r.Route("/api/v1/admin/sessions", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Post("/stitch", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.SessionsStitch)).ServeHTTP)
+	})
+}
+
+// registerChiWatchPartyRoutes adds the admin routes that mark detected watch
+// parties as false positives and clear the mark.
+func (router *Router) registerChiWatchPartyRoutes(r chi.Router) {
+	r.Route("/api/v1/admin/watch-parties", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(chiPathValue) // Bridge Chi URL params to r.PathValue()
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Post("/{id}/false-positive", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.WatchPartyMarkFalsePositive)).ServeHTTP)
+		r.Delete("/{id}/false-positive", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.WatchPartyUnmarkFalsePositive)).ServeHTTP)
+	})
+}
+
+// registerChiVPNRoutes adds the admin routes for the VPN dataset updates
+// and CIDR ranges.
+func (router *Router) registerChiVPNRoutes(r chi.Router) {
+	r.Route("/api/v1/admin/vpn", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Get("/updates", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.VPNUpdates)).ServeHTTP)
+		r.Post("/update", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.VPNUpdate)).ServeHTTP)
+		r.Get("/ranges", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.VPNRanges)).ServeHTTP)
+		r.Post("/ranges", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.VPNRangeAdd)).ServeHTTP)
+		r.Delete("/ranges", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.handler.VPNRangeRemove)).ServeHTTP)
+	})
+}
+
 // registerChiDetectionRoutes adds detection-related routes using Chi router.
 // ADR-0020: Detection rules engine for media playback security monitoring.
 // SECURITY FIX: Detection/security data requires authentication
@@ -930,14 +1180,29 @@ func (router *Router) registerChiDetectionRoutes(r chi.Router) {
 		r.Get("/rules", router.detectionHandlers.ListRules)
 		r.Get("/rules/{type}", router.detectionHandlers.GetRule)
 		r.Get("/users/{id}/trust", router.detectionHandlers.GetUserTrustScore)
+		r.Get("/users/{id}/trust/history", router.detectionHandlers.GetUserTrustHistory)
 		r.Get("/users/low-trust", router.detectionHandlers.ListLowTrustUsers)
 		r.Get("/metrics", router.detectionHandlers.GetEngineMetrics)
 		r.Get("/stats", router.detectionHandlers.GetAlertStats)
+		r.Get("/allowlist", router.detectionHandlers.ListAllowlist)
+		r.Get("/users/{id}/devices", router.detectionHandlers.ListUserDevices)
 
 		// Write operations
 		r.Post("/alerts/{id}/acknowledge", router.detectionHandlers.AcknowledgeAlert)
 		r.Put("/rules/{type}", router.detectionHandlers.UpdateRule)
 		r.Post("/rules/{type}/enable", router.detectionHandlers.SetRuleEnabled)
+		r.Post("/allowlist", router.detectionHandlers.AddAllowlistEntry)
+		r.Delete("/allowlist/{id}", router.detectionHandlers.DeleteAllowlistEntry)
+
+		// Manual trust score changes require admin role
+		r.Put("/users/{id}/trust", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.detectionHandlers.SetUserTrustScore)).ServeHTTP)
+		r.Post("/users/{id}/trust/reset", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.detectionHandlers.ResetUserTrustScore)).ServeHTTP)
+
+		// Renaming and flagging devices requires admin role
+		r.Patch("/users/{id}/devices/{deviceID}", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.detectionHandlers.UpdateUserDevice)).ServeHTTP)
 	})
 }
 
@@ -1029,6 +1294,38 @@ func (router *Router) registerChiReplayRoutes(r chi.Router) {
 	})
 }
 
+// registerChiDiagnosticsRoutes adds the /debug routes for profiling a
+// running server. All of them require the admin role and every request is
+// audited. They are not rate limited: a profiling session fetches several
+// profiles in quick succession. With ConfigurePprof only /debug/pprof is
+// registered.
+func (router *Router) registerChiDiagnosticsRoutes(r chi.Router) {
+	r.Route("/debug", func(r chi.Router) {
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+		r.Use(func(next http.Handler) http.Handler {
+			return router.sessionMiddleware.RequireRole("admin", next)
+		})
+		r.Use(router.diagnosticsHandlers.AuditAccess)
+
+		// pprof (named profiles such as heap and allocs are served by Index)
+		r.Get("/pprof/*", pprof.Index)
+		r.Get("/pprof/cmdline", pprof.Cmdline)
+		r.Get("/pprof/profile", pprof.Profile)
+		r.Get("/pprof/symbol", pprof.Symbol)
+		r.Post("/pprof/symbol", pprof.Symbol)
+		r.Get("/pprof/trace", pprof.Trace)
+
+		if router.diagnosticsPprofOnly {
+			return
+		}
+		r.Get("/vars", expvar.Handler().ServeHTTP)
+		r.Get("/goroutines", router.diagnosticsHandlers.GoroutineDump)
+		r.Get("/runtime", router.diagnosticsHandlers.RuntimeSummary)
+	})
+}
+
 // registerChiRecommendRoutes adds recommendation engine routes using Chi router.
 // ADR-0024: Hybrid recommendation engine for media content.
 // SECURITY: All recommendation endpoints require authentication.
@@ -1058,4 +1355,35 @@ func (router *Router) registerChiRecommendRoutes(r chi.Router) {
 		r.Get("/similar/{itemID}", router.recommendHandler.GetSimilar)
 		r.Get("/next/{itemID}", router.recommendHandler.GetWhatsNext)
 	})
+
+	// Top-K recommendations with catalog metadata and explanations, "more
+	// like this" item neighbors, explicit feedback on recommendations, A/B
+	// experiment clicks, and the engine diagnosis
+	r.Group(func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate)) // SECURITY: Require auth for recommendations
+		r.Get("/api/v1/recommend", router.recommendHandler.Recommend)
+		r.Get("/api/v1/recommend/status", router.recommendHandler.RecommendStatus)
+		r.Get("/api/v1/recommend/similar/{ratingKey}", router.recommendHandler.RecommendSimilar)
+		r.Post("/api/v1/recommend/feedback", router.recommendHandler.RecordFeedback)
+		r.Get("/api/v1/recommend/feedback", router.recommendHandler.GetFeedback)
+		r.Post("/api/v1/recommend/click", router.recommendHandler.RecordClick)
+	})
+
+	// Training coordinator status, manual runs, and A/B experiment
+	// outcomes (admin only)
+	r.Route("/api/v1/admin/recommend", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Get("/training", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.recommendHandler.GetTrainingStatus)).ServeHTTP)
+		r.Post("/train", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.recommendHandler.TriggerScheduledTraining)).ServeHTTP)
+		r.Get("/experiment", router.sessionMiddleware.RequireRole("admin",
+			http.HandlerFunc(router.recommendHandler.GetExperimentReport)).ServeHTTP)
+	})
 }
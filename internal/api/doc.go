@@ -75,8 +75,9 @@ Performance Characteristics:
 
   - Response times: p95 <100ms for most endpoints (target)
   - Caching: 5-minute TTL for analytics endpoints
-  - Streaming: Supports chunked transfer encoding for large exports
-  - Compression: Gzip middleware for responses >1KB
+  - Streaming: Supports chunked transfer encoding for large exports; the
+    GeoJSON stream encodes rows as they are read from DuckDB
+  - Compression: Brotli/gzip middleware for responses >1KB
 
 Thread Safety:
 
@@ -0,0 +1,266 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ErrorCode is a machine-readable error code returned in the "code" member of
+// error responses, in both the legacy envelope and problem+json documents.
+// Clients match on codes, so existing values must not change.
+type ErrorCode string
+
+// Error code catalog. Every code passed to respondError must be listed here
+// with a title (see TestErrorCatalog_CoversHandlers).
+const (
+	ErrCodeAdminRequired              ErrorCode = "ADMIN_REQUIRED"
+	ErrCodeApplyFailed                ErrorCode = "APPLY_FAILED"
+	ErrCodeAuditError                 ErrorCode = "AUDIT_ERROR"
+	ErrCodeAuthDisabled               ErrorCode = "AUTH_DISABLED"
+	ErrCodeAuthNotConfigured          ErrorCode = "AUTH_NOT_CONFIGURED"
+	ErrCodeAuthRequired               ErrorCode = "AUTH_REQUIRED"
+	ErrCodeBackupDisabled             ErrorCode = "BACKUP_DISABLED"
+	ErrCodeBackupFailed               ErrorCode = "BACKUP_FAILED"
+	ErrCodeBadRequest                 ErrorCode = "BAD_REQUEST"
+	ErrCodeCleanupFailed              ErrorCode = "CLEANUP_FAILED"
+	ErrCodeConfigurationError         ErrorCode = "CONFIGURATION_ERROR"
+	ErrCodeConfigWriteFailed          ErrorCode = "CONFIG_WRITE_FAILED"
+	ErrCodeConflict                   ErrorCode = "CONFLICT"
+	ErrCodeConnectionFailed           ErrorCode = "CONNECTION_FAILED"
+	ErrCodeContentError               ErrorCode = "CONTENT_ERROR"
+	ErrCodeCreateError                ErrorCode = "CREATE_ERROR"
+	ErrCodeDatabaseError              ErrorCode = "DATABASE_ERROR"
+	ErrCodeDBError                    ErrorCode = "DB_ERROR"
+	ErrCodeDecryptionError            ErrorCode = "DECRYPTION_ERROR"
+	ErrCodeDeleteFailed               ErrorCode = "DELETE_FAILED"
+	ErrCodeDetectionError             ErrorCode = "DETECTION_ERROR"
+	ErrCodeEncryptionError            ErrorCode = "ENCRYPTION_ERROR"
+	ErrCodeExportError                ErrorCode = "EXPORT_ERROR"
+	ErrCodeExtensionUnavailable       ErrorCode = "EXTENSION_UNAVAILABLE"
+	ErrCodeExternalServiceFail        ErrorCode = "EXTERNAL_SERVICE_FAILED"
+	ErrCodeFileError                  ErrorCode = "FILE_ERROR"
+	ErrCodeForbidden                  ErrorCode = "FORBIDDEN"
+	ErrCodeGenerationError            ErrorCode = "GENERATION_ERROR"
+	ErrCodeImmutable                  ErrorCode = "IMMUTABLE"
+	ErrCodeImportFailed               ErrorCode = "IMPORT_FAILED"
+	ErrCodeInternalError              ErrorCode = "INTERNAL_ERROR"
+	ErrCodeInvalidConfig              ErrorCode = "INVALID_CONFIG"
+	ErrCodeInvalidCredentials         ErrorCode = "INVALID_CREDENTIALS"
+	ErrCodeInvalidID                  ErrorCode = "INVALID_ID"
+	ErrCodeInvalidItemID              ErrorCode = "INVALID_ITEM_ID"
+	ErrCodeInvalidJSON                ErrorCode = "INVALID_JSON"
+	ErrCodeInvalidParameter           ErrorCode = "INVALID_PARAMETER"
+	ErrCodeInvalidPayload             ErrorCode = "INVALID_PAYLOAD"
+	ErrCodeInvalidPolicy              ErrorCode = "INVALID_POLICY"
+	ErrCodeInvalidRequest             ErrorCode = "INVALID_REQUEST"
+	ErrCodeInvalidSchedule            ErrorCode = "INVALID_SCHEDULE"
+	ErrCodeInvalidSettings            ErrorCode = "INVALID_SETTINGS"
+	ErrCodeInvalidSignal              ErrorCode = "INVALID_SIGNAL"
+	ErrCodeInvalidSignature           ErrorCode = "INVALID_SIGNATURE"
+	ErrCodeInvalidState               ErrorCode = "INVALID_STATE"
+	ErrCodeInvalidUserID              ErrorCode = "INVALID_USER_ID"
+	ErrCodeInvalidYear                ErrorCode = "INVALID_YEAR"
+	ErrCodeListFailed                 ErrorCode = "LIST_FAILED"
+	ErrCodeMethodNotAllowed           ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrCodeMissingExperiment          ErrorCode = "MISSING_EXPERIMENT"
+	ErrCodeMissingID                  ErrorCode = "MISSING_ID"
+	ErrCodeMissingSignature           ErrorCode = "MISSING_SIGNATURE"
+	ErrCodeMissingToken               ErrorCode = "MISSING_TOKEN"
+	ErrCodeModelNotTrained            ErrorCode = "MODEL_NOT_TRAINED"
+	ErrCodeNotBatchable               ErrorCode = "NOT_BATCHABLE"
+	ErrCodeNotFound                   ErrorCode = "NOT_FOUND"
+	ErrCodeOAuthError                 ErrorCode = "OAUTH_ERROR"
+	ErrCodeOAuthNotConfigured         ErrorCode = "OAUTH_NOT_CONFIGURED"
+	ErrCodeParseError                 ErrorCode = "PARSE_ERROR"
+	ErrCodePinExpired                 ErrorCode = "PIN_EXPIRED"
+	ErrCodePinNotFound                ErrorCode = "PIN_NOT_FOUND"
+	ErrCodePlexAPIError               ErrorCode = "PLEX_API_ERROR"
+	ErrCodePlexDisabled               ErrorCode = "PLEX_DISABLED"
+	ErrCodePlexError                  ErrorCode = "PLEX_ERROR"
+	ErrCodePlexNotConfigured          ErrorCode = "PLEX_NOT_CONFIGURED"
+	ErrCodePlexTVFailed               ErrorCode = "PLEX_TV_FAILED"
+	ErrCodePreviewFailed              ErrorCode = "PREVIEW_FAILED"
+	ErrCodeQueryCanceled              ErrorCode = "QUERY_CANCELED"
+	ErrCodeQueryError                 ErrorCode = "QUERY_ERROR"
+	ErrCodeQueryTimeout               ErrorCode = "QUERY_TIMEOUT"
+	ErrCodeRecommendationError        ErrorCode = "RECOMMENDATION_ERROR"
+	ErrCodeRefreshFailed              ErrorCode = "REFRESH_FAILED"
+	ErrCodeRegenerateError            ErrorCode = "REGENERATE_ERROR"
+	ErrCodeRenderError                ErrorCode = "RENDER_ERROR"
+	ErrCodeReplayError                ErrorCode = "REPLAY_ERROR"
+	ErrCodeRequestTimeout             ErrorCode = "REQUEST_TIMEOUT"
+	ErrCodeRequestTooLarge            ErrorCode = "REQUEST_TOO_LARGE"
+	ErrCodeRestoreFailed              ErrorCode = "RESTORE_FAILED"
+	ErrCodeRetryError                 ErrorCode = "RETRY_ERROR"
+	ErrCodeRevokeError                ErrorCode = "REVOKE_ERROR"
+	ErrCodeSeedFailed                 ErrorCode = "SEED_FAILED"
+	ErrCodeSeedForbidden              ErrorCode = "SEED_FORBIDDEN"
+	ErrCodeSeedNotAllowed             ErrorCode = "SEED_NOT_ALLOWED"
+	ErrCodeSendFailed                 ErrorCode = "SEND_FAILED"
+	ErrCodeServerError                ErrorCode = "SERVER_ERROR"
+	ErrCodeServerExists               ErrorCode = "SERVER_EXISTS"
+	ErrCodeServiceError               ErrorCode = "SERVICE_ERROR"
+	ErrCodeServiceUnavailable         ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrCodeSetupComplete              ErrorCode = "SETUP_COMPLETE"
+	ErrCodeSetupIncomplete            ErrorCode = "SETUP_INCOMPLETE"
+	ErrCodeSetupRequired              ErrorCode = "SETUP_REQUIRED"
+	ErrCodeSMTPNotConfigured          ErrorCode = "SMTP_NOT_CONFIGURED"
+	ErrCodeStatsFailed                ErrorCode = "STATS_FAILED"
+	ErrCodeTautulliError              ErrorCode = "TAUTULLI_ERROR"
+	ErrCodeTemplateError              ErrorCode = "TEMPLATE_ERROR"
+	ErrCodeTest                       ErrorCode = "TEST"
+	ErrCodeTestError                  ErrorCode = "TEST_ERROR"
+	ErrCodeTileGenerationError        ErrorCode = "TILE_GENERATION_ERROR"
+	ErrCodeTokenExchangeFailed        ErrorCode = "TOKEN_EXCHANGE_FAILED"
+	ErrCodeTokenGenerationFailed      ErrorCode = "TOKEN_GENERATION_FAILED"
+	ErrCodeTooManyPins                ErrorCode = "TOO_MANY_PINS"
+	ErrCodeTooManyRequests            ErrorCode = "TOO_MANY_REQUESTS"
+	ErrCodeTrainingError              ErrorCode = "TRAINING_ERROR"
+	ErrCodeTrainingInProgress         ErrorCode = "TRAINING_IN_PROGRESS"
+	ErrCodeTrainingPreconditionFailed ErrorCode = "TRAINING_PRECONDITION_FAILED"
+	ErrCodeTrainingUnavailable        ErrorCode = "TRAINING_UNAVAILABLE"
+	ErrCodeUnauthorized               ErrorCode = "UNAUTHORIZED"
+	ErrCodeUserInfoFailed             ErrorCode = "USER_INFO_FAILED"
+	ErrCodeValidationError            ErrorCode = "VALIDATION_ERROR"
+	ErrCodeValidationFailed           ErrorCode = "VALIDATION_FAILED"
+	ErrCodeWebhooksDisabled           ErrorCode = "WEBHOOKS_DISABLED"
+)
+
+// errorCodeTitles holds the problem+json title of each cataloged code: a
+// short summary that is the same for every occurrence of the problem.
+var errorCodeTitles = map[ErrorCode]string{
+	ErrCodeAdminRequired:              "Admin role required",
+	ErrCodeApplyFailed:                "Apply failed",
+	ErrCodeAuditError:                 "Audit error",
+	ErrCodeAuthDisabled:               "Authentication disabled",
+	ErrCodeAuthNotConfigured:          "Authentication not configured",
+	ErrCodeAuthRequired:               "Authentication required",
+	ErrCodeBackupDisabled:             "Backup disabled",
+	ErrCodeBackupFailed:               "Backup failed",
+	ErrCodeBadRequest:                 "Bad request",
+	ErrCodeCleanupFailed:              "Cleanup failed",
+	ErrCodeConfigurationError:         "Configuration error",
+	ErrCodeConfigWriteFailed:          "Config write failed",
+	ErrCodeConflict:                   "Conflict",
+	ErrCodeConnectionFailed:           "Connection failed",
+	ErrCodeContentError:               "Content error",
+	ErrCodeCreateError:                "Create error",
+	ErrCodeDatabaseError:              "Database error",
+	ErrCodeDBError:                    "DB error",
+	ErrCodeDecryptionError:            "Decryption error",
+	ErrCodeDeleteFailed:               "Delete failed",
+	ErrCodeDetectionError:             "Detection error",
+	ErrCodeEncryptionError:            "Encryption error",
+	ErrCodeExportError:                "Export error",
+	ErrCodeExtensionUnavailable:       "Extension unavailable",
+	ErrCodeExternalServiceFail:        "External service failed",
+	ErrCodeFileError:                  "File error",
+	ErrCodeForbidden:                  "Forbidden",
+	ErrCodeGenerationError:            "Generation error",
+	ErrCodeImmutable:                  "Resource is immutable",
+	ErrCodeImportFailed:               "Import failed",
+	ErrCodeInternalError:              "Internal error",
+	ErrCodeInvalidConfig:              "Invalid config",
+	ErrCodeInvalidCredentials:         "Invalid credentials",
+	ErrCodeInvalidID:                  "Invalid ID",
+	ErrCodeInvalidItemID:              "Invalid item ID",
+	ErrCodeInvalidJSON:                "Invalid JSON",
+	ErrCodeInvalidParameter:           "Invalid parameter",
+	ErrCodeInvalidPayload:             "Invalid payload",
+	ErrCodeInvalidPolicy:              "Invalid policy",
+	ErrCodeInvalidRequest:             "Invalid request",
+	ErrCodeInvalidSchedule:            "Invalid schedule",
+	ErrCodeInvalidSettings:            "Invalid settings",
+	ErrCodeInvalidSignal:              "Invalid signal",
+	ErrCodeInvalidSignature:           "Invalid signature",
+	ErrCodeInvalidState:               "Invalid state",
+	ErrCodeInvalidUserID:              "Invalid user ID",
+	ErrCodeInvalidYear:                "Invalid year",
+	ErrCodeListFailed:                 "List failed",
+	ErrCodeMethodNotAllowed:           "Method not allowed",
+	ErrCodeMissingExperiment:          "Missing experiment",
+	ErrCodeMissingID:                  "Missing ID",
+	ErrCodeMissingSignature:           "Missing signature",
+	ErrCodeMissingToken:               "Missing token",
+	ErrCodeModelNotTrained:            "Model not trained",
+	ErrCodeNotBatchable:               "Endpoint not available in a batch",
+	ErrCodeNotFound:                   "Not found",
+	ErrCodeOAuthError:                 "OAuth error",
+	ErrCodeOAuthNotConfigured:         "OAuth not configured",
+	ErrCodeParseError:                 "Parse error",
+	ErrCodePinExpired:                 "PIN expired",
+	ErrCodePinNotFound:                "PIN not found",
+	ErrCodePlexAPIError:               "Plex API error",
+	ErrCodePlexDisabled:               "Plex disabled",
+	ErrCodePlexError:                  "Plex error",
+	ErrCodePlexNotConfigured:          "Plex not configured",
+	ErrCodePlexTVFailed:               "Plex.tv request failed",
+	ErrCodePreviewFailed:              "Preview failed",
+	ErrCodeQueryCanceled:              "Query canceled",
+	ErrCodeQueryError:                 "Query error",
+	ErrCodeQueryTimeout:               "Query timeout",
+	ErrCodeRecommendationError:        "Recommendation error",
+	ErrCodeRefreshFailed:              "Refresh failed",
+	ErrCodeRegenerateError:            "Regenerate error",
+	ErrCodeRenderError:                "Render error",
+	ErrCodeReplayError:                "Replay error",
+	ErrCodeRequestTimeout:             "Request timeout",
+	ErrCodeRequestTooLarge:            "Request too large",
+	ErrCodeRestoreFailed:              "Restore failed",
+	ErrCodeRetryError:                 "Retry error",
+	ErrCodeRevokeError:                "Revoke error",
+	ErrCodeSeedFailed:                 "Seeding failed",
+	ErrCodeSeedForbidden:              "Seeding forbidden",
+	ErrCodeSeedNotAllowed:             "Seeding not allowed",
+	ErrCodeSendFailed:                 "Send failed",
+	ErrCodeServerError:                "Server error",
+	ErrCodeServerExists:               "Server already exists",
+	ErrCodeServiceError:               "Service error",
+	ErrCodeServiceUnavailable:         "Service unavailable",
+	ErrCodeSetupComplete:              "Setup already complete",
+	ErrCodeSetupIncomplete:            "Setup incomplete",
+	ErrCodeSetupRequired:              "Setup required",
+	ErrCodeSMTPNotConfigured:          "SMTP not configured",
+	ErrCodeStatsFailed:                "Statistics failed",
+	ErrCodeTautulliError:              "Tautulli error",
+	ErrCodeTemplateError:              "Template error",
+	ErrCodeTest:                       "Test failed",
+	ErrCodeTestError:                  "Test error",
+	ErrCodeTileGenerationError:        "Tile generation error",
+	ErrCodeTokenExchangeFailed:        "Token exchange failed",
+	ErrCodeTokenGenerationFailed:      "Token generation failed",
+	ErrCodeTooManyPins:                "Too many PINs",
+	ErrCodeTooManyRequests:            "Too many requests",
+	ErrCodeTrainingError:              "Training error",
+	ErrCodeTrainingInProgress:         "Training in progress",
+	ErrCodeTrainingPreconditionFailed: "Training precondition failed",
+	ErrCodeTrainingUnavailable:        "Training unavailable",
+	ErrCodeUnauthorized:               "Unauthorized",
+	ErrCodeUserInfoFailed:             "User info failed",
+	ErrCodeValidationError:            "Validation error",
+	ErrCodeValidationFailed:           "Validation failed",
+	ErrCodeWebhooksDisabled:           "Webhooks disabled",
+}
+
+// problemTypePrefix is the URI prefix of problem types; the lowercased code
+// completes it, e.g. urn:cartographus:problem:validation_error.
+const problemTypePrefix = "urn:cartographus:problem:"
+
+// Title returns the code's catalog title, falling back to the HTTP status
+// text for codes missing from the catalog.
+func (c ErrorCode) Title(status int) string {
+	if title, ok := errorCodeTitles[c]; ok {
+		return title
+	}
+	return http.StatusText(status)
+}
+
+// TypeURI returns the problem type URI identifying the code.
+func (c ErrorCode) TypeURI() string {
+	return problemTypePrefix + strings.ToLower(string(c))
+}
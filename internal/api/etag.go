@@ -0,0 +1,91 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// respondJSONWithETag sends a 200 JSON response with a strong ETag, or 304 Not
+// Modified with no body when the request's If-None-Match matches it.
+//
+// The ETag hashes the uncompressed Data payload together with the sync
+// generation, so it changes when the data does and after every sync. Metadata
+// (timestamp, query time, cached flag) is excluded because it differs on every
+// request. The hash is taken before the compression middleware runs, so gzip,
+// brotli, and identity responses share a tag (Vary: Accept-Encoding keeps
+// shared caches apart).
+//
+// Used by the analytics and stats handlers, which dashboards poll between syncs.
+func (h *Handler) respondJSONWithETag(w http.ResponseWriter, r *http.Request, response *models.APIResponse) {
+	payload, err := json.Marshal(response.Data)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to marshal JSON response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	etag := contentETag(payload, h.syncGeneration.Load())
+	setJSONCacheHeaders(w)
+	w.Header().Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// Reuse the payload instead of serializing the data twice
+	resp := *response
+	resp.Data = json.RawMessage(payload)
+	data, err := json.Marshal(&resp)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to marshal JSON response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		logging.Error().Err(err).Msg("Failed to write JSON response")
+	}
+}
+
+// bumpSyncGeneration invalidates every ETag issued by respondJSONWithETag.
+func (h *Handler) bumpSyncGeneration() {
+	h.syncGeneration.Add(1)
+}
+
+// contentETag builds a strong ETag from a payload hash and the sync generation.
+func contentETag(payload []byte, generation uint64) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write(payload) // hash.Hash never returns an error
+	return fmt.Sprintf(`"%x-%016x"`, generation, hash.Sum64())
+}
+
+// etagMatches reports whether an If-None-Match header matches etag. It uses
+// the weak comparison RFC 9110 requires for If-None-Match, so a tag weakened
+// by a proxy (W/"...") still matches.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/cache"
+	"github.com/tomtom215/cartographus/internal/middleware"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// etagTestHandler serves a large payload through respondJSONWithETag behind
+// the compression middleware, with a fresh timestamp on every request.
+func etagTestHandler(h *Handler) http.HandlerFunc {
+	data := map[string]string{"cities": strings.Repeat("Tokyo,", 500)}
+	return middleware.Compression(func(w http.ResponseWriter, r *http.Request) {
+		h.respondJSONWithETag(w, r, &models.APIResponse{
+			Status:   "success",
+			Data:     data,
+			Metadata: models.Metadata{Timestamp: time.Now()},
+		})
+	})
+}
+
+// getWithETag issues a GET with the given If-None-Match and Accept-Encoding
+func getWithETag(handler http.HandlerFunc, ifNoneMatch, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
+func TestRespondJSONWithETag_NotModified(t *testing.T) {
+	t.Parallel()
+
+	handler := etagTestHandler(&Handler{})
+
+	first := getWithETag(handler, "", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || first.Body.Len() == 0 {
+		t.Fatalf("first response = %d with %d bytes, want 200 with a body", first.Code, first.Body.Len())
+	}
+	if !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) {
+		t.Fatalf("ETag = %q, want a quoted strong tag", etag)
+	}
+
+	// The timestamp differs, but the data and therefore the ETag do not
+	second := getWithETag(handler, etag, "")
+	if second.Code != http.StatusNotModified {
+		t.Fatalf("conditional response = %d, want 304", second.Code)
+	}
+	if second.Body.Len() != 0 {
+		t.Errorf("304 body = %q, want empty", second.Body.String())
+	}
+	if got := second.Header().Get("ETag"); got != etag {
+		t.Errorf("304 ETag = %q, want %q", got, etag)
+	}
+
+	if w := getWithETag(handler, `"0-0000000000000000"`, ""); w.Code != http.StatusOK {
+		t.Errorf("stale If-None-Match = %d, want 200", w.Code)
+	}
+}
+
+func TestRespondJSONWithETag_ComposesWithCompression(t *testing.T) {
+	t.Parallel()
+
+	handler := etagTestHandler(&Handler{})
+	plain := getWithETag(handler, "", "")
+	gzipped := getWithETag(handler, "", "gzip")
+
+	if gzipped.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", gzipped.Header().Get("Content-Encoding"))
+	}
+	if plain.Header().Get("ETag") != gzipped.Header().Get("ETag") {
+		t.Errorf("ETags differ: identity %q, gzip %q; want the hash of the uncompressed payload",
+			plain.Header().Get("ETag"), gzipped.Header().Get("ETag"))
+	}
+
+	zr, err := gzip.NewReader(gzipped.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("read gzip body: %v", err)
+	}
+	if !strings.Contains(string(body), `"cities"`) {
+		t.Errorf("decompressed body = %.80s, want the payload", body)
+	}
+
+	// A 304 passes through the compression middleware without a body
+	notModified := getWithETag(handler, plain.Header().Get("ETag"), "gzip")
+	if notModified.Code != http.StatusNotModified || notModified.Body.Len() != 0 {
+		t.Errorf("compressed 304 = %d with %d bytes, want 304 and no body", notModified.Code, notModified.Body.Len())
+	}
+	if enc := notModified.Header().Get("Content-Encoding"); enc != "" {
+		t.Errorf("304 Content-Encoding = %q, want none", enc)
+	}
+}
+
+func TestRespondJSONWithETag_SyncInvalidates(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{cache: cache.New(time.Minute)}
+	handler := etagTestHandler(h)
+	etag := getWithETag(handler, "", "").Header().Get("ETag")
+
+	h.OnSyncCompleted(0, 10)
+
+	w := getWithETag(handler, etag, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status after sync = %d, want 200", w.Code)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Errorf("ETag %q unchanged after sync", etag)
+	}
+}
+
+func TestGeographicCacheHit_NotModified(t *testing.T) {
+	t.Parallel()
+
+	c := cache.New(5 * time.Minute)
+	c.Set("geo-key", models.GeographicResponse{
+		TopCities: []models.CityStats{{City: "Tokyo", Country: "JP", PlaybackCount: 50}},
+	})
+	h := &Handler{cache: c}
+
+	w := httptest.NewRecorder()
+	h.checkCacheAndReturnIfHit(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil), "geo-key", time.Now())
+	etag := w.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	if !h.checkCacheAndReturnIfHit(w, req, "geo-key", time.Now()) {
+		t.Fatal("expected a cache hit")
+	}
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("cached conditional response = %d with %d bytes, want 304 and no body", w.Code, w.Body.Len())
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"3-00000000deadbeef"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{etag, true},
+		{`W/"3-00000000deadbeef"`, true},
+		{`"1-0000000000000001", ` + etag, true},
+		{"*", true},
+		{`"2-00000000deadbeef"`, false},
+		{`3-00000000deadbeef`, false},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// geoJSONFlushEvery is how many features are written between flushes, so
+// clients receive a large collection progressively instead of all at once.
+const geoJSONFlushEvery = 100
+
+// geoJSONStreamWriter writes a GeoJSON FeatureCollection one feature at a
+// time: the collection header, each feature as it arrives, then the
+// footer. Only the current feature is held in memory, so the size of the
+// collection does not matter.
+//
+// The header is written by the first WriteFeature, or by Close for an
+// empty collection, so a handler can still answer with an error status
+// when the query fails before producing any rows. A stream abandoned after
+// Started is left without its footer, which clients see as invalid JSON
+// rather than a complete but truncated collection.
+type geoJSONStreamWriter struct {
+	w          io.Writer
+	flusher    http.Flusher // nil when w cannot flush
+	flushEvery int
+	features   int
+	started    bool
+	closed     bool
+}
+
+// newGeoJSONStreamWriter returns a writer that flushes w every flushEvery
+// features when w implements http.Flusher.
+func newGeoJSONStreamWriter(w io.Writer, flushEvery int) *geoJSONStreamWriter {
+	flusher, _ := w.(http.Flusher)
+	return &geoJSONStreamWriter{w: w, flusher: flusher, flushEvery: flushEvery}
+}
+
+// Started reports whether any bytes of the collection have been written.
+func (s *geoJSONStreamWriter) Started() bool {
+	return s.started
+}
+
+// Features returns the number of features written so far.
+func (s *geoJSONStreamWriter) Features() int {
+	return s.features
+}
+
+// WriteFeature appends one feature, writing the collection header first
+// if needed. feature must marshal to a GeoJSON Feature object.
+func (s *geoJSONStreamWriter) WriteFeature(feature interface{}) error {
+	if s.closed {
+		return fmt.Errorf("write feature: GeoJSON stream already closed")
+	}
+	data, err := json.Marshal(feature)
+	if err != nil {
+		return fmt.Errorf("encode feature %d: %w", s.features, err)
+	}
+
+	if err := s.start(); err != nil {
+		return err
+	}
+	if s.features > 0 {
+		data = append([]byte{','}, data...)
+	}
+	if _, err := s.w.Write(data); err != nil {
+		return fmt.Errorf("write feature %d: %w", s.features, err)
+	}
+	s.features++
+
+	if s.features%s.flushEvery == 0 {
+		s.flush()
+	}
+	return nil
+}
+
+// Close writes the footer, and the header for an empty collection, and
+// flushes. Close after Close is a no-op.
+func (s *geoJSONStreamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	if err := s.start(); err != nil {
+		return err
+	}
+	s.closed = true
+	if _, err := io.WriteString(s.w, `]}`); err != nil {
+		return fmt.Errorf("write GeoJSON footer: %w", err)
+	}
+	s.flush()
+	return nil
+}
+
+// start writes the collection header once and flushes it, so the client
+// gets the first bytes as soon as the first row is ready.
+func (s *geoJSONStreamWriter) start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+	if _, err := io.WriteString(s.w, `{"type":"FeatureCollection","features":[`); err != nil {
+		return fmt.Errorf("write GeoJSON header: %w", err)
+	}
+	s.flush()
+	return nil
+}
+
+func (s *geoJSONStreamWriter) flush() {
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+}
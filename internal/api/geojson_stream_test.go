@@ -0,0 +1,256 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// flushRecorder records what had been written at each flush
+type flushRecorder struct {
+	bytes.Buffer
+	flushes []string
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushes = append(f.flushes, f.String())
+}
+
+type testFeatureCollection struct {
+	Type     string `json:"type"`
+	Features []struct {
+		Type     string `json:"type"`
+		Geometry struct {
+			Type        string    `json:"type"`
+			Coordinates []float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	} `json:"features"`
+}
+
+func decodeFeatureCollection(t *testing.T, data []byte) testFeatureCollection {
+	t.Helper()
+	var fc testFeatureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		t.Fatalf("response is not valid JSON: %v\n%s", err, data)
+	}
+	if fc.Type != "FeatureCollection" {
+		t.Fatalf("type = %q, want FeatureCollection", fc.Type)
+	}
+	return fc
+}
+
+func TestGeoJSONStreamWriter_FlushesProgressively(t *testing.T) {
+	out := &flushRecorder{}
+	stream := newGeoJSONStreamWriter(out, 2)
+
+	if stream.Started() || out.Len() != 0 {
+		t.Fatal("stream wrote before the first feature")
+	}
+
+	for i := 0; i < 5; i++ {
+		location := models.LocationStats{City: stringPtr(fmt.Sprintf("City %d", i)), Latitude: float64(i), Longitude: -float64(i), PlaybackCount: i}
+		if err := stream.WriteFeature(buildStreamingFeature(&location)); err != nil {
+			t.Fatalf("WriteFeature(%d) error = %v", i, err)
+		}
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Header, every second feature, then the footer
+	if len(out.flushes) != 4 {
+		t.Fatalf("got %d flushes, want 4: %q", len(out.flushes), out.flushes)
+	}
+	if out.flushes[0] != `{"type":"FeatureCollection","features":[` {
+		t.Errorf("first flush = %q, want only the collection header", out.flushes[0])
+	}
+
+	fc := decodeFeatureCollection(t, out.Bytes())
+	if len(fc.Features) != 5 || stream.Features() != 5 {
+		t.Fatalf("got %d features (writer counted %d), want 5", len(fc.Features), stream.Features())
+	}
+	if got := fc.Features[3].Geometry.Coordinates; len(got) != 2 || got[0] != -3 || got[1] != 3 {
+		t.Errorf("feature 3 coordinates = %v, want [lon, lat] = [-3, 3]", got)
+	}
+	if fc.Features[3].Properties["city"] != "City 3" {
+		t.Errorf("feature 3 properties = %v, want city City 3", fc.Features[3].Properties)
+	}
+}
+
+func TestGeoJSONStreamWriter_Empty(t *testing.T) {
+	var out bytes.Buffer
+	stream := newGeoJSONStreamWriter(&out, geoJSONFlushEvery)
+
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+	if got, want := out.String(), `{"type":"FeatureCollection","features":[]}`; got != want {
+		t.Errorf("empty collection = %q, want %q", got, want)
+	}
+	if err := stream.WriteFeature(struct{}{}); err == nil {
+		t.Error("WriteFeature() after Close error = nil, want error")
+	}
+}
+
+func TestStreamLocationsGeoJSON_StreamsAllLocations(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	// More locations than GetLocationStatsFiltered's default limit of 100,
+	// one playback each, inserted in bulk
+	const locations = 150
+	geomColumn, geomValue := "", ""
+	if db.IsSpatialAvailable() {
+		geomColumn, geomValue = ", geom", ", ST_Point(range - 90, range / 2)"
+	}
+	for _, stmt := range []string{
+		fmt.Sprintf(`INSERT INTO geolocations (ip_address, latitude, longitude, city, country%s)
+			SELECT '10.0.0.' || range, range / 2, range - 90, 'City ' || range, 'Testland'%s
+			FROM range(%d)`, geomColumn, geomValue, locations),
+		fmt.Sprintf(`INSERT INTO playback_events (id, session_key, started_at, stopped_at, user_id, username, ip_address, media_type, title, percent_complete)
+			SELECT uuid(), CAST(uuid() AS TEXT), now() - INTERVAL 2 HOUR, now() - INTERVAL 1 HOUR, 1, 'alice', '10.0.0.' || range, 'movie', 'Test Title', 100
+			FROM range(%d)`, locations),
+	} {
+		if _, err := db.Conn().Exec(stmt); err != nil {
+			t.Fatalf("seed locations: %v", err)
+		}
+	}
+
+	handler := setupTestHandlerWithDB(t, db)
+	rec := httptest.NewRecorder()
+	handler.StreamLocationsGeoJSON(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stream/locations-geojson", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if !rec.Flushed {
+		t.Error("response was never flushed")
+	}
+	fc := decodeFeatureCollection(t, rec.Body.Bytes())
+	if len(fc.Features) != locations {
+		t.Errorf("got %d features, want all %d locations", len(fc.Features), locations)
+	}
+
+	// An explicit limit still applies
+	rec = httptest.NewRecorder()
+	handler.StreamLocationsGeoJSON(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stream/locations-geojson?limit=10", nil))
+	if fc := decodeFeatureCollection(t, rec.Body.Bytes()); len(fc.Features) != 10 {
+		t.Errorf("got %d features with limit=10, want 10", len(fc.Features))
+	}
+}
+
+func TestStreamLocationsGeoJSON_QueryErrorBeforeFirstFeature(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	handler := setupTestHandlerWithDB(t, db)
+	db.Close() // every query now fails
+
+	rec := httptest.NewRecorder()
+	handler.StreamLocationsGeoJSON(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stream/locations-geojson", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500 when the query fails before streaming starts", rec.Code)
+	}
+	if bytes.Contains(rec.Body.Bytes(), []byte("FeatureCollection")) {
+		t.Errorf("body = %s, want an error response without a partial collection", rec.Body.String())
+	}
+}
+
+func TestExportPlaybacksGeoJSON(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	for _, stmt := range []string{
+		`INSERT INTO geolocations (ip_address, latitude, longitude, city, country)
+			VALUES ('10.1.0.1', 38.72, -9.14, 'Lisbon', 'Portugal'), ('10.1.0.2', 52.52, 13.40, 'Berlin', 'Germany')`,
+		`INSERT INTO playback_events (id, session_key, started_at, user_id, username, ip_address, media_type, title, platform, percent_complete)
+			VALUES (uuid(), 'export-1', now() - INTERVAL 1 HOUR, 1, 'alice', '10.1.0.1', 'movie', 'Alpha', 'Roku', 90),
+			       (uuid(), 'export-2', now() - INTERVAL 2 HOUR, 2, 'bob', '10.1.0.2', 'episode', 'Beta', 'iOS', 40)`,
+	} {
+		if _, err := db.Conn().Exec(stmt); err != nil {
+			t.Fatalf("seed playbacks: %v", err)
+		}
+	}
+	handler := setupTestHandlerWithDB(t, db)
+
+	t.Run("selected properties", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ExportPlaybacksGeoJSON(rec, httptest.NewRequest(http.MethodGet,
+			"/api/v1/export/playbacks/geojson?properties=username,%20title,platform", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/geo+json" {
+			t.Errorf("Content-Type = %q, want application/geo+json", ct)
+		}
+		fc := decodeFeatureCollection(t, rec.Body.Bytes())
+		if len(fc.Features) != 2 {
+			t.Fatalf("got %d features, want 2", len(fc.Features))
+		}
+		first := fc.Features[0]
+		if first.Type != "Feature" || first.Geometry.Type != "Point" {
+			t.Errorf("feature = %+v, want a Point Feature", first)
+		}
+		if got := first.Geometry.Coordinates; len(got) != 2 || got[0] != -9.14 || got[1] != 38.72 {
+			t.Errorf("coordinates = %v, want [lon, lat] of Lisbon", got)
+		}
+		want := map[string]interface{}{"username": "alice", "title": "Alpha", "platform": "Roku"}
+		if len(first.Properties) != len(want) {
+			t.Errorf("properties = %v, want only %v", first.Properties, want)
+		}
+		for k, v := range want {
+			if first.Properties[k] != v {
+				t.Errorf("property %s = %v, want %v", k, first.Properties[k], v)
+			}
+		}
+	})
+
+	t.Run("default properties and filters", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ExportPlaybacksGeoJSON(rec, httptest.NewRequest(http.MethodGet,
+			"/api/v1/export/playbacks/geojson?media_types=episode", nil))
+
+		fc := decodeFeatureCollection(t, rec.Body.Bytes())
+		if len(fc.Features) != 1 || fc.Features[0].Properties["username"] != "bob" {
+			t.Fatalf("features = %+v, want bob's episode only", fc.Features)
+		}
+		for _, name := range []string{"started_at", "percent_complete", "media_type"} {
+			if _, ok := fc.Features[0].Properties[name]; !ok {
+				t.Errorf("default property %s missing: %v", name, fc.Features[0].Properties)
+			}
+		}
+	})
+
+	for name, query := range map[string]string{
+		"unknown property":   "properties=username,password",
+		"excluded property":  "properties=email",
+		"duplicate property": "properties=title,title",
+		"invalid filter":     "start_date=yesterday",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ExportPlaybacksGeoJSON(rec, httptest.NewRequest(http.MethodGet, "/api/v1/export/playbacks/geojson?"+query, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400: %s", rec.Code, rec.Body.String())
+			}
+			if bytes.Contains(rec.Body.Bytes(), []byte("FeatureCollection")) {
+				t.Errorf("body = %s, want an error without a collection", rec.Body.String())
+			}
+		})
+	}
+}
@@ -19,13 +19,13 @@ Usage:
     func (h *Handler) SomeHandler(w http.ResponseWriter, r *http.Request) {
         hctx := GetHandlerContext(r)
         if hctx == nil {
-            respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+            respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
             return
         }
 
         // Check if user can access target user's data
         if !hctx.CanAccessUser(targetUserID) {
-            respondError(w, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
+            respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
             return
         }
         // ... proceed with handler logic
@@ -391,7 +391,7 @@ var (
 // AuthError represents a structured error for authorization failures.
 // This is separate from APIError (in response.go) to avoid conflicts.
 type AuthError struct {
-	Code       string
+	Code       ErrorCode
 	Message    string
 	StatusCode int
 }
@@ -402,22 +402,22 @@ func (e *AuthError) Error() string {
 
 // RespondAuthError writes an authorization error response.
 // Use this helper to consistently respond to auth failures.
-func RespondAuthError(w http.ResponseWriter, err error) {
+func RespondAuthError(w http.ResponseWriter, r *http.Request, err error) {
 	var authErr *AuthError
 	if errors.As(err, &authErr) {
-		respondError(w, authErr.StatusCode, authErr.Code, authErr.Message, nil)
+		respondError(w, r, authErr.StatusCode, authErr.Code, authErr.Message, nil)
 		return
 	}
 
 	// Handle authz service errors using errors.Is for wrapped error support
 	switch {
 	case errors.Is(err, authz.ErrNilSubject):
-		respondError(w, 401, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, 401, "AUTH_REQUIRED", "Authentication required", nil)
 	case errors.Is(err, authz.ErrAdminRequired):
-		respondError(w, 403, "ADMIN_REQUIRED", "Admin role required", nil)
+		respondError(w, r, 403, "ADMIN_REQUIRED", "Admin role required", nil)
 	case errors.Is(err, authz.ErrNotAuthorized):
-		respondError(w, 403, "FORBIDDEN", "Access denied: insufficient permissions", nil)
+		respondError(w, r, 403, "FORBIDDEN", "Access denied: insufficient permissions", nil)
 	default:
-		respondError(w, 403, "FORBIDDEN", "Access denied", err)
+		respondError(w, r, 403, "FORBIDDEN", "Access denied", err)
 	}
 }
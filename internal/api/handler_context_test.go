@@ -614,7 +614,7 @@ func TestRespondAuthError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
-			RespondAuthError(w, tt.err)
+			RespondAuthError(w, httptest.NewRequest(http.MethodGet, "/api/v1/wrapped", nil), tt.err)
 
 			if w.Code != tt.wantStatusCode {
 				t.Errorf("RespondAuthError() status = %d, want %d", w.Code, tt.wantStatusCode)
@@ -8,9 +8,11 @@ package api
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/tomtom215/cartographus/internal/audit"
 	"github.com/tomtom215/cartographus/internal/auth"
 	"github.com/tomtom215/cartographus/internal/cache"
 	"github.com/tomtom215/cartographus/internal/config"
@@ -43,8 +45,21 @@ type Handler struct {
 	startTime       time.Time
 	cache           *cache.Cache
 	perfMon         *middleware.PerformanceMonitor
-	backupManager   BackupManager  // Backup manager for backup/restore operations (optional)
-	eventPublisher  EventPublisher // NATS event publisher for webhook events (optional)
+	backupManager   BackupManager                 // Backup manager for backup/restore operations (optional)
+	eventPublisher  EventPublisher                // NATS event publisher for webhook events (optional)
+	sourceHealth    []syncpkg.SourceHealthChecker // Media source probes for /sources/health (optional)
+	nowPlaying      *nowPlayingAggregator         // Live sessions for /now-playing (optional)
+
+	newsletterContent NewsletterContentResolver // Real content for live newsletter previews (optional)
+	configReloader    ConfigReloader            // Config hot-reload (optional)
+	retention         RetentionPlanner          // Retention dry run (optional)
+	vpnUpdater        VPNUpdater                // VPN data updates (optional)
+	vpnRanges         VPNRanges                 // VPN CIDR ranges (optional)
+	auditLogger       *audit.Logger             // Audit trail for user data erasure and export (optional)
+	readiness         ReadinessChecker          // Supervised service health for /health/ready (optional)
+
+	syncGeneration atomic.Uint64 // Bumped after each sync; part of analytics ETags
+	queryTimeout   atomic.Int64  // API_QUERY_TIMEOUT override set on config reload
 }
 
 // NewHandler creates a new API handler with all required dependencies.
@@ -61,7 +76,7 @@ type Handler struct {
 //   - wsHub: WebSocket hub for real-time broadcasts
 //
 // The handler initializes with:
-//   - 5-minute TTL cache for analytics endpoints
+//   - Analytics cache with cfg.API.CacheTTL (default 5 minutes)
 //   - Performance monitor tracking last 1000 requests
 //   - Start time for uptime calculations
 //
@@ -81,6 +96,11 @@ func NewHandler(db *database.DB, syncMgr *syncpkg.Manager, client syncpkg.Tautul
 		)
 	}
 
+	cacheTTL := cfg.API.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = config.DefaultCacheTTL
+	}
+
 	return &Handler{
 		db:              db,
 		sync:            syncMgr,
@@ -90,7 +110,7 @@ func NewHandler(db *database.DB, syncMgr *syncpkg.Manager, client syncpkg.Tautul
 		plexOAuthClient: plexOAuthClient,
 		wsHub:           wsHub,
 		startTime:       time.Now(),
-		cache:           cache.New(5 * time.Minute),             // 5 minute TTL for analytics cache
+		cache:           cache.New(cacheTTL),
 		perfMon:         middleware.NewPerformanceMonitor(1000), // Keep last 1000 requests
 	}
 }
@@ -101,7 +121,7 @@ func NewHandler(db *database.DB, syncMgr *syncpkg.Manager, client syncpkg.Tautul
 // clients receive fresh data. It can also be called manually to force cache
 // invalidation without waiting for a sync.
 //
-// The cache stores analytics query results with a 5-minute TTL. Clearing it
+// The cache stores analytics query results for API_CACHE_TTL. Clearing it
 // ensures the next request will query the database directly.
 //
 // Thread Safety: Safe for concurrent access.
@@ -112,6 +132,14 @@ func (h *Handler) ClearCache() {
 	}
 }
 
+// SetCacheTTL changes how long analytics results are cached, for config
+// hot-reload. Results already cached keep their expiration.
+func (h *Handler) SetCacheTTL(ttl time.Duration) {
+	if h.cache != nil {
+		h.cache.SetTTL(ttl)
+	}
+}
+
 // SetBackupManager sets the backup manager for backup/restore operations.
 //
 // This method allows late initialization of the backup manager after the handler
@@ -123,12 +151,33 @@ func (h *Handler) SetBackupManager(bm BackupManager) {
 	h.backupManager = bm
 }
 
+// SetNewsletterContentResolver enables live newsletter previews, which
+// render a template with the same content a send would resolve instead of
+// sample data. It is set when the newsletter scheduler is enabled.
+func (h *Handler) SetNewsletterContentResolver(resolver NewsletterContentResolver) {
+	h.newsletterContent = resolver
+}
+
+// SetConfigReloader enables POST /api/v1/admin/config/reload. Once set,
+// GET /api/v1/admin/config reports the reloader's running configuration.
+func (h *Handler) SetConfigReloader(reloader ConfigReloader) {
+	h.configReloader = reloader
+}
+
+// SetAuditLogger records user data erasures and exports in the audit log.
+func (h *Handler) SetAuditLogger(logger *audit.Logger) {
+	h.auditLogger = logger
+}
+
 // OnSyncCompleted is the callback invoked after each successful sync operation.
 //
 // This method handles post-sync tasks:
-//  1. Clears the analytics cache to serve fresh data
-//  2. Broadcasts sync completion to WebSocket clients
-//  3. Fetches and broadcasts updated statistics
+//  1. Stitches newly synced playbacks into logical viewing sessions
+//  2. Detects watch parties among newly synced playbacks
+//  3. Clears the analytics cache and bumps the sync generation, invalidating
+//     analytics ETags, to serve fresh data
+//  4. Broadcasts sync completion to WebSocket clients
+//  5. Fetches and broadcasts updated statistics
 //
 // Parameters:
 //   - newRecords: Number of playback events added during sync
@@ -142,8 +191,12 @@ func (h *Handler) SetBackupManager(bm BackupManager) {
 //
 // Thread Safety: Safe for concurrent access.
 func (h *Handler) OnSyncCompleted(newRecords int, durationMs int64) {
-	// Clear analytics cache
+	h.stitchSessions()
+	h.detectWatchParties()
+
+	// Clear analytics cache and invalidate ETags
 	h.ClearCache()
+	h.bumpSyncGeneration()
 
 	// Broadcast sync_completed message to all WebSocket clients
 	if h.wsHub != nil {
@@ -166,6 +219,48 @@ func (h *Handler) OnSyncCompleted(newRecords int, durationMs int64) {
 	}
 }
 
+// stitchSessions links playbacks that have not been stitched yet into logical
+// viewing sessions, using the configured SYNC_SESSION_STITCH_GAP. Failures
+// are logged; stitched analytics then treat the playbacks as sessions of
+// their own until the next sync.
+func (h *Handler) stitchSessions() {
+	if h.db == nil || h.config == nil || h.config.Sync.SessionStitchGap <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	changed, err := h.db.StitchSessions(ctx, h.config.Sync.SessionStitchGap, false)
+	if err != nil {
+		logging.Warn().Err(err).Msg("Session stitching failed")
+		return
+	}
+	if changed > 0 {
+		logging.Debug().Int64("playbacks", changed).Msg("Stitched playbacks into logical sessions")
+	}
+}
+
+// detectWatchParties finds watch parties among playbacks synced since the
+// last detection, using the configured SYNC_WATCH_PARTY_START_WINDOW and
+// SYNC_WATCH_PARTY_MIN_DURATION. Failures are logged; the playbacks are
+// scanned again after the next sync.
+func (h *Handler) detectWatchParties() {
+	if h.db == nil || h.config == nil || h.config.Sync.WatchPartyStartWindow <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	parties, err := h.db.DetectWatchParties(ctx, h.config.Sync.WatchPartyStartWindow, h.config.Sync.WatchPartyMinDuration)
+	if err != nil {
+		logging.Warn().Err(err).Msg("Watch party detection failed")
+		return
+	}
+	if parties > 0 {
+		logging.Debug().Int("parties", parties).Msg("Detected watch parties")
+	}
+}
+
 // getUpgrader creates a WebSocket upgrader with proper origin checking and timeouts.
 // Phase 2.4: Added HandshakeTimeout for protection against slow client attacks.
 func (h *Handler) getUpgrader() websocket.Upgrader {
@@ -57,9 +57,20 @@ import (
 // Query Parameters: Standard filter dimensions (users, media_types, platforms, etc.)
 //
 // Response: TrendsResponse with PlaybackTrend array and selected interval string.
+//
+// @Summary Get playback trends
+// @Description Returns playback counts over time; the interval (hour, day, week or month) is chosen from the date range
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.TrendsResponse}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/trends [get]
 func (h *Handler) AnalyticsTrends(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -84,10 +95,10 @@ func (h *Handler) AnalyticsTrends(w http.ResponseWriter, r *http.Request) {
 // checkCacheAndReturnIfHit checks cache and returns early if data is found
 //
 //nolint:gocyclo // Complexity is due to parallel query orchestration, inherent to purpose
-func (h *Handler) checkCacheAndReturnIfHit(w http.ResponseWriter, cacheKey string, start time.Time) bool {
+func (h *Handler) checkCacheAndReturnIfHit(w http.ResponseWriter, r *http.Request, cacheKey string, start time.Time) bool {
 	if cached, found := h.cache.Get(cacheKey); found {
 		if response, ok := cached.(models.GeographicResponse); ok {
-			respondJSON(w, http.StatusOK, &models.APIResponse{
+			h.respondJSONWithETag(w, r, &models.APIResponse{
 				Status: "success",
 				Data:   response,
 				Metadata: models.Metadata{
@@ -265,11 +276,11 @@ func (h *Handler) buildGeographicResponse(results []interface{}) (*models.Geogra
 }
 
 // cacheAndRespondSuccess stores the response in cache and returns JSON success response
-func (h *Handler) cacheAndRespondSuccess(w http.ResponseWriter, cacheKey string, response *models.GeographicResponse, start time.Time) {
+func (h *Handler) cacheAndRespondSuccess(w http.ResponseWriter, r *http.Request, cacheKey string, response *models.GeographicResponse, start time.Time) {
 	// Store in cache
 	h.cache.Set(cacheKey, *response)
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	h.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   *response,
 		Metadata: models.Metadata{
@@ -280,15 +291,27 @@ func (h *Handler) cacheAndRespondSuccess(w http.ResponseWriter, cacheKey string,
 }
 
 // AnalyticsGeographic handles GET /api/v1/analytics/geographic requests
+//
+// @Summary Get geographic analytics
+// @Description Returns top cities and countries with media type, platform, player, quality and library distributions
+// @Tags Analytics
+// @Produce json
+// @Param stitched query bool false "Count resumed playbacks as one logical viewing session" default(false)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.GeographicResponse}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/geographic [get]
 func (h *Handler) AnalyticsGeographic(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
@@ -299,19 +322,19 @@ func (h *Handler) AnalyticsGeographic(w http.ResponseWriter, r *http.Request) {
 	cacheKey := cache.GenerateKey("AnalyticsGeographic", filter)
 
 	// Check cache first - early return if hit
-	if h.checkCacheAndReturnIfHit(w, cacheKey, start) {
+	if h.checkCacheAndReturnIfHit(w, r, cacheKey, start) {
 		return
 	}
 
 	// Cache miss - execute parallel queries
 	response, err := h.executeParallelGeographicQueries(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", err.Error(), err)
 		return
 	}
 
 	// Cache and return success response
-	h.cacheAndRespondSuccess(w, cacheKey, response, start)
+	h.cacheAndRespondSuccess(w, r, cacheKey, response, start)
 }
 
 // validateLimitParam validates and returns the limit parameter within bounds
@@ -336,15 +359,27 @@ func validateStringParam(r *http.Request, paramName, defaultValue string, validV
 }
 
 // AnalyticsUsers handles user analytics requests
+//
+// @Summary Get top users
+// @Description Returns the most active users
+// @Tags Analytics
+// @Produce json
+// @Param limit query int false "Maximum number of users" default(10) minimum(1) maximum(1000)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.UsersResponse}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/users [get]
 func (h *Handler) AnalyticsUsers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	limit, err := h.validateLimitParam(r, 10, h.config.API.MaxPageSize)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -369,9 +404,21 @@ func (h *Handler) AnalyticsUsers(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsBinge retrieves binge-watching analytics
+//
+// @Summary Get binge-watching analytics
+// @Description Returns binge sessions (3+ episodes of a show within 6 hours) and per-user and per-show totals
+// @Tags Analytics
+// @Produce json
+// @Param stitched query bool false "Count resumed playbacks as one logical viewing session" default(false)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.BingeAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/binge [get]
 func (h *Handler) AnalyticsBinge(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -382,9 +429,20 @@ func (h *Handler) AnalyticsBinge(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsBandwidth retrieves bandwidth usage analytics
+//
+// @Summary Get bandwidth analytics
+// @Description Returns bandwidth usage by transcode decision, resolution, codec and user, with daily trends
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.BandwidthAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/bandwidth [get]
 func (h *Handler) AnalyticsBandwidth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -396,9 +454,20 @@ func (h *Handler) AnalyticsBandwidth(w http.ResponseWriter, r *http.Request) {
 
 // AnalyticsBitrate retrieves bitrate and bandwidth analytics (v1.42 - Phase 2.2)
 // Tracks bitrate at 3 levels (source, transcode, network) for network bottleneck identification
+//
+// @Summary Get bitrate analytics
+// @Description Returns source and transcoded bitrate distributions and bandwidth-constrained sessions
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.BitrateAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/bitrate [get]
 func (h *Handler) AnalyticsBitrate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -409,15 +478,28 @@ func (h *Handler) AnalyticsBitrate(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsPopular retrieves popular content analytics
+//
+// @Summary Get popular content
+// @Description Returns the most watched movies, shows and episodes
+// @Tags Analytics
+// @Produce json
+// @Param limit query int false "Maximum number of items per list" default(10) minimum(1) maximum(50)
+// @Param stitched query bool false "Count resumed playbacks as one logical viewing session" default(false)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.PopularAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/popular [get]
 func (h *Handler) AnalyticsPopular(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	limit, err := h.validateLimitParam(r, 10, 50)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -435,9 +517,20 @@ func (h *Handler) AnalyticsPopular(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsWatchParties returns watch party detection analytics
+//
+// @Summary Get watch parties
+// @Description Returns groups of users watching the same content at the same time
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.WatchPartyAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/watch-parties [get]
 func (h *Handler) AnalyticsWatchParties(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -448,15 +541,27 @@ func (h *Handler) AnalyticsWatchParties(w http.ResponseWriter, r *http.Request)
 }
 
 // AnalyticsUserEngagement retrieves user engagement analytics
+//
+// @Summary Get user engagement
+// @Description Returns per-user engagement metrics and viewing patterns by hour and day
+// @Tags Analytics
+// @Produce json
+// @Param limit query int false "Maximum number of users" default(10) minimum(1) maximum(100)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.UserEngagementAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/user-engagement [get]
 func (h *Handler) AnalyticsUserEngagement(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	limit, err := h.validateLimitParam(r, 10, 100)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -474,9 +579,20 @@ func (h *Handler) AnalyticsUserEngagement(w http.ResponseWriter, r *http.Request
 }
 
 // AnalyticsAbandonment retrieves content abandonment and drop-off rate analytics
+//
+// @Summary Get content abandonment
+// @Description Returns completion and drop-off rates by media type, genre and content
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ContentAbandonmentAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/abandonment [get]
 func (h *Handler) AnalyticsAbandonment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -487,9 +603,21 @@ func (h *Handler) AnalyticsAbandonment(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsComparative retrieves period-over-period comparison analytics
+//
+// @Summary Get period comparison
+// @Description Compares playback metrics between the current and the previous period
+// @Tags Analytics
+// @Produce json
+// @Param comparison_type query string false "Comparison period" Enums(week, month, quarter, year, custom) default(week)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ComparativeAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/comparative [get]
 func (h *Handler) AnalyticsComparative(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -498,7 +626,7 @@ func (h *Handler) AnalyticsComparative(w http.ResponseWriter, r *http.Request) {
 	}
 	comparisonType, err := validateStringParam(r, "comparison_type", "week", validTypes)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_PARAMETER",
+		respondError(w, r, http.StatusBadRequest, "INVALID_PARAMETER",
 			"Invalid comparison_type. Must be: week, month, quarter, year, or custom", nil)
 		return
 	}
@@ -517,9 +645,21 @@ func (h *Handler) AnalyticsComparative(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsTemporalHeatmap handles temporal heatmap analytics requests
+//
+// @Summary Get temporal heatmap
+// @Description Returns playback locations bucketed by time for animated heatmaps
+// @Tags Analytics
+// @Produce json
+// @Param interval query string false "Time bucket" Enums(hour, day, week, month) default(day)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.TemporalHeatmapResponse}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/temporal-heatmap [get]
 func (h *Handler) AnalyticsTemporalHeatmap(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -528,7 +668,7 @@ func (h *Handler) AnalyticsTemporalHeatmap(w http.ResponseWriter, r *http.Reques
 	}
 	interval, err := validateStringParam(r, "interval", "day", validIntervals)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_PARAMETER",
+		respondError(w, r, http.StatusBadRequest, "INVALID_PARAMETER",
 			"Invalid interval. Must be: hour, day, week, or month", nil)
 		return
 	}
@@ -560,9 +700,20 @@ func (h *Handler) AnalyticsTemporalHeatmap(w http.ResponseWriter, r *http.Reques
 // Query Parameters: Standard filter dimensions (users, media_types, platforms, etc.)
 //
 // Response: HardwareTranscodeStats with decoder/encoder breakdown and percentages
+//
+// @Summary Get hardware transcode analytics
+// @Description Returns hardware acceleration usage by decoder, encoder and full pipeline
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=database.HardwareTranscodeStats}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/hardware-transcode [get]
 func (h *Handler) AnalyticsHardwareTranscode(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -586,9 +737,20 @@ func (h *Handler) AnalyticsHardwareTranscode(w http.ResponseWriter, r *http.Requ
 // Query Parameters: Standard filter dimensions (users, media_types, platforms, etc.)
 //
 // Response: HDRContentStats with format breakdown and color metadata statistics
+//
+// @Summary Get HDR content analytics
+// @Description Returns HDR playback counts by format, color space and color primaries
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=database.HDRContentStats}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/hdr-content [get]
 func (h *Handler) AnalyticsHDRContent(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -612,9 +774,20 @@ func (h *Handler) AnalyticsHDRContent(w http.ResponseWriter, r *http.Request) {
 // Query Parameters: Standard filter dimensions (users, media_types, platforms, etc.)
 //
 // Response: Array of HWTranscodeTrend with daily statistics for the last 30 days
+//
+// @Summary Get hardware transcode trends
+// @Description Returns daily hardware transcode counts
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=[]database.HWTranscodeTrend}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/hardware-transcode/trends [get]
 func (h *Handler) AnalyticsHardwareTranscodeTrends(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -631,6 +804,42 @@ func (h *Handler) AnalyticsHardwareTranscodeTrends(w http.ResponseWriter, r *htt
 	})
 }
 
+// AnalyticsTranscodeReasons returns why and how much the server transcodes
+//
+// Method: GET
+// Path: /api/v1/analytics/transcode-reasons
+//
+// This endpoint aggregates stored sessions into:
+//   - Transcode reasons (video codec, resolution, bandwidth, audio codec, other)
+//   - Average and peak concurrent transcodes
+//   - Hourly concurrent transcodes across the date range
+//
+// Query Parameters: Standard filter dimensions (start_date, end_date, users, platforms, etc.)
+//
+// Response: TranscodeReasonAnalytics with reason buckets and hourly concurrency
+//
+// @Summary Get transcode reasons
+// @Description Returns why sessions were transcoded and hourly transcode concurrency
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=database.TranscodeReasonAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/transcode-reasons [get]
+func (h *Handler) AnalyticsTranscodeReasons(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+
+	executor := NewAnalyticsQueryExecutor(h)
+	executor.ExecuteUserScoped(w, r, "AnalyticsTranscodeReasons", func(ctx context.Context, filter database.LocationStatsFilter) (interface{}, error) {
+		return h.db.GetTranscodeReasonAnalytics(ctx, filter)
+	})
+}
+
 // TautulliHomeStats handles Tautulli home statistics requests
 //
 // @Summary Get Tautulli homepage statistics
@@ -643,4 +852,4 @@ func (h *Handler) AnalyticsHardwareTranscodeTrends(w http.ResponseWriter, r *htt
 // @Param stats_count query int false "Number of stats to return" default(10)
 // @Success 200 {object} models.APIResponse{data=tautulli.TautulliHomeStats} "Home statistics retrieved successfully"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/tautulli/home-stats [get]
+// @Router /tautulli/home-stats [get]
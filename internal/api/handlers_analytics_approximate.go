@@ -56,9 +56,9 @@ type ApproximatePercentileResponse struct {
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Database error"
 // @Failure 503 {object} models.APIResponse "Database unavailable"
-// @Router /api/v1/analytics/approximate [get]
+// @Router /analytics/approximate [get]
 func (h *Handler) ApproximateStats(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -67,14 +67,14 @@ func (h *Handler) ApproximateStats(w http.ResponseWriter, r *http.Request) {
 	// Parse filter parameters
 	filter, err := parseApproximateStatsFilter(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
 	// Get approximate stats
 	stats, err := h.db.GetApproximateStats(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get approximate statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get approximate statistics", err)
 		return
 	}
 
@@ -83,7 +83,7 @@ func (h *Handler) ApproximateStats(w http.ResponseWriter, r *http.Request) {
 		DataSketches: h.db.IsDataSketchesAvailable(),
 	}
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	h.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   response,
 		Metadata: models.Metadata{
@@ -113,9 +113,9 @@ func (h *Handler) ApproximateStats(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Database error"
 // @Failure 503 {object} models.APIResponse "Database unavailable"
-// @Router /api/v1/analytics/approximate/distinct [get]
+// @Router /analytics/approximate/distinct [get]
 func (h *Handler) ApproximateDistinctCount(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -124,21 +124,21 @@ func (h *Handler) ApproximateDistinctCount(w http.ResponseWriter, r *http.Reques
 	// Parse column parameter (required)
 	column := r.URL.Query().Get("column")
 	if column == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'column' is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'column' is required", nil)
 		return
 	}
 
 	// Parse filter parameters
 	filter, err := parseApproximateStatsFilter(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
 	// Get approximate distinct count
 	count, isApproximate, err := h.db.ApproximateDistinctCount(r.Context(), column, filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get distinct count", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get distinct count", err)
 		return
 	}
 
@@ -148,7 +148,7 @@ func (h *Handler) ApproximateDistinctCount(w http.ResponseWriter, r *http.Reques
 		IsApproximate: isApproximate,
 	}
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	h.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   response,
 		Metadata: models.Metadata{
@@ -179,9 +179,9 @@ func (h *Handler) ApproximateDistinctCount(w http.ResponseWriter, r *http.Reques
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Database error"
 // @Failure 503 {object} models.APIResponse "Database unavailable"
-// @Router /api/v1/analytics/approximate/percentile [get]
+// @Router /analytics/approximate/percentile [get]
 func (h *Handler) ApproximatePercentile(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -190,33 +190,33 @@ func (h *Handler) ApproximatePercentile(w http.ResponseWriter, r *http.Request)
 	// Parse column parameter (required)
 	column := r.URL.Query().Get("column")
 	if column == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'column' is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'column' is required", nil)
 		return
 	}
 
 	// Parse percentile parameter (required)
 	percentileStr := r.URL.Query().Get("percentile")
 	if percentileStr == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'percentile' is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'percentile' is required", nil)
 		return
 	}
 	percentile, err := strconv.ParseFloat(percentileStr, 64)
 	if err != nil || percentile < 0 || percentile > 1 {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "percentile must be a number between 0 and 1", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "percentile must be a number between 0 and 1", nil)
 		return
 	}
 
 	// Parse filter parameters
 	filter, err := parseApproximateStatsFilter(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
 	// Get approximate percentile
 	value, isApproximate, err := h.db.ApproximatePercentile(r.Context(), column, percentile, filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get percentile", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get percentile", err)
 		return
 	}
 
@@ -227,7 +227,7 @@ func (h *Handler) ApproximatePercentile(w http.ResponseWriter, r *http.Request)
 		IsApproximate: isApproximate,
 	}
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	h.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   response,
 		Metadata: models.Metadata{
@@ -0,0 +1,171 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// handlers_analytics_batch.go - Several analytics queries in one request
+//
+// POST /api/v1/analytics/batch runs named analytics queries, each with its
+// own filter, on the batch executor (see handlers_batch.go):
+//   - Every query is served by its standalone analytics handler, so each
+//     one reads and fills the response cache under its own filter
+//   - All queries share one deadline of API_QUERY_TIMEOUT; a query still
+//     running or queued when it passes fails with QUERY_TIMEOUT
+//   - A failing query only sets its own error; the batch still succeeds
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+const (
+	// analyticsBatchMaxQueries caps the queries in one analytics batch.
+	analyticsBatchMaxQueries = 20
+
+	// analyticsBatchPrefix is the path of the endpoints a batch query names.
+	analyticsBatchPrefix = "/api/v1/analytics/"
+)
+
+// AnalyticsBatchRequest is the body of POST /api/v1/analytics/batch.
+type AnalyticsBatchRequest struct {
+	Queries []AnalyticsBatchQuery `json:"queries"`
+}
+
+// AnalyticsBatchQuery is one named analytics query of a batch.
+type AnalyticsBatchQuery struct {
+	Name   string            `json:"name"`             // Key of the result in the response
+	Query  string            `json:"query,omitempty"`  // Endpoint below /api/v1/analytics/, e.g. "trends"; defaults to Name
+	Filter map[string]string `json:"filter,omitempty"` // Query parameters, as for the standalone endpoint
+}
+
+// AnalyticsBatchResult is the outcome of one query: Data and Metadata of
+// the standalone response on success, Error otherwise.
+type AnalyticsBatchResult struct {
+	Status   int              `json:"status"`
+	Data     json.RawMessage  `json:"data,omitempty"`
+	Metadata *models.Metadata `json:"metadata,omitempty"`
+	Error    *models.APIError `json:"error,omitempty"`
+}
+
+// analyticsBatchExecutor serves POST /api/v1/analytics/batch.
+type analyticsBatchExecutor struct {
+	batch   *batchExecutor
+	timeout func() time.Duration // Deadline shared by the queries of a batch
+}
+
+// newAnalyticsBatchExecutor creates the executor for the batchable
+// analytics endpoints of h.
+func newAnalyticsBatchExecutor(h *Handler) *analyticsBatchExecutor {
+	endpoints := make(map[string]http.HandlerFunc)
+	for path, handler := range h.batchEndpoints() {
+		if strings.HasPrefix(path, analyticsBatchPrefix) {
+			endpoints[path] = handler
+		}
+	}
+	return &analyticsBatchExecutor{batch: newBatchExecutor(endpoints, h.acquireAnalyticsSlot), timeout: h.QueryTimeout}
+}
+
+// Batch handles POST /api/v1/analytics/batch
+//
+// Request body: {"queries": [{name, query, filter}]} with at most 20
+// queries. Response: a map of name -> {status, data, metadata} or
+// {status, error}.
+func (a *analyticsBatchExecutor) Batch(w http.ResponseWriter, r *http.Request) {
+	var req AnalyticsBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Request body must be {\"queries\": [{name, query, filter}]}", err)
+		return
+	}
+	items, err := analyticsBatchItems(req.Queries)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), a.timeout())
+	defer cancel()
+
+	data := make(map[string]*AnalyticsBatchResult, len(items))
+	for item := range a.batch.run(r.WithContext(ctx), items) {
+		data[item.ID] = analyticsBatchResult(item)
+	}
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     data,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// analyticsBatchItems validates queries and converts them to batch items.
+func analyticsBatchItems(queries []AnalyticsBatchQuery) ([]BatchRequestItem, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("batch has no queries")
+	}
+	if len(queries) > analyticsBatchMaxQueries {
+		return nil, fmt.Errorf("batch has %d queries; the maximum is %d", len(queries), analyticsBatchMaxQueries)
+	}
+
+	items := make([]BatchRequestItem, 0, len(queries))
+	seen := make(map[string]bool, len(queries))
+	for _, q := range queries {
+		if q.Name == "" {
+			return nil, fmt.Errorf("every query needs a name")
+		}
+		if seen[q.Name] {
+			return nil, fmt.Errorf("duplicate query name %q", q.Name)
+		}
+		seen[q.Name] = true
+
+		endpoint := q.Query
+		if endpoint == "" {
+			endpoint = q.Name
+		}
+		items = append(items, BatchRequestItem{
+			ID:     q.Name,
+			Path:   analyticsBatchPrefix + strings.TrimPrefix(endpoint, "/"),
+			Params: q.Filter,
+		})
+	}
+	return items, nil
+}
+
+// analyticsBatchResult unwraps the response envelope of one query. An error
+// response without an envelope (such as an http.Error message) becomes a
+// QUERY_ERROR with that text.
+func analyticsBatchResult(item *BatchItemResult) *AnalyticsBatchResult {
+	result := &AnalyticsBatchResult{Status: item.Status}
+
+	var envelope struct {
+		Data     json.RawMessage  `json:"data"`
+		Metadata *models.Metadata `json:"metadata"`
+		Error    *models.APIError `json:"error"`
+	}
+	if err := json.Unmarshal(item.Body, &envelope); err != nil {
+		envelope.Data = item.Body // Not an object: keep the raw body
+	}
+
+	if item.Status < http.StatusBadRequest {
+		result.Data = envelope.Data
+		result.Metadata = envelope.Metadata
+		return result
+	}
+
+	result.Error = envelope.Error
+	if result.Error == nil {
+		message := http.StatusText(item.Status)
+		var text string
+		if json.Unmarshal(item.Body, &text) == nil && text != "" {
+			message = strings.TrimSpace(text)
+		}
+		result.Error = &models.APIError{Code: string(ErrCodeQueryError), Message: message}
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// postAnalyticsBatch sends body to the executor and returns the results.
+func postAnalyticsBatch(t *testing.T, a *analyticsBatchExecutor, body string) (int, map[string]AnalyticsBatchResult) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/analytics/batch", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	a.Batch(w, req)
+
+	var resp struct {
+		Data map[string]AnalyticsBatchResult `json:"data"`
+	}
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode analytics batch response: %v\n%s", err, w.Body.String())
+		}
+	}
+	return w.Code, resp.Data
+}
+
+func TestAnalyticsBatch_PartialFailures(t *testing.T) {
+	t.Parallel()
+
+	a := &analyticsBatchExecutor{
+		batch: newBatchExecutor(map[string]http.HandlerFunc{
+			"/api/v1/analytics/trends": batchTestEndpoint("trends"),
+			"/api/v1/analytics/fail": func(w http.ResponseWriter, _ *http.Request) {
+				respondError(w, nil, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to execute query: fail", nil)
+			},
+			"/api/v1/analytics/plain": func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "Forbidden: admin only", http.StatusForbidden)
+			},
+			// Like respondQueryError, writes nothing once the request is done
+			"/api/v1/analytics/slow": func(_ http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+		}, nil),
+		timeout: func() time.Duration { return 100 * time.Millisecond },
+	}
+
+	start := time.Now()
+	code, results := postAnalyticsBatch(t, a, `{"queries": [
+		{"name": "trends", "filter": {"days": "30"}},
+		{"name": "trends-7d", "query": "trends", "filter": {"days": "7"}},
+		{"name": "fail"},
+		{"name": "plain"},
+		{"name": "slow"},
+		{"name": "unknown", "query": "../stats"}
+	]}`)
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("batch took %v; the shared deadline did not stop the slow query", elapsed)
+	}
+	if len(results) != 6 {
+		t.Fatalf("got %d results, want 6", len(results))
+	}
+
+	for name, days := range map[string]string{"trends": "30", "trends-7d": "7"} {
+		result := results[name]
+		if result.Status != http.StatusOK || result.Error != nil {
+			t.Errorf("%s = %d %+v, want success", name, result.Status, result.Error)
+			continue
+		}
+		var data map[string]string
+		if err := json.Unmarshal(result.Data, &data); err != nil || data["days"] != days {
+			t.Errorf("%s data = %s, want its own filter (days=%s)", name, result.Data, days)
+		}
+	}
+
+	wantErrors := map[string]struct {
+		status int
+		code   string
+	}{
+		"fail":    {http.StatusInternalServerError, "DATABASE_ERROR"},
+		"plain":   {http.StatusForbidden, string(ErrCodeQueryError)},
+		"slow":    {http.StatusGatewayTimeout, string(ErrCodeQueryTimeout)},
+		"unknown": {http.StatusNotFound, string(ErrCodeNotBatchable)},
+	}
+	for name, want := range wantErrors {
+		result := results[name]
+		if result.Status != want.status || result.Error == nil || result.Error.Code != want.code {
+			t.Errorf("%s = %d %+v, want %d %s", name, result.Status, result.Error, want.status, want.code)
+		}
+		if result.Data != nil {
+			t.Errorf("%s has data %s alongside its error", name, result.Data)
+		}
+	}
+	if msg := results["plain"].Error; msg != nil && msg.Message != "Forbidden: admin only" {
+		t.Errorf("plain error message = %q, want the handler's text", msg.Message)
+	}
+}
+
+func TestAnalyticsBatch_Validation(t *testing.T) {
+	t.Parallel()
+
+	a := &analyticsBatchExecutor{
+		batch:   newBatchExecutor(map[string]http.HandlerFunc{"/api/v1/analytics/trends": batchTestEndpoint("trends")}, nil),
+		timeout: func() time.Duration { return time.Second },
+	}
+	tooMany := make([]string, analyticsBatchMaxQueries+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf(`{"name": "q%d", "query": "trends"}`, i)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"not an object", `[{"name": "trends"}]`},
+		{"no queries", `{"queries": []}`},
+		{"missing name", `{"queries": [{"query": "trends"}]}`},
+		{"duplicate name", `{"queries": [{"name": "trends"}, {"name": "trends"}]}`},
+		{"too many queries", `{"queries": [` + strings.Join(tooMany, ",") + `]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _ := postAnalyticsBatch(t, a, tt.body); code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", code)
+			}
+		})
+	}
+}
+
+func TestAnalyticsBatch_WithDB(t *testing.T) {
+	t.Parallel()
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	insertTestPlaybacks(t, db, 10)
+	a := newAnalyticsBatchExecutor(setupTestHandlerWithDB(t, db))
+
+	body := `{"queries": [
+		{"name": "trends"},
+		{"name": "platforms", "query": "geographic", "filter": {"days": "30"}},
+		{"name": "bogus", "query": "no-such-query"}
+	]}`
+	code, first := postAnalyticsBatch(t, a, body)
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	for _, name := range []string{"trends", "platforms"} {
+		if first[name].Status != http.StatusOK || len(first[name].Data) == 0 {
+			t.Errorf("%s = %d %+v, want data", name, first[name].Status, first[name].Error)
+		}
+	}
+	if first["bogus"].Error == nil || first["bogus"].Error.Code != string(ErrCodeNotBatchable) {
+		t.Errorf("bogus = %d %+v, want NOT_BATCHABLE", first["bogus"].Status, first["bogus"].Error)
+	}
+
+	// Each query is cached under its own filter, as for the standalone endpoint
+	_, second := postAnalyticsBatch(t, a, body)
+	if m := second["trends"].Metadata; m == nil || !m.Cached {
+		t.Errorf("second trends metadata = %+v, want a cache hit", m)
+	}
+	if string(second["trends"].Data) != string(first["trends"].Data) {
+		t.Errorf("cached trends data differs:\n%s\n%s", first["trends"].Data, second["trends"].Data)
+	}
+}
@@ -45,9 +45,20 @@ import (
 // Auditable: Execution time in metadata
 // Traceable: Total flows and show counts in response
 // Observable: Full metadata with node/link statistics
+//
+// @Summary Get content flow
+// @Description Returns show to season to episode viewing journeys for Sankey charts
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ContentFlowAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/content-flow [get]
 func (h *Handler) AnalyticsContentFlow(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -82,9 +93,21 @@ func (h *Handler) AnalyticsContentFlow(w http.ResponseWriter, r *http.Request) {
 // Deterministic: Jaccard similarity calculation is deterministic
 // Auditable: User counts and connection counts in response
 // Observable: Full metadata with cluster statistics
+//
+// @Summary Get user content overlap
+// @Description Returns content similarity between pairs of users for chord charts (admin only)
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.UserContentOverlapAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 403 {object} models.APIResponse "Admin role required"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/user-overlap [get]
 func (h *Handler) AnalyticsUserOverlap(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -123,9 +146,20 @@ func (h *Handler) AnalyticsUserOverlap(w http.ResponseWriter, r *http.Request) {
 // Deterministic: Normalized scores (0-100) for consistent visualization
 // Auditable: User counts and average profiles in response
 // Observable: Full metadata with ranking information
+//
+// @Summary Get user engagement profiles
+// @Description Returns multi-dimensional engagement scores for radar charts
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.UserProfileAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/user-profile [get]
 func (h *Handler) AnalyticsUserProfile(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -159,9 +193,20 @@ func (h *Handler) AnalyticsUserProfile(w http.ResponseWriter, r *http.Request) {
 // Deterministic: Consistent tree structure for same data
 // Auditable: Total content and utilization rates in response
 // Observable: Full metadata with hierarchy statistics
+//
+// @Summary Get library utilization
+// @Description Returns hierarchical library usage for treemap charts
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.LibraryUtilizationAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/library-utilization [get]
 func (h *Handler) AnalyticsLibraryUtilization(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -195,9 +240,20 @@ func (h *Handler) AnalyticsLibraryUtilization(w http.ResponseWriter, r *http.Req
 // Deterministic: Consistent intensity normalization
 // Auditable: Activity statistics and streak counts in response
 // Observable: Full metadata with daily averages
+//
+// @Summary Get calendar heatmap
+// @Description Returns daily playback activity for calendar heatmaps
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.CalendarHeatmapAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/calendar-heatmap [get]
 func (h *Handler) AnalyticsCalendarHeatmap(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -231,9 +287,21 @@ func (h *Handler) AnalyticsCalendarHeatmap(w http.ResponseWriter, r *http.Reques
 // Deterministic: Consistent ranking calculation
 // Auditable: Period counts and ranking entries in response
 // Observable: Full metadata with movers and shakers
+//
+// @Summary Get content ranking changes
+// @Description Returns how content rankings change over time for bump charts (admin only)
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.BumpChartAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 403 {object} models.APIResponse "Admin role required"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/bump-chart [get]
 func (h *Handler) AnalyticsBumpChart(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -38,9 +38,20 @@ import (
 // Auditable: Execution time and event counts in metadata
 // Traceable: Date range and platform counts in metadata
 // Observable: Full metadata with query execution details
+//
+// @Summary Get device migration analytics
+// @Description Returns how users move between platforms and devices over time
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.DeviceMigrationAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/device-migration [get]
 func (h *Handler) AnalyticsDeviceMigration(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -75,9 +86,20 @@ func (h *Handler) AnalyticsDeviceMigration(w http.ResponseWriter, r *http.Reques
 // Auditable: Execution time and event counts in metadata
 // Traceable: Date range and content counts in metadata
 // Observable: Full metadata with discovery thresholds
+//
+// @Summary Get content discovery analytics
+// @Description Returns time from library addition to first watch and discovery rates
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ContentDiscoveryAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/content-discovery [get]
 func (h *Handler) AnalyticsContentDiscovery(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -45,9 +45,23 @@ import (
 //   - When users typically stop using the service (churn points)
 //   - Which cohorts have best/worst retention
 //   - Overall user engagement health over time
+//
+// @Summary Get cohort retention
+// @Description Returns retention for users grouped by the week or month of their first playback
+// @Tags Analytics
+// @Produce json
+// @Param max_weeks query int false "Number of weeks tracked per cohort" default(12) minimum(1) maximum(52)
+// @Param min_cohort_size query int false "Smallest cohort included in the report" default(3) minimum(1)
+// @Param granularity query string false "Cohort bucket" Enums(week, month) default(week)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.CohortRetentionAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/cohort-retention [get]
 func (h *Handler) AnalyticsCohortRetention(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -93,9 +107,20 @@ func (h *Handler) AnalyticsCohortRetention(w http.ResponseWriter, r *http.Reques
 //   - QoE Score: Composite score (0-100) with letter grade
 //
 // Use this to understand and improve user experience quality.
+//
+// @Summary Get quality of experience dashboard
+// @Description Returns QoE scores, trends and issues by platform and transcode decision
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.QoEDashboard}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/qoe [get]
 func (h *Handler) AnalyticsQoE(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -125,9 +150,20 @@ func (h *Handler) AnalyticsQoE(w http.ResponseWriter, r *http.Request) {
 //   - Ensuring analytics accuracy and trustworthiness
 //   - Early detection of data pipeline issues
 //   - Maintaining auditability and compliance
+//
+// @Summary Get data quality report
+// @Description Returns per-field completeness, daily trends, issues and a per-source breakdown
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.DataQualityReport}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/data-quality [get]
 func (h *Handler) AnalyticsDataQuality(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -159,9 +195,22 @@ func (h *Handler) AnalyticsDataQuality(w http.ResponseWriter, r *http.Request) {
 //   - Identifying potential account sharing
 //   - Discovering user communities
 //   - Content recommendation opportunities
+//
+// @Summary Get user network graph
+// @Description Returns users as nodes and shared sessions or shared content as edges
+// @Tags Analytics
+// @Produce json
+// @Param min_shared_sessions query int false "Minimum shared sessions for an edge" default(2) minimum(1)
+// @Param min_content_overlap query number false "Minimum content overlap ratio for an edge" default(0.3) minimum(0) maximum(1)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.UserNetworkGraph}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/user-network [get]
 func (h *Handler) AnalyticsUserNetwork(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
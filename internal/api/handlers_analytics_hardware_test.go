@@ -613,3 +613,45 @@ func BenchmarkAnalyticsHardwareTranscodeTrends(b *testing.B) {
 		handler.AnalyticsHardwareTranscodeTrends(w, req)
 	}
 }
+
+// TestAnalyticsTranscodeReasons_MethodNotAllowed tests invalid HTTP methods
+func TestAnalyticsTranscodeReasons_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{
+		cache: cache.New(5 * time.Minute),
+	}
+
+	methods := []string{http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch}
+	for _, method := range methods {
+		t.Run(method, func(t *testing.T) {
+			req := httptest.NewRequest(method, "/api/v1/analytics/transcode-reasons", nil)
+			w := httptest.NewRecorder()
+
+			handler.AnalyticsTranscodeReasons(w, req)
+
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Errorf("Expected status 405 for %s, got %d", method, w.Code)
+			}
+		})
+	}
+}
+
+// TestAnalyticsTranscodeReasons_DBUnavailable tests when database is nil
+func TestAnalyticsTranscodeReasons_DBUnavailable(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{
+		db:    nil,
+		cache: cache.New(5 * time.Minute),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/transcode-reasons", nil)
+	w := httptest.NewRecorder()
+
+	handler.AnalyticsTranscodeReasons(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 for nil db, got %d", w.Code)
+	}
+}
@@ -496,7 +496,7 @@ func TestCheckCacheAndReturnIfHit(t *testing.T) {
 		w := httptest.NewRecorder()
 		start := time.Now()
 
-		hit := handler.checkCacheAndReturnIfHit(w, "nonexistent-key", start)
+		hit := handler.checkCacheAndReturnIfHit(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil), "nonexistent-key", start)
 
 		if hit {
 			t.Error("Expected cache miss, got hit")
@@ -522,7 +522,7 @@ func TestCheckCacheAndReturnIfHit(t *testing.T) {
 		w := httptest.NewRecorder()
 		start := time.Now()
 
-		hit := handler.checkCacheAndReturnIfHit(w, "test-key", start)
+		hit := handler.checkCacheAndReturnIfHit(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil), "test-key", start)
 
 		if !hit {
 			t.Error("Expected cache hit, got miss")
@@ -553,7 +553,7 @@ func TestCheckCacheAndReturnIfHit(t *testing.T) {
 		w := httptest.NewRecorder()
 		start := time.Now()
 
-		hit := handler.checkCacheAndReturnIfHit(w, "test-key", start)
+		hit := handler.checkCacheAndReturnIfHit(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil), "test-key", start)
 
 		// Should return false because type assertion fails
 		if hit {
@@ -582,7 +582,7 @@ func TestCacheAndRespondSuccess(t *testing.T) {
 	start := time.Now()
 	cacheKey := "geographic-test"
 
-	handler.cacheAndRespondSuccess(w, cacheKey, response, start)
+	handler.cacheAndRespondSuccess(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil), cacheKey, response, start)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status 200, got %d", w.Code)
@@ -622,7 +622,7 @@ func BenchmarkCheckCacheAndReturnIfHit(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		w := httptest.NewRecorder()
-		handler.checkCacheAndReturnIfHit(w, "bench-key", time.Now())
+		handler.checkCacheAndReturnIfHit(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil), "bench-key", time.Now())
 	}
 }
 
@@ -638,6 +638,6 @@ func BenchmarkCacheAndRespondSuccess(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		w := httptest.NewRecorder()
-		handler.cacheAndRespondSuccess(w, "bench-key", response, time.Now())
+		handler.cacheAndRespondSuccess(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil), "bench-key", response, time.Now())
 	}
 }
@@ -16,6 +16,7 @@ import (
 
 	"github.com/tomtom215/cartographus/internal/audit"
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // AuditHandlers provides HTTP handlers for audit log endpoints.
@@ -98,9 +99,9 @@ func (h *AuditHandlers) ListEvents(w http.ResponseWriter, r *http.Request) {
 		filter.TargetType = v
 	}
 
-	// Source IP filter
+	// Source IP filter (anonymized like the stored addresses)
 	if v := r.URL.Query().Get("source_ip"); v != "" {
-		filter.SourceIP = v
+		filter.SourceIP = privacy.AnonymizeIPList(v)
 	}
 
 	// Time range filter
@@ -136,7 +137,7 @@ func (h *AuditHandlers) ListEvents(w http.ResponseWriter, r *http.Request) {
 
 	events, err := h.store.Query(ctx, filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "AUDIT_ERROR", "Failed to fetch audit events", err)
+		respondError(w, r, http.StatusInternalServerError, "AUDIT_ERROR", "Failed to fetch audit events", err)
 		return
 	}
 
@@ -164,13 +165,13 @@ func (h *AuditHandlers) GetEvent(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	if id == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
 		return
 	}
 
 	event, err := h.store.Get(ctx, id)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Event not found", err)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Event not found", err)
 		return
 	}
 
@@ -184,7 +185,7 @@ func (h *AuditHandlers) GetStats(w http.ResponseWriter, r *http.Request) {
 
 	stats, err := h.store.GetStats(ctx)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "AUDIT_ERROR", "Failed to get audit statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "AUDIT_ERROR", "Failed to get audit statistics", err)
 		return
 	}
 
@@ -209,6 +210,7 @@ func (h *AuditHandlers) GetTypes(w http.ResponseWriter, _ *http.Request) {
 		string(audit.EventTypeDetectionAlert),
 		string(audit.EventTypeDetectionAcknowledged),
 		string(audit.EventTypeDetectionRuleChanged),
+		string(audit.EventTypeDetectionTrustChanged),
 		string(audit.EventTypeUserCreated),
 		string(audit.EventTypeUserModified),
 		string(audit.EventTypeUserDeleted),
@@ -273,7 +275,7 @@ func (h *AuditHandlers) ExportEvents(w http.ResponseWriter, r *http.Request) {
 
 	events, err := h.store.Query(ctx, filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "EXPORT_ERROR", "Failed to query events for export", err)
+		respondError(w, r, http.StatusInternalServerError, "EXPORT_ERROR", "Failed to query events for export", err)
 		return
 	}
 
@@ -294,7 +296,7 @@ func (h *AuditHandlers) ExportEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "EXPORT_ERROR", "Failed to export events", err)
+		respondError(w, r, http.StatusInternalServerError, "EXPORT_ERROR", "Failed to export events", err)
 		return
 	}
 
@@ -46,16 +46,16 @@ type BackupManager interface {
 // checkHTTPMethod validates the HTTP method and responds with error if invalid
 func checkHTTPMethod(w http.ResponseWriter, r *http.Request, expectedMethod string) bool {
 	if r.Method != expectedMethod {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Only %s method is allowed", expectedMethod), nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", fmt.Sprintf("Only %s method is allowed", expectedMethod), nil)
 		return false
 	}
 	return true
 }
 
 // checkBackupManagerAvailable checks if backup manager is available
-func (h *Handler) checkBackupManagerAvailable(w http.ResponseWriter) bool {
+func (h *Handler) checkBackupManagerAvailable(w http.ResponseWriter, r *http.Request) bool {
 	if h.backupManager == nil {
-		respondError(w, http.StatusServiceUnavailable, "BACKUP_DISABLED", "Backup functionality is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "BACKUP_DISABLED", "Backup functionality is not enabled", nil)
 		return false
 	}
 	return true
@@ -65,7 +65,7 @@ func (h *Handler) checkBackupManagerAvailable(w http.ResponseWriter) bool {
 func getBackupIDFromQuery(w http.ResponseWriter, r *http.Request) (string, bool) {
 	backupID := r.URL.Query().Get("id")
 	if backupID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Backup ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Backup ID is required", nil)
 		return "", false
 	}
 	return backupID, true
@@ -175,7 +175,7 @@ type SetScheduleConfigRequest struct {
 // HandleCreateBackup creates a new backup
 // POST /api/v1/backup
 func (h *Handler) HandleCreateBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -186,8 +186,8 @@ func (h *Handler) HandleCreateBackup(w http.ResponseWriter, r *http.Request) {
 
 	// Use validator for struct validation (validates type oneof and notes max length)
 	validationReq := CreateBackupRequestValidation(req)
-	if apiErr := validateRequest(&validationReq); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+	if verr := validateRequest(&validationReq); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
@@ -197,7 +197,7 @@ func (h *Handler) HandleCreateBackup(w http.ResponseWriter, r *http.Request) {
 	// Create backup
 	b, err := h.backupManager.CreateBackup(r.Context(), backupType, req.Notes)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "BACKUP_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "BACKUP_FAILED", err.Error(), err)
 		return
 	}
 
@@ -207,7 +207,7 @@ func (h *Handler) HandleCreateBackup(w http.ResponseWriter, r *http.Request) {
 // HandleListBackups lists all backups with optional filtering
 // GET /api/v1/backups
 func (h *Handler) HandleListBackups(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -217,7 +217,7 @@ func (h *Handler) HandleListBackups(w http.ResponseWriter, r *http.Request) {
 	// List backups
 	backups, err := h.backupManager.ListBackups(opts)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "LIST_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "LIST_FAILED", err.Error(), err)
 		return
 	}
 
@@ -232,7 +232,7 @@ func (h *Handler) HandleListBackups(w http.ResponseWriter, r *http.Request) {
 // HandleGetBackup gets a specific backup by ID
 // GET /api/v1/backups/{id}
 func (h *Handler) HandleGetBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -243,7 +243,7 @@ func (h *Handler) HandleGetBackup(w http.ResponseWriter, r *http.Request) {
 
 	b, err := h.backupManager.GetBackup(backupID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", err.Error(), err)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", err.Error(), err)
 		return
 	}
 
@@ -253,7 +253,7 @@ func (h *Handler) HandleGetBackup(w http.ResponseWriter, r *http.Request) {
 // HandleDeleteBackup deletes a backup
 // DELETE /api/v1/backups/{id}
 func (h *Handler) HandleDeleteBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodDelete) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodDelete) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -263,7 +263,7 @@ func (h *Handler) HandleDeleteBackup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.backupManager.DeleteBackup(backupID); err != nil {
-		respondError(w, http.StatusInternalServerError, "DELETE_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "DELETE_FAILED", err.Error(), err)
 		return
 	}
 
@@ -275,7 +275,7 @@ func (h *Handler) HandleDeleteBackup(w http.ResponseWriter, r *http.Request) {
 // HandleValidateBackup validates a backup's integrity
 // GET /api/v1/backups/{id}/validate
 func (h *Handler) HandleValidateBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -286,7 +286,7 @@ func (h *Handler) HandleValidateBackup(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.backupManager.ValidateBackup(backupID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "VALIDATION_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "VALIDATION_FAILED", err.Error(), err)
 		return
 	}
 
@@ -296,7 +296,7 @@ func (h *Handler) HandleValidateBackup(w http.ResponseWriter, r *http.Request) {
 // HandleRestoreBackup restores from a backup
 // POST /api/v1/backups/{id}/restore
 func (h *Handler) HandleRestoreBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -323,7 +323,7 @@ func (h *Handler) HandleRestoreBackup(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.backupManager.RestoreFromBackup(r.Context(), backupID, opts)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "RESTORE_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "RESTORE_FAILED", err.Error(), err)
 		return
 	}
 
@@ -338,7 +338,7 @@ func (h *Handler) HandleRestoreBackup(w http.ResponseWriter, r *http.Request) {
 // HandleDownloadBackup downloads a backup file
 // GET /api/v1/backups/{id}/download
 func (h *Handler) HandleDownloadBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -349,7 +349,7 @@ func (h *Handler) HandleDownloadBackup(w http.ResponseWriter, r *http.Request) {
 
 	reader, b, err := h.backupManager.DownloadBackup(backupID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", err.Error(), err)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", err.Error(), err)
 		return
 	}
 	defer reader.Close()
@@ -376,26 +376,26 @@ func setDownloadHeaders(w http.ResponseWriter, b *backup.Backup) {
 // HandleUploadBackup uploads and imports a backup file
 // POST /api/v1/backups/upload
 func (h *Handler) HandleUploadBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
 	// Parse multipart form (max 500MB)
 	if err := r.ParseMultipartForm(500 << 20); err != nil {
-		respondError(w, http.StatusBadRequest, "PARSE_ERROR", "Failed to parse upload: "+err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PARSE_ERROR", "Failed to parse upload: "+err.Error(), err)
 		return
 	}
 
 	file, header, err := r.FormFile("backup")
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "FILE_ERROR", "No backup file provided", err)
+		respondError(w, r, http.StatusBadRequest, "FILE_ERROR", "No backup file provided", err)
 		return
 	}
 	defer file.Close()
 
 	b, err := h.backupManager.ImportBackup(r.Context(), file, header.Filename)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "IMPORT_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "IMPORT_FAILED", err.Error(), err)
 		return
 	}
 
@@ -405,13 +405,13 @@ func (h *Handler) HandleUploadBackup(w http.ResponseWriter, r *http.Request) {
 // HandleGetBackupStats gets backup statistics
 // GET /api/v1/backup/stats
 func (h *Handler) HandleGetBackupStats(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
 	stats, err := h.backupManager.GetStats()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "STATS_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "STATS_FAILED", err.Error(), err)
 		return
 	}
 
@@ -421,7 +421,7 @@ func (h *Handler) HandleGetBackupStats(w http.ResponseWriter, r *http.Request) {
 // HandleGetRetentionPolicy gets the current retention policy
 // GET /api/v1/backup/retention
 func (h *Handler) HandleGetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -432,20 +432,20 @@ func (h *Handler) HandleGetRetentionPolicy(w http.ResponseWriter, r *http.Reques
 // HandleSetRetentionPolicy sets the retention policy
 // PUT /api/v1/backup/retention
 func (h *Handler) HandleSetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPut) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPut) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
 	var req SetRetentionPolicyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	// Use validator for struct validation (validates all fields >= 0)
 	validationReq := SetRetentionPolicyRequestValidation(req)
-	if apiErr := validateRequest(&validationReq); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+	if verr := validateRequest(&validationReq); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
@@ -460,7 +460,7 @@ func (h *Handler) HandleSetRetentionPolicy(w http.ResponseWriter, r *http.Reques
 	}
 
 	if err := h.backupManager.SetRetentionPolicy(policy); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_POLICY", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_POLICY", err.Error(), err)
 		return
 	}
 
@@ -470,13 +470,13 @@ func (h *Handler) HandleSetRetentionPolicy(w http.ResponseWriter, r *http.Reques
 // HandleRetentionPreview shows what would be deleted by retention policy
 // GET /api/v1/backup/retention/preview
 func (h *Handler) HandleRetentionPreview(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
 	preview, err := h.backupManager.GetRetentionPreview()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PREVIEW_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "PREVIEW_FAILED", err.Error(), err)
 		return
 	}
 
@@ -486,7 +486,7 @@ func (h *Handler) HandleRetentionPreview(w http.ResponseWriter, r *http.Request)
 // HandleApplyRetention manually applies retention policy
 // POST /api/v1/backup/retention/apply
 func (h *Handler) HandleApplyRetention(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -494,7 +494,7 @@ func (h *Handler) HandleApplyRetention(w http.ResponseWriter, r *http.Request) {
 	preview, previewErr := h.backupManager.GetRetentionPreview()
 
 	if err := h.backupManager.ApplyRetentionPolicy(r.Context()); err != nil {
-		respondError(w, http.StatusInternalServerError, "APPLY_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "APPLY_FAILED", err.Error(), err)
 		return
 	}
 
@@ -521,13 +521,13 @@ func buildRetentionResponseData(preview *backup.RetentionPreview, previewErr err
 // HandleCleanupCorrupted cleans up corrupted backups
 // POST /api/v1/backup/cleanup
 func (h *Handler) HandleCleanupCorrupted(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
 	count, err := h.backupManager.CleanupCorruptedBackups(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "CLEANUP_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "CLEANUP_FAILED", err.Error(), err)
 		return
 	}
 
@@ -541,7 +541,7 @@ func (h *Handler) HandleCleanupCorrupted(w http.ResponseWriter, r *http.Request)
 // This is a simplified endpoint for easy backup creation
 // POST /api/v1/backup/quick
 func (h *Handler) HandleQuickBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -549,7 +549,7 @@ func (h *Handler) HandleQuickBackup(w http.ResponseWriter, r *http.Request) {
 	notes := fmt.Sprintf("Quick backup created at %s", time.Now().Format(time.RFC3339))
 	b, err := h.backupManager.CreateBackup(r.Context(), backup.TypeFull, notes)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "BACKUP_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "BACKUP_FAILED", err.Error(), err)
 		return
 	}
 
@@ -559,7 +559,7 @@ func (h *Handler) HandleQuickBackup(w http.ResponseWriter, r *http.Request) {
 // HandleGetScheduleConfig gets the current backup schedule configuration
 // GET /api/v1/backup/schedule
 func (h *Handler) HandleGetScheduleConfig(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodGet) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
@@ -582,20 +582,20 @@ func buildScheduleResponseData(schedule backup.ScheduleConfig) map[string]interf
 // HandleSetScheduleConfig updates the backup schedule configuration
 // PUT /api/v1/backup/schedule
 func (h *Handler) HandleSetScheduleConfig(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPut) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPut) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
 	var req SetScheduleConfigRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	// Use validator for struct validation
 	validationReq := SetScheduleConfigRequestValidation(req)
-	if apiErr := validateRequest(&validationReq); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+	if verr := validateRequest(&validationReq); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
@@ -607,8 +607,9 @@ func (h *Handler) HandleSetScheduleConfig(w http.ResponseWriter, r *http.Request
 		PreSyncBackup: req.PreSyncBackup,
 	}
 
-	if err := h.backupManager.SetScheduleConfig(r.Context(), schedule); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_SCHEDULE", err.Error(), err)
+	// The scheduler outlives the request
+	if err := h.backupManager.SetScheduleConfig(context.WithoutCancel(r.Context()), schedule); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_SCHEDULE", err.Error(), err)
 		return
 	}
 
@@ -627,13 +628,13 @@ func (h *Handler) HandleSetScheduleConfig(w http.ResponseWriter, r *http.Request
 // HandleTriggerScheduledBackup triggers a backup using the scheduled backup settings
 // POST /api/v1/backup/schedule/trigger
 func (h *Handler) HandleTriggerScheduledBackup(w http.ResponseWriter, r *http.Request) {
-	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w) {
+	if !checkHTTPMethod(w, r, http.MethodPost) || !h.checkBackupManagerAvailable(w, r) {
 		return
 	}
 
 	b, err := h.backupManager.TriggerScheduledBackup(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "BACKUP_FAILED", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "BACKUP_FAILED", err.Error(), err)
 		return
 	}
 
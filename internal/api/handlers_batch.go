@@ -0,0 +1,375 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// handlers_batch.go - Batched GET requests for dashboard initial load
+//
+// The dashboard needs 15-20 endpoints on load. POST /api/v1/batch runs
+// several whitelisted GET endpoints in one request:
+//   - Items run concurrently on a bounded worker pool
+//   - Each item is served by the same handler as the standalone endpoint,
+//     with the batch request's auth context, so caching and user scoping match
+//   - Each item holds an analytics slot (DB_MAX_CONCURRENT_ANALYTICS) while
+//     it runs, so a batch counts against the limit like the requests it replaces
+//   - A failing item only sets its own status; the batch still succeeds
+//   - With Accept: application/x-ndjson, each item is written as one line
+//     as soon as it completes
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+const (
+	// batchMaxItems caps the items in one batch request.
+	batchMaxItems = 25
+
+	// batchWorkers is how many items of a batch run at once.
+	batchWorkers = 4
+
+	// batchNDJSON is the Accept type that streams items as they complete.
+	batchNDJSON = "application/x-ndjson"
+)
+
+// BatchRequestItem is one GET request of a batch.
+type BatchRequestItem struct {
+	ID     string            `json:"id"`               // Key of the item in the response
+	Path   string            `json:"path"`             // e.g. "/api/v1/analytics/trends"
+	Params map[string]string `json:"params,omitempty"` // Query parameters
+}
+
+// BatchItemResult is the response of one batch item. Body is the JSON the
+// standalone endpoint would have returned.
+type BatchItemResult struct {
+	ID     string          `json:"id,omitempty"` // Only set when streaming
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// batchExecutor serves POST /api/v1/batch for a whitelist of GET handlers.
+type batchExecutor struct {
+	endpoints map[string]http.HandlerFunc // Path -> handler
+	workers   int
+
+	// acquire waits for the analytics slot an item runs under; nil means
+	// items are not limited
+	acquire func(ctx context.Context) (release func(), err error)
+}
+
+// newBatchExecutor creates a batch executor for the given endpoints. Items
+// run while holding a slot from acquire, if not nil.
+func newBatchExecutor(endpoints map[string]http.HandlerFunc, acquire func(context.Context) (func(), error)) *batchExecutor {
+	return &batchExecutor{endpoints: endpoints, workers: batchWorkers, acquire: acquire}
+}
+
+// batchEndpoints returns the read-only endpoints that may be batched.
+// Endpoints with path parameters are not batchable.
+func (h *Handler) batchEndpoints() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/api/v1/stats":          h.Stats,
+		"/api/v1/playbacks":      h.Playbacks,
+		"/api/v1/locations":      h.Locations,
+		"/api/v1/users":          h.Users,
+		"/api/v1/media-types":    h.MediaTypes,
+		"/api/v1/server-info":    h.ServerInfo,
+		"/api/v1/sources/health": h.SourcesHealth,
+
+		"/api/v1/analytics/trends":                    h.AnalyticsTrends,
+		"/api/v1/analytics/geographic":                h.AnalyticsGeographic,
+		"/api/v1/analytics/users":                     h.AnalyticsUsers,
+		"/api/v1/analytics/binge":                     h.AnalyticsBinge,
+		"/api/v1/analytics/bandwidth":                 h.AnalyticsBandwidth,
+		"/api/v1/analytics/bitrate":                   h.AnalyticsBitrate,
+		"/api/v1/analytics/popular":                   h.AnalyticsPopular,
+		"/api/v1/analytics/watch-parties":             h.AnalyticsWatchParties,
+		"/api/v1/analytics/user-engagement":           h.AnalyticsUserEngagement,
+		"/api/v1/analytics/abandonment":               h.AnalyticsAbandonment,
+		"/api/v1/analytics/comparative":               h.AnalyticsComparative,
+		"/api/v1/analytics/temporal-heatmap":          h.AnalyticsTemporalHeatmap,
+		"/api/v1/analytics/resolution-mismatch":       h.AnalyticsResolutionMismatch,
+		"/api/v1/analytics/hdr":                       h.AnalyticsHDR,
+		"/api/v1/analytics/audio":                     h.AnalyticsAudio,
+		"/api/v1/analytics/subtitles":                 h.AnalyticsSubtitles,
+		"/api/v1/analytics/frame-rate":                h.AnalyticsFrameRate,
+		"/api/v1/analytics/container":                 h.AnalyticsContainer,
+		"/api/v1/analytics/connection-security":       h.AnalyticsConnectionSecurity,
+		"/api/v1/analytics/pause-patterns":            h.AnalyticsPausePatterns,
+		"/api/v1/analytics/concurrent-streams":        h.AnalyticsConcurrentStreams,
+		"/api/v1/analytics/library":                   h.AnalyticsLibrary,
+		"/api/v1/analytics/hardware-transcode":        h.AnalyticsHardwareTranscode,
+		"/api/v1/analytics/hardware-transcode/trends": h.AnalyticsHardwareTranscodeTrends,
+		"/api/v1/analytics/transcode-reasons":         h.AnalyticsTranscodeReasons,
+		"/api/v1/analytics/hdr-content":               h.AnalyticsHDRContent,
+		"/api/v1/analytics/cohort-retention":          h.AnalyticsCohortRetention,
+		"/api/v1/analytics/qoe":                       h.AnalyticsQoE,
+		"/api/v1/analytics/data-quality":              h.AnalyticsDataQuality,
+		"/api/v1/analytics/user-network":              h.AnalyticsUserNetwork,
+		"/api/v1/analytics/device-migration":          h.AnalyticsDeviceMigration,
+		"/api/v1/analytics/content-discovery":         h.AnalyticsContentDiscovery,
+		"/api/v1/analytics/content-flow":              h.AnalyticsContentFlow,
+		"/api/v1/analytics/user-overlap":              h.AnalyticsUserOverlap,
+		"/api/v1/analytics/user-profile":              h.AnalyticsUserProfile,
+		"/api/v1/analytics/library-utilization":       h.AnalyticsLibraryUtilization,
+		"/api/v1/analytics/calendar-heatmap":          h.AnalyticsCalendarHeatmap,
+		"/api/v1/analytics/bump-chart":                h.AnalyticsBumpChart,
+		"/api/v1/analytics/approximate":               h.ApproximateStats,
+		"/api/v1/analytics/approximate/distinct":      h.ApproximateDistinctCount,
+		"/api/v1/analytics/approximate/percentile":    h.ApproximatePercentile,
+		"/api/v1/analytics/cross-platform/summary":    h.CrossPlatformSummary,
+	}
+}
+
+// Batch handles POST /api/v1/batch
+//
+// Request body: a JSON array of {id, path, params} referencing batchable GET
+// endpoints (at most 25). Response: a map of id -> {status, body}, or with
+// Accept: application/x-ndjson one {id, status, body} line per item in
+// completion order.
+func (b *batchExecutor) Batch(w http.ResponseWriter, r *http.Request) {
+	var items []BatchRequestItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Request body must be a JSON array of {id, path, params}", err)
+		return
+	}
+	if err := validateBatchItems(items); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		return
+	}
+
+	results := b.run(r, items)
+
+	if strings.Contains(r.Header.Get("Accept"), batchNDJSON) {
+		b.stream(w, results)
+		return
+	}
+
+	data := make(map[string]*BatchItemResult, len(items))
+	for result := range results {
+		data[result.ID] = result
+		result.ID = ""
+	}
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     data,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// validateBatchItems checks the batch size and that item IDs are unique.
+func validateBatchItems(items []BatchRequestItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("batch is empty")
+	}
+	if len(items) > batchMaxItems {
+		return fmt.Errorf("batch has %d items; the maximum is %d", len(items), batchMaxItems)
+	}
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.ID == "" {
+			return fmt.Errorf("every batch item needs an id")
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("duplicate batch item id %q", item.ID)
+		}
+		seen[item.ID] = true
+	}
+	return nil
+}
+
+// run executes items on the worker pool. Results are sent as items complete;
+// the channel is closed once all are done.
+func (b *batchExecutor) run(r *http.Request, items []BatchRequestItem) <-chan *BatchItemResult {
+	queue := make(chan BatchRequestItem)
+	results := make(chan *BatchItemResult, len(items))
+
+	workers := min(b.workers, len(items))
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				results <- b.execute(r, item)
+			}
+		}()
+	}
+
+	go func() {
+		for _, item := range items {
+			queue <- item
+		}
+		close(queue)
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// execute serves one item with its standalone handler. The sub-request
+// shares the batch request's context, so auth claims, the request ID and
+// the request deadline carry over. Items always use the legacy error
+// envelope, so errors raised here pass no request to respondError.
+func (b *batchExecutor) execute(r *http.Request, item BatchRequestItem) (result *BatchItemResult) {
+	rec := newBatchRecorder()
+	defer func() {
+		if p := recover(); p != nil {
+			logging.Error().Str("path", sanitizeLogValue(item.Path)).Interface("panic", p).Msg("Batch item panicked")
+			rec = newBatchRecorder()
+			respondError(rec, nil, http.StatusInternalServerError, "INTERNAL_ERROR", "Batch item failed", nil)
+		}
+		if rec.status == 0 && rec.body.Len() == 0 {
+			// Handlers write nothing once the request context is done
+			// (see respondQueryError)
+			respondBatchContextError(rec, r.Context().Err())
+		}
+		result = rec.result(item.ID)
+	}()
+
+	if r.Context().Err() != nil {
+		return // The batch ran out of time before this item started
+	}
+
+	target, err := url.Parse(item.Path)
+	if err != nil {
+		respondError(rec, nil, http.StatusBadRequest, "INVALID_REQUEST", "Invalid path", nil)
+		return
+	}
+	handler, ok := b.endpoints[strings.TrimSuffix(target.Path, "/")]
+	if !ok {
+		respondError(rec, nil, http.StatusNotFound, "NOT_BATCHABLE", "Endpoint not found or not available in a batch: "+target.Path, nil)
+		return
+	}
+
+	query := target.Query()
+	for key, value := range item.Params {
+		query.Set(key, value)
+	}
+	target.RawQuery = query.Encode()
+
+	// Hold a slot like the standalone request would. Handlers that take one
+	// themselves (beginQuery) run within it rather than waiting for another.
+	ctx := r.Context()
+	if b.acquire != nil {
+		release, err := b.acquire(ctx)
+		if err != nil {
+			return // Answered from the batch context error
+		}
+		defer release()
+		ctx = database.WithAnalyticsSlot(ctx)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		respondError(rec, nil, http.StatusBadRequest, "INVALID_REQUEST", "Invalid path", nil)
+		return
+	}
+	req.Header = r.Header.Clone()
+	req.Header.Del("Content-Type")
+	req.Header.Del("Content-Length")
+	req.Header.Del("Accept-Encoding")
+	req.Header.Del("If-None-Match") // A 304 would leave the item without a body
+	req.Header.Set("Accept", "application/json")
+	req.RemoteAddr = r.RemoteAddr
+	req.Host = r.Host
+
+	handler(rec, req)
+	return
+}
+
+// respondBatchContextError answers an item that produced no response
+// because the batch context ended first. A nil err leaves the item as an
+// empty 200.
+func respondBatchContextError(rec *batchRecorder, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		respondError(rec, nil, http.StatusGatewayTimeout, ErrCodeQueryTimeout, "The batch deadline passed before this item completed", nil)
+	case err != nil:
+		respondError(rec, nil, http.StatusServiceUnavailable, ErrCodeQueryCanceled, "The batch was canceled before this item completed", nil)
+	}
+}
+
+// stream writes results as newline-delimited JSON, flushing after each one.
+func (b *batchExecutor) stream(w http.ResponseWriter, results <-chan *BatchItemResult) {
+	w.Header().Set("Content-Type", batchNDJSON)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	flusher := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	for result := range results {
+		if err := enc.Encode(result); err != nil {
+			logging.Warn().Err(err).Msg("Failed to write batch item")
+			continue
+		}
+		flusher.Flush() //nolint:errcheck // not every writer can flush; the items still arrive when the response ends
+	}
+}
+
+// batchRecorder captures the response of one batch item.
+type batchRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{header: make(http.Header)}
+}
+
+// Header implements http.ResponseWriter.
+func (rec *batchRecorder) Header() http.Header {
+	return rec.header
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (rec *batchRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+// Write implements http.ResponseWriter.
+func (rec *batchRecorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.body.Write(p)
+}
+
+// result converts the captured response. A body that is not JSON (such as
+// an http.Error message) is returned as a JSON string.
+func (rec *batchRecorder) result(id string) *BatchItemResult {
+	status := rec.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	body := bytes.TrimSpace(rec.body.Bytes())
+	switch {
+	case len(body) == 0:
+		body = []byte("null")
+	case !json.Valid(body):
+		quoted, err := json.Marshal(string(body))
+		if err != nil {
+			quoted = []byte("null")
+		}
+		body = quoted
+	}
+	return &BatchItemResult{ID: id, Status: status, Body: body}
+}
@@ -0,0 +1,370 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/auth"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// batchTestEndpoint responds with its name and the days query parameter.
+func batchTestEndpoint(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, &models.APIResponse{
+			Status: "success",
+			Data:   map[string]string{"name": name, "days": r.URL.Query().Get("days")},
+		})
+	}
+}
+
+// postBatch sends items to the executor and returns the recorded response.
+func postBatch(t *testing.T, b *batchExecutor, ctx context.Context, body string, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body)).WithContext(ctx)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	b.Batch(w, req)
+	return w
+}
+
+// decodeBatch decodes a map response into its items.
+func decodeBatch(t *testing.T, w *httptest.ResponseRecorder) map[string]BatchItemResult {
+	t.Helper()
+	var resp struct {
+		Status string                     `json:"status"`
+		Data   map[string]BatchItemResult `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode batch response: %v\n%s", err, w.Body.String())
+	}
+	return resp.Data
+}
+
+func TestBatch_ItemFailuresAreIsolated(t *testing.T) {
+	t.Parallel()
+
+	b := newBatchExecutor(map[string]http.HandlerFunc{
+		"/api/v1/stats": batchTestEndpoint("stats"),
+		"/api/v1/fail": func(w http.ResponseWriter, _ *http.Request) {
+			respondError(w, nil, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database unavailable", nil)
+		},
+		"/api/v1/plain": func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "Unauthorized: invalid token", http.StatusUnauthorized)
+		},
+		"/api/v1/panic": func(http.ResponseWriter, *http.Request) { panic("boom") },
+	}, nil)
+
+	w := postBatch(t, b, context.Background(), `[
+		{"id": "stats", "path": "/api/v1/stats", "params": {"days": "30"}},
+		{"id": "query", "path": "/api/v1/stats/?days=7"},
+		{"id": "fail", "path": "/api/v1/fail"},
+		{"id": "plain", "path": "/api/v1/plain"},
+		{"id": "panic", "path": "/api/v1/panic"},
+		{"id": "unknown", "path": "/api/v1/admin/backup"}
+	]`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	items := decodeBatch(t, w)
+	if len(items) != 6 {
+		t.Fatalf("got %d items, want 6", len(items))
+	}
+	wantStatus := map[string]int{
+		"stats": http.StatusOK, "query": http.StatusOK, "fail": http.StatusServiceUnavailable,
+		"plain": http.StatusUnauthorized, "panic": http.StatusInternalServerError, "unknown": http.StatusNotFound,
+	}
+	for id, status := range wantStatus {
+		if items[id].Status != status {
+			t.Errorf("%s status = %d, want %d (body %s)", id, items[id].Status, status, items[id].Body)
+		}
+	}
+
+	var stats models.APIResponse
+	if err := json.Unmarshal(items["stats"].Body, &stats); err != nil {
+		t.Fatalf("decode stats body: %v", err)
+	}
+	if data, _ := stats.Data.(map[string]interface{}); data["days"] != "30" {
+		t.Errorf("stats data = %v, want params applied", stats.Data)
+	}
+	if !strings.Contains(string(items["query"].Body), `"days":"7"`) {
+		t.Errorf("query body = %s, want query string and trailing slash handled", items["query"].Body)
+	}
+	if !strings.Contains(string(items["unknown"].Body), "NOT_BATCHABLE") {
+		t.Errorf("unknown body = %s", items["unknown"].Body)
+	}
+	if string(items["plain"].Body) != `"Unauthorized: invalid token"` {
+		t.Errorf("plain body = %s, want the text as a JSON string", items["plain"].Body)
+	}
+}
+
+func TestBatch_SharesAuthContext(t *testing.T) {
+	t.Parallel()
+
+	b := newBatchExecutor(map[string]http.HandlerFunc{
+		"/api/v1/me": func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := r.Context().Value(auth.ClaimsContextKey).(*auth.Claims)
+			if !ok {
+				http.Error(w, "no claims", http.StatusUnauthorized)
+				return
+			}
+			respondJSON(w, http.StatusOK, &models.APIResponse{Status: "success", Data: claims.Username})
+		},
+	}, nil)
+
+	ctx := context.WithValue(context.Background(), auth.ClaimsContextKey, &auth.Claims{Username: "alice", Role: "viewer"})
+	items := decodeBatch(t, postBatch(t, b, ctx, `[{"id": "me", "path": "/api/v1/me"}]`, ""))
+	if items["me"].Status != http.StatusOK || !strings.Contains(string(items["me"].Body), `"alice"`) {
+		t.Errorf("me = %d %s, want the batch request's claims", items["me"].Status, items["me"].Body)
+	}
+}
+
+// testSlots returns an acquire function for a semaphore of n analytics
+// slots, and the semaphore itself.
+func testSlots(n int) (func(context.Context) (func(), error), chan struct{}) {
+	slots := make(chan struct{}, n)
+	return func(ctx context.Context) (func(), error) {
+		select {
+		case slots <- struct{}{}:
+			return func() { <-slots }, nil
+		case <-ctx.Done():
+			return func() {}, ctx.Err()
+		}
+	}, slots
+}
+
+func TestBatch_ItemsHoldAnalyticsSlot(t *testing.T) {
+	t.Parallel()
+
+	acquire, slots := testSlots(1)
+	var active, peak atomic.Int32
+	held := func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		if len(slots) != 1 {
+			http.Error(w, "ran without a slot", http.StatusInternalServerError)
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+		batchTestEndpoint("held")(w, r)
+	}
+	b := newBatchExecutor(map[string]http.HandlerFunc{"/api/v1/a": held, "/api/v1/b": held, "/api/v1/c": held}, acquire)
+
+	items := decodeBatch(t, postBatch(t, b, context.Background(), `[
+		{"id": "a", "path": "/api/v1/a"}, {"id": "b", "path": "/api/v1/b"}, {"id": "c", "path": "/api/v1/c"}
+	]`, ""))
+	for id, item := range items {
+		if item.Status != http.StatusOK {
+			t.Errorf("%s = %d %s, want 200", id, item.Status, item.Body)
+		}
+	}
+	if got := peak.Load(); got != 1 {
+		t.Errorf("peak concurrent items = %d, want 1 with one analytics slot", got)
+	}
+}
+
+func TestBatch_QueuedBehindAnalyticsLimit(t *testing.T) {
+	t.Parallel()
+
+	acquire, slots := testSlots(1)
+	slots <- struct{}{} // Another request holds the only slot
+
+	var ran atomic.Bool
+	b := newBatchExecutor(map[string]http.HandlerFunc{
+		"/api/v1/analytics/trends": func(w http.ResponseWriter, r *http.Request) {
+			ran.Store(true)
+			batchTestEndpoint("trends")(w, r)
+		},
+	}, acquire)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	items := decodeBatch(t, postBatch(t, b, ctx, `[{"id": "trends", "path": "/api/v1/analytics/trends"}]`, ""))
+
+	if ran.Load() {
+		t.Error("item ran without an analytics slot")
+	}
+	if item := items["trends"]; item.Status != http.StatusGatewayTimeout || !strings.Contains(string(item.Body), string(ErrCodeQueryTimeout)) {
+		t.Errorf("trends = %d %s, want 504 QUERY_TIMEOUT", item.Status, item.Body)
+	}
+}
+
+func TestBatch_Validation(t *testing.T) {
+	t.Parallel()
+
+	b := newBatchExecutor(map[string]http.HandlerFunc{"/api/v1/stats": batchTestEndpoint("stats")}, nil)
+	tooMany := make([]string, batchMaxItems+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf(`{"id": "i%d", "path": "/api/v1/stats"}`, i)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"not an array", `{"id": "a", "path": "/api/v1/stats"}`},
+		{"empty", `[]`},
+		{"missing id", `[{"path": "/api/v1/stats"}]`},
+		{"duplicate id", `[{"id": "a", "path": "/api/v1/stats"}, {"id": "a", "path": "/api/v1/stats"}]`},
+		{"too many items", "[" + strings.Join(tooMany, ",") + "]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := postBatch(t, b, context.Background(), tt.body, ""); w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", w.Code)
+			}
+		})
+	}
+}
+
+func TestBatch_StreamsItemsAsTheyComplete(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	b := newBatchExecutor(map[string]http.HandlerFunc{
+		"/api/v1/fast": batchTestEndpoint("fast"),
+		"/api/v1/slow": func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			batchTestEndpoint("slow")(w, r)
+		},
+	}, nil)
+
+	server := httptest.NewServer(http.HandlerFunc(b.Batch))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(
+		`[{"id": "slow", "path": "/api/v1/slow"}, {"id": "fast", "path": "/api/v1/fast"}]`))
+	req.Header.Set("Accept", batchNDJSON)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST batch: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != batchNDJSON {
+		t.Errorf("Content-Type = %q, want %q", ct, batchNDJSON)
+	}
+
+	// The fast item arrives while the slow one is still blocked
+	lines := bufio.NewScanner(resp.Body)
+	var first BatchItemResult
+	if !lines.Scan() || json.Unmarshal(lines.Bytes(), &first) != nil || first.ID != "fast" {
+		t.Fatalf("first line = %q, want the fast item", lines.Text())
+	}
+	close(release)
+
+	var second BatchItemResult
+	if !lines.Scan() || json.Unmarshal(lines.Bytes(), &second) != nil || second.ID != "slow" || second.Status != http.StatusOK {
+		t.Fatalf("second line = %q, want the slow item", lines.Text())
+	}
+	if lines.Scan() {
+		t.Errorf("unexpected extra line %q", lines.Text())
+	}
+}
+
+// TestBatch_RequestCountAndLatency compares loading a dashboard with one
+// request per endpoint against a single batch request.
+func TestBatch_RequestCountAndLatency(t *testing.T) {
+	const endpoints = 12
+	const latency = 20 * time.Millisecond
+
+	var active, peak atomic.Int32
+	slow := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			n := active.Add(1)
+			defer active.Add(-1)
+			for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			time.Sleep(latency)
+			batchTestEndpoint(name)(w, r)
+		}
+	}
+
+	handlers := make(map[string]http.HandlerFunc, endpoints)
+	items := make([]string, 0, endpoints)
+	mux := http.NewServeMux()
+	for i := range endpoints {
+		path := fmt.Sprintf("/api/v1/analytics/e%d", i)
+		handlers[path] = slow(path)
+		mux.HandleFunc("GET "+path, handlers[path])
+		items = append(items, fmt.Sprintf(`{"id": "e%d", "path": %q}`, i, path))
+	}
+	mux.HandleFunc("POST /api/v1/batch", newBatchExecutor(handlers, nil).Batch)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	// One request per endpoint, as the dashboard loads today (sequentially
+	// here to keep the comparison deterministic)
+	start := time.Now()
+	for i := range endpoints {
+		resp, err := http.Get(fmt.Sprintf("%s/api/v1/analytics/e%d", server.URL, i))
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		resp.Body.Close()
+	}
+	standaloneLatency, standaloneRequests := time.Since(start), requests.Swap(0)
+
+	start = time.Now()
+	resp, err := http.Post(server.URL+"/api/v1/batch", "application/json", strings.NewReader("["+strings.Join(items, ",")+"]"))
+	if err != nil {
+		t.Fatalf("POST batch: %v", err)
+	}
+	var batch struct {
+		Data map[string]BatchItemResult `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&batch)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode batch: %v", err)
+	}
+	batchLatency, batchRequests := time.Since(start), requests.Load()
+
+	t.Logf("standalone: %d requests in %v; batch: %d request in %v", standaloneRequests, standaloneLatency, batchRequests, batchLatency)
+
+	if standaloneRequests != endpoints || batchRequests != 1 {
+		t.Errorf("requests = %d standalone, %d batched; want %d and 1", standaloneRequests, batchRequests, endpoints)
+	}
+	if len(batch.Data) != endpoints {
+		t.Errorf("batch returned %d items, want %d", len(batch.Data), endpoints)
+	}
+	if batchLatency >= standaloneLatency {
+		t.Errorf("batch took %v, not faster than %v for separate requests", batchLatency, standaloneLatency)
+	}
+	if p := peak.Load(); p > batchWorkers {
+		t.Errorf("peak concurrency = %d, want at most %d workers", p, batchWorkers)
+	}
+}
+
+func TestBatchEndpoints_AreGETRoutes(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	for path := range h.batchEndpoints() {
+		if !strings.HasPrefix(path, "/api/v1/") || strings.ContainsAny(path, "{}") || strings.HasSuffix(path, "/") {
+			t.Errorf("batch endpoint %q is not a plain API path", path)
+		}
+	}
+}
@@ -245,7 +245,7 @@ func TestRespondError_EdgeCases(t *testing.T) {
 
 	t.Run("with error", func(t *testing.T) {
 		w := httptest.NewRecorder()
-		respondError(w, http.StatusInternalServerError, "DB_ERROR", "Database error", errors.New("test error"))
+		respondError(w, nil, http.StatusInternalServerError, "DB_ERROR", "Database error", errors.New("test error"))
 
 		var resp models.APIResponse
 		json.NewDecoder(w.Body).Decode(&resp)
@@ -258,7 +258,7 @@ func TestRespondError_EdgeCases(t *testing.T) {
 		codes := []int{400, 401, 403, 404, 405, 409, 410, 429, 500, 501, 502, 503}
 		for _, code := range codes {
 			w := httptest.NewRecorder()
-			respondError(w, code, "TEST", "msg", nil)
+			respondError(w, nil, code, "TEST", "msg", nil)
 			if w.Code != code {
 				t.Errorf("Expected %d, got %d", code, w.Code)
 			}
@@ -0,0 +1,104 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// ConfigReloader applies configuration changes without a restart. The
+// server's reloader in cmd/server implements it.
+type ConfigReloader interface {
+	// Reload loads the configuration again and applies the settings that
+	// can change at runtime, reporting the rest as requiring a restart.
+	Reload(ctx context.Context, actor audit.Actor, source audit.Source) (*config.ReloadResult, error)
+
+	// Running returns the configuration currently in effect.
+	Running() *config.Config
+}
+
+// AdminConfig handles GET /api/v1/admin/config
+// Returns the effective configuration the server is running with, after
+// defaults, the config file and environment variables are merged, in the
+// same shape as config.yaml. Secrets are masked to their last 4 characters
+// by config.Config.Redacted.
+//
+// @Summary Get the effective configuration
+// @Description Returns the merged configuration (defaults, file, environment) with tokens, API keys, passwords and other secrets masked to their last 4 characters.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=map[string]interface{}} "Effective configuration"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 503 {object} models.APIResponse "Configuration not available"
+// @Router /admin/config [get]
+func (h *Handler) AdminConfig(w http.ResponseWriter, r *http.Request) {
+	cfg := h.config
+	if h.configReloader != nil {
+		cfg = h.configReloader.Running() // Includes settings changed by reloads
+	}
+	if cfg == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Configuration not available", nil)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     cfg.Redacted(),
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// ReloadConfig handles POST /api/v1/admin/config/reload
+// Loads the configuration again, as SIGHUP does, and applies changes to the
+// settings that can change at runtime: log level and format, sync interval
+// and batch size, cache TTL, rate limits, detection trust score amounts and
+// the backup schedule. Other changes are listed under requires_restart.
+// Each applied change is logged and audited as config.changed with secrets
+// masked.
+//
+// @Summary Reload the configuration
+// @Description Re-reads the config file and environment and applies runtime-changeable settings. Changes to other settings, such as the database path, ports or auth mode, are reported as requiring a restart.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=config.ReloadResult} "Reload result"
+// @Failure 400 {object} models.APIResponse "New configuration is invalid; nothing was applied"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 503 {object} models.APIResponse "Config reload not available"
+// @Router /admin/config/reload [post]
+func (h *Handler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if h.configReloader == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Config reload not available", nil)
+		return
+	}
+
+	actor := audit.Actor{Type: "user"}
+	if hctx := GetHandlerContext(r); hctx != nil {
+		actor.ID, actor.Name = hctx.UserID, hctx.Username
+	}
+	source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+
+	result, err := h.configReloader.Reload(r.Context(), actor, source)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_CONFIG", err.Error(), err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     result,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
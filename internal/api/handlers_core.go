@@ -43,16 +43,16 @@ import (
 // requireMethod validates HTTP method and returns true if valid, false if error was sent
 func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
 	if r.Method != method {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return false
 	}
 	return true
 }
 
 // requireDB checks database availability and returns true if available, false if error was sent
-func (h *Handler) requireDB(w http.ResponseWriter) bool {
+func (h *Handler) requireDB(w http.ResponseWriter, r *http.Request) bool {
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return false
 	}
 	return true
@@ -66,6 +66,7 @@ func (h *Handler) requireDB(w http.ResponseWriter) bool {
 //
 // Response:
 //   - 200: Statistics retrieved successfully
+//   - 304: Not modified (If-None-Match matches the current ETag)
 //   - 405: Method not allowed (non-GET request)
 //   - 500: Database error
 //   - 503: Database not available
@@ -73,7 +74,7 @@ func (h *Handler) requireDB(w http.ResponseWriter) bool {
 // The response includes query execution time in metadata for performance monitoring.
 // Last sync time is populated from the sync manager if available.
 func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -81,7 +82,7 @@ func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
 
 	stats, err := h.db.GetStats(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve statistics", err)
 		return
 	}
 
@@ -92,7 +93,7 @@ func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	respondJSON(w, http.StatusOK, &models.APIResponse{
+	h.respondJSONWithETag(w, r, &models.APIResponse{
 		Status: "success",
 		Data:   stats,
 		Metadata: models.Metadata{
@@ -112,6 +113,7 @@ func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
 // @Param limit query int false "Number of results per page (1-1000)" default(100) minimum(1) maximum(1000)
 // @Param cursor query string false "Cursor for next page (from previous response's next_cursor). Use this instead of offset for efficient pagination."
 // @Param offset query int false "LEGACY: Number of results to skip (0-1000000). Prefer cursor for large datasets." default(0) minimum(0) maximum(1000000)
+// @Param include_total query bool false "Include total_count in the pagination metadata (runs an extra COUNT query)" default(false)
 // @Success 200 {object} models.APIResponse{data=models.PlaybacksResponse} "Playback events retrieved successfully with pagination info"
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Internal server error"
@@ -123,11 +125,11 @@ func (h *Handler) Playbacks(w http.ResponseWriter, r *http.Request) {
 
 	params, err := h.parsePlaybacksParams(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), err)
 		return
 	}
 
-	if !h.requireDB(w) {
+	if !h.requireDB(w, r) {
 		return
 	}
 
@@ -149,7 +151,8 @@ func (h *Handler) parsePlaybacksParams(r *http.Request) (*playbacksParams, error
 		Offset: offset,
 		Cursor: cursorParam,
 	}
-	if apiErr := validateRequest(&req); apiErr != nil {
+	if verr := validateRequest(&req); verr != nil {
+		apiErr := verr.ToAPIError()
 		return nil, fmt.Errorf("%s: %s", apiErr.Code, apiErr.Message)
 	}
 
@@ -168,71 +171,91 @@ func (h *Handler) parsePlaybacksParams(r *http.Request) (*playbacksParams, error
 	}
 
 	return &playbacksParams{
-		limit:       limit,
-		offset:      offset,
-		cursorParam: cursorParam,
-		cursor:      cursor,
+		limit:        limit,
+		offset:       offset,
+		cursorParam:  cursorParam,
+		cursor:       cursor,
+		includeTotal: r.URL.Query().Get("include_total") == "true",
 	}, nil
 }
 
 // playbacksParams holds validated playback request parameters
 type playbacksParams struct {
-	limit       int
-	offset      int
-	cursorParam string
-	cursor      *models.PlaybackCursor
+	limit        int
+	offset       int
+	cursorParam  string
+	cursor       *models.PlaybackCursor
+	includeTotal bool
 }
 
 // handlePlaybacksPagination routes to appropriate pagination handler
 func (h *Handler) handlePlaybacksPagination(w http.ResponseWriter, r *http.Request, params *playbacksParams, start time.Time) {
-	if params.cursorParam != "" {
-		h.handleCursorPagination(w, r, params.limit, params.cursor, start)
+	var response models.PlaybacksResponse
+	var err error
+	switch {
+	case params.cursorParam != "":
+		response, err = h.handleCursorPagination(r, params.limit, params.cursor)
+	case params.offset == 0:
+		response, err = h.handleFirstPagePagination(r, params.limit)
+	default:
+		response, err = h.handleOffsetPagination(r, params.limit, params.offset)
+	}
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve playback events", err)
 		return
 	}
 
-	if params.offset == 0 {
-		h.handleFirstPagePagination(w, r, params.limit, start)
-		return
+	// The total costs a full count, so it is only computed on request
+	if params.includeTotal {
+		total, err := h.db.CountPlaybackEvents(r.Context())
+		if err != nil {
+			respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to count playback events", err)
+			return
+		}
+		response.Pagination.TotalCount = &total
 	}
 
-	h.handleOffsetPagination(w, r, params.limit, params.offset, start)
+	h.respondWithPlaybacks(w, response, start)
 }
 
 // handleCursorPagination handles cursor-based pagination
-func (h *Handler) handleCursorPagination(w http.ResponseWriter, r *http.Request, limit int, cursor *models.PlaybackCursor, start time.Time) {
+func (h *Handler) handleCursorPagination(r *http.Request, limit int, cursor *models.PlaybackCursor) (models.PlaybacksResponse, error) {
 	events, nextCursor, hasMore, err := h.db.GetPlaybackEventsWithCursor(r.Context(), limit, cursor)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve playback events", err)
-		return
+		return models.PlaybacksResponse{}, err
 	}
 
-	response := buildPlaybacksResponse(events, limit, hasMore, nextCursor)
-	h.respondWithPlaybacks(w, response, start)
+	return buildPlaybacksResponse(events, limit, hasMore, nextCursor), nil
 }
 
-// handleFirstPagePagination handles first page using cursor-based method internally
-func (h *Handler) handleFirstPagePagination(w http.ResponseWriter, r *http.Request, limit int, start time.Time) {
+// handleFirstPagePagination handles first page using cursor-based method internally.
+// The first page is shared by cursor and offset clients, so it carries both.
+func (h *Handler) handleFirstPagePagination(r *http.Request, limit int) (models.PlaybacksResponse, error) {
 	events, nextCursor, hasMore, err := h.db.GetPlaybackEventsWithCursor(r.Context(), limit, nil)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve playback events", err)
-		return
+		return models.PlaybacksResponse{}, err
 	}
 
 	response := buildPlaybacksResponse(events, limit, hasMore, nextCursor)
-	h.respondWithPlaybacks(w, response, start)
+	setOffsetPagination(&response.Pagination, 0, hasMore)
+	return response, nil
 }
 
 // handleOffsetPagination handles legacy offset-based pagination
-func (h *Handler) handleOffsetPagination(w http.ResponseWriter, r *http.Request, limit, offset int, start time.Time) {
-	events, err := h.db.GetPlaybackEvents(r.Context(), limit, offset)
+func (h *Handler) handleOffsetPagination(r *http.Request, limit, offset int) (models.PlaybacksResponse, error) {
+	// Fetch one extra row to know whether another page follows
+	events, err := h.db.GetPlaybackEvents(r.Context(), limit+1, offset)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve playback events", err)
-		return
+		return models.PlaybacksResponse{}, err
 	}
 
-	hasMore := len(events) == limit // Approximate for offset mode
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
 	response := buildPlaybacksResponse(events, limit, hasMore, nil)
-	h.respondWithPlaybacks(w, response, start)
+	setOffsetPagination(&response.Pagination, offset, hasMore)
+	return response, nil
 }
 
 // getPageSizeConfig returns page size configuration with safe defaults
@@ -292,14 +315,17 @@ func buildPlaybacksResponse(events []models.PlaybackEvent, limit int, hasMore bo
 	}
 }
 
-// respondWithPlaybacks sends a successful playback response with timing metadata
+// respondWithPlaybacks sends a successful playback response with timing and
+// pagination metadata
 func (h *Handler) respondWithPlaybacks(w http.ResponseWriter, response models.PlaybacksResponse, start time.Time) {
+	pagination := response.Pagination
 	respondJSON(w, http.StatusOK, &models.APIResponse{
 		Status: "success",
 		Data:   response,
 		Metadata: models.Metadata{
 			Timestamp:   time.Now(),
 			QueryTimeMS: time.Since(start).Milliseconds(),
+			Pagination:  &pagination,
 		},
 	})
 }
@@ -312,21 +338,7 @@ func (h *Handler) respondWithPlaybacks(w http.ResponseWriter, response models.Pl
 // @Accept json
 // @Produce json
 // @Param limit query int false "Maximum number of locations to return (1-1000)" default(100) minimum(1) maximum(1000)
-// @Param start_date query string false "Start date filter (RFC3339 format)" example("2025-01-01T00:00:00Z")
-// @Param end_date query string false "End date filter (RFC3339 format)" example("2025-12-31T23:59:59Z")
-// @Param days query int false "Filter by last N days (1-3650, alternative to start_date)" minimum(1) maximum(3650)
-// @Param users query string false "Comma-separated list of usernames" example("user1,user2")
-// @Param media_types query string false "Comma-separated list of media types" example("movie,episode")
-// @Param platforms query string false "Comma-separated list of platforms" example("Android,iOS")
-// @Param players query string false "Comma-separated list of players" example("Plex for Android,Plex Web")
-// @Param transcode_decisions query string false "Comma-separated transcode decisions" example("transcode,direct play")
-// @Param video_resolutions query string false "Comma-separated video resolutions" example("1080,720")
-// @Param video_codecs query string false "Comma-separated video codecs" example("h264,hevc")
-// @Param audio_codecs query string false "Comma-separated audio codecs" example("aac,ac3")
-// @Param libraries query string false "Comma-separated library names" example("Movies,TV Shows")
-// @Param content_ratings query string false "Comma-separated content ratings" example("PG,PG-13,R")
-// @Param years query string false "Comma-separated release years" example("2023,2024")
-// @Param location_types query string false "Comma-separated location types" example("lan,wan")
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
 // @Success 200 {object} models.APIResponse{data=[]models.LocationStats} "Location statistics retrieved successfully"
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Internal server error"
@@ -338,11 +350,11 @@ func (h *Handler) Locations(w http.ResponseWriter, r *http.Request) {
 
 	filter, err := h.parseLocationsFilter(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), err)
+		respondFilterError(w, r, err)
 		return
 	}
 
-	if !h.requireDB(w) {
+	if !h.requireDB(w, r) {
 		return
 	}
 
@@ -365,8 +377,8 @@ func (h *Handler) parseLocationsFilter(r *http.Request) (database.LocationStatsF
 		EndDate:   r.URL.Query().Get("end_date"),
 		Users:     r.URL.Query().Get("users"),
 	}
-	if apiErr := validateRequest(&req); apiErr != nil {
-		return database.LocationStatsFilter{}, fmt.Errorf("%s: %s", apiErr.Code, apiErr.Message)
+	if verr := validateRequest(&req); verr != nil {
+		return database.LocationStatsFilter{}, verr
 	}
 
 	// Validate limit against dynamic config
@@ -374,50 +386,19 @@ func (h *Handler) parseLocationsFilter(r *http.Request) (database.LocationStatsF
 		return database.LocationStatsFilter{}, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
 	}
 
-	// Validate days when explicitly provided
-	if err := h.validateDaysParam(r, days); err != nil {
-		return database.LocationStatsFilter{}, err
-	}
-
-	// Build and populate filter
-	filter := database.LocationStatsFilter{Limit: limit}
-	if err := parseDateFilter(r, &filter); err != nil {
+	filter, err := models.ParseLocationStatsFilter(r.URL.Query())
+	if err != nil {
 		return database.LocationStatsFilter{}, err
 	}
-
-	h.applyLocationFilters(r, &filter)
+	filter.Limit = limit
 	return filter, nil
 }
 
-// validateDaysParam validates the days query parameter
-func (h *Handler) validateDaysParam(r *http.Request, days int) error {
-	daysStr := r.URL.Query().Get("days")
-	if daysStr == "" {
-		return nil
-	}
-
-	if days < 1 || days > 3650 {
-		return fmt.Errorf("days must be between 1 and 3650")
-	}
-	return nil
-}
-
-// applyLocationFilters applies comma-separated filter parameters
-func (h *Handler) applyLocationFilters(r *http.Request, filter *database.LocationStatsFilter) {
-	if usersParam := r.URL.Query().Get("users"); usersParam != "" {
-		filter.Users = parseCommaSeparated(usersParam)
-	}
-
-	if mediaTypesParam := r.URL.Query().Get("media_types"); mediaTypesParam != "" {
-		filter.MediaTypes = parseCommaSeparated(mediaTypesParam)
-	}
-}
-
 // fetchAndRespondLocations queries database and sends response
 func (h *Handler) fetchAndRespondLocations(w http.ResponseWriter, r *http.Request, filter database.LocationStatsFilter, start time.Time) {
 	locations, err := h.db.GetLocationStatsFiltered(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve location statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve location statistics", err)
 		return
 	}
 
@@ -454,7 +435,7 @@ func (h *Handler) TriggerSync(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if h.sync == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Sync manager not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Sync manager not available", nil)
 		return
 	}
 
@@ -484,7 +465,7 @@ func (h *Handler) TriggerSync(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} models.APIResponse "Internal server error"
 // @Router /users [get]
 func (h *Handler) Users(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -492,7 +473,7 @@ func (h *Handler) Users(w http.ResponseWriter, r *http.Request) {
 
 	users, err := h.db.GetUniqueUsers(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve users", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve users", err)
 		return
 	}
 
@@ -565,7 +546,7 @@ func (h *Handler) ServerInfo(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} models.APIResponse "Internal server error"
 // @Router /media-types [get]
 func (h *Handler) MediaTypes(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -573,7 +554,7 @@ func (h *Handler) MediaTypes(w http.ResponseWriter, r *http.Request) {
 
 	mediaTypes, err := h.db.GetUniqueMediaTypes(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve media types", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve media types", err)
 		return
 	}
 
@@ -593,107 +574,14 @@ func (h *Handler) MediaTypes(w http.ResponseWriter, r *http.Request) {
 
 // AnalyticsTrends handles analytics trends requests
 
-// parseDateFilter parses start_date/days and end_date from query parameters
-func parseDateFilter(r *http.Request, filter *database.LocationStatsFilter) error {
-	if err := parseStartDateFilter(r, filter); err != nil {
-		return err
-	}
-	return parseEndDateFilter(r, filter)
-}
-
-// parseStartDateFilter parses start_date or days parameter
-func parseStartDateFilter(r *http.Request, filter *database.LocationStatsFilter) error {
-	startDateStr := r.URL.Query().Get("start_date")
-	if startDateStr != "" {
-		return parseStartDate(startDateStr, filter)
-	}
-
-	daysStr := r.URL.Query().Get("days")
-	if daysStr != "" {
-		return parseDaysFilter(r, filter)
-	}
-
-	return nil
-}
-
-// parseStartDate parses RFC3339 start date
-func parseStartDate(startDateStr string, filter *database.LocationStatsFilter) error {
-	startDate, err := time.Parse(time.RFC3339, startDateStr)
-	if err != nil {
-		return fmt.Errorf("invalid start_date format: %w", err)
-	}
-	filter.StartDate = &startDate
-	return nil
-}
-
-// parseDaysFilter converts days parameter to start date
-func parseDaysFilter(r *http.Request, filter *database.LocationStatsFilter) error {
-	days := getIntParam(r, "days", 0)
-	if days < 1 || days > 3650 {
-		return nil
-	}
-
-	since := time.Now().AddDate(0, 0, -days)
-	filter.StartDate = &since
-	return nil
-}
-
-// parseEndDateFilter parses end_date parameter
-func parseEndDateFilter(r *http.Request, filter *database.LocationStatsFilter) error {
-	endDateStr := r.URL.Query().Get("end_date")
-	if endDateStr == "" {
-		return nil
-	}
-
-	endDate, err := time.Parse(time.RFC3339, endDateStr)
-	if err != nil {
-		return fmt.Errorf("invalid end_date format: %w", err)
-	}
-	filter.EndDate = &endDate
-	return nil
-}
-
-// applyCommaSeparatedFilters applies comma-separated query parameters to filter fields
-func applyCommaSeparatedFilters(r *http.Request, filter *database.LocationStatsFilter) {
-	filterMap := map[string]*[]string{
-		"users":               &filter.Users,
-		"media_types":         &filter.MediaTypes,
-		"platforms":           &filter.Platforms,
-		"players":             &filter.Players,
-		"transcode_decisions": &filter.TranscodeDecisions,
-		"video_resolutions":   &filter.VideoResolutions,
-		"video_codecs":        &filter.VideoCodecs,
-		"audio_codecs":        &filter.AudioCodecs,
-		"libraries":           &filter.Libraries,
-		"content_ratings":     &filter.ContentRatings,
-		"location_types":      &filter.LocationTypes,
-		"server_ids":          &filter.ServerIDs, // v2.1: Multi-server support
-	}
-
-	for paramName, filterField := range filterMap {
-		if paramValue := r.URL.Query().Get(paramName); paramValue != "" {
-			*filterField = parseCommaSeparated(paramValue)
-		}
-	}
-
-	// Handle years separately (integer slice)
-	if yearsParam := r.URL.Query().Get("years"); yearsParam != "" {
-		filter.Years = parseCommaSeparatedInts(yearsParam)
-	}
-}
-
+// buildFilter parses the analytics filter leniently: invalid parameters are
+// ignored for backward compatibility, and Limit defaults to 1000.
 func (h *Handler) buildFilter(r *http.Request) database.LocationStatsFilter {
-	filter := database.LocationStatsFilter{
-		Limit: 1000,
-	}
-
-	// Parse date filters (silently ignore errors for backward compatibility)
 	//nolint:errcheck // Intentionally ignoring errors for backward compatibility
-	_ = parseDateFilter(r, &filter)
-
-	// Apply all comma-separated filters
-	applyCommaSeparatedFilters(r, &filter)
-
+	filter, _ := models.ParseLocationStatsFilter(r.URL.Query())
+	if filter.Limit == 0 {
+		filter.Limit = models.MaxFilterLimit
+	}
 	return filter
 }
 
@@ -712,7 +600,7 @@ func (h *Handler) WebSocket(w http.ResponseWriter, r *http.Request) {
 	// Check if WebSocket hub is available
 	if h.wsHub == nil {
 		logging.Warn().Msg("WebSocket connection rejected: hub not initialized")
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "WebSocket service unavailable", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "WebSocket service unavailable", nil)
 		return
 	}
 
@@ -752,11 +640,11 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !h.validateAuthConfiguration(w) {
+	if !h.validateAuthConfiguration(w, r) {
 		return
 	}
 
-	if !h.authenticateCredentials(w, req) {
+	if !h.authenticateCredentials(w, r, req) {
 		return
 	}
 
@@ -767,7 +655,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) parseAndValidateLoginRequest(w http.ResponseWriter, r *http.Request) (*models.LoginRequest, error) {
 	var req models.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
 		return nil, err
 	}
 
@@ -776,23 +664,23 @@ func (h *Handler) parseAndValidateLoginRequest(w http.ResponseWriter, r *http.Re
 		Password:   req.Password,
 		RememberMe: req.RememberMe,
 	}
-	if apiErr := validateRequest(&validationReq); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
-		return nil, fmt.Errorf("%s: %s", apiErr.Code, apiErr.Message)
+	if verr := validateRequest(&validationReq); verr != nil {
+		respondValidationError(w, r, verr)
+		return nil, verr
 	}
 
 	return &req, nil
 }
 
 // validateAuthConfiguration checks if JWT authentication is properly configured
-func (h *Handler) validateAuthConfiguration(w http.ResponseWriter) bool {
+func (h *Handler) validateAuthConfiguration(w http.ResponseWriter, r *http.Request) bool {
 	if h.config == nil || h.config.Security.AuthMode != "jwt" {
-		respondError(w, http.StatusForbidden, "AUTH_DISABLED", "Authentication is disabled", nil)
+		respondError(w, r, http.StatusForbidden, "AUTH_DISABLED", "Authentication is disabled", nil)
 		return false
 	}
 
 	if h.jwtManager == nil {
-		respondError(w, http.StatusInternalServerError, "AUTH_NOT_CONFIGURED", "JWT manager not initialized", nil)
+		respondError(w, r, http.StatusInternalServerError, "AUTH_NOT_CONFIGURED", "JWT manager not initialized", nil)
 		return false
 	}
 
@@ -800,9 +688,9 @@ func (h *Handler) validateAuthConfiguration(w http.ResponseWriter) bool {
 }
 
 // authenticateCredentials verifies username and password
-func (h *Handler) authenticateCredentials(w http.ResponseWriter, req *models.LoginRequest) bool {
+func (h *Handler) authenticateCredentials(w http.ResponseWriter, r *http.Request, req *models.LoginRequest) bool {
 	if req.Username != h.config.Security.AdminUsername || req.Password != h.config.Security.AdminPassword {
-		respondError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid username or password", nil)
+		respondError(w, r, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid username or password", nil)
 		return false
 	}
 	return true
@@ -815,7 +703,7 @@ func (h *Handler) generateAndSendToken(w http.ResponseWriter, r *http.Request, r
 
 	token, err := h.jwtManager.GenerateToken(req.Username, role)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "TOKEN_GENERATION_FAILED", "Failed to generate authentication token", err)
+		respondError(w, r, http.StatusInternalServerError, "TOKEN_GENERATION_FAILED", "Failed to generate authentication token", err)
 		return
 	}
 
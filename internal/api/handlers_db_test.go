@@ -7,9 +7,12 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -325,6 +328,99 @@ func TestPlaybacks_Pagination_WithDB(t *testing.T) {
 	}
 }
 
+// TestPlaybacks_PaginationMetadata_WithDB tests that the response metadata
+// describes the returned page and, on request, the total
+func TestPlaybacks_PaginationMetadata_WithDB(t *testing.T) {
+	t.Parallel()
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	insertTestPlaybacks(t, db, 25)
+	handler := setupTestHandlerWithDB(t, db)
+
+	intPtr := func(v int) *int { return &v }
+	tests := []struct {
+		name       string
+		params     string
+		wantEvents int
+		wantCursor bool
+		want       models.PaginationInfo
+	}{
+		{
+			name:       "first page",
+			params:     "limit=10",
+			wantEvents: 10,
+			wantCursor: true,
+			want:       models.PaginationInfo{Limit: 10, HasMore: true, Offset: intPtr(0), NextOffset: intPtr(10)},
+		},
+		{
+			name:       "middle page with total",
+			params:     "limit=10&offset=10&include_total=true",
+			wantEvents: 10,
+			want:       models.PaginationInfo{Limit: 10, HasMore: true, TotalCount: intPtr(25), Offset: intPtr(10), NextOffset: intPtr(20), PrevOffset: intPtr(0)},
+		},
+		{
+			name:       "last page",
+			params:     "limit=10&offset=20&include_total=true",
+			wantEvents: 5,
+			want:       models.PaginationInfo{Limit: 10, HasMore: false, TotalCount: intPtr(25), Offset: intPtr(20), PrevOffset: intPtr(10)},
+		},
+		{
+			name:       "exact final page",
+			params:     "limit=5&offset=20",
+			wantEvents: 5,
+			want:       models.PaginationInfo{Limit: 5, HasMore: false, Offset: intPtr(20), PrevOffset: intPtr(15)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/playbacks?"+tt.params, nil)
+			w := executeRequest(handler.Playbacks, req)
+			assertStatusCode(t, w.Code, http.StatusOK, tt.name)
+
+			var resp struct {
+				Data     models.PlaybacksResponse `json:"data"`
+				Metadata models.Metadata          `json:"metadata"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if len(resp.Data.Events) != tt.wantEvents {
+				t.Errorf("got %d events, want %d", len(resp.Data.Events), tt.wantEvents)
+			}
+			got := resp.Metadata.Pagination
+			if got == nil {
+				t.Fatal("metadata.pagination missing")
+			}
+			if !reflect.DeepEqual(*got, resp.Data.Pagination) {
+				t.Errorf("metadata.pagination = %+v, data.pagination = %+v", *got, resp.Data.Pagination)
+			}
+			// Cursors are opaque; only their presence is checked
+			if (got.NextCursor != nil) != tt.wantCursor {
+				t.Errorf("next_cursor present = %t, want %t", got.NextCursor != nil, tt.wantCursor)
+			}
+			got.NextCursor = nil
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("pagination = %s, want %s", formatPagination(*got), formatPagination(tt.want))
+			}
+		})
+	}
+}
+
+// formatPagination renders pagination info with its pointer fields resolved
+func formatPagination(p models.PaginationInfo) string {
+	deref := func(v *int) string {
+		if v == nil {
+			return "nil"
+		}
+		return strconv.Itoa(*v)
+	}
+	return fmt.Sprintf("{limit:%d has_more:%t total:%s offset:%s next:%s prev:%s}",
+		p.Limit, p.HasMore, deref(p.TotalCount), deref(p.Offset), deref(p.NextOffset), deref(p.PrevOffset))
+}
+
 // TestPlaybacks_EmptyDB tests playbacks endpoint with empty database
 func TestPlaybacks_EmptyDB(t *testing.T) {
 	t.Parallel()
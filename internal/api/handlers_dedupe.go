@@ -25,6 +25,13 @@ func dedupeMetadata(queryStartTime time.Time) models.Metadata {
 	}
 }
 
+// paginatedMetadata creates metadata for a page of a list endpoint.
+func paginatedMetadata(queryStartTime time.Time, pagination *models.PaginationInfo) models.Metadata {
+	metadata := dedupeMetadata(queryStartTime)
+	metadata.Pagination = pagination
+	return metadata
+}
+
 // =============================================================================
 // Dedupe Audit API Handlers (v2.2 - ADR-0022)
 // =============================================================================
@@ -74,7 +81,7 @@ func (h *Handler) DedupeAuditList(w http.ResponseWriter, r *http.Request) {
 	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
 		userID, err := strconv.Atoi(userIDStr)
 		if err != nil {
-			http.Error(w, "Invalid user_id parameter", http.StatusBadRequest)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid user_id parameter", nil)
 			return
 		}
 		filter.UserID = &userID
@@ -88,7 +95,7 @@ func (h *Handler) DedupeAuditList(w http.ResponseWriter, r *http.Request) {
 	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
 		t, err := time.Parse(time.RFC3339, fromStr)
 		if err != nil {
-			http.Error(w, "Invalid 'from' timestamp (use RFC3339 format)", http.StatusBadRequest)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid 'from' timestamp (use RFC3339 format)", nil)
 			return
 		}
 		filter.FromTime = &t
@@ -97,7 +104,7 @@ func (h *Handler) DedupeAuditList(w http.ResponseWriter, r *http.Request) {
 	if toStr := r.URL.Query().Get("to"); toStr != "" {
 		t, err := time.Parse(time.RFC3339, toStr)
 		if err != nil {
-			http.Error(w, "Invalid 'to' timestamp (use RFC3339 format)", http.StatusBadRequest)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid 'to' timestamp (use RFC3339 format)", nil)
 			return
 		}
 		filter.ToTime = &t
@@ -106,7 +113,7 @@ func (h *Handler) DedupeAuditList(w http.ResponseWriter, r *http.Request) {
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil || limit < 1 || limit > 1000 {
-			http.Error(w, "Invalid limit (1-1000)", http.StatusBadRequest)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid limit (1-1000)", nil)
 			return
 		}
 		filter.Limit = limit
@@ -115,7 +122,7 @@ func (h *Handler) DedupeAuditList(w http.ResponseWriter, r *http.Request) {
 	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
 		offset, err := strconv.Atoi(offsetStr)
 		if err != nil || offset < 0 {
-			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid offset", nil)
 			return
 		}
 		filter.Offset = offset
@@ -124,7 +131,7 @@ func (h *Handler) DedupeAuditList(w http.ResponseWriter, r *http.Request) {
 	entries, totalCount, err := h.db.ListDedupeAuditEntries(ctx, filter)
 	if err != nil {
 		logging.Error().Err(err).Msg("Failed to list dedupe audit entries")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list dedupe audit entries", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list dedupe audit entries", err)
 		return
 	}
 
@@ -144,7 +151,7 @@ func (h *Handler) DedupeAuditList(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, &models.APIResponse{
 		Status:   "success",
 		Data:     response,
-		Metadata: dedupeMetadata(queryStart),
+		Metadata: paginatedMetadata(queryStart, newOffsetPagination(limit, filter.Offset, totalCount)),
 	})
 }
 
@@ -156,20 +163,20 @@ func (h *Handler) DedupeAuditGet(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Missing entry ID", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Missing entry ID", nil)
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid entry ID format", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid entry ID format", err)
 		return
 	}
 
 	entry, err := h.db.GetDedupeAuditEntry(ctx, id)
 	if err != nil {
 		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to get dedupe audit entry")
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Dedupe audit entry not found", err)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Dedupe audit entry not found", err)
 		return
 	}
 
@@ -189,7 +196,7 @@ func (h *Handler) DedupeAuditStats(w http.ResponseWriter, r *http.Request) {
 	stats, err := h.db.GetDedupeAuditStats(ctx)
 	if err != nil {
 		logging.Error().Err(err).Msg("Failed to get dedupe audit stats")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get dedupe statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get dedupe statistics", err)
 		return
 	}
 
@@ -208,13 +215,13 @@ func (h *Handler) DedupeAuditConfirm(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Missing entry ID", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Missing entry ID", nil)
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid entry ID format", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid entry ID format", err)
 		return
 	}
 
@@ -230,7 +237,7 @@ func (h *Handler) DedupeAuditConfirm(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.db.UpdateDedupeAuditStatus(ctx, id, "user_confirmed", req.ResolvedBy, req.Notes); err != nil {
 		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to confirm dedupe audit entry")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to confirm dedupe entry", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to confirm dedupe entry", err)
 		return
 	}
 
@@ -238,7 +245,7 @@ func (h *Handler) DedupeAuditConfirm(w http.ResponseWriter, r *http.Request) {
 	entry, err := h.db.GetDedupeAuditEntry(ctx, id)
 	if err != nil {
 		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to get updated entry")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Entry confirmed but failed to retrieve updated record", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Entry confirmed but failed to retrieve updated record", err)
 		return
 	}
 
@@ -257,13 +264,13 @@ func (h *Handler) DedupeAuditRestore(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Missing entry ID", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Missing entry ID", nil)
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid entry ID format", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid entry ID format", err)
 		return
 	}
 
@@ -281,17 +288,17 @@ func (h *Handler) DedupeAuditRestore(w http.ResponseWriter, r *http.Request) {
 	entry, err := h.db.GetDedupeAuditEntry(ctx, id)
 	if err != nil {
 		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to get dedupe audit entry")
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Dedupe audit entry not found", err)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Dedupe audit entry not found", err)
 		return
 	}
 
 	if entry.Status == "user_restored" {
-		respondError(w, http.StatusConflict, "CONFLICT", "Event has already been restored", nil)
+		respondError(w, r, http.StatusConflict, "CONFLICT", "Event has already been restored", nil)
 		return
 	}
 
 	if len(entry.DiscardedRawPayload) == 0 {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "No raw payload available for restoration", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "No raw payload available for restoration", nil)
 		return
 	}
 
@@ -299,7 +306,7 @@ func (h *Handler) DedupeAuditRestore(w http.ResponseWriter, r *http.Request) {
 	var event models.PlaybackEvent
 	if err := json.Unmarshal(entry.DiscardedRawPayload, &event); err != nil {
 		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to unmarshal raw payload")
-		respondError(w, http.StatusInternalServerError, "PARSE_ERROR", "Failed to parse stored event data", err)
+		respondError(w, r, http.StatusInternalServerError, "PARSE_ERROR", "Failed to parse stored event data", err)
 		return
 	}
 
@@ -312,9 +319,9 @@ func (h *Handler) DedupeAuditRestore(w http.ResponseWriter, r *http.Request) {
 	event.CorrelationKey = &restoredFromAudit
 
 	// Insert the restored event
-	if err := h.db.InsertPlaybackEvent(&event); err != nil {
+	if err := h.db.InsertPlaybackEventWithContext(r.Context(), &event); err != nil {
 		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to insert restored event")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to restore event", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to restore event", err)
 		return
 	}
 
@@ -384,7 +391,7 @@ func (h *Handler) DedupeAuditExport(w http.ResponseWriter, r *http.Request) {
 	entries, _, err := h.db.ListDedupeAuditEntries(ctx, filter)
 	if err != nil {
 		logging.Error().Err(err).Msg("Failed to list dedupe audit entries for export")
-		http.Error(w, "Failed to export dedupe audit log", http.StatusInternalServerError)
+		respondError(w, r, http.StatusInternalServerError, "EXPORT_ERROR", "Failed to export dedupe audit log", nil)
 		return
 	}
 
@@ -66,6 +66,11 @@ func TestDedupeAuditList_EmptyDatabase(t *testing.T) {
 	if int(totalCount) != 0 {
 		t.Errorf("Expected total_count 0, got %d", int(totalCount))
 	}
+
+	pagination := response.Metadata.Pagination
+	if pagination == nil || pagination.TotalCount == nil || *pagination.TotalCount != 0 || pagination.HasMore {
+		t.Errorf("metadata.pagination = %+v, want total 0 without more pages", pagination)
+	}
 }
 
 func TestDedupeAuditList_InvalidParameters(t *testing.T) {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/goccy/go-json"
 
+	"github.com/tomtom215/cartographus/internal/audit"
 	"github.com/tomtom215/cartographus/internal/detection"
 	"github.com/tomtom215/cartographus/internal/logging"
 )
@@ -28,10 +29,13 @@ func writeJSON(w http.ResponseWriter, data interface{}) {
 
 // DetectionHandlers provides HTTP handlers for detection-related endpoints.
 type DetectionHandlers struct {
-	alertStore DetectionAlertStore
-	ruleStore  DetectionRuleStore
-	trustStore DetectionTrustStore
-	engine     *detection.Engine
+	alertStore     DetectionAlertStore
+	ruleStore      DetectionRuleStore
+	trustStore     DetectionTrustStore
+	allowlistStore DetectionAllowlistStore
+	deviceStore    DetectionDeviceStore
+	engine         *detection.Engine
+	auditLogger    *audit.Logger
 }
 
 // DetectionAlertStore interface for dependency injection.
@@ -55,6 +59,8 @@ type DetectionRuleStore interface {
 type DetectionTrustStore interface {
 	GetTrustScore(ctx context.Context, userID int) (*detection.TrustScore, error)
 	ListLowTrustUsers(ctx context.Context, threshold int) ([]detection.TrustScore, error)
+	SetTrustScore(ctx context.Context, change *detection.TrustScoreChange) (*detection.TrustScore, error)
+	GetTrustScoreHistory(ctx context.Context, userID int, limit int) ([]detection.TrustScoreChange, error)
 }
 
 // NewDetectionHandlers creates new detection handlers.
@@ -72,7 +78,52 @@ func NewDetectionHandlers(
 	}
 }
 
+// DetectionAlertList is a page of detection alerts.
+type DetectionAlertList struct {
+	Alerts []detection.Alert `json:"alerts"`
+	Total  int               `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
+
+// DetectionRuleList lists the detection rules.
+type DetectionRuleList struct {
+	Rules []detection.Rule `json:"rules"`
+}
+
+// LowTrustUserList lists the users whose trust score is below Threshold.
+type LowTrustUserList struct {
+	Users     []detection.TrustScore `json:"users"`
+	Threshold int                    `json:"threshold"`
+}
+
+// DetectionAlertStats summarizes alert counts by severity and rule type.
+type DetectionAlertStats struct {
+	BySeverity     map[string]int `json:"by_severity"`
+	ByRuleType     map[string]int `json:"by_rule_type"`
+	Unacknowledged int            `json:"unacknowledged"`
+	Total          int            `json:"total"`
+}
+
 // ListAlerts handles GET /api/v1/detection/alerts
+//
+// @Summary List detection alerts
+// @Description Returns detection alerts, newest first by default
+// @Tags Detection
+// @Produce json
+// @Param limit query int false "Maximum number of alerts" default(100) minimum(1)
+// @Param offset query int false "Number of alerts to skip" default(0) minimum(0)
+// @Param user_id query int false "Only alerts for this user"
+// @Param acknowledged query bool false "Only acknowledged (true) or unacknowledged (false) alerts"
+// @Param severity query string false "Alert severity" Enums(critical, warning, info)
+// @Param rule_type query string false "Rule that raised the alert"
+// @Param start_date query string false "Earliest alert time (RFC3339)" format(date-time)
+// @Param end_date query string false "Latest alert time (RFC3339)" format(date-time)
+// @Param order_by query string false "Sort column" default(created_at)
+// @Param order_direction query string false "Sort direction" Enums(asc, desc) default(desc)
+// @Success 200 {object} DetectionAlertList
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Router /detection/alerts [get]
 func (h *DetectionHandlers) ListAlerts(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -135,7 +186,7 @@ func (h *DetectionHandlers) ListAlerts(w http.ResponseWriter, r *http.Request) {
 
 	alerts, err := h.alertStore.ListAlerts(ctx, filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch alerts", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch alerts", err)
 		return
 	}
 
@@ -146,34 +197,42 @@ func (h *DetectionHandlers) ListAlerts(w http.ResponseWriter, r *http.Request) {
 		count = len(alerts) // Fallback to current page count
 	}
 
-	response := map[string]interface{}{
-		"alerts": alerts,
-		"total":  count,
-		"limit":  filter.Limit,
-		"offset": filter.Offset,
-	}
-
-	writeJSON(w, response)
+	writeJSON(w, DetectionAlertList{
+		Alerts: alerts,
+		Total:  count,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	})
 }
 
 // GetAlert handles GET /api/v1/detection/alerts/{id}
+//
+// @Summary Get a detection alert
+// @Tags Detection
+// @Produce json
+// @Param id path int true "Alert ID"
+// @Success 200 {object} detection.Alert
+// @Failure 400 {object} models.APIResponse "Invalid alert ID"
+// @Failure 404 {object} models.APIResponse "Alert not found"
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Router /detection/alerts/{id} [get]
 func (h *DetectionHandlers) GetAlert(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid alert ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid alert ID", err)
 		return
 	}
 
 	alert, err := h.alertStore.GetAlert(ctx, id)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch alert", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch alert", err)
 		return
 	}
 	if alert == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Alert not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Alert not found", nil)
 		return
 	}
 
@@ -187,7 +246,7 @@ func (h *DetectionHandlers) AcknowledgeAlert(w http.ResponseWriter, r *http.Requ
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid alert ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid alert ID", err)
 		return
 	}
 
@@ -200,7 +259,7 @@ func (h *DetectionHandlers) AcknowledgeAlert(w http.ResponseWriter, r *http.Requ
 	}
 
 	if err := h.alertStore.AcknowledgeAlert(ctx, id, req.AcknowledgedBy); err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to acknowledge alert", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to acknowledge alert", err)
 		return
 	}
 
@@ -208,19 +267,35 @@ func (h *DetectionHandlers) AcknowledgeAlert(w http.ResponseWriter, r *http.Requ
 }
 
 // ListRules handles GET /api/v1/detection/rules
+//
+// @Summary List detection rules
+// @Tags Detection
+// @Produce json
+// @Success 200 {object} DetectionRuleList
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Router /detection/rules [get]
 func (h *DetectionHandlers) ListRules(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	rules, err := h.ruleStore.ListRules(ctx)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch rules", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch rules", err)
 		return
 	}
 
-	writeJSON(w, map[string]interface{}{"rules": rules})
+	writeJSON(w, DetectionRuleList{Rules: rules})
 }
 
 // GetRule handles GET /api/v1/detection/rules/{type}
+//
+// @Summary Get a detection rule
+// @Tags Detection
+// @Produce json
+// @Param type path string true "Rule type" example(impossible_travel)
+// @Success 200 {object} detection.Rule
+// @Failure 404 {object} models.APIResponse "Rule not found"
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Router /detection/rules/{type} [get]
 func (h *DetectionHandlers) GetRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -228,11 +303,11 @@ func (h *DetectionHandlers) GetRule(w http.ResponseWriter, r *http.Request) {
 
 	rule, err := h.ruleStore.GetRule(ctx, ruleType)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch rule", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch rule", err)
 		return
 	}
 	if rule == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Rule not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Rule not found", nil)
 		return
 	}
 
@@ -250,18 +325,18 @@ func (h *DetectionHandlers) UpdateRule(w http.ResponseWriter, r *http.Request) {
 		Config  json.RawMessage `json:"config"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
 		return
 	}
 
 	// Get existing rule
 	rule, err := h.ruleStore.GetRule(ctx, ruleType)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch rule", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch rule", err)
 		return
 	}
 	if rule == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Rule not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Rule not found", nil)
 		return
 	}
 
@@ -272,7 +347,7 @@ func (h *DetectionHandlers) UpdateRule(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.ruleStore.SaveRule(ctx, rule); err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to update rule", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to update rule", err)
 		return
 	}
 
@@ -301,12 +376,12 @@ func (h *DetectionHandlers) SetRuleEnabled(w http.ResponseWriter, r *http.Reques
 		Enabled bool `json:"enabled"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
 		return
 	}
 
 	if err := h.ruleStore.SetRuleEnabled(ctx, ruleType, req.Enabled); err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to update rule", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to update rule", err)
 		return
 	}
 
@@ -321,19 +396,28 @@ func (h *DetectionHandlers) SetRuleEnabled(w http.ResponseWriter, r *http.Reques
 }
 
 // GetUserTrustScore handles GET /api/v1/detection/users/{id}/trust
+//
+// @Summary Get a user's trust score
+// @Tags Detection
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} detection.TrustScore
+// @Failure 400 {object} models.APIResponse "Invalid user ID"
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Router /detection/users/{id}/trust [get]
 func (h *DetectionHandlers) GetUserTrustScore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	idStr := r.PathValue("id")
 	userID, err := strconv.Atoi(idStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
 		return
 	}
 
 	score, err := h.trustStore.GetTrustScore(ctx, userID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch trust score", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch trust score", err)
 		return
 	}
 
@@ -341,6 +425,14 @@ func (h *DetectionHandlers) GetUserTrustScore(w http.ResponseWriter, r *http.Req
 }
 
 // ListLowTrustUsers handles GET /api/v1/detection/users/low-trust
+//
+// @Summary List low-trust users
+// @Tags Detection
+// @Produce json
+// @Param threshold query int false "Trust score threshold" default(50) minimum(0) maximum(100)
+// @Success 200 {object} LowTrustUserList
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Router /detection/users/low-trust [get]
 func (h *DetectionHandlers) ListLowTrustUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -353,20 +445,27 @@ func (h *DetectionHandlers) ListLowTrustUsers(w http.ResponseWriter, r *http.Req
 
 	scores, err := h.trustStore.ListLowTrustUsers(ctx, threshold)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch low trust users", err)
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch low trust users", err)
 		return
 	}
 
-	writeJSON(w, map[string]interface{}{
-		"users":     scores,
-		"threshold": threshold,
+	writeJSON(w, LowTrustUserList{
+		Users:     scores,
+		Threshold: threshold,
 	})
 }
 
 // GetEngineMetrics handles GET /api/v1/detection/metrics
+//
+// @Summary Get detection engine metrics
+// @Tags Detection
+// @Produce json
+// @Success 200 {object} detection.EngineMetrics
+// @Failure 503 {object} models.APIResponse "Detection engine not available"
+// @Router /detection/metrics [get]
 func (h *DetectionHandlers) GetEngineMetrics(w http.ResponseWriter, r *http.Request) {
 	if h.engine == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Detection engine not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Detection engine not available", nil)
 		return
 	}
 
@@ -377,6 +476,12 @@ func (h *DetectionHandlers) GetEngineMetrics(w http.ResponseWriter, r *http.Requ
 }
 
 // GetAlertStats handles GET /api/v1/detection/stats
+//
+// @Summary Get detection alert statistics
+// @Tags Detection
+// @Produce json
+// @Success 200 {object} DetectionAlertStats
+// @Router /detection/stats [get]
 func (h *DetectionHandlers) GetAlertStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -418,19 +523,19 @@ func (h *DetectionHandlers) GetAlertStats(w http.ResponseWriter, r *http.Request
 		RuleTypes: []detection.RuleType{detection.RuleTypeDeviceVelocity},
 	})
 
-	stats := map[string]interface{}{
-		"by_severity": map[string]int{
+	stats := DetectionAlertStats{
+		BySeverity: map[string]int{
 			"critical": criticalCount,
 			"warning":  warningCount,
 			"info":     infoCount,
 		},
-		"by_rule_type": map[string]int{
+		ByRuleType: map[string]int{
 			"impossible_travel":  impossibleTravelCount,
 			"concurrent_streams": concurrentStreamsCount,
 			"device_velocity":    deviceVelocityCount,
 		},
-		"unacknowledged": unacknowledgedCount,
-		"total":          criticalCount + warningCount + infoCount,
+		Unacknowledged: unacknowledgedCount,
+		Total:          criticalCount + warningCount + infoCount,
 	}
 
 	writeJSON(w, stats)
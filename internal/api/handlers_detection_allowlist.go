@@ -0,0 +1,131 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/detection"
+)
+
+// DetectionAllowlistStore interface for dependency injection.
+type DetectionAllowlistStore interface {
+	AddTrustedLocation(ctx context.Context, location *detection.TrustedLocation) error
+	ListTrustedLocations(ctx context.Context, userID *int) ([]detection.TrustedLocation, error)
+	DeleteTrustedLocation(ctx context.Context, id string) (bool, error)
+}
+
+// AllowlistResponse lists trusted locations.
+type AllowlistResponse struct {
+	Entries []detection.TrustedLocation `json:"entries"`
+}
+
+// SetAllowlistStore enables the trusted location endpoints.
+func (h *DetectionHandlers) SetAllowlistStore(store DetectionAllowlistStore) {
+	h.allowlistStore = store
+}
+
+// ListAllowlist handles GET /api/v1/detection/allowlist
+// Pass user_id to list only the entries that apply to that user, including
+// global entries.
+//
+// @Summary List trusted locations
+// @Tags Detection
+// @Produce json
+// @Param user_id query int false "Only entries that apply to this user"
+// @Success 200 {object} AllowlistResponse
+// @Failure 400 {object} models.APIResponse "Invalid user ID"
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Failure 503 {object} models.APIResponse "Allowlist not available"
+// @Router /detection/allowlist [get]
+func (h *DetectionHandlers) ListAllowlist(w http.ResponseWriter, r *http.Request) {
+	if h.allowlistStore == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Detection allowlist not available", nil)
+		return
+	}
+
+	var userID *int
+	if v := r.URL.Query().Get("user_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
+			return
+		}
+		userID = &id
+	}
+
+	locations, err := h.allowlistStore.ListTrustedLocations(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch allowlist", err)
+		return
+	}
+
+	writeJSON(w, AllowlistResponse{Entries: locations})
+}
+
+// AddAllowlistEntry handles POST /api/v1/detection/allowlist
+// The body sets user_id (0 or omitted for all users) and exactly one of
+// cidr or country.
+func (h *DetectionHandlers) AddAllowlistEntry(w http.ResponseWriter, r *http.Request) {
+	if h.allowlistStore == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Detection allowlist not available", nil)
+		return
+	}
+
+	var req struct {
+		UserID  int    `json:"user_id"`
+		CIDR    string `json:"cidr"`
+		Country string `json:"country"`
+		Note    string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		return
+	}
+
+	location := &detection.TrustedLocation{
+		UserID:    req.UserID,
+		CIDR:      req.CIDR,
+		Country:   req.Country,
+		Note:      req.Note,
+		CreatedBy: GetHandlerContext(r).Username,
+	}
+	if err := location.Normalize(); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), nil)
+		return
+	}
+
+	if err := h.allowlistStore.AddTrustedLocation(r.Context(), location); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to add allowlist entry", err)
+		return
+	}
+
+	writeJSON(w, location)
+}
+
+// DeleteAllowlistEntry handles DELETE /api/v1/detection/allowlist/{id}
+func (h *DetectionHandlers) DeleteAllowlistEntry(w http.ResponseWriter, r *http.Request) {
+	if h.allowlistStore == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Detection allowlist not available", nil)
+		return
+	}
+
+	deleted, err := h.allowlistStore.DeleteTrustedLocation(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to delete allowlist entry", err)
+		return
+	}
+	if !deleted {
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Allowlist entry not found", nil)
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,194 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/detection"
+)
+
+// mockAllowlistStore implements DetectionAllowlistStore for testing.
+type mockAllowlistStore struct {
+	entries    []detection.TrustedLocation
+	addErr     error
+	listErr    error
+	lastUserID *int
+	lastAdded  *detection.TrustedLocation
+}
+
+func (m *mockAllowlistStore) AddTrustedLocation(ctx context.Context, location *detection.TrustedLocation) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+	location.ID = "new-id"
+	m.lastAdded = location
+	return nil
+}
+
+func (m *mockAllowlistStore) ListTrustedLocations(ctx context.Context, userID *int) ([]detection.TrustedLocation, error) {
+	m.lastUserID = userID
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.entries, nil
+}
+
+func (m *mockAllowlistStore) DeleteTrustedLocation(ctx context.Context, id string) (bool, error) {
+	for _, e := range m.entries {
+		if e.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestDetectionHandlers_Allowlist_Unavailable(t *testing.T) {
+	handlers := NewDetectionHandlers(nil, nil, nil, nil)
+
+	for name, handle := range map[string]http.HandlerFunc{
+		"list":   handlers.ListAllowlist,
+		"add":    handlers.AddAllowlistEntry,
+		"delete": handlers.DeleteAllowlistEntry,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handle(w, httptest.NewRequest(http.MethodGet, "/api/v1/detection/allowlist", nil))
+			if w.Code != http.StatusServiceUnavailable {
+				t.Errorf("Status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestDetectionHandlers_ListAllowlist(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		listErr    error
+		wantStatus int
+		wantUserID *int
+	}{
+		{name: "all entries", wantStatus: http.StatusOK},
+		{name: "for user", query: "?user_id=7", wantStatus: http.StatusOK, wantUserID: intPtr(7)},
+		{name: "invalid user", query: "?user_id=abc", wantStatus: http.StatusBadRequest},
+		{name: "database error", listErr: errors.New("database error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockAllowlistStore{
+				entries: []detection.TrustedLocation{{ID: "a", Country: "Canada"}},
+				listErr: tt.listErr,
+			}
+			handlers := NewDetectionHandlers(nil, nil, nil, nil)
+			handlers.SetAllowlistStore(store)
+
+			w := httptest.NewRecorder()
+			handlers.ListAllowlist(w, httptest.NewRequest(http.MethodGet, "/api/v1/detection/allowlist"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if (store.lastUserID == nil) != (tt.wantUserID == nil) ||
+				(tt.wantUserID != nil && *store.lastUserID != *tt.wantUserID) {
+				t.Errorf("user filter = %v, want %v", store.lastUserID, tt.wantUserID)
+			}
+
+			var resp struct {
+				Entries []detection.TrustedLocation `json:"entries"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(resp.Entries) != 1 {
+				t.Errorf("Entries length = %d, want 1", len(resp.Entries))
+			}
+		})
+	}
+}
+
+func TestDetectionHandlers_AddAllowlistEntry(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		addErr     error
+		wantStatus int
+		wantCIDR   string
+	}{
+		{name: "cidr", body: `{"user_id":7,"cidr":"203.0.113.9/24","note":"home"}`, wantStatus: http.StatusOK, wantCIDR: "203.0.113.0/24"},
+		{name: "global country", body: `{"country":"Canada"}`, wantStatus: http.StatusOK},
+		{name: "invalid cidr", body: `{"cidr":"nope"}`, wantStatus: http.StatusBadRequest},
+		{name: "cidr and country", body: `{"cidr":"203.0.113.0/24","country":"Canada"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "database error", body: `{"country":"Canada"}`, addErr: errors.New("database error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockAllowlistStore{addErr: tt.addErr}
+			handlers := NewDetectionHandlers(nil, nil, nil, nil)
+			handlers.SetAllowlistStore(store)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/detection/allowlist", bytes.NewBufferString(tt.body))
+			handlers.AddAllowlistEntry(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp detection.TrustedLocation
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.ID != "new-id" || resp.CIDR != tt.wantCIDR {
+				t.Errorf("response = %+v, want new-id with cidr %q", resp, tt.wantCIDR)
+			}
+		})
+	}
+}
+
+func TestDetectionHandlers_DeleteAllowlistEntry(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{"existing entry", "a", http.StatusOK},
+		{"unknown entry", "missing", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockAllowlistStore{entries: []detection.TrustedLocation{{ID: "a", Country: "Canada"}}}
+			handlers := NewDetectionHandlers(nil, nil, nil, nil)
+			handlers.SetAllowlistStore(store)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/detection/allowlist/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+			handlers.DeleteAllowlistEntry(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/detection"
+)
+
+// DetectionDeviceStore interface for dependency injection.
+type DetectionDeviceStore interface {
+	ListUserDevices(ctx context.Context, userID int) ([]detection.UserDevice, error)
+	UpdateUserDevice(ctx context.Context, userID int, deviceID string, update detection.UserDeviceUpdate) (*detection.UserDevice, error)
+}
+
+// UserDevicesResponse lists a user's devices.
+type UserDevicesResponse struct {
+	UserID  int                    `json:"user_id"`
+	Devices []detection.UserDevice `json:"devices"`
+}
+
+// SetDeviceStore enables the user device endpoints.
+func (h *DetectionHandlers) SetDeviceStore(store DetectionDeviceStore) {
+	h.deviceStore = store
+}
+
+// ListUserDevices handles GET /api/v1/detection/users/{id}/devices
+// Returns the devices the user has streamed from, most recently used first,
+// with session counts and the location of the last session.
+//
+// @Summary List a user's devices
+// @Tags Detection
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} UserDevicesResponse
+// @Failure 400 {object} models.APIResponse "Invalid user ID"
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Failure 503 {object} models.APIResponse "Device inventory not available"
+// @Router /detection/users/{id}/devices [get]
+func (h *DetectionHandlers) ListUserDevices(w http.ResponseWriter, r *http.Request) {
+	if h.deviceStore == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Device inventory not available", nil)
+		return
+	}
+
+	userID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
+		return
+	}
+
+	devices, err := h.deviceStore.ListUserDevices(r.Context(), userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch user devices", err)
+		return
+	}
+
+	writeJSON(w, UserDevicesResponse{
+		UserID:  userID,
+		Devices: devices,
+	})
+}
+
+// UpdateUserDevice handles PATCH /api/v1/detection/users/{id}/devices/{deviceID} (admin only)
+// The body sets name (empty to clear), flagged, or both. The change is
+// recorded in the audit log.
+func (h *DetectionHandlers) UpdateUserDevice(w http.ResponseWriter, r *http.Request) {
+	if h.deviceStore == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Device inventory not available", nil)
+		return
+	}
+
+	userID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
+		return
+	}
+	deviceID := r.PathValue("deviceID")
+
+	var update detection.UserDeviceUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		return
+	}
+	if err := update.Normalize(); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), nil)
+		return
+	}
+
+	device, err := h.deviceStore.UpdateUserDevice(r.Context(), userID, deviceID, update)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to update user device", err)
+		return
+	}
+	if device == nil {
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Device not found", nil)
+		return
+	}
+
+	if h.auditLogger != nil {
+		hctx := GetHandlerContext(r)
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, "user_device.update",
+			"Updated user device", map[string]interface{}{
+				"user_id":   userID,
+				"device_id": deviceID,
+				"name":      device.Name,
+				"flagged":   device.Flagged,
+			})
+	}
+
+	writeJSON(w, device)
+}
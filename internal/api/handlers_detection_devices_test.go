@@ -0,0 +1,163 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/detection"
+)
+
+// mockDeviceStore implements DetectionDeviceStore for testing.
+type mockDeviceStore struct {
+	devices    []detection.UserDevice
+	err        error
+	lastUpdate detection.UserDeviceUpdate
+}
+
+func (m *mockDeviceStore) ListUserDevices(ctx context.Context, userID int) ([]detection.UserDevice, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.devices, nil
+}
+
+func (m *mockDeviceStore) UpdateUserDevice(ctx context.Context, userID int, deviceID string, update detection.UserDeviceUpdate) (*detection.UserDevice, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.lastUpdate = update
+	for _, d := range m.devices {
+		if d.UserID == userID && d.DeviceID == deviceID {
+			if update.Name != nil {
+				d.Name = *update.Name
+			}
+			if update.Flagged != nil {
+				d.Flagged = *update.Flagged
+			}
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestDetectionHandlers_Devices_Unavailable(t *testing.T) {
+	handlers := NewDetectionHandlers(nil, nil, nil, nil)
+
+	for name, handle := range map[string]http.HandlerFunc{
+		"list":   handlers.ListUserDevices,
+		"update": handlers.UpdateUserDevice,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handle(w, httptest.NewRequest(http.MethodGet, "/api/v1/detection/users/1/devices", nil))
+			if w.Code != http.StatusServiceUnavailable {
+				t.Errorf("Status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestDetectionHandlers_ListUserDevices(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		err        error
+		wantStatus int
+	}{
+		{"devices", "1", nil, http.StatusOK},
+		{"invalid user", "abc", nil, http.StatusBadRequest},
+		{"database error", "1", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockDeviceStore{
+				devices: []detection.UserDevice{{UserID: 1, DeviceID: "tv", SessionCount: 4}},
+				err:     tt.err,
+			}
+			handlers := NewDetectionHandlers(nil, nil, nil, nil)
+			handlers.SetDeviceStore(store)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/detection/users/"+tt.userID+"/devices", nil)
+			req.SetPathValue("id", tt.userID)
+			w := httptest.NewRecorder()
+			handlers.ListUserDevices(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp UserDevicesResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.UserID != 1 || len(resp.Devices) != 1 || resp.Devices[0].SessionCount != 4 {
+				t.Errorf("response = %+v, want user 1 with the tv", resp)
+			}
+		})
+	}
+}
+
+func TestDetectionHandlers_UpdateUserDevice(t *testing.T) {
+	tests := []struct {
+		name       string
+		deviceID   string
+		body       string
+		err        error
+		wantStatus int
+		wantName   string
+	}{
+		{name: "rename", deviceID: "tv", body: `{"name":"  Living room  "}`, wantStatus: http.StatusOK, wantName: "Living room"},
+		{name: "flag", deviceID: "tv", body: `{"flagged":true}`, wantStatus: http.StatusOK},
+		{name: "empty update", deviceID: "tv", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json", deviceID: "tv", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "unknown device", deviceID: "missing", body: `{"flagged":true}`, wantStatus: http.StatusNotFound},
+		{name: "database error", deviceID: "tv", body: `{"flagged":true}`, err: errors.New("database error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockDeviceStore{
+				devices: []detection.UserDevice{{UserID: 1, DeviceID: "tv"}},
+				err:     tt.err,
+			}
+			handlers := NewDetectionHandlers(nil, nil, nil, nil)
+			handlers.SetDeviceStore(store)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/detection/users/1/devices/"+tt.deviceID, bytes.NewBufferString(tt.body))
+			req.SetPathValue("id", "1")
+			req.SetPathValue("deviceID", tt.deviceID)
+			w := httptest.NewRecorder()
+			handlers.UpdateUserDevice(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp detection.UserDevice
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.DeviceID != "tv" || resp.Name != tt.wantName {
+				t.Errorf("response = %+v, want tv named %q", resp, tt.wantName)
+			}
+		})
+	}
+}
@@ -134,6 +134,9 @@ type mockTrustStore struct {
 	getErr        error
 	listErr       error
 	lastThreshold int
+	history       []detection.TrustScoreChange
+	setErr        error
+	lastChange    *detection.TrustScoreChange
 }
 
 func (m *mockTrustStore) GetTrustScore(ctx context.Context, userID int) (*detection.TrustScore, error) {
@@ -166,6 +169,38 @@ func (m *mockTrustStore) ListLowTrustUsers(ctx context.Context, threshold int) (
 	return result, nil
 }
 
+func (m *mockTrustStore) SetTrustScore(ctx context.Context, change *detection.TrustScoreChange) (*detection.TrustScore, error) {
+	if m.setErr != nil {
+		return nil, m.setErr
+	}
+	change.PreviousScore = 100
+	for _, s := range m.scores {
+		if s.UserID == change.UserID {
+			change.PreviousScore = s.Score
+		}
+	}
+	change.Delta = change.Score - change.PreviousScore
+	m.lastChange = change
+	return &detection.TrustScore{
+		UserID:     change.UserID,
+		Score:      change.Score,
+		Restricted: change.Score < 50,
+	}, nil
+}
+
+func (m *mockTrustStore) GetTrustScoreHistory(ctx context.Context, userID int, limit int) ([]detection.TrustScoreChange, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	var result []detection.TrustScoreChange
+	for _, c := range m.history {
+		if c.UserID == userID && len(result) < limit {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
 func TestNewDetectionHandlers(t *testing.T) {
 	alertStore := &mockAlertStore{}
 	ruleStore := &mockRuleStore{}
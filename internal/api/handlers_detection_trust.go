@@ -0,0 +1,179 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/detection"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// Trust score history page sizes.
+const (
+	defaultTrustHistoryLimit = 50
+	maxTrustHistoryLimit     = 500
+)
+
+// TrustHistoryResponse lists a user's trust score changes.
+type TrustHistoryResponse struct {
+	UserID  int                          `json:"user_id"`
+	History []detection.TrustScoreChange `json:"history"`
+}
+
+// TrustScoreChangeResponse is the updated score and the history entry
+// recorded for a manual change.
+type TrustScoreChangeResponse struct {
+	TrustScore *detection.TrustScore       `json:"trust_score"`
+	Change     *detection.TrustScoreChange `json:"change"`
+}
+
+// SetAuditLogger records manual trust score changes in the audit log.
+func (h *DetectionHandlers) SetAuditLogger(logger *audit.Logger) {
+	h.auditLogger = logger
+}
+
+// GetUserTrustHistory handles GET /api/v1/detection/users/{id}/trust/history
+// Returns the user's most recent trust score changes, newest first. Each entry
+// carries the delta, the reason (violation, recovery, override, reset), and
+// the rule for violations. Accepts limit (default 50, max 500).
+//
+// @Summary Get a user's trust score history
+// @Tags Detection
+// @Produce json
+// @Param id path int true "User ID"
+// @Param limit query int false "Maximum number of changes" default(50) minimum(1) maximum(500)
+// @Success 200 {object} TrustHistoryResponse
+// @Failure 400 {object} models.APIResponse "Invalid user ID"
+// @Failure 500 {object} models.APIResponse "Detection store error"
+// @Router /detection/users/{id}/trust/history [get]
+func (h *DetectionHandlers) GetUserTrustHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
+		return
+	}
+
+	limit := defaultTrustHistoryLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 {
+			limit = min(l, maxTrustHistoryLimit)
+		}
+	}
+
+	history, err := h.trustStore.GetTrustScoreHistory(r.Context(), userID, limit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to fetch trust score history", err)
+		return
+	}
+
+	writeJSON(w, TrustHistoryResponse{
+		UserID:  userID,
+		History: history,
+	})
+}
+
+// SetUserTrustScore handles PUT /api/v1/detection/users/{id}/trust (admin only)
+// Sets the score to a value between 0 and 100, e.g. after clearing a false
+// positive. The change is recorded in the score history and the audit log.
+func (h *DetectionHandlers) SetUserTrustScore(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
+		return
+	}
+
+	var req struct {
+		Score *int   `json:"score"`
+		Note  string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		return
+	}
+	if req.Score == nil || *req.Score < 0 || *req.Score > 100 {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "score must be between 0 and 100", nil)
+		return
+	}
+
+	h.changeTrustScore(w, r, &detection.TrustScoreChange{
+		UserID: userID,
+		Score:  *req.Score,
+		Reason: detection.TrustChangeOverride,
+		Note:   req.Note,
+	})
+}
+
+// ResetUserTrustScore handles POST /api/v1/detection/users/{id}/trust/reset (admin only)
+// Restores the score to 100 and lifts any restriction. An optional body
+// {"note": "..."} explains the reset. Violation counts are kept.
+func (h *DetectionHandlers) ResetUserTrustScore(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID", err)
+		return
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		return
+	}
+
+	h.changeTrustScore(w, r, &detection.TrustScoreChange{
+		UserID: userID,
+		Score:  100,
+		Reason: detection.TrustChangeReset,
+		Note:   req.Note,
+	})
+}
+
+// changeTrustScore applies a manual change, audits it, and responds with the
+// updated score and the history entry.
+func (h *DetectionHandlers) changeTrustScore(w http.ResponseWriter, r *http.Request, change *detection.TrustScoreChange) {
+	hctx := GetHandlerContext(r)
+	if hctx != nil {
+		change.ChangedBy = hctx.Username
+	}
+
+	score, err := h.trustStore.SetTrustScore(r.Context(), change)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DETECTION_ERROR", "Failed to update trust score", err)
+		return
+	}
+
+	// Logged at warn so the change is recorded at any level
+	logging.Warn().
+		Int("user_id", change.UserID).
+		Int("previous_score", change.PreviousScore).
+		Int("score", change.Score).
+		Str("reason", string(change.Reason)).
+		Str("changed_by", change.ChangedBy).
+		Msg("Trust score changed manually")
+
+	if h.auditLogger != nil {
+		actor := audit.Actor{ID: change.ChangedBy, Type: "user", Name: change.ChangedBy}
+		if hctx != nil && hctx.UserID != "" {
+			actor.ID = hctx.UserID
+		}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogTrustScoreChange(r.Context(), actor, source,
+			change.UserID, change.PreviousScore, change.Score, string(change.Reason), change.Note)
+	}
+
+	writeJSON(w, TrustScoreChangeResponse{
+		TrustScore: score,
+		Change:     change,
+	})
+}
@@ -0,0 +1,188 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/auth"
+	"github.com/tomtom215/cartographus/internal/detection"
+)
+
+func TestDetectionHandlers_GetUserTrustHistory(t *testing.T) {
+	store := &mockTrustStore{history: []detection.TrustScoreChange{
+		{ID: "c", UserID: 7, PreviousScore: 80, Score: 90, Delta: 10, Reason: detection.TrustChangeOverride, ChangedBy: "admin"},
+		{ID: "b", UserID: 7, PreviousScore: 90, Score: 80, Delta: -10, Reason: detection.TrustChangeViolation, RuleType: detection.RuleTypeImpossibleTravel},
+		{ID: "a", UserID: 8, PreviousScore: 100, Score: 90, Delta: -10, Reason: detection.TrustChangeViolation},
+	}}
+
+	tests := []struct {
+		name       string
+		id         string
+		query      string
+		getErr     error
+		wantStatus int
+		wantLen    int
+	}{
+		{name: "all entries", id: "7", wantStatus: http.StatusOK, wantLen: 2},
+		{name: "limit", id: "7", query: "?limit=1", wantStatus: http.StatusOK, wantLen: 1},
+		{name: "no history", id: "9", wantStatus: http.StatusOK, wantLen: 0},
+		{name: "invalid id", id: "abc", wantStatus: http.StatusBadRequest},
+		{name: "database error", id: "7", getErr: errors.New("database error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store.getErr = tt.getErr
+			handlers := NewDetectionHandlers(nil, nil, store, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/detection/users/"+tt.id+"/trust/history"+tt.query, nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+			handlers.GetUserTrustHistory(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				UserID  int                          `json:"user_id"`
+				History []detection.TrustScoreChange `json:"history"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(resp.History) != tt.wantLen {
+				t.Errorf("history entries = %d, want %d", len(resp.History), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestDetectionHandlers_SetUserTrustScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setErr     error
+		wantStatus int
+		wantScore  int
+	}{
+		{name: "override", body: `{"score":75,"note":"false positive"}`, wantStatus: http.StatusOK, wantScore: 75},
+		{name: "zero", body: `{"score":0}`, wantStatus: http.StatusOK, wantScore: 0},
+		{name: "missing score", body: `{"note":"x"}`, wantStatus: http.StatusBadRequest},
+		{name: "above range", body: `{"score":101}`, wantStatus: http.StatusBadRequest},
+		{name: "below range", body: `{"score":-1}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid json", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "database error", body: `{"score":50}`, setErr: errors.New("database error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockTrustStore{
+				scores: []detection.TrustScore{{UserID: 7, Score: 40, Restricted: true}},
+				setErr: tt.setErr,
+			}
+			handlers := NewDetectionHandlers(nil, nil, store, nil)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/detection/users/7/trust", bytes.NewBufferString(tt.body))
+			req.SetPathValue("id", "7")
+			w := httptest.NewRecorder()
+			handlers.SetUserTrustScore(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				TrustScore detection.TrustScore       `json:"trust_score"`
+				Change     detection.TrustScoreChange `json:"change"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.TrustScore.Score != tt.wantScore {
+				t.Errorf("score = %d, want %d", resp.TrustScore.Score, tt.wantScore)
+			}
+			if resp.Change.Reason != detection.TrustChangeOverride || resp.Change.PreviousScore != 40 {
+				t.Errorf("change = %+v, want override from 40", resp.Change)
+			}
+		})
+	}
+}
+
+func TestDetectionHandlers_ResetUserTrustScore_Audited(t *testing.T) {
+	store := &mockTrustStore{scores: []detection.TrustScore{{UserID: 7, Score: 30, Restricted: true}}}
+	auditStore := audit.NewMemoryStore(100)
+	auditLogger := audit.NewLogger(auditStore, nil)
+
+	handlers := NewDetectionHandlers(nil, nil, store, nil)
+	handlers.SetAuditLogger(auditLogger)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/detection/users/7/trust/reset", bytes.NewBufferString(`{"note":"cleared"}`))
+	req.SetPathValue("id", "7")
+	req = req.WithContext(context.WithValue(req.Context(), auth.AuthSubjectContextKey,
+		&auth.AuthSubject{ID: "admin-1", Username: "alice", Roles: []string{"admin"}}))
+	w := httptest.NewRecorder()
+	handlers.ResetUserTrustScore(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if store.lastChange == nil || store.lastChange.Score != 100 || store.lastChange.Reason != detection.TrustChangeReset {
+		t.Fatalf("lastChange = %+v, want reset to 100", store.lastChange)
+	}
+	if store.lastChange.ChangedBy != "alice" || store.lastChange.Note != "cleared" {
+		t.Errorf("changed_by = %q, note = %q", store.lastChange.ChangedBy, store.lastChange.Note)
+	}
+
+	// Close drains the async writer so the event is in the store
+	if err := auditLogger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	events, err := auditStore.Query(context.Background(), audit.QueryFilter{
+		Types: []audit.EventType{audit.EventTypeDetectionTrustChanged},
+	})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("audit events = %d, want 1", len(events))
+	}
+	if events[0].Actor.ID != "admin-1" || events[0].Target.ID != "7" || events[0].Action != "reset" {
+		t.Errorf("audit event = %+v", events[0])
+	}
+}
+
+func TestDetectionHandlers_ResetUserTrustScore_EmptyBody(t *testing.T) {
+	store := &mockTrustStore{}
+	handlers := NewDetectionHandlers(nil, nil, store, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/detection/users/3/trust/reset", http.NoBody)
+	req.SetPathValue("id", "3")
+	w := httptest.NewRecorder()
+	handlers.ResetUserTrustScore(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if store.lastChange == nil || store.lastChange.UserID != 3 {
+		t.Errorf("lastChange = %+v, want reset of user 3", store.lastChange)
+	}
+}
@@ -0,0 +1,193 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// recentGCPauses is how many of the most recent GC pauses the runtime
+// summary reports.
+const recentGCPauses = 16
+
+// DiagnosticsHandlers serves the /debug routes mounted when
+// DIAGNOSTICS_ENABLED is set: pprof profiles, expvar, a goroutine dump and
+// a runtime summary.
+type DiagnosticsHandlers struct {
+	auditLogger *audit.Logger
+	startTime   time.Time
+}
+
+// NewDiagnosticsHandlers creates the diagnostics handlers.
+func NewDiagnosticsHandlers() *DiagnosticsHandlers {
+	return &DiagnosticsHandlers{startTime: time.Now()}
+}
+
+// SetAuditLogger records every diagnostics request in the audit log.
+func (h *DiagnosticsHandlers) SetAuditLogger(logger *audit.Logger) {
+	h.auditLogger = logger
+}
+
+// AuditAccess is middleware that emits an admin.action audit event for each
+// diagnostics request. It runs after the admin role check, so only
+// authorized access is recorded.
+func (h *DiagnosticsHandlers) AuditAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hctx := GetHandlerContext(r)
+
+		// Logged at warn so access is recorded at any level
+		logging.Warn().
+			Str("user_id", hctx.UserID).
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Msg("Diagnostics endpoint accessed")
+
+		if h.auditLogger != nil {
+			actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+			source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+			h.auditLogger.LogAdminAction(r.Context(), actor, source,
+				"diagnostics.access", "Accessed "+r.Method+" "+r.URL.Path,
+				map[string]interface{}{
+					"method": r.Method,
+					"path":   r.URL.Path,
+					"query":  r.URL.RawQuery,
+				})
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RuntimeSummary is the response of GET /debug/runtime.
+type RuntimeSummary struct {
+	GoVersion  string      `json:"go_version"`
+	NumCPU     int         `json:"num_cpu"`
+	GOMAXPROCS int         `json:"gomaxprocs"`
+	Goroutines int         `json:"goroutines"`
+	OpenFDs    int         `json:"open_fds"` // -1 where /proc is unavailable
+	Uptime     string      `json:"uptime"`
+	Heap       HeapSummary `json:"heap"`
+	GC         GCSummary   `json:"gc"`
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// HeapSummary holds heap statistics from runtime.MemStats, in bytes.
+type HeapSummary struct {
+	Alloc        uint64 `json:"alloc_bytes"`
+	TotalAlloc   uint64 `json:"total_alloc_bytes"`
+	Sys          uint64 `json:"sys_bytes"`
+	HeapSys      uint64 `json:"heap_sys_bytes"`
+	HeapIdle     uint64 `json:"heap_idle_bytes"`
+	HeapInuse    uint64 `json:"heap_inuse_bytes"`
+	HeapReleased uint64 `json:"heap_released_bytes"`
+	HeapObjects  uint64 `json:"heap_objects"`
+	Mallocs      uint64 `json:"mallocs"`
+	Frees        uint64 `json:"frees"`
+}
+
+// GCSummary holds garbage collector statistics. RecentPausesNS lists the
+// most recent pauses, newest first.
+type GCSummary struct {
+	NumGC          uint32     `json:"num_gc"`
+	NumForcedGC    uint32     `json:"num_forced_gc"`
+	NextGC         uint64     `json:"next_gc_bytes"`
+	LastGC         *time.Time `json:"last_gc,omitempty"`
+	PauseTotalNS   uint64     `json:"pause_total_ns"`
+	RecentPausesNS []uint64   `json:"recent_pauses_ns"`
+	CPUFraction    float64    `json:"cpu_fraction"`
+}
+
+// RuntimeSummary handles GET /debug/runtime
+// Returns heap and GC statistics, the goroutine count and the number of
+// open file descriptors. Reading MemStats briefly stops the world, so this
+// is not meant for frequent polling; use /metrics for that.
+func (h *DiagnosticsHandlers) RuntimeSummary(w http.ResponseWriter, r *http.Request) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	summary := RuntimeSummary{
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		OpenFDs:    countOpenFDs(),
+		Uptime:     time.Since(h.startTime).Round(time.Second).String(),
+		Heap: HeapSummary{
+			Alloc:        ms.Alloc,
+			TotalAlloc:   ms.TotalAlloc,
+			Sys:          ms.Sys,
+			HeapSys:      ms.HeapSys,
+			HeapIdle:     ms.HeapIdle,
+			HeapInuse:    ms.HeapInuse,
+			HeapReleased: ms.HeapReleased,
+			HeapObjects:  ms.HeapObjects,
+			Mallocs:      ms.Mallocs,
+			Frees:        ms.Frees,
+		},
+		GC: GCSummary{
+			NumGC:          ms.NumGC,
+			NumForcedGC:    ms.NumForcedGC,
+			NextGC:         ms.NextGC,
+			PauseTotalNS:   ms.PauseTotalNs,
+			RecentPausesNS: recentPauses(&ms),
+			CPUFraction:    ms.GCCPUFraction,
+		},
+		Timestamp: time.Now(),
+	}
+	if ms.LastGC > 0 {
+		last := time.Unix(0, int64(ms.LastGC)) //nolint:gosec // nanoseconds since epoch fit in int64
+		summary.GC.LastGC = &last
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     summary,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// GoroutineDump handles GET /debug/goroutines
+// Writes the stack of every goroutine as plain text, in the same format as
+// an unrecovered panic.
+func (h *DiagnosticsHandlers) GoroutineDump(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := pprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		logging.Error().Err(err).Msg("Failed to write goroutine dump")
+	}
+}
+
+// recentPauses returns up to recentGCPauses of the latest GC pause
+// durations from the MemStats circular buffer, newest first.
+func recentPauses(ms *runtime.MemStats) []uint64 {
+	n := int(ms.NumGC)
+	if n > recentGCPauses {
+		n = recentGCPauses
+	}
+	pauses := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		pauses = append(pauses, ms.PauseNs[(int(ms.NumGC)-1-i)%len(ms.PauseNs)])
+	}
+	return pauses
+}
+
+// countOpenFDs returns the number of open file descriptors of this
+// process, or -1 where /proc/self/fd is not available.
+func countOpenFDs() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	// Don't count the descriptor ReadDir used for the listing itself
+	return len(entries) - 1
+}
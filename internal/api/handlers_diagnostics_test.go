@@ -0,0 +1,183 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/auth"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// newDiagnosticsTestRouter returns a router with session auth, passed to
+// configure (if non-nil) before its routes are set up. It creates sessions
+// "viewer" and "admin" with the matching roles.
+func newDiagnosticsTestRouter(t *testing.T, configure func(*Router)) http.Handler {
+	t.Helper()
+
+	mw := auth.NewMiddleware(nil, nil, string(auth.AuthModeNone), 100, time.Minute, true, nil, nil, "", "")
+	router := NewRouter(&Handler{}, mw)
+
+	store := auth.NewMemorySessionStore()
+	for _, role := range []string{"viewer", "admin"} {
+		session := &auth.Session{
+			ID:        role,
+			UserID:    role + "-id",
+			Username:  role,
+			Roles:     []string{role},
+			Provider:  "test",
+			CreatedAt: time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		if err := store.Create(context.Background(), session); err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+	}
+	router.sessionMiddleware = auth.NewSessionMiddleware(store, nil)
+
+	if configure != nil {
+		configure(router)
+	}
+	return router.SetupChi()
+}
+
+func serveDiagnostics(mux http.Handler, path, session string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: session})
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+var diagnosticsPaths = []string{
+	"/debug/runtime",
+	"/debug/goroutines",
+	"/debug/vars",
+	"/debug/pprof/",
+	"/debug/pprof/heap",
+	"/debug/pprof/cmdline",
+}
+
+func TestDiagnosticsRoutes_AbsentWhenDisabled(t *testing.T) {
+	mux := newDiagnosticsTestRouter(t, nil)
+	unknown := serveDiagnostics(mux, "/no-such-page", "admin")
+
+	for _, path := range diagnosticsPaths {
+		t.Run(path, func(t *testing.T) {
+			rec := serveDiagnostics(mux, path, "admin")
+			// Falls through to the SPA like any unknown path
+			if rec.Code != unknown.Code || rec.Body.String() != unknown.Body.String() {
+				t.Errorf("%s = %d %q, want the unknown-path response %d", path, rec.Code, rec.Body.String(), unknown.Code)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsRoutes_RequireAdmin(t *testing.T) {
+	store := audit.NewMemoryStore(100)
+	logger := audit.NewLogger(store, audit.DefaultConfig())
+	diagnostics := NewDiagnosticsHandlers()
+	diagnostics.SetAuditLogger(logger)
+	mux := newDiagnosticsTestRouter(t, func(router *Router) { router.ConfigureDiagnostics(diagnostics) })
+
+	for _, path := range diagnosticsPaths {
+		t.Run(path, func(t *testing.T) {
+			if rec := serveDiagnostics(mux, path, ""); rec.Code != http.StatusUnauthorized {
+				t.Errorf("anonymous status = %d, want 401", rec.Code)
+			}
+			if rec := serveDiagnostics(mux, path, "viewer"); rec.Code != http.StatusForbidden {
+				t.Errorf("viewer status = %d, want 403", rec.Code)
+			}
+			if rec := serveDiagnostics(mux, path, "admin"); rec.Code != http.StatusOK {
+				t.Errorf("admin status = %d, want 200", rec.Code)
+			}
+		})
+	}
+
+	// Only the admin requests reached the audit middleware
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	events, err := store.Query(context.Background(), audit.QueryFilter{Types: []audit.EventType{audit.EventTypeAdminAction}})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(events) != len(diagnosticsPaths) {
+		t.Fatalf("audit events = %d, want %d", len(events), len(diagnosticsPaths))
+	}
+	for _, event := range events {
+		if event.Action != "diagnostics.access" || event.Actor.ID != "admin-id" {
+			t.Errorf("audit event action/actor = %s/%s, want diagnostics.access/admin-id", event.Action, event.Actor.ID)
+		}
+	}
+}
+
+func TestDiagnosticsRoutes_PprofOnly(t *testing.T) {
+	mux := newDiagnosticsTestRouter(t, func(router *Router) { router.ConfigurePprof(NewDiagnosticsHandlers()) })
+	unknown := serveDiagnostics(mux, "/no-such-page", "admin")
+
+	for _, path := range diagnosticsPaths {
+		t.Run(path, func(t *testing.T) {
+			rec := serveDiagnostics(mux, path, "admin")
+			if strings.HasPrefix(path, "/debug/pprof/") {
+				if rec.Code != http.StatusOK {
+					t.Errorf("admin status = %d, want 200", rec.Code)
+				}
+				if rec := serveDiagnostics(mux, path, "viewer"); rec.Code != http.StatusForbidden {
+					t.Errorf("viewer status = %d, want 403", rec.Code)
+				}
+				return
+			}
+			if rec.Code != unknown.Code || rec.Body.String() != unknown.Body.String() {
+				t.Errorf("%s = %d %q, want the unknown-path response %d", path, rec.Code, rec.Body.String(), unknown.Code)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsHandlers_RuntimeSummary(t *testing.T) {
+	h := NewDiagnosticsHandlers()
+	rec := httptest.NewRecorder()
+	h.RuntimeSummary(rec, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var resp struct {
+		models.APIResponse
+		Data RuntimeSummary `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Data.Goroutines < 1 || resp.Data.Heap.Alloc == 0 || resp.Data.GoVersion == "" {
+		t.Errorf("summary = %+v, want goroutines, heap stats and Go version", resp.Data)
+	}
+	if len(resp.Data.GC.RecentPausesNS) > recentGCPauses {
+		t.Errorf("recent pauses = %d, want at most %d", len(resp.Data.GC.RecentPausesNS), recentGCPauses)
+	}
+}
+
+func TestDiagnosticsHandlers_GoroutineDump(t *testing.T) {
+	h := NewDiagnosticsHandlers()
+	rec := httptest.NewRecorder()
+	h.GoroutineDump(rec, httptest.NewRequest(http.MethodGet, "/debug/goroutines", nil))
+
+	if !strings.Contains(rec.Body.String(), "TestDiagnosticsHandlers_GoroutineDump") {
+		t.Error("goroutine dump should include the test goroutine's stack")
+	}
+}
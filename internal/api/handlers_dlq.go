@@ -203,13 +203,13 @@ func (h *DLQHandlers) ListEntries(w http.ResponseWriter, r *http.Request) {
 func (h *DLQHandlers) GetEntry(w http.ResponseWriter, r *http.Request) {
 	eventID := chi.URLParam(r, "id")
 	if eventID == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
 		return
 	}
 
 	entry := h.store.GetEntry(eventID)
 	if entry == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "DLQ entry not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "DLQ entry not found", nil)
 		return
 	}
 
@@ -221,19 +221,19 @@ func (h *DLQHandlers) GetEntry(w http.ResponseWriter, r *http.Request) {
 func (h *DLQHandlers) RetryEntry(w http.ResponseWriter, r *http.Request) {
 	eventID := chi.URLParam(r, "id")
 	if eventID == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
 		return
 	}
 
 	entry := h.store.GetEntry(eventID)
 	if entry == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "DLQ entry not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "DLQ entry not found", nil)
 		return
 	}
 
 	err := h.store.RetryEntry(eventID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "RETRY_ERROR", "Failed to retry entry", err)
+		respondError(w, r, http.StatusInternalServerError, "RETRY_ERROR", "Failed to retry entry", err)
 		return
 	}
 
@@ -249,12 +249,12 @@ func (h *DLQHandlers) RetryEntry(w http.ResponseWriter, r *http.Request) {
 func (h *DLQHandlers) DeleteEntry(w http.ResponseWriter, r *http.Request) {
 	eventID := chi.URLParam(r, "id")
 	if eventID == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Event ID is required", nil)
 		return
 	}
 
 	if !h.store.RemoveEntry(eventID) {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "DLQ entry not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "DLQ entry not found", nil)
 		return
 	}
 
@@ -298,10 +298,10 @@ func (h *DLQHandlers) GetStats(w http.ResponseWriter, _ *http.Request) {
 
 // RetryAllPending handles POST /api/v1/dlq/retry-all
 // Retries all entries that are ready for retry.
-func (h *DLQHandlers) RetryAllPending(w http.ResponseWriter, _ *http.Request) {
+func (h *DLQHandlers) RetryAllPending(w http.ResponseWriter, r *http.Request) {
 	count, err := h.store.RetryAllPending()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "RETRY_ERROR", "Failed to retry entries", err)
+		respondError(w, r, http.StatusInternalServerError, "RETRY_ERROR", "Failed to retry entries", err)
 		return
 	}
 
@@ -14,6 +14,18 @@ import (
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
+// ReadinessChecker reports whether the supervised services are healthy,
+// with the names of those that are not. The supervisor tree implements it.
+type ReadinessChecker interface {
+	Ready() (bool, []string)
+}
+
+// SetReadinessChecker makes /health/ready also require the checker's
+// services to be healthy.
+func (h *Handler) SetReadinessChecker(checker ReadinessChecker) {
+	h.readiness = checker
+}
+
 // Health handles health check requests
 //
 // @Summary Get system health status
@@ -25,7 +37,7 @@ import (
 // @Router /health [get]
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -92,7 +104,7 @@ func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
 // @Router /health/live [get]
 func (h *Handler) HealthLive(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -112,7 +124,7 @@ func (h *Handler) HealthLive(w http.ResponseWriter, r *http.Request) {
 // Returns 200 OK only if the service is ready to handle traffic
 //
 // @Summary Kubernetes readiness probe
-// @Description Returns 200 OK only if the service is ready to handle traffic (database and Tautulli are both connected). Returns 503 if not ready.
+// @Description Returns 200 OK only if the service is ready to handle traffic (database and Tautulli are both connected, and no supervised service is crash-looping or in backoff). Returns 503 if not ready.
 // @Tags Core
 // @Accept json
 // @Produce json
@@ -121,7 +133,7 @@ func (h *Handler) HealthLive(w http.ResponseWriter, r *http.Request) {
 // @Router /health/ready [get]
 func (h *Handler) HealthReady(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -130,7 +142,13 @@ func (h *Handler) HealthReady(w http.ResponseWriter, r *http.Request) {
 
 	// Check Tautulli connectivity (nil means not connected)
 	tautulliConnected := h.client != nil && h.client.Ping(r.Context()) == nil
-	ready := dbConnected && tautulliConnected
+
+	// Supervised services must not be failing
+	servicesReady, unhealthy := true, []string{}
+	if h.readiness != nil {
+		servicesReady, unhealthy = h.readiness.Ready()
+	}
+	ready := dbConnected && tautulliConnected && servicesReady
 
 	statusCode := http.StatusOK
 	status := "ready"
@@ -144,6 +162,8 @@ func (h *Handler) HealthReady(w http.ResponseWriter, r *http.Request) {
 		Data: map[string]interface{}{
 			"database_connected": dbConnected,
 			"tautulli_connected": tautulliConnected,
+			"services_ready":     servicesReady,
+			"unhealthy_services": unhealthy,
 			"ready_to_serve":     ready,
 			"uptime":             time.Since(h.startTime).Seconds(),
 		},
@@ -174,7 +194,7 @@ func (h *Handler) HealthReady(w http.ResponseWriter, r *http.Request) {
 // @Router /health/setup [get]
 func (h *Handler) SetupStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -329,3 +349,9 @@ func (h *Handler) GetPerformanceStats() []middleware.EndpointStats {
 	}
 	return nil
 }
+
+// PerformanceMonitor returns the handler's performance monitor, which also
+// serves as a Prometheus collector for per-endpoint latency quantiles
+func (h *Handler) PerformanceMonitor() *middleware.PerformanceMonitor {
+	return h.perfMon
+}
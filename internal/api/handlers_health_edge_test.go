@@ -251,6 +251,69 @@ func TestHealth_TautulliFails(t *testing.T) {
 	}
 }
 
+// fakeReadiness is a ReadinessChecker with a fixed answer.
+type fakeReadiness struct {
+	unhealthy []string
+}
+
+func (f fakeReadiness) Ready() (bool, []string) {
+	return len(f.unhealthy) == 0, f.unhealthy
+}
+
+// TestHealthReady_SupervisedServices tests that failing supervised services
+// make the readiness probe fail
+func TestHealthReady_SupervisedServices(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	tests := []struct {
+		name       string
+		checker    ReadinessChecker
+		wantStatus int
+	}{
+		{"no checker", nil, http.StatusOK},
+		{"services healthy", fakeReadiness{unhealthy: []string{}}, http.StatusOK},
+		{"service crash-looping", fakeReadiness{unhealthy: []string{"sync-manager"}}, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{
+				db:        db,
+				client:    &MockTautulliClient{},
+				startTime: time.Now(),
+			}
+			if tt.checker != nil {
+				handler.SetReadinessChecker(tt.checker)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/health/ready", nil)
+			w := httptest.NewRecorder()
+			handler.HealthReady(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d (body: %s)", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var response struct {
+				Data struct {
+					ServicesReady     bool     `json:"services_ready"`
+					UnhealthyServices []string `json:"unhealthy_services"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			wantReady := tt.wantStatus == http.StatusOK
+			if response.Data.ServicesReady != wantReady {
+				t.Errorf("services_ready = %v, want %v", response.Data.ServicesReady, wantReady)
+			}
+			if !wantReady && (len(response.Data.UnhealthyServices) != 1 || response.Data.UnhealthyServices[0] != "sync-manager") {
+				t.Errorf("unhealthy_services = %v, want [sync-manager]", response.Data.UnhealthyServices)
+			}
+		})
+	}
+}
+
 // TestHealth_ResponseFormat tests response format
 func TestHealth_ResponseFormat(t *testing.T) {
 	t.Parallel()
@@ -44,7 +44,7 @@ func SetNATSHealthChecker(checker NATSHealthChecker) {
 // @Router /health/nats [get]
 func (h *Handler) HealthNATS(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -94,7 +94,7 @@ func (h *Handler) HealthNATS(w http.ResponseWriter, r *http.Request) {
 // @Router /health/nats/component [get]
 func (h *Handler) HealthNATSComponent(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
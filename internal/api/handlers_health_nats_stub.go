@@ -19,7 +19,7 @@ import (
 // When NATS is not enabled, returns a message indicating NATS is disabled.
 func (h *Handler) HealthNATS(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -39,7 +39,7 @@ func (h *Handler) HealthNATS(w http.ResponseWriter, r *http.Request) {
 // When NATS is not enabled, returns a message indicating NATS is disabled.
 func (h *Handler) HealthNATSComponent(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
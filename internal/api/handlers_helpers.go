@@ -10,7 +10,6 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/goccy/go-json"
 
@@ -38,9 +37,7 @@ func sanitizeLogValue(s string) string {
 
 // respondJSON sends a JSON response with proper headers
 func respondJSON(w http.ResponseWriter, status int, response *models.APIResponse) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=60")
-	w.Header().Set("Vary", "Accept-Encoding")
+	setJSONCacheHeaders(w)
 
 	data, err := json.Marshal(response)
 	if err != nil {
@@ -58,6 +55,13 @@ func respondJSON(w http.ResponseWriter, status int, response *models.APIResponse
 	}
 }
 
+// setJSONCacheHeaders sets the content type and caching headers of JSON responses
+func setJSONCacheHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.Header().Set("Vary", "Accept-Encoding")
+}
+
 // generateETag creates a simple ETag from data using FNV-1a hash
 func generateETag(data []byte) string {
 	hash := uint32(2166136261)
@@ -68,29 +72,22 @@ func generateETag(data []byte) string {
 	return strconv.FormatUint(uint64(hash), 16)
 }
 
-// respondError sends an error response
-func respondError(w http.ResponseWriter, status int, code, message string, err error) {
+// respondError sends an error response: an RFC 7807 problem document when the
+// client accepts application/problem+json, otherwise the legacy envelope (see
+// wantsProblemJSON). err is logged, never sent to the client.
+func respondError(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message string, err error) {
 	if err != nil {
 		// Sanitize error output to prevent log injection attacks
-		logging.Error().Str("code", sanitizeLogValue(code)).Str("error", sanitizeLogValue(err.Error())).Msg("API Error")
-	}
-
-	respondJSON(w, status, &models.APIResponse{
-		Status: "error",
-		Data:   nil,
-		Metadata: models.Metadata{
-			Timestamp: time.Now(),
-		},
-		Error: &models.APIError{
-			Code:    code,
-			Message: message,
-		},
-	})
+		logging.Error().Str("code", sanitizeLogValue(string(code))).Str("error", sanitizeLogValue(err.Error())).Msg("API Error")
+	}
+
+	respondProblem(w, r, newProblem(r, status, code, message), nil)
 }
 
 // validateRequest validates a struct using go-playground/validator.
-// Returns nil if validation passes, or a models.APIError if validation fails.
-// The returned error uses the VALIDATION_ERROR code consistent with existing API errors.
+// Returns nil if validation passes; otherwise pass the error to
+// respondValidationError, which responds with VALIDATION_ERROR and the
+// failing fields.
 //
 // Example:
 //
@@ -98,23 +95,12 @@ func respondError(w http.ResponseWriter, status int, code, message string, err e
 //	    Limit:  getIntParam(r, "limit", 100),
 //	    Offset: getIntParam(r, "offset", 0),
 //	}
-//	if apiErr := validateRequest(&req); apiErr != nil {
-//	    respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+//	if verr := validateRequest(&req); verr != nil {
+//	    respondValidationError(w, r, verr)
 //	    return
 //	}
-func validateRequest(v interface{}) *models.APIError {
-	validationErr := validation.ValidateStruct(v)
-	if validationErr == nil {
-		return nil
-	}
-
-	// Convert validation error to API error format
-	apiErr := validationErr.ToAPIError()
-	return &models.APIError{
-		Code:    apiErr.Code,
-		Message: apiErr.Message,
-		Details: apiErr.Details,
-	}
+func validateRequest(v interface{}) *validation.RequestValidationError {
+	return validation.ValidateStruct(v)
 }
 
 // getIntParam extracts an integer query parameter with a default value
@@ -132,6 +118,32 @@ func getIntParam(r *http.Request, key string, defaultValue int) int {
 	return intValue
 }
 
+// setOffsetPagination records the offset of a page and of its neighbours.
+func setOffsetPagination(pagination *models.PaginationInfo, offset int, hasMore bool) {
+	pagination.Offset = &offset
+	if hasMore {
+		next := offset + pagination.Limit
+		pagination.NextOffset = &next
+	}
+	if offset > 0 {
+		prev := max(offset-pagination.Limit, 0)
+		pagination.PrevOffset = &prev
+	}
+}
+
+// newOffsetPagination builds pagination metadata for an offset-paginated page
+// whose total is already known.
+func newOffsetPagination(limit, offset int, total int64) *models.PaginationInfo {
+	totalCount := int(total)
+	pagination := &models.PaginationInfo{
+		Limit:      limit,
+		HasMore:    int64(offset+limit) < total,
+		TotalCount: &totalCount,
+	}
+	setOffsetPagination(pagination, offset, pagination.HasMore)
+	return pagination
+}
+
 // parseIntParam parses an integer from a string with a default value.
 // Uses fmt.Sscanf for lenient parsing (handles floats like "3.14" → 3, spaces like " 10 " → 10).
 func parseIntParam(value string, defaultValue int) int {
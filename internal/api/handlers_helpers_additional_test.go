@@ -386,7 +386,7 @@ func TestRespondError_WithNilError(t *testing.T) {
 	t.Parallel()
 
 	w := httptest.NewRecorder()
-	respondError(w, http.StatusBadRequest, "TEST_ERROR", "test message", nil)
+	respondError(w, nil, http.StatusBadRequest, "TEST_ERROR", "test message", nil)
 
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("Expected status 400, got %d", w.Code)
@@ -407,7 +407,7 @@ func TestRespondError_WithError(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	testErr := errors.New("detailed error message")
-	respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "database failed", testErr)
+	respondError(w, nil, http.StatusInternalServerError, "DATABASE_ERROR", "database failed", testErr)
 
 	if w.Code != http.StatusInternalServerError {
 		t.Errorf("Expected status 500, got %d", w.Code)
@@ -448,7 +448,7 @@ func TestRespondError_VariousStatusCodes(t *testing.T) {
 	for _, statusCode := range statusCodes {
 		t.Run(http.StatusText(statusCode), func(t *testing.T) {
 			w := httptest.NewRecorder()
-			respondError(w, statusCode, "TEST_ERROR", "test message", nil)
+			respondError(w, nil, statusCode, "TEST_ERROR", "test message", nil)
 
 			if w.Code != statusCode {
 				t.Errorf("Expected status %d, got %d", statusCode, w.Code)
@@ -435,7 +435,7 @@ func TestRespondError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
-			respondError(w, tt.status, tt.code, tt.message, tt.err)
+			respondError(w, nil, tt.status, ErrorCode(tt.code), tt.message, tt.err)
 
 			if w.Code != tt.expectedStatus {
 				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
@@ -723,3 +723,32 @@ func TestGetIntParam_FromRequest(t *testing.T) {
 		})
 	}
 }
+
+// ===================================================================================================
+// newOffsetPagination Tests
+// ===================================================================================================
+
+func TestNewOffsetPagination(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	tests := []struct {
+		name                 string
+		limit, offset, total int
+		want                 models.PaginationInfo
+	}{
+		{"first page", 10, 0, 25, models.PaginationInfo{Limit: 10, HasMore: true, TotalCount: intPtr(25), Offset: intPtr(0), NextOffset: intPtr(10)}},
+		{"middle page", 10, 10, 25, models.PaginationInfo{Limit: 10, HasMore: true, TotalCount: intPtr(25), Offset: intPtr(10), NextOffset: intPtr(20), PrevOffset: intPtr(0)}},
+		{"last page", 10, 20, 25, models.PaginationInfo{Limit: 10, HasMore: false, TotalCount: intPtr(25), Offset: intPtr(20), PrevOffset: intPtr(10)}},
+		{"exact final page", 10, 10, 20, models.PaginationInfo{Limit: 10, HasMore: false, TotalCount: intPtr(20), Offset: intPtr(10), PrevOffset: intPtr(0)}},
+		{"unaligned offset", 10, 5, 25, models.PaginationInfo{Limit: 10, HasMore: true, TotalCount: intPtr(25), Offset: intPtr(5), NextOffset: intPtr(15), PrevOffset: intPtr(0)}},
+		{"empty result", 10, 0, 0, models.PaginationInfo{Limit: 10, HasMore: false, TotalCount: intPtr(0), Offset: intPtr(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newOffsetPagination(tt.limit, tt.offset, int64(tt.total))
+			if formatPagination(*got) != formatPagination(tt.want) {
+				t.Errorf("newOffsetPagination() = %s, want %s", formatPagination(*got), formatPagination(tt.want))
+			}
+		})
+	}
+}
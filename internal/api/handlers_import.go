@@ -3,8 +3,6 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // https://github.com/tomtom215/cartographus
 
-//go:build nats
-
 package api
 
 import (
@@ -28,8 +26,17 @@ type ImportController interface {
 	// IsRunning returns whether an import is in progress.
 	IsRunning() bool
 
-	// Stop cancels a running import.
+	// Stop cancels a running import. Saved progress is retained.
 	Stop() error
+
+	// Pause suspends a running import at the next batch boundary.
+	Pause() error
+
+	// Resume continues a paused import.
+	Resume() error
+
+	// VerifyImport compares saved progress against the source database.
+	VerifyImport(ctx context.Context) (*tautulliimport.VerifyResult, error)
 }
 
 // ProgressController defines the interface for import progress tracking.
@@ -73,6 +80,29 @@ type ImportResponse struct {
 	Message string                          `json:"message,omitempty"`
 	Error   string                          `json:"error,omitempty"`
 	Stats   *tautulliimport.ProgressSummary `json:"stats,omitempty"`
+	Verify  *tautulliimport.VerifyResult    `json:"verify,omitempty"`
+}
+
+// ValidateRequest represents a request to validate a Tautulli database.
+type ValidateRequest struct {
+	// DBPath is the path to the Tautulli SQLite database.
+	DBPath string `json:"db_path"`
+}
+
+// ValidateResponse represents the result of validating a Tautulli database.
+type ValidateResponse struct {
+	Success      bool               `json:"success"`
+	Error        string             `json:"error,omitempty"`
+	TotalRecords int64              `json:"total_records,omitempty"`
+	DateRange    *ValidateDateRange `json:"date_range,omitempty"`
+	UniqueUsers  int                `json:"unique_users,omitempty"`
+	MediaTypes   map[string]int     `json:"media_types,omitempty"`
+}
+
+// ValidateDateRange is the range of playback dates in a Tautulli database.
+type ValidateDateRange struct {
+	Earliest string `json:"earliest"`
+	Latest   string `json:"latest"`
 }
 
 // HandleStartImport handles POST /api/v1/import/tautulli
@@ -87,7 +117,7 @@ type ImportResponse struct {
 // @Failure 400 {object} ImportResponse "Invalid request"
 // @Failure 409 {object} ImportResponse "Import already in progress"
 // @Failure 500 {object} ImportResponse "Internal error"
-// @Router /api/v1/import/tautulli [post]
+// @Router /import/tautulli [post]
 func (h *ImportHandlers) HandleStartImport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -142,14 +172,16 @@ func (h *ImportHandlers) HandleStartImport(w http.ResponseWriter, r *http.Reques
 	})
 }
 
-// HandleGetImportStatus handles GET /api/v1/import/status
+// HandleGetImportStatus handles GET /api/v1/import/status and GET /api/v1/admin/import/status
 //
 // @Summary Get import status
-// @Description Returns the current status of a Tautulli database import
+// @Description Returns the current status of a Tautulli database import, including
+// @Description rows read, imported, deduplicated, errored, throughput, and ETA
 // @Tags import
 // @Produce json
 // @Success 200 {object} ImportResponse
-// @Router /api/v1/import/status [get]
+// @Router /import/status [get]
+// @Router /admin/import/status [get]
 func (h *ImportHandlers) HandleGetImportStatus(w http.ResponseWriter, r *http.Request) {
 	running := h.importer.IsRunning()
 	stats := h.importer.GetStats()
@@ -175,7 +207,7 @@ func (h *ImportHandlers) HandleGetImportStatus(w http.ResponseWriter, r *http.Re
 // @Produce json
 // @Success 200 {object} ImportResponse
 // @Failure 400 {object} ImportResponse "No import in progress"
-// @Router /api/v1/import [delete]
+// @Router /import [delete]
 func (h *ImportHandlers) HandleStopImport(w http.ResponseWriter, r *http.Request) {
 	if !h.importer.IsRunning() {
 		h.writeJSON(w, http.StatusBadRequest, ImportResponse{
@@ -200,6 +232,123 @@ func (h *ImportHandlers) HandleStopImport(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// HandlePauseImport handles POST /api/v1/admin/import/pause
+//
+// @Summary Pause import
+// @Description Pauses a running Tautulli database import at the next batch boundary
+// @Tags import
+// @Produce json
+// @Success 200 {object} ImportResponse
+// @Failure 409 {object} ImportResponse "No import in progress or already paused"
+// @Router /admin/import/pause [post]
+func (h *ImportHandlers) HandlePauseImport(w http.ResponseWriter, r *http.Request) {
+	if err := h.importer.Pause(); err != nil {
+		h.writeJSON(w, http.StatusConflict, ImportResponse{
+			Success: false,
+			Error:   "failed to pause import: " + err.Error(),
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ImportResponse{
+		Success: true,
+		Message: "import pause requested",
+		Stats:   h.importer.GetStats().ToSummary(h.importer.IsRunning()),
+	})
+}
+
+// HandleResumeImport handles POST /api/v1/admin/import/resume
+//
+// @Summary Resume import
+// @Description Resumes a paused Tautulli database import
+// @Tags import
+// @Produce json
+// @Success 200 {object} ImportResponse
+// @Failure 409 {object} ImportResponse "No import in progress or not paused"
+// @Router /admin/import/resume [post]
+func (h *ImportHandlers) HandleResumeImport(w http.ResponseWriter, r *http.Request) {
+	if err := h.importer.Resume(); err != nil {
+		h.writeJSON(w, http.StatusConflict, ImportResponse{
+			Success: false,
+			Error:   "failed to resume import: " + err.Error(),
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ImportResponse{
+		Success: true,
+		Message: "import resumed",
+		Stats:   h.importer.GetStats().ToSummary(h.importer.IsRunning()),
+	})
+}
+
+// HandleCancelImport handles POST /api/v1/admin/import/cancel
+//
+// @Summary Cancel import
+// @Description Cancels a running or paused Tautulli database import at the next batch boundary.
+// @Description The progress checkpoint is retained so a later import with resume=true continues where this one stopped.
+// @Tags import
+// @Produce json
+// @Success 200 {object} ImportResponse
+// @Failure 409 {object} ImportResponse "No import in progress"
+// @Router /admin/import/cancel [post]
+func (h *ImportHandlers) HandleCancelImport(w http.ResponseWriter, r *http.Request) {
+	if err := h.importer.Stop(); err != nil {
+		h.writeJSON(w, http.StatusConflict, ImportResponse{
+			Success: false,
+			Error:   "failed to cancel import: " + err.Error(),
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ImportResponse{
+		Success: true,
+		Message: "import cancel requested; progress checkpoint retained",
+		Stats:   h.importer.GetStats().ToSummary(h.importer.IsRunning()),
+	})
+}
+
+// HandleVerifyImport handles GET /api/v1/admin/import/verify
+//
+// @Summary Verify import completeness
+// @Description Compares the saved import progress against the Tautulli database: the gap between
+// @Description the highest session_history ID and the last processed ID, and processed vs source rows.
+// @Description complete is true when no source records remain and the last run had no errors.
+// @Tags import
+// @Produce json
+// @Success 200 {object} ImportResponse
+// @Failure 409 {object} ImportResponse "Import in progress"
+// @Failure 500 {object} ImportResponse "Verification failed"
+// @Router /admin/import/verify [get]
+func (h *ImportHandlers) HandleVerifyImport(w http.ResponseWriter, r *http.Request) {
+	if h.importer.IsRunning() {
+		h.writeJSON(w, http.StatusConflict, ImportResponse{
+			Success: false,
+			Error:   "cannot verify while import is running",
+		})
+		return
+	}
+
+	result, err := h.importer.VerifyImport(r.Context())
+	if err != nil {
+		h.writeJSON(w, http.StatusInternalServerError, ImportResponse{
+			Success: false,
+			Error:   "failed to verify import: " + err.Error(),
+		})
+		return
+	}
+
+	message := "import incomplete"
+	if result.Complete {
+		message = "import complete"
+	}
+	h.writeJSON(w, http.StatusOK, ImportResponse{
+		Success: true,
+		Message: message,
+		Verify:  result,
+	})
+}
+
 // HandleClearProgress handles DELETE /api/v1/import/progress
 //
 // @Summary Clear import progress
@@ -209,7 +358,7 @@ func (h *ImportHandlers) HandleStopImport(w http.ResponseWriter, r *http.Request
 // @Success 200 {object} ImportResponse
 // @Failure 400 {object} ImportResponse "Import in progress"
 // @Failure 500 {object} ImportResponse "Internal error"
-// @Router /api/v1/import/progress [delete]
+// @Router /import/progress [delete]
 func (h *ImportHandlers) HandleClearProgress(w http.ResponseWriter, r *http.Request) {
 	if h.importer.IsRunning() {
 		h.writeJSON(w, http.StatusBadRequest, ImportResponse{
@@ -252,24 +401,22 @@ func (h *ImportHandlers) HandleClearProgress(w http.ResponseWriter, r *http.Requ
 // @Success 200 {object} ValidateResponse
 // @Failure 400 {object} ValidateResponse "Invalid request"
 // @Failure 500 {object} ValidateResponse "Validation failed"
-// @Router /api/v1/import/validate [post]
+// @Router /import/validate [post]
 func (h *ImportHandlers) HandleValidateDatabase(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		DBPath string `json:"db_path"`
-	}
+	var req ValidateRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   "invalid request body: " + err.Error(),
+		h.writeJSON(w, http.StatusBadRequest, ValidateResponse{
+			Success: false,
+			Error:   "invalid request body: " + err.Error(),
 		})
 		return
 	}
 
 	if req.DBPath == "" {
-		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   "db_path is required",
+		h.writeJSON(w, http.StatusBadRequest, ValidateResponse{
+			Success: false,
+			Error:   "db_path is required",
 		})
 		return
 	}
@@ -277,9 +424,9 @@ func (h *ImportHandlers) HandleValidateDatabase(w http.ResponseWriter, r *http.R
 	// Try to open and validate the database
 	reader, err := tautulliimport.NewSQLiteReader(req.DBPath)
 	if err != nil {
-		h.writeJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"success": false,
-			"error":   "failed to open database: " + err.Error(),
+		h.writeJSON(w, http.StatusBadRequest, ValidateResponse{
+			Success: false,
+			Error:   "failed to open database: " + err.Error(),
 		})
 		return
 	}
@@ -290,18 +437,18 @@ func (h *ImportHandlers) HandleValidateDatabase(w http.ResponseWriter, r *http.R
 	// Get statistics
 	totalRecords, err := reader.CountRecords(ctx)
 	if err != nil {
-		h.writeJSON(w, http.StatusInternalServerError, map[string]interface{}{
-			"success": false,
-			"error":   "failed to count records: " + err.Error(),
+		h.writeJSON(w, http.StatusInternalServerError, ValidateResponse{
+			Success: false,
+			Error:   "failed to count records: " + err.Error(),
 		})
 		return
 	}
 
 	earliest, latest, err := reader.GetDateRange(ctx)
 	if err != nil {
-		h.writeJSON(w, http.StatusInternalServerError, map[string]interface{}{
-			"success": false,
-			"error":   "failed to get date range: " + err.Error(),
+		h.writeJSON(w, http.StatusInternalServerError, ValidateResponse{
+			Success: false,
+			Error:   "failed to get date range: " + err.Error(),
 		})
 		return
 	}
@@ -309,15 +456,15 @@ func (h *ImportHandlers) HandleValidateDatabase(w http.ResponseWriter, r *http.R
 	userCount, _ := reader.GetUserStats(ctx)       //nolint:errcheck // optional stats, defaults to 0
 	mediaTypes, _ := reader.GetMediaTypeStats(ctx) //nolint:errcheck // optional stats, defaults to nil
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
-		"success":       true,
-		"total_records": totalRecords,
-		"date_range": map[string]string{
-			"earliest": earliest.Format("2006-01-02"),
-			"latest":   latest.Format("2006-01-02"),
+	h.writeJSON(w, http.StatusOK, ValidateResponse{
+		Success:      true,
+		TotalRecords: totalRecords,
+		DateRange: &ValidateDateRange{
+			Earliest: earliest.Format("2006-01-02"),
+			Latest:   latest.Format("2006-01-02"),
 		},
-		"unique_users": userCount,
-		"media_types":  mediaTypes,
+		UniqueUsers: userCount,
+		MediaTypes:  mediaTypes,
 	})
 }
 
@@ -0,0 +1,186 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	tautulliimport "github.com/tomtom215/cartographus/internal/import"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// JellystatImportController defines the interface for managing Jellystat imports.
+type JellystatImportController interface {
+	ImportController
+
+	// ImportFile imports the Jellystat or Playback Reporting export at path.
+	ImportFile(ctx context.Context, path string, opts tautulliimport.JellystatImportOptions) (*tautulliimport.ImportStats, error)
+}
+
+// JellystatImportHandlers holds the Jellystat import handlers. Status, pause,
+// resume, cancel, and clear are shared with ImportHandlers.
+type JellystatImportHandlers struct {
+	*ImportHandlers
+	jellystat JellystatImportController
+}
+
+// NewJellystatImportHandlers creates a new set of Jellystat import handlers.
+func NewJellystatImportHandlers(importer JellystatImportController, progress ProgressController) *JellystatImportHandlers {
+	return &JellystatImportHandlers{
+		ImportHandlers: NewImportHandlers(importer, progress),
+		jellystat:      importer,
+	}
+}
+
+// JellystatImportRequest represents a request to start a Jellystat import.
+// Multipart uploads carry the same fields as form values plus a "file" part.
+type JellystatImportRequest struct {
+	// FilePath is the path of an export file on the server.
+	FilePath string `json:"file_path,omitempty"`
+
+	// ServerID is the Jellyfin server the export was taken from (optional).
+	ServerID string `json:"server_id,omitempty"`
+
+	// Resume continues from the last saved progress for the same file.
+	Resume bool `json:"resume,omitempty"`
+}
+
+// HandleStartJellystatImport handles POST /api/v1/admin/import/jellystat
+//
+// @Summary Start Jellystat import
+// @Description Imports Jellyfin playback history from a Jellystat CSV export, a Jellystat pg_dump,
+// @Description or a Playback Reporting plugin export. Accepts either a JSON body with file_path
+// @Description or a multipart upload with a "file" part. The file format is validated before the import starts.
+// @Tags import
+// @Accept json,mpfd
+// @Produce json
+// @Param request body JellystatImportRequest false "Import options"
+// @Success 200 {object} ImportResponse
+// @Failure 400 {object} ImportResponse "Invalid request or unrecognized export format"
+// @Failure 409 {object} ImportResponse "Import already in progress"
+// @Failure 500 {object} ImportResponse "Internal error"
+// @Router /admin/import/jellystat [post]
+func (h *JellystatImportHandlers) HandleStartJellystatImport(w http.ResponseWriter, r *http.Request) {
+	if h.jellystat.IsRunning() {
+		h.writeJSON(w, http.StatusConflict, ImportResponse{
+			Success: false,
+			Error:   "import already in progress",
+			Stats:   h.jellystat.GetStats().ToSummary(true),
+		})
+		return
+	}
+
+	req, uploaded, err := h.parseJellystatRequest(w, r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, ImportResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+	cleanup := func() {
+		if uploaded {
+			if err := os.Remove(req.FilePath); err != nil && !os.IsNotExist(err) {
+				logging.Warn().Err(err).Str("path", req.FilePath).Msg("Failed to remove uploaded Jellystat export")
+			}
+		}
+	}
+
+	info, err := tautulliimport.ValidateJellystatFile(req.FilePath)
+	if err != nil {
+		cleanup()
+		h.writeJSON(w, http.StatusBadRequest, ImportResponse{
+			Success: false,
+			Error:   "invalid export file: " + err.Error(),
+		})
+		return
+	}
+
+	// Clear previous progress if not resuming
+	if !req.Resume && h.progress != nil {
+		if err := h.progress.Clear(r.Context()); err != nil {
+			cleanup()
+			h.writeJSON(w, http.StatusInternalServerError, ImportResponse{
+				Success: false,
+				Error:   "failed to clear previous progress: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	// Run detached from the request, as with Tautulli imports
+	importCtx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	opts := tautulliimport.JellystatImportOptions{ServerID: req.ServerID}
+	go func() {
+		defer cancel()
+		defer cleanup()
+		if _, err := h.jellystat.ImportFile(importCtx, req.FilePath, opts); err != nil {
+			logging.Warn().Err(err).Msg("Jellystat import failed")
+		}
+	}()
+
+	h.writeJSON(w, http.StatusOK, ImportResponse{
+		Success: true,
+		Message: fmt.Sprintf("jellystat import started (%s, %d records)", info.Format, info.Records),
+		Stats:   h.jellystat.GetStats().ToSummary(true),
+	})
+}
+
+// parseJellystatRequest reads a JSON or multipart request. For uploads the
+// file is saved to a temporary path and uploaded is true; the caller must
+// remove it.
+func (h *JellystatImportHandlers) parseJellystatRequest(w http.ResponseWriter, r *http.Request) (req JellystatImportRequest, uploaded bool, err error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				return req, false, fmt.Errorf("invalid request body: %w", err)
+			}
+		}
+		if req.FilePath == "" {
+			return req, false, fmt.Errorf("file_path or a multipart \"file\" upload is required")
+		}
+		return req, false, nil
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, uploadMaxBodySize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return req, false, fmt.Errorf("failed to parse upload: %w", err)
+	}
+	defer r.MultipartForm.RemoveAll() //nolint:errcheck // best-effort cleanup
+
+	req.ServerID = r.FormValue("server_id")
+	req.Resume = r.FormValue("resume") == "true"
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return req, false, fmt.Errorf("no export file provided: %w", err)
+	}
+	defer file.Close()
+
+	tmp, err := os.CreateTemp("", "jellystat-import-*")
+	if err != nil {
+		return req, false, fmt.Errorf("failed to store upload: %w", err)
+	}
+	if _, err := io.Copy(tmp, file); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return req, false, fmt.Errorf("failed to store upload: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return req, false, fmt.Errorf("failed to store upload: %w", err)
+	}
+
+	req.FilePath = tmp.Name()
+	return req, true, nil
+}
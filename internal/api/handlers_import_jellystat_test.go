@@ -0,0 +1,219 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	tautulliimport "github.com/tomtom215/cartographus/internal/import"
+)
+
+const testJellystatCSV = "UserId,UserName,NowPlayingItemId,NowPlayingItemName,PlaybackDuration,ActivityDateInserted\n" +
+	"user-a,alice,item-1,Some Movie,3600,2024-01-15 10:30:00\n"
+
+// mockJellystatController is a test double for JellystatImportController.
+type mockJellystatController struct {
+	*mockImportController
+	calls chan jellystatImportCall
+}
+
+type jellystatImportCall struct {
+	path       string
+	opts       tautulliimport.JellystatImportOptions
+	fileExists bool
+}
+
+func newMockJellystatController() *mockJellystatController {
+	return &mockJellystatController{
+		mockImportController: newMockImportController(),
+		calls:                make(chan jellystatImportCall, 1),
+	}
+}
+
+func (m *mockJellystatController) ImportFile(_ context.Context, path string, opts tautulliimport.JellystatImportOptions) (*tautulliimport.ImportStats, error) {
+	_, err := os.Stat(path)
+	m.calls <- jellystatImportCall{path: path, opts: opts, fileExists: err == nil}
+	return m.GetStats(), nil
+}
+
+func (m *mockJellystatController) waitForCall(t *testing.T) jellystatImportCall {
+	t.Helper()
+	select {
+	case call := <-m.calls:
+		return call
+	case <-time.After(2 * time.Second):
+		t.Fatal("ImportFile was not called")
+		return jellystatImportCall{}
+	}
+}
+
+func writeTestJellystatExport(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "activity.csv")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+	return path
+}
+
+func decodeImportResponse(t *testing.T, w *httptest.ResponseRecorder) ImportResponse {
+	t.Helper()
+	var response ImportResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return response
+}
+
+func TestHandleStartJellystatImport_FilePath(t *testing.T) {
+	importer := newMockJellystatController()
+	progress := newMockProgressController()
+	progress.setStats(&tautulliimport.ImportStats{LastProcessedID: 5})
+	handlers := NewJellystatImportHandlers(importer, progress)
+
+	path := writeTestJellystatExport(t, testJellystatCSV)
+	body, _ := json.Marshal(JellystatImportRequest{FilePath: path, ServerID: "jf-1"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/jellystat", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handlers.HandleStartJellystatImport(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	response := decodeImportResponse(t, w)
+	if !strings.Contains(response.Message, "jellystat_csv, 1 records") {
+		t.Errorf("Message = %q, want format and record count", response.Message)
+	}
+
+	call := importer.waitForCall(t)
+	if call.path != path || call.opts.ServerID != "jf-1" {
+		t.Errorf("ImportFile(%q, %+v), want %q with server jf-1", call.path, call.opts, path)
+	}
+	if saved, _ := progress.Load(context.Background()); saved != nil {
+		t.Error("progress should be cleared when resume is false")
+	}
+}
+
+func TestHandleStartJellystatImport_Upload(t *testing.T) {
+	importer := newMockJellystatController()
+	handlers := NewJellystatImportHandlers(importer, newMockProgressController())
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("server_id", "jf-2")
+	part, err := mw.CreateFormFile("file", "playback_reporting.tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = part.Write([]byte("2024-02-01 20:00:00\tuser-a\titem-1\tMovie\tSome Movie\tDirectPlay\tWeb\tFirefox\t3600\n"))
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/jellystat", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	handlers.HandleStartJellystatImport(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	call := importer.waitForCall(t)
+	if !call.fileExists || call.opts.ServerID != "jf-2" {
+		t.Errorf("ImportFile call = %+v, want existing temp file and server jf-2", call)
+	}
+
+	// The temporary upload is removed once the import finishes.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(call.path); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("uploaded file %s was not removed", call.path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandleStartJellystatImport_BadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"missing file", `{}`, "file_path"},
+		{"invalid json", `{`, "invalid request body"},
+		{"nonexistent file", `{"file_path": "/nonexistent/activity.csv"}`, "invalid export file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewJellystatImportHandlers(newMockJellystatController(), nil)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/jellystat", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handlers.HandleStartJellystatImport(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+			if response := decodeImportResponse(t, w); !strings.Contains(response.Error, tt.wantErr) {
+				t.Errorf("Error = %q, want it to contain %q", response.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleStartJellystatImport_UnrecognizedFormat(t *testing.T) {
+	importer := newMockJellystatController()
+	handlers := NewJellystatImportHandlers(importer, nil)
+
+	path := writeTestJellystatExport(t, "foo,bar\n1,2\n")
+	body, _ := json.Marshal(JellystatImportRequest{FilePath: path})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/jellystat", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handlers.HandleStartJellystatImport(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if response := decodeImportResponse(t, w); !strings.Contains(response.Error, "missing required columns") {
+		t.Errorf("Error = %q, want missing columns detail", response.Error)
+	}
+	select {
+	case <-importer.calls:
+		t.Error("ImportFile should not be called for an invalid export")
+	default:
+	}
+}
+
+func TestHandleStartJellystatImport_AlreadyRunning(t *testing.T) {
+	importer := newMockJellystatController()
+	importer.setRunning(true)
+	handlers := NewJellystatImportHandlers(importer, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/jellystat", strings.NewReader(`{"file_path":"x"}`))
+	w := httptest.NewRecorder()
+
+	handlers.HandleStartJellystatImport(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
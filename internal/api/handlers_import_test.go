@@ -3,8 +3,6 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // https://github.com/tomtom215/cartographus
 
-//go:build nats
-
 package api
 
 import (
@@ -27,10 +25,13 @@ import (
 type mockImportController struct {
 	mu          sync.Mutex
 	running     bool
+	paused      bool
 	stats       *tautulliimport.ImportStats
 	importErr   error
 	stopErr     error
 	importDelay time.Duration
+	verify      *tautulliimport.VerifyResult
+	verifyErr   error
 }
 
 func newMockImportController() *mockImportController {
@@ -94,6 +95,46 @@ func (m *mockImportController) Stop() error {
 	return nil
 }
 
+func (m *mockImportController) Pause() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.running {
+		return errors.New("no import in progress")
+	}
+	if m.paused {
+		return errors.New("import already paused")
+	}
+	m.paused = true
+	m.stats.Paused = true
+	return nil
+}
+
+func (m *mockImportController) Resume() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.running {
+		return errors.New("no import in progress")
+	}
+	if !m.paused {
+		return errors.New("import not paused")
+	}
+	m.paused = false
+	m.stats.Paused = false
+	return nil
+}
+
+func (m *mockImportController) VerifyImport(_ context.Context) (*tautulliimport.VerifyResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.verifyErr != nil {
+		return nil, m.verifyErr
+	}
+	if m.verify == nil {
+		return &tautulliimport.VerifyResult{}, nil
+	}
+	return m.verify, nil
+}
+
 func (m *mockImportController) setRunning(running bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -541,6 +582,162 @@ func TestHandleStopImport_NotRunning(t *testing.T) {
 	}
 }
 
+func TestHandlePauseResumeImport(t *testing.T) {
+	importer := newMockImportController()
+	importer.setRunning(true)
+	importer.setStats(&tautulliimport.ImportStats{
+		TotalRecords: 100,
+		Processed:    50,
+		StartTime:    time.Now(),
+	})
+	handlers := NewImportHandlers(importer, newMockProgressController())
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		path       string
+		wantStatus int
+		wantState  string
+	}{
+		{"pause", handlers.HandlePauseImport, "/api/v1/admin/import/pause", http.StatusOK, "paused"},
+		{"pause twice", handlers.HandlePauseImport, "/api/v1/admin/import/pause", http.StatusConflict, ""},
+		{"resume", handlers.HandleResumeImport, "/api/v1/admin/import/resume", http.StatusOK, "running"},
+		{"resume twice", handlers.HandleResumeImport, "/api/v1/admin/import/resume", http.StatusConflict, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			tt.handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			var response ImportResponse
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if tt.wantState == "" {
+				if response.Success {
+					t.Error("Expected success=false")
+				}
+				return
+			}
+			if response.Stats == nil || response.Stats.Status != tt.wantState {
+				t.Errorf("Stats.Status = %+v, want %q", response.Stats, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestHandlePauseImport_NotRunning(t *testing.T) {
+	handlers := NewImportHandlers(newMockImportController(), newMockProgressController())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/pause", nil)
+	w := httptest.NewRecorder()
+
+	handlers.HandlePauseImport(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestHandleCancelImport_RetainsProgress(t *testing.T) {
+	importer := newMockImportController()
+	importer.setRunning(true)
+	importer.setStats(&tautulliimport.ImportStats{
+		TotalRecords:    100,
+		Processed:       50,
+		LastProcessedID: 50,
+		StartTime:       time.Now(),
+	})
+	progress := newMockProgressController()
+	progress.setStats(&tautulliimport.ImportStats{
+		LastProcessedID: 50,
+		StartTime:       time.Now(),
+	})
+
+	handlers := NewImportHandlers(importer, progress)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/cancel", nil)
+	w := httptest.NewRecorder()
+
+	handlers.HandleCancelImport(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if importer.IsRunning() {
+		t.Error("import should be stopped after cancel")
+	}
+
+	saved, err := progress.Load(context.Background())
+	if err != nil || saved == nil || saved.LastProcessedID != 50 {
+		t.Errorf("progress checkpoint should be retained, got %+v (err=%v)", saved, err)
+	}
+
+	// Canceling again reports a conflict
+	w = httptest.NewRecorder()
+	handlers.HandleCancelImport(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/import/cancel", nil))
+	if w.Code != http.StatusConflict {
+		t.Errorf("second cancel Status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestHandleVerifyImport(t *testing.T) {
+	importer := newMockImportController()
+	importer.verify = &tautulliimport.VerifyResult{
+		SourceRecords:    100,
+		SourceMaxID:      120,
+		LastProcessedID:  90,
+		IDGap:            30,
+		ProcessedRecords: 80,
+		MissingRecords:   20,
+		HasProgress:      true,
+	}
+	handlers := NewImportHandlers(importer, newMockProgressController())
+
+	w := httptest.NewRecorder()
+	handlers.HandleVerifyImport(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/import/verify", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp ImportResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Message != "import incomplete" || resp.Verify == nil || resp.Verify.IDGap != 30 || resp.Verify.MissingRecords != 20 {
+		t.Errorf("response = %+v, verify = %+v", resp, resp.Verify)
+	}
+
+	// Verification is refused while an import is running
+	importer.setRunning(true)
+	w = httptest.NewRecorder()
+	handlers.HandleVerifyImport(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/import/verify", nil))
+	if w.Code != http.StatusConflict {
+		t.Errorf("running Status = %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	importer.setRunning(false)
+	importer.verifyErr = errors.New("open database: no such file")
+	w = httptest.NewRecorder()
+	handlers.HandleVerifyImport(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/import/verify", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("error Status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
 func TestHandleClearProgress_Success(t *testing.T) {
 	importer := newMockImportController()
 	importer.setRunning(false)
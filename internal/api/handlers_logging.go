@@ -0,0 +1,77 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// LogLevelRequest is the body of PUT /api/v1/admin/log-level.
+type LogLevelRequest struct {
+	// Level is the new global level. Accepts the same values as LOG_LEVEL.
+	Level string `json:"level" validate:"required,oneof=trace debug info warn error"`
+}
+
+// LogLevelResponse reports a runtime log level change.
+type LogLevelResponse struct {
+	Level         string `json:"level"`
+	PreviousLevel string `json:"previous_level"`
+}
+
+// SetLogLevel handles PUT /api/v1/admin/log-level
+// Changes the global log level without a restart, e.g. to enable debug
+// output during an incident. The change is not persisted; LOG_LEVEL applies
+// again on the next start.
+//
+// @Summary Change the log level
+// @Description Changes the global log level at runtime. Not persisted across restarts.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body LogLevelRequest true "New log level"
+// @Success 200 {object} models.APIResponse{data=LogLevelResponse} "Level changed"
+// @Failure 400 {object} models.APIResponse "Invalid log level"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Router /admin/log-level [put]
+func (h *Handler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req LogLevelRequest
+	if err := h.parseAndValidateRequest(r, &req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR",
+			"level must be one of: trace, debug, info, warn, error", err)
+		return
+	}
+
+	previous := logging.GetLevel()
+	if err := logging.SetLevel(req.Level); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), err)
+		return
+	}
+	level := logging.GetLevel()
+
+	// Logged at warn so the change is recorded at any level
+	evt := logging.Warn().
+		Str("previous_level", previous.String()).
+		Str("level", level.String())
+	if hctx := GetHandlerContext(r); hctx != nil {
+		evt = evt.Str("username", hctx.Username)
+	}
+	evt.Msg("Log level changed at runtime")
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: LogLevelResponse{
+			Level:         level.String(),
+			PreviousLevel: previous.String(),
+		},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
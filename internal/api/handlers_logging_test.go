@@ -0,0 +1,64 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	original := logging.GetLevel()
+	defer zerolog.SetGlobalLevel(original)
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	h := &Handler{}
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/log-level", strings.NewReader(`{"level":"debug"}`))
+	h.SetLogLevel(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Data LogLevelResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Data.Level != "debug" || body.Data.PreviousLevel != "info" {
+		t.Errorf("response = %+v, want debug from info", body.Data)
+	}
+	if logging.GetLevel() != zerolog.DebugLevel {
+		t.Errorf("global level = %v, want debug", logging.GetLevel())
+	}
+}
+
+func TestSetLogLevel_Invalid(t *testing.T) {
+	original := logging.GetLevel()
+	defer zerolog.SetGlobalLevel(original)
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	for _, body := range []string{`{"level":"verbose"}`, `{"level":"disabled"}`, `{}`, `not json`} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/log-level", strings.NewReader(body))
+		(&Handler{}).SetLogLevel(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("body %s: status = %d, want 400", body, rec.Code)
+		}
+	}
+	if logging.GetLevel() != zerolog.InfoLevel {
+		t.Errorf("global level = %v after invalid requests, want info", logging.GetLevel())
+	}
+}
@@ -6,19 +6,30 @@
 package api
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/cache"
+	"github.com/tomtom215/cartographus/internal/database"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
 // AnalyticsResolutionMismatch handles resolution mismatch analytics requests
+//
+// @Summary Get resolution mismatch analytics
+// @Description Returns sessions where the stream resolution differs from the source resolution
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ResolutionMismatchAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/resolution-mismatch [get]
 func (h *Handler) AnalyticsResolutionMismatch(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -45,13 +56,13 @@ func (h *Handler) AnalyticsResolutionMismatch(w http.ResponseWriter, r *http.Req
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetResolutionMismatchAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve resolution mismatch analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve resolution mismatch analytics", err)
 		return
 	}
 
@@ -69,9 +80,20 @@ func (h *Handler) AnalyticsResolutionMismatch(w http.ResponseWriter, r *http.Req
 }
 
 // AnalyticsHDR handles HDR and dynamic range analytics requests
+//
+// @Summary Get HDR analytics
+// @Description Returns HDR format distribution and tone-mapping activity
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.HDRAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/hdr [get]
 func (h *Handler) AnalyticsHDR(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -98,13 +120,13 @@ func (h *Handler) AnalyticsHDR(w http.ResponseWriter, r *http.Request) {
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetHDRAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve HDR analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve HDR analytics", err)
 		return
 	}
 
@@ -122,9 +144,20 @@ func (h *Handler) AnalyticsHDR(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsAudio handles audio quality analytics requests
+//
+// @Summary Get audio analytics
+// @Description Returns audio codec and channel distributions, downmix events and surround and lossless adoption
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.AudioAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/audio [get]
 func (h *Handler) AnalyticsAudio(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -151,13 +184,13 @@ func (h *Handler) AnalyticsAudio(w http.ResponseWriter, r *http.Request) {
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetAudioAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve audio analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve audio analytics", err)
 		return
 	}
 
@@ -175,9 +208,20 @@ func (h *Handler) AnalyticsAudio(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsSubtitles handles subtitle usage analytics requests
+//
+// @Summary Get subtitle analytics
+// @Description Returns subtitle usage by language, codec and user
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.SubtitleAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/subtitles [get]
 func (h *Handler) AnalyticsSubtitles(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -204,13 +248,13 @@ func (h *Handler) AnalyticsSubtitles(w http.ResponseWriter, r *http.Request) {
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetSubtitleAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve subtitle analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve subtitle analytics", err)
 		return
 	}
 
@@ -228,9 +272,20 @@ func (h *Handler) AnalyticsSubtitles(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsFrameRate handles frame rate analytics requests
+//
+// @Summary Get frame rate analytics
+// @Description Returns source frame rate distribution and frame rate conversions
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.FrameRateAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/frame-rate [get]
 func (h *Handler) AnalyticsFrameRate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -257,13 +312,13 @@ func (h *Handler) AnalyticsFrameRate(w http.ResponseWriter, r *http.Request) {
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetFrameRateAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve frame rate analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve frame rate analytics", err)
 		return
 	}
 
@@ -281,9 +336,20 @@ func (h *Handler) AnalyticsFrameRate(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsContainer handles container format analytics requests
+//
+// @Summary Get container analytics
+// @Description Returns media container distribution and remux activity
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ContainerAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/container [get]
 func (h *Handler) AnalyticsContainer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -310,13 +376,13 @@ func (h *Handler) AnalyticsContainer(w http.ResponseWriter, r *http.Request) {
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetContainerAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve container analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve container analytics", err)
 		return
 	}
 
@@ -334,9 +400,20 @@ func (h *Handler) AnalyticsContainer(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsConnectionSecurity handles connection security analytics requests
+//
+// @Summary Get connection security analytics
+// @Description Returns secure, insecure and relayed connection counts by user and platform
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ConnectionSecurityAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/connection-security [get]
 func (h *Handler) AnalyticsConnectionSecurity(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -363,13 +440,13 @@ func (h *Handler) AnalyticsConnectionSecurity(w http.ResponseWriter, r *http.Req
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetConnectionSecurityAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve connection security analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve connection security analytics", err)
 		return
 	}
 
@@ -387,9 +464,20 @@ func (h *Handler) AnalyticsConnectionSecurity(w http.ResponseWriter, r *http.Req
 }
 
 // AnalyticsPausePatterns handles pause pattern analytics requests
+//
+// @Summary Get pause pattern analytics
+// @Description Returns pause frequency and duration and the content paused most often
+// @Tags Analytics
+// @Produce json
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.PausePatternAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/pause-patterns [get]
 func (h *Handler) AnalyticsPausePatterns(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -416,13 +504,13 @@ func (h *Handler) AnalyticsPausePatterns(w http.ResponseWriter, r *http.Request)
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetPausePatternAnalytics(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve pause pattern analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve pause pattern analytics", err)
 		return
 	}
 
@@ -440,9 +528,21 @@ func (h *Handler) AnalyticsPausePatterns(w http.ResponseWriter, r *http.Request)
 }
 
 // AnalyticsLibrary handles library-specific analytics requests
+//
+// @Summary Get library analytics
+// @Description Returns playback statistics for a single library section
+// @Tags Analytics
+// @Produce json
+// @Param section_id query int true "Library section ID"
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.LibraryAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/library [get]
 func (h *Handler) AnalyticsLibrary(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -452,13 +552,13 @@ func (h *Handler) AnalyticsLibrary(w http.ResponseWriter, r *http.Request) {
 	// Get section_id from query parameter
 	sectionIDStr := r.URL.Query().Get("section_id")
 	if sectionIDStr == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "section_id parameter is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "section_id parameter is required", nil)
 		return
 	}
 
 	sectionID := 0
 	if _, err := fmt.Sscanf(sectionIDStr, "%d", &sectionID); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid section_id parameter", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid section_id parameter", err)
 		return
 	}
 
@@ -485,13 +585,13 @@ func (h *Handler) AnalyticsLibrary(w http.ResponseWriter, r *http.Request) {
 
 	// Check if database is available
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	analytics, err := h.db.GetLibraryAnalytics(r.Context(), sectionID, filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve library analytics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve library analytics", err)
 		return
 	}
 
@@ -509,9 +609,22 @@ func (h *Handler) AnalyticsLibrary(w http.ResponseWriter, r *http.Request) {
 }
 
 // AnalyticsConcurrentStreams handles concurrent streams analytics requests
+//
+// @Summary Get concurrent streams analytics
+// @Description Returns peak and average concurrent streams over time with transcode breakdowns
+// @Tags Analytics
+// @Produce json
+// @Param interval query string false "Time bucket" Enums(hour, day, week, month)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=models.ConcurrentStreamsAnalytics}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 504 {object} models.APIResponse "Query timed out"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /analytics/concurrent-streams [get]
 func (h *Handler) AnalyticsConcurrentStreams(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -520,7 +633,7 @@ func (h *Handler) AnalyticsConcurrentStreams(w http.ResponseWriter, r *http.Requ
 	if interval != "" {
 		validIntervals := map[string]bool{"hour": true, "day": true, "week": true, "month": true}
 		if !validIntervals[interval] {
-			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR",
 				"Invalid interval parameter: must be one of 'hour', 'day', 'week', 'month'", nil)
 			return
 		}
@@ -528,7 +641,7 @@ func (h *Handler) AnalyticsConcurrentStreams(w http.ResponseWriter, r *http.Requ
 
 	// Check if database is available AFTER validation (service errors = 503)
 	if h.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
@@ -555,24 +668,18 @@ func (h *Handler) AnalyticsConcurrentStreams(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	// Use a dedicated timeout context for this potentially slow query
-	// This prevents client disconnection from canceling the query mid-execution
-	// and ensures the query completes or times out gracefully
-	queryCtx, queryCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer queryCancel()
+	// This potentially slow query is bounded by the query budget and is
+	// interrupted when the client disconnects
+	queryCtx, done, err := h.beginQuery(r)
+	defer done()
+	if err != nil {
+		h.respondQueryError(w, r, queryCtx, "AnalyticsConcurrentStreams", err)
+		return
+	}
 
-	analytics, err := h.db.GetConcurrentStreamsAnalytics(queryCtx, filter)
+	analytics, err := h.db.GetConcurrentStreamsAnalytics(database.WithQueryLabel(queryCtx, "AnalyticsConcurrentStreams", &filter), filter)
 	if err != nil {
-		// Check for context cancellation errors
-		if queryCtx.Err() == context.DeadlineExceeded {
-			respondError(w, http.StatusGatewayTimeout, "QUERY_TIMEOUT", "Concurrent streams query timed out", err)
-			return
-		}
-		if queryCtx.Err() == context.Canceled {
-			respondError(w, http.StatusServiceUnavailable, "QUERY_CANCELED", "Concurrent streams query was canceled", err)
-			return
-		}
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve concurrent streams analytics", err)
+		h.respondQueryError(w, r, queryCtx, "AnalyticsConcurrentStreams", err)
 		return
 	}
 
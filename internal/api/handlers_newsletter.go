@@ -31,6 +31,7 @@
 // Deliveries (require editor/admin role for send, viewer for list):
 //   - GET    /api/v1/newsletter/deliveries         - List delivery history
 //   - GET    /api/v1/newsletter/deliveries/{id}    - Get delivery details
+//   - GET    /api/v1/newsletter/delivery-log       - Query per-recipient delivery log
 //   - POST   /api/v1/newsletter/send               - Send newsletter immediately
 //
 // User Preferences (authenticated users):
@@ -41,6 +42,10 @@
 // Stats (require viewer role):
 //   - GET    /api/v1/newsletter/stats              - Get newsletter statistics
 //
+// Feeds (require viewer role, see handlers_newsletter_feeds.go):
+//   - GET    /api/v1/newsletter/feeds/deliveries.rss - RSS feed of recent deliveries
+//   - GET    /api/v1/newsletter/feeds/schedules.ics  - iCalendar of upcoming schedule runs
+//
 // Audit (require admin role):
 //   - GET    /api/v1/newsletter/audit              - Get audit log
 //
@@ -65,6 +70,7 @@ import (
 
 	"github.com/tomtom215/cartographus/internal/models"
 	"github.com/tomtom215/cartographus/internal/newsletter"
+	"github.com/tomtom215/cartographus/internal/validation"
 )
 
 // ============================================================================
@@ -104,7 +110,7 @@ func (h *Handler) NewsletterTemplateList(w http.ResponseWriter, r *http.Request)
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to list newsletter templates")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list templates", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list templates", err)
 		return
 	}
 
@@ -140,20 +146,20 @@ func (h *Handler) NewsletterTemplateCreate(w http.ResponseWriter, r *http.Reques
 
 	var req models.CreateTemplateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	// Validate request
 	if err := validateTemplateCreateRequest(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
 	// Validate template syntax
 	engine := newsletter.NewTemplateEngine()
 	if err := engine.ValidateTemplate(req.BodyHTML); err != nil {
-		respondError(w, http.StatusBadRequest, "TEMPLATE_ERROR", "Invalid template syntax: "+err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "TEMPLATE_ERROR", "Invalid template syntax: "+err.Error(), nil)
 		return
 	}
 
@@ -167,7 +173,7 @@ func (h *Handler) NewsletterTemplateCreate(w http.ResponseWriter, r *http.Reques
 			Str("request_id", hctx.RequestID).
 			Str("user_id", hctx.UserID).
 			Msg("Failed to create newsletter template")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create template", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create template", err)
 		return
 	}
 
@@ -212,7 +218,7 @@ func (h *Handler) NewsletterTemplateGet(w http.ResponseWriter, r *http.Request)
 
 	templateID := chi.URLParam(r, "id")
 	if templateID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Template ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Template ID is required", nil)
 		return
 	}
 
@@ -224,12 +230,12 @@ func (h *Handler) NewsletterTemplateGet(w http.ResponseWriter, r *http.Request)
 			Str("template_id", templateID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter template")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
 		return
 	}
 
 	if template == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
 		return
 	}
 
@@ -265,13 +271,17 @@ func (h *Handler) NewsletterTemplateUpdate(w http.ResponseWriter, r *http.Reques
 
 	templateID := chi.URLParam(r, "id")
 	if templateID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Template ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Template ID is required", nil)
 		return
 	}
 
 	var req models.UpdateTemplateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		return
+	}
+	if err := validateTemplateConfig(req.DefaultConfig); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -279,7 +289,7 @@ func (h *Handler) NewsletterTemplateUpdate(w http.ResponseWriter, r *http.Reques
 	if req.BodyHTML != nil {
 		engine := newsletter.NewTemplateEngine()
 		if err := engine.ValidateTemplate(*req.BodyHTML); err != nil {
-			respondError(w, http.StatusBadRequest, "TEMPLATE_ERROR", "Invalid template syntax: "+err.Error(), nil)
+			respondError(w, r, http.StatusBadRequest, "TEMPLATE_ERROR", "Invalid template syntax: "+err.Error(), nil)
 			return
 		}
 	}
@@ -293,18 +303,18 @@ func (h *Handler) NewsletterTemplateUpdate(w http.ResponseWriter, r *http.Reques
 			Str("template_id", templateID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter template for update")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
 		return
 	}
 
 	if existing == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
 		return
 	}
 
 	// Prevent editing built-in templates
 	if existing.IsBuiltIn {
-		respondError(w, http.StatusForbidden, "FORBIDDEN", "Cannot modify built-in templates", nil)
+		respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Cannot modify built-in templates", nil)
 		return
 	}
 
@@ -314,7 +324,7 @@ func (h *Handler) NewsletterTemplateUpdate(w http.ResponseWriter, r *http.Reques
 			Str("template_id", templateID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to update newsletter template")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update template", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update template", err)
 		return
 	}
 
@@ -361,7 +371,7 @@ func (h *Handler) NewsletterTemplateDelete(w http.ResponseWriter, r *http.Reques
 
 	templateID := chi.URLParam(r, "id")
 	if templateID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Template ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Template ID is required", nil)
 		return
 	}
 
@@ -374,18 +384,18 @@ func (h *Handler) NewsletterTemplateDelete(w http.ResponseWriter, r *http.Reques
 			Str("template_id", templateID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter template for deletion")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
 		return
 	}
 
 	if existing == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
 		return
 	}
 
 	// Prevent deleting built-in templates
 	if existing.IsBuiltIn {
-		respondError(w, http.StatusForbidden, "FORBIDDEN", "Cannot delete built-in templates", nil)
+		respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Cannot delete built-in templates", nil)
 		return
 	}
 
@@ -395,7 +405,7 @@ func (h *Handler) NewsletterTemplateDelete(w http.ResponseWriter, r *http.Reques
 			Str("template_id", templateID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to delete newsletter template")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete template", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete template", err)
 		return
 	}
 
@@ -418,7 +428,16 @@ func (h *Handler) NewsletterTemplateDelete(w http.ResponseWriter, r *http.Reques
 	})
 }
 
-// NewsletterTemplatePreview previews a newsletter with sample data.
+// NewsletterContentResolver resolves the content of a newsletter as it would
+// be sent. newsletter.ContentResolver implements it.
+type NewsletterContentResolver interface {
+	ResolveContent(ctx context.Context, newsletterType models.NewsletterType, config *models.TemplateConfig, userID *string) (*models.NewsletterContentData, error)
+}
+
+// NewsletterTemplatePreview previews a newsletter without sending it. By
+// default it renders sample data; with "live": true it resolves the real
+// content for the template's date range and blocks, exactly as a send
+// would. The response lists the config's default recipients.
 //
 // Method: POST
 // Path: /api/v1/newsletter/templates/preview
@@ -437,12 +456,12 @@ func (h *Handler) NewsletterTemplatePreview(w http.ResponseWriter, r *http.Reque
 
 	var req models.PreviewNewsletterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	if req.TemplateID == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Template ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Template ID is required", nil)
 		return
 	}
 
@@ -455,23 +474,40 @@ func (h *Handler) NewsletterTemplatePreview(w http.ResponseWriter, r *http.Reque
 			Str("template_id", req.TemplateID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter template for preview")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get template", err)
 		return
 	}
 
 	if template == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Template not found", nil)
 		return
 	}
 
-	// Get config and generate sample data
+	// Get config and resolve live content or generate sample data
 	config := resolveTemplateConfig(req.Config, template.DefaultConfig)
-	sampleData := generateSampleContentData(template.Type, config)
+	var data *models.NewsletterContentData
+	if req.Live {
+		if h.newsletterContent == nil {
+			respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Live preview requires the newsletter scheduler (NEWSLETTER_ENABLED)", nil)
+			return
+		}
+		data, err = h.newsletterContent.ResolveContent(r.Context(), template.Type, config, req.ForUserID)
+		if err != nil {
+			log.Error().Err(err).
+				Str("template_id", template.ID).
+				Str("request_id", hctx.RequestID).
+				Msg("Failed to resolve newsletter content for preview")
+			respondError(w, r, http.StatusInternalServerError, "CONTENT_ERROR", "Failed to resolve newsletter content", err)
+			return
+		}
+	} else {
+		data = generateSampleContentData(template.Type, config)
+	}
 
 	// Render template
-	rendered, err := renderTemplatePreview(template, sampleData)
+	rendered, err := renderTemplatePreview(template, data)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "RENDER_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "RENDER_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -481,10 +517,12 @@ func (h *Handler) NewsletterTemplatePreview(w http.ResponseWriter, r *http.Reque
 	respondJSON(w, http.StatusOK, &models.APIResponse{
 		Status: "success",
 		Data: models.PreviewNewsletterResponse{
-			Subject:  rendered.Subject,
-			BodyHTML: rendered.HTML,
-			BodyText: rendered.Text,
-			Data:     sampleData,
+			Subject:    rendered.Subject,
+			BodyHTML:   rendered.HTML,
+			BodyText:   rendered.Text,
+			Data:       data,
+			Live:       req.Live,
+			Recipients: config.Recipients,
 		},
 		Metadata: models.Metadata{
 			Timestamp:   time.Now(),
@@ -685,7 +723,7 @@ func (h *Handler) NewsletterScheduleList(w http.ResponseWriter, r *http.Request)
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to list newsletter schedules")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list schedules", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list schedules", err)
 		return
 	}
 
@@ -721,19 +759,24 @@ func (h *Handler) NewsletterScheduleCreate(w http.ResponseWriter, r *http.Reques
 
 	var req models.CreateScheduleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	// Validate request
 	if err := validateScheduleCreateRequest(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
-	// Verify template exists
-	if err := h.verifyTemplateExists(r.Context(), req.TemplateID); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+	// Verify template exists and the newsletter has someone to go to
+	template, err := h.db.GetNewsletterTemplate(r.Context(), req.TemplateID)
+	if err != nil || template == nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Template not found", nil)
+		return
+	}
+	if len(models.ResolveNewsletterRecipients(req.Recipients, resolveTemplateConfig(req.Config, template.DefaultConfig))) == 0 {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "At least one recipient is required on the schedule or its template", nil)
 		return
 	}
 
@@ -747,7 +790,7 @@ func (h *Handler) NewsletterScheduleCreate(w http.ResponseWriter, r *http.Reques
 			Str("request_id", hctx.RequestID).
 			Str("user_id", hctx.UserID).
 			Msg("Failed to create newsletter schedule")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create schedule", err)
 		return
 	}
 
@@ -792,7 +835,7 @@ func (h *Handler) NewsletterScheduleGet(w http.ResponseWriter, r *http.Request)
 
 	scheduleID := chi.URLParam(r, "id")
 	if scheduleID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
 		return
 	}
 
@@ -804,12 +847,12 @@ func (h *Handler) NewsletterScheduleGet(w http.ResponseWriter, r *http.Request)
 			Str("schedule_id", scheduleID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter schedule")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
 		return
 	}
 
 	if schedule == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
 		return
 	}
 
@@ -845,13 +888,21 @@ func (h *Handler) NewsletterScheduleUpdate(w http.ResponseWriter, r *http.Reques
 
 	scheduleID := chi.URLParam(r, "id")
 	if scheduleID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
 		return
 	}
 
 	var req models.UpdateScheduleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		return
+	}
+	if verr := validation.ValidateFields(&req, "CronExpression"); verr != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", verr.Error(), nil)
+		return
+	}
+	if err := validateTemplateConfig(req.Config); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -864,12 +915,12 @@ func (h *Handler) NewsletterScheduleUpdate(w http.ResponseWriter, r *http.Reques
 			Str("schedule_id", scheduleID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter schedule for update")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
 		return
 	}
 
 	if existing == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
 		return
 	}
 
@@ -879,7 +930,7 @@ func (h *Handler) NewsletterScheduleUpdate(w http.ResponseWriter, r *http.Reques
 			Str("schedule_id", scheduleID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to update newsletter schedule")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update schedule", err)
 		return
 	}
 
@@ -928,7 +979,7 @@ func (h *Handler) NewsletterScheduleDelete(w http.ResponseWriter, r *http.Reques
 
 	scheduleID := chi.URLParam(r, "id")
 	if scheduleID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
 		return
 	}
 
@@ -941,12 +992,12 @@ func (h *Handler) NewsletterScheduleDelete(w http.ResponseWriter, r *http.Reques
 			Str("schedule_id", scheduleID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter schedule for deletion")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
 		return
 	}
 
 	if existing == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
 		return
 	}
 
@@ -956,7 +1007,7 @@ func (h *Handler) NewsletterScheduleDelete(w http.ResponseWriter, r *http.Reques
 			Str("schedule_id", scheduleID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to delete newsletter schedule")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete schedule", err)
 		return
 	}
 
@@ -999,7 +1050,7 @@ func (h *Handler) NewsletterScheduleTrigger(w http.ResponseWriter, r *http.Reque
 
 	scheduleID := chi.URLParam(r, "id")
 	if scheduleID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Schedule ID is required", nil)
 		return
 	}
 
@@ -1012,22 +1063,24 @@ func (h *Handler) NewsletterScheduleTrigger(w http.ResponseWriter, r *http.Reque
 			Str("schedule_id", scheduleID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter schedule for trigger")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get schedule", err)
 		return
 	}
 
 	if schedule == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Schedule not found", nil)
 		return
 	}
 
+	recipients := h.scheduleRecipients(r.Context(), schedule)
+
 	// Create delivery record
 	delivery := &models.NewsletterDelivery{
 		ScheduleID:        scheduleID,
 		ScheduleName:      schedule.Name,
 		TemplateID:        schedule.TemplateID,
 		Status:            models.DeliveryStatusPending,
-		RecipientsTotal:   len(schedule.Recipients) * len(schedule.Channels),
+		RecipientsTotal:   len(recipients) * len(schedule.Channels),
 		StartedAt:         time.Now(),
 		TriggeredBy:       "manual",
 		TriggeredByUserID: hctx.UserID,
@@ -1038,14 +1091,14 @@ func (h *Handler) NewsletterScheduleTrigger(w http.ResponseWriter, r *http.Reque
 			Str("schedule_id", scheduleID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to create newsletter delivery")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create delivery", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create delivery", err)
 		return
 	}
 
 	//nolint:errcheck // Audit log errors don't block the operation
 	_ = h.auditNewsletter(r, hctx, models.NewsletterAuditActionSend, models.NewsletterResourceSchedule, scheduleID, schedule.Name, map[string]interface{}{
 		"delivery_id": delivery.ID,
-		"recipients":  len(schedule.Recipients),
+		"recipients":  len(recipients),
 		"channels":    len(schedule.Channels),
 	})
 
@@ -1106,7 +1159,7 @@ func (h *Handler) NewsletterDeliveryList(w http.ResponseWriter, r *http.Request)
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to list newsletter deliveries")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list deliveries", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list deliveries", err)
 		return
 	}
 
@@ -1148,7 +1201,7 @@ func (h *Handler) NewsletterDeliveryGet(w http.ResponseWriter, r *http.Request)
 
 	deliveryID := chi.URLParam(r, "id")
 	if deliveryID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Delivery ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Delivery ID is required", nil)
 		return
 	}
 
@@ -1160,12 +1213,12 @@ func (h *Handler) NewsletterDeliveryGet(w http.ResponseWriter, r *http.Request)
 			Str("delivery_id", deliveryID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter delivery")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get delivery", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get delivery", err)
 		return
 	}
 
 	if delivery == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Delivery not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Delivery not found", nil)
 		return
 	}
 
@@ -1179,6 +1232,70 @@ func (h *Handler) NewsletterDeliveryGet(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// NewsletterDeliveryLog queries the per-recipient delivery log.
+//
+// Method: GET
+// Path: /api/v1/newsletter/delivery-log
+//
+// Query Parameters:
+//   - delivery_id: Filter by delivery ID
+//   - schedule_id: Filter by schedule ID
+//   - recipient: Filter by recipient (exact match)
+//   - channel: Filter by delivery channel
+//   - status: Filter by status (delivered, failed)
+//   - limit: Maximum results (default: 100)
+//   - offset: Pagination offset
+//
+// Response: ListDeliveryLogResponse
+//
+// Authentication: Required
+// Authorization: Viewer role or higher
+func (h *Handler) NewsletterDeliveryLog(w http.ResponseWriter, r *http.Request) {
+	hctx := h.requireAuth(w, r)
+	if hctx == nil {
+		return
+	}
+
+	start := time.Now()
+
+	query := r.URL.Query()
+	limit, offset := parsePaginationParams(r, 100, 500)
+	filter := models.DeliveryLogFilter{
+		DeliveryID: query.Get("delivery_id"),
+		ScheduleID: query.Get("schedule_id"),
+		Recipient:  query.Get("recipient"),
+		Channel:    query.Get("channel"),
+		Status:     query.Get("status"),
+		Limit:      limit,
+		Offset:     offset,
+	}
+
+	entries, totalCount, err := h.db.ListNewsletterDeliveryLog(r.Context(), filter)
+	if err != nil {
+		log.Error().Err(err).
+			Str("request_id", hctx.RequestID).
+			Msg("Failed to query newsletter delivery log")
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to query delivery log", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: models.ListDeliveryLogResponse{
+			Entries: entries,
+			Pagination: models.PaginationInfo{
+				Limit:      limit,
+				HasMore:    offset+len(entries) < totalCount,
+				TotalCount: &totalCount,
+			},
+		},
+		Metadata: models.Metadata{
+			Timestamp:   time.Now(),
+			QueryTimeMS: time.Since(start).Milliseconds(),
+		},
+	})
+}
+
 // ============================================================================
 // Stats and Audit Endpoints
 // ============================================================================
@@ -1205,7 +1322,7 @@ func (h *Handler) NewsletterStats(w http.ResponseWriter, r *http.Request) {
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter stats")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get statistics", err)
 		return
 	}
 
@@ -1256,7 +1373,7 @@ func (h *Handler) NewsletterAuditLog(w http.ResponseWriter, r *http.Request) {
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter audit log")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get audit log", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get audit log", err)
 		return
 	}
 
@@ -1299,7 +1416,7 @@ func (h *Handler) NewsletterUserPreferencesGet(w http.ResponseWriter, r *http.Re
 			Str("user_id", hctx.UserID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get newsletter preferences")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get preferences", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get preferences", err)
 		return
 	}
 
@@ -1339,7 +1456,7 @@ func (h *Handler) NewsletterUserPreferencesUpdate(w http.ResponseWriter, r *http
 
 	var prefs models.NewsletterUserPreferences
 	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
@@ -1355,7 +1472,7 @@ func (h *Handler) NewsletterUserPreferencesUpdate(w http.ResponseWriter, r *http
 			Str("user_id", hctx.UserID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to update newsletter preferences")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update preferences", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update preferences", err)
 		return
 	}
 
@@ -1411,7 +1528,7 @@ func (h *Handler) NewsletterUnsubscribe(w http.ResponseWriter, r *http.Request)
 			Str("user_id", hctx.UserID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to unsubscribe from newsletters")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to unsubscribe", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to unsubscribe", err)
 		return
 	}
 
@@ -1460,7 +1577,7 @@ func getClientIP(r *http.Request) string {
 func (h *Handler) requireAuth(w http.ResponseWriter, r *http.Request) *HandlerContext {
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return nil
 	}
 	return hctx
@@ -1474,7 +1591,7 @@ func (h *Handler) requireEditor(w http.ResponseWriter, r *http.Request, action s
 		return nil
 	}
 	if !hctx.HasRole("editor") && !hctx.HasRole("admin") {
-		respondError(w, http.StatusForbidden, "FORBIDDEN", "Editor role required to "+action, nil)
+		respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Editor role required to "+action, nil)
 		return nil
 	}
 	return hctx
@@ -1488,7 +1605,7 @@ func (h *Handler) requireAdmin(w http.ResponseWriter, r *http.Request, action st
 		return nil
 	}
 	if !hctx.HasRole("admin") {
-		respondError(w, http.StatusForbidden, "FORBIDDEN", "Admin role required to "+action, nil)
+		respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Admin role required to "+action, nil)
 		return nil
 	}
 	return hctx
@@ -1556,7 +1673,7 @@ func validateTemplateCreateRequest(req *models.CreateTemplateRequest) error {
 	if !models.IsValidNewsletterType(req.Type) {
 		return ErrValidation("Invalid newsletter type")
 	}
-	return nil
+	return validateTemplateConfig(req.DefaultConfig)
 }
 
 // buildTemplateFromRequest constructs a NewsletterTemplate from a request.
@@ -1590,11 +1707,16 @@ func validateScheduleCreateRequest(req *models.CreateScheduleRequest) error {
 	if req.CronExpression == "" {
 		return ErrValidation("Cron expression is required")
 	}
+	if verr := validation.ValidateFields(req, "CronExpression"); verr != nil {
+		return ErrValidation(verr.Error())
+	}
 	if req.Timezone == "" {
 		return ErrValidation("Timezone is required")
 	}
-	if len(req.Recipients) == 0 {
-		return ErrValidation("At least one recipient is required")
+	// Recipients may be left to the template; the handler checks that
+	// one of the two lists some
+	if err := validateNewsletterRecipients(req.Recipients); err != nil {
+		return err
 	}
 	if len(req.Channels) == 0 {
 		return ErrValidation("At least one channel is required")
@@ -1607,6 +1729,35 @@ func validateScheduleCreateRequest(req *models.CreateScheduleRequest) error {
 		}
 	}
 
+	return validateTemplateConfig(req.Config)
+}
+
+// validateTemplateConfig rejects unknown content block types and
+// malformed recipients.
+func validateTemplateConfig(config *models.TemplateConfig) error {
+	if config == nil {
+		return nil
+	}
+	for _, block := range config.Blocks {
+		if !models.IsValidNewsletterBlock(block) {
+			return ErrValidation("Invalid content block: " + string(block))
+		}
+	}
+	return validateNewsletterRecipients(config.Recipients)
+}
+
+// validateNewsletterRecipients checks each recipient's type and target.
+func validateNewsletterRecipients(recipients []models.NewsletterRecipient) error {
+	for _, recipient := range recipients {
+		switch recipient.Type {
+		case "user", "email", "webhook":
+		default:
+			return ErrValidation("Invalid recipient type: " + recipient.Type)
+		}
+		if strings.TrimSpace(recipient.Target) == "" {
+			return ErrValidation("Recipient target is required")
+		}
+	}
 	return nil
 }
 
@@ -1630,13 +1781,17 @@ func buildScheduleFromRequest(req *models.CreateScheduleRequest, userID string)
 	}
 }
 
-// verifyTemplateExists checks if a template exists in the database.
-func (h *Handler) verifyTemplateExists(ctx context.Context, templateID string) error {
-	template, err := h.db.GetNewsletterTemplate(ctx, templateID)
+// scheduleRecipients resolves the recipients a schedule sends to, falling
+// back to its template's when it lists none.
+func (h *Handler) scheduleRecipients(ctx context.Context, schedule *models.NewsletterSchedule) []models.NewsletterRecipient {
+	if len(schedule.Recipients) > 0 || schedule.Config != nil {
+		return models.ResolveNewsletterRecipients(schedule.Recipients, schedule.Config)
+	}
+	template, err := h.db.GetNewsletterTemplate(ctx, schedule.TemplateID)
 	if err != nil || template == nil {
-		return ErrValidation("Template not found")
+		return nil
 	}
-	return nil
+	return models.ResolveNewsletterRecipients(nil, template.DefaultConfig)
 }
 
 // determineScheduleAuditAction determines the appropriate audit action for a schedule update.
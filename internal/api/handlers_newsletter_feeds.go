@@ -0,0 +1,304 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// handlers_newsletter_feeds.go - Newsletter RSS and iCalendar feeds
+//
+// Endpoints (require viewer role):
+//   - GET /api/v1/newsletter/feeds/deliveries.rss - RSS feed of recent deliveries
+//   - GET /api/v1/newsletter/feeds/schedules.ics  - iCalendar of upcoming schedule runs
+//
+// Feed readers and calendar clients authenticate like any other API client,
+// for example with Basic Auth credentials in the subscription URL.
+
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/newsletter/scheduler"
+)
+
+const (
+	// feedDeliveryLimit is the number of recent deliveries in the RSS feed.
+	feedDeliveryLimit = 50
+
+	// feedCalendarHorizon is how far ahead the calendar lists schedule runs.
+	feedCalendarHorizon = 30 * 24 * time.Hour
+
+	// feedCalendarRunsPerSchedule caps the runs listed for one schedule, so
+	// a schedule firing every few minutes does not flood the calendar.
+	feedCalendarRunsPerSchedule = 50
+
+	// feedScheduleLimit is the maximum number of schedules in the calendar.
+	feedScheduleLimit = 200
+)
+
+// NewsletterDeliveriesFeed returns an RSS 2.0 feed of recent newsletter
+// deliveries. Each item links to the delivery record, which holds the
+// rendered subject, content summary and per-recipient results.
+//
+// Method: GET
+// Path: /api/v1/newsletter/feeds/deliveries.rss
+//
+// Response: application/rss+xml
+//
+// Authentication: Required
+// Authorization: Viewer role or higher
+func (h *Handler) NewsletterDeliveriesFeed(w http.ResponseWriter, r *http.Request) {
+	hctx := h.requireAuth(w, r)
+	if hctx == nil {
+		return
+	}
+
+	deliveries, _, err := h.db.ListNewsletterDeliveries(r.Context(), "", "", feedDeliveryLimit, 0)
+	if err != nil {
+		log.Error().Err(err).
+			Str("request_id", hctx.RequestID).
+			Msg("Failed to list newsletter deliveries for feed")
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list deliveries", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := writeDeliveriesRSS(w, requestBaseURL(r), deliveries); err != nil {
+		log.Error().Err(err).Str("request_id", hctx.RequestID).Msg("Failed to write newsletter delivery feed")
+	}
+}
+
+// NewsletterSchedulesCalendar returns an iCalendar feed with an event for
+// each run of an enabled newsletter schedule in the next 30 days. Run times
+// are computed from the cron expressions the same way the scheduler does.
+//
+// Method: GET
+// Path: /api/v1/newsletter/feeds/schedules.ics
+//
+// Response: text/calendar
+//
+// Authentication: Required
+// Authorization: Viewer role or higher
+func (h *Handler) NewsletterSchedulesCalendar(w http.ResponseWriter, r *http.Request) {
+	hctx := h.requireAuth(w, r)
+	if hctx == nil {
+		return
+	}
+
+	enabled := true
+	schedules, _, err := h.db.ListNewsletterSchedules(r.Context(), "", &enabled, feedScheduleLimit, 0)
+	if err != nil {
+		log.Error().Err(err).
+			Str("request_id", hctx.RequestID).
+			Msg("Failed to list newsletter schedules for calendar")
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list schedules", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := writeSchedulesCalendar(w, requestBaseURL(r), schedules, time.Now()); err != nil {
+		log.Error().Err(err).Str("request_id", hctx.RequestID).Msg("Failed to write newsletter schedule calendar")
+	}
+}
+
+// rssFeed is the root element of an RSS 2.0 document.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// writeDeliveriesRSS writes deliveries, newest first, as an RSS 2.0 feed.
+func writeDeliveriesRSS(w io.Writer, baseURL string, deliveries []models.NewsletterDelivery) error {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         "Cartographus newsletter deliveries",
+			Link:          baseURL + "/api/v1/newsletter/deliveries",
+			Description:   "Newsletters recently sent by Cartographus",
+			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(deliveries)),
+		},
+	}
+
+	for i := range deliveries {
+		d := &deliveries[i]
+		link := baseURL + "/api/v1/newsletter/deliveries/" + d.ID
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       deliveryFeedTitle(d),
+			Link:        link,
+			Description: deliveryFeedDescription(d),
+			PubDate:     d.StartedAt.UTC().Format(time.RFC1123Z),
+			GUID:        rssGUID{Value: "cartographus-newsletter-delivery-" + d.ID},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(feed)
+}
+
+// deliveryFeedTitle returns the rendered subject of a delivery, or its
+// schedule or template name when it failed before rendering.
+func deliveryFeedTitle(d *models.NewsletterDelivery) string {
+	switch {
+	case d.RenderedSubject != "":
+		return d.RenderedSubject
+	case d.ScheduleName != "":
+		return d.ScheduleName
+	case d.TemplateName != "":
+		return d.TemplateName
+	default:
+		return "Newsletter delivery " + d.ID
+	}
+}
+
+// deliveryFeedDescription summarizes the outcome of a delivery.
+func deliveryFeedDescription(d *models.NewsletterDelivery) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Status: %s. Delivered to %d of %d recipients via %s.",
+		d.Status, d.RecipientsDelivered, d.RecipientsTotal, d.Channel)
+	if d.ContentSummary != "" {
+		b.WriteString(" ")
+		b.WriteString(d.ContentSummary)
+	}
+	if d.ErrorMessage != "" {
+		b.WriteString(" Error: ")
+		b.WriteString(d.ErrorMessage)
+	}
+	return b.String()
+}
+
+// writeSchedulesCalendar writes the runs of schedules between now and the
+// calendar horizon as an iCalendar (RFC 5545) document.
+func writeSchedulesCalendar(w io.Writer, baseURL string, schedules []models.NewsletterSchedule, now time.Time) error {
+	cal := &icsWriter{}
+	cal.line("BEGIN:VCALENDAR")
+	cal.line("VERSION:2.0")
+	cal.line("PRODID:-//Cartographus//Newsletter Schedules//EN")
+	cal.line("CALSCALE:GREGORIAN")
+	cal.line("X-WR-CALNAME:" + icsEscape("Cartographus newsletters"))
+
+	stamp := now.UTC().Format(icsTimeFormat)
+	for i := range schedules {
+		s := &schedules[i]
+		if !s.IsEnabled {
+			continue
+		}
+		runs, err := scheduler.UpcomingRuns(s.CronExpression, now, now.Add(feedCalendarHorizon), s.Timezone, feedCalendarRunsPerSchedule)
+		if err != nil {
+			// Skip schedules whose expression or timezone no longer parses
+			log.Warn().Err(err).Str("schedule_id", s.ID).Msg("Skipping newsletter schedule in calendar")
+			continue
+		}
+		for _, run := range runs {
+			start := run.UTC().Format(icsTimeFormat)
+			cal.line("BEGIN:VEVENT")
+			cal.line("UID:" + s.ID + "-" + start + "@cartographus")
+			cal.line("DTSTAMP:" + stamp)
+			cal.line("DTSTART:" + start)
+			cal.line("SUMMARY:" + icsEscape("Newsletter: "+s.Name))
+			cal.line("DESCRIPTION:" + icsEscape(scheduleFeedDescription(s)))
+			cal.line("URL:" + baseURL + "/api/v1/newsletter/schedules/" + s.ID)
+			cal.line("END:VEVENT")
+		}
+	}
+
+	cal.line("END:VCALENDAR")
+	_, err := io.WriteString(w, cal.String())
+	return err
+}
+
+// scheduleFeedDescription describes a schedule for a calendar event.
+func scheduleFeedDescription(s *models.NewsletterSchedule) string {
+	channels := make([]string, 0, len(s.Channels))
+	for _, c := range s.Channels {
+		channels = append(channels, string(c))
+	}
+	desc := fmt.Sprintf("Template: %s\nChannels: %s\nSchedule: %s (%s)",
+		s.TemplateName, strings.Join(channels, ", "), s.CronExpression, s.Timezone)
+	if s.Description != "" {
+		desc = s.Description + "\n" + desc
+	}
+	return desc
+}
+
+// icsTimeFormat is the RFC 5545 UTC date-time format.
+const icsTimeFormat = "20060102T150405Z"
+
+// icsWriter builds an iCalendar document with CRLF line endings, folding
+// content lines longer than 75 octets as RFC 5545 requires.
+type icsWriter struct {
+	strings.Builder
+}
+
+func (c *icsWriter) line(s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		// Do not split a multi-byte UTF-8 sequence
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		c.WriteString(s[:cut])
+		c.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	c.WriteString(s)
+	c.WriteString("\r\n")
+}
+
+// icsEscape escapes an iCalendar TEXT value.
+func icsEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// requestBaseURL returns the scheme and host the client used to reach the
+// server, for absolute links in feeds.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
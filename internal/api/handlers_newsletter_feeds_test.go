@@ -0,0 +1,186 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestWriteDeliveriesRSS(t *testing.T) {
+	t.Parallel()
+
+	deliveries := []models.NewsletterDelivery{
+		{
+			ID:                  "d1",
+			RenderedSubject:     "Weekly digest: 3 new movies",
+			Channel:             models.DeliveryChannelEmail,
+			Status:              models.DeliveryStatusDelivered,
+			RecipientsTotal:     2,
+			RecipientsDelivered: 2,
+			ContentSummary:      "3 movies, 1 show",
+			StartedAt:           time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           "d2",
+			ScheduleName: "Monthly stats",
+			Channel:      models.DeliveryChannelDiscord,
+			Status:       models.DeliveryStatusFailed,
+			ErrorMessage: "Failed to fetch template",
+			StartedAt:    time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeDeliveriesRSS(&buf, "https://carto.example.com", deliveries); err != nil {
+		t.Fatalf("writeDeliveriesRSS() error = %v", err)
+	}
+
+	var feed rssFeed
+	if err := xml.Unmarshal(buf.Bytes(), &feed); err != nil {
+		t.Fatalf("feed is not valid XML: %v\n%s", err, buf.String())
+	}
+	if feed.Version != "2.0" {
+		t.Errorf("version = %q, want 2.0", feed.Version)
+	}
+	if len(feed.Channel.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(feed.Channel.Items))
+	}
+
+	first := feed.Channel.Items[0]
+	if first.Title != "Weekly digest: 3 new movies" {
+		t.Errorf("title = %q", first.Title)
+	}
+	if first.Link != "https://carto.example.com/api/v1/newsletter/deliveries/d1" {
+		t.Errorf("link = %q", first.Link)
+	}
+	if first.PubDate != "Mon, 12 Oct 2026 09:00:00 +0000" {
+		t.Errorf("pubDate = %q", first.PubDate)
+	}
+	if !strings.Contains(first.Description, "3 movies, 1 show") {
+		t.Errorf("description %q lacks content summary", first.Description)
+	}
+
+	// Failed before rendering: falls back to the schedule name
+	second := feed.Channel.Items[1]
+	if second.Title != "Monthly stats" {
+		t.Errorf("title = %q, want schedule name", second.Title)
+	}
+	if !strings.Contains(second.Description, "Failed to fetch template") {
+		t.Errorf("description %q lacks error", second.Description)
+	}
+}
+
+func TestWriteSchedulesCalendar(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	schedules := []models.NewsletterSchedule{
+		{
+			ID:             "weekly",
+			Name:           "Weekly digest, Mondays",
+			TemplateName:   "Digest",
+			CronExpression: "0 9 * * 1",
+			Timezone:       "UTC",
+			Channels:       []models.DeliveryChannel{models.DeliveryChannelEmail},
+			IsEnabled:      true,
+		},
+		{
+			ID:             "disabled",
+			Name:           "Disabled",
+			CronExpression: "0 9 * * *",
+			Timezone:       "UTC",
+		},
+		{
+			ID:             "broken",
+			Name:           "Broken",
+			CronExpression: "not a cron",
+			IsEnabled:      true,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSchedulesCalendar(&buf, "https://carto.example.com", schedules, now); err != nil {
+		t.Fatalf("writeSchedulesCalendar() error = %v", err)
+	}
+	ics := buf.String()
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("calendar is not wrapped in VCALENDAR:\n%s", ics)
+	}
+	// Mondays in the 30 days after Oct 1 2026: Oct 5, 12, 19, 26
+	if got := strings.Count(ics, "BEGIN:VEVENT"); got != 4 {
+		t.Errorf("got %d events, want 4", got)
+	}
+	if !strings.Contains(ics, "DTSTART:20261005T090000Z\r\n") {
+		t.Errorf("missing first run:\n%s", ics)
+	}
+	if !strings.Contains(ics, `SUMMARY:Newsletter: Weekly digest\, Mondays`) {
+		t.Errorf("summary not escaped:\n%s", ics)
+	}
+	if strings.Contains(ics, "Disabled") || strings.Contains(ics, "Broken") {
+		t.Errorf("disabled or invalid schedule listed:\n%s", ics)
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+}
+
+func TestICSWriter_FoldsLongLines(t *testing.T) {
+	t.Parallel()
+
+	var cal icsWriter
+	long := "DESCRIPTION:" + strings.Repeat("é", 60)
+	cal.line(long)
+
+	lines := strings.Split(strings.TrimSuffix(cal.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected folded lines, got %q", cal.String())
+	}
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line %d is %d octets", i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	if unfolded.String() != long {
+		t.Errorf("unfolded = %q, want %q", unfolded.String(), long)
+	}
+}
+
+func TestNewsletterFeeds_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	for name, handler := range map[string]http.HandlerFunc{
+		"rss": h.NewsletterDeliveriesFeed,
+		"ics": h.NewsletterSchedulesCalendar,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodGet, "/api/v1/newsletter/feeds/x", nil))
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401", w.Code)
+			}
+		})
+	}
+}
@@ -186,6 +186,19 @@ func TestValidateScheduleCreateRequest(t *testing.T) {
 			expectError: true,
 			errorMsg:    "Cron expression is required",
 		},
+		{
+			name: "invalid_cron",
+			req: &models.CreateScheduleRequest{
+				Name:           "Weekly Digest",
+				TemplateID:     "template-123",
+				CronExpression: "0 8 * *",
+				Timezone:       "America/New_York",
+				Recipients:     []models.NewsletterRecipient{{Type: "user", Target: "user-1"}},
+				Channels:       []models.DeliveryChannel{models.DeliveryChannelEmail},
+			},
+			expectError: true,
+			errorMsg:    "CronExpression must be a valid cron expression (minute hour day-of-month month day-of-week)",
+		},
 		{
 			name: "missing_timezone",
 			req: &models.CreateScheduleRequest{
@@ -199,6 +212,7 @@ func TestValidateScheduleCreateRequest(t *testing.T) {
 			errorMsg:    "Timezone is required",
 		},
 		{
+			// Recipients can come from the template; the handler checks
 			name: "no_recipients",
 			req: &models.CreateScheduleRequest{
 				Name:           "Weekly Digest",
@@ -208,8 +222,35 @@ func TestValidateScheduleCreateRequest(t *testing.T) {
 				Recipients:     []models.NewsletterRecipient{},
 				Channels:       []models.DeliveryChannel{models.DeliveryChannelEmail},
 			},
+			expectError: false,
+		},
+		{
+			name: "invalid_recipient_type",
+			req: &models.CreateScheduleRequest{
+				Name:           "Weekly Digest",
+				TemplateID:     "template-123",
+				CronExpression: "0 8 * * 1",
+				Timezone:       "America/New_York",
+				Recipients:     []models.NewsletterRecipient{{Type: "pager", Target: "ops"}},
+				Channels:       []models.DeliveryChannel{models.DeliveryChannelEmail},
+			},
 			expectError: true,
-			errorMsg:    "At least one recipient is required",
+			errorMsg:    "Invalid recipient type: pager",
+		},
+		{
+			name: "empty_config_recipient_target",
+			req: &models.CreateScheduleRequest{
+				Name:           "Weekly Digest",
+				TemplateID:     "template-123",
+				CronExpression: "0 8 * * 1",
+				Timezone:       "America/New_York",
+				Channels:       []models.DeliveryChannel{models.DeliveryChannelEmail},
+				Config: &models.TemplateConfig{Recipients: []models.NewsletterRecipient{
+					{Type: "email", Target: " "},
+				}},
+			},
+			expectError: true,
+			errorMsg:    "Recipient target is required",
 		},
 		{
 			name: "no_channels",
@@ -237,6 +278,35 @@ func TestValidateScheduleCreateRequest(t *testing.T) {
 			expectError: true,
 			errorMsg:    "Invalid delivery channel: invalid_channel",
 		},
+		{
+			name: "valid_blocks",
+			req: &models.CreateScheduleRequest{
+				Name:           "Weekly Digest",
+				TemplateID:     "template-123",
+				CronExpression: "0 8 * * 1",
+				Timezone:       "America/New_York",
+				Recipients:     []models.NewsletterRecipient{{Type: "user", Target: "user-1"}},
+				Channels:       []models.DeliveryChannel{models.DeliveryChannelEmail},
+				Config: &models.TemplateConfig{Blocks: []models.NewsletterBlockType{
+					models.NewsletterBlockWatchHours, models.NewsletterBlockPlaybackMap,
+				}},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid_block",
+			req: &models.CreateScheduleRequest{
+				Name:           "Weekly Digest",
+				TemplateID:     "template-123",
+				CronExpression: "0 8 * * 1",
+				Timezone:       "America/New_York",
+				Recipients:     []models.NewsletterRecipient{{Type: "user", Target: "user-1"}},
+				Channels:       []models.DeliveryChannel{models.DeliveryChannelEmail},
+				Config:         &models.TemplateConfig{Blocks: []models.NewsletterBlockType{"weather"}},
+			},
+			expectError: true,
+			errorMsg:    "Invalid content block: weather",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1049,6 +1119,72 @@ func TestNewsletterTemplatePreview_InvalidJSON(t *testing.T) {
 	}
 }
 
+// stubContentResolver returns fixed newsletter content.
+type stubContentResolver struct {
+	data *models.NewsletterContentData
+}
+
+func (s *stubContentResolver) ResolveContent(_ context.Context, _ models.NewsletterType, _ *models.TemplateConfig, _ *string) (*models.NewsletterContentData, error) {
+	return s.data, nil
+}
+
+func TestNewsletterTemplatePreview_Live(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	handler := setupTestHandlerWithDB(t, db)
+
+	template := &models.NewsletterTemplate{
+		Name:     "Weekly Top Users",
+		Type:     models.NewsletterTypeCustom,
+		Subject:  "Top users on {{.ServerName}}",
+		BodyHTML: "<p>{{.ServerName}}</p>",
+		IsActive: true,
+		DefaultConfig: &models.TemplateConfig{
+			TimeFrame:  7,
+			Blocks:     []models.NewsletterBlockType{models.NewsletterBlockActiveUsers},
+			Recipients: []models.NewsletterRecipient{{Type: "email", Target: "ops@example.com"}},
+		},
+	}
+	if err := db.CreateNewsletterTemplate(context.Background(), template); err != nil {
+		t.Fatalf("CreateNewsletterTemplate() error = %v", err)
+	}
+
+	preview := func() *httptest.ResponseRecorder {
+		body := `{"template_id":"` + template.ID + `","live":true}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/newsletter/templates/preview", strings.NewReader(body))
+		req = addAuthContext(req, "user-1", "testuser", "editor")
+		rec := httptest.NewRecorder()
+		handler.NewsletterTemplatePreview(rec, req)
+		return rec
+	}
+
+	// Without the scheduler's resolver there is no live content
+	if rec := preview(); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status without resolver = %d, want 503", rec.Code)
+	}
+
+	handler.SetNewsletterContentResolver(&stubContentResolver{data: &models.NewsletterContentData{ServerName: "Live Server"}})
+	rec := preview()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Data models.PreviewNewsletterResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !resp.Data.Live || resp.Data.Subject != "Top users on Live Server" {
+		t.Errorf("preview = live %v, subject %q; want live content", resp.Data.Live, resp.Data.Subject)
+	}
+	if len(resp.Data.Recipients) != 1 || resp.Data.Recipients[0].Target != "ops@example.com" {
+		t.Errorf("recipients = %+v, want the template's default recipient", resp.Data.Recipients)
+	}
+}
+
 func TestNewsletterTemplatePreview_MissingTemplateID(t *testing.T) {
 	t.Parallel()
 
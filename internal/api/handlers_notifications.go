@@ -0,0 +1,95 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/newsletter/delivery"
+)
+
+// testEmailTimeout bounds a test-email request, including connect and TLS.
+const testEmailTimeout = 30 * time.Second
+
+// NotificationTestEmail handles POST /api/v1/admin/notifications/test-email
+// Sends a test message so SMTP settings can be checked without waiting for a
+// newsletter schedule. Uses the settings in the request, or the server's
+// NEWSLETTER_SMTP_* settings when the request has none.
+//
+// @Summary Send a test email
+// @Description Sends a test message through SMTP and reports the result.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.TestEmailRequest true "Recipient and optional SMTP settings"
+// @Success 200 {object} models.APIResponse{data=models.TestEmailResponse} "Test result"
+// @Failure 400 {object} models.APIResponse "Invalid request or SMTP settings"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Router /admin/notifications/test-email [post]
+func (h *Handler) NotificationTestEmail(w http.ResponseWriter, r *http.Request) {
+	var req models.TestEmailRequest
+	if err := h.parseAndValidateRequest(r, &req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), err)
+		return
+	}
+
+	smtpConfig := req.Config
+	if smtpConfig == nil && h.config != nil {
+		smtpConfig = h.config.Newsletter.SMTP.ChannelConfig()
+	}
+	if smtpConfig == nil {
+		respondError(w, r, http.StatusBadRequest, "SMTP_NOT_CONFIGURED",
+			"No SMTP settings in the request and NEWSLETTER_SMTP_HOST is not set", nil)
+		return
+	}
+	if err := delivery.ValidateSMTPConfig(smtpConfig); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), err)
+		return
+	}
+
+	channel := delivery.NewEmailChannel()
+	defer func() { _ = channel.Close() }() //nolint:errcheck // Best effort cleanup
+
+	ctx, cancel := context.WithTimeout(r.Context(), testEmailTimeout)
+	defer cancel()
+
+	start := time.Now()
+	sentAt := start.UTC().Format(time.RFC1123)
+	result, err := channel.Send(ctx, &delivery.SendParams{
+		Recipient: models.NewsletterRecipient{Type: "email", Target: req.To},
+		Subject:   "Cartographus SMTP test",
+		BodyText:  fmt.Sprintf("This is a test message from Cartographus, sent %s.\r\n\r\nYour SMTP settings work.", sentAt),
+		BodyHTML: fmt.Sprintf(`<html><body style="font-family:sans-serif">`+
+			`<p>This is a test message from Cartographus, sent %s.</p>`+
+			`<p>Your SMTP settings work.</p></body></html>`, sentAt),
+		Config: smtpConfig,
+	})
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "SEND_FAILED", "Failed to send test email", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: models.TestEmailResponse{
+			Success:      result.Success,
+			Recipient:    req.To,
+			SMTPHost:     smtpConfig.SMTPHost,
+			SMTPPort:     smtpConfig.SMTPPort,
+			TLSMode:      smtpConfig.EffectiveSMTPTLSMode(),
+			ErrorCode:    result.ErrorCode,
+			ErrorMessage: result.ErrorMessage,
+			DurationMS:   time.Since(start).Milliseconds(),
+		},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
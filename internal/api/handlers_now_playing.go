@@ -0,0 +1,273 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	syncpkg "github.com/tomtom215/cartographus/internal/sync"
+)
+
+const (
+	// nowPlayingCacheTTL is how long a merged snapshot is served before the
+	// media servers are polled again.
+	nowPlayingCacheTTL = 10 * time.Second
+
+	// nowPlayingGeoTimeout bounds geolocation of a snapshot's new client
+	// addresses, which may need an external GeoIP lookup.
+	nowPlayingGeoTimeout = 5 * time.Second
+)
+
+// GeolocationResolver places client IP addresses.
+// Implemented by *sync.Manager.
+type GeolocationResolver interface {
+	ResolveGeolocation(ctx context.Context, ipAddress string) (*models.Geolocation, error)
+}
+
+// nowPlayingAggregator merges the live sessions of all media servers. The
+// merged snapshot is cached for ttl, and each server's last successful poll
+// is kept so its sessions can be reported as stale when a poll fails.
+type nowPlayingAggregator struct {
+	providers []syncpkg.NowPlayingProvider
+	geo       GeolocationResolver // nil leaves sessions without a geolocation
+	ttl       time.Duration
+	now       func() time.Time
+
+	pollMu   sync.Mutex // one poll at a time; concurrent requests share it
+	mu       sync.Mutex // guards snapshot, expires and lastGood
+	snapshot *models.NowPlayingResponse
+	expires  time.Time
+	lastGood map[string]nowPlayingPoll // keyed by source/server ID
+}
+
+// nowPlayingPoll is a server's sessions as of its last successful poll.
+type nowPlayingPoll struct {
+	sessions []models.NowPlayingSession
+	polledAt time.Time
+}
+
+// SetNowPlayingProviders sets the managers polled by NowPlaying and the
+// resolver that places session IP addresses. Nil providers are ignored; nil
+// Jellyfin/Emby managers report no sources.
+//
+// Thread Safety: Safe for concurrent access but should be called once during startup.
+func (h *Handler) SetNowPlayingProviders(geo GeolocationResolver, providers ...syncpkg.NowPlayingProvider) {
+	agg := &nowPlayingAggregator{
+		providers: make([]syncpkg.NowPlayingProvider, 0, len(providers)),
+		geo:       geo,
+		ttl:       nowPlayingCacheTTL,
+		now:       time.Now,
+		lastGood:  make(map[string]nowPlayingPoll),
+	}
+	for _, provider := range providers {
+		if provider != nil {
+			agg.providers = append(agg.providers, provider)
+		}
+	}
+	h.nowPlaying = agg
+}
+
+// NowPlaying lists the sessions currently playing on every configured media
+// server, normalized into one shape with the client's geolocation.
+//
+// Servers are polled in parallel, each bounded by a timeout, and the merged
+// list is cached for 10 seconds. When a server cannot be polled, its sessions
+// from the last successful poll are returned with stale set.
+//
+// @Summary Get sessions playing now
+// @Description Polls the session API of each configured Plex, Jellyfin and Emby server and returns the active
+// @Description sessions with user, title, progress, player, quality, transcode details and geolocation.
+// @Description Results are cached for 10 seconds. Sessions of a server that could not be polled are carried
+// @Description over from its last successful poll with stale set.
+// @Tags Core
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.NowPlayingResponse} "Active sessions retrieved successfully"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Router /now-playing [get]
+func (h *Handler) NowPlaying(w http.ResponseWriter, r *http.Request) {
+	var resp *models.NowPlayingResponse
+	if h.nowPlaying != nil {
+		resp = h.nowPlaying.get(r.Context())
+	} else {
+		resp = &models.NowPlayingResponse{
+			Sessions:    []models.NowPlayingSession{},
+			Sources:     []models.NowPlayingSourceStatus{},
+			GeneratedAt: time.Now(),
+		}
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   resp,
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
+
+// get returns the cached snapshot, polling the media servers if it expired.
+// The returned snapshot is shared and must not be modified.
+func (a *nowPlayingAggregator) get(ctx context.Context) *models.NowPlayingResponse {
+	if snapshot := a.cached(); snapshot != nil {
+		return snapshot
+	}
+
+	a.pollMu.Lock()
+	defer a.pollMu.Unlock()
+	if snapshot := a.cached(); snapshot != nil {
+		return snapshot
+	}
+
+	// Requests waiting on pollMu share the result, so one client
+	// disconnecting must not fail the poll for the others
+	snapshot := a.poll(context.WithoutCancel(ctx))
+
+	a.mu.Lock()
+	a.snapshot = snapshot
+	a.expires = a.now().Add(a.ttl)
+	a.mu.Unlock()
+	return snapshot
+}
+
+// cached returns the snapshot if it has not expired, or nil.
+func (a *nowPlayingAggregator) cached() *models.NowPlayingResponse {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.snapshot != nil && a.now().Before(a.expires) {
+		return a.snapshot
+	}
+	return nil
+}
+
+// poll fetches every server's sessions concurrently and merges them with the
+// last good sessions of the servers that failed.
+func (a *nowPlayingAggregator) poll(ctx context.Context) *models.NowPlayingResponse {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []syncpkg.NowPlayingResult
+	)
+	for _, provider := range a.providers {
+		wg.Add(1)
+		go func(provider syncpkg.NowPlayingProvider) {
+			defer wg.Done()
+			polled := provider.NowPlaying(ctx)
+			mu.Lock()
+			results = append(results, polled...)
+			mu.Unlock()
+		}(provider)
+	}
+	wg.Wait()
+
+	a.geolocate(ctx, results)
+
+	now := a.now()
+	resp := &models.NowPlayingResponse{
+		Sessions:    []models.NowPlayingSession{},
+		Sources:     make([]models.NowPlayingSourceStatus, 0, len(results)),
+		GeneratedAt: now,
+	}
+
+	a.mu.Lock()
+	for i := range results {
+		result := &results[i]
+		key := result.Source + "/" + result.ServerID
+		status := models.NowPlayingSourceStatus{
+			Source:   result.Source,
+			ServerID: result.ServerID,
+		}
+
+		if result.Err == nil {
+			a.lastGood[key] = nowPlayingPoll{sessions: result.Sessions, polledAt: now}
+			polledAt := now
+			status.LastPolledAt = &polledAt
+			status.SessionCount = len(result.Sessions)
+			resp.Sessions = append(resp.Sessions, result.Sessions...)
+			resp.Sources = append(resp.Sources, status)
+			continue
+		}
+
+		status.Stale = true
+		status.Error = result.Err.Error()
+		if last, ok := a.lastGood[key]; ok {
+			polledAt := last.polledAt
+			status.LastPolledAt = &polledAt
+			status.SessionCount = len(last.sessions)
+			for _, session := range last.sessions {
+				session.Stale = true
+				resp.Sessions = append(resp.Sessions, session)
+			}
+		}
+		resp.Sources = append(resp.Sources, status)
+	}
+	a.mu.Unlock()
+
+	for i := range resp.Sessions {
+		if resp.Sessions[i].Stale {
+			resp.StaleCount++
+		}
+	}
+	resp.TotalCount = len(resp.Sessions)
+
+	sort.Slice(resp.Sources, func(i, j int) bool {
+		if resp.Sources[i].Source != resp.Sources[j].Source {
+			return resp.Sources[i].Source < resp.Sources[j].Source
+		}
+		return resp.Sources[i].ServerID < resp.Sources[j].ServerID
+	})
+	sort.SliceStable(resp.Sessions, func(i, j int) bool {
+		si, sj := &resp.Sessions[i], &resp.Sessions[j]
+		if si.Source != sj.Source {
+			return si.Source < sj.Source
+		}
+		if si.ServerID != sj.ServerID {
+			return si.ServerID < sj.ServerID
+		}
+		return si.SessionID < sj.SessionID
+	})
+	return resp
+}
+
+// geolocate sets the geolocation of freshly polled sessions, resolving each
+// client address once. Addresses that cannot be resolved are left unset.
+func (a *nowPlayingAggregator) geolocate(ctx context.Context, results []syncpkg.NowPlayingResult) {
+	if a.geo == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, nowPlayingGeoTimeout)
+	defer cancel()
+
+	resolved := make(map[string]*models.Geolocation)
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		for j := range results[i].Sessions {
+			session := &results[i].Sessions[j]
+			if session.IPAddress == "" {
+				continue
+			}
+			geo, ok := resolved[session.IPAddress]
+			if !ok {
+				var err error
+				geo, err = a.geo.ResolveGeolocation(ctx, session.IPAddress)
+				if err != nil {
+					logging.Debug().Str("ip", session.IPAddress).Err(err).Msg("Failed to geolocate now playing session")
+				}
+				resolved[session.IPAddress] = geo
+			}
+			session.Geolocation = geo
+		}
+	}
+}
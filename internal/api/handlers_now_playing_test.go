@@ -0,0 +1,202 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	syncpkg "github.com/tomtom215/cartographus/internal/sync"
+)
+
+// stubNowPlayingProvider returns fixed sessions, or err, for one server.
+type stubNowPlayingProvider struct {
+	source   string
+	serverID string
+	sessions []models.NowPlayingSession
+	err      atomic.Pointer[error]
+	calls    atomic.Int32
+}
+
+func (s *stubNowPlayingProvider) NowPlaying(context.Context) []syncpkg.NowPlayingResult {
+	s.calls.Add(1)
+	result := syncpkg.NowPlayingResult{Source: s.source, ServerID: s.serverID}
+	if err := s.err.Load(); err != nil {
+		result.Err = *err
+		return []syncpkg.NowPlayingResult{result}
+	}
+	result.Sessions = append([]models.NowPlayingSession(nil), s.sessions...)
+	return []syncpkg.NowPlayingResult{result}
+}
+
+func (s *stubNowPlayingProvider) fail(err error) {
+	s.err.Store(&err)
+}
+
+// stubGeolocationResolver places every address in one country.
+type stubGeolocationResolver struct {
+	calls atomic.Int32
+}
+
+func (s *stubGeolocationResolver) ResolveGeolocation(_ context.Context, ip string) (*models.Geolocation, error) {
+	s.calls.Add(1)
+	return &models.Geolocation{IPAddress: ip, Country: "Netherlands", Latitude: 52.37, Longitude: 4.89}, nil
+}
+
+func decodeNowPlaying(t *testing.T, handler *Handler) models.NowPlayingResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler.NowPlaying(w, httptest.NewRequest(http.MethodGet, "/api/v1/now-playing", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var resp struct {
+		Data models.NowPlayingResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp.Data
+}
+
+func TestNowPlaying_Unconfigured(t *testing.T) {
+	t.Parallel()
+
+	data := decodeNowPlaying(t, &Handler{})
+	if data.TotalCount != 0 || data.Sessions == nil || data.Sources == nil {
+		t.Errorf("got %+v, want empty sessions and sources", data)
+	}
+}
+
+func TestNowPlaying_MergesSourcesWithGeolocation(t *testing.T) {
+	t.Parallel()
+
+	jellyfin := &stubNowPlayingProvider{source: "jellyfin", serverID: "jf-1", sessions: []models.NowPlayingSession{
+		{Source: "jellyfin", ServerID: "jf-1", SessionID: "b", Username: "bob", IPAddress: "203.0.113.7"},
+	}}
+	plex := &stubNowPlayingProvider{source: "plex", serverID: "plex-1", sessions: []models.NowPlayingSession{
+		{Source: "plex", ServerID: "plex-1", SessionID: "2", Username: "alice", IPAddress: "203.0.113.7"},
+		{Source: "plex", ServerID: "plex-1", SessionID: "1", Username: "carol"},
+	}}
+	geo := &stubGeolocationResolver{}
+
+	handler := &Handler{}
+	handler.SetNowPlayingProviders(geo, plex, nil, jellyfin)
+	data := decodeNowPlaying(t, handler)
+
+	want := []string{"jellyfin/b", "plex/1", "plex/2"}
+	if data.TotalCount != len(want) || len(data.Sessions) != len(want) {
+		t.Fatalf("TotalCount = %d (%d sessions), want %d", data.TotalCount, len(data.Sessions), len(want))
+	}
+	for i, session := range data.Sessions {
+		if got := session.Source + "/" + session.SessionID; got != want[i] {
+			t.Errorf("Sessions[%d] = %s, want %s", i, got, want[i])
+		}
+		if session.Stale {
+			t.Errorf("Sessions[%d] is stale", i)
+		}
+		hasGeo := session.Geolocation != nil
+		if hasGeo != (session.IPAddress != "") {
+			t.Errorf("Sessions[%d] geolocation = %v for IP %q", i, session.Geolocation, session.IPAddress)
+		}
+	}
+	if got := geo.calls.Load(); got != 1 {
+		t.Errorf("geolocation lookups = %d, want 1 per distinct address", got)
+	}
+	if len(data.Sources) != 2 || data.Sources[0].Source != "jellyfin" || data.Sources[1].SessionCount != 2 {
+		t.Errorf("Sources = %+v", data.Sources)
+	}
+	for _, source := range data.Sources {
+		if source.Stale || source.LastPolledAt == nil {
+			t.Errorf("source %s: Stale = %v, LastPolledAt = %v", source.Source, source.Stale, source.LastPolledAt)
+		}
+	}
+}
+
+func TestNowPlaying_CachesSnapshot(t *testing.T) {
+	t.Parallel()
+
+	plex := &stubNowPlayingProvider{source: "plex", serverID: "plex-1"}
+	handler := &Handler{}
+	handler.SetNowPlayingProviders(nil, plex)
+	now := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	handler.nowPlaying.now = func() time.Time { return now }
+
+	decodeNowPlaying(t, handler)
+	decodeNowPlaying(t, handler)
+	if got := plex.calls.Load(); got != 1 {
+		t.Errorf("polls within TTL = %d, want 1", got)
+	}
+
+	now = now.Add(nowPlayingCacheTTL)
+	decodeNowPlaying(t, handler)
+	if got := plex.calls.Load(); got != 2 {
+		t.Errorf("polls after TTL = %d, want 2", got)
+	}
+}
+
+func TestNowPlaying_FailedSourceReportsStaleSessions(t *testing.T) {
+	t.Parallel()
+
+	plex := &stubNowPlayingProvider{source: "plex", serverID: "plex-1", sessions: []models.NowPlayingSession{
+		{Source: "plex", ServerID: "plex-1", SessionID: "1", Username: "alice"},
+	}}
+	emby := &stubNowPlayingProvider{source: "emby", serverID: "emby-1", sessions: []models.NowPlayingSession{
+		{Source: "emby", ServerID: "emby-1", SessionID: "e", Username: "dave"},
+	}}
+	handler := &Handler{}
+	handler.SetNowPlayingProviders(nil, plex, emby)
+	firstPoll := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	now := firstPoll
+	handler.nowPlaying.now = func() time.Time { return now }
+
+	decodeNowPlaying(t, handler)
+
+	now = now.Add(nowPlayingCacheTTL)
+	plex.fail(context.DeadlineExceeded)
+	data := decodeNowPlaying(t, handler)
+
+	if data.TotalCount != 2 || data.StaleCount != 1 {
+		t.Fatalf("TotalCount/StaleCount = %d/%d, want 2/1", data.TotalCount, data.StaleCount)
+	}
+	for _, session := range data.Sessions {
+		if session.Stale != (session.Source == "plex") {
+			t.Errorf("%s session Stale = %v", session.Source, session.Stale)
+		}
+	}
+	plexStatus := data.Sources[1]
+	if plexStatus.Source != "plex" || !plexStatus.Stale || plexStatus.Error == "" {
+		t.Errorf("plex status = %+v, want stale with error", plexStatus)
+	}
+	if plexStatus.LastPolledAt == nil || !plexStatus.LastPolledAt.Equal(firstPoll) {
+		t.Errorf("plex LastPolledAt = %v, want %v", plexStatus.LastPolledAt, firstPoll)
+	}
+}
+
+func TestNowPlaying_FailedSourceWithoutPriorPoll(t *testing.T) {
+	t.Parallel()
+
+	jellyfin := &stubNowPlayingProvider{source: "jellyfin", serverID: "jf-1"}
+	jellyfin.fail(errors.New("connection refused"))
+	handler := &Handler{}
+	handler.SetNowPlayingProviders(nil, jellyfin)
+
+	data := decodeNowPlaying(t, handler)
+	if data.TotalCount != 0 || len(data.Sources) != 1 {
+		t.Fatalf("TotalCount = %d, %d sources; want 0, 1", data.TotalCount, len(data.Sources))
+	}
+	if status := data.Sources[0]; !status.Stale || status.Error != "connection refused" || status.LastPolledAt != nil {
+		t.Errorf("status = %+v, want stale with error and no last poll", status)
+	}
+}
@@ -54,13 +54,13 @@ import (
 // Authentication: Required
 func (h *Handler) PATList(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 
@@ -73,7 +73,7 @@ func (h *Handler) PATList(w http.ResponseWriter, r *http.Request) {
 			Str("user_id", hctx.UserID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to list PATs")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list tokens", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list tokens", err)
 		return
 	}
 
@@ -107,37 +107,41 @@ func (h *Handler) PATList(w http.ResponseWriter, r *http.Request) {
 // Authentication: Required
 func (h *Handler) PATCreate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 
 	// Parse request body
 	var req models.CreatePATRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	// Validate request
 	if req.Name == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Token name is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Token name is required", nil)
 		return
 	}
 	if len(req.Scopes) == 0 {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "At least one scope is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "At least one scope is required", nil)
+		return
+	}
+	if verr := validateRequest(&req); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
 	// Check for admin scope - only admins can create admin tokens
 	for _, scope := range req.Scopes {
 		if scope == models.ScopeAdmin && !hctx.IsAdmin {
-			respondError(w, http.StatusForbidden, "FORBIDDEN", "Only admins can create admin tokens", nil)
+			respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Only admins can create admin tokens", nil)
 			return
 		}
 	}
@@ -152,7 +156,7 @@ func (h *Handler) PATCreate(w http.ResponseWriter, r *http.Request) {
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to create PAT")
 		metrics.RecordPATOperation("create", false)
-		respondError(w, http.StatusInternalServerError, "CREATE_ERROR", "Failed to create token", err)
+		respondError(w, r, http.StatusInternalServerError, "CREATE_ERROR", "Failed to create token", err)
 		return
 	}
 
@@ -195,19 +199,19 @@ func (h *Handler) PATCreate(w http.ResponseWriter, r *http.Request) {
 // Authorization: Users can only view their own tokens
 func (h *Handler) PATGet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 
 	tokenID := chi.URLParam(r, "id")
 	if tokenID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
 		return
 	}
 
@@ -217,19 +221,19 @@ func (h *Handler) PATGet(w http.ResponseWriter, r *http.Request) {
 	token, err := patManager.Get(r.Context(), tokenID, hctx.UserID)
 	if err != nil {
 		if err.Error() == "access denied" {
-			respondError(w, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
+			respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
 			return
 		}
 		log.Error().Err(err).
 			Str("token_id", tokenID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get PAT")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get token", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get token", err)
 		return
 	}
 
 	if token == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
 		return
 	}
 
@@ -257,19 +261,19 @@ func (h *Handler) PATGet(w http.ResponseWriter, r *http.Request) {
 // Authorization: Users can only revoke their own tokens
 func (h *Handler) PATRevoke(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 
 	tokenID := chi.URLParam(r, "id")
 	if tokenID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
 		return
 	}
 
@@ -289,19 +293,19 @@ func (h *Handler) PATRevoke(w http.ResponseWriter, r *http.Request) {
 	token, err := patManager.Get(r.Context(), tokenID, hctx.UserID)
 	if err != nil {
 		if err.Error() == "access denied" {
-			respondError(w, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
+			respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
 			return
 		}
 		log.Error().Err(err).
 			Str("token_id", tokenID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get PAT for revocation")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to verify token ownership", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to verify token ownership", err)
 		return
 	}
 
 	if token == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
 		return
 	}
 
@@ -312,7 +316,7 @@ func (h *Handler) PATRevoke(w http.ResponseWriter, r *http.Request) {
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to revoke PAT")
 		metrics.RecordPATOperation("revoke", false)
-		respondError(w, http.StatusInternalServerError, "REVOKE_ERROR", "Failed to revoke token", err)
+		respondError(w, r, http.StatusInternalServerError, "REVOKE_ERROR", "Failed to revoke token", err)
 		return
 	}
 
@@ -352,19 +356,19 @@ func (h *Handler) PATRevoke(w http.ResponseWriter, r *http.Request) {
 // Authorization: Users can only regenerate their own tokens
 func (h *Handler) PATRegenerate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 
 	tokenID := chi.URLParam(r, "id")
 	if tokenID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
 		return
 	}
 
@@ -374,11 +378,11 @@ func (h *Handler) PATRegenerate(w http.ResponseWriter, r *http.Request) {
 	token, plaintextToken, err := patManager.Regenerate(r.Context(), tokenID, hctx.UserID)
 	if err != nil {
 		if err.Error() == "access denied" {
-			respondError(w, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
+			respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
 			return
 		}
 		if err.Error() == "token not found" {
-			respondError(w, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
+			respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
 			return
 		}
 		log.Error().Err(err).
@@ -386,7 +390,7 @@ func (h *Handler) PATRegenerate(w http.ResponseWriter, r *http.Request) {
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to regenerate PAT")
 		metrics.RecordPATOperation("regenerate", false)
-		respondError(w, http.StatusInternalServerError, "REGENERATE_ERROR", "Failed to regenerate token", err)
+		respondError(w, r, http.StatusInternalServerError, "REGENERATE_ERROR", "Failed to regenerate token", err)
 		return
 	}
 
@@ -423,13 +427,13 @@ func (h *Handler) PATRegenerate(w http.ResponseWriter, r *http.Request) {
 // Authentication: Required
 func (h *Handler) PATStats(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 
@@ -442,7 +446,7 @@ func (h *Handler) PATStats(w http.ResponseWriter, r *http.Request) {
 			Str("user_id", hctx.UserID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get PAT stats")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get token statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get token statistics", err)
 		return
 	}
 
@@ -473,19 +477,19 @@ func (h *Handler) PATStats(w http.ResponseWriter, r *http.Request) {
 // Authorization: Users can only view logs for their own tokens
 func (h *Handler) PATUsageLogs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	hctx := GetHandlerContext(r)
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return
 	}
 
 	tokenID := chi.URLParam(r, "id")
 	if tokenID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Token ID is required", nil)
 		return
 	}
 
@@ -494,19 +498,19 @@ func (h *Handler) PATUsageLogs(w http.ResponseWriter, r *http.Request) {
 	token, err := patManager.Get(r.Context(), tokenID, hctx.UserID)
 	if err != nil {
 		if err.Error() == "access denied" {
-			respondError(w, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
+			respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Access denied", nil)
 			return
 		}
 		log.Error().Err(err).
 			Str("token_id", tokenID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to verify token ownership")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to verify token ownership", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to verify token ownership", err)
 		return
 	}
 
 	if token == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Token not found", nil)
 		return
 	}
 
@@ -526,7 +530,7 @@ func (h *Handler) PATUsageLogs(w http.ResponseWriter, r *http.Request) {
 			Str("token_id", tokenID).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get PAT usage logs")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get usage logs", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get usage logs", err)
 		return
 	}
 
@@ -30,7 +30,7 @@ func (h *Handler) PlexBandwidthStatistics(w http.ResponseWriter, r *http.Request
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
@@ -45,7 +45,7 @@ func (h *Handler) PlexBandwidthStatistics(w http.ResponseWriter, r *http.Request
 	// Fetch bandwidth statistics
 	stats, err := h.sync.GetPlexBandwidthStatistics(r.Context(), timespan)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch bandwidth statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch bandwidth statistics", err)
 		return
 	}
 
@@ -73,14 +73,14 @@ func (h *Handler) PlexLibrarySections(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch library sections
 	sections, err := h.sync.GetPlexLibrarySections(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch library sections", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch library sections", err)
 		return
 	}
 
@@ -116,14 +116,14 @@ func (h *Handler) PlexLibrarySectionContent(w http.ResponseWriter, r *http.Reque
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Extract section key from URL path
 	sectionKey := r.PathValue("key")
 	if sectionKey == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "section key is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "section key is required", nil)
 		return
 	}
 
@@ -143,7 +143,7 @@ func (h *Handler) PlexLibrarySectionContent(w http.ResponseWriter, r *http.Reque
 	// Fetch section content
 	content, err := h.sync.GetPlexLibrarySectionContent(r.Context(), sectionKey, startOffset, size)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch section content", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch section content", err)
 		return
 	}
 
@@ -178,14 +178,14 @@ func (h *Handler) PlexLibrarySectionRecentlyAdded(w http.ResponseWriter, r *http
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Extract section key from URL path
 	sectionKey := r.PathValue("key")
 	if sectionKey == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "section key is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "section key is required", nil)
 		return
 	}
 
@@ -200,7 +200,7 @@ func (h *Handler) PlexLibrarySectionRecentlyAdded(w http.ResponseWriter, r *http
 	// Fetch recently added content
 	content, err := h.sync.GetPlexLibrarySectionRecentlyAdded(r.Context(), sectionKey, size)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch recently added content", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch recently added content", err)
 		return
 	}
 
@@ -228,14 +228,14 @@ func (h *Handler) PlexActivities(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch activities
 	activities, err := h.sync.GetPlexActivities(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch activities", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch activities", err)
 		return
 	}
 
@@ -268,14 +268,14 @@ func (h *Handler) PlexSessions(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch active sessions
 	sessions, err := h.sync.GetPlexSessions(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch active sessions", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch active sessions", err)
 		return
 	}
 
@@ -308,14 +308,14 @@ func (h *Handler) PlexIdentity(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch server identity
 	identity, err := h.sync.GetPlexIdentity(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch server identity", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch server identity", err)
 		return
 	}
 
@@ -352,21 +352,21 @@ func (h *Handler) PlexMetadata(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Extract rating key from URL path
 	ratingKey := r.PathValue("ratingKey")
 	if ratingKey == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "rating key is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "rating key is required", nil)
 		return
 	}
 
 	// Fetch metadata
 	metadata, err := h.sync.GetPlexMetadata(r.Context(), ratingKey)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch metadata", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch metadata", err)
 		return
 	}
 
@@ -399,14 +399,14 @@ func (h *Handler) PlexDevices(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch devices
 	devices, err := h.sync.GetPlexDevices(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch devices", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch devices", err)
 		return
 	}
 
@@ -439,14 +439,14 @@ func (h *Handler) PlexAccounts(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch accounts
 	accounts, err := h.sync.GetPlexAccounts(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch accounts", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch accounts", err)
 		return
 	}
 
@@ -479,14 +479,14 @@ func (h *Handler) PlexOnDeck(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch on-deck content
 	onDeck, err := h.sync.GetPlexOnDeck(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch on-deck content", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch on-deck content", err)
 		return
 	}
 
@@ -519,14 +519,14 @@ func (h *Handler) PlexPlaylists(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch playlists
 	playlists, err := h.sync.GetPlexPlaylists(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch playlists", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch playlists", err)
 		return
 	}
 
@@ -567,21 +567,21 @@ func (h *Handler) PlexSearch(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Extract section key from URL path
 	sectionKey := r.PathValue("key")
 	if sectionKey == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "section key is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "section key is required", nil)
 		return
 	}
 
 	// Get query parameter
 	query := r.URL.Query().Get("query")
 	if query == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "query parameter is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "query parameter is required", nil)
 		return
 	}
 
@@ -596,7 +596,7 @@ func (h *Handler) PlexSearch(w http.ResponseWriter, r *http.Request) {
 	// Perform search
 	results, err := h.sync.GetPlexSearch(r.Context(), sectionKey, query, mediaType)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to search", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to search", err)
 		return
 	}
 
@@ -629,14 +629,14 @@ func (h *Handler) PlexTranscodeSessions(w http.ResponseWriter, r *http.Request)
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch transcode sessions (same as GetPlexSessions but focused on transcode data)
 	sessions, err := h.sync.GetPlexTranscodeSessions(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch transcode sessions", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch transcode sessions", err)
 		return
 	}
 
@@ -673,21 +673,21 @@ func (h *Handler) PlexCancelTranscode(w http.ResponseWriter, r *http.Request) {
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Extract session key from URL path
 	sessionKey := r.PathValue("sessionKey")
 	if sessionKey == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "session key is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "session key is required", nil)
 		return
 	}
 
 	// Cancel transcode
 	err := h.sync.CancelPlexTranscode(r.Context(), sessionKey)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to cancel transcode", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to cancel transcode", err)
 		return
 	}
 
@@ -726,14 +726,14 @@ func (h *Handler) PlexServerCapabilities(w http.ResponseWriter, r *http.Request)
 
 	// Check if Plex is enabled
 	if h.sync == nil || !h.sync.IsPlexEnabled() {
-		respondError(w, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "PLEX_DISABLED", "Plex integration is not enabled", nil)
 		return
 	}
 
 	// Fetch server capabilities
 	capabilities, err := h.sync.GetPlexServerCapabilities(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch server capabilities", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_ERROR", "Failed to fetch server capabilities", err)
 		return
 	}
 
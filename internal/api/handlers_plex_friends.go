@@ -57,13 +57,13 @@ var validate = validator.New()
 // Returns true if the request should continue, false if an error response was sent.
 // SECURITY: All Plex library management operations require admin role to prevent
 // unauthorized users from modifying library access, sharing settings, or managed users.
-func requirePlexAdmin(w http.ResponseWriter, hctx *HandlerContext) bool {
+func requirePlexAdmin(w http.ResponseWriter, r *http.Request, hctx *HandlerContext) bool {
 	if !hctx.IsAuthenticated() {
-		respondError(w, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
+		respondError(w, r, http.StatusUnauthorized, "AUTH_REQUIRED", "Authentication required", nil)
 		return false
 	}
 	if !hctx.IsAdmin {
-		respondError(w, http.StatusForbidden, "ADMIN_REQUIRED", "Admin role required for Plex library management", nil)
+		respondError(w, r, http.StatusForbidden, "ADMIN_REQUIRED", "Admin role required for Plex library management", nil)
 		return false
 	}
 	return true
@@ -98,7 +98,7 @@ func (h *Handler) getPlexTVClient() (*sync.PlexTVClient, error) {
 // Authorization: Admin role required
 func (h *Handler) PlexFriendsList(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
@@ -106,7 +106,7 @@ func (h *Handler) PlexFriendsList(w http.ResponseWriter, r *http.Request) {
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -115,7 +115,7 @@ func (h *Handler) PlexFriendsList(w http.ResponseWriter, r *http.Request) {
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to list Plex friends")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to list friends", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to list friends", err)
 		return
 	}
 
@@ -166,24 +166,24 @@ func (h *Handler) PlexFriendsList(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexFriendsInvite(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	var req models.PlexInviteFriendRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	if err := validate.Struct(req); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -199,7 +199,7 @@ func (h *Handler) PlexFriendsInvite(w http.ResponseWriter, r *http.Request) {
 			Str("request_id", hctx.RequestID).
 			Str("email", req.Email).
 			Msg("Failed to invite friend")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to send invitation", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to send invitation", err)
 		return
 	}
 
@@ -226,20 +226,20 @@ func (h *Handler) PlexFriendsInvite(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexFriendsRemove(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	friendIDStr := chi.URLParam(r, "id")
 	friendID, err := strconv.ParseInt(friendIDStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid friend ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid friend ID", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -248,7 +248,7 @@ func (h *Handler) PlexFriendsRemove(w http.ResponseWriter, r *http.Request) {
 			Str("request_id", hctx.RequestID).
 			Int64("friend_id", friendID).
 			Msg("Failed to remove friend")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to remove friend", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to remove friend", err)
 		return
 	}
 
@@ -277,7 +277,7 @@ func (h *Handler) PlexFriendsRemove(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexSharingList(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
@@ -285,7 +285,7 @@ func (h *Handler) PlexSharingList(w http.ResponseWriter, r *http.Request) {
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -294,7 +294,7 @@ func (h *Handler) PlexSharingList(w http.ResponseWriter, r *http.Request) {
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to list shared servers")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to list shared servers", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to list shared servers", err)
 		return
 	}
 
@@ -344,24 +344,24 @@ func (h *Handler) PlexSharingList(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexSharingCreate(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	var req models.PlexShareLibrariesRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	if err := validate.Struct(req); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -382,7 +382,7 @@ func (h *Handler) PlexSharingCreate(w http.ResponseWriter, r *http.Request) {
 			Str("email", req.Email).
 			Ints("sections", req.LibrarySectionIDs).
 			Msg("Failed to share libraries")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to share libraries", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to share libraries", err)
 		return
 	}
 
@@ -412,31 +412,31 @@ func (h *Handler) PlexSharingCreate(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexSharingUpdate(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	sharedServerIDStr := chi.URLParam(r, "id")
 	sharedServerID, err := strconv.ParseInt(sharedServerIDStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid shared server ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid shared server ID", err)
 		return
 	}
 
 	var req models.PlexUpdateSharingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	if err := validate.Struct(req); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -445,7 +445,7 @@ func (h *Handler) PlexSharingUpdate(w http.ResponseWriter, r *http.Request) {
 			Str("request_id", hctx.RequestID).
 			Int64("shared_server_id", sharedServerID).
 			Msg("Failed to update sharing")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to update sharing", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to update sharing", err)
 		return
 	}
 
@@ -472,20 +472,20 @@ func (h *Handler) PlexSharingUpdate(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexSharingRevoke(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	sharedServerIDStr := chi.URLParam(r, "id")
 	sharedServerID, err := strconv.ParseInt(sharedServerIDStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid shared server ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid shared server ID", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -494,7 +494,7 @@ func (h *Handler) PlexSharingRevoke(w http.ResponseWriter, r *http.Request) {
 			Str("request_id", hctx.RequestID).
 			Int64("shared_server_id", sharedServerID).
 			Msg("Failed to revoke sharing")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to revoke sharing", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to revoke sharing", err)
 		return
 	}
 
@@ -523,7 +523,7 @@ func (h *Handler) PlexSharingRevoke(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexManagedUsersList(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
@@ -531,7 +531,7 @@ func (h *Handler) PlexManagedUsersList(w http.ResponseWriter, r *http.Request) {
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -540,7 +540,7 @@ func (h *Handler) PlexManagedUsersList(w http.ResponseWriter, r *http.Request) {
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to list managed users")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to list managed users", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to list managed users", err)
 		return
 	}
 
@@ -586,24 +586,24 @@ func (h *Handler) PlexManagedUsersList(w http.ResponseWriter, r *http.Request) {
 // Authorization: Admin role required
 func (h *Handler) PlexManagedUsersCreate(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	var req models.PlexCreateManagedUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	if err := validate.Struct(req); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -618,7 +618,7 @@ func (h *Handler) PlexManagedUsersCreate(w http.ResponseWriter, r *http.Request)
 			Str("request_id", hctx.RequestID).
 			Str("name", req.Name).
 			Msg("Failed to create managed user")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to create managed user", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to create managed user", err)
 		return
 	}
 
@@ -658,20 +658,20 @@ func (h *Handler) PlexManagedUsersCreate(w http.ResponseWriter, r *http.Request)
 // Authorization: Admin role required
 func (h *Handler) PlexManagedUsersDelete(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	userIDStr := chi.URLParam(r, "id")
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -680,7 +680,7 @@ func (h *Handler) PlexManagedUsersDelete(w http.ResponseWriter, r *http.Request)
 			Str("request_id", hctx.RequestID).
 			Int64("user_id", userID).
 			Msg("Failed to delete managed user")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to delete managed user", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to delete managed user", err)
 		return
 	}
 
@@ -709,31 +709,31 @@ func (h *Handler) PlexManagedUsersDelete(w http.ResponseWriter, r *http.Request)
 // Authorization: Admin role required
 func (h *Handler) PlexManagedUsersUpdate(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
 	userIDStr := chi.URLParam(r, "id")
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
 		return
 	}
 
 	var req models.PlexUpdateManagedUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 		return
 	}
 
 	if err := validate.Struct(req); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request", err)
 		return
 	}
 
 	client, err := h.getPlexTVClient()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", err.Error(), err)
 		return
 	}
 
@@ -743,7 +743,7 @@ func (h *Handler) PlexManagedUsersUpdate(w http.ResponseWriter, r *http.Request)
 			Int64("user_id", userID).
 			Str("profile", req.RestrictionProfile).
 			Msg("Failed to update managed user restrictions")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to update restrictions", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to update restrictions", err)
 		return
 	}
 
@@ -773,7 +773,7 @@ func (h *Handler) PlexManagedUsersUpdate(w http.ResponseWriter, r *http.Request)
 // Authorization: Admin role required
 func (h *Handler) PlexLibrariesList(w http.ResponseWriter, r *http.Request) {
 	hctx := GetHandlerContext(r)
-	if !requirePlexAdmin(w, hctx) {
+	if !requirePlexAdmin(w, r, hctx) {
 		return
 	}
 
@@ -781,7 +781,7 @@ func (h *Handler) PlexLibrariesList(w http.ResponseWriter, r *http.Request) {
 
 	// Use the existing Plex sync client to get library sections
 	if h.sync == nil {
-		respondError(w, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", "Plex sync not configured", nil)
+		respondError(w, r, http.StatusBadRequest, "PLEX_NOT_CONFIGURED", "Plex sync not configured", nil)
 		return
 	}
 
@@ -791,7 +791,7 @@ func (h *Handler) PlexLibrariesList(w http.ResponseWriter, r *http.Request) {
 		log.Error().Err(err).
 			Str("request_id", hctx.RequestID).
 			Msg("Failed to get library sections")
-		respondError(w, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to get libraries", err)
+		respondError(w, r, http.StatusInternalServerError, "PLEX_API_ERROR", "Failed to get libraries", err)
 		return
 	}
 
@@ -276,7 +276,7 @@ func TestRequirePlexAdmin(t *testing.T) {
 			w := httptest.NewRecorder()
 			hctx := GetHandlerContext(req)
 
-			result := requirePlexAdmin(w, hctx)
+			result := requirePlexAdmin(w, req, hctx)
 
 			if result != tt.expectContinue {
 				t.Errorf("requirePlexAdmin() = %v, want %v", result, tt.expectContinue)
@@ -119,21 +119,21 @@ func (h *Handler) fetchPlexUserInfo(ctx context.Context, accessToken string) (*p
 func (h *Handler) PlexOAuthStart(w http.ResponseWriter, r *http.Request) {
 	// Check if OAuth client is configured
 	if h.plexOAuthClient == nil {
-		respondError(w, http.StatusServiceUnavailable, "OAUTH_NOT_CONFIGURED", "Plex OAuth is not configured. Set PLEX_OAUTH_CLIENT_ID and PLEX_OAUTH_REDIRECT_URI environment variables.", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "OAUTH_NOT_CONFIGURED", "Plex OAuth is not configured. Set PLEX_OAUTH_CLIENT_ID and PLEX_OAUTH_REDIRECT_URI environment variables.", nil)
 		return
 	}
 
 	// Generate PKCE challenge
 	pkce, err := h.plexOAuthClient.GeneratePKCE()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "OAUTH_ERROR", "Failed to generate PKCE challenge", err)
+		respondError(w, r, http.StatusInternalServerError, "OAUTH_ERROR", "Failed to generate PKCE challenge", err)
 		return
 	}
 
 	// Generate random state token for CSRF protection
 	stateBytes := make([]byte, 32)
 	if _, err := rand.Read(stateBytes); err != nil {
-		respondError(w, http.StatusInternalServerError, "OAUTH_ERROR", "Failed to generate state token", err)
+		respondError(w, r, http.StatusInternalServerError, "OAUTH_ERROR", "Failed to generate state token", err)
 		return
 	}
 	state := base64.RawURLEncoding.EncodeToString(stateBytes)
@@ -145,7 +145,7 @@ func (h *Handler) PlexOAuthStart(w http.ResponseWriter, r *http.Request) {
 	}
 	oauthDataJSON, err := json.Marshal(oauthData)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "OAUTH_ERROR", "Failed to encode OAuth state", err)
+		respondError(w, r, http.StatusInternalServerError, "OAUTH_ERROR", "Failed to encode OAuth state", err)
 		return
 	}
 
@@ -222,53 +222,53 @@ func (h *Handler) PlexOAuthCallback(w http.ResponseWriter, r *http.Request) {
 	state := r.URL.Query().Get("state")
 
 	if code == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Missing authorization code", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Missing authorization code", nil)
 		return
 	}
 
 	// Retrieve OAuth state from cookie
 	cookie, err := r.Cookie("plex_oauth_state")
 	if err != nil {
-		respondError(w, http.StatusUnauthorized, "INVALID_STATE", "OAuth state cookie not found", err)
+		respondError(w, r, http.StatusUnauthorized, "INVALID_STATE", "OAuth state cookie not found", err)
 		return
 	}
 
 	// Decode cookie data
 	oauthDataJSON, err := base64.StdEncoding.DecodeString(cookie.Value)
 	if err != nil {
-		respondError(w, http.StatusUnauthorized, "INVALID_STATE", "Failed to decode OAuth state", err)
+		respondError(w, r, http.StatusUnauthorized, "INVALID_STATE", "Failed to decode OAuth state", err)
 		return
 	}
 
 	var oauthData map[string]string
 	if err := json.Unmarshal(oauthDataJSON, &oauthData); err != nil {
-		respondError(w, http.StatusUnauthorized, "INVALID_STATE", "Failed to parse OAuth state", err)
+		respondError(w, r, http.StatusUnauthorized, "INVALID_STATE", "Failed to parse OAuth state", err)
 		return
 	}
 
 	// Validate state parameter (CSRF protection)
 	if state != oauthData["state"] {
-		respondError(w, http.StatusUnauthorized, "INVALID_STATE", "State parameter mismatch", nil)
+		respondError(w, r, http.StatusUnauthorized, "INVALID_STATE", "State parameter mismatch", nil)
 		return
 	}
 
 	// Check if OAuth client is configured
 	if h.plexOAuthClient == nil {
-		respondError(w, http.StatusServiceUnavailable, "OAUTH_NOT_CONFIGURED", "Plex OAuth is not configured", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "OAUTH_NOT_CONFIGURED", "Plex OAuth is not configured", nil)
 		return
 	}
 
 	// Exchange authorization code for access token
 	plexToken, err := h.plexOAuthClient.ExchangeCodeForToken(r.Context(), code, oauthData["code_verifier"])
 	if err != nil {
-		respondError(w, http.StatusUnauthorized, "TOKEN_EXCHANGE_FAILED", fmt.Sprintf("Failed to exchange code: %v", err), err)
+		respondError(w, r, http.StatusUnauthorized, "TOKEN_EXCHANGE_FAILED", fmt.Sprintf("Failed to exchange code: %v", err), err)
 		return
 	}
 
 	// Fetch Plex user information with access token
 	userInfo, err := h.fetchPlexUserInfo(r.Context(), plexToken.AccessToken)
 	if err != nil {
-		respondError(w, http.StatusUnauthorized, "USER_INFO_FAILED", fmt.Sprintf("Failed to fetch user info: %v", err), err)
+		respondError(w, r, http.StatusUnauthorized, "USER_INFO_FAILED", fmt.Sprintf("Failed to fetch user info: %v", err), err)
 		return
 	}
 
@@ -281,7 +281,7 @@ func (h *Handler) PlexOAuthCallback(w http.ResponseWriter, r *http.Request) {
 	// Generate JWT session token for our application
 	jwtToken, err := h.jwtManager.GenerateToken(userInfo.Username, role)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "TOKEN_GENERATION_FAILED", "Failed to generate session token", err)
+		respondError(w, r, http.StatusInternalServerError, "TOKEN_GENERATION_FAILED", "Failed to generate session token", err)
 		return
 	}
 
@@ -383,25 +383,25 @@ func (h *Handler) PlexOAuthRefresh(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid JSON body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid JSON body", err)
 		return
 	}
 
 	if requestBody.RefreshToken == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Missing refresh_token", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Missing refresh_token", nil)
 		return
 	}
 
 	// Check if OAuth client is configured
 	if h.plexOAuthClient == nil {
-		respondError(w, http.StatusServiceUnavailable, "OAUTH_NOT_CONFIGURED", "Plex OAuth is not configured", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "OAUTH_NOT_CONFIGURED", "Plex OAuth is not configured", nil)
 		return
 	}
 
 	// Refresh access token
 	plexToken, err := h.plexOAuthClient.RefreshToken(r.Context(), requestBody.RefreshToken)
 	if err != nil {
-		respondError(w, http.StatusUnauthorized, "REFRESH_FAILED", fmt.Sprintf("Failed to refresh token: %v", err), err)
+		respondError(w, r, http.StatusUnauthorized, "REFRESH_FAILED", fmt.Sprintf("Failed to refresh token: %v", err), err)
 		return
 	}
 
@@ -465,12 +465,12 @@ func (h *Handler) PlexOAuthRevoke(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid JSON body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid JSON body", err)
 		return
 	}
 
 	if requestBody.AccessToken == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Missing access_token", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Missing access_token", nil)
 		return
 	}
 
@@ -50,14 +50,14 @@ func (h *Handler) PlexWebhook(w http.ResponseWriter, r *http.Request) {
 
 	// Check if webhooks are enabled
 	if !h.config.Plex.WebhooksEnabled {
-		respondError(w, http.StatusNotFound, "WEBHOOKS_DISABLED", "Plex webhooks are not enabled", nil)
+		respondError(w, r, http.StatusNotFound, "WEBHOOKS_DISABLED", "Plex webhooks are not enabled", nil)
 		return
 	}
 
 	// Read request body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Failed to read request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Failed to read request body", err)
 		return
 	}
 	defer r.Body.Close()
@@ -66,12 +66,12 @@ func (h *Handler) PlexWebhook(w http.ResponseWriter, r *http.Request) {
 	if h.config.Plex.WebhookSecret != "" {
 		signature := r.Header.Get("X-Plex-Signature")
 		if signature == "" {
-			respondError(w, http.StatusUnauthorized, "MISSING_SIGNATURE", "X-Plex-Signature header required", nil)
+			respondError(w, r, http.StatusUnauthorized, "MISSING_SIGNATURE", "X-Plex-Signature header required", nil)
 			return
 		}
 
 		if !h.verifyWebhookSignature(body, signature, h.config.Plex.WebhookSecret) {
-			respondError(w, http.StatusUnauthorized, "INVALID_SIGNATURE", "Webhook signature verification failed", nil)
+			respondError(w, r, http.StatusUnauthorized, "INVALID_SIGNATURE", "Webhook signature verification failed", nil)
 			return
 		}
 	}
@@ -79,7 +79,7 @@ func (h *Handler) PlexWebhook(w http.ResponseWriter, r *http.Request) {
 	// Parse webhook payload
 	var webhook models.PlexWebhook
 	if err := json.Unmarshal(body, &webhook); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_PAYLOAD", "Failed to parse webhook JSON", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_PAYLOAD", "Failed to parse webhook JSON", err)
 		return
 	}
 
@@ -0,0 +1,80 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// =============================================================================
+// Privacy API Handlers
+// =============================================================================
+
+// PrivacyAnonymizeIPs handles POST /api/v1/admin/privacy/anonymize-ips
+// Rewrites client IP addresses stored before PRIVACY_IP_MODE was set to
+// truncated or hashed, in one transaction: playback history,
+// geolocations, detection alerts and the audit tables. Values that are
+// already anonymized are left unchanged, so the migration can be run
+// again after a restart or an interrupted run. Analytics caches are
+// cleared afterwards.
+//
+// @Summary Anonymize stored IP addresses
+// @Description Applies PRIVACY_IP_MODE to IP addresses stored before it was enabled.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=database.IPAnonymizationResult} "Rows rewritten per column"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 409 {object} models.APIResponse "PRIVACY_IP_MODE is full"
+// @Failure 500 {object} models.APIResponse "Migration failed"
+// @Router /admin/privacy/anonymize-ips [post]
+func (h *Handler) PrivacyAnonymizeIPs(w http.ResponseWriter, r *http.Request) {
+	result, err := h.db.AnonymizeStoredIPs(r.Context())
+	if errors.Is(err, database.ErrIPModeFull) {
+		respondError(w, r, http.StatusConflict, ErrCodeInvalidState, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to anonymize stored IP addresses")
+		respondError(w, r, http.StatusInternalServerError, ErrCodeDatabaseError, "Failed to anonymize stored IP addresses", err)
+		return
+	}
+
+	h.ClearCache()
+	h.bumpSyncGeneration()
+
+	rows := make(map[string]int64, len(result.Tables))
+	for _, t := range result.Tables {
+		rows[t.Table+"."+t.Column] = t.Rows
+	}
+	description := fmt.Sprintf("Anonymized stored IP addresses (%s)", result.Mode)
+	hctx := GetHandlerContext(r)
+	logging.Warn().
+		Str("admin", hctx.Username).
+		Interface("rows", rows).
+		Msg(description)
+	if h.auditLogger != nil {
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, "privacy.anonymize_ips", description,
+			map[string]interface{}{"mode": result.Mode, "rows": rows})
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     result,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
@@ -0,0 +1,67 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/privacy"
+)
+
+// Not parallel: the test changes the process-wide IP anonymizer.
+func TestPrivacyAnonymizeIPs_WithDB(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	insertTestPlaybacks(t, db, 10)
+	handler := setupTestHandlerWithDB(t, db)
+
+	anonymize := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/privacy/anonymize-ips", http.NoBody)
+		w := httptest.NewRecorder()
+		handler.PrivacyAnonymizeIPs(w, req)
+		return w
+	}
+
+	if w := anonymize(); w.Code != http.StatusConflict {
+		t.Errorf("full mode status = %d, want 409 (body: %s)", w.Code, w.Body.String())
+	}
+
+	anonymizer, err := privacy.NewIPAnonymizer(privacy.IPModeTruncated, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privacy.SetIPAnonymizer(anonymizer)
+	t.Cleanup(func() { privacy.SetIPAnonymizer(nil) })
+
+	w := anonymize()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data database.IPAnonymizationResult `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Data.Mode != privacy.IPModeTruncated {
+		t.Errorf("mode = %q, want truncated", resp.Data.Mode)
+	}
+	var playbackRows int64
+	for _, table := range resp.Data.Tables {
+		if table.Table == "playback_events" && table.Column == "ip_address" {
+			playbackRows = table.Rows
+		}
+	}
+	if playbackRows != 10 {
+		t.Errorf("playback_events.ip_address rows = %d, want 10 (tables: %+v)", playbackRows, resp.Data.Tables)
+	}
+}
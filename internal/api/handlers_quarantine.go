@@ -0,0 +1,263 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/validation"
+)
+
+// =============================================================================
+// Quarantined Events API Handlers (timestamp sanity validation)
+// =============================================================================
+
+// QuarantineListResponse is the response for listing quarantined events.
+type QuarantineListResponse struct {
+	Entries    []*models.QuarantinedEvent `json:"entries"`
+	TotalCount int64                      `json:"total_count"`
+	Limit      int                        `json:"limit"`
+	Offset     int                        `json:"offset"`
+}
+
+// QuarantineReprocessRequest is the request body for reprocessing a quarantined event.
+type QuarantineReprocessRequest struct {
+	// StartedAt is the corrected start time (RFC3339). Required.
+	StartedAt time.Time `json:"started_at"`
+
+	// StoppedAt is the corrected stop time. When omitted, the original
+	// stopped_at is shifted by the same amount as started_at.
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+
+	ResolvedBy string `json:"resolved_by"`
+}
+
+// QuarantineReprocessResponse is the response after reprocessing an event.
+type QuarantineReprocessResponse struct {
+	Success       bool                     `json:"success"`
+	Message       string                   `json:"message"`
+	EventID       uuid.UUID                `json:"event_id"`
+	OriginalEntry *models.QuarantinedEvent `json:"original_entry,omitempty"`
+}
+
+// QuarantineList handles GET /api/v1/admin/quarantine
+// Lists quarantined events, newest first, with optional filtering.
+//
+// Query parameters:
+//   - status: Filter by status (pending, reprocessed)
+//   - source: Filter by source (tautulli, plex, jellyfin, emby)
+//   - reason: Filter by reason (started_at_too_old, started_at_in_future)
+//   - limit: Number of results (default 100, max 1000)
+//   - offset: Pagination offset
+func (h *Handler) QuarantineList(w http.ResponseWriter, r *http.Request) {
+	queryStart := time.Now()
+
+	filter := database.QuarantineFilter{
+		Status: r.URL.Query().Get("status"),
+		Source: r.URL.Query().Get("source"),
+		Reason: r.URL.Query().Get("reason"),
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 1000 {
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid limit (1-1000)", err)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid offset", err)
+			return
+		}
+		filter.Offset = offset
+	}
+
+	entries, totalCount, err := h.db.ListQuarantinedEvents(r.Context(), filter)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to list quarantined events")
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list quarantined events", err)
+		return
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: QuarantineListResponse{
+			Entries:    entries,
+			TotalCount: totalCount,
+			Limit:      limit,
+			Offset:     filter.Offset,
+		},
+		Metadata: paginatedMetadata(queryStart, newOffsetPagination(limit, filter.Offset, totalCount)),
+	})
+}
+
+// QuarantineGet handles GET /api/v1/admin/quarantine/{id}
+// Returns a single quarantined event including its raw payload.
+func (h *Handler) QuarantineGet(w http.ResponseWriter, r *http.Request) {
+	queryStart := time.Now()
+
+	id, ok := parseQuarantineID(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := h.db.GetQuarantinedEvent(r.Context(), id)
+	if err != nil {
+		respondQuarantineLookupError(w, r, id, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     entry,
+		Metadata: dedupeMetadata(queryStart),
+	})
+}
+
+// QuarantineReprocess handles POST /api/v1/admin/quarantine/{id}/reprocess
+// Inserts a quarantined event with a corrected started_at and marks the
+// entry as reprocessed. The corrected time must fall inside the configured
+// timestamp window.
+//
+// Request body:
+//   - started_at: Corrected start time (RFC3339, required)
+//   - stopped_at: Corrected stop time (optional; default shifts the original)
+//   - resolved_by: Who fixed the event (default "api_user")
+func (h *Handler) QuarantineReprocess(w http.ResponseWriter, r *http.Request) {
+	queryStart := time.Now()
+	ctx := r.Context()
+
+	id, ok := parseQuarantineID(w, r)
+	if !ok {
+		return
+	}
+
+	var req QuarantineReprocessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request body (started_at must be RFC3339)", err)
+		return
+	}
+	if req.StartedAt.IsZero() {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "started_at is required", nil)
+		return
+	}
+	if req.ResolvedBy == "" {
+		req.ResolvedBy = "api_user"
+	}
+
+	window, err := validation.NewTimestampWindow(h.config.Sync.TimestampMinDate, h.config.Sync.TimestampMaxFuture)
+	if err != nil {
+		window = validation.DefaultTimestampWindow()
+	}
+	if reason := window.Check(req.StartedAt, time.Now()); reason != "" {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Corrected started_at is still outside the accepted window ("+reason+")", nil)
+		return
+	}
+
+	entry, err := h.db.GetQuarantinedEvent(ctx, id)
+	if err != nil {
+		respondQuarantineLookupError(w, r, id, err)
+		return
+	}
+	if entry.Status == models.QuarantineStatusReprocessed {
+		respondError(w, r, http.StatusConflict, "CONFLICT", "Event has already been reprocessed", nil)
+		return
+	}
+
+	var event models.PlaybackEvent
+	if err := json.Unmarshal(entry.RawPayload, &event); err != nil {
+		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to unmarshal quarantined payload")
+		respondError(w, r, http.StatusInternalServerError, "PARSE_ERROR", "Failed to parse stored event data", err)
+		return
+	}
+
+	// Apply the correction; keep the original duration unless stopped_at is given
+	if req.StoppedAt != nil {
+		event.StoppedAt = req.StoppedAt
+	} else if event.StoppedAt != nil {
+		shifted := event.StoppedAt.Add(req.StartedAt.Sub(event.StartedAt))
+		event.StoppedAt = &shifted
+	}
+	event.StartedAt = req.StartedAt
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	// The original correlation key embeds the corrupt start time, so replace
+	// it with one tied to the quarantine entry
+	correlationKey := "quarantine:" + id.String()
+	event.CorrelationKey = &correlationKey
+
+	if err := h.db.InsertPlaybackEventWithContext(r.Context(), &event); err != nil {
+		logging.Error().Err(err).Str("id", id.String()).Msg("Failed to insert reprocessed event")
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to reprocess event", err)
+		return
+	}
+
+	if err := h.db.MarkQuarantinedEventReprocessed(ctx, id, req.StartedAt, req.ResolvedBy); err != nil {
+		logging.Warn().Err(err).Str("id", id.String()).Msg("Failed to update quarantine status after reprocess")
+		// Don't return error - event was inserted successfully
+	}
+
+	updatedEntry, err := h.db.GetQuarantinedEvent(ctx, id)
+	if err != nil {
+		logging.Warn().Err(err).Str("id", id.String()).Msg("Failed to get updated quarantine entry")
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: QuarantineReprocessResponse{
+			Success:       true,
+			Message:       "Event successfully reprocessed",
+			EventID:       event.ID,
+			OriginalEntry: updatedEntry,
+		},
+		Metadata: dedupeMetadata(queryStart),
+	})
+}
+
+// parseQuarantineID parses the {id} path value, writing a 400 response on failure.
+func parseQuarantineID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idStr := r.PathValue("id")
+	if idStr == "" {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Missing entry ID", nil)
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid entry ID format", err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondQuarantineLookupError maps a GetQuarantinedEvent error to a response.
+func respondQuarantineLookupError(w http.ResponseWriter, r *http.Request, id uuid.UUID, err error) {
+	if errors.Is(err, database.ErrQuarantinedEventNotFound) {
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Quarantined event not found", err)
+		return
+	}
+	logging.Error().Err(err).Str("id", id.String()).Msg("Failed to get quarantined event")
+	respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get quarantined event", err)
+}
@@ -0,0 +1,83 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+// The cases below are rejected before the database is touched.
+
+func TestQuarantineGet_InvalidID(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	for _, id := range []string{"", "not-a-uuid"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/quarantine/"+id, nil)
+		req.SetPathValue("id", id)
+		w := httptest.NewRecorder()
+
+		h.QuarantineGet(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("id %q: status = %d, want 400", id, w.Code)
+		}
+	}
+}
+
+func TestQuarantineList_InvalidPagination(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	for _, query := range []string{"limit=0", "limit=1001", "limit=abc", "offset=-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/quarantine?"+query, nil)
+		w := httptest.NewRecorder()
+
+		h.QuarantineList(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+		}
+	}
+}
+
+func TestQuarantineReprocess_Validation(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{config: &config.Config{}}
+	future := time.Now().Add(72 * time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed body", `{`},
+		{"missing started_at", `{"resolved_by":"admin"}`},
+		{"non-RFC3339 started_at", `{"started_at":"2024-01-01"}`},
+		{"still too old", `{"started_at":"1970-01-01T00:00:00Z"}`},
+		{"still in future", `{"started_at":"` + future + `"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New().String()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/quarantine/"+id+"/reprocess", strings.NewReader(tt.body))
+			req.SetPathValue("id", id)
+			w := httptest.NewRecorder()
+
+			h.QuarantineReprocess(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400 (body: %s)", w.Code, w.Body.String())
+			}
+		})
+	}
+}
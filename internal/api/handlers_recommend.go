@@ -8,8 +8,10 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -24,6 +26,17 @@ type RecommendHandler struct {
 	engine       *recommend.Engine
 	dataProvider *database.RecommendationDataProvider
 	db           *database.DB
+	catalog      recommendCatalog
+	feedback     recommendFeedbackStore
+	experiments  recommendExperimentStore
+	training     recommendTrainingCoordinator
+}
+
+// recommendCatalog provides item metadata and watch history for
+// GET /api/v1/recommend. Implemented by *database.DB.
+type recommendCatalog interface {
+	GetMediaItemsByIDs(ctx context.Context, itemIDs []int) (map[int]recommend.Item, error)
+	GetRecentlyWatchedItemIDs(ctx context.Context, userID int, limit int) ([]int, error)
 }
 
 // NewRecommendHandler creates a new recommendation handler.
@@ -43,21 +56,282 @@ func NewRecommendHandler(db *database.DB) (*RecommendHandler, error) {
 		engine:       engine,
 		dataProvider: dataProvider,
 		db:           db,
+		catalog:      db,
+		feedback:     db,
+		experiments:  db,
 	}, nil
 }
 
+// NewRecommendHandlerWithEngine creates a recommendation handler for an engine
+// that has already been configured, with algorithms and a data provider.
+func NewRecommendHandlerWithEngine(engine *recommend.Engine, db *database.DB) *RecommendHandler {
+	return &RecommendHandler{
+		engine:       engine,
+		dataProvider: database.NewRecommendationDataProvider(db),
+		db:           db,
+		catalog:      db,
+		feedback:     db,
+		experiments:  db,
+	}
+}
+
+// Recommend handles GET /api/v1/recommend
+// Returns top-K recommendations joined with catalog metadata. Each item carries
+// an explanation: the algorithms that contributed and a human-readable reason.
+// Results are served from the engine cache for RECOMMEND_CACHE_TTL.
+//
+// Query parameters:
+//   - user_id: User to recommend for (required)
+//   - k: Number of recommendations (default 20, max 100)
+//   - media_type: Only return items of this type (movie, episode, track)
+//
+// Users with no watch history (cold start) get popularity-ranked items and
+// cold_start=true. Returns 503 MODEL_NOT_TRAINED until a model is trained.
+func (h *RecommendHandler) Recommend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+
+	userID, err := strconv.Atoi(r.URL.Query().Get("user_id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "user_id is required and must be an integer", err)
+		return
+	}
+
+	k := 20
+	if kStr := r.URL.Query().Get("k"); kStr != "" {
+		if parsed, err := strconv.Atoi(kStr); err == nil && parsed > 0 {
+			k = parsed
+		}
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("media_type")))
+
+	status := h.engine.GetStatus()
+	if status.ModelVersion == 0 {
+		respondError(w, r, http.StatusServiceUnavailable, "MODEL_NOT_TRAINED", "Recommendation model has not been trained yet", nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	recent, err := h.catalog.GetRecentlyWatchedItemIDs(ctx, userID, recommendAnchorLimit)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "QUERY_ERROR", "Failed to get watch history", err)
+		return
+	}
+
+	coldStart := len(recent) == 0
+	req := recommend.Request{
+		UserID:    userID,
+		K:         k,
+		Mode:      recommend.ModePersonalized,
+		RequestID: r.Header.Get("X-Request-ID"),
+	}
+	if coldStart {
+		req.Mode = recommend.ModePopular
+	}
+	engineCfg := h.engine.GetConfig()
+	if mediaType != "" {
+		// Over-fetch so filtering by media type can still fill k slots
+		req.K = engineCfg.Limits.MaxK
+	}
+
+	resp, err := h.engine.Recommend(ctx, req)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to generate recommendations", err)
+		return
+	}
+
+	ids := make([]int, 0, len(resp.Items)+len(recent))
+	for i := range resp.Items {
+		ids = append(ids, resp.Items[i].Item.ID)
+	}
+	ids = append(ids, recent...)
+	catalog, err := h.catalog.GetMediaItemsByIDs(ctx, ids)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "QUERY_ERROR", "Failed to get item metadata", err)
+		return
+	}
+
+	weights := h.engine.WeightsFor(userID).Normalize().ToMap()
+	if coldStart {
+		weights = map[string]float64{recommend.PopularityAlgorithm: 1}
+	}
+	explainer := newRecommendExplainer(weights, recent, catalog, coldStart)
+
+	items := make([]RecommendedItem, 0, min(k, len(resp.Items)))
+	for i := range resp.Items {
+		if len(items) == k {
+			break
+		}
+		scored := &resp.Items[i]
+		item, ok := catalog[scored.Item.ID]
+		if !ok {
+			item = scored.Item
+		}
+		if mediaType != "" && !strings.EqualFold(item.MediaType, mediaType) {
+			continue
+		}
+		items = append(items, RecommendedItem{
+			ID:          item.ID,
+			Title:       item.Title,
+			Year:        item.Year,
+			MediaType:   item.MediaType,
+			Genres:      item.Genres,
+			Thumb:       item.Thumb,
+			Score:       scored.Score,
+			Explanation: explainer.explain(scored, item),
+		})
+	}
+
+	shown := make([]int, len(items))
+	for i := range items {
+		shown[i] = items[i].ID
+	}
+	h.recordExposures(ctx, &resp.Metadata, shown)
+
+	data := RecommendResponse{
+		UserID:          userID,
+		Items:           items,
+		Count:           len(items),
+		ColdStart:       coldStart,
+		Mode:            resp.Metadata.Mode,
+		AlgorithmsUsed:  resp.Metadata.AlgorithmsUsed,
+		ModelVersion:    status.ModelVersion,
+		TrainedAt:       status.LastTrainedAt,
+		CacheHit:        resp.Metadata.CacheHit,
+		CacheTTLSeconds: int(engineCfg.Cache.TTL.Seconds()),
+		Experiment:      resp.Metadata.Experiment,
+		Variant:         resp.Metadata.Variant,
+	}
+	if coldStart {
+		data.Fallback = recommend.PopularityAlgorithm
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   data,
+		Metadata: models.Metadata{
+			Timestamp:   time.Now(),
+			QueryTimeMS: resp.Metadata.LatencyMS,
+		},
+	})
+}
+
+// RecommendSimilar handles GET /api/v1/recommend/similar/{ratingKey}
+// Returns the items most like the given one by genres, cast, directors and
+// year ("more like this"), without a user context, joined with catalog
+// metadata. Scores come from the content-based model's precomputed feature
+// vectors and are served from the engine cache for RECOMMEND_CACHE_TTL.
+//
+// Query parameters:
+//   - k: Number of items (default 20, max 100)
+//
+// Returns 404 NOT_FOUND for an item the model has not seen, and 503
+// MODEL_NOT_TRAINED until the content-based model is trained.
+func (h *RecommendHandler) RecommendSimilar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+
+	ratingKey, err := strconv.Atoi(chi.URLParam(r, "ratingKey"))
+	if err != nil || ratingKey <= 0 {
+		respondError(w, r, http.StatusBadRequest, "INVALID_ITEM_ID", "ratingKey must be a positive integer", err)
+		return
+	}
+
+	k := 20
+	if kStr := r.URL.Query().Get("k"); kStr != "" {
+		if parsed, err := strconv.Atoi(kStr); err == nil && parsed > 0 {
+			k = parsed
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	similar, err := h.engine.SimilarItems(ctx, ratingKey, k)
+	switch {
+	case errors.Is(err, recommend.ErrItemNotFound):
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Item is not in the recommendation model", nil)
+		return
+	case errors.Is(err, recommend.ErrModelNotTrained), errors.Is(err, recommend.ErrNoSimilarityModel):
+		respondError(w, r, http.StatusServiceUnavailable, "MODEL_NOT_TRAINED", "Content-based recommendation model is not available", err)
+		return
+	case err != nil:
+		respondError(w, r, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to find similar items", err)
+		return
+	}
+
+	ids := make([]int, 0, len(similar)+1)
+	ids = append(ids, ratingKey)
+	for i := range similar {
+		ids = append(ids, similar[i].Item.ID)
+	}
+	catalog, err := h.catalog.GetMediaItemsByIDs(ctx, ids)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "QUERY_ERROR", "Failed to get item metadata", err)
+		return
+	}
+
+	reason := reasonSimilarTo + "this item"
+	if source, ok := catalog[ratingKey]; ok && source.Title != "" {
+		reason = reasonSimilarTo + source.Title
+	}
+
+	items := make([]RecommendedItem, 0, len(similar))
+	for i := range similar {
+		scored := &similar[i]
+		item, ok := catalog[scored.Item.ID]
+		if !ok {
+			item = scored.Item
+		}
+		contributions := make([]AlgorithmContribution, 0, len(scored.Scores))
+		for alg, score := range scored.Scores {
+			contributions = append(contributions, AlgorithmContribution{Algorithm: alg, Score: clampUnit(score), Contribution: 1})
+		}
+		items = append(items, RecommendedItem{
+			ID:          item.ID,
+			Title:       item.Title,
+			Year:        item.Year,
+			MediaType:   item.MediaType,
+			Genres:      item.Genres,
+			Thumb:       item.Thumb,
+			Score:       scored.Score,
+			Explanation: RecommendationExplanation{Reason: reason, Algorithms: contributions},
+		})
+	}
+
+	status := h.engine.GetStatus()
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: SimilarItemsResponse{
+			RatingKey:       ratingKey,
+			Items:           items,
+			Count:           len(items),
+			ModelVersion:    status.ModelVersion,
+			TrainedAt:       status.LastTrainedAt,
+			CacheTTLSeconds: int(h.engine.GetConfig().Cache.TTL.Seconds()),
+		},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
 // GetRecommendations handles GET /api/v1/recommendations/user/{userID}
 // Returns personalized recommendations for a user.
 func (h *RecommendHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	userIDStr := chi.URLParam(r, "userID")
 	userID, err := strconv.Atoi(userIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID", err)
 		return
 	}
 
@@ -81,9 +355,10 @@ func (h *RecommendHandler) GetRecommendations(w http.ResponseWriter, r *http.Req
 
 	resp, err := h.engine.Recommend(ctx, req)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to generate recommendations", err)
+		respondError(w, r, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to generate recommendations", err)
 		return
 	}
+	h.recordExposures(ctx, &resp.Metadata, scoredItemIDs(resp.Items))
 
 	respondJSON(w, http.StatusOK, &models.APIResponse{
 		Status: "success",
@@ -99,14 +374,14 @@ func (h *RecommendHandler) GetRecommendations(w http.ResponseWriter, r *http.Req
 // Returns in-progress content for a user.
 func (h *RecommendHandler) GetContinueWatching(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	userIDStr := chi.URLParam(r, "userID")
 	userID, err := strconv.Atoi(userIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID", err)
 		return
 	}
 
@@ -122,7 +397,7 @@ func (h *RecommendHandler) GetContinueWatching(w http.ResponseWriter, r *http.Re
 
 	items, err := h.db.GetContinueWatchingItems(ctx, userID, limit)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "QUERY_ERROR", "Failed to get continue watching items", err)
+		respondError(w, r, http.StatusInternalServerError, "QUERY_ERROR", "Failed to get continue watching items", err)
 		return
 	}
 
@@ -146,14 +421,14 @@ func (h *RecommendHandler) GetContinueWatching(w http.ResponseWriter, r *http.Re
 // Returns items similar to the given item.
 func (h *RecommendHandler) GetSimilar(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	itemIDStr := chi.URLParam(r, "itemID")
 	itemID, err := strconv.Atoi(itemIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_ITEM_ID", "Invalid item ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ITEM_ID", "Invalid item ID", err)
 		return
 	}
 
@@ -177,7 +452,7 @@ func (h *RecommendHandler) GetSimilar(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := h.engine.Recommend(ctx, req)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to find similar items", err)
+		respondError(w, r, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to find similar items", err)
 		return
 	}
 
@@ -195,7 +470,7 @@ func (h *RecommendHandler) GetSimilar(w http.ResponseWriter, r *http.Request) {
 // Returns the current training status and metrics.
 func (h *RecommendHandler) GetRecommendationStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -218,7 +493,7 @@ func (h *RecommendHandler) GetRecommendationStatus(w http.ResponseWriter, r *htt
 // Returns the current recommendation configuration.
 func (h *RecommendHandler) GetRecommendationConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -237,18 +512,18 @@ func (h *RecommendHandler) GetRecommendationConfig(w http.ResponseWriter, r *htt
 // Updates the recommendation configuration.
 func (h *RecommendHandler) UpdateRecommendationConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	var cfg recommend.Config
 	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
 		return
 	}
 
 	if err := h.engine.UpdateConfig(&cfg); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_CONFIG", "Invalid configuration", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_CONFIG", "Invalid configuration", err)
 		return
 	}
 
@@ -267,14 +542,14 @@ func (h *RecommendHandler) UpdateRecommendationConfig(w http.ResponseWriter, r *
 // Triggers model retraining (admin only).
 func (h *RecommendHandler) TriggerTraining(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	// Check if already training
 	status := h.engine.GetStatus()
 	if status.IsTraining {
-		respondError(w, http.StatusConflict, "TRAINING_IN_PROGRESS", "Training is already in progress", nil)
+		respondError(w, r, http.StatusConflict, "TRAINING_IN_PROGRESS", "Training is already in progress", nil)
 		return
 	}
 
@@ -305,14 +580,14 @@ func (h *RecommendHandler) TriggerTraining(w http.ResponseWriter, r *http.Reques
 // Returns discovery-focused recommendations.
 func (h *RecommendHandler) GetExploreRecommendations(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	userIDStr := chi.URLParam(r, "userID")
 	userID, err := strconv.Atoi(userIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID", err)
 		return
 	}
 
@@ -335,9 +610,10 @@ func (h *RecommendHandler) GetExploreRecommendations(w http.ResponseWriter, r *h
 
 	resp, err := h.engine.Recommend(ctx, req)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to generate explore recommendations", err)
+		respondError(w, r, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to generate explore recommendations", err)
 		return
 	}
+	h.recordExposures(ctx, &resp.Metadata, scoredItemIDs(resp.Items))
 
 	respondJSON(w, http.StatusOK, &models.APIResponse{
 		Status: "success",
@@ -353,14 +629,14 @@ func (h *RecommendHandler) GetExploreRecommendations(w http.ResponseWriter, r *h
 // Returns sequential predictions based on Markov chain patterns.
 func (h *RecommendHandler) GetWhatsNext(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	itemIDStr := chi.URLParam(r, "itemID")
 	itemID, err := strconv.Atoi(itemIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_ITEM_ID", "Invalid item ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_ITEM_ID", "Invalid item ID", err)
 		return
 	}
 
@@ -385,7 +661,7 @@ func (h *RecommendHandler) GetWhatsNext(w http.ResponseWriter, r *http.Request)
 
 	resp, err := h.engine.Recommend(ctx, req)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to generate next predictions", err)
+		respondError(w, r, http.StatusInternalServerError, "RECOMMENDATION_ERROR", "Failed to generate next predictions", err)
 		return
 	}
 
@@ -446,7 +722,7 @@ var algorithmInfoMap = []AlgorithmInfo{
 // Returns information about available recommendation algorithms.
 func (h *RecommendHandler) GetAlgorithms(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -463,7 +739,7 @@ func (h *RecommendHandler) GetAlgorithms(w http.ResponseWriter, r *http.Request)
 // Returns per-algorithm performance metrics.
 func (h *RecommendHandler) GetAlgorithmMetrics(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
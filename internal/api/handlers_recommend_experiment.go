@@ -0,0 +1,183 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// recommendExperimentStore persists A/B experiment exposures and clicks, and
+// aggregates their outcomes per variant. Implemented by *database.DB.
+type recommendExperimentStore interface {
+	RecordRecommendationExposures(ctx context.Context, exposures []recommend.Exposure) error
+	RecordRecommendationClick(ctx context.Context, experiment string, userID, itemID int, clickedAt time.Time) (bool, error)
+	GetRecommendationExperimentOutcomes(ctx context.Context, experiment string) ([]recommend.VariantOutcome, error)
+}
+
+// RecommendClickRequest is the body of POST /api/v1/recommend/click.
+type RecommendClickRequest struct {
+	UserID int `json:"user_id"`
+	ItemID int `json:"item_id"`
+}
+
+// RecommendClickResponse is the response of POST /api/v1/recommend/click.
+type RecommendClickResponse struct {
+	UserID     int    `json:"user_id"`
+	ItemID     int    `json:"item_id"`
+	Experiment string `json:"experiment,omitempty"`
+	Variant    string `json:"variant,omitempty"`
+
+	// Recorded is false when no experiment is running, or the item was
+	// not recommended to the user during it.
+	Recorded bool `json:"recorded"`
+}
+
+// RecommendExperimentReport is the response of
+// GET /api/v1/admin/recommend/experiment.
+type RecommendExperimentReport struct {
+	Experiment string                     `json:"experiment"`
+	Active     bool                       `json:"active"`
+	Variants   []recommend.Variant        `json:"variants"`
+	Outcomes   []recommend.VariantOutcome `json:"outcomes"`
+}
+
+// recordExposures stores the items of a response shown to a user in an
+// experiment variant. Failures are logged, not returned: outcome tracking
+// must not fail the recommendation request.
+func (h *RecommendHandler) recordExposures(ctx context.Context, meta *recommend.ResponseMetadata, itemIDs []int) {
+	if h.experiments == nil {
+		return
+	}
+	exposures := recommend.NewExposures(*meta, itemIDs, time.Now())
+	if len(exposures) == 0 {
+		return
+	}
+	if err := h.experiments.RecordRecommendationExposures(ctx, exposures); err != nil {
+		logging.Warn().Err(err).
+			Str("experiment", meta.Experiment).
+			Int("user_id", meta.UserID).
+			Msg("failed to record recommendation exposures")
+	}
+}
+
+// scoredItemIDs returns the IDs of scored items in display order.
+func scoredItemIDs(items []recommend.ScoredItem) []int {
+	ids := make([]int, len(items))
+	for i := range items {
+		ids[i] = items[i].Item.ID
+	}
+	return ids
+}
+
+// RecordClick handles POST /api/v1/recommend/click
+// Records that a user opened a recommended item. The click is attributed to
+// the user's variant in the running experiment; watches are attributed from
+// playback history and need no call.
+func (h *RecommendHandler) RecordClick(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+
+	var req RecommendClickRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
+		return
+	}
+	if req.UserID <= 0 {
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "user_id is required and must be a positive integer", nil)
+		return
+	}
+	if req.ItemID <= 0 {
+		respondError(w, r, http.StatusBadRequest, "INVALID_ITEM_ID", "item_id is required and must be a positive integer", nil)
+		return
+	}
+
+	data := RecommendClickResponse{UserID: req.UserID, ItemID: req.ItemID}
+	if variant, ok := h.engine.AssignVariant(req.UserID); ok && h.experiments != nil {
+		data.Experiment = h.engine.GetConfig().Experiment.Name
+		data.Variant = variant.Name
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		recorded, err := h.experiments.RecordRecommendationClick(ctx, data.Experiment, req.UserID, req.ItemID, time.Now())
+		if err != nil {
+			respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to record click", err)
+			return
+		}
+		data.Recorded = recorded
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   data,
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
+
+// GetExperimentReport handles GET /api/v1/admin/recommend/experiment
+// Returns the click-through, watch and watch-through rates of each variant.
+//
+// Query parameters:
+//   - experiment: Experiment to report on (default: the configured one)
+func (h *RecommendHandler) GetExperimentReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+	if h.experiments == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Experiment tracking is not available", nil)
+		return
+	}
+
+	current := h.engine.GetConfig().Experiment
+	name := strings.TrimSpace(r.URL.Query().Get("experiment"))
+	if name == "" {
+		name = current.Name
+	}
+	if name == "" {
+		respondError(w, r, http.StatusBadRequest, "MISSING_EXPERIMENT", "No experiment is configured; pass experiment to report on a past one", nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	outcomes, err := h.experiments.GetRecommendationExperimentOutcomes(ctx, name)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "QUERY_ERROR", "Failed to get experiment outcomes", err)
+		return
+	}
+
+	data := RecommendExperimentReport{
+		Experiment: name,
+		Variants:   []recommend.Variant{},
+		Outcomes:   outcomes,
+	}
+	if name == current.Name && current.Enabled() {
+		data.Active = true
+		data.Variants = current.Variants
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   data,
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
@@ -0,0 +1,222 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// stubExperimentStore keeps exposures and clicks in memory.
+type stubExperimentStore struct {
+	exposures   []recommend.Exposure
+	clicks      map[string]bool
+	exposureErr error
+	outcomes    []recommend.VariantOutcome
+	reported    string
+}
+
+func (s *stubExperimentStore) RecordRecommendationExposures(_ context.Context, exposures []recommend.Exposure) error {
+	if s.exposureErr != nil {
+		return s.exposureErr
+	}
+	s.exposures = append(s.exposures, exposures...)
+	return nil
+}
+
+func (s *stubExperimentStore) RecordRecommendationClick(_ context.Context, experiment string, userID, itemID int, _ time.Time) (bool, error) {
+	for _, e := range s.exposures {
+		if e.Experiment == experiment && e.UserID == userID && e.ItemID == itemID {
+			if s.clicks == nil {
+				s.clicks = make(map[string]bool)
+			}
+			s.clicks[e.Variant] = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *stubExperimentStore) GetRecommendationExperimentOutcomes(_ context.Context, experiment string) ([]recommend.VariantOutcome, error) {
+	s.reported = experiment
+	return s.outcomes, nil
+}
+
+// newTestExperimentHandler returns a trained handler whose users all land in
+// a single "treatment" variant.
+func newTestExperimentHandler(t *testing.T) (*RecommendHandler, *stubExperimentStore) {
+	t.Helper()
+
+	h := newTestRecommendHandler(t, true)
+	cfg := h.engine.GetConfig()
+	cfg.Experiment = recommend.ExperimentConfig{
+		Name:     "exp",
+		Variants: []recommend.Variant{{Name: "treatment", Traffic: 1}},
+	}
+	if err := h.engine.UpdateConfig(cfg); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	store := &stubExperimentStore{}
+	h.experiments = store
+	return h, store
+}
+
+func postClick(h *RecommendHandler, body string) (int, RecommendClickResponse) {
+	rec := httptest.NewRecorder()
+	h.RecordClick(rec, httptest.NewRequest(http.MethodPost, "/api/v1/recommend/click", strings.NewReader(body)))
+
+	var resp struct {
+		Data RecommendClickResponse `json:"data"`
+	}
+	_ = json.NewDecoder(rec.Body).Decode(&resp) //nolint:errcheck // error responses have no data
+	return rec.Code, resp.Data
+}
+
+func TestRecommend_TagsExperimentVariant(t *testing.T) {
+	t.Parallel()
+
+	h, store := newTestExperimentHandler(t)
+
+	code, data, _ := getRecommend(t, h, "user_id=1&k=2")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if data.Experiment != "exp" || data.Variant != "treatment" {
+		t.Errorf("experiment/variant = %q/%q, want exp/treatment", data.Experiment, data.Variant)
+	}
+	if len(store.exposures) != len(data.Items) {
+		t.Fatalf("exposures = %+v, want one per returned item", store.exposures)
+	}
+	for i, e := range store.exposures {
+		if e.ItemID != data.Items[i].ID || e.Position != i+1 || e.UserID != 1 || e.Variant != "treatment" {
+			t.Errorf("exposure %d = %+v, want item %d at position %d", i, e, data.Items[i].ID, i+1)
+		}
+	}
+}
+
+func TestRecommend_ExposureErrorDoesNotFailRequest(t *testing.T) {
+	t.Parallel()
+
+	h, store := newTestExperimentHandler(t)
+	store.exposureErr = errors.New("database is locked")
+
+	if code, data, _ := getRecommend(t, h, "user_id=1"); code != http.StatusOK || data.Count == 0 {
+		t.Errorf("status = %d, count = %d, want recommendations despite the exposure error", code, data.Count)
+	}
+}
+
+func TestRecommend_NoExperiment(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	store := &stubExperimentStore{}
+	h.experiments = store
+
+	_, data, _ := getRecommend(t, h, "user_id=1")
+	if data.Experiment != "" || data.Variant != "" || len(store.exposures) != 0 {
+		t.Errorf("experiment=%q variant=%q exposures=%d, want no experiment tracking",
+			data.Experiment, data.Variant, len(store.exposures))
+	}
+}
+
+func TestRecordClick(t *testing.T) {
+	t.Parallel()
+
+	h, store := newTestExperimentHandler(t)
+	_, shown, _ := getRecommend(t, h, "user_id=1&k=1")
+	if len(shown.Items) == 0 {
+		t.Fatal("no recommendations to click")
+	}
+
+	code, data := postClick(h, `{"user_id":1,"item_id":`+strconv.Itoa(shown.Items[0].ID)+`}`)
+	if code != http.StatusOK || !data.Recorded || data.Experiment != "exp" || data.Variant != "treatment" {
+		t.Errorf("click on shown item: status = %d, data = %+v, want recorded for exp/treatment", code, data)
+	}
+	if !store.clicks["treatment"] {
+		t.Error("click was not stored")
+	}
+
+	if code, data := postClick(h, `{"user_id":1,"item_id":999}`); code != http.StatusOK || data.Recorded {
+		t.Errorf("click on item never shown: status = %d, recorded = %v, want 200 and false", code, data.Recorded)
+	}
+}
+
+func TestRecordClick_NoExperiment(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	h.experiments = &stubExperimentStore{}
+
+	code, data := postClick(h, `{"user_id":1,"item_id":20}`)
+	if code != http.StatusOK || data.Recorded || data.Experiment != "" {
+		t.Errorf("status = %d, data = %+v, want 200 and nothing recorded", code, data)
+	}
+}
+
+func TestRecordClick_Invalid(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestExperimentHandler(t)
+	for _, body := range []string{`{`, `{"item_id":20}`, `{"user_id":1}`, `{"user_id":-1,"item_id":20}`} {
+		if code, _ := postClick(h, body); code != http.StatusBadRequest {
+			t.Errorf("body %s: status = %d, want 400", body, code)
+		}
+	}
+}
+
+func TestGetExperimentReport(t *testing.T) {
+	t.Parallel()
+
+	h, store := newTestExperimentHandler(t)
+	store.outcomes = []recommend.VariantOutcome{{Variant: "treatment", Users: 1, Impressions: 10, Clicks: 2, CTR: 0.2}}
+
+	get := func(query string) (int, RecommendExperimentReport) {
+		rec := httptest.NewRecorder()
+		h.GetExperimentReport(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/recommend/experiment"+query, nil))
+		var resp struct {
+			Data RecommendExperimentReport `json:"data"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return rec.Code, resp.Data
+	}
+
+	code, report := get("")
+	if code != http.StatusOK || store.reported != "exp" {
+		t.Fatalf("status = %d, reported = %q, want the configured experiment", code, store.reported)
+	}
+	if !report.Active || len(report.Variants) != 1 || len(report.Outcomes) != 1 || report.Outcomes[0].CTR != 0.2 {
+		t.Errorf("report = %+v, want active experiment with its variant and outcomes", report)
+	}
+
+	code, report = get("?experiment=old")
+	if code != http.StatusOK || store.reported != "old" || report.Active || len(report.Variants) != 0 {
+		t.Errorf("past experiment: status = %d, report = %+v, want inactive report for old", code, report)
+	}
+}
+
+func TestGetExperimentReport_NoExperiment(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	h.experiments = &stubExperimentStore{}
+
+	rec := httptest.NewRecorder()
+	h.GetExperimentReport(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/recommend/experiment", nil))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "MISSING_EXPERIMENT") {
+		t.Errorf("status = %d, body = %s, want 400 MISSING_EXPERIMENT", rec.Code, rec.Body.String())
+	}
+}
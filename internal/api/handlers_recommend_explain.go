@@ -0,0 +1,201 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// recommendAnchorLimit is how many recently watched items are considered when
+// explaining a recommendation as "because you watched X".
+const recommendAnchorLimit = 50
+
+// Human-readable recommendation reasons
+const (
+	reasonBecauseYouWatched = "Because you watched "
+	reasonWatchHistory      = "Based on your watch history"
+	reasonSimilarViewers    = "Popular among similar viewers"
+	reasonPopular           = "Popular on this server"
+	reasonSimilarTo         = "Similar to "
+)
+
+// itemAnchoredAlgorithms score items by their relationship to items the user
+// watched, so their recommendations are explained by a watched item.
+// All other algorithms are explained by similar viewers or popularity.
+var itemAnchoredAlgorithms = map[string]bool{
+	"covisit":         true,
+	"content":         true,
+	"ease":            true,
+	"itemcf":          true,
+	"multihop_itemcf": true,
+	"markov_chain":    true,
+	"fpmc":            true,
+}
+
+// RecommendResponse is the response for GET /api/v1/recommend.
+type RecommendResponse struct {
+	UserID int               `json:"user_id"`
+	Items  []RecommendedItem `json:"items"`
+	Count  int               `json:"count"`
+
+	// ColdStart is true when the user has no watch history and the
+	// Fallback ranking ("popularity") was used instead of a personal one.
+	ColdStart bool   `json:"cold_start"`
+	Fallback  string `json:"fallback,omitempty"`
+
+	Mode            string    `json:"mode"`
+	AlgorithmsUsed  []string  `json:"algorithms_used"`
+	ModelVersion    int       `json:"model_version"`
+	TrainedAt       time.Time `json:"trained_at"`
+	CacheHit        bool      `json:"cache_hit"`
+	CacheTTLSeconds int       `json:"cache_ttl_seconds"`
+
+	// Experiment and Variant identify the A/B experiment arm that produced
+	// the ranking. Omitted when no experiment is running.
+	Experiment string `json:"experiment,omitempty"`
+	Variant    string `json:"variant,omitempty"`
+}
+
+// SimilarItemsResponse is the response for
+// GET /api/v1/recommend/similar/{ratingKey}.
+type SimilarItemsResponse struct {
+	RatingKey       int               `json:"rating_key"`
+	Items           []RecommendedItem `json:"items"`
+	Count           int               `json:"count"`
+	ModelVersion    int               `json:"model_version"`
+	TrainedAt       time.Time         `json:"trained_at"`
+	CacheTTLSeconds int               `json:"cache_ttl_seconds"`
+}
+
+// RecommendedItem is a recommendation joined with catalog metadata.
+type RecommendedItem struct {
+	ID          int                       `json:"id"`
+	Title       string                    `json:"title"`
+	Year        int                       `json:"year,omitempty"`
+	MediaType   string                    `json:"media_type"`
+	Genres      []string                  `json:"genres"`
+	Thumb       string                    `json:"thumb,omitempty"`
+	Score       float64                   `json:"score"`
+	Explanation RecommendationExplanation `json:"explanation"`
+}
+
+// RecommendationExplanation describes why an item was recommended.
+type RecommendationExplanation struct {
+	Reason     string                  `json:"reason"`
+	Algorithms []AlgorithmContribution `json:"algorithms"`
+}
+
+// AlgorithmContribution is one algorithm's part in a recommendation.
+type AlgorithmContribution struct {
+	Algorithm string `json:"algorithm"`
+
+	// Score is the algorithm's own score for the item, normalized to 0-1.
+	Score float64 `json:"score"`
+
+	// Contribution is the algorithm's share of the combined score (0-1).
+	Contribution float64 `json:"contribution"`
+}
+
+// recommendExplainer builds explanations for one recommendation response.
+type recommendExplainer struct {
+	weights   map[string]float64
+	recent    []int
+	catalog   map[int]recommend.Item
+	coldStart bool
+}
+
+func newRecommendExplainer(weights map[string]float64, recent []int, catalog map[int]recommend.Item, coldStart bool) *recommendExplainer {
+	return &recommendExplainer{weights: weights, recent: recent, catalog: catalog, coldStart: coldStart}
+}
+
+// explain returns the explanation for a scored item. Contributions are
+// ordered largest first; the largest one determines the reason.
+func (e *recommendExplainer) explain(scored *recommend.ScoredItem, item recommend.Item) RecommendationExplanation {
+	var total float64
+	for alg, score := range scored.Scores {
+		total += e.weights[alg] * clampUnit(score)
+	}
+
+	contributions := make([]AlgorithmContribution, 0, len(scored.Scores))
+	for alg, score := range scored.Scores {
+		weighted := e.weights[alg] * clampUnit(score)
+		if weighted <= 0 {
+			continue
+		}
+		contributions = append(contributions, AlgorithmContribution{
+			Algorithm:    alg,
+			Score:        clampUnit(score),
+			Contribution: weighted / total,
+		})
+	}
+	sort.Slice(contributions, func(i, j int) bool {
+		if contributions[i].Contribution != contributions[j].Contribution {
+			return contributions[i].Contribution > contributions[j].Contribution
+		}
+		return contributions[i].Algorithm < contributions[j].Algorithm
+	})
+
+	explanation := RecommendationExplanation{Reason: scored.Reason, Algorithms: contributions}
+	if explanation.Reason == "" {
+		explanation.Reason = e.reason(contributions, item)
+	}
+	return explanation
+}
+
+// reason picks a human-readable reason from the dominant algorithm.
+func (e *recommendExplainer) reason(contributions []AlgorithmContribution, item recommend.Item) string {
+	if e.coldStart || len(contributions) == 0 {
+		return reasonPopular
+	}
+
+	switch dominant := contributions[0].Algorithm; {
+	case dominant == recommend.PopularityAlgorithm:
+		return reasonPopular
+	case itemAnchoredAlgorithms[dominant]:
+		if anchor, ok := e.anchor(item); ok {
+			return reasonBecauseYouWatched + anchor.Title
+		}
+		return reasonWatchHistory
+	default:
+		return reasonSimilarViewers
+	}
+}
+
+// anchor returns the recently watched item most like the recommendation:
+// the one sharing the most genres, preferring the most recent on a tie.
+func (e *recommendExplainer) anchor(item recommend.Item) (recommend.Item, bool) {
+	genres := make(map[string]bool, len(item.Genres))
+	for _, g := range item.Genres {
+		genres[g] = true
+	}
+
+	var best recommend.Item
+	bestShared, found := -1, false
+	for _, id := range e.recent {
+		watched, ok := e.catalog[id]
+		if !ok || watched.Title == "" {
+			continue
+		}
+		shared := 0
+		for _, g := range watched.Genres {
+			if genres[g] {
+				shared++
+			}
+		}
+		if shared > bestShared {
+			best, bestShared, found = watched, shared, true
+		}
+	}
+	return best, found
+}
+
+// clampUnit limits a score to [0, 1].
+func clampUnit(v float64) float64 {
+	return min(max(v, 0), 1)
+}
@@ -0,0 +1,313 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/tomtom215/cartographus/internal/recommend"
+	"github.com/tomtom215/cartographus/internal/recommend/algorithms"
+)
+
+// stubRecommendAlgorithm returns fixed scores for every user.
+type stubRecommendAlgorithm struct {
+	name    string
+	scores  map[int]float64
+	trained bool
+}
+
+func (a *stubRecommendAlgorithm) Name() string { return a.name }
+
+func (a *stubRecommendAlgorithm) Train(_ context.Context, _ []recommend.Interaction, _ []recommend.Item) error {
+	a.trained = true
+	return nil
+}
+
+func (a *stubRecommendAlgorithm) Predict(_ context.Context, _ int, candidates []int) (map[int]float64, error) {
+	scores := make(map[int]float64)
+	for _, id := range candidates {
+		if s, ok := a.scores[id]; ok {
+			scores[id] = s
+		}
+	}
+	return scores, nil
+}
+
+func (a *stubRecommendAlgorithm) PredictSimilar(ctx context.Context, _ int, candidates []int) (map[int]float64, error) {
+	return a.Predict(ctx, 0, candidates)
+}
+
+func (a *stubRecommendAlgorithm) IsTrained() bool          { return a.trained }
+func (a *stubRecommendAlgorithm) Version() int             { return 1 }
+func (a *stubRecommendAlgorithm) LastTrainedAt() time.Time { return time.Time{} }
+
+// stubRecommendData is both the engine DataProvider and the handler catalog.
+type stubRecommendData struct {
+	items   map[int]recommend.Item
+	history map[int][]int
+}
+
+func (d *stubRecommendData) GetInteractions(_ context.Context, _ time.Time) ([]recommend.Interaction, error) {
+	return []recommend.Interaction{{UserID: 1, ItemID: 10}}, nil
+}
+
+func (d *stubRecommendData) GetItems(_ context.Context) ([]recommend.Item, error) {
+	items := make([]recommend.Item, 0, len(d.items))
+	for _, item := range d.items {
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (d *stubRecommendData) GetUserHistory(_ context.Context, userID int) ([]int, error) {
+	return d.history[userID], nil
+}
+
+func (d *stubRecommendData) GetCandidates(_ context.Context, _ int, _ int) ([]int, error) {
+	return []int{10, 20, 30, 40}, nil
+}
+
+func (d *stubRecommendData) GetMediaItemsByIDs(_ context.Context, itemIDs []int) (map[int]recommend.Item, error) {
+	items := make(map[int]recommend.Item)
+	for _, id := range itemIDs {
+		if item, ok := d.items[id]; ok {
+			items[id] = item
+		}
+	}
+	return items, nil
+}
+
+func (d *stubRecommendData) GetRecentlyWatchedItemIDs(_ context.Context, userID int, _ int) ([]int, error) {
+	return d.history[userID], nil
+}
+
+// newTestRecommendHandler returns a handler whose engine has covisit and
+// popularity stubs. User 1 has watched "Alien"; user 2 has no history.
+func newTestRecommendHandler(t *testing.T, train bool) *RecommendHandler {
+	t.Helper()
+
+	cfg := recommend.DefaultConfig()
+	cfg.Training.MinInteractions = 0
+	engine, err := recommend.NewEngine(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	data := &stubRecommendData{
+		items: map[int]recommend.Item{
+			10: {ID: 10, Title: "Alien", MediaType: "movie", Genres: []string{"Horror", "Sci-Fi"}, Year: 1979},
+			20: {ID: 20, Title: "Aliens", MediaType: "movie", Genres: []string{"Action", "Sci-Fi"}, Year: 1986, Thumb: "/library/metadata/20/thumb"},
+			30: {ID: 30, Title: "Pilot", MediaType: "episode", Genres: []string{"Drama"}},
+			40: {ID: 40, Title: "Heat", MediaType: "movie", Genres: []string{"Crime"}, Year: 1995},
+		},
+		history: map[int][]int{1: {10}},
+	}
+	engine.SetDataProvider(data)
+	engine.RegisterAlgorithm(&stubRecommendAlgorithm{name: "covisit", scores: map[int]float64{20: 0.9, 30: 0.8}})
+	engine.RegisterAlgorithm(&stubRecommendAlgorithm{name: recommend.PopularityAlgorithm, scores: map[int]float64{40: 1.0, 20: 0.2}})
+
+	if train {
+		if err := engine.Train(context.Background()); err != nil {
+			t.Fatalf("Train() error = %v", err)
+		}
+	}
+
+	return &RecommendHandler{engine: engine, catalog: data}
+}
+
+func getRecommend(t *testing.T, h *RecommendHandler, query string) (int, RecommendResponse, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.Recommend(rec, httptest.NewRequest(http.MethodGet, "/api/v1/recommend?"+query, nil))
+
+	var body struct {
+		Data  RecommendResponse `json:"data"`
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return rec.Code, body.Data, body.Error.Code
+}
+
+func TestRecommend_ModelNotTrained(t *testing.T) {
+	t.Parallel()
+
+	code, _, errCode := getRecommend(t, newTestRecommendHandler(t, false), "user_id=1")
+	if code != http.StatusServiceUnavailable || errCode != "MODEL_NOT_TRAINED" {
+		t.Errorf("got %d %q, want 503 MODEL_NOT_TRAINED", code, errCode)
+	}
+}
+
+func TestRecommend_InvalidUserID(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	for _, query := range []string{"", "user_id=abc"} {
+		if code, _, _ := getRecommend(t, h, query); code != http.StatusBadRequest {
+			t.Errorf("query %q: status = %d, want 400", query, code)
+		}
+	}
+}
+
+func TestRecommend_PersonalizedWithExplanations(t *testing.T) {
+	t.Parallel()
+
+	code, data, _ := getRecommend(t, newTestRecommendHandler(t, true), "user_id=1&k=5")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if data.ColdStart || data.Fallback != "" || data.Mode != "personalized" {
+		t.Errorf("cold_start=%v fallback=%q mode=%q, want personalized", data.ColdStart, data.Fallback, data.Mode)
+	}
+	if len(data.Items) == 0 {
+		t.Fatal("expected recommendations")
+	}
+
+	top := data.Items[0]
+	if top.ID != 20 || top.Title != "Aliens" || top.Year != 1986 || top.Thumb == "" || len(top.Genres) != 2 {
+		t.Errorf("top item = %+v, want Aliens joined with catalog metadata", top)
+	}
+	if top.Explanation.Reason != "Because you watched Alien" {
+		t.Errorf("reason = %q, want %q", top.Explanation.Reason, "Because you watched Alien")
+	}
+
+	algs := top.Explanation.Algorithms
+	if len(algs) != 2 || algs[0].Algorithm != "covisit" || algs[1].Algorithm != recommend.PopularityAlgorithm {
+		t.Fatalf("algorithms = %+v, want covisit then popularity", algs)
+	}
+	if algs[0].Score != 0.9 {
+		t.Errorf("covisit score = %v, want 0.9", algs[0].Score)
+	}
+	if sum := algs[0].Contribution + algs[1].Contribution; sum < 0.999 || sum > 1.001 {
+		t.Errorf("contributions sum to %v, want 1", sum)
+	}
+}
+
+func TestRecommend_MediaTypeFilter(t *testing.T) {
+	t.Parallel()
+
+	code, data, _ := getRecommend(t, newTestRecommendHandler(t, true), "user_id=1&media_type=episode")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if len(data.Items) != 1 || data.Items[0].Title != "Pilot" {
+		t.Errorf("items = %+v, want only the episode", data.Items)
+	}
+}
+
+func TestRecommend_ColdStartFallsBackToPopularity(t *testing.T) {
+	t.Parallel()
+
+	code, data, _ := getRecommend(t, newTestRecommendHandler(t, true), "user_id=2")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if !data.ColdStart || data.Fallback != recommend.PopularityAlgorithm || data.Mode != "popular" {
+		t.Errorf("cold_start=%v fallback=%q mode=%q, want popularity fallback", data.ColdStart, data.Fallback, data.Mode)
+	}
+	if len(data.Items) == 0 || data.Items[0].Title != "Heat" {
+		t.Fatalf("items = %+v, want most popular item first", data.Items)
+	}
+	if reason := data.Items[0].Explanation.Reason; reason != reasonPopular {
+		t.Errorf("reason = %q, want %q", reason, reasonPopular)
+	}
+	for _, item := range data.Items {
+		for _, alg := range item.Explanation.Algorithms {
+			if alg.Algorithm != recommend.PopularityAlgorithm {
+				t.Errorf("item %d explained by %q, want popularity only", item.ID, alg.Algorithm)
+			}
+		}
+	}
+}
+
+func TestRecommend_ServedFromCache(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	_, first, _ := getRecommend(t, h, "user_id=1")
+	_, second, _ := getRecommend(t, h, "user_id=1")
+
+	if first.CacheHit || !second.CacheHit {
+		t.Errorf("cache_hit = %v then %v, want false then true", first.CacheHit, second.CacheHit)
+	}
+	if second.CacheTTLSeconds != 300 {
+		t.Errorf("cache_ttl_seconds = %d, want 300", second.CacheTTLSeconds)
+	}
+}
+
+// getSimilar calls RecommendSimilar for a rating key.
+func getSimilar(t *testing.T, h *RecommendHandler, ratingKey, query string) (int, SimilarItemsResponse, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recommend/similar/"+ratingKey+"?"+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("ratingKey", ratingKey)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+	h.RecommendSimilar(rec, req)
+
+	var body struct {
+		Data  SimilarItemsResponse `json:"data"`
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return rec.Code, body.Data, body.Error.Code
+}
+
+func TestRecommendSimilar(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, false)
+
+	// Without a content-based algorithm there is no item similarity
+	if code, _, errCode := getSimilar(t, h, "10", ""); code != http.StatusServiceUnavailable || errCode != "MODEL_NOT_TRAINED" {
+		t.Errorf("without content model: status = %d, code = %q; want 503 MODEL_NOT_TRAINED", code, errCode)
+	}
+
+	h.engine.RegisterAlgorithm(algorithms.NewContentBased(algorithms.ContentBasedConfig{}))
+	if err := h.engine.Train(context.Background()); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	code, resp, _ := getSimilar(t, h, "10", "k=5")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	// Only Aliens shares a genre with Alien
+	if resp.RatingKey != 10 || resp.Count != 1 || len(resp.Items) != 1 {
+		t.Fatalf("response = %+v, want Aliens only", resp)
+	}
+	top := resp.Items[0]
+	if top.ID != 20 || top.Title != "Aliens" || top.Thumb == "" {
+		t.Errorf("item = %+v, want Aliens with catalog metadata", top)
+	}
+	if top.Explanation.Reason != "Similar to Alien" || len(top.Explanation.Algorithms) != 1 ||
+		top.Explanation.Algorithms[0].Algorithm != "content" {
+		t.Errorf("explanation = %+v, want content similarity to Alien", top.Explanation)
+	}
+
+	if code, _, errCode := getSimilar(t, h, "999", ""); code != http.StatusNotFound || errCode != "NOT_FOUND" {
+		t.Errorf("unknown item: status = %d, code = %q; want 404 NOT_FOUND", code, errCode)
+	}
+	if code, _, errCode := getSimilar(t, h, "abc", ""); code != http.StatusBadRequest || errCode != "INVALID_ITEM_ID" {
+		t.Errorf("invalid key: status = %d, code = %q; want 400 INVALID_ITEM_ID", code, errCode)
+	}
+}
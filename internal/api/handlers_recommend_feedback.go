@@ -0,0 +1,136 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// recommendFeedbackStore persists explicit recommendation feedback.
+// Implemented by *database.DB.
+type recommendFeedbackStore interface {
+	SaveRecommendationFeedback(ctx context.Context, fb recommend.Feedback) error
+	GetUserRecommendationFeedback(ctx context.Context, userID int) ([]recommend.Feedback, error)
+}
+
+// RecommendFeedbackRequest is the body of POST /api/v1/recommend/feedback.
+type RecommendFeedbackRequest struct {
+	UserID int    `json:"user_id"`
+	ItemID int    `json:"item_id"`
+	Signal string `json:"signal"`
+}
+
+// RecommendFeedbackList is the response of GET /api/v1/recommend/feedback.
+type RecommendFeedbackList struct {
+	UserID   int                  `json:"user_id"`
+	Feedback []recommend.Feedback `json:"feedback"`
+	Count    int                  `json:"count"`
+}
+
+// RecordFeedback handles POST /api/v1/recommend/feedback
+// Stores a like, dislike, not_interested, or watched_elsewhere signal for an
+// item. A new signal replaces the user's previous signal for the same item.
+//
+// The signal takes effect immediately: the user's cached recommendations are
+// invalidated, not_interested and watched_elsewhere items are no longer
+// recommended, and online learners (LinUCB) receive it as a reward, negative
+// for dislike and not_interested. Training
+// picks it up on the next run.
+func (h *RecommendHandler) RecordFeedback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+
+	var req RecommendFeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
+		return
+	}
+	if req.UserID <= 0 {
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "user_id is required and must be a positive integer", nil)
+		return
+	}
+	if req.ItemID <= 0 {
+		respondError(w, r, http.StatusBadRequest, "INVALID_ITEM_ID", "item_id is required and must be a positive integer", nil)
+		return
+	}
+	signal, err := recommend.ParseFeedbackSignal(req.Signal)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_SIGNAL", err.Error(), nil)
+		return
+	}
+
+	fb := recommend.Feedback{
+		UserID:    req.UserID,
+		ItemID:    req.ItemID,
+		Signal:    signal,
+		CreatedAt: time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.feedback.SaveRecommendationFeedback(ctx, fb); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to save feedback", err)
+		return
+	}
+	h.engine.RecordFeedback(ctx, fb.UserID, fb.ItemID, fb.Signal)
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   fb,
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
+
+// GetFeedback handles GET /api/v1/recommend/feedback
+// Returns a user's latest feedback per item, newest first.
+//
+// Query parameters:
+//   - user_id: User whose feedback to list (required)
+func (h *RecommendHandler) GetFeedback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+
+	userID, err := strconv.Atoi(r.URL.Query().Get("user_id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "user_id is required and must be an integer", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	feedback, err := h.feedback.GetUserRecommendationFeedback(ctx, userID)
+	if err != nil {
+		respondError(w, r, http.StatusInternalServerError, "QUERY_ERROR", "Failed to get feedback", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: RecommendFeedbackList{
+			UserID:   userID,
+			Feedback: feedback,
+			Count:    len(feedback),
+		},
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
@@ -0,0 +1,176 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// stubFeedbackStore keeps feedback in memory, latest signal per user and item.
+type stubFeedbackStore struct {
+	saved   []recommend.Feedback
+	saveErr error
+}
+
+func (s *stubFeedbackStore) SaveRecommendationFeedback(_ context.Context, fb recommend.Feedback) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.saved = append(s.saved, fb)
+	return nil
+}
+
+func (s *stubFeedbackStore) GetUserRecommendationFeedback(_ context.Context, userID int) ([]recommend.Feedback, error) {
+	result := make([]recommend.Feedback, 0)
+	for _, fb := range recommend.DedupFeedback(s.saved) {
+		if fb.UserID == userID {
+			result = append(result, fb)
+		}
+	}
+	return result, nil
+}
+
+func postFeedback(h *RecommendHandler, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.RecordFeedback(rec, httptest.NewRequest(http.MethodPost, "/api/v1/recommend/feedback", strings.NewReader(body)))
+	return rec
+}
+
+func TestRecordFeedback_Valid(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	store := &stubFeedbackStore{}
+	h.feedback = store
+
+	// Populate the recommendation cache for user 1
+	if _, first, _ := getRecommend(t, h, "user_id=1"); first.CacheHit {
+		t.Fatal("first request should not be a cache hit")
+	}
+
+	rec := postFeedback(h, `{"user_id":1,"item_id":20,"signal":"like"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if len(store.saved) != 1 || store.saved[0].Signal != recommend.FeedbackLike || store.saved[0].CreatedAt.IsZero() {
+		t.Errorf("saved = %+v, want one like with a timestamp", store.saved)
+	}
+
+	if _, after, _ := getRecommend(t, h, "user_id=1"); after.CacheHit {
+		t.Error("feedback should invalidate the user's cached recommendations")
+	}
+}
+
+func TestRecordFeedback_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		code string
+	}{
+		{"bad json", `{`, "INVALID_JSON"},
+		{"missing user", `{"item_id":20,"signal":"like"}`, "INVALID_USER_ID"},
+		{"missing item", `{"user_id":1,"signal":"like"}`, "INVALID_ITEM_ID"},
+		{"unknown signal", `{"user_id":1,"item_id":20,"signal":"love"}`, "INVALID_SIGNAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := newTestRecommendHandler(t, true)
+			store := &stubFeedbackStore{}
+			h.feedback = store
+
+			rec := postFeedback(h, tt.body)
+			var body struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if rec.Code != http.StatusBadRequest || body.Error.Code != tt.code {
+				t.Errorf("got %d %q, want 400 %q", rec.Code, body.Error.Code, tt.code)
+			}
+			if len(store.saved) != 0 {
+				t.Errorf("saved = %+v, want nothing stored", store.saved)
+			}
+		})
+	}
+}
+
+func TestRecordFeedback_StoreError(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	h.feedback = &stubFeedbackStore{saveErr: errors.New("disk full")}
+
+	if rec := postFeedback(h, `{"user_id":1,"item_id":20,"signal":"dislike"}`); rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+}
+
+func TestGetFeedback_LatestSignalPerItem(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	h.feedback = &stubFeedbackStore{}
+
+	for _, body := range []string{
+		`{"user_id":1,"item_id":20,"signal":"like"}`,
+		`{"user_id":1,"item_id":20,"signal":"not_interested"}`,
+		`{"user_id":1,"item_id":30,"signal":"watched_elsewhere"}`,
+		`{"user_id":2,"item_id":20,"signal":"dislike"}`,
+	} {
+		if rec := postFeedback(h, body); rec.Code != http.StatusOK {
+			t.Fatalf("POST %s: status = %d", body, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.GetFeedback(rec, httptest.NewRequest(http.MethodGet, "/api/v1/recommend/feedback?user_id=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var body struct {
+		Data RecommendFeedbackList `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	signals := make(map[int]recommend.FeedbackSignal)
+	for _, fb := range body.Data.Feedback {
+		signals[fb.ItemID] = fb.Signal
+	}
+	if body.Data.Count != 2 || signals[20] != recommend.FeedbackNotInterested || signals[30] != recommend.FeedbackWatchedElsewhere {
+		t.Errorf("feedback = %+v, want latest signal for items 20 and 30", body.Data.Feedback)
+	}
+}
+
+func TestGetFeedback_InvalidUserID(t *testing.T) {
+	t.Parallel()
+
+	h := newTestRecommendHandler(t, true)
+	h.feedback = &stubFeedbackStore{}
+
+	rec := httptest.NewRecorder()
+	h.GetFeedback(rec, httptest.NewRequest(http.MethodGet, "/api/v1/recommend/feedback", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
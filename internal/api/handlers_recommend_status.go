@@ -0,0 +1,34 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// RecommendStatus handles GET /api/v1/recommend/status
+// Diagnoses why recommendations are empty: the engine state (ready,
+// insufficient_interactions, not_trained, training, training_failed, or
+// no_algorithms), each algorithm's trained state and version, whether its
+// stored model loaded on startup, and the interaction count against the
+// training minimum. Always returns 200; the diagnosis is in the body.
+func (h *RecommendHandler) RecommendStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   h.engine.Status(r.Context()),
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
@@ -0,0 +1,58 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/tomtom215/cartographus/internal/recommend"
+	"github.com/tomtom215/cartographus/internal/recommend/algorithms"
+)
+
+func TestRecommendStatus(t *testing.T) {
+	t.Parallel()
+
+	cfg := recommend.DefaultConfig()
+	cfg.Training.MinInteractions = 50
+	engine, err := recommend.NewEngine(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.RegisterAlgorithm(algorithms.NewCoVisitation(algorithms.CoVisitConfig{}))
+	h := &RecommendHandler{engine: engine}
+
+	rec := httptest.NewRecorder()
+	h.RecommendStatus(rec, httptest.NewRequest(http.MethodGet, "/api/v1/recommend/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var body struct {
+		Data recommend.EngineStatus `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Data.State != recommend.StateInsufficientData {
+		t.Errorf("state = %q, want %q", body.Data.State, recommend.StateInsufficientData)
+	}
+	if body.Data.MinInteractions != 50 || len(body.Data.Issues) != 1 {
+		t.Errorf("min_interactions = %d, issues = %v; want 50 and the cold start issue", body.Data.MinInteractions, body.Data.Issues)
+	}
+	if len(body.Data.Algorithms) != 1 || body.Data.Algorithms[0].Name != "covisit" || body.Data.Algorithms[0].Trained || !body.Data.Algorithms[0].Restorable {
+		t.Errorf("algorithms = %+v, want untrained, restorable covisit", body.Data.Algorithms)
+	}
+
+	rec = httptest.NewRecorder()
+	h.RecommendStatus(rec, httptest.NewRequest(http.MethodPost, "/api/v1/recommend/status", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+}
@@ -0,0 +1,88 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// recommendTrainingCoordinator reports on and triggers scheduled training.
+// Implemented by *recommend.TrainingCoordinator.
+type recommendTrainingCoordinator interface {
+	Status() recommend.CoordinatorStatus
+	Trigger() error
+}
+
+// SetTrainingCoordinator enables the admin training endpoints.
+func (h *RecommendHandler) SetTrainingCoordinator(coordinator recommendTrainingCoordinator) {
+	h.training = coordinator
+}
+
+// GetTrainingStatus handles GET /api/v1/admin/recommend/training
+// Returns the last training run with per-algorithm durations and errors,
+// the next scheduled run, and why the last scheduled run was skipped, if it
+// was (admin only).
+func (h *RecommendHandler) GetTrainingStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+	if h.training == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "TRAINING_UNAVAILABLE", "Training coordinator is not configured", nil)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   h.training.Status(),
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
+
+// TriggerScheduledTraining handles POST /api/v1/admin/recommend/train
+// Queues a manual training run through the coordinator (admin only). Manual
+// runs skip the new-interaction threshold but are refused with 409 while a
+// sync is running, the database pool is busy, or a run is already queued.
+func (h *RecommendHandler) TriggerScheduledTraining(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		return
+	}
+	if h.training == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "TRAINING_UNAVAILABLE", "Training coordinator is not configured", nil)
+		return
+	}
+
+	if err := h.training.Trigger(); err != nil {
+		var precondition *recommend.PreconditionError
+		switch {
+		case errors.Is(err, recommend.ErrTrainingInProgress):
+			respondError(w, r, http.StatusConflict, "TRAINING_IN_PROGRESS", "Training is already in progress", nil)
+		case errors.As(err, &precondition):
+			respondError(w, r, http.StatusConflict, "TRAINING_PRECONDITION_FAILED", precondition.Error(), nil)
+		default:
+			respondError(w, r, http.StatusInternalServerError, "TRAINING_ERROR", "Failed to start training", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, &models.APIResponse{
+		Status: "success",
+		Data: map[string]string{
+			"message": "Training queued",
+		},
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
@@ -0,0 +1,116 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+type stubTrainingCoordinator struct {
+	status     recommend.CoordinatorStatus
+	triggerErr error
+	triggered  int
+}
+
+func (s *stubTrainingCoordinator) Status() recommend.CoordinatorStatus { return s.status }
+
+func (s *stubTrainingCoordinator) Trigger() error {
+	if s.triggerErr != nil {
+		return s.triggerErr
+	}
+	s.triggered++
+	return nil
+}
+
+func TestGetTrainingStatus(t *testing.T) {
+	t.Parallel()
+
+	next := time.Now().Add(time.Hour).Truncate(time.Second)
+	stub := &stubTrainingCoordinator{status: recommend.CoordinatorStatus{
+		NextRunAt: next,
+		LastRun: &recommend.TrainingRun{
+			Trigger:      recommend.TriggerScheduled,
+			ModelVersion: 3,
+			Algorithms: []recommend.AlgorithmTrainingResult{
+				{Name: "covisit", DurationMS: 120},
+				{Name: "ease", DurationMS: 900, Error: "out of memory"},
+			},
+		},
+	}}
+	h := &RecommendHandler{}
+	h.SetTrainingCoordinator(stub)
+
+	rec := httptest.NewRecorder()
+	h.GetTrainingStatus(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/recommend/training", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var body struct {
+		Data recommend.CoordinatorStatus `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Data.LastRun == nil || len(body.Data.LastRun.Algorithms) != 2 {
+		t.Fatalf("last_run = %+v, want 2 algorithm results", body.Data.LastRun)
+	}
+	if body.Data.LastRun.Algorithms[1].Error != "out of memory" {
+		t.Errorf("ease error = %q, want out of memory", body.Data.LastRun.Algorithms[1].Error)
+	}
+	if !body.Data.NextRunAt.Equal(next) {
+		t.Errorf("next_run_at = %v, want %v", body.Data.NextRunAt, next)
+	}
+}
+
+func TestGetTrainingStatus_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	(&RecommendHandler{}).GetTrainingStatus(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/recommend/training", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+}
+
+func TestTriggerScheduledTraining(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		triggerErr error
+		wantStatus int
+	}{
+		{"queued", nil, http.StatusAccepted},
+		{"already running", recommend.ErrTrainingInProgress, http.StatusConflict},
+		{"sync in progress", &recommend.PreconditionError{Reason: "sync in progress"}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stub := &stubTrainingCoordinator{triggerErr: tt.triggerErr}
+			h := &RecommendHandler{}
+			h.SetTrainingCoordinator(stub)
+
+			rec := httptest.NewRecorder()
+			h.TriggerScheduledTraining(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/recommend/train", nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.triggerErr == nil && stub.triggered != 1 {
+				t.Errorf("Trigger() called %d times, want 1", stub.triggered)
+			}
+		})
+	}
+}
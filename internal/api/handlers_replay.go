@@ -93,8 +93,8 @@ type CheckpointResponse struct {
 // @Produce json
 // @Param status query string false "Filter by status (running, completed, error, canceled)"
 // @Success 200 {array} CheckpointResponse
-// @Failure 500 {object} ErrorResponse
-// @Router /api/v1/admin/replay/checkpoints [get]
+// @Failure 500 {object} models.APIResponse
+// @Router /admin/replay/checkpoints [get]
 func (h *ReplayHandlers) ListCheckpoints(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	status := r.URL.Query().Get("status")
@@ -102,7 +102,7 @@ func (h *ReplayHandlers) ListCheckpoints(w http.ResponseWriter, r *http.Request)
 	checkpoints, err := h.checkpointStore.List(ctx, status)
 	if err != nil {
 		logging.Error().Err(err).Msg("Failed to list checkpoints")
-		respondError(w, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to list checkpoints", err)
+		respondError(w, r, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to list checkpoints", err)
 		return
 	}
 
@@ -123,28 +123,28 @@ func (h *ReplayHandlers) ListCheckpoints(w http.ResponseWriter, r *http.Request)
 // @Produce json
 // @Param id path int true "Checkpoint ID"
 // @Success 200 {object} CheckpointResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
-// @Router /api/v1/admin/replay/checkpoints/{id} [get]
+// @Failure 404 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /admin/replay/checkpoints/{id} [get]
 func (h *ReplayHandlers) GetCheckpoint(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	idStr := r.PathValue("id")
 
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid checkpoint ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid checkpoint ID", err)
 		return
 	}
 
 	checkpoint, err := h.checkpointStore.GetByID(ctx, id)
 	if err != nil {
 		logging.Error().Err(err).Int64("id", id).Msg("Failed to get checkpoint")
-		respondError(w, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to get checkpoint", err)
+		respondError(w, r, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to get checkpoint", err)
 		return
 	}
 
 	if checkpoint == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Checkpoint not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Checkpoint not found", nil)
 		return
 	}
 
@@ -159,22 +159,22 @@ func (h *ReplayHandlers) GetCheckpoint(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param id path int true "Checkpoint ID"
 // @Success 204 "No Content"
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
-// @Router /api/v1/admin/replay/checkpoints/{id} [delete]
+// @Failure 404 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /admin/replay/checkpoints/{id} [delete]
 func (h *ReplayHandlers) DeleteCheckpoint(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	idStr := r.PathValue("id")
 
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid checkpoint ID", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid checkpoint ID", err)
 		return
 	}
 
 	if err := h.checkpointStore.Delete(ctx, id); err != nil {
 		logging.Error().Err(err).Int64("id", id).Msg("Failed to delete checkpoint")
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Checkpoint not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Checkpoint not found", nil)
 		return
 	}
 
@@ -189,27 +189,27 @@ func (h *ReplayHandlers) DeleteCheckpoint(w http.ResponseWriter, r *http.Request
 // @Produce json
 // @Param stream query string true "Stream name"
 // @Success 200 {object} CheckpointResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
-// @Router /api/v1/admin/replay/checkpoints/last [get]
+// @Failure 404 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /admin/replay/checkpoints/last [get]
 func (h *ReplayHandlers) GetLastCheckpoint(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	streamName := r.URL.Query().Get("stream")
 
 	if streamName == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "stream parameter is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "stream parameter is required", nil)
 		return
 	}
 
 	checkpoint, err := h.checkpointStore.GetLastForStream(ctx, streamName)
 	if err != nil {
 		logging.Error().Err(err).Str("stream", streamName).Msg("Failed to get last checkpoint")
-		respondError(w, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to get last checkpoint", err)
+		respondError(w, r, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to get last checkpoint", err)
 		return
 	}
 
 	if checkpoint == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "No checkpoints found for stream", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "No checkpoints found for stream", nil)
 		return
 	}
 
@@ -224,9 +224,9 @@ func (h *ReplayHandlers) GetLastCheckpoint(w http.ResponseWriter, r *http.Reques
 // @Produce json
 // @Param older_than query string false "Duration string (e.g., '24h', '7d')" default(168h)
 // @Success 200 {object} map[string]int64
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
-// @Router /api/v1/admin/replay/checkpoints/cleanup [post]
+// @Failure 400 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /admin/replay/checkpoints/cleanup [post]
 func (h *ReplayHandlers) CleanupOldCheckpoints(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	olderThanStr := r.URL.Query().Get("older_than")
@@ -236,14 +236,14 @@ func (h *ReplayHandlers) CleanupOldCheckpoints(w http.ResponseWriter, r *http.Re
 
 	olderThan, err := time.ParseDuration(olderThanStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid duration format", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid duration format", err)
 		return
 	}
 
 	deleted, err := h.checkpointStore.DeleteOld(ctx, olderThan)
 	if err != nil {
 		logging.Error().Err(err).Msg("Failed to cleanup checkpoints")
-		respondError(w, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to cleanup checkpoints", err)
+		respondError(w, r, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to cleanup checkpoints", err)
 		return
 	}
 
@@ -259,23 +259,23 @@ func (h *ReplayHandlers) CleanupOldCheckpoints(w http.ResponseWriter, r *http.Re
 // @Produce json
 // @Param request body ReplayRequest true "Replay configuration"
 // @Success 202 {object} ReplayResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
-// @Router /api/v1/admin/replay/start [post]
+// @Failure 400 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /admin/replay/start [post]
 func (h *ReplayHandlers) StartReplay(w http.ResponseWriter, r *http.Request) {
 	var req ReplayRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
 		return
 	}
 
 	// Validate required fields
 	if req.StreamName == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "stream_name is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "stream_name is required", nil)
 		return
 	}
 	if req.Topic == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "topic is required", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "topic is required", nil)
 		return
 	}
 
@@ -284,11 +284,11 @@ func (h *ReplayHandlers) StartReplay(w http.ResponseWriter, r *http.Request) {
 
 	// Validate mode-specific requirements
 	if mode == eventprocessor.ReplayModeSequence && req.StartSequence == 0 {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "start_sequence is required for sequence mode", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "start_sequence is required for sequence mode", nil)
 		return
 	}
 	if mode == eventprocessor.ReplayModeTime && req.StartTime == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "start_time is required for time mode", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "start_time is required for time mode", nil)
 		return
 	}
 
@@ -298,7 +298,7 @@ func (h *ReplayHandlers) StartReplay(w http.ResponseWriter, r *http.Request) {
 		var err error
 		startTime, err = time.Parse(time.RFC3339, req.StartTime)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid start_time format (use RFC3339)", err)
+			respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid start_time format (use RFC3339)", err)
 			return
 		}
 	}
@@ -306,7 +306,7 @@ func (h *ReplayHandlers) StartReplay(w http.ResponseWriter, r *http.Request) {
 		var err error
 		stopTime, err = time.Parse(time.RFC3339, req.StopTime)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid stop_time format (use RFC3339)", err)
+			respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid stop_time format (use RFC3339)", err)
 			return
 		}
 	}
@@ -324,14 +324,14 @@ func (h *ReplayHandlers) StartReplay(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.checkpointStore.Save(ctx, checkpoint); err != nil {
 		logging.Error().Err(err).Msg("Failed to create replay checkpoint")
-		respondError(w, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to initialize replay", err)
+		respondError(w, r, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to initialize replay", err)
 		return
 	}
 
 	// Get the saved checkpoint to return ID
 	saved, err := h.checkpointStore.Get(ctx, checkpoint.ConsumerName, checkpoint.StreamName)
 	if err != nil || saved == nil {
-		respondError(w, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to retrieve checkpoint", err)
+		respondError(w, r, http.StatusInternalServerError, "REPLAY_ERROR", "Failed to retrieve checkpoint", err)
 		return
 	}
 
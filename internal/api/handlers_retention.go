@@ -0,0 +1,71 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// RetentionPlanner reports what the retention policies would prune.
+// *database.Pruner implements it.
+type RetentionPlanner interface {
+	Plan(ctx context.Context) (*database.RetentionPlan, error)
+}
+
+// SetRetentionPlanner enables GET /api/v1/admin/db/retention/dry-run.
+func (h *Handler) SetRetentionPlanner(planner RetentionPlanner) {
+	h.retention = planner
+}
+
+// OnDataPruned clears the analytics cache and invalidates analytics ETags
+// after the retention job deleted rows, like a sync does.
+func (h *Handler) OnDataPruned() {
+	h.ClearCache()
+	h.bumpSyncGeneration()
+}
+
+// RetentionDryRun handles GET /api/v1/admin/db/retention/dry-run
+// Reports, per table, how many rows the configured RETENTION_* limits would
+// prune right now and the time range they span, plus the geolocations no
+// playback would reference afterwards. Nothing is deleted, and the report
+// is available whether or not RETENTION_ENABLED runs the pruning job.
+//
+// @Summary Preview retention pruning
+// @Description Reports what the configured retention policies would delete now, without deleting anything.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=database.RetentionPlan} "Retention plan"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 500 {object} models.APIResponse "Plan failed"
+// @Failure 503 {object} models.APIResponse "Retention not available"
+// @Router /admin/db/retention/dry-run [get]
+func (h *Handler) RetentionDryRun(w http.ResponseWriter, r *http.Request) {
+	if h.retention == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Retention not available", nil)
+		return
+	}
+
+	plan, err := h.retention.Plan(r.Context())
+	if err != nil {
+		logging.Error().Err(err).Msg("Retention dry run failed")
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to plan retention", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     plan,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
@@ -0,0 +1,65 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/database"
+)
+
+type stubRetentionPlanner struct {
+	plan *database.RetentionPlan
+	err  error
+}
+
+func (s stubRetentionPlanner) Plan(context.Context) (*database.RetentionPlan, error) {
+	return s.plan, s.err
+}
+
+func TestRetentionDryRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		planner    RetentionPlanner
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "not configured", wantStatus: http.StatusServiceUnavailable},
+		{
+			name: "plan",
+			planner: stubRetentionPlanner{plan: &database.RetentionPlan{
+				Tables:               []database.TableRetentionPlan{{Table: "playback_events", TotalRows: 10, PruneRows: 4}},
+				OrphanedGeolocations: 2,
+			}},
+			wantStatus: http.StatusOK,
+			wantBody:   `"prune_rows":4`,
+		},
+		{name: "plan fails", planner: stubRetentionPlanner{err: errors.New("boom")}, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{}
+			if tt.planner != nil {
+				h.SetRetentionPlanner(tt.planner)
+			}
+			rec := httptest.NewRecorder()
+			h.RetentionDryRun(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/db/retention/dry-run", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -47,9 +47,9 @@ type FuzzyUserSearchResponse struct {
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Database error"
 // @Failure 503 {object} models.APIResponse "Database unavailable"
-// @Router /api/v1/search/fuzzy [get]
+// @Router /search/fuzzy [get]
 func (h *Handler) FuzzySearch(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -58,11 +58,11 @@ func (h *Handler) FuzzySearch(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameter (required)
 	query := r.URL.Query().Get("q")
 	if query == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' is required", nil)
 		return
 	}
 	if len(query) > 200 {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' must be 200 characters or less", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' must be 200 characters or less", nil)
 		return
 	}
 
@@ -71,7 +71,7 @@ func (h *Handler) FuzzySearch(w http.ResponseWriter, r *http.Request) {
 	if minScoreStr := r.URL.Query().Get("min_score"); minScoreStr != "" {
 		parsed, err := strconv.Atoi(minScoreStr)
 		if err != nil || parsed < 0 || parsed > 100 {
-			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "min_score must be an integer between 0 and 100", nil)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "min_score must be an integer between 0 and 100", nil)
 			return
 		}
 		minScore = parsed
@@ -82,7 +82,7 @@ func (h *Handler) FuzzySearch(w http.ResponseWriter, r *http.Request) {
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		parsed, err := strconv.Atoi(limitStr)
 		if err != nil || parsed < 1 || parsed > 100 {
-			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "limit must be an integer between 1 and 100", nil)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "limit must be an integer between 1 and 100", nil)
 			return
 		}
 		limit = parsed
@@ -91,7 +91,7 @@ func (h *Handler) FuzzySearch(w http.ResponseWriter, r *http.Request) {
 	// Perform fuzzy search
 	results, err := h.db.FuzzySearchPlaybacks(r.Context(), query, minScore, limit)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Search failed", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Search failed", err)
 		return
 	}
 
@@ -135,9 +135,9 @@ func (h *Handler) FuzzySearch(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Database error"
 // @Failure 503 {object} models.APIResponse "Database unavailable"
-// @Router /api/v1/search/users [get]
+// @Router /search/users [get]
 func (h *Handler) FuzzySearchUsers(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -146,11 +146,11 @@ func (h *Handler) FuzzySearchUsers(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameter (required)
 	query := r.URL.Query().Get("q")
 	if query == "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' is required", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' is required", nil)
 		return
 	}
 	if len(query) > 200 {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' must be 200 characters or less", nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'q' must be 200 characters or less", nil)
 		return
 	}
 
@@ -159,7 +159,7 @@ func (h *Handler) FuzzySearchUsers(w http.ResponseWriter, r *http.Request) {
 	if minScoreStr := r.URL.Query().Get("min_score"); minScoreStr != "" {
 		parsed, err := strconv.Atoi(minScoreStr)
 		if err != nil || parsed < 0 || parsed > 100 {
-			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "min_score must be an integer between 0 and 100", nil)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "min_score must be an integer between 0 and 100", nil)
 			return
 		}
 		minScore = parsed
@@ -170,7 +170,7 @@ func (h *Handler) FuzzySearchUsers(w http.ResponseWriter, r *http.Request) {
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		parsed, err := strconv.Atoi(limitStr)
 		if err != nil || parsed < 1 || parsed > 100 {
-			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "limit must be an integer between 1 and 100", nil)
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "limit must be an integer between 1 and 100", nil)
 			return
 		}
 		limit = parsed
@@ -179,7 +179,7 @@ func (h *Handler) FuzzySearchUsers(w http.ResponseWriter, r *http.Request) {
 	// Perform fuzzy user search
 	results, err := h.db.FuzzySearchUsers(r.Context(), query, minScore, limit)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "User search failed", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "User search failed", err)
 		return
 	}
 
@@ -25,7 +25,7 @@ import (
 // @Success 200 {object} models.APIResponse "Seeding successful"
 // @Failure 403 {object} models.APIResponse "Forbidden in production environment"
 // @Failure 500 {object} models.APIResponse "Seeding failed"
-// @Router /api/v1/admin/seed [post]
+// @Router /admin/seed [post]
 func (h *Handler) SeedMockData(w http.ResponseWriter, r *http.Request) {
 	// Security: Only allow seeding in non-production environments
 	// Check multiple indicators for production environment
@@ -46,7 +46,7 @@ func (h *Handler) SeedMockData(w http.ResponseWriter, r *http.Request) {
 			Str("node_env", nodeEnv).
 			Str("auth_mode", authMode).
 			Msg("Blocked seed attempt in production environment")
-		respondError(w, http.StatusForbidden, "SEED_FORBIDDEN", "Seeding is not allowed in production environments", nil)
+		respondError(w, r, http.StatusForbidden, "SEED_FORBIDDEN", "Seeding is not allowed in production environments", nil)
 		return
 	}
 
@@ -58,7 +58,7 @@ func (h *Handler) SeedMockData(w http.ResponseWriter, r *http.Request) {
 			Bool("is_ci", isCI).
 			Bool("allow_seed", allowSeed).
 			Msg("Seed endpoint requires explicit opt-in outside CI/development")
-		respondError(w, http.StatusForbidden, "SEED_NOT_ALLOWED", "Seeding requires ALLOW_SEED_DATA=true or CI environment", nil)
+		respondError(w, r, http.StatusForbidden, "SEED_NOT_ALLOWED", "Seeding requires ALLOW_SEED_DATA=true or CI environment", nil)
 		return
 	}
 
@@ -75,7 +75,7 @@ func (h *Handler) SeedMockData(w http.ResponseWriter, r *http.Request) {
 	// Call the database seed method
 	if err := h.db.SeedMockData(ctx); err != nil {
 		logging.Error().Err(err).Msg("Failed to seed mock data")
-		respondError(w, http.StatusInternalServerError, "SEED_FAILED", "Failed to seed mock data: "+err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "SEED_FAILED", "Failed to seed mock data: "+err.Error(), err)
 		return
 	}
 
@@ -51,25 +51,25 @@ func (h *Handler) CreateServer(w http.ResponseWriter, r *http.Request) {
 
 	var req models.CreateMediaServerRequest
 	if err := h.parseAndValidateRequest(r, &req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), err)
 		return
 	}
 
 	encryptor, err := h.getCredentialEncryptor()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Failed to initialize encryption", err)
+		respondError(w, r, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Failed to initialize encryption", err)
 		return
 	}
 
 	urlEncrypted, tokenEncrypted, err := h.encryptCredentials(encryptor, req.URL, req.Token)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "ENCRYPTION_ERROR", err.Error(), err)
+		respondError(w, r, http.StatusInternalServerError, "ENCRYPTION_ERROR", err.Error(), err)
 		return
 	}
 
 	settingsJSON, err := h.serializeSettings(req.Settings)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_SETTINGS", "Failed to serialize settings", err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_SETTINGS", "Failed to serialize settings", err)
 		return
 	}
 
@@ -77,7 +77,7 @@ func (h *Handler) CreateServer(w http.ResponseWriter, r *http.Request) {
 	server := h.buildServerFromRequest(&req, urlEncrypted, tokenEncrypted, settingsJSON, userID)
 
 	if err := h.db.CreateMediaServer(ctx, server); err != nil {
-		h.handleServerCreateError(w, err)
+		h.handleServerCreateError(w, r, err)
 		return
 	}
 
@@ -115,24 +115,24 @@ func (h *Handler) GetServer(w http.ResponseWriter, r *http.Request) {
 	serverID := chi.URLParam(r, "id")
 
 	if serverID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Server ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Server ID is required", nil)
 		return
 	}
 
 	server, err := h.db.GetMediaServer(ctx, serverID)
 	if err != nil {
 		if errors.Is(err, database.ErrServerNotFound) {
-			respondError(w, http.StatusNotFound, "NOT_FOUND", "Server not found", nil)
+			respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Server not found", nil)
 			return
 		}
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve server", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve server", err)
 		return
 	}
 
 	// Decrypt URL and token for display
 	response, err := h.serverToResponse(server)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DECRYPTION_ERROR", "Failed to decrypt credentials", err)
+		respondError(w, r, http.StatusInternalServerError, "DECRYPTION_ERROR", "Failed to decrypt credentials", err)
 		return
 	}
 
@@ -167,42 +167,42 @@ func (h *Handler) UpdateServer(w http.ResponseWriter, r *http.Request) {
 	serverID := chi.URLParam(r, "id")
 
 	if serverID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Server ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Server ID is required", nil)
 		return
 	}
 
 	server, err := h.db.GetMediaServer(ctx, serverID)
 	if err != nil {
-		h.handleServerGetError(w, err)
+		h.handleServerGetError(w, r, err)
 		return
 	}
 
 	if server.Source == models.ServerSourceEnv {
-		respondError(w, http.StatusForbidden, "IMMUTABLE", "Cannot modify server configured via environment variables", nil)
+		respondError(w, r, http.StatusForbidden, "IMMUTABLE", "Cannot modify server configured via environment variables", nil)
 		return
 	}
 
 	var req models.UpdateMediaServerRequest
 	if err := h.parseAndValidateRequest(r, &req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), err)
 		return
 	}
 
 	encryptor, err := h.getCredentialEncryptor()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Failed to initialize encryption", err)
+		respondError(w, r, http.StatusInternalServerError, "ENCRYPTION_ERROR", "Failed to initialize encryption", err)
 		return
 	}
 
 	changes := make(map[string]any)
 	decryptedURL, decryptedToken, err := h.applyServerUpdates(server, &req, encryptor, changes)
 	if err != nil {
-		h.handleServerUpdateError(w, err)
+		h.handleServerUpdateError(w, r, err)
 		return
 	}
 
 	if err := h.db.UpdateMediaServer(ctx, server); err != nil {
-		h.handleServerUpdateError(w, err)
+		h.handleServerUpdateError(w, r, err)
 		return
 	}
 
@@ -236,21 +236,21 @@ func (h *Handler) DeleteServer(w http.ResponseWriter, r *http.Request) {
 	serverID := chi.URLParam(r, "id")
 
 	if serverID == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_ID", "Server ID is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_ID", "Server ID is required", nil)
 		return
 	}
 
 	// Delete from database
 	if err := h.db.DeleteMediaServer(ctx, serverID); err != nil {
 		if errors.Is(err, database.ErrServerNotFound) {
-			respondError(w, http.StatusNotFound, "NOT_FOUND", "Server not found", nil)
+			respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Server not found", nil)
 			return
 		}
 		if errors.Is(err, database.ErrImmutableServer) {
-			respondError(w, http.StatusForbidden, "IMMUTABLE", "Cannot delete server configured via environment variables", nil)
+			respondError(w, r, http.StatusForbidden, "IMMUTABLE", "Cannot delete server configured via environment variables", nil)
 			return
 		}
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete server", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete server", err)
 		return
 	}
 
@@ -296,7 +296,7 @@ func (h *Handler) DeleteServer(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) TestServerConnection(w http.ResponseWriter, r *http.Request) {
 	var req models.MediaServerTestRequest
 	if err := h.parseAndValidateRequest(r, &req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), err)
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), err)
 		return
 	}
 
@@ -333,7 +333,7 @@ func (h *Handler) ListDBServers(w http.ResponseWriter, r *http.Request) {
 
 	servers, err := h.db.ListMediaServers(ctx, platform, enabledOnly)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list servers", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to list servers", err)
 		return
 	}
 
@@ -508,30 +508,30 @@ func (h *Handler) buildServerFromRequest(req *models.CreateMediaServerRequest, u
 }
 
 // handleServerCreateError handles errors from CreateMediaServer.
-func (h *Handler) handleServerCreateError(w http.ResponseWriter, err error) {
+func (h *Handler) handleServerCreateError(w http.ResponseWriter, r *http.Request, err error) {
 	if errors.Is(err, database.ErrServerIDConflict) {
-		respondError(w, http.StatusConflict, "SERVER_EXISTS", "A server with this URL already exists", err)
+		respondError(w, r, http.StatusConflict, "SERVER_EXISTS", "A server with this URL already exists", err)
 		return
 	}
-	respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create server", err)
+	respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create server", err)
 }
 
 // handleServerGetError handles errors from GetMediaServer.
-func (h *Handler) handleServerGetError(w http.ResponseWriter, err error) {
+func (h *Handler) handleServerGetError(w http.ResponseWriter, r *http.Request, err error) {
 	if errors.Is(err, database.ErrServerNotFound) {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Server not found", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Server not found", nil)
 		return
 	}
-	respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve server", err)
+	respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve server", err)
 }
 
 // handleServerUpdateError handles errors from UpdateMediaServer.
-func (h *Handler) handleServerUpdateError(w http.ResponseWriter, err error) {
+func (h *Handler) handleServerUpdateError(w http.ResponseWriter, r *http.Request, err error) {
 	if errors.Is(err, database.ErrServerIDConflict) {
-		respondError(w, http.StatusConflict, "SERVER_EXISTS", "A server with this URL already exists", err)
+		respondError(w, r, http.StatusConflict, "SERVER_EXISTS", "A server with this URL already exists", err)
 		return
 	}
-	respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update server", err)
+	respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update server", err)
 }
 
 // createServerAuditLog creates an audit log entry (best effort).
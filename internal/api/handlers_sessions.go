@@ -0,0 +1,84 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// SessionStitchResult is the response of POST /api/v1/admin/sessions/stitch.
+type SessionStitchResult struct {
+	// PlaybacksUpdated is the number of playbacks whose logical session changed
+	PlaybacksUpdated int64 `json:"playbacks_updated"`
+	// Gap is the SYNC_SESSION_STITCH_GAP the history was stitched with
+	Gap string `json:"gap"`
+}
+
+// =============================================================================
+// Session Stitching API Handlers
+// =============================================================================
+
+// SessionsStitch handles POST /api/v1/admin/sessions/stitch
+// Stitches all playback history into logical viewing sessions again with
+// the current SYNC_SESSION_STITCH_GAP, e.g. after changing the gap. New
+// playbacks are stitched after each sync without this. Analytics caches
+// are cleared afterwards.
+//
+// @Summary Restitch logical viewing sessions
+// @Description Recomputes the logical viewing session of every playback with the current SYNC_SESSION_STITCH_GAP.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=SessionStitchResult} "Playbacks whose logical session changed"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 409 {object} models.APIResponse "Session stitching is disabled"
+// @Failure 500 {object} models.APIResponse "Stitching failed"
+// @Router /admin/sessions/stitch [post]
+func (h *Handler) SessionsStitch(w http.ResponseWriter, r *http.Request) {
+	gap := h.config.Sync.SessionStitchGap
+	if gap <= 0 {
+		respondError(w, r, http.StatusConflict, ErrCodeInvalidState,
+			"Session stitching is disabled (SYNC_SESSION_STITCH_GAP is 0)", nil)
+		return
+	}
+
+	changed, err := h.db.StitchSessions(r.Context(), gap, true)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to stitch playback sessions")
+		respondError(w, r, http.StatusInternalServerError, ErrCodeDatabaseError, "Failed to stitch playback sessions", err)
+		return
+	}
+
+	h.ClearCache()
+	h.bumpSyncGeneration()
+
+	result := SessionStitchResult{PlaybacksUpdated: changed, Gap: gap.String()}
+	description := fmt.Sprintf("Restitched playback sessions with a %s gap", result.Gap)
+	hctx := GetHandlerContext(r)
+	logging.Info().
+		Str("admin", hctx.Username).
+		Int64("playbacks", changed).
+		Msg(description)
+	if h.auditLogger != nil {
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, "sessions.stitch", description,
+			map[string]interface{}{"gap": result.Gap, "playbacks_updated": changed})
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     result,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
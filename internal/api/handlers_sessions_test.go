@@ -0,0 +1,74 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestSessionsStitch_WithDB(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	handler := setupTestHandlerWithDB(t, db)
+
+	// One movie paused and resumed an hour later
+	ratingKey := "m1"
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	var events []*models.PlaybackEvent
+	for i, percent := range []int{40, 100} {
+		started := start.Add(time.Duration(i) * 90 * time.Minute)
+		stopped := started.Add(30 * time.Minute)
+		events = append(events, &models.PlaybackEvent{
+			SessionKey:      "stitch-" + string(rune('a'+i)),
+			StartedAt:       started,
+			StoppedAt:       &stopped,
+			UserID:          1,
+			Username:        "testuser",
+			IPAddress:       "198.51.100.7",
+			MediaType:       "movie",
+			Title:           "Movie",
+			RatingKey:       &ratingKey,
+			PercentComplete: percent,
+		})
+	}
+	if _, _, err := db.InsertPlaybackEventsBatch(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+
+	stitch := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/sessions/stitch", http.NoBody)
+		w := httptest.NewRecorder()
+		handler.SessionsStitch(w, req)
+		return w
+	}
+
+	if w := stitch(); w.Code != http.StatusConflict {
+		t.Errorf("disabled status = %d, want 409 (body: %s)", w.Code, w.Body.String())
+	}
+
+	handler.config.Sync.SessionStitchGap = 2 * time.Hour
+	w := stitch()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data SessionStitchResult `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Data.PlaybacksUpdated != 2 || resp.Data.Gap != "2h0m0s" {
+		t.Errorf("result = %+v, want 2 playbacks updated with a 2h0m0s gap", resp.Data)
+	}
+}
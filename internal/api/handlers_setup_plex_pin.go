@@ -0,0 +1,248 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// handlers_setup_plex_pin.go - Plex sign-in for the setup wizard
+//
+// Instead of copying a PLEX_TOKEN by hand, the user signs in to plex.tv with
+// a PIN, the same flow Overseerr and other Plex companions use:
+//  1. POST /api/v1/setup/plex/pin creates a PIN and returns its auth URL
+//  2. The user opens the URL and approves the sign-in
+//  3. GET /api/v1/setup/plex/pin/{id} is polled until the PIN is approved,
+//     then lists the user's servers and which of their addresses answer
+//  4. POST /api/v1/setup/media-server with plex_pin_id and plex_server_id
+//     keeps the chosen server; its token never leaves the server
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	syncpkg "github.com/tomtom215/cartographus/internal/sync"
+)
+
+const (
+	// setupMaxPlexPINs caps the unexpired PINs the unauthenticated setup
+	// API keeps at once.
+	setupMaxPlexPINs = 10
+
+	// setupProbeTimeout bounds each connection probe of a discovered server.
+	setupProbeTimeout = 5 * time.Second
+)
+
+// setupPlexPIN is a PIN created by the wizard. Once it is approved, the
+// discovered servers and their access tokens are kept for SaveMediaServer.
+type setupPlexPIN struct {
+	expiresAt time.Time
+	servers   []SetupPlexServer
+	tokens    map[string]string // server ID -> access token
+}
+
+// SetupPlexPIN is the response of POST /api/v1/setup/plex/pin.
+type SetupPlexPIN struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	AuthURL   string    `json:"auth_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SetupPlexPINStatus is the response of GET /api/v1/setup/plex/pin/{id}.
+// Servers is set once the PIN is approved.
+type SetupPlexPINStatus struct {
+	Authorized bool              `json:"authorized"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	Servers    []SetupPlexServer `json:"servers,omitempty"`
+}
+
+// SetupPlexServer is a Plex server the signed-in user can access.
+type SetupPlexServer struct {
+	ID          string                `json:"id"` // machineIdentifier, passed back as plex_server_id
+	Name        string                `json:"name"`
+	Version     string                `json:"version"`
+	Owned       bool                  `json:"owned"`
+	Connections []SetupPlexConnection `json:"connections"`
+}
+
+// SetupPlexConnection is one address of a Plex server. Reachable reports
+// whether this Cartographus instance could connect to it.
+type SetupPlexConnection struct {
+	URI       string `json:"uri"`
+	Local     bool   `json:"local"`
+	Relay     bool   `json:"relay"`
+	Reachable bool   `json:"reachable"`
+}
+
+// CreatePlexPIN handles POST /api/v1/setup/plex/pin
+// Creates a plex.tv sign-in PIN. The user approves it at auth_url before
+// expires_at; poll GET /api/v1/setup/plex/pin/{id} meanwhile.
+func (s *SetupWizard) CreatePlexPIN(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	now := time.Now()
+	for id, pin := range s.plexPINs {
+		if pin.servers == nil && now.After(pin.expiresAt) {
+			delete(s.plexPINs, id)
+		}
+	}
+	full := len(s.plexPINs) >= setupMaxPlexPINs
+	s.mu.Unlock()
+	if full {
+		respondError(w, r, http.StatusTooManyRequests, "TOO_MANY_PINS",
+			"Too many Plex sign-ins in progress; wait for one to expire", nil)
+		return
+	}
+
+	client := syncpkg.NewPlexTVClient(s.plexTV)
+	ctx, cancel := context.WithTimeout(r.Context(), setupConnectionTimeout)
+	defer cancel()
+	pin, err := client.CreatePIN(ctx)
+	if err != nil {
+		logging.Warn().Err(err).Msg("Setup Plex PIN creation failed")
+		respondError(w, r, http.StatusBadGateway, "PLEX_TV_FAILED", "Could not reach plex.tv: "+err.Error(), nil)
+		return
+	}
+
+	s.mu.Lock()
+	s.plexPINs[pin.ID] = &setupPlexPIN{expiresAt: pin.ExpiresAt}
+	s.mu.Unlock()
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data: SetupPlexPIN{
+			ID:        pin.ID,
+			Code:      pin.Code,
+			AuthURL:   client.PINAuthURL(pin),
+			ExpiresAt: pin.ExpiresAt,
+		},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// CheckPlexPIN handles GET /api/v1/setup/plex/pin/{id}
+// Reports whether the PIN has been approved. Once it has, the user's Plex
+// servers are listed with a reachability probe of each connection. A PIN
+// that expired unapproved answers 410 PIN_EXPIRED.
+func (s *SetupWizard) CheckPlexPIN(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid PIN ID", nil)
+		return
+	}
+
+	s.mu.Lock()
+	entry := s.plexPINs[id]
+	var discovered []SetupPlexServer
+	if entry != nil {
+		discovered = entry.servers
+	}
+	s.mu.Unlock()
+	if entry == nil {
+		respondError(w, r, http.StatusNotFound, "PIN_NOT_FOUND", "Unknown Plex PIN; create one with POST /api/v1/setup/plex/pin", nil)
+		return
+	}
+	if discovered != nil {
+		s.respondPlexPINStatus(w, &SetupPlexPINStatus{Authorized: true, ExpiresAt: entry.expiresAt, Servers: discovered})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), setupConnectionTimeout)
+	defer cancel()
+	pin, err := syncpkg.NewPlexTVClient(s.plexTV).CheckPIN(ctx, id)
+	if errors.Is(err, syncpkg.ErrPlexPINExpired) {
+		s.mu.Lock()
+		delete(s.plexPINs, id)
+		s.mu.Unlock()
+		respondError(w, r, http.StatusGone, "PIN_EXPIRED", "The Plex PIN expired before it was approved; create a new one", nil)
+		return
+	}
+	if err != nil {
+		logging.Warn().Err(err).Int64("pin_id", id).Msg("Setup Plex PIN check failed")
+		respondError(w, r, http.StatusBadGateway, "PLEX_TV_FAILED", "Could not reach plex.tv: "+err.Error(), nil)
+		return
+	}
+	if pin.AuthToken == "" {
+		s.respondPlexPINStatus(w, &SetupPlexPINStatus{ExpiresAt: pin.ExpiresAt})
+		return
+	}
+
+	userCfg := s.plexTV
+	userCfg.Token = pin.AuthToken
+	resources, err := syncpkg.NewPlexTVClient(userCfg).ListServers(ctx)
+	if err != nil {
+		logging.Warn().Err(err).Msg("Setup Plex server discovery failed")
+		respondError(w, r, http.StatusBadGateway, "PLEX_TV_FAILED", "Could not list Plex servers: "+err.Error(), nil)
+		return
+	}
+	servers, tokens := s.probePlexServers(r.Context(), resources)
+
+	s.mu.Lock()
+	entry.servers = servers
+	entry.tokens = tokens
+	s.mu.Unlock()
+
+	logging.Info().Int("servers", len(servers)).Msg("Setup Plex sign-in approved")
+	s.respondPlexPINStatus(w, &SetupPlexPINStatus{Authorized: true, ExpiresAt: pin.ExpiresAt, Servers: servers})
+}
+
+func (s *SetupWizard) respondPlexPINStatus(w http.ResponseWriter, status *SetupPlexPINStatus) {
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     status,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// probePlexServers connects to every address of every server in parallel,
+// using the server's own access token, and records which ones answer.
+func (s *SetupWizard) probePlexServers(ctx context.Context, resources []syncpkg.PlexServerResource) ([]SetupPlexServer, map[string]string) {
+	servers := make([]SetupPlexServer, len(resources))
+	tokens := make(map[string]string, len(resources))
+
+	var wg sync.WaitGroup
+	for i := range resources {
+		res := &resources[i]
+		tokens[res.ClientIdentifier] = res.AccessToken
+		servers[i] = SetupPlexServer{
+			ID:          res.ClientIdentifier,
+			Name:        res.Name,
+			Version:     res.ProductVersion,
+			Owned:       res.Owned,
+			Connections: make([]SetupPlexConnection, len(res.Connections)),
+		}
+		for j, conn := range res.Connections {
+			servers[i].Connections[j] = SetupPlexConnection{URI: conn.URI, Local: conn.Local, Relay: conn.Relay}
+			wg.Add(1)
+			go func(target *SetupPlexConnection, token string) {
+				defer wg.Done()
+				probeCtx, cancel := context.WithTimeout(ctx, setupProbeTimeout)
+				defer cancel()
+				_, err := s.identify(probeCtx, "plex", target.URI, token)
+				target.Reachable = err == nil
+			}(&servers[i].Connections[j], res.AccessToken)
+		}
+	}
+	wg.Wait()
+	return servers, tokens
+}
+
+// plexServerToken returns the access token of a server discovered by an
+// approved PIN.
+func (s *SetupWizard) plexServerToken(pinID int64, serverID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := s.plexPINs[pinID]
+	if entry == nil {
+		return "", false
+	}
+	token, ok := entry.tokens[serverID]
+	return token, ok && token != ""
+}
@@ -0,0 +1,329 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// handlers_setup_wizard.go - First-run setup wizard
+//
+// When no media server is configured and no auth mode is chosen, the server
+// starts in setup mode and serves only these endpoints (no authentication):
+//   - GET  /api/v1/setup/status          - Wizard progress
+//   - POST /api/v1/setup/test-connection - Test a media server connection
+//   - POST /api/v1/setup/plex/pin        - Start a plex.tv PIN sign-in
+//   - GET  /api/v1/setup/plex/pin/{id}   - Poll the PIN; lists servers once approved
+//   - POST /api/v1/setup/media-server    - Test and keep a media server connection
+//   - POST /api/v1/setup/auth            - Choose the auth mode and admin account
+//   - POST /api/v1/setup/complete        - Write config.yaml and leave setup mode
+//
+// Once setup completes the endpoints answer 410 Gone, and the normal router
+// that takes over does not register them at all.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	syncpkg "github.com/tomtom215/cartographus/internal/sync"
+)
+
+const (
+	// setupConnectionTimeout bounds a media server connection test.
+	setupConnectionTimeout = 10 * time.Second
+
+	// setupMaxBodySize limits setup request bodies, which hold a few short fields.
+	setupMaxBodySize int64 = 64 << 10
+)
+
+// ServerIdentifier connects to a media server and returns its name and
+// version. syncpkg.IdentifyServer implements it.
+type ServerIdentifier func(ctx context.Context, platform, url, token string) (*syncpkg.ServerInfo, error)
+
+// SetupWizard serves the setup mode API. It keeps the choices made so far
+// in memory and writes them to the config file when setup completes.
+type SetupWizard struct {
+	configPath  string
+	environment string
+	identify    ServerIdentifier
+	plexTV      syncpkg.PlexTVClientConfig
+
+	mu         sync.Mutex
+	server     *config.SetupMediaServer
+	serverInfo *syncpkg.ServerInfo
+	auth       *config.SetupAuth
+	plexPINs   map[int64]*setupPlexPIN
+	completed  bool
+	done       chan struct{}
+}
+
+// SetupWizardStatus is the response of GET /api/v1/setup/status.
+type SetupWizardStatus struct {
+	SetupRequired bool               `json:"setup_required"`
+	ConfigPath    string             `json:"config_path"`
+	AuthModes     []string           `json:"auth_modes"`
+	MediaServer   *SetupServerStatus `json:"media_server,omitempty"`
+	AuthMode      string             `json:"auth_mode,omitempty"`
+}
+
+// SetupServerStatus describes the media server chosen so far. The token is
+// never returned.
+type SetupServerStatus struct {
+	Platform   string `json:"platform"`
+	URL        string `json:"url"`
+	ServerName string `json:"server_name"`
+	Version    string `json:"version"`
+}
+
+// NewSetupWizard creates the wizard. Settings are written to configPath;
+// environment is the ENVIRONMENT setting, which decides whether auth mode
+// none is allowed.
+func NewSetupWizard(configPath, environment string, identify ServerIdentifier) *SetupWizard {
+	return &SetupWizard{
+		configPath:  configPath,
+		environment: environment,
+		identify:    identify,
+		plexPINs:    make(map[int64]*setupPlexPIN),
+		done:        make(chan struct{}),
+	}
+}
+
+// SetPlexTV sets the client identifier (and, in tests, the base URL) used
+// for the plex.tv PIN sign-in. Must be called before Handler.
+func (s *SetupWizard) SetPlexTV(cfg syncpkg.PlexTVClientConfig) {
+	s.plexTV = cfg
+}
+
+// Done is closed once setup completes and the config file is written.
+func (s *SetupWizard) Done() <-chan struct{} {
+	return s.done
+}
+
+// Handler returns the router for setup mode. Every path outside
+// /api/v1/setup answers 503 SETUP_REQUIRED.
+func (s *SetupWizard) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Use(RequestIDWithLogging())
+	r.Use(chimiddleware.Recoverer)
+	r.Use(APISecurityHeaders())
+	r.Use(NewBodySizeLimiter(setupMaxBodySize).Middleware())
+
+	r.Route("/api/v1/setup", func(r chi.Router) {
+		r.Use(s.rejectWhenCompleted)
+		r.Get("/status", s.Status)
+		r.Post("/test-connection", s.TestConnection)
+		r.Post("/plex/pin", s.CreatePlexPIN)
+		r.Get("/plex/pin/{id}", s.CheckPlexPIN)
+		r.Post("/media-server", s.SaveMediaServer)
+		r.Post("/auth", s.SaveAuth)
+		r.Post("/complete", s.Complete)
+	})
+
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		respondError(w, r, http.StatusServiceUnavailable, "SETUP_REQUIRED",
+			"Cartographus is not configured yet; complete setup at /api/v1/setup", nil)
+	})
+	return r
+}
+
+// rejectWhenCompleted answers 410 Gone once setup has completed, for the
+// moments before the normal router takes over.
+func (s *SetupWizard) rejectWhenCompleted(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		completed := s.completed
+		s.mu.Unlock()
+		if completed {
+			respondError(w, r, http.StatusGone, "SETUP_COMPLETE", "Setup has already been completed", nil)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Status handles GET /api/v1/setup/status
+// Returns the choices made so far and the auth modes the wizard offers.
+func (s *SetupWizard) Status(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status := SetupWizardStatus{
+		SetupRequired: true,
+		ConfigPath:    s.configPath,
+		AuthModes:     config.SetupAuthModes,
+	}
+	if s.server != nil {
+		status.MediaServer = &SetupServerStatus{
+			Platform:   s.server.Platform,
+			URL:        s.server.URL,
+			ServerName: s.serverInfo.Name,
+			Version:    s.serverInfo.Version,
+		}
+	}
+	if s.auth != nil {
+		status.AuthMode = s.auth.Mode
+	}
+	s.mu.Unlock()
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     status,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// TestConnection handles POST /api/v1/setup/test-connection
+// Connects to a media server with the given token and returns its name and
+// version, without keeping the settings.
+//
+// Request body: {"platform": "plex", "url": "http://plex:32400", "token": "..."}
+func (s *SetupWizard) TestConnection(w http.ResponseWriter, r *http.Request) {
+	server, info, ok := s.testConnection(w, r)
+	if !ok {
+		return
+	}
+	logging.Info().Str("platform", server.Platform).Str("server_name", info.Name).Msg("Setup connection test succeeded")
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     info,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// SaveMediaServer handles POST /api/v1/setup/media-server
+// Tests the connection like test-connection and, if it succeeds, keeps the
+// settings for the config file. A later call replaces them.
+//
+// A Plex server found by the PIN sign-in can be chosen without a token:
+// {"platform": "plex", "url": "https://...", "plex_pin_id": 123, "plex_server_id": "..."}
+func (s *SetupWizard) SaveMediaServer(w http.ResponseWriter, r *http.Request) {
+	server, info, ok := s.testConnection(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.server = server
+	s.serverInfo = info
+	s.mu.Unlock()
+
+	logging.Info().Str("platform", server.Platform).Str("server_name", info.Name).Msg("Setup media server saved")
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     info,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// setupMediaServerRequest is a media server connection, optionally naming a
+// server found by the PIN sign-in instead of giving its token.
+type setupMediaServerRequest struct {
+	config.SetupMediaServer
+	PlexPINID    int64  `json:"plex_pin_id,omitempty"`
+	PlexServerID string `json:"plex_server_id,omitempty"`
+}
+
+// testConnection decodes and validates a media server request and connects
+// to the server. On failure it writes the error response and returns false.
+func (s *SetupWizard) testConnection(w http.ResponseWriter, r *http.Request) (*config.SetupMediaServer, *syncpkg.ServerInfo, bool) {
+	var req setupMediaServerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return nil, nil, false
+	}
+	server := req.SetupMediaServer
+	if server.Platform == "plex" && req.PlexPINID != 0 && server.Token == "" {
+		token, ok := s.plexServerToken(req.PlexPINID, req.PlexServerID)
+		if !ok {
+			respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR",
+				"plex_server_id is not a server found by this Plex sign-in", nil)
+			return nil, nil, false
+		}
+		server.Token = token
+	}
+	if err := server.Validate(); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		return nil, nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), setupConnectionTimeout)
+	defer cancel()
+	info, err := s.identify(ctx, server.Platform, server.URL, server.Token)
+	if err != nil {
+		logging.Warn().Err(err).Str("platform", server.Platform).Msg("Setup connection test failed")
+		respondError(w, r, http.StatusBadGateway, "CONNECTION_FAILED",
+			"Could not connect to the "+server.Platform+" server: "+err.Error(), nil)
+		return nil, nil, false
+	}
+	return &server, info, true
+}
+
+// SaveAuth handles POST /api/v1/setup/auth
+// Validates the auth mode (jwt, basic or none) and admin credentials against
+// the startup rules, including the password policy, and keeps them for the
+// config file.
+//
+// Request body: {"auth_mode": "jwt", "admin_username": "admin", "admin_password": "..."}
+func (s *SetupWizard) SaveAuth(w http.ResponseWriter, r *http.Request) {
+	var auth config.SetupAuth
+	if err := json.NewDecoder(r.Body).Decode(&auth); err != nil {
+		respondError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", nil)
+		return
+	}
+	if err := auth.Validate(s.environment); err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		return
+	}
+
+	s.mu.Lock()
+	s.auth = &auth
+	s.mu.Unlock()
+
+	logging.Info().Str("auth_mode", auth.Mode).Msg("Setup auth mode saved")
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     map[string]string{"auth_mode": auth.Mode},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// Complete handles POST /api/v1/setup/complete
+// Writes the media server and auth settings to the config file and closes
+// Done, after which the server starts normally. Both must have been saved.
+func (s *SetupWizard) Complete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.completed {
+		respondError(w, r, http.StatusGone, "SETUP_COMPLETE", "Setup has already been completed", nil)
+		return
+	}
+	if s.server == nil {
+		respondError(w, r, http.StatusConflict, "SETUP_INCOMPLETE", "Save a media server connection first", nil)
+		return
+	}
+	if s.auth == nil {
+		respondError(w, r, http.StatusConflict, "SETUP_INCOMPLETE", "Choose an auth mode first", nil)
+		return
+	}
+
+	if err := config.WriteSetupConfig(s.configPath, s.server, s.auth); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "CONFIG_WRITE_FAILED", "Failed to save configuration", err)
+		return
+	}
+	s.completed = true
+	close(s.done)
+
+	logging.Info().Str("path", s.configPath).Msg("Setup complete, configuration saved")
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     map[string]string{"config_path": s.configPath},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
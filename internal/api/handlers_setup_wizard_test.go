@@ -0,0 +1,209 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	syncpkg "github.com/tomtom215/cartographus/internal/sync"
+)
+
+// fakeIdentify accepts only the token "good-token".
+func fakeIdentify(ctx context.Context, platform, url, token string) (*syncpkg.ServerInfo, error) {
+	if token != "good-token" {
+		return nil, errors.New("unexpected status: 401 Unauthorized")
+	}
+	return &syncpkg.ServerInfo{Name: "Living Room", Version: "1.40.0"}, nil
+}
+
+func setupRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestSetupWizard_Flow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	wizard := NewSetupWizard(path, "development", fakeIdentify)
+	handler := wizard.Handler()
+
+	const plex = `{"platform":"plex","url":"http://plex:32400","token":"good-token"}`
+	const auth = `{"auth_mode":"basic","admin_username":"admin","admin_password":"Str0ng!Passw0rd#2026"}`
+
+	// Nothing outside the setup API is served
+	if w := setupRequest(t, handler, http.MethodGet, "/api/v1/stats", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /api/v1/stats status = %d, want 503", w.Code)
+	}
+
+	w := setupRequest(t, handler, http.MethodPost, "/api/v1/setup/test-connection", plex)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"server_name":"Living Room"`) {
+		t.Errorf("test-connection = %d %s", w.Code, w.Body.String())
+	}
+	w = setupRequest(t, handler, http.MethodPost, "/api/v1/setup/test-connection",
+		`{"platform":"plex","url":"http://plex:32400","token":"bad-token"}`)
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("test-connection with bad token status = %d, want 502", w.Code)
+	}
+	w = setupRequest(t, handler, http.MethodPost, "/api/v1/setup/test-connection",
+		`{"platform":"kodi","url":"http://kodi","token":"good-token"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("test-connection with unknown platform status = %d, want 400", w.Code)
+	}
+
+	// Completing before both steps are saved is refused
+	if w := setupRequest(t, handler, http.MethodPost, "/api/v1/setup/complete", ""); w.Code != http.StatusConflict {
+		t.Errorf("early complete status = %d, want 409", w.Code)
+	}
+
+	if w := setupRequest(t, handler, http.MethodPost, "/api/v1/setup/media-server", plex); w.Code != http.StatusOK {
+		t.Fatalf("media-server = %d %s", w.Code, w.Body.String())
+	}
+	w = setupRequest(t, handler, http.MethodPost, "/api/v1/setup/auth",
+		`{"auth_mode":"basic","admin_username":"admin","admin_password":"weak"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("auth with weak password status = %d, want 400", w.Code)
+	}
+	if w := setupRequest(t, handler, http.MethodPost, "/api/v1/setup/auth", auth); w.Code != http.StatusOK {
+		t.Fatalf("auth = %d %s", w.Code, w.Body.String())
+	}
+
+	w = setupRequest(t, handler, http.MethodGet, "/api/v1/setup/status", "")
+	if body := w.Body.String(); !strings.Contains(body, `"server_name":"Living Room"`) ||
+		!strings.Contains(body, `"auth_mode":"basic"`) || strings.Contains(body, "good-token") {
+		t.Errorf("status = %s", body)
+	}
+
+	if w := setupRequest(t, handler, http.MethodPost, "/api/v1/setup/complete", ""); w.Code != http.StatusOK {
+		t.Fatalf("complete = %d %s", w.Code, w.Body.String())
+	}
+	select {
+	case <-wizard.Done():
+	default:
+		t.Error("Done() not closed after setup completed")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("config file not written: %v", err)
+	}
+	for _, want := range []string{"auth_mode: basic", "url: http://plex:32400", "token: good-token"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("config file lacks %q:\n%s", want, data)
+		}
+	}
+
+	// The setup endpoints are closed once setup has completed
+	for _, path := range []string{"/api/v1/setup/status", "/api/v1/setup/complete"} {
+		method := http.MethodPost
+		if strings.HasSuffix(path, "status") {
+			method = http.MethodGet
+		}
+		if w := setupRequest(t, handler, method, path, ""); w.Code != http.StatusGone {
+			t.Errorf("%s after completion status = %d, want 410", path, w.Code)
+		}
+	}
+}
+
+func TestSetupWizard_PlexPINSignIn(t *testing.T) {
+	var approved atomic.Bool
+	plexTV := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Plex-Client-Identifier") != "cartographus-setup" {
+			t.Errorf("X-Plex-Client-Identifier = %q", r.Header.Get("X-Plex-Client-Identifier"))
+		}
+		expires := time.Now().Add(15 * time.Minute).UTC().Format(time.RFC3339)
+		switch r.URL.Path {
+		case "/api/v2/pins":
+			w.Write([]byte(`{"id": 7, "code": "wxyz9876", "expiresAt": "` + expires + `"}`))
+		case "/api/v2/pins/7":
+			token := "null"
+			if approved.Load() {
+				token = `"user-token"`
+			}
+			w.Write([]byte(`{"id": 7, "code": "wxyz9876", "expiresAt": "` + expires + `", "authToken": ` + token + `}`))
+		case "/api/v2/pins/8":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v2/resources":
+			w.Write([]byte(`[{"name": "Living Room", "clientIdentifier": "srv1", "provides": "server", "owned": true,
+				"accessToken": "good-token", "connections": [
+					{"uri": "http://plex:32400", "local": true},
+					{"uri": "https://relay.plex.direct:8443", "relay": true}]}]`))
+		}
+	}))
+	defer plexTV.Close()
+
+	// Only the LAN address answers
+	identify := func(ctx context.Context, platform, url, token string) (*syncpkg.ServerInfo, error) {
+		if url != "http://plex:32400" {
+			return nil, errors.New("connection refused")
+		}
+		return fakeIdentify(ctx, platform, url, token)
+	}
+
+	wizard := NewSetupWizard(filepath.Join(t.TempDir(), "config.yaml"), "development", identify)
+	wizard.SetPlexTV(syncpkg.PlexTVClientConfig{ClientID: "cartographus-setup", BaseURL: plexTV.URL})
+	handler := wizard.Handler()
+
+	w := setupRequest(t, handler, http.MethodPost, "/api/v1/setup/plex/pin", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"code":"wxyz9876"`) ||
+		!strings.Contains(w.Body.String(), "clientID=cartographus-setup") {
+		t.Fatalf("create PIN = %d %s", w.Code, w.Body.String())
+	}
+
+	w = setupRequest(t, handler, http.MethodGet, "/api/v1/setup/plex/pin/7", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"authorized":false`) {
+		t.Errorf("poll before approval = %d %s", w.Code, w.Body.String())
+	}
+
+	approved.Store(true)
+	w = setupRequest(t, handler, http.MethodGet, "/api/v1/setup/plex/pin/7", "")
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.Contains(body, `"authorized":true`) || !strings.Contains(body, `"id":"srv1"`) {
+		t.Fatalf("poll after approval = %d %s", w.Code, body)
+	}
+	if !strings.Contains(body, `"uri":"http://plex:32400","local":true,"relay":false,"reachable":true`) ||
+		!strings.Contains(body, `"uri":"https://relay.plex.direct:8443","local":false,"relay":true,"reachable":false`) {
+		t.Errorf("connections not probed: %s", body)
+	}
+	if strings.Contains(body, "token") {
+		t.Errorf("PIN status leaks a token: %s", body)
+	}
+
+	// Unknown and expired PINs
+	if w := setupRequest(t, handler, http.MethodGet, "/api/v1/setup/plex/pin/8", ""); w.Code != http.StatusNotFound {
+		t.Errorf("poll of PIN not created here = %d, want 404", w.Code)
+	}
+	wizard.plexPINs[8] = &setupPlexPIN{expiresAt: time.Now()}
+	if w := setupRequest(t, handler, http.MethodGet, "/api/v1/setup/plex/pin/8", ""); w.Code != http.StatusGone {
+		t.Errorf("poll of expired PIN = %d, want 410", w.Code)
+	}
+
+	// The chosen server is saved with its token from plex.tv
+	w = setupRequest(t, handler, http.MethodPost, "/api/v1/setup/media-server",
+		`{"platform":"plex","url":"http://plex:32400","plex_pin_id":7,"plex_server_id":"other"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("media-server with unknown server = %d, want 400", w.Code)
+	}
+	w = setupRequest(t, handler, http.MethodPost, "/api/v1/setup/media-server",
+		`{"platform":"plex","url":"http://plex:32400","plex_pin_id":7,"plex_server_id":"srv1"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("media-server = %d %s", w.Code, w.Body.String())
+	}
+	if wizard.server.Token != "good-token" {
+		t.Errorf("saved token = %q, want the server's access token", wizard.server.Token)
+	}
+}
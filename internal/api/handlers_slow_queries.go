@@ -0,0 +1,57 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// SlowQueriesResponse lists recent statements slower than DB_SLOW_QUERY_THRESHOLD.
+type SlowQueriesResponse struct {
+	Enabled     bool                 `json:"enabled"`
+	ThresholdMS int64                `json:"threshold_ms"`
+	Queries     []database.SlowQuery `json:"queries"`
+}
+
+// SlowQueries handles GET /api/v1/admin/db/slow-queries
+// Returns the most recent slow statements, newest first, with duration,
+// row count, and the analytics query and filters that issued them. When
+// DB_SLOW_QUERY_EXPLAIN is enabled entries also carry an EXPLAIN plan once
+// it has been captured.
+//
+// @Summary List slow database queries
+// @Description Returns recent DuckDB statements that exceeded DB_SLOW_QUERY_THRESHOLD, newest first.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=SlowQueriesResponse} "Slow queries"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 503 {object} models.APIResponse "Database not available"
+// @Router /admin/db/slow-queries [get]
+func (h *Handler) SlowQueries(w http.ResponseWriter, r *http.Request) {
+	if h.db == nil {
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		return
+	}
+
+	resp := SlowQueriesResponse{Queries: []database.SlowQuery{}}
+	if log := h.db.SlowQueries(); log != nil {
+		resp.Enabled = true
+		resp.ThresholdMS = log.Threshold().Milliseconds()
+		resp.Queries = log.Entries()
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     resp,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
@@ -0,0 +1,22 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSlowQueries_DatabaseUnavailable(t *testing.T) {
+	h := &Handler{}
+	rec := httptest.NewRecorder()
+	h.SlowQueries(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/db/slow-queries", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+}
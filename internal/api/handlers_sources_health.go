@@ -0,0 +1,112 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	syncpkg "github.com/tomtom215/cartographus/internal/sync"
+)
+
+// SetSourceHealthCheckers sets the managers probed by SourcesHealth.
+// Nil checkers are ignored; nil Jellyfin/Emby managers report no sources.
+//
+// Thread Safety: Safe for concurrent access but should be called once during startup.
+func (h *Handler) SetSourceHealthCheckers(checkers ...syncpkg.SourceHealthChecker) {
+	h.sourceHealth = make([]syncpkg.SourceHealthChecker, 0, len(checkers))
+	for _, checker := range checkers {
+		if checker != nil {
+			h.sourceHealth = append(h.sourceHealth, checker)
+		}
+	}
+}
+
+// SourcesHealth probes every configured media server and reports its
+// reachability, last successful sync, and circuit breaker state.
+//
+// Probes run in parallel and are individually bounded by a timeout, so one
+// unreachable server does not delay the others.
+//
+// @Summary Get media source health
+// @Description Pings each configured media server (Tautulli, Plex, Jellyfin, Emby) and returns
+// @Description per-server reachability, latency, last successful sync time, and circuit breaker state.
+// @Tags Core
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.SourcesHealthResponse} "Source health retrieved successfully"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Router /sources/health [get]
+func (h *Handler) SourcesHealth(w http.ResponseWriter, r *http.Request) {
+	sources := h.probeSources(r.Context())
+
+	resp := models.SourcesHealthResponse{
+		Sources:    sources,
+		TotalCount: len(sources),
+		CheckedAt:  time.Now(),
+	}
+	for i := range sources {
+		if sources[i].Reachable {
+			resp.Reachable++
+		} else {
+			resp.Unreachable++
+		}
+	}
+
+	switch {
+	case resp.TotalCount == 0:
+		resp.Status = "unconfigured"
+	case resp.Unreachable == 0:
+		resp.Status = "healthy"
+	case resp.Reachable == 0:
+		resp.Status = "unhealthy"
+	default:
+		resp.Status = "degraded"
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status: "success",
+		Data:   resp,
+		Metadata: models.Metadata{
+			Timestamp: time.Now(),
+		},
+	})
+}
+
+// probeSources runs all source health checkers concurrently and returns the
+// results ordered by platform and server ID.
+func (h *Handler) probeSources(ctx context.Context) []models.SourceHealth {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		sources = make([]models.SourceHealth, 0, len(h.sourceHealth))
+	)
+
+	for _, checker := range h.sourceHealth {
+		wg.Add(1)
+		go func(checker syncpkg.SourceHealthChecker) {
+			defer wg.Done()
+			results := checker.SourceHealth(ctx)
+			mu.Lock()
+			sources = append(sources, results...)
+			mu.Unlock()
+		}(checker)
+	}
+	wg.Wait()
+
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Platform != sources[j].Platform {
+			return sources[i].Platform < sources[j].Platform
+		}
+		return sources[i].ServerID < sources[j].ServerID
+	})
+	return sources
+}
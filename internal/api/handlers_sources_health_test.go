@@ -0,0 +1,128 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	syncpkg "github.com/tomtom215/cartographus/internal/sync"
+)
+
+// stubSourceHealthChecker returns fixed source health results.
+type stubSourceHealthChecker struct {
+	results []models.SourceHealth
+}
+
+func (s *stubSourceHealthChecker) SourceHealth(context.Context) []models.SourceHealth {
+	return s.results
+}
+
+func decodeSourcesHealth(t *testing.T, w *httptest.ResponseRecorder) models.SourcesHealthResponse {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var resp struct {
+		Data models.SourcesHealthResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp.Data
+}
+
+func TestSourcesHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		checkers   []syncpkg.SourceHealthChecker
+		wantStatus string
+		wantCount  int
+	}{
+		{"no sources", nil, "unconfigured", 0},
+		{"nil checker ignored", []syncpkg.SourceHealthChecker{nil}, "unconfigured", 0},
+		{
+			"all reachable",
+			[]syncpkg.SourceHealthChecker{&stubSourceHealthChecker{results: []models.SourceHealth{
+				{Platform: "plex", ServerID: "plex-1", Reachable: true},
+			}}},
+			"healthy", 1,
+		},
+		{
+			"some unreachable",
+			[]syncpkg.SourceHealthChecker{
+				&stubSourceHealthChecker{results: []models.SourceHealth{{Platform: "plex", ServerID: "plex-1", Reachable: true}}},
+				&stubSourceHealthChecker{results: []models.SourceHealth{{Platform: "jellyfin", ServerID: "jf-1"}}},
+			},
+			"degraded", 2,
+		},
+		{
+			"all unreachable",
+			[]syncpkg.SourceHealthChecker{&stubSourceHealthChecker{results: []models.SourceHealth{
+				{Platform: "emby", ServerID: "emby-1", Error: "timeout"},
+			}}},
+			"unhealthy", 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := &Handler{}
+			handler.SetSourceHealthCheckers(tt.checkers...)
+
+			w := httptest.NewRecorder()
+			handler.SourcesHealth(w, httptest.NewRequest(http.MethodGet, "/api/v1/sources/health", nil))
+
+			data := decodeSourcesHealth(t, w)
+			if data.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", data.Status, tt.wantStatus)
+			}
+			if data.TotalCount != tt.wantCount || len(data.Sources) != tt.wantCount {
+				t.Errorf("TotalCount = %d (%d sources), want %d", data.TotalCount, len(data.Sources), tt.wantCount)
+			}
+			if data.Reachable+data.Unreachable != data.TotalCount {
+				t.Errorf("Reachable %d + Unreachable %d != TotalCount %d", data.Reachable, data.Unreachable, data.TotalCount)
+			}
+		})
+	}
+}
+
+func TestSourcesHealth_SortsByPlatformAndServer(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{}
+	handler.SetSourceHealthCheckers(
+		&stubSourceHealthChecker{results: []models.SourceHealth{
+			{Platform: "tautulli", ServerID: "tautulli-default"},
+			{Platform: "plex", ServerID: "plex-b"},
+		}},
+		&stubSourceHealthChecker{results: []models.SourceHealth{{Platform: "plex", ServerID: "plex-a"}}},
+		&stubSourceHealthChecker{results: []models.SourceHealth{{Platform: "emby", ServerID: "emby-1"}}},
+	)
+
+	w := httptest.NewRecorder()
+	handler.SourcesHealth(w, httptest.NewRequest(http.MethodGet, "/api/v1/sources/health", nil))
+
+	data := decodeSourcesHealth(t, w)
+	want := []string{"emby/emby-1", "plex/plex-a", "plex/plex-b", "tautulli/tautulli-default"}
+	if len(data.Sources) != len(want) {
+		t.Fatalf("got %d sources, want %d", len(data.Sources), len(want))
+	}
+	for i, source := range data.Sources {
+		if got := source.Platform + "/" + source.ServerID; got != want[i] {
+			t.Errorf("Sources[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
@@ -23,7 +23,7 @@ import (
 )
 
 // This file contains spatial analytics and export endpoints
-// Total: 11 methods (2 export + 9 spatial)
+// Total: 12 methods (3 export + 9 spatial)
 
 // TileCoordinates represents parsed vector tile coordinates
 type TileCoordinates struct {
@@ -116,59 +116,11 @@ func optionalTime(t *time.Time) string {
 }
 
 // parseExportFilter parses filter parameters for export endpoints.
-// Returns the filter and any validation error message.
-func parseExportFilter(r *http.Request) (database.LocationStatsFilter, string) {
-	filter := database.LocationStatsFilter{}
-
-	// Parse and validate date filter
-	if errMsg := validateAndApplyDateFilter(r, &filter); errMsg != "" {
-		return filter, errMsg
-	}
-
-	// Validate days range if provided
-	if errMsg := validateDaysParam(r); errMsg != "" {
-		return filter, errMsg
-	}
-
-	// Apply comma-separated filters
-	applyCommaSeparatedFilters(r, &filter)
-
-	return filter, ""
-}
-
-// validateAndApplyDateFilter parses date filter and returns error message if invalid
-func validateAndApplyDateFilter(r *http.Request, filter *database.LocationStatsFilter) string {
-	err := parseDateFilter(r, filter)
-	if err == nil {
-		return ""
-	}
-
-	errMsg := err.Error()
-	if strings.Contains(errMsg, "invalid start_date format") {
-		return "Invalid start_date format. Use RFC3339 format"
-	}
-	if strings.Contains(errMsg, "invalid end_date format") {
-		return "Invalid end_date format. Use RFC3339 format"
-	}
-	return errMsg
-}
-
-// validateDaysParam validates the days parameter if present
-func validateDaysParam(r *http.Request) string {
-	daysStr := r.URL.Query().Get("days")
-	if daysStr == "" {
-		return ""
-	}
-
-	days := getIntParam(r, "days", 0)
-	if days < 1 || days > 3650 {
-		return "Days must be between 1 and 3650 (10 years)"
-	}
-	return ""
+// Exports are not paged, so Limit stays 0 unless limit= is given.
+func parseExportFilter(r *http.Request) (database.LocationStatsFilter, error) {
+	return models.ParseLocationStatsFilter(r.URL.Query())
 }
 
-// Note: applyCommaSeparatedFilters is defined in handlers_core.go
-
 // buildCSVRow builds a CSV row from a PlaybackEvent using the helper functions.
 // Note: watched_at is an alias for started_at (for E2E test compatibility)
 func buildCSVRow(event *models.PlaybackEvent) string {
@@ -215,19 +167,19 @@ func (h *Handler) ExportPlaybacksCSV(w http.ResponseWriter, r *http.Request) {
 		Limit:  limit,
 		Offset: offset,
 	}
-	if apiErr := validateRequest(&req); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+	if verr := validateRequest(&req); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
 	// Check if database is available AFTER validation (service errors = 503)
-	if !h.requireDB(w) {
+	if !h.requireDB(w, r) {
 		return
 	}
 
 	events, err := h.db.GetPlaybackEvents(r.Context(), limit, offset)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve playback events", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve playback events", err)
 		return
 	}
 
@@ -290,9 +242,10 @@ func buildGeoJSONCollection(locations []models.LocationStats) GeoJSONFeatureColl
 }
 
 // setGeoJSONDownloadHeaders sets appropriate headers for GeoJSON file download
-func setGeoJSONDownloadHeaders(w http.ResponseWriter) {
+// named cartographus-<name>-<timestamp>.geojson
+func setGeoJSONDownloadHeaders(w http.ResponseWriter, name string) {
 	timestamp := time.Now().Format("20060102-150405")
-	filename := "cartographus-locations-" + timestamp + ".geojson"
+	filename := "cartographus-" + name + "-" + timestamp + ".geojson"
 	w.Header().Set("Content-Type", "application/geo+json")
 	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -309,7 +262,7 @@ func setGeoJSONDownloadHeaders(w http.ResponseWriter) {
 // @Failure 500 {object} models.APIResponse "Internal server error"
 // @Router /export/locations/geojson [get]
 func (h *Handler) ExportLocationsGeoJSON(w http.ResponseWriter, r *http.Request) {
-	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w) {
+	if !requireMethod(w, r, http.MethodGet) || !h.requireDB(w, r) {
 		return
 	}
 
@@ -317,12 +270,12 @@ func (h *Handler) ExportLocationsGeoJSON(w http.ResponseWriter, r *http.Request)
 
 	locations, err := h.db.GetLocationStatsFiltered(r.Context(), filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve location statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to retrieve location statistics", err)
 		return
 	}
 
 	featureCollection := buildGeoJSONCollection(locations)
-	setGeoJSONDownloadHeaders(w)
+	setGeoJSONDownloadHeaders(w, "locations")
 
 	// Write GeoJSON (headers already sent, can only log errors)
 	if err := json.NewEncoder(w).Encode(featureCollection); err != nil {
@@ -330,15 +283,129 @@ func (h *Handler) ExportLocationsGeoJSON(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// parseGeoJSONProperties parses the comma-separated properties parameter,
+// defaulting to database.DefaultGeoJSONPlaybackProperties.
+func parseGeoJSONProperties(r *http.Request) ([]string, error) {
+	param := r.URL.Query().Get("properties")
+	if strings.TrimSpace(param) == "" {
+		return database.DefaultGeoJSONPlaybackProperties, nil
+	}
+
+	var properties []string
+	for _, name := range strings.Split(param, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			properties = append(properties, name)
+		}
+	}
+	if err := database.ValidateGeoJSONPlaybackProperties(properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// buildPlaybackFeature creates a GeoJSON feature from a located playback
+func buildPlaybackFeature(feature *database.PlaybackFeature) streamingFeature {
+	return streamingFeature{
+		Type: "Feature",
+		Geometry: map[string]interface{}{
+			"type":        "Point",
+			"coordinates": []float64{feature.Longitude, feature.Latitude},
+		},
+		Properties: feature.Properties,
+	}
+}
+
+// ExportPlaybacksGeoJSON streams one GeoJSON feature per geolocated playback,
+// with the playback fields chosen in properties as feature properties.
+// GET /api/v1/export/playbacks/geojson?properties=username,title&start_date=...
+// Features are written as rows are read (see StreamLocationsGeoJSON), so
+// exports of any size use constant memory. Every matching playback is
+// exported unless limit is set.
+//
+// @Summary Export playbacks as GeoJSON
+// @Description Streams a GeoJSON FeatureCollection with one point per geolocated playback, newest first.
+// @Description properties selects the playback fields included in each feature (default: started_at, username, title, media_type, platform, player, percent_complete).
+// @Tags Export
+// @Produce application/geo+json
+// @Param properties query string false "Comma-separated playback fields, e.g. username,title,started_at"
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {file} file "GeoJSON FeatureCollection"
+// @Failure 400 {object} models.APIResponse "Invalid filter or unknown property"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /export/playbacks/geojson [get]
+func (h *Handler) ExportPlaybacksGeoJSON(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	filter, err := parseExportFilter(r)
+	if err != nil {
+		respondFilterError(w, r, err)
+		return
+	}
+	properties, err := parseGeoJSONProperties(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		return
+	}
+
+	if !h.requireDB(w, r) {
+		return
+	}
+
+	queryCtx, done, err := h.beginQuery(r)
+	defer done()
+	if err != nil {
+		h.respondQueryError(w, r, queryCtx, "ExportPlaybacksGeoJSON", err)
+		return
+	}
+
+	setGeoJSONDownloadHeaders(w, "playbacks")
+	stream := newGeoJSONStreamWriter(w, geoJSONFlushEvery)
+	err = h.db.StreamPlaybackFeatures(database.WithQueryLabel(queryCtx, "ExportPlaybacksGeoJSON", &filter), filter, properties,
+		func(feature *database.PlaybackFeature) error {
+			return stream.WriteFeature(buildPlaybackFeature(feature))
+		})
+	if err != nil {
+		if !stream.Started() {
+			// Drop the download headers so the error is not saved as a file
+			w.Header().Del("Content-Disposition")
+			h.respondQueryError(w, r, queryCtx, "ExportPlaybacksGeoJSON", err)
+			return
+		}
+		logging.Warn().Err(err).Int("features", stream.Features()).Msg("GeoJSON playback export aborted")
+		return
+	}
+
+	if err := stream.Close(); err != nil {
+		logging.Debug().Err(err).Msg("Failed to finish GeoJSON playback export")
+	}
+}
+
+// SpatialHexagons handles GET /api/v1/spatial/hexagons
+//
+// @Summary Get H3 hexagon aggregation
+// @Description Returns playback counts aggregated into H3 hexagons
+// @Tags Spatial Analytics
+// @Produce json
+// @Param resolution query int false "H3 resolution (6=country, 7=city, 8=neighborhood)" default(7) minimum(6) maximum(8)
+// @Param zoom query int false "Map zoom level; picks the resolution when resolution is omitted" minimum(0) maximum(24)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=[]models.H3HexagonStats}
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Failure 503 {object} models.APIResponse "Database unavailable"
+// @Router /spatial/hexagons [get]
 func (h *Handler) SpatialHexagons(w http.ResponseWriter, r *http.Request) {
 	if !requireMethod(w, r, http.MethodGet) {
 		return
 	}
 
-	// Validate resolution parameter using existing validator
-	resParams, err := ValidateResolution(r, 7)
+	// Validate resolution parameter, or derive it from the map zoom level
+	resParams, err := ValidateHexagonResolution(r, 7)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -348,8 +415,8 @@ func (h *Handler) SpatialHexagons(w http.ResponseWriter, r *http.Request) {
 		StartDate:  r.URL.Query().Get("start_date"),
 		EndDate:    r.URL.Query().Get("end_date"),
 	}
-	if apiErr := validateRequest(&req); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+	if verr := validateRequest(&req); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
@@ -394,17 +461,11 @@ func (h *Handler) validateServerLocation() (*ServerLocation, error) {
 // @Tags Spatial Analytics
 // @Accept json
 // @Produce json
-// @Param start_date query string false "Start date (RFC3339 format)"
-// @Param end_date query string false "End date (RFC3339 format)"
-// @Param days query int false "Number of days to include (alternative to start_date)" minimum(1) maximum(3650)
-// @Param users query string false "Comma-separated usernames"
-// @Param media_types query string false "Comma-separated media types"
-// @Param platforms query string false "Comma-separated platforms"
-// @Param players query string false "Comma-separated players"
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
 // @Success 200 {object} models.APIResponse{data=[]models.ArcStats} "Distance-weighted arcs retrieved successfully"
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/spatial/arcs [get]
+// @Router /spatial/arcs [get]
 func (h *Handler) SpatialArcs(w http.ResponseWriter, r *http.Request) {
 	if !requireMethod(w, r, http.MethodGet) {
 		return
@@ -412,7 +473,7 @@ func (h *Handler) SpatialArcs(w http.ResponseWriter, r *http.Request) {
 
 	serverLoc, err := h.validateServerLocation()
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "CONFIGURATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "CONFIGURATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -445,17 +506,11 @@ func (h *Handler) SpatialArcs(w http.ResponseWriter, r *http.Request) {
 // @Param south query number true "Southern latitude boundary" minimum(-90) maximum(90)
 // @Param east query number true "Eastern longitude boundary" minimum(-180) maximum(180)
 // @Param north query number true "Northern latitude boundary" minimum(-90) maximum(90)
-// @Param start_date query string false "Start date (RFC3339 format)"
-// @Param end_date query string false "End date (RFC3339 format)"
-// @Param days query int false "Number of days to include (alternative to start_date)" minimum(1) maximum(3650)
-// @Param users query string false "Comma-separated usernames"
-// @Param media_types query string false "Comma-separated media types"
-// @Param platforms query string false "Comma-separated platforms"
-// @Param players query string false "Comma-separated players"
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
 // @Success 200 {object} models.APIResponse{data=[]models.LocationStats} "Viewport locations retrieved successfully"
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/spatial/viewport [get]
+// @Router /spatial/viewport [get]
 func (h *Handler) SpatialViewport(w http.ResponseWriter, r *http.Request) {
 	if !requireMethod(w, r, http.MethodGet) {
 		return
@@ -464,7 +519,7 @@ func (h *Handler) SpatialViewport(w http.ResponseWriter, r *http.Request) {
 	// Validate bounding box parameters using validator
 	bbox, err := ValidateBoundingBox(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -477,8 +532,8 @@ func (h *Handler) SpatialViewport(w http.ResponseWriter, r *http.Request) {
 		StartDate: r.URL.Query().Get("start_date"),
 		EndDate:   r.URL.Query().Get("end_date"),
 	}
-	if apiErr := validateRequest(&req); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+	if verr := validateRequest(&req); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
@@ -531,7 +586,8 @@ func validateTemporalDensityParams(r *http.Request) (*TemporalDensityParams, err
 		StartDate:  r.URL.Query().Get("start_date"),
 		EndDate:    r.URL.Query().Get("end_date"),
 	}
-	if apiErr := validateRequest(&req); apiErr != nil {
+	if verr := validateRequest(&req); verr != nil {
+		apiErr := verr.ToAPIError()
 		return nil, fmt.Errorf("%s: %s", apiErr.Code, apiErr.Message)
 	}
 
@@ -550,17 +606,11 @@ func validateTemporalDensityParams(r *http.Request) (*TemporalDensityParams, err
 // @Produce json
 // @Param interval query string false "Time interval (hour, day, week, month)" default(hour)
 // @Param resolution query int false "H3 resolution (6=country, 7=city, 8=neighborhood)" default(7) minimum(6) maximum(8)
-// @Param start_date query string false "Start date (RFC3339 format)"
-// @Param end_date query string false "End date (RFC3339 format)"
-// @Param days query int false "Number of days to include (alternative to start_date)" minimum(1) maximum(3650)
-// @Param users query string false "Comma-separated usernames"
-// @Param media_types query string false "Comma-separated media types"
-// @Param platforms query string false "Comma-separated platforms"
-// @Param players query string false "Comma-separated players"
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
 // @Success 200 {object} models.APIResponse{data=[]models.TemporalSpatialPoint} "Temporal-spatial density retrieved successfully"
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/spatial/temporal-density [get]
+// @Router /spatial/temporal-density [get]
 func (h *Handler) SpatialTemporalDensity(w http.ResponseWriter, r *http.Request) {
 	if !requireMethod(w, r, http.MethodGet) {
 		return
@@ -568,7 +618,7 @@ func (h *Handler) SpatialTemporalDensity(w http.ResponseWriter, r *http.Request)
 
 	params, err := validateTemporalDensityParams(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -600,17 +650,11 @@ func (h *Handler) SpatialTemporalDensity(w http.ResponseWriter, r *http.Request)
 // @Param lat query number true "Center latitude" minimum(-90) maximum(90)
 // @Param lon query number true "Center longitude" minimum(-180) maximum(180)
 // @Param radius query number false "Search radius in kilometers" default(100) minimum(1) maximum(20000)
-// @Param start_date query string false "Start date (RFC3339 format)"
-// @Param end_date query string false "End date (RFC3339 format)"
-// @Param days query int false "Number of days to include (alternative to start_date)" minimum(1) maximum(3650)
-// @Param users query string false "Comma-separated usernames"
-// @Param media_types query string false "Comma-separated media types"
-// @Param platforms query string false "Comma-separated platforms"
-// @Param players query string false "Comma-separated players"
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
 // @Success 200 {object} models.APIResponse{data=[]models.LocationStats} "Nearby locations retrieved successfully"
 // @Failure 400 {object} models.APIResponse "Invalid parameters"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/spatial/nearby [get]
+// @Router /spatial/nearby [get]
 func (h *Handler) SpatialNearby(w http.ResponseWriter, r *http.Request) {
 	if !requireMethod(w, r, http.MethodGet) {
 		return
@@ -619,7 +663,7 @@ func (h *Handler) SpatialNearby(w http.ResponseWriter, r *http.Request) {
 	// Validate coordinates and radius using existing validator
 	coords, err := ValidateCoordinates(r, true)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
@@ -631,8 +675,8 @@ func (h *Handler) SpatialNearby(w http.ResponseWriter, r *http.Request) {
 		StartDate: r.URL.Query().Get("start_date"),
 		EndDate:   r.URL.Query().Get("end_date"),
 	}
-	if apiErr := validateRequest(&req); apiErr != nil {
-		respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+	if verr := validateRequest(&req); verr != nil {
+		respondValidationError(w, r, verr)
 		return
 	}
 
@@ -664,13 +708,13 @@ type exportConfig struct {
 
 // handleFileExport is a common handler for file-based exports (GeoParquet, GeoJSON)
 func (h *Handler) handleFileExport(w http.ResponseWriter, r *http.Request, config exportConfig) {
-	filter, errMsg := parseExportFilter(r)
-	if errMsg != "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", errMsg, nil)
+	filter, err := parseExportFilter(r)
+	if err != nil {
+		respondFilterError(w, r, err)
 		return
 	}
 
-	if !h.requireDB(w) {
+	if !h.requireDB(w, r) {
 		return
 	}
 
@@ -684,7 +728,7 @@ func (h *Handler) handleFileExport(w http.ResponseWriter, r *http.Request, confi
 	}()
 
 	if err := config.exportFunc(r.Context(), outputPath, filter); err != nil {
-		respondError(w, http.StatusInternalServerError, "EXPORT_ERROR",
+		respondError(w, r, http.StatusInternalServerError, "EXPORT_ERROR",
 			fmt.Sprintf("Failed to export %s", config.fileExtension), err)
 		return
 	}
@@ -702,20 +746,20 @@ func (h *Handler) handleFileExport(w http.ResponseWriter, r *http.Request, confi
 // This addresses Medium Priority Issue M2 from the production audit
 func (h *Handler) ExportGeoParquet(w http.ResponseWriter, r *http.Request) {
 	// Validate filter params first
-	filter, errMsg := parseExportFilter(r)
-	if errMsg != "" {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", errMsg, nil)
+	filter, err := parseExportFilter(r)
+	if err != nil {
+		respondFilterError(w, r, err)
 		return
 	}
 
 	// Check DB availability before checking extensions
-	if !h.requireDB(w) {
+	if !h.requireDB(w, r) {
 		return
 	}
 
 	// Check if spatial extension is available (required for GeoParquet export)
 	if !h.db.IsSpatialAvailable() {
-		respondError(w, http.StatusServiceUnavailable, "EXTENSION_UNAVAILABLE",
+		respondError(w, r, http.StatusServiceUnavailable, "EXTENSION_UNAVAILABLE",
 			"Spatial extension not available. GeoParquet export requires the DuckDB spatial extension.", nil)
 		return
 	}
@@ -730,7 +774,7 @@ func (h *Handler) ExportGeoParquet(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	if err := h.db.ExportGeoParquet(r.Context(), outputPath, filter); err != nil {
-		respondError(w, http.StatusInternalServerError, "EXPORT_ERROR", "Failed to export parquet", err)
+		respondError(w, r, http.StatusInternalServerError, "EXPORT_ERROR", "Failed to export parquet", err)
 		return
 	}
 
@@ -787,62 +831,55 @@ func setupStreamingResponse(w http.ResponseWriter) (http.Flusher, error) {
 	return flusher, nil
 }
 
-// streamGeoJSONFeatures streams features as GeoJSON with batched flushing
-func streamGeoJSONFeatures(w http.ResponseWriter, flusher http.Flusher, locations []models.LocationStats) {
-	const batchSize = 100
-
-	_, _ = fmt.Fprintf(w, `{"type":"FeatureCollection","features":[`)
-	flusher.Flush()
-
-	for i := range locations {
-		if i > 0 {
-			_, _ = fmt.Fprintf(w, ",")
-		}
-
-		feature := buildStreamingFeature(&locations[i])
-		featureJSON, err := json.Marshal(feature)
-		if err != nil {
-			continue // Skip malformed features
-		}
-		_, _ = w.Write(featureJSON)
-
-		if (i+1)%batchSize == 0 {
-			flusher.Flush()
-		}
-	}
-
-	_, _ = fmt.Fprintf(w, `]}`)
-	flusher.Flush()
-}
-
 // StreamLocationsGeoJSON streams location data as GeoJSON with chunked transfer encoding
 // This addresses Medium Priority Issue M12 from the production audit
 // GET /api/v1/stream/locations-geojson?start_date=...&end_date=...
-// Handles 100k+ locations without memory spikes by streaming JSON incrementally
+// Handles 100k+ locations without memory spikes: each database row is encoded
+// and written as it is read, with a flush every geoJSONFlushEvery features.
+// Every matching location is returned unless limit is set. The stream runs
+// within the analytics query budget and concurrency limit (beginQuery).
 func (h *Handler) StreamLocationsGeoJSON(w http.ResponseWriter, r *http.Request) {
-	if !h.requireDB(w) {
+	if !h.requireDB(w, r) {
+		return
+	}
+
+	filter, err := models.ParseLocationStatsFilter(r.URL.Query())
+	if err != nil {
+		respondFilterError(w, r, err)
 		return
 	}
 
-	filter := database.LocationStatsFilter{}
-	if err := parseDateFilter(r, &filter); err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+	if _, err := setupStreamingResponse(w); err != nil {
+		respondError(w, r, http.StatusInternalServerError, "SERVER_ERROR", err.Error(), nil)
 		return
 	}
 
-	flusher, err := setupStreamingResponse(w)
+	queryCtx, done, err := h.beginQuery(r)
+	defer done()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "SERVER_ERROR", err.Error(), nil)
+		h.respondQueryError(w, r, queryCtx, "StreamLocationsGeoJSON", err)
 		return
 	}
 
-	locations, err := h.db.GetLocationStatsFiltered(r.Context(), filter)
+	stream := newGeoJSONStreamWriter(w, geoJSONFlushEvery)
+	err = h.db.StreamLocationStats(database.WithQueryLabel(queryCtx, "StreamLocationsGeoJSON", &filter), filter,
+		func(location *models.LocationStats) error {
+			return stream.WriteFeature(buildStreamingFeature(location))
+		})
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch locations", err)
+		if !stream.Started() {
+			h.respondQueryError(w, r, queryCtx, "StreamLocationsGeoJSON", err)
+			return
+		}
+		// The status is already sent; ending without the footer tells
+		// the client the collection is incomplete
+		logging.Warn().Err(err).Int("features", stream.Features()).Msg("GeoJSON location stream aborted")
 		return
 	}
 
-	streamGeoJSONFeatures(w, flusher, locations)
+	if err := stream.Close(); err != nil {
+		logging.Debug().Err(err).Msg("Failed to finish GeoJSON location stream")
+	}
 }
 
 // setVectorTileHeaders sets appropriate headers for MVT response
@@ -860,8 +897,8 @@ func parseTileRequest(r *http.Request) (*TileCoordinates, database.LocationStats
 		return nil, database.LocationStatsFilter{}, fmt.Errorf("%s: %s", errCode, err.Error())
 	}
 
-	filter := database.LocationStatsFilter{}
-	if err := parseDateFilter(r, &filter); err != nil {
+	filter, err := models.ParseLocationStatsFilter(r.URL.Query())
+	if err != nil {
 		return nil, database.LocationStatsFilter{}, err
 	}
 
@@ -875,17 +912,17 @@ func parseTileRequest(r *http.Request) (*TileCoordinates, database.LocationStats
 func (h *Handler) GetVectorTile(w http.ResponseWriter, r *http.Request) {
 	coords, filter, err := parseTileRequest(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 		return
 	}
 
-	if !h.requireDB(w) {
+	if !h.requireDB(w, r) {
 		return
 	}
 
 	mvtData, err := h.db.GenerateVectorTile(r.Context(), coords.Z, coords.X, coords.Y, filter)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "TILE_GENERATION_ERROR", "Failed to generate tile", err)
+		respondError(w, r, http.StatusInternalServerError, "TILE_GENERATION_ERROR", "Failed to generate tile", err)
 		return
 	}
 
@@ -250,6 +250,8 @@ func TestParseExportFilter(t *testing.T) {
 				}
 			},
 		},
+		{"end_date before start_date", map[string]string{"start_date": "2025-06-01T00:00:00Z", "end_date": "2025-01-01T00:00:00Z"}, nil, "end_date must be after start_date"},
+		{"date range too long", map[string]string{"start_date": "2010-01-01T00:00:00Z", "end_date": "2025-01-01T00:00:00Z"}, nil, "date range exceeds 3650 days"},
 		{"days parameter - too small", map[string]string{"days": "0"}, nil, "Days must be between 1 and 3650"},
 		{"days parameter - too large", map[string]string{"days": "3651"}, nil, "Days must be between 1 and 3650"},
 		{
@@ -288,16 +290,16 @@ func TestParseExportFilter(t *testing.T) {
 				params.Set(k, v)
 			}
 			req := httptest.NewRequest(http.MethodGet, "/?"+params.Encode(), nil)
-			filter, errMsg := parseExportFilter(req)
+			filter, err := parseExportFilter(req)
 
 			if tt.wantErr != "" {
-				if errMsg == "" || !strings.Contains(errMsg, tt.wantErr) {
-					t.Errorf("parseExportFilter() error = %q, want error containing %q", errMsg, tt.wantErr)
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseExportFilter() error = %v, want error containing %q", err, tt.wantErr)
 				}
 				return
 			}
-			if errMsg != "" {
-				t.Errorf("parseExportFilter() unexpected error = %q", errMsg)
+			if err != nil {
+				t.Errorf("parseExportFilter() unexpected error = %v", err)
 				return
 			}
 			if tt.checkFilter != nil {
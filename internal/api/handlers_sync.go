@@ -112,7 +112,7 @@ func NewSyncHandlers(importStatusProvider ImportStatusProvider, syncStatusProvid
 // @Tags sync
 // @Produce json
 // @Success 200 {object} SyncStatusResponse
-// @Router /api/v1/sync/status [get]
+// @Router /sync/status [get]
 func (h *SyncHandlers) HandleGetSyncStatus(w http.ResponseWriter, r *http.Request) {
 	response := SyncStatusResponse{
 		ServerSyncs: make(map[string]*SyncProgress),
@@ -157,7 +157,7 @@ func (h *SyncHandlers) HandleGetSyncStatus(w http.ResponseWriter, r *http.Reques
 // @Failure 400 {object} PlexHistoricalResponse "Invalid request"
 // @Failure 409 {object} PlexHistoricalResponse "Sync already in progress"
 // @Failure 500 {object} PlexHistoricalResponse "Internal error"
-// @Router /api/v1/sync/plex/historical [post]
+// @Router /sync/plex/historical [post]
 func (h *SyncHandlers) HandleStartPlexHistoricalSync(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
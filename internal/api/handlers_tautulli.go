@@ -46,7 +46,7 @@ func (h *Handler) TautulliHomeStats(w http.ResponseWriter, r *http.Request) {
 // @Param grouping query int false "Group data (0 or 1)" default(0)
 // @Success 200 {object} models.APIResponse{data=tautulli.TautulliPlaysByDateData} "Plays by date retrieved successfully"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/tautulli/plays-by-date [get]
+// @Router /tautulli/plays-by-date [get]
 func (h *Handler) TautulliPlaysByDate(w http.ResponseWriter, r *http.Request) {
 	proxyTautulliRequest(h, w, r, TautulliProxyConfig[StandardTimeRangeParams, *tautulli.TautulliPlaysByDate]{
 		CacheName:   "TautulliPlaysByDate",
@@ -74,7 +74,7 @@ func (h *Handler) TautulliPlaysByDate(w http.ResponseWriter, r *http.Request) {
 // @Param grouping query int false "Group data (0 or 1)" default(0)
 // @Success 200 {object} models.APIResponse{data=tautulli.TautulliPlaysByDayOfWeekData} "Plays by day of week retrieved successfully"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/tautulli/plays-by-dayofweek [get]
+// @Router /tautulli/plays-by-dayofweek [get]
 func (h *Handler) TautulliPlaysByDayOfWeek(w http.ResponseWriter, r *http.Request) {
 	proxyTautulliRequest(h, w, r, TautulliProxyConfig[StandardTimeRangeParams, *tautulli.TautulliPlaysByDayOfWeek]{
 		CacheName:   "TautulliPlaysByDayOfWeek",
@@ -102,7 +102,7 @@ func (h *Handler) TautulliPlaysByDayOfWeek(w http.ResponseWriter, r *http.Reques
 // @Param grouping query int false "Group data (0 or 1)" default(0)
 // @Success 200 {object} models.APIResponse{data=tautulli.TautulliPlaysByHourOfDayData} "Plays by hour of day retrieved successfully"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/tautulli/plays-by-hourofday [get]
+// @Router /tautulli/plays-by-hourofday [get]
 func (h *Handler) TautulliPlaysByHourOfDay(w http.ResponseWriter, r *http.Request) {
 	proxyTautulliRequest(h, w, r, TautulliProxyConfig[StandardTimeRangeParams, *tautulli.TautulliPlaysByHourOfDay]{
 		CacheName:   "TautulliPlaysByHourOfDay",
@@ -130,7 +130,7 @@ func (h *Handler) TautulliPlaysByHourOfDay(w http.ResponseWriter, r *http.Reques
 // @Param grouping query int false "Group data (0 or 1)" default(0)
 // @Success 200 {object} models.APIResponse{data=tautulli.TautulliPlaysByStreamTypeData} "Plays by stream type retrieved successfully"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/tautulli/plays-by-stream-type [get]
+// @Router /tautulli/plays-by-stream-type [get]
 func (h *Handler) TautulliPlaysByStreamType(w http.ResponseWriter, r *http.Request) {
 	proxyTautulliRequest(h, w, r, TautulliProxyConfig[StandardTimeRangeParams, *tautulli.TautulliPlaysByStreamType]{
 		CacheName:   "TautulliPlaysByStreamType",
@@ -156,7 +156,7 @@ func (h *Handler) TautulliPlaysByStreamType(w http.ResponseWriter, r *http.Reque
 // @Param user_id query int false "Filter by user ID" default(0)
 // @Success 200 {object} models.APIResponse{data=tautulli.TautulliConcurrentStreamsByStreamTypeData} "Concurrent streams by stream type retrieved successfully"
 // @Failure 500 {object} models.APIResponse "Internal server error"
-// @Router /api/v1/tautulli/concurrent-streams-by-stream-type [get]
+// @Router /tautulli/concurrent-streams-by-stream-type [get]
 func (h *Handler) TautulliConcurrentStreamsByStreamType(w http.ResponseWriter, r *http.Request) {
 	proxyTautulliRequest(h, w, r, TautulliProxyConfig[TwoIntParams, *tautulli.TautulliConcurrentStreamsByStreamType]{
 		CacheName:   "TautulliConcurrentStreamsByStreamType",
@@ -0,0 +1,543 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/models/tautulli"
+)
+
+// Tautulli-compatible API (TAUTULLI_COMPAT_API)
+//
+// GET /api/v2?cmd=<command> answers a subset of Tautulli's API v2 from the
+// DuckDB data, in the response envelope of models/tautulli, so dashboards
+// written for Tautulli keep working without a Tautulli server:
+//
+//   - get_history: playback history, paged and filtered like Tautulli
+//   - get_activity: sessions started in the last compatActivityWindow that
+//     have not stopped
+//   - get_users: every user with playback history
+//
+// Other commands are answered with a Tautulli error response. Unlike the
+// /api/v1/tautulli proxy endpoints, nothing is forwarded to Tautulli.
+
+// compatActivityWindow bounds how old an unfinished session may be to still
+// be reported by get_activity; older ones were most likely never closed.
+const compatActivityWindow = 24 * time.Hour
+
+// compatHistoryDefaultLength is Tautulli's default get_history page size
+const compatHistoryDefaultLength = 25
+
+// tautulliCompatCommands are the emulated Tautulli API commands
+var tautulliCompatCommands = map[string]func(h *Handler, w http.ResponseWriter, r *http.Request){
+	"get_history":  (*Handler).tautulliCompatHistory,
+	"get_activity": (*Handler).tautulliCompatActivity,
+	"get_users":    (*Handler).tautulliCompatUsers,
+}
+
+// tautulliCompatError is Tautulli's error envelope
+type tautulliCompatError struct {
+	Response struct {
+		Result  string   `json:"result"`
+		Message string   `json:"message"`
+		Data    struct{} `json:"data"`
+	} `json:"response"`
+}
+
+// respondTautulliCompatError answers in Tautulli's error envelope
+func respondTautulliCompatError(w http.ResponseWriter, status int, message string) {
+	var resp tautulliCompatError
+	resp.Response.Result = "error"
+	resp.Response.Message = message
+	writeJSONResponse(w, status, resp)
+}
+
+// TautulliCompatAuth authenticates /api/v2 requests. A request whose apikey
+// parameter matches TAUTULLI_COMPAT_API_KEY is let through, one with any
+// other apikey is rejected, and one without an apikey goes through
+// authenticate, the regular authentication middleware.
+func (h *Handler) TautulliCompatAuth(authenticate func(http.HandlerFunc) http.HandlerFunc, next http.HandlerFunc) http.HandlerFunc {
+	authenticated := authenticate(next)
+	return func(w http.ResponseWriter, r *http.Request) {
+		apiKey := r.URL.Query().Get("apikey")
+		if apiKey == "" {
+			authenticated(w, r)
+			return
+		}
+
+		var want string
+		if h.config != nil {
+			want = h.config.Tautulli.CompatAPIKey
+		}
+		if want == "" || subtle.ConstantTimeCompare([]byte(apiKey), []byte(want)) != 1 {
+			respondTautulliCompatError(w, http.StatusUnauthorized, "Invalid apikey")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// TautulliCompatAPI serves the emulated Tautulli API commands at /api/v2.
+// It has no swagger annotations: the route lives outside the /api/v1 base
+// path, and its responses are Tautulli's, documented in API-REFERENCE.md.
+func (h *Handler) TautulliCompatAPI(w http.ResponseWriter, r *http.Request) {
+	cmd := r.URL.Query().Get("cmd")
+	serve, ok := tautulliCompatCommands[cmd]
+	if !ok {
+		respondTautulliCompatError(w, http.StatusBadRequest, fmt.Sprintf("Unknown command: %s", sanitizeLogValue(cmd)))
+		return
+	}
+	if h.db == nil {
+		respondTautulliCompatError(w, http.StatusServiceUnavailable, "Database not available")
+		return
+	}
+	serve(h, w, r)
+}
+
+// beginCompatQuery starts the query budget of a compat command. On failure
+// it has already answered the request.
+func (h *Handler) beginCompatQuery(w http.ResponseWriter, r *http.Request) (context.Context, func(), bool) {
+	queryCtx, done, err := h.beginQuery(r)
+	if err != nil {
+		h.respondCompatQueryError(w, r, queryCtx, err)
+		return nil, done, false
+	}
+	return queryCtx, done, true
+}
+
+// respondCompatQueryError is respondQueryError in Tautulli's error envelope
+func (h *Handler) respondCompatQueryError(w http.ResponseWriter, r *http.Request, queryCtx context.Context, err error) {
+	if r.Context().Err() != nil {
+		return
+	}
+	if errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+		respondTautulliCompatError(w, http.StatusGatewayTimeout,
+			fmt.Sprintf("Query exceeded the %s query budget", h.QueryTimeout()))
+		return
+	}
+	logging.Error().Err(err).Str("cmd", r.URL.Query().Get("cmd")).Msg("Tautulli-compatible API query failed")
+	respondTautulliCompatError(w, http.StatusInternalServerError, "Database error")
+}
+
+func (h *Handler) tautulliCompatHistory(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseCompatHistoryFilter(r)
+	if err != nil {
+		respondTautulliCompatError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	queryCtx, done, ok := h.beginCompatQuery(w, r)
+	defer done()
+	if !ok {
+		return
+	}
+
+	page, err := h.db.GetPlaybackHistory(queryCtx, filter)
+	if err != nil {
+		h.respondCompatQueryError(w, r, queryCtx, err)
+		return
+	}
+
+	records := make([]tautulli.TautulliHistoryRecord, len(page.Events))
+	for i := range page.Events {
+		records[i] = compatHistoryRecord(&page.Events[i])
+	}
+	writeJSONResponse(w, http.StatusOK, tautulli.TautulliHistory{
+		Response: tautulli.TautulliHistoryResponse{
+			Result: "success",
+			Data: tautulli.TautulliHistoryData{
+				RecordsFiltered: page.RecordsFiltered,
+				RecordsTotal:    page.RecordsTotal,
+				Data:            records,
+			},
+		},
+	})
+}
+
+// parseCompatHistoryFilter reads get_history's parameters: start, length,
+// order_dir, user, user_id, media_type, rating_key (or parent_ or
+// grandparent_rating_key), search, and the YYYY-MM-DD dates start_date,
+// after and before (both inclusive, as in Tautulli)
+func parseCompatHistoryFilter(r *http.Request) (database.PlaybackHistoryFilter, error) {
+	q := r.URL.Query()
+	filter := database.PlaybackHistoryFilter{
+		Username:  q.Get("user"),
+		MediaType: q.Get("media_type"),
+		Search:    q.Get("search"),
+		Length:    compatHistoryDefaultLength,
+		Ascending: strings.EqualFold(q.Get("order_dir"), "asc"),
+	}
+
+	ints := []struct {
+		param string
+		dest  *int
+	}{
+		{"start", &filter.Start},
+		{"length", &filter.Length},
+		{"user_id", &filter.UserID},
+	}
+	for _, p := range ints {
+		raw := q.Get(p.param)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 0 {
+			return filter, fmt.Errorf("%s must be a non-negative integer", p.param)
+		}
+		*p.dest = v
+	}
+	if filter.Length > models.MaxFilterLimit {
+		return filter, fmt.Errorf("length must be at most %d", models.MaxFilterLimit)
+	}
+
+	for _, param := range []string{"rating_key", "parent_rating_key", "grandparent_rating_key"} {
+		if v := q.Get(param); v != "" {
+			filter.RatingKey = v
+			break
+		}
+	}
+
+	day := func(param string) (*time.Time, error) {
+		raw := q.Get(param)
+		if raw == "" {
+			return nil, nil
+		}
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", param)
+		}
+		return &t, nil
+	}
+	startDate, err := day("start_date")
+	if err != nil {
+		return filter, err
+	}
+	after, err := day("after")
+	if err != nil {
+		return filter, err
+	}
+	before, err := day("before")
+	if err != nil {
+		return filter, err
+	}
+
+	if startDate != nil {
+		after, before = startDate, startDate
+	}
+	filter.After = after
+	if before != nil {
+		end := before.AddDate(0, 0, 1)
+		filter.Before = &end
+	}
+	return filter, nil
+}
+
+func (h *Handler) tautulliCompatActivity(w http.ResponseWriter, r *http.Request) {
+	queryCtx, done, ok := h.beginCompatQuery(w, r)
+	defer done()
+	if !ok {
+		return
+	}
+
+	events, err := h.db.GetOpenPlaybackSessions(queryCtx, time.Now().Add(-compatActivityWindow))
+	if err != nil {
+		h.respondCompatQueryError(w, r, queryCtx, err)
+		return
+	}
+
+	data := tautulli.TautulliActivityData{
+		StreamCount: len(events),
+		Sessions:    make([]tautulli.TautulliActivitySession, len(events)),
+	}
+	for i := range events {
+		session := compatActivitySession(&events[i])
+		data.Sessions[i] = session
+
+		switch session.TranscodeDecision {
+		case "transcode":
+			data.StreamCountTranscode++
+		case "copy":
+			data.StreamCountDirectStream++
+		default:
+			data.StreamCountDirectPlay++
+		}
+		data.TotalBandwidth += session.Bandwidth
+		if session.Location == "lan" {
+			data.LANBandwidth += session.Bandwidth
+		} else {
+			data.WANBandwidth += session.Bandwidth
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, tautulli.TautulliActivity{
+		Response: tautulli.TautulliActivityResponse{Result: "success", Data: data},
+	})
+}
+
+func (h *Handler) tautulliCompatUsers(w http.ResponseWriter, r *http.Request) {
+	queryCtx, done, ok := h.beginCompatQuery(w, r)
+	defer done()
+	if !ok {
+		return
+	}
+
+	users, err := h.db.GetPlaybackUsers(queryCtx)
+	if err != nil {
+		h.respondCompatQueryError(w, r, queryCtx, err)
+		return
+	}
+
+	data := make([]tautulli.TautulliUserData, len(users))
+	for i := range users {
+		data[i] = compatUserData(&users[i])
+	}
+	writeJSONResponse(w, http.StatusOK, tautulli.TautulliUsers{
+		Response: tautulli.TautulliUsersResponse{Result: "success", Data: data},
+	})
+}
+
+// compatHistoryRecord converts a playback event to a get_history row.
+// Durations are in seconds, as in Tautulli; non-numeric rating keys (e.g.
+// Jellyfin item IDs) are reported as null.
+func compatHistoryRecord(e *models.PlaybackEvent) tautulli.TautulliHistoryRecord {
+	var stopped int64
+	if e.StoppedAt != nil {
+		stopped = e.StoppedAt.Unix()
+	}
+	var duration *int
+	if e.PlayDuration != nil {
+		seconds := *e.PlayDuration * 60
+		duration = &seconds
+	}
+	sessionKey := e.SessionKey
+	userID := e.UserID
+	percent := e.PercentComplete
+	paused := e.PausedCounter
+
+	return tautulli.TautulliHistoryRecord{
+		SessionKey: &sessionKey,
+		Date:       e.StartedAt.Unix(),
+		Started:    e.StartedAt.Unix(),
+		Stopped:    stopped,
+		State:      e.State,
+
+		UserID:          &userID,
+		User:            e.Username,
+		FriendlyName:    compatFriendlyName(e.FriendlyName, e.Username),
+		UserThumb:       compatString(e.UserThumb),
+		Email:           compatString(e.Email),
+		IPAddress:       e.IPAddress,
+		IPAddressPublic: compatString(e.IPAddressPublic),
+
+		MediaType:        e.MediaType,
+		Title:            e.Title,
+		ParentTitle:      e.ParentTitle,
+		GrandparentTitle: e.GrandparentTitle,
+		FullTitle:        compatFullTitle(e),
+		Year:             e.Year,
+
+		Platform:       e.Platform,
+		PlatformName:   compatString(e.PlatformName),
+		Player:         e.Player,
+		Product:        compatString(e.Product),
+		Device:         compatString(e.Device),
+		MachineID:      compatString(e.MachineID),
+		Location:       compatString(e.Location),
+		QualityProfile: compatString(e.QualityProfile),
+
+		PercentComplete: &percent,
+		PausedCounter:   &paused,
+		Duration:        duration,
+
+		TranscodeDecision: compatString(e.TranscodeDecision),
+		VideoDecision:     compatString(e.VideoDecision),
+		AudioDecision:     compatString(e.AudioDecision),
+
+		VideoResolution:       compatString(e.VideoResolution),
+		VideoFullResolution:   compatString(e.VideoFullResolution),
+		VideoCodec:            compatString(e.VideoCodec),
+		AudioCodec:            compatString(e.AudioCodec),
+		Container:             compatString(e.Container),
+		Bitrate:               e.Bitrate,
+		StreamVideoResolution: compatString(e.StreamVideoResolution),
+		StreamBitrate:         e.StreamBitrate,
+
+		SectionID:   e.SectionID,
+		LibraryName: compatString(e.LibraryName),
+
+		RatingKey:            compatRatingKey(e.RatingKey),
+		ParentRatingKey:      compatRatingKey(e.ParentRatingKey),
+		GrandparentRatingKey: compatRatingKey(e.GrandparentRatingKey),
+		MediaIndex:           e.MediaIndex,
+		ParentMediaIndex:     e.ParentMediaIndex,
+		GUID:                 compatString(e.GUID),
+		Thumb:                compatString(e.Thumb),
+
+		Local:     e.Local,
+		Secure:    e.Secure,
+		Relayed:   e.Relayed,
+		Bandwidth: e.Bandwidth,
+	}
+}
+
+// compatActivitySession converts an open playback event to a get_activity
+// session. State defaults to playing.
+func compatActivitySession(e *models.PlaybackEvent) tautulli.TautulliActivitySession {
+	state := compatString(e.State)
+	if state == "" {
+		state = "playing"
+	}
+	var sectionID string
+	if e.SectionID != nil {
+		sectionID = strconv.Itoa(*e.SectionID)
+	}
+	var mediaIndex, parentMediaIndex string
+	if e.MediaIndex != nil {
+		mediaIndex = strconv.Itoa(*e.MediaIndex)
+	}
+	if e.ParentMediaIndex != nil {
+		parentMediaIndex = strconv.Itoa(*e.ParentMediaIndex)
+	}
+
+	return tautulli.TautulliActivitySession{
+		SessionKey: e.SessionKey,
+
+		MediaType:            e.MediaType,
+		RatingKey:            compatString(e.RatingKey),
+		ParentRatingKey:      compatString(e.ParentRatingKey),
+		GrandparentRatingKey: compatString(e.GrandparentRatingKey),
+		Title:                e.Title,
+		ParentTitle:          compatString(e.ParentTitle),
+		GrandparentTitle:     compatString(e.GrandparentTitle),
+		FullTitle:            compatFullTitle(e),
+		MediaIndex:           mediaIndex,
+		ParentMediaIndex:     parentMediaIndex,
+		Year:                 compatInt(e.Year),
+		Thumb:                compatString(e.Thumb),
+
+		User:         e.Username,
+		UserID:       e.UserID,
+		FriendlyName: compatFriendlyName(e.FriendlyName, e.Username),
+		UserThumb:    compatString(e.UserThumb),
+		Email:        compatString(e.Email),
+
+		IPAddress:       e.IPAddress,
+		IPAddressPublic: compatString(e.IPAddressPublic),
+		Player:          e.Player,
+		Platform:        e.Platform,
+		PlatformName:    compatString(e.PlatformName),
+		Product:         compatString(e.Product),
+		Device:          compatString(e.Device),
+		MachineID:       compatString(e.MachineID),
+		Local:           compatInt(e.Local),
+		QualityProfile:  compatString(e.QualityProfile),
+
+		State:           state,
+		ProgressPercent: e.PercentComplete,
+
+		TranscodeDecision: compatString(e.TranscodeDecision),
+		VideoDecision:     compatString(e.VideoDecision),
+		AudioDecision:     compatString(e.AudioDecision),
+
+		Container:             compatString(e.Container),
+		VideoCodec:            compatString(e.VideoCodec),
+		VideoResolution:       compatString(e.VideoResolution),
+		VideoFullResolution:   compatString(e.VideoFullResolution),
+		AudioCodec:            compatString(e.AudioCodec),
+		StreamVideoResolution: compatString(e.StreamVideoResolution),
+		StreamBitrate:         compatInt(e.StreamBitrate),
+
+		Bitrate:   compatInt(e.Bitrate),
+		Bandwidth: compatInt(e.Bandwidth),
+		Location:  compatString(e.Location),
+		Secure:    compatInt(e.Secure),
+		Relayed:   compatInt(e.Relayed),
+
+		SectionID:   sectionID,
+		LibraryName: compatString(e.LibraryName),
+		GUID:        compatString(e.GUID),
+	}
+}
+
+// compatUserData converts a playback user to a get_users entry
+func compatUserData(u *database.PlaybackUser) tautulli.TautulliUserData {
+	var libraries []string
+	if u.SharedLibraries != nil && *u.SharedLibraries != "" {
+		libraries = strings.Split(*u.SharedLibraries, ";")
+	}
+	return tautulli.TautulliUserData{
+		UserID:          u.UserID,
+		Username:        u.Username,
+		FriendlyName:    compatFriendlyName(u.FriendlyName, u.Username),
+		UserThumb:       compatString(u.UserThumb),
+		Email:           compatString(u.Email),
+		IsHomeUser:      compatInt(u.IsHomeUser),
+		IsAllowSync:     compatInt(u.IsAllowSync),
+		IsRestricted:    compatInt(u.IsRestricted),
+		DoNotify:        compatInt(u.DoNotify),
+		KeepHistory:     compatInt(u.KeepHistory),
+		DeletedUser:     compatInt(u.DeletedUser),
+		AllowGuest:      compatInt(u.AllowGuest),
+		SharedLibraries: libraries,
+	}
+}
+
+// compatFullTitle returns the stored full title, or builds Tautulli's
+// "Show - Episode" form
+func compatFullTitle(e *models.PlaybackEvent) string {
+	if full := compatString(e.FullTitle); full != "" {
+		return full
+	}
+	if show := compatString(e.GrandparentTitle); show != "" {
+		return show + " - " + e.Title
+	}
+	return e.Title
+}
+
+// compatFriendlyName falls back to the username, as Tautulli does
+func compatFriendlyName(friendlyName *string, username string) string {
+	if name := compatString(friendlyName); name != "" {
+		return name
+	}
+	return username
+}
+
+func compatRatingKey(key *string) *int {
+	if key == nil {
+		return nil
+	}
+	v, err := strconv.Atoi(*key)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func compatString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func compatInt(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
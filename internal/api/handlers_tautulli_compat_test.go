@@ -0,0 +1,276 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+
+	"github.com/tomtom215/cartographus/internal/auth"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/models/tautulli"
+)
+
+// insertCompatEvent inserts a playback started minutesAgo minutes ago,
+// stopped 30 minutes later unless open
+func insertCompatEvent(t *testing.T, db *database.DB, minutesAgo, userID int, username, title string, open bool) {
+	t.Helper()
+
+	startedAt := time.Now().Add(-time.Duration(minutesAgo) * time.Minute).Truncate(time.Second)
+	ratingKey := "1234"
+	show := "The Show"
+	decision := "transcode"
+	location := "wan"
+	bandwidth := 4000
+	duration := 30
+	event := &models.PlaybackEvent{
+		SessionKey:        uuid.New().String(),
+		StartedAt:         startedAt,
+		UserID:            userID,
+		Username:          username,
+		IPAddress:         "192.168.1.100",
+		MediaType:         "episode",
+		Title:             title,
+		GrandparentTitle:  &show,
+		RatingKey:         &ratingKey,
+		Platform:          "Roku",
+		Player:            "Living Room",
+		PercentComplete:   50,
+		PlayDuration:      &duration,
+		TranscodeDecision: &decision,
+		Location:          &location,
+		Bandwidth:         &bandwidth,
+	}
+	if !open {
+		stoppedAt := startedAt.Add(30 * time.Minute)
+		event.StoppedAt = &stoppedAt
+	}
+	if err := db.InsertPlaybackEvent(event); err != nil {
+		t.Fatalf("Failed to insert playback event: %v", err)
+	}
+}
+
+func serveCompat(h *Handler, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v2?"+query, nil)
+	w := httptest.NewRecorder()
+	h.TautulliCompatAPI(w, req)
+	return w
+}
+
+func decodeCompat(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+}
+
+func TestTautulliCompatAPI_GetHistory(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	h := setupTestHandlerWithDB(t, db)
+
+	insertCompatEvent(t, db, 180, 1, "alice", "Pilot", false)
+	insertCompatEvent(t, db, 120, 2, "bob", "Episode 2", false)
+	insertCompatEvent(t, db, 60, 1, "alice", "Episode 3", false)
+
+	var resp tautulli.TautulliHistory
+	decodeCompat(t, serveCompat(h, "cmd=get_history&user=alice&length=1"), &resp)
+
+	if resp.Response.Result != "success" {
+		t.Errorf("result = %q, want success", resp.Response.Result)
+	}
+	data := resp.Response.Data
+	if data.RecordsTotal != 3 || data.RecordsFiltered != 2 {
+		t.Errorf("records total/filtered = %d/%d, want 3/2", data.RecordsTotal, data.RecordsFiltered)
+	}
+	if len(data.Data) != 1 {
+		t.Fatalf("got %d records, want 1", len(data.Data))
+	}
+
+	record := data.Data[0]
+	if record.Title != "Episode 3" || record.FullTitle != "The Show - Episode 3" {
+		t.Errorf("title = %q / %q, want the newest of alice's plays", record.Title, record.FullTitle)
+	}
+	if record.User != "alice" || record.FriendlyName != "alice" {
+		t.Errorf("user = %q, friendly name = %q, want alice", record.User, record.FriendlyName)
+	}
+	if record.Duration == nil || *record.Duration != 30*60 {
+		t.Errorf("duration = %v, want 1800 seconds", record.Duration)
+	}
+	if record.RatingKey == nil || *record.RatingKey != 1234 {
+		t.Errorf("rating_key = %v, want 1234", record.RatingKey)
+	}
+	if record.Stopped-record.Started != 30*60 {
+		t.Errorf("stopped - started = %d, want 1800", record.Stopped-record.Started)
+	}
+}
+
+func TestTautulliCompatAPI_GetHistoryInvalidParameter(t *testing.T) {
+	h := &Handler{db: &database.DB{}}
+
+	for _, query := range []string{
+		"cmd=get_history&length=abc",
+		"cmd=get_history&start=-1",
+		"cmd=get_history&before=yesterday",
+	} {
+		w := serveCompat(h, query)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+		}
+	}
+}
+
+func TestTautulliCompatAPI_GetActivity(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	h := setupTestHandlerWithDB(t, db)
+
+	insertCompatEvent(t, db, 120, 1, "alice", "Finished", false)
+	insertCompatEvent(t, db, 10, 2, "bob", "Watching", true)
+	insertCompatEvent(t, db, 3*24*60, 3, "carol", "Never stopped", true)
+
+	var resp tautulli.TautulliActivity
+	decodeCompat(t, serveCompat(h, "cmd=get_activity"), &resp)
+
+	data := resp.Response.Data
+	if data.StreamCount != 1 || len(data.Sessions) != 1 {
+		t.Fatalf("stream count = %d with %d sessions, want 1", data.StreamCount, len(data.Sessions))
+	}
+	if data.StreamCountTranscode != 1 {
+		t.Errorf("transcode count = %d, want 1", data.StreamCountTranscode)
+	}
+	if data.WANBandwidth != 4000 || data.TotalBandwidth != 4000 {
+		t.Errorf("wan/total bandwidth = %d/%d, want 4000/4000", data.WANBandwidth, data.TotalBandwidth)
+	}
+	session := data.Sessions[0]
+	if session.Title != "Watching" || session.User != "bob" || session.State != "playing" {
+		t.Errorf("session = %q by %q (%s), want Watching by bob (playing)", session.Title, session.User, session.State)
+	}
+	if session.RatingKey != "1234" {
+		t.Errorf("rating_key = %q, want 1234", session.RatingKey)
+	}
+}
+
+func TestTautulliCompatAPI_GetUsers(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	h := setupTestHandlerWithDB(t, db)
+
+	insertCompatEvent(t, db, 60, 2, "bob", "Pilot", false)
+	insertCompatEvent(t, db, 30, 1, "alice", "Pilot", false)
+	insertCompatEvent(t, db, 20, 1, "alice", "Episode 2", false)
+
+	var resp tautulli.TautulliUsers
+	decodeCompat(t, serveCompat(h, "cmd=get_users"), &resp)
+
+	users := resp.Response.Data
+	if len(users) != 2 {
+		t.Fatalf("got %d users, want 2", len(users))
+	}
+	if users[0].Username != "alice" || users[0].UserID != 1 || users[1].Username != "bob" {
+		t.Errorf("users = %+v, want alice then bob", users)
+	}
+}
+
+func TestTautulliCompatAPI_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    *Handler
+		query      string
+		wantStatus int
+	}{
+		{"unknown command", &Handler{db: &database.DB{}}, "cmd=delete_history", http.StatusBadRequest},
+		{"missing command", &Handler{db: &database.DB{}}, "", http.StatusBadRequest},
+		{"no database", &Handler{}, "cmd=get_history", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveCompat(tt.handler, tt.query)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var resp tautulliCompatError
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Response.Result != "error" || resp.Response.Message == "" {
+				t.Errorf("response = %+v, want a Tautulli error", resp.Response)
+			}
+		})
+	}
+}
+
+func TestTautulliCompatAuth(t *testing.T) {
+	h := &Handler{config: &config.Config{
+		Tautulli: config.TautulliConfig{CompatAPIKey: "0123456789abcdef"},
+	}}
+	denyAll := func(http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}
+	ok := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	wrapped := h.TautulliCompatAuth(denyAll, ok)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{"matching apikey", "apikey=0123456789abcdef", http.StatusOK},
+		{"wrong apikey", "apikey=fedcba9876543210", http.StatusUnauthorized},
+		{"no apikey uses regular auth", "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			wrapped(w, httptest.NewRequest(http.MethodGet, "/api/v2?cmd=get_users&"+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	// Without a configured key, every apikey is rejected
+	h.config.Tautulli.CompatAPIKey = ""
+	w := httptest.NewRecorder()
+	h.TautulliCompatAuth(denyAll, ok)(w, httptest.NewRequest(http.MethodGet, "/api/v2?apikey=anything", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status without configured key = %d, want 401", w.Code)
+	}
+}
+
+func TestTautulliCompatAPI_RegisteredOnlyWhenEnabled(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		mw := auth.NewMiddleware(nil, nil, string(auth.AuthModeNone), 100, time.Minute, true, nil, nil, "", "")
+		cfg := &config.Config{Tautulli: config.TautulliConfig{CompatAPI: enabled}}
+		mux := NewRouter(&Handler{config: cfg}, mw).SetupChi()
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2?cmd=get_users", nil))
+
+		want := http.StatusNotFound
+		if enabled {
+			want = http.StatusServiceUnavailable // no database in this handler
+		}
+		if w.Code != want {
+			t.Errorf("enabled=%v: status = %d, want %d", enabled, w.Code, want)
+		}
+	}
+}
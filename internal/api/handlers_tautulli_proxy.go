@@ -55,7 +55,7 @@ func proxyTautulliRequest[P any, R any](h *Handler, w http.ResponseWriter, r *ht
 
 	// Check HTTP method
 	if r.Method != httpMethod {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -64,13 +64,13 @@ func proxyTautulliRequest[P any, R any](h *Handler, w http.ResponseWriter, r *ht
 	// Parse and validate parameters FIRST (validation errors = 400)
 	params, err := config.ParseParams(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_PARAMETER", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_PARAMETER", err.Error(), nil)
 		return
 	}
 
 	// Check if Tautulli client is available AFTER validation (service errors = 503)
 	if h.client == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Tautulli client not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Tautulli client not available", nil)
 		return
 	}
 
@@ -97,7 +97,7 @@ func proxyTautulliRequest[P any, R any](h *Handler, w http.ResponseWriter, r *ht
 	// Call Tautulli client
 	response, err := config.CallClient(r.Context(), h, params)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "TAUTULLI_ERROR",
+		respondError(w, r, http.StatusInternalServerError, "TAUTULLI_ERROR",
 			config.ErrorMessage, err)
 		return
 	}
@@ -105,7 +105,7 @@ func proxyTautulliRequest[P any, R any](h *Handler, w http.ResponseWriter, r *ht
 	// Check if response is nil (defensive check for nil pointer responses)
 	// This uses reflection because R is a generic type parameter that could be a pointer
 	if isNilResponse(response) {
-		respondError(w, http.StatusInternalServerError, "TAUTULLI_ERROR",
+		respondError(w, r, http.StatusInternalServerError, "TAUTULLI_ERROR",
 			"Received nil response from Tautulli", nil)
 		return
 	}
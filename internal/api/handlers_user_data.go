@@ -0,0 +1,213 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// =============================================================================
+// User Data Erasure and Export API Handlers (data subject requests)
+// =============================================================================
+
+// wrappedRebuildTimeout bounds the background rebuild of wrapped reports
+// after an erasure.
+const wrappedRebuildTimeout = 10 * time.Minute
+
+// UserEraseRequest is the optional request body of UserErase.
+type UserEraseRequest struct {
+	// Mode is "delete" (default) or "anonymize"
+	Mode string `json:"mode"`
+}
+
+// UserErase handles POST /api/v1/admin/users/{id}/erase
+// Deletes or anonymizes everything stored about an internal user and the
+// users linked to it across sources, in one transaction, and reports the
+// rows affected per table. Analytics caches are cleared, and wrapped
+// reports of the affected years are rebuilt in the background so their
+// percentiles stop counting the erased history.
+//
+// Request body (optional):
+//   - mode: "delete" (default) removes playback history and alerts;
+//     "anonymize" keeps them for aggregate statistics without the user's
+//     identity, IP addresses or devices
+//
+// @Summary Erase a user's data
+// @Description Deletes or anonymizes a user's playback history, alerts, trust scores, feedback and audit references.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Internal user ID"
+// @Param request body UserEraseRequest false "Erasure mode"
+// @Success 200 {object} models.APIResponse{data=database.UserErasureResult} "Rows affected per table"
+// @Failure 400 {object} models.APIResponse "Invalid user ID or mode"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 404 {object} models.APIResponse "User not found"
+// @Failure 500 {object} models.APIResponse "Erasure failed"
+// @Router /admin/users/{id}/erase [post]
+func (h *Handler) UserErase(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserDataID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UserEraseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request body", err)
+		return
+	}
+	mode, err := database.ParseUserErasureMode(req.Mode)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		return
+	}
+
+	result, err := h.db.EraseUserData(r.Context(), userID, mode)
+	if err != nil {
+		respondUserDataLookupError(w, r, userID, "Failed to erase user data", err)
+		return
+	}
+
+	h.ClearCache()
+	h.bumpSyncGeneration()
+	go h.rebuildWrappedReports(result.AffectedYears)
+
+	rows := make(map[string]int64, len(result.Tables))
+	for _, t := range result.Tables {
+		rows[t.Table] = t.Rows
+	}
+	h.auditUserData(r, "user.erase",
+		fmt.Sprintf("Erased data of user %d (%s)", userID, mode),
+		map[string]interface{}{
+			"user_id":  userID,
+			"user_ids": result.UserIDs,
+			"mode":     mode,
+			"rows":     rows,
+		})
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     result,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// UserExport handles GET /api/v1/admin/users/{id}/export
+// Streams a zip archive of everything stored about an internal user and
+// the users linked to it: user mappings, playback history, the locations
+// played from and detection alerts as NDJSON, plus an export.json manifest.
+//
+// @Summary Export a user's data
+// @Description Downloads a zip of a user's playback history, locations and alerts as NDJSON.
+// @Tags Admin
+// @Produce application/zip
+// @Security BearerAuth
+// @Param id path int true "Internal user ID"
+// @Success 200 {file} file "Zip archive"
+// @Failure 400 {object} models.APIResponse "Invalid user ID"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 404 {object} models.APIResponse "User not found"
+// @Failure 500 {object} models.APIResponse "Export failed"
+// @Router /admin/users/{id}/export [get]
+func (h *Handler) UserExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserDataID(w, r)
+	if !ok {
+		return
+	}
+
+	// Resolve the user first; once the archive streams the status is sent
+	ids, err := h.db.ResolveUserDataIDs(r.Context(), userID)
+	if err != nil {
+		respondUserDataLookupError(w, r, userID, "Failed to export user data", err)
+		return
+	}
+
+	filename := fmt.Sprintf("cartographus-user-%d-%s.zip", userID, time.Now().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+
+	info, err := h.db.WriteUserDataExport(r.Context(), userID, ids, w)
+	if err != nil {
+		// Headers are already sent; the client gets a truncated archive
+		logging.Error().Err(err).Int("user_id", userID).Msg("User data export failed")
+		return
+	}
+
+	h.auditUserData(r, "user.export", fmt.Sprintf("Exported data of user %d", userID),
+		map[string]interface{}{
+			"user_id":  userID,
+			"user_ids": info.UserIDs,
+			"files":    info.Files,
+		})
+}
+
+// rebuildWrappedReports regenerates wrapped reports after an erasure.
+func (h *Handler) rebuildWrappedReports(years []int) {
+	if len(years) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), wrappedRebuildTimeout)
+	defer cancel()
+
+	n, err := h.db.RebuildWrappedReports(ctx, years)
+	if err != nil {
+		logging.Error().Err(err).Ints("years", years).Msg("Failed to rebuild wrapped reports after erasure")
+		return
+	}
+	logging.Info().Int("reports", n).Ints("years", years).Msg("Rebuilt wrapped reports after erasure")
+}
+
+// auditUserData records an erasure or export by the requesting admin.
+func (h *Handler) auditUserData(r *http.Request, action, description string, metadata map[string]interface{}) {
+	hctx := GetHandlerContext(r)
+	logging.Warn().
+		Str("admin", hctx.Username).
+		Str("action", action).
+		Interface("metadata", metadata).
+		Msg(description)
+
+	if h.auditLogger != nil {
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, action, description, metadata)
+	}
+}
+
+// parseUserDataID parses the {id} path value, writing a 400 response on failure.
+func parseUserDataID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid user ID", err)
+		return 0, false
+	}
+	return userID, true
+}
+
+// respondUserDataLookupError maps ErrUserNotFound to 404 and anything else to 500.
+func respondUserDataLookupError(w http.ResponseWriter, r *http.Request, userID int, msg string, err error) {
+	if errors.Is(err, database.ErrUserNotFound) {
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "User not found", err)
+		return
+	}
+	logging.Error().Err(err).Int("user_id", userID).Msg(msg)
+	respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", msg, err)
+}
@@ -0,0 +1,165 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/database"
+)
+
+// The cases below are rejected before the database is touched.
+
+func TestUserErase_Validation(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	tests := []struct {
+		name string
+		id   string
+		body string
+	}{
+		{"non-numeric id", "abc", ""},
+		{"malformed body", "1", `{`},
+		{"unknown mode", "1", `{"mode":"shred"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+tt.id+"/erase", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			h.UserErase(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400 (body: %s)", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserErase_WithDB(t *testing.T) {
+	t.Parallel()
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	// user1 has events 0, 3, 6 and 9
+	insertTestPlaybacks(t, db, 10)
+	handler := setupTestHandlerWithDB(t, db)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/1/erase", strings.NewReader(`{"mode":"delete"}`))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.UserErase(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Status string                     `json:"status"`
+		Data   database.UserErasureResult `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Data.Mode != database.UserErasureDelete {
+		t.Errorf("mode = %q, want delete", resp.Data.Mode)
+	}
+	var playbackRows int64 = -1
+	for _, table := range resp.Data.Tables {
+		if table.Table == "playback_events" {
+			playbackRows = table.Rows
+		}
+	}
+	if playbackRows != 4 {
+		t.Errorf("playback_events rows = %d, want 4 (tables: %+v)", playbackRows, resp.Data.Tables)
+	}
+
+	// Erasing again finds nothing
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/1/erase", http.NoBody)
+	req.SetPathValue("id", "1")
+	w = httptest.NewRecorder()
+	handler.UserErase(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("second erase status = %d, want 404", w.Code)
+	}
+}
+
+func TestUserExport_WithDB(t *testing.T) {
+	t.Parallel()
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+
+	insertTestPlaybacks(t, db, 10)
+	handler := setupTestHandlerWithDB(t, db)
+
+	t.Run("unknown user", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/99/export", nil)
+		req.SetPathValue("id", "99")
+		w := httptest.NewRecorder()
+		handler.UserExport(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want 404", w.Code)
+		}
+	})
+
+	t.Run("zip archive", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/2/export", nil)
+		req.SetPathValue("id", "2")
+		w := httptest.NewRecorder()
+		handler.UserExport(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200 (body: %s)", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/zip" {
+			t.Errorf("Content-Type = %q, want application/zip", ct)
+		}
+		if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, "cartographus-user-2-") {
+			t.Errorf("Content-Disposition = %q, want the user's export file name", cd)
+		}
+
+		body := w.Body.Bytes()
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("response is not a zip archive: %v", err)
+		}
+		files := make(map[string]string)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[f.Name] = string(data)
+		}
+
+		// user2 has events 1, 4 and 7
+		if n := strings.Count(files["playback_history.ndjson"], "\n"); n != 3 {
+			t.Errorf("playback_history.ndjson has %d lines, want 3", n)
+		}
+		var info database.UserDataExportInfo
+		if err := json.Unmarshal([]byte(files["export.json"]), &info); err != nil {
+			t.Fatalf("export.json: %v", err)
+		}
+		if info.UserID != 2 || info.Files["playback_history.ndjson"] != 3 {
+			t.Errorf("export.json = %+v, want user 2 with 3 playbacks", info)
+		}
+	})
+}
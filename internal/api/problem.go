@@ -0,0 +1,185 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/validation"
+)
+
+// problemContentType is the RFC 7807 media type for error responses.
+const problemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem document. Code, RequestID, Errors and
+// Details are extension members; Code carries the same value as the legacy
+// envelope's error.code.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Code      ErrorCode           `json:"code"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []ProblemFieldError `json:"errors,omitempty"`
+	Details   interface{}         `json:"details,omitempty"`
+}
+
+// ProblemFieldError describes one invalid request field in a problem document.
+type ProblemFieldError struct {
+	// Field is the field's JSON path, e.g. "items[0].name"
+	Field string `json:"field"`
+
+	// Message is the human-readable validation message
+	Message string `json:"message"`
+
+	// Rule is the validation rule that failed, e.g. "max"
+	Rule string `json:"rule"`
+}
+
+// newProblem builds a problem document for a request.
+func newProblem(r *http.Request, status int, code ErrorCode, detail string) *ProblemDetails {
+	problem := &ProblemDetails{
+		Type:   code.TypeURI(),
+		Title:  code.Title(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+	if r != nil {
+		problem.Instance = r.URL.Path
+		problem.RequestID = logging.RequestIDFromContext(r.Context())
+	}
+	return problem
+}
+
+// wantsProblemJSON reports whether the client accepts application/problem+json.
+//
+// Error responses are negotiated on Accept during the deprecation window of
+// the legacy envelope: clients listing application/problem+json (with a
+// non-zero q) receive problem documents, everyone else keeps receiving the
+// {"status": "error", "error": {...}} envelope. A nil request gets the envelope.
+func wantsProblemJSON(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mediaType != problemContentType {
+				continue
+			}
+			if q, ok := params["q"]; ok {
+				if v, err := strconv.ParseFloat(q, 64); err != nil || v <= 0 {
+					continue
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// respondProblem writes an error as a problem document or the legacy
+// envelope, depending on the request's Accept header.
+func respondProblem(w http.ResponseWriter, r *http.Request, problem *ProblemDetails, legacyDetails map[string]interface{}) {
+	if wantsProblemJSON(r) {
+		writeErrorBody(w, problem.Status, problemContentType, problem)
+		return
+	}
+
+	writeErrorBody(w, problem.Status, "application/json", &models.APIResponse{
+		Status:   "error",
+		Data:     nil,
+		Metadata: models.Metadata{Timestamp: time.Now()},
+		Error: &models.APIError{
+			Code:    string(problem.Code),
+			Message: problem.Detail,
+			Details: legacyDetails,
+		},
+	})
+}
+
+// respondValidationError sends a 400 VALIDATION_ERROR for failed struct
+// validation. Problem documents list every field in "errors"; the legacy
+// envelope keeps the details of RequestValidationError.ToAPIError.
+func respondValidationError(w http.ResponseWriter, r *http.Request, verr *validation.RequestValidationError) {
+	apiErr := verr.ToAPIError()
+	problem := newProblem(r, http.StatusBadRequest, ErrorCode(apiErr.Code), apiErr.Message)
+	for _, fieldErr := range verr.Errors() {
+		problem.Errors = append(problem.Errors, ProblemFieldError{
+			Field:   fieldErr.Path(),
+			Message: fieldErr.Error(),
+			Rule:    fieldErr.Tag(),
+		})
+	}
+	respondProblem(w, r, problem, apiErr.Details)
+}
+
+// respondFilterError sends a 400 VALIDATION_ERROR for a rejected analytics
+// filter. Struct and filter validation errors keep their per-field detail;
+// any other error is reported by message alone.
+func respondFilterError(w http.ResponseWriter, r *http.Request, err error) {
+	var verr *validation.RequestValidationError
+	if errors.As(err, &verr) {
+		respondValidationError(w, r, verr)
+		return
+	}
+
+	var ferr *models.FilterValidationError
+	if !errors.As(err, &ferr) {
+		respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+		return
+	}
+
+	problem := newProblem(r, http.StatusBadRequest, ErrCodeValidationError, ferr.Error())
+	fields := make([]map[string]interface{}, len(ferr.Fields))
+	for i, fieldErr := range ferr.Fields {
+		problem.Errors = append(problem.Errors, ProblemFieldError{
+			Field:   fieldErr.Param,
+			Message: fieldErr.Message,
+			Rule:    fieldErr.Rule,
+		})
+		fields[i] = map[string]interface{}{
+			"field":   fieldErr.Param,
+			"tag":     fieldErr.Rule,
+			"value":   fieldErr.Value,
+			"message": fieldErr.Message,
+		}
+	}
+	respondProblem(w, r, problem, map[string]interface{}{"fields": fields})
+}
+
+// writeErrorBody writes an error response. Errors are never cached, and vary
+// on Accept because of the envelope negotiation.
+func writeErrorBody(w http.ResponseWriter, status int, contentType string, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to marshal error response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Cache-Control", "no-store")
+	header.Add("Vary", "Accept")
+	w.WriteHeader(status)
+	if _, err := w.Write(data); err != nil {
+		logging.Error().Err(err).Msg("Failed to write error response")
+	}
+}
@@ -0,0 +1,191 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestErrorResponses_Negotiation(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{}
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		method     string
+		body       string
+		wantStatus int
+		wantCode   ErrorCode
+		wantFields []string
+	}{
+		{"method not allowed", h.Stats, http.MethodPost, "", http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, nil},
+		{"database unavailable", h.Stats, http.MethodGet, "", http.StatusServiceUnavailable, ErrCodeServiceError, nil},
+		{"malformed body", h.Login, http.MethodPost, "{", http.StatusBadRequest, ErrCodeInvalidRequest, nil},
+		{"validation error", h.Login, http.MethodPost, "{}", http.StatusBadRequest, ErrCodeValidationError, []string{"Username", "Password"}},
+		{"auth error", func(w http.ResponseWriter, r *http.Request) {
+			RespondAuthError(w, r, ErrNotAuthenticated)
+		}, http.MethodGet, "", http.StatusUnauthorized, ErrCodeAuthRequired, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/problem", func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/test", strings.NewReader(tt.body))
+			req.Header.Set("Accept", "application/problem+json, application/json;q=0.9")
+			w := httptest.NewRecorder()
+			tt.handler(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if ct := w.Header().Get("Content-Type"); ct != problemContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, problemContentType)
+			}
+
+			var problem ProblemDetails
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if problem.Status != tt.wantStatus || problem.Code != tt.wantCode {
+				t.Errorf("problem status/code = %d/%s, want %d/%s", problem.Status, problem.Code, tt.wantStatus, tt.wantCode)
+			}
+			if problem.Type != tt.wantCode.TypeURI() || problem.Title != errorCodeTitles[tt.wantCode] {
+				t.Errorf("problem type/title = %q/%q", problem.Type, problem.Title)
+			}
+			if problem.Detail == "" || problem.Instance != "/api/v1/test" {
+				t.Errorf("problem detail/instance = %q/%q", problem.Detail, problem.Instance)
+			}
+
+			var fields []string
+			for _, fieldErr := range problem.Errors {
+				if fieldErr.Message == "" || fieldErr.Rule == "" {
+					t.Errorf("incomplete field error %+v", fieldErr)
+				}
+				fields = append(fields, fieldErr.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("problem fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+
+		t.Run(tt.name+"/legacy", func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/test", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			tt.handler(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+			var resp models.APIResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode envelope: %v", err)
+			}
+			if resp.Status != "error" || resp.Error == nil || resp.Error.Code != string(tt.wantCode) || resp.Error.Message == "" {
+				t.Errorf("envelope = %s, want status error with code %s", w.Body.String(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWantsProblemJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"application/json, application/problem+json;q=0.5", true},
+		{"application/problem+json;q=0", false},
+		{"Application/Problem+JSON", true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		if got := wantsProblemJSON(req); got != tt.want {
+			t.Errorf("wantsProblemJSON(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+	if wantsProblemJSON(nil) {
+		t.Error("wantsProblemJSON(nil) = true, want false")
+	}
+}
+
+func TestResponseWriter_ProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/items", nil)
+	req.Header.Set("Accept", problemContentType)
+	w := httptest.NewRecorder()
+	NewResponseWriter(w, req).ValidationError("bad input", map[string]string{"name": "required"})
+
+	var problem ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if problem.Code != ErrCodeValidationFailed || problem.Detail != "bad input" || problem.Details == nil {
+		t.Errorf("problem = %+v", problem)
+	}
+}
+
+// TestErrorCatalog_CoversHandlers parses the package sources and checks that
+// every literal code passed to respondError is in the catalog.
+func TestErrorCatalog_CoversHandlers(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if ident, ok := call.Fun.(*ast.Ident); !ok || ident.Name != "respondError" || len(call.Args) < 4 {
+				return true
+			}
+			lit, ok := call.Args[3].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			code, _ := strconv.Unquote(lit.Value)
+			if _, ok := errorCodeTitles[ErrorCode(code)]; !ok {
+				t.Errorf("%s: error code %s is not in the catalog", fset.Position(lit.Pos()), code)
+			}
+			return true
+		})
+	}
+}
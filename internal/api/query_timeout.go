@@ -0,0 +1,87 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// QueryTimeout returns the API_QUERY_TIMEOUT budget for the database work
+// of one analytics or spatial request.
+func (h *Handler) QueryTimeout() time.Duration {
+	if d := time.Duration(h.queryTimeout.Load()); d > 0 {
+		return d
+	}
+	if h.config != nil && h.config.API.QueryTimeout > 0 {
+		return h.config.API.QueryTimeout
+	}
+	return config.DefaultQueryTimeout
+}
+
+// SetQueryTimeout changes the analytics query budget, for config
+// hot-reload. Queries already running keep their deadline.
+func (h *Handler) SetQueryTimeout(d time.Duration) {
+	h.queryTimeout.Store(int64(d))
+}
+
+// queryContext derives the context for the queries of an analytics
+// request. It ends when the client disconnects, which interrupts the
+// running DuckDB query, or when the query budget runs out.
+func (h *Handler) queryContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(r.Context(), h.QueryTimeout())
+}
+
+// beginQuery derives the query context like queryContext and then waits
+// for an analytics slot (DB_MAX_CONCURRENT_ANALYTICS), so a burst of
+// dashboard requests queues instead of taking every pool connection. The
+// wait counts against the query budget. done releases the slot and cancels
+// the context; callers defer it even when err is set.
+func (h *Handler) beginQuery(r *http.Request) (queryCtx context.Context, done func(), err error) {
+	queryCtx, cancel := h.queryContext(r)
+	if h.db == nil {
+		return queryCtx, cancel, nil
+	}
+	release, err := h.db.AcquireAnalyticsSlot(queryCtx)
+	return queryCtx, func() {
+		release()
+		cancel()
+	}, err
+}
+
+// acquireAnalyticsSlot waits for an analytics slot like beginQuery, for
+// callers that run a whole request under one, such as batch items. Without
+// a database there is no limit.
+func (h *Handler) acquireAnalyticsSlot(ctx context.Context) (release func(), err error) {
+	if h.db == nil {
+		return func() {}, nil
+	}
+	return h.db.AcquireAnalyticsSlot(ctx)
+}
+
+// respondQueryError answers a failed analytics query. A query that ran out
+// of budget is answered with 504 QUERY_TIMEOUT. When the request itself is
+// done, because the client disconnected or the request timeout already
+// answered it, nothing is written.
+func (h *Handler) respondQueryError(w http.ResponseWriter, r *http.Request, queryCtx context.Context, name string, err error) {
+	if reqErr := r.Context().Err(); reqErr != nil {
+		logging.Debug().Err(err).Str("query_name", name).Str("reason", reqErr.Error()).Msg("Analytics query abandoned")
+		return
+	}
+	if errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+		respondError(w, r, http.StatusGatewayTimeout, ErrCodeQueryTimeout,
+			fmt.Sprintf("Query %s exceeded the %s query budget", name, h.QueryTimeout()), err)
+		return
+	}
+	respondError(w, r, http.StatusInternalServerError, "DATABASE_ERROR",
+		fmt.Sprintf("Failed to execute query: %s", name), err)
+}
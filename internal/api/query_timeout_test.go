@@ -0,0 +1,176 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_ "github.com/duckdb/duckdb-go/v2"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/database"
+)
+
+// blockingQuery waits until its context ends, like a query interrupted by
+// its deadline
+func blockingQuery(ctx context.Context, _ database.LocationStatsFilter) (interface{}, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestHandler_QueryTimeout(t *testing.T) {
+	h := &Handler{}
+	if got := h.QueryTimeout(); got != config.DefaultQueryTimeout {
+		t.Errorf("QueryTimeout() without config = %v, want %v", got, config.DefaultQueryTimeout)
+	}
+
+	h.config = &config.Config{API: config.APIConfig{QueryTimeout: 3 * time.Second}}
+	if got := h.QueryTimeout(); got != 3*time.Second {
+		t.Errorf("QueryTimeout() = %v, want API_QUERY_TIMEOUT 3s", got)
+	}
+
+	h.SetQueryTimeout(time.Second)
+	if got := h.QueryTimeout(); got != time.Second {
+		t.Errorf("QueryTimeout() after reload = %v, want 1s", got)
+	}
+}
+
+func TestExecuteSimple_QueryTimeout(t *testing.T) {
+	handler := setupAnalyticsExecutorHandler(t)
+	handler.SetQueryTimeout(50 * time.Millisecond)
+	executor := NewAnalyticsQueryExecutor(handler)
+
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	executor.ExecuteSimple(rec, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/trends", nil), "AnalyticsTrends", blockingQuery)
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request took %v, want the query stopped near its 50ms budget", elapsed)
+	}
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want 504", rec.Code)
+	}
+	if resp := decodeTimeoutResponse(t, rec); resp.Error == nil || resp.Error.Code != string(ErrCodeQueryTimeout) {
+		t.Errorf("response = %+v, want QUERY_TIMEOUT error", resp)
+	}
+}
+
+func TestExecuteSimple_RequestDoneWritesNothing(t *testing.T) {
+	handler := setupAnalyticsExecutorHandler(t)
+	executor := NewAnalyticsQueryExecutor(handler)
+
+	// The client went away; there is no one to answer
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/trends", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	executor.ExecuteSimple(rec, req, "AnalyticsTrends", blockingQuery)
+
+	if rec.Body.Len() != 0 {
+		t.Errorf("response body = %q, want nothing written for a disconnected client", rec.Body.String())
+	}
+}
+
+func TestQueryContext_ClientDisconnectInterruptsDuckDB(t *testing.T) {
+	conn, err := sql.Open("duckdb", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open duckdb: %v", err)
+	}
+	defer conn.Close()
+
+	h := &Handler{config: &config.Config{API: config.APIConfig{QueryTimeout: time.Minute}}}
+
+	// A cross join that would run for hours unless interrupted
+	started := make(chan struct{})
+	queryErr := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queryCtx, cancel := h.queryContext(r)
+		defer cancel()
+		close(started)
+		var sum int64
+		queryErr <- conn.QueryRowContext(queryCtx,
+			"SELECT sum(a.range * b.range) FROM range(1000000000) a, range(1000000000) b").Scan(&sum)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	go func() {
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	disconnected := time.Now()
+
+	select {
+	case err := <-queryErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("query error = %v, want context.Canceled from the disconnect", err)
+		}
+		if elapsed := time.Since(disconnected); elapsed > 2*time.Second {
+			t.Errorf("query stopped %v after the disconnect, want it interrupted promptly", elapsed)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("query still running 10s after the client disconnected")
+	}
+}
+
+func TestExecuteSimple_QueuedBehindAnalyticsLimit(t *testing.T) {
+	db, err := database.New(&config.DatabaseConfig{
+		Path:                   ":memory:",
+		MaxMemory:              "512MB",
+		SkipIndexes:            true,
+		MaxConcurrentAnalytics: 1,
+	}, 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	handler := setupAnalyticsExecutorHandler(t)
+	handler.db = db
+	handler.SetQueryTimeout(50 * time.Millisecond)
+	executor := NewAnalyticsQueryExecutor(handler)
+
+	// Another dashboard request holds the only analytics slot
+	release, err := db.AcquireAnalyticsSlot(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireAnalyticsSlot() error = %v", err)
+	}
+	defer release()
+
+	ran := false
+	rec := httptest.NewRecorder()
+	executor.ExecuteSimple(rec, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/trends", nil), "AnalyticsTrends",
+		func(context.Context, database.LocationStatsFilter) (interface{}, error) {
+			ran = true
+			return nil, nil
+		})
+
+	if ran {
+		t.Error("query ran without an analytics slot")
+	}
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want 504 once the queue wait used up the query budget", rec.Code)
+	}
+	if resp := decodeTimeoutResponse(t, rec); resp.Error == nil || resp.Error.Code != string(ErrCodeQueryTimeout) {
+		t.Errorf("response = %+v, want QUERY_TIMEOUT error", resp)
+	}
+}
@@ -22,8 +22,8 @@
 //	    Offset: getIntParam(r, "offset", 0),
 //	    Cursor: r.URL.Query().Get("cursor"),
 //	}
-//	if err := validateRequest(&req); err != nil {
-//	    respondError(w, http.StatusBadRequest, err.Code, err.Message, nil)
+//	if verr := validateRequest(&req); verr != nil {
+//	    respondValidationError(w, r, verr)
 //	    return
 //	}
 package api
@@ -141,15 +141,15 @@ type SetScheduleConfigRequestValidation struct {
 //   - South: Southern latitude boundary (-90 to 90)
 //   - East: Eastern longitude boundary (-180 to 180)
 //   - North: Northern latitude boundary (-90 to 90)
-//   - StartDate: Optional start date filter (validated by parseDateFilter)
-//   - EndDate: Optional end date filter (validated by parseDateFilter)
+//   - StartDate: Optional start date filter (validated by models.ParseLocationStatsFilter)
+//   - EndDate: Optional end date filter (validated by models.ParseLocationStatsFilter)
 type SpatialViewportRequest struct {
 	West      float64 `validate:"min=-180,max=180"`
 	South     float64 `validate:"min=-90,max=90"`
 	East      float64 `validate:"min=-180,max=180"`
 	North     float64 `validate:"min=-90,max=90"`
-	StartDate string  // Date validation done by parseDateFilter
-	EndDate   string  // Date validation done by parseDateFilter
+	StartDate string  // Date validation done by models.ParseLocationStatsFilter
+	EndDate   string  // Date validation done by models.ParseLocationStatsFilter
 }
 
 // SpatialHexagonsRequest represents the validated query parameters for /spatial/hexagons.
@@ -157,12 +157,12 @@ type SpatialViewportRequest struct {
 //
 // Fields:
 //   - Resolution: H3 resolution level (0-15, default 7)
-//   - StartDate: Optional start date filter (validated by parseDateFilter)
-//   - EndDate: Optional end date filter (validated by parseDateFilter)
+//   - StartDate: Optional start date filter (validated by models.ParseLocationStatsFilter)
+//   - EndDate: Optional end date filter (validated by models.ParseLocationStatsFilter)
 type SpatialHexagonsRequest struct {
 	Resolution int    `validate:"min=0,max=15"`
-	StartDate  string // Date validation done by parseDateFilter
-	EndDate    string // Date validation done by parseDateFilter
+	StartDate  string // Date validation done by models.ParseLocationStatsFilter
+	EndDate    string // Date validation done by models.ParseLocationStatsFilter
 }
 
 // SpatialNearbyRequest represents the validated query parameters for /spatial/nearby.
@@ -172,14 +172,14 @@ type SpatialHexagonsRequest struct {
 //   - Lat: Center latitude (-90 to 90)
 //   - Lon: Center longitude (-180 to 180)
 //   - Radius: Search radius in kilometers (1-20000, default 100)
-//   - StartDate: Optional start date filter (validated by parseDateFilter)
-//   - EndDate: Optional end date filter (validated by parseDateFilter)
+//   - StartDate: Optional start date filter (validated by models.ParseLocationStatsFilter)
+//   - EndDate: Optional end date filter (validated by models.ParseLocationStatsFilter)
 type SpatialNearbyRequest struct {
 	Lat       float64 `validate:"latitude"`
 	Lon       float64 `validate:"longitude"`
 	Radius    float64 `validate:"min=1,max=20000"`
-	StartDate string  // Date validation done by parseDateFilter
-	EndDate   string  // Date validation done by parseDateFilter
+	StartDate string  // Date validation done by models.ParseLocationStatsFilter
+	EndDate   string  // Date validation done by models.ParseLocationStatsFilter
 }
 
 // SpatialTemporalDensityRequest represents the validated query parameters for /spatial/temporal-density.
@@ -188,13 +188,13 @@ type SpatialNearbyRequest struct {
 // Fields:
 //   - Interval: Time interval (hour, day, week, month)
 //   - Resolution: H3 resolution (6-8, default 7)
-//   - StartDate: Optional start date filter (validated by parseDateFilter)
-//   - EndDate: Optional end date filter (validated by parseDateFilter)
+//   - StartDate: Optional start date filter (validated by models.ParseLocationStatsFilter)
+//   - EndDate: Optional end date filter (validated by models.ParseLocationStatsFilter)
 type SpatialTemporalDensityRequest struct {
 	Interval   string `validate:"omitempty,oneof=hour day week month"`
 	Resolution int    `validate:"min=6,max=8"`
-	StartDate  string // Date validation done by parseDateFilter
-	EndDate    string // Date validation done by parseDateFilter
+	StartDate  string // Date validation done by models.ParseLocationStatsFilter
+	EndDate    string // Date validation done by models.ParseLocationStatsFilter
 }
 
 // ExportPlaybacksCSVRequest represents the validated query parameters for /export/playbacks/csv.
@@ -212,14 +212,14 @@ type ExportPlaybacksCSVRequest struct {
 // Used across multiple analytics handlers with consistent validation.
 //
 // Fields:
-//   - StartDate: Optional start date filter (validated by parseDateFilter)
-//   - EndDate: Optional end date filter (validated by parseDateFilter)
+//   - StartDate: Optional start date filter (validated by models.ParseLocationStatsFilter)
+//   - EndDate: Optional end date filter (validated by models.ParseLocationStatsFilter)
 //   - Days: Filter by last N days (1-3650)
 //   - Users: Comma-separated list of usernames
 //   - MediaTypes: Comma-separated list of media types
 type AnalyticsRequest struct {
-	StartDate  string // Date validation done by parseDateFilter
-	EndDate    string // Date validation done by parseDateFilter
+	StartDate  string // Date validation done by models.ParseLocationStatsFilter
+	EndDate    string // Date validation done by models.ParseLocationStatsFilter
 	Days       int    `validate:"omitempty,min=1,max=3650"`
 	Users      string // Comma-separated, no validation needed
 	MediaTypes string // Comma-separated, no validation needed
@@ -34,7 +34,7 @@ type APIResponse struct {
 // APIError represents an error response.
 type APIError struct {
 	// Code is a machine-readable error code
-	Code string `json:"code"`
+	Code ErrorCode `json:"code"`
 
 	// Message is a human-readable error message
 	Message string `json:"message"`
@@ -82,22 +82,6 @@ type PaginationMeta struct {
 	NextCursor string `json:"next_cursor,omitempty"`
 }
 
-// Error codes for API responses
-const (
-	ErrCodeBadRequest          = "BAD_REQUEST"
-	ErrCodeUnauthorized        = "UNAUTHORIZED"
-	ErrCodeForbidden           = "FORBIDDEN"
-	ErrCodeNotFound            = "NOT_FOUND"
-	ErrCodeMethodNotAllowed    = "METHOD_NOT_ALLOWED"
-	ErrCodeConflict            = "CONFLICT"
-	ErrCodeTooManyRequests     = "TOO_MANY_REQUESTS"
-	ErrCodeInternalError       = "INTERNAL_ERROR"
-	ErrCodeServiceUnavailable  = "SERVICE_UNAVAILABLE"
-	ErrCodeValidationFailed    = "VALIDATION_FAILED"
-	ErrCodeDatabaseError       = "DATABASE_ERROR"
-	ErrCodeExternalServiceFail = "EXTERNAL_SERVICE_FAILED"
-)
-
 // ResponseWriter provides methods for writing standardized API responses.
 type ResponseWriter struct {
 	w         http.ResponseWriter
@@ -168,14 +152,23 @@ func (rw *ResponseWriter) NoContent() {
 }
 
 // Error writes an error response with the given status code.
-func (rw *ResponseWriter) Error(statusCode int, code, message string) {
+func (rw *ResponseWriter) Error(statusCode int, code ErrorCode, message string) {
 	rw.ErrorWithDetails(statusCode, code, message, nil)
 }
 
-// ErrorWithDetails writes an error response with additional details.
-func (rw *ResponseWriter) ErrorWithDetails(statusCode int, code, message string, details interface{}) {
+// ErrorWithDetails writes an error response with additional details. Clients
+// that accept application/problem+json receive a problem document with the
+// details as an extension member (see wantsProblemJSON).
+func (rw *ResponseWriter) ErrorWithDetails(statusCode int, code ErrorCode, message string, details interface{}) {
 	requestID := logging.RequestIDFromContext(rw.r.Context())
 
+	if wantsProblemJSON(rw.r) {
+		problem := newProblem(rw.r, statusCode, code, message)
+		problem.Details = details
+		writeErrorBody(rw.w, statusCode, problemContentType, problem)
+		return
+	}
+
 	response := APIResponse{
 		Success: false,
 		Error: &APIError{
@@ -273,7 +266,7 @@ func WriteSuccess(w http.ResponseWriter, r *http.Request, data interface{}) {
 }
 
 // WriteError is a convenience function for writing error responses.
-func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
+func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, code ErrorCode, message string) {
 	NewResponseWriter(w, r).Error(statusCode, code, message)
 }
 
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/tomtom215/cartographus/internal/auth"
 	"github.com/tomtom215/cartographus/internal/authz"
 	"github.com/tomtom215/cartographus/internal/config"
@@ -28,8 +29,8 @@ type Router struct {
 	indexTemplate *template.Template
 
 	// importRouteRegistrar is called during SetupChi() to register import routes.
-	// This is set externally when NATS is enabled and import is configured.
-	importRouteRegistrar func(mux *http.ServeMux)
+	// This is set externally when import is enabled in configuration.
+	importRouteRegistrar func(r chi.Router)
 
 	// Zero Trust components (ADR-0015)
 	// ADR-0015: Zero Trust Authentication & Authorization (Zitadel Amendment)
@@ -67,6 +68,11 @@ type Router struct {
 
 	// Sync handlers for data sync UI
 	syncHandlers *SyncHandlers
+
+	// Diagnostics (pprof, expvar, runtime summary); nil unless DIAGNOSTICS_ENABLED
+	// or ENABLE_PPROF, which mounts only the pprof routes
+	diagnosticsHandlers  *DiagnosticsHandlers
+	diagnosticsPprofOnly bool
 }
 
 // ConfigureDetection sets up the detection handlers for anomaly detection endpoints.
@@ -121,6 +127,20 @@ func (router *Router) GetReplayHandlers() *ReplayHandlers {
 	return router.replayHandlers
 }
 
+// ConfigureDiagnostics mounts the admin-only /debug routes. Call it only
+// when DIAGNOSTICS_ENABLED is set; without it the routes are not registered.
+func (router *Router) ConfigureDiagnostics(handlers *DiagnosticsHandlers) {
+	router.diagnosticsHandlers = handlers
+	router.diagnosticsPprofOnly = false
+}
+
+// ConfigurePprof mounts only the admin-only /debug/pprof routes. Call it
+// when ENABLE_PPROF is set without DIAGNOSTICS_ENABLED.
+func (router *Router) ConfigurePprof(handlers *DiagnosticsHandlers) {
+	router.diagnosticsHandlers = handlers
+	router.diagnosticsPprofOnly = true
+}
+
 // ConfigureRecommend sets up the recommendation handler for recommendation engine endpoints.
 // ADR-0024: Hybrid recommendation engine for media content.
 func (router *Router) ConfigureRecommend(handler *RecommendHandler) {
@@ -142,6 +162,12 @@ func (router *Router) GetSyncHandlers() *SyncHandlers {
 	return router.syncHandlers
 }
 
+// SetRateLimit changes the API rate limits without a restart, for config
+// hot-reload.
+func (router *Router) SetRateLimit(requests int, window time.Duration, disabled bool) {
+	router.chiMiddleware.SetRateLimit(requests, window, disabled)
+}
+
 // NewRouter creates a new router with all routes configured
 func NewRouter(handler *Handler, middleware *auth.Middleware) *Router {
 	// Parse index.html template with nonce support
@@ -170,8 +196,8 @@ func NewRouter(handler *Handler, middleware *auth.Middleware) *Router {
 }
 
 // SetImportRouteRegistrar sets the function that will register import routes.
-// This is called from import initialization when NATS is enabled.
-func (router *Router) SetImportRouteRegistrar(registrar func(*http.ServeMux)) {
+// This is called from import initialization when import is enabled.
+func (router *Router) SetImportRouteRegistrar(registrar func(chi.Router)) {
 	router.importRouteRegistrar = registrar
 }
 
@@ -3,70 +3,70 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // https://github.com/tomtom215/cartographus
 
-//go:build nats
-
 package api
 
 import (
-	"net/http"
+	"github.com/go-chi/chi/v5"
+	"github.com/tomtom215/cartographus/internal/middleware"
 )
 
-// RegisterImportRoutes adds import-related routes to the given ServeMux.
-// This function is called from the main router setup when NATS is enabled.
+// RegisterImportRoutes adds import-related routes to the given Chi router.
+// This function is called from the main router setup when import is enabled.
 //
 // Routes added:
-//   - POST   /api/v1/import/tautulli    - Start import
-//   - GET    /api/v1/import/status      - Get import status
-//   - DELETE /api/v1/import             - Stop import
-//   - DELETE /api/v1/import/progress    - Clear saved progress
-//   - POST   /api/v1/import/validate    - Validate database file
-func (router *Router) RegisterImportRoutes(mux *http.ServeMux, handlers *ImportHandlers) {
-	// Start import (authenticated, rate limited)
-	mux.HandleFunc("/api/v1/import/tautulli",
-		router.middleware.CORS(
-			router.middleware.RateLimit(
-				router.middleware.Authenticate(
-					router.wrap(handlers.HandleStartImport),
-				),
-			),
-		),
-	)
+//   - POST   /api/v1/import/tautulli        - Start import
+//   - GET    /api/v1/import/status          - Get import status
+//   - DELETE /api/v1/import                 - Stop import
+//   - DELETE /api/v1/import/progress        - Clear saved progress
+//   - POST   /api/v1/import/validate        - Validate database file
+//   - GET    /api/v1/admin/import/status    - Detailed progress (throughput, ETA)
+//   - POST   /api/v1/admin/import/pause     - Pause at next batch boundary
+//   - POST   /api/v1/admin/import/resume    - Resume a paused import
+//   - POST   /api/v1/admin/import/cancel    - Cancel, keeping the progress checkpoint
+//   - GET    /api/v1/admin/import/verify    - Compare saved progress against the source
+//
+// When jellystat is non-nil, Jellystat/Playback Reporting import routes are added:
+//   - POST   /api/v1/admin/import/jellystat          - Start import (file path or upload)
+//   - GET    /api/v1/admin/import/jellystat/status   - Detailed progress
+//   - POST   /api/v1/admin/import/jellystat/pause    - Pause at next batch boundary
+//   - POST   /api/v1/admin/import/jellystat/resume   - Resume a paused import
+//   - POST   /api/v1/admin/import/jellystat/cancel   - Cancel, keeping the progress checkpoint
+//   - DELETE /api/v1/admin/import/jellystat/progress - Clear saved progress
+func (router *Router) RegisterImportRoutes(r chi.Router, handlers *ImportHandlers, jellystat *JellystatImportHandlers) {
+	r.Route("/api/v1/import", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
+		r.Use(chiMiddleware(router.middleware.Authenticate))
+
+		r.Post("/tautulli", handlers.HandleStartImport)
+		r.Get("/status", handlers.HandleGetImportStatus)
+		r.Delete("/", handlers.HandleStopImport)
+		r.Delete("/progress", handlers.HandleClearProgress)
+		r.Post("/validate", handlers.HandleValidateDatabase)
+	})
 
-	// Get import status (authenticated)
-	mux.HandleFunc("/api/v1/import/status",
-		router.middleware.CORS(
-			router.middleware.Authenticate(
-				router.wrap(handlers.HandleGetImportStatus),
-			),
-		),
-	)
+	r.Route("/api/v1/admin/import", func(r chi.Router) {
+		r.Use(router.chiMiddleware.RateLimit())
+		r.Use(APISecurityHeaders())
+		r.Use(chiMiddleware(middleware.PrometheusMetrics))
 
-	// Stop import (authenticated)
-	mux.HandleFunc("/api/v1/import/stop",
-		router.middleware.CORS(
-			router.middleware.Authenticate(
-				router.wrap(handlers.HandleStopImport),
-			),
-		),
-	)
+		// All import control operations require authentication (admin only)
+		r.Use(chiMiddleware(router.middleware.Authenticate))
 
-	// Clear progress (authenticated)
-	mux.HandleFunc("/api/v1/import/progress",
-		router.middleware.CORS(
-			router.middleware.Authenticate(
-				router.wrap(handlers.HandleClearProgress),
-			),
-		),
-	)
+		r.Get("/status", handlers.HandleGetImportStatus)
+		r.Post("/pause", handlers.HandlePauseImport)
+		r.Post("/resume", handlers.HandleResumeImport)
+		r.Post("/cancel", handlers.HandleCancelImport)
+		r.Get("/verify", handlers.HandleVerifyImport)
 
-	// Validate database (authenticated, rate limited)
-	mux.HandleFunc("/api/v1/import/validate",
-		router.middleware.CORS(
-			router.middleware.RateLimit(
-				router.middleware.Authenticate(
-					router.wrap(handlers.HandleValidateDatabase),
-				),
-			),
-		),
-	)
+		if jellystat != nil {
+			r.Post("/jellystat", jellystat.HandleStartJellystatImport)
+			r.Get("/jellystat/status", jellystat.HandleGetImportStatus)
+			r.Post("/jellystat/pause", jellystat.HandlePauseImport)
+			r.Post("/jellystat/resume", jellystat.HandleResumeImport)
+			r.Post("/jellystat/cancel", jellystat.HandleCancelImport)
+			r.Delete("/jellystat/progress", jellystat.HandleClearProgress)
+		}
+	})
 }
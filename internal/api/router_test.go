@@ -198,6 +198,9 @@ func TestRouterSetup_APIEndpoints(t *testing.T) {
 		{"users endpoint", "/api/v1/users", http.MethodGet},
 		{"media-types endpoint", "/api/v1/media-types", http.MethodGet},
 		{"server-info endpoint", "/api/v1/server-info", http.MethodGet},
+		{"sources health endpoint", "/api/v1/sources/health", http.MethodGet},
+		{"now playing endpoint", "/api/v1/now-playing", http.MethodGet},
+		{"batch endpoint", "/api/v1/batch", http.MethodPost},
 	}
 
 	for _, tt := range tests {
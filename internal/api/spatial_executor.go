@@ -101,7 +101,7 @@ type QueryFunc func(ctx context.Context, filter database.LocationStatsFilter, pa
 //
 //	bbox, err := ValidateBoundingBox(r)
 //	if err != nil {
-//	    respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+//	    respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 //	    return
 //	}
 //	executor.ExecuteWithCache(w, r, "SpatialViewport",
@@ -120,13 +120,13 @@ func (e *SpatialQueryExecutor) ExecuteWithCache(
 ) {
 	// Check if database is available (protects against nil pointer in queryFunc)
 	if e.handler.db == nil {
-		respondError(w, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
+		respondError(w, r, http.StatusServiceUnavailable, "SERVICE_ERROR", "Database not available", nil)
 		return
 	}
 
 	// Check if spatial extension is available (required for all spatial queries)
 	if !e.handler.db.IsSpatialAvailable() {
-		respondError(w, http.StatusServiceUnavailable, "EXTENSION_UNAVAILABLE",
+		respondError(w, r, http.StatusServiceUnavailable, "EXTENSION_UNAVAILABLE",
 			"Spatial extension not available. Spatial queries require the DuckDB spatial extension.", nil)
 		return
 	}
@@ -157,10 +157,15 @@ func (e *SpatialQueryExecutor) ExecuteWithCache(
 	}
 
 	// Execute query
-	data, err := queryFunc(r.Context(), filter, queryParams)
+	queryCtx, done, err := e.handler.beginQuery(r)
+	defer done()
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "DATABASE_ERROR",
-			fmt.Sprintf("Failed to execute query: %s", cacheKeyPrefix), err)
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
+		return
+	}
+	data, err := queryFunc(queryCtx, filter, queryParams)
+	if err != nil {
+		e.handler.respondQueryError(w, r, queryCtx, cacheKeyPrefix, err)
 		return
 	}
 
@@ -244,7 +249,7 @@ type BoundingBoxParams struct {
 //
 //	bbox, err := ValidateBoundingBox(r)
 //	if err != nil {
-//	    respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+//	    respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 //	    return
 //	}
 //	// bbox.West, bbox.South, bbox.East, bbox.North are all validated
@@ -306,7 +311,7 @@ type CoordinateParams struct {
 //
 //	coords, err := ValidateCoordinates(r, true)
 //	if err != nil {
-//	    respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+//	    respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 //	    return
 //	}
 //	// coords.Lat, coords.Lon, coords.Radius are all validated
@@ -364,7 +369,7 @@ type ResolutionParams struct {
 //
 //	resParams, err := ValidateResolution(r, 7)
 //	if err != nil {
-//	    respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+//	    respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 //	    return
 //	}
 //	// resParams.Resolution is validated (6-8) or default value
@@ -383,6 +388,24 @@ func ValidateResolution(r *http.Request, defaultVal int) (*ResolutionParams, err
 	return &ResolutionParams{Resolution: resolution}, nil
 }
 
+// maxMapZoom is the highest zoom level of the web map
+const maxMapZoom = 24
+
+// ValidateHexagonResolution is ValidateResolution for hexagon aggregation:
+// when resolution is not given but the map zoom level is (zoom, 0 to 24),
+// the resolution is picked for that zoom by database.H3ResolutionForZoom.
+func ValidateHexagonResolution(r *http.Request, defaultVal int) (*ResolutionParams, error) {
+	zoomStr := r.URL.Query().Get("zoom")
+	if zoomStr != "" && r.URL.Query().Get("resolution") == "" {
+		zoom, err := validateIntParam(zoomStr, "zoom", 0, maxMapZoom)
+		if err != nil {
+			return nil, err
+		}
+		defaultVal = database.H3ResolutionForZoom(zoom)
+	}
+	return ValidateResolution(r, defaultVal)
+}
+
 // ValidateInterval validates time interval parameter for temporal aggregation.
 // It ensures the interval is one of the supported temporal resolutions:
 //   - "hour": Hourly aggregation (24 buckets per day)
@@ -405,7 +428,7 @@ func ValidateResolution(r *http.Request, defaultVal int) (*ResolutionParams, err
 //	    interval = "hour" // default
 //	}
 //	if err := ValidateInterval(interval); err != nil {
-//	    respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
+//	    respondError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 //	    return
 //	}
 func ValidateInterval(interval string) error {
@@ -588,6 +588,47 @@ func TestValidateResolution(t *testing.T) {
 	}
 }
 
+// TestValidateHexagonResolution tests resolution selection from the map zoom
+func TestValidateHexagonResolution(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		query          string
+		wantErr        bool
+		wantResolution int
+	}{
+		{"default without zoom", "", false, 7},
+		{"world zoom", "zoom=2", false, 6},
+		{"city zoom", "zoom=10", false, 7},
+		{"street zoom", "zoom=15", false, 8},
+		{"explicit resolution wins over zoom", "zoom=15&resolution=6", false, 6},
+		{"zoom too high", "zoom=25", true, 0},
+		{"invalid zoom", "zoom=abc", true, 0},
+		{"invalid resolution with zoom", "zoom=3&resolution=9", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test?"+tt.query, nil)
+
+			resParams, err := ValidateHexagonResolution(req, 7)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resParams.Resolution != tt.wantResolution {
+				t.Errorf("Resolution = %d, want %d", resParams.Resolution, tt.wantResolution)
+			}
+		})
+	}
+}
+
 // TestValidateInterval tests the ValidateInterval function
 func TestValidateInterval(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,81 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomtom215/cartographus/internal/middleware"
+	"github.com/tomtom215/cartographus/internal/tracing"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestTracing_AnalyticsRequestSpans verifies that one analytics request yields
+// an HTTP server span with the cache lookup, the DuckDB queries, and the
+// cache store as its children.
+func TestTracing_AnalyticsRequestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.SetTracerProvider(nil)
+
+	// Open the database after enabling tracing so the driver is instrumented
+	db := setupTestDBForAPI(t)
+	defer db.Close()
+	handler := setupTestHandlerWithDB(t, db)
+
+	r := chi.NewRouter()
+	r.Use(RequestIDWithLogging())
+	r.Use(chiMiddleware(middleware.Tracing))
+	r.Get("/api/v1/analytics/trends", handler.AnalyticsTrends)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/trends", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+
+	var server sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.SpanKind() == trace.SpanKindServer {
+			server = span
+		}
+	}
+	if server == nil {
+		t.Fatal("no HTTP server span recorded")
+	}
+	if server.Name() != "GET /api/v1/analytics/trends" {
+		t.Errorf("server span name = %q", server.Name())
+	}
+
+	children := map[string]int{}
+	dbSpans := 0
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() != server.SpanContext().SpanID() {
+			continue
+		}
+		children[span.Name()]++
+		for _, kv := range span.Attributes() {
+			if kv.Key == "db.system.name" && kv.Value.AsString() == "duckdb" {
+				dbSpans++
+			}
+		}
+	}
+
+	if children["cache.get"] != 1 {
+		t.Errorf("cache.get child spans = %d, want 1", children["cache.get"])
+	}
+	if children["cache.set"] != 1 {
+		t.Errorf("cache.set child spans = %d, want 1", children["cache.set"])
+	}
+	if dbSpans == 0 {
+		t.Errorf("no DuckDB child spans under the request span; children = %v", children)
+	}
+}
@@ -230,13 +230,13 @@ func (r *ZeroTrustRouter) handleRevokeSession(w http.ResponseWriter, req *http.R
 	path := req.URL.Path
 	prefix := "/api/auth/sessions/"
 	if !strings.HasPrefix(path, prefix) {
-		http.Error(w, "Invalid path", http.StatusBadRequest)
+		respondError(w, req, http.StatusBadRequest, "INVALID_REQUEST", "Invalid path", nil)
 		return
 	}
 
 	sessionID := strings.TrimPrefix(path, prefix)
 	if sessionID == "" {
-		http.Error(w, "Session ID required", http.StatusBadRequest)
+		respondError(w, req, http.StatusBadRequest, "INVALID_REQUEST", "Session ID required", nil)
 		return
 	}
 
@@ -249,20 +249,20 @@ func (r *ZeroTrustRouter) handleRolePermissions(w http.ResponseWriter, req *http
 	path := req.URL.Path
 	prefix := "/api/admin/roles/"
 	if !strings.HasPrefix(path, prefix) {
-		http.Error(w, "Invalid path", http.StatusBadRequest)
+		respondError(w, req, http.StatusBadRequest, "INVALID_REQUEST", "Invalid path", nil)
 		return
 	}
 
 	remainder := strings.TrimPrefix(path, prefix)
 	parts := strings.Split(remainder, "/")
 	if len(parts) < 2 || parts[1] != "permissions" {
-		http.Error(w, "Invalid path", http.StatusBadRequest)
+		respondError(w, req, http.StatusBadRequest, "INVALID_REQUEST", "Invalid path", nil)
 		return
 	}
 
 	role := parts[0]
 	if role == "" {
-		http.Error(w, "Role required", http.StatusBadRequest)
+		respondError(w, req, http.StatusBadRequest, "INVALID_REQUEST", "Role required", nil)
 		return
 	}
 
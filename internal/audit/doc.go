@@ -40,6 +40,7 @@
 //   - detection.alert: Security anomaly alerts
 //   - detection.acknowledged: Alert acknowledgment
 //   - detection.rule_changed: Detection rule configuration changes
+//   - detection.trust_changed: Manual trust score overrides and resets
 //
 // Administrative Events:
 //   - user.created, user.modified, user.deleted: User lifecycle
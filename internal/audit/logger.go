@@ -9,12 +9,15 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // Config holds configuration for the audit logger.
@@ -161,6 +164,9 @@ func (l *Logger) Log(event *Event) {
 		event.Timestamp = time.Now()
 	}
 
+	// Sources not built by SourceFromRequest may carry the raw address
+	event.Source.IPAddress = privacy.AnonymizeIPList(event.Source.IPAddress)
+
 	// Send to async writer
 	select {
 	case l.eventChan <- event:
@@ -383,6 +389,32 @@ func (l *Logger) LogDetectionAlert(ctx context.Context, ruleType, alertTitle str
 	})
 }
 
+// LogTrustScoreChange logs a manual override or reset of a user's trust score.
+//
+//nolint:gocritic // hugeParam: Actor passed by value for API simplicity
+func (l *Logger) LogTrustScoreChange(ctx context.Context, actor Actor, source Source, userID, previousScore, newScore int, reason, note string) {
+	l.Log(&Event{
+		Type:     EventTypeDetectionTrustChanged,
+		Severity: SeverityWarning,
+		Outcome:  OutcomeSuccess,
+		Actor:    actor,
+		Source:   source,
+		Action:   reason,
+		Target: &Target{
+			ID:   strconv.Itoa(userID),
+			Type: "user",
+		},
+		Description: fmt.Sprintf("Trust score %s for user %d: %d -> %d", reason, userID, previousScore, newScore),
+		Metadata: mustJSON(map[string]interface{}{
+			"user_id":        userID,
+			"previous_score": previousScore,
+			"score":          newScore,
+			"note":           note,
+		}),
+		RequestID: getRequestID(ctx),
+	})
+}
+
 // LogConfigChange logs a configuration change.
 //
 //nolint:gocritic // hugeParam: Actor passed by value for API simplicity
@@ -471,7 +503,9 @@ type contextKey string
 // RequestIDKey is the context key for request ID.
 const RequestIDKey contextKey = "request_id"
 
-// SourceFromRequest creates a Source from an HTTP request.
+// SourceFromRequest creates a Source from an HTTP request. The address is
+// anonymized according to PRIVACY_IP_MODE; with a forwarded-for chain,
+// every address in it is.
 func SourceFromRequest(r *http.Request) Source {
 	ip := r.RemoteAddr
 	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
@@ -481,7 +515,7 @@ func SourceFromRequest(r *http.Request) Source {
 	}
 
 	return Source{
-		IPAddress: ip,
+		IPAddress: privacy.AnonymizeIPList(ip),
 		UserAgent: r.UserAgent(),
 		Hostname:  r.Host,
 	}
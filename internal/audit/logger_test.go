@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 func TestLogger_Log(t *testing.T) {
@@ -570,6 +572,26 @@ func TestSourceFromRequest(t *testing.T) {
 	}
 }
 
+func TestSourceFromRequest_Anonymized(t *testing.T) {
+	anonymizer, err := privacy.NewIPAnonymizer(privacy.IPModeTruncated, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privacy.SetIPAnonymizer(anonymizer)
+	t.Cleanup(func() { privacy.SetIPAnonymizer(nil) })
+
+	req := httptest.NewRequest("GET", "/api/v1/test", nil)
+	req.RemoteAddr = "192.168.1.100:54321"
+	if got := SourceFromRequest(req).IPAddress; got != "192.168.1.0" {
+		t.Errorf("IPAddress = %q, want 192.168.1.0", got)
+	}
+
+	req.Header.Set("X-Forwarded-For", "203.0.113.50, 10.0.0.1")
+	if got := SourceFromRequest(req).IPAddress; got != "203.0.113.0, 10.0.0.0" {
+		t.Errorf("IPAddress = %q, want every forwarded address truncated", got)
+	}
+}
+
 func TestActorFromUser(t *testing.T) {
 	actor := ActorFromUser("user123", "testuser", []string{"admin", "editor"}, "jwt", "sess456")
 
@@ -37,6 +37,7 @@ const (
 	EventTypeDetectionAlert        EventType = "detection.alert"
 	EventTypeDetectionAcknowledged EventType = "detection.acknowledged"
 	EventTypeDetectionRuleChanged  EventType = "detection.rule_changed"
+	EventTypeDetectionTrustChanged EventType = "detection.trust_changed"
 
 	// User management events
 	EventTypeUserCreated  EventType = "user.created"
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/middleware"
 	"golang.org/x/time/rate"
 )
 
@@ -30,6 +31,7 @@ type Middleware struct {
 	basicAuthManager       *BasicAuthManager
 	authMode               string
 	rateLimiter            *RateLimiter
+	rateLimitWindow        time.Duration
 	rateLimitDisabled      bool
 	corsOrigins            []string
 	trustedProxies         map[string]bool
@@ -54,6 +56,7 @@ func NewMiddleware(jwtManager *JWTManager, basicAuthManager *BasicAuthManager, a
 		basicAuthManager:       basicAuthManager,
 		authMode:               authMode,
 		rateLimiter:            NewRateLimiter(reqsPerWindow, window),
+		rateLimitWindow:        window,
 		rateLimitDisabled:      rateLimitDisabled,
 		corsOrigins:            corsOrigins,
 		trustedProxies:         trustedMap,
@@ -106,6 +109,7 @@ func (m *Middleware) handleBasicAuth(w http.ResponseWriter, r *http.Request, nex
 
 	claims := m.createBasicAuthClaims(username)
 	ctx := context.WithValue(r.Context(), ClaimsContextKey, claims)
+	middleware.SetPrincipal(ctx, claims.Username)
 	next(w, r.WithContext(ctx))
 }
 
@@ -144,6 +148,7 @@ func (m *Middleware) handleJWTAuth(w http.ResponseWriter, r *http.Request, next
 	}
 
 	ctx := context.WithValue(r.Context(), ClaimsContextKey, claims)
+	middleware.SetPrincipal(ctx, claims.Username)
 	next(w, r.WithContext(ctx))
 }
 
@@ -497,7 +502,5 @@ func (m *Middleware) GetRateLimitConfig() (reqsPerWindow int, disabled bool) {
 // GetRateLimitWindow returns the rate limit window duration.
 // ADR-0016: Exposes config for Chi middleware integration.
 func (m *Middleware) GetRateLimitWindow() time.Duration {
-	// The rate limiter stores rate as tokens/sec, convert back to window
-	// For simplicity, return 1 minute as the standard window
-	return time.Minute
+	return m.rateLimitWindow
 }
@@ -622,11 +622,11 @@ func TestMiddleware_GetRateLimitConfig(t *testing.T) {
 
 func TestMiddleware_GetRateLimitWindow(t *testing.T) {
 	jwtManager, _ := NewJWTManager(testJWTConfig())
-	m := NewMiddleware(jwtManager, nil, "jwt", 100, 1*time.Minute, false, []string{"*"}, nil, "", "")
+	m := NewMiddleware(jwtManager, nil, "jwt", 100, 30*time.Second, false, []string{"*"}, nil, "", "")
 
 	window := m.GetRateLimitWindow()
-	// The function returns 1 minute as the standard window
-	if window != 1*time.Minute {
-		t.Errorf("GetRateLimitWindow() = %v, want 1m", window)
+	// The configured window, not a fixed 1 minute
+	if window != 30*time.Second {
+		t.Errorf("GetRateLimitWindow() = %v, want 30s", window)
 	}
 }
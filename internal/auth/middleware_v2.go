@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/middleware"
 )
 
 // AuthSubjectContextKey is the context key for AuthSubject.
@@ -198,6 +199,7 @@ func (m *MiddlewareV2) AuthenticateV2(next http.HandlerFunc) http.HandlerFunc {
 
 		// Add AuthSubject to context
 		ctx := context.WithValue(r.Context(), AuthSubjectContextKey, subject)
+		middleware.SetPrincipal(ctx, subject.Username)
 
 		// Add Claims to context for backwards compatibility
 		claims := subject.ToClaims()
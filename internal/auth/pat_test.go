@@ -620,6 +620,15 @@ func TestPersonalAccessToken_Methods(t *testing.T) {
 		if token.IsIPAllowed("192.168.1.2") {
 			t.Error("IP not in allowlist should not be allowed")
 		}
+
+		// With CIDR range
+		token.IPAllowlist = []string{"10.0.0.0/8"}
+		if !token.IsIPAllowed("10.20.30.40") {
+			t.Error("IP in allowlisted CIDR range should be allowed")
+		}
+		if token.IsIPAllowed("11.0.0.1") || token.IsIPAllowed("not-an-ip") {
+			t.Error("IP outside allowlisted CIDR range should not be allowed")
+		}
 	})
 }
 
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/middleware"
 )
 
 // SessionMiddlewareConfig holds configuration for the session middleware.
@@ -114,6 +115,7 @@ func (m *SessionMiddleware) Authenticate(next http.Handler) http.Handler {
 		subject.SessionID = session.ID
 
 		ctx := context.WithValue(r.Context(), AuthSubjectContextKey, subject)
+		middleware.SetPrincipal(ctx, subject.Username)
 
 		// Also set Claims for backwards compatibility
 		if claims := subject.ToClaims(); claims != nil {
@@ -363,6 +363,46 @@ func TestCreateBackup(t *testing.T) {
 	}
 }
 
+// fileCopyDatabase records BeginFileCopy calls around the database copy
+type fileCopyDatabase struct {
+	MockDatabase
+	active  int
+	started int
+}
+
+func (m *fileCopyDatabase) BeginFileCopy() func() {
+	m.active++
+	m.started++
+	return func() { m.active-- }
+}
+
+// TestCreateBackup_NotifiesFileCopy tests that the database is told about the file copy
+func TestCreateBackup_NotifiesFileCopy(t *testing.T) {
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.duckdb")
+	if err := os.WriteFile(dbPath, []byte("test database content"), 0644); err != nil {
+		t.Fatalf("failed to create mock db file: %v", err)
+	}
+
+	mockDB := &fileCopyDatabase{MockDatabase: MockDatabase{path: dbPath}}
+	cfg := &Config{
+		Enabled:   true,
+		BackupDir: filepath.Join(tempDir, "backups"),
+		Retention: DefaultRetentionPolicy(),
+	}
+	manager, err := NewManager(cfg, mockDB)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	if _, err := manager.CreateBackup(context.Background(), TypeDatabase, ""); err != nil {
+		t.Fatalf("failed to create backup: %v", err)
+	}
+	if mockDB.started != 1 || mockDB.active != 0 {
+		t.Errorf("file copies started=%d active=%d, want 1 started and 0 active", mockDB.started, mockDB.active)
+	}
+}
+
 // TestListBackups tests backup listing with filters
 func TestListBackups(t *testing.T) {
 	// Create temp directory
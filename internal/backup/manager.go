@@ -56,6 +56,13 @@ type DatabaseInterface interface {
 	Checkpoint(ctx context.Context) error
 }
 
+// FileCopyNotifier is optionally implemented by the database to pause
+// background work that reads the database file while a backup copies it.
+type FileCopyNotifier interface {
+	// BeginFileCopy marks the copy as started; call end when it finishes
+	BeginFileCopy() (end func())
+}
+
 // Manager handles backup and restore operations
 type Manager struct {
 	cfg *Config
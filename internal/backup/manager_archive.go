@@ -141,6 +141,11 @@ func (m *Manager) addDatabaseToArchive(ctx context.Context, tw *tar.Writer, back
 		return fmt.Errorf("database connection not available")
 	}
 
+	if notifier, ok := m.db.(FileCopyNotifier); ok {
+		end := notifier.BeginFileCopy()
+		defer end()
+	}
+
 	// Force a checkpoint to ensure WAL is flushed
 	if err := m.db.Checkpoint(ctx); err != nil {
 		// Log but don't fail - backup can still proceed
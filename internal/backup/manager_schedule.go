@@ -29,7 +29,8 @@ func (m *Manager) GetScheduleConfig() ScheduleConfig {
 	return m.cfg.Schedule
 }
 
-// SetScheduleConfig updates the schedule configuration
+// SetScheduleConfig updates the schedule configuration. The scheduler runs
+// under ctx afterwards, so pass a context that lives as long as the server.
 func (m *Manager) SetScheduleConfig(ctx context.Context, schedule ScheduleConfig) error {
 	// Validate the new schedule
 	if err := m.validateSchedule(schedule); err != nil {
@@ -55,8 +56,8 @@ func (m *Manager) SetScheduleConfig(ctx context.Context, schedule ScheduleConfig
 	}
 	m.metadataMu.Unlock()
 
-	// Restart scheduler if it was running and is still enabled
-	if wasRunning && schedule.Enabled {
+	// Restart the scheduler, or start it if scheduling was just enabled
+	if schedule.Enabled {
 		if err := m.Start(ctx); err != nil {
 			return fmt.Errorf("failed to restart scheduler: %w", err)
 		}
@@ -6,12 +6,16 @@
 package cache
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/tomtom215/cartographus/internal/tracing"
 )
 
 // Entry represents a cached item with expiration
@@ -157,7 +161,40 @@ func (c *Cache) Get(key string) (interface{}, bool) {
 //	cache.Set("analytics:stats", stats)
 //	cache.Set("user:alice", userData)
 func (c *Cache) Set(key string, value interface{}) {
-	c.SetWithTTL(key, value, c.ttl)
+	c.mu.RLock()
+	ttl := c.ttl
+	c.mu.RUnlock()
+	c.SetWithTTL(key, value, ttl)
+}
+
+// SetTTL changes the default TTL used by Set. Entries already cached keep
+// their expiration. Non-positive values are ignored.
+func (c *Cache) SetTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// GetContext is Get with a cache.get trace span as a child of any span in
+// ctx. The span records whether the lookup was a hit.
+func (c *Cache) GetContext(ctx context.Context, key string) (interface{}, bool) {
+	_, span := tracing.Start(ctx, "cache.get")
+	defer span.End()
+
+	value, found := c.Get(key)
+	span.SetAttributes(attribute.Bool("cache.hit", found))
+	return value, found
+}
+
+// SetContext is Set with a cache.set trace span as a child of any span in ctx.
+func (c *Cache) SetContext(ctx context.Context, key string, value interface{}) {
+	_, span := tracing.Start(ctx, "cache.set")
+	defer span.End()
+
+	c.Set(key, value)
 }
 
 // SetWithTTL stores a value in the cache with a custom TTL
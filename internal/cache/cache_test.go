@@ -127,6 +127,24 @@ func TestCacheSetWithTTL(t *testing.T) {
 	}
 }
 
+func TestCacheSetTTL(t *testing.T) {
+	c := New(1 * time.Minute)
+	c.Set("before", "value")
+
+	c.SetTTL(100 * time.Millisecond)
+	c.SetTTL(0) // Ignored
+	c.Set("after", "value")
+
+	time.Sleep(150 * time.Millisecond)
+
+	if _, exists := c.Get("after"); exists {
+		t.Error("Expected entry set after SetTTL to use the new TTL")
+	}
+	if _, exists := c.Get("before"); !exists {
+		t.Error("Expected entry set before SetTTL to keep its expiration")
+	}
+}
+
 func TestGenerateKey(t *testing.T) {
 	type TestParams struct {
 		UserID int
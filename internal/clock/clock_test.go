@@ -0,0 +1,157 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// received returns the value waiting on ch, if any
+func received(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestReal(t *testing.T) {
+	c := Real()
+	if d := time.Since(c.Now()); d < 0 || d > time.Minute {
+		t.Errorf("Real().Now() is %v away from time.Now()", d)
+	}
+
+	ticker := c.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C():
+	case <-time.After(5 * time.Second):
+		t.Fatal("real ticker did not tick")
+	}
+
+	if OrReal(nil) == nil {
+		t.Error("OrReal(nil) = nil, want the real clock")
+	}
+	fake := NewFake(epoch)
+	if OrReal(fake) != fake {
+		t.Error("OrReal() replaced a non-nil clock")
+	}
+}
+
+func TestFake_After(t *testing.T) {
+	f := NewFake(epoch)
+	ch := f.After(time.Hour)
+
+	f.Advance(59 * time.Minute)
+	if _, ok := received(ch); ok {
+		t.Fatal("After fired before its deadline")
+	}
+
+	f.Advance(2 * time.Minute)
+	got, ok := received(ch)
+	if !ok || !got.Equal(epoch.Add(time.Hour)) {
+		t.Errorf("After sent %v (%v), want the deadline %v", got, ok, epoch.Add(time.Hour))
+	}
+	if now := f.Now(); !now.Equal(epoch.Add(61 * time.Minute)) {
+		t.Errorf("Now() = %v, want advanced by 61m", now)
+	}
+	if f.Waiters() != 0 {
+		t.Errorf("Waiters() = %d after firing, want 0", f.Waiters())
+	}
+
+	if _, ok := received(f.After(0)); !ok {
+		t.Error("After(0) did not fire immediately")
+	}
+}
+
+func TestFake_Ticker(t *testing.T) {
+	f := NewFake(epoch)
+	ticker := f.NewTicker(10 * time.Minute)
+
+	f.Advance(10 * time.Minute)
+	if got, ok := received(ticker.C()); !ok || !got.Equal(epoch.Add(10*time.Minute)) {
+		t.Errorf("first tick = %v (%v), want %v", got, ok, epoch.Add(10*time.Minute))
+	}
+
+	// Like time.Ticker, ticks missed by a slow reader are dropped
+	f.Advance(35 * time.Minute)
+	if _, ok := received(ticker.C()); !ok {
+		t.Error("no tick after three periods")
+	}
+	if _, ok := received(ticker.C()); ok {
+		t.Error("more than one tick buffered")
+	}
+
+	ticker.Reset(time.Hour)
+	f.Advance(59 * time.Minute)
+	if _, ok := received(ticker.C()); ok {
+		t.Error("ticker fired on its old period after Reset")
+	}
+	f.Advance(time.Minute)
+	if _, ok := received(ticker.C()); !ok {
+		t.Error("ticker did not fire on its new period")
+	}
+
+	ticker.Stop()
+	f.Advance(24 * time.Hour)
+	if _, ok := received(ticker.C()); ok {
+		t.Error("stopped ticker fired")
+	}
+}
+
+func TestFake_FiresInDeadlineOrder(t *testing.T) {
+	f := NewFake(epoch)
+	late := f.After(2 * time.Hour)
+	early := f.After(time.Hour)
+
+	f.Advance(3 * time.Hour)
+	earlyAt, _ := received(early)
+	lateAt, _ := received(late)
+	if !earlyAt.Equal(epoch.Add(time.Hour)) || !lateAt.Equal(epoch.Add(2*time.Hour)) {
+		t.Errorf("timers fired at %v and %v, want their own deadlines", earlyAt, lateAt)
+	}
+}
+
+func TestFake_Set(t *testing.T) {
+	f := NewFake(epoch)
+	ch := f.After(time.Hour)
+
+	f.Set(epoch.Add(-time.Hour))
+	if _, ok := received(ch); ok || !f.Now().Equal(epoch.Add(-time.Hour)) {
+		t.Error("moving the clock back should only change Now")
+	}
+
+	// Deadlines are absolute times
+	f.Set(epoch.Add(30 * time.Minute))
+	if _, ok := received(ch); ok {
+		t.Error("timer fired before its deadline")
+	}
+	f.Set(epoch.Add(time.Hour))
+	if _, ok := received(ch); !ok {
+		t.Error("timer did not fire once Set reached its deadline")
+	}
+}
+
+func TestFake_BlockUntil(t *testing.T) {
+	f := NewFake(epoch)
+	fired := make(chan struct{})
+	go func() {
+		<-f.After(time.Minute)
+		close(fired)
+	}()
+
+	f.BlockUntil(1)
+	f.Advance(time.Minute)
+	select {
+	case <-fired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("goroutine waiting on After was not woken by Advance")
+	}
+}
@@ -0,0 +1,39 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+/*
+Package clock provides a replaceable time source for time-dependent logic.
+
+Components that schedule work or compare against the current time, such as
+the sync manager, the detection engine and the WAL compactor, read time
+through a Clock instead of calling the time package directly. Production
+code uses Real; tests use Fake to move time forward instantly and
+deterministically instead of sleeping.
+
+# Usage Example
+
+Production constructors default to the real clock:
+
+	manager := sync.NewManager(db, resolver, client, cfg, hub)
+
+Tests pass a fake clock and advance it:
+
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	engine := detection.NewEngineWithClock(alerts, trust, history, nil, clk)
+	engine.StartTrustScoreRecovery(ctx, 1, 24*time.Hour)
+
+	clk.BlockUntil(1)           // wait for the recovery ticker to be created
+	clk.Advance(24 * time.Hour) // fire one recovery run
+
+BlockUntil waits until the code under test is waiting on a timer or ticker,
+so Advance cannot run before the wait it is meant to end.
+
+# Thread Safety
+
+Real is stateless. Fake is safe for concurrent use; Advance delivers ticks
+without blocking, dropping ticks a slow reader has not received, as
+time.Ticker does.
+*/
+package clock
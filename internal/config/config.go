@@ -7,7 +7,11 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/vpn"
 )
 
 // Config holds all application configuration loaded from environment variables and config files.
@@ -72,6 +76,9 @@ type Config struct {
 	Recommend  RecommendConfig  `koanf:"recommend"`  // Optional: Recommendation engine (ADR-0024)
 	GeoIP      GeoIPConfig      `koanf:"geoip"`      // Optional: Standalone GeoIP provider configuration (v2.0)
 	Newsletter NewsletterConfig `koanf:"newsletter"` // Optional: Newsletter scheduler for automated digest delivery
+	Backup     BackupConfig     `koanf:"backup"`     // Backup schedule; other backup settings are read by the backup package
+	Retention  RetentionConfig  `koanf:"retention"`  // Optional: Pruning of old playback history, alerts and newsletter delivery logs
+	Privacy    PrivacyConfig    `koanf:"privacy"`    // Optional: Anonymization of stored client IP addresses
 	Database   DatabaseConfig   `koanf:"database"`
 	Sync       SyncConfig       `koanf:"sync"`
 	Server     ServerConfig     `koanf:"server"`
@@ -103,6 +110,9 @@ type Config struct {
 //   - TAUTULLI_ENABLED: Enable Tautulli integration (default: false)
 //   - TAUTULLI_URL: Tautulli server URL (e.g., http://localhost:8181)
 //   - TAUTULLI_API_KEY: Tautulli API key from Settings > Web Interface
+//   - TAUTULLI_COMPAT_API: Serve get_history, get_activity and get_users at
+//     /api/v2 from Cartographus data, for Tautulli API clients (default: false)
+//   - TAUTULLI_COMPAT_API_KEY: apikey accepted by /api/v2 in place of a login
 //
 // Example - Enable Tautulli:
 //
@@ -122,8 +132,17 @@ type TautulliConfig struct {
 	URL      string `koanf:"url"`
 	APIKey   string `koanf:"api_key"`
 	ServerID string `koanf:"server_id"` // Unique identifier for this Tautulli instance (for multi-server deduplication)
+
+	// CompatAPI emulates a subset of the Tautulli API at /api/v2 from the
+	// DuckDB data, independent of Enabled. CompatAPIKey, when set, is
+	// accepted as the apikey parameter instead of the usual authentication.
+	CompatAPI    bool   `koanf:"compat_api"`
+	CompatAPIKey string `koanf:"compat_api_key"`
 }
 
+// MinTautulliCompatAPIKeyLength is the shortest TAUTULLI_COMPAT_API_KEY accepted
+const MinTautulliCompatAPIKeyLength = 16
+
 // PlexConfig holds Plex API connection settings for hybrid data architecture (v1.37+).
 // Provides optional Plex integration for real-time updates, historical backfill,
 // transcode monitoring, and buffer health tracking.
@@ -206,6 +225,7 @@ type PlexConfig struct {
 	BufferHealthPollInterval      time.Duration `koanf:"buffer_health_poll_interval"`      // Polling interval (default: 5s, recommended: 5-10s for balance between responsiveness and load)
 	BufferHealthCriticalThreshold float64       `koanf:"buffer_health_critical_threshold"` // Critical health threshold percentage (default: 20%, alert when buffer <20%)
 	BufferHealthRiskyThreshold    float64       `koanf:"buffer_health_risky_threshold"`    // Risky health threshold percentage (default: 50%, warn when buffer 20-50%)
+	BufferHealthNotify            bool          `koanf:"buffer_health_notify"`             // Also send critical buffer alerts to the detection notifiers (Discord, Slack, email, webhook)
 
 	// Webhook Receiver (Sprint 1, Task 1.3: v1.43)
 	WebhooksEnabled bool   `koanf:"webhooks_enabled"` // Enable Plex webhook endpoint
@@ -394,6 +414,7 @@ type EmbyConfig struct {
 //   - NATS_SUBSCRIBERS: Number of concurrent message processors (default: 4)
 //   - NATS_DURABLE_NAME: Consumer durable name (default: media-processor)
 //   - NATS_QUEUE_GROUP: Queue group for load balancing (default: processors)
+//   - NATS_TOPIC_PARTITIONING: Subject partitioning, none or server (default: none)
 //
 // Example - Default configuration (event sourcing mode):
 //
@@ -468,6 +489,11 @@ type NATSConfig struct {
 	// QueueGroup is the queue group for load balancing.
 	QueueGroup string `koanf:"queue_group"`
 
+	// TopicPartitioning adds the server ID to event subjects when "server",
+	// so consumers can subscribe per server. Default "none" keeps one
+	// subject per source and media type.
+	TopicPartitioning string `koanf:"topic_partitioning"`
+
 	// Router configuration (Watermill Router-based message processing)
 	// These settings control the middleware stack for message handling.
 
@@ -522,6 +548,8 @@ type NATSConfig struct {
 //   - IMPORT_BATCH_SIZE: Records per batch (default: 1000)
 //   - IMPORT_DRY_RUN: Validate without importing (default: false)
 //   - IMPORT_AUTO_START: Start import automatically on startup (default: false)
+//   - IMPORT_DATE_FROM / IMPORT_DATE_TO: Only import sessions in this date range (YYYY-MM-DD)
+//   - IMPORT_USERS: Only import these users (comma-separated usernames or user IDs)
 //
 // Example - One-time import:
 //
@@ -538,12 +566,22 @@ type NATSConfig struct {
 //	    DBPath:  "/path/to/tautulli-backup.db",
 //	    DryRun:  true,
 //	}
+//
+// Example - Last 90 days of two users:
+//
+//	cfg := ImportConfig{
+//	    Enabled:  true,
+//	    DBPath:   "/path/to/tautulli.db",
+//	    DateFrom: "2026-07-01",
+//	    Users:    []string{"alice", "bob"},
+//	}
 type ImportConfig struct {
 	// Enabled controls whether import functionality is active.
 	Enabled bool `koanf:"enabled"`
 
 	// DBPath is the path to the Tautulli SQLite database file.
-	// Supports .db files and .zip backups (will extract automatically).
+	// Supports .db files and .zip backups; the newest compatible database in a
+	// backup is extracted to a temporary directory for the import.
 	DBPath string `koanf:"db_path"`
 
 	// BatchSize is the number of records to process per batch.
@@ -566,6 +604,17 @@ type ImportConfig struct {
 	// SkipGeolocation skips geolocation enrichment during import.
 	// Set to true if geolocation data is already present in the source.
 	SkipGeolocation bool `koanf:"skip_geolocation"`
+
+	// DateFrom and DateTo (YYYY-MM-DD, inclusive) limit a Tautulli import to
+	// sessions started in that range. Either may be empty for an open range.
+	// Saved progress is a record ID, so start a fresh import (not a resume)
+	// after widening the filter.
+	DateFrom string `koanf:"date_from"`
+	DateTo   string `koanf:"date_to"`
+
+	// Users limits a Tautulli import to these users, matched by username
+	// (case-insensitive) or Tautulli user ID. Empty imports all users.
+	Users []string `koanf:"users"`
 }
 
 // DatabaseConfig holds DuckDB settings
@@ -576,8 +625,44 @@ type DatabaseConfig struct {
 	PreserveInsertionOrder bool   `koanf:"preserve_insertion_order"` // Whether to preserve insertion order (default true)
 	SeedMockData           bool   `koanf:"seed_mock_data"`           // Enable mock data seeding for CI/CD screenshot tests
 	SkipIndexes            bool   `koanf:"skip_indexes"`             // Skip index creation (for fast test setup - 97 indexes per DB)
+
+	// Slow query log: statements slower than SlowQueryThreshold (0 disables)
+	// are logged and kept in a ring buffer of SlowQueryLogSize entries.
+	// SlowQueryExplain attaches an EXPLAIN plan; SlowQueryRedact hides
+	// string parameters such as usernames.
+	SlowQueryThreshold time.Duration `koanf:"slow_query_threshold"`
+	SlowQueryLogSize   int           `koanf:"slow_query_log_size"`
+	SlowQueryExplain   bool          `koanf:"slow_query_explain"`
+	SlowQueryRedact    bool          `koanf:"slow_query_redact"`
+
+	// MetricsInterval is how often database size, memory, table row and
+	// connection pool gauges are refreshed (0 disables collection).
+	MetricsInterval time.Duration `koanf:"metrics_interval"`
+
+	// Connection pool. Zero MaxOpenConns, MaxIdleConns and ConnMaxLifetime
+	// use the defaults (NumCPU, 2, 1h). HealthCheckInterval is how often the
+	// pool is probed and broken idle connections recycled (0 disables).
+	MaxOpenConns        int           `koanf:"max_open_conns"`
+	MaxIdleConns        int           `koanf:"max_idle_conns"`
+	ConnMaxLifetime     time.Duration `koanf:"conn_max_lifetime"`
+	HealthCheckInterval time.Duration `koanf:"health_check_interval"`
+
+	// MaxConcurrentAnalytics caps analytics and spatial queries running at
+	// once; the rest queue within their query budget. 0 leaves one pool
+	// connection free for sync writes (MaxOpenConns - 1, at least 1).
+	MaxConcurrentAnalytics int `koanf:"max_concurrent_analytics"`
+
+	// TileWarmEnabled pre-generates the unfiltered vector tiles for zoom
+	// levels 0 to TileWarmMaxZoom in the background at startup and after
+	// each sync, so the first map loads hit the tile cache.
+	TileWarmEnabled bool `koanf:"tile_warm_enabled"`
+	TileWarmMaxZoom int  `koanf:"tile_warm_max_zoom"`
 }
 
+// MaxTileWarmZoom is the highest DB_TILE_WARM_MAX_ZOOM accepted; zoom 6
+// alone is 4096 tiles.
+const MaxTileWarmZoom = 6
+
 // SyncConfig holds data synchronization settings
 type SyncConfig struct {
 	Interval      time.Duration `koanf:"interval"`
@@ -586,22 +671,85 @@ type SyncConfig struct {
 	BatchSize     int           `koanf:"batch_size"`
 	RetryAttempts int           `koanf:"retry_attempts"`
 	RetryDelay    time.Duration `koanf:"retry_delay"`
+
+	// Timestamp sanity window: events whose started_at falls before
+	// TimestampMinDate (YYYY-MM-DD) or more than TimestampMaxFuture past now
+	// are quarantined instead of inserted.
+	TimestampMinDate   string        `koanf:"timestamp_min_date"`
+	TimestampMaxFuture time.Duration `koanf:"timestamp_max_future"`
+
+	// SessionStitchGap is the longest pause after which a resumed playback of
+	// the same item by the same user still continues its logical viewing
+	// session. Playbacks are stitched after each sync; 0 disables stitching.
+	SessionStitchGap time.Duration `koanf:"session_stitch_gap"`
+
+	// WatchPartyStartWindow is how close together the playbacks of different
+	// users must start to count as one watch party. Parties are detected after
+	// each sync; 0 disables detection.
+	WatchPartyStartWindow time.Duration `koanf:"watch_party_start_window"`
+
+	// WatchPartyMinDuration is how long the participants of a watch party must
+	// have been watching at the same time.
+	WatchPartyMinDuration time.Duration `koanf:"watch_party_min_duration"`
 }
 
 // ServerConfig holds HTTP server settings
 type ServerConfig struct {
-	Port        int           `koanf:"port"`
-	Host        string        `koanf:"host"`
-	Timeout     time.Duration `koanf:"timeout"`
-	Latitude    float64       `koanf:"latitude"`    // Server physical location latitude (optional, for visualization)
-	Longitude   float64       `koanf:"longitude"`   // Server physical location longitude (optional, for visualization)
-	Environment string        `koanf:"environment"` // Environment mode: "development", "staging", "production" (default: "development")
+	Port           int           `koanf:"port"`
+	Host           string        `koanf:"host"`
+	Timeout        time.Duration `koanf:"timeout"`
+	Latitude       float64       `koanf:"latitude"`        // Server physical location latitude (optional, for visualization)
+	Longitude      float64       `koanf:"longitude"`       // Server physical location longitude (optional, for visualization)
+	Environment    string        `koanf:"environment"`     // Environment mode: "development", "staging", "production" (default: "development")
+	MaxBodySize    int64         `koanf:"max_body_size"`   // Maximum request body size in bytes (default: 10MB); upload routes allow more
+	RequestTimeout time.Duration `koanf:"request_timeout"` // Deadline for handler work and database queries (default: 25s); backup routes allow more
+
+	// ShutdownTimeout bounds graceful shutdown: how long in-flight requests
+	// may run while new ones get 503 with Connection: close, before the
+	// remaining connections are closed.
+	ShutdownTimeout time.Duration `koanf:"shutdown_timeout"`
+
+	// DiagnosticsEnabled mounts the admin-only /debug routes (pprof, expvar,
+	// goroutine dump, runtime summary). Off by default; the routes do not
+	// exist unless enabled.
+	DiagnosticsEnabled bool `koanf:"diagnostics_enabled"`
+
+	// PprofEnabled mounts only the admin-only /debug/pprof routes, for
+	// profiling without the rest of the diagnostics. Off by default;
+	// DiagnosticsEnabled includes them.
+	PprofEnabled bool `koanf:"pprof_enabled"`
 }
 
+// DefaultMaxBodySize is the default HTTP_MAX_BODY_SIZE (10MB).
+const DefaultMaxBodySize int64 = 10 << 20
+
+// DefaultRequestTimeout is the default HTTP_REQUEST_TIMEOUT. It is shorter
+// than the default HTTP_TIMEOUT so the timeout response can still be written.
+const DefaultRequestTimeout = 25 * time.Second
+
+// DefaultShutdownTimeout is the default HTTP_SHUTDOWN_TIMEOUT.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// DefaultCacheTTL is the default API_CACHE_TTL.
+const DefaultCacheTTL = 5 * time.Minute
+
+// DefaultQueryTimeout is the default API_QUERY_TIMEOUT. It is shorter than
+// the default HTTP_REQUEST_TIMEOUT so a slow analytics query is reported as
+// QUERY_TIMEOUT rather than as a generic request timeout.
+const DefaultQueryTimeout = 15 * time.Second
+
 // APIConfig holds API pagination and response settings
 type APIConfig struct {
 	DefaultPageSize int `koanf:"default_page_size"`
 	MaxPageSize     int `koanf:"max_page_size"`
+
+	// CacheTTL is how long analytics query results are cached. The cache
+	// is also cleared after every sync.
+	CacheTTL time.Duration `koanf:"cache_ttl"`
+
+	// QueryTimeout bounds the database work of one analytics or spatial
+	// request; slower queries are interrupted and answered with 504.
+	QueryTimeout time.Duration `koanf:"query_timeout"`
 }
 
 // SecurityConfig holds authentication and authorization settings
@@ -729,6 +877,10 @@ type CasbinConfig struct {
 //   - LOG_LEVEL: trace, debug, info, warn, error (default: info)
 //   - LOG_FORMAT: json, console (default: json)
 //   - LOG_CALLER: true/false - include caller file:line (default: false)
+//   - LOG_SAMPLE_FIRST: lines per second logged in full on sampled hot paths (default: 0)
+//   - LOG_SAMPLE_THEREAFTER: log every Nth hot-path line after that (default: 0)
+//   - LOG_ACCESS_SAMPLE_RATE: fraction of successful requests in the access log (default: 1)
+//   - LOG_ACCESS_SLOW_THRESHOLD: requests at least this slow are always logged (default: 1s)
 type LoggingConfig struct {
 	// Level is the minimum log level: trace, debug, info, warn, error.
 	// Default: info
@@ -744,6 +896,29 @@ type LoggingConfig struct {
 	// Adds slight performance overhead.
 	// Default: false
 	Caller bool `koanf:"caller"`
+
+	// SampleFirst is the number of lines per second logged in full on
+	// high-frequency paths (logging.Sampled) before sampling starts.
+	// Sampling is off when SampleFirst and SampleThereafter are both 0.
+	// Default: 0
+	SampleFirst int `koanf:"sample_first"`
+
+	// SampleThereafter logs every Nth high-frequency line once SampleFirst
+	// is reached within a second; 0 drops the rest. Warnings and errors
+	// are never sampled.
+	// Default: 0
+	SampleThereafter int `koanf:"sample_thereafter"`
+
+	// AccessSampleRate is the fraction (0-1] of requests with a status
+	// below 400 written to the access log. 4xx, 5xx and slow requests are
+	// always logged.
+	// Default: 1 (every request)
+	AccessSampleRate float64 `koanf:"access_sample_rate"`
+
+	// AccessSlowThreshold is the duration at which a request is logged as
+	// slow (warn level, never sampled out). 0 disables it.
+	// Default: 1s
+	AccessSlowThreshold time.Duration `koanf:"access_slow_threshold"`
 }
 
 // DetectionConfig holds detection engine configuration (ADR-0020).
@@ -758,10 +933,27 @@ type LoggingConfig struct {
 //   - DISCORD_WEBHOOK_URL: Discord webhook URL for alerts
 //   - DISCORD_WEBHOOK_ENABLED: Enable Discord notifications (default: false)
 //   - DISCORD_RATE_LIMIT_MS: Rate limit between messages (default: 1000)
+//   - DISCORD_MIN_SEVERITY: Lowest alert severity sent to Discord (default: all)
 //   - WEBHOOK_URL: Generic webhook URL for alerts
 //   - WEBHOOK_ENABLED: Enable generic webhook notifications (default: false)
 //   - WEBHOOK_RATE_LIMIT_MS: Rate limit between messages (default: 500)
 //   - WEBHOOK_HEADERS: Comma-separated key=value headers (e.g., "Authorization=Bearer xyz,X-Custom=value")
+//   - WEBHOOK_MIN_SEVERITY: Lowest alert severity sent to the webhook (default: all)
+//   - SLACK_WEBHOOK_URL: Slack incoming webhook URL for alerts
+//   - SLACK_WEBHOOK_ENABLED: Enable Slack notifications (default: false)
+//   - SLACK_RATE_LIMIT_MS: Rate limit between messages (default: 1000)
+//   - SLACK_MIN_SEVERITY: Lowest alert severity sent to Slack (default: all)
+//   - ALERT_EMAIL_ENABLED: Enable email notifications (default: false)
+//   - ALERT_SMTP_HOST, ALERT_SMTP_PORT (default: 587): SMTP server
+//   - ALERT_SMTP_USERNAME, ALERT_SMTP_PASSWORD: SMTP credentials (optional)
+//   - ALERT_SMTP_TLS: Upgrade the connection with STARTTLS (default: true)
+//   - ALERT_EMAIL_FROM: Sender address
+//   - ALERT_EMAIL_TO: Comma-separated recipient addresses
+//   - ALERT_EMAIL_RATE_LIMIT_MS: Rate limit between messages (default: 5000)
+//   - ALERT_EMAIL_MIN_SEVERITY: Lowest alert severity sent by email (default: all)
+//
+// MIN_SEVERITY settings accept info, warning, or critical. When unset, the
+// notifier receives alerts of every severity.
 type DetectionConfig struct {
 	// Engine configuration
 	Enabled             bool `koanf:"enabled"`
@@ -774,6 +966,12 @@ type DetectionConfig struct {
 
 	// Generic webhook notifier configuration
 	Webhook WebhookNotifierConfig `koanf:"webhook"`
+
+	// Slack notifier configuration
+	Slack SlackNotifierConfig `koanf:"slack"`
+
+	// Email notifier configuration
+	Email EmailNotifierConfig `koanf:"email"`
 }
 
 // DiscordNotifierConfig holds Discord webhook notification settings.
@@ -781,6 +979,7 @@ type DiscordNotifierConfig struct {
 	WebhookURL  string `koanf:"webhook_url"`
 	Enabled     bool   `koanf:"enabled"`
 	RateLimitMs int    `koanf:"rate_limit_ms"`
+	MinSeverity string `koanf:"min_severity"`
 }
 
 // WebhookNotifierConfig holds generic webhook notification settings.
@@ -789,6 +988,29 @@ type WebhookNotifierConfig struct {
 	Enabled     bool              `koanf:"enabled"`
 	RateLimitMs int               `koanf:"rate_limit_ms"`
 	Headers     map[string]string `koanf:"headers"`
+	MinSeverity string            `koanf:"min_severity"`
+}
+
+// SlackNotifierConfig holds Slack webhook notification settings.
+type SlackNotifierConfig struct {
+	WebhookURL  string `koanf:"webhook_url"`
+	Enabled     bool   `koanf:"enabled"`
+	RateLimitMs int    `koanf:"rate_limit_ms"`
+	MinSeverity string `koanf:"min_severity"`
+}
+
+// EmailNotifierConfig holds SMTP email notification settings.
+type EmailNotifierConfig struct {
+	Enabled      bool     `koanf:"enabled"`
+	SMTPHost     string   `koanf:"smtp_host"`
+	SMTPPort     int      `koanf:"smtp_port"`
+	SMTPUsername string   `koanf:"smtp_username"`
+	SMTPPassword string   `koanf:"smtp_password"`
+	UseTLS       bool     `koanf:"use_tls"`
+	From         string   `koanf:"from"`
+	To           []string `koanf:"to"`
+	RateLimitMs  int      `koanf:"rate_limit_ms"`
+	MinSeverity  string   `koanf:"min_severity"`
 }
 
 // VPNConfig holds VPN detection service configuration.
@@ -806,8 +1028,11 @@ type WebhookNotifierConfig struct {
 //   - VPN_ENABLED: Enable VPN detection (default: true)
 //   - VPN_DATA_FILE: Path to gluetun servers.json file (optional)
 //   - VPN_CACHE_SIZE: Maximum lookup cache entries (default: 10000)
-//   - VPN_AUTO_UPDATE: Enable automatic data updates (default: false)
+//   - VPN_AUTO_UPDATE: Enable scheduled data updates (default: false)
 //   - VPN_UPDATE_INTERVAL: Update check interval (default: 24h)
+//   - VPN_UPDATE_URL: servers.json to update from (default: gluetun on GitHub)
+//   - VPN_DATACENTER_FILE: Datacenter IP range list to import at startup (optional)
+//   - VPN_DATACENTER_URL: Datacenter IP range list to update from (optional)
 //
 // Example - Basic VPN detection:
 //
@@ -837,13 +1062,31 @@ type VPNConfig struct {
 	// Default: 10000
 	CacheSize int `koanf:"cache_size"`
 
-	// AutoUpdate enables automatic data updates.
-	// Default: false (future feature)
+	// AutoUpdate enables scheduled data updates from UpdateURL. Unchanged
+	// data is detected with ETag/Last-Modified and not downloaded again.
+	// Default: false
 	AutoUpdate bool `koanf:"auto_update"`
 
 	// UpdateInterval is how often to check for updates.
-	// Default: 24h (future feature)
+	// Default: 24h
 	UpdateInterval time.Duration `koanf:"update_interval"`
+
+	// UpdateURL is the gluetun servers.json to update from, on schedule or
+	// with POST /api/v1/admin/vpn/update.
+	// Default: gluetun's servers.json on GitHub
+	UpdateURL string `koanf:"update_url"`
+
+	// DatacenterFile is a datacenter IP range list imported at startup:
+	// one CIDR per line, optionally followed by ",provider", or ipcat's
+	// datacenters.csv ("start,end,provider,url").
+	// Default: "" (empty)
+	DatacenterFile string `koanf:"datacenter_file"`
+
+	// DatacenterURL is a datacenter IP range list refreshed after each
+	// scheduled VPN update (requires AutoUpdate), for example
+	// https://raw.githubusercontent.com/client9/ipcat/master/datacenters.csv
+	// Default: "" (empty)
+	DatacenterURL string `koanf:"datacenter_url"`
 }
 
 // RecommendConfig holds recommendation engine configuration (ADR-0024).
@@ -866,6 +1109,10 @@ type VPNConfig struct {
 //   - RECOMMEND_TRAIN_INTERVAL: Training schedule interval (default: 24h)
 //   - RECOMMEND_MIN_INTERACTIONS: Minimum interactions before training (default: 100)
 //   - RECOMMEND_MODEL_PATH: Path to store trained models (default: /data/recommend)
+//   - RECOMMEND_MODEL_RETAIN_VERSIONS: Model versions kept per algorithm (default: 3)
+//   - RECOMMEND_TRAIN_PARALLELISM: Algorithms trained concurrently (default: 1)
+//   - RECOMMEND_TRAIN_MIN_NEW_INTERACTIONS: New plays needed before retraining (default: 50)
+//   - RECOMMEND_TRAIN_MAX_POOL_UTILIZATION: Skip training above this DB pool usage (default: 0.75)
 //   - RECOMMEND_ALGORITHMS: Comma-separated list of enabled algorithms
 //     (default: covisit,content - lightweight only)
 //   - RECOMMEND_CACHE_TTL: Recommendation cache TTL (default: 5m)
@@ -916,6 +1163,24 @@ type RecommendConfig struct {
 	// Default: /data/recommend
 	ModelPath string `koanf:"model_path"`
 
+	// ModelRetainVersions is how many persisted versions to keep per algorithm.
+	// Default: 3
+	ModelRetainVersions int `koanf:"model_retain_versions"`
+
+	// TrainParallelism is how many algorithms train at the same time.
+	// Default: 1 (sequential, lowest peak memory)
+	TrainParallelism int `koanf:"train_parallelism"`
+
+	// TrainMinNewInteractions skips scheduled training until this many
+	// playback events have been recorded since the last successful run.
+	// Default: 50 (0 = always retrain)
+	TrainMinNewInteractions int `koanf:"train_min_new_interactions"`
+
+	// TrainMaxPoolUtilization skips scheduled training while the database
+	// connection pool is at least this busy (0-1).
+	// Default: 0.75 (0 = no check)
+	TrainMaxPoolUtilization float64 `koanf:"train_max_pool_utilization"`
+
 	// Algorithms is the list of enabled recommendation algorithms.
 	// Available: covisit, content, popularity, ease, als, usercf, itemcf, fpmc, linucb
 	// Default: covisit, content (lightweight only)
@@ -1051,20 +1316,24 @@ type LinUCBAlgorithmConfig struct {
 // When Tautulli is not available, Cartographus can use external GeoIP services
 // to resolve IP addresses to geographic locations.
 //
-// Provider Priority (first available wins):
+// Provider Priority (default, first available wins):
 //  1. MaxMind GeoLite2 (if credentials configured) - same service Tautulli uses
 //  2. ip-api.com (free, no API key required, 45 req/min limit)
 //
+// Providers are tried in order; a provider that fails or is rate limited hands
+// off to the next one. GEOIP_PROVIDER reorders the chain, e.g. "ipapi,maxmind".
+//
 // Environment Variables:
-//   - GEOIP_PROVIDER: Preferred provider ("maxmind" or "ipapi", default: auto-detect)
+//   - GEOIP_PROVIDER: Provider priority, comma-separated ("maxmind", "ipapi", default: auto-detect)
 //   - MAXMIND_ACCOUNT_ID: MaxMind account ID (from https://www.maxmind.com/en/account)
 //   - MAXMIND_LICENSE_KEY: MaxMind license key (same as Tautulli uses)
 //
 // If you already use Tautulli, you likely have MaxMind credentials configured there.
 // Check Tautulli Settings > General > GeoIP Provider for your existing credentials.
 type GeoIPConfig struct {
-	// Provider specifies the preferred GeoIP provider.
+	// Provider specifies the GeoIP provider priority as a comma-separated list.
 	// Options: "maxmind", "ipapi", "" (auto-detect based on available credentials)
+	// Providers not listed are still tried afterwards as fallbacks.
 	Provider string `koanf:"provider"`
 
 	// MaxMind GeoLite2 credentials (same as Tautulli uses)
@@ -1073,6 +1342,37 @@ type GeoIPConfig struct {
 	MaxMindLicenseKey string `koanf:"maxmind_license_key"`
 }
 
+// GeoIP provider names accepted by GEOIP_PROVIDER.
+const (
+	GeoIPProviderMaxMind = "maxmind"
+	GeoIPProviderIPAPI   = "ipapi"
+)
+
+// ProviderChain returns the GeoIP providers in priority order. Providers
+// listed in Provider come first; the remaining known providers follow in the
+// default order (MaxMind, then ip-api.com). Unknown names are ignored here
+// and rejected by Validate.
+func (g GeoIPConfig) ProviderChain() []string {
+	chain := make([]string, 0, 2)
+	seen := make(map[string]bool, 2)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			chain = append(chain, name)
+		}
+	}
+
+	for _, name := range strings.Split(g.Provider, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == GeoIPProviderMaxMind || name == GeoIPProviderIPAPI {
+			add(name)
+		}
+	}
+	add(GeoIPProviderMaxMind)
+	add(GeoIPProviderIPAPI)
+	return chain
+}
+
 // NewsletterConfig holds configuration for the newsletter scheduler service.
 // The scheduler automatically sends newsletters based on cron schedules.
 //
@@ -1081,6 +1381,11 @@ type GeoIPConfig struct {
 //   - NEWSLETTER_CHECK_INTERVAL: How often to check for due schedules (default: 1m)
 //   - NEWSLETTER_MAX_CONCURRENT: Maximum concurrent deliveries (default: 5)
 //   - NEWSLETTER_EXECUTION_TIMEOUT: Max time for single newsletter execution (default: 5m)
+//   - NEWSLETTER_SMTP_HOST: Default SMTP server for the email channel
+//   - NEWSLETTER_SMTP_PORT: SMTP port (default: 587)
+//   - NEWSLETTER_SMTP_USERNAME / NEWSLETTER_SMTP_PASSWORD: SMTP credentials
+//   - NEWSLETTER_SMTP_FROM / NEWSLETTER_SMTP_FROM_NAME: Sender address and display name
+//   - NEWSLETTER_SMTP_TLS_MODE: starttls, implicit, or none (default: starttls)
 //
 // Example - Enable newsletter scheduler:
 //
@@ -1106,6 +1411,117 @@ type NewsletterConfig struct {
 	// Includes content resolution, rendering, and delivery across all channels.
 	// Default: 5 minutes
 	ExecutionTimeout time.Duration `koanf:"execution_timeout"`
+
+	// SMTP is the server-wide email channel configuration. It is used by
+	// schedules that do not set their own email channel config and by the
+	// test-email endpoint.
+	SMTP NewsletterSMTPConfig `koanf:"smtp"`
+}
+
+// BackupConfig holds the backup schedule. It is part of the layered
+// configuration so that config.yaml can set it and a config reload can
+// change it; the backup directory, retention, compression and encryption
+// are read from BACKUP_* environment variables by the backup package.
+//
+// Environment Variables:
+//   - BACKUP_SCHEDULE_ENABLED: Run scheduled backups (default: true)
+//   - BACKUP_INTERVAL: Time between scheduled backups, at least 1h (default: 24h)
+//   - BACKUP_PREFERRED_HOUR: Hour of day (0-23) for daily or longer intervals (default: 2)
+//   - BACKUP_TYPE: full, database, or config (default: full)
+type BackupConfig struct {
+	ScheduleEnabled bool          `koanf:"schedule_enabled"`
+	Interval        time.Duration `koanf:"interval"`
+	PreferredHour   int           `koanf:"preferred_hour"`
+	Type            string        `koanf:"type"`
+}
+
+// PrivacyConfig controls how client IP addresses are stored. IPMode is
+// full, truncated (last octet, or last 80 bits for IPv6, zeroed) or hashed
+// (HMAC-SHA256 with IPHashKey). Geolocation and VPN detection still see the
+// full address; see package privacy for the pipeline ordering.
+//
+// Environment Variables:
+//   - PRIVACY_IP_MODE: full, truncated or hashed (default: full)
+//   - PRIVACY_IP_HASH_KEY: HMAC key for hashed mode, at least 32 characters
+type PrivacyConfig struct {
+	IPMode    string `koanf:"ip_mode"`
+	IPHashKey string `koanf:"ip_hash_key"`
+}
+
+// RetentionConfig holds the data retention policies. Rows older than a
+// table's max age, and the oldest rows beyond its max row count, are pruned
+// in batches while the local hour is inside the quiet window, which runs
+// from WindowStartHour up to WindowEndHour and may wrap past midnight. A
+// zero limit keeps rows forever. Audit events have their own retention.
+//
+// Environment Variables:
+//   - RETENTION_ENABLED: Run the pruning job (default: false)
+//   - RETENTION_PLAYBACK_MAX_AGE_DAYS: Keep playback history for this many days (default: 0)
+//   - RETENTION_PLAYBACK_MAX_ROWS: Keep at most this many playback events (default: 0)
+//   - RETENTION_DETECTION_ALERTS_MAX_AGE_DAYS: Keep detection alerts for this many days (default: 0)
+//   - RETENTION_DETECTION_ALERTS_MAX_ROWS: Keep at most this many detection alerts (default: 0)
+//   - RETENTION_NEWSLETTER_LOG_MAX_AGE_DAYS: Keep newsletter delivery log entries for this many days (default: 0)
+//   - RETENTION_NEWSLETTER_LOG_MAX_ROWS: Keep at most this many newsletter delivery log entries (default: 0)
+//   - RETENTION_WINDOW_START_HOUR: First hour (0-23) of the quiet window (default: 3)
+//   - RETENTION_WINDOW_END_HOUR: Hour (0-23) the quiet window ends (default: 5)
+//   - RETENTION_BATCH_SIZE: Rows deleted per transaction (default: 5000)
+//   - RETENTION_ARCHIVE_DIR: Write pruned rows to Parquet files here first (default: none)
+type RetentionConfig struct {
+	Enabled bool `koanf:"enabled"`
+
+	PlaybackMaxAgeDays        int   `koanf:"playback_max_age_days"`
+	PlaybackMaxRows           int64 `koanf:"playback_max_rows"`
+	DetectionAlertsMaxAgeDays int   `koanf:"detection_alerts_max_age_days"`
+	DetectionAlertsMaxRows    int64 `koanf:"detection_alerts_max_rows"`
+	NewsletterLogMaxAgeDays   int   `koanf:"newsletter_log_max_age_days"`
+	NewsletterLogMaxRows      int64 `koanf:"newsletter_log_max_rows"`
+
+	WindowStartHour int    `koanf:"window_start_hour"`
+	WindowEndHour   int    `koanf:"window_end_hour"`
+	BatchSize       int    `koanf:"batch_size"`
+	ArchiveDir      string `koanf:"archive_dir"`
+}
+
+// HasLimits reports whether any table has a max age or max row count.
+func (c RetentionConfig) HasLimits() bool {
+	return c.PlaybackMaxAgeDays > 0 || c.PlaybackMaxRows > 0 ||
+		c.DetectionAlertsMaxAgeDays > 0 || c.DetectionAlertsMaxRows > 0 ||
+		c.NewsletterLogMaxAgeDays > 0 || c.NewsletterLogMaxRows > 0
+}
+
+// MaxRetentionBatchSize is the largest RETENTION_BATCH_SIZE accepted
+const MaxRetentionBatchSize = 100000
+
+// NewsletterSMTPConfig holds the default SMTP settings for newsletter and
+// report email delivery. Host empty means no default is configured.
+type NewsletterSMTPConfig struct {
+	Host     string `koanf:"host"`
+	Port     int    `koanf:"port"`
+	Username string `koanf:"username"`
+	Password string `koanf:"password"`
+	From     string `koanf:"from"`
+	FromName string `koanf:"from_name"`
+
+	// TLSMode is starttls (port 587), implicit (port 465), or none.
+	// Default: starttls
+	TLSMode string `koanf:"tls_mode"`
+}
+
+// ChannelConfig returns the settings as an email channel configuration, or
+// nil when no SMTP host is configured.
+func (c NewsletterSMTPConfig) ChannelConfig() *models.ChannelConfig {
+	if c.Host == "" {
+		return nil
+	}
+	return &models.ChannelConfig{
+		SMTPHost:     c.Host,
+		SMTPPort:     c.Port,
+		SMTPUser:     c.Username,
+		SMTPPassword: c.Password,
+		SMTPFrom:     c.From,
+		SMTPFromName: c.FromName,
+		SMTPTLSMode:  strings.ToLower(c.TLSMode),
+	}
 }
 
 // ========================================
@@ -1281,6 +1697,9 @@ func LoadLegacy() (*Config, error) {
 			URL:      getEnv("TAUTULLI_URL", ""),
 			APIKey:   getEnv("TAUTULLI_API_KEY", ""),
 			ServerID: getEnv("TAUTULLI_SERVER_ID", ""),
+
+			CompatAPI:    getBoolEnv("TAUTULLI_COMPAT_API", false),
+			CompatAPIKey: getEnv("TAUTULLI_COMPAT_API_KEY", ""),
 		},
 		Plex: PlexConfig{
 			Enabled:         getBoolEnv("ENABLE_PLEX_SYNC", false),
@@ -1306,6 +1725,7 @@ func LoadLegacy() (*Config, error) {
 			BufferHealthPollInterval:      getDurationEnv("BUFFER_HEALTH_POLL_INTERVAL", 5*time.Second),
 			BufferHealthCriticalThreshold: getFloatEnv("BUFFER_HEALTH_CRITICAL_THRESHOLD", 20.0),
 			BufferHealthRiskyThreshold:    getFloatEnv("BUFFER_HEALTH_RISKY_THRESHOLD", 50.0),
+			BufferHealthNotify:            getBoolEnv("BUFFER_HEALTH_NOTIFY", false),
 
 			// Webhook Receiver (Sprint 1, Task 1.3: v1.43)
 			WebhooksEnabled: getBoolEnv("ENABLE_PLEX_WEBHOOKS", false),
@@ -1351,6 +1771,7 @@ func LoadLegacy() (*Config, error) {
 			SubscribersCount:    getIntEnv("NATS_SUBSCRIBERS", 4),
 			DurableName:         getEnv("NATS_DURABLE_NAME", "media-processor"),
 			QueueGroup:          getEnv("NATS_QUEUE_GROUP", "processors"),
+			TopicPartitioning:   getEnv("NATS_TOPIC_PARTITIONING", "none"),
 			// Router configuration defaults
 			RouterRetryCount:           getIntEnv("NATS_ROUTER_RETRY_COUNT", 3),
 			RouterRetryInitialInterval: getDurationEnv("NATS_ROUTER_RETRY_INTERVAL", 100*time.Millisecond),
@@ -1377,6 +1798,9 @@ func LoadLegacy() (*Config, error) {
 			AutoStart:       getBoolEnv("IMPORT_AUTO_START", false),
 			ResumeFromID:    getInt64Env("IMPORT_RESUME_FROM_ID", 0),
 			SkipGeolocation: getBoolEnv("IMPORT_SKIP_GEOLOCATION", false),
+			DateFrom:        getEnv("IMPORT_DATE_FROM", ""),
+			DateTo:          getEnv("IMPORT_DATE_TO", ""),
+			Users:           getSliceEnv("IMPORT_USERS", nil),
 		},
 		Database: DatabaseConfig{
 			Path:                   getEnv("DUCKDB_PATH", "/data/cartographus.duckdb"),
@@ -1384,6 +1808,18 @@ func LoadLegacy() (*Config, error) {
 			Threads:                getIntEnv("DUCKDB_THREADS", 0), // 0 means use runtime.NumCPU()
 			PreserveInsertionOrder: getBoolEnv("DUCKDB_PRESERVE_INSERTION_ORDER", true),
 			SeedMockData:           getBoolEnv("SEED_MOCK_DATA", false),
+			SlowQueryThreshold:     getDurationEnv("DB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
+			SlowQueryLogSize:       getIntEnv("DB_SLOW_QUERY_LOG_SIZE", 100),
+			SlowQueryExplain:       getBoolEnv("DB_SLOW_QUERY_EXPLAIN", false),
+			SlowQueryRedact:        getBoolEnv("DB_SLOW_QUERY_REDACT", false),
+			MetricsInterval:        getDurationEnv("DB_METRICS_INTERVAL", time.Minute),
+			MaxOpenConns:           getIntEnv("DB_MAX_OPEN_CONNS", 0), // 0 means use runtime.NumCPU()
+			MaxIdleConns:           getIntEnv("DB_MAX_IDLE_CONNS", 2),
+			ConnMaxLifetime:        getDurationEnv("DB_CONN_MAX_LIFETIME", time.Hour),
+			HealthCheckInterval:    getDurationEnv("DB_HEALTH_CHECK_INTERVAL", 30*time.Second),
+			MaxConcurrentAnalytics: getIntEnv("DB_MAX_CONCURRENT_ANALYTICS", 0),
+			TileWarmEnabled:        getBoolEnv("DB_TILE_WARM_ENABLED", true),
+			TileWarmMaxZoom:        getIntEnv("DB_TILE_WARM_MAX_ZOOM", 3),
 		},
 		Sync: SyncConfig{
 			Interval:      getDurationEnv("SYNC_INTERVAL", 5*time.Minute),
@@ -1392,6 +1828,14 @@ func LoadLegacy() (*Config, error) {
 			BatchSize:     getIntEnv("SYNC_BATCH_SIZE", 1000),
 			RetryAttempts: getIntEnv("SYNC_RETRY_ATTEMPTS", 5),
 			RetryDelay:    getDurationEnv("SYNC_RETRY_DELAY", 2*time.Second),
+
+			TimestampMinDate:   getEnv("SYNC_TIMESTAMP_MIN_DATE", "2000-01-01"),
+			TimestampMaxFuture: getDurationEnv("SYNC_TIMESTAMP_MAX_FUTURE", 24*time.Hour),
+
+			SessionStitchGap: getDurationEnv("SYNC_SESSION_STITCH_GAP", 2*time.Hour),
+
+			WatchPartyStartWindow: getDurationEnv("SYNC_WATCH_PARTY_START_WINDOW", 2*time.Minute),
+			WatchPartyMinDuration: getDurationEnv("SYNC_WATCH_PARTY_MIN_DURATION", 10*time.Minute),
 		},
 		Server: ServerConfig{
 			Port:      getIntEnv("HTTP_PORT", 3857),
@@ -1399,10 +1843,19 @@ func LoadLegacy() (*Config, error) {
 			Timeout:   getDurationEnv("HTTP_TIMEOUT", 30*time.Second),
 			Latitude:  getFloatEnv("SERVER_LATITUDE", 0.0),
 			Longitude: getFloatEnv("SERVER_LONGITUDE", 0.0),
+
+			MaxBodySize:     getInt64Env("HTTP_MAX_BODY_SIZE", DefaultMaxBodySize),
+			RequestTimeout:  getDurationEnv("HTTP_REQUEST_TIMEOUT", DefaultRequestTimeout),
+			ShutdownTimeout: getDurationEnv("HTTP_SHUTDOWN_TIMEOUT", DefaultShutdownTimeout),
+
+			DiagnosticsEnabled: getBoolEnv("DIAGNOSTICS_ENABLED", false),
+			PprofEnabled:       getBoolEnv("ENABLE_PPROF", false),
 		},
 		API: APIConfig{
 			DefaultPageSize: getIntEnv("API_DEFAULT_PAGE_SIZE", 20),
 			MaxPageSize:     getIntEnv("API_MAX_PAGE_SIZE", 100),
+			CacheTTL:        getDurationEnv("API_CACHE_TTL", DefaultCacheTTL),
+			QueryTimeout:    getDurationEnv("API_QUERY_TIMEOUT", DefaultQueryTimeout),
 		},
 		Security: SecurityConfig{
 			AuthMode:             getEnv("AUTH_MODE", "jwt"),
@@ -1460,6 +1913,12 @@ func LoadLegacy() (*Config, error) {
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 			Caller: getBoolEnv("LOG_CALLER", false),
+
+			SampleFirst:      getIntEnv("LOG_SAMPLE_FIRST", 0),
+			SampleThereafter: getIntEnv("LOG_SAMPLE_THEREAFTER", 0),
+
+			AccessSampleRate:    getFloatEnv("LOG_ACCESS_SAMPLE_RATE", 1.0),
+			AccessSlowThreshold: getDurationEnv("LOG_ACCESS_SLOW_THRESHOLD", time.Second),
 		},
 		// Detection engine configuration (ADR-0020)
 		Detection: DetectionConfig{
@@ -1471,27 +1930,51 @@ func LoadLegacy() (*Config, error) {
 				WebhookURL:  getEnv("DISCORD_WEBHOOK_URL", ""),
 				Enabled:     getBoolEnv("DISCORD_WEBHOOK_ENABLED", false),
 				RateLimitMs: getIntEnv("DISCORD_RATE_LIMIT_MS", 1000),
+				MinSeverity: getEnv("DISCORD_MIN_SEVERITY", ""),
 			},
 			Webhook: WebhookNotifierConfig{
 				WebhookURL:  getEnv("WEBHOOK_URL", ""),
 				Enabled:     getBoolEnv("WEBHOOK_ENABLED", false),
 				RateLimitMs: getIntEnv("WEBHOOK_RATE_LIMIT_MS", 500),
 				Headers:     getMapEnv("WEBHOOK_HEADERS"),
+				MinSeverity: getEnv("WEBHOOK_MIN_SEVERITY", ""),
+			},
+			Slack: SlackNotifierConfig{
+				WebhookURL:  getEnv("SLACK_WEBHOOK_URL", ""),
+				Enabled:     getBoolEnv("SLACK_WEBHOOK_ENABLED", false),
+				RateLimitMs: getIntEnv("SLACK_RATE_LIMIT_MS", 1000),
+				MinSeverity: getEnv("SLACK_MIN_SEVERITY", ""),
+			},
+			Email: EmailNotifierConfig{
+				Enabled:      getBoolEnv("ALERT_EMAIL_ENABLED", false),
+				SMTPHost:     getEnv("ALERT_SMTP_HOST", ""),
+				SMTPPort:     getIntEnv("ALERT_SMTP_PORT", 587),
+				SMTPUsername: getEnv("ALERT_SMTP_USERNAME", ""),
+				SMTPPassword: getEnv("ALERT_SMTP_PASSWORD", ""),
+				UseTLS:       getBoolEnv("ALERT_SMTP_TLS", true),
+				From:         getEnv("ALERT_EMAIL_FROM", ""),
+				To:           getSliceEnv("ALERT_EMAIL_TO", nil),
+				RateLimitMs:  getIntEnv("ALERT_EMAIL_RATE_LIMIT_MS", 5000),
+				MinSeverity:  getEnv("ALERT_EMAIL_MIN_SEVERITY", ""),
 			},
 		},
 		// Recommendation engine configuration (ADR-0024)
 		// IMPORTANT: Disabled by default due to computational requirements
 		Recommend: RecommendConfig{
-			Enabled:            getBoolEnv("RECOMMEND_ENABLED", false), // Disabled by default
-			TrainInterval:      getDurationEnv("RECOMMEND_TRAIN_INTERVAL", 24*time.Hour),
-			TrainOnStartup:     getBoolEnv("RECOMMEND_TRAIN_ON_STARTUP", false),
-			MinInteractions:    getIntEnv("RECOMMEND_MIN_INTERACTIONS", 100),
-			ModelPath:          getEnv("RECOMMEND_MODEL_PATH", "/data/recommend"),
-			Algorithms:         getSliceEnv("RECOMMEND_ALGORITHMS", []string{"covisit", "content"}), // Lightweight only
-			CacheTTL:           getDurationEnv("RECOMMEND_CACHE_TTL", 5*time.Minute),
-			MaxCandidates:      getIntEnv("RECOMMEND_MAX_CANDIDATES", 1000),
-			DiversityLambda:    getFloatEnv("RECOMMEND_DIVERSITY_LAMBDA", 0.7),
-			CalibrationEnabled: getBoolEnv("RECOMMEND_CALIBRATION_ENABLED", true),
+			Enabled:                 getBoolEnv("RECOMMEND_ENABLED", false), // Disabled by default
+			TrainInterval:           getDurationEnv("RECOMMEND_TRAIN_INTERVAL", 24*time.Hour),
+			TrainOnStartup:          getBoolEnv("RECOMMEND_TRAIN_ON_STARTUP", false),
+			MinInteractions:         getIntEnv("RECOMMEND_MIN_INTERACTIONS", 100),
+			ModelPath:               getEnv("RECOMMEND_MODEL_PATH", "/data/recommend"),
+			ModelRetainVersions:     getIntEnv("RECOMMEND_MODEL_RETAIN_VERSIONS", 3),
+			TrainParallelism:        getIntEnv("RECOMMEND_TRAIN_PARALLELISM", 1),
+			TrainMinNewInteractions: getIntEnv("RECOMMEND_TRAIN_MIN_NEW_INTERACTIONS", 50),
+			TrainMaxPoolUtilization: getFloatEnv("RECOMMEND_TRAIN_MAX_POOL_UTILIZATION", 0.75),
+			Algorithms:              getSliceEnv("RECOMMEND_ALGORITHMS", []string{"covisit", "content"}), // Lightweight only
+			CacheTTL:                getDurationEnv("RECOMMEND_CACHE_TTL", 5*time.Minute),
+			MaxCandidates:           getIntEnv("RECOMMEND_MAX_CANDIDATES", 1000),
+			DiversityLambda:         getFloatEnv("RECOMMEND_DIVERSITY_LAMBDA", 0.7),
+			CalibrationEnabled:      getBoolEnv("RECOMMEND_CALIBRATION_ENABLED", true),
 			EASE: EASEAlgorithmConfig{
 				L2Regularization: getFloatEnv("RECOMMEND_EASE_REGULARIZATION", 500.0),
 				MinConfidence:    getFloatEnv("RECOMMEND_EASE_MIN_CONFIDENCE", 0.1),
@@ -1536,6 +2019,9 @@ func LoadLegacy() (*Config, error) {
 			CacheSize:      getIntEnv("VPN_CACHE_SIZE", 10000),
 			AutoUpdate:     getBoolEnv("VPN_AUTO_UPDATE", false),
 			UpdateInterval: getDurationEnv("VPN_UPDATE_INTERVAL", 24*time.Hour),
+			UpdateURL:      getEnv("VPN_UPDATE_URL", vpn.DefaultGluetunURL),
+			DatacenterFile: getEnv("VPN_DATACENTER_FILE", ""),
+			DatacenterURL:  getEnv("VPN_DATACENTER_URL", ""),
 		},
 	}
 
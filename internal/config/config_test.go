@@ -321,6 +321,86 @@ func TestLoad(t *testing.T) {
 			wantErr: true,
 			errMsg:  "configuration validation failed: HTTP_PORT must be between 1 and 65535",
 		},
+		{
+			name: "invalid max body size",
+			envVars: map[string]string{
+				"TAUTULLI_URL":       "http://localhost:8181",
+				"TAUTULLI_API_KEY":   "test_api_key",
+				"HTTP_MAX_BODY_SIZE": "0",
+				"AUTH_MODE":          "none",
+			},
+			wantErr: true,
+			errMsg:  "configuration validation failed: HTTP_MAX_BODY_SIZE must be at least 1 byte, got 0",
+		},
+		{
+			name: "invalid request timeout",
+			envVars: map[string]string{
+				"TAUTULLI_URL":         "http://localhost:8181",
+				"TAUTULLI_API_KEY":     "test_api_key",
+				"HTTP_REQUEST_TIMEOUT": "0s",
+				"AUTH_MODE":            "none",
+			},
+			wantErr: true,
+			errMsg:  "configuration validation failed: HTTP_REQUEST_TIMEOUT must be positive, got 0s",
+		},
+		{
+			name: "invalid shutdown timeout",
+			envVars: map[string]string{
+				"TAUTULLI_URL":          "http://localhost:8181",
+				"TAUTULLI_API_KEY":      "test_api_key",
+				"HTTP_SHUTDOWN_TIMEOUT": "-1s",
+				"AUTH_MODE":             "none",
+			},
+			wantErr: true,
+			errMsg:  "configuration validation failed: HTTP_SHUTDOWN_TIMEOUT must be positive, got -1s",
+		},
+		{
+			name: "VPN update interval too short",
+			envVars: map[string]string{
+				"TAUTULLI_URL":        "http://localhost:8181",
+				"TAUTULLI_API_KEY":    "test_api_key",
+				"VPN_AUTO_UPDATE":     "true",
+				"VPN_UPDATE_INTERVAL": "5m",
+				"AUTH_MODE":           "none",
+			},
+			wantErr: true,
+			errMsg:  "configuration validation failed: VPN_UPDATE_INTERVAL must be at least 1h0m0s, got 5m0s",
+		},
+		{
+			name: "invalid VPN update URL",
+			envVars: map[string]string{
+				"TAUTULLI_URL":     "http://localhost:8181",
+				"TAUTULLI_API_KEY": "test_api_key",
+				"VPN_AUTO_UPDATE":  "true",
+				"VPN_UPDATE_URL":   "file:///etc/servers.json",
+				"AUTH_MODE":        "none",
+			},
+			wantErr: true,
+			errMsg:  `configuration validation failed: VPN_UPDATE_URL must be an http(s) URL when VPN_AUTO_UPDATE=true, got "file:///etc/servers.json"`,
+		},
+		{
+			name: "invalid VPN datacenter URL",
+			envVars: map[string]string{
+				"TAUTULLI_URL":       "http://localhost:8181",
+				"TAUTULLI_API_KEY":   "test_api_key",
+				"VPN_AUTO_UPDATE":    "true",
+				"VPN_DATACENTER_URL": "datacenters.csv",
+				"AUTH_MODE":          "none",
+			},
+			wantErr: true,
+			errMsg:  `configuration validation failed: VPN_DATACENTER_URL must be an http(s) URL, got "datacenters.csv"`,
+		},
+		{
+			name: "invalid query timeout",
+			envVars: map[string]string{
+				"TAUTULLI_URL":      "http://localhost:8181",
+				"TAUTULLI_API_KEY":  "test_api_key",
+				"API_QUERY_TIMEOUT": "0s",
+				"AUTH_MODE":         "none",
+			},
+			wantErr: true,
+			errMsg:  "configuration validation failed: API_QUERY_TIMEOUT must be positive, got 0s",
+		},
 		{
 			name: "invalid log level",
 			envVars: map[string]string{
@@ -2209,3 +2289,554 @@ func TestValidateRateLimits(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSync(t *testing.T) {
+	tests := []struct {
+		name        string
+		minDate     string
+		maxFuture   time.Duration
+		stitchGap   time.Duration
+		partyWindow time.Duration
+		partyMinDur time.Duration
+		errContains string
+	}{
+		{name: "defaults", minDate: "2000-01-01", maxFuture: 24 * time.Hour, stitchGap: 2 * time.Hour},
+		{name: "unset", minDate: "", maxFuture: 0},
+		{name: "invalid date format", minDate: "01/01/2000", maxFuture: time.Hour, errContains: "SYNC_TIMESTAMP_MIN_DATE"},
+		{name: "negative max future", minDate: "2000-01-01", maxFuture: -time.Hour, errContains: "SYNC_TIMESTAMP_MAX_FUTURE"},
+		{name: "stitching disabled", minDate: "2000-01-01", stitchGap: 0},
+		{name: "negative stitch gap", minDate: "2000-01-01", stitchGap: -time.Minute, errContains: "SYNC_SESSION_STITCH_GAP"},
+		{name: "watch parties", minDate: "2000-01-01", partyWindow: 2 * time.Minute, partyMinDur: 10 * time.Minute},
+		{name: "negative party window", minDate: "2000-01-01", partyWindow: -time.Minute, errContains: "SYNC_WATCH_PARTY_START_WINDOW"},
+		{name: "negative party duration", minDate: "2000-01-01", partyMinDur: -time.Minute, errContains: "SYNC_WATCH_PARTY_MIN_DURATION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Sync: SyncConfig{
+				TimestampMinDate:      tt.minDate,
+				TimestampMaxFuture:    tt.maxFuture,
+				SessionStitchGap:      tt.stitchGap,
+				WatchPartyStartWindow: tt.partyWindow,
+				WatchPartyMinDuration: tt.partyMinDur,
+			}}
+
+			err := cfg.validateSync()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateSync() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateSync() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateImportDateRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		from, to    string
+		errContains string
+	}{
+		{name: "unset"},
+		{name: "open ended", from: "2026-01-01"},
+		{name: "single day", from: "2026-01-01", to: "2026-01-01"},
+		{name: "invalid from", from: "2026/01/01", errContains: "IMPORT_DATE_FROM"},
+		{name: "invalid to", to: "yesterday", errContains: "IMPORT_DATE_TO"},
+		{name: "reversed", from: "2026-02-01", to: "2026-01-01", errContains: "before IMPORT_DATE_FROM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Import: ImportConfig{DateFrom: tt.from, DateTo: tt.to}}
+
+			err := cfg.validateImportDateRange()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateImportDateRange() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateImportDateRange() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateDatabase(t *testing.T) {
+	tests := []struct {
+		name            string
+		threshold       time.Duration
+		logSize         int
+		metricsInterval time.Duration
+		errContains     string
+	}{
+		{name: "defaults", threshold: 500 * time.Millisecond, logSize: 100, metricsInterval: time.Minute},
+		{name: "disabled", threshold: 0, logSize: 0},
+		{name: "negative threshold", threshold: -time.Second, logSize: 100, errContains: "DB_SLOW_QUERY_THRESHOLD"},
+		{name: "negative log size", threshold: time.Second, logSize: -1, errContains: "DB_SLOW_QUERY_LOG_SIZE"},
+		{name: "negative metrics interval", metricsInterval: -time.Second, errContains: "DB_METRICS_INTERVAL"},
+		{name: "metrics interval too short", metricsInterval: 100 * time.Millisecond, errContains: "DB_METRICS_INTERVAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Database: DatabaseConfig{
+				SlowQueryThreshold: tt.threshold,
+				SlowQueryLogSize:   tt.logSize,
+				MetricsInterval:    tt.metricsInterval,
+			}}
+
+			err := cfg.validateDatabase()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateDatabase() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateDatabase() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateDatabasePool(t *testing.T) {
+	tests := []struct {
+		name        string
+		db          DatabaseConfig
+		errContains string
+	}{
+		{name: "defaults", db: DatabaseConfig{MaxIdleConns: 2, ConnMaxLifetime: time.Hour, HealthCheckInterval: 30 * time.Second}},
+		{name: "explicit limits", db: DatabaseConfig{MaxOpenConns: 8, MaxIdleConns: 4, MaxConcurrentAnalytics: 6}},
+		{name: "negative max open", db: DatabaseConfig{MaxOpenConns: -1}, errContains: "DB_MAX_OPEN_CONNS"},
+		{name: "negative max idle", db: DatabaseConfig{MaxIdleConns: -1}, errContains: "DB_MAX_IDLE_CONNS"},
+		{name: "negative lifetime", db: DatabaseConfig{ConnMaxLifetime: -time.Minute}, errContains: "DB_CONN_MAX_LIFETIME"},
+		{name: "health check too short", db: DatabaseConfig{HealthCheckInterval: 10 * time.Millisecond}, errContains: "DB_HEALTH_CHECK_INTERVAL"},
+		{name: "negative analytics limit", db: DatabaseConfig{MaxConcurrentAnalytics: -2}, errContains: "DB_MAX_CONCURRENT_ANALYTICS"},
+		{name: "tile warm max zoom", db: DatabaseConfig{TileWarmEnabled: true, TileWarmMaxZoom: MaxTileWarmZoom}},
+		{name: "tile warm zoom too high", db: DatabaseConfig{TileWarmMaxZoom: MaxTileWarmZoom + 1}, errContains: "DB_TILE_WARM_MAX_ZOOM"},
+		{name: "negative tile warm zoom", db: DatabaseConfig{TileWarmMaxZoom: -1}, errContains: "DB_TILE_WARM_MAX_ZOOM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Database: tt.db}
+
+			err := cfg.validateDatabase()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateDatabase() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateDatabase() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateTautulliCompatAPI(t *testing.T) {
+	tests := []struct {
+		name        string
+		tautulli    TautulliConfig
+		errContains string
+	}{
+		{name: "disabled", tautulli: TautulliConfig{}},
+		{name: "enabled without key", tautulli: TautulliConfig{CompatAPI: true}},
+		{name: "enabled with key", tautulli: TautulliConfig{CompatAPI: true, CompatAPIKey: "0123456789abcdef"}},
+		{name: "key too short", tautulli: TautulliConfig{CompatAPI: true, CompatAPIKey: "short"}, errContains: "at least 16"},
+		{name: "key without compat API", tautulli: TautulliConfig{CompatAPIKey: "0123456789abcdef"}, errContains: "TAUTULLI_COMPAT_API is disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Tautulli: tt.tautulli}
+
+			err := cfg.validateTautulli()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateTautulli() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateTautulli() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateNATSTopicPartitioning(t *testing.T) {
+	for _, mode := range []string{"", "none", "server"} {
+		cfg := &Config{NATS: NATSConfig{TopicPartitioning: mode}}
+		if err := cfg.validateNATSTopicPartitioning(); err != nil {
+			t.Errorf("validateNATSTopicPartitioning(%q) unexpected error: %v", mode, err)
+		}
+	}
+
+	cfg := &Config{NATS: NATSConfig{TopicPartitioning: "user"}}
+	err := cfg.validateNATSTopicPartitioning()
+	if err == nil || !strings.Contains(err.Error(), "NATS_TOPIC_PARTITIONING") {
+		t.Errorf("validateNATSTopicPartitioning(user) error = %v, want NATS_TOPIC_PARTITIONING error", err)
+	}
+}
+
+func TestValidateRecommend(t *testing.T) {
+	valid := RecommendConfig{
+		Enabled:                 true,
+		TrainParallelism:        1,
+		TrainMinNewInteractions: 50,
+		TrainMaxPoolUtilization: 0.75,
+		ModelRetainVersions:     3,
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(*RecommendConfig)
+		errContains string
+	}{
+		{name: "defaults", modify: func(*RecommendConfig) {}},
+		{name: "disabled skips checks", modify: func(c *RecommendConfig) { c.Enabled = false; c.TrainParallelism = 0 }},
+		{name: "pool check off", modify: func(c *RecommendConfig) { c.TrainMaxPoolUtilization = 0 }},
+		{name: "zero parallelism", modify: func(c *RecommendConfig) { c.TrainParallelism = 0 }, errContains: "RECOMMEND_TRAIN_PARALLELISM"},
+		{name: "negative new interactions", modify: func(c *RecommendConfig) { c.TrainMinNewInteractions = -1 }, errContains: "RECOMMEND_TRAIN_MIN_NEW_INTERACTIONS"},
+		{name: "pool above 1", modify: func(c *RecommendConfig) { c.TrainMaxPoolUtilization = 1.5 }, errContains: "RECOMMEND_TRAIN_MAX_POOL_UTILIZATION"},
+		{name: "no retained versions", modify: func(c *RecommendConfig) { c.ModelRetainVersions = 0 }, errContains: "RECOMMEND_MODEL_RETAIN_VERSIONS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := valid
+			tt.modify(&rc)
+			cfg := &Config{Recommend: rc}
+
+			err := cfg.validateRecommend()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateRecommend() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateRecommend() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestGeoIPConfig_ProviderChain(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{provider: "", want: []string{"maxmind", "ipapi"}},
+		{provider: "maxmind", want: []string{"maxmind", "ipapi"}},
+		{provider: "ipapi", want: []string{"ipapi", "maxmind"}},
+		{provider: " IPAPI , maxmind ", want: []string{"ipapi", "maxmind"}},
+		{provider: "ipapi,ipapi", want: []string{"ipapi", "maxmind"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			got := GeoIPConfig{Provider: tt.provider}.ProviderChain()
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ProviderChain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGeoIP(t *testing.T) {
+	tests := []struct {
+		provider string
+		wantErr  bool
+	}{
+		{provider: ""},
+		{provider: "maxmind"},
+		{provider: "ipapi,maxmind"},
+		{provider: "freegeoip", wantErr: true},
+		{provider: "ipapi,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			cfg := &Config{GeoIP: GeoIPConfig{Provider: tt.provider}}
+			err := cfg.validateGeoIP()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateGeoIP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "GEOIP_PROVIDER") {
+				t.Errorf("validateGeoIP() error = %v, want mention of GEOIP_PROVIDER", err)
+			}
+		})
+	}
+}
+
+func TestValidateDetection(t *testing.T) {
+	valid := EmailNotifierConfig{
+		Enabled:  true,
+		SMTPHost: "smtp.example.com",
+		SMTPPort: 587,
+		From:     "alerts@example.com",
+		To:       []string{"ops@example.com"},
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(*EmailNotifierConfig)
+		errContains string
+	}{
+		{name: "valid", modify: func(*EmailNotifierConfig) {}},
+		{name: "disabled skips checks", modify: func(c *EmailNotifierConfig) { c.Enabled = false; c.SMTPHost = "" }},
+		{name: "missing host", modify: func(c *EmailNotifierConfig) { c.SMTPHost = "" }, errContains: "ALERT_SMTP_HOST"},
+		{name: "bad port", modify: func(c *EmailNotifierConfig) { c.SMTPPort = 70000 }, errContains: "ALERT_SMTP_PORT"},
+		{name: "missing sender", modify: func(c *EmailNotifierConfig) { c.From = "" }, errContains: "ALERT_EMAIL_FROM"},
+		{name: "no recipients", modify: func(c *EmailNotifierConfig) { c.To = nil }, errContains: "ALERT_EMAIL_TO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec := valid
+			tt.modify(&ec)
+			cfg := &Config{Detection: DetectionConfig{Email: ec}}
+
+			err := cfg.validateDetection()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateDetection() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateDetection() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateDetection_MinSeverity(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*DetectionConfig)
+		errContains string
+	}{
+		{name: "unset", modify: func(*DetectionConfig) {}},
+		{name: "valid values", modify: func(d *DetectionConfig) {
+			d.Discord.MinSeverity = "critical"
+			d.Webhook.MinSeverity = "info"
+			d.Slack.MinSeverity = "Warning"
+		}},
+		{name: "invalid discord", modify: func(d *DetectionConfig) { d.Discord.MinSeverity = "high" }, errContains: "DISCORD_MIN_SEVERITY"},
+		{name: "invalid email checked when disabled", modify: func(d *DetectionConfig) { d.Email.MinSeverity = "urgent" }, errContains: "ALERT_EMAIL_MIN_SEVERITY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			tt.modify(&cfg.Detection)
+
+			err := cfg.validateDetection()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateDetection() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateDetection() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateNewsletterSMTP(t *testing.T) {
+	valid := NewsletterSMTPConfig{
+		Host:    "smtp.example.com",
+		Port:    587,
+		From:    "news@example.com",
+		TLSMode: "starttls",
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(*NewsletterSMTPConfig)
+		errContains string
+	}{
+		{name: "valid", modify: func(*NewsletterSMTPConfig) {}},
+		{name: "implicit TLS", modify: func(c *NewsletterSMTPConfig) { c.Port = 465; c.TLSMode = "implicit" }},
+		{name: "no host skips checks", modify: func(c *NewsletterSMTPConfig) { c.Host = ""; c.From = "" }},
+		{name: "bad port", modify: func(c *NewsletterSMTPConfig) { c.Port = 0 }, errContains: "NEWSLETTER_SMTP_PORT"},
+		{name: "missing sender", modify: func(c *NewsletterSMTPConfig) { c.From = "" }, errContains: "NEWSLETTER_SMTP_FROM"},
+		{name: "bad TLS mode", modify: func(c *NewsletterSMTPConfig) { c.TLSMode = "ssl" }, errContains: "NEWSLETTER_SMTP_TLS_MODE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := valid
+			tt.modify(&sc)
+			cfg := &Config{Newsletter: NewsletterConfig{SMTP: sc}}
+
+			err := cfg.validateNewsletterSMTP()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateNewsletterSMTP() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateNewsletterSMTP() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateAccessLog(t *testing.T) {
+	tests := []struct {
+		name        string
+		rate        float64
+		slow        time.Duration
+		errContains string
+	}{
+		{name: "defaults", rate: 1, slow: time.Second},
+		{name: "ten percent", rate: 0.1, slow: 500 * time.Millisecond},
+		{name: "slow detection off", rate: 1, slow: 0},
+		{name: "zero rate", rate: 0, errContains: "LOG_ACCESS_SAMPLE_RATE"},
+		{name: "rate above one", rate: 1.5, errContains: "LOG_ACCESS_SAMPLE_RATE"},
+		{name: "negative threshold", rate: 1, slow: -time.Second, errContains: "LOG_ACCESS_SLOW_THRESHOLD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Logging: LoggingConfig{AccessSampleRate: tt.rate, AccessSlowThreshold: tt.slow}}
+
+			err := cfg.validateAccessLog()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateAccessLog() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateAccessLog() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateLogSampling(t *testing.T) {
+	tests := []struct {
+		name        string
+		first       int
+		thereafter  int
+		errContains string
+	}{
+		{name: "off", first: 0, thereafter: 0},
+		{name: "burst then every 100th", first: 10, thereafter: 100},
+		{name: "negative first", first: -1, errContains: "LOG_SAMPLE_FIRST"},
+		{name: "negative thereafter", thereafter: -5, errContains: "LOG_SAMPLE_THEREAFTER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Logging: LoggingConfig{Level: "info", SampleFirst: tt.first, SampleThereafter: tt.thereafter, AccessSampleRate: 1}}
+
+			err := cfg.validateLogging()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateLogging() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateLogging() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateRetention(t *testing.T) {
+	enabled := RetentionConfig{Enabled: true, PlaybackMaxAgeDays: 730, WindowStartHour: 3, WindowEndHour: 5, BatchSize: 5000}
+	with := func(mutate func(*RetentionConfig)) RetentionConfig {
+		r := enabled
+		mutate(&r)
+		return r
+	}
+
+	tests := []struct {
+		name        string
+		retention   RetentionConfig
+		errContains string
+	}{
+		{name: "disabled", retention: RetentionConfig{}},
+		{name: "disabled with limits for the dry run", retention: RetentionConfig{PlaybackMaxRows: 1000000}},
+		{name: "enabled", retention: enabled},
+		{name: "window wraps midnight", retention: with(func(r *RetentionConfig) { r.WindowStartHour, r.WindowEndHour = 22, 4 })},
+		{name: "negative age", retention: RetentionConfig{DetectionAlertsMaxAgeDays: -1}, errContains: "RETENTION_DETECTION_ALERTS_MAX_AGE_DAYS"},
+		{name: "negative rows", retention: RetentionConfig{NewsletterLogMaxRows: -1}, errContains: "RETENTION_NEWSLETTER_LOG_MAX_ROWS"},
+		{name: "enabled without limits", retention: with(func(r *RetentionConfig) { r.PlaybackMaxAgeDays = 0 }), errContains: "at least one"},
+		{name: "start hour out of range", retention: with(func(r *RetentionConfig) { r.WindowStartHour = 24 }), errContains: "RETENTION_WINDOW_START_HOUR"},
+		{name: "end hour out of range", retention: with(func(r *RetentionConfig) { r.WindowEndHour = -1 }), errContains: "RETENTION_WINDOW_END_HOUR"},
+		{name: "empty window", retention: with(func(r *RetentionConfig) { r.WindowEndHour = 3 }), errContains: "must differ"},
+		{name: "batch size zero", retention: with(func(r *RetentionConfig) { r.BatchSize = 0 }), errContains: "RETENTION_BATCH_SIZE"},
+		{name: "batch size too large", retention: with(func(r *RetentionConfig) { r.BatchSize = MaxRetentionBatchSize + 1 }), errContains: "RETENTION_BATCH_SIZE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Retention: tt.retention}
+
+			err := cfg.validateRetention()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateRetention() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validateRetention() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidatePrivacy(t *testing.T) {
+	key := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	tests := []struct {
+		name        string
+		privacy     PrivacyConfig
+		errContains string
+	}{
+		{name: "unset", privacy: PrivacyConfig{}},
+		{name: "full", privacy: PrivacyConfig{IPMode: "full"}},
+		{name: "truncated without key", privacy: PrivacyConfig{IPMode: "truncated"}},
+		{name: "hashed", privacy: PrivacyConfig{IPMode: "hashed", IPHashKey: key}},
+		{name: "unknown mode", privacy: PrivacyConfig{IPMode: "masked"}, errContains: "PRIVACY_IP_MODE"},
+		{name: "hashed without key", privacy: PrivacyConfig{IPMode: "hashed"}, errContains: "PRIVACY_IP_HASH_KEY"},
+		{name: "hashed with short key", privacy: PrivacyConfig{IPMode: "hashed", IPHashKey: "too-short"}, errContains: "at least 32"},
+		{name: "hashed with placeholder key", privacy: PrivacyConfig{IPMode: "hashed", IPHashKey: "CHANGE_ME_to_a_random_32_character_key"}, errContains: "placeholder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Privacy: tt.privacy}
+
+			err := cfg.validatePrivacy()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validatePrivacy() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("validatePrivacy() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
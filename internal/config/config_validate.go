@@ -7,43 +7,65 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // Validate checks that required configuration is present and valid
 func (c *Config) Validate() error {
-	if err := c.validateTautulli(); err != nil {
-		return err
-	}
-
-	if err := c.validatePlex(); err != nil {
-		return err
-	}
-
-	if err := c.validateNATS(); err != nil {
-		return err
-	}
-
-	if err := c.validateImport(); err != nil {
-		return err
+	for _, validate := range c.validators() {
+		if err := validate(); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if err := c.validateServer(); err != nil {
-		return err
+// ValidateAll runs every validation check and returns all failures instead
+// of stopping at the first, for reporting (see the --validate-config flag).
+func (c *Config) ValidateAll() []error {
+	var errs []error
+	for _, validate := range c.validators() {
+		if err := validate(); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errs
+}
 
-	if err := c.validateSecurity(); err != nil {
-		return err
+// validators returns the validation checks in the order Validate runs them.
+func (c *Config) validators() []func() error {
+	return []func() error{
+		c.validateTautulli,
+		c.validatePlex,
+		c.validateSync,
+		c.validateDatabase,
+		c.validateGeoIP,
+		c.validateNATS,
+		c.validateImport,
+		c.validateServer,
+		c.validateRecommend,
+		c.validateDetection,
+		c.validateNewsletterSMTP,
+		c.validateSecurity,
+		c.validateLogging,
+		c.validateBackup,
+		c.validateRetention,
+		c.validatePrivacy,
+		c.validateVPN,
 	}
-
-	return c.validateLogging()
 }
 
 // validateTautulli validates Tautulli configuration (only if enabled)
 // As of v2.0, Tautulli is OPTIONAL - Cartographus can run standalone with direct
 // Plex, Jellyfin, and/or Emby integrations without requiring Tautulli.
 func (c *Config) validateTautulli() error {
+	if err := c.validateTautulliCompatAPI(); err != nil {
+		return err
+	}
 	if !c.Tautulli.Enabled {
 		return nil // Tautulli is optional - no validation needed when disabled
 	}
@@ -73,6 +95,22 @@ func (c *Config) validateTautulliAPIKey() error {
 	return nil
 }
 
+// validateTautulliCompatAPI validates the Tautulli-compatible API key, which
+// grants read access to playback history without a login
+func (c *Config) validateTautulliCompatAPI() error {
+	key := c.Tautulli.CompatAPIKey
+	if key == "" {
+		return nil
+	}
+	if !c.Tautulli.CompatAPI {
+		return fmt.Errorf("TAUTULLI_COMPAT_API_KEY is set but TAUTULLI_COMPAT_API is disabled")
+	}
+	if len(key) < MinTautulliCompatAPIKeyLength {
+		return fmt.Errorf("TAUTULLI_COMPAT_API_KEY must be at least %d characters", MinTautulliCompatAPIKeyLength)
+	}
+	return nil
+}
+
 // validatePlex validates Plex configuration (only if enabled)
 func (c *Config) validatePlex() error {
 	if !c.Plex.Enabled {
@@ -118,6 +156,83 @@ func (c *Config) validatePlexSyncDaysBack() error {
 	return nil
 }
 
+// validateSync validates the sync timestamp sanity window, session stitch gap
+// and watch party detection thresholds
+func (c *Config) validateSync() error {
+	if c.Sync.TimestampMinDate != "" {
+		if _, err := time.Parse(time.DateOnly, c.Sync.TimestampMinDate); err != nil {
+			return fmt.Errorf("SYNC_TIMESTAMP_MIN_DATE must be a date in YYYY-MM-DD format: %w", err)
+		}
+	}
+	if c.Sync.TimestampMaxFuture < 0 {
+		return fmt.Errorf("SYNC_TIMESTAMP_MAX_FUTURE must not be negative")
+	}
+	if c.Sync.SessionStitchGap < 0 {
+		return fmt.Errorf("SYNC_SESSION_STITCH_GAP must not be negative")
+	}
+	if c.Sync.WatchPartyStartWindow < 0 {
+		return fmt.Errorf("SYNC_WATCH_PARTY_START_WINDOW must not be negative")
+	}
+	if c.Sync.WatchPartyMinDuration < 0 {
+		return fmt.Errorf("SYNC_WATCH_PARTY_MIN_DURATION must not be negative")
+	}
+	return nil
+}
+
+// validateDatabase validates the slow query log, metrics, connection pool
+// and tile warming settings
+func (c *Config) validateDatabase() error {
+	if c.Database.SlowQueryThreshold < 0 {
+		return fmt.Errorf("DB_SLOW_QUERY_THRESHOLD must not be negative (use 0 to disable)")
+	}
+	if c.Database.SlowQueryLogSize < 0 {
+		return fmt.Errorf("DB_SLOW_QUERY_LOG_SIZE must not be negative")
+	}
+	if c.Database.MetricsInterval < 0 {
+		return fmt.Errorf("DB_METRICS_INTERVAL must not be negative (use 0 to disable)")
+	}
+	if c.Database.MetricsInterval > 0 && c.Database.MetricsInterval < time.Second {
+		return fmt.Errorf("DB_METRICS_INTERVAL must be at least 1s")
+	}
+	if c.Database.MaxOpenConns < 0 {
+		return fmt.Errorf("DB_MAX_OPEN_CONNS must not be negative (use 0 for NumCPU)")
+	}
+	if c.Database.MaxIdleConns < 0 {
+		return fmt.Errorf("DB_MAX_IDLE_CONNS must not be negative (use 0 for the default of 2)")
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		return fmt.Errorf("DB_CONN_MAX_LIFETIME must not be negative (use 0 for the default of 1h)")
+	}
+	if c.Database.HealthCheckInterval < 0 {
+		return fmt.Errorf("DB_HEALTH_CHECK_INTERVAL must not be negative (use 0 to disable)")
+	}
+	if c.Database.HealthCheckInterval > 0 && c.Database.HealthCheckInterval < time.Second {
+		return fmt.Errorf("DB_HEALTH_CHECK_INTERVAL must be at least 1s")
+	}
+	if c.Database.MaxConcurrentAnalytics < 0 {
+		return fmt.Errorf("DB_MAX_CONCURRENT_ANALYTICS must not be negative (use 0 for DB_MAX_OPEN_CONNS - 1)")
+	}
+	if c.Database.TileWarmMaxZoom < 0 || c.Database.TileWarmMaxZoom > MaxTileWarmZoom {
+		return fmt.Errorf("DB_TILE_WARM_MAX_ZOOM must be between 0 and %d", MaxTileWarmZoom)
+	}
+	return nil
+}
+
+// validateGeoIP validates the GeoIP provider priority list
+func (c *Config) validateGeoIP() error {
+	if c.GeoIP.Provider == "" {
+		return nil
+	}
+	for _, name := range strings.Split(c.GeoIP.Provider, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case GeoIPProviderMaxMind, GeoIPProviderIPAPI:
+		default:
+			return fmt.Errorf("GEOIP_PROVIDER contains unknown provider %q (valid: maxmind, ipapi)", strings.TrimSpace(name))
+		}
+	}
+	return nil
+}
+
 // validateNATS validates NATS configuration (only if enabled)
 func (c *Config) validateNATS() error {
 	if !c.NATS.Enabled {
@@ -152,6 +267,7 @@ func (c *Config) validateNATSLimits() error {
 		c.validateNATSBatchSize,
 		c.validateNATSFlushInterval,
 		c.validateNATSSubscribers,
+		c.validateNATSTopicPartitioning,
 	}
 
 	for _, validator := range validators {
@@ -210,6 +326,15 @@ func (c *Config) validateNATSSubscribers() error {
 	return nil
 }
 
+// validateNATSTopicPartitioning validates the event subject partitioning mode
+func (c *Config) validateNATSTopicPartitioning() error {
+	switch c.NATS.TopicPartitioning {
+	case "", "none", "server":
+		return nil
+	}
+	return fmt.Errorf("NATS_TOPIC_PARTITIONING must be 'none' or 'server', got %q", c.NATS.TopicPartitioning)
+}
+
 // validateImport validates Import configuration (only if enabled)
 func (c *Config) validateImport() error {
 	if !c.Import.Enabled {
@@ -222,7 +347,10 @@ func (c *Config) validateImport() error {
 	if err := c.validateImportBatchSize(); err != nil {
 		return err
 	}
-	return c.validateImportResumeFromID()
+	if err := c.validateImportResumeFromID(); err != nil {
+		return err
+	}
+	return c.validateImportDateRange()
 }
 
 // validateImportDBPath validates the import database path
@@ -249,11 +377,122 @@ func (c *Config) validateImportResumeFromID() error {
 	return nil
 }
 
+// validateImportDateRange validates the import date filter
+func (c *Config) validateImportDateRange() error {
+	var from, to time.Time
+	var err error
+	if c.Import.DateFrom != "" {
+		if from, err = time.Parse(time.DateOnly, c.Import.DateFrom); err != nil {
+			return fmt.Errorf("IMPORT_DATE_FROM must be a date (YYYY-MM-DD): %w", err)
+		}
+	}
+	if c.Import.DateTo != "" {
+		if to, err = time.Parse(time.DateOnly, c.Import.DateTo); err != nil {
+			return fmt.Errorf("IMPORT_DATE_TO must be a date (YYYY-MM-DD): %w", err)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return fmt.Errorf("IMPORT_DATE_TO (%s) is before IMPORT_DATE_FROM (%s)", c.Import.DateTo, c.Import.DateFrom)
+	}
+	return nil
+}
+
+// validateRecommend validates recommendation training settings (only if enabled)
+func (c *Config) validateRecommend() error {
+	if !c.Recommend.Enabled {
+		return nil
+	}
+	if c.Recommend.TrainParallelism < 1 {
+		return fmt.Errorf("RECOMMEND_TRAIN_PARALLELISM must be at least 1, got %d", c.Recommend.TrainParallelism)
+	}
+	if c.Recommend.TrainMinNewInteractions < 0 {
+		return fmt.Errorf("RECOMMEND_TRAIN_MIN_NEW_INTERACTIONS must be non-negative, got %d", c.Recommend.TrainMinNewInteractions)
+	}
+	if c.Recommend.TrainMaxPoolUtilization < 0 || c.Recommend.TrainMaxPoolUtilization > 1 {
+		return fmt.Errorf("RECOMMEND_TRAIN_MAX_POOL_UTILIZATION must be between 0 and 1, got %g", c.Recommend.TrainMaxPoolUtilization)
+	}
+	if c.Recommend.ModelRetainVersions < 1 {
+		return fmt.Errorf("RECOMMEND_MODEL_RETAIN_VERSIONS must be at least 1, got %d", c.Recommend.ModelRetainVersions)
+	}
+	return nil
+}
+
+// validateDetection validates detection notifier configuration (only if enabled)
+func (c *Config) validateDetection() error {
+	minSeverities := []struct {
+		env   string
+		value string
+	}{
+		{"DISCORD_MIN_SEVERITY", c.Detection.Discord.MinSeverity},
+		{"WEBHOOK_MIN_SEVERITY", c.Detection.Webhook.MinSeverity},
+		{"SLACK_MIN_SEVERITY", c.Detection.Slack.MinSeverity},
+		{"ALERT_EMAIL_MIN_SEVERITY", c.Detection.Email.MinSeverity},
+	}
+	for _, ms := range minSeverities {
+		switch strings.ToLower(strings.TrimSpace(ms.value)) {
+		case "", "info", "warning", "critical":
+		default:
+			return fmt.Errorf("%s must be one of: info, warning, critical, got %q", ms.env, ms.value)
+		}
+	}
+
+	email := c.Detection.Email
+	if !email.Enabled {
+		return nil
+	}
+	if email.SMTPHost == "" {
+		return fmt.Errorf("ALERT_SMTP_HOST is required when ALERT_EMAIL_ENABLED=true")
+	}
+	if email.SMTPPort < 1 || email.SMTPPort > 65535 {
+		return fmt.Errorf("ALERT_SMTP_PORT must be between 1 and 65535, got %d", email.SMTPPort)
+	}
+	if email.From == "" {
+		return fmt.Errorf("ALERT_EMAIL_FROM is required when ALERT_EMAIL_ENABLED=true")
+	}
+	if len(email.To) == 0 {
+		return fmt.Errorf("ALERT_EMAIL_TO is required when ALERT_EMAIL_ENABLED=true")
+	}
+	return nil
+}
+
+// validateNewsletterSMTP validates the default newsletter SMTP settings
+// (only if a host is configured)
+func (c *Config) validateNewsletterSMTP() error {
+	smtp := c.Newsletter.SMTP
+	if smtp.Host == "" {
+		return nil
+	}
+	if smtp.Port < 1 || smtp.Port > 65535 {
+		return fmt.Errorf("NEWSLETTER_SMTP_PORT must be between 1 and 65535, got %d", smtp.Port)
+	}
+	if smtp.From == "" {
+		return fmt.Errorf("NEWSLETTER_SMTP_FROM is required when NEWSLETTER_SMTP_HOST is set")
+	}
+	switch strings.ToLower(smtp.TLSMode) {
+	case "", "starttls", "implicit", "none":
+	default:
+		return fmt.Errorf("NEWSLETTER_SMTP_TLS_MODE must be starttls, implicit, or none, got %q", smtp.TLSMode)
+	}
+	return nil
+}
+
 // validateServer validates server configuration
 func (c *Config) validateServer() error {
 	if c.Server.Port < 1 || c.Server.Port > 65535 {
 		return fmt.Errorf("HTTP_PORT must be between 1 and 65535")
 	}
+	if c.Server.MaxBodySize < 1 {
+		return fmt.Errorf("HTTP_MAX_BODY_SIZE must be at least 1 byte, got %d", c.Server.MaxBodySize)
+	}
+	if c.Server.RequestTimeout <= 0 {
+		return fmt.Errorf("HTTP_REQUEST_TIMEOUT must be positive, got %s", c.Server.RequestTimeout)
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		return fmt.Errorf("HTTP_SHUTDOWN_TIMEOUT must be positive, got %s", c.Server.ShutdownTimeout)
+	}
+	if c.API.QueryTimeout <= 0 {
+		return fmt.Errorf("API_QUERY_TIMEOUT must be positive, got %s", c.API.QueryTimeout)
+	}
 	return nil
 }
 
@@ -604,7 +843,35 @@ func (c *Config) validateLogging() error {
 	if err := c.validateLogLevel(); err != nil {
 		return err
 	}
-	return c.validateLogFormat()
+	if err := c.validateLogFormat(); err != nil {
+		return err
+	}
+	if err := c.validateLogSampling(); err != nil {
+		return err
+	}
+	return c.validateAccessLog()
+}
+
+// validateLogSampling validates the log sampling configuration
+func (c *Config) validateLogSampling() error {
+	if c.Logging.SampleFirst < 0 {
+		return fmt.Errorf("LOG_SAMPLE_FIRST must be non-negative, got %d", c.Logging.SampleFirst)
+	}
+	if c.Logging.SampleThereafter < 0 {
+		return fmt.Errorf("LOG_SAMPLE_THEREAFTER must be non-negative, got %d", c.Logging.SampleThereafter)
+	}
+	return nil
+}
+
+// validateAccessLog validates the access log sampling configuration
+func (c *Config) validateAccessLog() error {
+	if c.Logging.AccessSampleRate <= 0 || c.Logging.AccessSampleRate > 1 {
+		return fmt.Errorf("LOG_ACCESS_SAMPLE_RATE must be greater than 0 and at most 1, got %g", c.Logging.AccessSampleRate)
+	}
+	if c.Logging.AccessSlowThreshold < 0 {
+		return fmt.Errorf("LOG_ACCESS_SLOW_THRESHOLD must be non-negative, got %s", c.Logging.AccessSlowThreshold)
+	}
+	return nil
 }
 
 // validateLogLevel validates the log level configuration
@@ -659,3 +926,107 @@ func containsAnyPattern(s string, patterns []string) bool {
 	}
 	return false
 }
+
+// validateBackup validates the backup schedule, with the same rules the
+// backup package applies when the schedule is changed through the API
+func (c *Config) validateBackup() error {
+	if !c.Backup.ScheduleEnabled {
+		return nil
+	}
+	if c.Backup.Interval < time.Hour {
+		return fmt.Errorf("BACKUP_INTERVAL must be at least 1h, got %s", c.Backup.Interval)
+	}
+	if c.Backup.PreferredHour < 0 || c.Backup.PreferredHour > 23 {
+		return fmt.Errorf("BACKUP_PREFERRED_HOUR must be between 0 and 23, got %d", c.Backup.PreferredHour)
+	}
+	switch c.Backup.Type {
+	case "full", "database", "config":
+		return nil
+	default:
+		return fmt.Errorf("BACKUP_TYPE must be one of: full, database, config")
+	}
+}
+
+// validateRetention validates the retention limits, and the quiet window and
+// batch size when pruning is enabled
+func (c *Config) validateRetention() error {
+	r := c.Retention
+	for _, limit := range []struct {
+		env   string
+		value int64
+	}{
+		{"RETENTION_PLAYBACK_MAX_AGE_DAYS", int64(r.PlaybackMaxAgeDays)},
+		{"RETENTION_PLAYBACK_MAX_ROWS", r.PlaybackMaxRows},
+		{"RETENTION_DETECTION_ALERTS_MAX_AGE_DAYS", int64(r.DetectionAlertsMaxAgeDays)},
+		{"RETENTION_DETECTION_ALERTS_MAX_ROWS", r.DetectionAlertsMaxRows},
+		{"RETENTION_NEWSLETTER_LOG_MAX_AGE_DAYS", int64(r.NewsletterLogMaxAgeDays)},
+		{"RETENTION_NEWSLETTER_LOG_MAX_ROWS", r.NewsletterLogMaxRows},
+	} {
+		if limit.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", limit.env, limit.value)
+		}
+	}
+
+	if !r.Enabled {
+		return nil
+	}
+	if !r.HasLimits() {
+		return fmt.Errorf("RETENTION_ENABLED requires at least one RETENTION_*_MAX_AGE_DAYS or RETENTION_*_MAX_ROWS limit")
+	}
+	if r.WindowStartHour < 0 || r.WindowStartHour > 23 {
+		return fmt.Errorf("RETENTION_WINDOW_START_HOUR must be between 0 and 23, got %d", r.WindowStartHour)
+	}
+	if r.WindowEndHour < 0 || r.WindowEndHour > 23 {
+		return fmt.Errorf("RETENTION_WINDOW_END_HOUR must be between 0 and 23, got %d", r.WindowEndHour)
+	}
+	if r.WindowStartHour == r.WindowEndHour {
+		return fmt.Errorf("RETENTION_WINDOW_START_HOUR and RETENTION_WINDOW_END_HOUR must differ, got %d for both", r.WindowStartHour)
+	}
+	if r.BatchSize < 1 || r.BatchSize > MaxRetentionBatchSize {
+		return fmt.Errorf("RETENTION_BATCH_SIZE must be between 1 and %d, got %d", MaxRetentionBatchSize, r.BatchSize)
+	}
+	return nil
+}
+
+// validatePrivacy validates PRIVACY_IP_MODE, and the hash key in hashed mode
+func (c *Config) validatePrivacy() error {
+	mode, err := privacy.ParseIPMode(c.Privacy.IPMode)
+	if err != nil {
+		return fmt.Errorf("PRIVACY_IP_MODE: %w", err)
+	}
+	if mode != privacy.IPModeHashed {
+		return nil
+	}
+	if len(c.Privacy.IPHashKey) < privacy.MinHashKeyLength {
+		return fmt.Errorf("PRIVACY_IP_HASH_KEY must be at least %d characters when PRIVACY_IP_MODE is hashed", privacy.MinHashKeyLength)
+	}
+	if containsPlaceholder(c.Privacy.IPHashKey) {
+		return fmt.Errorf("PRIVACY_IP_HASH_KEY contains a placeholder value; generate a random key")
+	}
+	return nil
+}
+
+// minVPNUpdateInterval keeps scheduled VPN updates from hammering the
+// source; gluetun's data changes a few times a week.
+const minVPNUpdateInterval = time.Hour
+
+// validateVPN validates the VPN data updates (only if scheduled)
+func (c *Config) validateVPN() error {
+	if !c.VPN.Enabled || !c.VPN.AutoUpdate {
+		return nil
+	}
+	if c.VPN.DatacenterURL != "" {
+		u, err := url.Parse(c.VPN.DatacenterURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("VPN_DATACENTER_URL must be an http(s) URL, got %q", c.VPN.DatacenterURL)
+		}
+	}
+	if c.VPN.UpdateInterval < minVPNUpdateInterval {
+		return fmt.Errorf("VPN_UPDATE_INTERVAL must be at least %s, got %s", minVPNUpdateInterval, c.VPN.UpdateInterval)
+	}
+	u, err := url.Parse(c.VPN.UpdateURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("VPN_UPDATE_URL must be an http(s) URL when VPN_AUTO_UPDATE=true, got %q", c.VPN.UpdateURL)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Change is a setting whose value differs between two configurations,
+// identified by its koanf path such as "sync.interval". Old and New are
+// rendered as in Redacted, so secrets are masked.
+type Change struct {
+	Key string      `json:"key"`
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ReloadResult reports the outcome of a configuration reload: the changed
+// settings that were applied to the running server, and those that only
+// take effect after a restart.
+type ReloadResult struct {
+	Applied         []Change `json:"applied"`
+	RequiresRestart []Change `json:"requires_restart"`
+}
+
+// Diff returns the settings that differ between old and new, sorted by key.
+// Structs are compared field by field; lists and maps such as plex_servers
+// are compared as a whole. Secrets are compared by their real values, so a
+// changed secret is reported even when its masked form is unchanged.
+func Diff(old, new *Config) []Change {
+	var changes []Change
+	diffValues(reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem(), "", false, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// diffValues appends the leaf settings that differ between a and b.
+func diffValues(a, b reflect.Value, path string, secret bool, changes *[]Change) {
+	if a.Kind() == reflect.Struct && a.Type() != durationType {
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			key := koanfKey(field)
+			if !field.IsExported() || key == "-" {
+				continue
+			}
+			diffValues(a.Field(i), b.Field(i), joinKey(path, key), secret || IsSecretKey(key), changes)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return
+	}
+	*changes = append(*changes, Change{
+		Key: path,
+		Old: redactValue(a, secret),
+		New: redactValue(b, secret),
+	})
+}
+
+// WithSettings returns a copy of c with the settings at keys, koanf paths
+// as reported by Diff, taken from src. It is used to advance the running
+// configuration by the changes that were applied without a restart. Unknown
+// keys are ignored.
+func (c *Config) WithSettings(src *Config, keys []string) *Config {
+	next := *c
+	for _, key := range keys {
+		dst := fieldByKey(reflect.ValueOf(&next).Elem(), key)
+		from := fieldByKey(reflect.ValueOf(src).Elem(), key)
+		if dst.IsValid() && from.IsValid() {
+			dst.Set(from)
+		}
+	}
+	return &next
+}
+
+// fieldByKey returns the field of struct v at a dotted koanf path, or the
+// zero Value if there is none.
+func fieldByKey(v reflect.Value, path string) reflect.Value {
+	for _, key := range strings.Split(path, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		next := reflect.Value{}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && koanfKey(t.Field(i)) == key {
+				next = v.Field(i)
+				break
+			}
+		}
+		if !next.IsValid() {
+			return reflect.Value{}
+		}
+		v = next
+	}
+	return v
+}
+
+// joinKey appends key to a dotted koanf path.
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,62 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Config{}
+	old.Sync.Interval = 5 * time.Minute
+	old.Logging.Level = "info"
+	old.Security.JWTSecret = "jwt-secret-first-value-abcd"
+	old.Security.CORSOrigins = []string{"https://a.example"}
+
+	new := *old
+	new.Sync.Interval = time.Minute
+	new.Logging.Level = "debug"
+	new.Security.JWTSecret = "jwt-secret-other-value-abcd" // Same last 4 characters
+	new.Security.CORSOrigins = []string{"https://a.example", "https://b.example"}
+
+	want := []Change{
+		{Key: "logging.level", Old: "info", New: "debug"},
+		{Key: "security.cors_origins", Old: []interface{}{"https://a.example"}, New: []interface{}{"https://a.example", "https://b.example"}},
+		{Key: "security.jwt_secret", Old: "****abcd", New: "****abcd"},
+		{Key: "sync.interval", Old: "5m0s", New: "1m0s"},
+	}
+	if got := Diff(old, &new); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() =\n%#v\nwant\n%#v", got, want)
+	}
+
+	if got := Diff(old, old); len(got) != 0 {
+		t.Errorf("Diff of identical configs = %v, want none", got)
+	}
+}
+
+func TestWithSettings(t *testing.T) {
+	running := &Config{}
+	running.Sync.Interval = 5 * time.Minute
+	running.Server.Port = 3857
+
+	loaded := &Config{}
+	loaded.Sync.Interval = time.Minute
+	loaded.Server.Port = 8080
+
+	next := running.WithSettings(loaded, []string{"sync.interval", "no.such.setting"})
+
+	if next.Sync.Interval != time.Minute {
+		t.Errorf("sync.interval = %v, want the loaded 1m", next.Sync.Interval)
+	}
+	if next.Server.Port != 3857 {
+		t.Errorf("server.port = %d, want the running 3857", next.Server.Port)
+	}
+	if running.Sync.Interval != 5*time.Minute {
+		t.Error("WithSettings modified the running config")
+	}
+}
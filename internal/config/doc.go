@@ -118,6 +118,22 @@ The package performs comprehensive validation:
   - URL formats: TAUTULLI_URL, PLEX_URL must be valid HTTP(S) URLs
   - IP addresses: TRUSTED_PROXIES must be valid IPs/CIDRs
 
+Validate stops at the first failure; ValidateAll returns every failure, and
+LoadUnvalidated loads without validating so that all problems can be
+reported (the server's --validate-config flag).
+
+# Secret Redaction
+
+Config.Redacted returns the effective configuration with secrets masked to
+their last 4 characters, for display (GET /api/v1/admin/config). Secrets are
+recognized centrally by koanf key name (IsSecretKey): keys containing
+secret, password, token or credentials, keys ending in key, webhook URLs
+and notifier headers. A new field named like a secret is masked without any
+further change; a field holding a secret under another kind of name must be
+named to match. Passwords embedded in URLs are masked in any field.
+Config.SecretValues returns the raw values for scrubbing text such as error
+messages.
+
 # Defaults
 
 Sensible defaults are provided for all optional settings:
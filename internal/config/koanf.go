@@ -16,6 +16,8 @@ import (
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/providers/structs"
 	"github.com/knadh/koanf/v2"
+
+	"github.com/tomtom215/cartographus/internal/vpn"
 )
 
 // DefaultConfigPaths lists the paths where config files are searched in order of priority.
@@ -39,6 +41,8 @@ func defaultConfig() *Config {
 			URL:      "",
 			APIKey:   "",
 			ServerID: "", // Auto-generated if empty (for multi-server support)
+
+			CompatAPI: false,
 		},
 		Plex: PlexConfig{
 			Enabled:                       false,
@@ -58,6 +62,7 @@ func defaultConfig() *Config {
 			BufferHealthPollInterval:      5 * time.Second,
 			BufferHealthCriticalThreshold: 20.0,
 			BufferHealthRiskyThreshold:    50.0,
+			BufferHealthNotify:            false,
 			WebhooksEnabled:               false,
 			WebhookSecret:                 "",
 			SessionPollingEnabled:         false,            // Disabled by default - WebSocket is the primary mechanism
@@ -103,6 +108,7 @@ func defaultConfig() *Config {
 			SubscribersCount:    4,
 			DurableName:         "media-processor",
 			QueueGroup:          "processors",
+			TopicPartitioning:   "none",
 			// Router defaults (Watermill Router middleware)
 			RouterRetryCount:           3,
 			RouterRetryInitialInterval: 100 * time.Millisecond,
@@ -119,6 +125,16 @@ func defaultConfig() *Config {
 			Threads:                0,    // 0 = use runtime.NumCPU()
 			PreserveInsertionOrder: true, // DuckDB default
 			SeedMockData:           false,
+			SlowQueryThreshold:     500 * time.Millisecond,
+			SlowQueryLogSize:       100,
+			MetricsInterval:        time.Minute,
+			MaxOpenConns:           0, // 0 = use runtime.NumCPU()
+			MaxIdleConns:           2,
+			ConnMaxLifetime:        time.Hour,
+			HealthCheckInterval:    30 * time.Second,
+			MaxConcurrentAnalytics: 0, // 0 = MaxOpenConns - 1
+			TileWarmEnabled:        true,
+			TileWarmMaxZoom:        3, // 85 tiles
 		},
 		Sync: SyncConfig{
 			Interval:      5 * time.Minute,
@@ -127,18 +143,31 @@ func defaultConfig() *Config {
 			BatchSize:     1000,
 			RetryAttempts: 5,
 			RetryDelay:    2 * time.Second,
+
+			TimestampMinDate:   "2000-01-01",
+			TimestampMaxFuture: 24 * time.Hour,
+
+			SessionStitchGap: 2 * time.Hour,
+
+			WatchPartyStartWindow: 2 * time.Minute,
+			WatchPartyMinDuration: 10 * time.Minute,
 		},
 		Server: ServerConfig{
-			Port:        3857,
-			Host:        "0.0.0.0",
-			Timeout:     30 * time.Second,
-			Latitude:    0.0,
-			Longitude:   0.0,
-			Environment: "development", // Default to development; set ENVIRONMENT=production for production checks
+			Port:            3857,
+			Host:            "0.0.0.0",
+			Timeout:         30 * time.Second,
+			Latitude:        0.0,
+			Longitude:       0.0,
+			Environment:     "development", // Default to development; set ENVIRONMENT=production for production checks
+			MaxBodySize:     DefaultMaxBodySize,
+			RequestTimeout:  DefaultRequestTimeout,
+			ShutdownTimeout: DefaultShutdownTimeout,
 		},
 		API: APIConfig{
 			DefaultPageSize: 20,
 			MaxPageSize:     100,
+			CacheTTL:        DefaultCacheTTL,
+			QueryTimeout:    DefaultQueryTimeout,
 		},
 		Security: SecurityConfig{
 			AuthMode:          "jwt",
@@ -198,23 +227,32 @@ func defaultConfig() *Config {
 			},
 		},
 		Logging: LoggingConfig{
-			Level:  "info",
-			Format: "json",
-			Caller: false,
+			Level:            "info",
+			Format:           "json",
+			Caller:           false,
+			SampleFirst:      0, // Sampling off by default
+			SampleThereafter: 0,
+
+			AccessSampleRate:    1.0,
+			AccessSlowThreshold: time.Second,
 		},
 		// Recommendation engine configuration (ADR-0024)
 		// IMPORTANT: Disabled by default due to computational requirements
 		Recommend: RecommendConfig{
-			Enabled:            false, // Disabled by default - opt-in only
-			TrainInterval:      24 * time.Hour,
-			TrainOnStartup:     false,
-			MinInteractions:    100,
-			ModelPath:          "/data/recommend",
-			Algorithms:         []string{"covisit", "content"}, // Lightweight only by default
-			CacheTTL:           5 * time.Minute,
-			MaxCandidates:      1000,
-			DiversityLambda:    0.7,
-			CalibrationEnabled: true,
+			Enabled:                 false, // Disabled by default - opt-in only
+			TrainInterval:           24 * time.Hour,
+			TrainOnStartup:          false,
+			MinInteractions:         100,
+			ModelPath:               "/data/recommend",
+			ModelRetainVersions:     3,
+			TrainParallelism:        1,
+			TrainMinNewInteractions: 50,
+			TrainMaxPoolUtilization: 0.75,
+			Algorithms:              []string{"covisit", "content"}, // Lightweight only by default
+			CacheTTL:                5 * time.Minute,
+			MaxCandidates:           1000,
+			DiversityLambda:         0.7,
+			CalibrationEnabled:      true,
 			EASE: EASEAlgorithmConfig{
 				L2Regularization: 500.0,
 				MinConfidence:    0.1,
@@ -252,6 +290,36 @@ func defaultConfig() *Config {
 			CheckInterval:           time.Minute,     // How often to check for due schedules
 			MaxConcurrentDeliveries: 5,               // Max newsletters to deliver concurrently
 			ExecutionTimeout:        5 * time.Minute, // Max time for a single newsletter execution
+			SMTP: NewsletterSMTPConfig{
+				Port:    587,        // Submission port
+				TLSMode: "starttls", // Upgrade with STARTTLS
+			},
+		},
+		// Backup schedule (defaults match the backup package)
+		Backup: BackupConfig{
+			ScheduleEnabled: true,
+			Interval:        24 * time.Hour,
+			PreferredHour:   2,
+			Type:            "full",
+		},
+		// Retention: nothing is pruned until limits are set and the job enabled
+		Retention: RetentionConfig{
+			Enabled:         false,
+			WindowStartHour: 3,
+			WindowEndHour:   5,
+			BatchSize:       5000,
+		},
+		// Privacy: addresses are stored as received unless a mode is chosen
+		Privacy: PrivacyConfig{
+			IPMode: "full",
+		},
+		// VPN detection: on, with data from VPN_DATA_FILE or updates
+		VPN: VPNConfig{
+			Enabled:        true,
+			CacheSize:      10000,
+			AutoUpdate:     false,
+			UpdateInterval: 24 * time.Hour,
+			UpdateURL:      vpn.DefaultGluetunURL,
 		},
 	}
 }
@@ -267,19 +335,37 @@ func defaultConfig() *Config {
 //   - Support for nested configuration via koanf struct tags
 //   - Backward compatibility with existing environment variables
 func LoadWithKoanf() (*Config, error) {
+	cfg, _, err := LoadUnvalidated()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the configuration
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// LoadUnvalidated loads configuration like LoadWithKoanf but does not
+// validate it, so that callers can report every problem (see
+// Config.ValidateAll). It also returns the path of the config file that was
+// loaded, or an empty string if none was found.
+func LoadUnvalidated() (*Config, string, error) {
 	k := koanf.New(".")
 
 	// Layer 1: Load defaults from struct
 	defaults := defaultConfig()
 	if err := k.Load(structs.Provider(defaults, "koanf"), nil); err != nil {
-		return nil, fmt.Errorf("failed to load defaults: %w", err)
+		return nil, "", fmt.Errorf("failed to load defaults: %w", err)
 	}
 
 	// Layer 2: Load config file (optional)
 	configPath := findConfigFile()
 	if configPath != "" {
 		if err := k.Load(file.Provider(configPath), yaml.Parser()); err != nil {
-			return nil, fmt.Errorf("failed to load config file %s: %w", configPath, err)
+			return nil, configPath, fmt.Errorf("failed to load config file %s: %w", configPath, err)
 		}
 	}
 
@@ -289,26 +375,21 @@ func LoadWithKoanf() (*Config, error) {
 	// PLEX_SYNC_DAYS_BACK -> plex.sync_days_back
 	envProvider := env.Provider("", ".", envTransformFunc)
 	if err := k.Load(envProvider, nil); err != nil {
-		return nil, fmt.Errorf("failed to load environment variables: %w", err)
+		return nil, configPath, fmt.Errorf("failed to load environment variables: %w", err)
 	}
 
 	// Post-process slice fields from comma-separated strings
 	if err := processSliceFields(k); err != nil {
-		return nil, fmt.Errorf("failed to process slice fields: %w", err)
+		return nil, configPath, fmt.Errorf("failed to process slice fields: %w", err)
 	}
 
 	// Unmarshal into Config struct
 	cfg := &Config{}
 	if err := k.Unmarshal("", cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+		return nil, configPath, fmt.Errorf("failed to unmarshal configuration: %w", err)
 	}
 
-	// Validate the configuration
-	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
-	}
-
-	return cfg, nil
+	return cfg, configPath, nil
 }
 
 // findConfigFile searches for a config file in the default paths.
@@ -342,6 +423,8 @@ var sliceConfigPaths = []string{
 	"security.plex_auth.default_roles",
 	// Recommendation engine (ADR-0024)
 	"recommend.algorithms",
+	// Tautulli import filter
+	"import.users",
 }
 
 // processSliceFields converts comma-separated string values to slices for known slice fields.
@@ -406,6 +489,9 @@ func envTransformFunc(key string) string {
 		"tautulli_api_key":   "tautulli.api_key",
 		"tautulli_server_id": "tautulli.server_id",
 
+		"tautulli_compat_api":     "tautulli.compat_api",
+		"tautulli_compat_api_key": "tautulli.compat_api_key",
+
 		// Plex mappings (handle ENABLE_ prefix)
 		"enable_plex_sync":                   "plex.enabled",
 		"plex_server_id":                     "plex.server_id",
@@ -424,6 +510,7 @@ func envTransformFunc(key string) string {
 		"buffer_health_poll_interval":        "plex.buffer_health_poll_interval",
 		"buffer_health_critical_threshold":   "plex.buffer_health_critical_threshold",
 		"buffer_health_risky_threshold":      "plex.buffer_health_risky_threshold",
+		"buffer_health_notify":               "plex.buffer_health_notify",
 		"enable_plex_webhooks":               "plex.webhooks_enabled",
 		"plex_webhook_secret":                "plex.webhook_secret",
 		"plex_session_polling_enabled":       "plex.session_polling_enabled",
@@ -454,19 +541,20 @@ func envTransformFunc(key string) string {
 		"emby_webhook_secret":           "emby.webhook_secret",
 
 		// NATS mappings
-		"nats_enabled":        "nats.enabled",
-		"nats_event_sourcing": "nats.event_sourcing",
-		"nats_url":            "nats.url",
-		"nats_embedded":       "nats.embedded_server",
-		"nats_store_dir":      "nats.store_dir",
-		"nats_max_memory":     "nats.max_memory",
-		"nats_max_store":      "nats.max_store",
-		"nats_retention_days": "nats.stream_retention_days",
-		"nats_batch_size":     "nats.batch_size",
-		"nats_flush_interval": "nats.flush_interval",
-		"nats_subscribers":    "nats.subscribers_count",
-		"nats_durable_name":   "nats.durable_name",
-		"nats_queue_group":    "nats.queue_group",
+		"nats_enabled":            "nats.enabled",
+		"nats_event_sourcing":     "nats.event_sourcing",
+		"nats_url":                "nats.url",
+		"nats_embedded":           "nats.embedded_server",
+		"nats_store_dir":          "nats.store_dir",
+		"nats_max_memory":         "nats.max_memory",
+		"nats_max_store":          "nats.max_store",
+		"nats_retention_days":     "nats.stream_retention_days",
+		"nats_batch_size":         "nats.batch_size",
+		"nats_flush_interval":     "nats.flush_interval",
+		"nats_subscribers":        "nats.subscribers_count",
+		"nats_durable_name":       "nats.durable_name",
+		"nats_queue_group":        "nats.queue_group",
+		"nats_topic_partitioning": "nats.topic_partitioning",
 		// Router configuration environment mappings
 		"nats_router_retry_count":    "nats.router_retry_count",
 		"nats_router_retry_interval": "nats.router_retry_initial_interval",
@@ -482,24 +570,50 @@ func envTransformFunc(key string) string {
 		"duckdb_max_memory": "database.max_memory",
 		"seed_mock_data":    "database.seed_mock_data",
 
+		"db_slow_query_threshold": "database.slow_query_threshold",
+		"db_slow_query_log_size":  "database.slow_query_log_size",
+		"db_slow_query_explain":   "database.slow_query_explain",
+		"db_slow_query_redact":    "database.slow_query_redact",
+		"db_metrics_interval":     "database.metrics_interval",
+
+		"db_max_open_conns":           "database.max_open_conns",
+		"db_max_idle_conns":           "database.max_idle_conns",
+		"db_conn_max_lifetime":        "database.conn_max_lifetime",
+		"db_health_check_interval":    "database.health_check_interval",
+		"db_max_concurrent_analytics": "database.max_concurrent_analytics",
+		"db_tile_warm_enabled":        "database.tile_warm_enabled",
+		"db_tile_warm_max_zoom":       "database.tile_warm_max_zoom",
+
 		// Sync mappings
-		"sync_interval":       "sync.interval",
-		"sync_lookback":       "sync.lookback",
-		"sync_batch_size":     "sync.batch_size",
-		"sync_retry_attempts": "sync.retry_attempts",
-		"sync_retry_delay":    "sync.retry_delay",
+		"sync_interval":                 "sync.interval",
+		"sync_lookback":                 "sync.lookback",
+		"sync_batch_size":               "sync.batch_size",
+		"sync_retry_attempts":           "sync.retry_attempts",
+		"sync_retry_delay":              "sync.retry_delay",
+		"sync_timestamp_min_date":       "sync.timestamp_min_date",
+		"sync_timestamp_max_future":     "sync.timestamp_max_future",
+		"sync_session_stitch_gap":       "sync.session_stitch_gap",
+		"sync_watch_party_start_window": "sync.watch_party_start_window",
+		"sync_watch_party_min_duration": "sync.watch_party_min_duration",
 
 		// Server mappings
-		"http_port":        "server.port",
-		"http_host":        "server.host",
-		"http_timeout":     "server.timeout",
-		"server_latitude":  "server.latitude",
-		"server_longitude": "server.longitude",
-		"environment":      "server.environment", // M-02: Environment mode for security validation
+		"http_port":             "server.port",
+		"http_host":             "server.host",
+		"http_timeout":          "server.timeout",
+		"http_max_body_size":    "server.max_body_size",
+		"http_request_timeout":  "server.request_timeout",
+		"http_shutdown_timeout": "server.shutdown_timeout",
+		"server_latitude":       "server.latitude",
+		"server_longitude":      "server.longitude",
+		"environment":           "server.environment", // M-02: Environment mode for security validation
+		"diagnostics_enabled":   "server.diagnostics_enabled",
+		"enable_pprof":          "server.pprof_enabled",
 
 		// API mappings
 		"api_default_page_size": "api.default_page_size",
 		"api_max_page_size":     "api.max_page_size",
+		"api_cache_ttl":         "api.cache_ttl",
+		"api_query_timeout":     "api.query_timeout",
 
 		// Security mappings
 		"auth_mode":           "security.auth_mode",
@@ -556,17 +670,27 @@ func envTransformFunc(key string) string {
 		"log_format": "logging.format",
 		"log_caller": "logging.caller",
 
+		"log_sample_first":      "logging.sample_first",
+		"log_sample_thereafter": "logging.sample_thereafter",
+
+		"log_access_sample_rate":    "logging.access_sample_rate",
+		"log_access_slow_threshold": "logging.access_slow_threshold",
+
 		// Recommendation engine mappings (ADR-0024)
-		"recommend_enabled":             "recommend.enabled",
-		"recommend_train_interval":      "recommend.train_interval",
-		"recommend_train_on_startup":    "recommend.train_on_startup",
-		"recommend_min_interactions":    "recommend.min_interactions",
-		"recommend_model_path":          "recommend.model_path",
-		"recommend_algorithms":          "recommend.algorithms",
-		"recommend_cache_ttl":           "recommend.cache_ttl",
-		"recommend_max_candidates":      "recommend.max_candidates",
-		"recommend_diversity_lambda":    "recommend.diversity_lambda",
-		"recommend_calibration_enabled": "recommend.calibration_enabled",
+		"recommend_enabled":                    "recommend.enabled",
+		"recommend_train_interval":             "recommend.train_interval",
+		"recommend_train_on_startup":           "recommend.train_on_startup",
+		"recommend_min_interactions":           "recommend.min_interactions",
+		"recommend_model_path":                 "recommend.model_path",
+		"recommend_model_retain_versions":      "recommend.model_retain_versions",
+		"recommend_train_parallelism":          "recommend.train_parallelism",
+		"recommend_train_min_new_interactions": "recommend.train_min_new_interactions",
+		"recommend_train_max_pool_utilization": "recommend.train_max_pool_utilization",
+		"recommend_algorithms":                 "recommend.algorithms",
+		"recommend_cache_ttl":                  "recommend.cache_ttl",
+		"recommend_max_candidates":             "recommend.max_candidates",
+		"recommend_diversity_lambda":           "recommend.diversity_lambda",
+		"recommend_calibration_enabled":        "recommend.calibration_enabled",
 		// EASE algorithm settings
 		"recommend_ease_regularization": "recommend.ease.l2_regularization",
 		"recommend_ease_min_confidence": "recommend.ease.min_confidence",
@@ -598,6 +722,46 @@ func envTransformFunc(key string) string {
 		"newsletter_check_interval": "newsletter.check_interval",
 		"newsletter_max_concurrent": "newsletter.max_concurrent",
 		"newsletter_exec_timeout":   "newsletter.execution_timeout",
+		"newsletter_smtp_host":      "newsletter.smtp.host",
+		"newsletter_smtp_port":      "newsletter.smtp.port",
+		"newsletter_smtp_username":  "newsletter.smtp.username",
+		"newsletter_smtp_password":  "newsletter.smtp.password",
+		"newsletter_smtp_from":      "newsletter.smtp.from",
+		"newsletter_smtp_from_name": "newsletter.smtp.from_name",
+		"newsletter_smtp_tls_mode":  "newsletter.smtp.tls_mode",
+
+		// Backup schedule mappings
+		"backup_schedule_enabled": "backup.schedule_enabled",
+		"backup_interval":         "backup.interval",
+		"backup_preferred_hour":   "backup.preferred_hour",
+		"backup_type":             "backup.type",
+
+		// Retention mappings
+		"retention_enabled":                       "retention.enabled",
+		"retention_playback_max_age_days":         "retention.playback_max_age_days",
+		"retention_playback_max_rows":             "retention.playback_max_rows",
+		"retention_detection_alerts_max_age_days": "retention.detection_alerts_max_age_days",
+		"retention_detection_alerts_max_rows":     "retention.detection_alerts_max_rows",
+		"retention_newsletter_log_max_age_days":   "retention.newsletter_log_max_age_days",
+		"retention_newsletter_log_max_rows":       "retention.newsletter_log_max_rows",
+		"retention_window_start_hour":             "retention.window_start_hour",
+		"retention_window_end_hour":               "retention.window_end_hour",
+		"retention_batch_size":                    "retention.batch_size",
+		"retention_archive_dir":                   "retention.archive_dir",
+
+		// Privacy mappings
+		"privacy_ip_mode":     "privacy.ip_mode",
+		"privacy_ip_hash_key": "privacy.ip_hash_key",
+
+		// VPN detection mappings
+		"vpn_enabled":         "vpn.enabled",
+		"vpn_data_file":       "vpn.data_file",
+		"vpn_cache_size":      "vpn.cache_size",
+		"vpn_auto_update":     "vpn.auto_update",
+		"vpn_update_interval": "vpn.update_interval",
+		"vpn_update_url":      "vpn.update_url",
+		"vpn_datacenter_file": "vpn.datacenter_file",
+		"vpn_datacenter_url":  "vpn.datacenter_url",
 	}
 
 	if mapped, ok := envMappings[key]; ok {
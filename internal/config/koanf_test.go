@@ -83,6 +83,17 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.API.MaxPageSize != 100 {
 		t.Errorf("API.MaxPageSize = %d, want 100", cfg.API.MaxPageSize)
 	}
+	if cfg.API.QueryTimeout != DefaultQueryTimeout {
+		t.Errorf("API.QueryTimeout = %v, want %v", cfg.API.QueryTimeout, DefaultQueryTimeout)
+	}
+
+	// Database pool defaults
+	if cfg.Database.MaxIdleConns != 2 || cfg.Database.ConnMaxLifetime != time.Hour {
+		t.Errorf("Database pool = %d idle, %v lifetime, want 2 and 1h", cfg.Database.MaxIdleConns, cfg.Database.ConnMaxLifetime)
+	}
+	if cfg.Database.HealthCheckInterval != 30*time.Second {
+		t.Errorf("Database.HealthCheckInterval = %v, want 30s", cfg.Database.HealthCheckInterval)
+	}
 
 	// Security defaults
 	if cfg.Security.AuthMode != "jwt" {
@@ -110,6 +121,8 @@ func TestEnvTransformFunc(t *testing.T) {
 		// Tautulli
 		{"TAUTULLI_URL", "tautulli.url"},
 		{"TAUTULLI_API_KEY", "tautulli.api_key"},
+		{"TAUTULLI_COMPAT_API", "tautulli.compat_api"},
+		{"TAUTULLI_COMPAT_API_KEY", "tautulli.compat_api_key"},
 
 		// Plex
 		{"ENABLE_PLEX_SYNC", "plex.enabled"},
@@ -130,12 +143,45 @@ func TestEnvTransformFunc(t *testing.T) {
 		{"DUCKDB_PATH", "database.path"},
 		{"DUCKDB_MAX_MEMORY", "database.max_memory"},
 		{"SEED_MOCK_DATA", "database.seed_mock_data"},
+		{"DB_MAX_OPEN_CONNS", "database.max_open_conns"},
+		{"DB_HEALTH_CHECK_INTERVAL", "database.health_check_interval"},
+		{"DB_MAX_CONCURRENT_ANALYTICS", "database.max_concurrent_analytics"},
+		{"DB_TILE_WARM_ENABLED", "database.tile_warm_enabled"},
+		{"DB_TILE_WARM_MAX_ZOOM", "database.tile_warm_max_zoom"},
+
+		// Sync
+		{"SYNC_SESSION_STITCH_GAP", "sync.session_stitch_gap"},
+		{"SYNC_WATCH_PARTY_START_WINDOW", "sync.watch_party_start_window"},
+		{"SYNC_WATCH_PARTY_MIN_DURATION", "sync.watch_party_min_duration"},
+
+		// Retention
+		{"RETENTION_ENABLED", "retention.enabled"},
+		{"RETENTION_PLAYBACK_MAX_AGE_DAYS", "retention.playback_max_age_days"},
+		{"RETENTION_NEWSLETTER_LOG_MAX_ROWS", "retention.newsletter_log_max_rows"},
+		{"RETENTION_ARCHIVE_DIR", "retention.archive_dir"},
+
+		// Privacy
+		{"PRIVACY_IP_MODE", "privacy.ip_mode"},
+		{"PRIVACY_IP_HASH_KEY", "privacy.ip_hash_key"},
+
+		// VPN
+		{"VPN_ENABLED", "vpn.enabled"},
+		{"VPN_AUTO_UPDATE", "vpn.auto_update"},
+		{"VPN_UPDATE_INTERVAL", "vpn.update_interval"},
+		{"VPN_UPDATE_URL", "vpn.update_url"},
+		{"VPN_DATACENTER_FILE", "vpn.datacenter_file"},
+		{"VPN_DATACENTER_URL", "vpn.datacenter_url"},
 
 		// Server
 		{"HTTP_PORT", "server.port"},
 		{"HTTP_HOST", "server.host"},
 		{"HTTP_TIMEOUT", "server.timeout"},
+		{"HTTP_REQUEST_TIMEOUT", "server.request_timeout"},
+		{"HTTP_SHUTDOWN_TIMEOUT", "server.shutdown_timeout"},
+		{"API_QUERY_TIMEOUT", "api.query_timeout"},
 		{"SERVER_LATITUDE", "server.latitude"},
+		{"DIAGNOSTICS_ENABLED", "server.diagnostics_enabled"},
+		{"ENABLE_PPROF", "server.pprof_enabled"},
 
 		// Security
 		{"AUTH_MODE", "security.auth_mode"},
@@ -0,0 +1,206 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+)
+
+// SetupAuthModes are the authentication modes the setup wizard can
+// configure. OIDC and Plex OAuth need provider registration and are
+// configured in config.yaml or the environment instead.
+var SetupAuthModes = []string{"jwt", "basic", "none"}
+
+// SetupMediaServer is the media server connection chosen in the setup
+// wizard. Token is the Plex token or the Jellyfin/Emby API key.
+type SetupMediaServer struct {
+	Platform string `json:"platform"` // plex, jellyfin or emby
+	URL      string `json:"url"`
+	Token    string `json:"token"`
+}
+
+// SetupAuth is the authentication mode and admin account chosen in the
+// setup wizard. The credentials are ignored when Mode is none.
+type SetupAuth struct {
+	Mode          string `json:"auth_mode"`
+	AdminUsername string `json:"admin_username"`
+	AdminPassword string `json:"admin_password"`
+}
+
+// SetupRequired reports whether the server should start in setup mode: no
+// media server is configured and no auth mode was chosen, neither in the
+// environment (AUTH_MODE) nor in the config file at path. cfg is the
+// unvalidated configuration loaded from the same file.
+func SetupRequired(cfg *Config, path string) (bool, error) {
+	if cfg.HasAnyMediaServer() {
+		return false, nil
+	}
+	if _, ok := os.LookupEnv("AUTH_MODE"); ok {
+		return false, nil
+	}
+	if path == "" {
+		return true, nil
+	}
+
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
+		return false, fmt.Errorf("failed to load config file %s: %w", path, err)
+	}
+	return !k.Exists("security.auth_mode"), nil
+}
+
+// SetupConfigPath returns the file the setup wizard writes: the config file
+// that was loaded, else CONFIG_PATH, else config.yaml in the working
+// directory.
+func SetupConfigPath(loaded string) string {
+	if loaded != "" {
+		return loaded
+	}
+	if envPath := os.Getenv(ConfigPathEnvVar); envPath != "" {
+		return envPath
+	}
+	return DefaultConfigPaths[0]
+}
+
+// Validate checks the media server settings.
+func (s *SetupMediaServer) Validate() error {
+	switch s.Platform {
+	case "plex", "jellyfin", "emby":
+	default:
+		return fmt.Errorf("platform must be one of: plex, jellyfin, emby")
+	}
+	if err := validateHTTPURL(s.URL, "url"); err != nil {
+		return err
+	}
+	if strings.TrimSpace(s.Token) == "" {
+		return fmt.Errorf("token is required")
+	}
+	return nil
+}
+
+// Validate checks the auth mode and admin credentials against the same
+// rules, including the password policy, that startup validation applies.
+func (a *SetupAuth) Validate(environment string) error {
+	c := &Config{
+		Server: ServerConfig{Environment: environment},
+		Security: SecurityConfig{
+			AuthMode:      a.Mode,
+			AdminUsername: a.AdminUsername,
+			AdminPassword: a.AdminPassword,
+		},
+	}
+	switch a.Mode {
+	case "none":
+		return c.validateAuthModeForEnvironment()
+	case "jwt", "basic":
+		return c.validateAdminCredentials(a.Mode)
+	default:
+		return fmt.Errorf("auth_mode must be one of: %s", strings.Join(SetupAuthModes, ", "))
+	}
+}
+
+// WriteSetupConfig saves the settings chosen in the setup wizard to the
+// config file at path, keeping any settings the file already holds. A JWT
+// secret is generated for jwt mode unless one is already configured. The
+// file is replaced atomically and readable only by its owner, since it
+// holds the media server token and admin password.
+func WriteSetupConfig(path string, server *SetupMediaServer, auth *SetupAuth) error {
+	k := koanf.New(".")
+	if _, err := os.Stat(path); err == nil {
+		if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
+			return fmt.Errorf("failed to load config file %s: %w", path, err)
+		}
+	}
+
+	values := map[string]interface{}{
+		"security.auth_mode": auth.Mode,
+	}
+	switch server.Platform {
+	case "plex":
+		values["plex.enabled"] = true
+		values["plex.url"] = server.URL
+		values["plex.token"] = server.Token
+	case "jellyfin":
+		values["jellyfin.enabled"] = true
+		values["jellyfin.url"] = server.URL
+		values["jellyfin.api_key"] = server.Token
+	case "emby":
+		values["emby.enabled"] = true
+		values["emby.url"] = server.URL
+		values["emby.api_key"] = server.Token
+	}
+	if auth.Mode != "none" {
+		values["security.admin_username"] = auth.AdminUsername
+		values["security.admin_password"] = auth.AdminPassword
+	}
+	if auth.Mode == "jwt" && k.String("security.jwt_secret") == "" && os.Getenv("JWT_SECRET") == "" {
+		secret, err := generateJWTSecret()
+		if err != nil {
+			return err
+		}
+		values["security.jwt_secret"] = secret
+	}
+	for key, value := range values {
+		if err := k.Set(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+
+	data, err := k.Marshal(yaml.Parser())
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	return writeFileAtomic(path, data, 0o600)
+}
+
+// generateJWTSecret returns a random 256-bit secret, base64 encoded.
+func generateJWTSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate JWT secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so a crash never leaves a truncated config file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create config file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // No-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to save config file %s: %w", path, err)
+	}
+	return nil
+}
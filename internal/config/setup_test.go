@@ -0,0 +1,132 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetupRequired(t *testing.T) {
+	dir := t.TempDir()
+	withAuth := filepath.Join(dir, "with-auth.yaml")
+	if err := os.WriteFile(withAuth, []byte("security:\n  auth_mode: basic\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	withoutAuth := filepath.Join(dir, "without-auth.yaml")
+	if err := os.WriteFile(withoutAuth, []byte("server:\n  port: 3857\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	withPlex := &Config{Plex: PlexConfig{Enabled: true, URL: "http://plex:32400", Token: "token"}}
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		path    string
+		envAuth bool
+		want    bool
+	}{
+		{name: "fresh install", cfg: &Config{}, want: true},
+		{name: "file without auth mode", cfg: &Config{}, path: withoutAuth, want: true},
+		{name: "file with auth mode", cfg: &Config{}, path: withAuth, want: false},
+		{name: "AUTH_MODE set", cfg: &Config{}, envAuth: true, want: false},
+		{name: "media server configured", cfg: withPlex, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.envAuth {
+				t.Setenv("AUTH_MODE", "none")
+			} else {
+				t.Setenv("AUTH_MODE", "") // Restored after the test
+				os.Unsetenv("AUTH_MODE")
+			}
+			got, err := SetupRequired(tt.cfg, tt.path)
+			if err != nil {
+				t.Fatalf("SetupRequired() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SetupRequired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupAuth_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		auth        SetupAuth
+		environment string
+		wantErr     bool
+	}{
+		{name: "jwt", auth: SetupAuth{Mode: "jwt", AdminUsername: "admin", AdminPassword: "Str0ng!Passw0rd#2026"}},
+		{name: "weak password", auth: SetupAuth{Mode: "basic", AdminUsername: "admin", AdminPassword: "password"}, wantErr: true},
+		{name: "missing username", auth: SetupAuth{Mode: "jwt", AdminPassword: "Str0ng!Passw0rd#2026"}, wantErr: true},
+		{name: "none in development", auth: SetupAuth{Mode: "none"}, environment: "development"},
+		{name: "none in production", auth: SetupAuth{Mode: "none"}, environment: "production", wantErr: true},
+		{name: "oidc not offered", auth: SetupAuth{Mode: "oidc"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.auth.Validate(tt.environment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteSetupConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  port: 4000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ConfigPathEnvVar, path)
+	// Other tests in this package leave these set; the file must win
+	for _, key := range []string{"JWT_SECRET", "AUTH_MODE", "HTTP_PORT"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	server := &SetupMediaServer{Platform: "jellyfin", URL: "http://jellyfin:8096", Token: "jellyfin-api-key-0123456789"}
+	auth := &SetupAuth{Mode: "jwt", AdminUsername: "admin", AdminPassword: "Str0ng!Passw0rd#2026"}
+	if err := WriteSetupConfig(path, server, auth); err != nil {
+		t.Fatalf("WriteSetupConfig() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("config file mode = %o, want 600", perm)
+	}
+
+	// The written file loads and validates as a complete configuration,
+	// keeping the settings it held before
+	cfg, err := LoadWithKoanf()
+	if err != nil {
+		t.Fatalf("LoadWithKoanf() error = %v", err)
+	}
+	if cfg.Server.Port != 4000 {
+		t.Errorf("server.port = %d, want existing 4000", cfg.Server.Port)
+	}
+	if !cfg.Jellyfin.Enabled || cfg.Jellyfin.URL != server.URL || cfg.Jellyfin.APIKey != server.Token {
+		t.Errorf("jellyfin = %+v", cfg.Jellyfin)
+	}
+	if cfg.Security.AuthMode != "jwt" || cfg.Security.AdminUsername != "admin" {
+		t.Errorf("security = %q/%q", cfg.Security.AuthMode, cfg.Security.AdminUsername)
+	}
+	if len(cfg.Security.JWTSecret) < 32 {
+		t.Errorf("jwt_secret not generated: %q", cfg.Security.JWTSecret)
+	}
+
+	required, err := SetupRequired(cfg, path)
+	if err != nil || required {
+		t.Errorf("SetupRequired() after setup = %v, %v; want false", required, err)
+	}
+}
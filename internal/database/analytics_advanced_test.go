@@ -327,14 +327,16 @@ func TestGetWatchParties_DetectsSimultaneousViewing(t *testing.T) {
 	defer func() { _ = db.Close() }()
 	insertTestGeolocations(t, db)
 
-	now := time.Now()
+	start := time.Now().Add(-3 * time.Hour)
+	stop := start.Add(3 * time.Hour)
 	title := "Avengers: Endgame"
 
 	for i := 1; i <= 3; i++ {
 		event := &models.PlaybackEvent{
 			ID:              uuid.New(),
 			SessionKey:      uuid.New().String(),
-			StartedAt:       now,
+			StartedAt:       start,
+			StoppedAt:       &stop,
 			UserID:          i,
 			Username:        "user",
 			IPAddress:       "192.168.1.1",
@@ -346,6 +348,7 @@ func TestGetWatchParties_DetectsSimultaneousViewing(t *testing.T) {
 			t.Fatalf("Failed to insert: %v", err)
 		}
 	}
+	detectTestWatchParties(t, db)
 
 	analytics, err := db.GetWatchParties(context.Background(), LocationStatsFilter{})
 	if err != nil {
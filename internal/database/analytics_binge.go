@@ -74,7 +74,7 @@ func buildBingeWhereClause(filter LocationStatsFilter) (string, []interface{}) {
 }
 
 // queryBingeSessions retrieves all binge sessions with totals for statistics calculation
-func (db *DB) queryBingeSessions(ctx context.Context, whereClause string, args []interface{}) ([]models.BingeSession, int, int, error) {
+func (db *DB) queryBingeSessions(ctx context.Context, source, whereClause string, args []interface{}) ([]models.BingeSession, int, int, error) {
 	query := fmt.Sprintf(`
 		WITH episode_sessions AS (
 			SELECT
@@ -88,7 +88,7 @@ func (db *DB) queryBingeSessions(ctx context.Context, whereClause string, args [
 					PARTITION BY user_id, grandparent_title
 					ORDER BY started_at
 				) as prev_started_at
-			FROM playback_events
+			FROM %s
 			WHERE %s
 				AND grandparent_title IS NOT NULL
 				AND grandparent_title != ''
@@ -138,7 +138,7 @@ func (db *DB) queryBingeSessions(ctx context.Context, whereClause string, args [
 			avg_completion
 		FROM binge_sessions
 		ORDER BY first_episode_time DESC
-	`, whereClause)
+	`, source, whereClause)
 
 	rows, err := db.conn.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -177,7 +177,7 @@ func (db *DB) queryBingeSessions(ctx context.Context, whereClause string, args [
 }
 
 // queryTopBingeShows retrieves shows with the most binge sessions
-func (db *DB) queryTopBingeShows(ctx context.Context, whereClause string, args []interface{}) ([]models.BingeShowStats, error) {
+func (db *DB) queryTopBingeShows(ctx context.Context, source, whereClause string, args []interface{}) ([]models.BingeShowStats, error) {
 	query := fmt.Sprintf(`
 		WITH episode_sessions AS (
 			SELECT
@@ -188,7 +188,7 @@ func (db *DB) queryTopBingeShows(ctx context.Context, whereClause string, args [
 					PARTITION BY user_id, grandparent_title
 					ORDER BY started_at
 				) as prev_started_at
-			FROM playback_events
+			FROM %s
 			WHERE %s
 				AND grandparent_title IS NOT NULL
 				AND grandparent_title != ''
@@ -232,7 +232,7 @@ func (db *DB) queryTopBingeShows(ctx context.Context, whereClause string, args [
 		GROUP BY show_name
 		ORDER BY binge_count DESC
 		LIMIT 10
-	`, whereClause)
+	`, source, whereClause)
 
 	rows, err := db.conn.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -263,7 +263,7 @@ func (db *DB) queryTopBingeShows(ctx context.Context, whereClause string, args [
 }
 
 // queryTopBingeWatchers retrieves users with the most binge sessions
-func (db *DB) queryTopBingeWatchers(ctx context.Context, whereClause string, args []interface{}) ([]models.BingeUserStats, error) {
+func (db *DB) queryTopBingeWatchers(ctx context.Context, source, whereClause string, args []interface{}) ([]models.BingeUserStats, error) {
 	query := fmt.Sprintf(`
 		WITH episode_sessions AS (
 			SELECT
@@ -275,7 +275,7 @@ func (db *DB) queryTopBingeWatchers(ctx context.Context, whereClause string, arg
 					PARTITION BY user_id, grandparent_title
 					ORDER BY started_at
 				) as prev_started_at
-			FROM playback_events
+			FROM %s
 			WHERE %s
 				AND grandparent_title IS NOT NULL
 				AND grandparent_title != ''
@@ -331,7 +331,7 @@ func (db *DB) queryTopBingeWatchers(ctx context.Context, whereClause string, arg
 		GROUP BY bs.user_id, bs.username
 		ORDER BY binge_count DESC
 		LIMIT 10
-	`, whereClause)
+	`, source, whereClause)
 
 	rows, err := db.conn.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -363,7 +363,7 @@ func (db *DB) queryTopBingeWatchers(ctx context.Context, whereClause string, arg
 }
 
 // queryBingesByDay retrieves binge session distribution by day of week
-func (db *DB) queryBingesByDay(ctx context.Context, whereClause string, args []interface{}) ([]models.BingesByDayOfWeek, error) {
+func (db *DB) queryBingesByDay(ctx context.Context, source, whereClause string, args []interface{}) ([]models.BingesByDayOfWeek, error) {
 	query := fmt.Sprintf(`
 		WITH episode_sessions AS (
 			SELECT
@@ -374,7 +374,7 @@ func (db *DB) queryBingesByDay(ctx context.Context, whereClause string, args []i
 					PARTITION BY user_id, grandparent_title
 					ORDER BY started_at
 				) as prev_started_at
-			FROM playback_events
+			FROM %s
 			WHERE %s
 				AND grandparent_title IS NOT NULL
 				AND grandparent_title != ''
@@ -413,7 +413,7 @@ func (db *DB) queryBingesByDay(ctx context.Context, whereClause string, args []i
 		FROM binge_sessions
 		GROUP BY day_of_week
 		ORDER BY day_of_week
-	`, whereClause)
+	`, source, whereClause)
 
 	rows, err := db.conn.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -488,9 +488,10 @@ func (db *DB) GetBingeAnalytics(ctx context.Context, filter LocationStatsFilter)
 
 	// Build WHERE clause once for all queries
 	whereClause, args := buildBingeWhereClause(filter)
+	source := playbackEventsSource(filter)
 
 	// Query all binge sessions with totals
-	bingeSessions, totalEpisodes, totalDuration, err := db.queryBingeSessions(ctx, whereClause, args)
+	bingeSessions, totalEpisodes, totalDuration, err := db.queryBingeSessions(ctx, source, whereClause, args)
 	if err != nil {
 		return nil, err
 	}
@@ -528,21 +529,21 @@ func (db *DB) GetBingeAnalytics(ctx context.Context, filter LocationStatsFilter)
 	analytics.RecentBingeSessions = bingeSessions[:recentCount]
 
 	// Query top binge shows
-	topShows, err := db.queryTopBingeShows(ctx, whereClause, args)
+	topShows, err := db.queryTopBingeShows(ctx, source, whereClause, args)
 	if err != nil {
 		return nil, err
 	}
 	analytics.TopBingeShows = topShows
 
 	// Query top binge watchers
-	topWatchers, err := db.queryTopBingeWatchers(ctx, whereClause, args)
+	topWatchers, err := db.queryTopBingeWatchers(ctx, source, whereClause, args)
 	if err != nil {
 		return nil, err
 	}
 	analytics.TopBingeWatchers = topWatchers
 
 	// Query binges by day
-	bingesByDay, err := db.queryBingesByDay(ctx, whereClause, args)
+	bingesByDay, err := db.queryBingesByDay(ctx, source, whereClause, args)
 	if err != nil {
 		return nil, err
 	}
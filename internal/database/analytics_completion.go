@@ -0,0 +1,113 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// completionQuartiles are the buckets of GetCompletionDistribution, indexed
+// by the bucket number computed in SQL. The last bucket includes 100%.
+var completionQuartiles = []models.DropOffBucket{
+	{Bucket: "0-25%", MinPercent: 0, MaxPercent: 25},
+	{Bucket: "25-50%", MinPercent: 25, MaxPercent: 50},
+	{Bucket: "50-75%", MinPercent: 50, MaxPercent: 75},
+	{Bucket: "75-100%", MinPercent: 75, MaxPercent: 100},
+}
+
+// GetCompletionDistribution buckets plays by percent_complete into
+// quartiles, overall and per media type, showing what share of plays is
+// finished versus abandoned early. Plays without a completion value are
+// left out; values outside 0-100 fall into the first or last bucket.
+func (db *DB) GetCompletionDistribution(ctx context.Context, filter LocationStatsFilter) (*models.CompletionDistribution, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	whereClauses, args := buildFilterConditions(filter, false, 1)
+	whereClauses = append(whereClauses, "percent_complete IS NOT NULL")
+
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(NULLIF(media_type, ''), 'unknown') AS media_type,
+			CASE
+				WHEN percent_complete >= 75 THEN 3
+				WHEN percent_complete >= 50 THEN 2
+				WHEN percent_complete >= 25 THEN 1
+				ELSE 0
+			END AS bucket,
+			COUNT(*) AS playback_count
+		FROM playback_events
+		WHERE %s
+		GROUP BY 1, 2
+	`, join(whereClauses, " AND "))
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query completion distribution: %w", err)
+	}
+	defer rows.Close()
+
+	result := &models.CompletionDistribution{
+		Buckets:     newCompletionBuckets(),
+		ByMediaType: []models.MediaTypeCompletionBuckets{},
+	}
+	byMediaType := make(map[string]*models.MediaTypeCompletionBuckets)
+
+	for rows.Next() {
+		var mediaType string
+		var bucket, count int
+		if err := rows.Scan(&mediaType, &bucket, &count); err != nil {
+			return nil, fmt.Errorf("scan completion bucket: %w", err)
+		}
+
+		dist, ok := byMediaType[mediaType]
+		if !ok {
+			dist = &models.MediaTypeCompletionBuckets{MediaType: mediaType, Buckets: newCompletionBuckets()}
+			byMediaType[mediaType] = dist
+		}
+		dist.Buckets[bucket].PlaybackCount += count
+		dist.TotalPlaybacks += count
+		result.Buckets[bucket].PlaybackCount += count
+		result.TotalPlaybacks += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate completion buckets: %w", err)
+	}
+
+	setBucketPercentages(result.Buckets, result.TotalPlaybacks)
+	for _, dist := range byMediaType {
+		setBucketPercentages(dist.Buckets, dist.TotalPlaybacks)
+		result.ByMediaType = append(result.ByMediaType, *dist)
+	}
+	sort.Slice(result.ByMediaType, func(i, j int) bool {
+		a, b := result.ByMediaType[i], result.ByMediaType[j]
+		if a.TotalPlaybacks != b.TotalPlaybacks {
+			return a.TotalPlaybacks > b.TotalPlaybacks
+		}
+		return a.MediaType < b.MediaType
+	})
+
+	return result, nil
+}
+
+// newCompletionBuckets returns an empty copy of completionQuartiles
+func newCompletionBuckets() []models.DropOffBucket {
+	return append([]models.DropOffBucket(nil), completionQuartiles...)
+}
+
+// setBucketPercentages fills in each bucket's share of total
+func setBucketPercentages(buckets []models.DropOffBucket, total int) {
+	if total == 0 {
+		return
+	}
+	for i := range buckets {
+		buckets[i].PercentageOfTotal = float64(buckets[i].PlaybackCount) / float64(total) * 100.0
+	}
+}
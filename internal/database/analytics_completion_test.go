@@ -0,0 +1,161 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// insertCompletionPlayback inserts one playback with the given completion;
+// a nil percent leaves percent_complete NULL
+func insertCompletionPlayback(t *testing.T, db *DB, username, mediaType string, percent *int) {
+	t.Helper()
+
+	startedAt := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	_, err := db.conn.Exec(`
+		INSERT INTO playback_events (
+			id, session_key, started_at, stopped_at, user_id, username,
+			ip_address, media_type, title, percent_complete
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, uuid.New().String(), uuid.New().String(), startedAt, startedAt.Add(time.Hour),
+		1, username, "192.168.1.1", mediaType, "Test Title", percent)
+	if err != nil {
+		t.Fatalf("Failed to insert completion playback: %v", err)
+	}
+}
+
+// insertCompletionTestData seeds plays across all completion levels:
+//
+//	movie:   0, 10, 24 | 25, 49 | 50 | 75, 99, 100, 100   (10 plays)
+//	episode: 30 | 60, 74 | 80                              (4 plays)
+//	track:   NULL (ignored)
+func insertCompletionTestData(t *testing.T, db *DB) {
+	t.Helper()
+
+	seed := map[string][]int{
+		"movie":   {0, 10, 24, 25, 49, 50, 75, 99, 100, 100},
+		"episode": {30, 60, 74, 80},
+	}
+	for mediaType, percents := range seed {
+		for _, p := range percents {
+			percent := p
+			insertCompletionPlayback(t, db, "alice", mediaType, &percent)
+		}
+	}
+	insertCompletionPlayback(t, db, "alice", "track", nil)
+}
+
+func TestGetCompletionDistribution(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	insertCompletionTestData(t, db)
+
+	dist, err := db.GetCompletionDistribution(context.Background(), LocationStatsFilter{})
+	if err != nil {
+		t.Fatalf("GetCompletionDistribution() error = %v", err)
+	}
+
+	if dist.TotalPlaybacks != 14 {
+		t.Errorf("TotalPlaybacks = %d, want 14", dist.TotalPlaybacks)
+	}
+	assertCompletionBuckets(t, "overall", dist.Buckets, []int{3, 3, 3, 5}, 14)
+
+	if len(dist.ByMediaType) != 2 {
+		t.Fatalf("got %d media types, want 2: %+v", len(dist.ByMediaType), dist.ByMediaType)
+	}
+	movie, episode := dist.ByMediaType[0], dist.ByMediaType[1]
+	if movie.MediaType != "movie" || movie.TotalPlaybacks != 10 {
+		t.Errorf("first media type = %s (%d plays), want movie (10)", movie.MediaType, movie.TotalPlaybacks)
+	}
+	if episode.MediaType != "episode" || episode.TotalPlaybacks != 4 {
+		t.Errorf("second media type = %s (%d plays), want episode (4)", episode.MediaType, episode.TotalPlaybacks)
+	}
+	assertCompletionBuckets(t, "movie", movie.Buckets, []int{3, 2, 1, 4}, 10)
+	assertCompletionBuckets(t, "episode", episode.Buckets, []int{0, 1, 2, 1}, 4)
+}
+
+func TestGetCompletionDistribution_Filter(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	insertCompletionTestData(t, db)
+	percent := 90
+	insertCompletionPlayback(t, db, "bob", "episode", &percent)
+
+	dist, err := db.GetCompletionDistribution(context.Background(), LocationStatsFilter{
+		Users:      []string{"bob"},
+		MediaTypes: []string{"episode"},
+	})
+	if err != nil {
+		t.Fatalf("GetCompletionDistribution() error = %v", err)
+	}
+
+	assertCompletionBuckets(t, "bob", dist.Buckets, []int{0, 0, 0, 1}, 1)
+	if len(dist.ByMediaType) != 1 || dist.ByMediaType[0].MediaType != "episode" {
+		t.Errorf("ByMediaType = %+v, want episode only", dist.ByMediaType)
+	}
+}
+
+func TestGetCompletionDistribution_OutOfRange(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	for _, p := range []int{-5, 120} {
+		percent := p
+		insertCompletionPlayback(t, db, "alice", "movie", &percent)
+	}
+
+	dist, err := db.GetCompletionDistribution(context.Background(), LocationStatsFilter{})
+	if err != nil {
+		t.Fatalf("GetCompletionDistribution() error = %v", err)
+	}
+	assertCompletionBuckets(t, "clamped", dist.Buckets, []int{1, 0, 0, 1}, 2)
+}
+
+func TestGetCompletionDistribution_Empty(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	dist, err := db.GetCompletionDistribution(context.Background(), LocationStatsFilter{})
+	if err != nil {
+		t.Fatalf("GetCompletionDistribution() error = %v", err)
+	}
+	if dist.TotalPlaybacks != 0 || dist.ByMediaType == nil || len(dist.ByMediaType) != 0 {
+		t.Errorf("dist = %+v, want no plays and an empty media type list", dist)
+	}
+	assertCompletionBuckets(t, "empty", dist.Buckets, []int{0, 0, 0, 0}, 0)
+}
+
+// assertCompletionBuckets checks the four quartile buckets in order, their
+// counts, and their share of total
+func assertCompletionBuckets(t *testing.T, name string, buckets []models.DropOffBucket, wantCounts []int, total int) {
+	t.Helper()
+
+	wantNames := []string{"0-25%", "25-50%", "50-75%", "75-100%"}
+	if len(buckets) != len(wantNames) {
+		t.Fatalf("%s: got %d buckets, want %d", name, len(buckets), len(wantNames))
+	}
+	for i, b := range buckets {
+		if b.Bucket != wantNames[i] || b.MinPercent != 25*i || b.MaxPercent != 25*(i+1) {
+			t.Errorf("%s: bucket %d = %s [%d, %d]", name, i, b.Bucket, b.MinPercent, b.MaxPercent)
+		}
+		if b.PlaybackCount != wantCounts[i] {
+			t.Errorf("%s: bucket %s count = %d, want %d", name, b.Bucket, b.PlaybackCount, wantCounts[i])
+		}
+		wantPct := 0.0
+		if total > 0 {
+			wantPct = float64(wantCounts[i]) / float64(total) * 100
+		}
+		if math.Abs(b.PercentageOfTotal-wantPct) > 0.001 {
+			t.Errorf("%s: bucket %s percentage = %.2f, want %.2f", name, b.Bucket, b.PercentageOfTotal, wantPct)
+		}
+	}
+}
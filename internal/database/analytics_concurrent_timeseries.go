@@ -0,0 +1,150 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// maxConcurrentStreamsBuckets caps the series length so a small bucket
+// width over a long range cannot build an unbounded response
+const maxConcurrentStreamsBuckets = 100000
+
+// concurrencyChange is the number of sessions playing from At onwards,
+// until the next change
+type concurrencyChange struct {
+	At         time.Time
+	Concurrent int
+}
+
+// GetConcurrentStreamsTimeSeries returns the peak number of simultaneous
+// sessions in each bucketMinutes-wide bucket, for capacity planning.
+//
+// Each session occupies [started_at, stopped_at); sessions without
+// stopped_at end after play_duration minutes and are skipped when neither is
+// known. Sessions spanning a bucket boundary count toward every bucket they
+// overlap, and a session ending at the instant another starts does not
+// overlap it. Buckets are aligned to UTC and run from the first session
+// start to the last session end, with empty buckets reported as zero.
+func (db *DB) GetConcurrentStreamsTimeSeries(ctx context.Context, filter LocationStatsFilter, bucketMinutes int) (*models.ConcurrentStreamsTimeSeries, error) {
+	if bucketMinutes <= 0 || bucketMinutes > 24*60 {
+		return nil, fmt.Errorf("invalid bucket width %d: must be between 1 and 1440 minutes", bucketMinutes)
+	}
+
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	changes, err := db.getConcurrencyChanges(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildConcurrentStreamsTimeSeries(changes, time.Duration(bucketMinutes)*time.Minute)
+}
+
+// getConcurrencyChanges sweeps session starts (+1) and ends (-1) in time
+// order and returns the running session count at every instant it changes.
+// Starts and ends at the same instant are netted before the running sum, so
+// back-to-back sessions do not count as overlapping.
+func (db *DB) getConcurrencyChanges(ctx context.Context, filter LocationStatsFilter) ([]concurrencyChange, error) {
+	whereClauses, args := buildFilterConditions(filter, false, 1)
+	whereClauses = append(whereClauses, "(stopped_at IS NOT NULL OR play_duration > 0)")
+
+	query := fmt.Sprintf(`
+		WITH sessions AS (
+			SELECT
+				started_at AS session_start,
+				COALESCE(stopped_at, started_at + to_minutes(play_duration)) AS session_end
+			FROM playback_events
+			WHERE %s
+		),
+		deltas AS (
+			SELECT session_start AS changed_at, 1 AS delta FROM sessions WHERE session_end > session_start
+			UNION ALL
+			SELECT session_end AS changed_at, -1 AS delta FROM sessions WHERE session_end > session_start
+		),
+		changes AS (
+			SELECT changed_at, SUM(delta) AS delta
+			FROM deltas
+			GROUP BY changed_at
+		)
+		SELECT
+			changed_at,
+			SUM(delta) OVER (ORDER BY changed_at ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS concurrent
+		FROM changes
+		ORDER BY changed_at
+	`, join(whereClauses, " AND "))
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query concurrency changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []concurrencyChange
+	for rows.Next() {
+		var c concurrencyChange
+		if err := rows.Scan(&c.At, &c.Concurrent); err != nil {
+			return nil, fmt.Errorf("scan concurrency change: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate concurrency changes: %w", err)
+	}
+	return changes, nil
+}
+
+// buildConcurrentStreamsTimeSeries folds the change points into buckets of
+// width size. A bucket's peak is the count carried in from the previous
+// bucket or any count reached inside it, whichever is higher.
+func buildConcurrentStreamsTimeSeries(changes []concurrencyChange, size time.Duration) (*models.ConcurrentStreamsTimeSeries, error) {
+	result := &models.ConcurrentStreamsTimeSeries{
+		BucketMinutes: int(size / time.Minute),
+		Buckets:       []models.ConcurrentStreamsPeakBucket{},
+	}
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	first := changes[0].At.UTC().Truncate(size)
+	last := changes[len(changes)-1].At.UTC() // every session has ended here
+	if n := int64(last.Sub(first)/size) + 1; n > maxConcurrentStreamsBuckets {
+		return nil, fmt.Errorf("time range needs %d buckets of %s, more than the limit of %d; use wider buckets or a narrower date range",
+			n, size, maxConcurrentStreamsBuckets)
+	}
+
+	level, i := 0, 0
+	for start := first; start.Before(last); start = start.Add(size) {
+		end := start.Add(size)
+		peak := level
+		// A change at the bucket start replaces the carried-in count
+		if i < len(changes) && changes[i].At.Equal(start) {
+			level = changes[i].Concurrent
+			peak = level
+			i++
+		}
+		for ; i < len(changes) && changes[i].At.Before(end); i++ {
+			level = changes[i].Concurrent
+			peak = max(peak, level)
+			if level > result.PeakConcurrent {
+				result.PeakConcurrent = level
+				result.PeakTime = changes[i].At.UTC()
+			}
+		}
+		if peak > result.PeakConcurrent {
+			result.PeakConcurrent = peak
+			result.PeakTime = start
+		}
+		result.Buckets = append(result.Buckets, models.ConcurrentStreamsPeakBucket{BucketStart: start, PeakConcurrent: peak})
+	}
+
+	return result, nil
+}
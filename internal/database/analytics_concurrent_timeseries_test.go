@@ -0,0 +1,195 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// concurrencyBase is 20:00 UTC on the day all concurrency sessions play
+var concurrencyBase = time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+// insertConcurrencySession inserts a session starting startMin minutes after
+// concurrencyBase. A positive stopMin sets stopped_at; otherwise stopped_at
+// is NULL and playDuration (seconds, 0 for NULL) is the only end
+func insertConcurrencySession(t *testing.T, db *DB, startMin, stopMin, playDuration int) {
+	t.Helper()
+
+	var stoppedAt *time.Time
+	if stopMin > 0 {
+		s := concurrencyBase.Add(time.Duration(stopMin) * time.Minute)
+		stoppedAt = &s
+	}
+	var duration *int
+	if playDuration > 0 {
+		duration = &playDuration
+	}
+	_, err := db.conn.Exec(`
+		INSERT INTO playback_events (
+			id, session_key, started_at, stopped_at, play_duration, user_id, username,
+			ip_address, media_type, title
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, uuid.New().String(), uuid.New().String(), concurrencyBase.Add(time.Duration(startMin)*time.Minute),
+		stoppedAt, duration, 1, "alice", "192.168.1.1", "movie", "Test Title")
+	if err != nil {
+		t.Fatalf("Failed to insert concurrency session: %v", err)
+	}
+}
+
+// assertPeakBuckets checks bucket starts step by width from concurrencyBase
+// and carry the wanted peaks
+func assertPeakBuckets(t *testing.T, got []models.ConcurrentStreamsPeakBucket, width time.Duration, want []int) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d buckets %+v, want %d", len(got), got, len(want))
+	}
+	for i, b := range got {
+		wantStart := concurrencyBase.Add(time.Duration(i) * width)
+		if !b.BucketStart.Equal(wantStart) {
+			t.Errorf("bucket %d starts at %s, want %s", i, b.BucketStart.Format(time.RFC3339), wantStart.Format(time.RFC3339))
+		}
+		if b.PeakConcurrent != want[i] {
+			t.Errorf("bucket %s peak = %d, want %d", b.BucketStart.Format("15:04"), b.PeakConcurrent, want[i])
+		}
+	}
+}
+
+func TestGetConcurrentStreamsTimeSeries(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// Minutes after 20:00:
+	//
+	//	A  0-40
+	//	B 10-20
+	//	C 20-50   starts as B stops, so B and C never overlap
+	//	D 35-70   no stopped_at, play_duration 35m
+	//	E 25-28
+	//	-  no stopped_at or play_duration (ignored)
+	//
+	// Peak per 15-minute bucket, by hand:
+	//
+	//	20:00  A, then A+B                         2
+	//	20:15  A+B in, B->C at 20:20, +E at 20:25  3
+	//	20:30  A+C in, +D at 20:35                 3
+	//	20:45  C+D in, C stops at 20:50            2
+	//	21:00  D in, stops at 21:10                1
+	insertConcurrencySession(t, db, 0, 40, 0)
+	insertConcurrencySession(t, db, 10, 20, 0)
+	insertConcurrencySession(t, db, 20, 50, 0)
+	insertConcurrencySession(t, db, 35, 0, 35)
+	insertConcurrencySession(t, db, 25, 28, 0)
+	insertConcurrencySession(t, db, 5, 0, 0)
+
+	series, err := db.GetConcurrentStreamsTimeSeries(context.Background(), LocationStatsFilter{}, 15)
+	if err != nil {
+		t.Fatalf("GetConcurrentStreamsTimeSeries() error = %v", err)
+	}
+
+	if series.BucketMinutes != 15 {
+		t.Errorf("BucketMinutes = %d, want 15", series.BucketMinutes)
+	}
+	assertPeakBuckets(t, series.Buckets, 15*time.Minute, []int{2, 3, 3, 2, 1})
+	if series.PeakConcurrent != 3 {
+		t.Errorf("PeakConcurrent = %d, want 3", series.PeakConcurrent)
+	}
+	if want := concurrencyBase.Add(25 * time.Minute); !series.PeakTime.Equal(want) {
+		t.Errorf("PeakTime = %s, want 20:25 when E joined A and C", series.PeakTime.Format(time.RFC3339))
+	}
+
+	// The same sessions in hourly buckets
+	hourly, err := db.GetConcurrentStreamsTimeSeries(context.Background(), LocationStatsFilter{}, 60)
+	if err != nil {
+		t.Fatalf("GetConcurrentStreamsTimeSeries(60) error = %v", err)
+	}
+	assertPeakBuckets(t, hourly.Buckets, time.Hour, []int{3, 1})
+}
+
+func TestGetConcurrentStreamsTimeSeries_SpanningAndIdleBuckets(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// A spans four boundaries; B stops exactly on one, leaving 20:45 and
+	// 21:00 idle; C plays alone afterwards
+	//
+	//	20:00  A                 1
+	//	20:15  A, +B at 20:20    2
+	//	20:30  A+B, B stops      2
+	//	20:45  A                 1
+	//	21:00  A stops at 21:05  1
+	//	21:15  idle              0
+	//	21:30  C 21:35-21:40     1
+	insertConcurrencySession(t, db, 5, 65, 0)
+	insertConcurrencySession(t, db, 20, 45, 0)
+	insertConcurrencySession(t, db, 95, 100, 0)
+
+	series, err := db.GetConcurrentStreamsTimeSeries(context.Background(), LocationStatsFilter{}, 15)
+	if err != nil {
+		t.Fatalf("GetConcurrentStreamsTimeSeries() error = %v", err)
+	}
+	assertPeakBuckets(t, series.Buckets, 15*time.Minute, []int{1, 2, 2, 1, 1, 0, 1})
+}
+
+func TestGetConcurrentStreamsTimeSeries_BackToBackSessions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// A stops on the 20:15 boundary as B starts; the 20:15 bucket holds
+	// only B, and nothing carries over once B stops at 20:30
+	insertConcurrencySession(t, db, 0, 15, 0)
+	insertConcurrencySession(t, db, 15, 30, 0)
+	insertConcurrencySession(t, db, 40, 50, 0)
+
+	series, err := db.GetConcurrentStreamsTimeSeries(context.Background(), LocationStatsFilter{}, 15)
+	if err != nil {
+		t.Fatalf("GetConcurrentStreamsTimeSeries() error = %v", err)
+	}
+	assertPeakBuckets(t, series.Buckets, 15*time.Minute, []int{1, 1, 1, 1})
+	if series.PeakConcurrent != 1 {
+		t.Errorf("PeakConcurrent = %d, want 1 for sessions that never overlap", series.PeakConcurrent)
+	}
+}
+
+func TestGetConcurrentStreamsTimeSeries_Empty(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	series, err := db.GetConcurrentStreamsTimeSeries(context.Background(), LocationStatsFilter{}, 15)
+	if err != nil {
+		t.Fatalf("GetConcurrentStreamsTimeSeries() error = %v", err)
+	}
+	if len(series.Buckets) != 0 || series.PeakConcurrent != 0 {
+		t.Errorf("series = %+v, want no buckets", series)
+	}
+}
+
+func TestGetConcurrentStreamsTimeSeries_InvalidBucket(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	for _, minutes := range []int{0, -15, 24*60 + 1} {
+		if _, err := db.GetConcurrentStreamsTimeSeries(context.Background(), LocationStatsFilter{}, minutes); err == nil {
+			t.Errorf("GetConcurrentStreamsTimeSeries(%d) error = nil, want invalid bucket width", minutes)
+		}
+	}
+}
+
+func TestBuildConcurrentStreamsTimeSeries_BucketLimit(t *testing.T) {
+	changes := []concurrencyChange{
+		{At: concurrencyBase, Concurrent: 1},
+		{At: concurrencyBase.AddDate(1, 0, 0), Concurrent: 0},
+	}
+	if _, err := buildConcurrentStreamsTimeSeries(changes, time.Minute); err == nil {
+		t.Error("buildConcurrentStreamsTimeSeries() error = nil, want bucket limit exceeded for a year of 1-minute buckets")
+	}
+}
@@ -278,7 +278,7 @@ func (db *DB) queryCompletionDistribution(ctx context.Context, filter LocationSt
 		END as bucket,
 		COUNT(*) as playback_count,
 		AVG(percent_complete) as avg_completion
-	FROM playback_events
+	FROM ` + playbackEventsSource(filter) + `
 	WHERE percent_complete IS NOT NULL`
 
 	args := []interface{}{}
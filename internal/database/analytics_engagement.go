@@ -119,7 +119,7 @@ func (db *DB) queryPopularContentByType(ctx context.Context, query string, args
 }
 
 // queryTopMovies retrieves top movies by playback count
-func (db *DB) queryTopMovies(ctx context.Context, whereClause string, args []interface{}, limit int) ([]models.PopularContent, error) {
+func (db *DB) queryTopMovies(ctx context.Context, source, whereClause string, args []interface{}, limit int) ([]models.PopularContent, error) {
 	query := fmt.Sprintf(`
 		SELECT
 			media_type,
@@ -134,19 +134,19 @@ func (db *DB) queryTopMovies(ctx context.Context, whereClause string, args []int
 			year,
 			content_rating,
 			CAST(COALESCE(SUM(play_duration), 0) / 60 AS INTEGER) as total_watch_time
-		FROM playback_events
+		FROM %s
 		WHERE %s AND media_type = 'movie'
 		GROUP BY media_type, title, year, content_rating
 		ORDER BY playback_count DESC
 		LIMIT ?
-	`, whereClause)
+	`, source, whereClause)
 
 	queryArgs := append(args, limit)
 	return db.queryPopularContentByType(ctx, query, queryArgs, "top movies")
 }
 
 // queryTopShows retrieves top TV shows by playback count (grouped by grandparent_title)
-func (db *DB) queryTopShows(ctx context.Context, whereClause string, args []interface{}, limit int) ([]models.PopularContent, error) {
+func (db *DB) queryTopShows(ctx context.Context, source, whereClause string, args []interface{}, limit int) ([]models.PopularContent, error) {
 	query := fmt.Sprintf(`
 		SELECT
 			'show' as media_type,
@@ -161,19 +161,19 @@ func (db *DB) queryTopShows(ctx context.Context, whereClause string, args []inte
 			NULL as year,
 			NULL as content_rating,
 			CAST(COALESCE(SUM(play_duration), 0) / 60 AS INTEGER) as total_watch_time
-		FROM playback_events
+		FROM %s
 		WHERE %s AND media_type = 'episode' AND grandparent_title IS NOT NULL AND grandparent_title != ''
 		GROUP BY grandparent_title
 		ORDER BY playback_count DESC
 		LIMIT ?
-	`, whereClause)
+	`, source, whereClause)
 
 	queryArgs := append(args, limit)
 	return db.queryPopularContentByType(ctx, query, queryArgs, "top shows")
 }
 
 // queryTopEpisodes retrieves top episodes by playback count
-func (db *DB) queryTopEpisodes(ctx context.Context, whereClause string, args []interface{}, limit int) ([]models.PopularContent, error) {
+func (db *DB) queryTopEpisodes(ctx context.Context, source, whereClause string, args []interface{}, limit int) ([]models.PopularContent, error) {
 	query := fmt.Sprintf(`
 		SELECT
 			media_type,
@@ -188,12 +188,12 @@ func (db *DB) queryTopEpisodes(ctx context.Context, whereClause string, args []i
 			NULL as year,
 			NULL as content_rating,
 			CAST(COALESCE(SUM(play_duration), 0) / 60 AS INTEGER) as total_watch_time
-		FROM playback_events
+		FROM %s
 		WHERE %s AND media_type = 'episode'
 		GROUP BY media_type, title, parent_title, grandparent_title
 		ORDER BY playback_count DESC
 		LIMIT ?
-	`, whereClause)
+	`, source, whereClause)
 
 	queryArgs := append(args, limit)
 	return db.queryPopularContentByType(ctx, query, queryArgs, "top episodes")
@@ -206,19 +206,20 @@ func (db *DB) GetPopularContent(ctx context.Context, filter LocationStatsFilter,
 
 	// Build WHERE clause once (no alias, exclude media types since queries filter explicitly)
 	whereClause, args := buildEngagementWhereClause(filter, "", false)
+	source := playbackEventsSource(filter)
 
 	// Query all three content types using helper methods
-	movies, err := db.queryTopMovies(ctx, whereClause, args, limit)
+	movies, err := db.queryTopMovies(ctx, source, whereClause, args, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	shows, err := db.queryTopShows(ctx, whereClause, args, limit)
+	shows, err := db.queryTopShows(ctx, source, whereClause, args, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	episodes, err := db.queryTopEpisodes(ctx, whereClause, args, limit)
+	episodes, err := db.queryTopEpisodes(ctx, source, whereClause, args, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -230,45 +231,40 @@ func (db *DB) GetPopularContent(ctx context.Context, filter LocationStatsFilter,
 	}, nil
 }
 
-// watchPartyCTE returns the common CTE for detecting watch parties
-// A watch party is 2+ users watching the same content within 15 minutes
-// Extracted to reduce duplication across 5 watch party query functions
+// watchPartyCTE returns the common CTE selecting the reported watch parties
+// stored by DetectWatchParties. The %s placeholder takes the WHERE clause
+// built by watchPartyFilter. The CTE is named parties because it reads the
+// watch_parties table.
 func watchPartyCTE() string {
 	return `
 		SELECT
-			p1.id as anchor_id,
-			p1.media_type,
-			p1.title,
-			p1.parent_title,
-			p1.grandparent_title,
-			p1.started_at as party_time,
-			COUNT(DISTINCT p2.user_id) as participant_count,
-			COUNT(DISTINCT CASE WHEN p2.ip_address != '' THEN p2.ip_address END) = 1 as same_ip,
-			AVG(p2.percent_complete) as avg_completion,
-			SUM(COALESCE(p2.play_duration, 0)) as total_duration
-		FROM playback_events p1
-		INNER JOIN playback_events p2 ON (
-			p1.title = p2.title
-			AND COALESCE(p1.parent_title, '') = COALESCE(p2.parent_title, '')
-			AND COALESCE(p1.grandparent_title, '') = COALESCE(p2.grandparent_title, '')
-			AND ABS(epoch(p2.started_at - p1.started_at)) <= 900
-		)
-		WHERE %s
-		GROUP BY p1.id, p1.media_type, p1.title, p1.parent_title, p1.grandparent_title, p1.started_at
-		HAVING COUNT(DISTINCT p2.user_id) >= 2`
+			wp.id as anchor_id,
+			wp.media_type,
+			wp.title,
+			wp.parent_title,
+			wp.grandparent_title,
+			wp.started_at as party_time,
+			wp.ended_at,
+			wp.participant_count,
+			wp.same_location as same_ip,
+			wp.avg_completion,
+			wp.total_duration,
+			wp.confidence
+		FROM watch_parties wp
+		WHERE %s`
 }
 
 // getWatchPartySummary retrieves overall watch party summary statistics
 func (db *DB) getWatchPartySummary(ctx context.Context, whereClause string, args []interface{}) (int, int, float64, int, error) {
 	cte := watchPartyCTE()
 	query := fmt.Sprintf(`
-		WITH watch_parties AS (`+cte+`)
+		WITH parties AS (`+cte+`)
 		SELECT
 			COUNT(*) as total_parties,
 			COALESCE(SUM(participant_count), 0) as total_participants,
 			COALESCE(AVG(participant_count), 0.0) as avg_participants,
 			COALESCE(SUM(CASE WHEN same_ip THEN 1 ELSE 0 END), 0) as same_location_parties
-		FROM watch_parties
+		FROM parties
 	`, whereClause)
 
 	var totalParties, totalParticipants, sameLocationParties int
@@ -288,20 +284,25 @@ func (db *DB) getWatchPartySummary(ctx context.Context, whereClause string, args
 }
 
 // getRecentWatchParties retrieves the most recent watch parties (last 10)
+// with their participants
 func (db *DB) getRecentWatchParties(ctx context.Context, whereClause string, args []interface{}) ([]models.WatchParty, error) {
 	cte := watchPartyCTE()
 	query := fmt.Sprintf(`
-		WITH watch_parties AS (`+cte+`)
+		WITH parties AS (`+cte+`)
 		SELECT
+			anchor_id,
 			media_type,
 			title,
 			parent_title,
 			grandparent_title,
 			party_time,
+			ended_at,
 			participant_count,
+			same_ip,
 			avg_completion,
-			total_duration
-		FROM watch_parties
+			total_duration,
+			confidence
+		FROM parties
 		ORDER BY party_time DESC
 		LIMIT 10
 	`, whereClause)
@@ -313,22 +314,27 @@ func (db *DB) getRecentWatchParties(ctx context.Context, whereClause string, arg
 	defer rows.Close()
 
 	var parties []models.WatchParty
+	index := make(map[string]int)
 	for rows.Next() {
 		var party models.WatchParty
 		if err := rows.Scan(
+			&party.ID,
 			&party.MediaType,
 			&party.Title,
 			&party.ParentTitle,
 			&party.GrandparentTitle,
 			&party.PartyTime,
+			&party.EndedAt,
 			&party.ParticipantCount,
+			&party.SameLocation,
 			&party.AvgCompletion,
 			&party.TotalDuration,
+			&party.Confidence,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan watch party: %w", err)
 		}
-		// Note: Participants list would require another query per party, skipping for performance
 		party.Participants = []models.WatchPartyParticipant{}
+		index[party.ID] = len(parties)
 		parties = append(parties, party)
 	}
 
@@ -336,42 +342,29 @@ func (db *DB) getRecentWatchParties(ctx context.Context, whereClause string, arg
 		return nil, fmt.Errorf("error iterating watch parties: %w", err)
 	}
 
+	if err := db.loadWatchPartyParticipants(ctx, parties, index); err != nil {
+		return nil, err
+	}
 	return parties, nil
 }
 
 // getTopWatchPartyContent retrieves content with the most watch parties
 func (db *DB) getTopWatchPartyContent(ctx context.Context, whereClause string, args []interface{}) ([]models.WatchPartyContentStats, error) {
-	// Simplified CTE without same_ip calculation (not needed for this query)
+	cte := watchPartyCTE()
 	query := fmt.Sprintf(`
-		WITH watch_parties AS (
-			SELECT
-				p1.media_type,
-				p1.title,
-				p1.parent_title,
-				p1.grandparent_title,
-				COUNT(DISTINCT p2.user_id) as participant_count
-			FROM playback_events p1
-			INNER JOIN playback_events p2 ON (
-				p1.title = p2.title
-				AND COALESCE(p1.parent_title, '') = COALESCE(p2.parent_title, '')
-				AND COALESCE(p1.grandparent_title, '') = COALESCE(p2.grandparent_title, '')
-				AND ABS(epoch(p2.started_at - p1.started_at)) <= 900
-			)
-			WHERE %s
-			GROUP BY p1.id, p1.media_type, p1.title, p1.parent_title, p1.grandparent_title
-			HAVING COUNT(DISTINCT p2.user_id) >= 2
-		)
+		WITH parties AS (`+cte+`)
 		SELECT
-			media_type,
-			title,
-			parent_title,
-			grandparent_title,
-			COUNT(*) as party_count,
-			SUM(participant_count) as total_participants,
-			AVG(participant_count) as avg_participants,
-			COUNT(DISTINCT participant_count) as unique_users
-		FROM watch_parties
-		GROUP BY media_type, title, parent_title, grandparent_title
+			wp.media_type,
+			wp.title,
+			wp.parent_title,
+			wp.grandparent_title,
+			COUNT(DISTINCT wp.anchor_id) as party_count,
+			COUNT(*) as total_participants,
+			COUNT(*) / COUNT(DISTINCT wp.anchor_id) as avg_participants,
+			COUNT(DISTINCT pp.user_id) as unique_users
+		FROM parties wp
+		INNER JOIN watch_party_participants pp ON pp.party_id = wp.anchor_id
+		GROUP BY wp.media_type, wp.title, wp.parent_title, wp.grandparent_title
 		ORDER BY party_count DESC
 		LIMIT 10
 	`, whereClause)
@@ -408,40 +401,23 @@ func (db *DB) getTopWatchPartyContent(ctx context.Context, whereClause string, a
 }
 
 // getTopSocialUsers retrieves users with the most watch party participations
-// Takes two separate where clauses because the CTEs use different table aliases (p1 and p)
+// Takes two separate where clauses: one selecting the parties (watchPartyFilter)
+// and one selecting the participant playbacks (alias p)
 func (db *DB) getTopSocialUsers(ctx context.Context, whereClauseP1 string, argsP1 []interface{}, whereClauseP string, argsP []interface{}) ([]models.WatchPartyUserStats, error) {
+	cte := watchPartyCTE()
 	query := fmt.Sprintf(`
-		WITH watch_parties AS (
-			SELECT
-				p1.id as anchor_id,
-				p1.title,
-				p1.grandparent_title,
-				COUNT(DISTINCT p2.user_id) as participant_count,
-				COUNT(DISTINCT CASE WHEN p2.ip_address != '' THEN p2.ip_address END) = 1 as same_location
-			FROM playback_events p1
-			INNER JOIN playback_events p2 ON (
-				p1.title = p2.title
-				AND COALESCE(p1.parent_title, '') = COALESCE(p2.parent_title, '')
-				AND COALESCE(p1.grandparent_title, '') = COALESCE(p2.grandparent_title, '')
-				AND ABS(epoch(p2.started_at - p1.started_at)) <= 900
-			)
-			WHERE %s
-			GROUP BY p1.id, p1.title, p1.grandparent_title
-			HAVING COUNT(DISTINCT p2.user_id) >= 2
-		),
+		WITH parties AS (`+cte+`),
 		user_parties AS (
 			SELECT
-				p.user_id,
-				p.username,
+				pp.user_id,
+				pp.username,
 				wp.anchor_id,
 				wp.participant_count,
-				wp.same_location,
+				wp.same_ip as same_location,
 				COALESCE(wp.grandparent_title, wp.title) as content_title
-			FROM playback_events p
-			INNER JOIN watch_parties wp ON p.id = wp.anchor_id OR (
-				p.title = wp.title
-				AND COALESCE(p.grandparent_title, '') = COALESCE(wp.grandparent_title, '')
-			)
+			FROM watch_party_participants pp
+			INNER JOIN parties wp ON pp.party_id = wp.anchor_id
+			INNER JOIN playback_events p ON CAST(p.id AS VARCHAR) = pp.playback_id
 			WHERE %s
 		)
 		SELECT
@@ -451,10 +427,7 @@ func (db *DB) getTopSocialUsers(ctx context.Context, whereClauseP1 string, argsP
 			SUM(participant_count - 1) as total_co_watchers,
 			AVG(participant_count) as avg_party_size,
 			SUM(CASE WHEN same_location THEN 1 ELSE 0 END) as same_location_count,
-			(SELECT content_title FROM user_parties up2
-			 WHERE up2.user_id = user_parties.user_id
-			 GROUP BY content_title
-			 ORDER BY COUNT(*) DESC LIMIT 1) as favorite_content
+			mode(content_title) as favorite_content
 		FROM user_parties
 		GROUP BY user_id, username
 		ORDER BY party_count DESC
@@ -463,7 +436,7 @@ func (db *DB) getTopSocialUsers(ctx context.Context, whereClauseP1 string, argsP
 
 	// Combine args for both WHERE clauses
 	allArgs := make([]interface{}, 0, len(argsP1)+len(argsP))
-	allArgs = append(allArgs, argsP1...) // for first WHERE clause (watch_parties CTE with p1 alias)
+	allArgs = append(allArgs, argsP1...) // for first WHERE clause (parties CTE)
 	allArgs = append(allArgs, argsP...)  // for second WHERE clause (user_parties CTE with p alias)
 	rows, err := db.conn.QueryContext(ctx, query, allArgs...)
 	if err != nil {
@@ -497,28 +470,14 @@ func (db *DB) getTopSocialUsers(ctx context.Context, whereClauseP1 string, argsP
 
 // getWatchPartiesByDay retrieves watch party distribution by day of week
 func (db *DB) getWatchPartiesByDay(ctx context.Context, whereClause string, args []interface{}) ([]models.WatchPartyByDay, error) {
-	// Simplified CTE without same_ip and other unused fields
+	cte := watchPartyCTE()
 	query := fmt.Sprintf(`
-		WITH watch_parties AS (
-			SELECT
-				p1.started_at as party_time,
-				COUNT(DISTINCT p2.user_id) as participant_count
-			FROM playback_events p1
-			INNER JOIN playback_events p2 ON (
-				p1.title = p2.title
-				AND COALESCE(p1.parent_title, '') = COALESCE(p2.parent_title, '')
-				AND COALESCE(p1.grandparent_title, '') = COALESCE(p2.grandparent_title, '')
-				AND ABS(epoch(p2.started_at - p1.started_at)) <= 900
-			)
-			WHERE %s
-			GROUP BY p1.id, p1.started_at
-			HAVING COUNT(DISTINCT p2.user_id) >= 2
-		)
+		WITH parties AS (`+cte+`)
 		SELECT
 			DAYOFWEEK(party_time) as day_of_week,
 			COUNT(*) as party_count,
 			AVG(participant_count) as avg_participants
-		FROM watch_parties
+		FROM parties
 		GROUP BY day_of_week
 		ORDER BY day_of_week
 	`, whereClause)
@@ -559,14 +518,20 @@ func (db *DB) getWatchPartiesByDay(ctx context.Context, whereClause string, args
 	return partiesByDay, nil
 }
 
-// GetWatchParties detects and analyzes watch parties (2+ users watching same content together)
-// A watch party is detected when different users watch the same content within a 15-minute time window
+// GetWatchParties analyzes watch parties (2+ users watching same content together)
+// Parties are detected after each sync by DetectWatchParties; only parties at or
+// above the current WatchPartyMinConfidence and not marked as false positives count
 func (db *DB) GetWatchParties(ctx context.Context, filter LocationStatsFilter) (*models.WatchPartyAnalytics, error) {
 	ctx, cancel := db.ensureContext(ctx)
 	defer cancel()
 
-	// Build WHERE clause with p1 prefix for most queries (self-join on p1/p2)
-	whereClauseP1, argsP1 := buildEngagementWhereClause(filter, "p1", true)
+	minConfidence, err := db.WatchPartyMinConfidence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build WHERE clause selecting the parties with a participant playback (p1) matching the filter
+	whereClauseP1, argsP1 := watchPartyFilter(filter, minConfidence, false)
 
 	// Build WHERE clause with p prefix for user_parties CTE in getTopSocialUsers
 	whereClauseP, argsP := buildEngagementWhereClause(filter, "p", true)
@@ -606,8 +571,8 @@ func (db *DB) GetWatchParties(ctx context.Context, filter LocationStatsFilter) (
 	analytics.TopContent = topContent
 
 	// getTopSocialUsers needs two different WHERE clauses:
-	// - First CTE (watch_parties) uses p1 alias
-	// - Second CTE (user_parties) uses p alias
+	// - First CTE (parties) selects the parties
+	// - Second CTE (user_parties) uses p alias for participant playbacks
 	topUsers, err := db.getTopSocialUsers(ctx, whereClauseP1, argsP1, whereClauseP, argsP)
 	if err != nil {
 		return nil, err
@@ -51,6 +51,7 @@ func TestGetWatchParties(t *testing.T) {
 
 	insertTestGeolocations(t, db)
 	insertWatchPartyTestPlaybacks(t, db)
+	detectTestWatchParties(t, db)
 
 	tests := []struct {
 		name   string
@@ -293,7 +294,7 @@ func TestQueryHelpers(t *testing.T) {
 		{
 			name: "queryTopMovies",
 			queryFunc: func() error {
-				movies, err := db.queryTopMovies(context.Background(), whereClause, args, 10)
+				movies, err := db.queryTopMovies(context.Background(), "playback_events", whereClause, args, 10)
 				if err != nil {
 					return err
 				}
@@ -308,7 +309,7 @@ func TestQueryHelpers(t *testing.T) {
 		{
 			name: "queryTopShows",
 			queryFunc: func() error {
-				shows, err := db.queryTopShows(context.Background(), whereClause, args, 10)
+				shows, err := db.queryTopShows(context.Background(), "playback_events", whereClause, args, 10)
 				if err != nil {
 					return err
 				}
@@ -323,7 +324,7 @@ func TestQueryHelpers(t *testing.T) {
 		{
 			name: "queryTopEpisodes",
 			queryFunc: func() error {
-				episodes, err := db.queryTopEpisodes(context.Background(), whereClause, args, 10)
+				episodes, err := db.queryTopEpisodes(context.Background(), "playback_events", whereClause, args, 10)
 				if err != nil {
 					return err
 				}
@@ -353,8 +354,9 @@ func TestWatchPartyHelpers(t *testing.T) {
 
 	insertTestGeolocations(t, db)
 	insertWatchPartyTestPlaybacks(t, db)
+	detectTestWatchParties(t, db)
 
-	whereClause, args := buildEngagementWhereClause(LocationStatsFilter{}, "p1", true)
+	whereClause, args := watchPartyFilter(LocationStatsFilter{}, DefaultWatchPartyMinConfidence, false)
 
 	tests := []struct {
 		name      string
@@ -603,7 +605,7 @@ func insertWatchPartyTestPlaybacks(t *testing.T, db *DB) {
 	now := time.Now()
 	baseTime := now.Add(-1 * time.Hour)
 
-	// Create a watch party - multiple users watching the same content within 15 minutes
+	// Create a watch party - multiple users starting the same content within 2 minutes
 	watchPartyPlaybacks := []struct {
 		userID    int
 		username  string
@@ -612,10 +614,11 @@ func insertWatchPartyTestPlaybacks(t *testing.T, db *DB) {
 		startedAt time.Time
 	}{
 		{1, "user1", "192.168.1.1", "Watch Party Movie", baseTime},
-		{2, "user2", "192.168.1.2", "Watch Party Movie", baseTime.Add(5 * time.Minute)},
+		{2, "user2", "192.168.1.2", "Watch Party Movie", baseTime.Add(1 * time.Minute)},
+		// Started too late to be part of the party
 		{3, "user3", "192.168.1.3", "Watch Party Movie", baseTime.Add(10 * time.Minute)},
 		// Same IP - in-person watch party
-		{4, "user4", "192.168.1.1", "Watch Party Movie", baseTime.Add(2 * time.Minute)},
+		{4, "user4", "192.168.1.1", "Watch Party Movie", baseTime.Add(30 * time.Second)},
 	}
 
 	for _, pb := range watchPartyPlaybacks {
@@ -633,5 +636,13 @@ func insertWatchPartyTestPlaybacks(t *testing.T, db *DB) {
 	}
 }
 
+// detectTestWatchParties runs watch party detection with the default thresholds
+func detectTestWatchParties(t *testing.T, db *DB) {
+	t.Helper()
+	if _, err := db.DetectWatchParties(context.Background(), DefaultWatchPartyStartWindow, DefaultWatchPartyMinDuration); err != nil {
+		t.Fatalf("DetectWatchParties failed: %v", err)
+	}
+}
+
 // Note: nullableString is defined in newsletter.go and shared across package
 // Note: timePtr is defined in database_test.go and shared across test files
@@ -0,0 +1,88 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// analyticsLimiter is a semaphore bounding how many heavyweight analytics
+// queries hold pool connections at once, so a burst of dashboard refreshes
+// queues instead of starving the sync writer of connections.
+type analyticsLimiter struct {
+	slots  chan struct{}
+	queued atomic.Int64
+}
+
+func newAnalyticsLimiter(n int) *analyticsLimiter {
+	return &analyticsLimiter{slots: make(chan struct{}, n)}
+}
+
+type analyticsSlotKey struct{}
+
+// WithAnalyticsSlot marks ctx as belonging to work that already holds an
+// analytics slot, such as one item of a batch request. AcquireAnalyticsSlot
+// with ctx or a context derived from it returns at once instead of taking a
+// second slot, which could otherwise wait on the slot its caller holds.
+func WithAnalyticsSlot(ctx context.Context) context.Context {
+	return context.WithValue(ctx, analyticsSlotKey{}, true)
+}
+
+// AcquireAnalyticsSlot waits for one of the DB_MAX_CONCURRENT_ANALYTICS
+// slots and returns the function that releases it. Callers run one analytics
+// request's queries while holding the slot. Waiting ends with ctx, so time
+// spent queued counts against the query budget; the returned error then
+// wraps ctx.Err().
+func (db *DB) AcquireAnalyticsSlot(ctx context.Context) (release func(), err error) {
+	l := db.analytics
+	if l == nil || ctx.Value(analyticsSlotKey{}) != nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		l.updateMetrics()
+		return l.releaser(), nil
+	default:
+	}
+
+	l.queued.Add(1)
+	l.updateMetrics()
+	start := time.Now()
+	defer func() {
+		l.queued.Add(-1)
+		l.updateMetrics()
+		metrics.RecordDBAnalyticsQueueWait(time.Since(start), err != nil)
+	}()
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.releaser(), nil
+	case <-ctx.Done():
+		return func() {}, fmt.Errorf("wait for analytics query slot: %w", ctx.Err())
+	}
+}
+
+// releaser returns a release function that frees the slot once
+func (l *analyticsLimiter) releaser() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-l.slots
+			l.updateMetrics()
+		})
+	}
+}
+
+func (l *analyticsLimiter) updateMetrics() {
+	metrics.UpdateDBAnalyticsQueries(len(l.slots), int(l.queued.Load()))
+}
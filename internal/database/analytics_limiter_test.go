@@ -0,0 +1,133 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+func TestAcquireAnalyticsSlot_QueuesBeyondLimit(t *testing.T) {
+	db := &DB{analytics: newAnalyticsLimiter(2)}
+	ctx := context.Background()
+
+	release1, err := db.AcquireAnalyticsSlot(ctx)
+	if err != nil {
+		t.Fatalf("first AcquireAnalyticsSlot() error = %v", err)
+	}
+	release2, err := db.AcquireAnalyticsSlot(ctx)
+	if err != nil {
+		t.Fatalf("second AcquireAnalyticsSlot() error = %v", err)
+	}
+	if got := testutil.ToFloat64(metrics.DBAnalyticsQueries.WithLabelValues("running")); got != 2 {
+		t.Errorf("running analytics gauge = %v, want 2", got)
+	}
+
+	// A third query waits for a slot
+	acquired := make(chan func(), 1)
+	go func() {
+		release, err := db.AcquireAnalyticsSlot(ctx)
+		if err != nil {
+			t.Errorf("queued AcquireAnalyticsSlot() error = %v", err)
+		}
+		acquired <- release
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for db.analytics.queued.Load() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("third query never queued")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-acquired:
+		t.Fatal("third query got a slot while both were held")
+	default:
+	}
+	if got := testutil.ToFloat64(metrics.DBAnalyticsQueries.WithLabelValues("queued")); got != 1 {
+		t.Errorf("queued analytics gauge = %v, want 1", got)
+	}
+
+	// Releasing twice frees only one slot
+	release1()
+	release1()
+	release3 := <-acquired
+	if got := len(db.analytics.slots); got != 2 {
+		t.Errorf("slots in use = %d, want 2 after the queued query took the freed slot", got)
+	}
+
+	release2()
+	release3()
+	if got := len(db.analytics.slots); got != 0 {
+		t.Errorf("slots in use = %d, want 0 after all releases", got)
+	}
+}
+
+func TestAcquireAnalyticsSlot_QueueWaitEndsWithContext(t *testing.T) {
+	db := &DB{analytics: newAnalyticsLimiter(1)}
+
+	release, err := db.AcquireAnalyticsSlot(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireAnalyticsSlot() error = %v", err)
+	}
+	defer release()
+
+	abandoned := testutil.ToFloat64(metrics.DBAnalyticsQueueAbandoned)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	queuedRelease, err := db.AcquireAnalyticsSlot(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AcquireAnalyticsSlot() error = %v, want context.DeadlineExceeded from the query budget", err)
+	}
+	queuedRelease() // a no-op, safe to defer on error
+
+	if got := len(db.analytics.slots); got != 1 {
+		t.Errorf("slots in use = %d, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.DBAnalyticsQueueAbandoned) - abandoned; got != 1 {
+		t.Errorf("abandoned waits = %v, want 1", got)
+	}
+}
+
+func TestAcquireAnalyticsSlot_HeldByCaller(t *testing.T) {
+	db := &DB{analytics: newAnalyticsLimiter(1)}
+
+	release, err := db.AcquireAnalyticsSlot(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireAnalyticsSlot() error = %v", err)
+	}
+	defer release()
+
+	// A handler running inside the held slot does not wait for another one
+	ctx, cancel := context.WithTimeout(WithAnalyticsSlot(context.Background()), 20*time.Millisecond)
+	defer cancel()
+	nested, err := db.AcquireAnalyticsSlot(ctx)
+	if err != nil {
+		t.Fatalf("nested AcquireAnalyticsSlot() error = %v, want the held slot reused", err)
+	}
+	nested()
+
+	if got := len(db.analytics.slots); got != 1 {
+		t.Errorf("slots in use = %d, want 1 after releasing the nested acquisition", got)
+	}
+}
+
+func TestAcquireAnalyticsSlot_Unlimited(t *testing.T) {
+	db := &DB{}
+	release, err := db.AcquireAnalyticsSlot(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireAnalyticsSlot() without a limiter error = %v", err)
+	}
+	release()
+}
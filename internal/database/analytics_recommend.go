@@ -8,6 +8,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,7 +28,7 @@ func (db *DB) GetRecommendationInteractions(ctx context.Context, since time.Time
 				COUNT(*) AS play_count,
 				MAX(started_at) AS last_played,
 				session_key
-			FROM playbacks
+			FROM playback_events
 			WHERE started_at >= ?
 			  AND user_id IS NOT NULL
 			  AND rating_key IS NOT NULL
@@ -97,6 +98,25 @@ func (db *DB) GetRecommendationInteractions(ctx context.Context, since time.Time
 	return interactions, nil
 }
 
+// CountRecommendationInteractions returns the number of playback events usable
+// as recommendation interactions that were recorded since the given time.
+// Recording time is used rather than start time so backfilled history counts.
+func (db *DB) CountRecommendationInteractions(ctx context.Context, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM playback_events
+		WHERE created_at >= ?
+		  AND user_id IS NOT NULL
+		  AND rating_key IS NOT NULL
+	`
+
+	var count int
+	if err := db.conn.QueryRowContext(ctx, query, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count interactions: %w", err)
+	}
+	return count, nil
+}
+
 // GetRecommendationItems returns item metadata for recommendation training.
 func (db *DB) GetRecommendationItems(ctx context.Context) ([]recommend.Item, error) {
 	query := `
@@ -110,11 +130,11 @@ func (db *DB) GetRecommendationItems(ctx context.Context) ([]recommend.Item, err
 			COALESCE(year, 0) AS year,
 			COALESCE(studio, '') AS studio,
 			COALESCE(content_rating, '') AS content_rating,
-			COALESCE(rating, 0) AS rating,
-			COALESCE(audience_rating, 0) AS audience_rating,
-			COALESCE(parent_rating_key, 0) AS parent_id,
-			COALESCE(grandparent_rating_key, 0) AS grandparent_id
-		FROM playbacks
+			COALESCE(TRY_CAST(rating AS DOUBLE), 0) AS rating,
+			COALESCE(TRY_CAST(audience_rating AS DOUBLE), 0) AS audience_rating,
+			COALESCE(TRY_CAST(parent_rating_key AS INTEGER), 0) AS parent_id,
+			COALESCE(TRY_CAST(grandparent_rating_key AS INTEGER), 0) AS grandparent_id
+		FROM playback_events
 		WHERE rating_key IS NOT NULL
 		ORDER BY rating_key, started_at DESC
 	`
@@ -179,7 +199,7 @@ func (db *DB) GetRecommendationItems(ctx context.Context) ([]recommend.Item, err
 func (db *DB) GetUserWatchHistory(ctx context.Context, userID int) ([]int, error) {
 	query := `
 		SELECT DISTINCT rating_key
-		FROM playbacks
+		FROM playback_events
 		WHERE user_id = ?
 		  AND rating_key IS NOT NULL
 	`
@@ -215,12 +235,12 @@ func (db *DB) GetRecommendationCandidates(ctx context.Context, userID int, limit
 	query := `
 		WITH user_watched AS (
 			SELECT DISTINCT rating_key
-			FROM playbacks
+			FROM playback_events
 			WHERE user_id = ?
 		),
 		all_items AS (
 			SELECT DISTINCT rating_key
-			FROM playbacks
+			FROM playback_events
 			WHERE rating_key IS NOT NULL
 		)
 		SELECT rating_key
@@ -273,7 +293,7 @@ func (db *DB) GetContinueWatchingItems(ctx context.Context, userID int, limit in
 					PARTITION BY COALESCE(grandparent_rating_key, parent_rating_key, rating_key)
 					ORDER BY started_at DESC
 				) AS rn
-			FROM playbacks
+			FROM playback_events
 			WHERE user_id = ?
 			  AND percent_complete < 90
 			  AND percent_complete > 5
@@ -390,8 +410,9 @@ func (db *DB) GetMediaItemByID(ctx context.Context, itemID int) (*recommend.Item
 			COALESCE(title, '') AS title,
 			COALESCE(media_type, 'unknown') AS media_type,
 			COALESCE(genres, '') AS genres,
-			COALESCE(year, 0) AS year
-		FROM playbacks
+			COALESCE(year, 0) AS year,
+			COALESCE(thumb, '') AS thumb
+		FROM playback_events
 		WHERE rating_key = ?
 		ORDER BY rating_key, started_at DESC
 		LIMIT 1
@@ -406,9 +427,10 @@ func (db *DB) GetMediaItemByID(ctx context.Context, itemID int) (*recommend.Item
 		mediaType string
 		genresStr string
 		year      int
+		thumb     string
 	)
 
-	err := db.conn.QueryRowContext(ctx, query, itemID).Scan(&id, &title, &mediaType, &genresStr, &year)
+	err := db.conn.QueryRowContext(ctx, query, itemID).Scan(&id, &title, &mediaType, &genresStr, &year, &thumb)
 	if err != nil {
 		return nil, fmt.Errorf("query item by ID: %w", err)
 	}
@@ -428,9 +450,102 @@ func (db *DB) GetMediaItemByID(ctx context.Context, itemID int) (*recommend.Item
 		MediaType: mediaType,
 		Genres:    genres,
 		Year:      year,
+		Thumb:     thumb,
 	}, nil
 }
 
+// GetMediaItemsByIDs returns catalog metadata for the given item IDs, keyed by
+// ID. IDs with no playback history are omitted from the result.
+func (db *DB) GetMediaItemsByIDs(ctx context.Context, itemIDs []int) (map[int]recommend.Item, error) {
+	items := make(map[int]recommend.Item, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return items, nil
+	}
+
+	placeholders := make([]string, len(itemIDs))
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		placeholders[i] = "?"
+		args[i] = strconv.Itoa(id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT DISTINCT ON (rating_key)
+			rating_key AS id,
+			COALESCE(title, '') AS title,
+			COALESCE(media_type, 'unknown') AS media_type,
+			COALESCE(genres, '') AS genres,
+			COALESCE(year, 0) AS year,
+			COALESCE(thumb, '') AS thumb
+		FROM playback_events
+		WHERE rating_key IN (%s)
+		ORDER BY rating_key, started_at DESC
+	`, strings.Join(placeholders, ", "))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query items by ID: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item recommend.Item
+		var genresStr string
+		if err := rows.Scan(&item.ID, &item.Title, &item.MediaType, &genresStr, &item.Year, &item.Thumb); err != nil {
+			return nil, fmt.Errorf("scan item: %w", err)
+		}
+		item.Genres = splitAndTrim(genresStr)
+		items[item.ID] = item
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetRecentlyWatchedItemIDs returns the distinct item IDs a user has watched,
+// most recently watched first.
+func (db *DB) GetRecentlyWatchedItemIDs(ctx context.Context, userID int, limit int) ([]int, error) {
+	query := `
+		SELECT rating_key
+		FROM playback_events
+		WHERE user_id = ?
+		  AND rating_key IS NOT NULL
+		GROUP BY rating_key
+		ORDER BY MAX(started_at) DESC
+		LIMIT ?
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query recently watched: %w", err)
+	}
+	defer rows.Close()
+
+	var itemIDs []int
+	for rows.Next() {
+		var itemID int
+		if err := rows.Scan(&itemID); err != nil {
+			return nil, fmt.Errorf("scan item id: %w", err)
+		}
+		itemIDs = append(itemIDs, itemID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recently watched: %w", err)
+	}
+
+	return itemIDs, nil
+}
+
 // NewRecommendationDataProvider creates a new data provider.
 func NewRecommendationDataProvider(db *DB) *RecommendationDataProvider {
 	return &RecommendationDataProvider{db: db}
@@ -441,6 +556,11 @@ func (p *RecommendationDataProvider) GetInteractions(ctx context.Context, since
 	return p.db.GetRecommendationInteractions(ctx, since)
 }
 
+// CountInteractions implements recommend.InteractionCounter.
+func (p *RecommendationDataProvider) CountInteractions(ctx context.Context, since time.Time) (int, error) {
+	return p.db.CountRecommendationInteractions(ctx, since)
+}
+
 // GetItems implements recommend.DataProvider.
 func (p *RecommendationDataProvider) GetItems(ctx context.Context) ([]recommend.Item, error) {
 	return p.db.GetRecommendationItems(ctx)
@@ -456,5 +576,19 @@ func (p *RecommendationDataProvider) GetCandidates(ctx context.Context, userID i
 	return p.db.GetRecommendationCandidates(ctx, userID, limit)
 }
 
+// GetFeedback implements recommend.FeedbackProvider.
+func (p *RecommendationDataProvider) GetFeedback(ctx context.Context) ([]recommend.Feedback, error) {
+	return p.db.GetRecommendationFeedback(ctx)
+}
+
+// GetUserFeedback implements recommend.FeedbackProvider.
+func (p *RecommendationDataProvider) GetUserFeedback(ctx context.Context, userID int) ([]recommend.Feedback, error) {
+	return p.db.GetUserRecommendationFeedback(ctx, userID)
+}
+
 // Ensure interface compliance.
-var _ recommend.DataProvider = (*RecommendationDataProvider)(nil)
+var (
+	_ recommend.DataProvider       = (*RecommendationDataProvider)(nil)
+	_ recommend.FeedbackProvider   = (*RecommendationDataProvider)(nil)
+	_ recommend.InteractionCounter = (*RecommendationDataProvider)(nil)
+)
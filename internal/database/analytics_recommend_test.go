@@ -6,7 +6,9 @@
 package database
 
 import (
+	"context"
 	"testing"
+	"time"
 )
 
 func TestSplitAndTrim(t *testing.T) {
@@ -65,6 +67,80 @@ func TestSplitAndTrim(t *testing.T) {
 	}
 }
 
+func TestRecommendationCatalogQueries(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	playbacks := []struct {
+		ratingKey string
+		userID    int
+		title     string
+		thumb     string
+		startedAt time.Time
+	}{
+		{"101", 1, "Alien", "/library/metadata/101/thumb", now.Add(-3 * time.Hour)},
+		{"102", 1, "Aliens", "/library/metadata/102/thumb", now.Add(-1 * time.Hour)},
+		{"101", 1, "Alien", "/library/metadata/101/thumb", now.Add(-30 * time.Minute)},
+		{"103", 2, "Heat", "", now.Add(-2 * time.Hour)},
+	}
+	for i, pb := range playbacks {
+		_, err := db.conn.Exec(`
+			INSERT INTO playback_events (
+				id, session_key, started_at, user_id, username, ip_address,
+				media_type, title, rating_key, year, genres, thumb
+			) VALUES (gen_random_uuid(), ?, ?, ?, 'user', '127.0.0.1', 'movie', ?, ?, 1986, 'Action, Sci-Fi', ?)
+		`, "rec-session-"+string(rune('a'+i)), pb.startedAt, pb.userID, pb.title, pb.ratingKey, pb.thumb)
+		checkNoError(t, err)
+	}
+
+	recent, err := db.GetRecentlyWatchedItemIDs(ctx, 1, 10)
+	checkNoError(t, err)
+	if len(recent) != 2 || recent[0] != 101 || recent[1] != 102 {
+		t.Errorf("GetRecentlyWatchedItemIDs() = %v, want [101 102]", recent)
+	}
+
+	items, err := db.GetMediaItemsByIDs(ctx, []int{101, 103, 999})
+	checkNoError(t, err)
+	if len(items) != 2 {
+		t.Fatalf("GetMediaItemsByIDs() returned %d items, want 2", len(items))
+	}
+	alien := items[101]
+	if alien.Title != "Alien" || alien.Year != 1986 || alien.Thumb != "/library/metadata/101/thumb" || len(alien.Genres) != 2 {
+		t.Errorf("item 101 = %+v, want Alien (1986) with thumb and 2 genres", alien)
+	}
+
+	item, err := db.GetMediaItemByID(ctx, 102)
+	checkNoError(t, err)
+	if item.Title != "Aliens" || item.Thumb == "" {
+		t.Errorf("GetMediaItemByID(102) = %+v, want Aliens with thumb", item)
+	}
+
+	history, err := db.GetUserWatchHistory(ctx, 2)
+	checkNoError(t, err)
+	if len(history) != 1 || history[0] != 103 {
+		t.Errorf("GetUserWatchHistory(2) = %v, want [103]", history)
+	}
+
+	candidates, err := db.GetRecommendationCandidates(ctx, 2, 10)
+	checkNoError(t, err)
+	if len(candidates) != 2 {
+		t.Errorf("GetRecommendationCandidates(2) = %v, want the 2 items user 2 has not watched", candidates)
+	}
+
+	// Counts go by recording time, so all four rows are new
+	count, err := db.CountRecommendationInteractions(ctx, now.Add(-time.Hour))
+	checkNoError(t, err)
+	if count != 4 {
+		t.Errorf("CountRecommendationInteractions(recent) = %d, want 4", count)
+	}
+	count, err = db.CountRecommendationInteractions(ctx, now.Add(time.Hour))
+	checkNoError(t, err)
+	if count != 0 {
+		t.Errorf("CountRecommendationInteractions(future) = %d, want 0", count)
+	}
+}
+
 // Note: Integration tests for recommendation queries would require a test database.
 // These are covered by the integration test suite using testcontainers.
 // See: internal/testinfra/duckdb_test.go for patterns.
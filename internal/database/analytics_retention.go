@@ -0,0 +1,177 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// Retention matrix buckets accepted by GetRetentionCohorts
+const (
+	RetentionBucketDay   = "day"
+	RetentionBucketWeek  = "week"
+	RetentionBucketMonth = "month"
+)
+
+// Bounds on the retention matrix: the most recent cohorts are kept, and
+// offsets past the last column only count towards rolling retention.
+const (
+	maxRetentionCohorts = 52
+	maxRetentionPeriods = 26
+)
+
+// GetRetentionCohorts builds a cohort retention matrix. Users are assigned to
+// the bucket ("day", "week" or "month"; empty means "week") of their first
+// playback matching the filter, and each row reports how many of them played
+// again 1, 2, ... buckets later, as well as rolling retention (played that
+// many buckets later or after).
+//
+// The matrix holds at most maxRetentionCohorts rows of maxRetentionPeriods
+// columns; when there are more cohorts the oldest are dropped and Truncated
+// is set.
+func (db *DB) GetRetentionCohorts(ctx context.Context, filter LocationStatsFilter, bucket string) (*models.RetentionMatrix, error) {
+	if bucket == "" {
+		bucket = RetentionBucketWeek
+	}
+	switch bucket {
+	case RetentionBucketDay, RetentionBucketWeek, RetentionBucketMonth:
+	default:
+		return nil, fmt.Errorf("invalid retention bucket %q: must be day, week or month", bucket)
+	}
+
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	whereClauses, args := buildFilterConditions(filter, false, 1)
+	whereClause := "1=1"
+	if len(whereClauses) > 0 {
+		whereClause = join(whereClauses, " AND ")
+	}
+
+	// Window functions tag every (user, bucket) row with the user's first and
+	// last bucket and the latest bucket overall. Offsets at or past the last
+	// column are folded into an overflow column that only feeds "ending".
+	query := fmt.Sprintf(`
+		WITH activity AS (
+			SELECT DISTINCT user_id, DATE_TRUNC('%[1]s', started_at) AS bucket
+			FROM playback_events
+			WHERE %[2]s
+		),
+		seen AS (
+			SELECT
+				user_id,
+				bucket,
+				MIN(bucket) OVER (PARTITION BY user_id) AS cohort,
+				MAX(bucket) OVER (PARTITION BY user_id) AS last_seen,
+				MAX(bucket) OVER () AS latest
+			FROM activity
+		),
+		offsets AS (
+			SELECT
+				cohort,
+				DATEDIFF('%[1]s', cohort, bucket) AS period,
+				DATEDIFF('%[1]s', cohort, last_seen) AS last_period,
+				DATEDIFF('%[1]s', cohort, latest) AS max_period,
+				DENSE_RANK() OVER (ORDER BY cohort DESC) AS cohort_rank
+			FROM seen
+		)
+		SELECT
+			cohort,
+			LEAST(period, ?) AS column_index,
+			MAX(max_period) AS max_period,
+			COUNT(*) FILTER (WHERE period < ?) AS active,
+			COUNT(*) FILTER (WHERE period = last_period) AS ending,
+			(SELECT COUNT(DISTINCT cohort) FROM seen) AS total_cohorts
+		FROM offsets
+		WHERE cohort_rank <= ?
+		GROUP BY cohort, column_index
+		ORDER BY cohort, column_index
+	`, bucket, whereClause)
+
+	fullArgs := append([]interface{}{}, args...)
+	fullArgs = append(fullArgs, maxRetentionPeriods, maxRetentionPeriods, maxRetentionCohorts)
+
+	rows, err := db.conn.QueryContext(ctx, query, fullArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("query retention cohorts: %w", err)
+	}
+	defer rows.Close()
+
+	matrix := &models.RetentionMatrix{
+		Bucket:  bucket,
+		Periods: maxRetentionPeriods,
+		Cohorts: []models.RetentionCohort{},
+	}
+
+	// ending[n] counts users whose last bucket is n buckets after the cohort;
+	// index maxRetentionPeriods is the overflow column.
+	var current *models.RetentionCohort
+	var ending []int
+	flush := func() {
+		if current != nil {
+			finishRetentionCohort(current, ending)
+			matrix.Cohorts = append(matrix.Cohorts, *current)
+		}
+	}
+
+	for rows.Next() {
+		var cohortStart time.Time
+		var column, maxPeriod, active, ended, totalCohorts int
+		if err := rows.Scan(&cohortStart, &column, &maxPeriod, &active, &ended, &totalCohorts); err != nil {
+			return nil, fmt.Errorf("scan retention cohort row: %w", err)
+		}
+		matrix.Truncated = totalCohorts > maxRetentionCohorts
+
+		if current == nil || !current.CohortStart.Equal(cohortStart) {
+			flush()
+			columns := min(maxPeriod+1, maxRetentionPeriods)
+			current = &models.RetentionCohort{
+				CohortStart: cohortStart,
+				Active:      make([]int, columns),
+			}
+			ending = make([]int, maxRetentionPeriods+1)
+		}
+
+		if column < len(current.Active) {
+			current.Active[column] = active
+		}
+		ending[column] = ended
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate retention cohort rows: %w", err)
+	}
+	flush()
+
+	return matrix, nil
+}
+
+// finishRetentionCohort fills in the cohort size and the retention
+// percentages from the active counts and the per-offset last-seen counts.
+func finishRetentionCohort(cohort *models.RetentionCohort, ending []int) {
+	// Every user is active in their cohort bucket
+	cohort.Users = cohort.Active[0]
+	cohort.Retention = make([]float64, len(cohort.Active))
+	cohort.RollingRetention = make([]float64, len(cohort.Active))
+	if cohort.Users == 0 {
+		return
+	}
+
+	// Users retained at offset n or later = users last seen at n or later
+	retained := 0
+	for n := len(ending) - 1; n >= 0; n-- {
+		retained += ending[n]
+		if n < len(cohort.Active) {
+			cohort.RollingRetention[n] = float64(retained) / float64(cohort.Users) * 100.0
+		}
+	}
+	for n, active := range cohort.Active {
+		cohort.Retention[n] = float64(active) / float64(cohort.Users) * 100.0
+	}
+}
@@ -0,0 +1,212 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// retentionWeek0 is a Monday, the start of a DuckDB 'week' bucket
+var retentionWeek0 = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+// retentionWeek returns a time within the n-th week after retentionWeek0
+func retentionWeek(n int) time.Time {
+	return retentionWeek0.AddDate(0, 0, 7*n).Add(20 * time.Hour)
+}
+
+// insertRetentionPlayback inserts one playback for a user at startedAt
+func insertRetentionPlayback(t *testing.T, db *DB, userID int, startedAt time.Time) {
+	t.Helper()
+
+	_, err := db.conn.Exec(`
+		INSERT INTO playback_events (
+			id, session_key, started_at, stopped_at, user_id, username,
+			ip_address, media_type, title, percent_complete, play_duration
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, uuid.New().String(), uuid.New().String(), startedAt, startedAt.Add(time.Hour),
+		userID, fmt.Sprintf("user%d", userID), "192.168.1.1", "movie", "Test Movie", 100, 60)
+	if err != nil {
+		t.Fatalf("Failed to insert retention playback: %v", err)
+	}
+}
+
+// insertRetentionTestData seeds two weekly cohorts with known return weeks:
+//
+//	week 0 cohort: user1 (weeks 0,1,2), user2 (0,1), user3 (0,2), user4 (0)
+//	week 1 cohort: user5 (weeks 1,2), user6 (1)
+func insertRetentionTestData(t *testing.T, db *DB) {
+	t.Helper()
+
+	activity := map[int][]int{
+		1: {0, 0, 1, 2}, // two plays in week 0 count once
+		2: {0, 1},
+		3: {0, 2},
+		4: {0},
+		5: {1, 2},
+		6: {1},
+	}
+	for userID, weeks := range activity {
+		for _, week := range weeks {
+			insertRetentionPlayback(t, db, userID, retentionWeek(week))
+		}
+	}
+}
+
+func TestGetRetentionCohorts_Weekly(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	insertRetentionTestData(t, db)
+
+	matrix, err := db.GetRetentionCohorts(context.Background(), LocationStatsFilter{}, "week")
+	if err != nil {
+		t.Fatalf("GetRetentionCohorts() error = %v", err)
+	}
+	if matrix.Bucket != "week" || matrix.Periods != maxRetentionPeriods || matrix.Truncated {
+		t.Errorf("matrix bucket/periods/truncated = %s/%d/%v", matrix.Bucket, matrix.Periods, matrix.Truncated)
+	}
+	if len(matrix.Cohorts) != 2 {
+		t.Fatalf("got %d cohorts, want 2", len(matrix.Cohorts))
+	}
+
+	first, second := matrix.Cohorts[0], matrix.Cohorts[1]
+	if !first.CohortStart.Equal(retentionWeek0) || !second.CohortStart.Equal(retentionWeek0.AddDate(0, 0, 7)) {
+		t.Errorf("cohort starts = %v, %v", first.CohortStart, second.CohortStart)
+	}
+
+	// The last bucket with data is week 2, so the week 1 cohort has two columns
+	assertRetentionRow(t, "week 0", first.Users, first.Active, first.Retention, first.RollingRetention,
+		4, []int{4, 2, 2}, []float64{100, 50, 50}, []float64{100, 75, 50})
+	assertRetentionRow(t, "week 1", second.Users, second.Active, second.Retention, second.RollingRetention,
+		2, []int{2, 1}, []float64{100, 50}, []float64{100, 50})
+}
+
+func TestGetRetentionCohorts_Filter(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	insertRetentionTestData(t, db)
+
+	filter := LocationStatsFilter{Users: []string{"user1", "user2"}}
+	matrix, err := db.GetRetentionCohorts(context.Background(), filter, "")
+	if err != nil {
+		t.Fatalf("GetRetentionCohorts() error = %v", err)
+	}
+	if matrix.Bucket != "week" {
+		t.Errorf("default bucket = %s, want week", matrix.Bucket)
+	}
+	if len(matrix.Cohorts) != 1 {
+		t.Fatalf("got %d cohorts, want 1", len(matrix.Cohorts))
+	}
+
+	cohort := matrix.Cohorts[0]
+	assertRetentionRow(t, "filtered", cohort.Users, cohort.Active, cohort.Retention, cohort.RollingRetention,
+		2, []int{2, 2, 1}, []float64{100, 100, 50}, []float64{100, 100, 50})
+}
+
+func TestGetRetentionCohorts_Monthly(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	insertRetentionTestData(t, db)
+
+	matrix, err := db.GetRetentionCohorts(context.Background(), LocationStatsFilter{}, "month")
+	if err != nil {
+		t.Fatalf("GetRetentionCohorts() error = %v", err)
+	}
+	if len(matrix.Cohorts) != 1 {
+		t.Fatalf("got %d cohorts, want 1", len(matrix.Cohorts))
+	}
+
+	// Every play is in January 2025
+	cohort := matrix.Cohorts[0]
+	if !cohort.CohortStart.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CohortStart = %v, want 2025-01-01", cohort.CohortStart)
+	}
+	assertRetentionRow(t, "january", cohort.Users, cohort.Active, cohort.Retention, cohort.RollingRetention,
+		6, []int{6}, []float64{100}, []float64{100})
+}
+
+func TestGetRetentionCohorts_Bounded(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// One new user per week for 60 weeks; the week 20 user (user 21) comes back
+	// 30 weeks later, past the last column
+	for week := 0; week < 60; week++ {
+		insertRetentionPlayback(t, db, week+1, retentionWeek(week))
+	}
+	insertRetentionPlayback(t, db, 21, retentionWeek(50))
+
+	matrix, err := db.GetRetentionCohorts(context.Background(), LocationStatsFilter{}, "week")
+	if err != nil {
+		t.Fatalf("GetRetentionCohorts() error = %v", err)
+	}
+	if !matrix.Truncated || len(matrix.Cohorts) != maxRetentionCohorts {
+		t.Fatalf("got %d cohorts (truncated=%v), want %d truncated", len(matrix.Cohorts), matrix.Truncated, maxRetentionCohorts)
+	}
+
+	// The 8 oldest cohorts are dropped
+	if want := retentionWeek0.AddDate(0, 0, 7*8); !matrix.Cohorts[0].CohortStart.Equal(want) {
+		t.Errorf("oldest cohort = %v, want %v", matrix.Cohorts[0].CohortStart, want)
+	}
+
+	cohort := matrix.Cohorts[20-8]
+	if len(cohort.Active) != maxRetentionPeriods {
+		t.Fatalf("row has %d columns, want %d", len(cohort.Active), maxRetentionPeriods)
+	}
+	if cohort.Retention[maxRetentionPeriods-1] != 0 || cohort.RollingRetention[maxRetentionPeriods-1] != 100 {
+		t.Errorf("last column retention/rolling = %v/%v, want 0/100",
+			cohort.Retention[maxRetentionPeriods-1], cohort.RollingRetention[maxRetentionPeriods-1])
+	}
+
+	latest := matrix.Cohorts[len(matrix.Cohorts)-1]
+	if len(latest.Active) != 1 {
+		t.Errorf("latest cohort has %d columns, want 1", len(latest.Active))
+	}
+}
+
+func TestGetRetentionCohorts_Empty(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	matrix, err := db.GetRetentionCohorts(context.Background(), LocationStatsFilter{}, "day")
+	if err != nil {
+		t.Fatalf("GetRetentionCohorts() error = %v", err)
+	}
+	if matrix.Cohorts == nil || len(matrix.Cohorts) != 0 {
+		t.Errorf("Cohorts = %v, want empty slice", matrix.Cohorts)
+	}
+}
+
+func TestGetRetentionCohorts_InvalidBucket(t *testing.T) {
+	db := &DB{}
+	if _, err := db.GetRetentionCohorts(context.Background(), LocationStatsFilter{}, "year'; DROP TABLE playback_events; --"); err == nil {
+		t.Error("expected error for invalid bucket")
+	}
+}
+
+// assertRetentionRow compares one matrix row against expected values
+func assertRetentionRow(t *testing.T, name string, users int, active []int, retention, rolling []float64,
+	wantUsers int, wantActive []int, wantRetention, wantRolling []float64) {
+	t.Helper()
+
+	if users != wantUsers {
+		t.Errorf("%s: Users = %d, want %d", name, users, wantUsers)
+	}
+	if !reflect.DeepEqual(active, wantActive) {
+		t.Errorf("%s: Active = %v, want %v", name, active, wantActive)
+	}
+	if !reflect.DeepEqual(retention, wantRetention) {
+		t.Errorf("%s: Retention = %v, want %v", name, retention, wantRetention)
+	}
+	if !reflect.DeepEqual(rolling, wantRolling) {
+		t.Errorf("%s: RollingRetention = %v, want %v", name, rolling, wantRolling)
+	}
+}
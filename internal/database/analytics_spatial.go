@@ -12,6 +12,30 @@ import (
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
+// H3 resolutions with a precomputed geolocations.h3_index_<n> column
+const (
+	MinH3Resolution = 6
+	MaxH3Resolution = 8
+)
+
+// H3ResolutionForZoom returns the H3 resolution whose hexagons are roughly
+// 10-20 pixels wide at the given web map zoom level, clamped to
+// MinH3Resolution..MaxH3Resolution. Hexagons finer than that blur into
+// noise when zoomed out; coarser ones hide detail when zoomed in.
+//
+// A resolution 6 hexagon is ~7 km across, 7 ~2.8 km and 8 ~1 km; a pixel
+// at the equator covers 156543/2^zoom meters.
+func H3ResolutionForZoom(zoom int) int {
+	switch {
+	case zoom < 9:
+		return MinH3Resolution
+	case zoom < 11:
+		return 7
+	default:
+		return MaxH3Resolution
+	}
+}
+
 // GetH3AggregatedHexagons returns pre-aggregated hexagon data using H3 spatial indexing
 // This is 10x faster than client-side hexagon aggregation in deck.gl
 // Resolution: 6 (country), 7 (city), 8 (neighborhood)
@@ -23,7 +47,7 @@ func (db *DB) GetH3AggregatedHexagons(ctx context.Context, filter LocationStatsF
 		return nil, fmt.Errorf("spatial extension not available")
 	}
 
-	if resolution < 6 || resolution > 8 {
+	if resolution < MinH3Resolution || resolution > MaxH3Resolution {
 		return nil, fmt.Errorf("resolution must be 6, 7, or 8")
 	}
 
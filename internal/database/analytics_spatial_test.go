@@ -0,0 +1,36 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import "testing"
+
+func TestH3ResolutionForZoom(t *testing.T) {
+	prev := H3ResolutionForZoom(-1)
+	if prev != MinH3Resolution {
+		t.Errorf("H3ResolutionForZoom(-1) = %d, want %d", prev, MinH3Resolution)
+	}
+
+	for zoom := 0; zoom <= 24; zoom++ {
+		res := H3ResolutionForZoom(zoom)
+		if res < MinH3Resolution || res > MaxH3Resolution {
+			t.Errorf("H3ResolutionForZoom(%d) = %d, want %d-%d", zoom, res, MinH3Resolution, MaxH3Resolution)
+		}
+		if res < prev {
+			t.Errorf("H3ResolutionForZoom(%d) = %d, coarser than %d at zoom %d", zoom, res, prev, zoom-1)
+		}
+		prev = res
+	}
+
+	if got := H3ResolutionForZoom(0); got != MinH3Resolution {
+		t.Errorf("H3ResolutionForZoom(0) = %d, want %d", got, MinH3Resolution)
+	}
+	if got := H3ResolutionForZoom(24); got != MaxH3Resolution {
+		t.Errorf("H3ResolutionForZoom(24) = %d, want %d", got, MaxH3Resolution)
+	}
+	if got := H3ResolutionForZoom(100); got != MaxH3Resolution {
+		t.Errorf("H3ResolutionForZoom(100) = %d, want %d", got, MaxH3Resolution)
+	}
+}
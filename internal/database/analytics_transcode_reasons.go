@@ -0,0 +1,218 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Transcode reasons, in the order they are checked. A transcode session is
+// attributed to the first reason that matches its source and stream properties.
+const (
+	TranscodeReasonVideoCodec = "video_codec" // Video codec changed (client can't decode the source)
+	TranscodeReasonResolution = "resolution"  // Video resolution reduced
+	TranscodeReasonBandwidth  = "bandwidth"   // Same codec and resolution, bitrate reduced
+	TranscodeReasonAudioCodec = "audio_codec" // Only the audio codec changed
+	TranscodeReasonOther      = "other"       // Container, subtitles, or unknown
+)
+
+// transcodeReasonExpr classifies a transcode session into one of the
+// TranscodeReason constants from the stored source and stream properties.
+const transcodeReasonExpr = `
+	CASE
+		WHEN video_codec IS NOT NULL AND COALESCE(transcode_video_codec, stream_video_codec) IS NOT NULL
+		     AND LOWER(COALESCE(transcode_video_codec, stream_video_codec)) != LOWER(video_codec) THEN 'video_codec'
+		WHEN video_resolution IS NOT NULL AND stream_video_resolution IS NOT NULL
+		     AND LOWER(stream_video_resolution) != LOWER(video_resolution) THEN 'resolution'
+		WHEN COALESCE(video_decision, 'transcode') = 'transcode'
+		     AND bitrate > 0 AND stream_bitrate > 0 AND stream_bitrate < bitrate THEN 'bandwidth'
+		WHEN audio_codec IS NOT NULL AND COALESCE(transcode_audio_codec, stream_audio_codec) IS NOT NULL
+		     AND LOWER(COALESCE(transcode_audio_codec, stream_audio_codec)) != LOWER(audio_codec) THEN 'audio_codec'
+		ELSE 'other'
+	END`
+
+// TranscodeReasonAnalytics summarizes why and how much the server transcodes
+type TranscodeReasonAnalytics struct {
+	TotalSessions       int     `json:"total_sessions"`
+	TranscodeSessions   int     `json:"transcode_sessions"`
+	TranscodePercentage float64 `json:"transcode_percentage"`
+
+	// Reasons attributes every transcode session to exactly one reason
+	Reasons []TranscodeReasonStats `json:"reasons"`
+
+	// Concurrency across hours with playback activity
+	AvgConcurrentTranscodes  float64   `json:"avg_concurrent_transcodes"`
+	PeakConcurrentTranscodes int       `json:"peak_concurrent_transcodes"`
+	PeakTime                 time.Time `json:"peak_time"`
+
+	// Hourly concurrent transcodes within the date range
+	Hourly []TranscodeHourBucket `json:"hourly"`
+}
+
+// TranscodeReasonStats represents the transcode sessions attributed to one reason
+type TranscodeReasonStats struct {
+	Reason       string  `json:"reason"` // One of the TranscodeReason constants
+	SessionCount int     `json:"session_count"`
+	Percentage   float64 `json:"percentage"` // Of transcode sessions
+	AvgBitrate   float64 `json:"avg_stream_bitrate_kbps"`
+}
+
+// TranscodeHourBucket represents one hour of transcode concurrency
+type TranscodeHourBucket struct {
+	Hour                 time.Time `json:"hour"`
+	ActiveSessions       int       `json:"active_sessions"`
+	ConcurrentTranscodes int       `json:"concurrent_transcodes"`
+}
+
+// GetTranscodeReasonAnalytics retrieves transcode reasons and hourly transcode concurrency
+func (db *DB) GetTranscodeReasonAnalytics(ctx context.Context, filter LocationStatsFilter) (*TranscodeReasonAnalytics, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	whereClause, args := buildFilterWhereClause(filter)
+	stats := &TranscodeReasonAnalytics{}
+
+	// Use COALESCE to handle empty database (SUM returns NULL when no rows)
+	overviewQuery := fmt.Sprintf(`
+		SELECT
+			COUNT(*) as total_sessions,
+			COALESCE(SUM(CASE WHEN transcode_decision = 'transcode' THEN 1 ELSE 0 END), 0) as transcode_sessions
+		FROM playback_events
+		WHERE %s
+	`, whereClause)
+
+	if err := db.conn.QueryRowContext(ctx, overviewQuery, args...).Scan(&stats.TotalSessions, &stats.TranscodeSessions); err != nil {
+		return nil, fmt.Errorf("failed to query transcode overview: %w", err)
+	}
+	if stats.TotalSessions > 0 {
+		stats.TranscodePercentage = float64(stats.TranscodeSessions) / float64(stats.TotalSessions) * 100
+	}
+
+	reasons, err := db.getTranscodeReasons(ctx, whereClause, args, stats.TranscodeSessions)
+	if err != nil {
+		return nil, errorContext("get transcode reasons", err)
+	}
+	stats.Reasons = reasons
+
+	hourly, err := db.getTranscodeHourlyConcurrency(ctx, filter)
+	if err != nil {
+		return nil, errorContext("get hourly transcode concurrency", err)
+	}
+	stats.Hourly = hourly
+
+	var totalTranscodes int64
+	for _, bucket := range hourly {
+		totalTranscodes += int64(bucket.ConcurrentTranscodes)
+		if bucket.ConcurrentTranscodes > stats.PeakConcurrentTranscodes {
+			stats.PeakConcurrentTranscodes = bucket.ConcurrentTranscodes
+			stats.PeakTime = bucket.Hour
+		}
+	}
+	if len(hourly) > 0 {
+		stats.AvgConcurrentTranscodes = float64(totalTranscodes) / float64(len(hourly))
+	}
+
+	return stats, nil
+}
+
+// getTranscodeReasons groups transcode sessions by their attributed reason
+func (db *DB) getTranscodeReasons(ctx context.Context, whereClause string, args []interface{}, transcodeSessions int) ([]TranscodeReasonStats, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			%s as reason,
+			COUNT(*) as session_count,
+			COALESCE(AVG(stream_bitrate), 0) as avg_bitrate
+		FROM playback_events
+		WHERE %s AND transcode_decision = 'transcode'
+		GROUP BY reason
+		ORDER BY session_count DESC, reason
+	`, transcodeReasonExpr, whereClause)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transcode reasons: %w", err)
+	}
+	defer rows.Close()
+
+	reasons := make([]TranscodeReasonStats, 0, 5)
+	for rows.Next() {
+		var r TranscodeReasonStats
+		if err := rows.Scan(&r.Reason, &r.SessionCount, &r.AvgBitrate); err != nil {
+			return nil, fmt.Errorf("failed to scan transcode reason row: %w", err)
+		}
+		if transcodeSessions > 0 {
+			r.Percentage = float64(r.SessionCount) / float64(transcodeSessions) * 100
+		}
+		reasons = append(reasons, r)
+	}
+	return reasons, rows.Err()
+}
+
+// getTranscodeHourlyConcurrency counts the sessions and transcodes overlapping
+// each hour, using the same generate_series bucketing as the concurrent
+// streams analytics. Hours without playback are omitted.
+func (db *DB) getTranscodeHourlyConcurrency(ctx context.Context, filter LocationStatsFilter) ([]TranscodeHourBucket, error) {
+	whereClauses, args := buildFilterConditions(filter, false, 1)
+
+	whereAnd := ""
+	if len(whereClauses) > 0 {
+		whereAnd = "AND " + buildAndWhereClause(whereClauses)
+	}
+
+	query := fmt.Sprintf(`
+		WITH time_range AS (
+			SELECT
+				DATE_TRUNC('hour', MIN(started_at)) as min_time,
+				DATE_TRUNC('hour', MAX(stopped_at)) as max_time
+			FROM playback_events
+			WHERE stopped_at IS NOT NULL %s
+		),
+		time_buckets AS (
+			SELECT unnest(generate_series(
+				(SELECT min_time FROM time_range),
+				(SELECT max_time FROM time_range),
+				INTERVAL '1 hour'
+			)) as bucket_time
+			WHERE (SELECT min_time FROM time_range) IS NOT NULL
+		)
+		SELECT
+			tb.bucket_time as hour,
+			COUNT(DISTINCT pe.session_key) as active_sessions,
+			COUNT(DISTINCT CASE WHEN pe.transcode_decision = 'transcode' THEN pe.session_key END) as transcodes
+		FROM time_buckets tb
+		LEFT JOIN playback_events pe ON (
+			pe.started_at < tb.bucket_time + INTERVAL '1 hour'
+			AND pe.stopped_at >= tb.bucket_time
+			%s
+		)
+		GROUP BY tb.bucket_time
+		HAVING COUNT(DISTINCT pe.session_key) > 0
+		ORDER BY hour ASC
+	`, whereAnd, whereAnd)
+
+	// WHERE clause appears 2 times
+	allArgs := make([]interface{}, 0, len(args)*2)
+	allArgs = append(allArgs, args...)
+	allArgs = append(allArgs, args...)
+
+	rows, err := db.conn.QueryContext(ctx, query, allArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query hourly transcode concurrency: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []TranscodeHourBucket
+	for rows.Next() {
+		var b TranscodeHourBucket
+		if err := rows.Scan(&b.Hour, &b.ActiveSessions, &b.ConcurrentTranscodes); err != nil {
+			return nil, fmt.Errorf("failed to scan hourly transcode row: %w", err)
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
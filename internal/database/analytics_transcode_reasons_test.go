@@ -0,0 +1,192 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// transcodeReasonTestBase is the first hour of the seeded transcode data
+var transcodeReasonTestBase = time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+// Helper to insert playback events covering every transcode reason.
+//
+// Hour 0 has two video codec transcodes, a resolution transcode and a direct
+// play. Hour 1 has a bandwidth transcode and an audio-only transcode.
+func insertTranscodeReasonTestData(t *testing.T, db *DB) {
+	t.Helper()
+	insertTestGeolocations(t, db)
+
+	testEvents := []struct {
+		startMin, stopMin    int
+		decision, videoDec   string
+		videoCodec, stream   string
+		resolution, streamRs string
+		bitrate, streamBr    int
+		audioCodec, streamAC string
+	}{
+		// video_codec: HEVC transcoded to H.264
+		{0, 30, "transcode", "transcode", "hevc", "h264", "4k", "1080", 40000, 8000, "eac3", "eac3"},
+		{15, 40, "transcode", "transcode", "hevc", "h264", "1080", "1080", 12000, 8000, "aac", "aac"},
+		// resolution: H.264 4K downscaled to 1080p
+		{10, 50, "transcode", "transcode", "h264", "h264", "4k", "1080", 40000, 10000, "aac", "aac"},
+		// direct play
+		{5, 20, "direct play", "direct play", "h264", "h264", "1080", "1080", 8000, 8000, "aac", "aac"},
+		// bandwidth: same codec and resolution at a lower bitrate
+		{70, 100, "transcode", "transcode", "h264", "h264", "1080", "1080", 20000, 4000, "aac", "aac"},
+		// audio_codec: video copied, TrueHD transcoded to AAC
+		{75, 90, "transcode", "copy", "h264", "h264", "1080", "1080", 8000, 8000, "truehd", "aac"},
+	}
+
+	for i := range testEvents {
+		te := &testEvents[i]
+		stoppedAt := transcodeReasonTestBase.Add(time.Duration(te.stopMin) * time.Minute)
+		event := &models.PlaybackEvent{
+			ID:                    uuid.New(),
+			SessionKey:            uuid.New().String(),
+			StartedAt:             transcodeReasonTestBase.Add(time.Duration(te.startMin) * time.Minute),
+			StoppedAt:             &stoppedAt,
+			UserID:                1,
+			Username:              "testuser",
+			IPAddress:             "192.168.1.1",
+			MediaType:             "movie",
+			Title:                 "Test Movie",
+			TranscodeDecision:     &te.decision,
+			VideoDecision:         &te.videoDec,
+			VideoCodec:            &te.videoCodec,
+			StreamVideoCodec:      &te.stream,
+			VideoResolution:       &te.resolution,
+			StreamVideoResolution: &te.streamRs,
+			Bitrate:               &te.bitrate,
+			StreamBitrate:         &te.streamBr,
+			AudioCodec:            &te.audioCodec,
+			StreamAudioCodec:      &te.streamAC,
+			PercentComplete:       100,
+		}
+		if err := db.InsertPlaybackEvent(event); err != nil {
+			t.Fatalf("Failed to insert event: %v", err)
+		}
+	}
+}
+
+// TestGetTranscodeReasonAnalytics_Reasons tests the transcode reason buckets
+func TestGetTranscodeReasonAnalytics_Reasons(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	insertTranscodeReasonTestData(t, db)
+
+	stats, err := db.GetTranscodeReasonAnalytics(context.Background(), LocationStatsFilter{})
+	if err != nil {
+		t.Fatalf("GetTranscodeReasonAnalytics failed: %v", err)
+	}
+
+	if stats.TotalSessions != 6 {
+		t.Errorf("Expected 6 total sessions, got %d", stats.TotalSessions)
+	}
+	if stats.TranscodeSessions != 5 {
+		t.Errorf("Expected 5 transcode sessions, got %d", stats.TranscodeSessions)
+	}
+
+	want := map[string]int{
+		TranscodeReasonVideoCodec: 2,
+		TranscodeReasonResolution: 1,
+		TranscodeReasonBandwidth:  1,
+		TranscodeReasonAudioCodec: 1,
+	}
+	if len(stats.Reasons) != len(want) {
+		t.Fatalf("Expected %d reasons, got %+v", len(want), stats.Reasons)
+	}
+	if stats.Reasons[0].Reason != TranscodeReasonVideoCodec {
+		t.Errorf("Expected video_codec to be the top reason, got %s", stats.Reasons[0].Reason)
+	}
+	for _, r := range stats.Reasons {
+		if r.SessionCount != want[r.Reason] {
+			t.Errorf("Reason %s: expected %d sessions, got %d", r.Reason, want[r.Reason], r.SessionCount)
+		}
+		if wantPct := float64(want[r.Reason]) / 5 * 100; r.Percentage != wantPct {
+			t.Errorf("Reason %s: expected %.0f%%, got %.2f%%", r.Reason, wantPct, r.Percentage)
+		}
+	}
+}
+
+// TestGetTranscodeReasonAnalytics_HourlyConcurrency tests hourly buckets and the peak
+func TestGetTranscodeReasonAnalytics_HourlyConcurrency(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	insertTranscodeReasonTestData(t, db)
+
+	stats, err := db.GetTranscodeReasonAnalytics(context.Background(), LocationStatsFilter{})
+	if err != nil {
+		t.Fatalf("GetTranscodeReasonAnalytics failed: %v", err)
+	}
+
+	if len(stats.Hourly) != 2 {
+		t.Fatalf("Expected 2 hourly buckets, got %+v", stats.Hourly)
+	}
+	if stats.Hourly[0].ActiveSessions != 4 || stats.Hourly[0].ConcurrentTranscodes != 3 {
+		t.Errorf("Hour 0: expected 4 active / 3 transcodes, got %+v", stats.Hourly[0])
+	}
+	if stats.Hourly[1].ActiveSessions != 2 || stats.Hourly[1].ConcurrentTranscodes != 2 {
+		t.Errorf("Hour 1: expected 2 active / 2 transcodes, got %+v", stats.Hourly[1])
+	}
+
+	if stats.PeakConcurrentTranscodes != 3 {
+		t.Errorf("Expected peak of 3 concurrent transcodes, got %d", stats.PeakConcurrentTranscodes)
+	}
+	if !stats.PeakTime.Equal(transcodeReasonTestBase) {
+		t.Errorf("Expected peak at %v, got %v", transcodeReasonTestBase, stats.PeakTime)
+	}
+	if stats.AvgConcurrentTranscodes != 2.5 {
+		t.Errorf("Expected 2.5 average concurrent transcodes, got %.2f", stats.AvgConcurrentTranscodes)
+	}
+}
+
+// TestGetTranscodeReasonAnalytics_DateRange tests that the date filter limits both views
+func TestGetTranscodeReasonAnalytics_DateRange(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	insertTranscodeReasonTestData(t, db)
+
+	start := transcodeReasonTestBase.Add(time.Hour)
+	stats, err := db.GetTranscodeReasonAnalytics(context.Background(), LocationStatsFilter{StartDate: &start})
+	if err != nil {
+		t.Fatalf("GetTranscodeReasonAnalytics failed: %v", err)
+	}
+
+	if stats.TranscodeSessions != 2 {
+		t.Errorf("Expected 2 transcode sessions in range, got %d", stats.TranscodeSessions)
+	}
+	for _, r := range stats.Reasons {
+		if r.Reason != TranscodeReasonBandwidth && r.Reason != TranscodeReasonAudioCodec {
+			t.Errorf("Unexpected reason %s outside the date range", r.Reason)
+		}
+	}
+	if len(stats.Hourly) != 1 || stats.Hourly[0].ConcurrentTranscodes != 2 {
+		t.Errorf("Expected a single hour with 2 transcodes, got %+v", stats.Hourly)
+	}
+}
+
+// TestGetTranscodeReasonAnalytics_Empty tests an empty database
+func TestGetTranscodeReasonAnalytics_Empty(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	stats, err := db.GetTranscodeReasonAnalytics(context.Background(), LocationStatsFilter{})
+	if err != nil {
+		t.Fatalf("GetTranscodeReasonAnalytics failed: %v", err)
+	}
+	if stats.TotalSessions != 0 || len(stats.Reasons) != 0 || len(stats.Hourly) != 0 || stats.PeakConcurrentTranscodes != 0 {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+}
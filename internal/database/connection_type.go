@@ -0,0 +1,42 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import "github.com/tomtom215/cartographus/internal/models"
+
+// ConnectionClassifier classifies a client address as "residential",
+// "vpn", "datacenter" or "unknown". It is implemented by vpn.Service.
+type ConnectionClassifier interface {
+	ConnectionType(ip string) string
+}
+
+// SetConnectionClassifier sets the classifier that tags inserted playback
+// events with their connection type. Without one, events are stored
+// without a connection type unless they carry their own.
+func (db *DB) SetConnectionClassifier(classifier ConnectionClassifier) {
+	db.connClassifierMu.Lock()
+	defer db.connClassifierMu.Unlock()
+	db.connClassifier = classifier
+}
+
+// connectionType returns the connection type to store for an event: its
+// own, or the classification of its raw client address (before IP
+// anonymization). Returns nil without a classifier.
+func (db *DB) connectionType(event *models.PlaybackEvent) *string {
+	if event.ConnectionType != nil {
+		return event.ConnectionType
+	}
+
+	db.connClassifierMu.RLock()
+	classifier := db.connClassifier
+	db.connClassifierMu.RUnlock()
+	if classifier == nil {
+		return nil
+	}
+
+	connectionType := classifier.ConnectionType(event.IPAddress)
+	return &connectionType
+}
@@ -43,7 +43,7 @@ func (db *DB) ExportGeoParquet(ctx context.Context, outputPath string, filter Lo
 		WHERE 1=1`
 
 	// Apply filters using extracted helper
-	conditions, args := filter.buildFilterConditions()
+	conditions, args := buildPrefixedFilterConditions(&filter)
 	query += conditions
 
 	query += `
@@ -105,7 +105,7 @@ func (db *DB) ExportGeoJSON(ctx context.Context, outputPath string, filter Locat
 		WHERE 1=1`
 
 	// Apply filters using extracted helper
-	conditions, args := filter.buildFilterConditions()
+	conditions, args := buildPrefixedFilterConditions(&filter)
 	query += conditions
 
 	query += `
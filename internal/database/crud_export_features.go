@@ -0,0 +1,160 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// geoJSONPlaybackProperties maps the playback fields that can be exported as
+// GeoJSON feature properties to safe SQL expressions, preventing SQL
+// injection. Keys are models.PlaybackEvent JSON names; contact details,
+// artwork and raw payloads are deliberately left out.
+var geoJSONPlaybackProperties = map[string]string{
+	"id":                 "CAST(p.id AS VARCHAR)",
+	"source":             "p.source",
+	"server_id":          "p.server_id",
+	"session_key":        "p.session_key",
+	"started_at":         "p.started_at",
+	"stopped_at":         "p.stopped_at",
+	"user_id":            "p.user_id",
+	"username":           "p.username",
+	"friendly_name":      "p.friendly_name",
+	"ip_address":         "p.ip_address",
+	"media_type":         "p.media_type",
+	"title":              "p.title",
+	"parent_title":       "p.parent_title",
+	"grandparent_title":  "p.grandparent_title",
+	"rating_key":         "p.rating_key",
+	"year":               "p.year",
+	"library_name":       "p.library_name",
+	"content_rating":     "p.content_rating",
+	"genres":             "p.genres",
+	"platform":           "p.platform",
+	"player":             "p.player",
+	"product":            "p.product",
+	"device":             "p.device",
+	"location_type":      "p.location_type",
+	"connection_type":    "p.connection_type",
+	"percent_complete":   "p.percent_complete",
+	"paused_counter":     "p.paused_counter",
+	"play_duration":      "p.play_duration",
+	"transcode_decision": "p.transcode_decision",
+	"video_resolution":   "p.video_resolution",
+	"video_codec":        "p.video_codec",
+	"audio_codec":        "p.audio_codec",
+	"stream_bitrate":     "p.stream_bitrate",
+}
+
+// DefaultGeoJSONPlaybackProperties are exported when no properties are
+// requested.
+var DefaultGeoJSONPlaybackProperties = []string{
+	"started_at", "username", "title", "media_type", "platform", "player", "percent_complete",
+}
+
+// GeoJSONPlaybackPropertyNames returns the names of the playback fields that
+// can be exported as feature properties, sorted.
+func GeoJSONPlaybackPropertyNames() []string {
+	names := make([]string, 0, len(geoJSONPlaybackProperties))
+	for name := range geoJSONPlaybackProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateGeoJSONPlaybackProperties checks that every requested property can
+// be exported and that none is requested twice.
+func ValidateGeoJSONPlaybackProperties(properties []string) error {
+	seen := make(map[string]bool, len(properties))
+	for _, name := range properties {
+		if _, ok := geoJSONPlaybackProperties[name]; !ok {
+			return fmt.Errorf("unknown property %q; valid properties: %s",
+				name, strings.Join(GeoJSONPlaybackPropertyNames(), ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("property %q requested more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// PlaybackFeature is a playback located at the geolocation of its IP
+// address, with the requested properties. NULL fields are nil.
+type PlaybackFeature struct {
+	Latitude   float64
+	Longitude  float64
+	Properties map[string]interface{}
+}
+
+// StreamPlaybackFeatures calls fn for each geolocated playback matching
+// filter, newest first, reading rows as fn consumes them so exports of any
+// size use constant memory. The feature passed to fn is reused for the next
+// row. Playbacks without a geolocation are skipped. A filter limit of 0
+// returns every playback.
+func (db *DB) StreamPlaybackFeatures(ctx context.Context, filter LocationStatsFilter, properties []string, fn func(*PlaybackFeature) error) error {
+	if err := ValidateGeoJSONPlaybackProperties(properties); err != nil {
+		return err
+	}
+
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	columns := make([]string, 0, len(properties)+2)
+	columns = append(columns, "g.latitude", "g.longitude")
+	for _, name := range properties {
+		columns = append(columns, geoJSONPlaybackProperties[name])
+	}
+
+	query := `
+	SELECT ` + strings.Join(columns, ", ") + `
+	FROM playback_events p
+	JOIN geolocations g ON p.ip_address = g.ip_address
+	WHERE 1=1`
+	conditions, args := buildPrefixedFilterConditions(&filter)
+	query += conditions + `
+	ORDER BY p.started_at DESC, p.id`
+	if filter.Limit > 0 {
+		query += `
+	LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query playback features: %w", err)
+	}
+	defer rows.Close()
+
+	feature := PlaybackFeature{Properties: make(map[string]interface{}, len(properties))}
+	values := make([]interface{}, len(properties))
+	dest := make([]interface{}, 0, len(properties)+2)
+	dest = append(dest, &feature.Latitude, &feature.Longitude)
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan playback feature: %w", err)
+		}
+		for i, name := range properties {
+			feature.Properties[name] = values[i]
+		}
+		if err := fn(&feature); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating playback features: %w", err)
+	}
+	return nil
+}
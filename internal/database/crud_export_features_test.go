@@ -0,0 +1,133 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestGeoJSONPlaybackProperties_MatchModel(t *testing.T) {
+	fields := make(map[string]bool)
+	typ := reflect.TypeOf(models.PlaybackEvent{})
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		fields[name] = true
+	}
+
+	for _, name := range GeoJSONPlaybackPropertyNames() {
+		if !fields[name] {
+			t.Errorf("property %q is not a PlaybackEvent JSON field", name)
+		}
+	}
+	if err := ValidateGeoJSONPlaybackProperties(DefaultGeoJSONPlaybackProperties); err != nil {
+		t.Errorf("default properties are invalid: %v", err)
+	}
+}
+
+func TestValidateGeoJSONPlaybackProperties(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties []string
+		wantErr    string
+	}{
+		{"valid", []string{"username", "title", "started_at"}, ""},
+		{"none", nil, ""},
+		{"unknown", []string{"username", "password"}, `unknown property "password"`},
+		{"excluded field", []string{"email"}, `unknown property "email"`},
+		{"sql", []string{"username; DROP TABLE playback_events"}, "unknown property"},
+		{"duplicate", []string{"title", "title"}, "more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGeoJSONPlaybackProperties(tt.properties)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStreamPlaybackFeatures(t *testing.T) {
+	db := setupTestDBWithData(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	// A playback without a geolocation is not exported
+	_, err := db.conn.Exec(`
+		INSERT INTO playback_events (id, session_key, started_at, user_id, username, ip_address, media_type, title)
+		VALUES (uuid(), 'unlocated', now(), 9, 'user9', '10.9.9.9', 'movie', 'Nowhere')`)
+	checkNoError(t, err)
+
+	var features []PlaybackFeature
+	collect := func(f *PlaybackFeature) error {
+		props := make(map[string]interface{}, len(f.Properties))
+		for k, v := range f.Properties {
+			props[k] = v
+		}
+		features = append(features, PlaybackFeature{Latitude: f.Latitude, Longitude: f.Longitude, Properties: props})
+		return nil
+	}
+
+	checkNoError(t, db.StreamPlaybackFeatures(ctx, LocationStatsFilter{}, []string{"username", "title", "started_at", "stopped_at"}, collect))
+	if len(features) != 9 {
+		t.Fatalf("got %d features, want the 9 geolocated playbacks", len(features))
+	}
+
+	// Newest first: user2's playback an hour ago in Los Angeles
+	first := features[0]
+	if first.Latitude != 34.0522 || first.Longitude != -118.2437 {
+		t.Errorf("first feature at (%v, %v), want Los Angeles", first.Latitude, first.Longitude)
+	}
+	if first.Properties["username"] != "user2" || first.Properties["title"] != "Test Show S01E02" {
+		t.Errorf("first feature properties = %v, want user2's Test Show S01E02", first.Properties)
+	}
+	if _, ok := first.Properties["started_at"].(time.Time); !ok {
+		t.Errorf("started_at = %T, want time.Time", first.Properties["started_at"])
+	}
+	if len(first.Properties) != 4 {
+		t.Errorf("properties = %v, want only the 4 requested", first.Properties)
+	}
+
+	// Filters and limit apply
+	features = nil
+	filter := LocationStatsFilter{Users: []string{"user1"}, MediaTypes: []string{"movie"}, Limit: 1}
+	checkNoError(t, db.StreamPlaybackFeatures(ctx, filter, []string{"id", "title"}, collect))
+	if len(features) != 1 || features[0].Properties["title"] != "Test Movie 1" {
+		t.Errorf("features = %+v, want user1's newest movie only", features)
+	}
+	if id, _ := features[0].Properties["id"].(string); len(id) != 36 {
+		t.Errorf("id = %v, want a UUID string", features[0].Properties["id"])
+	}
+
+	if err := db.StreamPlaybackFeatures(ctx, LocationStatsFilter{}, []string{"email"}, collect); err == nil {
+		t.Error("expected error for a property that cannot be exported")
+	}
+
+	// Callback errors stop the stream
+	stop := errors.New("stop")
+	calls := 0
+	err = db.StreamPlaybackFeatures(ctx, LocationStatsFilter{}, DefaultGeoJSONPlaybackProperties, func(*PlaybackFeature) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("err = %v after %d calls, want the callback error after 1", err, calls)
+	}
+}
@@ -14,20 +14,31 @@ import (
 
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // UpsertGeolocationWithServer inserts or updates a geolocation record with server location
 // Automatically populates H3 spatial indexes and distance calculations
 // Uses per-IP locking to prevent DuckDB INTERNAL errors while allowing concurrent writes to different IPs
 // Implements retry logic for transaction conflicts with exponential backoff
+// The record is stored under the anonymized address (PRIVACY_IP_MODE); geo
+// itself is not modified apart from a zero LastUpdated.
 func (db *DB) UpsertGeolocationWithServer(geo *models.Geolocation, serverLat, serverLon float64) error {
-	// Acquire per-IP lock to prevent concurrent UPSERTs on same IP (prevents INTERNAL errors)
-	mu := db.acquireIPLock(geo.IPAddress)
-	defer db.releaseIPLock(geo.IPAddress, mu)
-
 	if geo.LastUpdated.IsZero() {
 		geo.LastUpdated = time.Now()
 	}
+	if stored := privacy.AnonymizeIP(geo.IPAddress); stored != geo.IPAddress {
+		if stored == "" {
+			return nil // Not an address, nothing to store in truncated mode
+		}
+		anonymized := *geo
+		anonymized.IPAddress = stored
+		geo = &anonymized
+	}
+
+	// Acquire per-IP lock to prevent concurrent UPSERTs on same IP (prevents INTERNAL errors)
+	mu := db.acquireIPLock(geo.IPAddress)
+	defer db.releaseIPLock(geo.IPAddress, mu)
 
 	// Create context with timeout to prevent indefinite hangs
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -151,6 +162,8 @@ func (db *DB) doUpsertGeolocation(ctx context.Context, geo *models.Geolocation,
 // GetGeolocations retrieves multiple geolocation records in a single query
 // Returns a map of IP address -> geolocation for efficient lookups
 // MEDIUM-2: Batch geolocation lookups for 10-20x performance improvement
+// The map is keyed by the addresses as passed in, even when the records are
+// stored under their anonymized form.
 func (db *DB) GetGeolocations(ctx context.Context, ipAddresses []string) (map[string]*models.Geolocation, error) {
 	ctx, cancel := db.ensureContext(ctx)
 	defer cancel()
@@ -159,8 +172,19 @@ func (db *DB) GetGeolocations(ctx context.Context, ipAddresses []string) (map[st
 		return make(map[string]*models.Geolocation), nil
 	}
 
+	// Stored address -> the addresses asked for that map to it
+	requested := make(map[string][]string, len(ipAddresses))
+	stored := make([]string, 0, len(ipAddresses))
+	for _, ip := range ipAddresses {
+		key := privacy.AnonymizeIP(ip)
+		if _, ok := requested[key]; !ok {
+			stored = append(stored, key)
+		}
+		requested[key] = append(requested[key], ip)
+	}
+
 	// Build parameterized IN clause using helper
-	placeholders, args := buildInClause(ipAddresses)
+	placeholders, args := buildInClause(stored)
 
 	query := fmt.Sprintf(`
 		SELECT ip_address, latitude, longitude, city, region, country,
@@ -187,7 +211,9 @@ func (db *DB) GetGeolocations(ctx context.Context, ipAddresses []string) (map[st
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan geolocation: %w", err)
 		}
-		result[geo.IPAddress] = geo
+		for _, ip := range requested[geo.IPAddress] {
+			result[ip] = geo
+		}
 	}
 
 	if err := rows.Err(); err != nil {
@@ -212,6 +238,9 @@ func (db *DB) GetGeolocations(ctx context.Context, ipAddresses []string) (map[st
 //   - nil if IP address not found in database (no error)
 //   - error only if query execution fails
 //
+// The address is anonymized like on upsert, so any address that maps to a
+// stored record finds it (in truncated mode, the whole /24 or /48).
+//
 // Performance: ~0.5-1ms with primary key index on ip_address.
 //
 // For batch lookups of multiple IPs, use GetGeolocations() instead for 10-20x
@@ -237,7 +266,7 @@ func (db *DB) GetGeolocation(ctx context.Context, ipAddress string) (*models.Geo
 	WHERE ip_address = ?`
 
 	var geo models.Geolocation
-	err := db.conn.QueryRowContext(ctx, query, ipAddress).Scan(
+	err := db.conn.QueryRowContext(ctx, query, privacy.AnonymizeIP(ipAddress)).Scan(
 		&geo.IPAddress, &geo.Latitude, &geo.Longitude, &geo.City, &geo.Region,
 		&geo.Country, &geo.PostalCode, &geo.Timezone, &geo.AccuracyRadius,
 		&geo.LastUpdated,
@@ -0,0 +1,252 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// historyColumns are the playback_events columns read by GetPlaybackHistory
+// and GetOpenPlaybackSessions, in scanHistoryEvent order. Columns scanned
+// into non-pointer fields are coalesced.
+const historyColumns = `
+	id, session_key, started_at, stopped_at, state,
+	user_id, username, friendly_name, user_thumb, email,
+	ip_address, ip_address_public,
+	media_type, title, parent_title, grandparent_title, full_title, year,
+	rating_key, parent_rating_key, grandparent_rating_key,
+	media_index, parent_media_index, guid, thumb, section_id, library_name,
+	COALESCE(platform, ''), platform_name, COALESCE(player, ''), product, device, machine_id,
+	COALESCE(location_type, ''), quality_profile, location, local, secure, relayed,
+	COALESCE(percent_complete, 0), COALESCE(paused_counter, 0), play_duration,
+	transcode_decision, video_decision, audio_decision,
+	video_resolution, video_full_resolution, video_codec, audio_codec, container,
+	bitrate, stream_video_resolution, stream_bitrate, bandwidth`
+
+// scanHistoryEvent scans a row selected with historyColumns
+func scanHistoryEvent(rows *sql.Rows, e *models.PlaybackEvent) error {
+	return rows.Scan(
+		&e.ID, &e.SessionKey, &e.StartedAt, &e.StoppedAt, &e.State,
+		&e.UserID, &e.Username, &e.FriendlyName, &e.UserThumb, &e.Email,
+		&e.IPAddress, &e.IPAddressPublic,
+		&e.MediaType, &e.Title, &e.ParentTitle, &e.GrandparentTitle, &e.FullTitle, &e.Year,
+		&e.RatingKey, &e.ParentRatingKey, &e.GrandparentRatingKey,
+		&e.MediaIndex, &e.ParentMediaIndex, &e.GUID, &e.Thumb, &e.SectionID, &e.LibraryName,
+		&e.Platform, &e.PlatformName, &e.Player, &e.Product, &e.Device, &e.MachineID,
+		&e.LocationType, &e.QualityProfile, &e.Location, &e.Local, &e.Secure, &e.Relayed,
+		&e.PercentComplete, &e.PausedCounter, &e.PlayDuration,
+		&e.TranscodeDecision, &e.VideoDecision, &e.AudioDecision,
+		&e.VideoResolution, &e.VideoFullResolution, &e.VideoCodec, &e.AudioCodec, &e.Container,
+		&e.Bitrate, &e.StreamVideoResolution, &e.StreamBitrate, &e.Bandwidth,
+	)
+}
+
+// PlaybackHistoryFilter selects and pages the rows of GetPlaybackHistory.
+// Zero values do not filter.
+type PlaybackHistoryFilter struct {
+	UserID    int    // Exact user ID
+	Username  string // Case-insensitive username
+	MediaType string // movie, episode, track, ...
+	RatingKey string // Matches the item, its season or its show
+	Search    string // Case-insensitive substring of any title
+	After     *time.Time
+	Before    *time.Time
+
+	Start     int  // Rows to skip
+	Length    int  // Rows to return (0 = all)
+	Ascending bool // Oldest first instead of newest first
+}
+
+// condition builds the WHERE condition for the filter
+func (f PlaybackHistoryFilter) condition() (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if f.UserID > 0 {
+		clauses = append(clauses, "user_id = ?")
+		args = append(args, f.UserID)
+	}
+	if f.Username != "" {
+		clauses = append(clauses, "lower(username) = lower(?)")
+		args = append(args, f.Username)
+	}
+	if f.MediaType != "" {
+		clauses = append(clauses, "media_type = ?")
+		args = append(args, f.MediaType)
+	}
+	if f.RatingKey != "" {
+		clauses = append(clauses, "(rating_key = ? OR parent_rating_key = ? OR grandparent_rating_key = ?)")
+		args = append(args, f.RatingKey, f.RatingKey, f.RatingKey)
+	}
+	if f.Search != "" {
+		clauses = append(clauses, `(contains(lower(title), ?) OR contains(lower(parent_title), ?)
+			OR contains(lower(grandparent_title), ?) OR contains(lower(full_title), ?))`)
+		search := strings.ToLower(f.Search)
+		args = append(args, search, search, search, search)
+	}
+	if f.After != nil {
+		clauses = append(clauses, "started_at >= ?")
+		args = append(args, *f.After)
+	}
+	if f.Before != nil {
+		clauses = append(clauses, "started_at < ?")
+		args = append(args, *f.Before)
+	}
+
+	if len(clauses) == 0 {
+		return "TRUE", nil
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// PlaybackHistoryPage is one page of GetPlaybackHistory
+type PlaybackHistoryPage struct {
+	Events          []models.PlaybackEvent
+	RecordsTotal    int // All playback events
+	RecordsFiltered int // Events matching the filter, before paging
+}
+
+// GetPlaybackHistory returns playback events matching filter, newest first
+// unless filter.Ascending, with the counts a paged history table needs.
+func (db *DB) GetPlaybackHistory(ctx context.Context, filter PlaybackHistoryFilter) (*PlaybackHistoryPage, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	condition, args := filter.condition()
+	page := &PlaybackHistoryPage{}
+
+	//nolint:gosec // condition holds placeholders only
+	err := db.conn.QueryRowContext(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE `+condition+`)
+		FROM playback_events`, args...).Scan(&page.RecordsTotal, &page.RecordsFiltered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count playback history: %w", err)
+	}
+
+	order := "DESC"
+	if filter.Ascending {
+		order = "ASC"
+	}
+	//nolint:gosec // condition holds placeholders only, order is a constant
+	query := `SELECT ` + historyColumns + `
+		FROM playback_events
+		WHERE ` + condition + `
+		ORDER BY started_at ` + order + `, id ` + order
+	if filter.Length > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Length, max(filter.Start, 0))
+	} else if filter.Start > 0 {
+		query += " OFFSET ?"
+		args = append(args, filter.Start)
+	}
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query playback history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.PlaybackEvent
+		if err := scanHistoryEvent(rows, &e); err != nil {
+			return nil, fmt.Errorf("failed to scan playback history: %w", err)
+		}
+		page.Events = append(page.Events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating playback history: %w", err)
+	}
+	return page, nil
+}
+
+// GetOpenPlaybackSessions returns playback events without stopped_at that
+// started at or after since, newest first. These are the sessions a media
+// server has reported as started but not yet finished.
+func (db *DB) GetOpenPlaybackSessions(ctx context.Context, since time.Time) ([]models.PlaybackEvent, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT `+historyColumns+`
+		FROM playback_events
+		WHERE stopped_at IS NULL AND started_at >= ?
+		ORDER BY started_at DESC, id DESC`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open playback sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.PlaybackEvent
+	for rows.Next() {
+		var e models.PlaybackEvent
+		if err := scanHistoryEvent(rows, &e); err != nil {
+			return nil, fmt.Errorf("failed to scan open playback session: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open playback sessions: %w", err)
+	}
+	return events, nil
+}
+
+// PlaybackUser is a user seen in playback history, with the profile fields
+// of their most recent playback
+type PlaybackUser struct {
+	UserID          int
+	Username        string
+	FriendlyName    *string
+	UserThumb       *string
+	Email           *string
+	IsAdmin         *int
+	IsHomeUser      *int
+	IsAllowSync     *int
+	IsRestricted    *int
+	DoNotify        *int
+	KeepHistory     *int
+	DeletedUser     *int
+	AllowGuest      *int
+	SharedLibraries *string
+}
+
+// GetPlaybackUsers returns every user with at least one playback event,
+// ordered by username
+func (db *DB) GetPlaybackUsers(ctx context.Context) ([]PlaybackUser, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `
+		SELECT user_id, username, friendly_name, user_thumb, email,
+			is_admin, is_home_user, is_allow_sync, is_restricted, do_notify,
+			keep_history, deleted_user, allow_guest, shared_libraries
+		FROM playback_events
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY started_at DESC, id DESC) = 1
+		ORDER BY lower(username), user_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query playback users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []PlaybackUser
+	for rows.Next() {
+		var u PlaybackUser
+		if err := rows.Scan(&u.UserID, &u.Username, &u.FriendlyName, &u.UserThumb, &u.Email,
+			&u.IsAdmin, &u.IsHomeUser, &u.IsAllowSync, &u.IsRestricted, &u.DoNotify,
+			&u.KeepHistory, &u.DeletedUser, &u.AllowGuest, &u.SharedLibraries); err != nil {
+			return nil, fmt.Errorf("failed to scan playback user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating playback users: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,177 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+var historyBase = time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+
+// insertHistoryEvent inserts a playback startMin minutes after historyBase,
+// stopped an hour later unless open
+func insertHistoryEvent(t *testing.T, db *DB, startMin, userID int, username, mediaType, title string, open bool) {
+	t.Helper()
+
+	startedAt := historyBase.Add(time.Duration(startMin) * time.Minute)
+	event := &models.PlaybackEvent{
+		SessionKey:      uuid.New().String(),
+		StartedAt:       startedAt,
+		UserID:          userID,
+		Username:        username,
+		IPAddress:       "192.168.1.100",
+		MediaType:       mediaType,
+		Title:           title,
+		Platform:        "Roku",
+		Player:          "Living Room",
+		PercentComplete: 50,
+	}
+	if !open {
+		stoppedAt := startedAt.Add(time.Hour)
+		event.StoppedAt = &stoppedAt
+	}
+	if err := db.InsertPlaybackEvent(event); err != nil {
+		t.Fatalf("Failed to insert playback event: %v", err)
+	}
+}
+
+func seedHistory(t *testing.T, db *DB) {
+	t.Helper()
+	insertHistoryEvent(t, db, 0, 1, "Alice", "movie", "Arrival", false)
+	insertHistoryEvent(t, db, 10, 2, "bob", "episode", "Pilot", false)
+	insertHistoryEvent(t, db, 20, 1, "Alice", "episode", "The Arrival Party", false)
+	insertHistoryEvent(t, db, 30, 3, "carol", "track", "Song", true)
+}
+
+func historyTitles(events []models.PlaybackEvent) []string {
+	titles := make([]string, len(events))
+	for i, e := range events {
+		titles[i] = e.Title
+	}
+	return titles
+}
+
+func TestGetPlaybackHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	seedHistory(t, db)
+	ctx := context.Background()
+
+	after := historyBase.Add(5 * time.Minute)
+	before := historyBase.Add(25 * time.Minute)
+
+	tests := []struct {
+		name         string
+		filter       PlaybackHistoryFilter
+		wantTitles   []string
+		wantFiltered int
+	}{
+		{
+			name:         "all, newest first",
+			filter:       PlaybackHistoryFilter{},
+			wantTitles:   []string{"Song", "The Arrival Party", "Pilot", "Arrival"},
+			wantFiltered: 4,
+		},
+		{
+			name:         "ascending page",
+			filter:       PlaybackHistoryFilter{Start: 1, Length: 2, Ascending: true},
+			wantTitles:   []string{"Pilot", "The Arrival Party"},
+			wantFiltered: 4,
+		},
+		{
+			name:         "username is case-insensitive",
+			filter:       PlaybackHistoryFilter{Username: "alice"},
+			wantTitles:   []string{"The Arrival Party", "Arrival"},
+			wantFiltered: 2,
+		},
+		{
+			name:         "user ID and media type",
+			filter:       PlaybackHistoryFilter{UserID: 1, MediaType: "movie"},
+			wantTitles:   []string{"Arrival"},
+			wantFiltered: 1,
+		},
+		{
+			name:         "search",
+			filter:       PlaybackHistoryFilter{Search: "ARRIVAL", Length: 1},
+			wantTitles:   []string{"The Arrival Party"},
+			wantFiltered: 2,
+		},
+		{
+			name:         "date range",
+			filter:       PlaybackHistoryFilter{After: &after, Before: &before},
+			wantTitles:   []string{"The Arrival Party", "Pilot"},
+			wantFiltered: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := db.GetPlaybackHistory(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("GetPlaybackHistory() error = %v", err)
+			}
+			if page.RecordsTotal != 4 {
+				t.Errorf("RecordsTotal = %d, want 4", page.RecordsTotal)
+			}
+			if page.RecordsFiltered != tt.wantFiltered {
+				t.Errorf("RecordsFiltered = %d, want %d", page.RecordsFiltered, tt.wantFiltered)
+			}
+			got := historyTitles(page.Events)
+			if len(got) != len(tt.wantTitles) {
+				t.Fatalf("titles = %v, want %v", got, tt.wantTitles)
+			}
+			for i := range got {
+				if got[i] != tt.wantTitles[i] {
+					t.Fatalf("titles = %v, want %v", got, tt.wantTitles)
+				}
+			}
+		})
+	}
+}
+
+func TestGetOpenPlaybackSessions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	seedHistory(t, db)
+	insertHistoryEvent(t, db, -24*60, 4, "dave", "movie", "Abandoned", true)
+
+	sessions, err := db.GetOpenPlaybackSessions(context.Background(), historyBase.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetOpenPlaybackSessions() error = %v", err)
+	}
+	if got := historyTitles(sessions); len(got) != 1 || got[0] != "Song" {
+		t.Errorf("open sessions = %v, want [Song]", got)
+	}
+}
+
+func TestGetPlaybackUsers(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	seedHistory(t, db)
+
+	users, err := db.GetPlaybackUsers(context.Background())
+	if err != nil {
+		t.Fatalf("GetPlaybackUsers() error = %v", err)
+	}
+
+	want := []struct {
+		id   int
+		name string
+	}{{1, "Alice"}, {2, "bob"}, {3, "carol"}}
+	if len(users) != len(want) {
+		t.Fatalf("got %d users %+v, want %d", len(users), users, len(want))
+	}
+	for i, w := range want {
+		if users[i].UserID != w.id || users[i].Username != w.name {
+			t.Errorf("user %d = %d/%s, want %d/%s", i, users[i].UserID, users[i].Username, w.id, w.name)
+		}
+	}
+}
@@ -6,6 +6,7 @@
 package database
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -233,3 +234,158 @@ func TestInsertPlaybackEvent_MetadataEnrichmentFields(t *testing.T) {
 		t.Errorf("Expected genres %s, got %v", genres, dbGenres)
 	}
 }
+
+// stubClassifier classifies every address as connectionType.
+type stubClassifier struct {
+	connectionType string
+	ips            []string
+}
+
+func (c *stubClassifier) ConnectionType(ip string) string {
+	c.ips = append(c.ips, ip)
+	return c.connectionType
+}
+
+func TestInsertPlaybackEvent_ConnectionType(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	newEvent := func() *models.PlaybackEvent {
+		return &models.PlaybackEvent{
+			SessionKey:      "test-session-" + uuid.New().String(),
+			StartedAt:       time.Now(),
+			UserID:          1,
+			Username:        "testuser",
+			IPAddress:       "203.0.113.7",
+			MediaType:       "movie",
+			Title:           "Test Movie",
+			Platform:        "Test Platform",
+			Player:          "Test Player",
+			LocationType:    "WAN",
+			PercentComplete: 100,
+		}
+	}
+	storedType := func(t *testing.T, event *models.PlaybackEvent) *string {
+		t.Helper()
+		var connectionType *string
+		if err := db.conn.QueryRow(`SELECT connection_type FROM playback_events WHERE id = ?`, event.ID).Scan(&connectionType); err != nil {
+			t.Fatalf("query connection_type: %v", err)
+		}
+		return connectionType
+	}
+
+	// Without a classifier the column stays NULL
+	event := newEvent()
+	if err := db.InsertPlaybackEvent(event); err != nil {
+		t.Fatalf("InsertPlaybackEvent failed: %v", err)
+	}
+	if got := storedType(t, event); got != nil {
+		t.Errorf("connection_type = %q, want NULL", *got)
+	}
+
+	classifier := &stubClassifier{connectionType: "datacenter"}
+	db.SetConnectionClassifier(classifier)
+
+	event = newEvent()
+	if err := db.InsertPlaybackEvent(event); err != nil {
+		t.Fatalf("InsertPlaybackEvent failed: %v", err)
+	}
+	if got := storedType(t, event); got == nil || *got != "datacenter" {
+		t.Errorf("connection_type = %v, want datacenter", got)
+	}
+	if len(classifier.ips) != 1 || classifier.ips[0] != "203.0.113.7" {
+		t.Errorf("classified %v, want [203.0.113.7]", classifier.ips)
+	}
+
+	// An event's own connection type is kept
+	event = newEvent()
+	vpnType := "vpn"
+	event.ConnectionType = &vpnType
+	if _, _, err := db.InsertPlaybackEventsBatch(context.Background(), []*models.PlaybackEvent{event}); err != nil {
+		t.Fatalf("InsertPlaybackEventsBatch failed: %v", err)
+	}
+	if got := storedType(t, event); got == nil || *got != "vpn" {
+		t.Errorf("connection_type = %v, want vpn", got)
+	}
+}
+
+func TestInsertPlaybackEvent_UserDevices(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	base := time.Date(2026, 2, 1, 20, 0, 0, 0, time.UTC)
+	tv, phone := "machine-tv", "machine-phone"
+	ratingKey := "1001"
+	newEvent := func(session string, machineID *string, startedAt time.Time, player, ip string) *models.PlaybackEvent {
+		return &models.PlaybackEvent{
+			SessionKey: session,
+			StartedAt:  startedAt,
+			UserID:     1,
+			Username:   "testuser",
+			IPAddress:  ip,
+			MediaType:  "movie",
+			Title:      "Test Movie",
+			RatingKey:  &ratingKey,
+			Platform:   "webOS",
+			Player:     player,
+			MachineID:  machineID,
+		}
+	}
+
+	// Single inserts, the second one older than the first
+	checkNoError(t, db.InsertPlaybackEventWithContext(ctx, newEvent("s2", &tv, base, "Plex for LG", "198.51.100.7")))
+	checkNoError(t, db.InsertPlaybackEventWithContext(ctx, newEvent("s1", &tv, base.Add(-24*time.Hour), "Old Player", "198.51.100.8")))
+	// Duplicate session is not counted again
+	checkNoError(t, db.InsertPlaybackEventWithContext(ctx, newEvent("s2", &tv, base, "Plex for LG", "198.51.100.7")))
+
+	// Batch inserts, including an event without a machine ID
+	empty := ""
+	inserted, _, err := db.InsertPlaybackEventsBatch(ctx, []*models.PlaybackEvent{
+		newEvent("s3", &phone, base.Add(time.Hour), "Plex for iOS", "203.0.113.9"),
+		newEvent("s4", &tv, base.Add(2*time.Hour), "Plex for LG", "198.51.100.9"),
+		newEvent("s5", nil, base.Add(3*time.Hour), "Unknown", "203.0.113.10"),
+		newEvent("s6", &empty, base.Add(4*time.Hour), "Unknown", "203.0.113.11"),
+	})
+	checkNoError(t, err)
+	if inserted != 4 {
+		t.Fatalf("inserted = %d, want 4", inserted)
+	}
+
+	rows, err := db.conn.QueryContext(ctx, `
+		SELECT device_id, player, first_seen_at, first_session_key, last_seen_at, last_ip_address, session_count
+		FROM user_devices WHERE user_id = 1 ORDER BY device_id`)
+	checkNoError(t, err)
+	defer rows.Close()
+
+	type deviceRow struct {
+		deviceID, player string
+		firstSeen        time.Time
+		firstSession     string
+		lastSeen         time.Time
+		lastIP           string
+		sessions         int
+	}
+	var got []deviceRow
+	for rows.Next() {
+		var r deviceRow
+		checkNoError(t, rows.Scan(&r.deviceID, &r.player, &r.firstSeen, &r.firstSession, &r.lastSeen, &r.lastIP, &r.sessions))
+		got = append(got, r)
+	}
+	checkNoError(t, rows.Err())
+
+	want := []deviceRow{
+		{"machine-phone", "Plex for iOS", base.Add(time.Hour), "s3", base.Add(time.Hour), "203.0.113.9", 1},
+		{"machine-tv", "Plex for LG", base.Add(-24 * time.Hour), "s1", base.Add(2 * time.Hour), "198.51.100.9", 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("devices = %+v, want %d", got, len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.deviceID != w.deviceID || g.player != w.player || !g.firstSeen.Equal(w.firstSeen) ||
+			g.firstSession != w.firstSession || !g.lastSeen.Equal(w.lastSeen) || g.lastIP != w.lastIP || g.sessions != w.sessions {
+			t.Errorf("device %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // Media server errors
@@ -289,7 +290,7 @@ func (db *DB) CreateMediaServerAudit(ctx context.Context, audit *models.MediaSer
 
 	_, err := db.conn.ExecContext(ctx, query,
 		audit.ID, audit.ServerID, audit.Action, audit.UserID, audit.Username,
-		audit.Changes, audit.IPAddress, audit.UserAgent, audit.CreatedAt,
+		audit.Changes, privacy.AnonymizeIP(audit.IPAddress), audit.UserAgent, audit.CreatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create audit log: %w", err)
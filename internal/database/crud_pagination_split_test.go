@@ -62,6 +62,14 @@ func TestGetPlaybackEvents_Pagination(t *testing.T) {
 	if len(events) != 2 {
 		t.Errorf("Expected 2 events with offset, got %d", len(events))
 	}
+
+	count, err := db.CountPlaybackEvents(context.Background())
+	if err != nil {
+		t.Fatalf("CountPlaybackEvents failed: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected count 5, got %d", count)
+	}
 }
 
 func TestGetPlaybackEventsWithCursor_Pagination(t *testing.T) {
@@ -198,4 +206,9 @@ func TestGetPlaybackEventsWithCursor_EmptyDatabase(t *testing.T) {
 	if nextCursor != nil {
 		t.Error("Expected nextCursor=nil for empty database")
 	}
+
+	count, err := db.CountPlaybackEvents(context.Background())
+	if err != nil || count != 0 {
+		t.Errorf("CountPlaybackEvents() = %d, %v; want 0, nil", count, err)
+	}
 }
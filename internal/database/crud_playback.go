@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // InsertPlaybackEvent inserts a new playback event into the database with duplicate handling.
@@ -30,6 +31,14 @@ import (
 // Updated v1.47: Added correlation_key for cross-source deduplication
 // Updated v2.0: Migrated from INSERT OR IGNORE (SQLite) to ON CONFLICT DO NOTHING (DuckDB-native)
 func (db *DB) InsertPlaybackEvent(event *models.PlaybackEvent) error {
+	return db.InsertPlaybackEventWithContext(context.Background(), event)
+}
+
+// InsertPlaybackEventWithContext inserts a playback event with context support.
+// Unlike reads it adds no default deadline: under heavy write concurrency an
+// insert may wait for a connection longer than the read timeout. The user's
+// device inventory is updated in the same transaction.
+func (db *DB) InsertPlaybackEventWithContext(ctx context.Context, event *models.PlaybackEvent) error {
 	if event.ID == uuid.Nil {
 		event.ID = uuid.New()
 	}
@@ -96,6 +105,7 @@ func (db *DB) InsertPlaybackEvent(event *models.PlaybackEvent) error {
 		container, subtitle_codec, subtitle_language, subtitles,
 		-- Connection and network (v1.43 extended)
 		secure, relayed, relay, local, bandwidth, location, bandwidth_lan, bandwidth_wan,
+		connection_type,
 		-- File metadata (v1.43 extended)
 		file_size, bitrate, file,
 		-- Bitrate analytics
@@ -121,20 +131,26 @@ func (db *DB) InsertPlaybackEvent(event *models.PlaybackEvent) error {
 		?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
 		?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
 		?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
-		?, ?, ?, ?
+		?, ?, ?, ?, ?
 	) ON CONFLICT DO NOTHING`
 
-	result, err := db.conn.ExecContext(context.Background(), query,
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	result, err := tx.ExecContext(ctx, query,
 		// Core identification
 		event.ID, event.SessionKey, event.StartedAt, event.StoppedAt,
-		event.UserID, event.Username, event.IPAddress,
+		event.UserID, event.Username, privacy.AnonymizeIP(event.IPAddress),
 		event.Source, event.PlexKey,
 		// Cross-source deduplication (v1.47)
 		event.CorrelationKey,
 		// Exactly-once delivery (v2.1 - ADR-0023)
 		event.TransactionID,
 		// User extended fields
-		event.FriendlyName, event.UserThumb, event.Email, event.IPAddressPublic,
+		event.FriendlyName, event.UserThumb, event.Email, anonymizeIPPtr(event.IPAddressPublic),
 		// Media identification
 		event.MediaType, event.Title, event.ParentTitle, event.GrandparentTitle,
 		// Client/Player information
@@ -183,6 +199,7 @@ func (db *DB) InsertPlaybackEvent(event *models.PlaybackEvent) error {
 		event.Container, event.SubtitleCodec, event.SubtitleLanguage, event.Subtitles,
 		// Connection and network
 		event.Secure, event.Relayed, event.Relay, event.Local, event.Bandwidth, event.Location, event.BandwidthLAN, event.BandwidthWAN,
+		db.connectionType(event),
 		// File metadata
 		event.FileSize, event.Bitrate, event.File,
 		// Bitrate analytics
@@ -213,6 +230,14 @@ func (db *DB) InsertPlaybackEvent(event *models.PlaybackEvent) error {
 			Str("rating_key", ratingKey).
 			Str("started_at", event.StartedAt.Format("2006-01-02T15:04:05")).
 			Msg("Duplicate detected")
+	} else if args := userDeviceArgs(event); err == nil && args != nil {
+		if _, err := tx.ExecContext(ctx, upsertUserDeviceQuery, args...); err != nil {
+			return fmt.Errorf("failed to update user device: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit playback event: %w", err)
 	}
 
 	// MEDIUM-1: Increment data version to invalidate tile cache
@@ -321,6 +346,7 @@ func (db *DB) InsertPlaybackEventsBatch(ctx context.Context, events []*models.Pl
 		container, subtitle_codec, subtitle_language, subtitles,
 		-- Connection and network
 		secure, relayed, relay, local, bandwidth, location, bandwidth_lan, bandwidth_wan,
+		connection_type,
 		-- File metadata
 		file_size, bitrate, file,
 		-- Bitrate analytics
@@ -346,7 +372,7 @@ func (db *DB) InsertPlaybackEventsBatch(ctx context.Context, events []*models.Pl
 		?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
 		?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
 		?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
-		?, ?, ?, ?
+		?, ?, ?, ?, ?
 	) ON CONFLICT DO NOTHING`
 
 	stmt, err := tx.PrepareContext(ctx, query)
@@ -359,6 +385,17 @@ func (db *DB) InsertPlaybackEventsBatch(ctx context.Context, events []*models.Pl
 		}
 	}()
 
+	// Device inventory rows are updated in the same transaction
+	deviceStmt, err := tx.PrepareContext(ctx, upsertUserDeviceQuery)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prepare device statement: %w", err)
+	}
+	defer func() {
+		if closeErr := deviceStmt.Close(); closeErr != nil {
+			logging.Warn().Err(closeErr).Msg("Failed to close prepared statement")
+		}
+	}()
+
 	// Insert each event and track results
 	inserted = 0
 	duplicates = 0
@@ -375,14 +412,14 @@ func (db *DB) InsertPlaybackEventsBatch(ctx context.Context, events []*models.Pl
 		result, execErr := stmt.ExecContext(ctx,
 			// Core identification
 			event.ID, event.SessionKey, event.StartedAt, event.StoppedAt,
-			event.UserID, event.Username, event.IPAddress,
+			event.UserID, event.Username, privacy.AnonymizeIP(event.IPAddress),
 			event.Source, event.PlexKey,
 			// Cross-source deduplication
 			event.CorrelationKey,
 			// Exactly-once delivery
 			event.TransactionID,
 			// User extended fields
-			event.FriendlyName, event.UserThumb, event.Email, event.IPAddressPublic,
+			event.FriendlyName, event.UserThumb, event.Email, anonymizeIPPtr(event.IPAddressPublic),
 			// Media identification
 			event.MediaType, event.Title, event.ParentTitle, event.GrandparentTitle,
 			// Client/Player information
@@ -431,6 +468,7 @@ func (db *DB) InsertPlaybackEventsBatch(ctx context.Context, events []*models.Pl
 			event.Container, event.SubtitleCodec, event.SubtitleLanguage, event.Subtitles,
 			// Connection and network
 			event.Secure, event.Relayed, event.Relay, event.Local, event.Bandwidth, event.Location, event.BandwidthLAN, event.BandwidthWAN,
+			db.connectionType(event),
 			// File metadata
 			event.FileSize, event.Bitrate, event.File,
 			// Bitrate analytics
@@ -457,6 +495,12 @@ func (db *DB) InsertPlaybackEventsBatch(ctx context.Context, events []*models.Pl
 
 		if rowsAffected > 0 {
 			inserted++
+			if args := userDeviceArgs(event); args != nil {
+				if _, execErr := deviceStmt.ExecContext(ctx, args...); execErr != nil {
+					err = fmt.Errorf("failed to update device of event %d (session=%s): %w", i, event.SessionKey, execErr)
+					return 0, 0, err
+				}
+			}
 		} else {
 			duplicates++
 			// Log duplicate for debugging
@@ -595,6 +639,21 @@ func (db *DB) GetPlaybackEvents(ctx context.Context, limit, offset int) ([]model
 	return events, nil
 }
 
+// CountPlaybackEvents returns the total number of playback events.
+//
+// Paginated endpoints call this only when the client asks for a total, since
+// a full count costs an extra scan on top of the page query.
+func (db *DB) CountPlaybackEvents(ctx context.Context) (int, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	var count int
+	if err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM playback_events").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count playback events: %w", err)
+	}
+	return count, nil
+}
+
 // GetPlaybackEventsWithCursor retrieves playback events using cursor-based pagination.
 //
 // Cursor-based pagination is more efficient than offset-based for large datasets because
@@ -0,0 +1,215 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// =============================================================================
+// Quarantined Events CRUD Operations (timestamp sanity validation)
+// =============================================================================
+
+// ErrQuarantinedEventNotFound is returned when a quarantine entry does not exist.
+var ErrQuarantinedEventNotFound = errors.New("quarantined event not found")
+
+const quarantinedEventColumns = `id, quarantined_at, event_id, source, server_id, session_key,
+		started_at, user_id, username, title, raw_payload, reason,
+		status, corrected_started_at, resolved_by, resolved_at`
+
+// InsertQuarantinedEvent stores an event that failed timestamp validation.
+func (db *DB) InsertQuarantinedEvent(ctx context.Context, entry *models.QuarantinedEvent) error {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.QuarantinedAt.IsZero() {
+		entry.QuarantinedAt = time.Now()
+	}
+	if entry.Status == "" {
+		entry.Status = models.QuarantineStatusPending
+	}
+
+	query := `INSERT INTO quarantined_events (` + quarantinedEventColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := db.conn.ExecContext(ctx, query,
+		entry.ID, entry.QuarantinedAt, entry.EventID, entry.Source, entry.ServerID, entry.SessionKey,
+		entry.StartedAt, entry.UserID, entry.Username, entry.Title, string(entry.RawPayload), entry.Reason,
+		entry.Status, entry.CorrectedStartedAt, entry.ResolvedBy, entry.ResolvedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert quarantined event: %w", err)
+	}
+	return nil
+}
+
+// scanQuarantinedEvent scans a row selected with quarantinedEventColumns.
+func scanQuarantinedEvent(scan func(dest ...interface{}) error) (*models.QuarantinedEvent, error) {
+	entry := &models.QuarantinedEvent{}
+	var serverID, sessionKey, username, title, resolvedBy sql.NullString
+	var payload []byte
+
+	err := scan(
+		&entry.ID, &entry.QuarantinedAt, &entry.EventID, &entry.Source, &serverID, &sessionKey,
+		&entry.StartedAt, &entry.UserID, &username, &title, &payload, &entry.Reason,
+		&entry.Status, &entry.CorrectedStartedAt, &resolvedBy, &entry.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ServerID = serverID.String
+	entry.SessionKey = sessionKey.String
+	entry.Username = username.String
+	entry.Title = title.String
+	entry.ResolvedBy = resolvedBy.String
+	entry.RawPayload = payload
+	return entry, nil
+}
+
+// GetQuarantinedEvent retrieves a quarantine entry by ID.
+// Returns ErrQuarantinedEventNotFound if it does not exist.
+func (db *DB) GetQuarantinedEvent(ctx context.Context, id uuid.UUID) (*models.QuarantinedEvent, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quarantinedEventColumns + ` FROM quarantined_events WHERE id = ?`
+
+	entry, err := scanQuarantinedEvent(db.conn.QueryRowContext(ctx, query, id).Scan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrQuarantinedEventNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get quarantined event: %w", err)
+	}
+	return entry, nil
+}
+
+// QuarantineFilter contains filter options for listing quarantined events.
+type QuarantineFilter struct {
+	Source string
+	Status string
+	Reason string
+	Limit  int
+	Offset int
+}
+
+// buildWhereClause builds the WHERE clause and args for quarantine queries.
+func (filter QuarantineFilter) buildWhereClause() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Source != "" {
+		conditions = append(conditions, "source = ?")
+		args = append(args, filter.Source)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Reason != "" {
+		conditions = append(conditions, "reason = ?")
+		args = append(args, filter.Reason)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return whereClause, args
+}
+
+// getPaginationDefaults returns normalized limit and offset values.
+func (filter QuarantineFilter) getPaginationDefaults() (int, int) {
+	limit := filter.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// ListQuarantinedEvents lists quarantined events, newest first, with optional filtering.
+// Returns the page of entries and the total number of matching entries.
+func (db *DB) ListQuarantinedEvents(ctx context.Context, filter QuarantineFilter) ([]*models.QuarantinedEvent, int64, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	whereClause, args := filter.buildWhereClause()
+
+	var totalCount int64
+	if err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM quarantined_events"+whereClause, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count quarantined events: %w", err)
+	}
+
+	limit, offset := filter.getPaginationDefaults()
+	query := `SELECT ` + quarantinedEventColumns + ` FROM quarantined_events` + whereClause +
+		` ORDER BY quarantined_at DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list quarantined events: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*models.QuarantinedEvent, 0)
+	for rows.Next() {
+		entry, err := scanQuarantinedEvent(rows.Scan)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan quarantined event: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating quarantined events: %w", err)
+	}
+
+	return entries, totalCount, nil
+}
+
+// MarkQuarantinedEventReprocessed records that a quarantined event was
+// re-inserted with a corrected started_at.
+func (db *DB) MarkQuarantinedEventReprocessed(ctx context.Context, id uuid.UUID, correctedStartedAt time.Time, resolvedBy string) error {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	query := `UPDATE quarantined_events SET
+		status = ?,
+		corrected_started_at = ?,
+		resolved_by = ?,
+		resolved_at = ?
+	WHERE id = ?`
+
+	result, err := db.conn.ExecContext(ctx, query,
+		models.QuarantineStatusReprocessed, correctedStartedAt, resolvedBy, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update quarantined event: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", ErrQuarantinedEventNotFound, id)
+	}
+	return nil
+}
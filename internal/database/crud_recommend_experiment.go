@@ -0,0 +1,138 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// =============================================================================
+// Recommendation Experiment Operations
+// =============================================================================
+
+// RecordRecommendationExposures stores items shown to users in an experiment
+// variant. An item already shown to the user in the same experiment keeps
+// its first exposure.
+func (db *DB) RecordRecommendationExposures(ctx context.Context, exposures []recommend.Exposure) error {
+	if len(exposures) == 0 {
+		return nil
+	}
+
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO recommendation_exposures (
+			experiment, variant, user_id, item_id, position, model_version, shown_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (experiment, user_id, item_id) DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare exposure insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i := range exposures {
+		e := &exposures[i]
+		if _, err := stmt.ExecContext(ctx,
+			e.Experiment, e.Variant, e.UserID, e.ItemID, e.Position, e.ModelVersion, e.ShownAt,
+		); err != nil {
+			return fmt.Errorf("failed to insert exposure of item %d: %w", e.ItemID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit exposures: %w", err)
+	}
+	return nil
+}
+
+// RecordRecommendationClick marks an item shown to the user in an experiment
+// as clicked. Only the first click counts. Returns false when the item was
+// never shown to the user in that experiment.
+func (db *DB) RecordRecommendationClick(ctx context.Context, experiment string, userID, itemID int, clickedAt time.Time) (bool, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	result, err := db.conn.ExecContext(ctx, `
+		UPDATE recommendation_exposures
+		SET clicked_at = COALESCE(clicked_at, ?)
+		WHERE experiment = ? AND user_id = ? AND item_id = ?`,
+		clickedAt, experiment, userID, itemID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record recommendation click: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record recommendation click: %w", err)
+	}
+	return n > 0, nil
+}
+
+// GetRecommendationExperimentOutcomes aggregates the outcomes of each
+// variant of an experiment. An impression counts as watched when the user
+// played the item after it was shown, and as completed when a play reached
+// 90%.
+func (db *DB) GetRecommendationExperimentOutcomes(ctx context.Context, experiment string) ([]recommend.VariantOutcome, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `
+		WITH impressions AS (
+			SELECT
+				e.variant,
+				e.user_id,
+				e.item_id,
+				e.clicked_at,
+				COUNT(p.id) AS plays,
+				COALESCE(MAX(p.percent_complete), 0) AS max_percent
+			FROM recommendation_exposures e
+			LEFT JOIN playback_events p
+				ON p.user_id = e.user_id
+				AND p.rating_key = CAST(e.item_id AS TEXT)
+				AND p.started_at >= e.shown_at
+			WHERE e.experiment = ?
+			GROUP BY e.variant, e.user_id, e.item_id, e.clicked_at
+		)
+		SELECT
+			variant,
+			COUNT(DISTINCT user_id) AS users,
+			COUNT(*) AS impressions,
+			COUNT(clicked_at) AS clicks,
+			COUNT(*) FILTER (WHERE plays > 0) AS watches,
+			COUNT(*) FILTER (WHERE max_percent >= 90) AS completions
+		FROM impressions
+		GROUP BY variant
+		ORDER BY variant`, experiment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query experiment outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	outcomes := make([]recommend.VariantOutcome, 0)
+	for rows.Next() {
+		var o recommend.VariantOutcome
+		if err := rows.Scan(&o.Variant, &o.Users, &o.Impressions, &o.Clicks, &o.Watches, &o.Completions); err != nil {
+			return nil, fmt.Errorf("failed to scan experiment outcome: %w", err)
+		}
+		o.ComputeRates()
+		outcomes = append(outcomes, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate experiment outcomes: %w", err)
+	}
+	return outcomes, nil
+}
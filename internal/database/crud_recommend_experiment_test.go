@@ -0,0 +1,101 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+func TestRecommendationExperimentOutcomes(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	shownAt := time.Now().Add(-2 * time.Hour)
+
+	exposure := func(variant string, userID, itemID, position int) recommend.Exposure {
+		return recommend.Exposure{
+			Experiment: "exp", Variant: variant, UserID: userID, ItemID: itemID,
+			Position: position, ModelVersion: 3, ShownAt: shownAt,
+		}
+	}
+	checkNoError(t, db.RecordRecommendationExposures(ctx, []recommend.Exposure{
+		exposure("a", 1, 101, 1),
+		exposure("a", 1, 102, 2),
+		exposure("a", 2, 101, 1),
+		exposure("b", 3, 101, 1),
+		exposure("b", 3, 103, 2),
+	}))
+	// Showing an item again keeps the first exposure
+	later := exposure("a", 1, 101, 5)
+	later.ShownAt = time.Now()
+	checkNoError(t, db.RecordRecommendationExposures(ctx, []recommend.Exposure{later}))
+	checkNoError(t, db.RecordRecommendationExposures(ctx, nil))
+
+	// Other experiments are reported separately
+	other := exposure("a", 1, 101, 1)
+	other.Experiment = "old"
+	checkNoError(t, db.RecordRecommendationExposures(ctx, []recommend.Exposure{other}))
+
+	for _, click := range []struct{ userID, itemID int }{{1, 101}, {1, 101}, {3, 103}} {
+		recorded, err := db.RecordRecommendationClick(ctx, "exp", click.userID, click.itemID, time.Now())
+		checkNoError(t, err)
+		if !recorded {
+			t.Errorf("click of user %d on %d not recorded", click.userID, click.itemID)
+		}
+	}
+	recorded, err := db.RecordRecommendationClick(ctx, "exp", 2, 999, time.Now())
+	checkNoError(t, err)
+	if recorded {
+		t.Error("click on an item never shown should not be recorded")
+	}
+
+	playbacks := []struct {
+		ratingKey string
+		userID    int
+		percent   int
+		startedAt time.Time
+	}{
+		{"101", 1, 95, shownAt.Add(time.Hour)},        // watched and completed
+		{"101", 1, 40, shownAt.Add(90 * time.Minute)}, // second play of the same impression
+		{"102", 1, 80, shownAt.Add(-time.Hour)},       // played before it was shown
+		{"103", 3, 50, shownAt.Add(time.Hour)},        // watched, not completed
+		{"101", 4, 100, shownAt.Add(time.Hour)},       // user was never shown the item
+	}
+	for i, pb := range playbacks {
+		_, err := db.conn.Exec(`
+			INSERT INTO playback_events (
+				id, session_key, started_at, user_id, username, ip_address,
+				media_type, title, rating_key, percent_complete
+			) VALUES (gen_random_uuid(), ?, ?, ?, 'user', '127.0.0.1', 'movie', 'Title', ?, ?)
+		`, "exp-session-"+string(rune('a'+i)), pb.startedAt, pb.userID, pb.ratingKey, pb.percent)
+		checkNoError(t, err)
+	}
+
+	outcomes, err := db.GetRecommendationExperimentOutcomes(ctx, "exp")
+	checkNoError(t, err)
+	want := []recommend.VariantOutcome{
+		{Variant: "a", Users: 2, Impressions: 3, Clicks: 1, Watches: 1, Completions: 1},
+		{Variant: "b", Users: 1, Impressions: 2, Clicks: 1, Watches: 1, Completions: 0},
+	}
+	if len(outcomes) != len(want) {
+		t.Fatalf("outcomes = %+v, want %d variants", outcomes, len(want))
+	}
+	for i := range want {
+		want[i].ComputeRates()
+		if outcomes[i] != want[i] {
+			t.Errorf("variant %s = %+v, want %+v", want[i].Variant, outcomes[i], want[i])
+		}
+	}
+
+	none, err := db.GetRecommendationExperimentOutcomes(ctx, "missing")
+	checkNoError(t, err)
+	if none == nil || len(none) != 0 {
+		t.Errorf("outcomes of unknown experiment = %#v, want empty slice", none)
+	}
+}
@@ -0,0 +1,98 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend"
+)
+
+// =============================================================================
+// Recommendation Feedback Operations
+// =============================================================================
+
+// SaveRecommendationFeedback stores a user's feedback for an item, replacing
+// any earlier signal for the same user and item.
+//
+//nolint:gocritic // hugeParam: fb passed by value for immutability
+func (db *DB) SaveRecommendationFeedback(ctx context.Context, fb recommend.Feedback) error {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	if fb.CreatedAt.IsZero() {
+		fb.CreatedAt = time.Now()
+	}
+
+	_, err := db.conn.ExecContext(ctx, `
+		INSERT INTO recommendation_feedback (user_id, item_id, signal, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (user_id, item_id) DO UPDATE SET
+			signal = EXCLUDED.signal,
+			created_at = EXCLUDED.created_at`,
+		fb.UserID, fb.ItemID, string(fb.Signal), fb.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save recommendation feedback: %w", err)
+	}
+	return nil
+}
+
+// GetRecommendationFeedback returns the latest feedback of every user.
+func (db *DB) GetRecommendationFeedback(ctx context.Context) ([]recommend.Feedback, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `
+		SELECT user_id, item_id, signal, created_at
+		FROM recommendation_feedback
+		ORDER BY user_id, created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recommendation feedback: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRecommendationFeedback(rows)
+}
+
+// GetUserRecommendationFeedback returns a user's feedback, newest first.
+func (db *DB) GetUserRecommendationFeedback(ctx context.Context, userID int) ([]recommend.Feedback, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `
+		SELECT user_id, item_id, signal, created_at
+		FROM recommendation_feedback
+		WHERE user_id = ?
+		ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user recommendation feedback: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRecommendationFeedback(rows)
+}
+
+// scanRecommendationFeedback reads feedback rows.
+func scanRecommendationFeedback(rows *sql.Rows) ([]recommend.Feedback, error) {
+	feedback := make([]recommend.Feedback, 0)
+	for rows.Next() {
+		var fb recommend.Feedback
+		var signal string
+		if err := rows.Scan(&fb.UserID, &fb.ItemID, &signal, &fb.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan recommendation feedback: %w", err)
+		}
+		fb.Signal = recommend.FeedbackSignal(signal)
+		feedback = append(feedback, fb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate recommendation feedback: %w", err)
+	}
+	return feedback, nil
+}
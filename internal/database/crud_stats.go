@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -67,6 +68,7 @@ func (db *DB) GetLocationStats(ctx context.Context, since time.Time, limit int)
 //   - ContentRatings: Filter by content rating (PG, R, etc.)
 //   - Years: Filter by release year
 //   - LocationTypes: Filter by connection type (lan, wan)
+//   - ConnectionTypes: Filter by client connection type (residential, vpn, datacenter, unknown)
 //   - Limit: Maximum results to return
 //
 // Returns LocationStats with:
@@ -81,6 +83,75 @@ func (db *DB) GetLocationStatsFiltered(ctx context.Context, filter LocationStats
 	ctx, cancel := db.ensureContext(ctx)
 	defer cancel()
 
+	// Use default limit of 100 if not specified
+	limit := filter.Limit
+	if limit == 0 {
+		limit = 100
+	}
+	query, args := locationStatsQuery(&filter, limit)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query location stats: %w", err)
+	}
+	defer rows.Close()
+
+	// Initialize with empty slice instead of nil to ensure consistent JSON serialization
+	stats := []models.LocationStats{}
+	for rows.Next() {
+		var s models.LocationStats
+		if err := scanLocationStats(rows, &s); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating location stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// StreamLocationStats calls fn for each location matching filter, in the
+// order of GetLocationStatsFiltered, without collecting the results, so
+// memory stays flat however many locations match. Unlike
+// GetLocationStatsFiltered there is no default limit; filter.Limit applies
+// only when set.
+//
+// fn receives a location that is reused for the next row. Returning an
+// error from fn stops the iteration and is returned unwrapped.
+func (db *DB) StreamLocationStats(ctx context.Context, filter LocationStatsFilter, fn func(*models.LocationStats) error) error {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	query, args := locationStatsQuery(&filter, filter.Limit)
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query location stats: %w", err)
+	}
+	defer rows.Close()
+
+	var s models.LocationStats
+	for rows.Next() {
+		if err := scanLocationStats(rows, &s); err != nil {
+			return err
+		}
+		if err := fn(&s); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating location stats: %w", err)
+	}
+	return nil
+}
+
+// locationStatsQuery builds the per-location aggregate query shared by
+// GetLocationStatsFiltered and StreamLocationStats. A limit of 0 returns
+// every location.
+func locationStatsQuery(filter *LocationStatsFilter, limit int) (string, []interface{}) {
 	query := `
 	SELECT
 		g.country,
@@ -98,47 +169,32 @@ func (db *DB) GetLocationStatsFiltered(ctx context.Context, filter LocationStats
 	WHERE 1=1`
 
 	// Use extracted filter builder
-	conditions, args := filter.buildFilterConditions()
+	conditions, args := buildPrefixedFilterConditions(filter)
 	query += conditions
 
 	query += `
 	GROUP BY g.country, g.region, g.city, g.latitude, g.longitude
-	ORDER BY playback_count DESC
-	LIMIT ?`
+	ORDER BY playback_count DESC`
 
-	// Use default limit of 100 if not specified
-	limit := filter.Limit
-	if limit == 0 {
-		limit = 100
+	if limit > 0 {
+		query += `
+	LIMIT ?`
+		args = append(args, limit)
 	}
-	args = append(args, limit)
+	return query, args
+}
 
-	rows, err := db.conn.QueryContext(ctx, query, args...)
+// scanLocationStats scans one row of locationStatsQuery into s
+func scanLocationStats(rows *sql.Rows, s *models.LocationStats) error {
+	err := rows.Scan(
+		&s.Country, &s.Region, &s.City, &s.Latitude, &s.Longitude,
+		&s.PlaybackCount, &s.UniqueUsers, &s.FirstSeen, &s.LastSeen,
+		&s.AvgCompletion,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query location stats: %w", err)
+		return fmt.Errorf("failed to scan location stats: %w", err)
 	}
-	defer rows.Close()
-
-	// Initialize with empty slice instead of nil to ensure consistent JSON serialization
-	stats := []models.LocationStats{}
-	for rows.Next() {
-		var s models.LocationStats
-		err := rows.Scan(
-			&s.Country, &s.Region, &s.City, &s.Latitude, &s.Longitude,
-			&s.PlaybackCount, &s.UniqueUsers, &s.FirstSeen, &s.LastSeen,
-			&s.AvgCompletion,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan location stats: %w", err)
-		}
-		stats = append(stats, s)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating location stats: %w", err)
-	}
-
-	return stats, nil
+	return nil
 }
 
 // GetStats retrieves comprehensive system-wide statistics for the dashboard.
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -137,3 +138,45 @@ func TestGetStats_EmptyDatabase(t *testing.T) {
 		t.Errorf("Expected 0 recent activity, got %d", stats.RecentActivity)
 	}
 }
+
+func TestStreamLocationStats(t *testing.T) {
+	db := setupTestDBWithData(t)
+	defer db.Close()
+
+	want, err := db.GetLocationStatsFiltered(context.Background(), LocationStatsFilter{})
+	checkNoError(t, err)
+
+	var got []models.LocationStats
+	err = db.StreamLocationStats(context.Background(), LocationStatsFilter{}, func(s *models.LocationStats) error {
+		got = append(got, *s)
+		return nil
+	})
+	checkNoError(t, err)
+
+	if len(got) != len(want) || len(got) == 0 {
+		t.Fatalf("streamed %d locations, want the %d from GetLocationStatsFiltered", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i].City != *want[i].City || got[i].PlaybackCount != want[i].PlaybackCount {
+			t.Errorf("location %d = %s (%d), want %s (%d)", i, *got[i].City, got[i].PlaybackCount, *want[i].City, want[i].PlaybackCount)
+		}
+	}
+}
+
+func TestStreamLocationStats_StopsOnCallbackError(t *testing.T) {
+	db := setupTestDBWithData(t)
+	defer db.Close()
+
+	stop := errors.New("client went away")
+	calls := 0
+	err := db.StreamLocationStats(context.Background(), LocationStatsFilter{}, func(*models.LocationStats) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("StreamLocationStats() error = %v, want the callback error", err)
+	}
+	if calls != 1 {
+		t.Errorf("callback ran %d times, want iteration to stop after the first error", calls)
+	}
+}
@@ -0,0 +1,59 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// Sync Watermark Operations (incremental sync)
+// =============================================================================
+
+// GetSyncWatermark returns the latest ingested playback time for a source and
+// server. Returns the zero time if no watermark has been stored yet.
+func (db *DB) GetSyncWatermark(ctx context.Context, source, serverID string) (time.Time, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	var watermark time.Time
+	err := db.conn.QueryRowContext(ctx,
+		`SELECT watermark FROM sync_watermarks WHERE source = ? AND server_id = ?`,
+		source, serverID,
+	).Scan(&watermark)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get sync watermark: %w", err)
+	}
+	return watermark, nil
+}
+
+// SetSyncWatermark stores the latest ingested playback time for a source and
+// server. The stored watermark never moves backwards: an older value than the
+// one already stored is ignored.
+func (db *DB) SetSyncWatermark(ctx context.Context, source, serverID string, watermark time.Time) error {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	_, err := db.conn.ExecContext(ctx, `
+		INSERT INTO sync_watermarks (source, server_id, watermark, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (source, server_id) DO UPDATE SET
+			watermark = GREATEST(sync_watermarks.watermark, EXCLUDED.watermark),
+			updated_at = EXCLUDED.updated_at`,
+		source, serverID, watermark, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set sync watermark: %w", err)
+	}
+	return nil
+}
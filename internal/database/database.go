@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	_ "github.com/duckdb/duckdb-go/v2"
@@ -50,10 +51,31 @@ type DB struct {
 	dataVersion   int64
 	dataVersionMu sync.RWMutex
 	tileCacheTTL  time.Duration
+	// renderTile renders a tile on a cache miss; nil uses queryVectorTile.
+	// Tests substitute it to count renders without the spatial extension.
+	renderTile func(ctx context.Context, z, x, y int, filter LocationStatsFilter) ([]byte, error)
+	// migrations overrides the embedded schema migrations; tests set it to
+	// exercise upgrades.
+	migrations []Migration
 
 	// Per-row write locks for concurrent UPSERTs
 	ipLocks sync.Map
 
+	// Slow query log (nil when DB_SLOW_QUERY_THRESHOLD is 0)
+	slowQueries *SlowQueryLog
+
+	// Limits concurrent analytics queries (see AcquireAnalyticsSlot); nil
+	// means unlimited
+	analytics *analyticsLimiter
+
+	// Number of in-progress copies of the database file (see BeginFileCopy)
+	fileCopies atomic.Int32
+
+	// Tags inserted playback events with a connection type (see
+	// SetConnectionClassifier); nil leaves it unset
+	connClassifier   ConnectionClassifier
+	connClassifierMu sync.RWMutex
+
 	// Connection recovery fields
 	serverLat         float64
 	serverLon         float64
@@ -102,10 +124,14 @@ func New(cfg *config.DatabaseConfig, serverLat, serverLon float64) (*DB, error)
 	connStr := fmt.Sprintf("%s?access_mode=read_write&threads=%d&max_memory=%s&preserve_insertion_order=%s&autoinstall_known_extensions=false&autoload_known_extensions=true",
 		cfg.Path, numThreads, cfg.MaxMemory, preserveOrder)
 
-	conn, err := sql.Open("duckdb", connStr)
+	slowQueries := NewSlowQueryLog(cfg)
+	conn, err := openDuckDB(connStr, slowQueries)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	if slowQueries != nil {
+		slowQueries.db = conn
+	}
 
 	db := &DB{
 		conn:                  conn,
@@ -117,6 +143,7 @@ func New(cfg *config.DatabaseConfig, serverLat, serverLon float64) (*DB, error)
 		sqliteAvailable:       true,
 		rapidfuzzAvailable:    true,
 		datasketchesAvailable: true,
+		slowQueries:           slowQueries,
 		stmtCache:             make(map[string]*sql.Stmt),
 		tileCache:             make(map[string]CachedTile),
 		dataVersion:           0,
@@ -210,6 +237,16 @@ func (db *DB) Conn() *sql.DB {
 	return db.conn
 }
 
+// PoolUtilization returns the fraction of the connection pool in use (0-1).
+// Background jobs such as model training check it to avoid starving queries.
+func (db *DB) PoolUtilization() float64 {
+	stats := db.conn.Stats()
+	if stats.MaxOpenConnections <= 0 {
+		return 0
+	}
+	return float64(stats.InUse) / float64(stats.MaxOpenConnections)
+}
+
 // Close closes the database connection and all prepared statements.
 // It performs a CHECKPOINT before closing to flush the WAL to the main database file.
 // This prevents WAL replay issues on next startup caused by a DuckDB bug where
@@ -255,13 +292,19 @@ func (db *DB) initialize() error {
 		return err
 	}
 
-	// Create tables
+	// Refuse a database migrated by a newer binary before touching its schema
+	existing, err := db.checkSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	// Create tables (the baseline schema)
 	if err := db.createTables(); err != nil {
 		return err
 	}
 
 	// Run versioned migrations (CRITICAL-006 fix: tracks applied migrations)
-	if err := db.runVersionedMigrations(); err != nil {
+	if err := db.runVersionedMigrations(existing); err != nil {
 		return err
 	}
 
@@ -22,6 +22,7 @@ Caching Systems:
   - TTL-based expiration (default 5 minutes)
   - Version-based invalidation when data changes
   - Prometheus metrics for cache hit/miss monitoring
+  - Low zoom levels can be pre-generated by TileWarmer (see WarmTileCache)
 
 3. Per-IP Locking:
   - Provides mutex locks per IP address for concurrent UPSERT operations
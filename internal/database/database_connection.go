@@ -9,11 +9,15 @@ database_connection.go - Connection Management
 This file provides connection pool configuration and error detection utilities.
 
 Connection Pool Configuration:
-  - MaxOpenConns: Based on CPU count for parallelism
-  - MaxIdleConns: 2 for efficient connection reuse
-  - ConnMaxLifetime: 1 hour to prevent stale connections
+  - MaxOpenConns: DB_MAX_OPEN_CONNS, default CPU count for parallelism
+  - MaxIdleConns: DB_MAX_IDLE_CONNS, default 2 for efficient connection reuse
+  - ConnMaxLifetime: DB_CONN_MAX_LIFETIME, default 1 hour to prevent stale connections
   - ConnMaxIdleTime: 5 minutes for idle connection cleanup
 
+Analytics queries share the pool with sync writes; see AcquireAnalyticsSlot
+for the limit on how many run at once, and PoolHealthChecker for the
+periodic probe that recycles broken connections.
+
 Error Detection:
 The package identifies connection errors vs query errors to determine
 appropriate error handling and recovery strategies.
@@ -26,6 +30,8 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
 )
 
 // isConnectionError checks if an error indicates database connection loss
@@ -43,22 +49,54 @@ func isConnectionError(err error) bool {
 		stringContains(errMsg, "sql: database is closed")
 }
 
-// configureConnectionPool sets connection pool parameters
+// Connection pool defaults, used when the DatabaseConfig value is zero
+const (
+	defaultMaxIdleConns    = 2
+	defaultConnMaxLifetime = time.Hour
+	connMaxIdleTime        = 5 * time.Minute
+)
+
+// configureConnectionPool sets connection pool parameters and the analytics
+// query limit from the database config
 func (db *DB) configureConnectionPool() error {
-	db.conn.SetMaxOpenConns(runtime.NumCPU())
-	db.conn.SetMaxIdleConns(2)
-	db.conn.SetConnMaxLifetime(time.Hour)
-	db.conn.SetConnMaxIdleTime(5 * time.Minute)
+	maxOpen := db.cfg.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = runtime.NumCPU()
+	}
+	db.conn.SetMaxOpenConns(maxOpen)
+	db.conn.SetMaxIdleConns(db.maxIdleConns())
+	lifetime := db.cfg.ConnMaxLifetime
+	if lifetime <= 0 {
+		lifetime = defaultConnMaxLifetime
+	}
+	db.conn.SetConnMaxLifetime(lifetime)
+	db.conn.SetConnMaxIdleTime(connMaxIdleTime)
 
-	// Note: Connection pool settings:
-	// - max_open: NumCPU() for parallelism
-	// - max_idle: 2 for connection reuse
-	// - max_lifetime: 1h to prevent stale connections
-	// - max_idle_time: 5m for idle connection cleanup
+	// Leave one connection for sync writes unless configured otherwise
+	maxAnalytics := db.cfg.MaxConcurrentAnalytics
+	if maxAnalytics <= 0 {
+		maxAnalytics = max(maxOpen-1, 1)
+	}
+	db.analytics = newAnalyticsLimiter(maxAnalytics)
+
+	logging.Debug().
+		Int("max_open", maxOpen).
+		Int("max_idle", db.maxIdleConns()).
+		Dur("max_lifetime", lifetime).
+		Int("max_concurrent_analytics", maxAnalytics).
+		Msg("Database connection pool configured")
 
 	return nil
 }
 
+// maxIdleConns returns the configured idle connection limit
+func (db *DB) maxIdleConns() int {
+	if db.cfg == nil || db.cfg.MaxIdleConns <= 0 {
+		return defaultMaxIdleConns
+	}
+	return db.cfg.MaxIdleConns
+}
+
 // isTransactionConflict checks if an error is a DuckDB transaction conflict
 func isTransactionConflict(err error) bool {
 	if err == nil {
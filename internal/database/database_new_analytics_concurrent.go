@@ -20,7 +20,7 @@ func (db *DB) GetConcurrentStreamsAnalytics(ctx context.Context, filter Location
 	defer cancel()
 
 	// Query time series data and calculate peak/average metrics
-	timeSeriesData, peakConcurrent, peakTime, avgConcurrent, err := db.getConcurrentStreamsTimeSeries(ctx, filter)
+	timeSeriesData, peakConcurrent, peakTime, avgConcurrent, err := db.getConcurrentStreamsHourly(ctx, filter)
 	if err != nil {
 		return nil, errorContext("get time series", err)
 	}
@@ -76,10 +76,10 @@ func generateCapacityRecommendation(peak, avg float64) string {
 	}
 }
 
-// getConcurrentStreamsTimeSeries queries hourly concurrent stream counts using DuckDB's generate_series
+// getConcurrentStreamsHourly queries hourly concurrent stream counts using DuckDB's generate_series
 // (optimized from recursive CTE) and calculates peak/average concurrency metrics across the filtered time range.
 // Performance: ~10-20x faster than recursive CTE for large date ranges (90 days = 2160 hours).
-func (db *DB) getConcurrentStreamsTimeSeries(ctx context.Context, filter LocationStatsFilter) ([]models.ConcurrentStreamsTimeBucket, int, time.Time, float64, error) {
+func (db *DB) getConcurrentStreamsHourly(ctx context.Context, filter LocationStatsFilter) ([]models.ConcurrentStreamsTimeBucket, int, time.Time, float64, error) {
 	whereClauses, args := buildFilterConditions(filter, false, 1)
 
 	// Build AND-prefixed WHERE clause for subqueries
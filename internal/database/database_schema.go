@@ -16,17 +16,17 @@ Tables:
   - user_mappings: Cross-platform user ID mapping for multi-server support
   - failed_events: Dead letter queue for events that failed processing
   - dedupe_audit_log: Audit trail for deduplication decisions
-
-Schema Strategy (Pre-Release):
-All columns are defined in the initial CREATE TABLE statement. This provides:
-  - Single source of truth for the complete schema
-  - Faster startup (no migrations to run)
-  - Cleaner codebase
-
-Post-Release Migration Strategy:
-After the first public release with real users, use versioned migrations in
-migrations.go to add new columns without losing existing data. See CLAUDE.md
-for details on when and how to re-enable migrations.
+  - quarantined_events: Events held back for implausible started_at timestamps
+  - sync_watermarks: Latest ingested playback time per source and server (incremental sync)
+  - recommendation_feedback: Latest explicit feedback signal per user and item
+
+Schema Strategy:
+The CREATE TABLE statements here are the baseline schema (migration 0001)
+and run on every start. CREATE TABLE IF NOT EXISTS never alters a table
+that already exists, so changes to existing tables (new columns, type or
+default changes, data fixes) go in numbered migrations in migrations/ (see
+migrations.go) rather than here; that way fresh installs and upgraded
+databases end up with the same schema.
 
 Index Strategy:
 Indexes are created for:
@@ -546,6 +546,57 @@ func (db *DB) getTableCreationQueries() []string {
 		created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
 	);`)
 
+	// Quarantined events table (timestamp sanity validation)
+	// Holds events whose started_at fell outside the accepted window (e.g. corrupt
+	// 1970/2106 epoch values) so they don't skew date ranges and trend charts.
+	// Stores the full event so it can be corrected and reprocessed.
+	queries = append(queries, `CREATE TABLE IF NOT EXISTS quarantined_events (
+		id UUID PRIMARY KEY,
+		quarantined_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+		-- The quarantined event
+		event_id TEXT NOT NULL,
+		source TEXT NOT NULL,
+		server_id TEXT,
+		session_key TEXT,
+		started_at TIMESTAMPTZ NOT NULL,
+		user_id INTEGER NOT NULL,
+		username TEXT,
+		title TEXT,
+		raw_payload JSON NOT NULL,
+
+		-- Why it was quarantined
+		reason TEXT NOT NULL,
+
+		-- Resolution
+		status TEXT NOT NULL DEFAULT 'pending',
+		corrected_started_at TIMESTAMPTZ,
+		resolved_by TEXT,
+		resolved_at TIMESTAMPTZ
+	);`)
+
+	// Sync watermarks table (incremental sync)
+	// Latest started_at successfully ingested per source and server, so periodic
+	// and post-restart syncs only fetch newer history instead of the full lookback.
+	queries = append(queries, `CREATE TABLE IF NOT EXISTS sync_watermarks (
+		source TEXT NOT NULL,
+		server_id TEXT NOT NULL,
+		watermark TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (source, server_id)
+	);`)
+
+	// Recommendation feedback table
+	// Explicit like/dislike/not_interested/watched_elsewhere signals. One row per
+	// user and item: a new signal replaces the previous one.
+	queries = append(queries, `CREATE TABLE IF NOT EXISTS recommendation_feedback (
+		user_id INTEGER NOT NULL,
+		item_id INTEGER NOT NULL,
+		signal TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (user_id, item_id)
+	);`)
+
 	// User roles table (v2.4 - RBAC Implementation)
 	// Stores persistent role assignments for users.
 	// Roles determine authorization levels: viewer (default), editor, admin.
@@ -700,6 +751,24 @@ func (db *DB) getTableCreationQueries() []string {
 		triggered_by_user_id TEXT
 	);`)
 
+	// Newsletter delivery log table
+	// One row per recipient and channel of each delivery, including the
+	// number of attempts, so failed recipients can be queried directly.
+	queries = append(queries, `CREATE TABLE IF NOT EXISTS newsletter_delivery_log (
+		id TEXT PRIMARY KEY,
+		delivery_id TEXT NOT NULL,
+		schedule_id TEXT,
+		channel TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		recipient_type TEXT NOT NULL,
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 1,
+		error_code TEXT,
+		error_message TEXT,
+		delivered_at TIMESTAMPTZ,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);`)
+
 	// Newsletter user preferences table (v2.6 - Newsletter Generator)
 	// Stores per-user newsletter preferences including opt-out status.
 	// Allows users to customize their newsletter experience.
@@ -809,6 +878,10 @@ func (db *DB) getTableCreationQueries() []string {
 		`CREATE INDEX IF NOT EXISTS idx_dedupe_audit_discarded ON dedupe_audit_log(discarded_event_id);`,
 		`CREATE INDEX IF NOT EXISTS idx_dedupe_audit_source ON dedupe_audit_log(discarded_source);`,
 		`CREATE INDEX IF NOT EXISTS idx_dedupe_audit_reason ON dedupe_audit_log(dedupe_reason);`,
+		// Quarantined events indexes
+		`CREATE INDEX IF NOT EXISTS idx_quarantined_events_quarantined_at ON quarantined_events(quarantined_at DESC);`,
+		`CREATE INDEX IF NOT EXISTS idx_quarantined_events_status ON quarantined_events(status);`,
+		`CREATE INDEX IF NOT EXISTS idx_quarantined_events_source ON quarantined_events(source);`,
 		// Wrapped reports indexes (v2.3 - Annual Wrapped Reports)
 		`CREATE INDEX IF NOT EXISTS idx_wrapped_reports_year ON wrapped_reports(year);`,
 		`CREATE INDEX IF NOT EXISTS idx_wrapped_reports_user_year ON wrapped_reports(user_id, year);`,
@@ -854,6 +927,11 @@ func (db *DB) getTableCreationQueries() []string {
 		`CREATE INDEX IF NOT EXISTS idx_newsletter_deliveries_status ON newsletter_deliveries(status);`,
 		`CREATE INDEX IF NOT EXISTS idx_newsletter_deliveries_started ON newsletter_deliveries(started_at DESC);`,
 		`CREATE INDEX IF NOT EXISTS idx_newsletter_deliveries_triggered_by ON newsletter_deliveries(triggered_by);`,
+		// Newsletter delivery log indexes
+		`CREATE INDEX IF NOT EXISTS idx_newsletter_delivery_log_delivery ON newsletter_delivery_log(delivery_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_newsletter_delivery_log_recipient ON newsletter_delivery_log(recipient);`,
+		`CREATE INDEX IF NOT EXISTS idx_newsletter_delivery_log_status ON newsletter_delivery_log(status);`,
+		`CREATE INDEX IF NOT EXISTS idx_newsletter_delivery_log_created ON newsletter_delivery_log(created_at DESC);`,
 		// Newsletter user preferences indexes (v2.6 - Newsletter Generator)
 		`CREATE INDEX IF NOT EXISTS idx_newsletter_prefs_opt_out ON newsletter_user_preferences(global_opt_out);`,
 		// Newsletter audit log indexes (v2.6 - Newsletter Generator)
@@ -877,9 +955,8 @@ func (db *DB) getTableCreationQueries() []string {
 	return queries
 }
 
-// NOTE: The schema is now fully consolidated in getTableCreationQueries().
-// For post-release schema changes, use versioned migrations in migrations.go.
-// See CLAUDE.md "Schema Consolidation" section for details on the migration strategy.
+// NOTE: The baseline schema is consolidated in getTableCreationQueries().
+// Later schema changes are versioned migrations (see migrations.go).
 
 // createIndexes creates database indexes for query optimization
 // Skips index creation if cfg.SkipIndexes is true (for fast test setup).
@@ -23,6 +23,9 @@
 //   - database_connection.go: Connection recovery with exponential backoff and pool configuration
 //   - database_cache.go: Prepared statement caching and vector tile caching with TTL
 //   - database_utils.go: Profiling, context management, and backup interface
+//   - instrumented_driver.go: Driver wrapper feeding tracing and the slow query log
+//   - slow_query.go: Slow query ring buffer with optional EXPLAIN capture
+//   - metrics_collector.go: Periodic Prometheus gauges for file sizes, memory and row counts
 //   - crud.go: Basic CRUD operations for playback events and geolocations
 //   - filter.go: Filter building and WHERE clause construction
 //
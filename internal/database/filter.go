@@ -7,110 +7,14 @@ package database
 
 import (
 	"fmt"
-	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
 )
 
-// LocationStatsFilter contains filter parameters for location statistics and analytics queries.
-// Provides comprehensive multi-dimensional filtering with 14+ filter dimensions, supporting
-// complex analytics queries across temporal, user, content, technical, and geographic axes.
-//
-// All filter fields are optional and combine using AND logic. Multi-select fields (slices)
-// use OR logic within the field (e.g., Users: ["alice", "bob"] matches alice OR bob).
-//
-// Filter Dimensions:
-//
-//  1. Temporal Filtering:
-//     - StartDate: Filter events on or after this timestamp (nil = no start limit)
-//     - EndDate: Filter events on or before this timestamp (nil = no end limit)
-//     - Years: Filter by content release year (supports multiple years)
-//
-//  2. User Filtering:
-//     - Users: Filter by usernames (multi-select OR)
-//
-//  3. Content Filtering:
-//     - MediaTypes: Filter by media type ("movie", "episode", "track", "photo")
-//     - Libraries: Filter by library name (multi-select OR)
-//     - ContentRatings: Filter by content rating ("G", "PG", "PG-13", "R", etc.)
-//
-//  4. Technical Filtering:
-//     - Platforms: Filter by platform/OS ("iOS", "Android", "Web", etc.)
-//     - Players: Filter by player app ("Plex Web", "Plex for iOS", etc.)
-//     - TranscodeDecisions: Filter by transcode decision ("direct play", "transcode", "copy")
-//     - VideoResolutions: Filter by video resolution ("4k", "1080p", "720p", etc.)
-//     - VideoCodecs: Filter by video codec ("h264", "hevc", "vp9", etc.)
-//     - AudioCodecs: Filter by audio codec ("aac", "ac3", "dts", etc.)
-//
-//  5. Geographic Filtering:
-//     - LocationTypes: Filter by location type ("country", "city", "isp")
-//
-//  6. Server Filtering (v2.1 Multi-Server Support):
-//     - ServerIDs: Filter by server ID ("plex-home", "jellyfin-abc123", etc.)
-//
-//  7. Result Limiting:
-//     - Limit: Maximum number of results to return (0 = no limit)
-//
-// Example - Basic temporal filter:
-//
-//	filter := LocationStatsFilter{
-//	    StartDate: &time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-//	    EndDate:   &time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC),
-//	    Limit:     100,
-//	}
-//
-// Example - Multi-dimensional analytics filter:
-//
-//	now := time.Now()
-//	thirtyDaysAgo := now.AddDate(0, 0, -30)
-//	filter := LocationStatsFilter{
-//	    StartDate:        &thirtyDaysAgo,
-//	    EndDate:          &now,
-//	    Users:            []string{"alice", "bob"},            // alice OR bob
-//	    MediaTypes:       []string{"movie", "episode"},        // movies OR episodes
-//	    TranscodeDecisions: []string{"transcode"},             // only transcoded streams
-//	    VideoResolutions: []string{"4k", "1080p"},             // 4K OR 1080p
-//	    Platforms:        []string{"iOS", "Android"},          // mobile only
-//	    Limit:            50,
-//	}
-//
-// SQL Generation:
-// This filter generates parameterized SQL WHERE clauses via buildFilterConditions():
-//
-//	// Example generated SQL:
-//	WHERE started_at >= ? AND started_at <= ?
-//	  AND username IN (?, ?)
-//	  AND media_type IN (?, ?)
-//	  AND transcode_decision = ?
-//	  AND video_resolution IN (?, ?)
-//	  AND platform IN (?, ?)
-//	LIMIT ?
-//
-// Performance Notes:
-//   - All fields use indexed columns for efficient filtering
-//   - Multi-select filters use IN clauses (optimized by DuckDB query planner)
-//   - Date range filters leverage composite index on (started_at DESC, id)
-//   - Typical query time: 5-50ms with proper indexing
-//
-// Thread Safety:
-// LocationStatsFilter is immutable after creation and safe for concurrent read access.
-// Multiple goroutines can safely pass the same filter to different query methods.
-type LocationStatsFilter struct {
-	StartDate          *time.Time
-	EndDate            *time.Time
-	Users              []string
-	MediaTypes         []string
-	Platforms          []string
-	Players            []string
-	TranscodeDecisions []string
-	VideoResolutions   []string
-	VideoCodecs        []string
-	AudioCodecs        []string
-	Libraries          []string
-	ContentRatings     []string
-	Years              []int
-	LocationTypes      []string
-	ServerIDs          []string // v2.1: Multi-server support - filter by server ID
-	Limit              int
-}
+// LocationStatsFilter is the multi-dimensional analytics filter. It is defined
+// in models so that query-parameter parsing (models.ParseLocationStatsFilter)
+// can live next to the type; the alias keeps existing database callers intact.
+type LocationStatsFilter = models.LocationStatsFilter
 
 // appendInClause is a generic helper for building SQL IN clauses
 // Eliminates code duplication across 12+ filter dimensions
@@ -196,7 +100,7 @@ func buildFilterConditions(filter LocationStatsFilter, usePositionalParams bool,
 		argPos++
 	}
 
-	// Multi-value filters using generic helper (13 filter dimensions)
+	// Multi-value filters using generic helper (14 filter dimensions)
 	appendInClause("username", filter.Users, &whereClauses, &args, &argPos, usePositionalParams)
 	appendInClause("media_type", filter.MediaTypes, &whereClauses, &args, &argPos, usePositionalParams)
 	appendInClause("platform", filter.Platforms, &whereClauses, &args, &argPos, usePositionalParams)
@@ -209,6 +113,8 @@ func buildFilterConditions(filter LocationStatsFilter, usePositionalParams bool,
 	appendInClause("content_rating", filter.ContentRatings, &whereClauses, &args, &argPos, usePositionalParams)
 	appendInClause("year", filter.Years, &whereClauses, &args, &argPos, usePositionalParams)
 	appendInClause("location_type", filter.LocationTypes, &whereClauses, &args, &argPos, usePositionalParams)
+	// Events stored before connection types were tagged have none
+	appendInClause("COALESCE(connection_type, 'unknown')", filter.ConnectionTypes, &whereClauses, &args, &argPos, usePositionalParams)
 	appendInClause("server_id", filter.ServerIDs, &whereClauses, &args, &argPos, usePositionalParams) // v2.1: Multi-server support
 
 	return whereClauses, args
@@ -296,3 +296,20 @@ func TestBuildFilterConditions_LocationTypes(t *testing.T) {
 		t.Errorf("Expected 'location_type IN (?, ?)', got '%s'", whereClauses[0])
 	}
 }
+
+func TestBuildFilterConditions_ConnectionTypes(t *testing.T) {
+	filter := LocationStatsFilter{
+		ConnectionTypes: []string{"vpn", "unknown"},
+	}
+
+	whereClauses, args := buildFilterConditions(filter, false, 1)
+
+	if len(whereClauses) != 1 || len(args) != 2 {
+		t.Fatalf("Expected 1 where clause and 2 args, got %v %v", whereClauses, args)
+	}
+
+	// Events without a connection type match "unknown"
+	if whereClauses[0] != "COALESCE(connection_type, 'unknown') IN (?, ?)" {
+		t.Errorf("Unexpected where clause '%s'", whereClauses[0])
+	}
+}
@@ -0,0 +1,274 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/duckdb/duckdb-go/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tomtom215/cartographus/internal/tracing"
+)
+
+// openDuckDB opens the DuckDB database. When tracing or the slow query log is
+// enabled the driver is wrapped so each statement can be observed; otherwise
+// the plain driver is used and instrumentation adds no overhead.
+func openDuckDB(connStr string, slow *SlowQueryLog) (*sql.DB, error) {
+	if !tracing.Enabled() && slow == nil {
+		return sql.Open("duckdb", connStr)
+	}
+
+	connector, err := duckdb.NewConnector(connStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(&instrumentedConnector{Connector: connector, slow: slow}), nil
+}
+
+// instrumentedDriverConn is the subset of *duckdb.Conn that database/sql
+// uses. Only these interfaces are forwarded, so database/sql sees the same
+// capabilities as with the unwrapped driver.
+type instrumentedDriverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.NamedValueChecker
+}
+
+// instrumentedConnector wraps a connector so its connections are observed.
+type instrumentedConnector struct {
+	driver.Connector
+	slow *SlowQueryLog
+}
+
+// Connect opens an instrumented connection.
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inner, ok := conn.(instrumentedDriverConn)
+	if !ok {
+		return conn, nil
+	}
+	return &instrumentedConn{conn: inner, slow: c.slow}, nil
+}
+
+// Close closes the underlying connector. For DuckDB this closes the
+// database, so sql.DB.Close must reach it.
+func (c *instrumentedConnector) Close() error {
+	if closer, ok := c.Connector.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// instrumentedConn observes each statement executed on a connection.
+type instrumentedConn struct {
+	conn instrumentedDriverConn
+	slow *SlowQueryLog
+}
+
+func (c *instrumentedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	return c.conn.CheckNamedValue(nv)
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ctx, st := startStatement(ctx, c.slow, query, args)
+	result, err := c.conn.ExecContext(ctx, query, args)
+	st.endExec(result, err)
+	return result, err
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, st := startStatement(ctx, c.slow, query, args)
+	rows, err := c.conn.QueryContext(ctx, query, args)
+	return st.wrapRows(rows, err)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrapStmt(stmt, query), nil
+}
+
+func (c *instrumentedConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrapStmt(stmt, query), nil
+}
+
+func (c *instrumentedConn) Begin() (driver.Tx, error) {
+	return c.conn.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.conn.BeginTx(ctx, opts)
+}
+
+func (c *instrumentedConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *instrumentedConn) wrapStmt(stmt driver.Stmt, query string) driver.Stmt {
+	inner, ok := stmt.(instrumentedDriverStmt)
+	if !ok {
+		return stmt
+	}
+	return &instrumentedStmt{instrumentedDriverStmt: inner, query: query, slow: c.slow}
+}
+
+// instrumentedDriverStmt is the subset of *duckdb.Stmt that database/sql uses.
+type instrumentedDriverStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+}
+
+// instrumentedStmt observes each execution of a prepared statement.
+type instrumentedStmt struct {
+	instrumentedDriverStmt
+	query string
+	slow  *SlowQueryLog
+}
+
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, st := startStatement(ctx, s.slow, s.query, args)
+	result, err := s.instrumentedDriverStmt.ExecContext(ctx, args)
+	st.endExec(result, err)
+	return result, err
+}
+
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, st := startStatement(ctx, s.slow, s.query, args)
+	rows, err := s.instrumentedDriverStmt.QueryContext(ctx, args)
+	return st.wrapRows(rows, err)
+}
+
+// statement is one execution of a SQL statement: its span, if traced, and
+// what the slow query log needs once it finishes.
+type statement struct {
+	ctx   context.Context
+	span  trace.Span
+	slow  *SlowQueryLog
+	query string
+	args  []driver.NamedValue
+	start time.Time
+}
+
+func startStatement(ctx context.Context, slow *SlowQueryLog, query string, args []driver.NamedValue) (context.Context, statement) {
+	spanCtx, span := startQuerySpan(ctx, query)
+	st := statement{ctx: ctx, span: span, slow: slow, query: query, args: args}
+	if slow != nil {
+		st.start = time.Now()
+	}
+	return spanCtx, st
+}
+
+// observed reports whether anything needs the statement's outcome.
+func (st *statement) observed() bool {
+	return st.slow != nil || st.span.IsRecording()
+}
+
+func (st *statement) endExec(result driver.Result, err error) {
+	if !st.observed() {
+		return
+	}
+	var affected int64
+	if err == nil && result != nil {
+		if n, affErr := result.RowsAffected(); affErr == nil {
+			affected = n
+		}
+	}
+	st.end(attrRowsAffected, affected, err)
+}
+
+func (st *statement) end(rowsAttr attribute.Key, rows int64, err error) {
+	if st.slow != nil {
+		st.slow.observe(st.ctx, st.query, st.args, st.start, rows, err)
+	}
+	if st.span.IsRecording() {
+		st.span.SetAttributes(rowsAttr.Int64(rows))
+		tracing.RecordError(st.span, err)
+		st.span.End()
+	}
+}
+
+func (st statement) wrapRows(rows driver.Rows, err error) (driver.Rows, error) {
+	if err != nil {
+		st.end(attrRowsReturned, 0, err)
+		return nil, err
+	}
+	if !st.observed() {
+		return rows, nil
+	}
+	return &instrumentedRows{Rows: rows, st: st}, nil
+}
+
+// instrumentedRows counts returned rows and ends the statement when the
+// result set is exhausted or closed, so the span and the slow query
+// duration cover the time spent streaming.
+type instrumentedRows struct {
+	driver.Rows
+	st    statement
+	count int64
+	once  sync.Once
+}
+
+func (r *instrumentedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	switch err {
+	case nil:
+		r.count++
+	case io.EOF:
+		r.end(nil)
+	default:
+		r.end(err)
+	}
+	return err
+}
+
+func (r *instrumentedRows) Close() error {
+	err := r.Rows.Close()
+	r.end(nil)
+	return err
+}
+
+func (r *instrumentedRows) end(err error) {
+	r.once.Do(func() {
+		r.st.end(attrRowsReturned, r.count, err)
+	})
+}
+
+// ColumnTypeScanType forwards to the driver so Scan into any keeps DuckDB's
+// native Go types.
+func (r *instrumentedRows) ColumnTypeScanType(index int) reflect.Type {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return typed.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(any)).Elem()
+}
+
+func (r *instrumentedRows) ColumnTypeDatabaseTypeName(index int) string {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return typed.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
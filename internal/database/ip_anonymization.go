@@ -0,0 +1,261 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/privacy"
+)
+
+// ErrIPModeFull is returned by AnonymizeStoredIPs when PRIVACY_IP_MODE is
+// full, so there is nothing to rewrite.
+var ErrIPModeFull = errors.New("PRIVACY_IP_MODE is full; set truncated or hashed first")
+
+// ipAnonymizationBatch is the number of address pairs inserted into the
+// mapping table per statement.
+const ipAnonymizationBatch = 500
+
+// ipColumn is a column holding client IP addresses.
+type ipColumn struct {
+	table  string
+	column string
+	list   bool // Comma-separated lists, such as a forwarded-for chain
+}
+
+// storedIPColumns are the columns AnonymizeStoredIPs rewrites, apart from
+// geolocations.ip_address (a primary key, handled separately).
+var storedIPColumns = []ipColumn{
+	{table: "playback_events", column: "ip_address"},
+	{table: "playback_events", column: "ip_address_public"},
+	{table: "detection_alerts", column: "ip_address"},
+	{table: "audit_events", column: "source_ip", list: true},
+	{table: "personal_access_tokens", column: "last_used_ip"},
+	{table: "pat_usage_log", column: "ip_address"},
+	{table: "newsletter_audit_log", column: "ip_address"},
+	{table: "media_server_audit", column: "ip_address"},
+	{table: "role_audit_log", column: "ip_address"},
+}
+
+// IPAnonymizationTable reports the rows of one column rewritten by
+// AnonymizeStoredIPs.
+type IPAnonymizationTable struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+	Rows   int64  `json:"rows"`
+}
+
+// IPAnonymizationResult is the outcome of AnonymizeStoredIPs.
+type IPAnonymizationResult struct {
+	Mode   privacy.IPMode         `json:"mode"`
+	Tables []IPAnonymizationTable `json:"tables"`
+}
+
+// anonymizeIPPtr anonymizes an optional address; nil stays nil.
+func anonymizeIPPtr(ip *string) *string {
+	if ip == nil {
+		return nil
+	}
+	anonymized := privacy.AnonymizeIP(*ip)
+	return &anonymized
+}
+
+// AnonymizeStoredIPs applies the current PRIVACY_IP_MODE to addresses
+// stored before it was enabled, in one transaction. Values that are
+// already anonymized are left unchanged, so it is safe to run again.
+//
+// Geolocations are re-keyed by their anonymized address. When several
+// addresses collapse into one (truncated mode), the most recently updated
+// record is kept. Tables of optional subsystems that do not exist are
+// skipped. JSON payloads (alert metadata, audit details) and the
+// dead-letter queue are not rewritten.
+//
+// Returns ErrIPModeFull when the mode is full.
+func (db *DB) AnonymizeStoredIPs(ctx context.Context) (*IPAnonymizationResult, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	mode := privacy.CurrentIPMode()
+	if mode == privacy.IPModeFull {
+		return nil, ErrIPModeFull
+	}
+
+	columns := append([]ipColumn{{table: "geolocations", column: "ip_address"}}, storedIPColumns...)
+
+	// Build each column's mapping before the transaction starts
+	mappings := make([]map[string]string, len(columns))
+	for i, col := range columns {
+		exists, err := db.tableExists(ctx, col.table)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		if mappings[i], err = db.ipColumnMapping(ctx, col); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	if _, err := tx.ExecContext(ctx, `CREATE OR REPLACE TEMP TABLE ip_anonymization_map (original TEXT, anonymized TEXT)`); err != nil {
+		return nil, fmt.Errorf("failed to create IP mapping table: %w", err)
+	}
+
+	result := &IPAnonymizationResult{Mode: mode, Tables: make([]IPAnonymizationTable, 0, len(columns))}
+	for i, col := range columns {
+		if len(mappings[i]) == 0 {
+			continue
+		}
+		if err := fillIPMappingTable(ctx, tx, mappings[i]); err != nil {
+			return nil, err
+		}
+
+		var rows int64
+		if col.table == "geolocations" {
+			rows, err = rekeyGeolocations(ctx, tx)
+		} else {
+			rows, err = rewriteIPColumn(ctx, tx, col)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Tables = append(result.Tables, IPAnonymizationTable{Table: col.table, Column: col.column, Rows: rows})
+	}
+
+	if _, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS ip_anonymization_map`); err != nil {
+		return nil, fmt.Errorf("failed to drop IP mapping table: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit IP anonymization: %w", err)
+	}
+
+	db.IncrementDataVersion()
+
+	logging.Info().
+		Str("mode", string(mode)).
+		Int("columns", len(result.Tables)).
+		Msg("Anonymized stored IP addresses")
+
+	return result, nil
+}
+
+// ipColumnMapping returns original -> anonymized for the distinct values
+// of col that the current mode changes.
+func (db *DB) ipColumnMapping(ctx context.Context, col ipColumn) (map[string]string, error) {
+	query := fmt.Sprintf(`SELECT DISTINCT %s FROM %s WHERE %s IS NOT NULL AND %s <> ''`,
+		col.column, col.table, col.column, col.column)
+	rows, err := db.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.%s: %w", col.table, col.column, err)
+	}
+	defer rows.Close()
+
+	mapping := make(map[string]string)
+	for rows.Next() {
+		var ip string
+		if err := rows.Scan(&ip); err != nil {
+			return nil, fmt.Errorf("failed to scan %s.%s: %w", col.table, col.column, err)
+		}
+		anonymized := privacy.AnonymizeIP(ip)
+		if col.list {
+			anonymized = privacy.AnonymizeIPList(ip)
+		}
+		if anonymized != ip {
+			mapping[ip] = anonymized
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s.%s: %w", col.table, col.column, err)
+	}
+	return mapping, nil
+}
+
+// fillIPMappingTable replaces the contents of ip_anonymization_map.
+func fillIPMappingTable(ctx context.Context, tx *sql.Tx, mapping map[string]string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ip_anonymization_map`); err != nil {
+		return fmt.Errorf("failed to clear IP mapping table: %w", err)
+	}
+
+	values := make([]string, 0, ipAnonymizationBatch)
+	args := make([]interface{}, 0, 2*ipAnonymizationBatch)
+	flush := func() error {
+		if len(values) == 0 {
+			return nil
+		}
+		query := `INSERT INTO ip_anonymization_map VALUES ` + strings.Join(values, ", ")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to fill IP mapping table: %w", err)
+		}
+		values, args = values[:0], args[:0]
+		return nil
+	}
+
+	for original, anonymized := range mapping {
+		values = append(values, "(?, ?)")
+		args = append(args, original, anonymized)
+		if len(values) == ipAnonymizationBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// rewriteIPColumn replaces the mapped values of col.
+func rewriteIPColumn(ctx context.Context, tx *sql.Tx, col ipColumn) (int64, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET %s = m.anonymized
+		FROM ip_anonymization_map m
+		WHERE %s.%s = m.original`, col.table, col.column, col.table, col.column)
+	res, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize %s.%s: %w", col.table, col.column, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count anonymized %s rows: %w", col.table, err)
+	}
+	return n, nil
+}
+
+// rekeyGeolocations copies each mapped geolocation to its anonymized
+// address, unless that address already has a record, then deletes the
+// originals. Returns the number of original records removed.
+func rekeyGeolocations(ctx context.Context, tx *sql.Tx) (int64, error) {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO geolocations
+		SELECT g.* REPLACE (m.anonymized AS ip_address)
+		FROM geolocations g
+		JOIN ip_anonymization_map m ON g.ip_address = m.original
+		WHERE m.anonymized <> ''
+		QUALIFY row_number() OVER (PARTITION BY m.anonymized ORDER BY g.last_updated DESC) = 1`); err != nil {
+		return 0, fmt.Errorf("failed to re-key geolocations: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM geolocations
+		WHERE ip_address IN (SELECT original FROM ip_anonymization_map)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete re-keyed geolocations: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count re-keyed geolocations: %w", err)
+	}
+	return n, nil
+}
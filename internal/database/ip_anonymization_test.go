@@ -0,0 +1,140 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
+)
+
+// setIPMode installs an anonymizer for the rest of the test. Tests that
+// call it must not run in parallel.
+func setIPMode(t *testing.T, mode privacy.IPMode) {
+	t.Helper()
+	anonymizer, err := privacy.NewIPAnonymizer(mode, "0123456789abcdef0123456789abcdef")
+	checkNoError(t, err)
+	privacy.SetIPAnonymizer(anonymizer)
+	t.Cleanup(func() { privacy.SetIPAnonymizer(nil) })
+}
+
+func TestIPAnonymization_OnWrite(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	setIPMode(t, privacy.IPModeTruncated)
+
+	public := "198.51.100.23"
+	event := &models.PlaybackEvent{
+		ID: uuid.New(), SessionKey: "s1", StartedAt: time.Now(), UserID: 1, Username: "user1",
+		IPAddress: "203.0.113.57", IPAddressPublic: &public, MediaType: "movie", Title: "Movie",
+	}
+	checkNoError(t, db.InsertPlaybackEvent(event))
+	if event.IPAddress != "203.0.113.57" {
+		t.Errorf("caller's event changed to %q", event.IPAddress)
+	}
+
+	var ip, ipPublic string
+	checkNoError(t, db.conn.QueryRow(`SELECT ip_address, ip_address_public FROM playback_events WHERE id = ?`, event.ID).
+		Scan(&ip, &ipPublic))
+	if ip != "203.0.113.0" || ipPublic != "198.51.100.0" {
+		t.Errorf("stored addresses = %q, %q; want 203.0.113.0, 198.51.100.0", ip, ipPublic)
+	}
+
+	// Geolocations are stored and looked up under the anonymized address
+	geo := &models.Geolocation{IPAddress: "203.0.113.57", Latitude: 40.7, Longitude: -74.0, Country: "United States"}
+	checkNoError(t, db.UpsertGeolocation(geo))
+	if geo.IPAddress != "203.0.113.57" {
+		t.Errorf("caller's geolocation changed to %q", geo.IPAddress)
+	}
+
+	found, err := db.GetGeolocation(ctx, "203.0.113.99")
+	checkNoError(t, err)
+	if found == nil || found.IPAddress != "203.0.113.0" {
+		t.Fatalf("GetGeolocation(same /24) = %+v, want the record stored as 203.0.113.0", found)
+	}
+
+	geos, err := db.GetGeolocations(ctx, []string{"203.0.113.57", "203.0.113.8", "192.0.2.1"})
+	checkNoError(t, err)
+	if len(geos) != 2 || geos["203.0.113.57"] == nil || geos["203.0.113.8"] == nil {
+		t.Errorf("GetGeolocations() keys = %v, want both requested addresses of the /24", geos)
+	}
+}
+
+func TestAnonymizeStoredIPs(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	insertTestGeolocations(t, db)
+	insertTestPlaybacks(t, db)
+
+	for _, stmt := range []string{
+		`CREATE TABLE audit_events (id TEXT PRIMARY KEY, source_ip TEXT NOT NULL)`,
+		`INSERT INTO audit_events VALUES ('a1', '192.168.1.1, 10.0.0.7'), ('a2', '192.168.2.9')`,
+		// An entry already stored under an anonymized address is kept
+		`INSERT INTO geolocations (ip_address, latitude, longitude, city, country, last_updated)
+			VALUES ('192.168.2.0', 1, 2, 'Existing', 'Nowhere', now())`,
+	} {
+		_, err := db.conn.Exec(stmt)
+		checkNoError(t, err)
+	}
+
+	if _, err := db.AnonymizeStoredIPs(ctx); !errors.Is(err, ErrIPModeFull) {
+		t.Fatalf("AnonymizeStoredIPs(full) error = %v, want ErrIPModeFull", err)
+	}
+
+	setIPMode(t, privacy.IPModeTruncated)
+	result, err := db.AnonymizeStoredIPs(ctx)
+	checkNoError(t, err)
+
+	rows := make(map[string]int64)
+	for _, table := range result.Tables {
+		rows[table.Table+"."+table.Column] = table.Rows
+	}
+	want := map[string]int64{
+		"geolocations.ip_address":    5,
+		"playback_events.ip_address": 9,
+		"audit_events.source_ip":     2,
+	}
+	for key, n := range want {
+		if rows[key] != n {
+			t.Errorf("%s rows = %d, want %d (all: %v)", key, rows[key], n, rows)
+		}
+	}
+
+	var geoCount int
+	var city string
+	checkNoError(t, db.conn.QueryRow(`SELECT COUNT(*) FROM geolocations`).Scan(&geoCount))
+	checkNoError(t, db.conn.QueryRow(`SELECT city FROM geolocations WHERE ip_address = '192.168.1.0'`).Scan(&city))
+	if geoCount != 2 {
+		t.Errorf("geolocations = %d, want 2 (the five 192.168.1.x collapse into one)", geoCount)
+	}
+
+	// Every playback still joins its geolocation
+	var joined int
+	checkNoError(t, db.conn.QueryRow(`
+		SELECT COUNT(*) FROM playback_events p JOIN geolocations g ON p.ip_address = g.ip_address`).Scan(&joined))
+	if joined != 9 {
+		t.Errorf("playbacks joined to a geolocation = %d, want 9", joined)
+	}
+
+	var sourceIP string
+	checkNoError(t, db.conn.QueryRow(`SELECT source_ip FROM audit_events WHERE id = 'a1'`).Scan(&sourceIP))
+	if sourceIP != "192.168.1.0, 10.0.0.0" {
+		t.Errorf("audit source_ip = %q, want each address of the list truncated", sourceIP)
+	}
+
+	// A second run finds nothing left to change
+	again, err := db.AnonymizeStoredIPs(ctx)
+	checkNoError(t, err)
+	if len(again.Tables) != 0 {
+		t.Errorf("second run rewrote %+v, want nothing", again.Tables)
+	}
+}
@@ -0,0 +1,187 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// metricsCollectTimeout bounds one collection so a busy database cannot
+// stall the collector.
+const metricsCollectTimeout = 10 * time.Second
+
+// metricsTables are the tables whose row counts are exported. Tables that
+// do not exist (e.g. detection disabled) are skipped.
+var metricsTables = []string{
+	"playback_events",
+	"geolocations",
+	"audit_events",
+	"detection_alerts",
+	"detection_rules",
+	"user_trust_scores",
+	"trust_score_history",
+}
+
+// MetricsCollector periodically exports database file sizes, DuckDB memory
+// usage, table row counts and connection pool stats as Prometheus gauges.
+// It implements suture.Service.
+//
+// Collection is cheap: row counts come from DuckDB's estimated_size in
+// duckdb_tables() rather than COUNT(*), and file sizes from os.Stat. It is
+// skipped while a backup copies the database file (see BeginFileCopy).
+type MetricsCollector struct {
+	db       *DB
+	interval time.Duration
+}
+
+// NewMetricsCollector creates a collector that runs every interval.
+func NewMetricsCollector(db *DB, interval time.Duration) *MetricsCollector {
+	return &MetricsCollector{db: db, interval: interval}
+}
+
+// Serve collects once immediately and then every interval until ctx is canceled.
+func (c *MetricsCollector) Serve(ctx context.Context) error {
+	c.collectAndRecord(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			c.collectAndRecord(ctx)
+		}
+	}
+}
+
+// String implements fmt.Stringer for supervisor logging.
+func (c *MetricsCollector) String() string {
+	return "database-metrics"
+}
+
+func (c *MetricsCollector) collectAndRecord(ctx context.Context) {
+	if c.db.fileCopyInProgress() {
+		metrics.RecordDBStatsCollection("skipped")
+		logging.Debug().Msg("Skipping database metrics collection while backup copies the database file")
+		return
+	}
+
+	if err := c.Collect(ctx); err != nil {
+		metrics.RecordDBStatsCollection("error")
+		logging.Warn().Err(err).Msg("Database metrics collection incomplete")
+		return
+	}
+	metrics.RecordDBStatsCollection("success")
+}
+
+// Collect gathers all database metrics once. Gauges that could be read are
+// updated even when others fail; the returned error joins the failures.
+func (c *MetricsCollector) Collect(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, metricsCollectTimeout)
+	defer cancel()
+
+	metrics.UpdateDBConnectionPool(c.db.conn.Stats())
+
+	var errs []error
+	if err := c.collectFileSizes(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.collectMemory(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.collectTableRows(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (c *MetricsCollector) collectFileSizes() error {
+	path := c.db.GetDatabasePath()
+	if path == "" || path == ":memory:" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat database file: %w", err)
+	}
+
+	// The WAL only exists between checkpoints
+	var walSize int64
+	if walInfo, err := os.Stat(path + ".wal"); err == nil {
+		walSize = walInfo.Size()
+	}
+
+	metrics.UpdateDBFileSizes(info.Size(), walSize)
+	return nil
+}
+
+func (c *MetricsCollector) collectMemory(ctx context.Context) error {
+	var used int64
+	err := c.db.conn.QueryRowContext(ctx,
+		"SELECT CAST(COALESCE(SUM(memory_usage_bytes), 0) AS BIGINT) FROM duckdb_memory()").Scan(&used)
+	if err != nil {
+		return fmt.Errorf("query duckdb_memory: %w", err)
+	}
+	metrics.UpdateDBMemoryUsage(used)
+	return nil
+}
+
+func (c *MetricsCollector) collectTableRows(ctx context.Context) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(metricsTables)), ", ")
+	args := make([]any, len(metricsTables))
+	for i, table := range metricsTables {
+		args[i] = table
+	}
+
+	//nolint:gosec // placeholders only, table names are bound parameters
+	rows, err := c.db.conn.QueryContext(ctx, `
+		SELECT table_name, estimated_size
+		FROM duckdb_tables()
+		WHERE database_name = current_database()
+		  AND schema_name = 'main'
+		  AND table_name IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("query duckdb_tables: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table string
+		var count int64
+		if err := rows.Scan(&table, &count); err != nil {
+			return fmt.Errorf("scan table stats: %w", err)
+		}
+		metrics.UpdateDBTableRows(table, count)
+	}
+	return rows.Err()
+}
+
+// BeginFileCopy marks the database file as being copied, e.g. by a backup,
+// until the returned function is called. Background readers of the file,
+// such as the metrics collector, skip their work meanwhile.
+func (db *DB) BeginFileCopy() (end func()) {
+	db.fileCopies.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() { db.fileCopies.Add(-1) })
+	}
+}
+
+func (db *DB) fileCopyInProgress() bool {
+	return db.fileCopies.Load() > 0
+}
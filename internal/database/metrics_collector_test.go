@@ -0,0 +1,138 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// openMetricsTestDB opens a plain DuckDB file in a temp directory with
+// small stand-ins for the tables the collector reports on.
+func openMetricsTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "metrics.duckdb")
+	conn, err := sql.Open("duckdb", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	for _, stmt := range []string{
+		"CREATE TABLE playback_events (id INTEGER)",
+		"INSERT INTO playback_events SELECT range FROM range(250)",
+		"CREATE TABLE geolocations (ip VARCHAR)",
+		"INSERT INTO geolocations VALUES ('10.0.0.1'), ('10.0.0.2')",
+		"CREATE TABLE unrelated (id INTEGER)",
+		"CHECKPOINT",
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("Exec(%q) error = %v", stmt, err)
+		}
+	}
+
+	return &DB{conn: conn, cfg: &config.DatabaseConfig{Path: path}}
+}
+
+func TestMetricsCollector_Collect(t *testing.T) {
+	db := openMetricsTestDB(t)
+	collector := NewMetricsCollector(db, time.Minute)
+
+	if err := collector.Collect(context.Background()); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.DBTableRows.WithLabelValues("playback_events")); got != 250 {
+		t.Errorf("playback_events rows = %v, want 250", got)
+	}
+	if got := testutil.ToFloat64(metrics.DBTableRows.WithLabelValues("geolocations")); got != 2 {
+		t.Errorf("geolocations rows = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(metrics.DBFileSizeBytes); got <= 0 {
+		t.Errorf("database size = %v, want > 0", got)
+	}
+	if got := testutil.ToFloat64(metrics.DBMemoryUsageBytes); got < 0 {
+		t.Errorf("memory usage = %v, want >= 0", got)
+	}
+	if got := testutil.ToFloat64(metrics.DBConnectionPool.WithLabelValues("open")); got < 1 {
+		t.Errorf("open connections = %v, want >= 1", got)
+	}
+
+	// Only the listed tables are exported
+	if metrics.DBTableRows.DeleteLabelValues("unrelated") {
+		t.Error("row count exported for a table outside metricsTables")
+	}
+}
+
+func TestMetricsCollector_SkippedDuringFileCopy(t *testing.T) {
+	db := openMetricsTestDB(t)
+	collector := NewMetricsCollector(db, time.Minute)
+
+	skipped := metrics.DBStatsCollections.WithLabelValues("skipped")
+	succeeded := metrics.DBStatsCollections.WithLabelValues("success")
+	skippedBefore := testutil.ToFloat64(skipped)
+	succeededBefore := testutil.ToFloat64(succeeded)
+
+	end := db.BeginFileCopy()
+	collector.collectAndRecord(context.Background())
+	if got := testutil.ToFloat64(skipped) - skippedBefore; got != 1 {
+		t.Errorf("skipped collections = %v, want 1 while a file copy is in progress", got)
+	}
+
+	end()
+	end() // ending twice must not underflow the copy count
+	if db.fileCopyInProgress() {
+		t.Fatal("fileCopyInProgress() = true after the copy ended")
+	}
+
+	collector.collectAndRecord(context.Background())
+	if got := testutil.ToFloat64(succeeded) - succeededBefore; got != 1 {
+		t.Errorf("successful collections = %v, want 1 after the copy ended", got)
+	}
+}
+
+func TestMetricsCollector_ServeStopsOnCancel(t *testing.T) {
+	db := openMetricsTestDB(t)
+	collector := NewMetricsCollector(db, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- collector.Serve(ctx) }()
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("Serve() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() did not return after cancel")
+	}
+}
+
+func TestMetricsCollector_InMemoryDatabase(t *testing.T) {
+	conn, err := sql.Open("duckdb", "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer conn.Close()
+
+	db := &DB{conn: conn, cfg: &config.DatabaseConfig{Path: ":memory:"}}
+	if err := NewMetricsCollector(db, time.Minute).Collect(context.Background()); err != nil {
+		t.Errorf("Collect() error = %v, want file sizes skipped for in-memory databases", err)
+	}
+}
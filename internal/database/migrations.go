@@ -5,40 +5,146 @@
 
 // Package database provides versioned schema migration support.
 //
-// This file implements a production-ready migration system that:
-// - Tracks applied migrations in schema_migrations table
-// - Ensures migrations run exactly once
-// - Provides migration version information for debugging
-// - Supports both initial schema creation and incremental changes
+// This file implements the migration system that:
+//   - Embeds numbered SQL migrations (migrations/NNNN_name.sql) in the binary
+//   - Records applied versions and their checksums in schema_migrations
+//   - Applies pending migrations at startup, each in its own transaction
+//   - Refuses to open a database migrated by a newer binary (ErrSchemaTooNew)
+//   - Baselines databases created before migrations were tracked
 //
-// SCHEMA CONSOLIDATION (Pre-Release):
-// As of 2026-01-04, all 187 column migrations have been consolidated into
-// the initial CREATE TABLE statement in database_schema.go. This is appropriate
-// because:
-//   - The app has never been publicly released
-//   - No existing databases need migration
-//   - Single source of truth is cleaner and faster
+// SCHEMA BASELINE:
+// database_schema.go holds the consolidated schema as of migration 0001
+// (baseline) and creates it on every start with CREATE TABLE IF NOT EXISTS.
+// It must not change any more: every schema change after the baseline is a
+// new migration file, so fresh installs and upgrades run the same SQL.
 //
-// POST-RELEASE MIGRATION STRATEGY:
-// After the first public release with real users, add new migrations here
-// starting from version 1. The migration infrastructure is preserved and ready.
-// See CLAUDE.md "Schema Consolidation" section for full details.
+// WRITING A MIGRATION:
+// Add migrations/NNNN_short_name.sql with the next version number. The first
+// line is a "-- " comment describing the change. Migrations are append-only:
+// never edit or remove one that has shipped, since its checksum is verified
+// on every start. A migration whose statements cannot run inside a
+// transaction declares "-- migrate:no-transaction" on its own line.
 package database
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
 )
 
+// ErrSchemaTooNew is returned by New when the database has migrations
+// applied that this binary does not know, i.e. it was last opened by a
+// newer version of Cartographus.
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
 // Migration represents a versioned database migration.
 type Migration struct {
-	Version     int       // Unique version number (monotonically increasing)
-	Name        string    // Human-readable migration name
-	Description string    // Description of what this migration does
-	SQL         string    // SQL statement to execute
-	AppliedAt   time.Time // When the migration was applied (populated on query)
+	Version       int       // Unique version number (1, 2, 3, ... without gaps)
+	Name          string    // Human-readable migration name
+	Description   string    // Description of what this migration does
+	SQL           string    // SQL statements to execute
+	Checksum      string    // SHA-256 of SQL, hex encoded
+	NoTransaction bool      // Run outside a transaction (-- migrate:no-transaction)
+	AppliedAt     time.Time // When the migration was applied (populated on query)
+}
+
+// noTransactionDirective marks a migration that must not run in a transaction
+const noTransactionDirective = "-- migrate:no-transaction"
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationFileName matches NNNN_name.sql
+var migrationFileName = regexp.MustCompile(`^(\d{4})_([a-z0-9_]+)\.sql$`)
+
+// embeddedMigrations loads the migrations compiled into the binary once
+var embeddedMigrations = sync.OnceValues(func() ([]Migration, error) {
+	return loadMigrations(migrationFiles, "migrations")
+})
+
+// loadMigrations reads the migration files in dir of fsys, ordered by
+// version. Versions must start at 1 and have no gaps.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q (want NNNN_name.sql)", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		version, _ := strconv.Atoi(match[1]) // Four digits, cannot fail
+		if version != len(migrations)+1 {
+			return nil, fmt.Errorf("migration %s: expected version %d", entry.Name(), len(migrations)+1)
+		}
+		migrations = append(migrations, newMigration(version, match[2], string(content)))
+	}
+	return migrations, nil
+}
+
+// newMigration builds a migration from its SQL. The description is the
+// first line's comment.
+func newMigration(version int, name, sqlText string) Migration {
+	sum := sha256.Sum256([]byte(sqlText))
+	m := Migration{
+		Version:  version,
+		Name:     name,
+		SQL:      sqlText,
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+
+	firstLine, _, _ := strings.Cut(sqlText, "\n")
+	if desc, ok := strings.CutPrefix(strings.TrimSpace(firstLine), "--"); ok {
+		m.Description = strings.TrimSpace(desc)
+	}
+	for _, line := range strings.Split(sqlText, "\n") {
+		if strings.TrimSpace(line) == noTransactionDirective {
+			m.NoTransaction = true
+		}
+	}
+	return m
+}
+
+// hasStatements reports whether the migration has SQL besides comments
+func (m Migration) hasStatements() bool {
+	for _, line := range strings.Split(m.SQL, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+// LatestSchemaVersion returns the version of the newest migration compiled
+// into this binary
+func LatestSchemaVersion() int {
+	migrations, err := embeddedMigrations()
+	if err != nil || len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
 }
 
 // schemaMigrationsTable creates the migration tracking table
@@ -47,49 +153,35 @@ CREATE TABLE IF NOT EXISTS schema_migrations (
 	version INTEGER PRIMARY KEY,
 	name TEXT NOT NULL,
 	description TEXT,
+	checksum TEXT,
 	applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 `
 
-// getMigrations returns all versioned migrations in order.
-//
-// PRE-RELEASE: This returns an empty slice because all columns are now
-// defined in the initial CREATE TABLE statement in database_schema.go.
-//
-// POST-RELEASE: After the first public release, add new migrations here
-// starting from version 1. Example:
-//
-//	{Version: 1, Name: "add_new_column", Description: "Add new_column for feature X",
-//	 SQL: `ALTER TABLE playback_events ADD COLUMN IF NOT EXISTS new_column TEXT;`},
-//
-// Migrations MUST be append-only - never modify or remove existing migrations
-// once users have databases with data.
-func (db *DB) getMigrations() []Migration {
-	// All 187 original column migrations have been consolidated into
-	// database_schema.go as of 2026-01-04. This is appropriate because:
-	//   - The app has never been publicly released
-	//   - No existing databases need migration
-	//   - Single source of truth is cleaner and faster
-	//
-	// The migration infrastructure is preserved for post-release schema changes.
-	// When adding new migrations after release, start from version 1.
-	return []Migration{
-		// Post-release migrations will be added here.
-		// Example:
-		// {Version: 1, Name: "add_new_feature_column", Description: "Add column for new feature",
-		//  SQL: `ALTER TABLE playback_events ADD COLUMN IF NOT EXISTS new_feature_column TEXT;`},
+// getMigrations returns all versioned migrations in order: the embedded
+// migrations, or db.migrations when a test has set them.
+func (db *DB) getMigrations() ([]Migration, error) {
+	if db.migrations != nil {
+		return db.migrations, nil
 	}
+	return embeddedMigrations()
 }
 
-// createMigrationsTable creates the schema_migrations table if it doesn't exist
+// createMigrationsTable creates the schema_migrations table if it doesn't
+// exist, and adds the checksum column to tables created before it existed
 func (db *DB) createMigrationsTable(ctx context.Context) error {
-	_, err := db.conn.ExecContext(ctx, schemaMigrationsTable)
+	if _, err := db.conn.ExecContext(ctx, schemaMigrationsTable); err != nil {
+		return err
+	}
+	_, err := db.conn.ExecContext(ctx, `ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum TEXT`)
 	return err
 }
 
 // getAppliedMigrations returns a map of version -> Migration for all applied migrations
 func (db *DB) getAppliedMigrations(ctx context.Context) (map[int]Migration, error) {
-	rows, err := db.conn.QueryContext(ctx, `SELECT version, name, description, applied_at FROM schema_migrations ORDER BY version`)
+	rows, err := db.conn.QueryContext(ctx,
+		`SELECT version, name, COALESCE(description, ''), COALESCE(checksum, ''), applied_at
+		FROM schema_migrations ORDER BY version`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
 	}
@@ -98,7 +190,7 @@ func (db *DB) getAppliedMigrations(ctx context.Context) (map[int]Migration, erro
 	applied := make(map[int]Migration)
 	for rows.Next() {
 		var m Migration
-		if err := rows.Scan(&m.Version, &m.Name, &m.Description, &m.AppliedAt); err != nil {
+		if err := rows.Scan(&m.Version, &m.Name, &m.Description, &m.Checksum, &m.AppliedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan migration row: %w", err)
 		}
 		applied[m.Version] = m
@@ -106,9 +198,46 @@ func (db *DB) getAppliedMigrations(ctx context.Context) (map[int]Migration, erro
 	return applied, rows.Err()
 }
 
-// runVersionedMigrations executes only new migrations that haven't been applied yet.
-// This replaces the old runMigrations() function with a proper versioned approach.
-func (db *DB) runVersionedMigrations() error {
+// checkSchemaVersion runs before the schema is touched. It refuses databases
+// migrated by a newer binary and reports whether the database already
+// existed, i.e. had a playback_events table.
+func (db *DB) checkSchemaVersion() (existing bool, err error) {
+	ctx, cancel := schemaContext()
+	defer cancel()
+
+	err = db.conn.QueryRowContext(ctx, `
+		SELECT COUNT(*) > 0 FROM information_schema.tables
+		WHERE table_name = 'playback_events'`).Scan(&existing)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect schema: %w", err)
+	}
+
+	if err := db.createMigrationsTable(ctx); err != nil {
+		return false, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	var current int
+	if err := db.conn.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return false, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	migrations, err := db.getMigrations()
+	if err != nil {
+		return false, err
+	}
+	if latest := len(migrations); current > latest {
+		return existing, fmt.Errorf("%w: the database is at schema version %d but this binary only knows versions up to %d; "+
+			"upgrade Cartographus, or restore a backup taken before the database was upgraded",
+			ErrSchemaTooNew, current, latest)
+	}
+	return existing, nil
+}
+
+// runVersionedMigrations verifies the checksums of applied migrations and
+// applies pending ones in order. existing reports whether the database
+// predates this start (see checkSchemaVersion); a database that existed
+// without recorded migrations is baselined.
+func (db *DB) runVersionedMigrations(existing bool) error {
 	ctx, cancel := schemaContext()
 	defer cancel()
 
@@ -122,42 +251,89 @@ func (db *DB) runVersionedMigrations() error {
 	if err != nil {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
+	migrations, err := db.getMigrations()
+	if err != nil {
+		return err
+	}
 
-	// Get all migrations
-	migrations := db.getMigrations()
+	if existing && len(applied) == 0 {
+		logging.Info().Msg("Existing database without migration history, baselining it")
+	}
 
 	// Apply new migrations in order
 	newMigrations := 0
 	for _, m := range migrations {
-		if _, exists := applied[m.Version]; exists {
-			continue // Already applied
+		if prev, exists := applied[m.Version]; exists {
+			if err := db.verifyMigration(ctx, prev, m); err != nil {
+				return err
+			}
+			continue
 		}
 
-		// Execute migration
-		if _, err := db.conn.ExecContext(ctx, m.SQL); err != nil {
-			return fmt.Errorf("failed to execute migration v%d (%s): %w", m.Version, m.Name, err)
+		if err := db.applyMigration(ctx, m); err != nil {
+			return err
 		}
+		newMigrations++
+	}
 
-		// Record migration as applied
+	if newMigrations > 0 {
+		logging.Info().Int("applied", newMigrations).Int("schema_version", len(migrations)).
+			Msg("Applied database migrations")
+	}
+	return nil
+}
+
+// verifyMigration checks that an applied migration has not been changed
+// since. Migrations recorded before checksums existed get theirs now.
+func (db *DB) verifyMigration(ctx context.Context, applied, m Migration) error {
+	if applied.Checksum == "" {
 		_, err := db.conn.ExecContext(ctx,
-			`INSERT INTO schema_migrations (version, name, description) VALUES (?, ?, ?)`,
-			m.Version, m.Name, m.Description)
+			`UPDATE schema_migrations SET checksum = ? WHERE version = ?`, m.Checksum, m.Version)
 		if err != nil {
-			return fmt.Errorf("failed to record migration v%d: %w", m.Version, err)
+			return fmt.Errorf("failed to record checksum of migration v%d: %w", m.Version, err)
 		}
-
-		newMigrations++
+		return nil
+	}
+	if applied.Checksum != m.Checksum {
+		return fmt.Errorf("migration v%d (%s) was changed after it was applied: checksum %s, expected %s",
+			m.Version, m.Name, m.Checksum, applied.Checksum)
 	}
+	return nil
+}
 
-	if newMigrations > 0 {
-		// Log migration count using the logging package
-		// Note: This is called during initialization, so logging should be available
-		// Suppress output during benchmarks to avoid polluting benchmark output
-		if os.Getenv("BENCHMARK_MODE") != "1" {
-			fmt.Printf("Applied %d new database migrations\n", newMigrations)
+// applyMigration runs a migration and records it, in one transaction unless
+// the migration opts out
+func (db *DB) applyMigration(ctx context.Context, m Migration) error {
+	record := func(exec func(ctx context.Context, query string, args ...interface{}) (sql.Result, error)) error {
+		if m.hasStatements() {
+			if _, err := exec(ctx, m.SQL); err != nil {
+				return fmt.Errorf("failed to execute migration v%d (%s): %w", m.Version, m.Name, err)
+			}
 		}
+		_, err := exec(ctx,
+			`INSERT INTO schema_migrations (version, name, description, checksum) VALUES (?, ?, ?, ?)`,
+			m.Version, m.Name, m.Description, m.Checksum)
+		if err != nil {
+			return fmt.Errorf("failed to record migration v%d: %w", m.Version, err)
+		}
+		return nil
+	}
+
+	if m.NoTransaction {
+		return record(db.conn.ExecContext)
 	}
 
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration v%d: %w", m.Version, err)
+	}
+	if err := record(tx.ExecContext); err != nil {
+		_ = tx.Rollback() // Best-effort; the migration error is what matters
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration v%d: %w", m.Version, err)
+	}
 	return nil
 }
 
@@ -180,7 +356,8 @@ func (db *DB) GetMigrationHistory() ([]Migration, error) {
 	defer cancel()
 
 	rows, err := db.conn.QueryContext(ctx,
-		`SELECT version, name, description, applied_at FROM schema_migrations ORDER BY version`)
+		`SELECT version, name, COALESCE(description, ''), COALESCE(checksum, ''), applied_at
+		FROM schema_migrations ORDER BY version`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query migration history: %w", err)
 	}
@@ -189,7 +366,7 @@ func (db *DB) GetMigrationHistory() ([]Migration, error) {
 	var history []Migration
 	for rows.Next() {
 		var m Migration
-		if err := rows.Scan(&m.Version, &m.Name, &m.Description, &m.AppliedAt); err != nil {
+		if err := rows.Scan(&m.Version, &m.Name, &m.Description, &m.Checksum, &m.AppliedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan migration: %w", err)
 		}
 		history = append(history, m)
@@ -0,0 +1,5 @@
+-- Baseline: the consolidated schema created by database_schema.go
+--
+-- Every database starts from the schema in database_schema.go, so this
+-- migration has no statements. Recording it marks databases created before
+-- migrations were tracked as being at the baseline.
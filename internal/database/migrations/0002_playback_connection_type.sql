@@ -0,0 +1,4 @@
+-- Connection type of the client address at ingest: residential, vpn,
+-- datacenter or unknown. Events stored before this column existed are NULL
+-- and are reported as unknown.
+ALTER TABLE playback_events ADD COLUMN IF NOT EXISTS connection_type TEXT;
@@ -0,0 +1,15 @@
+-- Recommendations shown to users in an A/B experiment variant, for outcome
+-- reports. One row per experiment, user and item keeps the first time the
+-- item was shown; clicks are recorded on the row and watches are joined from
+-- playback_events.
+CREATE TABLE IF NOT EXISTS recommendation_exposures (
+	experiment TEXT NOT NULL,
+	variant TEXT NOT NULL,
+	user_id INTEGER NOT NULL,
+	item_id INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	model_version INTEGER NOT NULL,
+	shown_at TIMESTAMPTZ NOT NULL,
+	clicked_at TIMESTAMPTZ,
+	PRIMARY KEY (experiment, user_id, item_id)
+);
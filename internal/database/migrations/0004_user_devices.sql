@@ -0,0 +1,43 @@
+-- Per-user device inventory keyed by the client's machine ID, kept up to
+-- date by playback event inserts. Backfilled from existing playback events so
+-- devices streamed from before the upgrade are not reported as new.
+CREATE TABLE IF NOT EXISTS user_devices (
+	user_id INTEGER NOT NULL,
+	device_id TEXT NOT NULL,
+	name TEXT,
+	flagged BOOLEAN NOT NULL DEFAULT false,
+	player TEXT,
+	product TEXT,
+	platform TEXT,
+	device TEXT,
+	first_seen_at TIMESTAMP NOT NULL,
+	first_session_key TEXT,
+	first_ip_address TEXT,
+	last_seen_at TIMESTAMP NOT NULL,
+	last_ip_address TEXT,
+	session_count INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (user_id, device_id)
+);
+
+INSERT INTO user_devices (
+	user_id, device_id, player, product, platform, device,
+	first_seen_at, first_session_key, first_ip_address,
+	last_seen_at, last_ip_address, session_count
+)
+SELECT
+	user_id,
+	machine_id,
+	arg_max(player, started_at),
+	arg_max(product, started_at),
+	arg_max(platform, started_at),
+	arg_max(device, started_at),
+	MIN(started_at),
+	arg_min(session_key, started_at),
+	arg_min(ip_address, started_at),
+	MAX(started_at),
+	arg_max(ip_address, started_at),
+	COUNT(*)
+FROM playback_events
+WHERE machine_id IS NOT NULL AND machine_id <> ''
+GROUP BY user_id, machine_id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- Logical viewing session of each playback: the id of the first playback of
+-- a run of resumed plays of the same item by the same user, set by session
+-- stitching after each sync. NULL until the event has been stitched.
+ALTER TABLE playback_events ADD COLUMN IF NOT EXISTS logical_session_id TEXT;
@@ -0,0 +1,37 @@
+-- Watch parties detected after each sync: playbacks of the same item by
+-- different users that started close together and were watched alongside
+-- each other. A party's id is the id of its first playback, so re-detecting
+-- it keeps the false positive mark an admin gave it.
+CREATE TABLE IF NOT EXISTS watch_parties (
+	id TEXT PRIMARY KEY,
+	media_type TEXT NOT NULL,
+	title TEXT NOT NULL,
+	parent_title TEXT,
+	grandparent_title TEXT,
+	started_at TIMESTAMP NOT NULL,
+	ended_at TIMESTAMP NOT NULL,
+	participant_count INTEGER NOT NULL,
+	confidence DOUBLE NOT NULL,
+	same_location BOOLEAN NOT NULL DEFAULT false,
+	avg_completion DOUBLE NOT NULL DEFAULT 0,
+	total_duration INTEGER NOT NULL DEFAULT 0,
+	false_positive BOOLEAN NOT NULL DEFAULT false,
+	detected_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_watch_parties_started_at ON watch_parties(started_at);
+
+CREATE TABLE IF NOT EXISTS watch_party_participants (
+	party_id TEXT NOT NULL,
+	playback_id TEXT NOT NULL,
+	user_id INTEGER NOT NULL,
+	username TEXT NOT NULL,
+	ip_address TEXT,
+	started_at TIMESTAMP NOT NULL,
+	stopped_at TIMESTAMP,
+	percent_complete INTEGER,
+	play_duration INTEGER,
+	PRIMARY KEY (party_id, playback_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_watch_party_participants_playback ON watch_party_participants(playback_id);
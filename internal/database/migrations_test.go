@@ -0,0 +1,249 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+// openMigrationTestDB opens (or reopens) the file database at path
+func openMigrationTestDB(t *testing.T, path string) (*DB, error) {
+	t.Helper()
+	testDBMutex.Lock()
+	defer testDBMutex.Unlock()
+	return New(&config.DatabaseConfig{Path: path, MaxMemory: "512MB", SkipIndexes: true}, 0, 0)
+}
+
+func mustOpenMigrationTestDB(t *testing.T, path string) *DB {
+	t.Helper()
+	db, err := openMigrationTestDB(t, path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return db
+}
+
+func columnExists(t *testing.T, db *DB, table, column string) bool {
+	t.Helper()
+	var n int
+	err := db.conn.QueryRow(`SELECT COUNT(*) FROM information_schema.columns
+		WHERE table_name = ? AND column_name = ?`, table, column).Scan(&n)
+	if err != nil {
+		t.Fatalf("failed to inspect columns: %v", err)
+	}
+	return n > 0
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		t.Fatalf("embeddedMigrations() error = %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Name != "baseline" {
+		t.Fatalf("migrations = %+v, want 0001_baseline first", migrations)
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 || m.Checksum == "" || m.Description == "" {
+			t.Errorf("migration %d = %+v, want version %d with checksum and description", i, m, i+1)
+		}
+	}
+	if got := LatestSchemaVersion(); got != len(migrations) {
+		t.Errorf("LatestSchemaVersion() = %d, want %d", got, len(migrations))
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		wantErr string
+	}{
+		{
+			name: "valid",
+			files: fstest.MapFS{
+				"m/0001_baseline.sql":   {Data: []byte("-- Baseline\n")},
+				"m/0002_add_column.sql": {Data: []byte("-- Add a column\n" + noTransactionDirective + "\nALTER TABLE t ADD COLUMN c INT;\n")},
+			},
+		},
+		{
+			name:    "gap",
+			files:   fstest.MapFS{"m/0001_a.sql": {Data: []byte("-- a\n")}, "m/0003_c.sql": {Data: []byte("-- c\n")}},
+			wantErr: "expected version 2",
+		},
+		{
+			name:    "bad name",
+			files:   fstest.MapFS{"m/1_baseline.sql": {Data: []byte("-- a\n")}},
+			wantErr: "invalid migration file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := loadMigrations(tt.files, "m")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadMigrations() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadMigrations() error = %v", err)
+			}
+			if len(migrations) != 2 {
+				t.Fatalf("got %d migrations, want 2", len(migrations))
+			}
+			if m := migrations[0]; m.hasStatements() || m.NoTransaction || m.Description != "Baseline" {
+				t.Errorf("baseline = %+v, want a description and no statements", m)
+			}
+			if m := migrations[1]; !m.hasStatements() || !m.NoTransaction || m.Name != "add_column" {
+				t.Errorf("add_column = %+v, want statements outside a transaction", m)
+			}
+		})
+	}
+}
+
+func TestMigrations_FreshInstall(t *testing.T) {
+	db := mustOpenMigrationTestDB(t, filepath.Join(t.TempDir(), "fresh.duckdb"))
+	defer db.Close()
+
+	history, err := db.GetMigrationHistory()
+	if err != nil {
+		t.Fatalf("GetMigrationHistory() error = %v", err)
+	}
+	if len(history) != LatestSchemaVersion() {
+		t.Fatalf("got %d applied migrations, want %d", len(history), LatestSchemaVersion())
+	}
+	if history[0].Name != "baseline" || history[0].Checksum == "" {
+		t.Errorf("first migration = %+v, want baseline with checksum", history[0])
+	}
+	if version, err := db.GetCurrentSchemaVersion(); err != nil || version != LatestSchemaVersion() {
+		t.Errorf("GetCurrentSchemaVersion() = %d, %v; want %d", version, err, LatestSchemaVersion())
+	}
+}
+
+func TestMigrations_BaselinesUntrackedDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.duckdb")
+
+	// A database from before migrations were tracked: no rows and no
+	// checksum column in schema_migrations
+	db := mustOpenMigrationTestDB(t, path)
+	for _, stmt := range []string{
+		`DROP TABLE schema_migrations`,
+		`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, name TEXT NOT NULL,
+			description TEXT, applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP)`,
+	} {
+		if _, err := db.conn.Exec(stmt); err != nil {
+			t.Fatalf("failed to simulate legacy database: %v", err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	db = mustOpenMigrationTestDB(t, path)
+	defer db.Close()
+
+	history, err := db.GetMigrationHistory()
+	if err != nil {
+		t.Fatalf("GetMigrationHistory() error = %v", err)
+	}
+	if len(history) != LatestSchemaVersion() || history[0].Name != "baseline" || history[0].Checksum == "" {
+		t.Errorf("history = %+v, want the database baselined with checksums", history)
+	}
+}
+
+func TestMigrations_Upgrade(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	baseline, err := embeddedMigrations()
+	if err != nil {
+		t.Fatalf("embeddedMigrations() error = %v", err)
+	}
+	next := len(baseline) + 1
+	db.migrations = append(append([]Migration{}, baseline...),
+		newMigration(next, "add_upgrade_test_column", "-- Add a test column\n"+
+			"ALTER TABLE playback_events ADD COLUMN upgrade_test_a TEXT;\n"+
+			"ALTER TABLE playback_events ADD COLUMN upgrade_test_b TEXT;\n"))
+
+	if err := db.runVersionedMigrations(true); err != nil {
+		t.Fatalf("runVersionedMigrations() error = %v", err)
+	}
+	if !columnExists(t, db, "playback_events", "upgrade_test_a") || !columnExists(t, db, "playback_events", "upgrade_test_b") {
+		t.Error("migration columns were not added")
+	}
+	if version, _ := db.GetCurrentSchemaVersion(); version != next {
+		t.Errorf("schema version = %d, want %d", version, next)
+	}
+
+	// Applied migrations are not run again
+	if err := db.runVersionedMigrations(true); err != nil {
+		t.Fatalf("second runVersionedMigrations() error = %v", err)
+	}
+
+	// Editing an applied migration is detected
+	db.migrations[next-1] = newMigration(next, "add_upgrade_test_column", "-- Edited\nSELECT 1;\n")
+	err = db.runVersionedMigrations(true)
+	if err == nil || !strings.Contains(err.Error(), "changed after it was applied") {
+		t.Errorf("runVersionedMigrations() with edited migration error = %v, want checksum mismatch", err)
+	}
+}
+
+func TestMigrations_FailedMigrationRollsBack(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	baseline, err := embeddedMigrations()
+	if err != nil {
+		t.Fatalf("embeddedMigrations() error = %v", err)
+	}
+	next := len(baseline) + 1
+	db.migrations = append(append([]Migration{}, baseline...),
+		newMigration(next, "broken", "-- Half-broken migration\n"+
+			"ALTER TABLE playback_events ADD COLUMN rollback_test TEXT;\n"+
+			"ALTER TABLE no_such_table ADD COLUMN x TEXT;\n"))
+
+	if err := db.runVersionedMigrations(true); err == nil {
+		t.Fatal("runVersionedMigrations() error = nil, want the migration to fail")
+	}
+	if columnExists(t, db, "playback_events", "rollback_test") {
+		t.Error("column of the failed migration was not rolled back")
+	}
+	if version, _ := db.GetCurrentSchemaVersion(); version != len(baseline) {
+		t.Errorf("schema version = %d, want %d", version, len(baseline))
+	}
+}
+
+func TestMigrations_RefusesNewerDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "newer.duckdb")
+
+	db := mustOpenMigrationTestDB(t, path)
+	tooNew := LatestSchemaVersion() + 1
+	if _, err := db.conn.ExecContext(context.Background(),
+		`INSERT INTO schema_migrations (version, name, description, checksum) VALUES (?, 'from_the_future', '', '')`,
+		tooNew); err != nil {
+		t.Fatalf("failed to record future migration: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	db, err := openMigrationTestDB(t, path)
+	if err == nil {
+		db.Close()
+		t.Fatal("New() error = nil, want ErrSchemaTooNew")
+	}
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("New() error = %v, want ErrSchemaTooNew", err)
+	}
+}
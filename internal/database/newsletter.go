@@ -30,6 +30,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // ============================================================================
@@ -893,6 +894,7 @@ func (db *DB) UpdateNewsletterDelivery(ctx context.Context, delivery *models.New
 	query := `
 		UPDATE newsletter_deliveries SET
 			status = ?,
+			recipients_total = ?,
 			recipients_delivered = ?,
 			recipients_failed = ?,
 			recipient_details = ?,
@@ -905,6 +907,7 @@ func (db *DB) UpdateNewsletterDelivery(ctx context.Context, delivery *models.New
 
 	_, err = db.conn.ExecContext(ctx, query,
 		string(delivery.Status),
+		delivery.RecipientsTotal,
 		delivery.RecipientsDelivered,
 		delivery.RecipientsFailed,
 		nullableJSON(recipientDetailsJSON),
@@ -1130,6 +1133,144 @@ func (db *DB) ListNewsletterDeliveries(ctx context.Context, scheduleID string, s
 	return deliveries, totalCount, nil
 }
 
+// CreateNewsletterDeliveryLogEntries records per-recipient delivery results.
+// Entries without an ID are assigned one.
+func (db *DB) CreateNewsletterDeliveryLogEntries(ctx context.Context, entries []models.NewsletterDeliveryLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO newsletter_delivery_log (
+			id, delivery_id, schedule_id, channel, recipient, recipient_type,
+			status, attempts, error_code, error_message, delivered_at, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare delivery log insert: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for i := range entries {
+		e := &entries[i]
+		if e.ID == "" {
+			e.ID = uuid.New().String()
+		}
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = now
+		}
+		if _, err := stmt.ExecContext(ctx,
+			e.ID,
+			e.DeliveryID,
+			nullableString(e.ScheduleID),
+			string(e.Channel),
+			e.Recipient,
+			e.RecipientType,
+			string(e.Status),
+			e.Attempts,
+			nullableString(e.ErrorCode),
+			nullableString(e.ErrorMessage),
+			e.DeliveredAt,
+			e.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to insert delivery log entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit delivery log: %w", err)
+	}
+	return nil
+}
+
+// ListNewsletterDeliveryLog queries the per-recipient delivery log, newest
+// first. It returns the matching page and the total number of matches.
+func (db *DB) ListNewsletterDeliveryLog(ctx context.Context, filter models.DeliveryLogFilter) ([]models.NewsletterDeliveryLogEntry, int, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	baseQuery := " FROM newsletter_delivery_log WHERE 1=1"
+	args := make([]interface{}, 0)
+
+	for _, f := range []struct{ column, value string }{
+		{"delivery_id", filter.DeliveryID},
+		{"schedule_id", filter.ScheduleID},
+		{"recipient", filter.Recipient},
+		{"channel", filter.Channel},
+		{"status", filter.Status},
+	} {
+		if f.value != "" {
+			baseQuery += " AND " + f.column + " = ?"
+			args = append(args, f.value)
+		}
+	}
+
+	var totalCount int
+	if err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*)"+baseQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count delivery log entries: %w", err)
+	}
+
+	selectQuery := `
+		SELECT id, delivery_id, schedule_id, channel, recipient, recipient_type,
+			status, attempts, error_code, error_message, delivered_at, created_at
+	` + baseQuery + " ORDER BY created_at DESC LIMIT ? OFFSET ?"
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := db.conn.QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query delivery log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.NewsletterDeliveryLogEntry{}
+	for rows.Next() {
+		var e models.NewsletterDeliveryLogEntry
+		var scheduleID, errorCode, errorMessage sql.NullString
+		var deliveredAt sql.NullTime
+
+		if err := rows.Scan(
+			&e.ID,
+			&e.DeliveryID,
+			&scheduleID,
+			&e.Channel,
+			&e.Recipient,
+			&e.RecipientType,
+			&e.Status,
+			&e.Attempts,
+			&errorCode,
+			&errorMessage,
+			&deliveredAt,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan delivery log entry: %w", err)
+		}
+
+		e.ScheduleID = scheduleID.String
+		e.ErrorCode = errorCode.String
+		e.ErrorMessage = errorMessage.String
+		if deliveredAt.Valid {
+			e.DeliveredAt = &deliveredAt.Time
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, totalCount, nil
+}
+
 // ============================================================================
 // Newsletter User Preferences
 // ============================================================================
@@ -1292,7 +1433,7 @@ func (db *DB) CreateNewsletterAuditEntry(ctx context.Context, entry *models.News
 		entry.ResourceID,
 		entry.ResourceName,
 		nullableJSON(detailsJSON),
-		entry.IPAddress,
+		privacy.AnonymizeIP(entry.IPAddress),
 		entry.UserAgent,
 	)
 	if err != nil {
@@ -14,6 +14,7 @@
 //   - Period statistics (playbacks, watch time, users)
 //   - User-specific statistics and recommendations
 //   - Server health information
+//   - Playback locations for the newsletter map block
 package database
 
 import (
@@ -693,6 +694,16 @@ func (db *DB) GetServerHealth(ctx context.Context) (*models.NewsletterHealthData
 	return health, nil
 }
 
+// GetNewsletterLocations returns geolocated playback counts for the period,
+// busiest locations first. Used by the newsletter playback map block.
+func (db *DB) GetNewsletterLocations(ctx context.Context, start, end time.Time, limit int) ([]models.LocationStats, error) {
+	return db.GetLocationStatsFiltered(ctx, LocationStatsFilter{
+		StartDate: &start,
+		EndDate:   &end,
+		Limit:     limit,
+	})
+}
+
 // scanNewsletterMediaItems scans rows into NewsletterMediaItem slice.
 func scanNewsletterMediaItems(rows *sql.Rows) ([]models.NewsletterMediaItem, error) {
 	var items []models.NewsletterMediaItem
@@ -31,6 +31,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // CreatePAT creates a new Personal Access Token in the database.
@@ -61,7 +62,7 @@ func (db *DB) CreatePAT(ctx context.Context, token *models.PersonalAccessToken)
 		token.ID, token.UserID, token.Username, token.Name, token.Description,
 		token.TokenPrefix, token.TokenHash, string(scopesJSON),
 		token.ExpiresAt, ipAllowlistSQL, token.CreatedAt,
-		token.LastUsedAt, token.LastUsedIP, token.UseCount,
+		token.LastUsedAt, privacy.AnonymizeIP(token.LastUsedIP), token.UseCount,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert PAT: %w", err)
@@ -204,7 +205,7 @@ func (db *DB) UpdatePAT(ctx context.Context, token *models.PersonalAccessToken)
 	result, err := db.conn.ExecContext(ctx, query,
 		token.Name, token.Description,
 		token.TokenPrefix, token.TokenHash, string(scopesJSON),
-		token.ExpiresAt, token.LastUsedAt, token.LastUsedIP,
+		token.ExpiresAt, token.LastUsedAt, privacy.AnonymizeIP(token.LastUsedIP),
 		token.UseCount, ipAllowlistSQL, token.ID,
 	)
 	if err != nil {
@@ -269,7 +270,7 @@ func (db *DB) LogPATUsage(ctx context.Context, log *models.PATUsageLog) error {
 
 	_, err := db.conn.ExecContext(ctx, query,
 		log.ID, log.Timestamp, log.TokenID, log.UserID, log.Action,
-		log.Endpoint, log.Method, log.IPAddress, log.UserAgent,
+		log.Endpoint, log.Method, privacy.AnonymizeIP(log.IPAddress), log.UserAgent,
 		log.Success, log.ErrorCode, log.ResponseTimeMS,
 	)
 	if err != nil {
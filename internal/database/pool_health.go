@@ -0,0 +1,116 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// poolHealthCheckTimeout bounds one probe of the connection pool.
+const poolHealthCheckTimeout = 5 * time.Second
+
+// PoolHealthChecker periodically probes the connection pool with a trivial
+// query and exports its connection stats. It implements suture.Service.
+//
+// database/sql already discards a connection when the driver reports it
+// bad, but only once a query runs on it. When the probe fails, the checker
+// closes every idle connection so the next queries open fresh ones, then
+// probes again. A probe that times out while every connection is in use is
+// reported as busy rather than broken.
+type PoolHealthChecker struct {
+	db       *DB
+	interval time.Duration
+	timeout  time.Duration // per probe
+}
+
+// NewPoolHealthChecker creates a checker that runs every interval.
+func NewPoolHealthChecker(db *DB, interval time.Duration) *PoolHealthChecker {
+	return &PoolHealthChecker{db: db, interval: interval, timeout: poolHealthCheckTimeout}
+}
+
+// Serve checks every interval until ctx is canceled.
+func (c *PoolHealthChecker) Serve(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			result, err := c.Check(ctx)
+			metrics.RecordDBHealthCheck(result)
+			switch {
+			case err != nil:
+				logging.Error().Err(err).Msg("Database connection pool health check failed")
+			case result == "recycled":
+				logging.Warn().Msg("Database health check recycled idle connections after a failed probe")
+			case result == "busy":
+				logging.Warn().Int("max_open", c.db.conn.Stats().MaxOpenConnections).
+					Msg("Database health check timed out waiting for a connection; the pool is saturated")
+			}
+		}
+	}
+}
+
+// String implements fmt.Stringer for supervisor logging.
+func (c *PoolHealthChecker) String() string {
+	return "database-pool-health"
+}
+
+// Check probes the pool once and returns the result: "healthy", "busy",
+// "recycled" (the probe passed after recycling idle connections) or
+// "failed", with the probe error.
+func (c *PoolHealthChecker) Check(ctx context.Context) (string, error) {
+	defer func() { metrics.UpdateDBConnectionPool(c.db.conn.Stats()) }()
+
+	err := c.probe(ctx)
+	if err == nil {
+		return "healthy", nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) && c.saturated() {
+		return "busy", nil
+	}
+	if ctx.Err() != nil {
+		return "failed", err
+	}
+
+	c.db.recycleIdleConnections()
+	if err := c.probe(ctx); err != nil {
+		return "failed", fmt.Errorf("probe after recycling idle connections: %w", err)
+	}
+	return "recycled", nil
+}
+
+func (c *PoolHealthChecker) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var one int
+	if err := c.db.conn.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return fmt.Errorf("probe query: %w", err)
+	}
+	return nil
+}
+
+// saturated reports whether every pool connection is in use
+func (c *PoolHealthChecker) saturated() bool {
+	stats := c.db.conn.Stats()
+	return stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections
+}
+
+// recycleIdleConnections closes all idle pool connections; connections in
+// use are unaffected and return to the pool as usual.
+func (db *DB) recycleIdleConnections() {
+	db.conn.SetMaxIdleConns(0)
+	db.conn.SetMaxIdleConns(db.maxIdleConns())
+}
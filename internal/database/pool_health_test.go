@@ -0,0 +1,130 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// openPoolTestDB opens an in-memory DuckDB with the pool configured from cfg
+func openPoolTestDB(t *testing.T, cfg *config.DatabaseConfig) *DB {
+	t.Helper()
+
+	conn, err := sql.Open("duckdb", "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	db := &DB{conn: conn, cfg: cfg}
+	if err := db.configureConnectionPool(); err != nil {
+		t.Fatalf("configureConnectionPool() error = %v", err)
+	}
+	return db
+}
+
+func TestConfigureConnectionPool(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.DatabaseConfig
+		wantMaxOpen   int
+		wantAnalytics int
+	}{
+		{name: "defaults", wantMaxOpen: runtime.NumCPU(), wantAnalytics: max(runtime.NumCPU()-1, 1)},
+		{name: "explicit pool", cfg: config.DatabaseConfig{MaxOpenConns: 6}, wantMaxOpen: 6, wantAnalytics: 5},
+		{name: "single connection", cfg: config.DatabaseConfig{MaxOpenConns: 1}, wantMaxOpen: 1, wantAnalytics: 1},
+		{name: "explicit analytics limit", cfg: config.DatabaseConfig{MaxOpenConns: 6, MaxConcurrentAnalytics: 2}, wantMaxOpen: 6, wantAnalytics: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openPoolTestDB(t, &tt.cfg)
+
+			if got := db.conn.Stats().MaxOpenConnections; got != tt.wantMaxOpen {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.wantMaxOpen)
+			}
+			if got := cap(db.analytics.slots); got != tt.wantAnalytics {
+				t.Errorf("analytics slots = %d, want %d", got, tt.wantAnalytics)
+			}
+		})
+	}
+}
+
+func TestPoolHealthChecker_Healthy(t *testing.T) {
+	db := openPoolTestDB(t, &config.DatabaseConfig{MaxOpenConns: 2})
+
+	result, err := NewPoolHealthChecker(db, time.Minute).Check(context.Background())
+	if err != nil || result != "healthy" {
+		t.Fatalf("Check() = %q, %v, want healthy", result, err)
+	}
+	if got := testutil.ToFloat64(metrics.DBConnectionPool.WithLabelValues("max_open")); got != 2 {
+		t.Errorf("max_open gauge = %v, want 2", got)
+	}
+}
+
+func TestPoolHealthChecker_BusyPool(t *testing.T) {
+	db := openPoolTestDB(t, &config.DatabaseConfig{MaxOpenConns: 1})
+
+	// Hold the only connection, as a long analytics query would
+	held, err := db.conn.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Conn() error = %v", err)
+	}
+	defer held.Close()
+
+	checker := NewPoolHealthChecker(db, time.Minute)
+	checker.timeout = 50 * time.Millisecond
+
+	result, err := checker.Check(context.Background())
+	if err != nil || result != "busy" {
+		t.Fatalf("Check() = %q, %v, want busy for a saturated pool", result, err)
+	}
+	if got := testutil.ToFloat64(metrics.DBConnectionWaits); got < 1 {
+		t.Errorf("wait count gauge = %v, want the probe's wait recorded", got)
+	}
+}
+
+func TestPoolHealthChecker_ClosedDatabaseFails(t *testing.T) {
+	db := openPoolTestDB(t, &config.DatabaseConfig{})
+	db.conn.Close()
+
+	result, err := NewPoolHealthChecker(db, time.Minute).Check(context.Background())
+	if err == nil || result != "failed" {
+		t.Fatalf("Check() = %q, %v, want failed for a closed database", result, err)
+	}
+}
+
+func TestRecycleIdleConnections(t *testing.T) {
+	db := openPoolTestDB(t, &config.DatabaseConfig{MaxOpenConns: 2, MaxIdleConns: 2})
+
+	if err := db.conn.Ping(); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if idle := db.conn.Stats().Idle; idle != 1 {
+		t.Fatalf("idle connections = %d, want 1 before recycling", idle)
+	}
+
+	db.recycleIdleConnections()
+
+	if stats := db.conn.Stats(); stats.Idle != 0 || stats.MaxIdleClosed < 1 {
+		t.Errorf("after recycling: idle = %d, closed = %d, want 0 idle and the connection closed", stats.Idle, stats.MaxIdleClosed)
+	}
+	if err := db.conn.Ping(); err != nil {
+		t.Errorf("Ping() after recycling error = %v", err)
+	}
+	if idle := db.conn.Stats().Idle; idle != 1 {
+		t.Errorf("idle connections = %d, want the idle limit restored", idle)
+	}
+}
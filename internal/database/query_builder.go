@@ -28,7 +28,7 @@ func buildInClause(items []string) (string, []interface{}) {
 	return strings.Join(placeholders, ","), args
 }
 
-// buildFilterConditions extracts common filter logic used across multiple queries.
+// buildPrefixedFilterConditions extracts common filter logic used across multiple queries.
 // Builds WHERE clause conditions for LocationStatsFilter including:
 // - Date range filtering (StartDate, EndDate)
 // - User filtering (Users IN clause)
@@ -36,7 +36,7 @@ func buildInClause(items []string) (string, []interface{}) {
 //
 // Returns SQL conditions (without WHERE keyword) and corresponding arguments.
 // The base query should already have "WHERE 1=1" to which these conditions are appended.
-func (f *LocationStatsFilter) buildFilterConditions() (string, []interface{}) {
+func buildPrefixedFilterConditions(f *LocationStatsFilter) (string, []interface{}) {
 	var conditions []string
 	var args []interface{}
 
@@ -152,7 +152,7 @@ func TestLocationStatsFilterBuildFilterConditions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			conditions, args := tt.filter.buildFilterConditions()
+			conditions, args := buildPrefixedFilterConditions(&tt.filter)
 
 			if tt.expectConditions {
 				if conditions == "" {
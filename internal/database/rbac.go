@@ -40,6 +40,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // rbacMutex protects concurrent role operations
@@ -566,7 +567,7 @@ func (db *DB) auditRoleChangeLocked(ctx context.Context, entry *models.RoleAudit
 	_, err := db.conn.ExecContext(ctx, query,
 		entry.ID, entry.Timestamp, entry.ActorID, entry.ActorUsername, entry.Action,
 		entry.TargetUserID, entry.TargetUsername, entry.OldRole, entry.NewRole,
-		entry.Reason, entry.IPAddress, entry.UserAgent, entry.SessionID,
+		entry.Reason, privacy.AnonymizeIP(entry.IPAddress), entry.UserAgent, entry.SessionID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert audit entry: %w", err)
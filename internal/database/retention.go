@@ -0,0 +1,497 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/clock"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// retentionCheckInterval is how often the pruner checks whether the quiet
+// window is open.
+const retentionCheckInterval = 10 * time.Minute
+
+// retentionBatchTimeout bounds one batch: archive, delete and commit.
+const retentionBatchTimeout = 2 * time.Minute
+
+// orphanedGeolocationGrace keeps geolocations that were cached shortly
+// before their playback event is inserted from being pruned as orphans.
+const orphanedGeolocationGrace = time.Hour
+
+// retentionTimeColumns maps each table a retention policy may name to the
+// column that orders its rows by age.
+var retentionTimeColumns = map[string]string{
+	"playback_events":         "started_at",
+	"detection_alerts":        "created_at",
+	"newsletter_delivery_log": "created_at",
+}
+
+// RetentionPolicy limits how much history one table keeps: rows older than
+// MaxAge and the oldest rows beyond MaxRows are pruned. Zero leaves a limit off.
+type RetentionPolicy struct {
+	Table   string
+	MaxAge  time.Duration
+	MaxRows int64
+}
+
+// RetentionPolicies returns the policies for the tables that have a limit in cfg.
+func RetentionPolicies(cfg config.RetentionConfig) []RetentionPolicy {
+	days := func(n int) time.Duration { return time.Duration(n) * 24 * time.Hour }
+
+	var policies []RetentionPolicy
+	for _, p := range []RetentionPolicy{
+		{Table: "playback_events", MaxAge: days(cfg.PlaybackMaxAgeDays), MaxRows: cfg.PlaybackMaxRows},
+		{Table: "detection_alerts", MaxAge: days(cfg.DetectionAlertsMaxAgeDays), MaxRows: cfg.DetectionAlertsMaxRows},
+		{Table: "newsletter_delivery_log", MaxAge: days(cfg.NewsletterLogMaxAgeDays), MaxRows: cfg.NewsletterLogMaxRows},
+	} {
+		if p.MaxAge > 0 || p.MaxRows > 0 {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+// TableRetentionPlan reports what a retention policy would prune from one table.
+type TableRetentionPlan struct {
+	Table      string `json:"table"`
+	MaxAgeDays int    `json:"max_age_days,omitempty"`
+	MaxRows    int64  `json:"max_rows,omitempty"`
+
+	TotalRows int64      `json:"total_rows"`
+	PruneRows int64      `json:"prune_rows"`
+	AgeCutoff *time.Time `json:"age_cutoff,omitempty"`
+
+	// OldestPruned and NewestPruned bound the timestamps of the pruned rows
+	OldestPruned *time.Time `json:"oldest_pruned,omitempty"`
+	NewestPruned *time.Time `json:"newest_pruned,omitempty"`
+
+	// Missing is set when the table does not exist, e.g. detection is disabled
+	Missing bool `json:"missing,omitempty"`
+}
+
+// RetentionPlan is the result of a retention dry run.
+type RetentionPlan struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Tables      []TableRetentionPlan `json:"tables"`
+
+	// OrphanedGeolocations is the number of geolocations no playback event
+	// would reference after pruning
+	OrphanedGeolocations int64  `json:"orphaned_geolocations"`
+	ArchiveDir           string `json:"archive_dir,omitempty"`
+}
+
+// TableRetentionResult reports what a prune run deleted from one table.
+type TableRetentionResult struct {
+	Table        string   `json:"table"`
+	DeletedRows  int64    `json:"deleted_rows"`
+	ArchiveFiles []string `json:"archive_files,omitempty"`
+}
+
+// RetentionResult reports one prune run.
+type RetentionResult struct {
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+	Tables     []TableRetentionResult `json:"tables"`
+
+	OrphanedGeolocations int64 `json:"orphaned_geolocations"`
+
+	// Complete is false when the quiet window closed before every doomed
+	// row was pruned; the rest is pruned in the next window
+	Complete bool `json:"complete"`
+}
+
+// DeletedRows returns the rows deleted from all tables, geolocations included.
+func (r *RetentionResult) DeletedRows() int64 {
+	total := r.OrphanedGeolocations
+	for _, t := range r.Tables {
+		total += t.DeletedRows
+	}
+	return total
+}
+
+// Pruner enforces the retention policies (RETENTION_*). It implements
+// suture.Service: every retentionCheckInterval it checks whether the local
+// hour is inside the quiet window and, once per window, prunes the oldest
+// rows of each table in batches of one transaction each. Geolocations no
+// longer referenced by any playback event are pruned afterwards.
+//
+// With an archive directory, each batch is first written to a Parquet file
+// in the same transaction, so a row is only deleted once it is archived.
+type Pruner struct {
+	db          *DB
+	policies    []RetentionPolicy
+	windowStart int
+	windowEnd   int
+	batchSize   int64
+	archiveDir  string
+	clock       clock.Clock
+	onPruned    func(*RetentionResult, error)
+}
+
+// NewPruner creates a pruner for the policies and quiet window in cfg.
+func NewPruner(db *DB, cfg config.RetentionConfig) *Pruner {
+	return &Pruner{
+		db:          db,
+		policies:    RetentionPolicies(cfg),
+		windowStart: cfg.WindowStartHour,
+		windowEnd:   cfg.WindowEndHour,
+		batchSize:   int64(cfg.BatchSize),
+		archiveDir:  cfg.ArchiveDir,
+		clock:       clock.Real(),
+	}
+}
+
+// SetOnPruned sets a function called after every scheduled prune run with
+// its result, which is partial when err is not nil. Call it before Serve.
+func (p *Pruner) SetOnPruned(fn func(*RetentionResult, error)) {
+	p.onPruned = fn
+}
+
+// Serve prunes once in every quiet window until ctx is canceled.
+func (p *Pruner) Serve(ctx context.Context) error {
+	ticker := p.clock.NewTicker(retentionCheckInterval)
+	defer ticker.Stop()
+
+	ranInWindow := false
+	for {
+		if !p.inWindow(p.clock.Now()) {
+			ranInWindow = false
+		} else if !ranInWindow {
+			p.run(ctx)
+			ranInWindow = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C():
+		}
+	}
+}
+
+// String implements fmt.Stringer for supervisor logging.
+func (p *Pruner) String() string {
+	return "retention-pruner"
+}
+
+func (p *Pruner) run(ctx context.Context) {
+	result, err := p.Prune(ctx)
+	if err != nil {
+		logging.Error().Err(err).Int64("deleted_rows", result.DeletedRows()).Msg("Retention pruning failed")
+	} else {
+		logging.Info().Int64("deleted_rows", result.DeletedRows()).
+			Int64("orphaned_geolocations", result.OrphanedGeolocations).
+			Bool("complete", result.Complete).
+			Dur("duration", result.FinishedAt.Sub(result.StartedAt)).
+			Msg("Retention pruning finished")
+	}
+	if p.onPruned != nil {
+		p.onPruned(result, err)
+	}
+}
+
+// inWindow reports whether t falls inside the quiet window, which may wrap
+// past midnight.
+func (p *Pruner) inWindow(t time.Time) bool {
+	h := t.Hour()
+	if p.windowStart < p.windowEnd {
+		return h >= p.windowStart && h < p.windowEnd
+	}
+	return h >= p.windowStart || h < p.windowEnd
+}
+
+// Plan reports what the policies would prune now without deleting anything.
+func (p *Pruner) Plan(ctx context.Context) (*RetentionPlan, error) {
+	now := p.clock.Now()
+	plan := &RetentionPlan{GeneratedAt: now, Tables: []TableRetentionPlan{}, ArchiveDir: p.archiveDir}
+
+	var playbackPruned int64
+	for _, policy := range p.policies {
+		tp, err := p.planTable(ctx, policy, now)
+		if err != nil {
+			return nil, err
+		}
+		if tp.Missing {
+			plan.Tables = append(plan.Tables, *tp)
+			continue
+		}
+		if tp.PruneRows > 0 {
+			column := retentionTimeColumns[policy.Table]
+			oldest, newest, err := p.prunedRange(ctx, policy.Table, column, tp.PruneRows)
+			if err != nil {
+				return nil, err
+			}
+			tp.OldestPruned, tp.NewestPruned = oldest, newest
+		}
+		if policy.Table == "playback_events" {
+			playbackPruned = tp.PruneRows
+		}
+		plan.Tables = append(plan.Tables, *tp)
+	}
+
+	orphaned, err := p.countOrphanedGeolocations(ctx, now, playbackPruned)
+	if err != nil {
+		return nil, err
+	}
+	plan.OrphanedGeolocations = orphaned
+	return plan, nil
+}
+
+// Prune deletes the rows the policies doom, oldest first, and then the
+// orphaned geolocations. It stops early, with Complete false, when the
+// quiet window closes. Rows deleted before an error stay deleted and are
+// counted in the returned result.
+func (p *Pruner) Prune(ctx context.Context) (*RetentionResult, error) {
+	result := &RetentionResult{StartedAt: p.clock.Now(), Tables: []TableRetentionResult{}, Complete: true}
+	defer func() {
+		result.FinishedAt = p.clock.Now()
+		if result.DeletedRows() > 0 {
+			// Tiles and analytics computed from the pruned rows are stale
+			p.db.IncrementDataVersion()
+		}
+	}()
+
+	for _, policy := range p.policies {
+		tr := TableRetentionResult{Table: policy.Table}
+		err := p.pruneTable(ctx, policy, result, &tr)
+		if tr.DeletedRows > 0 || len(tr.ArchiveFiles) > 0 {
+			result.Tables = append(result.Tables, tr)
+			metrics.RecordRetentionPruned(policy.Table, tr.DeletedRows)
+		}
+		if err != nil {
+			return result, fmt.Errorf("prune %s: %w", policy.Table, err)
+		}
+		if !result.Complete {
+			return result, nil
+		}
+	}
+
+	err := p.pruneOrphanedGeolocations(ctx, result)
+	if result.OrphanedGeolocations > 0 {
+		metrics.RecordRetentionPruned("geolocations", result.OrphanedGeolocations)
+	}
+	if err != nil {
+		return result, fmt.Errorf("prune orphaned geolocations: %w", err)
+	}
+	return result, nil
+}
+
+func (p *Pruner) pruneTable(ctx context.Context, policy RetentionPolicy, result *RetentionResult, tr *TableRetentionResult) error {
+	plan, err := p.planTable(ctx, policy, p.clock.Now())
+	if err != nil || plan.Missing {
+		return err
+	}
+
+	column := retentionTimeColumns[policy.Table]
+	remaining := plan.PruneRows
+	for batch := 1; remaining > 0; batch++ {
+		if !p.inWindow(p.clock.Now()) {
+			result.Complete = false
+			return nil
+		}
+
+		var archivePath string
+		if p.archiveDir != "" {
+			archivePath = filepath.Join(p.archiveDir, fmt.Sprintf("%s-%s-%04d.parquet",
+				policy.Table, result.StartedAt.UTC().Format("20060102T150405Z"), batch))
+		}
+
+		deleted, err := p.pruneBatch(ctx, policy.Table, column, min(p.batchSize, remaining), archivePath)
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			// Rows were removed by someone else meanwhile
+			return nil
+		}
+		tr.DeletedRows += deleted
+		if archivePath != "" {
+			tr.ArchiveFiles = append(tr.ArchiveFiles, archivePath)
+		}
+		remaining -= deleted
+	}
+	return nil
+}
+
+// pruneBatch archives (when archivePath is set) and deletes the n oldest
+// rows of table in one transaction. The archive file is removed again if
+// the rows could not be deleted.
+func (p *Pruner) pruneBatch(ctx context.Context, table, column string, n int64, archivePath string) (deleted int64, err error) {
+	ctx, cancel := context.WithTimeout(ctx, retentionBatchTimeout)
+	defer cancel()
+
+	tx, err := p.db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	// table and column come from retentionTimeColumns, not from input
+	if archivePath != "" {
+		if err := os.MkdirAll(filepath.Dir(archivePath), 0o750); err != nil {
+			return 0, fmt.Errorf("failed to create archive directory: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				_ = os.Remove(archivePath) //nolint:errcheck // best effort, the file may not exist
+			}
+		}()
+		// COPY binds neither parameters in its query nor the target here, so
+		// both are inlined; the path is built by pruneTable, not from input
+		archive := fmt.Sprintf(`COPY (SELECT * FROM %s ORDER BY %s, id LIMIT %d) TO '%s' (FORMAT PARQUET, COMPRESSION 'ZSTD')`,
+			table, column, n, strings.ReplaceAll(archivePath, "'", "''"))
+		if _, err := tx.ExecContext(ctx, archive); err != nil {
+			return 0, fmt.Errorf("failed to archive rows: %w", err)
+		}
+	}
+
+	//nolint:gosec // table and column are fixed identifiers
+	res, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id IN (SELECT id FROM %s ORDER BY %s, id LIMIT ?)`,
+		table, table, column), n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rows: %w", err)
+	}
+	deleted, err = res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted rows: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return deleted, nil
+}
+
+// planTable counts the rows of a table and how many of the oldest ones the
+// policy dooms: those older than MaxAge, or beyond MaxRows if that is more.
+func (p *Pruner) planTable(ctx context.Context, policy RetentionPolicy, now time.Time) (*TableRetentionPlan, error) {
+	tp := &TableRetentionPlan{
+		Table:      policy.Table,
+		MaxAgeDays: int(policy.MaxAge / (24 * time.Hour)),
+		MaxRows:    policy.MaxRows,
+	}
+
+	exists, err := p.db.tableExists(ctx, policy.Table)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		tp.Missing = true
+		return tp, nil
+	}
+
+	// Without an age limit the cutoff is far enough in the past to match nothing
+	cutoff := time.Unix(0, 0).UTC()
+	if policy.MaxAge > 0 {
+		cutoff = now.Add(-policy.MaxAge)
+		tp.AgeCutoff = &cutoff
+	}
+
+	var expired int64
+	column := retentionTimeColumns[policy.Table]
+	//nolint:gosec // table and column are fixed identifiers
+	err = p.db.conn.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE %s < ?) FROM %s`, column, policy.Table), cutoff).
+		Scan(&tp.TotalRows, &expired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count %s rows: %w", policy.Table, err)
+	}
+
+	tp.PruneRows = expired
+	if policy.MaxRows > 0 && tp.TotalRows-policy.MaxRows > tp.PruneRows {
+		tp.PruneRows = tp.TotalRows - policy.MaxRows
+	}
+	return tp, nil
+}
+
+// prunedRange returns the oldest and newest timestamps of the n oldest rows.
+func (p *Pruner) prunedRange(ctx context.Context, table, column string, n int64) (oldest, newest *time.Time, err error) {
+	var first, last sql.NullTime
+	//nolint:gosec // table and column are fixed identifiers
+	err = p.db.conn.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT MIN(%[1]s), MAX(%[1]s) FROM (SELECT %[1]s FROM %[2]s ORDER BY %[1]s, id LIMIT ?)`, column, table), n).
+		Scan(&first, &last)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s pruned range: %w", table, err)
+	}
+	if first.Valid {
+		oldest = &first.Time
+	}
+	if last.Valid {
+		newest = &last.Time
+	}
+	return oldest, newest, nil
+}
+
+// countOrphanedGeolocations counts the geolocations that no playback event
+// would reference once the oldest playbackPruned events are gone.
+func (p *Pruner) countOrphanedGeolocations(ctx context.Context, now time.Time, playbackPruned int64) (int64, error) {
+	var count int64
+	err := p.db.conn.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM geolocations g
+		WHERE g.last_updated < ?
+		  AND NOT EXISTS (
+			SELECT 1 FROM (SELECT ip_address FROM playback_events ORDER BY started_at, id OFFSET ?) p
+			WHERE p.ip_address = g.ip_address
+		  )`, now.Add(-orphanedGeolocationGrace), playbackPruned).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count orphaned geolocations: %w", err)
+	}
+	return count, nil
+}
+
+// pruneOrphanedGeolocations deletes, in batches, the geolocations no
+// playback event references.
+func (p *Pruner) pruneOrphanedGeolocations(ctx context.Context, result *RetentionResult) error {
+	for {
+		if !p.inWindow(p.clock.Now()) {
+			result.Complete = false
+			return nil
+		}
+
+		batchCtx, cancel := context.WithTimeout(ctx, retentionBatchTimeout)
+		res, err := p.db.conn.ExecContext(batchCtx, `
+			DELETE FROM geolocations WHERE ip_address IN (
+				SELECT g.ip_address FROM geolocations g
+				WHERE g.last_updated < ?
+				  AND NOT EXISTS (SELECT 1 FROM playback_events p WHERE p.ip_address = g.ip_address)
+				LIMIT ?
+			)`, p.clock.Now().Add(-orphanedGeolocationGrace), p.batchSize)
+		cancel()
+		if err != nil {
+			return err
+		}
+		deleted, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		result.OrphanedGeolocations += deleted
+		if deleted < p.batchSize {
+			return nil
+		}
+	}
+}
+
+// tableExists reports whether a table exists in the main schema.
+func (db *DB) tableExists(ctx context.Context, table string) (bool, error) {
+	var n int
+	err := db.conn.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM duckdb_tables()
+		WHERE database_name = current_database() AND schema_name = 'main' AND table_name = ?`, table).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up table %s: %w", table, err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,204 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/clock"
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+// newTestPruner returns a pruner whose quiet window is open at now
+func newTestPruner(db *DB, now time.Time, cfg config.RetentionConfig) *Pruner {
+	cfg.WindowStartHour = now.Hour()
+	cfg.WindowEndHour = (now.Hour() + 1) % 24
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 1000
+	}
+	p := NewPruner(db, cfg)
+	p.clock = clock.NewFake(now)
+	return p
+}
+
+// setupRetentionTestDB returns a database with the nine test playbacks,
+// started 1 hour to 30 days ago, and geolocations cached two days ago
+func setupRetentionTestDB(t *testing.T) *DB {
+	t.Helper()
+	db := setupTestDB(t)
+	insertTestGeolocations(t, db)
+	insertTestPlaybacks(t, db)
+	_, err := db.conn.Exec(`UPDATE geolocations SET last_updated = ?`, time.Now().Add(-48*time.Hour))
+	checkNoError(t, err)
+	return db
+}
+
+func countRows(t *testing.T, db *DB, query string, args ...any) int64 {
+	t.Helper()
+	var n int64
+	if err := db.conn.QueryRow(query, args...).Scan(&n); err != nil {
+		t.Fatalf("count query failed: %v", err)
+	}
+	return n
+}
+
+func TestRetentionPolicies(t *testing.T) {
+	policies := RetentionPolicies(config.RetentionConfig{
+		PlaybackMaxAgeDays:   365,
+		NewsletterLogMaxRows: 1000,
+	})
+	want := []RetentionPolicy{
+		{Table: "playback_events", MaxAge: 365 * 24 * time.Hour},
+		{Table: "newsletter_delivery_log", MaxRows: 1000},
+	}
+	if len(policies) != len(want) {
+		t.Fatalf("RetentionPolicies() = %+v, want %+v", policies, want)
+	}
+	for i := range want {
+		if policies[i] != want[i] {
+			t.Errorf("policy %d = %+v, want %+v", i, policies[i], want[i])
+		}
+	}
+}
+
+func TestPruner_InWindow(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, 3, 1, hour, 30, 0, 0, time.Local) }
+
+	tests := []struct {
+		name       string
+		start, end int
+		hour       int
+		want       bool
+	}{
+		{name: "inside", start: 3, end: 5, hour: 4, want: true},
+		{name: "start is inclusive", start: 3, end: 5, hour: 3, want: true},
+		{name: "end is exclusive", start: 3, end: 5, hour: 5, want: false},
+		{name: "wrapped before midnight", start: 22, end: 2, hour: 23, want: true},
+		{name: "wrapped after midnight", start: 22, end: 2, hour: 1, want: true},
+		{name: "outside wrapped window", start: 22, end: 2, hour: 12, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Pruner{windowStart: tt.start, windowEnd: tt.end}
+			if got := p.inWindow(at(tt.hour)); got != tt.want {
+				t.Errorf("inWindow(%02d:30) = %v, want %v", tt.hour, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruner_PlanAndPruneByAge(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	defer db.Close()
+
+	archiveDir := t.TempDir()
+	p := newTestPruner(db, time.Now(), config.RetentionConfig{
+		PlaybackMaxAgeDays:        5,
+		DetectionAlertsMaxAgeDays: 30,
+		BatchSize:                 1,
+		ArchiveDir:                archiveDir,
+	})
+	ctx := context.Background()
+
+	plan, err := p.Plan(ctx)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(plan.Tables) != 2 {
+		t.Fatalf("plan tables = %+v, want playback_events and detection_alerts", plan.Tables)
+	}
+	playback := plan.Tables[0]
+	if playback.TotalRows != 9 || playback.PruneRows != 2 || playback.AgeCutoff == nil || playback.OldestPruned == nil {
+		t.Errorf("playback plan = %+v, want 2 of 9 rows pruned with a cutoff and range", playback)
+	}
+	if !plan.Tables[1].Missing {
+		t.Errorf("detection_alerts plan = %+v, want missing (detection disabled)", plan.Tables[1])
+	}
+	if plan.OrphanedGeolocations != 2 {
+		t.Errorf("plan orphaned geolocations = %d, want 2 (Paris and Tokyo)", plan.OrphanedGeolocations)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events`); n != 9 {
+		t.Fatalf("Plan() deleted rows: %d left, want 9", n)
+	}
+
+	var notified *RetentionResult
+	p.SetOnPruned(func(r *RetentionResult, err error) { notified = r })
+	versionBefore := db.dataVersion
+	p.run(ctx)
+
+	result := notified
+	if result == nil {
+		t.Fatal("onPruned was not called")
+	}
+	if !result.Complete || len(result.Tables) != 1 || result.Tables[0].DeletedRows != 2 || result.OrphanedGeolocations != 2 {
+		t.Fatalf("result = %+v, want 2 playback rows and 2 geolocations pruned", result)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events WHERE started_at < ?`, time.Now().Add(-5*24*time.Hour)); n != 0 {
+		t.Errorf("%d expired playback events left", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM geolocations`); n != 3 {
+		t.Errorf("geolocations left = %d, want 3", n)
+	}
+	if db.dataVersion == versionBefore {
+		t.Error("data version was not incremented")
+	}
+
+	// One Parquet file per batch holds the pruned rows
+	files := result.Tables[0].ArchiveFiles
+	if len(files) != 2 {
+		t.Fatalf("archive files = %v, want 2", files)
+	}
+	archived := countRows(t, db, `SELECT COUNT(*) FROM read_parquet(?)`, filepath.Join(archiveDir, "playback_events-*.parquet"))
+	if archived != 2 {
+		t.Errorf("archived rows = %d, want 2", archived)
+	}
+}
+
+func TestPruner_PruneByRowCount(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	defer db.Close()
+
+	p := newTestPruner(db, time.Now(), config.RetentionConfig{PlaybackMaxAgeDays: 60, PlaybackMaxRows: 5})
+	result, err := p.Prune(context.Background())
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if result.Tables[0].DeletedRows != 4 {
+		t.Errorf("deleted rows = %d, want 4", result.Tables[0].DeletedRows)
+	}
+
+	// The newest five playbacks are kept
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events WHERE started_at >= ?`, time.Now().Add(-5*time.Hour)); n != 4 {
+		t.Errorf("recent playbacks left = %d, want 4", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events`); n != 5 {
+		t.Errorf("playbacks left = %d, want 5", n)
+	}
+}
+
+func TestPruner_StopsOutsideWindow(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	p := newTestPruner(db, now, config.RetentionConfig{PlaybackMaxRows: 1})
+	p.windowStart, p.windowEnd = (now.Hour()+1)%24, (now.Hour()+2)%24
+
+	result, err := p.Prune(context.Background())
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if result.Complete || result.DeletedRows() != 0 {
+		t.Errorf("result = %+v, want an incomplete run that deleted nothing", result)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events`); n != 9 {
+		t.Errorf("playbacks left = %d, want 9", n)
+	}
+}
@@ -0,0 +1,147 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultSessionStitchGap is the longest pause after which a resumed
+// playback still continues the same logical viewing session.
+const DefaultSessionStitchGap = 2 * time.Hour
+
+// stitchFinishedPercent is the completion at which a playback is finished,
+// so a later play of the item is a rewatch rather than a resume.
+const stitchFinishedPercent = 90
+
+// stitchRewindPercent is how far, in percentage points, a resumed playback
+// may end behind the previous one and still continue it (e.g. after
+// rewinding a few minutes).
+const stitchRewindPercent = 5
+
+// stitchedPlaybackEvents replaces playback_events in analytics queries run
+// with LocationStatsFilter.Stitched. It has one row per logical session:
+// the first playback's columns, with the session's latest stop, furthest
+// completion and summed play time and pauses. Playbacks that have not been
+// stitched yet are sessions of their own.
+const stitchedPlaybackEvents = `(
+	SELECT * EXCLUDE (stitch_rank) FROM (
+		SELECT e.* REPLACE (
+				MAX(e.stopped_at) OVER stitch AS stopped_at,
+				MAX(e.percent_complete) OVER stitch AS percent_complete,
+				CAST(SUM(e.play_duration) OVER stitch AS INTEGER) AS play_duration,
+				CAST(SUM(e.paused_counter) OVER stitch AS INTEGER) AS paused_counter
+			),
+			ROW_NUMBER() OVER (stitch ORDER BY e.started_at, e.id) AS stitch_rank
+		FROM playback_events e
+		WINDOW stitch AS (PARTITION BY COALESCE(e.logical_session_id, CAST(e.id AS VARCHAR)))
+	) WHERE stitch_rank = 1
+) AS playback_events`
+
+// playbackEventsSource returns the relation analytics read playbacks from:
+// playback_events, or one row per logical session when filter.Stitched is
+// set. The stitched relation is aliased playback_events, so queries can
+// reference its columns as usual.
+//
+//nolint:gocritic // hugeParam: filter passed by value like the other query builders
+func playbackEventsSource(filter LocationStatsFilter) string {
+	if filter.Stitched {
+		return stitchedPlaybackEvents
+	}
+	return "playback_events"
+}
+
+// StitchSessions links playbacks of the same item by the same user into
+// logical viewing sessions and records the session on each row as
+// logical_session_id: the id of the session's first playback.
+//
+// Playbacks of a user and item are taken in start order, and one continues
+// the session of the playback before it when it starts at most gap after
+// that playback stopped (or while it was still playing), the earlier
+// playback was not finished (under 90%), and it reaches at least about the
+// same completion (at most 5 points behind). Start offsets are not stored,
+// so completion at stop stands in for the view offset. Playbacks without a
+// rating key are left alone.
+//
+// Only users and items with unstitched playbacks are processed unless full
+// is set, in which case all history is stitched again (e.g. after changing
+// the gap). Events arriving out of order are handled because each affected
+// user and item is stitched from its whole history. Returns the number of
+// playbacks whose logical session changed.
+func (db *DB) StitchSessions(ctx context.Context, gap time.Duration, full bool) (int64, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	pending := " AND logical_session_id IS NULL"
+	if full {
+		pending = ""
+	}
+
+	query := `
+	UPDATE playback_events
+	SET logical_session_id = s.logical_session_id
+	FROM (
+		WITH affected AS (
+			SELECT DISTINCT user_id, rating_key
+			FROM playback_events
+			WHERE rating_key IS NOT NULL AND rating_key <> ''` + pending + `
+		),
+		ordered AS (
+			SELECT
+				e.id, e.user_id, e.rating_key, e.started_at, e.percent_complete,
+				e.logical_session_id AS current_session_id,
+				LAG(COALESCE(e.stopped_at, e.started_at)) OVER w AS prev_stopped_at,
+				LAG(e.percent_complete) OVER w AS prev_percent
+			FROM playback_events e
+			JOIN affected a ON e.user_id = a.user_id AND e.rating_key = a.rating_key
+			WINDOW w AS (PARTITION BY e.user_id, e.rating_key ORDER BY e.started_at, e.id)
+		),
+		marked AS (
+			SELECT *,
+				CASE
+					WHEN prev_stopped_at IS NULL
+						OR epoch(started_at - prev_stopped_at) > ?
+						OR prev_percent IS NULL OR percent_complete IS NULL
+						OR prev_percent >= ?
+						OR percent_complete < prev_percent - ?
+					THEN 1
+					ELSE 0
+				END AS is_new_session
+			FROM ordered
+		),
+		numbered AS (
+			SELECT *,
+				SUM(is_new_session) OVER (
+					PARTITION BY user_id, rating_key
+					ORDER BY started_at, id
+					ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW
+				) AS session_number
+			FROM marked
+		)
+		SELECT
+			id,
+			current_session_id,
+			CAST(FIRST_VALUE(id) OVER (
+				PARTITION BY user_id, rating_key, session_number
+				ORDER BY started_at, id
+			) AS VARCHAR) AS logical_session_id
+		FROM numbered
+	) s
+	WHERE playback_events.id = s.id
+		AND s.logical_session_id IS DISTINCT FROM s.current_session_id`
+
+	result, err := db.conn.ExecContext(ctx, query, gap.Seconds(), stitchFinishedPercent, stitchRewindPercent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stitch sessions: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count stitched playbacks: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,232 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// stitchTestEvent is a movie playback by user 1 unless changed.
+func stitchTestEvent(session, ratingKey string, start, stop time.Time, percent int) *models.PlaybackEvent {
+	playDuration := int(stop.Sub(start).Minutes())
+	return &models.PlaybackEvent{
+		SessionKey:      session,
+		StartedAt:       start,
+		StoppedAt:       &stop,
+		UserID:          1,
+		Username:        "testuser",
+		IPAddress:       "198.51.100.7",
+		MediaType:       "movie",
+		Title:           "Movie " + ratingKey,
+		RatingKey:       &ratingKey,
+		PercentComplete: percent,
+		PlayDuration:    &playDuration,
+		PausedCounter:   1,
+	}
+}
+
+// logicalSessions returns the logical session of each playback by session key.
+func logicalSessions(t *testing.T, db *DB) map[string]*string {
+	t.Helper()
+
+	rows, err := db.conn.Query(`SELECT session_key, CAST(id AS VARCHAR), logical_session_id FROM playback_events`)
+	checkNoError(t, err)
+	defer rows.Close()
+
+	ids := make(map[string]string)
+	sessions := make(map[string]*string)
+	for rows.Next() {
+		var key, id string
+		var logical sql.NullString
+		checkNoError(t, rows.Scan(&key, &id, &logical))
+		ids[key] = id
+		if logical.Valid {
+			sessions[key] = &logical.String
+		} else {
+			sessions[key] = nil
+		}
+	}
+	checkNoError(t, rows.Err())
+
+	// Replace ids by the session key of the playback they belong to
+	for key, logical := range sessions {
+		if logical == nil {
+			continue
+		}
+		for k, id := range ids {
+			if id == *logical {
+				name := k
+				sessions[key] = &name
+			}
+		}
+	}
+	return sessions
+}
+
+func TestStitchSessions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+	other := stitchTestEvent("other-user", "m1", at(1, 20, 0), at(1, 21, 0), 80)
+	other.UserID, other.Username = 2, "otheruser"
+	noKey := stitchTestEvent("no-key", "", at(1, 18, 0), at(1, 18, 30), 20)
+	noKey.RatingKey = nil
+
+	_, _, err := db.InsertPlaybackEventsBatch(ctx, []*models.PlaybackEvent{
+		// Paused for dinner, resumed 80 minutes later and finished
+		stitchTestEvent("m1-part1", "m1", at(1, 18, 0), at(1, 18, 40), 40),
+		stitchTestEvent("m1-part2", "m1", at(1, 20, 0), at(1, 21, 0), 95),
+		// Watched again the next day after finishing
+		stitchTestEvent("m1-rewatch", "m1", at(2, 20, 0), at(2, 20, 30), 30),
+		// Resumed after more than the gap
+		stitchTestEvent("m2-part1", "m2", at(1, 9, 0), at(1, 10, 0), 30),
+		stitchTestEvent("m2-later", "m2", at(1, 13, 0), at(1, 14, 0), 60),
+		// Started over from the beginning
+		stitchTestEvent("m3-part1", "m3", at(1, 9, 0), at(1, 10, 0), 60),
+		stitchTestEvent("m3-restart", "m3", at(1, 10, 30), at(1, 10, 50), 20),
+		// Switched devices before the first stream stopped
+		stitchTestEvent("m4-tv", "m4", at(1, 20, 0), at(1, 20, 50), 50),
+		stitchTestEvent("m4-phone", "m4", at(1, 20, 30), at(1, 21, 30), 100),
+		other,
+		noKey,
+	})
+	checkNoError(t, err)
+
+	changed, err := db.StitchSessions(ctx, DefaultSessionStitchGap, false)
+	checkNoError(t, err)
+	if changed != 10 {
+		t.Errorf("changed = %d, want the 10 playbacks with a rating key", changed)
+	}
+
+	want := map[string]string{
+		"m1-part1":   "m1-part1",
+		"m1-part2":   "m1-part1",
+		"m1-rewatch": "m1-rewatch",
+		"m2-part1":   "m2-part1",
+		"m2-later":   "m2-later",
+		"m3-part1":   "m3-part1",
+		"m3-restart": "m3-restart",
+		"m4-tv":      "m4-tv",
+		"m4-phone":   "m4-tv",
+		"other-user": "other-user",
+	}
+	sessions := logicalSessions(t, db)
+	for key, wantSession := range want {
+		if got := sessions[key]; got == nil || *got != wantSession {
+			t.Errorf("%s: logical session = %v, want %s", key, got, wantSession)
+		}
+	}
+	if sessions["no-key"] != nil {
+		t.Errorf("no-key: logical session = %v, want none", *sessions["no-key"])
+	}
+
+	// Nothing left to stitch, and a full pass changes nothing
+	for _, full := range []bool{false, true} {
+		changed, err = db.StitchSessions(ctx, DefaultSessionStitchGap, full)
+		checkNoError(t, err)
+		if changed != 0 {
+			t.Errorf("full=%v: changed = %d on a second pass, want 0", full, changed)
+		}
+	}
+
+	// A full pass with a longer gap joins m2's plays
+	changed, err = db.StitchSessions(ctx, 4*time.Hour, true)
+	checkNoError(t, err)
+	if changed != 1 {
+		t.Errorf("changed = %d with a 4h gap, want 1", changed)
+	}
+	if got := logicalSessions(t, db)["m2-later"]; got == nil || *got != "m2-part1" {
+		t.Errorf("m2-later: logical session = %v, want m2-part1", got)
+	}
+}
+
+func TestStitchSessions_OutOfOrder(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	// The resumed playback is synced first
+	checkNoError(t, db.InsertPlaybackEventWithContext(ctx,
+		stitchTestEvent("resumed", "m1", start.Add(time.Hour), start.Add(2*time.Hour), 90)))
+	_, err := db.StitchSessions(ctx, DefaultSessionStitchGap, false)
+	checkNoError(t, err)
+	if got := logicalSessions(t, db)["resumed"]; got == nil || *got != "resumed" {
+		t.Fatalf("resumed: logical session = %v, want its own", got)
+	}
+
+	// The playback it resumed arrives later and takes over the session
+	checkNoError(t, db.InsertPlaybackEventWithContext(ctx,
+		stitchTestEvent("first", "m1", start, start.Add(30*time.Minute), 35)))
+	changed, err := db.StitchSessions(ctx, DefaultSessionStitchGap, false)
+	checkNoError(t, err)
+	if changed != 2 {
+		t.Errorf("changed = %d, want 2", changed)
+	}
+	sessions := logicalSessions(t, db)
+	for _, key := range []string{"first", "resumed"} {
+		if got := sessions[key]; got == nil || *got != "first" {
+			t.Errorf("%s: logical session = %v, want first", key, got)
+		}
+	}
+}
+
+func TestStitchedAnalytics(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	show := "Test Show"
+	episode := func(session, ratingKey string, offset time.Duration, minutes, percent int) *models.PlaybackEvent {
+		e := stitchTestEvent(session, ratingKey, start.Add(offset), start.Add(offset+time.Duration(minutes)*time.Minute), percent)
+		e.MediaType = "episode"
+		e.GrandparentTitle = &show
+		return e
+	}
+
+	// Three episodes in a row, the first one paused and resumed twice
+	_, _, err := db.InsertPlaybackEventsBatch(ctx, []*models.PlaybackEvent{
+		episode("e1-a", "e1", 0, 10, 20),
+		episode("e1-b", "e1", 15*time.Minute, 10, 50),
+		episode("e1-c", "e1", 30*time.Minute, 20, 100),
+		episode("e2", "e2", time.Hour, 45, 100),
+		episode("e3", "e3", 2*time.Hour, 45, 95),
+	})
+	checkNoError(t, err)
+	_, err = db.StitchSessions(ctx, DefaultSessionStitchGap, false)
+	checkNoError(t, err)
+
+	raw, err := db.GetBingeAnalytics(ctx, LocationStatsFilter{})
+	checkNoError(t, err)
+	stitched, err := db.GetBingeAnalytics(ctx, LocationStatsFilter{Stitched: true})
+	checkNoError(t, err)
+	if raw.TotalEpisodesBinged != 5 || stitched.TotalEpisodesBinged != 3 {
+		t.Errorf("episodes binged = %d raw, %d stitched; want 5 and 3", raw.TotalEpisodesBinged, stitched.TotalEpisodesBinged)
+	}
+
+	completion, err := db.GetContentCompletionStats(ctx, LocationStatsFilter{Stitched: true})
+	checkNoError(t, err)
+	if completion.TotalPlaybacks != 3 || completion.FullyWatched != 2 {
+		t.Errorf("stitched completion = %+v, want 3 plays with 2 fully watched", completion)
+	}
+
+	popular, err := db.GetPopularContent(ctx, LocationStatsFilter{Stitched: true}, 10)
+	checkNoError(t, err)
+	if len(popular.TopShows) != 1 || popular.TopShows[0].PlaybackCount != 3 {
+		t.Errorf("stitched top shows = %+v, want Test Show with 3 plays", popular.TopShows)
+	}
+}
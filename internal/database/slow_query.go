@@ -0,0 +1,347 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// Slow query log limits.
+const (
+	defaultSlowQueryLogSize = 100
+	slowQueryExplainTimeout = 10 * time.Second
+	redactedValue           = "[REDACTED]"
+)
+
+// SlowQuery is a statement that took longer than DB_SLOW_QUERY_THRESHOLD,
+// or that was interrupted because its context deadline passed.
+type SlowQuery struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Query      string    `json:"query"`
+	Params     []string  `json:"params,omitempty"`
+	Filter     string    `json:"filter,omitempty"`
+	DurationMS float64   `json:"duration_ms"`
+	Rows       int64     `json:"rows"`
+	Error      string    `json:"error,omitempty"`
+	TimedOut   bool      `json:"timed_out,omitempty"`
+	Plan       string    `json:"plan,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+}
+
+// queryLabel names the statements run for one logical query.
+type queryLabel struct {
+	name   string
+	filter *LocationStatsFilter
+}
+
+type queryLabelKey struct{}
+
+// WithQueryLabel names the statements run with ctx in the slow query log,
+// e.g. after the analytics endpoint that issued them. The filter, if not
+// nil, is summarized in the entry; it is only read when a query is slow.
+func WithQueryLabel(ctx context.Context, name string, filter *LocationStatsFilter) context.Context {
+	return context.WithValue(ctx, queryLabelKey{}, queryLabel{name: name, filter: filter})
+}
+
+// SlowQueryLog keeps the most recent slow statements in a ring buffer.
+// Statements under the threshold only cost a clock read; formatting,
+// logging and EXPLAIN happen only for slow ones.
+type SlowQueryLog struct {
+	threshold time.Duration
+	explain   bool
+	redact    bool
+
+	mu      sync.Mutex
+	entries []SlowQuery
+	next    int
+	lastID  int64
+
+	// db runs EXPLAIN; explaining holds at most one EXPLAIN in flight so a
+	// burst of slow queries cannot add load to an already busy database.
+	db         *sql.DB
+	explaining chan struct{}
+}
+
+// NewSlowQueryLog creates a slow query log from the database config.
+// Returns nil when DB_SLOW_QUERY_THRESHOLD is 0, which disables it.
+func NewSlowQueryLog(cfg *config.DatabaseConfig) *SlowQueryLog {
+	if cfg == nil || cfg.SlowQueryThreshold <= 0 {
+		return nil
+	}
+	size := cfg.SlowQueryLogSize
+	if size <= 0 {
+		size = defaultSlowQueryLogSize
+	}
+	return &SlowQueryLog{
+		threshold:  cfg.SlowQueryThreshold,
+		explain:    cfg.SlowQueryExplain,
+		redact:     cfg.SlowQueryRedact,
+		entries:    make([]SlowQuery, 0, size),
+		explaining: make(chan struct{}, 1),
+	}
+}
+
+// SlowQueries returns the slow query log, or nil when it is disabled.
+func (db *DB) SlowQueries() *SlowQueryLog {
+	return db.slowQueries
+}
+
+// Threshold returns the duration above which statements are recorded.
+func (l *SlowQueryLog) Threshold() time.Duration {
+	return l.threshold
+}
+
+// Entries returns the recorded slow queries, newest first.
+func (l *SlowQueryLog) Entries() []SlowQuery {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := make([]SlowQuery, 0, len(l.entries))
+	for i := 1; i <= len(l.entries); i++ {
+		idx := (l.next - i + len(l.entries)) % len(l.entries)
+		result = append(result, l.entries[idx])
+	}
+	return result
+}
+
+// observe records a finished statement if it exceeded the threshold or
+// failed because its deadline passed, such as the API_QUERY_TIMEOUT of an
+// analytics request; a timeout is recorded even under the threshold.
+func (l *SlowQueryLog) observe(ctx context.Context, query string, args []driver.NamedValue, start time.Time, rows int64, err error) {
+	elapsed := time.Since(start)
+	timedOut := err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded)
+	if (elapsed < l.threshold && !timedOut) || sqlOperation(query) == "EXPLAIN" {
+		return
+	}
+
+	entry := SlowQuery{
+		Name:       sqlOperation(query),
+		Query:      strings.TrimSpace(query),
+		Params:     l.formatParams(args),
+		DurationMS: float64(elapsed.Microseconds()) / 1000,
+		Rows:       rows,
+		TimedOut:   timedOut,
+		StartedAt:  start,
+	}
+	if label, ok := ctx.Value(queryLabelKey{}).(queryLabel); ok {
+		entry.Name = label.name
+		if label.filter != nil {
+			entry.Filter = summarizeFilter(label.filter, l.redact)
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	l.record(&entry)
+
+	msg := "Slow database query"
+	if timedOut {
+		msg = "Database query timed out"
+	}
+	logging.Warn().
+		Str("query_name", entry.Name).
+		Float64("duration_ms", entry.DurationMS).
+		Str("filter", entry.Filter).
+		Int64("rows", entry.Rows).
+		Dur("threshold", l.threshold).
+		Msg(msg)
+
+	if l.explain && err == nil && l.db != nil && explainable(query) {
+		l.explainAsync(entry.ID, query, args)
+	}
+}
+
+// record assigns entry an ID and adds it to the ring buffer.
+func (l *SlowQueryLog) record(entry *SlowQuery) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastID++
+	entry.ID = l.lastID
+	if len(l.entries) < cap(l.entries) {
+		l.entries = append(l.entries, *entry)
+	} else {
+		l.entries[l.next] = *entry
+	}
+	l.next = (l.next + 1) % cap(l.entries)
+}
+
+// explainAsync runs EXPLAIN (never ANALYZE, which would execute the
+// statement again) in the background and attaches the plan to entry id.
+// Skipped when another EXPLAIN is still running.
+func (l *SlowQueryLog) explainAsync(id int64, query string, args []driver.NamedValue) {
+	select {
+	case l.explaining <- struct{}{}:
+	default:
+		return
+	}
+
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	go func() {
+		defer func() { <-l.explaining }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), slowQueryExplainTimeout)
+		defer cancel()
+
+		plan, err := l.runExplain(ctx, query, values)
+		if err != nil {
+			logging.Debug().Err(err).Int64("slow_query_id", id).Msg("Failed to explain slow query")
+			return
+		}
+		if l.redact {
+			plan = redactPlan(plan, values)
+		}
+		l.attachPlan(id, plan)
+	}()
+}
+
+func (l *SlowQueryLog) runExplain(ctx context.Context, query string, args []any) (string, error) {
+	rows, err := l.db.QueryContext(ctx, "EXPLAIN "+query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	// DuckDB returns (explain_key, explain_value) rows; the plan is the value
+	var plan strings.Builder
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return "", err
+		}
+		plan.WriteString(values[len(values)-1].String)
+	}
+	return plan.String(), rows.Err()
+}
+
+func (l *SlowQueryLog) attachPlan(id int64, plan string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range l.entries {
+		if l.entries[i].ID == id {
+			l.entries[i].Plan = plan
+			return
+		}
+	}
+}
+
+// formatParams renders statement parameters for display. With redaction
+// enabled every string parameter is replaced, since usernames are passed
+// as strings; dates and numbers are kept.
+func (l *SlowQueryLog) formatParams(args []driver.NamedValue) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	params := make([]string, len(args))
+	for i, arg := range args {
+		if _, isString := arg.Value.(string); isString && l.redact {
+			params[i] = redactedValue
+			continue
+		}
+		if t, isTime := arg.Value.(time.Time); isTime {
+			params[i] = t.Format(time.RFC3339)
+			continue
+		}
+		params[i] = fmt.Sprint(arg.Value)
+	}
+	return params
+}
+
+// redactPlan removes string parameter values from an EXPLAIN plan, which
+// can show the constants a filter compares against.
+func redactPlan(plan string, args []any) string {
+	for _, arg := range args {
+		if s, ok := arg.(string); ok && s != "" {
+			plan = strings.ReplaceAll(plan, s, redactedValue)
+		}
+	}
+	return plan
+}
+
+// explainable reports whether query is a read that EXPLAIN can describe.
+func explainable(query string) bool {
+	switch sqlOperation(query) {
+	case "SELECT", "WITH", "FROM":
+		return true
+	}
+	return false
+}
+
+// summarizeFilter describes the filter dimensions of a query for the slow
+// query log. With redaction enabled users are only counted.
+func summarizeFilter(filter *LocationStatsFilter, redact bool) string {
+	var parts []string
+	if filter.StartDate != nil {
+		parts = append(parts, "start="+filter.StartDate.Format(time.DateOnly))
+	}
+	if filter.EndDate != nil {
+		parts = append(parts, "end="+filter.EndDate.Format(time.DateOnly))
+	}
+	if len(filter.Users) > 0 {
+		if redact {
+			parts = append(parts, fmt.Sprintf("users=%d", len(filter.Users)))
+		} else {
+			parts = append(parts, "users="+strings.Join(filter.Users, ","))
+		}
+	}
+	for _, dim := range []struct {
+		name   string
+		values []string
+	}{
+		{"media_types", filter.MediaTypes},
+		{"platforms", filter.Platforms},
+		{"players", filter.Players},
+		{"transcode_decisions", filter.TranscodeDecisions},
+		{"video_resolutions", filter.VideoResolutions},
+		{"video_codecs", filter.VideoCodecs},
+		{"audio_codecs", filter.AudioCodecs},
+		{"libraries", filter.Libraries},
+		{"content_ratings", filter.ContentRatings},
+		{"location_types", filter.LocationTypes},
+		{"connection_types", filter.ConnectionTypes},
+		{"server_ids", filter.ServerIDs},
+	} {
+		if len(dim.values) > 0 {
+			parts = append(parts, dim.name+"="+strings.Join(dim.values, ","))
+		}
+	}
+	if len(filter.Years) > 0 {
+		parts = append(parts, "years="+strings.Trim(fmt.Sprint(filter.Years), "[]"))
+	}
+	if filter.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit=%d", filter.Limit))
+	}
+	if filter.Stitched {
+		parts = append(parts, "stitched=true")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,203 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+// openSlowLogFake returns a sql.DB over the fake driver from tracing_test.go
+// with a slow query log that records every statement.
+func openSlowLogFake(t *testing.T, cfg config.DatabaseConfig) (*sql.DB, *SlowQueryLog) {
+	t.Helper()
+	if cfg.SlowQueryThreshold == 0 {
+		cfg.SlowQueryThreshold = time.Nanosecond
+	}
+	log := NewSlowQueryLog(&cfg)
+	db := sql.OpenDB(&instrumentedConnector{Connector: &fakeTracedConnector{}, slow: log})
+	log.db = db
+	t.Cleanup(func() { db.Close() })
+	return db, log
+}
+
+func TestNewSlowQueryLog_Disabled(t *testing.T) {
+	if log := NewSlowQueryLog(&config.DatabaseConfig{}); log != nil {
+		t.Error("NewSlowQueryLog() with zero threshold should return nil")
+	}
+	if log := NewSlowQueryLog(nil); log != nil {
+		t.Error("NewSlowQueryLog(nil) should return nil")
+	}
+}
+
+func TestSlowQueryLog_RecordsQuery(t *testing.T) {
+	db, log := openSlowLogFake(t, config.DatabaseConfig{})
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := LocationStatsFilter{StartDate: &start, Users: []string{"alice"}, MediaTypes: []string{"movie"}}
+	ctx := WithQueryLabel(context.Background(), "AnalyticsTrends", &filter)
+
+	rows, err := db.QueryContext(ctx, "SELECT n FROM playback_events WHERE username = ? AND started_at >= ?", "alice", start)
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	rows.Close()
+
+	entries := log.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(entries))
+	}
+	got := entries[0]
+	if got.Name != "AnalyticsTrends" || got.Rows != int64(count) {
+		t.Errorf("entry = %+v, want AnalyticsTrends with %d rows", got, count)
+	}
+	if got.Filter != "start=2026-01-01 users=alice media_types=movie" {
+		t.Errorf("filter = %q", got.Filter)
+	}
+	if len(got.Params) != 2 || got.Params[0] != "alice" || got.Params[1] != "2026-01-01T00:00:00Z" {
+		t.Errorf("params = %v", got.Params)
+	}
+}
+
+func TestSlowQueryLog_Redact(t *testing.T) {
+	db, log := openSlowLogFake(t, config.DatabaseConfig{SlowQueryRedact: true})
+
+	filter := LocationStatsFilter{Users: []string{"alice", "bob"}}
+	ctx := WithQueryLabel(context.Background(), "TopUsers", &filter)
+	if _, err := db.ExecContext(ctx, "DELETE FROM sessions WHERE username = ? AND n > ?", "alice", 5); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+
+	got := log.Entries()[0]
+	if got.Params[0] != redactedValue || got.Params[1] != "5" {
+		t.Errorf("params = %v, want username redacted and number kept", got.Params)
+	}
+	if strings.Contains(got.Filter, "alice") || got.Filter != "users=2" {
+		t.Errorf("filter = %q, want users counted", got.Filter)
+	}
+	if got.Rows != 2 {
+		t.Errorf("rows = %d, want 2 affected", got.Rows)
+	}
+}
+
+func TestSlowQueryLog_FastQueriesSkipped(t *testing.T) {
+	db, log := openSlowLogFake(t, config.DatabaseConfig{SlowQueryThreshold: time.Hour})
+
+	if _, err := db.ExecContext(context.Background(), "CHECKPOINT"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	if entries := log.Entries(); len(entries) != 0 {
+		t.Errorf("entries = %d, want 0 for fast statements", len(entries))
+	}
+}
+
+func TestSlowQueryLog_RingBuffer(t *testing.T) {
+	db, log := openSlowLogFake(t, config.DatabaseConfig{SlowQueryLogSize: 3})
+
+	for i := 0; i < 5; i++ {
+		if _, err := db.ExecContext(context.Background(), "UPDATE t SET n = ?", i); err != nil {
+			t.Fatalf("ExecContext() error = %v", err)
+		}
+	}
+
+	entries := log.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("entries = %d, want 3", len(entries))
+	}
+	for i, want := range []int64{5, 4, 3} {
+		if entries[i].ID != want {
+			t.Errorf("entries[%d].ID = %d, want %d (newest first)", i, entries[i].ID, want)
+		}
+	}
+	if entries[0].Name != "UPDATE" {
+		t.Errorf("unlabeled entry name = %q, want SQL operation", entries[0].Name)
+	}
+}
+
+func TestSlowQueryLog_Explain(t *testing.T) {
+	db, log := openSlowLogFake(t, config.DatabaseConfig{SlowQueryExplain: true, SlowQueryRedact: true})
+
+	rows, err := db.QueryContext(context.Background(), "SELECT n FROM t WHERE username = ?", "alice")
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	rows.Close()
+
+	// The plan is attached asynchronously
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if entries := log.Entries(); len(entries) == 1 && entries[0].Plan != "" {
+			if entries[0].Plan != "210" {
+				t.Errorf("plan = %q, want the fake driver's rows", entries[0].Plan)
+			}
+			if len(log.Entries()) != 1 {
+				t.Error("EXPLAIN statement was recorded as a slow query")
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("EXPLAIN plan was not attached")
+}
+
+func TestSlowQueryLog_NoExplainForWrites(t *testing.T) {
+	if explainable("DELETE FROM t") || explainable("CHECKPOINT") {
+		t.Error("writes and utility statements must not be explained")
+	}
+	if !explainable("WITH x AS (SELECT 1) SELECT * FROM x") || !explainable(" SELECT 1") {
+		t.Error("reads should be explained")
+	}
+}
+
+func TestSlowQueryLog_RecordsErrors(t *testing.T) {
+	log := NewSlowQueryLog(&config.DatabaseConfig{SlowQueryThreshold: time.Nanosecond})
+	log.observe(context.Background(), "SELECT 1", nil, time.Now().Add(-time.Second), 0, errors.New("timeout"))
+
+	got := log.Entries()[0]
+	if got.Error != "timeout" || got.DurationMS < 1000 {
+		t.Errorf("entry = %+v, want error and duration of at least 1s", got)
+	}
+}
+
+func TestSlowQueryLog_RecordsTimeouts(t *testing.T) {
+	log := NewSlowQueryLog(&config.DatabaseConfig{SlowQueryThreshold: time.Hour})
+
+	expired, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+	ctx := WithQueryLabel(expired, "AnalyticsTrends", nil)
+	log.observe(ctx, "SELECT 1", nil, time.Now(), 0, errors.New("INTERRUPT Error: Interrupted!"))
+
+	// A canceled request is not a timeout and stays under the threshold
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	log.observe(canceled, "SELECT 2", nil, time.Now(), 0, context.Canceled)
+
+	entries := log.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("entries = %d, want only the timed out statement", len(entries))
+	}
+	if got := entries[0]; !got.TimedOut || got.Name != "AnalyticsTrends" || got.Query != "SELECT 1" {
+		t.Errorf("entry = %+v, want timed out AnalyticsTrends statement", got)
+	}
+}
+
+func TestRedactPlan(t *testing.T) {
+	plan := redactPlan("FILTER username='alice' AND n > 5", []any{"alice", 5})
+	if strings.Contains(plan, "alice") {
+		t.Errorf("plan = %q, username not redacted", plan)
+	}
+}
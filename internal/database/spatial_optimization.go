@@ -19,6 +19,9 @@ func (db *DB) initializeSpatialOptimizations(serverLat, serverLon float64) error
 		return nil // Skip if spatial extension not available
 	}
 
+	ctx, cancel := schemaContext()
+	defer cancel()
+
 	// Migration queries for spatial optimization columns
 	spatialMigrations := []string{
 		// H3 hexagon indexes at multiple resolutions for hierarchical aggregation
@@ -41,7 +44,7 @@ func (db *DB) initializeSpatialOptimizations(serverLat, serverLon float64) error
 	}
 
 	for _, query := range spatialMigrations {
-		if _, err := db.conn.Exec(query); err != nil {
+		if _, err := db.conn.ExecContext(ctx, query); err != nil {
 			return fmt.Errorf("failed to execute spatial migration: %s: %w", query, err)
 		}
 	}
@@ -64,7 +67,7 @@ func (db *DB) initializeSpatialOptimizations(serverLat, serverLon float64) error
 	}
 
 	for _, query := range spatialIndexes {
-		if _, err := db.conn.Exec(query); err != nil {
+		if _, err := db.conn.ExecContext(ctx, query); err != nil {
 			// R-tree index may fail if geom column doesn't exist (test mode)
 			// Continue with other indexes
 			logging.Warn().Err(err).Msg("Failed to create spatial index (may not be supported)")
@@ -91,7 +94,7 @@ func (db *DB) initializeSpatialOptimizations(serverLat, serverLon float64) error
 		WHERE h3_index_6 IS NULL OR distance_from_server IS NULL;
 		`, serverLon, serverLat)
 
-		if _, err := db.conn.Exec(updateQuery); err != nil {
+		if _, err := db.conn.ExecContext(ctx, updateQuery); err != nil {
 			// This is not fatal - it means h3 functions aren't available
 			logging.Warn().Err(err).Msg("Failed to populate H3 indexes (H3 extension may not be available)")
 		}
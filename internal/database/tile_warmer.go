@@ -0,0 +1,125 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// tileWarmTimeout bounds one warming pass so a slow database cannot keep
+// the warmer busy past the next sync.
+const tileWarmTimeout = 2 * time.Minute
+
+// WarmTileCache generates every unfiltered vector tile from zoom 0 to
+// maxZoom (4^z tiles per level) and stores it in the tile cache, so the
+// first map loads after a start or sync are served from memory. Tiles that
+// are already cached and current are not regenerated. Tiles are generated
+// one at a time, each within an analytics slot, so warming never holds
+// more than one pool connection.
+//
+// It stops at the first error, including cancellation of ctx, and returns
+// the number of tiles warmed so far.
+func (db *DB) WarmTileCache(ctx context.Context, maxZoom int) (int, error) {
+	if !db.spatialAvailable {
+		return 0, fmt.Errorf("spatial extension required for vector tile generation")
+	}
+
+	warmed := 0
+	for z := 0; z <= maxZoom; z++ {
+		n := 1 << z
+		for x := 0; x < n; x++ {
+			for y := 0; y < n; y++ {
+				if err := db.warmTile(ctx, z, x, y); err != nil {
+					return warmed, fmt.Errorf("warm tile %d/%d/%d: %w", z, x, y, err)
+				}
+				warmed++
+			}
+		}
+	}
+	return warmed, nil
+}
+
+func (db *DB) warmTile(ctx context.Context, z, x, y int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	release, err := db.AcquireAnalyticsSlot(ctx)
+	defer release()
+	if err != nil {
+		return err
+	}
+	_, err = db.GenerateVectorTile(ctx, z, x, y, LocationStatsFilter{})
+	return err
+}
+
+// TileWarmer warms the vector tile cache (see WarmTileCache) once at
+// startup and again whenever Trigger is called, typically after a sync
+// bumps the data version. It implements suture.Service.
+//
+// Warming runs in the background and never delays startup; triggers that
+// arrive while a pass is running are coalesced into one more pass.
+type TileWarmer struct {
+	db      *DB
+	maxZoom int
+	timeout time.Duration // per pass
+	trigger chan struct{}
+}
+
+// NewTileWarmer creates a warmer for zoom levels 0 to maxZoom.
+func NewTileWarmer(db *DB, maxZoom int) *TileWarmer {
+	return &TileWarmer{
+		db:      db,
+		maxZoom: maxZoom,
+		timeout: tileWarmTimeout,
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+// Trigger requests a warming pass without blocking.
+func (w *TileWarmer) Trigger() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Serve warms once immediately and then on every trigger until ctx is canceled.
+func (w *TileWarmer) Serve(ctx context.Context) error {
+	w.warm(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.trigger:
+			w.warm(ctx)
+		}
+	}
+}
+
+// String implements fmt.Stringer for supervisor logging.
+func (w *TileWarmer) String() string {
+	return "tile-cache-warmer"
+}
+
+func (w *TileWarmer) warm(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	start := time.Now()
+	warmed, err := w.db.WarmTileCache(ctx, w.maxZoom)
+	if err != nil {
+		logging.Warn().Err(err).Int("tiles", warmed).Int("max_zoom", w.maxZoom).
+			Msg("Vector tile cache warming incomplete")
+		return
+	}
+	logging.Debug().Int("tiles", warmed).Int("max_zoom", w.maxZoom).
+		Dur("duration", time.Since(start)).Msg("Vector tile cache warmed")
+}
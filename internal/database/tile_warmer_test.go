@@ -0,0 +1,156 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTileWarmTestDB returns a DB whose tiles are rendered by a stub that
+// counts renders, so warming can be tested without the spatial extension.
+func newTileWarmTestDB(renders *atomic.Int32) *DB {
+	return &DB{
+		spatialAvailable: true,
+		tileCache:        make(map[string]CachedTile),
+		tileCacheTTL:     time.Minute,
+		renderTile: func(_ context.Context, z, x, y int, _ LocationStatsFilter) ([]byte, error) {
+			renders.Add(1)
+			return []byte(fmt.Sprintf("%d/%d/%d", z, x, y)), nil
+		},
+	}
+}
+
+func TestWarmTileCache(t *testing.T) {
+	var renders atomic.Int32
+	db := newTileWarmTestDB(&renders)
+	ctx := context.Background()
+
+	warmed, err := db.WarmTileCache(ctx, 2)
+	if err != nil {
+		t.Fatalf("WarmTileCache() error = %v", err)
+	}
+	// 1 + 4 + 16 tiles for zoom 0-2
+	if warmed != 21 {
+		t.Errorf("warmed = %d, want 21", warmed)
+	}
+	if got := renders.Load(); got != 21 {
+		t.Errorf("renders = %d, want 21", got)
+	}
+	if got := len(db.tileCache); got != 21 {
+		t.Errorf("tile cache size = %d, want 21", got)
+	}
+
+	// Warmed tiles are served from the cache without another render
+	for _, tc := range []struct{ z, x, y int }{{0, 0, 0}, {1, 1, 0}, {2, 3, 3}} {
+		data, err := db.GenerateVectorTile(ctx, tc.z, tc.x, tc.y, LocationStatsFilter{})
+		if err != nil {
+			t.Fatalf("GenerateVectorTile(%d/%d/%d) error = %v", tc.z, tc.x, tc.y, err)
+		}
+		if want := fmt.Sprintf("%d/%d/%d", tc.z, tc.x, tc.y); string(data) != want {
+			t.Errorf("GenerateVectorTile(%d/%d/%d) = %q, want %q", tc.z, tc.x, tc.y, data, want)
+		}
+	}
+	if got := renders.Load(); got != 21 {
+		t.Errorf("renders after serving warmed tiles = %d, want 21", got)
+	}
+
+	// A tile beyond the warmed zoom is still rendered on demand
+	if _, err := db.GenerateVectorTile(ctx, 3, 0, 0, LocationStatsFilter{}); err != nil {
+		t.Fatalf("GenerateVectorTile(3/0/0) error = %v", err)
+	}
+	if got := renders.Load(); got != 22 {
+		t.Errorf("renders after cold tile = %d, want 22", got)
+	}
+}
+
+func TestWarmTileCache_RerendersOnlyStaleTiles(t *testing.T) {
+	var renders atomic.Int32
+	db := newTileWarmTestDB(&renders)
+	ctx := context.Background()
+
+	if _, err := db.WarmTileCache(ctx, 1); err != nil {
+		t.Fatalf("WarmTileCache() error = %v", err)
+	}
+	if _, err := db.WarmTileCache(ctx, 1); err != nil {
+		t.Fatalf("second WarmTileCache() error = %v", err)
+	}
+	if got := renders.Load(); got != 5 {
+		t.Errorf("renders after warming a warm cache = %d, want 5", got)
+	}
+
+	// New data makes every cached tile stale
+	db.IncrementDataVersion()
+	if _, err := db.WarmTileCache(ctx, 1); err != nil {
+		t.Fatalf("WarmTileCache() after sync error = %v", err)
+	}
+	if got := renders.Load(); got != 10 {
+		t.Errorf("renders after data version bump = %d, want 10", got)
+	}
+}
+
+func TestWarmTileCache_StopsWhenCanceled(t *testing.T) {
+	var renders atomic.Int32
+	db := newTileWarmTestDB(&renders)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	warmed, err := db.WarmTileCache(ctx, 3)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WarmTileCache() error = %v, want context.Canceled", err)
+	}
+	if warmed != 0 || renders.Load() != 0 {
+		t.Errorf("warmed = %d, renders = %d after cancellation, want 0", warmed, renders.Load())
+	}
+}
+
+func TestWarmTileCache_NoSpatialExtension(t *testing.T) {
+	db := &DB{spatialAvailable: false}
+
+	if _, err := db.WarmTileCache(context.Background(), 0); err == nil {
+		t.Error("Expected error when spatial extension not available")
+	}
+}
+
+func TestTileWarmer_WarmsAtStartAndOnTrigger(t *testing.T) {
+	var renders atomic.Int32
+	db := newTileWarmTestDB(&renders)
+	warmer := NewTileWarmer(db, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- warmer.Serve(ctx) }()
+
+	waitForRenders := func(want int32) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for renders.Load() < want {
+			if time.Now().After(deadline) {
+				t.Fatalf("renders = %d, want %d", renders.Load(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	waitForRenders(5)
+
+	db.IncrementDataVersion()
+	warmer.Trigger()
+	waitForRenders(10)
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Serve() error = %v, want context.Canceled", err)
+	}
+	if got := renders.Load(); got != 10 {
+		t.Errorf("renders = %d, want 10", got)
+	}
+}
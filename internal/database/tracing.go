@@ -0,0 +1,76 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tomtom215/cartographus/internal/tracing"
+)
+
+// Row count attributes. OpenTelemetry has no semantic convention for the
+// rows affected by INSERT/UPDATE/DELETE statements yet.
+const (
+	attrRowsReturned = semconv.DBResponseReturnedRowsKey
+	attrRowsAffected = attribute.Key("db.response.affected_rows")
+)
+
+// startQuerySpan starts a client span for a statement. Statements outside a
+// traced operation (background jobs, health checks) are not traced so they
+// do not flood the backend with root spans. The SQL text is never recorded
+// because it can contain user data.
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	operation := sqlOperation(query)
+	return tracing.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameKey.String("duckdb"),
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
+// sqlOperation returns the leading SQL keyword of query in upper case,
+// skipping whitespace, comments, and opening parentheses.
+func sqlOperation(query string) string {
+	q := query
+	for {
+		q = strings.TrimLeft(q, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(q, "--"):
+			end := strings.IndexByte(q, '\n')
+			if end < 0 {
+				return "QUERY"
+			}
+			q = q[end+1:]
+		case strings.HasPrefix(q, "/*"):
+			end := strings.Index(q, "*/")
+			if end < 0 {
+				return "QUERY"
+			}
+			q = q[end+2:]
+		default:
+			end := strings.IndexFunc(q, func(r rune) bool {
+				return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+			})
+			if end < 0 {
+				end = len(q)
+			}
+			if end == 0 {
+				return "QUERY"
+			}
+			return strings.ToUpper(q[:end])
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/tomtom215/cartographus/internal/tracing"
+)
+
+// fakeTracedDriver is a minimal driver that returns three rows for every
+// query and reports two affected rows for every exec.
+type fakeTracedDriver struct{}
+
+func (fakeTracedDriver) Open(string) (driver.Conn, error) { return &fakeTracedConn{}, nil }
+
+type fakeTracedConnector struct{ closed bool }
+
+func (c *fakeTracedConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeTracedConn{}, nil
+}
+func (c *fakeTracedConnector) Driver() driver.Driver { return fakeTracedDriver{} }
+func (c *fakeTracedConnector) Close() error          { c.closed = true; return nil }
+
+type fakeTracedConn struct{}
+
+func (c *fakeTracedConn) Prepare(string) (driver.Stmt, error)      { return &fakeTracedStmt{}, nil }
+func (c *fakeTracedConn) Close() error                             { return nil }
+func (c *fakeTracedConn) Begin() (driver.Tx, error)                { return c, nil }
+func (c *fakeTracedConn) Commit() error                            { return nil }
+func (c *fakeTracedConn) Rollback() error                          { return nil }
+func (c *fakeTracedConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+func (c *fakeTracedConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return c, nil
+}
+func (c *fakeTracedConn) PrepareContext(context.Context, string) (driver.Stmt, error) {
+	return &fakeTracedStmt{}, nil
+}
+func (c *fakeTracedConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(2), nil
+}
+func (c *fakeTracedConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeTracedRows{remaining: 3}, nil
+}
+
+type fakeTracedStmt struct{}
+
+func (s *fakeTracedStmt) Close() error  { return nil }
+func (s *fakeTracedStmt) NumInput() int { return -1 }
+func (s *fakeTracedStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(2), nil
+}
+func (s *fakeTracedStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeTracedRows{remaining: 3}, nil
+}
+func (s *fakeTracedStmt) ExecContext(context.Context, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(2), nil
+}
+func (s *fakeTracedStmt) QueryContext(context.Context, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeTracedRows{remaining: 3}, nil
+}
+
+type fakeTracedRows struct{ remaining int }
+
+func (r *fakeTracedRows) Columns() []string { return []string{"n"} }
+func (r *fakeTracedRows) Close() error      { return nil }
+func (r *fakeTracedRows) Next(dest []driver.Value) error {
+	if r.remaining == 0 {
+		return io.EOF
+	}
+	r.remaining--
+	dest[0] = int64(r.remaining)
+	return nil
+}
+
+// openTracedFake returns a sql.DB over the fake driver with tracing enabled.
+func openTracedFake(t *testing.T) (*sql.DB, *fakeTracedConnector, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { tracing.SetTracerProvider(nil) })
+
+	connector := &fakeTracedConnector{}
+	return sql.OpenDB(&instrumentedConnector{Connector: connector}), connector, recorder
+}
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracedConn_QuerySpan(t *testing.T) {
+	db, _, recorder := openTracedFake(t)
+	defer db.Close()
+
+	ctx, parent := tracing.Start(context.Background(), "request")
+	rows, err := db.QueryContext(ctx, "SELECT n FROM playback_events WHERE user = 'alice'")
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	rows.Close()
+	parent.End()
+
+	if count != 3 {
+		t.Fatalf("scanned %d rows, want 3", count)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want query and request", len(spans))
+	}
+	query := spans[0]
+	if query.Name() != "SELECT" {
+		t.Errorf("span name = %q, want SELECT", query.Name())
+	}
+	if query.Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Error("query span is not a child of the request span")
+	}
+	if v, ok := spanAttr(query, "db.response.returned_rows"); !ok || v.AsInt64() != 3 {
+		t.Errorf("returned rows = %v, want 3", v.Emit())
+	}
+	if v, _ := spanAttr(query, "db.system.name"); v.AsString() != "duckdb" {
+		t.Errorf("db.system.name = %q, want duckdb", v.AsString())
+	}
+	for _, kv := range query.Attributes() {
+		if kv.Value.Type() == attribute.STRING && kv.Value.AsString() != "SELECT" && kv.Value.AsString() != "duckdb" {
+			t.Errorf("unexpected string attribute %s=%q; SQL text must not be recorded", kv.Key, kv.Value.AsString())
+		}
+	}
+}
+
+func TestTracedConn_ExecSpan(t *testing.T) {
+	db, _, recorder := openTracedFake(t)
+	defer db.Close()
+
+	ctx, parent := tracing.Start(context.Background(), "request")
+	if _, err := db.ExecContext(ctx, "  -- purge\n DELETE FROM sessions"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 || spans[0].Name() != "DELETE" {
+		t.Fatalf("spans = %d, first = %q; want DELETE and request", len(spans), spans[0].Name())
+	}
+	if v, ok := spanAttr(spans[0], attrRowsAffected); !ok || v.AsInt64() != 2 {
+		t.Errorf("affected rows = %v, want 2", v.Emit())
+	}
+}
+
+func TestTracedConn_PreparedStatementSpan(t *testing.T) {
+	db, _, recorder := openTracedFake(t)
+	defer db.Close()
+
+	ctx, parent := tracing.Start(context.Background(), "request")
+	stmt, err := db.PrepareContext(ctx, "INSERT INTO events VALUES (?)")
+	if err != nil {
+		t.Fatalf("PrepareContext() error = %v", err)
+	}
+	if _, err := stmt.ExecContext(ctx, 1); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	stmt.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 || spans[0].Name() != "INSERT" {
+		t.Fatalf("got %d spans; want INSERT and request", len(spans))
+	}
+}
+
+func TestTracedConn_NoRootSpans(t *testing.T) {
+	db, _, recorder := openTracedFake(t)
+	defer db.Close()
+
+	// Statements without a traced parent (background jobs) are not traced
+	if _, err := db.ExecContext(context.Background(), "CHECKPOINT"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	rows, err := db.QueryContext(context.Background(), "SELECT 1")
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	rows.Close()
+
+	if spans := recorder.Ended(); len(spans) != 0 {
+		t.Errorf("got %d spans for untraced statements, want 0", len(spans))
+	}
+}
+
+func TestTracedConnector_CloseForwarded(t *testing.T) {
+	db, connector, _ := openTracedFake(t)
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !connector.closed {
+		t.Error("closing sql.DB did not close the wrapped connector")
+	}
+}
+
+func TestSQLOperation(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT * FROM t", "SELECT"},
+		{"select 1", "SELECT"},
+		{"\n\tinsert into t values (1)", "INSERT"},
+		{"WITH x AS (SELECT 1) SELECT * FROM x", "WITH"},
+		{"(SELECT 1) UNION (SELECT 2)", "SELECT"},
+		{"-- comment\nUPDATE t SET a = 1", "UPDATE"},
+		{"/* hint */ DELETE FROM t", "DELETE"},
+		{"CHECKPOINT;", "CHECKPOINT"},
+		{"", "QUERY"},
+		{"-- only a comment", "QUERY"},
+		{"123", "QUERY"},
+	}
+
+	for _, tt := range tests {
+		if got := sqlOperation(tt.query); got != tt.want {
+			t.Errorf("sqlOperation(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,554 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// UserErasureMode selects what EraseUserData does with a user's playback
+// history and alerts.
+type UserErasureMode string
+
+const (
+	// UserErasureDelete removes the user's rows outright (default)
+	UserErasureDelete UserErasureMode = "delete"
+
+	// UserErasureAnonymize keeps the rows for aggregate statistics but moves
+	// them to ErasedUserID and strips the personal columns
+	UserErasureAnonymize UserErasureMode = "anonymize"
+)
+
+const (
+	// ErasedUserID owns anonymized rows. Zero is taken by Tautulli's "Local" user.
+	ErasedUserID = -1
+
+	// ErasedUsername replaces usernames, audit actors and audit targets of
+	// erased users
+	ErasedUsername = "erased-user"
+)
+
+// ErrUserNotFound is returned when no playback event or user mapping
+// references the requested internal user ID.
+var ErrUserNotFound = errors.New("user not found")
+
+// ParseUserErasureMode validates a mode name; an empty name means delete.
+func ParseUserErasureMode(s string) (UserErasureMode, error) {
+	switch UserErasureMode(s) {
+	case "", UserErasureDelete:
+		return UserErasureDelete, nil
+	case UserErasureAnonymize:
+		return UserErasureAnonymize, nil
+	}
+	return "", fmt.Errorf("invalid erasure mode %q (use delete or anonymize)", s)
+}
+
+// UserErasureTable reports the rows one table lost or had scrubbed.
+type UserErasureTable struct {
+	Table  string `json:"table"`
+	Action string `json:"action"` // deleted or anonymized
+	Rows   int64  `json:"rows"`
+}
+
+// UserErasureResult is the outcome of EraseUserData.
+type UserErasureResult struct {
+	UserID int             `json:"user_id"`
+	Mode   UserErasureMode `json:"mode"`
+
+	// UserIDs holds UserID and every internal ID linked to it in user_links
+	UserIDs []int              `json:"user_ids"`
+	Tables  []UserErasureTable `json:"tables"`
+
+	// AffectedYears are the years the user had playback in; other users'
+	// wrapped reports for them include the erased history in their percentiles
+	AffectedYears []int `json:"affected_years,omitempty"`
+}
+
+// userErasureStep is one statement of an erasure. Steps whose table does
+// not exist are skipped.
+type userErasureStep struct {
+	table  string
+	action string
+	query  string
+	args   []interface{}
+}
+
+// EraseUserData deletes or anonymizes everything stored about an internal
+// user and the users linked to it, in one transaction:
+//
+//   - playback_events, detection_alerts and dedupe_audit_log rows are
+//     deleted, or in anonymize mode moved to ErasedUserID with usernames,
+//     IP addresses, devices and raw payloads removed
+//   - trust scores and their history, allowlist entries, recommendation
+//     feedback, wrapped reports, quarantined events, user links and user
+//     mappings are always deleted
+//   - audit events keep their place in the trail, but actors and targets
+//     naming the user are replaced by ErasedUsername
+//
+// It returns ErrUserNotFound when nothing references userID. Callers
+// should clear their caches afterwards and may pass AffectedYears to
+// RebuildWrappedReports.
+func (db *DB) EraseUserData(ctx context.Context, userID int, mode UserErasureMode) (*UserErasureResult, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	ids, err := db.resolveUserDataIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	names, err := db.userIdentifiers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	years, err := db.userPlaybackYears(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := userErasureSteps(ids, names, mode)
+	result := &UserErasureResult{UserID: userID, Mode: mode, UserIDs: ids, AffectedYears: years}
+
+	// Tables of optional subsystems (detection, audit, cross-platform) may
+	// not exist; look them up before the transaction starts
+	exists := make(map[string]bool)
+	for _, step := range steps {
+		if _, seen := exists[step.table]; seen {
+			continue
+		}
+		ok, err := db.tableExists(ctx, step.table)
+		if err != nil {
+			return nil, err
+		}
+		exists[step.table] = ok
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	index := make(map[string]int)
+	for _, step := range steps {
+		if !exists[step.table] {
+			continue
+		}
+		res, err := tx.ExecContext(ctx, step.query, step.args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase user from %s: %w", step.table, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to count erased %s rows: %w", step.table, err)
+		}
+
+		// Tables touched by several statements are reported once
+		i, ok := index[step.table]
+		if !ok {
+			i = len(result.Tables)
+			index[step.table] = i
+			result.Tables = append(result.Tables, UserErasureTable{Table: step.table, Action: step.action})
+		}
+		result.Tables[i].Rows += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit erasure: %w", err)
+	}
+
+	db.IncrementDataVersion()
+
+	logging.Info().
+		Int("user_id", userID).
+		Ints("user_ids", ids).
+		Str("mode", string(mode)).
+		Msg("Erased user data")
+
+	return result, nil
+}
+
+// userErasureSteps builds the statements EraseUserData runs, in order.
+// ids are internal user IDs; names are the external IDs and usernames
+// audit events may name the user by.
+func userErasureSteps(ids []int, names []string, mode UserErasureMode) []userErasureStep {
+	in, idArgs := buildIntInClause(ids)
+	step := func(table, action, query string, args ...interface{}) userErasureStep {
+		return userErasureStep{table: table, action: action, query: query, args: args}
+	}
+	withIDs := func(args ...interface{}) []interface{} {
+		return append(args, idArgs...)
+	}
+
+	var steps []userErasureStep
+	if mode == UserErasureAnonymize {
+		// Usernames may be quoted in alert titles and messages
+		title, message := "title", "message"
+		var titleArgs, messageArgs []interface{}
+		for _, name := range names {
+			title = "replace(" + title + ", ?, ?)"
+			message = "replace(" + message + ", ?, ?)"
+			titleArgs = append(titleArgs, name, ErasedUsername)
+			messageArgs = append(messageArgs, name, ErasedUsername)
+		}
+		alertArgs := append(append(titleArgs, messageArgs...), ErasedUserID, ErasedUsername)
+
+		steps = append(steps,
+			step("playback_events", "anonymized", fmt.Sprintf(`
+				UPDATE playback_events
+				SET user_id = ?, username = ?, ip_address = '', ip_address_public = NULL,
+					friendly_name = NULL, user_thumb = NULL, email = NULL,
+					player = NULL, device = NULL, machine_id = NULL, correlation_key = NULL
+				WHERE user_id IN (%s)`, in), withIDs(ErasedUserID, ErasedUsername)...),
+			step("detection_alerts", "anonymized", fmt.Sprintf(`
+				UPDATE detection_alerts
+				SET title = %s, message = %s, user_id = ?, username = ?,
+					ip_address = NULL, machine_id = NULL, metadata = NULL
+				WHERE user_id IN (%s)`, title, message, in), withIDs(alertArgs...)...),
+			step("dedupe_audit_log", "anonymized", fmt.Sprintf(`
+				UPDATE dedupe_audit_log
+				SET user_id = ?, username = ?, discarded_raw_payload = NULL
+				WHERE user_id IN (%s)`, in), withIDs(ErasedUserID, ErasedUsername)...),
+		)
+	} else {
+		steps = append(steps,
+			step("playback_events", "deleted", fmt.Sprintf(`DELETE FROM playback_events WHERE user_id IN (%s)`, in), idArgs...),
+			step("detection_alerts", "deleted", fmt.Sprintf(`DELETE FROM detection_alerts WHERE user_id IN (%s)`, in), idArgs...),
+			step("dedupe_audit_log", "deleted", fmt.Sprintf(`DELETE FROM dedupe_audit_log WHERE user_id IN (%s)`, in), idArgs...),
+		)
+	}
+
+	steps = append(steps,
+		step("user_trust_scores", "deleted", fmt.Sprintf(`DELETE FROM user_trust_scores WHERE user_id IN (%s)`, in), idArgs...),
+		step("trust_score_history", "deleted", fmt.Sprintf(`DELETE FROM trust_score_history WHERE user_id IN (%s)`, in), idArgs...),
+		// user_id 0 in the allowlist means every user, not Tautulli's Local user
+		step("detection_allowlist", "deleted", fmt.Sprintf(`DELETE FROM detection_allowlist WHERE user_id IN (%s) AND user_id <> 0`, in), idArgs...),
+		step("recommendation_feedback", "deleted", fmt.Sprintf(`DELETE FROM recommendation_feedback WHERE user_id IN (%s)`, in), idArgs...),
+		step("recommendation_exposures", "deleted", fmt.Sprintf(`DELETE FROM recommendation_exposures WHERE user_id IN (%s)`, in), idArgs...),
+		step("user_devices", "deleted", fmt.Sprintf(`DELETE FROM user_devices WHERE user_id IN (%s)`, in), idArgs...),
+		step("wrapped_reports", "deleted", fmt.Sprintf(`DELETE FROM wrapped_reports WHERE user_id IN (%s)`, in), idArgs...),
+		step("quarantined_events", "deleted", fmt.Sprintf(`DELETE FROM quarantined_events WHERE user_id IN (%s)`, in), idArgs...),
+		step("user_links", "deleted", fmt.Sprintf(`DELETE FROM user_links WHERE primary_user_id IN (%s) OR linked_user_id IN (%s)`, in, in),
+			withIDs(idArgs...)...),
+		step("user_mappings", "deleted", fmt.Sprintf(`DELETE FROM user_mappings WHERE internal_user_id IN (%s)`, in), idArgs...),
+	)
+
+	return append(steps, auditErasureStep(ids, names))
+}
+
+// auditErasureStep replaces the actors and targets naming the user in the
+// audit trail. Trust score changes target the internal ID; everything else
+// names the user by external ID or username.
+func auditErasureStep(ids []int, names []string) userErasureStep {
+	internal := make([]string, len(ids))
+	for i, id := range ids {
+		internal[i] = strconv.Itoa(id)
+	}
+	internalIn, internalArgs := buildInClause(internal)
+
+	actor, target := "false", fmt.Sprintf("(target_type = 'user' AND target_id IN (%s))", internalIn)
+	var actorArgs []interface{}
+	targetArgs := internalArgs
+	if len(names) > 0 {
+		nameIn, nameArgs := buildInClause(names)
+		actor = fmt.Sprintf("(actor_id IN (%s) OR actor_name IN (%s))", nameIn, nameIn)
+		actorArgs = append(append(actorArgs, nameArgs...), nameArgs...)
+		target = fmt.Sprintf("(%s OR target_id IN (%s) OR target_name IN (%s))", target, nameIn, nameIn)
+		targetArgs = append(append(append([]interface{}{}, internalArgs...), nameArgs...), nameArgs...)
+	}
+
+	// Placeholders appear in this order: actor_id, actor_name,
+	// actor_session_id, target_id, target_name, then the WHERE clause
+	var args []interface{}
+	args = append(append(args, actorArgs...), ErasedUsername)
+	args = append(args, actorArgs...)
+	args = append(args, actorArgs...)
+	args = append(append(args, targetArgs...), ErasedUsername)
+	args = append(args, targetArgs...)
+	args = append(append(args, actorArgs...), targetArgs...)
+
+	//nolint:gosec // actor and target hold only placeholders
+	query := fmt.Sprintf(`
+		UPDATE audit_events SET
+			actor_id = CASE WHEN %[1]s THEN ? ELSE actor_id END,
+			actor_name = CASE WHEN %[1]s THEN NULL ELSE actor_name END,
+			actor_session_id = CASE WHEN %[1]s THEN NULL ELSE actor_session_id END,
+			target_id = CASE WHEN %[2]s THEN ? ELSE target_id END,
+			target_name = CASE WHEN %[2]s THEN NULL ELSE target_name END
+		WHERE %[1]s OR %[2]s`, actor, target)
+
+	return userErasureStep{table: "audit_events", action: "anonymized", query: query, args: args}
+}
+
+// RebuildWrappedReports regenerates the stored wrapped reports of the given
+// years so their server-wide percentiles stop counting erased history.
+// Reports that can no longer be generated are dropped. It returns how many
+// reports were regenerated.
+func (db *DB) RebuildWrappedReports(ctx context.Context, years []int) (int, error) {
+	if len(years) == 0 {
+		return 0, nil
+	}
+
+	in, args := buildIntInClause(years)
+	//nolint:gosec // in holds only placeholders
+	rows, err := db.conn.QueryContext(ctx, fmt.Sprintf(`
+		SELECT user_id, year FROM wrapped_reports WHERE year IN (%s) ORDER BY year, user_id`, in), args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list wrapped reports: %w", err)
+	}
+	type report struct{ userID, year int }
+	var reports []report
+	for rows.Next() {
+		var r report
+		if err := rows.Scan(&r.userID, &r.year); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan wrapped report: %w", err)
+		}
+		reports = append(reports, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to list wrapped reports: %w", err)
+	}
+
+	rebuilt := 0
+	for _, r := range reports {
+		if _, err := db.GenerateWrappedReport(ctx, r.userID, r.year); err != nil {
+			logging.Warn().Err(err).Int("user_id", r.userID).Int("year", r.year).
+				Msg("Dropping wrapped report that could not be rebuilt")
+			if _, err := db.conn.ExecContext(ctx, `DELETE FROM wrapped_reports WHERE user_id = ? AND year = ?`,
+				r.userID, r.year); err != nil {
+				return rebuilt, fmt.Errorf("failed to drop wrapped report: %w", err)
+			}
+			continue
+		}
+		rebuilt++
+	}
+	return rebuilt, nil
+}
+
+// userDataExportFiles lists the NDJSON files of a user data export and the
+// queries producing their lines. %s is replaced by the user ID placeholders.
+var userDataExportFiles = []struct {
+	name  string
+	table string
+	query string
+}{
+	{"user_mappings.ndjson", "user_mappings", `
+		SELECT to_json(m)::VARCHAR FROM (
+			SELECT source, server_id, external_user_id, internal_user_id, username,
+				friendly_name, email, user_thumb, created_at, updated_at
+			FROM user_mappings WHERE internal_user_id IN (%s) ORDER BY source, server_id
+		) m`},
+	{"playback_history.ndjson", "playback_events", `
+		SELECT to_json(p)::VARCHAR FROM (
+			SELECT * FROM playback_events WHERE user_id IN (%s) ORDER BY started_at, id
+		) p`},
+	{"locations.ndjson", "geolocations", `
+		SELECT to_json(l)::VARCHAR FROM (
+			SELECT g.ip_address, g.latitude, g.longitude, g.city, g.region, g.country,
+				g.postal_code, g.timezone, COUNT(*) AS playbacks,
+				MIN(p.started_at) AS first_seen, MAX(p.started_at) AS last_seen
+			FROM playback_events p
+			JOIN geolocations g ON g.ip_address = p.ip_address
+			WHERE p.user_id IN (%s)
+			GROUP BY ALL
+			ORDER BY first_seen
+		) l`},
+	{"alerts.ndjson", "detection_alerts", `
+		SELECT to_json(a)::VARCHAR FROM (
+			SELECT * FROM detection_alerts WHERE user_id IN (%s) ORDER BY created_at, id
+		) a`},
+}
+
+// UserDataExportInfo describes an export; it is written to the archive as
+// export.json.
+type UserDataExportInfo struct {
+	UserID     int            `json:"user_id"`
+	UserIDs    []int          `json:"user_ids"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Files      map[string]int `json:"files"` // file name -> lines
+}
+
+// ResolveUserDataIDs returns userID and the internal IDs linked to it, or
+// ErrUserNotFound when no playback event or mapping references userID.
+// Call it before WriteUserDataExport to reject unknown users while a
+// response can still change its status.
+func (db *DB) ResolveUserDataIDs(ctx context.Context, userID int) ([]int, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+	return db.resolveUserDataIDs(ctx, userID)
+}
+
+// WriteUserDataExport writes a zip archive of everything stored about the
+// users in ids (see ResolveUserDataIDs): their mappings, playback history,
+// the locations they played from and their detection alerts, one JSON
+// object per line, plus export.json describing the archive.
+func (db *DB) WriteUserDataExport(ctx context.Context, userID int, ids []int, w io.Writer) (*UserDataExportInfo, error) {
+	info := &UserDataExportInfo{
+		UserID:     userID,
+		UserIDs:    ids,
+		ExportedAt: time.Now().UTC(),
+		Files:      make(map[string]int),
+	}
+	in, args := buildIntInClause(ids)
+
+	zw := zip.NewWriter(w)
+	for _, file := range userDataExportFiles {
+		exists, err := db.tableExists(ctx, file.table)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: info.ExportedAt})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", file.name, err)
+		}
+		n, err := db.writeNDJSON(ctx, fw, fmt.Sprintf(file.query, in), args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", file.name, err)
+		}
+		info.Files[file.name] = n
+	}
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: "export.json", Method: zip.Deflate, Modified: info.ExportedAt})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add export.json: %w", err)
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(info); err != nil {
+		return nil, fmt.Errorf("failed to write export.json: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return info, nil
+}
+
+// writeNDJSON writes each single-column JSON row of query as one line.
+func (db *DB) writeNDJSON(ctx context.Context, w io.Writer, query string, args []interface{}) (int, error) {
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return n, err
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// resolveUserDataIDs returns userID plus its linked users.
+func (db *DB) resolveUserDataIDs(ctx context.Context, userID int) ([]int, error) {
+	var found bool
+	err := db.conn.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM playback_events WHERE user_id = ?)
+			OR EXISTS (SELECT 1 FROM user_mappings WHERE internal_user_id = ?)`,
+		userID, userID).Scan(&found)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user %d: %w", userID, err)
+	}
+	if !found {
+		return nil, ErrUserNotFound
+	}
+
+	linked, err := db.tableExists(ctx, "user_links")
+	if err != nil {
+		return nil, err
+	}
+	if !linked {
+		return []int{userID}, nil
+	}
+	return db.GetAllLinkedUserIDs(ctx, userID)
+}
+
+// userIdentifiers returns the external IDs and usernames the users in ids
+// are known by.
+func (db *DB) userIdentifiers(ctx context.Context, ids []int) ([]string, error) {
+	in, args := buildIntInClause(ids)
+	//nolint:gosec // in holds only placeholders
+	rows, err := db.conn.QueryContext(ctx, fmt.Sprintf(`
+		SELECT DISTINCT name FROM (
+			SELECT external_user_id AS name FROM user_mappings WHERE internal_user_id IN (%s)
+			UNION ALL
+			SELECT username FROM user_mappings WHERE internal_user_id IN (%s)
+			UNION ALL
+			SELECT DISTINCT username FROM playback_events WHERE user_id IN (%s)
+		) WHERE name IS NOT NULL AND name <> ''
+		ORDER BY name`, in, in, in), append(append(append([]interface{}{}, args...), args...), args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan user name: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// userPlaybackYears returns the years the users in ids played anything.
+func (db *DB) userPlaybackYears(ctx context.Context, ids []int) ([]int, error) {
+	in, args := buildIntInClause(ids)
+	//nolint:gosec // in holds only placeholders
+	rows, err := db.conn.QueryContext(ctx, fmt.Sprintf(`
+		SELECT DISTINCT CAST(year(started_at) AS INTEGER) AS y
+		FROM playback_events WHERE user_id IN (%s) ORDER BY y`, in), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up playback years: %w", err)
+	}
+	defer rows.Close()
+
+	var years []int
+	for rows.Next() {
+		var y sql.NullInt64
+		if err := rows.Scan(&y); err != nil {
+			return nil, fmt.Errorf("failed to scan playback year: %w", err)
+		}
+		if y.Valid {
+			years = append(years, int(y.Int64))
+		}
+	}
+	return years, rows.Err()
+}
+
+// buildIntInClause is buildInClause for integer IDs.
+func buildIntInClause(ids []int) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args
+}
@@ -0,0 +1,294 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// setupUserDataTestDB returns the test playbacks plus, for user1 (linked to
+// user2), a mapping, trust score, alert, recommendation feedback and audit
+// events. The detection and audit tables are normally created by their
+// packages; minimal versions stand in for them here.
+func setupUserDataTestDB(t *testing.T) *DB {
+	t.Helper()
+	db := setupTestDB(t)
+	insertTestGeolocations(t, db)
+	insertTestPlaybacks(t, db)
+
+	for _, stmt := range []string{
+		`CREATE TABLE detection_alerts (id INTEGER PRIMARY KEY, rule_type TEXT, user_id INTEGER NOT NULL,
+			username TEXT, machine_id TEXT, ip_address TEXT, title TEXT NOT NULL, message TEXT NOT NULL,
+			metadata JSON, created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP)`,
+		`CREATE TABLE user_trust_scores (user_id INTEGER PRIMARY KEY, username TEXT, score INTEGER)`,
+		`CREATE TABLE audit_events (id TEXT PRIMARY KEY, actor_id TEXT NOT NULL, actor_name TEXT,
+			actor_session_id TEXT, target_id TEXT, target_type TEXT, target_name TEXT, action TEXT)`,
+
+		`INSERT INTO user_mappings (id, source, server_id, external_user_id, internal_user_id, username, email)
+			VALUES (1, 'plex', 'srv', 'plex-42', 1, 'user1', 'user1@example.com')`,
+		`INSERT INTO user_links (id, primary_user_id, linked_user_id, link_type) VALUES (1, 1, 2, 'manual')`,
+		`INSERT INTO detection_alerts (id, rule_type, user_id, username, ip_address, title, message, metadata) VALUES
+			(1, 'impossible_travel', 1, 'user1', '192.168.1.1', 'Impossible travel', 'user1 moved 5000 km', '{"from":"New York"}'),
+			(2, 'impossible_travel', 3, 'user3', '192.168.1.3', 'Impossible travel', 'user3 moved 900 km', NULL)`,
+		`INSERT INTO user_trust_scores VALUES (1, 'user1', 80), (3, 'user3', 100)`,
+		`INSERT INTO recommendation_feedback (user_id, item_id, signal) VALUES (1, 10, 'like'), (3, 10, 'dislike')`,
+		`INSERT INTO recommendation_exposures (experiment, variant, user_id, item_id, position, model_version, shown_at) VALUES
+			('exp', 'a', 2, 10, 1, 1, now()), ('exp', 'b', 3, 10, 1, 1, now())`,
+		`INSERT INTO user_devices (user_id, device_id, first_seen_at, last_seen_at, session_count) VALUES
+			(1, 'erasure-tv', now(), now(), 1), (3, 'erasure-phone', now(), now(), 1)
+			ON CONFLICT DO NOTHING`,
+		`INSERT INTO audit_events VALUES
+			('a1', 'plex-42', 'user1', 'sess-1', NULL, NULL, NULL, 'login'),
+			('a2', 'admin', 'admin', NULL, '1', 'user', NULL, 'trust_score.reset'),
+			('a3', 'admin', 'admin', NULL, '3', 'user', NULL, 'trust_score.reset')`,
+	} {
+		_, err := db.conn.Exec(stmt)
+		checkNoError(t, err)
+	}
+	return db
+}
+
+func erasureRows(result *UserErasureResult) map[string]int64 {
+	rows := make(map[string]int64)
+	for _, t := range result.Tables {
+		rows[t.Table] = t.Rows
+	}
+	return rows
+}
+
+func TestEraseUserData_Delete(t *testing.T) {
+	db := setupUserDataTestDB(t)
+	ctx := context.Background()
+
+	result, err := db.EraseUserData(ctx, 1, UserErasureDelete)
+	checkNoError(t, err)
+
+	if len(result.UserIDs) != 2 || result.UserIDs[0] != 1 || result.UserIDs[1] != 2 {
+		t.Errorf("UserIDs = %v, want [1 2] (user2 is linked)", result.UserIDs)
+	}
+	if len(result.AffectedYears) == 0 {
+		t.Error("AffectedYears is empty, want the years of the erased playbacks")
+	}
+
+	want := map[string]int64{
+		"playback_events":          5,
+		"detection_alerts":         1,
+		"user_trust_scores":        1,
+		"recommendation_feedback":  1,
+		"recommendation_exposures": 1,
+		"user_links":               1,
+		"user_mappings":            1,
+		"audit_events":             2,
+	}
+	got := erasureRows(result)
+	for table, n := range want {
+		if got[table] != n {
+			t.Errorf("%s rows = %d, want %d", table, got[table], n)
+		}
+	}
+	if _, ok := got["trust_score_history"]; ok {
+		t.Error("trust_score_history reported although the table does not exist")
+	}
+
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events WHERE user_id IN (1, 2)`); n != 0 {
+		t.Errorf("%d playback events of users 1 and 2 left", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events`); n != 4 {
+		t.Errorf("%d playback events left, want the other users' 4", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM detection_alerts`); n != 1 {
+		t.Errorf("%d alerts left, want user3's", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM user_devices WHERE user_id IN (1, 2)`); n != 0 {
+		t.Errorf("%d devices of users 1 and 2 left", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM user_devices WHERE device_id = 'erasure-phone'`); n != 1 {
+		t.Error("user3's device was deleted")
+	}
+
+	// The audit trail keeps every event but no longer names the user
+	if n := countRows(t, db, `SELECT COUNT(*) FROM audit_events`); n != 3 {
+		t.Errorf("%d audit events left, want 3", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM audit_events
+		WHERE actor_id = 'plex-42' OR actor_name = 'user1' OR actor_session_id IS NOT NULL OR target_id = '1'`); n != 0 {
+		t.Errorf("%d audit events still reference user1", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM audit_events WHERE actor_id = 'admin'`); n != 2 {
+		t.Errorf("%d audit events by admin, want 2 (the admin actor is untouched)", n)
+	}
+}
+
+func TestEraseUserData_Anonymize(t *testing.T) {
+	db := setupUserDataTestDB(t)
+	ctx := context.Background()
+
+	result, err := db.EraseUserData(ctx, 1, UserErasureAnonymize)
+	checkNoError(t, err)
+
+	got := erasureRows(result)
+	if got["playback_events"] != 5 || got["detection_alerts"] != 1 {
+		t.Errorf("rows = %v, want 5 playback events and 1 alert anonymized", got)
+	}
+	for _, table := range result.Tables {
+		if table.Table == "playback_events" && table.Action != "anonymized" {
+			t.Errorf("playback_events action = %q, want anonymized", table.Action)
+		}
+	}
+
+	// History still counts towards totals, under the erased user
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events
+		WHERE user_id = ? AND username = ? AND ip_address = '' AND correlation_key IS NULL`,
+		ErasedUserID, ErasedUsername); n != 5 {
+		t.Errorf("%d anonymized playback events, want 5", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM playback_events`); n != 9 {
+		t.Errorf("%d playback events, want all 9 kept", n)
+	}
+	// The device inventory identifies the user, so it is deleted either way
+	if n := countRows(t, db, `SELECT COUNT(*) FROM user_devices WHERE user_id IN (1, 2)`); n != 0 {
+		t.Errorf("%d devices of users 1 and 2 left", n)
+	}
+
+	var message string
+	err = db.conn.QueryRow(`SELECT message FROM detection_alerts WHERE id = 1`).Scan(&message)
+	checkNoError(t, err)
+	if message != ErasedUsername+" moved 5000 km" {
+		t.Errorf("alert message = %q, want the username replaced", message)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM detection_alerts
+		WHERE id = 1 AND (ip_address IS NOT NULL OR metadata IS NOT NULL)`); n != 0 {
+		t.Error("anonymized alert kept its IP address or metadata")
+	}
+
+	// Scores and mappings go in either mode
+	if n := countRows(t, db, `SELECT COUNT(*) FROM user_trust_scores WHERE user_id = 1`); n != 0 {
+		t.Errorf("%d trust scores left for user1", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM user_mappings`); n != 0 {
+		t.Errorf("%d user mappings left", n)
+	}
+}
+
+func TestEraseUserData_NotFound(t *testing.T) {
+	db := setupUserDataTestDB(t)
+
+	_, err := db.EraseUserData(context.Background(), 999, UserErasureDelete)
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("EraseUserData(999) error = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestParseUserErasureMode(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    UserErasureMode
+		wantErr bool
+	}{
+		{in: "", want: UserErasureDelete},
+		{in: "delete", want: UserErasureDelete},
+		{in: "anonymize", want: UserErasureAnonymize},
+		{in: "shred", wantErr: true},
+	} {
+		got, err := ParseUserErasureMode(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseUserErasureMode(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteUserDataExport(t *testing.T) {
+	db := setupUserDataTestDB(t)
+	ctx := context.Background()
+
+	ids, err := db.ResolveUserDataIDs(ctx, 1)
+	checkNoError(t, err)
+
+	var buf bytes.Buffer
+	info, err := db.WriteUserDataExport(ctx, 1, ids, &buf)
+	checkNoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	checkNoError(t, err)
+
+	lines := make(map[string][]map[string]interface{})
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		checkNoError(t, err)
+		if f.Name == "export.json" {
+			var got UserDataExportInfo
+			checkNoError(t, json.NewDecoder(rc).Decode(&got))
+			if got.UserID != 1 || len(got.UserIDs) != 2 {
+				t.Errorf("export.json = %+v, want user 1 and its linked user", got)
+			}
+			rc.Close()
+			continue
+		}
+		scanner := bufio.NewScanner(rc)
+		scanner.Buffer(make([]byte, 1<<20), 1<<20)
+		for scanner.Scan() {
+			var row map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				t.Fatalf("%s line is not JSON: %v", f.Name, err)
+			}
+			lines[f.Name] = append(lines[f.Name], row)
+		}
+		checkNoError(t, scanner.Err())
+		rc.Close()
+	}
+
+	want := map[string]int{
+		"user_mappings.ndjson":    1,
+		"playback_history.ndjson": 5,
+		"locations.ndjson":        2,
+		"alerts.ndjson":           1,
+	}
+	for name, n := range want {
+		if len(lines[name]) != n {
+			t.Errorf("%s has %d lines, want %d", name, len(lines[name]), n)
+		}
+		if info.Files[name] != n {
+			t.Errorf("info.Files[%s] = %d, want %d", name, info.Files[name], n)
+		}
+	}
+
+	for _, row := range lines["playback_history.ndjson"] {
+		if uid := row["user_id"]; uid != float64(1) && uid != float64(2) {
+			t.Errorf("exported playback of user %v", uid)
+		}
+	}
+	if city := lines["locations.ndjson"][0]["city"]; city != "New York" {
+		t.Errorf("first location city = %v, want New York", city)
+	}
+}
+
+func TestRebuildWrappedReports(t *testing.T) {
+	db := setupUserDataTestDB(t)
+	ctx := context.Background()
+
+	year := time.Now().Add(-72 * time.Hour).Year()
+	_, err := db.GenerateWrappedReport(ctx, 3, year)
+	checkNoError(t, err)
+
+	_, err = db.EraseUserData(ctx, 1, UserErasureDelete)
+	checkNoError(t, err)
+
+	rebuilt, err := db.RebuildWrappedReports(ctx, []int{year})
+	checkNoError(t, err)
+	if rebuilt != 1 {
+		t.Errorf("RebuildWrappedReports() = %d, want user3's report", rebuilt)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM wrapped_reports WHERE user_id = 3`); n != 1 {
+		t.Errorf("%d wrapped reports for user3, want 1", n)
+	}
+}
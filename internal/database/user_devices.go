@@ -0,0 +1,49 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/privacy"
+)
+
+// upsertUserDeviceQuery records a session in the user's device inventory.
+// It runs in the transaction that inserted the playback event, and only for
+// events that were not duplicates, so session counts match playback_events.
+// Sessions may arrive out of order (imports, sync backfill): first and last
+// seen only move outward, and the latest session's client details win.
+const upsertUserDeviceQuery = `
+	INSERT INTO user_devices (
+		user_id, device_id, player, product, platform, device,
+		first_seen_at, first_session_key, first_ip_address,
+		last_seen_at, last_ip_address, session_count
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)
+	ON CONFLICT (user_id, device_id) DO UPDATE SET
+		player = CASE WHEN excluded.last_seen_at >= user_devices.last_seen_at THEN excluded.player ELSE user_devices.player END,
+		product = CASE WHEN excluded.last_seen_at >= user_devices.last_seen_at THEN excluded.product ELSE user_devices.product END,
+		platform = CASE WHEN excluded.last_seen_at >= user_devices.last_seen_at THEN excluded.platform ELSE user_devices.platform END,
+		device = CASE WHEN excluded.last_seen_at >= user_devices.last_seen_at THEN excluded.device ELSE user_devices.device END,
+		last_ip_address = CASE WHEN excluded.last_seen_at >= user_devices.last_seen_at THEN excluded.last_ip_address ELSE user_devices.last_ip_address END,
+		first_session_key = CASE WHEN excluded.first_seen_at < user_devices.first_seen_at THEN excluded.first_session_key ELSE user_devices.first_session_key END,
+		first_ip_address = CASE WHEN excluded.first_seen_at < user_devices.first_seen_at THEN excluded.first_ip_address ELSE user_devices.first_ip_address END,
+		first_seen_at = LEAST(user_devices.first_seen_at, excluded.first_seen_at),
+		last_seen_at = GREATEST(user_devices.last_seen_at, excluded.last_seen_at),
+		session_count = user_devices.session_count + 1`
+
+// userDeviceArgs returns the upsertUserDeviceQuery arguments for an event,
+// or nil when the event has no machine ID to key the device by.
+func userDeviceArgs(event *models.PlaybackEvent) []interface{} {
+	if event.MachineID == nil || *event.MachineID == "" {
+		return nil
+	}
+	ip := privacy.AnonymizeIP(event.IPAddress)
+	return []interface{}{
+		event.UserID, *event.MachineID,
+		event.Player, event.Product, event.Platform, event.Device,
+		event.StartedAt, event.SessionKey, ip,
+		event.StartedAt, ip,
+	}
+}
@@ -60,6 +60,23 @@ func (db *DB) GenerateVectorTile(ctx context.Context, z, x, y int, filter Locati
 		return cachedData, nil // Cache hit - return immediately
 	}
 
+	render := db.queryVectorTile
+	if db.renderTile != nil {
+		render = db.renderTile
+	}
+	mvtData, err := render(ctx, z, x, y, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// MEDIUM-1: Store in cache for future requests (5-minute TTL)
+	db.setTileCache(cacheKey, mvtData)
+
+	return mvtData, nil
+}
+
+// queryVectorTile renders one tile with ST_AsMVT, bypassing the cache
+func (db *DB) queryVectorTile(ctx context.Context, z, x, y int, filter LocationStatsFilter) ([]byte, error) {
 	// Calculate tile bounds
 	bounds := CalculateTileBounds(z, x, y)
 
@@ -146,9 +163,5 @@ func (db *DB) GenerateVectorTile(ctx context.Context, z, x, y int, filter Locati
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate vector tile: %w", err)
 	}
-
-	// MEDIUM-1: Store in cache for future requests (5-minute TTL)
-	db.setTileCache(cacheKey, mvtData)
-
 	return mvtData, nil
 }
@@ -0,0 +1,643 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// DefaultWatchPartyStartWindow is how close together the playbacks of
+// different users must start to form a watch party.
+const DefaultWatchPartyStartWindow = 2 * time.Minute
+
+// DefaultWatchPartyMinDuration is how long watch party participants must
+// have been watching at the same time.
+const DefaultWatchPartyMinDuration = 10 * time.Minute
+
+// DefaultWatchPartyMinConfidence is the confidence a detected watch party
+// needs to be reported, until admins have marked enough false positives to
+// raise it (see WatchPartyMinConfidence).
+const DefaultWatchPartyMinConfidence = 0.5
+
+// maxWatchPartyMinConfidence caps how far false positive feedback can raise
+// the confidence threshold, so feedback never hides every party.
+const maxWatchPartyMinConfidence = 0.9
+
+// watchPartyFeedbackMinimum is the number of false positives needed before
+// they adjust the confidence threshold.
+const watchPartyFeedbackMinimum = 3
+
+// watchPartyProgressionTolerance is the mean difference in playback
+// position, in percentage points, at which participants no longer count as
+// watching in step at all.
+const watchPartyProgressionTolerance = 20.0
+
+// watchPartyProgressionSamples is the number of points in time, spread over
+// the participants' common viewing time, at which positions are compared.
+const watchPartyProgressionSamples = 5
+
+// watchPartyWatermarkSource is the sync_watermarks source under which
+// detection records the newest playback insert it has scanned.
+const watchPartyWatermarkSource = "watch_parties"
+
+// watchPartyRescanMargin is how far before the watermark detection rescans,
+// for playbacks inserted by a sync that was still running during the last
+// detection.
+const watchPartyRescanMargin = time.Hour
+
+// ErrWatchPartyNotFound is returned when no watch party has the given id.
+var ErrWatchPartyNotFound = errors.New("watch party not found")
+
+// watchPartyPlayback is a playback considered for watch party detection.
+type watchPartyPlayback struct {
+	id               string
+	userID           int
+	username         string
+	ipAddress        string
+	mediaType        string
+	title            string
+	parentTitle      *string
+	grandparentTitle *string
+	startedAt        time.Time
+	stoppedAt        *time.Time
+	percentComplete  *int
+	playDuration     *int
+}
+
+// sameContent reports whether two playbacks are of the same item.
+func (p *watchPartyPlayback) sameContent(other *watchPartyPlayback) bool {
+	return p.title == other.title &&
+		titleOrEmpty(p.parentTitle) == titleOrEmpty(other.parentTitle) &&
+		titleOrEmpty(p.grandparentTitle) == titleOrEmpty(other.grandparentTitle)
+}
+
+// titleOrEmpty returns an optional title, or "" when it is not set.
+func titleOrEmpty(title *string) string {
+	if title == nil {
+		return ""
+	}
+	return *title
+}
+
+// endedAt is when the playback stopped: stopped_at, or started_at plus the
+// play duration for playbacks without one.
+func (p *watchPartyPlayback) endedAt() time.Time {
+	if p.stoppedAt != nil {
+		return *p.stoppedAt
+	}
+	if p.playDuration != nil {
+		return p.startedAt.Add(time.Duration(*p.playDuration) * time.Minute)
+	}
+	return p.startedAt
+}
+
+// positionAt estimates the playback position, in percent of the item, at t.
+// Start offsets are not stored, so the position is taken to move evenly from
+// 0% at start to percent_complete at stop; a playback resumed halfway
+// through therefore moves faster than one watched from the beginning.
+func (p *watchPartyPlayback) positionAt(t time.Time) float64 {
+	end := p.endedAt()
+	if !end.After(p.startedAt) || p.percentComplete == nil {
+		return 0
+	}
+	fraction := float64(t.Sub(p.startedAt)) / float64(end.Sub(p.startedAt))
+	return float64(*p.percentComplete) * math.Max(0, math.Min(1, fraction))
+}
+
+// detectedWatchParty is a group of playbacks detected as one watch party.
+type detectedWatchParty struct {
+	members    []*watchPartyPlayback
+	startedAt  time.Time
+	endedAt    time.Time
+	confidence float64
+}
+
+// clusterWatchParties groups playbacks, sorted by item and start time, into
+// watch parties. A party starts at the first playback not in a party yet
+// and takes the playbacks of the same item starting at most window after
+// it, keeping each user's first one. It needs two or more users who were
+// all watching at the same time for at least minDuration.
+func clusterWatchParties(playbacks []*watchPartyPlayback, window, minDuration time.Duration) []detectedWatchParty {
+	var parties []detectedWatchParty
+	for i := 0; i < len(playbacks); {
+		anchor := playbacks[i]
+		members := []*watchPartyPlayback{anchor}
+		seen := map[int]bool{anchor.userID: true}
+		j := i + 1
+		for ; j < len(playbacks); j++ {
+			p := playbacks[j]
+			if !p.sameContent(anchor) || p.startedAt.Sub(anchor.startedAt) > window {
+				break
+			}
+			if !seen[p.userID] {
+				seen[p.userID] = true
+				members = append(members, p)
+			}
+		}
+		i = j
+
+		if len(members) < 2 {
+			continue
+		}
+		party, ok := scoreWatchParty(members, window, minDuration)
+		if ok {
+			parties = append(parties, party)
+		}
+	}
+	return parties
+}
+
+// scoreWatchParty rates how likely playbacks of different users, all started
+// within window, were watched together. It returns false when their common
+// viewing time is shorter than minDuration.
+//
+// The confidence (0-1) weighs how close together the playbacks started
+// (40%), how closely their positions tracked each other while all were
+// watching (40%), and the number of participants (20%, full at four).
+// Participants who started together by chance drift apart, or watched
+// different parts of the item, and score low on progression.
+func scoreWatchParty(members []*watchPartyPlayback, window, minDuration time.Duration) (detectedWatchParty, bool) {
+	firstStart, lastStart := members[0].startedAt, members[0].startedAt
+	overlapEnd := members[0].endedAt()
+	partyEnd := overlapEnd
+	for _, m := range members[1:] {
+		if m.startedAt.Before(firstStart) {
+			firstStart = m.startedAt
+		}
+		if m.startedAt.After(lastStart) {
+			lastStart = m.startedAt
+		}
+		end := m.endedAt()
+		if end.Before(overlapEnd) {
+			overlapEnd = end
+		}
+		if end.After(partyEnd) {
+			partyEnd = end
+		}
+	}
+
+	overlap := overlapEnd.Sub(lastStart)
+	if overlap <= 0 || overlap < minDuration {
+		return detectedWatchParty{}, false
+	}
+
+	startScore := 1.0
+	if window > 0 {
+		startScore = 1 - float64(lastStart.Sub(firstStart))/float64(window)
+	}
+
+	var totalSpread float64
+	for s := 0; s < watchPartyProgressionSamples; s++ {
+		at := lastStart.Add(overlap * time.Duration(s) / time.Duration(watchPartyProgressionSamples-1))
+		lowest, highest := math.Inf(1), math.Inf(-1)
+		for _, m := range members {
+			position := m.positionAt(at)
+			lowest = math.Min(lowest, position)
+			highest = math.Max(highest, position)
+		}
+		totalSpread += highest - lowest
+	}
+	meanSpread := totalSpread / watchPartyProgressionSamples
+	progressionScore := math.Max(0, 1-meanSpread/watchPartyProgressionTolerance)
+
+	sizeScore := math.Min(1, float64(len(members)-1)/3)
+
+	confidence := 0.4*startScore + 0.4*progressionScore + 0.2*sizeScore
+	return detectedWatchParty{
+		members:    members,
+		startedAt:  firstStart,
+		endedAt:    partyEnd,
+		confidence: math.Round(confidence*1000) / 1000,
+	}, true
+}
+
+// watchPartyCandidatesCTE selects the ids of playbacks detection looks at:
+// those of the same item starting at most ? seconds from a playback
+// inserted since ?, plus the other participants of parties they are in, so
+// those parties are detected again as a whole.
+const watchPartyCandidatesCTE = `
+	fresh AS (
+		SELECT DISTINCT
+			title,
+			COALESCE(parent_title, '') AS parent_title,
+			COALESCE(grandparent_title, '') AS grandparent_title,
+			started_at
+		FROM playback_events
+		WHERE created_at >= ?
+	),
+	nearby AS (
+		SELECT DISTINCT CAST(e.id AS VARCHAR) AS id
+		FROM playback_events e
+		JOIN fresh f ON e.title = f.title
+			AND COALESCE(e.parent_title, '') = f.parent_title
+			AND COALESCE(e.grandparent_title, '') = f.grandparent_title
+			AND ABS(epoch(e.started_at - f.started_at)) <= ?
+	),
+	candidates AS (
+		SELECT id FROM nearby
+		UNION
+		SELECT other.playback_id
+		FROM watch_party_participants touched
+		JOIN watch_party_participants other ON other.party_id = touched.party_id
+		WHERE touched.playback_id IN (SELECT id FROM nearby)
+	)`
+
+// DetectWatchParties finds watch parties among playbacks inserted since the
+// last detection and stores them in watch_parties, replacing the parties
+// those playbacks were in before. Parties keep their false positive mark
+// when they are detected again. See clusterWatchParties and scoreWatchParty
+// for the rules. Detection is disabled when startWindow is 0. Returns the
+// number of parties stored.
+func (db *DB) DetectWatchParties(ctx context.Context, startWindow, minDuration time.Duration) (int, error) {
+	if startWindow <= 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	since, err := db.GetSyncWatermark(ctx, watchPartyWatermarkSource, "")
+	if err != nil {
+		return 0, err
+	}
+	if !since.IsZero() {
+		since = since.Add(-watchPartyRescanMargin)
+	}
+
+	var latest sql.NullTime
+	if err := db.conn.QueryRowContext(ctx, `SELECT MAX(created_at) FROM playback_events`).Scan(&latest); err != nil {
+		return 0, fmt.Errorf("failed to get latest playback insert: %w", err)
+	}
+	if !latest.Valid {
+		return 0, nil
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	// A party starts at most one window before its first playback in the
+	// scan and ends at most one window after it
+	radius := (2 * startWindow).Seconds()
+
+	falsePositives, err := replacedWatchParties(ctx, tx, since, radius)
+	if err != nil {
+		return 0, err
+	}
+	playbacks, err := watchPartyCandidates(ctx, tx, since, radius)
+	if err != nil {
+		return 0, err
+	}
+	parties := clusterWatchParties(playbacks, startWindow, minDuration)
+
+	if err := deleteWatchParties(ctx, tx, falsePositives); err != nil {
+		return 0, err
+	}
+	for i := range parties {
+		if err := insertWatchParty(ctx, tx, &parties[i], falsePositives[parties[i].members[0].id]); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit watch parties: %w", err)
+	}
+
+	if err := db.SetSyncWatermark(ctx, watchPartyWatermarkSource, "", latest.Time); err != nil {
+		return 0, err
+	}
+	return len(parties), nil
+}
+
+// replacedWatchParties returns the stored parties that detection replaces,
+// with their false positive marks.
+func replacedWatchParties(ctx context.Context, tx *sql.Tx, since time.Time, radius float64) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, `
+		WITH`+watchPartyCandidatesCTE+`
+		SELECT DISTINCT w.id, w.false_positive
+		FROM watch_parties w
+		JOIN watch_party_participants pp ON pp.party_id = w.id
+		WHERE pp.playback_id IN (SELECT id FROM candidates)`,
+		since, radius)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stored watch parties: %w", err)
+	}
+	defer rows.Close()
+
+	falsePositives := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		var falsePositive bool
+		if err := rows.Scan(&id, &falsePositive); err != nil {
+			return nil, fmt.Errorf("failed to scan stored watch party: %w", err)
+		}
+		falsePositives[id] = falsePositive
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stored watch parties: %w", err)
+	}
+	return falsePositives, nil
+}
+
+// watchPartyCandidates loads the playbacks detection looks at, sorted by
+// item and start time.
+func watchPartyCandidates(ctx context.Context, tx *sql.Tx, since time.Time, radius float64) ([]*watchPartyPlayback, error) {
+	rows, err := tx.QueryContext(ctx, `
+		WITH`+watchPartyCandidatesCTE+`
+		SELECT
+			CAST(e.id AS VARCHAR), e.user_id, e.username, COALESCE(e.ip_address, ''),
+			e.media_type, e.title, e.parent_title, e.grandparent_title,
+			e.started_at, e.stopped_at, e.percent_complete, e.play_duration
+		FROM playback_events e
+		WHERE CAST(e.id AS VARCHAR) IN (SELECT id FROM candidates)
+		ORDER BY e.title, COALESCE(e.parent_title, ''), COALESCE(e.grandparent_title, ''), e.started_at, e.id`,
+		since, radius)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watch party candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var playbacks []*watchPartyPlayback
+	for rows.Next() {
+		p := &watchPartyPlayback{}
+		if err := rows.Scan(
+			&p.id, &p.userID, &p.username, &p.ipAddress,
+			&p.mediaType, &p.title, &p.parentTitle, &p.grandparentTitle,
+			&p.startedAt, &p.stoppedAt, &p.percentComplete, &p.playDuration,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan watch party candidate: %w", err)
+		}
+		playbacks = append(playbacks, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating watch party candidates: %w", err)
+	}
+	return playbacks, nil
+}
+
+// deleteWatchParties deletes stored parties and their participants.
+func deleteWatchParties(ctx context.Context, tx *sql.Tx, parties map[string]bool) error {
+	for id := range parties {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM watch_party_participants WHERE party_id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete participants of watch party %s: %w", id, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM watch_parties WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete watch party %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// insertWatchParty stores a detected party and its participants. The party
+// id is the id of its first playback.
+func insertWatchParty(ctx context.Context, tx *sql.Tx, party *detectedWatchParty, falsePositive bool) error {
+	anchor := party.members[0]
+
+	ips := make(map[string]bool)
+	var completion float64
+	var completions, totalDuration int
+	for _, m := range party.members {
+		if m.ipAddress != "" {
+			ips[m.ipAddress] = true
+		}
+		if m.percentComplete != nil {
+			completion += float64(*m.percentComplete)
+			completions++
+		}
+		if m.playDuration != nil {
+			totalDuration += *m.playDuration
+		}
+	}
+	if completions > 0 {
+		completion /= float64(completions)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO watch_parties (
+			id, media_type, title, parent_title, grandparent_title, started_at, ended_at,
+			participant_count, confidence, same_location, avg_completion, total_duration,
+			false_positive, detected_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		anchor.id, anchor.mediaType, anchor.title, anchor.parentTitle, anchor.grandparentTitle,
+		party.startedAt, party.endedAt, len(party.members), party.confidence, len(ips) == 1,
+		completion, totalDuration, falsePositive, time.Now(),
+	); err != nil {
+		return fmt.Errorf("failed to insert watch party %s: %w", anchor.id, err)
+	}
+
+	for _, m := range party.members {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO watch_party_participants (
+				party_id, playback_id, user_id, username, ip_address,
+				started_at, stopped_at, percent_complete, play_duration
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			anchor.id, m.id, m.userID, m.username, m.ipAddress,
+			m.startedAt, m.stoppedAt, m.percentComplete, m.playDuration,
+		); err != nil {
+			return fmt.Errorf("failed to insert participant of watch party %s: %w", anchor.id, err)
+		}
+	}
+	return nil
+}
+
+// WatchPartyMinConfidence returns the confidence a watch party needs to be
+// reported. It starts at DefaultWatchPartyMinConfidence; once admins have
+// marked three or more parties as false positives, it rises to the mean
+// confidence of those parties (at most 0.9), so parties scoring like the
+// rejected ones are no longer reported.
+func (db *DB) WatchPartyMinConfidence(ctx context.Context) (float64, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	var count int
+	var mean sql.NullFloat64
+	if err := db.conn.QueryRowContext(ctx, `
+		SELECT COUNT(*), AVG(confidence) FROM watch_parties WHERE false_positive`,
+	).Scan(&count, &mean); err != nil {
+		return 0, fmt.Errorf("failed to query watch party feedback: %w", err)
+	}
+	if count < watchPartyFeedbackMinimum || !mean.Valid {
+		return DefaultWatchPartyMinConfidence, nil
+	}
+	return math.Max(DefaultWatchPartyMinConfidence, math.Min(maxWatchPartyMinConfidence, mean.Float64)), nil
+}
+
+// SetWatchPartyFalsePositive marks a watch party as a false positive, or
+// clears the mark. Returns ErrWatchPartyNotFound for an unknown id.
+func (db *DB) SetWatchPartyFalsePositive(ctx context.Context, id string, falsePositive bool) error {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	result, err := db.conn.ExecContext(ctx,
+		`UPDATE watch_parties SET false_positive = ? WHERE id = ?`, falsePositive, id)
+	if err != nil {
+		return fmt.Errorf("failed to update watch party: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check watch party update: %w", err)
+	}
+	if rows == 0 {
+		return ErrWatchPartyNotFound
+	}
+	return nil
+}
+
+// watchPartyFilter builds the WHERE clause and args selecting stored watch
+// parties (alias wp) with a participant playback (alias p1) matching filter
+// and at least minConfidence. Parties marked as false positives are left
+// out unless includeFalsePositives is set.
+//
+//nolint:gocritic // hugeParam: filter passed by value like the other query builders
+func watchPartyFilter(filter LocationStatsFilter, minConfidence float64, includeFalsePositives bool) (string, []interface{}) {
+	playbackClause, args := buildEngagementWhereClause(filter, "p1", true)
+	whereClause := `EXISTS (
+			SELECT 1 FROM watch_party_participants pp
+			JOIN playback_events p1 ON CAST(p1.id AS VARCHAR) = pp.playback_id
+			WHERE pp.party_id = wp.id AND ` + playbackClause + `
+		)
+		AND wp.confidence >= ?`
+	if !includeFalsePositives {
+		whereClause += " AND NOT wp.false_positive"
+	}
+	return whereClause, append(args, minConfidence)
+}
+
+// WatchPartyListFilter selects the watch parties ListWatchParties returns.
+type WatchPartyListFilter struct {
+	// Playbacks selects parties with a participant playback matching it
+	Playbacks LocationStatsFilter
+	// MinConfidence is the lowest confidence listed
+	MinConfidence float64
+	// IncludeFalsePositives also lists parties marked as false positives
+	IncludeFalsePositives bool
+	Limit                 int
+	Offset                int
+}
+
+// ListWatchParties lists stored watch parties with their participants,
+// newest first. Returns the page of parties and the total number matching.
+//
+//nolint:gocritic // hugeParam: filter passed by value like LocationStatsFilter
+func (db *DB) ListWatchParties(ctx context.Context, filter WatchPartyListFilter) ([]models.WatchParty, int64, error) {
+	ctx, cancel := db.ensureContext(ctx)
+	defer cancel()
+
+	whereClause, args := watchPartyFilter(filter.Playbacks, filter.MinConfidence, filter.IncludeFalsePositives)
+
+	var total int64
+	if err := db.conn.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM watch_parties wp WHERE `+whereClause, args...,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count watch parties: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	offset := max(filter.Offset, 0)
+
+	rows, err := db.conn.QueryContext(ctx, `
+		SELECT
+			wp.id, wp.media_type, wp.title, wp.parent_title, wp.grandparent_title,
+			wp.started_at, wp.ended_at, wp.participant_count, wp.confidence,
+			wp.same_location, wp.avg_completion, wp.total_duration, wp.false_positive
+		FROM watch_parties wp
+		WHERE `+whereClause+`
+		ORDER BY wp.started_at DESC, wp.id
+		LIMIT ? OFFSET ?`,
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list watch parties: %w", err)
+	}
+	defer rows.Close()
+
+	parties := make([]models.WatchParty, 0)
+	index := make(map[string]int)
+	for rows.Next() {
+		var party models.WatchParty
+		if err := rows.Scan(
+			&party.ID, &party.MediaType, &party.Title, &party.ParentTitle, &party.GrandparentTitle,
+			&party.PartyTime, &party.EndedAt, &party.ParticipantCount, &party.Confidence,
+			&party.SameLocation, &party.AvgCompletion, &party.TotalDuration, &party.FalsePositive,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan watch party: %w", err)
+		}
+		party.Participants = []models.WatchPartyParticipant{}
+		index[party.ID] = len(parties)
+		parties = append(parties, party)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating watch parties: %w", err)
+	}
+
+	if err := db.loadWatchPartyParticipants(ctx, parties, index); err != nil {
+		return nil, 0, err
+	}
+	return parties, total, nil
+}
+
+// loadWatchPartyParticipants fills in the participants of listed parties,
+// with the location of their IP address where known.
+func (db *DB) loadWatchPartyParticipants(ctx context.Context, parties []models.WatchParty, index map[string]int) error {
+	if len(parties) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(index))
+	for id := range index {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := db.conn.QueryContext(ctx, `
+		SELECT
+			pp.party_id, pp.user_id, pp.username, COALESCE(pp.ip_address, ''),
+			g.city, g.country, pp.started_at, COALESCE(pp.percent_complete, 0), pp.play_duration
+		FROM watch_party_participants pp
+		LEFT JOIN geolocations g ON g.ip_address = pp.ip_address
+		WHERE pp.party_id IN (`+join(placeholders, ", ")+`)
+		ORDER BY pp.party_id, pp.started_at, pp.user_id`,
+		args...)
+	if err != nil {
+		return fmt.Errorf("failed to query watch party participants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var partyID string
+		var p models.WatchPartyParticipant
+		if err := rows.Scan(
+			&partyID, &p.UserID, &p.Username, &p.IPAddress,
+			&p.City, &p.Country, &p.StartedAt, &p.PercentComplete, &p.PlayDuration,
+		); err != nil {
+			return fmt.Errorf("failed to scan watch party participant: %w", err)
+		}
+		i := index[partyID]
+		parties[i].Participants = append(parties[i].Participants, p)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating watch party participants: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// partyTestPlayback is a playback of "Movie" by the user, playing for the
+// given minutes and ending at percent.
+func partyTestPlayback(id string, userID int, start time.Time, minutes, percent int) *watchPartyPlayback {
+	stop := start.Add(time.Duration(minutes) * time.Minute)
+	return &watchPartyPlayback{
+		id:              id,
+		userID:          userID,
+		mediaType:       "movie",
+		title:           "Movie",
+		startedAt:       start,
+		stoppedAt:       &stop,
+		percentComplete: &percent,
+		playDuration:    &minutes,
+	}
+}
+
+func TestScoreWatchParty(t *testing.T) {
+	start := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	window := DefaultWatchPartyStartWindow
+	minDuration := DefaultWatchPartyMinDuration
+
+	tests := []struct {
+		name    string
+		members []*watchPartyPlayback
+		wantOK  bool
+		minConf float64
+		maxConf float64
+	}{
+		{
+			name: "started together and watched to the end",
+			members: []*watchPartyPlayback{
+				partyTestPlayback("a", 1, start, 120, 100),
+				partyTestPlayback("b", 2, start.Add(10*time.Second), 120, 100),
+			},
+			wantOK:  true,
+			minConf: 0.8,
+			maxConf: 1,
+		},
+		{
+			name: "one user resumed halfway through",
+			members: []*watchPartyPlayback{
+				partyTestPlayback("a", 1, start, 60, 50),
+				partyTestPlayback("b", 2, start.Add(10*time.Second), 60, 100),
+			},
+			wantOK:  true,
+			minConf: 0,
+			maxConf: DefaultWatchPartyMinConfidence,
+		},
+		{
+			name: "started at the edge of the window",
+			members: []*watchPartyPlayback{
+				partyTestPlayback("a", 1, start, 120, 100),
+				partyTestPlayback("b", 2, start.Add(window), 120, 100),
+			},
+			wantOK:  true,
+			minConf: 0.4,
+			maxConf: 0.6,
+		},
+		{
+			name: "watched together too briefly",
+			members: []*watchPartyPlayback{
+				partyTestPlayback("a", 1, start, 5, 4),
+				partyTestPlayback("b", 2, start.Add(10*time.Second), 120, 100),
+			},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			party, ok := scoreWatchParty(tt.members, window, minDuration)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if party.confidence < tt.minConf || party.confidence > tt.maxConf {
+				t.Errorf("confidence = %.3f, want between %.2f and %.2f", party.confidence, tt.minConf, tt.maxConf)
+			}
+			if !party.startedAt.Equal(start) {
+				t.Errorf("startedAt = %v, want %v", party.startedAt, start)
+			}
+		})
+	}
+}
+
+func TestClusterWatchParties(t *testing.T) {
+	start := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	other := partyTestPlayback("e", 5, start.Add(30*time.Second), 120, 100)
+	other.title = "Other Movie"
+
+	playbacks := []*watchPartyPlayback{
+		partyTestPlayback("a", 1, start, 120, 100),
+		partyTestPlayback("b", 2, start.Add(30*time.Second), 120, 100),
+		// User 1 again: kept out of the party
+		partyTestPlayback("c", 1, start.Add(time.Minute), 120, 100),
+		// Outside the window of the first party and alone
+		partyTestPlayback("d", 3, start.Add(10*time.Minute), 120, 100),
+		other,
+	}
+
+	parties := clusterWatchParties(playbacks, DefaultWatchPartyStartWindow, DefaultWatchPartyMinDuration)
+	if len(parties) != 1 {
+		t.Fatalf("got %d parties, want 1", len(parties))
+	}
+	var ids []string
+	for _, m := range parties[0].members {
+		ids = append(ids, m.id)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("members = %v, want [a b]", ids)
+	}
+}
+
+// partyTestEvent is a two hour playback of "Party Movie" by the user.
+func partyTestEvent(userID int, username string, start time.Time) *models.PlaybackEvent {
+	stop := start.Add(2 * time.Hour)
+	playDuration := 120
+	return &models.PlaybackEvent{
+		SessionKey:      username + "-" + start.Format(time.RFC3339),
+		StartedAt:       start,
+		StoppedAt:       &stop,
+		UserID:          userID,
+		Username:        username,
+		IPAddress:       "198.51.100.7",
+		MediaType:       "movie",
+		Title:           "Party Movie",
+		PercentComplete: 100,
+		PlayDuration:    &playDuration,
+	}
+}
+
+func TestDetectWatchParties(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	detect := func() {
+		t.Helper()
+		_, err := db.DetectWatchParties(ctx, DefaultWatchPartyStartWindow, DefaultWatchPartyMinDuration)
+		checkNoError(t, err)
+	}
+	list := func(includeFalsePositives bool) []models.WatchParty {
+		t.Helper()
+		parties, total, err := db.ListWatchParties(ctx, WatchPartyListFilter{
+			MinConfidence:         DefaultWatchPartyMinConfidence,
+			IncludeFalsePositives: includeFalsePositives,
+		})
+		checkNoError(t, err)
+		if int(total) != len(parties) {
+			t.Fatalf("total = %d, want %d", total, len(parties))
+		}
+		return parties
+	}
+
+	start := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	_, _, err := db.InsertPlaybackEventsBatch(ctx, []*models.PlaybackEvent{
+		partyTestEvent(1, "alice", start),
+		partyTestEvent(2, "bob", start.Add(20*time.Second)),
+		// Same movie an hour later: not part of the party
+		partyTestEvent(3, "carol", start.Add(time.Hour)),
+	})
+	checkNoError(t, err)
+
+	if n, err := db.DetectWatchParties(ctx, 0, DefaultWatchPartyMinDuration); err != nil || n != 0 {
+		t.Fatalf("disabled detection = %d, %v; want 0, nil", n, err)
+	}
+
+	detect()
+	parties := list(false)
+	if len(parties) != 1 {
+		t.Fatalf("got %d parties, want 1", len(parties))
+	}
+	party := parties[0]
+	if party.ParticipantCount != 2 || len(party.Participants) != 2 || !party.SameLocation {
+		t.Errorf("party = %+v, want 2 participants at the same location", party)
+	}
+	if party.Confidence < DefaultWatchPartyMinConfidence {
+		t.Errorf("confidence = %.3f, want at least %.2f", party.Confidence, DefaultWatchPartyMinConfidence)
+	}
+
+	// A late joiner synced later is added to the same party
+	_, _, err = db.InsertPlaybackEventsBatch(ctx, []*models.PlaybackEvent{
+		partyTestEvent(4, "dave", start.Add(90*time.Second)),
+	})
+	checkNoError(t, err)
+	checkNoError(t, db.SetWatchPartyFalsePositive(ctx, party.ID, true))
+	detect()
+
+	if got := list(false); len(got) != 0 {
+		t.Errorf("listed %d parties, want the false positive left out", len(got))
+	}
+	got := list(true)
+	if len(got) != 1 || got[0].ID != party.ID || got[0].ParticipantCount != 3 || !got[0].FalsePositive {
+		t.Fatalf("parties = %+v, want party %s with 3 participants still marked false positive", got, party.ID)
+	}
+
+	if err := db.SetWatchPartyFalsePositive(ctx, "missing", true); !errors.Is(err, ErrWatchPartyNotFound) {
+		t.Errorf("unknown party error = %v, want ErrWatchPartyNotFound", err)
+	}
+}
+
+func TestWatchPartyMinConfidence(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	insert := func(id string, confidence float64, falsePositive bool) {
+		t.Helper()
+		_, err := db.conn.Exec(`
+			INSERT INTO watch_parties (
+				id, media_type, title, started_at, ended_at, participant_count, confidence, false_positive
+			) VALUES (?, 'movie', 'Movie', ?, ?, 2, ?, ?)`,
+			id, time.Now(), time.Now(), confidence, falsePositive)
+		checkNoError(t, err)
+	}
+
+	insert("a", 0.7, true)
+	insert("b", 0.8, true)
+	insert("c", 0.95, false)
+	minConfidence, err := db.WatchPartyMinConfidence(ctx)
+	checkNoError(t, err)
+	if minConfidence != DefaultWatchPartyMinConfidence {
+		t.Errorf("with 2 false positives = %.2f, want the default %.2f", minConfidence, DefaultWatchPartyMinConfidence)
+	}
+
+	insert("d", 0.9, true)
+	minConfidence, err = db.WatchPartyMinConfidence(ctx)
+	checkNoError(t, err)
+	if minConfidence < 0.799 || minConfidence > 0.801 {
+		t.Errorf("with 3 false positives = %.3f, want their mean 0.8", minConfidence)
+	}
+}
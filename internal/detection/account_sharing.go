@@ -0,0 +1,259 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// RuleTypeAccountSharing detects one account watching different content from
+// different cities at the same time.
+const RuleTypeAccountSharing RuleType = "account_sharing"
+
+// AccountSharingConfig configures the account sharing detector.
+type AccountSharingConfig struct {
+	// MinSessions is the number of concurrent sessions, each playing a
+	// distinct title from a distinct location, that triggers an alert.
+	MinSessions int `json:"min_sessions"`
+
+	// WindowMinutes is the time window to consider sessions concurrent.
+	WindowMinutes int `json:"window_minutes"`
+
+	// MinDistanceKm is the minimum distance between two locations for them
+	// to count as distinct.
+	MinDistanceKm float64 `json:"min_distance_km"`
+
+	// Severity for generated alerts.
+	Severity Severity `json:"severity"`
+}
+
+// DefaultAccountSharingConfig returns sensible defaults.
+func DefaultAccountSharingConfig() AccountSharingConfig {
+	return AccountSharingConfig{
+		MinSessions:   3,
+		WindowMinutes: 30,
+		MinDistanceKm: 50, // Different cities
+		Severity:      SeverityCritical,
+	}
+}
+
+// AccountSharingMetadata contains details for account sharing alerts.
+type AccountSharingMetadata struct {
+	Sessions    []AccountSharingSession `json:"sessions"`
+	MinSessions int                     `json:"min_sessions"`
+}
+
+// AccountSharingSession is one of the concurrent sessions in an account
+// sharing alert.
+type AccountSharingSession struct {
+	LocationInfo
+	RatingKey string `json:"rating_key,omitempty"`
+}
+
+// AccountSharingDetector flags a user with several concurrent sessions that
+// play different content from different cities, the typical pattern of a
+// shared account. Simultaneous locations alone fires for a single household
+// member travelling; requiring distinct content as well narrows it to several
+// people using the same login.
+type AccountSharingDetector struct {
+	config       AccountSharingConfig
+	eventHistory EventHistory
+	enabled      bool
+	mu           sync.RWMutex
+}
+
+// NewAccountSharingDetector creates a new account sharing detector.
+func NewAccountSharingDetector(eventHistory EventHistory) *AccountSharingDetector {
+	return &AccountSharingDetector{
+		config:       DefaultAccountSharingConfig(),
+		eventHistory: eventHistory,
+		enabled:      true,
+	}
+}
+
+// Type returns the rule type.
+func (d *AccountSharingDetector) Type() RuleType {
+	return RuleTypeAccountSharing
+}
+
+// Check evaluates the event against the account sharing rule.
+func (d *AccountSharingDetector) Check(ctx context.Context, event *DetectionEvent) (*Alert, error) {
+	d.mu.RLock()
+	if !d.enabled {
+		d.mu.RUnlock()
+		return nil, nil
+	}
+	config := d.config
+	d.mu.RUnlock()
+
+	// DETERMINISM: Use epsilon-based coordinate check instead of direct float equality
+	if IsUnknownLocation(event.Latitude, event.Longitude) || contentKey(event) == "" {
+		return nil, nil
+	}
+
+	// v2.1: Pass serverID to scope detection to the same server instance
+	window := time.Duration(config.WindowMinutes) * time.Minute
+	concurrentEvents, err := d.eventHistory.GetSimultaneousLocations(ctx, event.UserID, event.ServerID, window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get concurrent sessions: %w", err)
+	}
+
+	sessions := selectSharedSessions(event, concurrentEvents, config.MinDistanceKm)
+	if len(sessions) < config.MinSessions {
+		return nil, nil
+	}
+
+	metadataJSON, err := json.Marshal(AccountSharingMetadata{
+		Sessions:    sessions,
+		MinSessions: config.MinSessions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	locations := make([]LocationInfo, len(sessions))
+	for i := range sessions {
+		locations[i] = sessions[i].LocationInfo
+	}
+
+	alert := &Alert{
+		RuleType:  RuleTypeAccountSharing,
+		UserID:    event.UserID,
+		Username:  event.Username,
+		ServerID:  event.ServerID,
+		MachineID: event.MachineID,
+		IPAddress: event.IPAddress,
+		Severity:  config.Severity,
+		Title:     "Account Sharing Detected",
+		Message: fmt.Sprintf(
+			"User %s has %d concurrent sessions playing different titles from different locations: %s",
+			event.Username,
+			len(sessions),
+			formatLocationSummary(locations),
+		),
+		Metadata:  metadataJSON,
+		CreatedAt: time.Now(),
+	}
+
+	return alert, nil
+}
+
+// selectSharedSessions picks the current session plus every concurrent
+// session whose content differs from all picked sessions and whose location
+// is at least minDistanceKm from all of them. Sessions are considered in
+// history order (most recent first), so the result is deterministic.
+func selectSharedSessions(event *DetectionEvent, concurrent []DetectionEvent, minDistanceKm float64) []AccountSharingSession {
+	picked := []*DetectionEvent{event}
+	seenSessions := map[string]bool{event.SessionKey: true}
+
+	for i := range concurrent {
+		candidate := &concurrent[i]
+		if seenSessions[candidate.SessionKey] || contentKey(candidate) == "" ||
+			IsUnknownLocation(candidate.Latitude, candidate.Longitude) {
+			continue
+		}
+		if isDistinctSession(candidate, picked, minDistanceKm) {
+			picked = append(picked, candidate)
+			seenSessions[candidate.SessionKey] = true
+		}
+	}
+
+	sessions := make([]AccountSharingSession, len(picked))
+	for i, e := range picked {
+		sessions[i] = AccountSharingSession{
+			LocationInfo: LocationInfo{
+				SessionKey: e.SessionKey,
+				IPAddress:  e.IPAddress,
+				City:       e.City,
+				Country:    e.Country,
+				Latitude:   e.Latitude,
+				Longitude:  e.Longitude,
+				Title:      e.Title,
+				StartedAt:  e.Timestamp,
+			},
+			RatingKey: e.RatingKey,
+		}
+	}
+	return sessions
+}
+
+// isDistinctSession reports whether candidate plays different content from a
+// location far enough from every picked session.
+func isDistinctSession(candidate *DetectionEvent, picked []*DetectionEvent, minDistanceKm float64) bool {
+	key := contentKey(candidate)
+	for _, p := range picked {
+		if contentKey(p) == key {
+			return false
+		}
+		if haversineDistance(p.Latitude, p.Longitude, candidate.Latitude, candidate.Longitude) < minDistanceKm {
+			return false
+		}
+	}
+	return true
+}
+
+// contentKey identifies what a session is playing: the rating key when
+// known, otherwise the show and title.
+func contentKey(event *DetectionEvent) string {
+	if event.RatingKey != "" {
+		return event.RatingKey
+	}
+	if event.Title == "" {
+		return ""
+	}
+	return event.GrandparentTitle + "\x00" + event.Title
+}
+
+// Configure updates the detector configuration.
+func (d *AccountSharingDetector) Configure(config json.RawMessage) error {
+	var newConfig AccountSharingConfig
+	if err := json.Unmarshal(config, &newConfig); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Validate configuration
+	if newConfig.MinSessions < 2 {
+		return fmt.Errorf("min_sessions must be at least 2")
+	}
+	if newConfig.WindowMinutes <= 0 {
+		return fmt.Errorf("window_minutes must be positive")
+	}
+	if newConfig.MinDistanceKm < 0 {
+		return fmt.Errorf("min_distance_km cannot be negative")
+	}
+
+	d.mu.Lock()
+	d.config = newConfig
+	d.mu.Unlock()
+
+	return nil
+}
+
+// Enabled returns whether this detector is enabled.
+func (d *AccountSharingDetector) Enabled() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.enabled
+}
+
+// SetEnabled enables or disables the detector.
+func (d *AccountSharingDetector) SetEnabled(enabled bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.enabled = enabled
+}
+
+// Config returns the current configuration.
+func (d *AccountSharingDetector) Config() AccountSharingConfig {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.config
+}
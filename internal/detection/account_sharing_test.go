@@ -0,0 +1,170 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+// Session fixtures in three cities more than 50 km apart.
+func sharingEvent(sessionKey, ratingKey string, lat, lon float64, city string) DetectionEvent {
+	return DetectionEvent{
+		SessionKey: sessionKey,
+		UserID:     1,
+		Username:   "testuser",
+		RatingKey:  ratingKey,
+		Title:      "Title " + ratingKey,
+		Latitude:   lat,
+		Longitude:  lon,
+		City:       city,
+		Country:    "US",
+	}
+}
+
+var (
+	sharingNYC     = sharingEvent("s1", "100", 40.7128, -74.0060, "New York")
+	sharingLA      = sharingEvent("s2", "200", 34.0522, -118.2437, "Los Angeles")
+	sharingChicago = sharingEvent("s3", "300", 41.8781, -87.6298, "Chicago")
+)
+
+func TestNewAccountSharingDetector(t *testing.T) {
+	detector := NewAccountSharingDetector(&mockEventHistory{})
+
+	if detector.Type() != RuleTypeAccountSharing {
+		t.Errorf("Type() = %v, want %v", detector.Type(), RuleTypeAccountSharing)
+	}
+	if !detector.Enabled() {
+		t.Error("detector should be enabled by default")
+	}
+	if cfg := detector.Config(); cfg.MinSessions != 3 || cfg.WindowMinutes != 30 {
+		t.Errorf("Config() = %+v, want 3 sessions in 30 minutes", cfg)
+	}
+}
+
+func TestAccountSharingDetector_Check(t *testing.T) {
+	nycOtherTitle := sharingEvent("s4", "400", 40.7306, -73.9352, "Brooklyn")
+	laSameTitle := sharingLA
+	laSameTitle.RatingKey = "100"
+	noGeo := sharingEvent("s5", "500", 0, 0, "")
+
+	tests := []struct {
+		name       string
+		concurrent []DetectionEvent
+		wantAlert  bool
+	}{
+		{"no concurrent sessions", nil, false},
+		{"three titles in three cities", []DetectionEvent{sharingLA, sharingChicago}, true},
+		{"current session repeated in history", []DetectionEvent{sharingNYC, sharingLA, sharingChicago}, true},
+		{"only two cities", []DetectionEvent{sharingLA, nycOtherTitle}, false},
+		{"same title in another city", []DetectionEvent{laSameTitle, sharingChicago}, false},
+		{"concurrent session without geolocation", []DetectionEvent{sharingLA, noGeo}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewAccountSharingDetector(&mockEventHistory{simultaneousLocations: tt.concurrent})
+			current := sharingNYC
+
+			alert, err := detector.Check(context.Background(), &current)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (alert != nil) != tt.wantAlert {
+				t.Fatalf("alert = %v, want alert %v", alert, tt.wantAlert)
+			}
+			if alert == nil {
+				return
+			}
+
+			if alert.RuleType != RuleTypeAccountSharing || alert.Severity != SeverityCritical || alert.UserID != 1 {
+				t.Errorf("alert = %+v, want critical account_sharing for user 1", alert)
+			}
+			var metadata AccountSharingMetadata
+			if err := json.Unmarshal(alert.Metadata, &metadata); err != nil {
+				t.Fatalf("failed to unmarshal metadata: %v", err)
+			}
+			if len(metadata.Sessions) != 3 || metadata.Sessions[0].SessionKey != "s1" {
+				t.Errorf("sessions = %+v, want current session first and 3 in total", metadata.Sessions)
+			}
+		})
+	}
+}
+
+func TestAccountSharingDetector_Check_TitleFallback(t *testing.T) {
+	// Without rating keys, sessions are told apart by show and title
+	la, chicago := sharingLA, sharingChicago
+	la.RatingKey, chicago.RatingKey = "", ""
+	chicago.Title = sharingNYC.Title
+
+	detector := NewAccountSharingDetector(&mockEventHistory{simultaneousLocations: []DetectionEvent{la, chicago}})
+	current := sharingNYC
+	current.RatingKey = ""
+
+	alert, err := detector.Check(context.Background(), &current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alert != nil {
+		t.Error("expected no alert when two sessions play the same title")
+	}
+}
+
+func TestAccountSharingDetector_Check_ConfiguredMinSessions(t *testing.T) {
+	detector := NewAccountSharingDetector(&mockEventHistory{simultaneousLocations: []DetectionEvent{sharingLA}})
+	if err := detector.Configure(json.RawMessage(`{"min_sessions":2,"window_minutes":10,"min_distance_km":100,"severity":"warning"}`)); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	current := sharingNYC
+	alert, err := detector.Check(context.Background(), &current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alert == nil || alert.Severity != SeverityWarning {
+		t.Errorf("alert = %+v, want warning with min_sessions 2", alert)
+	}
+}
+
+func TestAccountSharingDetector_Check_Disabled(t *testing.T) {
+	detector := NewAccountSharingDetector(&mockEventHistory{simultaneousLocations: []DetectionEvent{sharingLA, sharingChicago}})
+	detector.SetEnabled(false)
+
+	current := sharingNYC
+	alert, err := detector.Check(context.Background(), &current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alert != nil {
+		t.Error("expected no alert when detector is disabled")
+	}
+}
+
+func TestAccountSharingDetector_Configure(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{"valid", `{"min_sessions":3,"window_minutes":30,"min_distance_km":50,"severity":"critical"}`, false},
+		{"min sessions too low", `{"min_sessions":1,"window_minutes":30,"min_distance_km":50}`, true},
+		{"zero window", `{"min_sessions":3,"window_minutes":0,"min_distance_km":50}`, true},
+		{"negative distance", `{"min_sessions":3,"window_minutes":30,"min_distance_km":-1}`, true},
+		{"invalid json", `{`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewAccountSharingDetector(&mockEventHistory{})
+			err := detector.Configure(json.RawMessage(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Configure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// TrustedLocation is an allowlist entry for a network or country that a user
+// legitimately streams from, such as a VPN exit or a travel destination.
+// Alerts for events matching an entry are suppressed and do not lower the
+// user's trust score.
+type TrustedLocation struct {
+	ID string `json:"id"`
+
+	// UserID scopes the entry to one user. Zero applies it to all users.
+	UserID int `json:"user_id"`
+
+	// CIDR matches the event's IP address, e.g. "203.0.113.0/24". A bare IP
+	// is stored as a single-address prefix.
+	CIDR string `json:"cidr,omitempty"`
+
+	// Country matches the event's geolocated country, compared
+	// case-insensitively. Use the value shown in alerts, e.g. "Canada".
+	Country string `json:"country,omitempty"`
+
+	Note      string    `json:"note,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Normalize validates the entry and puts its CIDR into canonical form.
+// Exactly one of CIDR and Country must be set.
+func (t *TrustedLocation) Normalize() error {
+	t.CIDR = strings.TrimSpace(t.CIDR)
+	t.Country = strings.TrimSpace(t.Country)
+
+	if t.UserID < 0 {
+		return fmt.Errorf("user_id cannot be negative")
+	}
+	if (t.CIDR == "") == (t.Country == "") {
+		return fmt.Errorf("exactly one of cidr or country is required")
+	}
+	if t.CIDR == "" {
+		return nil
+	}
+
+	prefix, err := parseTrustedPrefix(t.CIDR)
+	if err != nil {
+		return fmt.Errorf("invalid cidr %q: %w", t.CIDR, err)
+	}
+	t.CIDR = prefix.String()
+	return nil
+}
+
+// Matches reports whether the event comes from this trusted location.
+// The caller is responsible for checking that the entry applies to the
+// event's user.
+func (t *TrustedLocation) Matches(event *DetectionEvent) bool {
+	if t.Country != "" {
+		return strings.EqualFold(t.Country, event.Country)
+	}
+
+	prefix, err := parseTrustedPrefix(t.CIDR)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(event.ClientIP())
+	if err != nil {
+		return false
+	}
+	return prefix.Contains(addr.Unmap())
+}
+
+// AppliesTo reports whether the entry covers the given user.
+func (t *TrustedLocation) AppliesTo(userID int) bool {
+	return t.UserID == 0 || t.UserID == userID
+}
+
+// FindTrustedLocation returns the first entry that applies to the event's
+// user and matches the event, or nil.
+func FindTrustedLocation(entries []TrustedLocation, event *DetectionEvent) *TrustedLocation {
+	for i := range entries {
+		if entries[i].AppliesTo(event.UserID) && entries[i].Matches(event) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// parseTrustedPrefix parses a CIDR or bare IP address into a masked prefix.
+func parseTrustedPrefix(s string) (netip.Prefix, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+		prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+	}
+	return prefix.Masked(), nil
+}
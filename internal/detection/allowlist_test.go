@@ -0,0 +1,221 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/privacy"
+)
+
+// mockAllowlistStore implements AllowlistStore for testing
+type mockAllowlistStore struct {
+	entries []TrustedLocation
+	listErr error
+	calls   int
+}
+
+func (m *mockAllowlistStore) AddTrustedLocation(ctx context.Context, location *TrustedLocation) error {
+	m.entries = append(m.entries, *location)
+	return nil
+}
+
+func (m *mockAllowlistStore) ListTrustedLocations(ctx context.Context, userID *int) ([]TrustedLocation, error) {
+	m.calls++
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.entries, nil
+}
+
+func (m *mockAllowlistStore) DeleteTrustedLocation(ctx context.Context, id string) (bool, error) {
+	return false, nil
+}
+
+func TestTrustedLocation_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		location TrustedLocation
+		wantCIDR string
+		wantErr  bool
+	}{
+		{"ipv4 cidr", TrustedLocation{CIDR: "203.0.113.7/24"}, "203.0.113.0/24", false},
+		{"bare ipv4", TrustedLocation{CIDR: " 198.51.100.4 "}, "198.51.100.4/32", false},
+		{"ipv6 cidr", TrustedLocation{CIDR: "2001:db8::1/48"}, "2001:db8::/48", false},
+		{"ipv4-mapped", TrustedLocation{CIDR: "::ffff:203.0.113.0/120"}, "203.0.113.0/24", false},
+		{"country", TrustedLocation{UserID: 3, Country: "Canada"}, "", false},
+		{"invalid cidr", TrustedLocation{CIDR: "not-an-ip"}, "", true},
+		{"both set", TrustedLocation{CIDR: "203.0.113.0/24", Country: "Canada"}, "", true},
+		{"neither set", TrustedLocation{}, "", true},
+		{"negative user", TrustedLocation{UserID: -1, Country: "Canada"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.location
+			err := loc.Normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && loc.CIDR != tt.wantCIDR {
+				t.Errorf("CIDR = %q, want %q", loc.CIDR, tt.wantCIDR)
+			}
+		})
+	}
+}
+
+func TestTrustedLocation_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		location TrustedLocation
+		event    DetectionEvent
+		want     bool
+	}{
+		{"ip in cidr", TrustedLocation{CIDR: "203.0.113.0/24"}, DetectionEvent{IPAddress: "203.0.113.50"}, true},
+		{"ip outside cidr", TrustedLocation{CIDR: "203.0.113.0/24"}, DetectionEvent{IPAddress: "203.0.114.50"}, false},
+		{"ipv4-mapped event ip", TrustedLocation{CIDR: "203.0.113.0/24"}, DetectionEvent{IPAddress: "::ffff:203.0.113.9"}, true},
+		{"ipv6 in cidr", TrustedLocation{CIDR: "2001:db8::/32"}, DetectionEvent{IPAddress: "2001:db8:1::5"}, true},
+		{"missing event ip", TrustedLocation{CIDR: "203.0.113.0/24"}, DetectionEvent{}, false},
+		{"country case-insensitive", TrustedLocation{Country: "canada"}, DetectionEvent{Country: "Canada"}, true},
+		{"other country", TrustedLocation{Country: "Canada"}, DetectionEvent{Country: "Mexico"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.location.Matches(&tt.event); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTrustedLocation_UserScope(t *testing.T) {
+	entries := []TrustedLocation{
+		{ID: "user-2", UserID: 2, Country: "Canada"},
+		{ID: "global", UserID: 0, CIDR: "203.0.113.0/24"},
+	}
+
+	if got := FindTrustedLocation(entries, &DetectionEvent{UserID: 1, Country: "Canada"}); got != nil {
+		t.Errorf("another user's entry matched: %+v", got)
+	}
+	if got := FindTrustedLocation(entries, &DetectionEvent{UserID: 2, Country: "Canada"}); got == nil || got.ID != "user-2" {
+		t.Errorf("got %+v, want user-2 entry", got)
+	}
+	if got := FindTrustedLocation(entries, &DetectionEvent{UserID: 1, IPAddress: "203.0.113.1"}); got == nil || got.ID != "global" {
+		t.Errorf("got %+v, want global entry", got)
+	}
+}
+
+// londonEventAfterNYC returns an engine with impossible travel registered and
+// an event that triggers it: NYC 30 minutes ago, London now.
+func londonEventAfterNYC(allowlist AllowlistStore) (*Engine, *mockAlertStore, *DetectionEvent) {
+	eventHistory := &mockEventHistory{
+		lastEvent: &DetectionEvent{
+			UserID:    1,
+			Latitude:  40.7128,
+			Longitude: -74.0060,
+			Timestamp: time.Now().Add(-30 * time.Minute),
+		},
+	}
+	alertStore := &mockAlertStore{}
+	engine := NewEngine(alertStore, newMockTrustStore(), eventHistory, &mockBroadcaster{})
+	engine.RegisterDetector(NewImpossibleTravelDetector(eventHistory))
+	if allowlist != nil {
+		engine.SetAllowlist(allowlist)
+	}
+
+	event := &DetectionEvent{
+		UserID:    1,
+		Username:  "traveler",
+		IPAddress: "203.0.113.10",
+		Latitude:  51.5074,
+		Longitude: -0.1278,
+		Country:   "United Kingdom",
+		Timestamp: time.Now(),
+	}
+	return engine, alertStore, event
+}
+
+func TestEngine_Process_TrustedLocationSuppressesAlert(t *testing.T) {
+	allowlist := &mockAllowlistStore{entries: []TrustedLocation{{ID: "vpn", UserID: 1, CIDR: "203.0.113.0/24"}}}
+	engine, alertStore, event := londonEventAfterNYC(allowlist)
+	defer engine.Close()
+
+	alerts, err := engine.Process(context.Background(), event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 0 || len(alertStore.alerts) != 0 {
+		t.Errorf("got %d alerts (%d saved), want suppressed", len(alerts), len(alertStore.alerts))
+	}
+
+	metrics := engine.Metrics()
+	if metrics.AlertsSuppressed != 1 || metrics.AlertsGenerated != 0 {
+		t.Errorf("suppressed = %d, generated = %d, want 1 and 0", metrics.AlertsSuppressed, metrics.AlertsGenerated)
+	}
+}
+
+func TestEngine_Process_TrustedCIDRUsesAddressBeforeAnonymization(t *testing.T) {
+	setIPMode(t, privacy.IPModeHashed)
+	allowlist := &mockAllowlistStore{entries: []TrustedLocation{{ID: "vpn", UserID: 1, CIDR: "203.0.113.0/24"}}}
+	engine, alertStore, event := londonEventAfterNYC(allowlist)
+	defer engine.Close()
+
+	alerts, err := engine.Process(context.Background(), event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 0 || len(alertStore.alerts) != 0 {
+		t.Errorf("got %d alerts (%d saved), want suppressed by the CIDR", len(alerts), len(alertStore.alerts))
+	}
+	if !strings.HasPrefix(event.IPAddress, privacy.HashedPrefix) || event.ClientIP() != "203.0.113.10" {
+		t.Errorf("IPAddress = %q, ClientIP() = %q; want hashed and the original", event.IPAddress, event.ClientIP())
+	}
+}
+
+func TestEngine_Process_UntrustedLocationAlerts(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist *mockAllowlistStore
+	}{
+		{"other user's entry", &mockAllowlistStore{entries: []TrustedLocation{{UserID: 2, Country: "United Kingdom"}}}},
+		{"lookup error", &mockAllowlistStore{listErr: errors.New("db down")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, _, event := londonEventAfterNYC(tt.allowlist)
+			defer engine.Close()
+
+			alerts, err := engine.Process(context.Background(), event)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(alerts) != 1 {
+				t.Errorf("expected 1 alert, got %d", len(alerts))
+			}
+		})
+	}
+}
+
+func TestEngine_Process_AllowlistQueriedOnlyOnAlert(t *testing.T) {
+	allowlist := &mockAllowlistStore{}
+	engine, _, event := londonEventAfterNYC(allowlist)
+	defer engine.Close()
+
+	// Same place as the last event: no alert, so no allowlist lookup
+	event.Latitude, event.Longitude = 40.7128, -74.0060
+	if _, err := engine.Process(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if allowlist.calls != 0 {
+		t.Errorf("allowlist queried %d times, want 0", allowlist.calls)
+	}
+}
@@ -0,0 +1,246 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/vpn"
+)
+
+// RuleTypeDatacenterStream detects streaming from hosting provider
+// addresses, such as a proxy or relay on a rented server.
+const RuleTypeDatacenterStream RuleType = "datacenter_stream"
+
+// DatacenterStreamConfig configures the datacenter stream detector.
+type DatacenterStreamConfig struct {
+	// Severity for generated alerts.
+	Severity Severity `json:"severity"`
+
+	// ExcludedProviders are hosting providers to ignore (e.g., a provider
+	// the server owner runs a relay on).
+	ExcludedProviders []string `json:"excluded_providers,omitempty"`
+
+	// ExcludedUsers are user IDs to exclude from datacenter detection.
+	ExcludedUsers []int `json:"excluded_users,omitempty"`
+
+	// AlertCooldownMinutes is the minimum time between alerts for the same
+	// user and provider; 0 alerts on every session.
+	AlertCooldownMinutes int `json:"alert_cooldown_minutes"`
+}
+
+// DefaultDatacenterStreamConfig returns sensible defaults.
+func DefaultDatacenterStreamConfig() DatacenterStreamConfig {
+	return DatacenterStreamConfig{
+		Severity:             SeverityWarning,
+		ExcludedProviders:    []string{},
+		ExcludedUsers:        []int{},
+		AlertCooldownMinutes: 60,
+	}
+}
+
+// DatacenterStreamMetadata contains details for datacenter stream alerts.
+type DatacenterStreamMetadata struct {
+	// Provider is the hosting provider.
+	Provider string `json:"provider"`
+
+	// MatchedRange is the datacenter range containing the address.
+	MatchedRange string `json:"matched_range"`
+
+	// GeolocationCountry is the geolocation country of the address.
+	GeolocationCountry string `json:"geolocation_country,omitempty"`
+}
+
+// DatacenterLookupService defines the interface for datacenter IP lookup.
+// This interface allows for mocking in tests.
+type DatacenterLookupService interface {
+	// LookupDatacenter returns the datacenter range containing an address.
+	LookupDatacenter(ip string) *vpn.DatacenterResult
+
+	// IsVPN checks if an address belongs to a known VPN provider.
+	IsVPN(ip string) bool
+
+	// Enabled returns whether detection is enabled.
+	Enabled() bool
+}
+
+// DatacenterStreamDetector detects streaming from datacenter addresses.
+// Known VPN addresses are left to the VPN usage detector, so one session
+// does not raise both alerts.
+type DatacenterStreamDetector struct {
+	config  DatacenterStreamConfig
+	enabled bool
+	svc     DatacenterLookupService
+
+	// lastAlert is when each user and provider last raised an alert
+	lastAlert map[string]time.Time
+
+	mu sync.RWMutex
+}
+
+// NewDatacenterStreamDetector creates a new datacenter stream detector.
+func NewDatacenterStreamDetector(svc DatacenterLookupService) *DatacenterStreamDetector {
+	return &DatacenterStreamDetector{
+		config:    DefaultDatacenterStreamConfig(),
+		enabled:   true,
+		svc:       svc,
+		lastAlert: make(map[string]time.Time),
+	}
+}
+
+// Type returns the rule type.
+func (d *DatacenterStreamDetector) Type() RuleType {
+	return RuleTypeDatacenterStream
+}
+
+// Check evaluates the event for a datacenter address.
+func (d *DatacenterStreamDetector) Check(ctx context.Context, event *DetectionEvent) (*Alert, error) {
+	d.mu.RLock()
+	if !d.enabled {
+		d.mu.RUnlock()
+		return nil, nil
+	}
+	config := d.config
+	d.mu.RUnlock()
+
+	for _, excludedUser := range config.ExcludedUsers {
+		if event.UserID == excludedUser {
+			return nil, nil
+		}
+	}
+
+	// Skip events without an address and LAN connections
+	if event.IPAddress == "" || event.LocationType == "lan" {
+		return nil, nil
+	}
+
+	if d.svc == nil || !d.svc.Enabled() {
+		return nil, nil
+	}
+
+	// Look up the address from before anonymization
+	ip := event.ClientIP()
+	result := d.svc.LookupDatacenter(ip)
+	if !result.IsDatacenter || d.svc.IsVPN(ip) {
+		return nil, nil
+	}
+
+	for _, excludedProvider := range config.ExcludedProviders {
+		if result.Provider == excludedProvider {
+			return nil, nil
+		}
+	}
+
+	now := event.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if !d.startCooldown(event.UserID, result.Provider, now, config.AlertCooldownMinutes) {
+		return nil, nil
+	}
+
+	metadataJSON, err := json.Marshal(DatacenterStreamMetadata{
+		Provider:           result.Provider,
+		MatchedRange:       result.MatchedRange,
+		GeolocationCountry: event.Country,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	return &Alert{
+		RuleType:  RuleTypeDatacenterStream,
+		UserID:    event.UserID,
+		Username:  event.Username,
+		ServerID:  event.ServerID,
+		IPAddress: event.IPAddress,
+		Severity:  config.Severity,
+		Title:     "Datacenter Stream Detected",
+		Message: fmt.Sprintf("User %s is streaming from a %s datacenter address (range %s)",
+			event.Username, result.Provider, result.MatchedRange),
+		Metadata:  metadataJSON,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// startCooldown reports whether an alert for the user and provider is due
+// at now, and if so records it.
+func (d *DatacenterStreamDetector) startCooldown(userID int, provider string, now time.Time, cooldownMinutes int) bool {
+	key := strconv.Itoa(userID) + "|" + provider
+	cooldown := time.Duration(cooldownMinutes) * time.Minute
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if last, ok := d.lastAlert[key]; ok && cooldown > 0 && now.Sub(last) < cooldown {
+		return false
+	}
+
+	// Forget expired entries so the map does not grow without bound
+	for k, last := range d.lastAlert {
+		if now.Sub(last) >= cooldown {
+			delete(d.lastAlert, k)
+		}
+	}
+	d.lastAlert[key] = now
+	return true
+}
+
+// Configure updates the detector configuration.
+func (d *DatacenterStreamDetector) Configure(config json.RawMessage) error {
+	var newConfig DatacenterStreamConfig
+	if err := json.Unmarshal(config, &newConfig); err != nil {
+		return fmt.Errorf("failed to parse datacenter stream config: %w", err)
+	}
+
+	if newConfig.Severity != "" &&
+		newConfig.Severity != SeverityInfo &&
+		newConfig.Severity != SeverityWarning &&
+		newConfig.Severity != SeverityCritical {
+		return fmt.Errorf("invalid severity: %s", newConfig.Severity)
+	}
+	if newConfig.AlertCooldownMinutes < 0 {
+		return fmt.Errorf("alert_cooldown_minutes must not be negative")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Merge with defaults for unset values
+	if newConfig.Severity == "" {
+		newConfig.Severity = d.config.Severity
+	}
+
+	d.config = newConfig
+	return nil
+}
+
+// Enabled returns whether this detector is enabled.
+func (d *DatacenterStreamDetector) Enabled() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.enabled
+}
+
+// SetEnabled enables or disables the detector.
+func (d *DatacenterStreamDetector) SetEnabled(enabled bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.enabled = enabled
+}
+
+// Config returns the current configuration.
+func (d *DatacenterStreamDetector) Config() DatacenterStreamConfig {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.config
+}
@@ -0,0 +1,155 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/vpn"
+)
+
+// mockDatacenterService implements DatacenterLookupService for testing.
+type mockDatacenterService struct {
+	lookup  *vpn.Lookup
+	enabled bool
+}
+
+func (s *mockDatacenterService) LookupDatacenter(ip string) *vpn.DatacenterResult {
+	return s.lookup.LookupDatacenter(ip)
+}
+
+func (s *mockDatacenterService) IsVPN(ip string) bool {
+	return s.lookup.ContainsIP(ip)
+}
+
+func (s *mockDatacenterService) Enabled() bool {
+	return s.enabled
+}
+
+func newDatacenterTestDetector(t *testing.T) *DatacenterStreamDetector {
+	t.Helper()
+	lookup := vpn.NewLookup()
+	lookup.SetDatacenterRanges([]vpn.DatacenterRange{
+		{CIDR: "203.0.113.0/24", Provider: "Example Cloud"},
+		{CIDR: "198.51.100.0/24", Provider: "Other Host"},
+	})
+	if err := lookup.AddServer(&vpn.Server{Provider: "nordvpn", Country: "US", IPs: []string{"203.0.113.50"}}); err != nil {
+		t.Fatal(err)
+	}
+	return NewDatacenterStreamDetector(&mockDatacenterService{lookup: lookup, enabled: true})
+}
+
+func datacenterEvent(ip string, at time.Time) *DetectionEvent {
+	return &DetectionEvent{
+		UserID:       1,
+		Username:     "testuser",
+		IPAddress:    ip,
+		LocationType: "wan",
+		Timestamp:    at,
+	}
+}
+
+func TestDatacenterStreamDetector_DetectsDatacenterIP(t *testing.T) {
+	detector := newDatacenterTestDetector(t)
+
+	alert, err := detector.Check(context.Background(), datacenterEvent("203.0.113.7", time.Now()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alert == nil {
+		t.Fatal("expected an alert for a datacenter address")
+	}
+	if alert.RuleType != RuleTypeDatacenterStream || alert.Severity != SeverityWarning {
+		t.Errorf("alert = %s/%s, want %s/%s", alert.RuleType, alert.Severity, RuleTypeDatacenterStream, SeverityWarning)
+	}
+
+	var metadata DatacenterStreamMetadata
+	if err := json.Unmarshal(alert.Metadata, &metadata); err != nil {
+		t.Fatalf("failed to unmarshal metadata: %v", err)
+	}
+	if metadata.Provider != "Example Cloud" || metadata.MatchedRange != "203.0.113.0/24" {
+		t.Errorf("metadata = %+v", metadata)
+	}
+}
+
+func TestDatacenterStreamDetector_Skips(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *DetectionEvent
+	}{
+		{"residential address", datacenterEvent("192.0.2.10", time.Now())},
+		{"known VPN address", datacenterEvent("203.0.113.50", time.Now())},
+		{"LAN connection", &DetectionEvent{UserID: 1, IPAddress: "203.0.113.7", LocationType: "lan"}},
+		{"excluded user", &DetectionEvent{UserID: 42, IPAddress: "203.0.113.7", LocationType: "wan"}},
+		{"excluded provider", datacenterEvent("198.51.100.7", time.Now())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := newDatacenterTestDetector(t)
+			if err := detector.Configure(json.RawMessage(`{"excluded_users":[42],"excluded_providers":["Other Host"]}`)); err != nil {
+				t.Fatal(err)
+			}
+
+			alert, err := detector.Check(context.Background(), tt.event)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if alert != nil {
+				t.Errorf("unexpected alert: %s", alert.Message)
+			}
+		})
+	}
+}
+
+func TestDatacenterStreamDetector_Cooldown(t *testing.T) {
+	detector := newDatacenterTestDetector(t)
+	ctx := context.Background()
+	start := time.Now()
+
+	alert, _ := detector.Check(ctx, datacenterEvent("203.0.113.7", start))
+	if alert == nil {
+		t.Fatal("expected the first session to alert")
+	}
+	alert, _ = detector.Check(ctx, datacenterEvent("203.0.113.8", start.Add(30*time.Minute)))
+	if alert != nil {
+		t.Error("expected no alert within the cooldown")
+	}
+	alert, _ = detector.Check(ctx, datacenterEvent("203.0.113.7", start.Add(61*time.Minute)))
+	if alert == nil {
+		t.Error("expected an alert after the cooldown")
+	}
+}
+
+func TestDatacenterStreamDetector_Configure(t *testing.T) {
+	detector := NewDatacenterStreamDetector(nil)
+	if detector.Type() != RuleTypeDatacenterStream {
+		t.Errorf("Type() = %s", detector.Type())
+	}
+
+	if err := detector.Configure(json.RawMessage(`{"severity":"critical","alert_cooldown_minutes":0}`)); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if config := detector.Config(); config.Severity != SeverityCritical || config.AlertCooldownMinutes != 0 {
+		t.Errorf("config = %+v", config)
+	}
+
+	if err := detector.Configure(json.RawMessage(`{"severity":"extreme"}`)); err == nil {
+		t.Error("expected an error for an invalid severity")
+	}
+	if err := detector.Configure(json.RawMessage(`{"alert_cooldown_minutes":-1}`)); err == nil {
+		t.Error("expected an error for a negative cooldown")
+	}
+
+	// Without a lookup service nothing is detected
+	alert, err := detector.Check(context.Background(), datacenterEvent("203.0.113.7", time.Now()))
+	if err != nil || alert != nil {
+		t.Errorf("Check() = %v, %v; want nil, nil", alert, err)
+	}
+}
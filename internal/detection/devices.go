@@ -0,0 +1,66 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxDeviceNameLength bounds the custom name an admin can give a device.
+const maxDeviceNameLength = 100
+
+// UserDevice is an entry in a user's device inventory, keyed by the client's
+// machine ID. Rows are maintained by playback event inserts: each stored
+// session updates last seen, the session count, and the client details.
+type UserDevice struct {
+	UserID   int    `json:"user_id"`
+	DeviceID string `json:"device_id"`
+
+	// Name is a custom name set by an admin; empty until renamed.
+	Name string `json:"name,omitempty"`
+
+	// Flagged marks a device an admin has reviewed as suspicious.
+	Flagged bool `json:"flagged"`
+
+	// Client details from the most recent session.
+	Player   string `json:"player,omitempty"`
+	Product  string `json:"product,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Device   string `json:"device,omitempty"`
+
+	FirstSeenAt     time.Time `json:"first_seen_at"`
+	FirstSessionKey string    `json:"first_session_key,omitempty"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+	LastIPAddress   string    `json:"last_ip_address,omitempty"`
+	SessionCount    int       `json:"session_count"`
+
+	// LastLocation is the geolocation of LastIPAddress, when known.
+	LastLocation *Geolocation `json:"last_location,omitempty"`
+}
+
+// UserDeviceUpdate changes the admin-managed fields of a device. Nil fields
+// are left unchanged; an empty name clears the custom name.
+type UserDeviceUpdate struct {
+	Name    *string `json:"name"`
+	Flagged *bool   `json:"flagged"`
+}
+
+// Normalize trims the name and validates the update.
+func (u *UserDeviceUpdate) Normalize() error {
+	if u.Name == nil && u.Flagged == nil {
+		return fmt.Errorf("name or flagged is required")
+	}
+	if u.Name != nil {
+		name := strings.TrimSpace(*u.Name)
+		if len(name) > maxDeviceNameLength {
+			return fmt.Errorf("name must be at most %d characters", maxDeviceNameLength)
+		}
+		u.Name = &name
+	}
+	return nil
+}
@@ -12,7 +12,7 @@
 //	MediaEvent -> Detection Engine -> Alert -> Notification System
 //	               |                    |
 //	               v                    v
-//	         Rule Evaluators     WebSocket/Discord/Webhook
+//	         Rule Evaluators     WebSocket/Discord/Slack/Email/Webhook
 //
 // The detection engine integrates with the existing NATS JetStream event
 // pipeline via Watermill handlers. Each playback event is evaluated against
@@ -12,7 +12,9 @@ import (
 	"time"
 
 	"github.com/goccy/go-json"
+	"github.com/tomtom215/cartographus/internal/clock"
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // Engine coordinates detection rule evaluation and alert generation.
@@ -21,13 +23,29 @@ type Engine struct {
 	alertStore   AlertStore
 	trustStore   TrustStore
 	eventHistory EventHistory
-	notifiers    []Notifier
+	allowlist    AllowlistStore
+	notifiers    []registeredNotifier
 	broadcaster  AlertBroadcaster
 
 	mu            sync.RWMutex
 	enabled       bool
 	metricsStore  *EngineMetrics
 	violationChan chan *Alert // Internal channel for trust score updates
+
+	// Trust score points lost per violation and recovered per day, set by
+	// SetTrustScoreSettings (guarded by mu)
+	trustDecrement int
+	trustRecovery  int
+
+	// clock drives the trust score recovery schedule and metric timestamps
+	clock clock.Clock
+}
+
+// registeredNotifier pairs a notifier with the lowest alert severity it
+// receives. An empty minSeverity receives every alert.
+type registeredNotifier struct {
+	notifier    Notifier
+	minSeverity Severity
 }
 
 // AlertBroadcaster broadcasts alerts via WebSocket.
@@ -37,22 +55,23 @@ type AlertBroadcaster interface {
 
 // EngineMetrics tracks detection engine performance.
 type EngineMetrics struct {
-	EventsProcessed  int64
-	AlertsGenerated  int64
-	DetectionErrors  int64
-	ProcessingTimeMs int64
-	LastProcessedAt  time.Time
-	DetectorMetrics  map[RuleType]*DetectorMetrics
+	EventsProcessed  int64                         `json:"events_processed"`
+	AlertsGenerated  int64                         `json:"alerts_generated"`
+	AlertsSuppressed int64                         `json:"alerts_suppressed"` // Alerts dropped because the event matched a trusted location
+	DetectionErrors  int64                         `json:"detection_errors"`
+	ProcessingTimeMs int64                         `json:"processing_time_ms"`
+	LastProcessedAt  time.Time                     `json:"last_processed_at"`
+	DetectorMetrics  map[RuleType]*DetectorMetrics `json:"detector_metrics"`
 	mu               sync.RWMutex
 }
 
 // DetectorMetrics tracks individual detector performance.
 type DetectorMetrics struct {
-	EventsChecked   int64
-	AlertsGenerated int64
-	Errors          int64
-	AvgProcessingMs float64
-	LastTriggeredAt *time.Time
+	EventsChecked   int64      `json:"events_checked"`
+	AlertsGenerated int64      `json:"alerts_generated"`
+	Errors          int64      `json:"errors"`
+	AvgProcessingMs float64    `json:"avg_processing_ms"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
 }
 
 // EngineConfig configures the detection engine.
@@ -86,16 +105,32 @@ func NewEngine(
 	trustStore TrustStore,
 	eventHistory EventHistory,
 	broadcaster AlertBroadcaster,
+) *Engine {
+	return NewEngineWithClock(alertStore, trustStore, eventHistory, broadcaster, clock.Real())
+}
+
+// NewEngineWithClock creates a detection engine that reads time from clk,
+// so tests can run the trust score recovery schedule with a clock.Fake. A
+// nil clk uses the real clock.
+func NewEngineWithClock(
+	alertStore AlertStore,
+	trustStore TrustStore,
+	eventHistory EventHistory,
+	broadcaster AlertBroadcaster,
+	clk clock.Clock,
 ) *Engine {
 	e := &Engine{
-		detectors:     make(map[RuleType]Detector),
-		alertStore:    alertStore,
-		trustStore:    trustStore,
-		eventHistory:  eventHistory,
-		broadcaster:   broadcaster,
-		notifiers:     make([]Notifier, 0),
-		enabled:       true,
-		violationChan: make(chan *Alert, 100),
+		detectors:      make(map[RuleType]Detector),
+		alertStore:     alertStore,
+		trustStore:     trustStore,
+		eventHistory:   eventHistory,
+		broadcaster:    broadcaster,
+		notifiers:      make([]registeredNotifier, 0),
+		enabled:        true,
+		violationChan:  make(chan *Alert, 100),
+		trustDecrement: DefaultEngineConfig().TrustScoreDecrement,
+		trustRecovery:  DefaultEngineConfig().TrustScoreRecovery,
+		clock:          clock.OrReal(clk),
 		metricsStore: &EngineMetrics{
 			DetectorMetrics: make(map[RuleType]*DetectorMetrics),
 		},
@@ -119,13 +154,37 @@ func (e *Engine) RegisterDetector(detector Detector) {
 	logging.Info().Str("detector", string(ruleType)).Msg("registered detector")
 }
 
-// RegisterNotifier adds a notifier to the engine.
+// RegisterNotifier adds a notifier to the engine. The notifier receives
+// alerts of every severity.
 func (e *Engine) RegisterNotifier(notifier Notifier) {
+	e.RegisterNotifierWithMinSeverity(notifier, "")
+}
+
+// RegisterNotifierWithMinSeverity adds a notifier that only receives alerts
+// at or above minSeverity, so low-value rules do not page high-urgency
+// channels. An empty minSeverity receives every alert.
+func (e *Engine) RegisterNotifierWithMinSeverity(notifier Notifier, minSeverity Severity) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	e.notifiers = append(e.notifiers, notifier)
-	logging.Info().Str("notifier", notifier.Name()).Msg("registered notifier")
+	e.notifiers = append(e.notifiers, registeredNotifier{
+		notifier:    notifier,
+		minSeverity: minSeverity,
+	})
+
+	evt := logging.Info().Str("notifier", notifier.Name())
+	if minSeverity != "" {
+		evt = evt.Str("min_severity", string(minSeverity))
+	}
+	evt.Msg("registered notifier")
+}
+
+// SetAllowlist sets the store of trusted locations. Alerts for events that
+// match a trusted location are suppressed and do not lower trust scores.
+func (e *Engine) SetAllowlist(allowlist AllowlistStore) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.allowlist = allowlist
 }
 
 // Process evaluates an event against all enabled detection rules.
@@ -135,13 +194,15 @@ func (e *Engine) Process(ctx context.Context, event *DetectionEvent) ([]*Alert,
 		return nil, nil
 	}
 
-	start := time.Now()
+	start := e.clock.Now()
 
-	// Enrich event with geolocation if not present
+	// Enrich event with geolocation if not present, then anonymize its
+	// address so detectors, alerts and notifications never see the real one
 	e.enrichWithGeolocation(ctx, event)
+	anonymizeEvent(event)
 
 	// Run detectors and collect alerts
-	alerts, errs := e.runDetectors(ctx, detectors, event)
+	alerts, errs := e.runDetectors(ctx, detectors, event, e.trustedLocationLookup(ctx, event))
 
 	// Update processing metrics
 	e.updateProcessingMetrics(start)
@@ -194,13 +255,58 @@ func (e *Engine) enrichWithGeolocation(ctx context.Context, event *DetectionEven
 	}
 }
 
+// anonymizeEvent applies PRIVACY_IP_MODE to the event's address, keeping
+// the original for ClientIP.
+func anonymizeEvent(event *DetectionEvent) {
+	if event.clientIP == "" {
+		event.clientIP = event.IPAddress
+	}
+	event.IPAddress = privacy.AnonymizeIP(event.IPAddress)
+}
+
+// trustedLocationLookup returns a function that finds the trusted location
+// matching the event. The allowlist is queried at most once, and only when a
+// detector raises an alert.
+func (e *Engine) trustedLocationLookup(ctx context.Context, event *DetectionEvent) func() *TrustedLocation {
+	var (
+		loaded bool
+		match  *TrustedLocation
+	)
+	return func() *TrustedLocation {
+		if !loaded {
+			loaded = true
+			match = e.findTrustedLocation(ctx, event)
+		}
+		return match
+	}
+}
+
+// findTrustedLocation returns the allowlist entry matching the event, or nil.
+// Lookup errors are logged and treated as no match so alerts are not lost.
+func (e *Engine) findTrustedLocation(ctx context.Context, event *DetectionEvent) *TrustedLocation {
+	e.mu.RLock()
+	allowlist := e.allowlist
+	e.mu.RUnlock()
+
+	if allowlist == nil {
+		return nil
+	}
+
+	entries, err := allowlist.ListTrustedLocations(ctx, &event.UserID)
+	if err != nil {
+		logging.Warn().Err(err).Int("user_id", event.UserID).Msg("failed to load trusted locations")
+		return nil
+	}
+	return FindTrustedLocation(entries, event)
+}
+
 // runDetectors executes all detectors against the event and returns alerts and errors.
-func (e *Engine) runDetectors(ctx context.Context, detectors []Detector, event *DetectionEvent) ([]*Alert, []error) {
+func (e *Engine) runDetectors(ctx context.Context, detectors []Detector, event *DetectionEvent, trusted func() *TrustedLocation) ([]*Alert, []error) {
 	var alerts []*Alert
 	var errs []error
 
 	for _, detector := range detectors {
-		alert, err := e.runSingleDetector(ctx, detector, event)
+		alert, err := e.runSingleDetector(ctx, detector, event, trusted)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -214,7 +320,9 @@ func (e *Engine) runDetectors(ctx context.Context, detectors []Detector, event *
 }
 
 // runSingleDetector executes one detector and updates its metrics.
-func (e *Engine) runSingleDetector(ctx context.Context, detector Detector, event *DetectionEvent) (*Alert, error) {
+// Alerts for events from a trusted location are dropped before they are
+// counted or lower the user's trust score.
+func (e *Engine) runSingleDetector(ctx context.Context, detector Detector, event *DetectionEvent, trusted func() *TrustedLocation) (*Alert, error) {
 	ruleType := detector.Type()
 
 	// Increment events checked
@@ -237,11 +345,24 @@ func (e *Engine) runSingleDetector(ctx context.Context, detector Detector, event
 	}
 
 	if alert != nil {
+		if loc := trusted(); loc != nil {
+			e.metricsStore.mu.Lock()
+			e.metricsStore.AlertsSuppressed++
+			e.metricsStore.mu.Unlock()
+
+			logging.Debug().
+				Str("rule_type", string(ruleType)).
+				Int("user_id", event.UserID).
+				Str("trusted_location", loc.ID).
+				Msg("alert suppressed by trusted location")
+			return nil, nil
+		}
+
 		// Update metrics for generated alert
 		e.metricsStore.mu.Lock()
 		if metrics, ok := e.metricsStore.DetectorMetrics[ruleType]; ok {
 			metrics.AlertsGenerated++
-			now := time.Now()
+			now := e.clock.Now()
 			metrics.LastTriggeredAt = &now
 		}
 		e.metricsStore.AlertsGenerated++
@@ -260,11 +381,11 @@ func (e *Engine) runSingleDetector(ctx context.Context, detector Detector, event
 
 // updateProcessingMetrics records processing time and event count.
 func (e *Engine) updateProcessingMetrics(start time.Time) {
-	processingTime := time.Since(start)
+	processingTime := e.clock.Now().Sub(start)
 	e.metricsStore.mu.Lock()
 	e.metricsStore.EventsProcessed++
 	e.metricsStore.ProcessingTimeMs = processingTime.Milliseconds()
-	e.metricsStore.LastProcessedAt = time.Now()
+	e.metricsStore.LastProcessedAt = e.clock.Now()
 	e.metricsStore.mu.Unlock()
 }
 
@@ -284,7 +405,8 @@ func (e *Engine) processViolations() {
 
 		// Decrement trust score
 		if e.trustStore != nil {
-			if err := e.trustStore.DecrementTrustScore(ctx, alert.UserID, 10); err != nil {
+			decrement, _ := e.trustScoreSettings()
+			if err := e.trustStore.DecrementTrustScore(ctx, alert.UserID, decrement, alert.RuleType); err != nil {
 				logging.Error().Err(err).Int("user_id", alert.UserID).Msg("failed to update trust score")
 			}
 		}
@@ -293,11 +415,34 @@ func (e *Engine) processViolations() {
 	}
 }
 
+// SetTrustScoreSettings sets the trust score points lost per violation and
+// recovered per recovery run. It can be called while the engine runs, for
+// config hot-reload; non-positive values are ignored.
+func (e *Engine) SetTrustScoreSettings(decrement, recovery int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if decrement > 0 {
+		e.trustDecrement = decrement
+	}
+	if recovery > 0 {
+		e.trustRecovery = recovery
+	}
+}
+
+// trustScoreSettings returns the current decrement and recovery amounts.
+func (e *Engine) trustScoreSettings() (decrement, recovery int) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.trustDecrement, e.trustRecovery
+}
+
 // StartTrustScoreRecovery starts the daily trust score recovery scheduler.
 // This should be called from main.go after engine creation.
 //
 // The recovery job runs once per day (at the specified interval) and increments
 // all users' trust scores by the specified amount, up to a maximum of 100.
+// Each run uses the amount current at the time, so SetTrustScoreSettings
+// applies from the next run.
 //
 // Parameters:
 //   - ctx: Context for cancellation (stop when context is done)
@@ -309,14 +454,16 @@ func (e *Engine) StartTrustScoreRecovery(ctx context.Context, recoveryAmount int
 		return
 	}
 
+	e.SetTrustScoreSettings(0, recoveryAmount)
 	logging.Info().Int("amount", recoveryAmount).Str("interval", interval.String()).Msg("starting trust score recovery scheduler")
 
 	// Run immediately on startup, then on interval
 	go func() {
 		// Run once at startup
-		e.runRecovery(recoveryAmount)
+		_, recovery := e.trustScoreSettings()
+		e.runRecovery(recovery)
 
-		ticker := time.NewTicker(interval)
+		ticker := e.clock.NewTicker(interval)
 		defer ticker.Stop()
 
 		for {
@@ -324,8 +471,9 @@ func (e *Engine) StartTrustScoreRecovery(ctx context.Context, recoveryAmount int
 			case <-ctx.Done():
 				logging.Info().Msg("trust score recovery scheduler stopped")
 				return
-			case <-ticker.C:
-				e.runRecovery(recoveryAmount)
+			case <-ticker.C():
+				_, recovery := e.trustScoreSettings()
+				e.runRecovery(recovery)
 			}
 		}
 	}()
@@ -343,28 +491,39 @@ func (e *Engine) runRecovery(amount int) {
 	}
 }
 
-// notify sends alerts to all enabled notifiers.
+// Notify sends an alert raised outside the detectors, such as a critical
+// Plex buffer health alert, to the registered notifiers. The alert is not
+// stored or broadcast, and minimum severities apply as for detector alerts.
+func (e *Engine) Notify(ctx context.Context, alert *Alert) {
+	e.notify(ctx, []*Alert{alert})
+}
+
+// notify sends alerts to all enabled notifiers whose minimum severity the
+// alert meets.
 func (e *Engine) notify(ctx context.Context, alerts []*Alert) {
 	if len(alerts) == 0 {
 		return
 	}
 
 	e.mu.RLock()
-	notifiers := make([]Notifier, 0, len(e.notifiers))
+	notifiers := make([]registeredNotifier, 0, len(e.notifiers))
 	for _, n := range e.notifiers {
-		if n.Enabled() {
+		if n.notifier.Enabled() {
 			notifiers = append(notifiers, n)
 		}
 	}
 	e.mu.RUnlock()
 
 	for _, alert := range alerts {
-		for _, notifier := range notifiers {
+		for _, rn := range notifiers {
+			if !alert.Severity.AtLeast(rn.minSeverity) {
+				continue
+			}
 			go func(n Notifier, a *Alert) {
 				if err := n.Send(ctx, a); err != nil {
 					logging.Error().Err(err).Str("notifier", n.Name()).Msg("failed to send alert")
 				}
-			}(notifier, alert)
+			}(rn.notifier, alert)
 		}
 	}
 }
@@ -429,6 +588,7 @@ func (e *Engine) Metrics() EngineMetrics {
 	return EngineMetrics{
 		EventsProcessed:  e.metricsStore.EventsProcessed,
 		AlertsGenerated:  e.metricsStore.AlertsGenerated,
+		AlertsSuppressed: e.metricsStore.AlertsSuppressed,
 		DetectionErrors:  e.metricsStore.DetectionErrors,
 		ProcessingTimeMs: e.metricsStore.ProcessingTimeMs,
 		LastProcessedAt:  e.metricsStore.LastProcessedAt,
@@ -11,6 +11,9 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/clock"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // mockAlertStore implements AlertStore for testing
@@ -91,7 +94,7 @@ func (m *mockTrustStore) UpdateTrustScore(ctx context.Context, score *TrustScore
 	return nil
 }
 
-func (m *mockTrustStore) DecrementTrustScore(ctx context.Context, userID int, amount int) error {
+func (m *mockTrustStore) DecrementTrustScore(ctx context.Context, userID int, amount int, ruleType RuleType) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if score, ok := m.scores[userID]; ok {
@@ -126,6 +129,25 @@ func (m *mockTrustStore) RecoverTrustScores(ctx context.Context, amount int) err
 	return nil
 }
 
+func (m *mockTrustStore) SetTrustScore(ctx context.Context, change *TrustScoreChange) (*TrustScore, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	score, ok := m.scores[change.UserID]
+	if !ok {
+		score = &TrustScore{UserID: change.UserID, Score: 100}
+		m.scores[change.UserID] = score
+	}
+	change.PreviousScore = score.Score
+	change.Delta = change.Score - score.Score
+	score.Score = change.Score
+	copied := *score
+	return &copied, nil
+}
+
+func (m *mockTrustStore) GetTrustScoreHistory(ctx context.Context, userID int, limit int) ([]TrustScoreChange, error) {
+	return []TrustScoreChange{}, nil
+}
+
 func (m *mockTrustStore) ListLowTrustUsers(ctx context.Context, threshold int) ([]TrustScore, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -171,6 +193,31 @@ func TestEngine_RegisterDetector(t *testing.T) {
 	}
 }
 
+func TestEngine_SetTrustScoreSettings(t *testing.T) {
+	trustStore := newMockTrustStore()
+	engine := NewEngine(&mockAlertStore{}, trustStore, &mockEventHistory{}, &mockBroadcaster{})
+	defer engine.Close()
+
+	engine.SetTrustScoreSettings(25, 0) // Recovery is left unchanged
+	if decrement, recovery := engine.trustScoreSettings(); decrement != 25 || recovery != 1 {
+		t.Fatalf("settings = %d/%d, want 25/1", decrement, recovery)
+	}
+
+	engine.violationChan <- &Alert{UserID: 7, RuleType: RuleTypeImpossibleTravel}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		score, _ := trustStore.GetTrustScore(context.Background(), 7)
+		if score.Score == 75 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("score = %d, want 75 after one violation", score.Score)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func TestEngine_Process(t *testing.T) {
 	eventHistory := &mockEventHistory{
 		lastEvent: &DetectionEvent{
@@ -545,6 +592,38 @@ func TestEngine_RegisterNotifier(t *testing.T) {
 	}
 }
 
+func TestEngine_RegisterNotifierWithMinSeverity(t *testing.T) {
+	engine := NewEngine(&mockAlertStore{}, newMockTrustStore(), &mockEventHistory{}, nil)
+	defer engine.Close()
+
+	all := &mockNotifier{name: "log-webhook", enabled: true}
+	pager := &mockNotifier{name: "discord", enabled: true}
+	engine.RegisterNotifier(all)
+	engine.RegisterNotifierWithMinSeverity(pager, SeverityCritical)
+
+	engine.notify(context.Background(), []*Alert{
+		{RuleType: RuleTypeUserAgentAnomaly, Severity: SeverityInfo},
+		{RuleType: RuleTypeConcurrentStreams, Severity: SeverityWarning},
+		{RuleType: RuleTypeImpossibleTravel, Severity: SeverityCritical},
+	})
+
+	// Give the async notifiers time to send
+	time.Sleep(50 * time.Millisecond)
+
+	all.mu.Lock()
+	allCount := len(all.sentAlerts)
+	all.mu.Unlock()
+	if allCount != 3 {
+		t.Errorf("unfiltered notifier received %d alerts, want 3", allCount)
+	}
+
+	pager.mu.Lock()
+	defer pager.mu.Unlock()
+	if len(pager.sentAlerts) != 1 || pager.sentAlerts[0].Severity != SeverityCritical {
+		t.Errorf("critical-only notifier received %d alerts, want only the critical one", len(pager.sentAlerts))
+	}
+}
+
 func TestEngine_ConfigureDetector_NotFound(t *testing.T) {
 	alertStore := &mockAlertStore{}
 	trustStore := newMockTrustStore()
@@ -691,3 +770,57 @@ func TestEngine_Metrics_DetectorMetrics(t *testing.T) {
 		t.Errorf("EventsChecked = %d, want >= 1", detectorMetrics.EventsChecked)
 	}
 }
+
+func TestEngine_TrustScoreRecoverySchedule(t *testing.T) {
+	trustStore := newMockTrustStore()
+	trustStore.scores[1] = &TrustScore{UserID: 1, Score: 50}
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	engine := NewEngineWithClock(&mockAlertStore{}, trustStore, &mockEventHistory{}, &mockBroadcaster{}, clk)
+	defer engine.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine.StartTrustScoreRecovery(ctx, 5, 24*time.Hour)
+
+	// The startup run happens before the daily ticker is created
+	clk.BlockUntil(1)
+	waitForTrustScore(t, trustStore, 1, 55)
+
+	clk.Advance(23 * time.Hour)
+	clk.Advance(time.Hour)
+	waitForTrustScore(t, trustStore, 1, 60)
+
+	clk.Advance(24 * time.Hour)
+	waitForTrustScore(t, trustStore, 1, 65)
+}
+
+// waitForTrustScore waits for the asynchronous recovery run to set the
+// user's score to want
+func waitForTrustScore(t *testing.T, store *mockTrustStore, userID, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		store.mu.Lock()
+		got := store.scores[userID].Score
+		store.mu.Unlock()
+		if got == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("trust score = %d, want %d", got, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// setIPMode installs an anonymizer for the rest of the test. Tests that
+// call it must not run in parallel.
+func setIPMode(t *testing.T, mode privacy.IPMode) {
+	t.Helper()
+	anonymizer, err := privacy.NewIPAnonymizer(mode, "0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privacy.SetIPAnonymizer(anonymizer)
+	t.Cleanup(func() { privacy.SetIPAnonymizer(nil) })
+}
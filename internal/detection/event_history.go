@@ -10,6 +10,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
 // DuckDBEventHistory implements EventHistory using the existing DuckDB tables.
@@ -306,7 +308,7 @@ func (h *DuckDBEventHistory) GetGeolocation(ctx context.Context, ipAddress strin
 		WHERE ip_address = ?`
 
 	geo := &Geolocation{}
-	err := h.db.QueryRowContext(ctx, query, ipAddress).Scan(
+	err := h.db.QueryRowContext(ctx, query, privacy.AnonymizeIP(ipAddress)).Scan(
 		&geo.IPAddress,
 		&geo.Latitude,
 		&geo.Longitude,
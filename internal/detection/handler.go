@@ -216,6 +216,9 @@ func parseEventMedia(raw map[string]interface{}, event *DetectionEvent) {
 	if v, ok := raw["grandparent_title"].(string); ok {
 		event.GrandparentTitle = v
 	}
+	if v, ok := raw["rating_key"].(string); ok {
+		event.RatingKey = v
+	}
 }
 
 // parseEventNetwork extracts network information fields.
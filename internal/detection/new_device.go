@@ -0,0 +1,307 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// RuleTypeNewDevice detects a user streaming from a device they have never
+// used before.
+const RuleTypeNewDevice RuleType = "new_device"
+
+// newDeviceAlertRetention is how long an alerted device is remembered, so
+// the later events of its first session (pause, stop) do not alert again.
+const newDeviceAlertRetention = 24 * time.Hour
+
+// NewDeviceConfig configures the new device detector.
+type NewDeviceConfig struct {
+	// Severity for generated alerts.
+	Severity Severity `json:"severity"`
+
+	// LearningPeriodDays is how long after a user's first recorded session
+	// new devices are accepted silently, while their usual devices are
+	// being learned.
+	LearningPeriodDays int `json:"learning_period_days"`
+
+	// ExcludedUsers are user IDs to exclude from new device detection.
+	ExcludedUsers []int `json:"excluded_users,omitempty"`
+}
+
+// DefaultNewDeviceConfig returns sensible defaults.
+func DefaultNewDeviceConfig() NewDeviceConfig {
+	return NewDeviceConfig{
+		Severity:           SeverityInfo,
+		LearningPeriodDays: 7,
+		ExcludedUsers:      []int{},
+	}
+}
+
+// NewDeviceMetadata contains details for new device alerts.
+type NewDeviceMetadata struct {
+	DeviceID string `json:"device_id"`
+	Player   string `json:"player,omitempty"`
+	Product  string `json:"product,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Device   string `json:"device,omitempty"`
+
+	// SessionKey identifies the first session on the device.
+	SessionKey string `json:"session_key"`
+
+	// Location is the geolocation of the first session, when known.
+	Location *Geolocation `json:"location,omitempty"`
+
+	// UserFirstSeenAt is when the user first streamed from any device.
+	UserFirstSeenAt time.Time `json:"user_first_seen_at"`
+}
+
+// DeviceHistory provides a user's device inventory.
+// This interface allows for mocking in tests.
+type DeviceHistory interface {
+	// GetUserDevice retrieves a device from a user's inventory. Returns
+	// nil if the user has never streamed from it.
+	GetUserDevice(ctx context.Context, userID int, deviceID string) (*UserDevice, error)
+
+	// GetUserFirstSeen returns when the user first streamed from any
+	// device, or the zero time if they never have.
+	GetUserFirstSeen(ctx context.Context, userID int) (time.Time, error)
+}
+
+// NewDeviceDetector detects the first session from a device ID a user has
+// never streamed from. The device inventory is updated when the event is
+// stored, which may happen before or after detection: a device is new if
+// it has no inventory row yet or the row was created by this session.
+type NewDeviceDetector struct {
+	config  NewDeviceConfig
+	enabled bool
+	devices DeviceHistory
+
+	// alerted is when each user and device raised an alert
+	alerted map[string]time.Time
+
+	mu sync.RWMutex
+}
+
+// NewNewDeviceDetector creates a new device detector.
+func NewNewDeviceDetector(devices DeviceHistory) *NewDeviceDetector {
+	return &NewDeviceDetector{
+		config:  DefaultNewDeviceConfig(),
+		enabled: true,
+		devices: devices,
+		alerted: make(map[string]time.Time),
+	}
+}
+
+// Type returns the rule type.
+func (d *NewDeviceDetector) Type() RuleType {
+	return RuleTypeNewDevice
+}
+
+// Check evaluates whether the event is the first session on a new device.
+func (d *NewDeviceDetector) Check(ctx context.Context, event *DetectionEvent) (*Alert, error) {
+	d.mu.RLock()
+	if !d.enabled {
+		d.mu.RUnlock()
+		return nil, nil
+	}
+	config := d.config
+	d.mu.RUnlock()
+
+	for _, excludedUser := range config.ExcludedUsers {
+		if event.UserID == excludedUser {
+			return nil, nil
+		}
+	}
+
+	// Skip if no machine ID (can't track device)
+	if event.MachineID == "" || d.devices == nil {
+		return nil, nil
+	}
+
+	device, err := d.devices.GetUserDevice(ctx, event.UserID, event.MachineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user device: %w", err)
+	}
+	if device != nil && device.FirstSessionKey != event.SessionKey {
+		return nil, nil
+	}
+
+	now := event.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	// A user with no recorded sessions is new, so still learning
+	firstSeen, err := d.devices.GetUserFirstSeen(ctx, event.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user first seen: %w", err)
+	}
+	learningPeriod := time.Duration(config.LearningPeriodDays) * 24 * time.Hour
+	if firstSeen.IsZero() || now.Sub(firstSeen) < learningPeriod {
+		return nil, nil
+	}
+
+	if !d.markAlerted(event.UserID, event.MachineID, now) {
+		return nil, nil
+	}
+
+	metadata := NewDeviceMetadata{
+		DeviceID:        event.MachineID,
+		Player:          event.Player,
+		Product:         event.Product,
+		Platform:        event.Platform,
+		Device:          event.Device,
+		SessionKey:      event.SessionKey,
+		UserFirstSeenAt: firstSeen,
+	}
+	if event.Country != "" {
+		metadata.Location = &Geolocation{
+			IPAddress: event.IPAddress,
+			Latitude:  event.Latitude,
+			Longitude: event.Longitude,
+			City:      event.City,
+			Region:    event.Region,
+			Country:   event.Country,
+		}
+	}
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	message := fmt.Sprintf("User %s started streaming from a new device: %s",
+		event.Username, describeDevice(event))
+	if place := describePlace(event); place != "" {
+		message += " in " + place
+	}
+
+	return &Alert{
+		RuleType:  RuleTypeNewDevice,
+		UserID:    event.UserID,
+		Username:  event.Username,
+		ServerID:  event.ServerID,
+		MachineID: event.MachineID,
+		IPAddress: event.IPAddress,
+		Severity:  config.Severity,
+		Title:     "New Device Detected",
+		Message:   message,
+		Metadata:  metadataJSON,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// markAlerted reports whether the user and device have not alerted yet,
+// and if so records the alert at now.
+func (d *NewDeviceDetector) markAlerted(userID int, deviceID string, now time.Time) bool {
+	key := strconv.Itoa(userID) + "|" + deviceID
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.alerted[key]; ok {
+		return false
+	}
+
+	// Forget old entries so the map does not grow without bound
+	for k, at := range d.alerted {
+		if now.Sub(at) >= newDeviceAlertRetention {
+			delete(d.alerted, k)
+		}
+	}
+	d.alerted[key] = now
+	return true
+}
+
+// describeDevice names the device for alert messages, e.g.
+// "Plex Web on Chrome (Windows)".
+func describeDevice(event *DetectionEvent) string {
+	name := event.Player
+	if name == "" {
+		name = event.Product
+	}
+	if name == "" {
+		name = truncateMachineID(event.MachineID)
+	}
+	var details []string
+	for _, v := range []string{event.Device, event.Platform} {
+		if v != "" && v != name {
+			details = append(details, v)
+		}
+	}
+	if len(details) > 0 {
+		name += " (" + strings.Join(details, ", ") + ")"
+	}
+	return name
+}
+
+// describePlace returns "City, Country" for the event, or "" if unknown.
+func describePlace(event *DetectionEvent) string {
+	var parts []string
+	for _, v := range []string{event.City, event.Country} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Configure updates the detector configuration.
+func (d *NewDeviceDetector) Configure(config json.RawMessage) error {
+	var newConfig NewDeviceConfig
+	if err := json.Unmarshal(config, &newConfig); err != nil {
+		return fmt.Errorf("failed to parse new device config: %w", err)
+	}
+
+	if newConfig.Severity != "" &&
+		newConfig.Severity != SeverityInfo &&
+		newConfig.Severity != SeverityWarning &&
+		newConfig.Severity != SeverityCritical {
+		return fmt.Errorf("invalid severity: %s", newConfig.Severity)
+	}
+	if newConfig.LearningPeriodDays < 0 {
+		return fmt.Errorf("learning_period_days must not be negative")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Merge with defaults for unset values
+	if newConfig.Severity == "" {
+		newConfig.Severity = d.config.Severity
+	}
+
+	d.config = newConfig
+	return nil
+}
+
+// Enabled returns whether this detector is enabled.
+func (d *NewDeviceDetector) Enabled() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.enabled
+}
+
+// SetEnabled enables or disables the detector.
+func (d *NewDeviceDetector) SetEnabled(enabled bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.enabled = enabled
+}
+
+// Config returns the current configuration.
+func (d *NewDeviceDetector) Config() NewDeviceConfig {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.config
+}
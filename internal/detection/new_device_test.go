@@ -0,0 +1,231 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// mockDeviceHistory is a DeviceHistory backed by a map of device ID to row.
+type mockDeviceHistory struct {
+	devices   map[string]*UserDevice
+	firstSeen time.Time
+	err       error
+}
+
+func (m *mockDeviceHistory) GetUserDevice(_ context.Context, _ int, deviceID string) (*UserDevice, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.devices[deviceID], nil
+}
+
+func (m *mockDeviceHistory) GetUserFirstSeen(_ context.Context, _ int) (time.Time, error) {
+	return m.firstSeen, nil
+}
+
+var newDeviceNow = time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+
+func newDeviceEvent() DetectionEvent {
+	return DetectionEvent{
+		SessionKey: "s1",
+		Timestamp:  newDeviceNow,
+		UserID:     1,
+		Username:   "testuser",
+		MachineID:  "device-new",
+		Player:     "Plex Web",
+		Platform:   "Chrome",
+		Device:     "Windows",
+		IPAddress:  "203.0.113.10",
+		City:       "Lisbon",
+		Country:    "PT",
+	}
+}
+
+func TestNewNewDeviceDetector(t *testing.T) {
+	detector := NewNewDeviceDetector(&mockDeviceHistory{})
+
+	if detector.Type() != RuleTypeNewDevice {
+		t.Errorf("Type() = %v, want %v", detector.Type(), RuleTypeNewDevice)
+	}
+	if !detector.Enabled() {
+		t.Error("detector should be enabled by default")
+	}
+	if cfg := detector.Config(); cfg.Severity != SeverityInfo || cfg.LearningPeriodDays != 7 {
+		t.Errorf("Config() = %+v, want info with a 7 day learning period", cfg)
+	}
+}
+
+func TestNewDeviceDetector_Check(t *testing.T) {
+	established := newDeviceNow.AddDate(0, -2, 0)
+	known := &UserDevice{DeviceID: "device-new", FirstSessionKey: "s0"}
+	createdBySession := &UserDevice{DeviceID: "device-new", FirstSessionKey: "s1"}
+
+	tests := []struct {
+		name      string
+		device    *UserDevice
+		firstSeen time.Time
+		wantAlert bool
+	}{
+		{"device not yet stored", nil, established, true},
+		{"device stored by this session", createdBySession, established, true},
+		{"known device", known, established, false},
+		{"user without sessions", nil, time.Time{}, false},
+		{"user in learning period", nil, newDeviceNow.AddDate(0, 0, -3), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &mockDeviceHistory{
+				devices:   map[string]*UserDevice{"device-new": tt.device},
+				firstSeen: tt.firstSeen,
+			}
+			detector := NewNewDeviceDetector(history)
+			event := newDeviceEvent()
+
+			alert, err := detector.Check(context.Background(), &event)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (alert != nil) != tt.wantAlert {
+				t.Fatalf("alert = %v, want alert %v", alert, tt.wantAlert)
+			}
+			if alert == nil {
+				return
+			}
+
+			if alert.RuleType != RuleTypeNewDevice || alert.Severity != SeverityInfo || alert.MachineID != "device-new" {
+				t.Errorf("alert = %+v, want info new_device for device-new", alert)
+			}
+			if want := "User testuser started streaming from a new device: Plex Web (Windows, Chrome) in Lisbon, PT"; alert.Message != want {
+				t.Errorf("Message = %q, want %q", alert.Message, want)
+			}
+			var metadata NewDeviceMetadata
+			if err := json.Unmarshal(alert.Metadata, &metadata); err != nil {
+				t.Fatalf("failed to unmarshal metadata: %v", err)
+			}
+			if metadata.SessionKey != "s1" || metadata.Location == nil || metadata.Location.City != "Lisbon" {
+				t.Errorf("metadata = %+v, want session s1 in Lisbon", metadata)
+			}
+			if !metadata.UserFirstSeenAt.Equal(established) {
+				t.Errorf("UserFirstSeenAt = %v, want %v", metadata.UserFirstSeenAt, established)
+			}
+		})
+	}
+}
+
+func TestNewDeviceDetector_Check_AlertsOncePerSession(t *testing.T) {
+	history := &mockDeviceHistory{firstSeen: newDeviceNow.AddDate(0, -1, 0)}
+	detector := NewNewDeviceDetector(history)
+
+	// The pause and stop events of the first session must not alert again
+	for i, wantAlert := range []bool{true, false, false} {
+		event := newDeviceEvent()
+		event.Timestamp = newDeviceNow.Add(time.Duration(i) * time.Minute)
+
+		alert, err := detector.Check(context.Background(), &event)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if (alert != nil) != wantAlert {
+			t.Errorf("event %d: alert = %v, want alert %v", i, alert, wantAlert)
+		}
+	}
+}
+
+func TestNewDeviceDetector_Check_Skipped(t *testing.T) {
+	history := &mockDeviceHistory{firstSeen: newDeviceNow.AddDate(0, -1, 0)}
+
+	t.Run("no machine ID", func(t *testing.T) {
+		detector := NewNewDeviceDetector(history)
+		event := newDeviceEvent()
+		event.MachineID = ""
+
+		if alert, err := detector.Check(context.Background(), &event); err != nil || alert != nil {
+			t.Errorf("Check() = %v, %v; want no alert", alert, err)
+		}
+	})
+
+	t.Run("excluded user", func(t *testing.T) {
+		detector := NewNewDeviceDetector(history)
+		if err := detector.Configure(json.RawMessage(`{"learning_period_days":7,"excluded_users":[1]}`)); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		event := newDeviceEvent()
+
+		if alert, err := detector.Check(context.Background(), &event); err != nil || alert != nil {
+			t.Errorf("Check() = %v, %v; want no alert", alert, err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		detector := NewNewDeviceDetector(history)
+		detector.SetEnabled(false)
+		event := newDeviceEvent()
+
+		if alert, err := detector.Check(context.Background(), &event); err != nil || alert != nil {
+			t.Errorf("Check() = %v, %v; want no alert", alert, err)
+		}
+	})
+}
+
+func TestNewDeviceDetector_Check_StoreError(t *testing.T) {
+	detector := NewNewDeviceDetector(&mockDeviceHistory{err: errors.New("db down")})
+	event := newDeviceEvent()
+
+	if _, err := detector.Check(context.Background(), &event); err == nil {
+		t.Error("expected error when the device store fails")
+	}
+}
+
+func TestNewDeviceDetector_Configure(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{"valid", `{"severity":"warning","learning_period_days":14}`, false},
+		{"no learning period", `{"learning_period_days":0}`, false},
+		{"negative learning period", `{"learning_period_days":-1}`, true},
+		{"invalid severity", `{"severity":"loud"}`, true},
+		{"invalid json", `{`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewNewDeviceDetector(&mockDeviceHistory{})
+			err := detector.Configure(json.RawMessage(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Configure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserDeviceUpdate_Normalize(t *testing.T) {
+	name := "  Living room TV  "
+	flagged := true
+	long := string(make([]byte, maxDeviceNameLength+1))
+
+	update := UserDeviceUpdate{Name: &name}
+	if err := update.Normalize(); err != nil || *update.Name != "Living room TV" {
+		t.Errorf("Normalize() = %v, name %q; want trimmed name", err, *update.Name)
+	}
+	if err := (&UserDeviceUpdate{Flagged: &flagged}).Normalize(); err != nil {
+		t.Errorf("Normalize() flagged only error = %v", err)
+	}
+	if err := (&UserDeviceUpdate{}).Normalize(); err == nil {
+		t.Error("expected error for empty update")
+	}
+	if err := (&UserDeviceUpdate{Name: &long}).Normalize(); err == nil {
+		t.Error("expected error for name over the length limit")
+	}
+}
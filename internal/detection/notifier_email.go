@@ -0,0 +1,255 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/smtpmail"
+)
+
+// EmailNotifier sends alerts by email over SMTP.
+type EmailNotifier struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+	useTLS   bool
+	enabled  bool
+	timeout  time.Duration
+	mu       sync.RWMutex
+
+	// Rate limiting
+	lastSent  time.Time
+	rateLimit time.Duration
+}
+
+// EmailConfig configures the email notifier.
+type EmailConfig struct {
+	SMTPHost     string   `json:"smtp_host"`
+	SMTPPort     int      `json:"smtp_port"`
+	SMTPUsername string   `json:"smtp_username,omitempty"`
+	SMTPPassword string   `json:"smtp_password,omitempty"`
+	From         string   `json:"from"`
+	To           []string `json:"to"`
+	UseTLS       bool     `json:"use_tls"` // Upgrade the connection with STARTTLS
+	Enabled      bool     `json:"enabled"`
+	RateLimitMs  int      `json:"rate_limit_ms"` // Minimum ms between messages
+}
+
+// NewEmailNotifier creates a new email notifier.
+func NewEmailNotifier(config EmailConfig) *EmailNotifier {
+	rateLimit := time.Duration(config.RateLimitMs) * time.Millisecond
+	if rateLimit == 0 {
+		rateLimit = 5 * time.Second // Default 5 second rate limit
+	}
+
+	port := config.SMTPPort
+	if port == 0 {
+		port = 587
+	}
+
+	to := make([]string, 0, len(config.To))
+	for _, addr := range config.To {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+
+	return &EmailNotifier{
+		host:      config.SMTPHost,
+		port:      port,
+		username:  config.SMTPUsername,
+		password:  config.SMTPPassword,
+		from:      config.From,
+		to:        to,
+		useTLS:    config.UseTLS,
+		enabled:   config.Enabled,
+		timeout:   30 * time.Second,
+		rateLimit: rateLimit,
+	}
+}
+
+// Name returns the notifier name.
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+// Enabled returns whether this notifier is enabled.
+func (n *EmailNotifier) Enabled() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.enabled && n.host != "" && n.from != "" && len(n.to) > 0
+}
+
+// SetEnabled enables or disables the notifier.
+func (n *EmailNotifier) SetEnabled(enabled bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.enabled = enabled
+}
+
+// SetRecipients replaces the recipient list.
+func (n *EmailNotifier) SetRecipients(to []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.to = append([]string(nil), to...)
+}
+
+// Send emails an alert to all recipients in one SMTP transaction.
+func (n *EmailNotifier) Send(ctx context.Context, alert *Alert) error {
+	if !n.Enabled() {
+		return nil
+	}
+
+	n.mu.RLock()
+	to := append([]string(nil), n.to...)
+	rateLimit := n.rateLimit
+	lastSent := n.lastSent
+	n.mu.RUnlock()
+
+	// Rate limiting with context cancellation support
+	if time.Since(lastSent) < rateLimit {
+		waitTime := rateLimit - time.Since(lastSent)
+		select {
+		case <-time.After(waitTime):
+			// Continue after rate limit wait
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := n.sendSMTP(ctx, to, n.buildMessage(alert, to)); err != nil {
+		return err
+	}
+
+	// Update last sent time
+	n.mu.Lock()
+	n.lastSent = time.Now()
+	n.mu.Unlock()
+
+	return nil
+}
+
+// sendSMTP delivers msg to the recipients.
+func (n *EmailNotifier) sendSMTP(ctx context.Context, to []string, msg []byte) error {
+	tlsMode := smtpmail.TLSModeNone
+	if n.useTLS {
+		tlsMode = smtpmail.TLSModeStartTLS
+	}
+
+	client, err := smtpmail.Dial(ctx, smtpmail.Config{
+		Host:     n.host,
+		Port:     n.port,
+		Username: n.username,
+		Password: n.password,
+		TLSMode:  tlsMode,
+		Timeout:  n.timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := client.Send(ctx, n.from, to, msg); err != nil {
+		_ = client.Close() //nolint:errcheck // Best effort cleanup
+		return err
+	}
+
+	// The message is accepted once DATA completes; a failed QUIT is ignored
+	client.Quit()
+	return nil
+}
+
+// buildMessage renders the alert as a multipart/alternative message with
+// plain text and HTML parts.
+func (n *EmailNotifier) buildMessage(alert *Alert, to []string) []byte {
+	var buf bytes.Buffer
+
+	subject := fmt.Sprintf("[Cartographus] %s: %s", strings.ToUpper(string(alert.Severity)), alert.Title)
+	smtpmail.WriteHeader(&buf, "From", smtpmail.FormatAddress("", n.from))
+	smtpmail.WriteHeader(&buf, "To", smtpmail.SanitizeHeader(strings.Join(to, ", ")))
+	smtpmail.WriteHeader(&buf, "Subject", smtpmail.EncodeText(subject))
+	smtpmail.WriteHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	smtpmail.WriteHeader(&buf, "Message-ID", smtpmail.MessageID(n.from))
+	smtpmail.WriteHeader(&buf, "MIME-Version", "1.0")
+
+	bodyHeader, body := smtpmail.BodyEntity(n.htmlBody(alert), n.textBody(alert))
+	smtpmail.WriteMIMEHeader(&buf, bodyHeader)
+	buf.WriteString("\r\n")
+	buf.Write(body)
+
+	return buf.Bytes()
+}
+
+// alertFields returns the labeled alert details shown in both parts.
+func alertFields(alert *Alert) [][2]string {
+	fields := [][2]string{
+		{"User", alert.Username},
+		{"Severity", string(alert.Severity)},
+		{"Rule Type", string(alert.RuleType)},
+	}
+	if alert.IPAddress != "" {
+		fields = append(fields, [2]string{"IP Address", alert.IPAddress})
+	}
+	if alert.MachineID != "" {
+		fields = append(fields, [2]string{"Device", truncateMachineID(alert.MachineID)})
+	}
+	fields = append(fields, [2]string{"Time", alert.CreatedAt.Format(time.RFC3339)})
+	return fields
+}
+
+func (n *EmailNotifier) textBody(alert *Alert) string {
+	var b strings.Builder
+	b.WriteString(alert.Title + "\r\n\r\n")
+	if alert.Message != "" {
+		b.WriteString(alert.Message + "\r\n\r\n")
+	}
+	for _, f := range alertFields(alert) {
+		fmt.Fprintf(&b, "%s: %s\r\n", f[0], f[1])
+	}
+	b.WriteString("\r\n-- \r\nCartographus Detection Engine\r\n")
+	return b.String()
+}
+
+func (n *EmailNotifier) htmlBody(alert *Alert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<html><body style="font-family:sans-serif;color:#222">`+
+		`<div style="border-left:4px solid %s;padding-left:12px">`+
+		`<h2 style="margin:0 0 8px">%s</h2>`, n.severityColor(alert.Severity), html.EscapeString(alert.Title))
+	if alert.Message != "" {
+		fmt.Fprintf(&b, `<p>%s</p>`, html.EscapeString(alert.Message))
+	}
+	b.WriteString(`<table cellpadding="4" style="border-collapse:collapse">`)
+	for _, f := range alertFields(alert) {
+		fmt.Fprintf(&b, `<tr><th align="left">%s</th><td>%s</td></tr>`,
+			html.EscapeString(f[0]), html.EscapeString(f[1]))
+	}
+	b.WriteString(`</table></div>` +
+		`<p style="color:#888;font-size:12px">Cartographus Detection Engine</p></body></html>`)
+	return b.String()
+}
+
+// severityColor returns the accent color for a severity level.
+func (n *EmailNotifier) severityColor(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "#FF0000" // Red
+	case SeverityWarning:
+		return "#FFA500" // Orange
+	case SeverityInfo:
+		return "#3498DB" // Blue
+	default:
+		return "#95A5A6" // Gray
+	}
+}
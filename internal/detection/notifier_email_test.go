@@ -0,0 +1,178 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/smtpmail/smtptest"
+)
+
+func TestNewEmailNotifier(t *testing.T) {
+	notifier := NewEmailNotifier(EmailConfig{
+		SMTPHost: "smtp.example.com",
+		From:     "alerts@example.com",
+		To:       []string{" ops@example.com ", ""},
+		Enabled:  true,
+	})
+
+	if notifier.Name() != "email" {
+		t.Errorf("Name() = %q, want %q", notifier.Name(), "email")
+	}
+	if notifier.port != 587 {
+		t.Errorf("port = %d, want default 587", notifier.port)
+	}
+	if notifier.rateLimit != 5*time.Second {
+		t.Errorf("rateLimit = %v, want 5s", notifier.rateLimit)
+	}
+	if len(notifier.to) != 1 || notifier.to[0] != "ops@example.com" {
+		t.Errorf("to = %q, want [ops@example.com]", notifier.to)
+	}
+	if !notifier.Enabled() {
+		t.Error("notifier should be enabled")
+	}
+}
+
+func TestEmailNotifier_Enabled(t *testing.T) {
+	base := EmailConfig{SMTPHost: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}, Enabled: true}
+
+	tests := []struct {
+		name     string
+		modify   func(*EmailConfig)
+		expected bool
+	}{
+		{"complete", func(*EmailConfig) {}, true},
+		{"disabled", func(c *EmailConfig) { c.Enabled = false }, false},
+		{"no host", func(c *EmailConfig) { c.SMTPHost = "" }, false},
+		{"no sender", func(c *EmailConfig) { c.From = "" }, false},
+		{"no recipients", func(c *EmailConfig) { c.To = nil }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			tt.modify(&cfg)
+			if got := NewEmailNotifier(cfg).Enabled(); got != tt.expected {
+				t.Errorf("Enabled() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEmailNotifier_Send(t *testing.T) {
+	server := smtptest.NewServer(t, nil)
+
+	notifier := NewEmailNotifier(EmailConfig{
+		SMTPHost:    server.Host(),
+		SMTPPort:    server.Port(),
+		From:        "alerts@example.com",
+		To:          []string{"ops@example.com", "security@example.com"},
+		Enabled:     true,
+		RateLimitMs: 10,
+	})
+
+	alert := &Alert{
+		RuleType:  RuleTypeConcurrentStreams,
+		Username:  "testuser",
+		IPAddress: "1.2.3.4",
+		Severity:  SeverityWarning,
+		Title:     "Concurrent Streams\r\nBcc: attacker@example.com",
+		Message:   "User has <4> streams",
+		CreatedAt: time.Now(),
+	}
+
+	if err := notifier.Send(context.Background(), alert); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(messages))
+	}
+	if messages[0].From != "alerts@example.com" {
+		t.Errorf("MAIL FROM = %q", messages[0].From)
+	}
+	if len(messages[0].To) != 2 {
+		t.Errorf("RCPT TO = %q, want both recipients", messages[0].To)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(messages[0].Data))
+	if err != nil {
+		t.Fatalf("message does not parse: %v", err)
+	}
+	if msg.Header.Get("Bcc") != "" {
+		t.Error("alert title injected a Bcc header")
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || !strings.HasPrefix(subject, "[Cartographus] WARNING: Concurrent Streams") {
+		t.Errorf("Subject = %q (%v)", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, want multipart/alternative", msg.Header.Get("Content-Type"))
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+		body, _ := io.ReadAll(part) // quoted-printable is decoded by NextPart
+		ct := part.Header.Get("Content-Type")
+		types = append(types, ct)
+		if strings.HasPrefix(ct, "text/html") && !strings.Contains(string(body), "User has &lt;4&gt; streams") {
+			t.Errorf("HTML part not escaped: %s", body)
+		}
+		if strings.HasPrefix(ct, "text/plain") && !strings.Contains(string(body), "IP Address: 1.2.3.4") {
+			t.Errorf("text part missing fields: %s", body)
+		}
+	}
+	if len(types) != 2 {
+		t.Errorf("got parts %q, want text and HTML", types)
+	}
+}
+
+func TestEmailNotifier_Send_ConnectionError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	_ = l.Close()
+
+	notifier := NewEmailNotifier(EmailConfig{
+		SMTPHost: "127.0.0.1",
+		SMTPPort: port,
+		From:     "alerts@example.com",
+		To:       []string{"ops@example.com"},
+		Enabled:  true,
+	})
+
+	err = notifier.Send(context.Background(), &Alert{Title: "Test", CreatedAt: time.Now()})
+	if err == nil || !strings.Contains(err.Error(), "failed to connect") {
+		t.Errorf("Send() error = %v, want connection error", err)
+	}
+}
+
+func TestEmailNotifier_Send_Disabled(t *testing.T) {
+	notifier := NewEmailNotifier(EmailConfig{SMTPHost: "127.0.0.1", SMTPPort: 1, Enabled: false})
+	if err := notifier.Send(context.Background(), &Alert{Title: "Test"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// SlackNotifier sends alerts to Slack via incoming webhooks, formatted
+// with Block Kit.
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+	enabled    bool
+	mu         sync.RWMutex
+
+	// Rate limiting
+	lastSent  time.Time
+	rateLimit time.Duration
+}
+
+// SlackConfig configures the Slack notifier.
+type SlackConfig struct {
+	WebhookURL  string `json:"webhook_url"`
+	Enabled     bool   `json:"enabled"`
+	RateLimitMs int    `json:"rate_limit_ms"` // Minimum ms between messages
+}
+
+// NewSlackNotifier creates a new Slack notifier.
+func NewSlackNotifier(config SlackConfig) *SlackNotifier {
+	rateLimit := time.Duration(config.RateLimitMs) * time.Millisecond
+	if rateLimit == 0 {
+		rateLimit = 1 * time.Second // Slack allows about one message per second per webhook
+	}
+
+	return &SlackNotifier{
+		webhookURL: config.WebhookURL,
+		enabled:    config.Enabled,
+		rateLimit:  rateLimit,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Name returns the notifier name.
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// Enabled returns whether this notifier is enabled.
+func (n *SlackNotifier) Enabled() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.enabled && n.webhookURL != ""
+}
+
+// SetEnabled enables or disables the notifier.
+func (n *SlackNotifier) SetEnabled(enabled bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.enabled = enabled
+}
+
+// SetWebhookURL updates the webhook URL.
+func (n *SlackNotifier) SetWebhookURL(url string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.webhookURL = url
+}
+
+// Send delivers an alert to Slack.
+func (n *SlackNotifier) Send(ctx context.Context, alert *Alert) error {
+	n.mu.RLock()
+	if !n.enabled || n.webhookURL == "" {
+		n.mu.RUnlock()
+		return nil
+	}
+	webhookURL := n.webhookURL
+	rateLimit := n.rateLimit
+	lastSent := n.lastSent
+	n.mu.RUnlock()
+
+	// Rate limiting with context cancellation support
+	if time.Since(lastSent) < rateLimit {
+		waitTime := rateLimit - time.Since(lastSent)
+		select {
+		case <-time.After(waitTime):
+			// Continue after rate limit wait
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	body, err := json.Marshal(n.buildPayload(alert))
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Slack webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Update last sent time
+	n.mu.Lock()
+	n.lastSent = time.Now()
+	n.mu.Unlock()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// buildPayload creates a Block Kit message from an alert. The blocks sit in
+// an attachment so Slack draws the severity color bar beside them; Text is
+// the fallback used in notifications.
+func (n *SlackNotifier) buildPayload(alert *Alert) slackPayload {
+	fields := []slackText{
+		slackMrkdwn("*User*\n" + slackEscape(alert.Username)),
+		slackMrkdwn("*Severity*\n" + string(alert.Severity)),
+		slackMrkdwn("*Rule Type*\n" + string(alert.RuleType)),
+	}
+	if alert.IPAddress != "" {
+		fields = append(fields, slackMrkdwn("*IP Address*\n"+slackEscape(alert.IPAddress)))
+	}
+	if alert.MachineID != "" {
+		fields = append(fields, slackMrkdwn("*Device*\n"+slackEscape(truncateMachineID(alert.MachineID))))
+	}
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: truncateRunes(alert.Title, 150)}},
+	}
+	if alert.Message != "" {
+		text := slackMrkdwn(truncateRunes(slackEscape(alert.Message), 3000))
+		blocks = append(blocks, slackBlock{Type: "section", Text: &text})
+	}
+	blocks = append(blocks,
+		slackBlock{Type: "section", Fields: fields},
+		slackBlock{Type: "context", Elements: []slackText{
+			slackMrkdwn(fmt.Sprintf("Cartographus Detection Engine • <!date^%d^{date_short_pretty} {time}|%s>",
+				alert.CreatedAt.Unix(), alert.CreatedAt.Format(time.RFC3339))),
+		}},
+	)
+
+	return slackPayload{
+		Text: fmt.Sprintf("[%s] %s: %s", strings.ToUpper(string(alert.Severity)), alert.Title, alert.Username),
+		Attachments: []slackAttachment{{
+			Color:  n.severityColor(alert.Severity),
+			Blocks: blocks,
+		}},
+	}
+}
+
+// severityColor returns the attachment color for a severity level.
+func (n *SlackNotifier) severityColor(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "#FF0000" // Red
+	case SeverityWarning:
+		return "#FFA500" // Orange
+	case SeverityInfo:
+		return "#3498DB" // Blue
+	default:
+		return "#95A5A6" // Gray
+	}
+}
+
+// slackEscape escapes the characters Slack treats as control sequences in
+// mrkdwn text.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// truncateRunes shortens s to at most n runes to fit Slack's block limits.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func slackMrkdwn(text string) slackText {
+	return slackText{Type: "mrkdwn", Text: text}
+}
+
+// Slack webhook structures
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color,omitempty"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
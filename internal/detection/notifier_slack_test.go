@@ -0,0 +1,152 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package detection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewSlackNotifier(t *testing.T) {
+	notifier := NewSlackNotifier(SlackConfig{
+		WebhookURL: "https://hooks.slack.com/services/T000/B000/XXX",
+		Enabled:    true,
+	})
+
+	if notifier.Name() != "slack" {
+		t.Errorf("Name() = %q, want %q", notifier.Name(), "slack")
+	}
+	if !notifier.Enabled() {
+		t.Error("notifier should be enabled")
+	}
+	if notifier.rateLimit != 1*time.Second {
+		t.Errorf("rateLimit = %v, want 1s", notifier.rateLimit)
+	}
+
+	notifier.SetWebhookURL("")
+	if notifier.Enabled() {
+		t.Error("notifier without a webhook URL should be disabled")
+	}
+}
+
+func TestSlackNotifier_Send_Disabled(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(SlackConfig{WebhookURL: server.URL, Enabled: false})
+	if err := notifier.Send(context.Background(), &Alert{Title: "Test"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&requestCount) != 0 {
+		t.Error("disabled notifier should not send")
+	}
+}
+
+func TestSlackNotifier_Send_Success(t *testing.T) {
+	var received slackPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(SlackConfig{WebhookURL: server.URL, Enabled: true, RateLimitMs: 10})
+	alert := &Alert{
+		RuleType:  RuleTypeImpossibleTravel,
+		Username:  "testuser",
+		IPAddress: "1.2.3.4",
+		MachineID: "machine123",
+		Severity:  SeverityCritical,
+		Title:     "Impossible Travel",
+		Message:   "Moved <9000 km> in 1 hour",
+		CreatedAt: time.Now(),
+	}
+
+	if err := notifier.Send(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received.Text != "[CRITICAL] Impossible Travel: testuser" {
+		t.Errorf("fallback text = %q", received.Text)
+	}
+	if len(received.Attachments) != 1 || received.Attachments[0].Color != "#FF0000" {
+		t.Fatalf("attachments = %+v, want one red attachment", received.Attachments)
+	}
+
+	blocks := received.Attachments[0].Blocks
+	if len(blocks) != 4 {
+		t.Fatalf("got %d blocks, want header, message, fields, context", len(blocks))
+	}
+	if blocks[0].Type != "header" || blocks[0].Text.Text != "Impossible Travel" {
+		t.Errorf("header block = %+v", blocks[0])
+	}
+	if blocks[1].Text.Text != "Moved &lt;9000 km&gt; in 1 hour" {
+		t.Errorf("message not escaped: %q", blocks[1].Text.Text)
+	}
+	if len(blocks[2].Fields) != 5 {
+		t.Errorf("got %d fields, want 5 (user, severity, rule, IP, device)", len(blocks[2].Fields))
+	}
+	if blocks[3].Type != "context" || !strings.Contains(blocks[3].Elements[0].Text, "Cartographus Detection Engine") {
+		t.Errorf("context block = %+v", blocks[3])
+	}
+}
+
+func TestSlackNotifier_Send_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(SlackConfig{WebhookURL: server.URL, Enabled: true, RateLimitMs: 10})
+	err := notifier.Send(context.Background(), &Alert{Title: "Test", Severity: SeverityInfo, CreatedAt: time.Now()})
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Send() error = %v, want status 403 error", err)
+	}
+}
+
+func TestSlackNotifier_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(SlackConfig{WebhookURL: server.URL, Enabled: true, RateLimitMs: 200})
+	alert := &Alert{Title: "Test", Severity: SeverityWarning, CreatedAt: time.Now()}
+
+	if err := notifier.Send(context.Background(), alert); err != nil {
+		t.Fatalf("first Send() error = %v", err)
+	}
+
+	// A second alert inside the window waits; a cancelled context gives up
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := notifier.Send(ctx, alert); err != context.DeadlineExceeded {
+		t.Errorf("rate limited Send() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("héllo wörld", 5); got != "héll…" {
+		t.Errorf("truncateRunes() = %q, want %q", got, "héll…")
+	}
+	if got := truncateRunes("short", 10); got != "short" {
+		t.Errorf("truncateRunes() = %q, want %q", got, "short")
+	}
+}
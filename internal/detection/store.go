@@ -14,11 +14,13 @@ import (
 	"time"
 
 	"github.com/goccy/go-json"
+	"github.com/google/uuid"
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/privacy"
 )
 
-// DuckDBStore implements AlertStore, RuleStore, TrustStore, and EventHistory
-// using DuckDB as the backend storage.
+// DuckDBStore implements AlertStore, RuleStore, TrustStore, AllowlistStore,
+// DeviceHistory, and EventHistory using DuckDB as the backend storage.
 type DuckDBStore struct {
 	db *sql.DB
 }
@@ -44,6 +46,7 @@ const (
 		p.media_type,
 		p.title,
 		COALESCE(p.grandparent_title, '') as grandparent_title,
+		COALESCE(p.rating_key, '') as rating_key,
 		p.ip_address,
 		COALESCE(p.location_type, '') as location_type,
 		COALESCE(g.latitude, 0) as latitude,
@@ -86,6 +89,7 @@ func scanDetectionEvent(scanner interface {
 		&event.MediaType,
 		&event.Title,
 		&event.GrandparentTitle,
+		&event.RatingKey,
 		&event.IPAddress,
 		&event.LocationType,
 		&event.Latitude,
@@ -252,6 +256,31 @@ func (s *DuckDBStore) InitSchema(ctx context.Context) error {
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,
 
+		// Trust score change history (violations, recovery, manual overrides)
+		`CREATE TABLE IF NOT EXISTS trust_score_history (
+			id TEXT PRIMARY KEY,
+			user_id INTEGER NOT NULL,
+			previous_score INTEGER NOT NULL,
+			score INTEGER NOT NULL,
+			delta INTEGER NOT NULL,
+			reason TEXT NOT NULL,
+			rule_type TEXT,
+			changed_by TEXT,
+			note TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		// Trusted locations that suppress alerts (user_id 0 = all users)
+		`CREATE TABLE IF NOT EXISTS detection_allowlist (
+			id TEXT PRIMARY KEY,
+			user_id INTEGER NOT NULL DEFAULT 0,
+			cidr TEXT,
+			country TEXT,
+			note TEXT,
+			created_by TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+
 		// Indexes for performance
 		`CREATE INDEX IF NOT EXISTS idx_alerts_user_id ON detection_alerts(user_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_alerts_rule_type ON detection_alerts(rule_type)`,
@@ -260,6 +289,8 @@ func (s *DuckDBStore) InitSchema(ctx context.Context) error {
 		`CREATE INDEX IF NOT EXISTS idx_alerts_acknowledged ON detection_alerts(acknowledged)`,
 		`CREATE INDEX IF NOT EXISTS idx_alerts_server_id ON detection_alerts(server_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_trust_score ON user_trust_scores(score)`,
+		`CREATE INDEX IF NOT EXISTS idx_allowlist_user_id ON detection_allowlist(user_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_trust_history_user ON trust_score_history(user_id, created_at DESC)`,
 
 		// v2.1: Migration - add server_id column to existing tables
 		`ALTER TABLE detection_alerts ADD COLUMN IF NOT EXISTS server_id TEXT`,
@@ -300,6 +331,9 @@ func (s *DuckDBStore) insertDefaultRules(ctx context.Context) error {
 		{RuleTypeSimultaneousLocations, "Simultaneous Locations", true, DefaultSimultaneousLocationsConfig()},
 		{RuleTypeUserAgentAnomaly, "User Agent Anomaly Detection", true, DefaultUserAgentAnomalyConfig()},
 		{RuleTypeVPNUsage, "VPN Usage Detection", true, DefaultVPNUsageConfig()},
+		{RuleTypeAccountSharing, "Account Sharing Detection", true, DefaultAccountSharingConfig()},
+		{RuleTypeDatacenterStream, "Datacenter Stream Detection", true, DefaultDatacenterStreamConfig()},
+		{RuleTypeNewDevice, "New Device Detection", true, DefaultNewDeviceConfig()},
 	}
 
 	for _, def := range defaults {
@@ -341,7 +375,7 @@ func (s *DuckDBStore) SaveAlert(ctx context.Context, alert *Alert) error {
 		alert.Username,
 		alert.ServerID,
 		alert.MachineID,
-		alert.IPAddress,
+		privacy.AnonymizeIP(alert.IPAddress),
 		alert.Severity,
 		alert.Title,
 		alert.Message,
@@ -689,7 +723,19 @@ func (s *DuckDBStore) UpdateTrustScore(ctx context.Context, score *TrustScore) e
 }
 
 // DecrementTrustScore decreases a user's trust score by the given amount.
-func (s *DuckDBStore) DecrementTrustScore(ctx context.Context, userID int, amount int) error {
+// The change and the violated rule are recorded in the score history.
+func (s *DuckDBStore) DecrementTrustScore(ctx context.Context, userID int, amount int, ruleType RuleType) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	previous, err := currentTrustScore(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
 	// First ensure the user has a record
 	now := time.Now()
 	query := `INSERT INTO user_trust_scores (user_id, score, violations_count, last_violation_at, restricted, updated_at)
@@ -701,7 +747,7 @@ func (s *DuckDBStore) DecrementTrustScore(ctx context.Context, userID int, amoun
 			restricted = GREATEST(0, user_trust_scores.score - ?) < 50,
 			updated_at = ?`
 
-	_, err := s.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		userID, amount, now, 100-amount, now, // INSERT values
 		amount, now, amount, now, // UPDATE values
 	)
@@ -709,22 +755,190 @@ func (s *DuckDBStore) DecrementTrustScore(ctx context.Context, userID int, amoun
 		return fmt.Errorf("failed to decrement trust score: %w", err)
 	}
 
+	score := max(0, previous-amount)
+	if err := insertTrustScoreChange(ctx, tx, &TrustScoreChange{
+		UserID:        userID,
+		PreviousScore: previous,
+		Score:         score,
+		Delta:         score - previous,
+		Reason:        TrustChangeViolation,
+		RuleType:      ruleType,
+		CreatedAt:     now,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit trust score decrement: %w", err)
+	}
 	return nil
 }
 
 // RecoverTrustScores increases all users' trust scores (daily job).
+// Each user whose score rises gets a recovery entry in the score history.
 func (s *DuckDBStore) RecoverTrustScores(ctx context.Context, amount int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	now := time.Now()
+
+	// Record history before the update, while the previous scores are visible
+	history := `INSERT INTO trust_score_history (id, user_id, previous_score, score, delta, reason, created_at)
+		SELECT uuid()::TEXT, user_id, score, LEAST(100, score + ?), LEAST(100, score + ?) - score, ?, ?
+		FROM user_trust_scores
+		WHERE score < 100`
+	if _, err := tx.ExecContext(ctx, history, amount, amount, string(TrustChangeRecovery), now); err != nil {
+		return fmt.Errorf("failed to record trust score recovery: %w", err)
+	}
+
 	query := `UPDATE user_trust_scores
 		SET score = LEAST(100, score + ?),
-		    restricted = LEAST(100, score + ?) >= 50,
+		    restricted = LEAST(100, score + ?) < 50,
 		    updated_at = ?
 		WHERE score < 100`
 
-	_, err := s.db.ExecContext(ctx, query, amount, amount, time.Now())
-	if err != nil {
+	if _, err := tx.ExecContext(ctx, query, amount, amount, now); err != nil {
 		return fmt.Errorf("failed to recover trust scores: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit trust score recovery: %w", err)
+	}
+	return nil
+}
+
+// SetTrustScore sets a user's score to change.Score and records the change.
+// The caller sets UserID, Score, Reason, ChangedBy, and Note; the remaining
+// fields are filled in. Violation counts are kept.
+func (s *DuckDBStore) SetTrustScore(ctx context.Context, change *TrustScoreChange) (*TrustScore, error) {
+	if change.Score < 0 || change.Score > 100 {
+		return nil, fmt.Errorf("trust score must be between 0 and 100, got %d", change.Score)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	previous, err := currentTrustScore(ctx, tx, change.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	query := `INSERT INTO user_trust_scores (user_id, score, violations_count, restricted, updated_at)
+		VALUES (?, ?, 0, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			score = EXCLUDED.score,
+			restricted = EXCLUDED.restricted,
+			updated_at = EXCLUDED.updated_at`
+	if _, err := tx.ExecContext(ctx, query, change.UserID, change.Score, change.Score < 50, now); err != nil {
+		return nil, fmt.Errorf("failed to set trust score: %w", err)
+	}
+
+	change.PreviousScore = previous
+	change.Delta = change.Score - previous
+	change.CreatedAt = now
+	if err := insertTrustScoreChange(ctx, tx, change); err != nil {
+		return nil, err
+	}
+
+	score := &TrustScore{}
+	scoreQuery := `SELECT user_id, username, score, violations_count, last_violation_at, restricted, updated_at
+		FROM user_trust_scores WHERE user_id = ?`
+	if err := scanTrustScoreRow(tx.QueryRowContext(ctx, scoreQuery, change.UserID), score); err != nil {
+		return nil, fmt.Errorf("failed to read trust score: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit trust score change: %w", err)
+	}
+	return score, nil
+}
+
+// GetTrustScoreHistory returns a user's most recent score changes, newest first.
+func (s *DuckDBStore) GetTrustScoreHistory(ctx context.Context, userID int, limit int) ([]TrustScoreChange, error) {
+	query := `SELECT id, user_id, previous_score, score, delta, reason, rule_type, changed_by, note, created_at
+		FROM trust_score_history
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ?`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trust score history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []TrustScoreChange{}
+	for rows.Next() {
+		var change TrustScoreChange
+		var ruleType, changedBy, note sql.NullString
+		if err := rows.Scan(
+			&change.ID,
+			&change.UserID,
+			&change.PreviousScore,
+			&change.Score,
+			&change.Delta,
+			&change.Reason,
+			&ruleType,
+			&changedBy,
+			&note,
+			&change.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan trust score change: %w", err)
+		}
+		change.RuleType = RuleType(ruleType.String)
+		change.ChangedBy = changedBy.String
+		change.Note = note.String
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+// currentTrustScore returns a user's score inside tx, or 100 for users
+// without a record.
+func currentTrustScore(ctx context.Context, tx *sql.Tx, userID int) (int, error) {
+	var score int
+	err := tx.QueryRowContext(ctx, `SELECT score FROM user_trust_scores WHERE user_id = ?`, userID).Scan(&score)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 100, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trust score: %w", err)
+	}
+	return score, nil
+}
+
+// insertTrustScoreChange writes one history entry inside tx, assigning its ID.
+func insertTrustScoreChange(ctx context.Context, tx *sql.Tx, change *TrustScoreChange) error {
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+
+	query := `INSERT INTO trust_score_history
+		(id, user_id, previous_score, score, delta, reason, rule_type, changed_by, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := tx.ExecContext(ctx, query,
+		change.ID,
+		change.UserID,
+		change.PreviousScore,
+		change.Score,
+		change.Delta,
+		string(change.Reason),
+		string(change.RuleType),
+		change.ChangedBy,
+		change.Note,
+		change.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record trust score change: %w", err)
+	}
 	return nil
 }
 
@@ -755,6 +969,204 @@ func (s *DuckDBStore) scanTrustScores(rows *sql.Rows) ([]TrustScore, error) {
 	return scores, rows.Err()
 }
 
+// =============================================================================
+// AllowlistStore Interface Implementation
+// =============================================================================
+
+// AddTrustedLocation persists a new allowlist entry, assigning its ID.
+func (s *DuckDBStore) AddTrustedLocation(ctx context.Context, location *TrustedLocation) error {
+	if location.ID == "" {
+		location.ID = uuid.New().String()
+	}
+	if location.CreatedAt.IsZero() {
+		location.CreatedAt = time.Now()
+	}
+
+	query := `INSERT INTO detection_allowlist (id, user_id, cidr, country, note, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := s.db.ExecContext(ctx, query,
+		location.ID,
+		location.UserID,
+		location.CIDR,
+		location.Country,
+		location.Note,
+		location.CreatedBy,
+		location.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert trusted location: %w", err)
+	}
+
+	return nil
+}
+
+// ListTrustedLocations retrieves allowlist entries. Pass a user ID to get
+// only the entries that apply to that user, including global ones.
+func (s *DuckDBStore) ListTrustedLocations(ctx context.Context, userID *int) ([]TrustedLocation, error) {
+	query := `SELECT id, user_id, COALESCE(cidr, ''), COALESCE(country, ''),
+		COALESCE(note, ''), COALESCE(created_by, ''), created_at
+		FROM detection_allowlist`
+	args := make([]interface{}, 0, 1)
+	if userID != nil {
+		query += ` WHERE user_id = 0 OR user_id = ?`
+		args = append(args, *userID)
+	}
+	query += ` ORDER BY user_id, created_at`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trusted locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := make([]TrustedLocation, 0)
+	for rows.Next() {
+		var loc TrustedLocation
+		if err := rows.Scan(&loc.ID, &loc.UserID, &loc.CIDR, &loc.Country, &loc.Note, &loc.CreatedBy, &loc.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan trusted location: %w", err)
+		}
+		locations = append(locations, loc)
+	}
+	return locations, rows.Err()
+}
+
+// DeleteTrustedLocation removes an entry. Returns false if it did not exist.
+func (s *DuckDBStore) DeleteTrustedLocation(ctx context.Context, id string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM detection_allowlist WHERE id = ?`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete trusted location: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// =============================================================================
+// DeviceHistory Interface Implementation
+// =============================================================================
+
+// userDeviceColumns selects a user_devices row with the geolocation of its
+// last address.
+const userDeviceColumns = `
+		d.user_id, d.device_id, COALESCE(d.name, ''), d.flagged,
+		COALESCE(d.player, ''), COALESCE(d.product, ''),
+		COALESCE(d.platform, ''), COALESCE(d.device, ''),
+		d.first_seen_at, COALESCE(d.first_session_key, ''),
+		d.last_seen_at, COALESCE(d.last_ip_address, ''), d.session_count,
+		g.ip_address, COALESCE(g.latitude, 0), COALESCE(g.longitude, 0),
+		COALESCE(g.city, ''), COALESCE(g.region, ''), COALESCE(g.country, '')
+	FROM user_devices d
+	LEFT JOIN geolocations g ON g.ip_address = d.last_ip_address`
+
+// scanUserDevice scans a row selected with userDeviceColumns.
+func scanUserDevice(row interface{ Scan(...interface{}) error }) (*UserDevice, error) {
+	var d UserDevice
+	var geoIP sql.NullString
+	geo := &Geolocation{}
+	if err := row.Scan(
+		&d.UserID, &d.DeviceID, &d.Name, &d.Flagged,
+		&d.Player, &d.Product, &d.Platform, &d.Device,
+		&d.FirstSeenAt, &d.FirstSessionKey,
+		&d.LastSeenAt, &d.LastIPAddress, &d.SessionCount,
+		&geoIP, &geo.Latitude, &geo.Longitude, &geo.City, &geo.Region, &geo.Country,
+	); err != nil {
+		return nil, err
+	}
+	if geoIP.Valid {
+		geo.IPAddress = geoIP.String
+		d.LastLocation = geo
+	}
+	return &d, nil
+}
+
+// GetUserDevice retrieves a device from a user's inventory. Returns nil if
+// the user has never streamed from it.
+func (s *DuckDBStore) GetUserDevice(ctx context.Context, userID int, deviceID string) (*UserDevice, error) {
+	query := `SELECT ` + userDeviceColumns + `
+		WHERE d.user_id = ? AND d.device_id = ?`
+
+	device, err := scanUserDevice(s.db.QueryRowContext(ctx, query, userID, deviceID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user device: %w", err)
+	}
+	return device, nil
+}
+
+// ListUserDevices retrieves a user's devices, most recently used first.
+func (s *DuckDBStore) ListUserDevices(ctx context.Context, userID int) ([]UserDevice, error) {
+	query := `SELECT ` + userDeviceColumns + `
+		WHERE d.user_id = ?
+		ORDER BY d.last_seen_at DESC, d.device_id`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := make([]UserDevice, 0)
+	for rows.Next() {
+		device, err := scanUserDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user device: %w", err)
+		}
+		devices = append(devices, *device)
+	}
+	return devices, rows.Err()
+}
+
+// GetUserFirstSeen returns when the user first streamed from any device,
+// or the zero time if they never have.
+func (s *DuckDBStore) GetUserFirstSeen(ctx context.Context, userID int) (time.Time, error) {
+	var firstSeen sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		`SELECT MIN(first_seen_at) FROM user_devices WHERE user_id = ?`, userID,
+	).Scan(&firstSeen)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get user first seen: %w", err)
+	}
+	return firstSeen.Time, nil
+}
+
+// UpdateUserDevice renames or flags a device and returns the updated entry.
+// Returns nil if the user has no such device.
+func (s *DuckDBStore) UpdateUserDevice(ctx context.Context, userID int, deviceID string, update UserDeviceUpdate) (*UserDevice, error) {
+	var sets []string
+	var args []interface{}
+	if update.Name != nil {
+		sets = append(sets, "name = NULLIF(?, '')")
+		args = append(args, *update.Name)
+	}
+	if update.Flagged != nil {
+		sets = append(sets, "flagged = ?")
+		args = append(args, *update.Flagged)
+	}
+	if len(sets) == 0 {
+		return s.GetUserDevice(ctx, userID, deviceID)
+	}
+
+	query := `UPDATE user_devices SET ` + strings.Join(sets, ", ") + ` WHERE user_id = ? AND device_id = ?`
+	result, err := s.db.ExecContext(ctx, query, append(args, userID, deviceID)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user device: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+	return s.GetUserDevice(ctx, userID, deviceID)
+}
+
 // =============================================================================
 // EventHistory Interface Implementation
 // =============================================================================
@@ -885,7 +1297,7 @@ func (s *DuckDBStore) GetGeolocation(ctx context.Context, ipAddress string) (*Ge
 		WHERE ip_address = ?`
 
 	geo := &Geolocation{}
-	err := s.db.QueryRowContext(ctx, query, ipAddress).Scan(
+	err := s.db.QueryRowContext(ctx, query, privacy.AnonymizeIP(ipAddress)).Scan(
 		&geo.IPAddress,
 		&geo.Latitude,
 		&geo.Longitude,
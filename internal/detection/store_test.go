@@ -108,6 +108,27 @@ func initTestSchema(ctx context.Context, db *sql.DB) error {
 			restricted BOOLEAN DEFAULT false,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,
+		`CREATE TABLE IF NOT EXISTS trust_score_history (
+			id TEXT PRIMARY KEY,
+			user_id INTEGER NOT NULL,
+			previous_score INTEGER NOT NULL,
+			score INTEGER NOT NULL,
+			delta INTEGER NOT NULL,
+			reason TEXT NOT NULL,
+			rule_type TEXT,
+			changed_by TEXT,
+			note TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS detection_allowlist (
+			id TEXT PRIMARY KEY,
+			user_id INTEGER NOT NULL DEFAULT 0,
+			cidr TEXT,
+			country TEXT,
+			note TEXT,
+			created_by TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
 	}
 
 	for _, table := range tables {
@@ -674,7 +695,7 @@ func TestDuckDBStore_DecrementTrustScore(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("new user", func(t *testing.T) {
-		err := store.DecrementTrustScore(ctx, 1001, 10)
+		err := store.DecrementTrustScore(ctx, 1001, 10, RuleTypeImpossibleTravel)
 		if err != nil {
 			t.Fatalf("DecrementTrustScore failed: %v", err)
 		}
@@ -693,7 +714,7 @@ func TestDuckDBStore_DecrementTrustScore(t *testing.T) {
 
 	t.Run("existing user", func(t *testing.T) {
 		// Decrement again
-		err := store.DecrementTrustScore(ctx, 1001, 50)
+		err := store.DecrementTrustScore(ctx, 1001, 50, RuleTypeConcurrentStreams)
 		if err != nil {
 			t.Fatalf("DecrementTrustScore failed: %v", err)
 		}
@@ -714,7 +735,7 @@ func TestDuckDBStore_DecrementTrustScore(t *testing.T) {
 	})
 
 	t.Run("does not go below zero", func(t *testing.T) {
-		err := store.DecrementTrustScore(ctx, 1001, 100)
+		err := store.DecrementTrustScore(ctx, 1001, 100, RuleTypeDeviceVelocity)
 		if err != nil {
 			t.Fatalf("DecrementTrustScore failed: %v", err)
 		}
@@ -795,6 +816,111 @@ func TestDuckDBStore_RecoverTrustScores_CapsAt100(t *testing.T) {
 	}
 }
 
+func TestDuckDBStore_TrustScoreHistory(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	if err := store.DecrementTrustScore(ctx, 7, 30, RuleTypeImpossibleTravel); err != nil {
+		t.Fatalf("DecrementTrustScore failed: %v", err)
+	}
+	if err := store.DecrementTrustScore(ctx, 7, 40, RuleTypeAccountSharing); err != nil {
+		t.Fatalf("DecrementTrustScore failed: %v", err)
+	}
+	if err := store.RecoverTrustScores(ctx, 5); err != nil {
+		t.Fatalf("RecoverTrustScores failed: %v", err)
+	}
+
+	history, err := store.GetTrustScoreHistory(ctx, 7, 10)
+	if err != nil {
+		t.Fatalf("GetTrustScoreHistory failed: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("history has %d entries, want 3", len(history))
+	}
+
+	// Newest first: recovery, then the two violations
+	want := []struct {
+		reason   TrustScoreChangeReason
+		rule     RuleType
+		previous int
+		score    int
+	}{
+		{TrustChangeRecovery, "", 30, 35},
+		{TrustChangeViolation, RuleTypeAccountSharing, 70, 30},
+		{TrustChangeViolation, RuleTypeImpossibleTravel, 100, 70},
+	}
+	for i, w := range want {
+		got := history[i]
+		if got.Reason != w.reason || got.RuleType != w.rule || got.PreviousScore != w.previous || got.Score != w.score {
+			t.Errorf("entry %d = %+v, want %+v", i, got, w)
+		}
+		if got.Delta != w.score-w.previous {
+			t.Errorf("entry %d delta = %d, want %d", i, got.Delta, w.score-w.previous)
+		}
+	}
+
+	// Recovery lifts the restriction once the score reaches 50
+	if err := store.RecoverTrustScores(ctx, 20); err != nil {
+		t.Fatalf("RecoverTrustScores failed: %v", err)
+	}
+	score, err := store.GetTrustScore(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetTrustScore failed: %v", err)
+	}
+	if score.Score != 55 || score.Restricted {
+		t.Errorf("after recovery score = %d, restricted = %v; want 55, false", score.Score, score.Restricted)
+	}
+}
+
+func TestDuckDBStore_SetTrustScore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	if err := store.DecrementTrustScore(ctx, 9, 60, RuleTypeGeoRestriction); err != nil {
+		t.Fatalf("DecrementTrustScore failed: %v", err)
+	}
+
+	change := &TrustScoreChange{UserID: 9, Score: 100, Reason: TrustChangeReset, ChangedBy: "admin", Note: "false positive"}
+	score, err := store.SetTrustScore(ctx, change)
+	if err != nil {
+		t.Fatalf("SetTrustScore failed: %v", err)
+	}
+	if score.Score != 100 || score.Restricted {
+		t.Errorf("score = %d, restricted = %v; want 100, false", score.Score, score.Restricted)
+	}
+	if score.ViolationsCount != 1 {
+		t.Errorf("ViolationsCount = %d, want 1 (kept after reset)", score.ViolationsCount)
+	}
+	if change.PreviousScore != 40 || change.Delta != 60 || change.ID == "" {
+		t.Errorf("change = %+v, want previous 40, delta 60, ID set", change)
+	}
+
+	history, err := store.GetTrustScoreHistory(ctx, 9, 1)
+	if err != nil {
+		t.Fatalf("GetTrustScoreHistory failed: %v", err)
+	}
+	if len(history) != 1 || history[0].Reason != TrustChangeReset || history[0].ChangedBy != "admin" || history[0].Note != "false positive" {
+		t.Errorf("latest history = %+v, want reset by admin", history)
+	}
+
+	// Setting a score for an unknown user creates the record
+	score, err = store.SetTrustScore(ctx, &TrustScoreChange{UserID: 10, Score: 20, Reason: TrustChangeOverride, ChangedBy: "admin"})
+	if err != nil {
+		t.Fatalf("SetTrustScore (new user) failed: %v", err)
+	}
+	if score.Score != 20 || !score.Restricted {
+		t.Errorf("score = %d, restricted = %v; want 20, true", score.Score, score.Restricted)
+	}
+
+	if _, err := store.SetTrustScore(ctx, &TrustScoreChange{UserID: 9, Score: 101}); err == nil {
+		t.Error("expected error for score above 100")
+	}
+}
+
 func TestDuckDBStore_ListLowTrustUsers(t *testing.T) {
 	store, cleanup := setupTestStore(t)
 	defer cleanup()
@@ -841,6 +967,54 @@ func TestDuckDBStore_ListLowTrustUsers(t *testing.T) {
 	}
 }
 
+func TestDuckDBStore_TrustedLocations(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	global := &TrustedLocation{CIDR: "203.0.113.0/24", Note: "office VPN", CreatedBy: "admin"}
+	user := &TrustedLocation{UserID: 7, Country: "Canada"}
+	other := &TrustedLocation{UserID: 8, Country: "Mexico"}
+	for _, loc := range []*TrustedLocation{global, user, other} {
+		if err := store.AddTrustedLocation(ctx, loc); err != nil {
+			t.Fatalf("AddTrustedLocation failed: %v", err)
+		}
+		if loc.ID == "" || loc.CreatedAt.IsZero() {
+			t.Errorf("AddTrustedLocation did not set ID and CreatedAt: %+v", loc)
+		}
+	}
+
+	all, err := store.ListTrustedLocations(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTrustedLocations failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("len(all) = %d, want 3", len(all))
+	}
+	if all[0].ID != global.ID || all[0].Note != "office VPN" || all[0].CreatedBy != "admin" {
+		t.Errorf("first entry = %+v, want global entry first", all[0])
+	}
+
+	userID := 7
+	forUser, err := store.ListTrustedLocations(ctx, &userID)
+	if err != nil {
+		t.Fatalf("ListTrustedLocations failed: %v", err)
+	}
+	if len(forUser) != 2 || forUser[1].Country != "Canada" {
+		t.Errorf("entries for user 7 = %+v, want global and Canada", forUser)
+	}
+
+	deleted, err := store.DeleteTrustedLocation(ctx, user.ID)
+	if err != nil || !deleted {
+		t.Fatalf("DeleteTrustedLocation = %v, %v, want true", deleted, err)
+	}
+	deleted, err = store.DeleteTrustedLocation(ctx, user.ID)
+	if err != nil || deleted {
+		t.Errorf("second DeleteTrustedLocation = %v, %v, want false", deleted, err)
+	}
+}
+
 func TestDuckDBStore_BuildPlaceholders(t *testing.T) {
 	store := &DuckDBStore{}
 
@@ -1040,3 +1214,94 @@ func TestDuckDBStore_GetAlertCount_WithAcknowledged(t *testing.T) {
 		t.Errorf("expected 3 unacknowledged alerts, got %d", count)
 	}
 }
+
+func TestDuckDBStore_UserDevices(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		`CREATE TABLE user_devices (
+			user_id INTEGER NOT NULL,
+			device_id TEXT NOT NULL,
+			name TEXT,
+			flagged BOOLEAN NOT NULL DEFAULT false,
+			player TEXT,
+			product TEXT,
+			platform TEXT,
+			device TEXT,
+			first_seen_at TIMESTAMP NOT NULL,
+			first_session_key TEXT,
+			first_ip_address TEXT,
+			last_seen_at TIMESTAMP NOT NULL,
+			last_ip_address TEXT,
+			session_count INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (user_id, device_id)
+		)`,
+		`CREATE TABLE geolocations (
+			ip_address TEXT PRIMARY KEY,
+			latitude DOUBLE,
+			longitude DOUBLE,
+			city TEXT,
+			region TEXT,
+			country TEXT
+		)`,
+		`INSERT INTO geolocations VALUES ('203.0.113.0', 38.72, -9.14, 'Lisbon', 'Lisboa', 'Portugal')`,
+		`INSERT INTO user_devices (user_id, device_id, player, platform, first_seen_at, first_session_key,
+			last_seen_at, last_ip_address, session_count) VALUES
+			(1, 'tv', 'Plex for LG', 'webOS', '2026-01-01 10:00:00', 's1', '2026-02-01 10:00:00', '198.51.100.0', 12),
+			(1, 'phone', 'Plex for iOS', 'iOS', '2026-01-10 10:00:00', 's2', '2026-02-10 10:00:00', '203.0.113.0', 3),
+			(2, 'laptop', 'Plex Web', 'Chrome', '2025-12-01 10:00:00', 's3', '2025-12-01 10:00:00', NULL, 1)`,
+	} {
+		if _, err := store.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to set up user devices: %v", err)
+		}
+	}
+
+	devices, err := store.ListUserDevices(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListUserDevices failed: %v", err)
+	}
+	if len(devices) != 2 || devices[0].DeviceID != "phone" || devices[1].DeviceID != "tv" {
+		t.Fatalf("devices = %+v, want phone then tv", devices)
+	}
+	if loc := devices[0].LastLocation; loc == nil || loc.City != "Lisbon" {
+		t.Errorf("phone LastLocation = %+v, want Lisbon", loc)
+	}
+	if devices[1].LastLocation != nil || devices[1].SessionCount != 12 {
+		t.Errorf("tv = %+v, want 12 sessions and no location", devices[1])
+	}
+
+	device, err := store.GetUserDevice(ctx, 1, "tv")
+	if err != nil || device == nil || device.FirstSessionKey != "s1" || device.Player != "Plex for LG" {
+		t.Errorf("GetUserDevice = %+v, %v; want tv first seen in s1", device, err)
+	}
+	device, err = store.GetUserDevice(ctx, 2, "tv")
+	if err != nil || device != nil {
+		t.Errorf("GetUserDevice for another user = %+v, %v; want nil", device, err)
+	}
+
+	firstSeen, err := store.GetUserFirstSeen(ctx, 1)
+	if err != nil || !firstSeen.Equal(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("GetUserFirstSeen = %v, %v; want 2026-01-01 10:00", firstSeen, err)
+	}
+	firstSeen, err = store.GetUserFirstSeen(ctx, 99)
+	if err != nil || !firstSeen.IsZero() {
+		t.Errorf("GetUserFirstSeen for unknown user = %v, %v; want zero", firstSeen, err)
+	}
+
+	name, flagged := "Living room TV", true
+	device, err = store.UpdateUserDevice(ctx, 1, "tv", UserDeviceUpdate{Name: &name, Flagged: &flagged})
+	if err != nil || device == nil || device.Name != name || !device.Flagged {
+		t.Fatalf("UpdateUserDevice = %+v, %v; want renamed and flagged", device, err)
+	}
+	empty := ""
+	device, err = store.UpdateUserDevice(ctx, 1, "tv", UserDeviceUpdate{Name: &empty})
+	if err != nil || device == nil || device.Name != "" || !device.Flagged {
+		t.Errorf("UpdateUserDevice clearing name = %+v, %v; want name cleared, still flagged", device, err)
+	}
+	device, err = store.UpdateUserDevice(ctx, 1, "missing", UserDeviceUpdate{Flagged: &flagged})
+	if err != nil || device != nil {
+		t.Errorf("UpdateUserDevice for missing device = %+v, %v; want nil", device, err)
+	}
+}
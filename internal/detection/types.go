@@ -7,7 +7,9 @@ package detection
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-json"
@@ -57,6 +59,10 @@ const (
 
 	// RuleTypeSimultaneousLocations flags same account from multiple cities.
 	RuleTypeSimultaneousLocations RuleType = "simultaneous_locations"
+
+	// RuleTypeBufferHealth marks critical Plex buffer health alerts. They are
+	// raised by the sync manager's buffer monitoring, not by a detector.
+	RuleTypeBufferHealth RuleType = "buffer_health"
 )
 
 // Severity indicates the severity level of an alert.
@@ -68,13 +74,45 @@ const (
 	SeverityCritical Severity = "critical"
 )
 
+// rank orders severities from least to most severe. Unknown values rank
+// below info.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is at least as severe as threshold. An empty
+// threshold matches every severity.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return threshold == "" || s.rank() >= threshold.rank()
+}
+
+// ParseSeverity parses a severity name, case-insensitively. An empty string
+// returns an empty severity, which matches every alert.
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(strings.ToLower(strings.TrimSpace(s))); sev {
+	case "", SeverityInfo, SeverityWarning, SeverityCritical:
+		return sev, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q (must be info, warning, or critical)", s)
+	}
+}
+
 // Rule represents a detection rule configuration.
 type Rule struct {
 	ID        int64           `json:"id"`
 	RuleType  RuleType        `json:"rule_type"`
 	Name      string          `json:"name"`
 	Enabled   bool            `json:"enabled"`
-	Config    json.RawMessage `json:"config"`
+	Config    json.RawMessage `json:"config" swaggertype:"object"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
@@ -201,7 +239,7 @@ type Alert struct {
 	Severity       Severity        `json:"severity"`
 	Title          string          `json:"title"`
 	Message        string          `json:"message"`
-	Metadata       json.RawMessage `json:"metadata,omitempty"`
+	Metadata       json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
 	Acknowledged   bool            `json:"acknowledged"`
 	AcknowledgedBy string          `json:"acknowledged_by,omitempty"`
 	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty"`
@@ -251,6 +289,30 @@ type TrustScore struct {
 	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
+// TrustScoreChangeReason explains why a trust score changed.
+type TrustScoreChangeReason string
+
+const (
+	TrustChangeViolation TrustScoreChangeReason = "violation" // Detection rule violation
+	TrustChangeRecovery  TrustScoreChangeReason = "recovery"  // Daily recovery job
+	TrustChangeOverride  TrustScoreChangeReason = "override"  // Set manually by an admin
+	TrustChangeReset     TrustScoreChangeReason = "reset"     // Reset to 100 by an admin
+)
+
+// TrustScoreChange is one entry in a user's trust score history.
+type TrustScoreChange struct {
+	ID            string                 `json:"id"`
+	UserID        int                    `json:"user_id"`
+	PreviousScore int                    `json:"previous_score"`
+	Score         int                    `json:"score"`
+	Delta         int                    `json:"delta"`
+	Reason        TrustScoreChangeReason `json:"reason"`
+	RuleType      RuleType               `json:"rule_type,omitempty"`  // Rule that fired, for violations
+	ChangedBy     string                 `json:"changed_by,omitempty"` // Admin username, for manual changes
+	Note          string                 `json:"note,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
 // Detector is the interface that all detection rules implement.
 type Detector interface {
 	// Type returns the rule type this detector handles.
@@ -291,12 +353,14 @@ type DetectionEvent struct {
 	MachineID string `json:"machine_id,omitempty"`
 	Platform  string `json:"platform,omitempty"`
 	Player    string `json:"player,omitempty"`
+	Product   string `json:"product,omitempty"`
 	Device    string `json:"device,omitempty"`
 
 	// Media information
 	MediaType        string `json:"media_type"`
 	Title            string `json:"title"`
 	GrandparentTitle string `json:"grandparent_title,omitempty"` // Show name
+	RatingKey        string `json:"rating_key,omitempty"`        // Media server item ID
 
 	// Network information
 	IPAddress    string `json:"ip_address"`
@@ -308,6 +372,20 @@ type DetectionEvent struct {
 	City      string  `json:"city,omitempty"`
 	Region    string  `json:"region,omitempty"`
 	Country   string  `json:"country,omitempty"`
+
+	// clientIP is IPAddress as received, before Engine.Process anonymized it
+	clientIP string
+}
+
+// ClientIP returns the address the event arrived with. Engine.Process
+// replaces IPAddress with its anonymized form (PRIVACY_IP_MODE) before the
+// detectors run; the trusted-location CIDR check and the VPN lookup need
+// the real address and use this instead.
+func (e *DetectionEvent) ClientIP() string {
+	if e.clientIP != "" {
+		return e.clientIP
+	}
+	return e.IPAddress
 }
 
 // AlertStore defines the interface for alert persistence.
@@ -366,16 +444,38 @@ type TrustStore interface {
 	// UpdateTrustScore updates a user's trust score.
 	UpdateTrustScore(ctx context.Context, score *TrustScore) error
 
-	// DecrementTrustScore decreases a user's trust score by the given amount.
-	DecrementTrustScore(ctx context.Context, userID int, amount int) error
+	// DecrementTrustScore decreases a user's trust score by the given amount
+	// and records the violated rule in the score history.
+	DecrementTrustScore(ctx context.Context, userID int, amount int, ruleType RuleType) error
 
-	// RecoverTrustScores increases all users' trust scores (daily job).
+	// RecoverTrustScores increases all users' trust scores (daily job) and
+	// records each change in the score history.
 	RecoverTrustScores(ctx context.Context, amount int) error
 
+	// SetTrustScore sets a user's score to change.Score, recording change in
+	// the score history. Used for manual overrides and resets.
+	SetTrustScore(ctx context.Context, change *TrustScoreChange) (*TrustScore, error)
+
+	// GetTrustScoreHistory returns a user's most recent score changes, newest first.
+	GetTrustScoreHistory(ctx context.Context, userID int, limit int) ([]TrustScoreChange, error)
+
 	// ListLowTrustUsers returns users with trust scores below threshold.
 	ListLowTrustUsers(ctx context.Context, threshold int) ([]TrustScore, error)
 }
 
+// AllowlistStore defines the interface for trusted location persistence.
+type AllowlistStore interface {
+	// AddTrustedLocation persists a new allowlist entry, assigning its ID.
+	AddTrustedLocation(ctx context.Context, location *TrustedLocation) error
+
+	// ListTrustedLocations retrieves allowlist entries. Pass a user ID to get
+	// only the entries that apply to that user, including global ones.
+	ListTrustedLocations(ctx context.Context, userID *int) ([]TrustedLocation, error)
+
+	// DeleteTrustedLocation removes an entry. Returns false if it did not exist.
+	DeleteTrustedLocation(ctx context.Context, id string) (bool, error)
+}
+
 // EventHistory provides access to recent events for detection rules.
 // v2.1: All methods now include serverID parameter for multi-server support.
 // Pass empty string for serverID to query across all servers (legacy behavior).
@@ -239,6 +239,57 @@ func TestSeverityConstants(t *testing.T) {
 	}
 }
 
+func TestSeverity_AtLeast(t *testing.T) {
+	tests := []struct {
+		severity  Severity
+		threshold Severity
+		expected  bool
+	}{
+		{SeverityInfo, "", true},
+		{SeverityInfo, SeverityInfo, true},
+		{SeverityInfo, SeverityWarning, false},
+		{SeverityWarning, SeverityInfo, true},
+		{SeverityWarning, SeverityCritical, false},
+		{SeverityCritical, SeverityWarning, true},
+		{SeverityCritical, SeverityCritical, true},
+		{Severity("unknown"), SeverityInfo, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.severity)+">="+string(tt.threshold), func(t *testing.T) {
+			if got := tt.severity.AtLeast(tt.threshold); got != tt.expected {
+				t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.severity, tt.threshold, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Severity
+		wantErr  bool
+	}{
+		{"", "", false},
+		{"info", SeverityInfo, false},
+		{" Warning ", SeverityWarning, false},
+		{"CRITICAL", SeverityCritical, false},
+		{"high", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSeverity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSeverity(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseSeverity(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestCoordinateEpsilonValue(t *testing.T) {
 	// Verify epsilon is sensible (approximately 1cm at equator)
 	// 1e-7 degrees * 111,111 m/degree ≈ 1.1cm
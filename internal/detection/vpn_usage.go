@@ -74,6 +74,13 @@ type VPNUsageMetadata struct {
 	// Confidence is the VPN detection confidence (0-100).
 	Confidence int `json:"confidence"`
 
+	// MatchType is "exact" for a listed server address or "cidr" for an
+	// address inside a provider's range.
+	MatchType string `json:"match_type,omitempty"`
+
+	// MatchedRange is the CIDR range that matched, for a "cidr" match.
+	MatchedRange string `json:"matched_range,omitempty"`
+
 	// AlertReason explains why the alert was generated.
 	AlertReason string `json:"alert_reason"`
 
@@ -170,8 +177,8 @@ func (d *VPNUsageDetector) Check(ctx context.Context, event *DetectionEvent) (*A
 		return nil, nil
 	}
 
-	// Lookup VPN information
-	vpnResult := d.vpnSvc.LookupIP(event.IPAddress)
+	// Lookup VPN information (with the address from before anonymization)
+	vpnResult := d.vpnSvc.LookupIP(event.ClientIP())
 	if !vpnResult.IsVPN {
 		return nil, nil
 	}
@@ -203,6 +210,8 @@ func (d *VPNUsageDetector) Check(ctx context.Context, event *DetectionEvent) (*A
 		VPNServerCountry:    vpnResult.ServerCountry,
 		VPNServerCity:       vpnResult.ServerCity,
 		Confidence:          vpnResult.Confidence,
+		MatchType:           vpnResult.MatchType,
+		MatchedRange:        vpnResult.MatchedRange,
 		AlertReason:         alertReason,
 		GeolocationCountry:  event.Country,
 	}
@@ -230,10 +239,14 @@ func (d *VPNUsageDetector) Check(ctx context.Context, event *DetectionEvent) (*A
 
 	// Build alert message
 	var message string
-	if vpnResult.ServerCity != "" {
+	switch {
+	case vpnResult.ServerCity != "":
 		message = fmt.Sprintf("User %s is streaming via %s VPN (server in %s, %s)",
 			event.Username, vpnResult.ProviderDisplayName, vpnResult.ServerCity, vpnResult.ServerCountry)
-	} else {
+	case vpnResult.ServerCountry == "" && vpnResult.MatchedRange != "":
+		message = fmt.Sprintf("User %s is streaming via %s VPN (address in range %s)",
+			event.Username, vpnResult.ProviderDisplayName, vpnResult.MatchedRange)
+	default:
 		message = fmt.Sprintf("User %s is streaming via %s VPN (server in %s)",
 			event.Username, vpnResult.ProviderDisplayName, vpnResult.ServerCountry)
 	}
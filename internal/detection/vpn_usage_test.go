@@ -12,6 +12,7 @@ import (
 
 	"github.com/goccy/go-json"
 
+	"github.com/tomtom215/cartographus/internal/privacy"
 	"github.com/tomtom215/cartographus/internal/vpn"
 )
 
@@ -511,3 +512,36 @@ func TestVPNUsageDetector_NoIPAddress(t *testing.T) {
 		t.Error("expected no alert for empty IP")
 	}
 }
+
+func TestEngine_Process_VPNLookupUsesAddressBeforeAnonymization(t *testing.T) {
+	setIPMode(t, privacy.IPModeTruncated)
+
+	lookup := vpn.NewLookup()
+	lookup.AddServer(&vpn.Server{Provider: "nordvpn", Country: "United States", IPs: []string{"198.51.100.1"}})
+	lookup.AddProvider(&vpn.Provider{Name: "nordvpn", DisplayName: "NordVPN"})
+
+	alertStore := &mockAlertStore{}
+	engine := NewEngine(alertStore, newMockTrustStore(), &mockEventHistory{}, &mockBroadcaster{})
+	defer engine.Close()
+	engine.RegisterDetector(NewVPNUsageDetectorForTest(lookup))
+
+	event := &DetectionEvent{
+		UserID:       1,
+		Username:     "testuser",
+		IPAddress:    "198.51.100.1",
+		LocationType: "wan",
+		Timestamp:    time.Now(),
+	}
+	alerts, err := engine.Process(context.Background(), event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 198.51.100.0 is not a VPN server; the alert proves the lookup saw the real address
+	if len(alerts) != 1 || len(alertStore.alerts) != 1 {
+		t.Fatalf("got %d alerts (%d saved), want 1", len(alerts), len(alertStore.alerts))
+	}
+	if got := alertStore.alerts[0].IPAddress; got != "198.51.100.0" {
+		t.Errorf("saved alert IPAddress = %q, want the truncated address", got)
+	}
+}
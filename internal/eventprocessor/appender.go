@@ -47,6 +47,7 @@ type AppenderStats struct {
 //   - Configurable batch size and flush interval
 //   - Graceful shutdown with pending event flush
 //   - Error retry with buffer retention
+//   - Per-event acknowledgment callbacks run after the event is written
 //   - Metrics for monitoring
 //
 // DETERMINISM: Flush operations are serialized via flushMu to ensure consistent
@@ -59,6 +60,7 @@ type Appender struct {
 	// Buffer management
 	mu     sync.Mutex
 	buffer []*MediaEvent
+	acks   []func() // parallel to buffer; nil for events appended without one
 
 	// DETERMINISM: Flush serialization mutex ensures only one flush runs at a time.
 	// This prevents race conditions between timer-based and batch-triggered flushes
@@ -99,6 +101,7 @@ func NewAppender(store EventStore, cfg AppenderConfig) (*Appender, error) {
 		store:    store,
 		config:   cfg,
 		buffer:   make([]*MediaEvent, 0, cfg.BatchSize),
+		acks:     make([]func(), 0, cfg.BatchSize),
 		stopChan: make(chan struct{}),
 		doneChan: make(chan struct{}),
 	}
@@ -129,12 +132,23 @@ func (a *Appender) Start(ctx context.Context) error {
 // Returns an error if the appender is closed.
 // If the buffer reaches batch size, an async flush is triggered.
 func (a *Appender) Append(ctx context.Context, event *MediaEvent) error {
+	return a.AppendWithAck(ctx, event, nil)
+}
+
+// AppendWithAck adds an event to the buffer like Append and calls ack once
+// the event has been written to the store. Events whose flush fails stay
+// buffered for retry, so ack is never called for an event that was not
+// persisted. Callers use it to acknowledge the source message only after
+// the write (flush-then-ack). ack runs on the flushing goroutine and must
+// not block or call back into the Appender.
+func (a *Appender) AppendWithAck(ctx context.Context, event *MediaEvent, ack func()) error {
 	if a.closed.Load() {
 		return fmt.Errorf("appender is closed")
 	}
 
 	a.mu.Lock()
 	a.buffer = append(a.buffer, event)
+	a.acks = append(a.acks, ack)
 	bufferSize := len(a.buffer)
 	received := a.eventsReceived.Add(1)
 	needsFlush := bufferSize >= a.config.BatchSize
@@ -297,7 +311,9 @@ func (a *Appender) doFlushSync(ctx context.Context) error {
 
 	// Take ownership of buffer
 	events := a.buffer
+	acks := a.acks
 	a.buffer = make([]*MediaEvent, 0, a.config.BatchSize)
+	a.acks = make([]func(), 0, a.config.BatchSize)
 	a.mu.Unlock()
 
 	logging.Debug().
@@ -339,6 +355,7 @@ func (a *Appender) doFlushSync(ctx context.Context) error {
 				Msg("APPENDER: Chunk insert failed, restoring unflushed events to buffer")
 			a.mu.Lock()
 			a.buffer = append(unflushed, a.buffer...)
+			a.acks = append(acks[start:], a.acks...)
 			a.mu.Unlock()
 
 			a.errorCount.Add(1)
@@ -361,6 +378,13 @@ func (a *Appender) doFlushSync(ctx context.Context) error {
 			Msg("APPENDER: Chunk flushed successfully")
 		totalFlushed += len(chunk)
 
+		// Acknowledge only what is now persisted
+		for _, ack := range acks[start:end] {
+			if ack != nil {
+				ack()
+			}
+		}
+
 		// Record per-chunk metrics
 		metrics.RecordNATSBatchFlush(chunkElapsed, len(chunk))
 	}
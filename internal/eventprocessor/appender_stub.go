@@ -49,6 +49,11 @@ func (a *Appender) Append(_ context.Context, _ *MediaEvent) error {
 	return ErrNATSNotEnabled
 }
 
+// AppendWithAck is a no-op stub.
+func (a *Appender) AppendWithAck(_ context.Context, _ *MediaEvent, _ func()) error {
+	return ErrNATSNotEnabled
+}
+
 // Flush is a no-op stub.
 func (a *Appender) Flush(_ context.Context) error {
 	return ErrNATSNotEnabled
@@ -561,6 +561,64 @@ func TestAppender_Flush_Manual(t *testing.T) {
 	}
 }
 
+// TestAppender_AppendWithAck_AfterFlush verifies acks run only once events
+// are written, and not for events whose flush failed until a retry succeeds.
+func TestAppender_AppendWithAck_AfterFlush(t *testing.T) {
+	store := NewMockEventStore()
+	cfg := AppenderConfig{
+		BatchSize:     1000, // Won't trigger
+		FlushInterval: time.Hour,
+	}
+
+	appender, err := NewAppender(store, cfg)
+	if err != nil {
+		t.Fatalf("NewAppender() error = %v", err)
+	}
+
+	ctx := context.Background()
+	var acked sync.WaitGroup
+	var ackCount int
+	var mu sync.Mutex
+	for i := 0; i < 3; i++ {
+		event := NewMediaEvent(SourcePlex)
+		event.UserID = i + 1
+		acked.Add(1)
+		if err := appender.AppendWithAck(ctx, event, func() {
+			mu.Lock()
+			ackCount++
+			mu.Unlock()
+			acked.Done()
+		}); err != nil {
+			t.Fatalf("AppendWithAck() error = %v", err)
+		}
+	}
+	// Events appended without a callback share the buffer
+	_ = appender.Append(ctx, NewMediaEvent(SourcePlex))
+
+	store.SetError(errors.New("database connection failed"))
+	if err := appender.Flush(ctx); err == nil {
+		t.Fatal("Flush() error = nil, want store error")
+	}
+	mu.Lock()
+	if ackCount != 0 {
+		t.Errorf("acks after failed flush = %d, want 0", ackCount)
+	}
+	mu.Unlock()
+
+	store.SetError(nil)
+	if err := appender.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	acked.Wait()
+
+	if ackCount != 3 {
+		t.Errorf("acks = %d, want 3", ackCount)
+	}
+	if events := store.GetEvents(); len(events) != 4 {
+		t.Errorf("Store events = %d, want 4", len(events))
+	}
+}
+
 // TestAppender_ConcurrentAppend verifies thread safety.
 func TestAppender_ConcurrentAppend(t *testing.T) {
 	store := NewMockEventStore()
@@ -189,6 +189,9 @@ type PublisherConfig struct {
 	ReconnectWait    time.Duration
 	ReconnectBuffer  int
 	EnableTrackMsgID bool // nolint:revive // ID is correct per Go conventions
+
+	// Partitioning selects the subject PublishEvent uses (default: PartitionNone).
+	Partitioning TopicPartitioning
 }
 
 // DefaultPublisherConfig returns production defaults for publisher.
@@ -199,6 +202,7 @@ func DefaultPublisherConfig(url string) PublisherConfig {
 		ReconnectWait:    2 * time.Second,
 		ReconnectBuffer:  8 * 1024 * 1024, // 8MB
 		EnableTrackMsgID: true,
+		Partitioning:     PartitionNone,
 	}
 }
 
@@ -220,6 +224,15 @@ type SubscriberConfig struct {
 	// subscribing to topics with wildcards (e.g., "playback.>") because
 	// NATS stream names cannot contain wildcards.
 	StreamName string
+	// PoisonQueueTopic is the subject the router's poison queue publishes
+	// to. ListPoison and RequeuePoison read it from StreamName.
+	PoisonQueueTopic string
+	// DeferredAck delivers the next message without waiting for the previous
+	// one to be acked, so handlers can hold their ack until the event is
+	// written (flush-then-ack). In-flight messages are then bounded by
+	// MaxAckPending instead of SubscribersCount. The stream must already
+	// exist.
+	DeferredAck bool
 }
 
 // DefaultSubscriberConfig returns production defaults for subscriber.
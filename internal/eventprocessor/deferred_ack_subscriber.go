@@ -0,0 +1,201 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package eventprocessor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmNats "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
+	"github.com/ThreeDotsLabs/watermill/message"
+	natsgo "github.com/nats-io/nats.go"
+)
+
+// deferredAckSubscriber is a JetStream message.Subscriber that does not block
+// delivery on acknowledgment. The Watermill NATS subscriber waits in the
+// subscription callback until each message is acked, so a handler that acks
+// only after a batched write would allow just SubscribersCount messages in
+// flight. Here each delivered message is acked from its own goroutine and
+// JetStream's MaxAckPending bounds how many can be outstanding.
+type deferredAckSubscriber struct {
+	conn        *natsgo.Conn
+	js          natsgo.JetStreamContext
+	config      SubscriberConfig
+	subOpts     []natsgo.SubOpt
+	unmarshaler wmNats.Unmarshaler
+	logger      watermill.LoggerAdapter
+
+	closing   chan struct{}
+	closeOnce sync.Once
+	outputs   sync.WaitGroup // subscriptions whose output is still open
+	pending   sync.WaitGroup // delivered messages waiting for ack or nack
+}
+
+// newDeferredAckSubscriber connects to NATS and prepares a deferred-ack
+// subscriber using the same durable and queue group names as NewSubscriber,
+// so either can consume from the same JetStream consumer.
+func newDeferredAckSubscriber(
+	cfg *SubscriberConfig,
+	natsOpts []natsgo.Option,
+	subOpts []natsgo.SubOpt,
+	logger watermill.LoggerAdapter,
+) (*deferredAckSubscriber, error) {
+	conn, err := natsgo.Connect(cfg.URL, natsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("connect to NATS: %w", err)
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("create JetStream context: %w", err)
+	}
+
+	opts := append(slices.Clone(subOpts), natsgo.ManualAck())
+	if cfg.DurableName != "" {
+		opts = append(opts, natsgo.Durable(cfg.DurableName))
+	}
+
+	return &deferredAckSubscriber{
+		conn:        conn,
+		js:          js,
+		config:      *cfg,
+		subOpts:     opts,
+		unmarshaler: &wmNats.NATSMarshaler{},
+		logger:      logger,
+		closing:     make(chan struct{}),
+	}, nil
+}
+
+// Subscribe starts SubscribersCount queue subscriptions for the topic and
+// returns their messages on one channel. The channel is closed when ctx is
+// canceled or the subscriber is closed.
+func (s *deferredAckSubscriber) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	output := make(chan *message.Message)
+
+	// closed is set under mu before output is closed; callbacks hold the read
+	// lock while sending so they never send on a closed channel
+	var mu sync.RWMutex
+	closed := false
+
+	count := max(s.config.SubscribersCount, 1)
+	subs := make([]*natsgo.Subscription, 0, count)
+	for range count {
+		sub, err := s.js.QueueSubscribe(topic, s.config.QueueGroup, func(m *natsgo.Msg) {
+			mu.RLock()
+			defer mu.RUnlock()
+			if !closed {
+				s.deliver(ctx, m, output)
+			}
+		}, s.subOpts...)
+		if err != nil {
+			for _, sub := range subs {
+				_ = sub.Unsubscribe() //nolint:errcheck // Best effort cleanup
+			}
+			return nil, fmt.Errorf("subscribe to %s: %w", topic, err)
+		}
+		subs = append(subs, sub)
+	}
+
+	s.outputs.Add(1)
+	go func() {
+		defer s.outputs.Done()
+		select {
+		case <-s.closing:
+		case <-ctx.Done():
+		}
+		for _, sub := range subs {
+			if err := sub.Unsubscribe(); err != nil {
+				s.logger.Error("Cannot unsubscribe", err, watermill.LogFields{"topic": topic})
+			}
+		}
+
+		mu.Lock()
+		closed = true
+		mu.Unlock()
+		close(output)
+	}()
+
+	return output, nil
+}
+
+// deliver hands one message to the consumer and returns without waiting for
+// its ack, which awaitAck sends to JetStream later.
+func (s *deferredAckSubscriber) deliver(ctx context.Context, m *natsgo.Msg, output chan<- *message.Message) {
+	msg, err := s.unmarshaler.Unmarshal(m)
+	if err != nil {
+		// Left unacked: JetStream redelivers it after AckWait, up to MaxDeliver
+		s.logger.Error("Cannot unmarshal message", err, watermill.LogFields{"subject": m.Subject})
+		return
+	}
+
+	msgCtx, cancel := context.WithCancel(ctx)
+	msg.SetContext(msgCtx)
+
+	select {
+	case output <- msg:
+	case <-s.closing:
+		cancel()
+		return
+	case <-ctx.Done():
+		cancel()
+		return
+	}
+
+	s.pending.Add(1)
+	go s.awaitAck(m, msg, cancel)
+}
+
+// awaitAck forwards the consumer's ack or nack to JetStream. A message that
+// is neither within AckWaitTimeout is left for JetStream to redeliver.
+func (s *deferredAckSubscriber) awaitAck(m *natsgo.Msg, msg *message.Message, cancel context.CancelFunc) {
+	defer s.pending.Done()
+	defer cancel()
+
+	timeout := time.NewTimer(s.config.AckWaitTimeout)
+	defer timeout.Stop()
+
+	fields := watermill.LogFields{"message_uuid": msg.UUID}
+	select {
+	case <-msg.Acked():
+		if err := m.AckSync(); err != nil {
+			s.logger.Error("Cannot send ack", err, fields)
+		}
+	case <-msg.Nacked():
+		if err := m.Nak(); err != nil {
+			s.logger.Error("Cannot send nak", err, fields)
+		}
+	case <-timeout.C:
+		s.logger.Trace("Ack timeout", fields)
+	}
+}
+
+// Close stops the subscriptions, waits up to CloseTimeout for delivered
+// messages to be acked, and closes the connection.
+func (s *deferredAckSubscriber) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+		s.outputs.Wait()
+
+		done := make(chan struct{})
+		go func() {
+			s.pending.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(s.config.CloseTimeout):
+			s.logger.Info("Closing with messages still waiting for ack", nil)
+		}
+		s.conn.Close()
+	})
+	return nil
+}
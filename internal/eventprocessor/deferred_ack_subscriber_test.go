@@ -0,0 +1,127 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package eventprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+)
+
+// TestRouter_AckAfterFlush_KeepsBatchesFlowing runs the production DuckDB
+// path (router, DuckDBHandler with AckAfterFlush, deferred-ack subscriber)
+// against an embedded JetStream server. The appender only flushes when a
+// batch fills, so if acks blocked delivery the batch would never fill and
+// nothing would be written until the hour-long flush interval.
+func TestRouter_AckAfterFlush_KeepsBatchesFlowing(t *testing.T) {
+	const batchSize = 50
+
+	url := startReplayTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := NewMockEventStore()
+	appender, err := NewAppender(store, AppenderConfig{BatchSize: batchSize, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewAppender() error = %v", err)
+	}
+	if err := appender.Start(ctx); err != nil {
+		t.Fatalf("Appender.Start() error = %v", err)
+	}
+	defer appender.Close()
+
+	handlerCfg := DefaultDuckDBHandlerConfig()
+	handlerCfg.EnableCrossSourceDedup = false
+	handler, err := NewDuckDBHandler(appender, handlerCfg, nil)
+	if err != nil {
+		t.Fatalf("NewDuckDBHandler() error = %v", err)
+	}
+
+	subCfg := DefaultSubscriberConfig(url)
+	subCfg.DurableName = "ack-after-flush"
+	subCfg.QueueGroup = "ack-after-flush"
+	subCfg.StreamName = DefaultStreamConfig().Name
+	subCfg.SubscribersCount = 1
+	subCfg.DeferredAck = true
+	subscriber, err := NewSubscriber(&subCfg, nil)
+	if err != nil {
+		t.Fatalf("NewSubscriber() error = %v", err)
+	}
+	defer subscriber.Close()
+
+	routerCfg := DefaultRouterConfig()
+	router, err := NewRouter(&routerCfg, nil, nil)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	router.AddConsumerHandler("duckdb-handler", "playback.>", subscriber, handler.Handle)
+	<-router.RunAsync(ctx)
+	defer router.Close()
+
+	publisher, err := NewPublisher(DefaultPublisherConfig(url), nil)
+	if err != nil {
+		t.Fatalf("NewPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+
+	// One full batch plus a partial one that stays buffered
+	const total = batchSize + 10
+	for i := 0; i < total; i++ {
+		event := NewMediaEvent(SourcePlex)
+		event.UserID = i + 1
+		event.Username = "user"
+		event.MediaType = MediaTypeMovie
+		event.Title = "Movie"
+		if err := publisher.PublishEvent(ctx, event); err != nil {
+			t.Fatalf("PublishEvent() error = %v", err)
+		}
+	}
+
+	waitFor(t, 10*time.Second, func() bool { return len(store.GetEvents()) == batchSize })
+
+	// Exactly the events still buffered are unacked
+	nc, err := natsgo.Connect(url)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer nc.Close()
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("JetStream() error = %v", err)
+	}
+	ackPending := func() int {
+		info, err := js.ConsumerInfo(subCfg.StreamName, subCfg.DurableName)
+		if err != nil {
+			t.Fatalf("ConsumerInfo() error = %v", err)
+		}
+		return info.NumAckPending
+	}
+	waitFor(t, 5*time.Second, func() bool { return ackPending() == total-batchSize })
+
+	if err := appender.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	waitFor(t, 5*time.Second, func() bool { return ackPending() == 0 })
+	if got := len(store.GetEvents()); got != total {
+		t.Errorf("stored %d events, want %d", got, total)
+	}
+}
+
+// waitFor polls cond until it holds or the timeout expires.
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met within %v", timeout)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -14,7 +14,6 @@ import (
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
-	"github.com/goccy/go-json"
 
 	"github.com/tomtom215/cartographus/internal/detection"
 )
@@ -104,9 +103,9 @@ func (h *DetectionHandler) Handle(msg *message.Message) error {
 		return nil
 	}
 
-	// Deserialize event
-	var event MediaEvent
-	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+	// Deserialize event, upgrading older schema versions
+	event, err := MigrateEvent(msg.Payload)
+	if err != nil {
 		h.parseErrors.Add(1)
 		h.logger.Error("Failed to parse message for detection", err, watermill.LogFields{
 			"message_uuid": msg.UUID,
@@ -171,12 +170,14 @@ func (h *DetectionHandler) mediaEventToDetectionEvent(event *MediaEvent) *detect
 		MachineID: event.MachineID,
 		Platform:  event.Platform,
 		Player:    event.Player,
+		Product:   event.Product,
 		Device:    event.Device,
 
 		// Media information
 		MediaType:        event.MediaType,
 		Title:            event.Title,
 		GrandparentTitle: event.GrandparentTitle,
+		RatingKey:        event.RatingKey,
 
 		// Network information
 		IPAddress:    event.IPAddress,
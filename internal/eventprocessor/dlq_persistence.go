@@ -325,8 +325,8 @@ func (s *DuckDBDLQStore) scanEntry(row *sql.Row) (*DLQEntry, error) {
 	}
 
 	// Deserialize event data
-	var event MediaEvent
-	if err := json.Unmarshal([]byte(eventData), &event); err != nil {
+	event, err := MigrateEvent([]byte(eventData))
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
 	}
 
@@ -367,8 +367,8 @@ func (s *DuckDBDLQStore) scanEntryFromRows(rows *sql.Rows) (*DLQEntry, error) {
 	}
 
 	// Deserialize event data
-	var event MediaEvent
-	if err := json.Unmarshal([]byte(eventData), &event); err != nil {
+	event, err := MigrateEvent([]byte(eventData))
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
 	}
 
@@ -51,6 +51,7 @@
 //     need cross-source deduplication at the event level
 //   - Single Source of Truth: NATS JetStream holds the authoritative event log
 //   - Replay & Audit: Full event history enables debugging and replay
+//     (Subscriber.Replay rebuilds DuckDB from a time range of the stream)
 //   - Scalability: Adding Jellyfin becomes "just another event publisher"
 //   - Real-Time: WebSocket consumers get events immediately
 //   - Testability: Centralized event tests work for all sources
@@ -74,6 +75,26 @@
 //  4. Database Constraint: UNIQUE INDEX as final safety net
 //     - Prevents duplicates that slip through other layers
 //
+// # Topic Partitioning
+//
+// By default events are published to playback.<source>.<media_type>. With
+// NATS_TOPIC_PARTITIONING=server the server ID is appended
+// (playback.<source>.<media_type>.<server_id>, "default" when unset) so a
+// pool of consumers can each subscribe to one server with PartitionSubject;
+// NewPartitionedConsumers builds such a pool. Partitioning changes the
+// deduplication layers as follows:
+//
+//   - JetStream Nats-Msg-Id deduplication is per stream, not per subject,
+//     so it is unaffected.
+//   - The in-memory cache must be shared by all partition consumers, since
+//     the same playback can arrive on more than one subject; see
+//     NewPartitionedConsumers.
+//   - Consumers check and record keys independently, so two partitions can
+//     both accept a duplicate that arrives at the same instant. The database
+//     constraint remains the final guard.
+//   - Events already in the stream keep their unpartitioned subjects; they
+//     are matched by UnpartitionedSubject.
+//
 // # Data Flow
 //
 // All sources publish to NATS, DuckDBConsumer is the ONLY writer to DuckDB:
@@ -83,7 +104,49 @@
 //	WebSocket:       Plex WS      → MediaEvent → NATS publish
 //	Future:          Jellyfin API → MediaEvent → NATS publish
 //
-//	DuckDBConsumer:  NATS subscribe → Deduplicate → Batch → DuckDB INSERT
+//	DuckDBConsumer:  NATS subscribe → Deduplicate → Batch → DuckDB INSERT → Ack
+//
+// DuckDBConsumer acknowledges a message only after the appender has written
+// its event (Appender.AppendWithAck), and flushes the partial batch before it
+// stops, so a shutdown mid-batch neither loses acked events nor leaves them
+// for redelivery. Shutdown flushes are counted in nats_shutdown_flushes_total.
+//
+// The router path does the same with DuckDBHandlerConfig.AckAfterFlush:
+// Handle returns once the event's batch is written. Both rely on a
+// Subscriber created with SubscriberConfig.DeferredAck, which keeps
+// delivering while earlier messages wait for their flush, up to
+// MaxAckPending in flight.
+//
+// # Replay
+//
+// Subscriber.Replay reads the messages stored in a stream between two
+// times with an ephemeral ordered consumer and feeds them through a
+// Consumer such as DuckDBConsumer, e.g. to rebuild playback_events after
+// it was corrupted. The durable consumer is not affected. Replay is
+// idempotent: duplicates are skipped by the consumer's cache and by the
+// ON CONFLICT DO NOTHING insert, so a rebuild should use a new consumer
+// whose cache has not already seen the range.
+//
+// # Schema Versions
+//
+// Events carry schema_version (SchemaVersion, 1 when absent) and outlive
+// releases in the stream, the WAL and the DLQ. Everything that reads them
+// back decodes with MigrateEvent, which upgrades older versions one step
+// at a time and fails with ErrUnsupportedSchemaVersion on a version newer
+// than this release knows, e.g. after a downgrade. An incompatible change
+// to MediaEvent increments SchemaVersion, registers the migration from the
+// previous version, and adds a fixture of the old format to testdata.
+//
+// # Poison Queue
+//
+// Messages that still fail after the router's retries are published to
+// the poison queue topic (NATS_ROUTER_POISON_TOPIC, in the MEDIA_EVENTS
+// stream) through NewPoisonPublisher, which gives each copy a new UUID so
+// JetStream deduplication does not drop it. Subscriber.ListPoison lists
+// them with the handler error and failure count, and
+// Subscriber.RequeuePoison publishes one back to its original topic after
+// the cause is fixed and removes it from the queue. A requeued message
+// that fails again returns with its failure count incremented.
 //
 // # Key Components
 //
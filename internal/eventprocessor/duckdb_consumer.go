@@ -10,17 +10,23 @@ package eventprocessor
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync/atomic"
 	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
-	"github.com/goccy/go-json"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/tomtom215/cartographus/internal/cache"
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/metrics"
+	"github.com/tomtom215/cartographus/internal/tracing"
 )
 
+// shutdownFlushTimeout bounds the partial batch flush on consumer shutdown.
+const shutdownFlushTimeout = 30 * time.Second
+
 // MessageSource defines the interface for receiving messages.
 // This abstraction allows the consumer to work with different message sources.
 type MessageSource interface {
@@ -44,6 +50,11 @@ type ConsumerConfig struct {
 	// MaxDeduplicationEntries is the maximum number of entries in the dedup cache
 	MaxDeduplicationEntries int
 
+	// DedupCache is a deduplication cache shared with other consumers. When
+	// nil the consumer creates its own from the settings above. Consumers of
+	// different partitions must share one, see NewPartitionedConsumers.
+	DedupCache *cache.BloomLRU
+
 	// WorkerCount is the number of concurrent message processors
 	WorkerCount int
 
@@ -94,6 +105,15 @@ type ConsumerStats struct {
 //	router.AddNoPublisherHandler("duckdb-handler", "playback.>", subscriber, handler.Handle)
 //	router.Run(ctx)
 //
+// Delivery: a message is acknowledged only after its event has been written
+// to DuckDB (flush-then-ack), and on shutdown the consumer flushes the
+// partial batch before it stops. An event is therefore never acked without
+// being persisted; if the process dies before a flush, JetStream redelivers
+// the unacked messages and the store's deduplication drops any that were
+// written. The JetStream AckWait must exceed the appender's FlushInterval,
+// and the source should be a Subscriber created with DeferredAck so that
+// messages waiting for a flush do not hold up delivery of the next ones.
+//
 // Performance: Uses BloomLRU for O(1) deduplication with ~90%+ fast-path rejections.
 type DuckDBConsumer struct {
 	source   MessageSource
@@ -119,6 +139,10 @@ type DuckDBConsumer struct {
 	duplicatesSkipped atomic.Int64
 	messagesSentToDLQ atomic.Int64
 	lastMessageTime   atomic.Value // stores time.Time
+
+	// unflushed counts messages appended but not yet acked, i.e. waiting
+	// for the appender to write their events
+	unflushed atomic.Int64
 }
 
 // NewDuckDBConsumer creates a new DuckDB consumer.
@@ -134,13 +158,16 @@ func NewDuckDBConsumer(source MessageSource, appender *Appender, cfg *ConsumerCo
 		return nil, fmt.Errorf("appender required")
 	}
 
-	// Initialize BloomLRU with config values
+	// Initialize BloomLRU with config values unless a shared cache is given
 	// BloomLRU provides O(1) operations vs O(n) eviction with map
-	dedupCache := cache.NewBloomLRU(
-		cfg.MaxDeduplicationEntries,
-		cfg.DeduplicationWindow,
-		0.01, // 1% false positive rate
-	)
+	dedupCache := cfg.DedupCache
+	if dedupCache == nil {
+		dedupCache = cache.NewBloomLRU(
+			cfg.MaxDeduplicationEntries,
+			cfg.DeduplicationWindow,
+			0.01, // 1% false positive rate
+		)
+	}
 
 	c := &DuckDBConsumer{
 		source:     source,
@@ -165,6 +192,49 @@ func NewDuckDBConsumer(source MessageSource, appender *Appender, cfg *ConsumerCo
 	return c, nil
 }
 
+// NewPartitionedConsumers creates one consumer per server partition for
+// streams published with PartitionServer, so partitions are written in
+// parallel. Besides the given servers it always covers DefaultPartition
+// (events without a server ID) and UnpartitionedSubject (events published
+// before partitioning was enabled), so no event is left without a consumer.
+//
+// All consumers share one deduplication cache. Duplicates are not confined
+// to a partition: while partitioning is being enabled the same playback can
+// be in the stream on both an unpartitioned and a partitioned subject, and
+// an event whose server ID is added by a later sync moves from the default
+// partition to its server's. Per-partition caches would let such duplicates
+// through to DuckDB.
+func NewPartitionedConsumers(source MessageSource, appender *Appender, cfg *ConsumerConfig, serverIDs []string) ([]*DuckDBConsumer, error) {
+	shared := *cfg
+	if shared.DedupCache == nil {
+		shared.DedupCache = cache.NewBloomLRU(
+			shared.MaxDeduplicationEntries,
+			shared.DeduplicationWindow,
+			0.01, // 1% false positive rate
+		)
+	}
+
+	subjects := []string{UnpartitionedSubject, PartitionSubject("")}
+	for _, id := range serverIDs {
+		subject := PartitionSubject(id)
+		if !slices.Contains(subjects, subject) {
+			subjects = append(subjects, subject)
+		}
+	}
+
+	consumers := make([]*DuckDBConsumer, 0, len(subjects))
+	for _, subject := range subjects {
+		partCfg := shared
+		partCfg.Topic = subject
+		consumer, err := NewDuckDBConsumer(source, appender, &partCfg)
+		if err != nil {
+			return nil, fmt.Errorf("create consumer for %s: %w", subject, err)
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, nil
+}
+
 // Start begins consuming messages from the source.
 // Returns immediately - consumption happens in a goroutine.
 func (c *DuckDBConsumer) Start(ctx context.Context) error {
@@ -233,11 +303,26 @@ func (c *DuckDBConsumer) DLQStats() DLQStats {
 	return c.dlqHandler.Stats()
 }
 
+// HandleMessage processes one message outside the subscription, e.g. one
+// delivered by Subscriber.Replay. Like subscribed messages it is acked once
+// its event is written, or right away when it is a duplicate. Implements
+// Consumer.
+func (c *DuckDBConsumer) HandleMessage(ctx context.Context, msg *message.Message) {
+	c.processMessage(ctx, msg)
+}
+
+// Flush writes the events buffered in the appender, acking their messages.
+// Implements Consumer.
+func (c *DuckDBConsumer) Flush(ctx context.Context) error {
+	return c.appender.Flush(ctx)
+}
+
 // consumeLoop processes messages from the subscription.
 // DETERMINISM: Implements graceful shutdown with message draining to prevent data loss.
 // When shutdown is signaled, it drains all pending messages before returning.
 func (c *DuckDBConsumer) consumeLoop(ctx context.Context, messages <-chan *message.Message) {
 	defer func() {
+		c.flushOnShutdown()
 		c.running.Store(false)
 		close(c.doneCh)
 	}()
@@ -262,6 +347,29 @@ func (c *DuckDBConsumer) consumeLoop(ctx context.Context, messages <-chan *messa
 	}
 }
 
+// flushOnShutdown writes the partial batch still buffered in the appender so
+// the messages drained on shutdown are acked before the consumer stops,
+// rather than left for the appender's next timer flush or redelivery.
+func (c *DuckDBConsumer) flushOnShutdown() {
+	pending := c.unflushed.Load()
+	if pending == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownFlushTimeout)
+	defer cancel()
+
+	err := c.appender.Flush(ctx)
+	metrics.RecordNATSShutdownFlush(int(pending), err)
+	if err != nil {
+		// The events stay buffered and their messages unacked; JetStream
+		// redelivers them if the appender cannot write them before exit
+		logging.Error().Err(err).Int64("pending", pending).Msg("DuckDB consumer failed to flush partial batch on shutdown")
+		return
+	}
+	logging.Info().Int64("count", pending).Msg("DuckDB consumer flushed partial batch on shutdown")
+}
+
 // drainMessages processes all remaining messages in the channel before shutdown.
 // DETERMINISM: This ensures no messages are lost during graceful shutdown.
 // Uses a timeout to prevent blocking indefinitely if the channel keeps receiving.
@@ -307,9 +415,23 @@ func (c *DuckDBConsumer) processMessage(ctx context.Context, msg *message.Messag
 	// Record message consumption
 	metrics.RecordNATSConsume()
 
-	// Deserialize event
-	var event MediaEvent
-	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+	// Continue the publisher's trace from the message metadata
+	ctx = tracing.ExtractMetadata(ctx, msg.Metadata)
+	ctx, span := tracing.Start(ctx, "process "+c.config.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nats"),
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingDestinationName(c.config.Topic),
+			semconv.MessagingMessageID(msg.UUID),
+		),
+	)
+	defer span.End()
+
+	// Deserialize event, upgrading older schema versions
+	event, err := MigrateEvent(msg.Payload)
+	if err != nil {
+		tracing.RecordError(span, err)
 		c.parseErrors.Add(1)
 		metrics.RecordNATSParseFailed()
 		logging.Warn().
@@ -337,8 +459,16 @@ func (c *DuckDBConsumer) processMessage(ctx context.Context, msg *message.Messag
 		return
 	}
 
-	// Append to buffer for batch write
-	if err := c.appender.Append(ctx, &event); err != nil {
+	// Append to buffer for batch write. The message is acked by the
+	// appender once the event is in DuckDB, never before (flush-then-ack).
+	c.unflushed.Add(1)
+	ack := func() {
+		c.unflushed.Add(-1)
+		msg.Ack()
+	}
+	if err := c.appender.AppendWithAck(ctx, &event, ack); err != nil {
+		c.unflushed.Add(-1)
+		tracing.RecordError(span, err)
 		logging.Warn().
 			Str("event_id", event.EventID).
 			Err(err).
@@ -362,7 +492,6 @@ func (c *DuckDBConsumer) processMessage(ctx context.Context, msg *message.Messag
 	c.messagesProcessed.Add(1)
 	metrics.RecordNATSProcessed()
 	metrics.RecordNATSProcessingDuration(time.Since(startTime))
-	msg.Ack()
 }
 
 // isDuplicate checks if an event has been seen recently.
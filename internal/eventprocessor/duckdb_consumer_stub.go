@@ -55,6 +55,11 @@ func NewDuckDBConsumer(_ interface{}, _ *Appender, _ *ConsumerConfig) (*DuckDBCo
 	return nil, ErrNATSNotEnabled
 }
 
+// NewPartitionedConsumers returns an error in non-NATS builds.
+func NewPartitionedConsumers(_ interface{}, _ *Appender, _ *ConsumerConfig, _ []string) ([]*DuckDBConsumer, error) {
+	return nil, ErrNATSNotEnabled
+}
+
 // Start is a stub for non-NATS builds.
 func (c *DuckDBConsumer) Start(_ context.Context) error {
 	return ErrNATSNotEnabled
@@ -72,3 +77,11 @@ func (c *DuckDBConsumer) IsRunning() bool {
 func (c *DuckDBConsumer) Stats() ConsumerStats {
 	return ConsumerStats{}
 }
+
+// HandleMessage is a stub for non-NATS builds.
+func (c *DuckDBConsumer) HandleMessage(_ context.Context, _ interface{}) {}
+
+// Flush is a stub for non-NATS builds.
+func (c *DuckDBConsumer) Flush(_ context.Context) error {
+	return ErrNATSNotEnabled
+}
@@ -16,6 +16,9 @@ import (
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/goccy/go-json"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tomtom215/cartographus/internal/metrics"
 )
 
 // MockMessageSource implements a mock message source for testing.
@@ -302,6 +305,98 @@ func TestDuckDBConsumer_Stop(t *testing.T) {
 	consumer.Stop()
 }
 
+// TestDuckDBConsumer_StopMidBatch verifies flush-then-ack: messages of a
+// partial batch are not acked before their events are written, and Stop
+// flushes the batch so every received event is persisted exactly once and
+// every message acked. Not parallel so the shutdown flush metrics are exact.
+func TestDuckDBConsumer_StopMidBatch(t *testing.T) {
+	store := NewMockEventStore()
+	appenderCfg := DefaultAppenderConfig()
+	appenderCfg.BatchSize = 100           // Won't trigger
+	appenderCfg.FlushInterval = time.Hour // Only the shutdown flush writes
+	appender, err := NewAppender(store, appenderCfg)
+	if err != nil {
+		t.Fatalf("failed to create appender: %v", err)
+	}
+	defer appender.Close()
+
+	source := NewMockMessageSource()
+	cfg := DefaultConsumerConfig()
+	consumer, err := NewDuckDBConsumer(source, appender, &cfg)
+	if err != nil {
+		t.Fatalf("NewDuckDBConsumer() error = %v", err)
+	}
+
+	flushesBefore := testutil.ToFloat64(metrics.NATSShutdownFlushes.WithLabelValues("success"))
+	flushEventsBefore := testutil.ToFloat64(metrics.NATSShutdownFlushEvents)
+
+	if err := consumer.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	var msgs []*message.Message
+	for i := 0; i < 5; i++ {
+		event := NewMediaEvent(SourcePlex)
+		event.UserID = i + 1
+		event.MediaType = MediaTypeMovie
+		event.Title = "Test Movie"
+		data, err := json.Marshal(event)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		msg := message.NewMessage(event.EventID, data)
+		msgs = append(msgs, msg)
+		source.messages <- msg
+	}
+	// Redelivery of the first message before it was acked
+	redelivered := message.NewMessage(msgs[0].UUID, msgs[0].Payload)
+	source.messages <- redelivered
+
+	deadline := time.Now().Add(5 * time.Second)
+	for consumer.Stats().MessagesReceived < 6 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	for i, msg := range msgs {
+		select {
+		case <-msg.Acked():
+			t.Fatalf("message %d acked before its event was flushed", i)
+		default:
+		}
+	}
+	if got := len(store.GetEvents()); got != 0 {
+		t.Fatalf("store events before Stop = %d, want 0 (mid-batch)", got)
+	}
+
+	consumer.Stop()
+
+	events := store.GetEvents()
+	if len(events) != len(msgs) {
+		t.Fatalf("store events after Stop = %d, want %d", len(events), len(msgs))
+	}
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		if seen[event.EventID] {
+			t.Errorf("event %s persisted more than once", event.EventID)
+		}
+		seen[event.EventID] = true
+	}
+	for i, msg := range append(msgs, redelivered) {
+		select {
+		case <-msg.Acked():
+		default:
+			t.Errorf("message %d not acked after the shutdown flush", i)
+		}
+	}
+
+	if got := testutil.ToFloat64(metrics.NATSShutdownFlushes.WithLabelValues("success")) - flushesBefore; got != 1 {
+		t.Errorf("shutdown flushes = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.NATSShutdownFlushEvents) - flushEventsBefore; got != 5 {
+		t.Errorf("shutdown flush events = %v, want 5", got)
+	}
+}
+
 // TestDuckDBConsumer_Stats tests statistics collection.
 func TestDuckDBConsumer_Stats(t *testing.T) {
 	t.Parallel()
@@ -859,3 +954,159 @@ func TestDuckDBConsumer_CorrelationKeyDifferentContent(t *testing.T) {
 		t.Errorf("Correlation keys should be different for different content: %q", event1.CorrelationKey)
 	}
 }
+
+// partitionedMessageSource delivers messages only to the subscription whose
+// subject equals the topic they were sent to.
+type partitionedMessageSource struct {
+	mu      sync.Mutex
+	streams map[string]chan *message.Message
+}
+
+func newPartitionedMessageSource() *partitionedMessageSource {
+	return &partitionedMessageSource{streams: make(map[string]chan *message.Message)}
+}
+
+func (m *partitionedMessageSource) stream(topic string) chan *message.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ch, ok := m.streams[topic]
+	if !ok {
+		ch = make(chan *message.Message, 100)
+		m.streams[topic] = ch
+	}
+	return ch
+}
+
+func (m *partitionedMessageSource) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	return m.stream(topic), nil
+}
+
+func (m *partitionedMessageSource) Close() error { return nil }
+
+func (m *partitionedMessageSource) send(t *testing.T, subject string, event *MediaEvent) {
+	t.Helper()
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal event: %v", err)
+	}
+	m.stream(subject) <- message.NewMessage(event.EventID, data)
+}
+
+func TestNewPartitionedConsumers_Subjects(t *testing.T) {
+	appender, err := NewAppender(NewMockEventStore(), DefaultAppenderConfig())
+	if err != nil {
+		t.Fatalf("failed to create appender: %v", err)
+	}
+	defer appender.Close()
+
+	cfg := DefaultConsumerConfig()
+	consumers, err := NewPartitionedConsumers(NewMockMessageSource(), appender, &cfg, []string{"srv-1", "srv-2", "srv-1"})
+	if err != nil {
+		t.Fatalf("NewPartitionedConsumers() error = %v", err)
+	}
+
+	want := []string{UnpartitionedSubject, PartitionSubject(""), PartitionSubject("srv-1"), PartitionSubject("srv-2")}
+	if len(consumers) != len(want) {
+		t.Fatalf("got %d consumers, want %d", len(consumers), len(want))
+	}
+	for i, c := range consumers {
+		if c.config.Topic != want[i] {
+			t.Errorf("consumer %d topic = %q, want %q", i, c.config.Topic, want[i])
+		}
+		if c.dedupCache != consumers[0].dedupCache {
+			t.Errorf("consumer %d does not share the deduplication cache", i)
+		}
+	}
+	if cfg.Topic != "playback.>" || cfg.DedupCache != nil {
+		t.Error("NewPartitionedConsumers() modified the caller's config")
+	}
+}
+
+// TestPartitionedConsumers_DedupAcrossPartitions verifies that duplicates
+// arriving on different partitions are still skipped.
+func TestPartitionedConsumers_DedupAcrossPartitions(t *testing.T) {
+	t.Parallel()
+
+	store := NewMockEventStore()
+	appenderCfg := DefaultAppenderConfig()
+	appenderCfg.BatchSize = 10
+	appenderCfg.FlushInterval = 50 * time.Millisecond
+	appender, err := NewAppender(store, appenderCfg)
+	if err != nil {
+		t.Fatalf("failed to create appender: %v", err)
+	}
+
+	source := newPartitionedMessageSource()
+	cfg := DefaultConsumerConfig()
+	cfg.DeduplicationWindow = time.Minute
+	consumers, err := NewPartitionedConsumers(source, appender, &cfg, []string{"srv-1"})
+	if err != nil {
+		t.Fatalf("NewPartitionedConsumers() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, c := range consumers {
+		if err := c.Start(ctx); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+	}
+	if err := appender.Start(ctx); err != nil {
+		t.Fatalf("appender.Start() error = %v", err)
+	}
+
+	baseTime := time.Date(2024, 1, 15, 10, 32, 0, 0, time.UTC)
+
+	// Plex webhook with a server ID lands in the srv-1 partition
+	plexEvent := NewMediaEvent(SourcePlex)
+	plexEvent.EventID = "plex-event-123"
+	plexEvent.SessionKey = "webhook-device123-54321"
+	plexEvent.ServerID = "srv-1"
+	plexEvent.UserID = 12345
+	plexEvent.Username = "testuser"
+	plexEvent.MediaType = MediaTypeMovie
+	plexEvent.Title = "Test Movie"
+	plexEvent.RatingKey = "54321"
+	plexEvent.MachineID = "device123"
+	plexEvent.StartedAt = baseTime
+	plexEvent.SetCorrelationKey()
+	source.send(t, PartitionSubject(plexEvent.ServerID), plexEvent)
+
+	time.Sleep(50 * time.Millisecond)
+
+	// Tautulli sync of the same playback, published on the unpartitioned
+	// subject before partitioning was enabled
+	tautulliEvent := NewMediaEvent(SourceTautulli)
+	tautulliEvent.EventID = "tautulli-event-456"
+	tautulliEvent.SessionKey = "tautulli-session-abc123"
+	tautulliEvent.ServerID = "srv-1"
+	tautulliEvent.UserID = 12345
+	tautulliEvent.Username = "testuser"
+	tautulliEvent.MediaType = MediaTypeMovie
+	tautulliEvent.Title = "Test Movie"
+	tautulliEvent.RatingKey = "54321"
+	tautulliEvent.MachineID = "device123"
+	tautulliEvent.StartedAt = baseTime
+	tautulliEvent.SetCorrelationKey()
+	source.send(t, UnpartitionedSubject, tautulliEvent)
+
+	// Redelivery of the Plex event on the default partition
+	source.send(t, PartitionSubject(""), plexEvent)
+
+	time.Sleep(200 * time.Millisecond)
+
+	var skipped int64
+	for _, c := range consumers {
+		c.Stop()
+		skipped += c.Stats().DuplicatesSkipped
+	}
+	appender.Close()
+
+	if events := store.GetEvents(); len(events) != 1 {
+		t.Errorf("Expected 1 event (deduplicated across partitions), got %d", len(events))
+	}
+	if skipped != 2 {
+		t.Errorf("Expected 2 duplicates skipped across partitions, got %d", skipped)
+	}
+}
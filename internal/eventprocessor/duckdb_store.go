@@ -11,10 +11,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
@@ -47,9 +49,13 @@ type BatchPlaybackEventInserter interface {
 // CRITICAL (v2.3): Supports atomic batch inserts via BatchPlaybackEventInserter.
 // When the underlying db implements BatchPlaybackEventInserter, all inserts
 // are wrapped in a transaction for all-or-nothing semantics.
+//
+// Events that fail PlaybackEvent.Validate are skipped and counted rather
+// than failing the batch.
 type DuckDBStore struct {
 	db      PlaybackEventInserter
 	batchDB BatchPlaybackEventInserter // nil if db doesn't support batch ops
+	invalid atomic.Int64               // events skipped by validation
 }
 
 // NewDuckDBStore creates a new DuckDBStore with the given database.
@@ -82,6 +88,8 @@ func NewDuckDBStore(db PlaybackEventInserter) (*DuckDBStore, error) {
 //
 // Note: With ON CONFLICT DO NOTHING, duplicate events are silently skipped.
 // The batch method returns counts of inserted vs duplicates for auditability.
+// Events that fail validation are skipped before the insert and counted in
+// InvalidCount; they never cause the batch to fail.
 func (s *DuckDBStore) InsertMediaEvents(ctx context.Context, events []*MediaEvent) error {
 	if len(events) == 0 {
 		return nil
@@ -105,11 +113,28 @@ func (s *DuckDBStore) InsertMediaEvents(ctx context.Context, events []*MediaEven
 		}
 	}
 
-	// Convert all events to PlaybackEvents first
-	playbackEvents := make([]*models.PlaybackEvent, len(events))
-	for i, event := range events {
-		playbackEvents[i] = s.mediaEventToPlaybackEvent(event)
+	// Convert all events to PlaybackEvents first, dropping invalid records
+	playbackEvents := make([]*models.PlaybackEvent, 0, len(events))
+	valid := make([]*MediaEvent, 0, len(events))
+	for _, event := range events {
+		playback := mediaEventToPlaybackEvent(event)
+		if err := playback.Validate(); err != nil {
+			s.invalid.Add(1)
+			metrics.RecordEventInvalid(event.Source)
+			logging.Warn().
+				Str("event_id", event.EventID).
+				Str("source", event.Source).
+				Err(err).
+				Msg("STORE: Skipping invalid event")
+			continue
+		}
+		playbackEvents = append(playbackEvents, playback)
+		valid = append(valid, event)
 	}
+	if len(playbackEvents) == 0 {
+		return nil
+	}
+	events = valid
 
 	// Use atomic batch insert if available
 	if s.batchDB != nil {
@@ -155,6 +180,12 @@ func (s *DuckDBStore) InsertMediaEvents(ctx context.Context, events []*MediaEven
 	return nil
 }
 
+// InvalidCount returns the number of events skipped because they failed
+// validation.
+func (s *DuckDBStore) InvalidCount() int64 {
+	return s.invalid.Load()
+}
+
 // mediaEventToPlaybackEvent converts a MediaEvent to a PlaybackEvent.
 // This mapping handles the transformation from the NATS event format
 // to the database schema format.
@@ -163,7 +194,7 @@ func (s *DuckDBStore) InsertMediaEvents(ctx context.Context, events []*MediaEven
 // If not set on the event, generate it here to ensure database-level dedup works.
 //
 //nolint:gocyclo // Data mapping function with many optional fields requires conditional checks
-func (s *DuckDBStore) mediaEventToPlaybackEvent(event *MediaEvent) *models.PlaybackEvent {
+func mediaEventToPlaybackEvent(event *MediaEvent) *models.PlaybackEvent {
 	// Use SessionKey if available, fallback to EventID
 	sessionKey := event.SessionKey
 	if sessionKey == "" {
@@ -31,3 +31,8 @@ func NewDuckDBStore(_ PlaybackEventInserter) (*DuckDBStore, error) {
 func (s *DuckDBStore) InsertMediaEvents(_ context.Context, _ []*MediaEvent) error {
 	return ErrNATSNotEnabled
 }
+
+// InvalidCount always returns 0 in non-NATS builds.
+func (s *DuckDBStore) InvalidCount() int64 {
+	return 0
+}
@@ -404,6 +404,52 @@ func TestDuckDBStore_EmptyBatch(t *testing.T) {
 	}
 }
 
+// TestDuckDBStore_InsertMediaEvents_SkipsInvalid verifies invalid events are
+// skipped and counted without failing the rest of the batch.
+func TestDuckDBStore_InsertMediaEvents_SkipsInvalid(t *testing.T) {
+	db := NewMockPlaybackInserter()
+	store, err := NewDuckDBStore(db)
+	if err != nil {
+		t.Fatalf("NewDuckDBStore() error = %v", err)
+	}
+
+	newEvent := func(title string, percent int) *MediaEvent {
+		event := NewMediaEvent(SourceTautulli)
+		event.UserID = 1
+		event.MediaType = MediaTypeMovie
+		event.Title = title
+		event.PercentComplete = percent
+		event.StartedAt = time.Now()
+		return event
+	}
+	events := []*MediaEvent{
+		newEvent("Valid A", 50),
+		newEvent("", 50),         // missing title
+		newEvent("Overrun", 250), // percent_complete > 100
+		newEvent("Valid B", 100),
+	}
+
+	if err := store.InsertMediaEvents(context.Background(), events); err != nil {
+		t.Fatalf("InsertMediaEvents() error = %v, want invalid events skipped", err)
+	}
+
+	stored := db.GetEvents()
+	if len(stored) != 2 || stored[0].Title != "Valid A" || stored[1].Title != "Valid B" {
+		t.Errorf("stored %d events, want only the two valid ones", len(stored))
+	}
+	if got := store.InvalidCount(); got != 2 {
+		t.Errorf("InvalidCount() = %d, want 2", got)
+	}
+
+	// A batch with nothing valid inserts nothing and still succeeds
+	if err := store.InsertMediaEvents(context.Background(), []*MediaEvent{newEvent("", 0)}); err != nil {
+		t.Errorf("InsertMediaEvents() all-invalid error = %v", err)
+	}
+	if db.GetInsertCalls() != 2 || store.InvalidCount() != 3 {
+		t.Errorf("after all-invalid batch: %d inserts, %d invalid; want 2 and 3", db.GetInsertCalls(), store.InvalidCount())
+	}
+}
+
 // BenchmarkDuckDBStore_InsertMediaEvents benchmarks batch insertion.
 func BenchmarkDuckDBStore_InsertMediaEvents(b *testing.B) {
 	db := NewMockPlaybackInserter()
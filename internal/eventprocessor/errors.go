@@ -19,3 +19,10 @@ var ErrStreamNotFound = errors.New("stream not found")
 
 // ErrInvalidConfig is returned when configuration is invalid.
 var ErrInvalidConfig = errors.New("invalid configuration")
+
+// ErrPoisonMessageNotFound is returned when a poison queue message does not exist.
+var ErrPoisonMessageNotFound = errors.New("poison message not found")
+
+// ErrUnsupportedSchemaVersion is returned when a stored event has a schema
+// version this release cannot read, usually one written by a newer release.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
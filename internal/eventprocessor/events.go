@@ -6,14 +6,17 @@
 package eventprocessor
 
 import (
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 )
 
 // SchemaVersion is the current event schema version.
-// Increment this when making breaking changes to MediaEvent.
+// Increment this when making breaking changes to MediaEvent, and add the
+// migration from the previous version to eventMigrations (see MigrateEvent).
 const SchemaVersion = 1
 
 // MediaEvent represents a playback event from media servers.
@@ -21,7 +24,7 @@ const SchemaVersion = 1
 //
 // Schema versioning (Phase 2.3):
 // - SchemaVersion field tracks the event format version
-// - Consumers should handle older schema versions for backward compatibility
+// - Stored events are decoded with MigrateEvent, which upgrades older versions
 // - Version 1: Initial schema with all current fields
 type MediaEvent struct {
 	// Schema version for forward/backward compatibility
@@ -143,6 +146,66 @@ func (e *MediaEvent) Topic() string {
 	return "playback." + e.Source + "." + e.MediaType
 }
 
+// TopicPartitioning selects whether event subjects carry the server ID.
+type TopicPartitioning string
+
+const (
+	// PartitionNone publishes to playback.<source>.<media_type> (default).
+	PartitionNone TopicPartitioning = "none"
+
+	// PartitionServer appends the server ID so consumers can subscribe per
+	// server: playback.<source>.<media_type>.<server_id>
+	PartitionServer TopicPartitioning = "server"
+)
+
+const (
+	// DefaultPartition is the partition token for events without a server ID.
+	DefaultPartition = "default"
+
+	// UnpartitionedSubject matches subjects published with PartitionNone,
+	// including those already in the stream when partitioning is enabled.
+	UnpartitionedSubject = "playback.*.*"
+)
+
+// BufferHealthCriticalSubject is the subject of critical buffer health
+// events (models.BufferHealthCriticalEvent). It is captured by the stream's
+// plex.> subjects but not by the playback.> consumers.
+const BufferHealthCriticalSubject = "plex.buffer_health.critical"
+
+// PartitionedTopic returns the NATS subject for this event under the given
+// partitioning. With PartitionNone it is the same as Topic.
+// Example: playback.plex.movie.a1b2c3
+//
+// Partitioned subjects still match playback.>, so consumers of the whole
+// stream are unaffected.
+func (e *MediaEvent) PartitionedTopic(partitioning TopicPartitioning) string {
+	if partitioning != PartitionServer {
+		return e.Topic()
+	}
+	return e.Topic() + "." + PartitionToken(e.ServerID)
+}
+
+// PartitionToken returns the subject token for a server ID. Characters that
+// NATS does not allow in a token ('.', '*', '>' and whitespace) are
+// replaced with '_'; an empty ID maps to DefaultPartition.
+func PartitionToken(serverID string) string {
+	if serverID == "" {
+		return DefaultPartition
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '*' || r == '>' || unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, serverID)
+}
+
+// PartitionSubject returns the subject pattern for all events of one server
+// partition, across sources and media types: playback.*.*.<server_id>
+func PartitionSubject(serverID string) string {
+	return UnpartitionedSubject + "." + PartitionToken(serverID)
+}
+
 // IsComplete returns true if the playback has ended.
 func (e *MediaEvent) IsComplete() bool {
 	return e.StoppedAt != nil
@@ -6,6 +6,7 @@
 package eventprocessor
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -139,6 +140,84 @@ func TestMediaEvent_Topic(t *testing.T) {
 	}
 }
 
+// subjectMatches reports whether a NATS subject matches a subscription
+// pattern, where '*' matches one token and '>' matches one or more.
+func subjectMatches(pattern, subject string) bool {
+	p := strings.Split(pattern, ".")
+	s := strings.Split(subject, ".")
+	for i, token := range p {
+		if token == ">" {
+			return len(s) > i
+		}
+		if i >= len(s) || (token != "*" && token != s[i]) {
+			return false
+		}
+	}
+	return len(p) == len(s)
+}
+
+func TestMediaEvent_PartitionedTopic(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverID     string
+		partitioning TopicPartitioning
+		expected     string
+	}{
+		{"unpartitioned", "srv-1", PartitionNone, "playback.plex.movie"},
+		{"unset partitioning", "srv-1", "", "playback.plex.movie"},
+		{"server", "srv-1", PartitionServer, "playback.plex.movie.srv-1"},
+		{"no server id", "", PartitionServer, "playback.plex.movie.default"},
+		{"reserved characters", "plex.home *1>", PartitionServer, "playback.plex.movie.plex_home__1_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &MediaEvent{Source: "plex", MediaType: "movie", ServerID: tt.serverID}
+			if got := event.PartitionedTopic(tt.partitioning); got != tt.expected {
+				t.Errorf("PartitionedTopic(%q) = %q, want %q", tt.partitioning, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPartitionSubject_Routing(t *testing.T) {
+	events := []*MediaEvent{
+		{Source: "plex", MediaType: "movie", ServerID: "srv-1"},
+		{Source: "jellyfin", MediaType: "episode", ServerID: "srv-2"},
+		{Source: "tautulli", MediaType: "track"},
+	}
+	partitions := map[string]string{
+		PartitionSubject("srv-1"): "playback.plex.movie.srv-1",
+		PartitionSubject("srv-2"): "playback.jellyfin.episode.srv-2",
+		PartitionSubject(""):      "playback.tautulli.track.default",
+	}
+
+	for _, event := range events {
+		topic := event.PartitionedTopic(PartitionServer)
+		matched := 0
+		for subject, want := range partitions {
+			if subjectMatches(subject, topic) {
+				matched++
+				if topic != want {
+					t.Errorf("%s routed to %s, want %s", topic, subject, want)
+				}
+			}
+		}
+		if matched != 1 {
+			t.Errorf("%s matched %d partition subjects, want exactly 1", topic, matched)
+		}
+		if !subjectMatches("playback.>", topic) {
+			t.Errorf("%s does not match the stream-wide playback.> subscription", topic)
+		}
+		if subjectMatches(UnpartitionedSubject, topic) {
+			t.Errorf("%s matches %s, which is reserved for unpartitioned subjects", topic, UnpartitionedSubject)
+		}
+		if !subjectMatches(UnpartitionedSubject, event.PartitionedTopic(PartitionNone)) {
+			t.Errorf("unpartitioned topic %s does not match %s", event.Topic(), UnpartitionedSubject)
+		}
+	}
+}
+
 func TestMediaEvent_IsComplete(t *testing.T) {
 	t.Run("incomplete event", func(t *testing.T) {
 		event := &MediaEvent{}
@@ -15,13 +15,13 @@ import (
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
-	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 
 	"github.com/tomtom215/cartographus/internal/cache"
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/validation"
 )
 
 // DedupeAuditStore is the interface for logging deduplication decisions.
@@ -31,6 +31,12 @@ type DedupeAuditStore interface {
 	InsertDedupeAuditEntry(ctx context.Context, entry *models.DedupeAuditEntry) error
 }
 
+// QuarantineStore is the interface for holding back events with implausible timestamps.
+type QuarantineStore interface {
+	// InsertQuarantinedEvent stores an event that failed timestamp validation.
+	InsertQuarantinedEvent(ctx context.Context, entry *models.QuarantinedEvent) error
+}
+
 // DuckDBHandler processes media events for DuckDB persistence.
 // It handles deserialization, cross-source deduplication, and batch appending.
 //
@@ -50,6 +56,11 @@ type DuckDBHandler struct {
 	logger     watermill.LoggerAdapter
 	auditStore DedupeAuditStore // Optional: for logging dedupe decisions (ADR-0022)
 
+	// Optional: events whose started_at falls outside timestampWindow are
+	// quarantined instead of appended
+	quarantineStore QuarantineStore
+	timestampWindow validation.TimestampWindow
+
 	// Cross-source deduplication cache using ExactLRU (v2.3)
 	// CRITICAL: Uses exact-match LRU for ZERO false positives
 	// This prevents data loss from incorrectly marking unique events as duplicates
@@ -90,6 +101,14 @@ type DuckDBHandlerConfig struct {
 	// ACK and async flush, at the cost of higher latency.
 	// Default: false (async batching for better performance)
 	SyncFlush bool
+
+	// AckAfterFlush makes Handle return only once the appender has written
+	// the event, so the router acks the message after the write
+	// (flush-then-ack) while events are still batched. Handlers wait for a
+	// flush concurrently, so the subscriber must be created with DeferredAck
+	// and a MaxAckPending of at least the appender's BatchSize; otherwise
+	// delivery stalls at SubscribersCount messages per FlushInterval.
+	AckAfterFlush bool
 }
 
 // DefaultDuckDBHandlerConfig returns production defaults.
@@ -100,6 +119,7 @@ func DefaultDuckDBHandlerConfig() DuckDBHandlerConfig {
 		MaxDeduplicationEntries: 10000,
 		EnableDedupeAudit:       true, // Enable audit logging by default
 		StoreRawPayload:         true, // Store full payload for recovery
+		AckAfterFlush:           true, // Never ack an event that is not written
 	}
 }
 
@@ -142,9 +162,20 @@ func (h *DuckDBHandler) SetAuditStore(store DedupeAuditStore) {
 	h.auditStore = store
 }
 
+// SetQuarantine enables timestamp validation. Events whose started_at falls
+// outside window are written to store instead of being appended.
+// This is optional - if not set, events are appended regardless of started_at.
+func (h *DuckDBHandler) SetQuarantine(store QuarantineStore, window validation.TimestampWindow) {
+	h.quarantineStore = store
+	h.timestampWindow = window
+}
+
 // Handle processes a single media event message.
 // This is the handler function passed to Router.AddNoPublisherHandler.
 //
+// With AckAfterFlush, Handle returns once the event's batch has been
+// written, so the router's ack follows the write.
+//
 // Error handling:
 //   - Parse errors return PermanentError (no retry, goes to DLQ)
 //   - Append errors return error (triggers retry)
@@ -158,9 +189,9 @@ func (h *DuckDBHandler) Handle(msg *message.Message) error {
 	// Store raw payload for potential audit logging (before parsing)
 	rawPayload := msg.Payload
 
-	// Deserialize event
-	var event MediaEvent
-	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+	// Deserialize event, upgrading older schema versions
+	event, err := MigrateEvent(msg.Payload)
+	if err != nil {
 		h.parseErrors.Add(1)
 		metrics.RecordNATSParseFailed()
 		h.logger.Error("Failed to parse message", err, watermill.LogFields{
@@ -179,6 +210,16 @@ func (h *DuckDBHandler) Handle(msg *message.Message) error {
 		Str("username", event.Username).
 		Msg("HANDLER: RECEIVED")
 
+	// Hold back events with implausible timestamps (e.g. corrupt epoch values)
+	// before they reach deduplication or the appender
+	quarantined, err := h.quarantineIfImplausible(msg.Context(), &event)
+	if err != nil {
+		return NewRetryableError("quarantine failed", err)
+	}
+	if quarantined {
+		return nil
+	}
+
 	// Cross-source deduplication (EventID, SessionKey, CorrelationKey)
 	// Pass raw payload for audit logging if enabled
 	if h.config.EnableCrossSourceDedup && h.isDuplicateWithAudit(&event, rawPayload) {
@@ -200,7 +241,13 @@ func (h *DuckDBHandler) Handle(msg *message.Message) error {
 		ctx = msgCtx
 	}
 
-	if err := h.appender.Append(ctx, &event); err != nil {
+	var persisted chan struct{}
+	var ack func()
+	if h.config.AckAfterFlush && !h.config.SyncFlush {
+		persisted = make(chan struct{})
+		ack = func() { close(persisted) }
+	}
+	if err := h.appender.AppendWithAck(ctx, &event, ack); err != nil {
 		h.logger.Error("Failed to append event", err, watermill.LogFields{
 			"event_id": event.EventID,
 		})
@@ -208,6 +255,17 @@ func (h *DuckDBHandler) Handle(msg *message.Message) error {
 		return NewRetryableError("append failed", err)
 	}
 
+	// Hold the ack until the batch containing the event is written. If the
+	// wait is abandoned the event stays buffered; the redelivered copy is
+	// dropped by the store's deduplication once the first one is written.
+	if persisted != nil {
+		select {
+		case <-persisted:
+		case <-ctx.Done():
+			return NewRetryableError("waiting for flush", ctx.Err())
+		}
+	}
+
 	// DETERMINISM: If SyncFlush is enabled, flush immediately to ensure
 	// the database write completes before we ACK the NATS message.
 	// This prevents data loss if the consumer crashes between ACK and async flush.
@@ -253,7 +311,7 @@ func (h *DuckDBHandler) isDuplicateWithAudit(event *MediaEvent, rawPayload []byt
 	// Check EventID (primary dedup key)
 	// NOTE: IsDuplicate both checks AND records if new - this is the expected behavior
 	if h.dedupCache.IsDuplicate(event.EventID) {
-		logging.Debug().
+		logging.Sampled().Debug().
 			Str("event_id", event.EventID).
 			Str("session_key", event.SessionKey).
 			Msg("DEDUP: DUPLICATE by EventID")
@@ -268,7 +326,7 @@ func (h *DuckDBHandler) isDuplicateWithAudit(event *MediaEvent, rawPayload []byt
 	// Check SessionKey if different from EventID
 	if event.SessionKey != "" && event.SessionKey != event.EventID {
 		if h.dedupCache.Contains(event.SessionKey) {
-			logging.Debug().
+			logging.Sampled().Debug().
 				Str("session_key", event.SessionKey).
 				Str("event_id", event.EventID).
 				Msg("DEDUP: DUPLICATE by SessionKey")
@@ -284,7 +342,7 @@ func (h *DuckDBHandler) isDuplicateWithAudit(event *MediaEvent, rawPayload []byt
 	// Check CorrelationKey for same-source deduplication
 	if event.CorrelationKey != "" {
 		if h.dedupCache.Contains("corr:" + event.CorrelationKey) {
-			logging.Debug().
+			logging.Sampled().Debug().
 				Str("correlation_key", event.CorrelationKey).
 				Str("event_id", event.EventID).
 				Str("session_key", event.SessionKey).
@@ -313,7 +371,7 @@ func (h *DuckDBHandler) isDuplicateWithAudit(event *MediaEvent, rawPayload []byt
 						continue // Skip same source - this prevents false positives
 					}
 					if h.dedupCache.Contains("xsrc:" + otherSource + ":" + crossSourceKey) {
-						logging.Debug().
+						logging.Sampled().Debug().
 							Str("cross_source_key", crossSourceKey).
 							Str("event_id", event.EventID).
 							Str("matched_source", otherSource).
@@ -388,6 +446,51 @@ func (h *DuckDBHandler) logDedupeDecision(event *MediaEvent, rawPayload []byte,
 	}()
 }
 
+// quarantineIfImplausible stores the event in the quarantine table when its
+// started_at is outside the configured timestamp window. Returns true if the
+// event was quarantined and must not be appended.
+//
+// The insert is synchronous so the message is only ACKed once the event is
+// safely quarantined; a failed insert is retried by the router.
+func (h *DuckDBHandler) quarantineIfImplausible(ctx context.Context, event *MediaEvent) (bool, error) {
+	if h.quarantineStore == nil {
+		return false, nil
+	}
+
+	reason := h.timestampWindow.Check(event.StartedAt, time.Now())
+	if reason == "" {
+		return false, nil
+	}
+
+	entry, err := models.NewQuarantinedEvent(mediaEventToPlaybackEvent(event), reason)
+	if err != nil {
+		return false, err
+	}
+	if event.ServerID != "" {
+		entry.ServerID = event.ServerID
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := h.quarantineStore.InsertQuarantinedEvent(ctx, entry); err != nil {
+		h.logger.Error("Failed to quarantine event", err, watermill.LogFields{
+			"event_id": event.EventID,
+			"reason":   reason,
+		})
+		return false, err
+	}
+
+	metrics.RecordEventQuarantined(event.Source, reason)
+	logging.Warn().
+		Str("event_id", event.EventID).
+		Str("source", event.Source).
+		Time("started_at", event.StartedAt).
+		Str("reason", reason).
+		Msg("Quarantined event with implausible timestamp")
+	return true, nil
+}
+
 // recordEvent adds event keys to the deduplication cache.
 // Uses BloomLRU which handles capacity limits and TTL automatically.
 func (h *DuckDBHandler) recordEvent(event *MediaEvent) {
@@ -9,6 +9,7 @@ package eventprocessor
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -16,6 +17,9 @@ import (
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/goccy/go-json"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/validation"
 )
 
 func TestDefaultDuckDBHandlerConfig(t *testing.T) {
@@ -165,6 +169,7 @@ func TestDuckDBHandler_CrossSourceDeduplication(t *testing.T) {
 	cfg := DefaultDuckDBHandlerConfig()
 	cfg.EnableCrossSourceDedup = true
 	cfg.DeduplicationWindow = time.Minute
+	cfg.AckAfterFlush = false // The appender is never started
 	handler, err := NewDuckDBHandler(appender, cfg, nil)
 	if err != nil {
 		t.Fatalf("NewDuckDBHandler error: %v", err)
@@ -241,6 +246,7 @@ func TestDuckDBHandler_StartCleanup(t *testing.T) {
 	cfg := DefaultDuckDBHandlerConfig()
 	cfg.EnableCrossSourceDedup = true
 	cfg.DeduplicationWindow = 50 * time.Millisecond // Short for testing
+	cfg.AckAfterFlush = false                       // The appender is never started
 	handler, err := NewDuckDBHandler(appender, cfg, nil)
 	if err != nil {
 		t.Fatalf("NewDuckDBHandler error: %v", err)
@@ -378,6 +384,7 @@ func TestDuckDBHandler_Stats_LastMessageTime(t *testing.T) {
 
 	cfg := DefaultDuckDBHandlerConfig()
 	cfg.EnableCrossSourceDedup = false
+	cfg.AckAfterFlush = false // The appender is never started
 	handler, err := NewDuckDBHandler(appender, cfg, nil)
 	if err != nil {
 		t.Fatalf("NewDuckDBHandler error: %v", err)
@@ -412,3 +419,112 @@ func TestDuckDBHandler_Stats_LastMessageTime(t *testing.T) {
 			stats.LastMessageTime, before, after)
 	}
 }
+
+// mockQuarantineStore records quarantined events.
+type mockQuarantineStore struct {
+	entries []*models.QuarantinedEvent
+	err     error
+}
+
+func (m *mockQuarantineStore) InsertQuarantinedEvent(_ context.Context, entry *models.QuarantinedEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func TestDuckDBHandler_Handle_QuarantinesImplausibleTimestamps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		startedAt  time.Time
+		wantReason string
+	}{
+		{"epoch zero", time.Unix(0, 0), models.QuarantineReasonTooOld},
+		{"far future", time.Now().Add(48 * time.Hour), models.QuarantineReasonInFuture},
+		{"plausible", time.Now().Add(-time.Hour), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := NewMockEventStore()
+			appender, err := NewAppender(store, DefaultAppenderConfig())
+			if err != nil {
+				t.Fatalf("NewAppender error: %v", err)
+			}
+			defer appender.Close()
+
+			cfg := DefaultDuckDBHandlerConfig()
+			cfg.SyncFlush = true
+			handler, err := NewDuckDBHandler(appender, cfg, nil)
+			if err != nil {
+				t.Fatalf("NewDuckDBHandler error: %v", err)
+			}
+			quarantine := &mockQuarantineStore{}
+			handler.SetQuarantine(quarantine, validation.DefaultTimestampWindow())
+
+			data, _ := json.Marshal(&MediaEvent{
+				EventID:   "event-" + tt.name,
+				Source:    "tautulli",
+				ServerID:  "srv-1",
+				UserID:    1,
+				Username:  "testuser",
+				Title:     "Test Movie",
+				StartedAt: tt.startedAt,
+			})
+			if err := handler.Handle(message.NewMessage(watermill.NewUUID(), data)); err != nil {
+				t.Fatalf("Handle error: %v", err)
+			}
+
+			if tt.wantReason == "" {
+				if len(quarantine.entries) != 0 {
+					t.Errorf("plausible event quarantined: %+v", quarantine.entries[0])
+				}
+				if got := len(store.GetEvents()); got != 1 {
+					t.Errorf("stored %d events, want 1", got)
+				}
+				return
+			}
+
+			if len(quarantine.entries) != 1 {
+				t.Fatalf("quarantined %d events, want 1", len(quarantine.entries))
+			}
+			entry := quarantine.entries[0]
+			if entry.Reason != tt.wantReason || entry.ServerID != "srv-1" || len(entry.RawPayload) == 0 {
+				t.Errorf("unexpected entry: reason=%q server=%q payload=%d bytes", entry.Reason, entry.ServerID, len(entry.RawPayload))
+			}
+			if got := len(store.GetEvents()); got != 0 {
+				t.Errorf("stored %d events, want 0", got)
+			}
+			if stats := handler.Stats(); stats.MessagesProcessed != 0 {
+				t.Errorf("MessagesProcessed = %d, want 0", stats.MessagesProcessed)
+			}
+		})
+	}
+}
+
+func TestDuckDBHandler_Handle_QuarantineStoreError(t *testing.T) {
+	t.Parallel()
+
+	appender, err := NewAppender(NewMockEventStore(), DefaultAppenderConfig())
+	if err != nil {
+		t.Fatalf("NewAppender error: %v", err)
+	}
+	defer appender.Close()
+
+	handler, err := NewDuckDBHandler(appender, DefaultDuckDBHandlerConfig(), nil)
+	if err != nil {
+		t.Fatalf("NewDuckDBHandler error: %v", err)
+	}
+	handler.SetQuarantine(&mockQuarantineStore{err: errors.New("db down")}, validation.DefaultTimestampWindow())
+
+	data, _ := json.Marshal(&MediaEvent{EventID: "bad-ts", Source: "plex", StartedAt: time.Unix(0, 0)})
+	err = handler.Handle(message.NewMessage(watermill.NewUUID(), data))
+	if err == nil || !IsRetryableError(err) {
+		t.Errorf("Handle error = %v, want retryable error", err)
+	}
+}
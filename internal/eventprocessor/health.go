@@ -70,7 +70,7 @@ type OverallHealth struct {
 	// Healthy indicates whether all critical components are healthy.
 	Healthy bool `json:"healthy"`
 	// Status is the overall health status.
-	Status HealthStatusType `json:"status"`
+	Status HealthStatusType `json:"status" swaggertype:"string" enums:"healthy,degraded,unhealthy"`
 	// Timestamp is when this health check was performed.
 	Timestamp time.Time `json:"timestamp"`
 	// Components contains individual component health.
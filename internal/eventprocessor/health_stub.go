@@ -57,7 +57,7 @@ type HealthCheckable interface {
 // OverallHealth represents the aggregated health status of all components.
 type OverallHealth struct {
 	Healthy    bool                       `json:"healthy"`
-	Status     HealthStatusType           `json:"status"`
+	Status     HealthStatusType           `json:"status" swaggertype:"string" enums:"healthy,degraded,unhealthy"`
 	Timestamp  time.Time                  `json:"timestamp"`
 	Components map[string]ComponentHealth `json:"components"`
 }
@@ -167,7 +167,7 @@ func TestIntegration_SyncPublisherConversion(t *testing.T) {
 	dynamicRange := "Dolby Vision"
 	audioCodec := "truehd"
 	playDuration := 3600
-	audioChannels := 8
+	audioChannels := "8"
 	streamBitrate := 50000
 	secure := 1
 	local := 0
@@ -205,9 +205,8 @@ func TestIntegration_SyncPublisherConversion(t *testing.T) {
 	// Convert to MediaEvent
 	mediaEvent := pub.playbackEventToMediaEvent(original)
 
-	// Convert back to PlaybackEvent using DuckDBStore
-	store := &DuckDBStore{}
-	restored := store.mediaEventToPlaybackEvent(mediaEvent)
+	// Convert back to PlaybackEvent as DuckDBStore does
+	restored := mediaEventToPlaybackEvent(mediaEvent)
 
 	// Verify roundtrip preservation
 	if restored.Source != original.Source {
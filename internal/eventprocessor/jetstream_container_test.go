@@ -0,0 +1,162 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration && nats
+
+// Package eventprocessor_test runs the event processor against a NATS
+// server container; testinfra imports eventprocessor, so these tests live
+// outside the package.
+package eventprocessor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/tomtom215/cartographus/internal/eventprocessor"
+	"github.com/tomtom215/cartographus/internal/testinfra"
+)
+
+// TestJetStreamContainer_DurableConsumer publishes events to a real
+// JetStream server and checks that a durable consumer bound to the
+// MEDIA_EVENTS stream receives and acknowledges every one.
+//
+// Usage:
+//
+//	go test -tags "integration nats" -run TestJetStreamContainer ./internal/eventprocessor/...
+func TestJetStreamContainer_DurableConsumer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	testinfra.SkipIfNoDocker(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	nats, err := testinfra.NewNATSContainer(ctx, testinfra.WithNATSStreamRetention(time.Hour, 64<<20, 1000))
+	if err != nil {
+		t.Fatalf("Failed to start NATS container: %v", err)
+	}
+	defer testinfra.CleanupContainer(t, ctx, nats.Container)
+
+	nc, err := nats.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	js, err := jetstream.New(nc)
+	if err != nil {
+		t.Fatalf("jetstream.New() error = %v", err)
+	}
+	stream, err := js.Stream(ctx, nats.Stream.Name)
+	if err != nil {
+		t.Fatalf("stream %s was not pre-created: %v", nats.Stream.Name, err)
+	}
+
+	t.Run("stream has the configured retention", func(t *testing.T) {
+		cfg := stream.CachedInfo().Config
+		if cfg.MaxAge != time.Hour || cfg.MaxBytes != 64<<20 || cfg.MaxMsgs != 1000 {
+			t.Errorf("stream limits = age %v, bytes %d, msgs %d; want 1h, 64MiB, 1000",
+				cfg.MaxAge, cfg.MaxBytes, cfg.MaxMsgs)
+		}
+	})
+
+	t.Run("durable consumer receives and acks events", func(t *testing.T) {
+		subCfg := eventprocessor.DefaultSubscriberConfig(nats.ClientURL)
+		subCfg.DurableName = "integration-durable"
+		subCfg.QueueGroup = "integration"
+		subCfg.SubscribersCount = 1
+		subCfg.AckWaitTimeout = 5 * time.Second
+		subCfg.CloseTimeout = 5 * time.Second
+		subCfg.StreamName = nats.Stream.Name
+
+		subscriber, err := eventprocessor.NewSubscriber(&subCfg, nil)
+		if err != nil {
+			t.Fatalf("NewSubscriber() error = %v", err)
+		}
+		defer subscriber.Close()
+
+		messages, err := subscriber.Subscribe(ctx, "playback.>")
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+
+		publisher, err := eventprocessor.NewPublisher(eventprocessor.DefaultPublisherConfig(nats.ClientURL), nil)
+		if err != nil {
+			t.Fatalf("NewPublisher() error = %v", err)
+		}
+		defer publisher.Close()
+
+		const numEvents = 3
+		want := make(map[string]bool, numEvents)
+		for i := 0; i < numEvents; i++ {
+			event := eventprocessor.NewMediaEvent(eventprocessor.SourcePlex)
+			event.UserID = i + 1
+			event.Username = "alice"
+			event.MediaType = eventprocessor.MediaTypeMovie
+			event.Title = "JetStream Test"
+			if err := publisher.PublishEvent(ctx, event); err != nil {
+				t.Fatalf("PublishEvent() error = %v", err)
+			}
+			want[event.EventID] = true
+		}
+
+		for i := 0; i < numEvents; i++ {
+			select {
+			case msg := <-messages:
+				event, err := eventprocessor.DeserializeEvent(msg.Payload)
+				if err != nil {
+					t.Fatalf("DeserializeEvent() error = %v", err)
+				}
+				if !want[event.EventID] {
+					t.Errorf("received unexpected or duplicate event %s", event.EventID)
+				}
+				delete(want, event.EventID)
+				msg.Ack()
+			case <-time.After(30 * time.Second):
+				t.Fatalf("received %d of %d events", i, numEvents)
+			}
+		}
+
+		// Acks are sent after the message channel hands over the next
+		// message; poll until the server has recorded them all
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			info := durableConsumerInfo(ctx, t, stream)
+			if info.NumAckPending == 0 && info.AckFloor.Consumer == numEvents {
+				if info.Config.Durable == "" {
+					t.Errorf("consumer %s is not durable", info.Name)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("consumer %s: ack pending %d, ack floor %d; want 0 and %d",
+					info.Name, info.NumAckPending, info.AckFloor.Consumer, numEvents)
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+}
+
+// durableConsumerInfo returns the stream's only consumer.
+func durableConsumerInfo(ctx context.Context, t *testing.T, stream jetstream.Stream) *jetstream.ConsumerInfo {
+	t.Helper()
+
+	var consumers []*jetstream.ConsumerInfo
+	list := stream.ListConsumers(ctx)
+	for info := range list.Info() {
+		consumers = append(consumers, info)
+	}
+	if err := list.Err(); err != nil {
+		t.Fatalf("ListConsumers() error = %v", err)
+	}
+	if len(consumers) != 1 {
+		t.Fatalf("stream has %d consumers, want 1", len(consumers))
+	}
+	return consumers[0]
+}
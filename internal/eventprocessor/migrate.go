@@ -0,0 +1,86 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package eventprocessor
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+)
+
+// eventMigration upgrades a serialized event, decoded into its top-level
+// fields, by one schema version. It edits fields in place: renaming keys,
+// converting values, or filling in fields the older version lacked.
+type eventMigration func(fields map[string]json.RawMessage) error
+
+// eventMigrations upgrades events from the schema version they are keyed by
+// to the next one. Changing MediaEvent incompatibly means incrementing
+// SchemaVersion and adding the migration from the previous version here,
+// together with a fixture of the previous format in testdata, so events
+// already stored in JetStream, the WAL and the DLQ stay readable.
+var eventMigrations = map[int]eventMigration{}
+
+// MigrateEvent decodes a serialized MediaEvent of any supported schema
+// version into the current struct. Events without a schema_version are
+// version 1. Older versions are upgraded one version at a time by
+// eventMigrations; a version newer than SchemaVersion, written by a later
+// release, is rejected with ErrUnsupportedSchemaVersion rather than decoded
+// with fields silently missing.
+//
+// Use it instead of json.Unmarshal wherever stored events are read back:
+// consumers, replay, WAL recovery and the DLQ.
+func MigrateEvent(raw []byte) (MediaEvent, error) {
+	return migrateEvent(raw, SchemaVersion, eventMigrations)
+}
+
+// migrateEvent implements MigrateEvent for a given current version and set
+// of migrations.
+func migrateEvent(raw []byte, current int, migrations map[int]eventMigration) (MediaEvent, error) {
+	var header struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return MediaEvent{}, fmt.Errorf("read event schema version: %w", err)
+	}
+
+	version := header.SchemaVersion
+	if version == 0 {
+		version = 1 // Written before schema versioning
+	}
+	if version < 0 || version > current {
+		return MediaEvent{}, fmt.Errorf("%w: event has schema version %d, this release reads up to %d",
+			ErrUnsupportedSchemaVersion, version, current)
+	}
+
+	if version < current {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return MediaEvent{}, fmt.Errorf("decode event for migration: %w", err)
+		}
+		for v := version; v < current; v++ {
+			migrate, ok := migrations[v]
+			if !ok {
+				return MediaEvent{}, fmt.Errorf("%w: no migration from schema version %d",
+					ErrUnsupportedSchemaVersion, v)
+			}
+			if err := migrate(fields); err != nil {
+				return MediaEvent{}, fmt.Errorf("migrate event from schema version %d: %w", v, err)
+			}
+		}
+		migrated, err := json.Marshal(fields)
+		if err != nil {
+			return MediaEvent{}, fmt.Errorf("encode migrated event: %w", err)
+		}
+		raw = migrated
+	}
+
+	var event MediaEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return MediaEvent{}, fmt.Errorf("unmarshal event: %w", err)
+	}
+	event.SchemaVersion = current
+	return event, nil
+}
@@ -0,0 +1,146 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package eventprocessor
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+func TestMigrateEvent_V1Fixture(t *testing.T) {
+	raw, err := os.ReadFile("testdata/media_event_v1.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	event, err := MigrateEvent(raw)
+	if err != nil {
+		t.Fatalf("MigrateEvent() error = %v", err)
+	}
+
+	started := time.Date(2026, 1, 15, 20, 0, 0, 0, time.UTC)
+	stopped := started.Add(45 * time.Minute)
+	if event.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", event.SchemaVersion, SchemaVersion)
+	}
+	if event.EventID != "8f14e45f-ceea-467f-a0e6-1a1f0c2e7b3d" || event.Source != "plex" || event.ServerID != "plex-1" {
+		t.Errorf("identification = %q/%q/%q", event.EventID, event.Source, event.ServerID)
+	}
+	if event.CorrelationKey != "plex-1:7:12345:2026-01-15T20:00:00Z" || event.TransactionID != "txn-8f14e45f" {
+		t.Errorf("correlation/transaction = %q/%q", event.CorrelationKey, event.TransactionID)
+	}
+	if event.UserID != 7 || event.Username != "alice" || event.FriendlyName != "Alice" {
+		t.Errorf("user = %d/%q/%q", event.UserID, event.Username, event.FriendlyName)
+	}
+	if event.MediaType != "episode" || event.Title != "Pilot" || event.GrandparentTitle != "Example Show" || event.Year != 2024 {
+		t.Errorf("media = %q/%q/%q/%d", event.MediaType, event.Title, event.GrandparentTitle, event.Year)
+	}
+	if !event.StartedAt.Equal(started) || event.StoppedAt == nil || !event.StoppedAt.Equal(stopped) {
+		t.Errorf("started/stopped = %v/%v", event.StartedAt, event.StoppedAt)
+	}
+	if event.PercentComplete != 98 || event.PlayDuration != 2640 || event.PausedCounter != 2 {
+		t.Errorf("progress = %d%%/%ds/%d pauses", event.PercentComplete, event.PlayDuration, event.PausedCounter)
+	}
+	if event.Platform != "Roku" || event.Player != "Living Room" || event.IPAddress != "203.0.113.10" || event.LocationType != "wan" {
+		t.Errorf("platform = %q/%q/%q/%q", event.Platform, event.Player, event.IPAddress, event.LocationType)
+	}
+	if event.TranscodeDecision != "direct play" || event.AudioChannels != 6 || event.StreamBitrate != 8000 {
+		t.Errorf("quality = %q/%d/%d", event.TranscodeDecision, event.AudioChannels, event.StreamBitrate)
+	}
+	if !event.Secure || event.Local || event.Relayed {
+		t.Errorf("connection = secure %v, local %v, relayed %v", event.Secure, event.Local, event.Relayed)
+	}
+	if string(event.RawPayload) != `{"ratingKey": "12345"}` {
+		t.Errorf("RawPayload = %s", event.RawPayload)
+	}
+	if err := event.Validate(); err != nil {
+		t.Errorf("migrated event does not validate: %v", err)
+	}
+}
+
+func TestMigrateEvent_Unversioned(t *testing.T) {
+	// Events written before schema versioning have no schema_version
+	raw := []byte(`{"event_id":"legacy","source":"tautulli","user_id":3,"media_type":"movie","title":"Old Movie"}`)
+
+	event, err := MigrateEvent(raw)
+	if err != nil {
+		t.Fatalf("MigrateEvent() error = %v", err)
+	}
+	if event.SchemaVersion != SchemaVersion || event.EventID != "legacy" || event.Title != "Old Movie" {
+		t.Errorf("event = %+v, want the legacy event at the current version", event)
+	}
+}
+
+func TestMigrateEvent_RejectsUnsupportedVersions(t *testing.T) {
+	for _, version := range []int{SchemaVersion + 1, -1} {
+		raw, err := json.Marshal(map[string]interface{}{"schema_version": version, "event_id": "future"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := MigrateEvent(raw); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+			t.Errorf("MigrateEvent(version %d) error = %v, want ErrUnsupportedSchemaVersion", version, err)
+		}
+	}
+
+	if _, err := MigrateEvent([]byte(`not json`)); err == nil || errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Errorf("MigrateEvent(invalid JSON) error = %v, want a decode error", err)
+	}
+}
+
+func TestMigrateEvent_AppliesMigrationsInOrder(t *testing.T) {
+	// A hypothetical history: version 1 called the client address "ip" and
+	// version 2 added location_type, derived from the local flag.
+	migrations := map[int]eventMigration{
+		1: func(fields map[string]json.RawMessage) error {
+			fields["ip_address"] = fields["ip"]
+			delete(fields, "ip")
+			return nil
+		},
+		2: func(fields map[string]json.RawMessage) error {
+			var local bool
+			if err := json.Unmarshal(fields["local"], &local); err != nil {
+				return err
+			}
+			fields["location_type"] = json.RawMessage(`"wan"`)
+			if local {
+				fields["location_type"] = json.RawMessage(`"lan"`)
+			}
+			return nil
+		},
+	}
+	raw := []byte(`{"schema_version":1,"event_id":"e1","ip":"192.168.1.20","local":true}`)
+
+	event, err := migrateEvent(raw, 3, migrations)
+	if err != nil {
+		t.Fatalf("migrateEvent() error = %v", err)
+	}
+	if event.SchemaVersion != 3 || event.IPAddress != "192.168.1.20" || event.LocationType != "lan" {
+		t.Errorf("event = version %d, ip %q, location %q; want 3, 192.168.1.20, lan",
+			event.SchemaVersion, event.IPAddress, event.LocationType)
+	}
+
+	// Version 2 events only need the second migration
+	raw = []byte(`{"schema_version":2,"event_id":"e2","ip_address":"203.0.113.5","local":false}`)
+	if event, err = migrateEvent(raw, 3, migrations); err != nil || event.LocationType != "wan" {
+		t.Errorf("migrateEvent(v2) = %q, %v; want wan", event.LocationType, err)
+	}
+
+	// A gap in the chain is an error, not a silently partial upgrade
+	delete(migrations, 2)
+	if _, err := migrateEvent(raw, 3, migrations); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Errorf("migrateEvent() with a missing migration error = %v, want ErrUnsupportedSchemaVersion", err)
+	}
+
+	// Migration failures are reported
+	migrations[2] = func(map[string]json.RawMessage) error { return errors.New("bad field") }
+	if _, err := migrateEvent(raw, 3, migrations); err == nil {
+		t.Error("migrateEvent() with a failing migration succeeded")
+	}
+}
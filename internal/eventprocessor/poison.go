@@ -0,0 +1,282 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package eventprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	wmNats "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// Poison queue metadata set by the poison publisher. Both survive a
+// requeue, so a message that fails again is listed with its full history.
+const (
+	// PoisonFailuresKey counts how many times a message has been poisoned.
+	PoisonFailuresKey = "poison_failures"
+	// PoisonOriginalUUIDKey holds the UUID the message was first published
+	// with; poisoned and requeued copies get new UUIDs.
+	PoisonOriginalUUIDKey = "poison_original_uuid"
+)
+
+// defaultPoisonListLimit caps ListPoison when no limit is given.
+const defaultPoisonListLimit = 100
+
+// PoisonMessage is a message the router gave up on, as listed by ListPoison.
+type PoisonMessage struct {
+	// ID is the stream sequence of the poisoned copy; pass it to
+	// RequeuePoison.
+	ID           uint64            `json:"id"`
+	UUID         string            `json:"uuid"`
+	Topic        string            `json:"topic"`
+	Reason       string            `json:"reason"`
+	FailureCount int               `json:"failure_count"`
+	Handler      string            `json:"handler,omitempty"`
+	PoisonedAt   time.Time         `json:"poisoned_at"`
+	Payload      []byte            `json:"payload"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// poisonPublisher publishes poisoned messages under a new UUID.
+type poisonPublisher struct {
+	message.Publisher
+}
+
+// NewPoisonPublisher wraps the publisher given to NewRouter for its poison
+// queue. The poison queue usually lives in the same stream as the events,
+// and JetStream deduplicates by UUID per stream, so republishing a message
+// under its own UUID within the duplicate window would silently drop it.
+// Each poisoned copy gets a new UUID, keeping the original in
+// PoisonOriginalUUIDKey, and PoisonFailuresKey is incremented.
+func NewPoisonPublisher(pub message.Publisher) message.Publisher {
+	return &poisonPublisher{Publisher: pub}
+}
+
+// Publish implements message.Publisher.
+func (p *poisonPublisher) Publish(topic string, msgs ...*message.Message) error {
+	poisoned := make([]*message.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		cp := msg.Copy()
+		cp.UUID = watermill.NewUUID()
+		if cp.Metadata.Get(PoisonOriginalUUIDKey) == "" {
+			cp.Metadata.Set(PoisonOriginalUUIDKey, msg.UUID)
+		}
+		cp.Metadata.Set(PoisonFailuresKey, strconv.Itoa(poisonFailures(cp)+1))
+		poisoned = append(poisoned, cp)
+	}
+	return p.Publisher.Publish(topic, poisoned...)
+}
+
+// poisonFailures returns the message's poison count, 0 if never poisoned.
+func poisonFailures(msg *message.Message) int {
+	n, err := strconv.Atoi(msg.Metadata.Get(PoisonFailuresKey))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// ListPoison returns up to limit messages from the poison queue, oldest
+// first (100 when limit is 0 or less). It needs StreamName and
+// PoisonQueueTopic in the subscriber config and reads with an ephemeral
+// consumer, so listing does not remove anything.
+func (s *Subscriber) ListPoison(ctx context.Context, limit int) ([]PoisonMessage, error) {
+	if limit <= 0 {
+		limit = defaultPoisonListLimit
+	}
+
+	nc, str, err := s.poisonStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer nc.Close()
+
+	// An empty queue would otherwise wait out a fetch
+	info, err := str.Info(ctx, jetstream.WithSubjectFilter(s.config.PoisonQueueTopic))
+	if err != nil {
+		return nil, fmt.Errorf("get stream %s info: %w", s.config.StreamName, err)
+	}
+	if info.State.Subjects[s.config.PoisonQueueTopic] == 0 {
+		return []PoisonMessage{}, nil
+	}
+
+	cons, err := str.OrderedConsumer(ctx, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{s.config.PoisonQueueTopic},
+		DeliverPolicy:  jetstream.DeliverAllPolicy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create poison queue consumer: %w", err)
+	}
+
+	poisoned := make([]PoisonMessage, 0)
+	for len(poisoned) < limit {
+		batch, err := cons.Fetch(limit-len(poisoned), jetstream.FetchMaxWait(replayFetchWait))
+		if err != nil {
+			return nil, fmt.Errorf("fetch poison messages: %w", err)
+		}
+
+		fetched, done := 0, false
+		for m := range batch.Messages() {
+			fetched++
+			meta, err := m.Metadata()
+			if err != nil {
+				return nil, fmt.Errorf("read poison message metadata: %w", err)
+			}
+			pm, err := toPoisonMessage(meta.Sequence.Stream, meta.Timestamp, m.Subject(), m.Headers(), m.Data())
+			if err != nil {
+				logging.Warn().Err(err).Uint64("sequence", meta.Sequence.Stream).Msg("Skipping unreadable poison message")
+			} else {
+				poisoned = append(poisoned, pm)
+			}
+			if meta.NumPending == 0 {
+				done = true
+			}
+		}
+		if err := batch.Error(); err != nil {
+			return nil, fmt.Errorf("fetch poison messages: %w", err)
+		}
+		if done || fetched == 0 {
+			break
+		}
+	}
+	return poisoned, nil
+}
+
+// RequeuePoison publishes the poison message with stream sequence id back
+// to the topic it failed on and removes it from the poison queue. Call it
+// once the cause of the failure is fixed. The message is republished under
+// a new UUID so neither JetStream nor the router deduplicates it; if it
+// fails again it returns to the poison queue with its failure count
+// incremented. Returns ErrPoisonMessageNotFound if id is not a message in
+// the poison queue.
+func (s *Subscriber) RequeuePoison(ctx context.Context, id uint64) error {
+	nc, str, err := s.poisonStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	raw, err := str.GetMsg(ctx, id)
+	if errors.Is(err, jetstream.ErrMsgNotFound) {
+		return fmt.Errorf("%w: %d", ErrPoisonMessageNotFound, id)
+	}
+	if err != nil {
+		return fmt.Errorf("get poison message %d: %w", id, err)
+	}
+	if raw.Subject != s.config.PoisonQueueTopic {
+		return fmt.Errorf("%w: %d", ErrPoisonMessageNotFound, id)
+	}
+
+	msg, err := (&wmNats.NATSMarshaler{}).Unmarshal(&natsgo.Msg{Subject: raw.Subject, Header: raw.Header, Data: raw.Data})
+	if err != nil {
+		return fmt.Errorf("read poison message %d: %w", id, err)
+	}
+	topic := msg.Metadata.Get(middleware.PoisonedTopicKey)
+	if topic == "" {
+		return fmt.Errorf("poison message %d has no original topic", id)
+	}
+
+	msg.UUID = watermill.NewUUID()
+	for _, key := range []string{
+		middleware.ReasonForPoisonedKey,
+		middleware.PoisonedTopicKey,
+		middleware.PoisonedHandlerKey,
+		middleware.PoisonedSubscriberKey,
+	} {
+		delete(msg.Metadata, key)
+	}
+
+	out, err := (&wmNats.NATSMarshaler{}).Marshal(topic, msg)
+	if err != nil {
+		return fmt.Errorf("marshal requeued message: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return fmt.Errorf("create JetStream context: %w", err)
+	}
+	if _, err := js.PublishMsg(ctx, out, jetstream.WithMsgID(msg.UUID)); err != nil {
+		return fmt.Errorf("requeue poison message %d: %w", id, err)
+	}
+
+	// The message is back on its topic; failing to delete only leaves a
+	// stale entry in the poison queue
+	if err := str.DeleteMsg(ctx, id); err != nil {
+		return fmt.Errorf("requeued poison message %d but could not remove it: %w", id, err)
+	}
+
+	logging.Info().
+		Uint64("sequence", id).
+		Str("topic", topic).
+		Str("uuid", msg.UUID).
+		Msg("Requeued poison message")
+	return nil
+}
+
+// poisonStream connects to NATS and returns the stream holding the poison
+// queue. The caller closes the connection.
+func (s *Subscriber) poisonStream(ctx context.Context) (*natsgo.Conn, jetstream.Stream, error) {
+	if s.config.StreamName == "" || s.config.PoisonQueueTopic == "" {
+		return nil, nil, fmt.Errorf("%w: poison queue needs StreamName and PoisonQueueTopic", ErrInvalidConfig)
+	}
+
+	nc, err := natsgo.Connect(s.config.URL, natsgo.Name("cartographus-poison"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect for poison queue: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, nil, fmt.Errorf("create JetStream context: %w", err)
+	}
+	str, err := js.Stream(ctx, s.config.StreamName)
+	if err != nil {
+		nc.Close()
+		return nil, nil, fmt.Errorf("get stream %s: %w", s.config.StreamName, err)
+	}
+	return nc, str, nil
+}
+
+// toPoisonMessage decodes a stored poison queue message.
+func toPoisonMessage(seq uint64, stored time.Time, subject string, header natsgo.Header, data []byte) (PoisonMessage, error) {
+	msg, err := (&wmNats.NATSMarshaler{}).Unmarshal(&natsgo.Msg{Subject: subject, Header: header, Data: data})
+	if err != nil {
+		return PoisonMessage{}, err
+	}
+
+	uuid := msg.Metadata.Get(PoisonOriginalUUIDKey)
+	if uuid == "" {
+		uuid = msg.UUID
+	}
+	failures := poisonFailures(msg)
+	if failures == 0 {
+		// Poisoned without NewPoisonPublisher
+		failures = 1
+	}
+
+	return PoisonMessage{
+		ID:           seq,
+		UUID:         uuid,
+		Topic:        msg.Metadata.Get(middleware.PoisonedTopicKey),
+		Reason:       msg.Metadata.Get(middleware.ReasonForPoisonedKey),
+		FailureCount: failures,
+		Handler:      msg.Metadata.Get(middleware.PoisonedHandlerKey),
+		PoisonedAt:   stored,
+		Payload:      msg.Payload,
+		Metadata:     msg.Metadata,
+	}, nil
+}
@@ -0,0 +1,42 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build !nats
+
+package eventprocessor
+
+import (
+	"context"
+	"time"
+)
+
+// Poison queue metadata keys (stub for non-NATS builds).
+const (
+	PoisonFailuresKey     = "poison_failures"
+	PoisonOriginalUUIDKey = "poison_original_uuid"
+)
+
+// PoisonMessage is a stub for non-NATS builds.
+type PoisonMessage struct {
+	ID           uint64            `json:"id"`
+	UUID         string            `json:"uuid"`
+	Topic        string            `json:"topic"`
+	Reason       string            `json:"reason"`
+	FailureCount int               `json:"failure_count"`
+	Handler      string            `json:"handler,omitempty"`
+	PoisonedAt   time.Time         `json:"poisoned_at"`
+	Payload      []byte            `json:"payload"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// ListPoison returns ErrNATSNotEnabled in non-NATS builds.
+func (s *Subscriber) ListPoison(_ context.Context, _ int) ([]PoisonMessage, error) {
+	return nil, ErrNATSNotEnabled
+}
+
+// RequeuePoison returns ErrNATSNotEnabled in non-NATS builds.
+func (s *Subscriber) RequeuePoison(_ context.Context, _ uint64) error {
+	return ErrNATSNotEnabled
+}
@@ -0,0 +1,187 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package eventprocessor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// recordingPublisher keeps the messages published to it.
+type recordingPublisher struct {
+	published []*message.Message
+}
+
+func (p *recordingPublisher) Publish(_ string, msgs ...*message.Message) error {
+	p.published = append(p.published, msgs...)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestPoisonPublisher_NewUUIDAndFailureCount(t *testing.T) {
+	rec := &recordingPublisher{}
+	pub := NewPoisonPublisher(rec)
+
+	msg := message.NewMessage("original", []byte("payload"))
+	if err := pub.Publish("playback.poison", msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	first := rec.published[0]
+	if first.UUID == "original" {
+		t.Error("poisoned copy should get a new UUID")
+	}
+	if got := first.Metadata.Get(PoisonOriginalUUIDKey); got != "original" {
+		t.Errorf("original UUID = %q, want original", got)
+	}
+	if got := first.Metadata.Get(PoisonFailuresKey); got != "1" {
+		t.Errorf("failures = %q, want 1", got)
+	}
+	if msg.Metadata.Get(PoisonFailuresKey) != "" {
+		t.Error("the handler's message should not be modified")
+	}
+
+	// Poisoned again after a requeue
+	if err := pub.Publish("playback.poison", first); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	second := rec.published[1]
+	if second.Metadata.Get(PoisonOriginalUUIDKey) != "original" || second.Metadata.Get(PoisonFailuresKey) != "2" {
+		t.Errorf("second poisoning metadata = %v, want original UUID and 2 failures", second.Metadata)
+	}
+}
+
+func TestSubscriber_Poison_RequiresConfig(t *testing.T) {
+	subscriber := &Subscriber{config: SubscriberConfig{StreamName: "MEDIA_EVENTS"}}
+
+	if _, err := subscriber.ListPoison(context.Background(), 10); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("ListPoison() error = %v, want ErrInvalidConfig", err)
+	}
+	if err := subscriber.RequeuePoison(context.Background(), 1); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("RequeuePoison() error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestSubscriber_PoisonRequeue(t *testing.T) {
+	url := startReplayTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		topic       = "playback.poisontest"
+		poisonTopic = "playback.poison"
+	)
+
+	publisher, err := NewPublisher(DefaultPublisherConfig(url), nil)
+	if err != nil {
+		t.Fatalf("NewPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+
+	subCfg := DefaultSubscriberConfig(url)
+	subCfg.StreamName = DefaultStreamConfig().Name
+	subCfg.PoisonQueueTopic = poisonTopic
+	subCfg.SubscribersCount = 1
+	subscriber, err := NewSubscriber(&subCfg, nil)
+	if err != nil {
+		t.Fatalf("NewSubscriber() error = %v", err)
+	}
+	defer subscriber.Close()
+
+	routerCfg := DefaultRouterConfig()
+	routerCfg.RetryMaxRetries = 1
+	routerCfg.RetryInitialInterval = 10 * time.Millisecond
+	routerCfg.RetryMaxInterval = 10 * time.Millisecond
+	routerCfg.DeduplicationEnabled = true
+	routerCfg.PoisonQueueTopic = poisonTopic
+	router, err := NewRouter(&routerCfg, NewPoisonPublisher(publisher.WatermillPublisher()), nil)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	var healthy atomic.Bool
+	processed := make(chan string, 1)
+	router.AddConsumerHandler("poison-test", topic, subscriber, func(msg *message.Message) error {
+		if !healthy.Load() {
+			return errors.New("database unavailable")
+		}
+		processed <- string(msg.Payload)
+		return nil
+	})
+	select {
+	case <-router.RunAsync(ctx):
+	case <-time.After(10 * time.Second):
+		t.Fatal("router did not start")
+	}
+	defer router.Close()
+
+	original := message.NewMessage(watermill.NewUUID(), []byte(`{"title":"Poisoned Movie"}`))
+	if err := publisher.Publish(ctx, topic, original); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	// The failing handler sends the message to the poison queue
+	var poisoned []PoisonMessage
+	deadline := time.Now().Add(10 * time.Second)
+	for len(poisoned) == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		if poisoned, err = subscriber.ListPoison(ctx, 10); err != nil {
+			t.Fatalf("ListPoison() error = %v", err)
+		}
+	}
+	if len(poisoned) != 1 {
+		t.Fatalf("poison queue = %d messages, want 1", len(poisoned))
+	}
+	pm := poisoned[0]
+	if pm.UUID != original.UUID || pm.Topic != topic || pm.FailureCount != 1 || pm.Handler != "poison-test" {
+		t.Errorf("poison message = %+v, want original UUID, topic %s, 1 failure", pm, topic)
+	}
+	if !strings.Contains(pm.Reason, "database unavailable") {
+		t.Errorf("reason = %q, want the handler error", pm.Reason)
+	}
+	if string(pm.Payload) != string(original.Payload) {
+		t.Errorf("payload = %s, want %s", pm.Payload, original.Payload)
+	}
+
+	// Only poison queue messages can be requeued
+	if err := subscriber.RequeuePoison(ctx, 1); !errors.Is(err, ErrPoisonMessageNotFound) {
+		t.Errorf("RequeuePoison(original) error = %v, want ErrPoisonMessageNotFound", err)
+	}
+	if err := subscriber.RequeuePoison(ctx, 999); !errors.Is(err, ErrPoisonMessageNotFound) {
+		t.Errorf("RequeuePoison(999) error = %v, want ErrPoisonMessageNotFound", err)
+	}
+
+	// After the fix, the requeued message is processed
+	healthy.Store(true)
+	if err := subscriber.RequeuePoison(ctx, pm.ID); err != nil {
+		t.Fatalf("RequeuePoison() error = %v", err)
+	}
+	select {
+	case payload := <-processed:
+		if payload != string(original.Payload) {
+			t.Errorf("processed payload = %s, want %s", payload, original.Payload)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("requeued message was not processed")
+	}
+
+	poisoned, err = subscriber.ListPoison(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListPoison() error = %v", err)
+	}
+	if len(poisoned) != 0 {
+		t.Errorf("poison queue after requeue = %d messages, want 0", len(poisoned))
+	}
+}
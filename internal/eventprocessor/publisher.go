@@ -16,9 +16,14 @@ import (
 	"github.com/ThreeDotsLabs/watermill"
 	wmNats "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/goccy/go-json"
 	natsgo "github.com/nats-io/nats.go"
 	gobreaker "github.com/sony/gobreaker/v2"
 	"github.com/tomtom215/cartographus/internal/metrics"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Publisher wraps Watermill publisher with resilience patterns.
@@ -29,6 +34,7 @@ type Publisher struct {
 	mu             sync.RWMutex
 	closed         bool
 	logger         watermill.LoggerAdapter
+	partitioning   TopicPartitioning
 }
 
 // NewPublisher creates a resilient Watermill NATS publisher.
@@ -83,8 +89,9 @@ func NewPublisher(cfg PublisherConfig, logger watermill.LoggerAdapter) (*Publish
 	}
 
 	return &Publisher{
-		publisher: pub,
-		logger:    logger,
+		publisher:    pub,
+		logger:       logger,
+		partitioning: cfg.Partitioning,
 	}, nil
 }
 
@@ -108,6 +115,20 @@ func (p *Publisher) Publish(ctx context.Context, topic string, msg *message.Mess
 		msg.Metadata.Set(natsgo.MsgIdHdr, msg.UUID)
 	}
 
+	// Producer span; its context travels in the message metadata so
+	// consumers continue the same trace
+	ctx, span := tracing.Start(ctx, "publish "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nats"),
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingMessageID(msg.UUID),
+		),
+	)
+	defer span.End()
+	tracing.InjectMetadata(ctx, msg.Metadata)
+
 	var err error
 
 	// Circuit breaker wrapper (using v2.3.0 generic API)
@@ -123,12 +144,14 @@ func (p *Publisher) Publish(ctx context.Context, topic string, msg *message.Mess
 	if err == nil {
 		metrics.RecordNATSPublish()
 	}
+	tracing.RecordError(span, err)
 
 	return err
 }
 
 // PublishEvent serializes and publishes a media event.
-// This is a convenience method that handles serialization.
+// This is a convenience method that handles serialization. The subject
+// follows the configured partitioning (see MediaEvent.PartitionedTopic).
 func (p *Publisher) PublishEvent(ctx context.Context, event *MediaEvent) error {
 	data, err := SerializeEvent(event)
 	if err != nil {
@@ -139,8 +162,24 @@ func (p *Publisher) PublishEvent(ctx context.Context, event *MediaEvent) error {
 	msg.Metadata.Set("source", event.Source)
 	msg.Metadata.Set("media_type", event.MediaType)
 	msg.Metadata.Set("user_id", fmt.Sprintf("%d", event.UserID))
+	if event.ServerID != "" {
+		msg.Metadata.Set("server_id", event.ServerID)
+	}
+
+	return p.Publish(ctx, event.PartitionedTopic(p.partitioning), msg)
+}
+
+// PublishBufferHealthCritical publishes a critical buffer health event as
+// JSON to BufferHealthCriticalSubject.
+func (p *Publisher) PublishBufferHealthCritical(ctx context.Context, event *models.BufferHealthCriticalEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("serialize buffer health event: %w", err)
+	}
 
-	return p.Publish(ctx, event.Topic(), msg)
+	msg := message.NewMessage(watermill.NewUUID(), data)
+	msg.Metadata.Set("session_key", event.SessionKey)
+	return p.Publish(ctx, BufferHealthCriticalSubject, msg)
 }
 
 // PublishBatch publishes multiple messages atomically.
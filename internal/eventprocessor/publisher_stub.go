@@ -12,6 +12,8 @@ import (
 	"fmt"
 
 	gobreaker "github.com/sony/gobreaker/v2"
+
+	"github.com/tomtom215/cartographus/internal/models"
 )
 
 // Publisher is a stub when NATS dependencies are not available.
@@ -41,6 +43,11 @@ func (p *Publisher) PublishEvent(ctx context.Context, event *MediaEvent) error {
 	return fmt.Errorf("NATS publisher not available: build with -tags=nats")
 }
 
+// PublishBufferHealthCritical is a stub that returns an error.
+func (p *Publisher) PublishBufferHealthCritical(ctx context.Context, event *models.BufferHealthCriticalEvent) error {
+	return fmt.Errorf("NATS publisher not available: build with -tags=nats")
+}
+
 // PublishBatch is a stub that returns an error.
 func (p *Publisher) PublishBatch(ctx context.Context, topic string, msgs ...interface{}) error {
 	return fmt.Errorf("NATS publisher not available: build with -tags=nats")
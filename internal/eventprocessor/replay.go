@@ -0,0 +1,205 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package eventprocessor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	wmNats "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
+	"github.com/ThreeDotsLabs/watermill/message"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// Replay batching: messages are fetched and handed to the consumer in
+// batches, and the consumer is flushed after each batch so acks can be
+// tallied without holding the whole replay in memory.
+const (
+	replayFetchBatch = 500
+	replayFetchWait  = 2 * time.Second
+)
+
+// Consumer processes messages delivered by Replay. It must Ack a message
+// once its event is persisted or skipped as a duplicate, and Nack it on
+// failure; Flush writes anything it still buffers. DuckDBConsumer
+// implements it.
+type Consumer interface {
+	HandleMessage(ctx context.Context, msg *message.Message)
+	Flush(ctx context.Context) error
+}
+
+// ReplayResult summarizes a Replay run.
+type ReplayResult struct {
+	Stream string    `json:"stream"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to,omitempty"`
+
+	// Delivered is the number of messages read from the stream in range.
+	Delivered int64 `json:"delivered"`
+	// Persisted counts messages the consumer acked: written to DuckDB, or
+	// skipped as duplicates of events already written.
+	Persisted int64 `json:"persisted"`
+	// Failed counts messages the consumer nacked or could not write.
+	Failed int64 `json:"failed"`
+
+	FirstSequence uint64        `json:"first_sequence"`
+	LastSequence  uint64        `json:"last_sequence"`
+	Duration      time.Duration `json:"duration"`
+}
+
+// Replay reads the messages stored in stream between from and to (by
+// JetStream timestamp; a zero from starts at the beginning, a zero to runs
+// to the end of the stream as of the call) and feeds them through consumer,
+// e.g. to rebuild playback_events after it was corrupted or wiped.
+//
+// Replay uses an ephemeral ordered consumer, so the durable consumer's
+// position and acks are untouched and live consumption continues alongside.
+// It is idempotent: the consumer's deduplication skips events it has seen
+// and the store ignores events already in the table, so replaying a range
+// twice writes nothing the second time. Note that a consumer that already
+// processed the range will skip all of it; use a new consumer to rebuild.
+func (s *Subscriber) Replay(ctx context.Context, stream string, from, to time.Time, consumer Consumer) (result ReplayResult, err error) {
+	if consumer == nil {
+		return result, fmt.Errorf("replay consumer required")
+	}
+	if !to.IsZero() && to.Before(from) {
+		return result, fmt.Errorf("replay range end %s is before start %s", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	result = ReplayResult{Stream: stream, From: from, To: to}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	nc, err := natsgo.Connect(s.config.URL, natsgo.Name("cartographus-replay"))
+	if err != nil {
+		return result, fmt.Errorf("connect for replay: %w", err)
+	}
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return result, fmt.Errorf("create JetStream context: %w", err)
+	}
+	str, err := js.Stream(ctx, stream)
+	if err != nil {
+		return result, fmt.Errorf("get stream %s: %w", stream, err)
+	}
+
+	// Stop at the end of the stream as it is now, not at messages published
+	// while the replay runs (those reach DuckDB through live consumption)
+	state := str.CachedInfo().State
+	if state.Msgs == 0 || state.LastTime.Before(from) {
+		return result, nil
+	}
+
+	consCfg := jetstream.OrderedConsumerConfig{DeliverPolicy: jetstream.DeliverAllPolicy}
+	if !from.IsZero() {
+		consCfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		consCfg.OptStartTime = &from
+	}
+	cons, err := str.OrderedConsumer(ctx, consCfg)
+	if err != nil {
+		return result, fmt.Errorf("create replay consumer: %w", err)
+	}
+
+	logging.Info().
+		Str("stream", stream).
+		Time("from", from).
+		Time("to", to).
+		Uint64("last_sequence", state.LastSeq).
+		Msg("Starting event replay")
+
+	unmarshaler := &wmNats.NATSMarshaler{}
+	for done := false; !done; {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		batch, err := cons.Fetch(replayFetchBatch, jetstream.FetchMaxWait(replayFetchWait))
+		if err != nil {
+			return result, fmt.Errorf("fetch replay batch: %w", err)
+		}
+
+		fetched := 0
+		pending := make([]*message.Message, 0, replayFetchBatch)
+		for m := range batch.Messages() {
+			fetched++
+			meta, err := m.Metadata()
+			if err != nil {
+				return result, fmt.Errorf("read replay message metadata: %w", err)
+			}
+			if !to.IsZero() && meta.Timestamp.After(to) {
+				done = true
+				break
+			}
+
+			msg, err := unmarshaler.Unmarshal(&natsgo.Msg{Subject: m.Subject(), Header: m.Headers(), Data: m.Data()})
+			if err != nil {
+				result.Failed++
+				logging.Warn().Err(err).Uint64("sequence", meta.Sequence.Stream).Msg("Skipping unreadable message during replay")
+			} else {
+				if result.FirstSequence == 0 {
+					result.FirstSequence = meta.Sequence.Stream
+				}
+				result.LastSequence = meta.Sequence.Stream
+				result.Delivered++
+				consumer.HandleMessage(ctx, msg)
+				pending = append(pending, msg)
+			}
+
+			if meta.Sequence.Stream >= state.LastSeq || meta.NumPending == 0 {
+				done = true
+				break
+			}
+		}
+		if err := batch.Error(); err != nil {
+			return result, fmt.Errorf("fetch replay batch: %w", err)
+		}
+		if fetched == 0 {
+			done = true
+		}
+
+		if err := settleReplayBatch(ctx, consumer, pending, &result); err != nil {
+			return result, err
+		}
+	}
+
+	logging.Info().
+		Str("stream", stream).
+		Int64("delivered", result.Delivered).
+		Int64("persisted", result.Persisted).
+		Int64("failed", result.Failed).
+		Msg("Event replay completed")
+	return result, nil
+}
+
+// settleReplayBatch flushes the consumer and counts the batch's messages as
+// persisted (acked) or failed.
+func settleReplayBatch(ctx context.Context, consumer Consumer, pending []*message.Message, result *ReplayResult) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	flushErr := consumer.Flush(ctx)
+	for _, msg := range pending {
+		select {
+		case <-msg.Acked():
+			result.Persisted++
+		default:
+			result.Failed++
+		}
+	}
+	if flushErr != nil {
+		return fmt.Errorf("flush replayed events: %w", flushErr)
+	}
+	return nil
+}
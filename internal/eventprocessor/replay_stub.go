@@ -0,0 +1,37 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build !nats
+
+package eventprocessor
+
+import (
+	"context"
+	"time"
+)
+
+// Consumer is a stub for non-NATS builds.
+type Consumer interface {
+	HandleMessage(ctx context.Context, msg interface{})
+	Flush(ctx context.Context) error
+}
+
+// ReplayResult is a stub for non-NATS builds.
+type ReplayResult struct {
+	Stream        string        `json:"stream"`
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to,omitempty"`
+	Delivered     int64         `json:"delivered"`
+	Persisted     int64         `json:"persisted"`
+	Failed        int64         `json:"failed"`
+	FirstSequence uint64        `json:"first_sequence"`
+	LastSequence  uint64        `json:"last_sequence"`
+	Duration      time.Duration `json:"duration"`
+}
+
+// Replay returns ErrNATSNotEnabled in non-NATS builds.
+func (s *Subscriber) Replay(_ context.Context, _ string, _, _ time.Time, _ Consumer) (ReplayResult, error) {
+	return ReplayResult{}, ErrNATSNotEnabled
+}
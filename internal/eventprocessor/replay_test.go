@@ -0,0 +1,207 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package eventprocessor
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+)
+
+// replayTestStore writes events to a plain DuckDB table keyed by event ID,
+// ignoring events already present like the production store.
+type replayTestStore struct {
+	db *sql.DB
+}
+
+func (s *replayTestStore) InsertMediaEvents(ctx context.Context, events []*MediaEvent) error {
+	for _, event := range events {
+		if _, err := s.db.ExecContext(ctx,
+			"INSERT OR IGNORE INTO playback_events (event_id, title) VALUES (?, ?)",
+			event.EventID, event.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *replayTestStore) count(t *testing.T) int {
+	t.Helper()
+	var n int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM playback_events").Scan(&n); err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	return n
+}
+
+// startReplayTestServer starts an embedded JetStream server with the
+// default stream and returns its client URL.
+func startReplayTestServer(t *testing.T) string {
+	t.Helper()
+
+	srv, err := NewEmbeddedServer(&ServerConfig{
+		Host:              "127.0.0.1",
+		Port:              -1, // random
+		StoreDir:          t.TempDir(),
+		JetStreamMaxMem:   64 << 20,
+		JetStreamMaxStore: 256 << 20,
+	})
+	if err != nil {
+		t.Fatalf("NewEmbeddedServer() error = %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	nc, err := natsgo.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	streamCfg := DefaultStreamConfig()
+	streamCfg.MaxBytes = 64 << 20 // within the server's store limit
+	manager, err := NewStreamManager(nc, &streamCfg)
+	if err != nil {
+		t.Fatalf("NewStreamManager() error = %v", err)
+	}
+	if _, err := manager.EnsureStream(context.Background()); err != nil {
+		t.Fatalf("EnsureStream() error = %v", err)
+	}
+	return srv.ClientURL()
+}
+
+// newReplayTestConsumer creates a DuckDBConsumer with a fresh dedup cache
+// writing to store. It is never started; Replay feeds it directly.
+func newReplayTestConsumer(t *testing.T, store EventStore) *DuckDBConsumer {
+	t.Helper()
+	appender, err := NewAppender(store, AppenderConfig{BatchSize: 100, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewAppender() error = %v", err)
+	}
+	t.Cleanup(func() { _ = appender.Close() })
+
+	cfg := DefaultConsumerConfig()
+	consumer, err := NewDuckDBConsumer(NewMockMessageSource(), appender, &cfg)
+	if err != nil {
+		t.Fatalf("NewDuckDBConsumer() error = %v", err)
+	}
+	return consumer
+}
+
+func TestSubscriber_Replay_RebuildsTable(t *testing.T) {
+	url := startReplayTestServer(t)
+	ctx := context.Background()
+
+	db, err := sql.Open("duckdb", "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE playback_events (event_id VARCHAR PRIMARY KEY, title VARCHAR)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	store := &replayTestStore{db: db}
+
+	publisher, err := NewPublisher(DefaultPublisherConfig(url), nil)
+	if err != nil {
+		t.Fatalf("NewPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+
+	publish := func(n int) {
+		for i := 0; i < n; i++ {
+			event := NewMediaEvent(SourcePlex)
+			event.UserID = i + 1
+			event.Username = "user"
+			event.MediaType = MediaTypeMovie
+			event.Title = "Replay Movie"
+			if err := publisher.PublishEvent(ctx, event); err != nil {
+				t.Fatalf("PublishEvent() error = %v", err)
+			}
+		}
+	}
+	publish(3)
+	time.Sleep(50 * time.Millisecond)
+	mid := time.Now()
+	time.Sleep(50 * time.Millisecond)
+	publish(2)
+
+	subCfg := DefaultSubscriberConfig(url)
+	subCfg.StreamName = DefaultStreamConfig().Name
+	subscriber, err := NewSubscriber(&subCfg, nil)
+	if err != nil {
+		t.Fatalf("NewSubscriber() error = %v", err)
+	}
+	defer subscriber.Close()
+
+	// Initial materialization
+	live := newReplayTestConsumer(t, store)
+	result, err := subscriber.Replay(ctx, subCfg.StreamName, time.Time{}, time.Time{}, live)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if result.Delivered != 5 || result.Persisted != 5 || result.Failed != 0 {
+		t.Fatalf("initial Replay() = %+v, want 5 delivered and persisted", result)
+	}
+	if got := store.count(t); got != 5 {
+		t.Fatalf("rows after initial replay = %d, want 5", got)
+	}
+
+	// Corrupt the materialized view
+	if _, err := db.Exec("DELETE FROM playback_events"); err != nil {
+		t.Fatalf("wipe table: %v", err)
+	}
+
+	// A fresh consumer rebuilds the table from the stream
+	result, err = subscriber.Replay(ctx, subCfg.StreamName, time.Time{}, time.Time{}, newReplayTestConsumer(t, store))
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if result.Persisted != 5 || result.FirstSequence != 1 || result.LastSequence != 5 {
+		t.Errorf("rebuild Replay() = %+v, want sequences 1-5 persisted", result)
+	}
+	if got := store.count(t); got != 5 {
+		t.Errorf("rows after rebuild = %d, want 5", got)
+	}
+
+	// Replaying again is idempotent: the consumer that already saw the
+	// events skips them as duplicates
+	result, err = subscriber.Replay(ctx, subCfg.StreamName, time.Time{}, time.Time{}, live)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if result.Persisted != 5 || live.Stats().DuplicatesSkipped != 5 {
+		t.Errorf("repeat Replay() = %+v with %d duplicates, want all 5 skipped", result, live.Stats().DuplicatesSkipped)
+	}
+	if got := store.count(t); got != 5 {
+		t.Errorf("rows after repeat replay = %d, want 5", got)
+	}
+
+	// Only the range after mid
+	result, err = subscriber.Replay(ctx, subCfg.StreamName, mid, time.Now(), newReplayTestConsumer(t, store))
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if result.Delivered != 2 || result.FirstSequence != 4 {
+		t.Errorf("ranged Replay() = %+v, want the last 2 messages", result)
+	}
+}
+
+func TestSubscriber_Replay_InvalidRange(t *testing.T) {
+	subscriber := &Subscriber{}
+	now := time.Now()
+
+	if _, err := subscriber.Replay(context.Background(), "MEDIA_EVENTS", now, now.Add(-time.Hour), newReplayTestConsumer(t, NewMockEventStore())); err == nil {
+		t.Error("Replay() with to before from should fail")
+	}
+	if _, err := subscriber.Replay(context.Background(), "MEDIA_EVENTS", now, time.Time{}, nil); err == nil {
+		t.Error("Replay() without a consumer should fail")
+	}
+}
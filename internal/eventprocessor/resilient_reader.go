@@ -17,6 +17,7 @@ import (
 	gobreaker "github.com/sony/gobreaker/v2"
 
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
 )
 
 // ResilientReaderConfig configures the resilient reader behavior.
@@ -64,6 +65,10 @@ func DefaultResilientReaderConfig(natsURL string) ResilientReaderConfig {
 // ResilientReader provides automatic failover between primary and fallback readers.
 // It uses a circuit breaker to detect primary reader failures and automatically
 // falls back to the Go NATS client when the primary is unavailable.
+//
+// The active backend is logged when it changes and exported as the
+// nats_stream_reader_backend gauge; each read served by the fallback after
+// the primary failed is counted in nats_stream_reader_fallbacks_total.
 type ResilientReader struct {
 	config          ResilientReaderConfig
 	primary         StreamReader // nats_js extension reader (may be nil)
@@ -100,6 +105,11 @@ func NewResilientReader(cfg *ResilientReaderConfig) (*ResilientReader, error) {
 	r.currentReader.Store(ReaderTypeFallback)
 	r.lastQueryTime.Store(time.Time{})
 	r.primaryAvailable.Store(false)
+	metrics.SetNATSStreamReaderBackend(string(ReaderTypeFallback))
+
+	if !cfg.EnablePrimaryReader {
+		logging.Info().Msg("nats_js extension reader disabled, reading streams with the Go NATS client")
+	}
 
 	// Configure circuit breaker for primary reader
 	cbSettings := gobreaker.Settings{
@@ -111,9 +121,13 @@ func NewResilientReader(cfg *ResilientReaderConfig) (*ResilientReader, error) {
 			return counts.ConsecutiveFailures >= cfg.FailureThreshold
 		},
 		OnStateChange: func(name string, from, to gobreaker.State) {
-			// Log state changes for observability
+			logging.Warn().
+				Str("circuit_breaker", name).
+				Str("from", from.String()).
+				Str("to", to.String()).
+				Msg("Stream reader circuit breaker state changed")
 			if to == gobreaker.StateOpen {
-				r.currentReader.Store(ReaderTypeFallback)
+				r.setCurrentReader(ReaderTypeFallback)
 			}
 		},
 	}
@@ -155,16 +169,16 @@ func (r *ResilientReader) Query(ctx context.Context, stream string, opts *QueryO
 			return primary.Query(ctx, stream, opts)
 		})
 		if err == nil {
-			r.currentReader.Store(ReaderTypeNatsJS)
+			r.setCurrentReader(ReaderTypeNatsJS)
 			return result, nil
 		}
 		// Circuit breaker open or primary failed
 		r.errorsTotal.Add(1)
-		r.fallbacksTotal.Add(1)
+		r.recordFallback("query", err)
 	}
 
 	// Use fallback reader
-	r.currentReader.Store(ReaderTypeFallback)
+	r.setCurrentReader(ReaderTypeFallback)
 	result, err := r.fallback.Query(ctx, stream, opts)
 	if err != nil {
 		r.errorsTotal.Add(1)
@@ -194,14 +208,14 @@ func (r *ResilientReader) GetMessage(ctx context.Context, stream string, seq uin
 			return []StreamMessage{*msg}, nil
 		})
 		if err == nil && len(result) > 0 {
-			r.currentReader.Store(ReaderTypeNatsJS)
+			r.setCurrentReader(ReaderTypeNatsJS)
 			return &result[0], nil
 		}
-		r.fallbacksTotal.Add(1)
+		r.recordFallback("get_message", err)
 	}
 
 	// Use fallback reader
-	r.currentReader.Store(ReaderTypeFallback)
+	r.setCurrentReader(ReaderTypeFallback)
 	return r.fallback.GetMessage(ctx, stream, seq)
 }
 
@@ -221,23 +235,34 @@ func (r *ResilientReader) GetLastSequence(ctx context.Context, stream string) (u
 		if err == nil {
 			return seq, nil
 		}
+		r.recordFallback("get_last_sequence", err)
 	}
 
 	// Use fallback reader
 	return r.fallback.GetLastSequence(ctx, stream)
 }
 
-// Health checks the current reader availability.
+// Health probes the backend currently serving reads: the nats_js
+// extension reader while it is active, otherwise the Go NATS client.
 func (r *ResilientReader) Health(ctx context.Context) error {
 	r.mu.RLock()
 	if r.closed {
 		r.mu.RUnlock()
 		return fmt.Errorf("reader is closed")
 	}
+	primary := r.primary
 	r.mu.RUnlock()
 
-	// Check fallback (always required)
-	return r.fallback.Health(ctx)
+	if primary != nil && r.activeReader() == ReaderTypeNatsJS {
+		if err := primary.Health(ctx); err != nil {
+			return fmt.Errorf("%s reader: %w", ReaderTypeNatsJS, err)
+		}
+		return nil
+	}
+	if err := r.fallback.Health(ctx); err != nil {
+		return fmt.Errorf("%s reader: %w", ReaderTypeFallback, err)
+	}
+	return nil
 }
 
 // Close releases all resources.
@@ -271,13 +296,8 @@ func (r *ResilientReader) Stats() ReaderStats {
 	if t, ok := r.lastQueryTime.Load().(time.Time); ok {
 		lastQuery = t
 	}
-	var currentReader ReaderType
-	if rt, ok := r.currentReader.Load().(ReaderType); ok {
-		currentReader = rt
-	}
-
 	return ReaderStats{
-		CurrentReader:       currentReader,
+		CurrentReader:       r.activeReader(),
 		CircuitBreakerState: r.circuitBreaker.State().String(),
 		PrimaryAvailable:    r.primaryAvailable.Load(),
 		QueriesTotal:        r.queriesTotal.Load(),
@@ -291,6 +311,35 @@ func (r *ResilientReader) FallbackCount() int64 {
 	return r.fallbacksTotal.Load()
 }
 
+// activeReader returns the backend that served the most recent read.
+func (r *ResilientReader) activeReader() ReaderType {
+	rt, _ := r.currentReader.Load().(ReaderType)
+	return rt
+}
+
+// setCurrentReader records the backend serving reads, logging and updating
+// the backend gauge when it changes.
+func (r *ResilientReader) setCurrentReader(rt ReaderType) {
+	previous, _ := r.currentReader.Swap(rt).(ReaderType)
+	if previous == rt {
+		return
+	}
+	metrics.SetNATSStreamReaderBackend(string(rt))
+	if rt == ReaderTypeFallback {
+		logging.Warn().Str("from", string(previous)).Msg("Stream reader switched to the Go NATS client fallback")
+	} else {
+		logging.Info().Str("from", string(previous)).Msg("Stream reader switched to the nats_js extension")
+	}
+}
+
+// recordFallback counts a read served by the fallback reader after the
+// primary failed with err.
+func (r *ResilientReader) recordFallback(operation string, err error) {
+	r.fallbacksTotal.Add(1)
+	metrics.RecordNATSStreamReaderFallback(operation)
+	logging.Debug().Err(err).Str("operation", operation).Msg("Primary stream reader failed, using fallback")
+}
+
 // healthCheckLoop periodically checks primary reader availability.
 func (r *ResilientReader) healthCheckLoop() {
 	ticker := time.NewTicker(r.config.HealthCheckInterval)
@@ -0,0 +1,135 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package eventprocessor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// stubPrimaryReader stands in for the nats_js extension reader. While
+// unavailable is set every call fails as if the extension were not loaded.
+type stubPrimaryReader struct {
+	unavailable bool
+}
+
+var errExtensionNotLoaded = errors.New("nats_js extension not loaded")
+
+func (s *stubPrimaryReader) Query(ctx context.Context, stream string, opts *QueryOptions) ([]StreamMessage, error) {
+	if s.unavailable {
+		return nil, errExtensionNotLoaded
+	}
+	return []StreamMessage{{Sequence: 1, Subject: "playback.test"}}, nil
+}
+
+func (s *stubPrimaryReader) GetMessage(ctx context.Context, stream string, seq uint64) (*StreamMessage, error) {
+	if s.unavailable {
+		return nil, errExtensionNotLoaded
+	}
+	return &StreamMessage{Sequence: seq}, nil
+}
+
+func (s *stubPrimaryReader) GetLastSequence(ctx context.Context, stream string) (uint64, error) {
+	if s.unavailable {
+		return 0, errExtensionNotLoaded
+	}
+	return 1, nil
+}
+
+func (s *stubPrimaryReader) Health(ctx context.Context) error {
+	if s.unavailable {
+		return errExtensionNotLoaded
+	}
+	return nil
+}
+
+func (s *stubPrimaryReader) Close() error { return nil }
+
+// newTestResilientReader creates a resilient reader against an embedded
+// server with primary as its nats_js reader.
+func newTestResilientReader(t *testing.T, primary StreamReader) *ResilientReader {
+	t.Helper()
+
+	cfg := DefaultResilientReaderConfig(startReplayTestServer(t))
+	cfg.EnablePrimaryReader = true
+	cfg.HealthCheckInterval = 0 // no background probing
+	reader, err := NewResilientReader(&cfg)
+	if err != nil {
+		t.Fatalf("NewResilientReader() error = %v", err)
+	}
+	t.Cleanup(func() { _ = reader.Close() })
+	reader.SetPrimaryReader(primary)
+	return reader
+}
+
+func TestResilientReader_FallsBackWhenExtensionUnavailable(t *testing.T) {
+	ctx := context.Background()
+	stream := DefaultStreamConfig().Name
+	primary := &stubPrimaryReader{}
+	reader := newTestResilientReader(t, primary)
+
+	// Primary healthy: reads and health checks go to the extension
+	if _, err := reader.Query(ctx, stream, &QueryOptions{}); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if got := reader.Stats().CurrentReader; got != ReaderTypeNatsJS {
+		t.Fatalf("CurrentReader = %q, want %q", got, ReaderTypeNatsJS)
+	}
+	if got := testutil.ToFloat64(metrics.NATSStreamReaderBackend.WithLabelValues("natsjs")); got != 1 {
+		t.Errorf("natsjs backend gauge = %v, want 1", got)
+	}
+
+	// The extension becomes unavailable; the active backend's health probe
+	// reports it before any read falls back
+	primary.unavailable = true
+	if err := reader.Health(ctx); err == nil || !strings.Contains(err.Error(), "natsjs") {
+		t.Errorf("Health() error = %v, want natsjs reader error", err)
+	}
+
+	fallbacksBefore := testutil.ToFloat64(metrics.NATSStreamReaderFallbacks.WithLabelValues("query"))
+	if _, err := reader.Query(ctx, stream, &QueryOptions{}); err != nil {
+		t.Fatalf("Query() after extension failure error = %v", err)
+	}
+
+	if got := reader.Stats().CurrentReader; got != ReaderTypeFallback {
+		t.Errorf("CurrentReader = %q, want %q", got, ReaderTypeFallback)
+	}
+	if got := reader.FallbackCount(); got != 1 {
+		t.Errorf("FallbackCount() = %d, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.NATSStreamReaderFallbacks.WithLabelValues("query")) - fallbacksBefore; got != 1 {
+		t.Errorf("query fallbacks metric increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.NATSStreamReaderBackend.WithLabelValues("fallback")); got != 1 {
+		t.Errorf("fallback backend gauge = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.NATSStreamReaderBackend.WithLabelValues("natsjs")); got != 0 {
+		t.Errorf("natsjs backend gauge = %v, want 0", got)
+	}
+
+	// Health now probes the Go client, which is connected
+	if err := reader.Health(ctx); err != nil {
+		t.Errorf("Health() on fallback error = %v", err)
+	}
+}
+
+func TestResilientReader_HealthReportsClosed(t *testing.T) {
+	reader := newTestResilientReader(t, &stubPrimaryReader{})
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := reader.Health(context.Background()); err == nil {
+		t.Error("Health() on closed reader should return an error")
+	}
+}
@@ -17,6 +17,9 @@ import (
 	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
 	"github.com/ThreeDotsLabs/watermill/message/router/plugin"
 	"github.com/tomtom215/cartographus/internal/cache"
+	"github.com/tomtom215/cartographus/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RouterConfig holds configuration for the Watermill Router.
@@ -151,11 +154,15 @@ func NewRouter(
 	wmRouter.AddPlugin(plugin.SignalsHandler)
 
 	// Add middleware in order (outer to inner):
-	// 1. Recoverer - catch panics and convert to errors
-	// 2. Retry - handle transient failures with backoff
-	// 3. Throttle - rate limiting (if enabled)
-	// 4. Deduplicator - simple dedup (if enabled)
-	// 5. Poison Queue - route permanent failures to DLQ
+	// 1. Tracing - continue the publisher's trace (no-op when tracing is disabled)
+	// 2. Recoverer - catch panics and convert to errors
+	// 3. Retry - handle transient failures with backoff
+	// 4. Throttle - rate limiting (if enabled)
+	// 5. Deduplicator - simple dedup (if enabled)
+	// 6. Poison Queue - route permanent failures to DLQ
+
+	// Tracing: One consumer span per message, covering all retries
+	wmRouter.AddMiddleware(tracingMiddleware)
 
 	// Recoverer: Convert panics to errors
 	wmRouter.AddMiddleware(middleware.Recoverer)
@@ -200,6 +207,35 @@ func NewRouter(
 	return r, nil
 }
 
+// tracingMiddleware starts a consumer span for each message as a child of the
+// trace context in its metadata, and hands the span context to the handler
+// through msg.Context().
+func tracingMiddleware(h message.HandlerFunc) message.HandlerFunc {
+	return func(msg *message.Message) ([]*message.Message, error) {
+		if !tracing.Enabled() {
+			return h(msg)
+		}
+
+		topic := message.SubscribeTopicFromCtx(msg.Context())
+		ctx := tracing.ExtractMetadata(msg.Context(), msg.Metadata)
+		ctx, span := tracing.Start(ctx, "process "+topic,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystemKey.String("nats"),
+				semconv.MessagingOperationTypeProcess,
+				semconv.MessagingDestinationName(topic),
+				semconv.MessagingMessageID(msg.UUID),
+			),
+		)
+		defer span.End()
+		msg.SetContext(ctx)
+
+		produced, err := h(msg)
+		tracing.RecordError(span, err)
+		return produced, err
+	}
+}
+
 // AddHandler registers a handler for processing messages from a topic.
 // The handler function should process the message and return any output messages.
 // Errors trigger retry logic; permanent failures go to the poison queue.
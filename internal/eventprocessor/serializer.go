@@ -33,11 +33,12 @@ func (s *Serializer) Marshal(event *MediaEvent) ([]byte, error) {
 	return data, nil
 }
 
-// Unmarshal converts JSON bytes to an event.
+// Unmarshal converts JSON bytes to an event, migrating older schema
+// versions (see MigrateEvent).
 func (s *Serializer) Unmarshal(data []byte) (*MediaEvent, error) {
-	var event MediaEvent
-	if err := json.Unmarshal(data, &event); err != nil {
-		return nil, fmt.Errorf("unmarshal event: %w", err)
+	event, err := MigrateEvent(data)
+	if err != nil {
+		return nil, err
 	}
 
 	return &event, nil
@@ -67,6 +67,18 @@ func NewSubscriber(cfg *SubscriberConfig, logger watermill.LoggerAdapter) (*Subs
 		autoProvision = false
 	}
 
+	if cfg.DeferredAck {
+		sub, err := newDeferredAckSubscriber(cfg, natsOpts, subOpts, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &Subscriber{
+			subscriber: sub,
+			config:     *cfg,
+			logger:     logger,
+		}, nil
+	}
+
 	wmConfig := wmNats.SubscriberConfig{
 		URL:              cfg.URL,
 		QueueGroupPrefix: cfg.QueueGroup,
@@ -123,7 +123,7 @@ func (p *SyncEventPublisher) PublishPlaybackEvent(ctx context.Context, event *mo
 }
 
 // playbackEventToMediaEvent converts a PlaybackEvent to MediaEvent.
-// This is the inverse of DuckDBStore.mediaEventToPlaybackEvent.
+// This is the inverse of mediaEventToPlaybackEvent.
 //
 //nolint:gocyclo // Data mapping function with many optional fields requires conditional checks
 func (p *SyncEventPublisher) playbackEventToMediaEvent(event *models.PlaybackEvent) *MediaEvent {
@@ -112,9 +112,10 @@ func (p *WALEnabledPublisher) Inner() *SyncEventPublisher {
 // This is used by the WAL recovery and retry loops.
 func (p *WALEnabledPublisher) CreateWALPublisher() wal.Publisher {
 	return wal.PublisherFunc(func(ctx context.Context, entry *wal.Entry) error {
-		// Unmarshal the payload to get the MediaEvent
-		var event MediaEvent
-		if err := entry.UnmarshalPayload(&event); err != nil {
+		// Unmarshal the payload to get the MediaEvent, upgrading entries
+		// written by an older release
+		event, err := MigrateEvent(entry.Payload)
+		if err != nil {
 			return err
 		}
 		return p.inner.publisher.PublishEvent(ctx, &event)
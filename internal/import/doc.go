@@ -31,6 +31,12 @@
 //	       ↓
 //	DuckDB (internal/database)
 //
+// When no EventPublisher is available (standard builds, or NATS disabled),
+// the importer runs in direct mode and inserts each batch through an
+// EventStore (database.DB.InsertPlaybackEventsBatch) instead:
+//
+//	Tautulli SQLite DB → TautulliImporter → DuckDB (internal/database)
+//
 // # Deduplication
 //
 // The importer leverages the existing triple-layer deduplication:
@@ -39,6 +45,9 @@
 //  2. Consumer Level: In-memory cache for EventID, SessionKey, CorrelationKey
 //  3. Database Level: INSERT OR IGNORE on unique indexes
 //
+// Direct mode relies on the database level alone; the correlation keys set by
+// the mappers are the same ones the NATS consumer deduplicates on.
+//
 // This ensures that importing the same database multiple times or importing
 // data that was already synced via the API will not create duplicates.
 //
@@ -52,6 +61,29 @@
 //
 // These tables are joined on the session ID to create complete PlaybackEvent records.
 //
+// # Zip Backups
+//
+// A path ending in .zip is treated as a Tautulli backup archive. Backups can
+// hold several timestamped .db files plus config.ini; the newest SQLite file
+// with the tables above is extracted to a temporary directory, which is
+// removed when the reader closes. If no entry qualifies, the error
+// (ErrNoTautulliDatabase) lists the archive contents.
+//
+// # Jellystat and Playback Reporting
+//
+// JellystatImporter imports Jellyfin history through the same batch, progress,
+// and pause/resume machinery. JellystatReader detects the export format from
+// the file contents:
+//
+//   - Jellystat CSV/TSV export of jf_playback_activity
+//   - Jellystat pg_dump (the jf_playback_activity COPY block)
+//   - Playback Reporting plugin export (headerless TSV or CSV with header)
+//
+// Records are published with source "jellyfin" and the live-sync correlation
+// key format, with Jellyfin user UUIDs resolved through UserResolver, so
+// imported history deduplicates against events synced from the same server.
+// Rows are addressed by their 1-based position in the file for resume.
+//
 // # Progress Tracking
 //
 // Import progress is tracked in BadgerDB for resumability:
@@ -3,27 +3,52 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // https://github.com/tomtom215/cartographus
 
-//go:build nats
-
 package tautulliimport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/tomtom215/cartographus/internal/config"
 	"github.com/tomtom215/cartographus/internal/eventprocessor"
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
+// DefaultProgressBroadcastInterval is how often import progress is broadcast
+// to WebSocket clients while an import is running.
+const DefaultProgressBroadcastInterval = 3 * time.Second
+
+// progressOperation identifies Tautulli imports in sync_progress broadcasts.
+const progressOperation = "tautulli_import"
+
+// sourceOpener opens the record source for an import run from a file path.
+type sourceOpener func(ctx context.Context, path string) (recordSource, error)
+
+// ErrImportCanceled is returned by Import when the import was canceled via Stop.
+var ErrImportCanceled = errors.New("import canceled")
+
+// ErrNoEventSink is returned by Import when neither an EventPublisher nor an
+// EventStore is configured and the import is not a dry run.
+var ErrNoEventSink = errors.New("import requires an event publisher or event store")
+
 // EventPublisher defines the interface for publishing events to NATS.
 type EventPublisher interface {
 	PublishEvent(ctx context.Context, event *eventprocessor.MediaEvent) error
 }
 
+// EventStore defines the interface for writing events directly to the
+// database when no EventPublisher is available. It is implemented by
+// database.DB; events conflicting with a unique index (event ID or
+// correlation key) are skipped and reported as duplicates.
+type EventStore interface {
+	InsertPlaybackEventsBatch(ctx context.Context, events []*models.PlaybackEvent) (inserted, duplicates int, err error)
+}
+
 // ProgressTracker defines the interface for tracking import progress.
 type ProgressTracker interface {
 	// Save persists the current import progress.
@@ -36,94 +61,211 @@ type ProgressTracker interface {
 	Clear(ctx context.Context) error
 }
 
+// ProgressBroadcaster defines the interface for pushing import progress to
+// connected clients. It is implemented by websocket.Hub.
+type ProgressBroadcaster interface {
+	BroadcastSyncProgress(operation, status, correlationID string, progress interface{})
+}
+
 // Importer handles importing Tautulli database files.
+//
+// Events are published to NATS when a publisher is available. Without one
+// (standard builds, or NATS disabled) they are inserted directly through the
+// EventStore set with SetEventStore.
+//
+// Its batch, progress, pause/resume, and broadcast machinery is shared with
+// other history importers (see JellystatImporter) via recordSource.
 type Importer struct {
 	cfg       *config.ImportConfig
 	publisher EventPublisher
+	store     EventStore // direct mode, used when publisher is nil
 	progress  ProgressTracker
 	mapper    *Mapper
 
+	// Source selection
+	operation  string       // sync_progress operation name
+	openSource sourceOpener // opens the default source for Import
+
+	// Progress broadcasting (optional)
+	broadcaster       ProgressBroadcaster
+	broadcastInterval time.Duration
+
 	// State
-	mu       sync.RWMutex
-	running  bool
-	stats    *ImportStats
-	stopChan chan struct{}
+	mu         sync.RWMutex
+	running    bool
+	paused     bool
+	pausedAt   time.Time
+	resumeChan chan struct{}
+	stats      *ImportStats
+	stopChan   chan struct{}
 }
 
 // NewImporter creates a new Tautulli database importer.
+// publisher may be nil, in which case SetEventStore must be called before Import.
 func NewImporter(cfg *config.ImportConfig, publisher EventPublisher, progress ProgressTracker) *Importer {
+	i := newImporterCore(cfg, publisher, progress, progressOperation)
+	i.openSource = i.openTautulliSource
+	return i
+}
+
+// newImporterCore creates an importer without a default source.
+func newImporterCore(cfg *config.ImportConfig, publisher EventPublisher, progress ProgressTracker, operation string) *Importer {
 	return &Importer{
 		cfg:       cfg,
 		publisher: publisher,
 		progress:  progress,
 		mapper:    NewMapper(),
+		operation: operation,
 		stopChan:  make(chan struct{}),
+
+		broadcastInterval: DefaultProgressBroadcastInterval,
 	}
 }
 
+// openTautulliSource opens a Tautulli SQLite database as a record source.
+// The configured date and user filter is applied to every query.
+func (i *Importer) openTautulliSource(_ context.Context, path string) (recordSource, error) {
+	filter, err := newRecordFilter(i.cfg)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := NewSQLiteReader(path)
+	if err != nil {
+		return nil, err
+	}
+	reader.SetFilter(filter)
+	return &tautulliSource{reader: reader, mapper: i.mapper}, nil
+}
+
+// SetEventStore enables direct mode: when the importer has no publisher,
+// events are inserted into store instead of being published to NATS.
+// Must be called before Import.
+func (i *Importer) SetEventStore(store EventStore) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.store = store
+}
+
+// DirectMode reports whether events are inserted directly into the database
+// rather than published to NATS.
+func (i *Importer) DirectMode() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.publisher == nil && i.store != nil
+}
+
+// SetBroadcaster enables periodic progress broadcasts while an import runs.
+// Must be called before Import.
+func (i *Importer) SetBroadcaster(broadcaster ProgressBroadcaster) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.broadcaster = broadcaster
+}
+
 // Import performs the import operation.
 // It reads records from the Tautulli SQLite database, converts them to
 // PlaybackEvents, and publishes them to NATS JetStream.
 func (i *Importer) Import(ctx context.Context) (*ImportStats, error) {
+	return i.run(ctx, i.cfg.DBPath, i.openSource)
+}
+
+// run performs an import of the source at path.
+// Saved progress is only resumed when it was recorded for the same path.
+func (i *Importer) run(ctx context.Context, path string, open sourceOpener) (_ *ImportStats, err error) {
 	i.mu.Lock()
 	if i.running {
 		i.mu.Unlock()
 		return nil, fmt.Errorf("import already in progress")
 	}
+	if i.publisher == nil && i.store == nil && !i.cfg.DryRun {
+		i.mu.Unlock()
+		return nil, ErrNoEventSink
+	}
 	i.running = true
+	i.paused = false
+	i.resumeChan = nil
 	i.stats = &ImportStats{
-		StartTime: time.Now(),
-		DryRun:    i.cfg.DryRun,
+		ImportID:   uuid.New().String(),
+		SourcePath: path,
+		StartTime:  time.Now(),
+		DryRun:     i.cfg.DryRun,
 	}
+	stopChan := i.stopChan
+	broadcaster := i.broadcaster
 	i.mu.Unlock()
 
+	broadcastDone := make(chan struct{})
+	var broadcastWG sync.WaitGroup
+	if broadcaster != nil {
+		broadcastWG.Add(1)
+		go func() {
+			defer broadcastWG.Done()
+			i.broadcastProgress(broadcaster, broadcastDone)
+		}()
+	}
+
 	defer func() {
-		i.mu.Lock()
-		i.running = false
-		i.stats.EndTime = time.Now()
-		i.mu.Unlock()
+		i.finish(ctx, err)
+		close(broadcastDone)
+		broadcastWG.Wait()
 	}()
 
-	// Open SQLite reader
-	reader, err := NewSQLiteReader(i.cfg.DBPath)
+	// Open record source
+	if open == nil {
+		return i.GetStats(), fmt.Errorf("no import source configured")
+	}
+	source, err := open(ctx, path)
 	if err != nil {
 		return i.GetStats(), fmt.Errorf("open database: %w", err)
 	}
 	defer func() {
-		if closeErr := reader.Close(); closeErr != nil {
-			logging.Warn().Err(closeErr).Msg("Error closing SQLite reader")
+		if closeErr := source.Close(); closeErr != nil {
+			logging.Warn().Err(closeErr).Msg("Error closing import source")
 		}
 	}()
 
-	// Get total record count
-	total, err := reader.CountRecords(ctx)
+	// Get total record count (matching the filter, if any)
+	total, err := source.CountRecords(ctx)
 	if err != nil {
 		return i.GetStats(), fmt.Errorf("count records: %w", err)
 	}
+	sourceTotal := total
+	if counter, ok := source.(sourceFilterCounter); ok {
+		if sourceTotal, err = counter.CountAllRecords(ctx); err != nil {
+			return i.GetStats(), fmt.Errorf("count source records: %w", err)
+		}
+	}
 
 	i.mu.Lock()
 	i.stats.TotalRecords = total
+	i.stats.SourceRecords = sourceTotal
 	i.mu.Unlock()
 
-	logging.Info().Int64("total_records", total).Msg("Starting import")
-
-	// Log database statistics
-	if err := i.logDatabaseStats(ctx, reader); err != nil {
-		logging.Warn().Err(err).Msg("Failed to get database stats")
+	logging.Info().
+		Str("operation", i.operation).
+		Int64("total_records", total).
+		Int64("source_records", sourceTotal).
+		Msg("Starting import")
+
+	// Log source statistics
+	if statsSource, ok := source.(sourceStatsLogger); ok {
+		if err := statsSource.LogStats(ctx); err != nil {
+			logging.Warn().Err(err).Msg("Failed to get database stats")
+		}
 	}
 
 	// Determine starting point
 	startID := i.cfg.ResumeFromID
 	if startID == 0 && i.progress != nil {
 		// Try to load previous progress
-		if prevStats, err := i.progress.Load(ctx); err == nil && prevStats != nil {
+		if prevStats, err := i.progress.Load(ctx); err == nil && prevStats != nil && prevStats.resumableFor(path) {
 			startID = prevStats.LastProcessedID
 			logging.Info().Int64("start_id", startID).Msg("Resuming import from record ID")
 		}
 	}
 
 	// Count remaining records
-	remaining, err := reader.CountRecordsSince(ctx, startID)
+	remaining, err := source.CountRecordsSince(ctx, startID)
 	if err != nil {
 		return i.GetStats(), fmt.Errorf("count remaining records: %w", err)
 	}
@@ -131,60 +273,102 @@ func (i *Importer) Import(ctx context.Context) (*ImportStats, error) {
 	logging.Info().Int64("records_to_process", remaining).Int64("start_id", startID).Msg("Records to process")
 
 	// Process all batches
-	if err := i.processAllBatches(ctx, reader, startID); err != nil {
+	if err := i.processAllBatches(ctx, source, startID, stopChan); err != nil {
 		return i.GetStats(), err
 	}
 
+	stats := i.GetStats()
 	logging.Info().
-		Int64("imported", i.stats.Imported).
-		Int64("skipped", i.stats.Skipped).
-		Int64("errors", i.stats.Errors).
-		Dur("duration", i.stats.Duration()).
+		Int64("imported", stats.Imported).
+		Int64("skipped", stats.Skipped).
+		Int64("deduplicated", stats.Deduplicated).
+		Int64("errors", stats.Errors).
+		Dur("duration", stats.Duration()).
 		Msg("Import completed")
 
-	return i.GetStats(), nil
+	return stats, nil
+}
+
+// finish records the terminal state of an import run. When the run was
+// canceled, the final checkpoint is persisted so a later run resumes from
+// the last processed record rather than restarting.
+func (i *Importer) finish(ctx context.Context, err error) {
+	i.mu.Lock()
+	i.running = false
+	i.paused = false
+	i.resumeChan = nil
+	i.stats.Paused = false
+	i.stats.EndTime = time.Now()
+	switch {
+	case errors.Is(err, ErrImportCanceled), errors.Is(err, context.Canceled):
+		i.stats.Canceled = true
+	case err != nil:
+		i.stats.Error = err.Error()
+	}
+	stats := *i.stats
+	i.mu.Unlock()
+
+	if !stats.Canceled || i.progress == nil || i.cfg.DryRun || stats.LastProcessedID == 0 {
+		return
+	}
+
+	// Use a non-canceled context: the import context may be the reason we stopped.
+	if saveErr := i.progress.Save(context.WithoutCancel(ctx), &stats); saveErr != nil {
+		logging.Warn().Err(saveErr).Msg("Failed to save progress checkpoint after cancel")
+		return
+	}
+	logging.Info().
+		Int64("last_processed_id", stats.LastProcessedID).
+		Msg("Import canceled; progress checkpoint retained for resume")
 }
 
 // processAllBatches processes all batches starting from the given ID.
-func (i *Importer) processAllBatches(ctx context.Context, reader *SQLiteReader, startID int64) error {
+// Pause and cancel requests are honored between batches.
+func (i *Importer) processAllBatches(ctx context.Context, source recordSource, startID int64, stopChan <-chan struct{}) error {
 	currentID := startID
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-i.stopChan:
-			return fmt.Errorf("import canceled")
+		case <-stopChan:
+			return ErrImportCanceled
 		default:
 		}
 
+		if err := i.waitIfPaused(ctx, stopChan); err != nil {
+			return err
+		}
+
 		// Read batch
-		records, err := reader.ReadBatch(ctx, currentID, i.cfg.BatchSize)
+		batch, err := source.ReadBatch(ctx, currentID, i.cfg.BatchSize)
 		if err != nil {
 			return fmt.Errorf("read batch: %w", err)
 		}
 
-		if len(records) == 0 {
+		if batch == nil || batch.Records == 0 {
 			return nil // Done
 		}
 
 		// Process batch and update stats
-		currentID = i.processBatchAndUpdateStats(ctx, records)
+		currentID = i.processBatchAndUpdateStats(ctx, batch)
 	}
 }
 
-// processBatchAndUpdateStats processes a batch and updates statistics.
+// processBatchAndUpdateStats publishes a batch and updates statistics.
 // Returns the last processed ID for the next iteration.
-func (i *Importer) processBatchAndUpdateStats(ctx context.Context, records []TautulliRecord) int64 {
-	imported, skipped, errors := i.processBatch(ctx, records)
+func (i *Importer) processBatchAndUpdateStats(ctx context.Context, batch *sourceBatch) int64 {
+	batch.dropInvalidEvents()
+	imported, deduplicated, errors := i.publishEvents(ctx, batch.Events)
 
 	// Update stats
 	i.mu.Lock()
-	i.stats.Processed += int64(len(records))
+	i.stats.Processed += int64(batch.Records)
 	i.stats.Imported += int64(imported)
-	i.stats.Skipped += int64(skipped)
+	i.stats.Skipped += int64(batch.Skipped)
+	i.stats.Deduplicated += int64(deduplicated)
 	i.stats.Errors += int64(errors)
-	lastID := records[len(records)-1].ID
-	i.stats.LastProcessedID = lastID
+	i.stats.LastProcessedID = batch.LastID
+	i.stats.CurrentSessionKey = batch.LastSessionKey
 	stats := *i.stats
 	i.mu.Unlock()
 
@@ -197,35 +381,56 @@ func (i *Importer) processBatchAndUpdateStats(ctx context.Context, records []Tau
 
 	// Log progress
 	logging.Info().
+		Str("operation", i.operation).
 		Float64("progress_percent", stats.Progress()).
 		Int64("processed", stats.Processed).
 		Int64("total_records", stats.TotalRecords).
 		Int64("imported", stats.Imported).
 		Int64("skipped", stats.Skipped).
+		Int64("deduplicated", stats.Deduplicated).
 		Int64("errors", stats.Errors).
 		Float64("records_per_second", stats.RecordsPerSecond()).
 		Msg("Import progress")
 
-	return lastID
+	return batch.LastID
 }
 
-// processBatch processes a batch of records.
-// Returns counts of imported, skipped, and error records.
-func (i *Importer) processBatch(ctx context.Context, records []TautulliRecord) (imported, skipped, errors int) {
-	// Filter valid records
-	validRecords, skipCount := i.mapper.FilterValidRecords(records)
-	skipped = skipCount
+// processBatch processes a batch of Tautulli records.
+// Returns counts of imported, skipped, deduplicated, and error records.
+func (i *Importer) processBatch(ctx context.Context, records []TautulliRecord) (imported, skipped, deduplicated, errors int) {
+	batch := newTautulliBatch(i.mapper, records)
+	batch.dropInvalidEvents()
+	imported, deduplicated, errors = i.publishEvents(ctx, batch.Events)
+	return imported, batch.Skipped, deduplicated, errors
+}
 
-	// Convert to PlaybackEvents
-	events := i.mapper.ToPlaybackEvents(validRecords)
+// publishEvents publishes converted events to NATS, or inserts them directly
+// when no publisher is available (unless dry run).
+// Returns counts of imported, deduplicated, and error events.
+func (i *Importer) publishEvents(ctx context.Context, events []*models.PlaybackEvent) (imported, deduplicated, errors int) {
+	// Drop events whose deterministic ID was already seen in this batch
+	seen := make(map[uuid.UUID]struct{}, len(events))
+	unique := make([]*models.PlaybackEvent, 0, len(events))
 
-	// Publish to NATS (unless dry run)
 	for _, event := range events {
-		if i.cfg.DryRun {
-			imported++
+		if _, dup := seen[event.ID]; dup {
+			deduplicated++
 			continue
 		}
+		seen[event.ID] = struct{}{}
+		unique = append(unique, event)
+	}
 
+	if i.cfg.DryRun {
+		return len(unique), deduplicated, 0
+	}
+
+	if i.publisher == nil {
+		inserted, duplicates, errors := i.insertEvents(ctx, unique)
+		return inserted, deduplicated + duplicates, errors
+	}
+
+	for _, event := range unique {
 		// Convert to MediaEvent for NATS publishing
 		mediaEvent := playbackEventToMediaEvent(event)
 
@@ -237,55 +442,141 @@ func (i *Importer) processBatch(ctx context.Context, records []TautulliRecord) (
 		}
 	}
 
-	return imported, skipped, errors
+	return imported, deduplicated, errors
 }
 
-// logDatabaseStats logs statistics about the source database.
-func (i *Importer) logDatabaseStats(ctx context.Context, reader *SQLiteReader) error {
-	// Get date range
-	earliest, latest, err := reader.GetDateRange(ctx)
-	if err != nil {
-		return err
+// insertEvents writes events straight to the event store (direct mode).
+// The mappers set the same correlation keys the NATS consumer deduplicates
+// on, and the store skips events that conflict with its unique indexes, so
+// events already synced from the API or imported earlier count as duplicates.
+// The batch is atomic: on failure every event counts as an error.
+func (i *Importer) insertEvents(ctx context.Context, events []*models.PlaybackEvent) (inserted, duplicates, errors int) {
+	if len(events) == 0 {
+		return 0, 0, 0
 	}
-	logging.Info().
-		Str("earliest", earliest.Format("2006-01-02")).
-		Str("latest", latest.Format("2006-01-02")).
-		Msg("Import date range")
 
-	// Get user count
-	userCount, err := reader.GetUserStats(ctx)
+	inserted, duplicates, err := i.store.InsertPlaybackEventsBatch(ctx, events)
 	if err != nil {
-		return err
+		logging.Error().Err(err).Int("events", len(events)).Msg("Failed to insert import batch")
+		return 0, 0, len(events)
 	}
-	logging.Info().Int("unique_users", userCount).Msg("Unique users in database")
+	return inserted, duplicates, 0
+}
 
-	// Get media type breakdown
-	mediaTypes, err := reader.GetMediaTypeStats(ctx)
-	if err != nil {
-		return err
+// Stop cancels a running import operation at the next batch boundary.
+// A paused import is canceled immediately. Saved progress is not cleared,
+// so a later import resumes from the last processed record.
+func (i *Importer) Stop() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !i.running {
+		return fmt.Errorf("no import in progress")
 	}
-	for mediaType, count := range mediaTypes {
-		logging.Info().Str("media_type", mediaType).Int("count", count).Msg("Media type statistics")
+
+	close(i.stopChan)
+	i.stopChan = make(chan struct{}) // Reset for next import
+
+	return nil
+}
+
+// Pause suspends a running import at the next batch boundary.
+func (i *Importer) Pause() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !i.running {
+		return fmt.Errorf("no import in progress")
 	}
+	if i.paused {
+		return fmt.Errorf("import already paused")
+	}
+
+	i.paused = true
+	i.pausedAt = time.Now()
+	i.resumeChan = make(chan struct{})
+	i.stats.Paused = true
 
 	return nil
 }
 
-// Stop cancels a running import operation.
-func (i *Importer) Stop() error {
+// Resume continues a paused import.
+func (i *Importer) Resume() error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
 	if !i.running {
 		return fmt.Errorf("no import in progress")
 	}
+	if !i.paused {
+		return fmt.Errorf("import not paused")
+	}
 
-	close(i.stopChan)
-	i.stopChan = make(chan struct{}) // Reset for next import
+	close(i.resumeChan)
+	i.resumeChan = nil
+	i.paused = false
+	i.stats.Paused = false
+	i.stats.PausedDuration += time.Since(i.pausedAt)
 
 	return nil
 }
 
+// IsPaused returns whether the running import is paused.
+func (i *Importer) IsPaused() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.paused
+}
+
+// waitIfPaused blocks while the import is paused.
+// Returns an error if the import is canceled or the context is done while waiting.
+func (i *Importer) waitIfPaused(ctx context.Context, stopChan <-chan struct{}) error {
+	i.mu.RLock()
+	paused := i.paused
+	resumeChan := i.resumeChan
+	i.mu.RUnlock()
+
+	if !paused {
+		return nil
+	}
+
+	logging.Info().Msg("Import paused")
+	select {
+	case <-resumeChan:
+		logging.Info().Msg("Import resumed")
+		return nil
+	case <-stopChan:
+		return ErrImportCanceled
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcastProgress periodically broadcasts import progress until done is closed,
+// then sends a final broadcast with the terminal status.
+func (i *Importer) broadcastProgress(broadcaster ProgressBroadcaster, done <-chan struct{}) {
+	ticker := time.NewTicker(i.broadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			i.broadcastSummary(broadcaster)
+		case <-done:
+			i.broadcastSummary(broadcaster)
+			return
+		}
+	}
+}
+
+// broadcastSummary sends a single progress snapshot to the broadcaster.
+func (i *Importer) broadcastSummary(broadcaster ProgressBroadcaster) {
+	running := i.IsRunning()
+	stats := i.GetStats()
+	summary := stats.ToSummary(running)
+	broadcaster.BroadcastSyncProgress(i.operation, summary.Status, stats.ImportID, summary)
+}
+
 // GetStats returns the current import statistics.
 func (i *Importer) GetStats() *ImportStats {
 	i.mu.RLock()
@@ -307,6 +598,95 @@ func (i *Importer) IsRunning() bool {
 	return i.running
 }
 
+// VerifyImport compares the saved import progress for the configured
+// database against the database itself. It reports how far the last
+// processed record ID trails the highest source ID and how many source
+// records have not been processed, so a migration can be confirmed
+// complete before the source is decommissioned.
+func (i *Importer) VerifyImport(ctx context.Context) (*VerifyResult, error) {
+	if i.IsRunning() {
+		return nil, fmt.Errorf("import in progress")
+	}
+	if i.openSource == nil {
+		return nil, fmt.Errorf("no import source configured")
+	}
+
+	path := i.cfg.DBPath
+	source, err := i.openSource(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer func() {
+		if closeErr := source.Close(); closeErr != nil {
+			logging.Warn().Err(closeErr).Msg("Error closing import source")
+		}
+	}()
+
+	idRange, ok := source.(sourceIDRange)
+	if !ok {
+		return nil, fmt.Errorf("import source does not support verification")
+	}
+
+	result := &VerifyResult{SourcePath: path}
+	if result.SourceRecords, err = source.CountRecords(ctx); err != nil {
+		return nil, fmt.Errorf("count records: %w", err)
+	}
+	if result.SourceMaxID, err = idRange.MaxRecordID(ctx); err != nil {
+		return nil, err
+	}
+
+	saved, err := i.savedProgress(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if saved != nil {
+		result.HasProgress = true
+		result.LastProcessedID = saved.LastProcessedID
+		result.Imported = saved.Imported
+		result.Skipped = saved.Skipped
+		result.Deduplicated = saved.Deduplicated
+		result.Errors = saved.Errors
+	}
+
+	if result.MissingRecords, err = source.CountRecordsSince(ctx, result.LastProcessedID); err != nil {
+		return nil, fmt.Errorf("count remaining records: %w", err)
+	}
+	result.IDGap = result.SourceMaxID - result.LastProcessedID
+	result.ProcessedRecords = result.SourceRecords - result.MissingRecords
+	result.Complete = result.HasProgress && result.MissingRecords == 0 && result.Errors == 0
+
+	logging.Info().
+		Str("operation", i.operation).
+		Int64("source_records", result.SourceRecords).
+		Int64("source_max_id", result.SourceMaxID).
+		Int64("last_processed_id", result.LastProcessedID).
+		Int64("missing_records", result.MissingRecords).
+		Bool("complete", result.Complete).
+		Msg("Import verified")
+
+	return result, nil
+}
+
+// savedProgress returns the progress recorded for an import of path, or nil
+// if there is none. Without a progress tracker, the stats of the last
+// non-dry run in this process are used.
+func (i *Importer) savedProgress(ctx context.Context, path string) (*ImportStats, error) {
+	var saved *ImportStats
+	if i.progress != nil {
+		var err error
+		if saved, err = i.progress.Load(ctx); err != nil {
+			return nil, err
+		}
+	} else if stats := i.GetStats(); !stats.StartTime.IsZero() && !stats.DryRun {
+		saved = stats
+	}
+
+	if saved == nil || !saved.resumableFor(path) {
+		return nil, nil
+	}
+	return saved, nil
+}
+
 // playbackEventToMediaEvent converts a PlaybackEvent to a MediaEvent for NATS publishing.
 func playbackEventToMediaEvent(pe *models.PlaybackEvent) *eventprocessor.MediaEvent {
 	me := &eventprocessor.MediaEvent{
@@ -327,6 +707,9 @@ func playbackEventToMediaEvent(pe *models.PlaybackEvent) *eventprocessor.MediaEv
 	}
 
 	// Optional fields
+	if pe.ServerID != nil {
+		me.ServerID = *pe.ServerID
+	}
 	if pe.CorrelationKey != nil {
 		me.CorrelationKey = *pe.CorrelationKey
 	}
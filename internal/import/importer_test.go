@@ -3,13 +3,12 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // https://github.com/tomtom215/cartographus
 
-//go:build nats
-
 package tautulliimport
 
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -20,6 +19,7 @@ import (
 	_ "github.com/duckdb/duckdb-go/v2"
 	"github.com/tomtom215/cartographus/internal/config"
 	"github.com/tomtom215/cartographus/internal/eventprocessor"
+	"github.com/tomtom215/cartographus/internal/models"
 )
 
 // --- Mock Implementations ---
@@ -245,9 +245,9 @@ func assertImportCompleted(t *testing.T, stats *ImportStats, err error) {
 		t.Error("StartTime should be set")
 	}
 	// Verify processed count matches total
-	if stats.Imported+stats.Skipped+stats.Errors != stats.Processed {
-		t.Errorf("Imported (%d) + Skipped (%d) + Errors (%d) != Processed (%d)",
-			stats.Imported, stats.Skipped, stats.Errors, stats.Processed)
+	if stats.Imported+stats.Skipped+stats.Deduplicated+stats.Errors != stats.Processed {
+		t.Errorf("Imported (%d) + Skipped (%d) + Deduplicated (%d) + Errors (%d) != Processed (%d)",
+			stats.Imported, stats.Skipped, stats.Deduplicated, stats.Errors, stats.Processed)
 	}
 }
 
@@ -694,7 +694,7 @@ func TestImporter_processBatch(t *testing.T) {
 			},
 		}
 
-		imported, skipped, errors := importer.processBatch(context.Background(), records)
+		imported, skipped, _, errors := importer.processBatch(context.Background(), records)
 
 		if imported != 2 {
 			t.Errorf("imported = %d, want 2", imported)
@@ -735,7 +735,7 @@ func TestImporter_processBatch(t *testing.T) {
 			},
 		}
 
-		imported, skipped, errors := importer.processBatch(context.Background(), records)
+		imported, skipped, _, errors := importer.processBatch(context.Background(), records)
 
 		if imported != 1 {
 			t.Errorf("imported = %d, want 1", imported)
@@ -770,7 +770,7 @@ func TestImporter_processBatch(t *testing.T) {
 			},
 		}
 
-		imported, _, errors := importer.processBatch(context.Background(), records)
+		imported, _, _, errors := importer.processBatch(context.Background(), records)
 
 		if imported != 0 {
 			t.Errorf("imported = %d, want 0 (publish failed)", imported)
@@ -802,7 +802,7 @@ func TestImporter_processBatch(t *testing.T) {
 			},
 		}
 
-		imported, _, errors := importer.processBatch(context.Background(), records)
+		imported, _, _, errors := importer.processBatch(context.Background(), records)
 
 		if imported != 1 {
 			t.Errorf("imported = %d, want 1 (counted in dry run)", imported)
@@ -1034,3 +1034,491 @@ func insertTestRecordsWithKnownData(t *testing.T, dbPath string) {
 
 	detachSQLiteDB(t, db, ctx, "tautulli")
 }
+
+// mockProgressBroadcaster records progress broadcasts.
+type mockProgressBroadcaster struct {
+	mu       sync.Mutex
+	statuses []string
+	ids      []string
+}
+
+func (m *mockProgressBroadcaster) BroadcastSyncProgress(operation, status, correlationID string, _ interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if operation != progressOperation {
+		return
+	}
+	m.statuses = append(m.statuses, status)
+	m.ids = append(m.ids, correlationID)
+}
+
+func (m *mockProgressBroadcaster) snapshot() (statuses, ids []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.statuses...), append([]string(nil), m.ids...)
+}
+
+func TestImporter_processBatch_Deduplicates(t *testing.T) {
+	// processBatch never touches the source database, so no SQLite file is needed.
+	publisher := newMockEventPublisher()
+	importer := NewImporter(createImportConfig(""), publisher, nil)
+
+	started := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	record := TautulliRecord{
+		SessionKey:      "dup-session",
+		StartedAt:       started,
+		UserID:          1,
+		Username:        "user1",
+		IPAddress:       "192.168.1.1",
+		MediaType:       "movie",
+		Title:           "Test Movie",
+		Platform:        "Chrome",
+		Player:          "Plex Web",
+		PercentComplete: 100,
+	}
+	first, second := record, record
+	first.ID, second.ID = 1, 2
+
+	imported, skipped, deduplicated, errs := importer.processBatch(context.Background(), []TautulliRecord{first, second})
+
+	if imported != 1 || skipped != 0 || deduplicated != 1 || errs != 0 {
+		t.Errorf("processBatch() = (%d, %d, %d, %d), want (1, 0, 1, 0)", imported, skipped, deduplicated, errs)
+	}
+	if got := len(publisher.getEvents()); got != 1 {
+		t.Errorf("published %d events, want 1", got)
+	}
+}
+
+func TestImporter_PauseResume(t *testing.T) {
+	setup := setupImporter(t, 20, withBatchSize(1), withPublishDelay(5*time.Millisecond))
+	defer setup.cleanup()
+
+	t.Run("pause and resume require a running import", func(t *testing.T) {
+		if err := setup.importer.Pause(); err == nil {
+			t.Error("Pause() should fail when no import is running")
+		}
+		if err := setup.importer.Resume(); err == nil {
+			t.Error("Resume() should fail when no import is running")
+		}
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := setup.importer.Import(context.Background())
+		errCh <- err
+	}()
+	waitForRunning(t, setup.importer, 5*time.Second)
+
+	if err := setup.importer.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := setup.importer.Pause(); err == nil {
+		t.Error("second Pause() should fail")
+	}
+	if !setup.importer.IsPaused() {
+		t.Error("IsPaused() should be true after Pause()")
+	}
+
+	// Let the in-flight batch finish, then verify no further progress is made.
+	time.Sleep(50 * time.Millisecond)
+	before := setup.importer.GetStats().Processed
+	time.Sleep(100 * time.Millisecond)
+	if after := setup.importer.GetStats().Processed; after != before {
+		t.Errorf("Processed advanced while paused: %d -> %d", before, after)
+	}
+	if status := setup.importer.GetStats().ToSummary(true).Status; status != "paused" {
+		t.Errorf("Status = %s, want paused", status)
+	}
+
+	if err := setup.importer.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Import didn't finish after Resume()")
+	}
+
+	stats := setup.importer.GetStats()
+	if stats.Processed != 20 {
+		t.Errorf("Processed = %d, want 20", stats.Processed)
+	}
+	if stats.PausedDuration <= 0 {
+		t.Error("PausedDuration should be recorded")
+	}
+}
+
+func TestImporter_CancelRetainsCheckpoint(t *testing.T) {
+	setup := setupImporter(t, 20, withBatchSize(1), withPublishDelay(5*time.Millisecond))
+	defer setup.cleanup()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := setup.importer.Import(context.Background())
+		errCh <- err
+	}()
+	waitForRunning(t, setup.importer, 5*time.Second)
+
+	// Cancel while paused to verify a paused import can still be canceled.
+	if err := setup.importer.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := setup.importer.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, ErrImportCanceled) {
+			t.Fatalf("Import() error = %v, want ErrImportCanceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Import didn't stop after Stop()")
+	}
+
+	stats := setup.importer.GetStats()
+	if !stats.Canceled {
+		t.Error("Canceled should be set")
+	}
+	if status := stats.ToSummary(false).Status; status != "canceled" {
+		t.Errorf("Status = %s, want canceled", status)
+	}
+
+	saved := setup.progress.getStats()
+	if saved == nil {
+		t.Fatal("progress checkpoint should be retained after cancel")
+	}
+	if saved.LastProcessedID != stats.LastProcessedID || saved.LastProcessedID == 0 {
+		t.Errorf("saved LastProcessedID = %d, want %d", saved.LastProcessedID, stats.LastProcessedID)
+	}
+
+	// A new run resumes from the checkpoint rather than restarting.
+	resumed := NewImporter(setup.cfg, newMockEventPublisher(), setup.progress)
+	resumedStats, err := resumed.Import(context.Background())
+	if err != nil {
+		t.Fatalf("resumed Import() error = %v", err)
+	}
+	if resumedStats.Processed+stats.Processed != 20 {
+		t.Errorf("resumed Processed = %d, want %d", resumedStats.Processed, 20-stats.Processed)
+	}
+}
+
+func TestImporter_BroadcastsProgress(t *testing.T) {
+	setup := setupImporter(t, 10, withBatchSize(1), withPublishDelay(5*time.Millisecond))
+	defer setup.cleanup()
+
+	broadcaster := &mockProgressBroadcaster{}
+	setup.importer.SetBroadcaster(broadcaster)
+	setup.importer.broadcastInterval = 10 * time.Millisecond
+
+	stats, err := setup.importer.Import(context.Background())
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	statuses, ids := broadcaster.snapshot()
+	if len(statuses) < 2 {
+		t.Fatalf("expected periodic and final broadcasts, got %d", len(statuses))
+	}
+	if statuses[0] != "running" {
+		t.Errorf("first status = %s, want running", statuses[0])
+	}
+	if last := statuses[len(statuses)-1]; last != "completed" {
+		t.Errorf("final status = %s, want completed", last)
+	}
+	for _, id := range ids {
+		if id != stats.ImportID {
+			t.Errorf("correlation ID = %s, want %s", id, stats.ImportID)
+		}
+	}
+}
+
+// fakeIDSource is an in-memory recordSource holding records with the given IDs.
+type fakeIDSource struct {
+	ids []int64
+}
+
+func (s *fakeIDSource) CountRecords(_ context.Context) (int64, error) {
+	return int64(len(s.ids)), nil
+}
+
+func (s *fakeIDSource) CountRecordsSince(_ context.Context, sinceID int64) (int64, error) {
+	var count int64
+	for _, id := range s.ids {
+		if id > sinceID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *fakeIDSource) ReadBatch(_ context.Context, sinceID int64, limit int) (*sourceBatch, error) {
+	batch := &sourceBatch{}
+	for _, id := range s.ids {
+		if id > sinceID && batch.Records < limit {
+			batch.Records++
+			batch.Skipped++
+			batch.LastID = id
+		}
+	}
+	return batch, nil
+}
+
+func (s *fakeIDSource) MaxRecordID(_ context.Context) (int64, error) {
+	if len(s.ids) == 0 {
+		return 0, nil
+	}
+	return s.ids[len(s.ids)-1], nil
+}
+
+func (s *fakeIDSource) Close() error { return nil }
+
+func TestImporter_VerifyImport(t *testing.T) {
+	// IDs are sparse, as in a Tautulli database with deleted history
+	source := &fakeIDSource{ids: []int64{1, 2, 3, 5, 8, 13, 21}}
+	cfg := createImportConfig("/data/tautulli.db")
+	progress := newMockProgressTracker()
+	importer := NewImporter(cfg, newMockEventPublisher(), progress)
+	importer.openSource = func(context.Context, string) (recordSource, error) { return source, nil }
+
+	// No progress yet: everything is missing
+	result, err := importer.VerifyImport(context.Background())
+	if err != nil {
+		t.Fatalf("VerifyImport() error = %v", err)
+	}
+	if result.HasProgress || result.Complete || result.MissingRecords != 7 || result.IDGap != 21 {
+		t.Errorf("before import: %+v", result)
+	}
+
+	// An import that silently stopped at ID 5
+	progress.setStats(&ImportStats{
+		SourcePath:      cfg.DBPath,
+		LastProcessedID: 5,
+		Processed:       4,
+		Imported:        4,
+		StartTime:       time.Now(),
+	})
+	result, err = importer.VerifyImport(context.Background())
+	if err != nil {
+		t.Fatalf("VerifyImport() error = %v", err)
+	}
+	if result.Complete {
+		t.Error("Complete = true for a partial import")
+	}
+	if result.SourceMaxID != 21 || result.LastProcessedID != 5 || result.IDGap != 16 {
+		t.Errorf("max ID = %d, last processed = %d, gap = %d; want 21, 5, 16",
+			result.SourceMaxID, result.LastProcessedID, result.IDGap)
+	}
+	if result.SourceRecords != 7 || result.ProcessedRecords != 4 || result.MissingRecords != 3 {
+		t.Errorf("source = %d, processed = %d, missing = %d; want 7, 4, 3",
+			result.SourceRecords, result.ProcessedRecords, result.MissingRecords)
+	}
+
+	// Progress for another database does not count
+	progress.setStats(&ImportStats{SourcePath: "/data/other.db", LastProcessedID: 21, StartTime: time.Now()})
+	if result, _ = importer.VerifyImport(context.Background()); result.HasProgress {
+		t.Error("progress for a different source path was used")
+	}
+
+	// Resuming the import finishes it
+	progress.setStats(&ImportStats{SourcePath: cfg.DBPath, LastProcessedID: 5, StartTime: time.Now()})
+	if _, err := importer.Import(context.Background()); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	result, err = importer.VerifyImport(context.Background())
+	if err != nil {
+		t.Fatalf("VerifyImport() error = %v", err)
+	}
+	if !result.Complete || result.IDGap != 0 || result.MissingRecords != 0 || result.ProcessedRecords != 7 {
+		t.Errorf("after resume: %+v", result)
+	}
+}
+
+// fakeFilteredSource is a fakeIDSource holding the records that matched a
+// filter out of a larger source.
+type fakeFilteredSource struct {
+	fakeIDSource
+	all int64
+}
+
+func (s *fakeFilteredSource) CountAllRecords(_ context.Context) (int64, error) {
+	return s.all, nil
+}
+
+func TestImporter_Import_ReportsFilteredRecords(t *testing.T) {
+	source := &fakeFilteredSource{fakeIDSource: fakeIDSource{ids: []int64{4, 9, 12}}, all: 40}
+	importer := NewImporter(createImportConfig("/data/tautulli.db"), newMockEventPublisher(), nil)
+	importer.openSource = func(context.Context, string) (recordSource, error) { return source, nil }
+
+	stats, err := importer.Import(context.Background())
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if stats.TotalRecords != 3 || stats.SourceRecords != 40 || stats.Processed != 3 {
+		t.Errorf("total = %d, source = %d, processed = %d; want 3, 40, 3",
+			stats.TotalRecords, stats.SourceRecords, stats.Processed)
+	}
+	if summary := stats.ToSummary(false); summary.Progress != 100 || summary.SourceRecords != 40 {
+		t.Errorf("summary progress = %.0f, source records = %d; want 100, 40", summary.Progress, summary.SourceRecords)
+	}
+}
+
+func TestNewRecordFilter(t *testing.T) {
+	cfg := &config.ImportConfig{DateFrom: "2026-03-01", DateTo: "2026-03-31", Users: []string{"alice"}}
+	filter, err := newRecordFilter(cfg)
+	if err != nil {
+		t.Fatalf("newRecordFilter() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local); !filter.From.Equal(want) {
+		t.Errorf("From = %v, want %v", filter.From, want)
+	}
+	// DateTo is inclusive
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local); !filter.To.Equal(want) {
+		t.Errorf("To = %v, want %v", filter.To, want)
+	}
+	if len(filter.Users) != 1 || filter.Users[0] != "alice" {
+		t.Errorf("Users = %v", filter.Users)
+	}
+
+	if filter, err = newRecordFilter(&config.ImportConfig{}); err != nil || !filter.IsZero() {
+		t.Errorf("newRecordFilter() of unfiltered config = %+v, %v; want zero filter", filter, err)
+	}
+	if _, err = newRecordFilter(&config.ImportConfig{DateFrom: "March"}); err == nil {
+		t.Error("newRecordFilter() accepted an invalid date")
+	}
+}
+
+// mockEventStore is a test double for EventStore. Like the database's unique
+// index, it skips events whose correlation key was already inserted.
+type mockEventStore struct {
+	mu        sync.Mutex
+	keys      map[string]struct{}
+	batches   int
+	insertErr error
+}
+
+func newMockEventStore() *mockEventStore {
+	return &mockEventStore{keys: make(map[string]struct{})}
+}
+
+func (m *mockEventStore) InsertPlaybackEventsBatch(_ context.Context, events []*models.PlaybackEvent) (inserted, duplicates int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches++
+	if m.insertErr != nil {
+		return 0, 0, m.insertErr
+	}
+	for _, event := range events {
+		if _, dup := m.keys[*event.CorrelationKey]; dup {
+			duplicates++
+			continue
+		}
+		m.keys[*event.CorrelationKey] = struct{}{}
+		inserted++
+	}
+	return inserted, duplicates, nil
+}
+
+// directModeRecords returns two distinct Tautulli records.
+func directModeRecords() []TautulliRecord {
+	started := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	records := make([]TautulliRecord, 2)
+	for i := range records {
+		records[i] = TautulliRecord{
+			ID:              int64(i + 1),
+			SessionKey:      fmt.Sprintf("direct-session-%d", i),
+			StartedAt:       started.Add(time.Duration(i) * time.Hour),
+			UserID:          1,
+			Username:        "user1",
+			IPAddress:       "192.168.1.1",
+			MediaType:       "movie",
+			Title:           fmt.Sprintf("Movie %d", i),
+			Platform:        "Chrome",
+			Player:          "Plex Web",
+			PercentComplete: 100,
+		}
+	}
+	return records
+}
+
+func TestImporter_DirectMode(t *testing.T) {
+	store := newMockEventStore()
+	importer := NewImporter(createImportConfig(""), nil, nil)
+	importer.SetEventStore(store)
+
+	if !importer.DirectMode() {
+		t.Fatal("DirectMode() = false without a publisher")
+	}
+
+	imported, skipped, deduplicated, errs := importer.processBatch(context.Background(), directModeRecords())
+	if imported != 2 || skipped != 0 || deduplicated != 0 || errs != 0 {
+		t.Errorf("processBatch() = (%d, %d, %d, %d), want (2, 0, 0, 0)", imported, skipped, deduplicated, errs)
+	}
+
+	// Importing the same records again is deduplicated on their correlation keys
+	imported, _, deduplicated, errs = importer.processBatch(context.Background(), directModeRecords())
+	if imported != 0 || deduplicated != 2 || errs != 0 {
+		t.Errorf("re-import = (%d imported, %d deduplicated, %d errors), want (0, 2, 0)", imported, deduplicated, errs)
+	}
+	if store.batches != 2 {
+		t.Errorf("store received %d batches, want 2 (one insert per batch)", store.batches)
+	}
+}
+
+func TestImporter_DirectMode_InsertError(t *testing.T) {
+	store := newMockEventStore()
+	store.insertErr = errors.New("database locked")
+	importer := NewImporter(createImportConfig(""), nil, nil)
+	importer.SetEventStore(store)
+
+	// The batch insert is atomic, so every event counts as an error
+	imported, _, _, errs := importer.processBatch(context.Background(), directModeRecords())
+	if imported != 0 || errs != 2 {
+		t.Errorf("processBatch() imported %d, errors %d; want 0, 2", imported, errs)
+	}
+}
+
+func TestImporter_PublisherTakesPrecedence(t *testing.T) {
+	publisher := newMockEventPublisher()
+	store := newMockEventStore()
+	importer := NewImporter(createImportConfig(""), publisher, nil)
+	importer.SetEventStore(store)
+
+	if importer.DirectMode() {
+		t.Error("DirectMode() = true with a publisher")
+	}
+	importer.processBatch(context.Background(), directModeRecords())
+	if got := len(publisher.getEvents()); got != 2 || store.batches != 0 {
+		t.Errorf("published %d events and inserted %d batches, want 2 and 0", got, store.batches)
+	}
+}
+
+func TestImporter_Import_RequiresEventSink(t *testing.T) {
+	importer := NewImporter(createImportConfig("/data/tautulli.db"), nil, nil)
+	importer.openSource = func(context.Context, string) (recordSource, error) {
+		return &fakeIDSource{ids: []int64{1}}, nil
+	}
+
+	if _, err := importer.Import(context.Background()); !errors.Is(err, ErrNoEventSink) {
+		t.Errorf("Import() error = %v, want ErrNoEventSink", err)
+	}
+	if importer.IsRunning() {
+		t.Error("importer still running after rejected import")
+	}
+
+	// A dry run needs neither
+	cfg := createImportConfig("/data/tautulli.db")
+	cfg.DryRun = true
+	importer = NewImporter(cfg, nil, nil)
+	importer.openSource = func(context.Context, string) (recordSource, error) {
+		return &fakeIDSource{ids: []int64{1}}, nil
+	}
+	if _, err := importer.Import(context.Background()); err != nil {
+		t.Errorf("dry run Import() error = %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+// jellystatProgressOperation identifies Jellystat imports in sync_progress broadcasts.
+const jellystatProgressOperation = "jellystat_import"
+
+// ErrImportFileRequired is returned by JellystatImporter.Import, which has no
+// configured source; use ImportFile instead.
+var ErrImportFileRequired = errors.New("jellystat import requires an export file")
+
+// JellystatImportOptions configures a single Jellystat import run.
+type JellystatImportOptions struct {
+	// ServerID is the Jellyfin server the export was taken from. It should
+	// match the configured server's server_id so imported events deduplicate
+	// against live syncs. Empty uses "default".
+	ServerID string
+}
+
+// JellystatImporter imports Jellyfin playback history from Jellystat exports
+// (CSV or pg_dump) and Playback Reporting plugin exports.
+//
+// It shares the batch, progress, pause/resume, and broadcast machinery of
+// Importer; only the record source differs.
+type JellystatImporter struct {
+	*Importer
+	resolver UserResolver
+}
+
+// NewJellystatImporter creates a new Jellystat importer.
+// progress should be keyed separately from the Tautulli importer's tracker
+// (see NewBadgerProgressWithKey) so the two do not overwrite each other.
+func NewJellystatImporter(cfg *config.ImportConfig, publisher EventPublisher, progress ProgressTracker) *JellystatImporter {
+	// ResumeFromID refers to Tautulli session IDs and does not apply to export rows.
+	coreCfg := *cfg
+	coreCfg.ResumeFromID = 0
+
+	return &JellystatImporter{
+		Importer: newImporterCore(&coreCfg, publisher, progress, jellystatProgressOperation),
+	}
+}
+
+// SetUserResolver enables mapping Jellyfin user UUIDs to internal user IDs.
+// Must be called before ImportFile.
+func (j *JellystatImporter) SetUserResolver(resolver UserResolver) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.resolver = resolver
+}
+
+// Import always fails with ErrImportFileRequired; Jellystat imports are
+// started on demand with ImportFile.
+func (j *JellystatImporter) Import(_ context.Context) (*ImportStats, error) {
+	return nil, ErrImportFileRequired
+}
+
+// ImportFile imports the export at path. The format is detected from the
+// file contents. Saved progress is resumed when it was recorded for the
+// same path.
+func (j *JellystatImporter) ImportFile(ctx context.Context, path string, opts JellystatImportOptions) (*ImportStats, error) {
+	j.mu.RLock()
+	resolver := j.resolver
+	j.mu.RUnlock()
+
+	return j.run(ctx, path, func(_ context.Context, path string) (recordSource, error) {
+		reader, err := NewJellystatReader(path)
+		if err != nil {
+			return nil, err
+		}
+		return newJellystatSource(reader, opts.ServerID, resolver), nil
+	})
+}
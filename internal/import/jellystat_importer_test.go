@@ -0,0 +1,124 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+// mockUserResolver is a test double for UserResolver.
+type mockUserResolver struct {
+	mu    sync.Mutex
+	calls map[string]int
+	err   error
+}
+
+func (m *mockUserResolver) ResolveUserID(_ context.Context, source, serverID, externalUserID string, _, _ *string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return 0, m.err
+	}
+	if source != "jellyfin" || serverID != "jf-1" {
+		return 0, errors.New("unexpected source or server")
+	}
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[externalUserID]++
+	if externalUserID == "user-a" {
+		return 100, nil
+	}
+	return 200, nil
+}
+
+func TestJellystatImporter_ImportFile(t *testing.T) {
+	publisher := newMockEventPublisher()
+	progress := newMockProgressTracker()
+	resolver := &mockUserResolver{}
+
+	importer := NewJellystatImporter(&config.ImportConfig{BatchSize: 1}, publisher, progress)
+	importer.SetUserResolver(resolver)
+
+	path := writeJellystatFixture(t, "activity.csv", jellystatCSVFixture)
+	stats, err := importer.ImportFile(context.Background(), path, JellystatImportOptions{ServerID: "jf-1"})
+	if err != nil {
+		t.Fatalf("ImportFile() error = %v", err)
+	}
+
+	if stats.TotalRecords != 2 || stats.Imported != 2 || stats.LastProcessedID != 2 {
+		t.Errorf("stats = total %d, imported %d, last %d; want 2, 2, 2",
+			stats.TotalRecords, stats.Imported, stats.LastProcessedID)
+	}
+
+	events := publisher.getEvents()
+	if len(events) != 2 {
+		t.Fatalf("published %d events, want 2", len(events))
+	}
+	first := events[0]
+	if first.Source != "jellyfin" || first.ServerID != "jf-1" || first.UserID != 100 {
+		t.Errorf("event source/server/user = %q/%q/%d", first.Source, first.ServerID, first.UserID)
+	}
+	if first.CorrelationKey != "jellyfin:jf-1:100:ep-1:dev-1:2024-01-15T10:00:00:sess-1" {
+		t.Errorf("CorrelationKey = %q", first.CorrelationKey)
+	}
+	if events[1].UserID != 200 {
+		t.Errorf("second event UserID = %d, want 200", events[1].UserID)
+	}
+
+	if saved := progress.getStats(); saved == nil || saved.SourcePath != path {
+		t.Errorf("saved progress = %+v, want SourcePath %s", saved, path)
+	}
+}
+
+func TestJellystatImporter_ResumesSameFileOnly(t *testing.T) {
+	publisher := newMockEventPublisher()
+	progress := newMockProgressTracker()
+	importer := NewJellystatImporter(&config.ImportConfig{BatchSize: 10, ResumeFromID: 99}, publisher, progress)
+
+	path := writeJellystatFixture(t, "activity.csv", jellystatCSVFixture)
+	progress.setStats(&ImportStats{SourcePath: path, LastProcessedID: 1})
+
+	if _, err := importer.ImportFile(context.Background(), path, JellystatImportOptions{}); err != nil {
+		t.Fatalf("ImportFile() error = %v", err)
+	}
+	if got := len(publisher.getEvents()); got != 1 {
+		t.Errorf("resumed import published %d events, want 1", got)
+	}
+
+	other := writeJellystatFixture(t, "other.csv", jellystatCSVFixture)
+	progress.setStats(&ImportStats{SourcePath: path, LastProcessedID: 2})
+	if _, err := importer.ImportFile(context.Background(), other, JellystatImportOptions{}); err != nil {
+		t.Fatalf("ImportFile() error = %v", err)
+	}
+	if got := len(publisher.getEvents()); got != 3 {
+		t.Errorf("after importing a different file got %d events, want 3", got)
+	}
+}
+
+func TestJellystatImporter_InvalidFile(t *testing.T) {
+	importer := NewJellystatImporter(&config.ImportConfig{BatchSize: 10}, newMockEventPublisher(), nil)
+
+	path := writeJellystatFixture(t, "bad.csv", "foo,bar\n1,2\n")
+	if _, err := importer.ImportFile(context.Background(), path, JellystatImportOptions{}); err == nil {
+		t.Fatal("expected error for unrecognized export")
+	}
+	if importer.IsRunning() {
+		t.Error("importer still running after failed open")
+	}
+}
+
+func TestJellystatImporter_ImportRequiresFile(t *testing.T) {
+	importer := NewJellystatImporter(&config.ImportConfig{}, newMockEventPublisher(), nil)
+	if _, err := importer.Import(context.Background()); !errors.Is(err, ErrImportFileRequired) {
+		t.Errorf("Import() error = %v, want ErrImportFileRequired", err)
+	}
+}
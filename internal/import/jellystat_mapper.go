@@ -0,0 +1,243 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// jellystatEventSource is the event source for imported Jellyfin history. It
+// matches the source used by the live Jellyfin sync so correlation keys of
+// imported and synced playbacks line up.
+const jellystatEventSource = "jellyfin"
+
+// JellystatMapper converts JellystatRecord to PlaybackEvent.
+type JellystatMapper struct {
+	// serverID is the Jellyfin server the export was taken from ("" for default)
+	serverID string
+}
+
+// NewJellystatMapper creates a mapper for records exported from serverID.
+// serverID should match the server_id of the configured Jellyfin server so
+// imported events deduplicate against live syncs.
+func NewJellystatMapper(serverID string) *JellystatMapper {
+	return &JellystatMapper{serverID: serverID}
+}
+
+// ToPlaybackEvent converts a JellystatRecord to a PlaybackEvent.
+// userID is the internal user ID resolved for the record's Jellyfin user.
+func (m *JellystatMapper) ToPlaybackEvent(rec *JellystatRecord, userID int) *models.PlaybackEvent {
+	sessionKey := jellystatSessionKey(rec)
+
+	event := &models.PlaybackEvent{
+		ID:        m.generateDeterministicID(rec, sessionKey),
+		Source:    jellystatEventSource,
+		CreatedAt: time.Now(),
+
+		SessionKey: sessionKey,
+		StartedAt:  rec.StartedAt,
+
+		UserID:    userID,
+		Username:  jellystatUsername(rec),
+		IPAddress: rec.IPAddress,
+
+		MediaType: jellystatMediaType(rec),
+		Title:     rec.ItemName,
+
+		Platform: rec.Client,
+		Player:   rec.DeviceName,
+	}
+
+	if event.Title == "" {
+		event.Title = rec.ItemID
+	}
+	if m.serverID != "" {
+		serverID := m.serverID
+		event.ServerID = &serverID
+	}
+	if friendly := rec.Username; friendly != "" {
+		event.FriendlyName = &friendly
+	}
+	if machineID := jellystatMachineID(rec); machineID != "" {
+		event.MachineID = &machineID
+	}
+	if rec.ItemID != "" {
+		ratingKey := rec.ItemID
+		event.RatingKey = &ratingKey
+	}
+	if rec.SeriesName != "" {
+		seriesName := rec.SeriesName
+		event.GrandparentTitle = &seriesName
+	}
+	if decision := jellystatTranscodeDecision(rec.PlayMethod); decision != "" {
+		event.TranscodeDecision = &decision
+	}
+	if !rec.StoppedAt.IsZero() {
+		stoppedAt := rec.StoppedAt
+		event.StoppedAt = &stoppedAt
+	}
+	if rec.Duration > 0 {
+		minutes := rec.Duration / 60
+		event.PlayDuration = &minutes
+	}
+
+	correlationKey := m.generateCorrelationKey(rec, userID, sessionKey)
+	event.CorrelationKey = &correlationKey
+
+	return event
+}
+
+// generateDeterministicID creates a deterministic UUID so that re-importing
+// the same export produces the same event IDs.
+func (m *JellystatMapper) generateDeterministicID(rec *JellystatRecord, sessionKey string) uuid.UUID {
+	input := fmt.Sprintf("jellystat-import:%s:%d:%s", sessionKey, rec.StartedAt.Unix(), jellystatUserKey(rec))
+	hash := sha256.Sum256([]byte(input))
+
+	id, err := uuid.FromBytes(hash[:16])
+	if err != nil {
+		return uuid.New()
+	}
+	id[6] = (id[6] & 0x0f) | 0x50 // Version 5
+	id[8] = (id[8] & 0x3f) | 0x80 // Variant 10
+	return id
+}
+
+// generateCorrelationKey creates a correlation key in the same format as
+// MediaEvent.GenerateCorrelationKey() for live Jellyfin events:
+//
+//	jellyfin:{server_id}:{user_id}:{item_id}:{device_id}:{started_at}:{session_id}
+func (m *JellystatMapper) generateCorrelationKey(rec *JellystatRecord, userID int, sessionKey string) string {
+	timeBucket := rec.StartedAt.UTC().Format("2006-01-02T15:04:05")
+
+	ratingKey := rec.ItemID
+	if ratingKey == "" {
+		ratingKey = rec.ItemName
+	}
+
+	machineID := jellystatMachineID(rec)
+	if machineID == "" {
+		machineID = "unknown"
+	}
+
+	serverID := m.serverID
+	if serverID == "" {
+		serverID = "default"
+	}
+
+	return fmt.Sprintf("%s:%s:%d:%s:%s:%s:%s", jellystatEventSource, serverID, userID, ratingKey, machineID, timeBucket, sessionKey)
+}
+
+// ValidateRecord checks if a record has the fields required for import.
+func (m *JellystatMapper) ValidateRecord(rec *JellystatRecord) error {
+	if rec.ParseError != "" {
+		return fmt.Errorf("row %d: %s", rec.ID, rec.ParseError)
+	}
+	if rec.StartedAt.IsZero() {
+		return fmt.Errorf("row %d: missing date", rec.ID)
+	}
+	if rec.UserID == "" && rec.Username == "" {
+		return fmt.Errorf("row %d: missing user", rec.ID)
+	}
+	if rec.ItemID == "" && rec.ItemName == "" {
+		return fmt.Errorf("row %d: missing item", rec.ID)
+	}
+	return nil
+}
+
+// FilterValidRecords filters out invalid records and returns valid ones.
+// Also returns a count of skipped records.
+func (m *JellystatMapper) FilterValidRecords(records []JellystatRecord) (valid []JellystatRecord, skipped int) {
+	for i := range records {
+		if err := m.ValidateRecord(&records[i]); err == nil {
+			valid = append(valid, records[i])
+		} else {
+			skipped++
+		}
+	}
+	return valid, skipped
+}
+
+// jellystatSessionKey returns the Jellyfin session ID, or a stable synthetic
+// key for exports (like Playback Reporting) that do not record one.
+func jellystatSessionKey(rec *JellystatRecord) string {
+	if rec.SessionID != "" {
+		return rec.SessionID
+	}
+	input := fmt.Sprintf("%s:%s:%s:%d", jellystatUserKey(rec), rec.ItemID+rec.ItemName, jellystatMachineID(rec), rec.StartedAt.Unix())
+	hash := sha256.Sum256([]byte(input))
+	return "jellystat-" + hex.EncodeToString(hash[:8])
+}
+
+// jellystatUserKey returns the external user identifier for a record,
+// preferring the Jellyfin user UUID over the username.
+func jellystatUserKey(rec *JellystatRecord) string {
+	if rec.UserID != "" {
+		return rec.UserID
+	}
+	return rec.Username
+}
+
+// jellystatUsername returns the username, falling back to the user ID.
+func jellystatUsername(rec *JellystatRecord) string {
+	if rec.Username != "" {
+		return rec.Username
+	}
+	return rec.UserID
+}
+
+// jellystatMachineID returns the device identifier, falling back to the device name.
+func jellystatMachineID(rec *JellystatRecord) string {
+	if rec.DeviceID != "" {
+		return rec.DeviceID
+	}
+	return rec.DeviceName
+}
+
+// jellystatMediaType maps Jellyfin item types to Cartographus media types,
+// matching JellyfinNowPlayingItem.GetMediaType().
+func jellystatMediaType(rec *JellystatRecord) string {
+	switch strings.ToLower(rec.ItemType) {
+	case "movie":
+		return "movie"
+	case "episode":
+		return "episode"
+	case "audio", "musicvideo":
+		return "track"
+	case "":
+		if rec.SeriesName != "" {
+			return "episode"
+		}
+		return "movie"
+	default:
+		return strings.ToLower(rec.ItemType)
+	}
+}
+
+// jellystatTranscodeDecision maps Jellyfin play methods to transcode
+// decisions, matching JellyfinSession.GetTranscodeDecision(). Playback
+// Reporting appends codec details, e.g. "Transcode (v:h264 a:aac)".
+func jellystatTranscodeDecision(playMethod string) string {
+	method := strings.ToLower(strings.ReplaceAll(playMethod, " ", ""))
+	switch {
+	case method == "":
+		return ""
+	case strings.HasPrefix(method, "directplay"):
+		return "direct play"
+	case strings.HasPrefix(method, "directstream"):
+		return "direct stream"
+	case strings.HasPrefix(method, "transcode"):
+		return "transcode"
+	default:
+		return playMethod
+	}
+}
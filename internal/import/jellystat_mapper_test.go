@@ -0,0 +1,180 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestJellystatRecord() JellystatRecord {
+	started := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	return JellystatRecord{
+		ID:         7,
+		SessionID:  "sess-1",
+		UserID:     "user-a",
+		Username:   "alice",
+		ItemID:     "ep-1",
+		ItemName:   "Pilot",
+		SeriesName: "Some Show",
+		Client:     "Jellyfin Web",
+		DeviceName: "Firefox",
+		DeviceID:   "dev-1",
+		PlayMethod: "DirectStream",
+		IPAddress:  "192.168.1.10",
+		StartedAt:  started,
+		StoppedAt:  started.Add(30 * time.Minute),
+		Duration:   1800,
+	}
+}
+
+func TestJellystatMapper_ToPlaybackEvent(t *testing.T) {
+	mapper := NewJellystatMapper("jellyfin-main")
+	rec := newTestJellystatRecord()
+
+	event := mapper.ToPlaybackEvent(&rec, 42)
+
+	if event.Source != "jellyfin" {
+		t.Errorf("Source = %q, want jellyfin", event.Source)
+	}
+	if event.ServerID == nil || *event.ServerID != "jellyfin-main" {
+		t.Errorf("ServerID = %v, want jellyfin-main", event.ServerID)
+	}
+	if event.SessionKey != "sess-1" || event.UserID != 42 || event.Username != "alice" {
+		t.Errorf("unexpected identity fields: key=%q user=%d name=%q", event.SessionKey, event.UserID, event.Username)
+	}
+	if event.MediaType != "episode" || event.Title != "Pilot" {
+		t.Errorf("MediaType/Title = %q/%q", event.MediaType, event.Title)
+	}
+	if event.GrandparentTitle == nil || *event.GrandparentTitle != "Some Show" {
+		t.Errorf("GrandparentTitle = %v", event.GrandparentTitle)
+	}
+	if event.RatingKey == nil || *event.RatingKey != "ep-1" {
+		t.Errorf("RatingKey = %v", event.RatingKey)
+	}
+	if event.MachineID == nil || *event.MachineID != "dev-1" {
+		t.Errorf("MachineID = %v", event.MachineID)
+	}
+	if event.Platform != "Jellyfin Web" || event.Player != "Firefox" {
+		t.Errorf("Platform/Player = %q/%q", event.Platform, event.Player)
+	}
+	if event.TranscodeDecision == nil || *event.TranscodeDecision != "direct stream" {
+		t.Errorf("TranscodeDecision = %v", event.TranscodeDecision)
+	}
+	if event.PlayDuration == nil || *event.PlayDuration != 30 {
+		t.Errorf("PlayDuration = %v, want 30 minutes", event.PlayDuration)
+	}
+	if event.StoppedAt == nil || !event.StoppedAt.Equal(rec.StoppedAt) {
+		t.Errorf("StoppedAt = %v", event.StoppedAt)
+	}
+
+	wantKey := "jellyfin:jellyfin-main:42:ep-1:dev-1:2024-01-15T10:00:00:sess-1"
+	if event.CorrelationKey == nil || *event.CorrelationKey != wantKey {
+		t.Errorf("CorrelationKey = %v, want %s", event.CorrelationKey, wantKey)
+	}
+
+	// Re-importing the same record yields the same event ID.
+	if again := mapper.ToPlaybackEvent(&rec, 42); again.ID != event.ID {
+		t.Errorf("ID not deterministic: %s != %s", again.ID, event.ID)
+	}
+}
+
+func TestJellystatMapper_Fallbacks(t *testing.T) {
+	mapper := NewJellystatMapper("")
+	rec := JellystatRecord{
+		ID:         1,
+		UserID:     "user-a",
+		ItemName:   "Some Movie",
+		DeviceName: "Firefox",
+		StartedAt:  time.Date(2024, 2, 1, 20, 0, 0, 0, time.UTC),
+	}
+
+	event := mapper.ToPlaybackEvent(&rec, 0)
+
+	if event.ServerID != nil {
+		t.Errorf("ServerID = %v, want nil", *event.ServerID)
+	}
+	if !strings.HasPrefix(event.SessionKey, "jellystat-") {
+		t.Errorf("SessionKey = %q, want synthetic jellystat- key", event.SessionKey)
+	}
+	if again := mapper.ToPlaybackEvent(&rec, 0); again.SessionKey != event.SessionKey {
+		t.Error("synthetic session key is not stable")
+	}
+	if event.Username != "user-a" {
+		t.Errorf("Username = %q, want user ID fallback", event.Username)
+	}
+	if event.MediaType != "movie" {
+		t.Errorf("MediaType = %q, want movie", event.MediaType)
+	}
+	if event.MachineID == nil || *event.MachineID != "Firefox" {
+		t.Errorf("MachineID = %v, want device name fallback", event.MachineID)
+	}
+	if event.TranscodeDecision != nil {
+		t.Errorf("TranscodeDecision = %v, want nil", *event.TranscodeDecision)
+	}
+	if !strings.HasPrefix(*event.CorrelationKey, "jellyfin:default:0:Some Movie:Firefox:2024-02-01T20:00:00:") {
+		t.Errorf("CorrelationKey = %s", *event.CorrelationKey)
+	}
+}
+
+func TestJellystatMediaType(t *testing.T) {
+	tests := []struct {
+		itemType, series, want string
+	}{
+		{"Movie", "", "movie"},
+		{"Episode", "", "episode"},
+		{"Audio", "", "track"},
+		{"MusicVideo", "", "track"},
+		{"", "Some Show", "episode"},
+		{"", "", "movie"},
+		{"TvChannel", "", "tvchannel"},
+	}
+	for _, tt := range tests {
+		rec := JellystatRecord{ItemType: tt.itemType, SeriesName: tt.series}
+		if got := jellystatMediaType(&rec); got != tt.want {
+			t.Errorf("jellystatMediaType(%q, %q) = %q, want %q", tt.itemType, tt.series, got, tt.want)
+		}
+	}
+}
+
+func TestJellystatTranscodeDecision(t *testing.T) {
+	tests := map[string]string{
+		"":                         "",
+		"DirectPlay":               "direct play",
+		"Direct Stream":            "direct stream",
+		"Transcode":                "transcode",
+		"Transcode (v:h264 a:aac)": "transcode",
+		"Other":                    "Other",
+	}
+	for in, want := range tests {
+		if got := jellystatTranscodeDecision(in); got != want {
+			t.Errorf("jellystatTranscodeDecision(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestJellystatMapper_FilterValidRecords(t *testing.T) {
+	mapper := NewJellystatMapper("")
+	valid := newTestJellystatRecord()
+
+	noUser := newTestJellystatRecord()
+	noUser.UserID, noUser.Username = "", ""
+
+	noItem := newTestJellystatRecord()
+	noItem.ItemID, noItem.ItemName = "", ""
+
+	badRow := newTestJellystatRecord()
+	badRow.ParseError = "invalid date"
+
+	noDate := newTestJellystatRecord()
+	noDate.StartedAt = time.Time{}
+
+	records, skipped := mapper.FilterValidRecords([]JellystatRecord{valid, noUser, noItem, badRow, noDate})
+	if len(records) != 1 || skipped != 4 {
+		t.Errorf("FilterValidRecords() = %d valid, %d skipped; want 1, 4", len(records), skipped)
+	}
+}
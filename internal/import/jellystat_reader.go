@@ -0,0 +1,580 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JellystatFormat identifies the layout of a Jellyfin history export.
+type JellystatFormat string
+
+const (
+	// JellystatFormatCSV is a CSV/TSV export of Jellystat's jf_playback_activity table.
+	JellystatFormatCSV JellystatFormat = "jellystat_csv"
+
+	// JellystatFormatPGDump is a pg_dump of the Jellystat database containing
+	// a COPY block for jf_playback_activity.
+	JellystatFormatPGDump JellystatFormat = "jellystat_pg_dump"
+
+	// JellystatFormatPlaybackReporting is an export of the Jellyfin Playback
+	// Reporting plugin's PlaybackActivity table (with or without a header row).
+	JellystatFormatPlaybackReporting JellystatFormat = "playback_reporting"
+)
+
+const (
+	// jellystatActivityTable is the Jellystat table holding playback history.
+	jellystatActivityTable = "jf_playback_activity"
+
+	// jellystatPeekSize is how much of the file is inspected to detect its format.
+	jellystatPeekSize = 64 * 1024
+
+	// utf8BOM is stripped from the start of CSV exports written by spreadsheet tools.
+	utf8BOM = "\ufeff"
+)
+
+// playbackReportingColumns is the column order of the Playback Reporting
+// plugin's headerless TSV export.
+var playbackReportingColumns = []string{
+	"DateCreated", "UserId", "ItemId", "ItemType", "ItemName",
+	"PlaybackMethod", "ClientName", "DeviceName", "PlayDuration",
+}
+
+// jellystatColumnAliases maps normalized header names to record fields.
+// Headers are normalized by lowercasing and dropping non-alphanumerics, so
+// "NowPlayingItemName", "now_playing_item_name" and "Now Playing Item Name"
+// all resolve to the same field.
+var jellystatColumnAliases = map[string]string{
+	"id":                   "session_id",
+	"sessionid":            "session_id",
+	"rowid":                "session_id",
+	"userid":               "user_id",
+	"username":             "username",
+	"user":                 "username",
+	"nowplayingitemid":     "item_id",
+	"itemid":               "item_id",
+	"episodeid":            "episode_id",
+	"nowplayingitemname":   "item_name",
+	"itemname":             "item_name",
+	"name":                 "item_name",
+	"title":                "item_name",
+	"itemtype":             "item_type",
+	"type":                 "item_type",
+	"seriesname":           "series_name",
+	"client":               "client",
+	"clientname":           "client",
+	"devicename":           "device_name",
+	"device":               "device_name",
+	"deviceid":             "device_id",
+	"playmethod":           "play_method",
+	"playbackmethod":       "play_method",
+	"remoteendpoint":       "ip_address",
+	"ipaddress":            "ip_address",
+	"activitydateinserted": "date",
+	"datecreated":          "date",
+	"date":                 "date",
+	"playbackduration":     "duration",
+	"playduration":         "duration",
+	"duration":             "duration",
+}
+
+// playbackReportingMarkers are headers only present in Playback Reporting
+// exports. Their dates mark the start of playback rather than the end.
+var playbackReportingMarkers = []string{"datecreated", "playbackmethod", "playduration"}
+
+// jellystatDateLayouts are the timestamp layouts accepted in exports.
+var jellystatDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// JellystatRecord is one playback row from a Jellystat or Playback Reporting export.
+type JellystatRecord struct {
+	ID         int64 // 1-based row number within the export (resume checkpoint)
+	SessionID  string
+	UserID     string // Jellyfin user UUID
+	Username   string
+	ItemID     string
+	ItemName   string
+	ItemType   string
+	SeriesName string
+	Client     string
+	DeviceName string
+	DeviceID   string
+	PlayMethod string
+	IPAddress  string
+	StartedAt  time.Time
+	StoppedAt  time.Time
+	Duration   int // seconds
+
+	// ParseError describes why the row could not be parsed; such rows are skipped.
+	ParseError string
+}
+
+// JellystatReader reads playback history from a Jellystat export or a
+// Playback Reporting plugin export.
+//
+// The whole file is parsed and validated when the reader is created so that
+// malformed exports are rejected before an import starts.
+type JellystatReader struct {
+	format  JellystatFormat
+	records []JellystatRecord
+}
+
+// NewJellystatReader detects the format of the export at path and parses it.
+func NewJellystatReader(path string) (*JellystatReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open export file: %w", err)
+	}
+	defer f.Close()
+
+	r := &JellystatReader{}
+	if err := r.parse(f); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// parse detects the export format and loads all records.
+func (r *JellystatReader) parse(in io.Reader) error {
+	br := bufio.NewReaderSize(in, jellystatPeekSize)
+	first, err := peekFirstLine(br)
+	if err != nil {
+		return err
+	}
+	if head, _ := br.Peek(len(utf8BOM)); string(head) == utf8BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+
+	switch {
+	case isPGDumpLine(first):
+		r.format = JellystatFormatPGDump
+		return r.parsePGDump(br)
+	case isHeaderlessPlaybackReporting(first):
+		r.format = JellystatFormatPlaybackReporting
+		return r.parseDelimited(br, '\t', playbackReportingColumns)
+	default:
+		comma := ','
+		if strings.Contains(first, "\t") {
+			comma = '\t'
+		}
+		return r.parseDelimited(br, comma, nil)
+	}
+}
+
+// peekFirstLine returns the first non-blank line without consuming input.
+func peekFirstLine(br *bufio.Reader) (string, error) {
+	buf, err := br.Peek(jellystatPeekSize)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return "", fmt.Errorf("read export file: %w", err)
+	}
+	text := strings.TrimPrefix(string(buf), utf8BOM)
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line, nil
+		}
+	}
+	return "", errors.New("export file is empty")
+}
+
+// isPGDumpLine reports whether line looks like the start of a pg_dump file.
+func isPGDumpLine(line string) bool {
+	return strings.HasPrefix(line, "--") ||
+		strings.HasPrefix(line, "SET ") ||
+		strings.HasPrefix(line, "COPY ") ||
+		strings.HasPrefix(line, "CREATE ")
+}
+
+// isHeaderlessPlaybackReporting reports whether line is a data row of the
+// Playback Reporting plugin's headerless TSV export.
+func isHeaderlessPlaybackReporting(line string) bool {
+	fields := strings.Split(line, "\t")
+	if len(fields) != len(playbackReportingColumns) {
+		return false
+	}
+	_, err := parseJellystatDate(fields[0])
+	return err == nil
+}
+
+// parseDelimited parses a CSV/TSV export. When columns is nil the first row
+// is treated as the header.
+func (r *JellystatReader) parseDelimited(in io.Reader, comma rune, columns []string) error {
+	cr := csv.NewReader(in)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	if columns == nil {
+		header, err := cr.Read()
+		if err != nil {
+			return fmt.Errorf("read export header: %w", err)
+		}
+		columns = header
+	}
+
+	index, err := r.buildColumnIndex(columns)
+	if err != nil {
+		return err
+	}
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read export row %d: %w", len(r.records)+1, err)
+		}
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		r.appendRecord(index, row)
+	}
+	return nil
+}
+
+// parsePGDump extracts the jf_playback_activity COPY block from a pg_dump file.
+func (r *JellystatReader) parsePGDump(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var index map[string]int
+	for scanner.Scan() {
+		line := scanner.Text()
+		if index == nil {
+			columns, ok := parseCopyHeader(line)
+			if !ok {
+				continue
+			}
+			var err error
+			if index, err = r.buildColumnIndex(columns); err != nil {
+				return err
+			}
+			continue
+		}
+		if line == `\.` {
+			return nil
+		}
+		fields := strings.Split(line, "\t")
+		for i := range fields {
+			fields[i] = unescapeCopyField(fields[i])
+		}
+		r.appendRecord(index, fields)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read pg_dump: %w", err)
+	}
+	if index == nil {
+		return fmt.Errorf("pg_dump contains no COPY block for %s; "+
+			"dump the Jellystat database with pg_dump's default COPY format (not --inserts)", jellystatActivityTable)
+	}
+	return fmt.Errorf("pg_dump COPY block for %s is not terminated (truncated file?)", jellystatActivityTable)
+}
+
+// parseCopyHeader parses `COPY public.jf_playback_activity ("Id", ...) FROM stdin;`.
+func parseCopyHeader(line string) ([]string, bool) {
+	if !strings.HasPrefix(line, "COPY ") || !strings.HasSuffix(line, "FROM stdin;") {
+		return nil, false
+	}
+	open := strings.Index(line, "(")
+	closing := strings.LastIndex(line, ")")
+	if open < 0 || closing < open {
+		return nil, false
+	}
+	table := strings.Trim(strings.TrimSpace(line[len("COPY "):open]), `"`)
+	if table != jellystatActivityTable && !strings.HasSuffix(table, "."+jellystatActivityTable) {
+		return nil, false
+	}
+
+	columns := strings.Split(line[open+1:closing], ",")
+	for i, c := range columns {
+		columns[i] = strings.Trim(strings.TrimSpace(c), `"`)
+	}
+	return columns, true
+}
+
+// unescapeCopyField decodes a field in PostgreSQL COPY text format.
+func unescapeCopyField(field string) string {
+	if field == `\N` {
+		return ""
+	}
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] != '\\' || i+1 == len(field) {
+			b.WriteByte(field[i])
+			continue
+		}
+		i++
+		switch field[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			b.WriteByte(field[i])
+		}
+	}
+	return b.String()
+}
+
+// buildColumnIndex maps record fields to column positions and verifies that
+// the required columns are present.
+func (r *JellystatReader) buildColumnIndex(columns []string) (map[string]int, error) {
+	index := make(map[string]int)
+	found := make([]string, 0, len(columns))
+	reporting := false
+	for i, col := range columns {
+		normalized := normalizeJellystatColumn(col)
+		found = append(found, col)
+		for _, marker := range playbackReportingMarkers {
+			if normalized == marker {
+				reporting = true
+			}
+		}
+		if field, ok := jellystatColumnAliases[normalized]; ok {
+			if _, dup := index[field]; !dup {
+				index[field] = i
+			}
+		}
+	}
+
+	var missing []string
+	if _, ok := index["date"]; !ok {
+		missing = append(missing, "date (ActivityDateInserted/DateCreated)")
+	}
+	if !hasAny(index, "username", "user_id") {
+		missing = append(missing, "user (UserName/UserId)")
+	}
+	if !hasAny(index, "item_name", "item_id") {
+		missing = append(missing, "item (NowPlayingItemName/ItemName/ItemId)")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unrecognized export format: missing required columns %s (found: %s); "+
+			"expected a Jellystat %s export or a Playback Reporting TSV export",
+			strings.Join(missing, ", "), strings.Join(found, ", "), jellystatActivityTable)
+	}
+
+	if reporting && r.format == "" {
+		r.format = JellystatFormatPlaybackReporting
+	} else if r.format == "" {
+		r.format = JellystatFormatCSV
+	}
+	return index, nil
+}
+
+// normalizeJellystatColumn lowercases a header and drops non-alphanumerics.
+func normalizeJellystatColumn(col string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(col) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+func hasAny(index map[string]int, fields ...string) bool {
+	for _, f := range fields {
+		if _, ok := index[f]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// appendRecord converts one row to a JellystatRecord.
+func (r *JellystatReader) appendRecord(index map[string]int, row []string) {
+	get := func(field string) string {
+		if i, ok := index[field]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	rec := JellystatRecord{
+		ID:         int64(len(r.records) + 1),
+		SessionID:  get("session_id"),
+		UserID:     get("user_id"),
+		Username:   get("username"),
+		ItemID:     get("item_id"),
+		ItemName:   get("item_name"),
+		ItemType:   get("item_type"),
+		SeriesName: get("series_name"),
+		Client:     get("client"),
+		DeviceName: get("device_name"),
+		DeviceID:   get("device_id"),
+		PlayMethod: get("play_method"),
+		IPAddress:  get("ip_address"),
+	}
+
+	// Jellystat stores episodes under the series item; prefer the episode ID.
+	if episodeID := get("episode_id"); episodeID != "" {
+		rec.ItemID = episodeID
+		if rec.ItemType == "" {
+			rec.ItemType = "Episode"
+		}
+	}
+
+	if raw := get("duration"); raw != "" {
+		seconds, err := strconv.ParseFloat(raw, 64)
+		if err != nil || seconds < 0 {
+			rec.ParseError = fmt.Sprintf("invalid duration %q", raw)
+		} else {
+			rec.Duration = int(seconds)
+		}
+	}
+
+	date, err := parseJellystatDate(get("date"))
+	switch {
+	case err != nil:
+		rec.ParseError = err.Error()
+	case r.format == JellystatFormatPlaybackReporting:
+		// Playback Reporting records when playback started.
+		rec.StartedAt = date
+		rec.StoppedAt = date.Add(time.Duration(rec.Duration) * time.Second)
+	default:
+		// Jellystat inserts the activity row when playback ends.
+		rec.StoppedAt = date
+		rec.StartedAt = date.Add(-time.Duration(rec.Duration) * time.Second)
+	}
+
+	r.records = append(r.records, rec)
+}
+
+// parseJellystatDate parses a timestamp in any of the supported layouts.
+// Timestamps without a zone are interpreted as UTC.
+func parseJellystatDate(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, errors.New("missing date")
+	}
+	for _, layout := range jellystatDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", raw)
+}
+
+// Format returns the detected export format.
+func (r *JellystatReader) Format() JellystatFormat {
+	return r.format
+}
+
+// Close releases resources held by the reader.
+func (r *JellystatReader) Close() error {
+	r.records = nil
+	return nil
+}
+
+// CountRecords returns the total number of rows in the export.
+func (r *JellystatReader) CountRecords(_ context.Context) (int64, error) {
+	return int64(len(r.records)), nil
+}
+
+// CountRecordsSince returns the number of rows after sinceID.
+func (r *JellystatReader) CountRecordsSince(_ context.Context, sinceID int64) (int64, error) {
+	remaining := int64(len(r.records)) - sinceID
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// ReadBatch returns up to limit rows after sinceID.
+func (r *JellystatReader) ReadBatch(ctx context.Context, sinceID int64, limit int) ([]JellystatRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	start := sinceID
+	if start < 0 {
+		start = 0
+	}
+	if start >= int64(len(r.records)) {
+		return nil, nil
+	}
+	end := start + int64(limit)
+	if end > int64(len(r.records)) {
+		end = int64(len(r.records))
+	}
+	return r.records[start:end], nil
+}
+
+// GetDateRange returns the earliest and latest playback start times.
+func (r *JellystatReader) GetDateRange(_ context.Context) (earliest, latest time.Time, err error) {
+	for i := range r.records {
+		started := r.records[i].StartedAt
+		if started.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || started.Before(earliest) {
+			earliest = started
+		}
+		if started.After(latest) {
+			latest = started
+		}
+	}
+	return earliest, latest, nil
+}
+
+// GetUserStats returns the number of distinct users in the export.
+func (r *JellystatReader) GetUserStats(_ context.Context) (int, error) {
+	users := make(map[string]struct{})
+	for i := range r.records {
+		users[jellystatUserKey(&r.records[i])] = struct{}{}
+	}
+	return len(users), nil
+}
+
+// GetMediaTypeStats returns the number of rows per item type.
+func (r *JellystatReader) GetMediaTypeStats(_ context.Context) (map[string]int, error) {
+	stats := make(map[string]int)
+	for i := range r.records {
+		stats[jellystatMediaType(&r.records[i])]++
+	}
+	return stats, nil
+}
+
+// JellystatFileInfo summarizes a validated export file.
+type JellystatFileInfo struct {
+	Format  JellystatFormat `json:"format"`
+	Records int64           `json:"records"`
+}
+
+// ValidateJellystatFile parses the export at path and reports its format and
+// record count, returning a descriptive error if it cannot be imported.
+func ValidateJellystatFile(path string) (*JellystatFileInfo, error) {
+	reader, err := NewJellystatReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	if len(reader.records) == 0 {
+		return nil, fmt.Errorf("%s export contains no playback records", reader.format)
+	}
+	return &JellystatFileInfo{Format: reader.format, Records: int64(len(reader.records))}, nil
+}
@@ -0,0 +1,251 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const jellystatCSVFixture = `Id,IsPaused,UserId,UserName,Client,DeviceName,DeviceId,ApplicationVersion,NowPlayingItemId,NowPlayingItemName,SeasonId,SeriesName,EpisodeId,PlaybackDuration,ActivityDateInserted,PlayMethod,RemoteEndPoint
+sess-1,false,user-a,alice,Jellyfin Web,Firefox,dev-1,10.9.0,series-1,"Pilot, Part 1",season-1,Some Show,ep-1,1800,2024-01-15 10:30:00.000+00,DirectPlay,192.168.1.10
+sess-2,false,user-b,bob,Infuse,Apple TV,dev-2,7.0,movie-1,Some Movie,,,,5400,2024-01-16T21:00:00Z,Transcode,10.0.0.5
+`
+
+const jellystatPGDumpFixture = `--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+
+COPY public.jf_users ("Id", "Name") FROM stdin;
+user-a	alice
+\.
+
+COPY public.jf_playback_activity ("Id", "UserId", "UserName", "Client", "DeviceName", "DeviceId", "NowPlayingItemId", "NowPlayingItemName", "SeriesName", "EpisodeId", "PlaybackDuration", "ActivityDateInserted", "PlayMethod") FROM stdin;
+sess-1	user-a	alice	Jellyfin Web	Firefox	dev-1	movie-1	Tab\there	\N	\N	600	2024-01-15 10:30:00+00	DirectStream
+sess-2	user-a	alice	Jellyfin Web	Firefox	dev-1	movie-2	Other	\N	\N	60	not-a-date	DirectPlay
+\.
+`
+
+const playbackReportingFixture = "2024-02-01 20:00:00.0000000\tuser-a\titem-1\tMovie\tSome Movie\tDirectPlay\tJellyfin Web\tFirefox\t3600\n" +
+	"2024-02-02 21:15:30.1234567\tuser-b\titem-2\tEpisode\tShow - s01e01 - Pilot\tTranscode (v:h264 a:aac)\tAndroid\tPixel\t1200\n"
+
+func writeJellystatFixture(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return path
+}
+
+func readAllJellystat(t *testing.T, reader *JellystatReader) []JellystatRecord {
+	t.Helper()
+	records, err := reader.ReadBatch(context.Background(), 0, 100)
+	if err != nil {
+		t.Fatalf("ReadBatch() error = %v", err)
+	}
+	return records
+}
+
+func TestJellystatReader_CSV(t *testing.T) {
+	reader, err := NewJellystatReader(writeJellystatFixture(t, "activity.csv", jellystatCSVFixture))
+	if err != nil {
+		t.Fatalf("NewJellystatReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	if reader.Format() != JellystatFormatCSV {
+		t.Errorf("Format() = %q, want %q", reader.Format(), JellystatFormatCSV)
+	}
+
+	records := readAllJellystat(t, reader)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	ep := records[0]
+	if ep.ID != 1 || ep.SessionID != "sess-1" || ep.UserID != "user-a" || ep.Username != "alice" {
+		t.Errorf("unexpected identity fields: %+v", ep)
+	}
+	if ep.ItemID != "ep-1" {
+		t.Errorf("ItemID = %q, want episode ID ep-1", ep.ItemID)
+	}
+	if ep.ItemName != "Pilot, Part 1" || ep.SeriesName != "Some Show" {
+		t.Errorf("ItemName/SeriesName = %q/%q", ep.ItemName, ep.SeriesName)
+	}
+	if ep.Client != "Jellyfin Web" || ep.DeviceName != "Firefox" || ep.DeviceID != "dev-1" {
+		t.Errorf("unexpected device fields: %+v", ep)
+	}
+	// Jellystat records the end of playback; start is derived from the duration.
+	wantStop := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	if !ep.StoppedAt.Equal(wantStop) || !ep.StartedAt.Equal(wantStop.Add(-30*time.Minute)) {
+		t.Errorf("StartedAt/StoppedAt = %v/%v", ep.StartedAt, ep.StoppedAt)
+	}
+	if ep.IPAddress != "192.168.1.10" {
+		t.Errorf("IPAddress = %q", ep.IPAddress)
+	}
+
+	if records[1].ItemID != "movie-1" || records[1].Duration != 5400 {
+		t.Errorf("unexpected movie record: %+v", records[1])
+	}
+}
+
+func TestJellystatReader_PGDump(t *testing.T) {
+	reader, err := NewJellystatReader(writeJellystatFixture(t, "jellystat.sql", jellystatPGDumpFixture))
+	if err != nil {
+		t.Fatalf("NewJellystatReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	if reader.Format() != JellystatFormatPGDump {
+		t.Errorf("Format() = %q, want %q", reader.Format(), JellystatFormatPGDump)
+	}
+
+	records := readAllJellystat(t, reader)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (jf_users rows must be ignored)", len(records))
+	}
+	if records[0].ItemName != "Tab\there" {
+		t.Errorf("ItemName = %q, want unescaped tab", records[0].ItemName)
+	}
+	if records[0].SeriesName != "" {
+		t.Errorf(`SeriesName = %q, want "" for \N`, records[0].SeriesName)
+	}
+	if records[0].ParseError != "" {
+		t.Errorf("unexpected ParseError: %s", records[0].ParseError)
+	}
+	if records[1].ParseError == "" {
+		t.Error("expected ParseError for invalid date")
+	}
+}
+
+func TestJellystatReader_PlaybackReporting(t *testing.T) {
+	reader, err := NewJellystatReader(writeJellystatFixture(t, "playback_reporting.tsv", playbackReportingFixture))
+	if err != nil {
+		t.Fatalf("NewJellystatReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	if reader.Format() != JellystatFormatPlaybackReporting {
+		t.Errorf("Format() = %q, want %q", reader.Format(), JellystatFormatPlaybackReporting)
+	}
+
+	records := readAllJellystat(t, reader)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	// Playback Reporting records the start of playback.
+	wantStart := time.Date(2024, 2, 1, 20, 0, 0, 0, time.UTC)
+	if !records[0].StartedAt.Equal(wantStart) || !records[0].StoppedAt.Equal(wantStart.Add(time.Hour)) {
+		t.Errorf("StartedAt/StoppedAt = %v/%v", records[0].StartedAt, records[0].StoppedAt)
+	}
+	if records[1].PlayMethod != "Transcode (v:h264 a:aac)" || records[1].ItemType != "Episode" {
+		t.Errorf("unexpected record: %+v", records[1])
+	}
+
+	earliest, latest, err := reader.GetDateRange(context.Background())
+	if err != nil {
+		t.Fatalf("GetDateRange() error = %v", err)
+	}
+	if !earliest.Equal(wantStart) || latest.Before(earliest) {
+		t.Errorf("GetDateRange() = %v, %v", earliest, latest)
+	}
+}
+
+func TestJellystatReader_PlaybackReportingWithHeader(t *testing.T) {
+	content := "DateCreated,UserId,ItemId,ItemType,ItemName,PlaybackMethod,ClientName,DeviceName,PlayDuration\n" +
+		"2024-02-01 20:00:00,user-a,item-1,Movie,Some Movie,DirectPlay,Jellyfin Web,Firefox,3600\n"
+	reader, err := NewJellystatReader(writeJellystatFixture(t, "reporting.csv", content))
+	if err != nil {
+		t.Fatalf("NewJellystatReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	if reader.Format() != JellystatFormatPlaybackReporting {
+		t.Errorf("Format() = %q, want %q", reader.Format(), JellystatFormatPlaybackReporting)
+	}
+	if records := readAllJellystat(t, reader); !records[0].StartedAt.Equal(time.Date(2024, 2, 1, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf("StartedAt = %v", records[0].StartedAt)
+	}
+}
+
+func TestJellystatReader_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty file", "\n\n", "empty"},
+		{"unknown columns", "foo,bar\n1,2\n", "missing required columns"},
+		{"pg_dump without activity table", "--\n-- PostgreSQL database dump\nCOPY public.jf_users (\"Id\") FROM stdin;\nx\n\\.\n", "no COPY block"},
+		{"truncated pg_dump", "COPY public.jf_playback_activity (\"Id\", \"UserName\", \"NowPlayingItemName\", \"ActivityDateInserted\") FROM stdin;\na\tb\tc\t2024-01-01\n", "not terminated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJellystatReader(writeJellystatFixture(t, "export", tt.content))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewJellystatReader(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestJellystatReader_ReadBatchResume(t *testing.T) {
+	reader, err := NewJellystatReader(writeJellystatFixture(t, "activity.csv", jellystatCSVFixture))
+	if err != nil {
+		t.Fatalf("NewJellystatReader() error = %v", err)
+	}
+	defer reader.Close()
+	ctx := context.Background()
+
+	remaining, err := reader.CountRecordsSince(ctx, 1)
+	if err != nil || remaining != 1 {
+		t.Errorf("CountRecordsSince(1) = %d, %v; want 1", remaining, err)
+	}
+
+	batch, err := reader.ReadBatch(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("ReadBatch() error = %v", err)
+	}
+	if len(batch) != 1 || batch[0].ID != 2 {
+		t.Errorf("ReadBatch(1) = %+v, want record 2", batch)
+	}
+
+	if batch, _ := reader.ReadBatch(ctx, 2, 10); len(batch) != 0 {
+		t.Errorf("ReadBatch past end returned %d records", len(batch))
+	}
+}
+
+func TestValidateJellystatFile(t *testing.T) {
+	info, err := ValidateJellystatFile(writeJellystatFixture(t, "activity.csv", jellystatCSVFixture))
+	if err != nil {
+		t.Fatalf("ValidateJellystatFile() error = %v", err)
+	}
+	if info.Format != JellystatFormatCSV || info.Records != 2 {
+		t.Errorf("ValidateJellystatFile() = %+v", info)
+	}
+
+	headerOnly := "UserName,NowPlayingItemName,ActivityDateInserted\n"
+	if _, err := ValidateJellystatFile(writeJellystatFixture(t, "empty.csv", headerOnly)); err == nil ||
+		!strings.Contains(err.Error(), "no playback records") {
+		t.Errorf("ValidateJellystatFile(header only) error = %v", err)
+	}
+}
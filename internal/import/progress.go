@@ -17,17 +17,27 @@ import (
 const (
 	// progressKey is the BadgerDB key for storing import progress.
 	progressKey = "import:tautulli:progress"
+
+	// JellystatProgressKey is the BadgerDB key for Jellystat import progress.
+	JellystatProgressKey = "import:jellystat:progress"
 )
 
 // BadgerProgress implements ProgressTracker using BadgerDB for persistence.
 // This enables resumable imports across application restarts.
 type BadgerProgress struct {
-	db *badger.DB
+	db  *badger.DB
+	key string
 }
 
 // NewBadgerProgress creates a new progress tracker using the provided BadgerDB instance.
 func NewBadgerProgress(db *badger.DB) *BadgerProgress {
-	return &BadgerProgress{db: db}
+	return NewBadgerProgressWithKey(db, progressKey)
+}
+
+// NewBadgerProgressWithKey creates a progress tracker stored under key, so
+// importers for different sources keep independent checkpoints.
+func NewBadgerProgressWithKey(db *badger.DB, key string) *BadgerProgress {
+	return &BadgerProgress{db: db, key: key}
 }
 
 // Save persists the current import progress to BadgerDB.
@@ -38,7 +48,7 @@ func (p *BadgerProgress) Save(ctx context.Context, stats *ImportStats) error {
 	}
 
 	return p.db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(progressKey), data)
+		return txn.Set([]byte(p.key), data)
 	})
 }
 
@@ -48,7 +58,7 @@ func (p *BadgerProgress) Load(ctx context.Context) (*ImportStats, error) {
 	var stats ImportStats
 
 	err := p.db.View(func(txn *badger.Txn) error {
-		item, err := txn.Get([]byte(progressKey))
+		item, err := txn.Get([]byte(p.key))
 		if errors.Is(err, badger.ErrKeyNotFound) {
 			return nil
 		}
@@ -77,7 +87,7 @@ func (p *BadgerProgress) Load(ctx context.Context) (*ImportStats, error) {
 // Use this to start a fresh import.
 func (p *BadgerProgress) Clear(ctx context.Context) error {
 	return p.db.Update(func(txn *badger.Txn) error {
-		err := txn.Delete([]byte(progressKey))
+		err := txn.Delete([]byte(p.key))
 		if errors.Is(err, badger.ErrKeyNotFound) {
 			return nil // Already cleared
 		}
@@ -230,6 +230,24 @@ func TestProgressSummary(t *testing.T) {
 				running:  false,
 				expected: "pending",
 			},
+			{
+				name:     "paused import",
+				stats:    &ImportStats{StartTime: time.Now(), Paused: true},
+				running:  true,
+				expected: "paused",
+			},
+			{
+				name:     "canceled import",
+				stats:    &ImportStats{StartTime: time.Now().Add(-time.Hour), EndTime: time.Now(), Canceled: true},
+				running:  false,
+				expected: "canceled",
+			},
+			{
+				name:     "failed import",
+				stats:    &ImportStats{StartTime: time.Now().Add(-time.Hour), EndTime: time.Now(), Error: "read batch: boom"},
+				running:  false,
+				expected: "error",
+			},
 		}
 
 		for _, tt := range tests {
@@ -257,6 +275,34 @@ func TestProgressSummary(t *testing.T) {
 			t.Errorf("EstimatedRemain = %f, want ~50 seconds", summary.EstimatedRemain)
 		}
 	})
+
+	t.Run("ToSummary excludes paused time from throughput", func(t *testing.T) {
+		stats := &ImportStats{
+			TotalRecords:   1000,
+			Processed:      500,
+			StartTime:      time.Now().Add(-100 * time.Second),
+			PausedDuration: 50 * time.Second, // 50 active seconds = 10 records/second
+		}
+
+		summary := stats.ToSummary(true)
+
+		if summary.RecordsPerSec < 8 || summary.RecordsPerSec > 12 {
+			t.Errorf("RecordsPerSec = %f, want ~10", summary.RecordsPerSec)
+		}
+	})
+
+	t.Run("ToSummary omits ETA while paused", func(t *testing.T) {
+		stats := &ImportStats{
+			TotalRecords: 1000,
+			Processed:    500,
+			StartTime:    time.Now().Add(-50 * time.Second),
+			Paused:       true,
+		}
+
+		if summary := stats.ToSummary(true); summary.EstimatedRemain != 0 {
+			t.Errorf("EstimatedRemain = %f, want 0 while paused", summary.EstimatedRemain)
+		}
+	})
 }
 
 // createTestBadgerDB creates a temporary BadgerDB for testing.
@@ -488,4 +534,31 @@ func TestBadgerProgress(t *testing.T) {
 			t.Errorf("LastProcessedID = %d, want 750", loaded.LastProcessedID)
 		}
 	})
+
+	t.Run("keys isolate importers", func(t *testing.T) {
+		db, cleanup := createTestBadgerDB(t)
+		defer cleanup()
+
+		tautulli := NewBadgerProgress(db)
+		jellystat := NewBadgerProgressWithKey(db, JellystatProgressKey)
+		ctx := context.Background()
+
+		if err := tautulli.Save(ctx, &ImportStats{StartTime: time.Now(), LastProcessedID: 10}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := jellystat.Save(ctx, &ImportStats{StartTime: time.Now(), LastProcessedID: 3}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := jellystat.Clear(ctx); err != nil {
+			t.Fatalf("Clear() error = %v", err)
+		}
+
+		loaded, err := tautulli.Load(ctx)
+		if err != nil || loaded == nil || loaded.LastProcessedID != 10 {
+			t.Errorf("Tautulli progress = %+v, %v; want LastProcessedID 10", loaded, err)
+		}
+		if loaded, _ := jellystat.Load(ctx); loaded != nil {
+			t.Errorf("Jellystat progress = %+v, want cleared", loaded)
+		}
+	})
 }
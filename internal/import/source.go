@@ -0,0 +1,315 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// recordSource is a paginated source of playback history for an import run.
+//
+// Records are addressed by a monotonically increasing ID so that imports can
+// be resumed from the last processed record (LastProcessedID).
+type recordSource interface {
+	// CountRecords returns the total number of records in the source.
+	CountRecords(ctx context.Context) (int64, error)
+
+	// CountRecordsSince returns the number of records with ID greater than sinceID.
+	CountRecordsSince(ctx context.Context, sinceID int64) (int64, error)
+
+	// ReadBatch reads up to limit records with ID greater than sinceID and
+	// converts them to PlaybackEvents. Returns an empty batch when done.
+	ReadBatch(ctx context.Context, sinceID int64, limit int) (*sourceBatch, error)
+
+	// Close releases resources held by the source.
+	Close() error
+}
+
+// sourceStatsLogger is optionally implemented by sources that can log
+// statistics about their contents before an import starts.
+type sourceStatsLogger interface {
+	LogStats(ctx context.Context) error
+}
+
+// sourceIDRange is optionally implemented by sources that can report the
+// highest record ID they hold, so a finished import can be verified.
+type sourceIDRange interface {
+	MaxRecordID(ctx context.Context) (int64, error)
+}
+
+// sourceFilterCounter is optionally implemented by filtered sources, whose
+// CountRecords only counts matching records, to report the unfiltered total.
+type sourceFilterCounter interface {
+	CountAllRecords(ctx context.Context) (int64, error)
+}
+
+// sourceBatch is one batch of records read from a recordSource.
+type sourceBatch struct {
+	// Events are the valid records converted to PlaybackEvents.
+	Events []*models.PlaybackEvent
+
+	// Records is the number of source records consumed (valid + skipped).
+	Records int
+
+	// Skipped is the number of records that failed validation.
+	Skipped int
+
+	// LastID is the ID of the last record in the batch (resume checkpoint).
+	LastID int64
+
+	// LastSessionKey is the session key of the last record in the batch.
+	LastSessionKey string
+}
+
+// dropInvalidEvents removes events that fail PlaybackEvent.Validate after
+// mapping and counts them as skipped, so one bad record never fails the batch.
+func (b *sourceBatch) dropInvalidEvents() {
+	valid := b.Events[:0]
+	for _, event := range b.Events {
+		if err := event.Validate(); err != nil {
+			logging.Warn().Err(err).Str("event_id", event.ID.String()).Msg("Skipping invalid import record")
+			b.Skipped++
+			continue
+		}
+		valid = append(valid, event)
+	}
+	b.Events = valid
+}
+
+// tautulliSource adapts SQLiteReader to recordSource.
+type tautulliSource struct {
+	reader *SQLiteReader
+	mapper *Mapper
+}
+
+// CountRecords implements recordSource.
+func (s *tautulliSource) CountRecords(ctx context.Context) (int64, error) {
+	return s.reader.CountRecords(ctx)
+}
+
+// CountRecordsSince implements recordSource.
+func (s *tautulliSource) CountRecordsSince(ctx context.Context, sinceID int64) (int64, error) {
+	return s.reader.CountRecordsSince(ctx, sinceID)
+}
+
+// ReadBatch implements recordSource.
+func (s *tautulliSource) ReadBatch(ctx context.Context, sinceID int64, limit int) (*sourceBatch, error) {
+	records, err := s.reader.ReadBatch(ctx, sinceID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return newTautulliBatch(s.mapper, records), nil
+}
+
+// Close implements recordSource.
+func (s *tautulliSource) Close() error {
+	return s.reader.Close()
+}
+
+// MaxRecordID implements sourceIDRange.
+func (s *tautulliSource) MaxRecordID(ctx context.Context) (int64, error) {
+	return s.reader.MaxRecordID(ctx)
+}
+
+// CountAllRecords implements sourceFilterCounter.
+func (s *tautulliSource) CountAllRecords(ctx context.Context) (int64, error) {
+	return s.reader.CountAllRecords(ctx)
+}
+
+// newRecordFilter builds the Tautulli record filter from the import
+// settings. DateTo is inclusive, so the filter ends at the following midnight.
+func newRecordFilter(cfg *config.ImportConfig) (RecordFilter, error) {
+	filter := RecordFilter{Users: cfg.Users}
+	if cfg.DateFrom != "" {
+		from, err := time.ParseInLocation(time.DateOnly, cfg.DateFrom, time.Local)
+		if err != nil {
+			return RecordFilter{}, fmt.Errorf("invalid date_from: %w", err)
+		}
+		filter.From = from
+	}
+	if cfg.DateTo != "" {
+		to, err := time.ParseInLocation(time.DateOnly, cfg.DateTo, time.Local)
+		if err != nil {
+			return RecordFilter{}, fmt.Errorf("invalid date_to: %w", err)
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	return filter, nil
+}
+
+// LogStats logs statistics about the source database.
+func (s *tautulliSource) LogStats(ctx context.Context) error {
+	// Get date range
+	earliest, latest, err := s.reader.GetDateRange(ctx)
+	if err != nil {
+		return err
+	}
+	logging.Info().
+		Str("earliest", earliest.Format("2006-01-02")).
+		Str("latest", latest.Format("2006-01-02")).
+		Msg("Import date range")
+
+	// Get user count
+	userCount, err := s.reader.GetUserStats(ctx)
+	if err != nil {
+		return err
+	}
+	logging.Info().Int("unique_users", userCount).Msg("Unique users in database")
+
+	// Get media type breakdown
+	mediaTypes, err := s.reader.GetMediaTypeStats(ctx)
+	if err != nil {
+		return err
+	}
+	for mediaType, count := range mediaTypes {
+		logging.Info().Str("media_type", mediaType).Int("count", count).Msg("Media type statistics")
+	}
+
+	return nil
+}
+
+// newTautulliBatch filters and converts Tautulli records into a batch.
+func newTautulliBatch(mapper *Mapper, records []TautulliRecord) *sourceBatch {
+	batch := &sourceBatch{Records: len(records)}
+	if len(records) == 0 {
+		return batch
+	}
+
+	validRecords, skipped := mapper.FilterValidRecords(records)
+	batch.Events = mapper.ToPlaybackEvents(validRecords)
+	batch.Skipped = skipped
+
+	last := records[len(records)-1]
+	batch.LastID = last.ID
+	batch.LastSessionKey = last.SessionKey
+	return batch
+}
+
+// UserResolver resolves external (Jellyfin UUID) user IDs to internal user IDs.
+// It is implemented by database.DB.
+type UserResolver interface {
+	ResolveUserID(ctx context.Context, source, serverID, externalUserID string, username, friendlyName *string) (int, error)
+}
+
+// jellystatSource adapts JellystatReader to recordSource.
+type jellystatSource struct {
+	reader   *JellystatReader
+	mapper   *JellystatMapper
+	resolver UserResolver
+	serverID string
+
+	// users caches resolved internal user IDs by external user key.
+	users map[string]int
+}
+
+// newJellystatSource creates a record source for a parsed export.
+func newJellystatSource(reader *JellystatReader, serverID string, resolver UserResolver) *jellystatSource {
+	return &jellystatSource{
+		reader:   reader,
+		mapper:   NewJellystatMapper(serverID),
+		resolver: resolver,
+		serverID: serverID,
+		users:    make(map[string]int),
+	}
+}
+
+// CountRecords implements recordSource.
+func (s *jellystatSource) CountRecords(ctx context.Context) (int64, error) {
+	return s.reader.CountRecords(ctx)
+}
+
+// CountRecordsSince implements recordSource.
+func (s *jellystatSource) CountRecordsSince(ctx context.Context, sinceID int64) (int64, error) {
+	return s.reader.CountRecordsSince(ctx, sinceID)
+}
+
+// ReadBatch implements recordSource.
+func (s *jellystatSource) ReadBatch(ctx context.Context, sinceID int64, limit int) (*sourceBatch, error) {
+	records, err := s.reader.ReadBatch(ctx, sinceID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &sourceBatch{Records: len(records)}
+	if len(records) == 0 {
+		return batch, nil
+	}
+
+	validRecords, skipped := s.mapper.FilterValidRecords(records)
+	batch.Skipped = skipped
+	batch.Events = make([]*models.PlaybackEvent, 0, len(validRecords))
+	for i := range validRecords {
+		rec := &validRecords[i]
+		batch.Events = append(batch.Events, s.mapper.ToPlaybackEvent(rec, s.resolveUser(ctx, rec)))
+	}
+
+	last := records[len(records)-1]
+	batch.LastID = last.ID
+	batch.LastSessionKey = jellystatSessionKey(&last)
+	return batch, nil
+}
+
+// resolveUser maps the record's Jellyfin user to an internal user ID the
+// same way the live Jellyfin sync does. Falls back to 0 when no resolver is
+// configured or resolution fails.
+func (s *jellystatSource) resolveUser(ctx context.Context, rec *JellystatRecord) int {
+	key := jellystatUserKey(rec)
+	if id, ok := s.users[key]; ok {
+		return id
+	}
+	if s.resolver == nil {
+		return 0
+	}
+
+	username := jellystatUsername(rec)
+	id, err := s.resolver.ResolveUserID(ctx, jellystatEventSource, s.serverID, key, &username, &username)
+	if err != nil {
+		logging.Warn().Str("user", key).Err(err).Msg("Failed to resolve imported Jellyfin user ID")
+		return 0
+	}
+	s.users[key] = id
+	return id
+}
+
+// Close implements recordSource.
+func (s *jellystatSource) Close() error {
+	return s.reader.Close()
+}
+
+// LogStats logs statistics about the export file.
+func (s *jellystatSource) LogStats(ctx context.Context) error {
+	earliest, latest, err := s.reader.GetDateRange(ctx)
+	if err != nil {
+		return err
+	}
+	logging.Info().
+		Str("format", string(s.reader.Format())).
+		Str("earliest", earliest.Format("2006-01-02")).
+		Str("latest", latest.Format("2006-01-02")).
+		Msg("Import date range")
+
+	userCount, err := s.reader.GetUserStats(ctx)
+	if err != nil {
+		return err
+	}
+	logging.Info().Int("unique_users", userCount).Msg("Unique users in export")
+
+	mediaTypes, err := s.reader.GetMediaTypeStats(ctx)
+	if err != nil {
+		return err
+	}
+	for mediaType, count := range mediaTypes {
+		logging.Info().Str("media_type", mediaType).Int("count", count).Msg("Media type statistics")
+	}
+
+	return nil
+}
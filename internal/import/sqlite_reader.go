@@ -9,6 +9,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	// DuckDB driver - used with SQLite extension for reading Tautulli databases
@@ -84,16 +86,69 @@ type TautulliRecord struct {
 	LocationType string  // derived from session_history.location
 }
 
+// RecordFilter selects the session_history rows a reader returns.
+// The zero value matches every row.
+type RecordFilter struct {
+	From  time.Time // Sessions started at or after From (zero = no lower bound)
+	To    time.Time // Sessions started before To (zero = no upper bound)
+	Users []string  // Usernames (case-insensitive) or user IDs (empty = all users)
+}
+
+// IsZero reports whether the filter matches every row.
+func (f RecordFilter) IsZero() bool {
+	return f.From.IsZero() && f.To.IsZero() && len(f.Users) == 0
+}
+
+// conditions returns the filter as an AND-prefixed SQL clause on
+// session_history aliased as sh, with its arguments.
+func (f RecordFilter) conditions() (string, []interface{}) {
+	var clause strings.Builder
+	var args []interface{}
+	if !f.From.IsZero() {
+		clause.WriteString(" AND sh.started >= ?")
+		args = append(args, f.From.Unix())
+	}
+	if !f.To.IsZero() {
+		clause.WriteString(" AND sh.started < ?")
+		args = append(args, f.To.Unix())
+	}
+	if len(f.Users) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(f.Users)), ", ")
+		clause.WriteString(" AND (LOWER(sh.user) IN (" + placeholders + ") OR CAST(sh.user_id AS VARCHAR) IN (" + placeholders + "))")
+		for _, user := range f.Users {
+			args = append(args, strings.ToLower(user))
+		}
+		for _, user := range f.Users {
+			args = append(args, user)
+		}
+	}
+	return clause.String(), args
+}
+
 // SQLiteReader reads records from a Tautulli SQLite database using DuckDB's SQLite extension.
 // This approach allows direct reading of SQLite databases without a separate SQLite driver.
 type SQLiteReader struct {
-	db     *sql.DB
-	dbPath string
+	db      *sql.DB
+	dbPath  string
+	filter  RecordFilter
+	tempDir string // extraction directory for zip backups, removed by Close
 }
 
 // NewSQLiteReader creates a new reader for the specified Tautulli database file.
 // It uses DuckDB's SQLite extension to attach and read the SQLite database.
+//
+// A .zip backup is extracted to a temporary directory first (see
+// openZipBackup); the directory is removed when the reader is closed.
 func NewSQLiteReader(dbPath string) (*SQLiteReader, error) {
+	if isZipBackup(dbPath) {
+		return openZipBackup(dbPath)
+	}
+	return openSQLiteDatabase(dbPath)
+}
+
+// openSQLiteDatabase attaches a Tautulli SQLite database file and verifies
+// its tables.
+func openSQLiteDatabase(dbPath string) (*SQLiteReader, error) {
 	// Create an in-memory DuckDB connection for reading the SQLite database
 	db, err := sql.Open("duckdb", "")
 	if err != nil {
@@ -200,28 +255,53 @@ func verifyTables(db *sql.DB) error {
 	return nil
 }
 
-// Close closes the database connection.
+// Close closes the database connection and removes any extracted backup.
 func (r *SQLiteReader) Close() error {
 	detachSQLiteDatabase(r.db)
-	return r.db.Close()
+	err := r.db.Close()
+	if r.tempDir != "" {
+		if removeErr := os.RemoveAll(r.tempDir); removeErr != nil && err == nil {
+			err = fmt.Errorf("remove extracted backup: %w", removeErr)
+		}
+	}
+	return err
 }
 
-// CountRecords returns the total number of session history records.
+// SetFilter restricts the records counted and read to those matching filter.
+// GetDateRange, GetUserStats and GetMediaTypeStats still describe the whole database.
+func (r *SQLiteReader) SetFilter(filter RecordFilter) {
+	r.filter = filter
+}
+
+// CountRecords returns the number of session history records matching the filter.
 func (r *SQLiteReader) CountRecords(ctx context.Context) (int64, error) {
+	where, args := r.filter.conditions()
 	var count int64
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM session_history").Scan(&count)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM session_history sh WHERE 1=1"+where, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("count records: %w", err)
 	}
 	return count, nil
 }
 
-// CountRecordsSince returns the number of records with ID greater than the given ID.
+// CountAllRecords returns the total number of session history records,
+// ignoring the filter.
+func (r *SQLiteReader) CountAllRecords(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM session_history").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count all records: %w", err)
+	}
+	return count, nil
+}
+
+// CountRecordsSince returns the number of matching records with ID greater than the given ID.
 func (r *SQLiteReader) CountRecordsSince(ctx context.Context, sinceID int64) (int64, error) {
+	where, filterArgs := r.filter.conditions()
 	var count int64
 	err := r.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM session_history WHERE id > ?",
-		sinceID,
+		"SELECT COUNT(*) FROM session_history sh WHERE sh.id > ?"+where,
+		append([]interface{}{sinceID}, filterArgs...)...,
 	).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("count records since %d: %w", sinceID, err)
@@ -229,9 +309,22 @@ func (r *SQLiteReader) CountRecordsSince(ctx context.Context, sinceID int64) (in
 	return count, nil
 }
 
-// ReadBatch reads a batch of records starting from the given ID.
+// MaxRecordID returns the highest matching session history ID, or 0 if there are no records.
+func (r *SQLiteReader) MaxRecordID(ctx context.Context) (int64, error) {
+	where, args := r.filter.conditions()
+	var maxID int64
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(sh.id), 0) FROM session_history sh WHERE 1=1"+where, args...).Scan(&maxID)
+	if err != nil {
+		return 0, fmt.Errorf("max record id: %w", err)
+	}
+	return maxID, nil
+}
+
+// ReadBatch reads a batch of matching records starting from the given ID.
 // Records are ordered by ID ascending to ensure consistent resumability.
 func (r *SQLiteReader) ReadBatch(ctx context.Context, sinceID int64, limit int) ([]TautulliRecord, error) {
+	where, filterArgs := r.filter.conditions()
+
 	// Join all three tables to get complete record data
 	// Tables are accessed through the attached SQLite database
 	query := `
@@ -300,12 +393,17 @@ func (r *SQLiteReader) ReadBatch(ctx context.Context, sinceID int64, limit int)
 		FROM session_history sh
 		LEFT JOIN session_history_metadata shm ON sh.id = shm.id
 		LEFT JOIN session_history_media_info shmi ON sh.id = shmi.id
-		WHERE sh.id > ?
+		WHERE sh.id > ?` + where + `
 		ORDER BY sh.id ASC
 		LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, sinceID, limit)
+	args := make([]interface{}, 0, len(filterArgs)+2)
+	args = append(args, sinceID)
+	args = append(args, filterArgs...)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query records: %w", err)
 	}
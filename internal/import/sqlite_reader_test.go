@@ -557,3 +557,121 @@ func TestSQLiteReader_GetMediaTypeStats(t *testing.T) {
 		t.Errorf("movie count = %d, want 7", stats["movie"])
 	}
 }
+
+func TestSQLiteReader_MaxRecordID(t *testing.T) {
+	dbPath, cleanup := createTestDatabase(t)
+	defer cleanup()
+
+	reader, err := NewSQLiteReader(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteReader() error = %v", err)
+	}
+
+	maxID, err := reader.MaxRecordID(context.Background())
+	if err != nil {
+		t.Fatalf("MaxRecordID() error = %v", err)
+	}
+	if maxID != 0 {
+		t.Errorf("MaxRecordID() on empty database = %d, want 0", maxID)
+	}
+	reader.Close()
+
+	insertTestRecords(t, dbPath, 10)
+
+	reader, err = NewSQLiteReader(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	if maxID, err = reader.MaxRecordID(context.Background()); err != nil {
+		t.Fatalf("MaxRecordID() error = %v", err)
+	}
+	if maxID != 10 {
+		t.Errorf("MaxRecordID() = %d, want 10", maxID)
+	}
+}
+
+func TestSQLiteReader_Filter(t *testing.T) {
+	dbPath, cleanup := createTestDatabase(t)
+	defer cleanup()
+	insertTestRecords(t, dbPath, 10)
+
+	reader, err := NewSQLiteReader(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteReader() error = %v", err)
+	}
+	defer reader.Close()
+	ctx := context.Background()
+
+	earliest, _, err := reader.GetDateRange(ctx)
+	if err != nil {
+		t.Fatalf("GetDateRange() error = %v", err)
+	}
+
+	// Records are an hour apart; user<i%5> has user ID i%5+1
+	tests := []struct {
+		name    string
+		filter  RecordFilter
+		wantIDs []int64
+	}{
+		{"username is case-insensitive", RecordFilter{Users: []string{"USER1"}}, []int64{1, 6}},
+		{"username or user ID", RecordFilter{Users: []string{"user1", "3"}}, []int64{1, 2, 6, 7}},
+		{"from date", RecordFilter{From: earliest.Add(4 * time.Hour)}, []int64{5, 6, 7, 8, 9, 10}},
+		{"date range", RecordFilter{From: earliest.Add(time.Hour), To: earliest.Add(3 * time.Hour)}, []int64{2, 3}},
+		{"date and user", RecordFilter{From: earliest.Add(4 * time.Hour), Users: []string{"user1"}}, []int64{6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader.SetFilter(tt.filter)
+
+			count, err := reader.CountRecords(ctx)
+			if err != nil {
+				t.Fatalf("CountRecords() error = %v", err)
+			}
+			if count != int64(len(tt.wantIDs)) {
+				t.Errorf("CountRecords() = %d, want %d", count, len(tt.wantIDs))
+			}
+			if all, err := reader.CountAllRecords(ctx); err != nil || all != 10 {
+				t.Errorf("CountAllRecords() = %d, %v; want 10", all, err)
+			}
+			if since, err := reader.CountRecordsSince(ctx, tt.wantIDs[0]); err != nil || since != int64(len(tt.wantIDs)-1) {
+				t.Errorf("CountRecordsSince(%d) = %d, %v; want %d", tt.wantIDs[0], since, err, len(tt.wantIDs)-1)
+			}
+			if maxID, err := reader.MaxRecordID(ctx); err != nil || maxID != tt.wantIDs[len(tt.wantIDs)-1] {
+				t.Errorf("MaxRecordID() = %d, %v; want %d", maxID, err, tt.wantIDs[len(tt.wantIDs)-1])
+			}
+
+			records, err := reader.ReadBatch(ctx, 0, 100)
+			if err != nil {
+				t.Fatalf("ReadBatch() error = %v", err)
+			}
+			ids := make([]int64, len(records))
+			for i := range records {
+				ids[i] = records[i].ID
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("ReadBatch() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestRecordFilter_Conditions(t *testing.T) {
+	if where, args := (RecordFilter{}).conditions(); where != "" || len(args) != 0 {
+		t.Errorf("zero filter conditions = %q, %v; want none", where, args)
+	}
+
+	from := time.Unix(1700000000, 0)
+	to := time.Unix(1700086400, 0)
+	where, args := RecordFilter{From: from, To: to, Users: []string{"Alice", "42"}}.conditions()
+	want := " AND sh.started >= ? AND sh.started < ?" +
+		" AND (LOWER(sh.user) IN (?, ?) OR CAST(sh.user_id AS VARCHAR) IN (?, ?))"
+	if where != want {
+		t.Errorf("conditions() = %q, want %q", where, want)
+	}
+	if fmt.Sprint(args) != "[1700000000 1700086400 alice 42 Alice 42]" {
+		t.Errorf("conditions() args = %v", args)
+	}
+}
@@ -11,9 +11,14 @@ import (
 
 // ImportStats holds statistics about an import operation.
 type ImportStats struct {
-	// TotalRecords is the total number of records in the source database.
+	// TotalRecords is the number of records to import: those in the source
+	// database matching the date and user filter, or all of them.
 	TotalRecords int64
 
+	// SourceRecords is the number of records in the source database before
+	// filtering.
+	SourceRecords int64
+
 	// Processed is the number of records processed (including skipped).
 	Processed int64
 
@@ -23,9 +28,24 @@ type ImportStats struct {
 	// Skipped is the number of records skipped due to validation failures.
 	Skipped int64
 
+	// Deduplicated is the number of records dropped because an identical
+	// event (same deterministic event ID) was already seen in the same batch.
+	Deduplicated int64
+
 	// Errors is the number of records that failed to import.
 	Errors int64
 
+	// ImportID uniquely identifies this import run. It is used as the
+	// correlation ID for WebSocket progress broadcasts.
+	ImportID string
+
+	// CurrentSessionKey is the session key of the most recently processed record.
+	CurrentSessionKey string
+
+	// SourcePath is the file the import reads from. Saved progress is only
+	// resumed by a run against the same file.
+	SourcePath string
+
 	// StartTime is when the import started.
 	StartTime time.Time
 
@@ -37,6 +57,20 @@ type ImportStats struct {
 
 	// DryRun indicates if this was a dry run (no actual imports).
 	DryRun bool
+
+	// Paused indicates the import is suspended at a batch boundary.
+	Paused bool
+
+	// PausedDuration is the total time the import spent paused.
+	// It is excluded from throughput and ETA calculations.
+	PausedDuration time.Duration
+
+	// Canceled indicates the import was canceled before completion.
+	// The progress checkpoint is retained so a later run can resume.
+	Canceled bool
+
+	// Error holds the error message if the import failed.
+	Error string
 }
 
 // Duration returns the duration of the import operation.
@@ -55,30 +89,41 @@ func (s *ImportStats) Progress() float64 {
 	return float64(s.Processed) / float64(s.TotalRecords) * 100
 }
 
-// RecordsPerSecond returns the import rate.
+// RecordsPerSecond returns the import rate, excluding time spent paused.
 func (s *ImportStats) RecordsPerSecond() float64 {
-	duration := s.Duration().Seconds()
-	if duration == 0 {
+	duration := (s.Duration() - s.PausedDuration).Seconds()
+	if duration <= 0 {
 		return 0
 	}
 	return float64(s.Processed) / duration
 }
 
+// resumableFor reports whether saved progress applies to an import of path.
+// Progress saved before SourcePath was recorded is treated as resumable.
+func (s *ImportStats) resumableFor(path string) bool {
+	return s.SourcePath == "" || s.SourcePath == path
+}
+
 // ProgressSummary provides a human-readable summary of import progress.
 type ProgressSummary struct {
 	Status          string    `json:"status"`
 	Progress        float64   `json:"progress"`
 	TotalRecords    int64     `json:"total_records"`
+	SourceRecords   int64     `json:"source_records"`
 	Processed       int64     `json:"processed"`
 	Imported        int64     `json:"imported"`
 	Skipped         int64     `json:"skipped"`
+	Deduplicated    int64     `json:"deduplicated"`
 	Errors          int64     `json:"errors"`
 	RecordsPerSec   float64   `json:"records_per_second"`
 	ElapsedSeconds  float64   `json:"elapsed_seconds"`
 	EstimatedRemain float64   `json:"estimated_remaining_seconds"`
 	StartTime       time.Time `json:"start_time"`
 	LastProcessedID int64     `json:"last_processed_id"`
+	ImportID        string    `json:"import_id,omitempty"`
+	CurrentSession  string    `json:"current_session_key,omitempty"`
 	DryRun          bool      `json:"dry_run"`
+	Error           string    `json:"error,omitempty"`
 }
 
 // ToSummary converts ImportStats to a ProgressSummary with calculated fields.
@@ -86,31 +131,83 @@ func (s *ImportStats) ToSummary(running bool) *ProgressSummary {
 	summary := &ProgressSummary{
 		Progress:        s.Progress(),
 		TotalRecords:    s.TotalRecords,
+		SourceRecords:   s.SourceRecords,
 		Processed:       s.Processed,
 		Imported:        s.Imported,
 		Skipped:         s.Skipped,
+		Deduplicated:    s.Deduplicated,
 		Errors:          s.Errors,
 		RecordsPerSec:   s.RecordsPerSecond(),
 		ElapsedSeconds:  s.Duration().Seconds(),
 		StartTime:       s.StartTime,
 		LastProcessedID: s.LastProcessedID,
+		ImportID:        s.ImportID,
+		CurrentSession:  s.CurrentSessionKey,
 		DryRun:          s.DryRun,
+		Error:           s.Error,
 	}
 
 	// Set status
-	if running {
+	switch {
+	case running && s.Paused:
+		summary.Status = "paused"
+	case running:
 		summary.Status = "running"
-	} else if s.EndTime.IsZero() {
+	case s.Canceled:
+		summary.Status = "canceled"
+	case s.Error != "":
+		summary.Status = "error"
+	case s.EndTime.IsZero():
 		summary.Status = "pending"
-	} else {
+	default:
 		summary.Status = "completed"
 	}
 
 	// Estimate remaining time
-	if running && summary.RecordsPerSec > 0 {
+	if running && !s.Paused && summary.RecordsPerSec > 0 {
 		remaining := s.TotalRecords - s.Processed
 		summary.EstimatedRemain = float64(remaining) / summary.RecordsPerSec
 	}
 
 	return summary
 }
+
+// VerifyResult compares an import's saved progress against its source to
+// show whether the import covered every source record.
+type VerifyResult struct {
+	// SourcePath is the database that was verified.
+	SourcePath string `json:"source_path"`
+
+	// SourceRecords is the number of records in the source.
+	SourceRecords int64 `json:"source_records"`
+
+	// SourceMaxID is the highest record ID in the source.
+	SourceMaxID int64 `json:"source_max_id"`
+
+	// LastProcessedID is the last record ID in the saved progress.
+	LastProcessedID int64 `json:"last_processed_id"`
+
+	// IDGap is SourceMaxID minus LastProcessedID.
+	IDGap int64 `json:"id_gap"`
+
+	// ProcessedRecords is the number of source records at or below
+	// LastProcessedID, across all runs of a resumed import.
+	ProcessedRecords int64 `json:"processed_records"`
+
+	// MissingRecords is the number of source records above LastProcessedID.
+	MissingRecords int64 `json:"missing_records"`
+
+	// Imported, Skipped, Deduplicated, and Errors are the counts of the
+	// most recent run, which only covers part of the source when resumed.
+	Imported     int64 `json:"imported"`
+	Skipped      int64 `json:"skipped"`
+	Deduplicated int64 `json:"deduplicated"`
+	Errors       int64 `json:"errors"`
+
+	// HasProgress is false when no progress was saved for SourcePath.
+	HasProgress bool `json:"has_progress"`
+
+	// Complete is true when every source record was processed and the most
+	// recent run had no publish errors.
+	Complete bool `json:"complete"`
+}
@@ -0,0 +1,154 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoTautulliDatabase is returned when a zip backup contains no SQLite
+// database with the Tautulli session history tables.
+var ErrNoTautulliDatabase = errors.New("no compatible Tautulli database in backup")
+
+// sqliteHeader is the magic string at the start of every SQLite database file.
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// backupTimestamp matches the timestamp in Tautulli backup file names, e.g.
+// tautulli.backup-20260301120000.sched.db.
+var backupTimestamp = regexp.MustCompile(`\d{14}`)
+
+// isZipBackup reports whether path names a zip backup rather than a database.
+func isZipBackup(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".zip")
+}
+
+// openZipBackup opens the newest Tautulli database in a zip backup.
+//
+// Tautulli backups can hold several timestamped .db files alongside
+// config.ini. Candidates are tried newest first: each is extracted to a
+// temporary directory, checked for the SQLite header, and opened with the
+// usual table verification. The first one that passes is returned, and the
+// directory is removed when the reader is closed. If none passes, the error
+// wraps ErrNoTautulliDatabase and lists the zip contents.
+func openZipBackup(zipPath string) (*SQLiteReader, error) {
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("open zip backup: %w", err)
+	}
+	defer archive.Close() //nolint:errcheck // read-only archive
+
+	tempDir, err := os.MkdirTemp("", "tautulli-backup-*")
+	if err != nil {
+		return nil, fmt.Errorf("create extraction directory: %w", err)
+	}
+
+	var rejected []string
+	for _, file := range backupCandidates(archive.File) {
+		dbPath := filepath.Join(tempDir, "tautulli.db")
+		if err := extractSQLiteFile(file, dbPath); err != nil {
+			rejected = append(rejected, fmt.Sprintf("%s (%v)", file.Name, err))
+			continue
+		}
+
+		reader, err := openSQLiteDatabase(dbPath)
+		if err != nil {
+			rejected = append(rejected, fmt.Sprintf("%s (%v)", file.Name, err))
+			continue
+		}
+		reader.tempDir = tempDir
+		return reader, nil
+	}
+
+	os.RemoveAll(tempDir) //nolint:errcheck // best-effort cleanup on error path
+	return nil, noDatabaseError(zipPath, archive.File, rejected)
+}
+
+// backupCandidates returns the .db entries of a backup, newest first.
+// The timestamp in a Tautulli backup name wins over the entry's modification
+// time, which archivers do not always preserve.
+func backupCandidates(files []*zip.File) []*zip.File {
+	var candidates []*zip.File
+	for _, file := range files {
+		name := path.Base(file.Name)
+		if file.FileInfo().IsDir() || strings.HasPrefix(name, ".") || strings.Contains(file.Name, "__MACOSX/") {
+			continue
+		}
+		if strings.EqualFold(path.Ext(name), ".db") {
+			candidates = append(candidates, file)
+		}
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return backupTime(candidates[a]).After(backupTime(candidates[b]))
+	})
+	return candidates
+}
+
+// backupTime returns when a backup entry was taken.
+func backupTime(file *zip.File) time.Time {
+	if stamp := backupTimestamp.FindString(path.Base(file.Name)); stamp != "" {
+		if t, err := time.ParseInLocation("20060102150405", stamp, time.Local); err == nil {
+			return t
+		}
+	}
+	return file.Modified
+}
+
+// extractSQLiteFile writes a zip entry to dest after checking that it starts
+// with the SQLite header.
+func extractSQLiteFile(file *zip.File, dest string) error {
+	src, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("open entry: %w", err)
+	}
+	defer src.Close() //nolint:errcheck // read-only entry
+
+	header := make([]byte, len(sqliteHeader))
+	if _, err := io.ReadFull(src, header); err != nil || !bytes.Equal(header, sqliteHeader) {
+		return errors.New("not a SQLite database")
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dest, err)
+	}
+	if _, err := io.Copy(out, io.MultiReader(bytes.NewReader(header), src)); err != nil {
+		out.Close() //nolint:errcheck // already failing
+		return fmt.Errorf("extract: %w", err)
+	}
+	return out.Close()
+}
+
+// noDatabaseError describes a backup without a usable database, listing
+// every entry and why each candidate was rejected.
+func noDatabaseError(zipPath string, files []*zip.File, rejected []string) error {
+	contents := make([]string, 0, len(files))
+	for _, file := range files {
+		if !file.FileInfo().IsDir() {
+			contents = append(contents, file.Name)
+		}
+	}
+	if len(contents) == 0 {
+		contents = append(contents, "(empty)")
+	}
+
+	msg := fmt.Sprintf("%s contains: %s", filepath.Base(zipPath), strings.Join(contents, ", "))
+	if len(rejected) > 0 {
+		msg += "; rejected: " + strings.Join(rejected, "; ")
+	}
+	return fmt.Errorf("%w: %s", ErrNoTautulliDatabase, msg)
+}
@@ -0,0 +1,181 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tautulliimport
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// zipEntry is one file written by writeTestZip.
+type zipEntry struct {
+	name     string
+	data     []byte
+	modified time.Time
+}
+
+// writeTestZip creates a zip backup with the given entries.
+func writeTestZip(t *testing.T, entries ...zipEntry) string {
+	t.Helper()
+
+	zipPath := filepath.Join(t.TempDir(), "tautulli-backup.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("create zip: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for _, e := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Deflate, Modified: e.modified})
+		if err != nil {
+			t.Fatalf("create entry %s: %v", e.name, err)
+		}
+		if _, err := w.Write(e.data); err != nil {
+			t.Fatalf("write entry %s: %v", e.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip writer: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return zipPath
+}
+
+func TestIsZipBackup(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/backups/tautulli.zip": true,
+		"/backups/BACKUP.ZIP":   true,
+		"/data/tautulli.db":     false,
+		"/data/zip":             false,
+	} {
+		if got := isZipBackup(path); got != want {
+			t.Errorf("isZipBackup(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestBackupCandidates_NewestFirst(t *testing.T) {
+	old := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	zipPath := writeTestZip(t,
+		zipEntry{name: "config.ini", modified: old},
+		zipEntry{name: "backups/tautulli.backup-20260105120000.sched.db", modified: old},
+		zipEntry{name: "backups/tautulli.backup-20260301120000.db", modified: old},
+		zipEntry{name: "tautulli.db", modified: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		zipEntry{name: "tautulli.db-wal", modified: old},
+		zipEntry{name: "__MACOSX/._tautulli.db", modified: old},
+	)
+
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	defer archive.Close()
+
+	var got []string
+	for _, file := range backupCandidates(archive.File) {
+		got = append(got, file.Name)
+	}
+	want := []string{
+		"backups/tautulli.backup-20260301120000.db",
+		"tautulli.db",
+		"backups/tautulli.backup-20260105120000.sched.db",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("candidates = %v, want %v", got, want)
+	}
+}
+
+func TestNewSQLiteReader_ZipWithoutDatabase(t *testing.T) {
+	zipPath := writeTestZip(t,
+		zipEntry{name: "config.ini", data: []byte("[General]\n")},
+		zipEntry{name: "tautulli.db", data: []byte("not a database")},
+	)
+
+	_, err := NewSQLiteReader(zipPath)
+	if !errors.Is(err, ErrNoTautulliDatabase) {
+		t.Fatalf("NewSQLiteReader() error = %v, want ErrNoTautulliDatabase", err)
+	}
+	for _, want := range []string{"config.ini", "tautulli.db (not a SQLite database)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+}
+
+func TestNewSQLiteReader_ZipBackup(t *testing.T) {
+	dbPath, cleanup := createTestDatabase(t)
+	defer cleanup()
+	insertTestRecords(t, dbPath, 5)
+
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatalf("read test database: %v", err)
+	}
+
+	// The newest entry is a SQLite file without Tautulli tables, so the
+	// reader falls back to the older backup
+	emptyPath, emptyCleanup := createEmptySQLiteDatabase(t)
+	defer emptyCleanup()
+	empty, err := os.ReadFile(emptyPath)
+	if err != nil {
+		t.Fatalf("read empty database: %v", err)
+	}
+
+	zipPath := writeTestZip(t,
+		zipEntry{name: "config.ini", data: []byte("[General]\n")},
+		zipEntry{name: "tautulli.backup-20260101120000.db", data: data},
+		zipEntry{name: "tautulli.backup-20260301120000.db", data: empty},
+	)
+
+	reader, err := NewSQLiteReader(zipPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteReader() error = %v", err)
+	}
+	tempDir := reader.tempDir
+
+	count, err := reader.CountRecords(context.Background())
+	if err != nil {
+		t.Fatalf("CountRecords() error = %v", err)
+	}
+	if count != 5 {
+		t.Errorf("CountRecords() = %d, want 5", count)
+	}
+
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(tempDir); !os.IsNotExist(err) {
+		t.Errorf("extraction directory %s still exists after Close", tempDir)
+	}
+}
+
+// createEmptySQLiteDatabase creates a SQLite database with an unrelated table.
+func createEmptySQLiteDatabase(t *testing.T) (string, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "tautulli-empty-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	dbPath := filepath.Join(tmpDir, "other.db")
+
+	db, ctx := setupDuckDBWithSQLiteScanner(t)
+	defer db.Close()
+	attachSQLiteDB(t, db, ctx, dbPath, "other")
+	if _, err := db.ExecContext(ctx, "CREATE TABLE other.settings (key TEXT, value TEXT)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	detachSQLiteDB(t, db, ctx, "other")
+
+	return dbPath, func() { os.RemoveAll(tmpDir) }
+}
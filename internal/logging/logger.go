@@ -37,6 +37,27 @@
 //   - LOG_LEVEL: debug, info, warn, error (default: info)
 //   - LOG_FORMAT: json, console (default: json)
 //   - LOG_CALLER: true/false - include caller info (default: false)
+//   - LOG_SAMPLE_FIRST: lines per second logged in full by Sampled() (default: 0, no sampling)
+//   - LOG_SAMPLE_THEREAFTER: after that, log every Nth line (default: 0, drop the rest)
+//
+// # Sampling
+//
+// Hot paths that log once per event (deduplication, per-event debug output)
+// should use Sampled() so bursts do not flood the output:
+//
+//	logging.Sampled().Debug().Str("event_id", id).Msg("Duplicate event")
+//
+// Trace, debug, and info lines from Sampled() are rate-limited; warnings and
+// errors are never dropped.
+//
+// # Runtime Level Changes
+//
+// SetLevel changes the global level without a restart, for example to turn on
+// debug output during an incident (PUT /api/v1/admin/log-level):
+//
+//	if err := logging.SetLevel("debug"); err != nil {
+//	    // unknown level name
+//	}
 //
 // # Best Practices
 //
@@ -83,6 +104,17 @@ type Config struct {
 	// Output is the writer for log output.
 	// Default: os.Stderr
 	Output io.Writer
+
+	// SampleFirst is the number of lines per second Sampled() logs before
+	// sampling starts. Sampling is disabled when both SampleFirst and
+	// SampleThereafter are 0.
+	// Default: 0
+	SampleFirst int
+
+	// SampleThereafter logs every Nth line after the first SampleFirst in a
+	// second. 0 drops them all.
+	// Default: 0
+	SampleThereafter int
 }
 
 // DefaultConfig returns the default logging configuration.
@@ -100,6 +132,9 @@ var (
 	// log is the global logger instance.
 	log zerolog.Logger
 
+	// sampler rate-limits lines logged through Sampled(); nil disables sampling.
+	sampler zerolog.Sampler
+
 	// mu protects concurrent initialization.
 	mu sync.RWMutex
 )
@@ -178,8 +213,38 @@ func initLogger(cfg Config) {
 	}
 
 	log = ctx
+	sampler = newSampler(cfg.SampleFirst, cfg.SampleThereafter)
 }
 
+// newSampler builds the sampler used by Sampled. Each second the first
+// `first` lines pass, then every `thereafter`th line. Warn and above are
+// never sampled.
+func newSampler(first, thereafter int) zerolog.Sampler {
+	if first <= 0 && thereafter <= 0 {
+		return nil
+	}
+
+	var next zerolog.Sampler = dropSampler{}
+	if thereafter > 0 {
+		next = &zerolog.BasicSampler{N: uint32(thereafter)} //nolint:gosec // checked positive
+	}
+	burst := &zerolog.BurstSampler{
+		Burst:       uint32(max(first, 0)), //nolint:gosec // clamped non-negative
+		Period:      time.Second,
+		NextSampler: next,
+	}
+	return zerolog.LevelSampler{
+		TraceSampler: burst,
+		DebugSampler: burst,
+		InfoSampler:  burst,
+	}
+}
+
+// dropSampler rejects every line.
+type dropSampler struct{}
+
+func (dropSampler) Sample(zerolog.Level) bool { return false }
+
 // parseLevel converts a string level to zerolog.Level.
 func parseLevel(level string) zerolog.Level {
 	switch strings.ToLower(level) {
@@ -222,6 +287,22 @@ func SetLogger(l zerolog.Logger) {
 	log = l
 }
 
+// Sampled returns the global logger with LOG_SAMPLE_FIRST/LOG_SAMPLE_THEREAFTER
+// sampling applied, for hot paths that log once per event. Warnings and
+// errors are never sampled. Without sampling configured it returns the
+// global logger unchanged.
+//
+//	logging.Sampled().Debug().Str("event_id", id).Msg("Event received")
+func Sampled() *zerolog.Logger {
+	mu.RLock()
+	defer mu.RUnlock()
+	l := log
+	if sampler != nil {
+		l = log.Sample(sampler)
+	}
+	return &l
+}
+
 // With creates a child logger with additional context.
 // Use this to create component-specific loggers with default fields.
 //
@@ -352,9 +433,26 @@ func GetLevel() zerolog.Level {
 	return zerolog.GlobalLevel()
 }
 
-// SetLevel updates the global log level.
-func SetLevel(level zerolog.Level) {
-	zerolog.SetGlobalLevel(level)
+// ParseLevel converts a level name to a zerolog.Level. Unlike the lenient
+// parsing used by Init, unknown names are an error.
+func ParseLevel(level string) (zerolog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "trace", "debug", "info", "warn", "warning", "error", "fatal", "panic", "disabled":
+		return parseLevel(strings.TrimSpace(level)), nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("invalid log level %q (must be trace, debug, info, warn, error, fatal, panic, or disabled)", level)
+	}
+}
+
+// SetLevel changes the global log level at runtime. The level is stored
+// atomically by zerolog, so it is safe to call while other goroutines log.
+func SetLevel(level string) error {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	zerolog.SetGlobalLevel(lvl)
+	return nil
 }
 
 // SetLevelString updates the global log level from a string.
@@ -7,7 +7,9 @@ package logging
 
 import (
 	"bytes"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -152,7 +154,7 @@ func TestNewTestLogger(t *testing.T) {
 func TestSetLevelString(t *testing.T) {
 	// Save original level
 	originalLevel := GetLevel()
-	defer SetLevel(originalLevel)
+	defer zerolog.SetGlobalLevel(originalLevel)
 
 	SetLevelString("debug")
 	if GetLevel() != zerolog.DebugLevel {
@@ -165,12 +167,91 @@ func TestSetLevelString(t *testing.T) {
 	}
 }
 
+func TestSetLevel(t *testing.T) {
+	originalLevel := GetLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	var buf bytes.Buffer
+	Init(Config{Level: "info", Format: "json", Output: &buf})
+
+	Debug().Msg("debug before")
+	if strings.Contains(buf.String(), "debug before") {
+		t.Fatal("debug line logged at info level")
+	}
+
+	if err := SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel(debug) error = %v", err)
+	}
+	Debug().Msg("debug after")
+	if !strings.Contains(buf.String(), "debug after") {
+		t.Error("debug line suppressed after SetLevel(debug)")
+	}
+
+	if err := SetLevel("WARN"); err != nil {
+		t.Fatalf("SetLevel(WARN) error = %v", err)
+	}
+	buf.Reset()
+	Info().Msg("info suppressed")
+	Warn().Msg("warn kept")
+	if strings.Contains(buf.String(), "info suppressed") {
+		t.Error("info line logged at warn level")
+	}
+	if !strings.Contains(buf.String(), "warn kept") {
+		t.Error("warn line suppressed at warn level")
+	}
+}
+
+func TestSetLevel_Invalid(t *testing.T) {
+	originalLevel := GetLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	for _, level := range []string{"", "verbose", "5"} {
+		if err := SetLevel(level); err == nil {
+			t.Errorf("SetLevel(%q) expected error", level)
+		}
+	}
+	if GetLevel() != zerolog.InfoLevel {
+		t.Errorf("level changed to %v after invalid SetLevel", GetLevel())
+	}
+}
+
+func TestSetLevel_Concurrent(t *testing.T) {
+	originalLevel := GetLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	Init(Config{Level: "info", Output: io.Discard})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				Debug().Int("j", j).Msg("concurrent")
+				Info().Int("j", j).Msg("concurrent")
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			levels := []string{"debug", "info", "warn"}
+			for j := 0; j < 200; j++ {
+				if err := SetLevel(levels[(i+j)%len(levels)]); err != nil {
+					t.Errorf("SetLevel error = %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestIsLevelEnabled(t *testing.T) {
 	// Save original level
 	originalLevel := GetLevel()
-	defer SetLevel(originalLevel)
+	defer zerolog.SetGlobalLevel(originalLevel)
 
-	SetLevel(zerolog.InfoLevel)
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 
 	if !IsLevelEnabled(zerolog.InfoLevel) {
 		t.Error("expected InfoLevel to be enabled")
@@ -240,3 +321,57 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+func TestSampled(t *testing.T) {
+	var buf bytes.Buffer
+	Init(Config{
+		Level:            "debug",
+		Output:           &buf,
+		SampleFirst:      10,
+		SampleThereafter: 100,
+	})
+	t.Cleanup(func() { Init(DefaultConfig()) })
+
+	for i := 0; i < 1000; i++ {
+		Sampled().Debug().Str("event_id", "evt-1").Msg("duplicate event")
+	}
+
+	// The first 10 lines pass, then lines 1, 101, ..., 901 of the remaining 990
+	if got := strings.Count(buf.String(), "duplicate event"); got != 20 {
+		t.Errorf("wrote %d of 1000 sampled debug lines, want 20", got)
+	}
+
+	buf.Reset()
+	for i := 0; i < 1000; i++ {
+		Sampled().Error().Msg("insert failed")
+	}
+	if got := strings.Count(buf.String(), "insert failed"); got != 1000 {
+		t.Errorf("wrote %d of 1000 error lines, want all of them", got)
+	}
+}
+
+func TestSampled_DropAfterBurst(t *testing.T) {
+	var buf bytes.Buffer
+	Init(Config{Level: "debug", Output: &buf, SampleFirst: 5})
+	t.Cleanup(func() { Init(DefaultConfig()) })
+
+	for i := 0; i < 100; i++ {
+		Sampled().Info().Msg("tick")
+	}
+	if got := strings.Count(buf.String(), "tick"); got != 5 {
+		t.Errorf("wrote %d lines, want 5", got)
+	}
+}
+
+func TestSampled_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	Init(Config{Level: "debug", Output: &buf})
+	t.Cleanup(func() { Init(DefaultConfig()) })
+
+	for i := 0; i < 100; i++ {
+		Sampled().Debug().Msg("tick")
+	}
+	if got := strings.Count(buf.String(), "tick"); got != 100 {
+		t.Errorf("wrote %d lines without sampling configured, want 100", got)
+	}
+}
@@ -26,6 +26,21 @@ Metrics are exposed at the /metrics endpoint in Prometheus text format:
 
 	curl http://localhost:3857/metrics
 
+# Registries
+
+The metrics are created unregistered. Init registers them with the default
+Prometheus registry (next to the Go runtime and process collectors), and
+InitWithRegistry with a caller-owned *prometheus.Registry, which avoids
+duplicate registration when Cartographus is embedded in another service or
+initialized by several tests. Handler serves whichever registry was picked
+last. Both functions are safe to call repeatedly.
+
+	reg := prometheus.NewRegistry()
+	if err := metrics.InitWithRegistry(reg); err != nil {
+	    return err
+	}
+	mux.Handle("/metrics", metrics.Handler()) // Serves reg only
+
 # Available Metrics
 
 HTTP Metrics:
@@ -43,6 +58,18 @@ Database Metrics:
   - db_query_errors_total: Failed queries (counter)
     Labels: operation, error_type
 
+DuckDB Internals (refreshed every DB_METRICS_INTERVAL, skipped during backups):
+  - duckdb_database_size_bytes: Database file size on disk (gauge)
+  - duckdb_wal_size_bytes: Write-ahead log file size on disk (gauge)
+  - duckdb_memory_usage_bytes: Memory used by DuckDB, from duckdb_memory() (gauge)
+  - duckdb_table_rows: Approximate rows per main table (gauge)
+    Labels: table (playback_events, geolocations, audit_events, detection_*, ...)
+  - duckdb_connection_pool_connections: Pool connections (gauge)
+    Labels: state (open, in_use, idle)
+  - duckdb_connection_pool_wait_count: Cumulative waits for a connection (gauge)
+  - duckdb_stats_collections_total: Collection runs (counter)
+    Labels: result (success, error, skipped)
+
 Sync Metrics:
   - sync_duration_seconds: Sync operation duration (histogram)
     Buckets: 1, 5, 10, 30, 60, 120, 300
@@ -51,11 +78,29 @@ Sync Metrics:
   - sync_errors_total: Failed syncs (counter)
     Labels: source, error_type
   - sync_last_success_timestamp: Unix timestamp of last successful sync (gauge)
+    Labels: source (tautulli, plex, jellyfin, emby), server_id
+  - sync_in_progress: Sync operations currently running (gauge)
+    Labels: source, server_id
+  - events_quarantined_total: Events quarantined for implausible started_at (counter)
+    Labels: source, reason (started_at_too_old, started_at_in_future)
+
+Event Processing Metrics (NATS builds):
+  - nats_messages_consumed_total, nats_messages_processed_total: Consumer throughput (counters)
+  - nats_batch_flush_duration_seconds, nats_batch_size: Appender flushes (histograms)
+  - nats_shutdown_flushes_total: Partial batches flushed on consumer shutdown (counter)
+    Labels: result (success, error)
+  - nats_shutdown_flush_events_total: Events written by shutdown flushes (counter)
+  - nats_stream_reader_backend: Active stream reader backend, 1=active (gauge)
+    Labels: backend (natsjs, fallback)
+  - nats_stream_reader_fallbacks_total: Reads served by the Go client after nats_js failed (counter)
+    Labels: operation (query, get_message, get_last_sequence)
 
 Circuit Breaker Metrics:
   - circuit_breaker_state: Current state (gauge)
-    Labels: name
-    Values: 0=closed, 1=open, 2=half-open
+    Labels: name (tautulli-api, plex-api[:server_id], jellyfin-api[:server_id], emby-api[:server_id])
+    Values: 0=closed, 1=half-open, 2=open
+  - circuit_breaker_state_transitions_total: State transitions (counter)
+    Labels: name, from_state, to_state
   - circuit_breaker_failures_total: Failure counts (counter)
     Labels: name, state
   - circuit_breaker_successes_total: Success counts (counter)
@@ -80,7 +125,9 @@ WebSocket Metrics:
 
 Plex Metrics (v1.39+):
   - plex_websocket_state: WebSocket connection state (gauge)
-    Values: 0=disconnected, 1=connected
+    Values: 0=disconnected, 1=connected, 2=reconnecting (backing off)
+  - plex_websocket_reconnects_total: Reconnection attempts (counter)
+    Labels: result (success, failure)
   - plex_transcode_sessions_active: Active transcode sessions (gauge)
   - plex_buffer_health_critical_sessions: Sessions with critical buffer (gauge)
   - plex_api_calls_total: Plex API calls (counter)
@@ -92,15 +139,16 @@ Basic setup in main.go:
 
 	import (
 	    "github.com/tomtom215/cartographus/internal/metrics"
-	    "github.com/prometheus/client_golang/prometheus/promhttp"
 	)
 
 	func main() {
-	    // Initialize metrics
-	    metrics.Init()
+	    // Register metrics with the default Prometheus registry
+	    if err := metrics.Init(); err != nil {
+	        log.Fatal(err)
+	    }
 
 	    // Register metrics endpoint
-	    http.Handle("/metrics", promhttp.Handler())
+	    http.Handle("/metrics", metrics.Handler())
 
 	    // Record metrics
 	    metrics.RecordHTTPRequest("GET", "/api/v1/stats", 200, 0.023)
@@ -180,6 +228,9 @@ Example PromQL queries:
 	# Sync records per minute
 	rate(sync_records_total[1m]) * 60
 
+	# Servers that have not synced in 2 hours
+	time() - sync_last_success_timestamp > 7200
+
 # Performance Impact
 
 Metrics collection overhead:
@@ -6,11 +6,11 @@
 package metrics
 
 import (
+	"database/sql"
 	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 // Prometheus Metrics Integration for Production Observability
@@ -23,7 +23,7 @@ import (
 
 var (
 	// Database Metrics
-	DBQueryDuration = promauto.NewHistogramVec(
+	DBQueryDuration = factory.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "duckdb_query_duration_seconds",
 			Help:    "Duration of DuckDB queries in seconds",
@@ -32,7 +32,7 @@ var (
 		[]string{"operation", "table"},
 	)
 
-	DBQueryErrors = promauto.NewCounterVec(
+	DBQueryErrors = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "duckdb_query_errors_total",
 			Help: "Total number of DuckDB query errors",
@@ -40,14 +40,14 @@ var (
 		[]string{"operation", "table", "error_type"},
 	)
 
-	DBConnectionPoolSize = promauto.NewGauge(
+	DBConnectionPoolSize = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "duckdb_connection_pool_size",
 			Help: "Current number of database connections in use",
 		},
 	)
 
-	DBSpatialOperations = promauto.NewCounterVec(
+	DBSpatialOperations = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "duckdb_spatial_operations_total",
 			Help: "Total number of spatial operations (ST_* functions)",
@@ -56,28 +56,28 @@ var (
 	)
 
 	// Vector Tile Cache Metrics (MEDIUM-1)
-	TileCacheHits = promauto.NewCounter(
+	TileCacheHits = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "tile_cache_hits_total",
 			Help: "Total number of vector tile cache hits",
 		},
 	)
 
-	TileCacheMisses = promauto.NewCounter(
+	TileCacheMisses = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "tile_cache_misses_total",
 			Help: "Total number of vector tile cache misses",
 		},
 	)
 
-	TileCacheSize = promauto.NewGauge(
+	TileCacheSize = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "tile_cache_entries",
 			Help: "Current number of cached vector tiles",
 		},
 	)
 
-	TileCacheDataVersion = promauto.NewGauge(
+	TileCacheDataVersion = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "tile_cache_data_version",
 			Help: "Current tile cache data version (increments on data changes)",
@@ -85,7 +85,7 @@ var (
 	)
 
 	// API Endpoint Metrics
-	APIRequestsTotal = promauto.NewCounterVec(
+	APIRequestsTotal = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "api_requests_total",
 			Help: "Total number of API requests",
@@ -93,7 +93,7 @@ var (
 		[]string{"method", "endpoint", "status_code"},
 	)
 
-	APIRequestDuration = promauto.NewHistogramVec(
+	APIRequestDuration = factory.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "api_request_duration_seconds",
 			Help:    "API request duration in seconds",
@@ -102,14 +102,14 @@ var (
 		[]string{"method", "endpoint"},
 	)
 
-	APIActiveRequests = promauto.NewGauge(
+	APIActiveRequests = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "api_active_requests",
 			Help: "Current number of active API requests",
 		},
 	)
 
-	APIRateLimitHits = promauto.NewCounterVec(
+	APIRateLimitHits = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "api_rate_limit_hits_total",
 			Help: "Total number of rate limit rejections",
@@ -118,7 +118,7 @@ var (
 	)
 
 	// Sync Operation Metrics
-	SyncDuration = promauto.NewHistogram(
+	SyncDuration = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "sync_duration_seconds",
 			Help:    "Duration of sync operations in seconds",
@@ -126,14 +126,14 @@ var (
 		},
 	)
 
-	SyncRecordsProcessed = promauto.NewCounter(
+	SyncRecordsProcessed = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "sync_records_processed_total",
 			Help: "Total number of playback records processed during sync",
 		},
 	)
 
-	SyncErrors = promauto.NewCounterVec(
+	SyncErrors = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "sync_errors_total",
 			Help: "Total number of sync errors",
@@ -141,14 +141,23 @@ var (
 		[]string{"error_type"}, // "tautulli_api", "database", "geolocation", "validation"
 	)
 
-	SyncLastSuccess = promauto.NewGauge(
+	SyncLastSuccess = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "sync_last_success_timestamp",
-			Help: "Unix timestamp of last successful sync",
+			Help: "Unix timestamp of the last successful sync per source and server",
 		},
+		[]string{"source", "server_id"}, // source: "tautulli", "plex", "jellyfin", "emby"
 	)
 
-	SyncBatchSize = promauto.NewHistogram(
+	SyncInProgress = factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sync_in_progress",
+			Help: "Number of sync operations currently running per source and server",
+		},
+		[]string{"source", "server_id"},
+	)
+
+	SyncBatchSize = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "sync_batch_size",
 			Help:    "Number of records in sync batches",
@@ -157,7 +166,7 @@ var (
 	)
 
 	// Geolocation Metrics (MEDIUM-2)
-	GeolocationBatchSize = promauto.NewHistogram(
+	GeolocationBatchSize = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "geolocation_batch_size",
 			Help:    "Number of IPs in geolocation batch lookups",
@@ -165,21 +174,21 @@ var (
 		},
 	)
 
-	GeolocationCacheHits = promauto.NewCounter(
+	GeolocationCacheHits = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "geolocation_cache_hits_total",
 			Help: "Total number of geolocation cache hits (DB)",
 		},
 	)
 
-	GeolocationCacheMisses = promauto.NewCounter(
+	GeolocationCacheMisses = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "geolocation_cache_misses_total",
 			Help: "Total number of geolocation cache misses (API fetch required)",
 		},
 	)
 
-	GeolocationAPICallDuration = promauto.NewHistogram(
+	GeolocationAPICallDuration = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "geolocation_api_call_duration_seconds",
 			Help:    "Duration of Tautulli geolocation API calls",
@@ -188,7 +197,7 @@ var (
 	)
 
 	// Cache Metrics (General)
-	CacheHits = promauto.NewCounterVec(
+	CacheHits = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "cache_hits_total",
 			Help: "Total number of cache hits",
@@ -196,7 +205,7 @@ var (
 		[]string{"cache_type"}, // "analytics", "tile", "geolocation"
 	)
 
-	CacheMisses = promauto.NewCounterVec(
+	CacheMisses = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "cache_misses_total",
 			Help: "Total number of cache misses",
@@ -204,7 +213,7 @@ var (
 		[]string{"cache_type"},
 	)
 
-	CacheSize = promauto.NewGaugeVec(
+	CacheSize = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "cache_entries",
 			Help: "Current number of cached entries",
@@ -212,7 +221,7 @@ var (
 		[]string{"cache_type"},
 	)
 
-	CacheEvictions = promauto.NewCounterVec(
+	CacheEvictions = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "cache_evictions_total",
 			Help: "Total number of cache evictions (TTL expiry)",
@@ -221,28 +230,28 @@ var (
 	)
 
 	// WebSocket Metrics
-	WSConnections = promauto.NewGauge(
+	WSConnections = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "websocket_connections",
 			Help: "Current number of active WebSocket connections",
 		},
 	)
 
-	WSMessagesSent = promauto.NewCounter(
+	WSMessagesSent = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "websocket_messages_sent_total",
 			Help: "Total number of WebSocket messages sent",
 		},
 	)
 
-	WSMessagesReceived = promauto.NewCounter(
+	WSMessagesReceived = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "websocket_messages_received_total",
 			Help: "Total number of WebSocket messages received",
 		},
 	)
 
-	WSErrors = promauto.NewCounterVec(
+	WSErrors = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "websocket_errors_total",
 			Help: "Total number of WebSocket errors",
@@ -250,8 +259,24 @@ var (
 		[]string{"error_type"},
 	)
 
+	// Plex WebSocket Metrics
+	PlexWebSocketState = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "plex_websocket_state",
+			Help: "Plex WebSocket connection state (0=disconnected, 1=connected, 2=reconnecting)",
+		},
+	)
+
+	PlexWebSocketReconnects = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "plex_websocket_reconnects_total",
+			Help: "Total number of Plex WebSocket reconnection attempts",
+		},
+		[]string{"result"}, // success, failure
+	)
+
 	// Circuit Breaker Metrics
-	CircuitBreakerState = promauto.NewGaugeVec(
+	CircuitBreakerState = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "circuit_breaker_state",
 			Help: "Circuit breaker state (0=closed, 1=half-open, 2=open)",
@@ -259,7 +284,7 @@ var (
 		[]string{"name"},
 	)
 
-	CircuitBreakerRequests = promauto.NewCounterVec(
+	CircuitBreakerRequests = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "circuit_breaker_requests_total",
 			Help: "Total number of requests through circuit breaker",
@@ -267,7 +292,7 @@ var (
 		[]string{"name", "result"}, // result: "success", "failure", "rejected"
 	)
 
-	CircuitBreakerConsecutiveFailures = promauto.NewGaugeVec(
+	CircuitBreakerConsecutiveFailures = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "circuit_breaker_consecutive_failures",
 			Help: "Current number of consecutive failures",
@@ -275,7 +300,7 @@ var (
 		[]string{"name"},
 	)
 
-	CircuitBreakerTransitions = promauto.NewCounterVec(
+	CircuitBreakerTransitions = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "circuit_breaker_state_transitions_total",
 			Help: "Total number of circuit breaker state transitions",
@@ -284,14 +309,14 @@ var (
 	)
 
 	// Dead Letter Queue Metrics (Phase 5)
-	DLQEntriesTotal = promauto.NewGauge(
+	DLQEntriesTotal = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "dlq_entries_total",
 			Help: "Current number of entries in the Dead Letter Queue",
 		},
 	)
 
-	DLQEntriesByCategory = promauto.NewGaugeVec(
+	DLQEntriesByCategory = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "dlq_entries_by_category",
 			Help: "Current number of DLQ entries by error category",
@@ -299,49 +324,49 @@ var (
 		[]string{"category"}, // connection, timeout, validation, database, capacity, unknown
 	)
 
-	DLQMessagesAdded = promauto.NewCounter(
+	DLQMessagesAdded = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "dlq_messages_added_total",
 			Help: "Total number of messages added to the DLQ",
 		},
 	)
 
-	DLQMessagesRemoved = promauto.NewCounter(
+	DLQMessagesRemoved = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "dlq_messages_removed_total",
 			Help: "Total number of messages removed from the DLQ (successfully reprocessed)",
 		},
 	)
 
-	DLQMessagesExpired = promauto.NewCounter(
+	DLQMessagesExpired = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "dlq_messages_expired_total",
 			Help: "Total number of messages expired from the DLQ",
 		},
 	)
 
-	DLQRetryAttempts = promauto.NewCounter(
+	DLQRetryAttempts = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "dlq_retry_attempts_total",
 			Help: "Total number of retry attempts for DLQ messages",
 		},
 	)
 
-	DLQRetrySuccesses = promauto.NewCounter(
+	DLQRetrySuccesses = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "dlq_retry_successes_total",
 			Help: "Total number of successful DLQ message retries",
 		},
 	)
 
-	DLQRetryFailures = promauto.NewCounter(
+	DLQRetryFailures = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "dlq_retry_failures_total",
 			Help: "Total number of failed DLQ message retries",
 		},
 	)
 
-	DLQOldestEntryAge = promauto.NewGauge(
+	DLQOldestEntryAge = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "dlq_oldest_entry_age_seconds",
 			Help: "Age of the oldest entry in the DLQ in seconds",
@@ -349,42 +374,42 @@ var (
 	)
 
 	// NATS Event Processing Metrics (Phase 6)
-	NATSMessagesPublished = promauto.NewCounter(
+	NATSMessagesPublished = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "nats_messages_published_total",
 			Help: "Total number of messages published to NATS",
 		},
 	)
 
-	NATSMessagesConsumed = promauto.NewCounter(
+	NATSMessagesConsumed = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "nats_messages_consumed_total",
 			Help: "Total number of messages consumed from NATS",
 		},
 	)
 
-	NATSMessagesProcessed = promauto.NewCounter(
+	NATSMessagesProcessed = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "nats_messages_processed_total",
 			Help: "Total number of messages successfully processed",
 		},
 	)
 
-	NATSMessagesDeduplicated = promauto.NewCounter(
+	NATSMessagesDeduplicated = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "nats_messages_deduplicated_total",
 			Help: "Total number of messages skipped due to deduplication",
 		},
 	)
 
-	NATSMessagesParseFailed = promauto.NewCounter(
+	NATSMessagesParseFailed = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "nats_messages_parse_failed_total",
 			Help: "Total number of messages that failed to parse",
 		},
 	)
 
-	NATSProcessingDuration = promauto.NewHistogram(
+	NATSProcessingDuration = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "nats_processing_duration_seconds",
 			Help:    "Duration of NATS message processing in seconds",
@@ -392,7 +417,7 @@ var (
 		},
 	)
 
-	NATSBatchFlushDuration = promauto.NewHistogram(
+	NATSBatchFlushDuration = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "nats_batch_flush_duration_seconds",
 			Help:    "Duration of batch flush operations in seconds",
@@ -400,7 +425,7 @@ var (
 		},
 	)
 
-	NATSBatchSize = promauto.NewHistogram(
+	NATSBatchSize = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "nats_batch_size",
 			Help:    "Number of events in each batch flush",
@@ -408,22 +433,77 @@ var (
 		},
 	)
 
-	NATSQueueDepth = promauto.NewGauge(
+	NATSQueueDepth = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "nats_queue_depth",
 			Help: "Current depth of the NATS message queue",
 		},
 	)
 
-	NATSConsumerLag = promauto.NewGauge(
+	NATSConsumerLag = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "nats_consumer_lag",
 			Help: "Number of pending messages in NATS consumer",
 		},
 	)
 
+	// NATSShutdownFlushes counts partial batches flushed by a consumer on
+	// graceful shutdown, by result
+	NATSShutdownFlushes = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_shutdown_flushes_total",
+			Help: "Total partial batches flushed to DuckDB on consumer shutdown, by result",
+		},
+		[]string{"result"}, // success, error
+	)
+
+	NATSShutdownFlushEvents = factory.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nats_shutdown_flush_events_total",
+			Help: "Total events written to DuckDB by consumer shutdown flushes",
+		},
+	)
+
+	// NATSStreamReaderBackend is 1 for the backend the resilient stream
+	// reader is currently using and 0 for the other
+	NATSStreamReaderBackend = factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nats_stream_reader_backend",
+			Help: "Active stream reader backend (1=active, 0=inactive)",
+		},
+		[]string{"backend"}, // natsjs, fallback
+	)
+
+	NATSStreamReaderFallbacks = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_stream_reader_fallbacks_total",
+			Help: "Total stream reads served by the Go NATS client after the nats_js extension reader failed",
+		},
+		[]string{"operation"}, // query, get_message, get_last_sequence
+	)
+
+	// EventsQuarantined counts events held back from playback_events because
+	// their started_at is outside the accepted window
+	EventsQuarantined = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_quarantined_total",
+			Help: "Total number of playback events quarantined instead of inserted",
+		},
+		[]string{"source", "reason"},
+	)
+
+	// EventsInvalid counts events skipped before insert because they failed
+	// PlaybackEvent validation
+	EventsInvalid = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_invalid_total",
+			Help: "Total number of playback events skipped because they failed validation",
+		},
+		[]string{"source"},
+	)
+
 	// System Metrics
-	AppInfo = promauto.NewGaugeVec(
+	AppInfo = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "app_info",
 			Help: "Application version and build information",
@@ -431,7 +511,7 @@ var (
 		[]string{"version", "go_version"},
 	)
 
-	AppUptime = promauto.NewGauge(
+	AppUptime = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "app_uptime_seconds",
 			Help: "Application uptime in seconds",
@@ -458,7 +538,8 @@ func RecordAPIRequest(method, endpoint, statusCode string, duration time.Duratio
 	APIRequestDuration.WithLabelValues(method, endpoint).Observe(duration.Seconds())
 }
 
-// RecordSyncOperation records a sync operation metric
+// RecordSyncOperation records the duration, records and error of a sync
+// operation. Callers record success per source with RecordSyncSuccess.
 func RecordSyncOperation(duration time.Duration, recordsProcessed int, err error) {
 	SyncDuration.Observe(duration.Seconds())
 	SyncRecordsProcessed.Add(float64(recordsProcessed))
@@ -479,12 +560,22 @@ func RecordSyncOperation(duration time.Duration, recordsProcessed int, err error
 			}
 		}
 		SyncErrors.WithLabelValues(errorType).Inc()
-	} else {
-		// Update last success timestamp
-		SyncLastSuccess.Set(float64(time.Now().Unix()))
 	}
 }
 
+// RecordSyncSuccess sets the last success timestamp of a source's server to now
+func RecordSyncSuccess(source, serverID string) {
+	SyncLastSuccess.WithLabelValues(source, serverID).Set(float64(time.Now().Unix()))
+}
+
+// TrackSyncInProgress counts a sync of a source's server as running until
+// the returned func is called
+func TrackSyncInProgress(source, serverID string) func() {
+	gauge := SyncInProgress.WithLabelValues(source, serverID)
+	gauge.Inc()
+	return gauge.Dec
+}
+
 // TrackActiveRequest tracks active API requests
 func TrackActiveRequest(inc bool) {
 	if inc {
@@ -572,6 +663,45 @@ func RecordNATSBatchFlush(duration time.Duration, batchSize int) {
 	NATSBatchSize.Observe(float64(batchSize))
 }
 
+// RecordNATSShutdownFlush records a consumer flushing its partial batch on
+// shutdown; events is the number of events written
+func RecordNATSShutdownFlush(events int, err error) {
+	if err != nil {
+		NATSShutdownFlushes.WithLabelValues("error").Inc()
+		return
+	}
+	NATSShutdownFlushes.WithLabelValues("success").Inc()
+	NATSShutdownFlushEvents.Add(float64(events))
+}
+
+// SetNATSStreamReaderBackend marks backend as the stream reader backend in
+// use
+func SetNATSStreamReaderBackend(backend string) {
+	for _, b := range []string{"natsjs", "fallback"} {
+		value := 0.0
+		if b == backend {
+			value = 1
+		}
+		NATSStreamReaderBackend.WithLabelValues(b).Set(value)
+	}
+}
+
+// RecordNATSStreamReaderFallback records a stream read falling back from
+// the nats_js extension reader to the Go NATS client
+func RecordNATSStreamReaderFallback(operation string) {
+	NATSStreamReaderFallbacks.WithLabelValues(operation).Inc()
+}
+
+// RecordEventQuarantined records a playback event being quarantined
+func RecordEventQuarantined(source, reason string) {
+	EventsQuarantined.WithLabelValues(source, reason).Inc()
+}
+
+// RecordEventInvalid records a playback event skipped by validation
+func RecordEventInvalid(source string) {
+	EventsInvalid.WithLabelValues(source).Inc()
+}
+
 // UpdateNATSQueueDepth updates the NATS queue depth gauge
 func UpdateNATSQueueDepth(depth int64) {
 	NATSQueueDepth.Set(float64(depth))
@@ -585,7 +715,7 @@ func UpdateNATSConsumerLag(lag int64) {
 // Wrapped Report Metrics (Annual Wrapped / Year-in-Review Feature)
 var (
 	// WrappedReportGenerationDuration tracks the time to generate wrapped reports
-	WrappedReportGenerationDuration = promauto.NewHistogramVec(
+	WrappedReportGenerationDuration = factory.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "wrapped_report_generation_duration_seconds",
 			Help:    "Duration of wrapped report generation in seconds",
@@ -595,7 +725,7 @@ var (
 	)
 
 	// WrappedReportsGenerated counts total wrapped reports generated
-	WrappedReportsGenerated = promauto.NewCounterVec(
+	WrappedReportsGenerated = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "wrapped_reports_generated_total",
 			Help: "Total number of wrapped reports generated",
@@ -604,7 +734,7 @@ var (
 	)
 
 	// WrappedReportGenerationErrors counts errors during report generation
-	WrappedReportGenerationErrors = promauto.NewCounterVec(
+	WrappedReportGenerationErrors = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "wrapped_report_generation_errors_total",
 			Help: "Total number of wrapped report generation errors",
@@ -613,7 +743,7 @@ var (
 	)
 
 	// WrappedReportBatchSize tracks the number of reports generated in batch operations
-	WrappedReportBatchSize = promauto.NewHistogram(
+	WrappedReportBatchSize = factory.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "wrapped_report_batch_size",
 			Help:    "Number of wrapped reports generated in batch operations",
@@ -622,7 +752,7 @@ var (
 	)
 
 	// WrappedReportCacheHits counts successful cache hits for wrapped reports
-	WrappedReportCacheHits = promauto.NewCounterVec(
+	WrappedReportCacheHits = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "wrapped_report_cache_hits_total",
 			Help: "Total number of wrapped report cache hits",
@@ -631,7 +761,7 @@ var (
 	)
 
 	// WrappedReportCacheMisses counts cache misses for wrapped reports
-	WrappedReportCacheMisses = promauto.NewCounterVec(
+	WrappedReportCacheMisses = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "wrapped_report_cache_misses_total",
 			Help: "Total number of wrapped report cache misses",
@@ -640,7 +770,7 @@ var (
 	)
 
 	// WrappedShareTokensCreated counts share tokens created for wrapped reports
-	WrappedShareTokensCreated = promauto.NewCounter(
+	WrappedShareTokensCreated = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "wrapped_share_tokens_created_total",
 			Help: "Total number of share tokens created for wrapped reports",
@@ -648,7 +778,7 @@ var (
 	)
 
 	// WrappedShareTokenAccess counts accesses via share tokens
-	WrappedShareTokenAccess = promauto.NewCounter(
+	WrappedShareTokenAccess = factory.NewCounter(
 		prometheus.CounterOpts{
 			Name: "wrapped_share_token_access_total",
 			Help: "Total number of wrapped report accesses via share tokens",
@@ -656,7 +786,7 @@ var (
 	)
 
 	// WrappedLeaderboardQueries counts leaderboard queries
-	WrappedLeaderboardQueries = promauto.NewCounterVec(
+	WrappedLeaderboardQueries = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "wrapped_leaderboard_queries_total",
 			Help: "Total number of wrapped leaderboard queries",
@@ -665,7 +795,7 @@ var (
 	)
 
 	// WrappedActiveYear tracks which years have active reports
-	WrappedActiveYear = promauto.NewGaugeVec(
+	WrappedActiveYear = factory.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "wrapped_active_year_reports",
 			Help: "Number of reports available for each year",
@@ -736,7 +866,7 @@ func UpdateWrappedActiveYearReports(year int, count int64) {
 
 var (
 	// PATOperationsTotal counts PAT operations
-	PATOperationsTotal = promauto.NewCounterVec(
+	PATOperationsTotal = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "pat_operations_total",
 			Help: "Total number of PAT operations",
@@ -745,7 +875,7 @@ var (
 	)
 
 	// PATValidationsTotal counts PAT validation attempts
-	PATValidationsTotal = promauto.NewCounterVec(
+	PATValidationsTotal = factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "pat_validations_total",
 			Help: "Total number of PAT validation attempts",
@@ -754,7 +884,7 @@ var (
 	)
 
 	// PATActiveTokens tracks the number of active tokens
-	PATActiveTokens = promauto.NewGauge(
+	PATActiveTokens = factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "pat_active_tokens",
 			Help: "Current number of active (non-revoked, non-expired) PATs",
@@ -780,3 +910,215 @@ func RecordPATValidation(result string) {
 func SetPATActiveTokens(count int64) {
 	PATActiveTokens.Set(float64(count))
 }
+
+// =============================================================================
+// DuckDB Internals Metrics
+// =============================================================================
+
+var (
+	// DBFileSizeBytes tracks the size of the DuckDB database file on disk
+	DBFileSizeBytes = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "duckdb_database_size_bytes",
+			Help: "Size of the DuckDB database file on disk in bytes",
+		},
+	)
+
+	// DBWALSizeBytes tracks the size of the DuckDB write-ahead log on disk
+	DBWALSizeBytes = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "duckdb_wal_size_bytes",
+			Help: "Size of the DuckDB write-ahead log file on disk in bytes",
+		},
+	)
+
+	// DBMemoryUsageBytes tracks memory held by DuckDB's buffer manager
+	DBMemoryUsageBytes = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "duckdb_memory_usage_bytes",
+			Help: "Memory used by DuckDB in bytes (sum of duckdb_memory())",
+		},
+	)
+
+	// DBTableRows tracks approximate row counts for the main tables
+	DBTableRows = factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "duckdb_table_rows",
+			Help: "Approximate number of rows per table (DuckDB estimated_size)",
+		},
+		[]string{"table"},
+	)
+
+	// DBConnectionPool tracks database/sql connection pool state
+	DBConnectionPool = factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "duckdb_connection_pool_connections",
+			Help: "Database connections by state (open, in_use, idle, max_open)",
+		},
+		[]string{"state"},
+	)
+
+	// DBConnectionWaits tracks the cumulative number of waits for a connection
+	DBConnectionWaits = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "duckdb_connection_pool_wait_count",
+			Help: "Total number of times a query waited for a free connection",
+		},
+	)
+
+	// DBConnectionWaitSeconds tracks the cumulative time spent waiting for a connection
+	DBConnectionWaitSeconds = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "duckdb_connection_pool_wait_seconds",
+			Help: "Total time queries spent waiting for a free connection in seconds",
+		},
+	)
+
+	// DBHealthChecks counts connection pool health checks by result
+	DBHealthChecks = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "duckdb_health_checks_total",
+			Help: "Total number of connection pool health checks",
+		},
+		[]string{"result"}, // "healthy", "recycled", "busy", "failed"
+	)
+
+	// DBAnalyticsQueries tracks analytics queries holding or waiting for a slot
+	DBAnalyticsQueries = factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "duckdb_analytics_queries",
+			Help: "Analytics queries by state (running, queued)",
+		},
+		[]string{"state"},
+	)
+
+	// DBAnalyticsQueueWait tracks how long analytics queries waited for a slot
+	DBAnalyticsQueueWait = factory.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "duckdb_analytics_queue_wait_seconds",
+			Help:    "Time analytics queries waited for a concurrency slot in seconds",
+			Buckets: []float64{.005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+	)
+
+	// DBAnalyticsQueueAbandoned counts analytics queries that gave up waiting
+	DBAnalyticsQueueAbandoned = factory.NewCounter(
+		prometheus.CounterOpts{
+			Name: "duckdb_analytics_queue_abandoned_total",
+			Help: "Total number of analytics queries canceled or timed out while queued",
+		},
+	)
+
+	// DBStatsCollections counts database stats collection runs by result
+	DBStatsCollections = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "duckdb_stats_collections_total",
+			Help: "Total number of database stats collections",
+		},
+		[]string{"result"}, // "success", "error", "skipped"
+	)
+
+	// DBRetentionPrunedRows counts rows deleted by the retention policies
+	DBRetentionPrunedRows = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "duckdb_retention_pruned_rows_total",
+			Help: "Total number of rows deleted by the retention policies",
+		},
+		[]string{"table"},
+	)
+)
+
+// UpdateDBFileSizes sets the database and WAL file size gauges
+func UpdateDBFileSizes(dbBytes, walBytes int64) {
+	DBFileSizeBytes.Set(float64(dbBytes))
+	DBWALSizeBytes.Set(float64(walBytes))
+}
+
+// UpdateDBMemoryUsage sets the DuckDB memory usage gauge
+func UpdateDBMemoryUsage(bytes int64) {
+	DBMemoryUsageBytes.Set(float64(bytes))
+}
+
+// UpdateDBTableRows sets the approximate row count for a table
+func UpdateDBTableRows(table string, rows int64) {
+	DBTableRows.WithLabelValues(table).Set(float64(rows))
+}
+
+// UpdateDBConnectionPool sets the connection pool gauges from sql.DB stats
+func UpdateDBConnectionPool(stats sql.DBStats) {
+	DBConnectionPool.WithLabelValues("open").Set(float64(stats.OpenConnections))
+	DBConnectionPool.WithLabelValues("in_use").Set(float64(stats.InUse))
+	DBConnectionPool.WithLabelValues("idle").Set(float64(stats.Idle))
+	DBConnectionPool.WithLabelValues("max_open").Set(float64(stats.MaxOpenConnections))
+	DBConnectionPoolSize.Set(float64(stats.InUse))
+	DBConnectionWaits.Set(float64(stats.WaitCount))
+	DBConnectionWaitSeconds.Set(stats.WaitDuration.Seconds())
+}
+
+// RecordDBHealthCheck records the result of a connection pool health check
+func RecordDBHealthCheck(result string) {
+	DBHealthChecks.WithLabelValues(result).Inc()
+}
+
+// UpdateDBAnalyticsQueries sets the running and queued analytics query gauges
+func UpdateDBAnalyticsQueries(running, queued int) {
+	DBAnalyticsQueries.WithLabelValues("running").Set(float64(running))
+	DBAnalyticsQueries.WithLabelValues("queued").Set(float64(queued))
+}
+
+// RecordDBAnalyticsQueueWait records how long an analytics query waited for
+// a slot; abandoned waits are also counted separately
+func RecordDBAnalyticsQueueWait(wait time.Duration, abandoned bool) {
+	DBAnalyticsQueueWait.Observe(wait.Seconds())
+	if abandoned {
+		DBAnalyticsQueueAbandoned.Inc()
+	}
+}
+
+// RecordDBStatsCollection records the result of a database stats collection
+func RecordDBStatsCollection(result string) {
+	DBStatsCollections.WithLabelValues(result).Inc()
+}
+
+// RecordRetentionPruned records rows deleted from a table by the retention policies
+func RecordRetentionPruned(table string, rows int64) {
+	DBRetentionPrunedRows.WithLabelValues(table).Add(float64(rows))
+}
+
+// =============================================================================
+// Newsletter Delivery Metrics
+// =============================================================================
+
+var (
+	// NewsletterDeliveries counts per-recipient newsletter deliveries by
+	// channel and final result, after retries
+	NewsletterDeliveries = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "newsletter_deliveries_total",
+			Help: "Total number of newsletter deliveries to a recipient by channel and result",
+		},
+		[]string{"channel", "result"}, // "delivered", "failed"
+	)
+
+	// NewsletterDeliveryRetries counts retried newsletter send attempts by channel
+	NewsletterDeliveryRetries = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "newsletter_delivery_retries_total",
+			Help: "Total number of retried newsletter send attempts by channel",
+		},
+		[]string{"channel"},
+	)
+)
+
+// RecordNewsletterDelivery records the outcome of delivering a newsletter to
+// one recipient on one channel, and the retries it took
+func RecordNewsletterDelivery(channel string, delivered bool, retries int) {
+	result := "delivered"
+	if !delivered {
+		result = "failed"
+	}
+	NewsletterDeliveries.WithLabelValues(channel, result).Inc()
+	if retries > 0 {
+		NewsletterDeliveryRetries.WithLabelValues(channel).Add(float64(retries))
+	}
+}
@@ -571,14 +571,30 @@ func TestDBConnectionPoolSize(t *testing.T) {
 	DBConnectionPoolSize.Dec()
 }
 
-// TestSyncLastSuccess tests sync timestamp recording
+// TestSyncLastSuccess tests per-source sync timestamp and in-progress recording
 func TestSyncLastSuccess(t *testing.T) {
-	// Simulate successful sync
 	RecordSyncOperation(5*time.Second, 100, nil)
 
-	// Get the current value - should be recent
-	// Note: We can't easily get the value without more complex setup,
-	// but we verify no panic occurs
+	before := float64(time.Now().Unix())
+	RecordSyncSuccess("plex", "test-plex-1")
+	if got := testutil.ToFloat64(SyncLastSuccess.WithLabelValues("plex", "test-plex-1")); got < before {
+		t.Errorf("sync_last_success_timestamp = %v, want >= %v", got, before)
+	}
+	if got := testutil.ToFloat64(SyncLastSuccess.WithLabelValues("plex", "test-plex-2")); got != 0 {
+		t.Errorf("sync_last_success_timestamp of another server = %v, want 0", got)
+	}
+
+	inProgress := SyncInProgress.WithLabelValues("jellyfin", "test-jellyfin-1")
+	doneA := TrackSyncInProgress("jellyfin", "test-jellyfin-1")
+	doneB := TrackSyncInProgress("jellyfin", "test-jellyfin-1")
+	if got := testutil.ToFloat64(inProgress); got != 2 {
+		t.Errorf("sync_in_progress with two syncs = %v, want 2", got)
+	}
+	doneA()
+	doneB()
+	if got := testutil.ToFloat64(inProgress); got != 0 {
+		t.Errorf("sync_in_progress after both finished = %v, want 0", got)
+	}
 }
 
 // TestMetricsRegistration verifies all metrics are properly registered
@@ -601,6 +617,7 @@ func TestMetricsRegistration(t *testing.T) {
 		SyncRecordsProcessed,
 		SyncErrors,
 		SyncLastSuccess,
+		SyncInProgress,
 		SyncBatchSize,
 		GeolocationBatchSize,
 		GeolocationCacheHits,
@@ -687,8 +704,14 @@ func TestMetricGathering(t *testing.T) {
 	RecordDBQuery("TEST", "test_table", time.Millisecond, nil)
 	RecordAPIRequest("GET", "/test", "200", time.Millisecond)
 
+	reg := prometheus.NewRegistry()
+	if err := InitWithRegistry(reg); err != nil {
+		t.Fatalf("InitWithRegistry() error = %v", err)
+	}
+	t.Cleanup(func() { _ = Init() })
+
 	// Verify we can lint the metrics (checks for consistency issues)
-	problems, err := testutil.GatherAndLint(prometheus.DefaultGatherer)
+	problems, err := testutil.GatherAndLint(reg)
 	if err != nil {
 		t.Logf("Lint errors (may be expected): %v", err)
 	}
@@ -0,0 +1,104 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// collectorList is a prometheus.Registerer that only records collectors.
+// The package metrics are created through it so that nothing is registered
+// until Init or InitWithRegistry picks the registry.
+type collectorList struct {
+	collectors []prometheus.Collector
+}
+
+func (l *collectorList) Register(c prometheus.Collector) error {
+	l.collectors = append(l.collectors, c)
+	return nil
+}
+
+func (l *collectorList) MustRegister(cs ...prometheus.Collector) {
+	l.collectors = append(l.collectors, cs...)
+}
+
+func (l *collectorList) Unregister(prometheus.Collector) bool {
+	return false
+}
+
+var (
+	// collectors holds every metric of this package, in creation order
+	collectors collectorList
+
+	// factory creates the package metrics without registering them
+	factory = promauto.With(&collectors)
+)
+
+var (
+	registryMu sync.Mutex
+	// registerer and gatherer are the registry picked by the last Init or
+	// InitWithRegistry; Handler serves gatherer
+	registerer prometheus.Registerer = prometheus.DefaultRegisterer
+	gatherer   prometheus.Gatherer   = prometheus.DefaultGatherer
+)
+
+// Init registers the package metrics with the default Prometheus registry,
+// which also holds the Go runtime and process collectors and the metrics
+// other packages create with promauto. Calling it again is a no-op.
+func Init() error {
+	return initRegistry(prometheus.DefaultRegisterer, prometheus.DefaultGatherer)
+}
+
+// InitWithRegistry registers the package metrics with reg instead of the
+// default registry and makes Handler serve reg, so Cartographus can run
+// next to another service, or in several tests, without duplicate
+// registration panics. Registering with the same registry again is a no-op.
+//
+// Metrics that other packages create with promauto stay in the default
+// registry and are not served from reg.
+func InitWithRegistry(reg *prometheus.Registry) error {
+	if reg == nil {
+		return errors.New("metrics: nil registry")
+	}
+	return initRegistry(reg, reg)
+}
+
+func initRegistry(reg prometheus.Registerer, g prometheus.Gatherer) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, c := range collectors.collectors {
+		if err := reg.Register(c); err != nil {
+			// The same collector from an earlier Init is fine; a different
+			// one with the same name belongs to someone else
+			var are prometheus.AlreadyRegisteredError
+			if errors.As(err, &are) && are.ExistingCollector == c {
+				continue
+			}
+			return fmt.Errorf("metrics: register collector: %w", err)
+		}
+	}
+	registerer, gatherer = reg, g
+	return nil
+}
+
+// Handler serves the registry picked by Init or InitWithRegistry (the
+// default registry before either is called) in the Prometheus exposition
+// format, with the promhttp_metric_handler_* metrics of the handler itself.
+func Handler() http.Handler {
+	registryMu.Lock()
+	reg, g := registerer, gatherer
+	registryMu.Unlock()
+
+	return promhttp.InstrumentMetricHandler(reg, promhttp.HandlerFor(g, promhttp.HandlerOpts{}))
+}
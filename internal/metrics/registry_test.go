@@ -0,0 +1,85 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestInitWithRegistry(t *testing.T) {
+	t.Cleanup(func() { _ = Init() })
+
+	reg := prometheus.NewRegistry()
+	if err := InitWithRegistry(reg); err != nil {
+		t.Fatalf("InitWithRegistry() error = %v", err)
+	}
+	// Registering again with the same registry is a no-op
+	if err := InitWithRegistry(reg); err != nil {
+		t.Fatalf("second InitWithRegistry() error = %v", err)
+	}
+	// A second, independent registry gets the same collectors
+	if err := InitWithRegistry(prometheus.NewRegistry()); err != nil {
+		t.Fatalf("InitWithRegistry() with another registry error = %v", err)
+	}
+
+	if err := InitWithRegistry(nil); err == nil {
+		t.Error("InitWithRegistry(nil) error = nil, want an error")
+	}
+}
+
+func TestInitWithRegistry_Conflict(t *testing.T) {
+	t.Cleanup(func() { _ = Init() })
+
+	// A host service already exports a metric with one of our names
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "tile_cache_hits_total",
+		Help: "Host metric with the same name",
+	}))
+
+	if err := InitWithRegistry(reg); err == nil {
+		t.Error("InitWithRegistry() error = nil, want a registration conflict")
+	}
+}
+
+func TestInit_Idempotent(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		if err := Init(); err != nil {
+			t.Fatalf("Init() call %d error = %v", i+1, err)
+		}
+	}
+}
+
+func TestHandler_ServesSelectedRegistry(t *testing.T) {
+	t.Cleanup(func() { _ = Init() })
+
+	reg := prometheus.NewRegistry()
+	if err := InitWithRegistry(reg); err != nil {
+		t.Fatalf("InitWithRegistry() error = %v", err)
+	}
+	TileCacheHits.Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+
+	if !strings.Contains(string(body), "tile_cache_hits_total") {
+		t.Error("response is missing the package metrics")
+	}
+	// The isolated registry has no Go runtime collector
+	if strings.Contains(string(body), "go_goroutines") {
+		t.Error("response contains default registry metrics")
+	}
+}
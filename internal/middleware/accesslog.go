@@ -0,0 +1,426 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+)
+
+// DefaultAccessLogMaxBodyBytes is the default number of body bytes captured
+// per request and response at debug level.
+const DefaultAccessLogMaxBodyBytes = 2048
+
+// redactedValue replaces sensitive header, field, and parameter values.
+const redactedValue = "[REDACTED]"
+
+// AccessLogConfig configures the access log middleware.
+type AccessLogConfig struct {
+	// Logger receives the access log lines. Defaults to the global logger
+	// with component=access.
+	Logger *zerolog.Logger
+
+	// MaxBodyBytes limits how much of each body is captured at debug level.
+	// Default: DefaultAccessLogMaxBodyBytes.
+	MaxBodyBytes int
+
+	// BodySampleRate is the fraction of requests (0-1] whose headers and
+	// bodies are captured at debug level. Default: 1 (every request).
+	BodySampleRate float64
+
+	// SuccessSampleRate is the fraction of requests (0-1] with a status
+	// below 400 that are logged. Client and server errors and slow
+	// requests are always logged. Default: 1 (every request).
+	SuccessSampleRate float64
+
+	// SlowThreshold marks requests that take at least this long as slow:
+	// they are logged at warn level with slow=true regardless of sampling.
+	// Zero disables slow request detection.
+	SlowThreshold time.Duration
+}
+
+// AccessLogger emits one structured log line per HTTP request.
+//
+// Every line carries request_id, correlation_id, method, route (the chi
+// route pattern, so /users/42 and /users/7 share a route), status, bytes,
+// duration, client_ip, the authenticated principal when there is one, and
+// the query string with credentials redacted. When the global log level is
+// debug, request headers and a prefix of the request and response bodies
+// are added, also redacted.
+//
+// Successful requests can be sampled with SuccessSampleRate; 4xx and 5xx
+// responses and requests slower than SlowThreshold are always logged.
+type AccessLogger struct {
+	logger        zerolog.Logger
+	maxBodyBytes  int
+	sampleRate    float64
+	successRate   float64
+	slowThreshold time.Duration
+}
+
+// NewAccessLogger creates an access logger.
+func NewAccessLogger(cfg AccessLogConfig) *AccessLogger {
+	a := &AccessLogger{
+		maxBodyBytes:  cfg.MaxBodyBytes,
+		sampleRate:    cfg.BodySampleRate,
+		successRate:   cfg.SuccessSampleRate,
+		slowThreshold: cfg.SlowThreshold,
+	}
+	if cfg.Logger != nil {
+		a.logger = *cfg.Logger
+	} else {
+		a.logger = logging.With().Str("component", "access").Logger()
+	}
+	if a.maxBodyBytes <= 0 {
+		a.maxBodyBytes = DefaultAccessLogMaxBodyBytes
+	}
+	if a.sampleRate <= 0 || a.sampleRate > 1 {
+		a.sampleRate = 1
+	}
+	if a.successRate <= 0 || a.successRate > 1 {
+		a.successRate = 1
+	}
+	return a
+}
+
+// accessLogPrincipalKey is the context key for the request's principal
+// holder, set by the access logger and filled in by authentication.
+type accessLogPrincipalKey struct{}
+
+// SetPrincipal records the authenticated user for the request's access log
+// line. Authentication middleware calls it once the user is known; it is a
+// no-op for requests without an access logger.
+func SetPrincipal(ctx context.Context, principal string) {
+	if holder, ok := ctx.Value(accessLogPrincipalKey{}).(*atomic.Pointer[string]); ok {
+		holder.Store(&principal)
+	}
+}
+
+// Middleware wraps a handler with access logging. It should run after
+// request ID assignment so the line carries the request's IDs.
+func (a *AccessLogger) Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		// Authentication runs deeper in the chain; it reports the user
+		// through this holder since its context never reaches us
+		principal := &atomic.Pointer[string]{}
+		r = r.WithContext(context.WithValue(r.Context(), accessLogPrincipalKey{}, principal))
+
+		var reqBody []byte
+		var reqTruncated bool
+		var respBody *limitedBuffer
+		capture := logging.IsLevelEnabled(zerolog.DebugLevel) &&
+			(a.sampleRate >= 1 || rand.Float64() < a.sampleRate) //nolint:gosec // sampling, not security
+		if capture {
+			if capturableContentType(r.Header.Get("Content-Type")) {
+				reqBody, reqTruncated = a.peekRequestBody(r)
+			}
+			respBody = &limitedBuffer{max: a.maxBodyBytes}
+			ww.Tee(respBody)
+		}
+
+		next(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		duration := time.Since(start)
+		slow := a.slowThreshold > 0 && duration >= a.slowThreshold
+
+		var event *zerolog.Event
+		switch {
+		case status >= http.StatusInternalServerError:
+			event = a.logger.Error()
+		case slow:
+			event = a.logger.Warn().Bool("slow", true)
+		case status < http.StatusBadRequest && a.successRate < 1 &&
+			rand.Float64() >= a.successRate: //nolint:gosec // sampling, not security
+			return
+		default:
+			event = a.logger.Info()
+		}
+		event = event.
+			Str("request_id", logging.RequestIDFromContext(r.Context())).
+			Str("method", r.Method).
+			Str("route", routePattern(r)).
+			Int("status", status).
+			Int("bytes", ww.BytesWritten()).
+			Dur("duration", duration).
+			Str("client_ip", clientIP(r))
+		if id := logging.CorrelationIDFromContext(r.Context()); id != "" {
+			event = event.Str("correlation_id", id)
+		}
+		if p := principal.Load(); p != nil && *p != "" {
+			event = event.Str("principal", *p)
+		}
+		if r.URL.RawQuery != "" {
+			event = event.Str("query", redactQuery(r.URL.RawQuery))
+		}
+
+		if capture {
+			event = event.
+				Str("path", r.URL.Path).
+				Interface("request_headers", redactHeaders(r.Header))
+			if len(reqBody) > 0 {
+				event = event.Str("request_body", redactBody(reqBody, r.Header.Get("Content-Type"), reqTruncated))
+			}
+			if respBody.Len() > 0 && ww.Header().Get("Content-Encoding") == "" &&
+				capturableContentType(ww.Header().Get("Content-Type")) {
+				event = event.Str("response_body", redactBody(respBody.Bytes(), ww.Header().Get("Content-Type"), respBody.truncated))
+			}
+		}
+
+		event.Msg("HTTP request")
+	}
+}
+
+// peekRequestBody reads up to maxBodyBytes of the request body and puts the
+// bytes back so the handler still sees the whole body.
+func (a *AccessLogger) peekRequestBody(r *http.Request) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(r.Body, int64(a.maxBodyBytes)+1))
+	r.Body = &peekedBody{Reader: io.MultiReader(bytes.NewReader(buf), r.Body), Closer: r.Body}
+	if err != nil {
+		return nil, false
+	}
+	if len(buf) > a.maxBodyBytes {
+		return buf[:a.maxBodyBytes], true
+	}
+	return buf, false
+}
+
+// peekedBody replays the peeked prefix before the rest of the body.
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedBuffer keeps the first max bytes written to it and discards the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+// Write always reports success so the tee never interrupts the response.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.max - b.Buffer.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.Buffer.Write(p[:remaining])
+			b.truncated = true
+		} else {
+			b.Buffer.Write(p)
+		}
+	} else if len(p) > 0 {
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+// routePattern returns the chi route pattern matched by the request.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return "unmatched"
+}
+
+// clientIP returns the client address without the port. The access logger
+// runs after chi's RealIP, so behind a proxy this is the forwarded client
+// address rather than the proxy's.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// capturableContentType reports whether a body of this type is text that is
+// worth logging. Uploads, images, and compressed data are skipped.
+func capturableContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" ||
+		mediaType == "application/x-www-form-urlencoded" ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasPrefix(mediaType, "text/")
+}
+
+// sensitiveNameParts are substrings of header, field, and parameter names
+// whose values are never logged.
+var sensitiveNameParts = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"api_key",
+	"api-key",
+	"authorization",
+	"cookie",
+	"credential",
+	"session",
+	"private_key",
+}
+
+// isSensitiveName reports whether a header, field, or parameter name holds
+// a credential.
+func isSensitiveName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHeaders flattens headers for logging with credential values replaced.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if isSensitiveName(name) {
+			out[name] = redactedValue
+			continue
+		}
+		out[name] = redactBearer(strings.Join(values, ", "))
+	}
+	return out
+}
+
+// redactQuery replaces sensitive query parameter values.
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return redactText(rawQuery)
+	}
+	redactValues(values)
+	return values.Encode()
+}
+
+// redactValues replaces sensitive query or form values. Besides the names
+// caught by isSensitiveName, a bare "key" parameter (and *_key, *-key) is
+// treated as an API key.
+func redactValues(values url.Values) {
+	for name := range values {
+		lower := strings.ToLower(name)
+		if isSensitiveName(name) || lower == "key" ||
+			strings.HasSuffix(lower, "_key") || strings.HasSuffix(lower, "-key") {
+			values[name] = []string{redactedValue}
+		}
+	}
+}
+
+// redactBody returns a body for logging with credentials replaced. JSON and
+// form bodies are redacted by field name; anything else, including bodies
+// cut off at the capture limit, falls back to pattern matching.
+func redactBody(body []byte, contentType string, truncated bool) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType) //nolint:errcheck // empty type falls through to text
+	var out string
+
+	switch {
+	case !truncated && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")):
+		var v interface{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err == nil {
+			if redacted, err := json.Marshal(redactJSON(v)); err == nil {
+				out = string(redacted)
+				break
+			}
+		}
+		out = redactText(string(body))
+	case !truncated && mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			redactValues(values)
+			out = values.Encode()
+			break
+		}
+		out = redactText(string(body))
+	default:
+		out = redactText(string(body))
+	}
+
+	if truncated {
+		out += "...(truncated)"
+	}
+	return out
+}
+
+// redactJSON walks a decoded JSON value, replacing values of sensitive keys.
+func redactJSON(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if isSensitiveName(k) {
+				val[k] = redactedValue
+			} else {
+				val[k] = redactJSON(child)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactJSON(child)
+		}
+		return val
+	case string:
+		return redactBearer(val)
+	default:
+		return val
+	}
+}
+
+var (
+	// sensitiveAssignmentPattern matches key=value and "key": "value" pairs
+	// with a sensitive key, as found in partial JSON and form bodies.
+	sensitiveAssignmentPattern = regexp.MustCompile(
+		`(?i)("?[\w-]*(?:password|passwd|secret|token|api_?key|api-key|authorization|cookie|credential|session|private_key)[\w-]*"?\s*[:=]\s*)("[^"]*"?|[^&\s,}]+)`)
+
+	// bearerPattern matches bearer credentials anywhere in a value.
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+)
+
+// redactText redacts credentials in unstructured text.
+func redactText(s string) string {
+	s = sensitiveAssignmentPattern.ReplaceAllString(s, `${1}"`+redactedValue+`"`)
+	return redactBearer(s)
+}
+
+func redactBearer(s string) string {
+	return bearerPattern.ReplaceAllString(s, "${1} "+redactedValue)
+}
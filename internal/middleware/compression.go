@@ -9,27 +9,29 @@ import (
 	"compress/gzip"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/andybalholm/brotli"
 )
 
-// gzipResponseWriter wraps http.ResponseWriter to support gzip compression
-type gzipResponseWriter struct {
-	io.Writer
-	http.ResponseWriter
-	wroteHeader bool
-}
+const (
+	// DefaultBrotliQuality balances ratio and CPU for dynamic responses (0-11)
+	DefaultBrotliQuality = 5
 
-func (w *gzipResponseWriter) WriteHeader(status int) {
-	w.wroteHeader = true
-	w.ResponseWriter.WriteHeader(status)
-}
+	// compressionMinSize is the smallest body worth compressing
+	compressionMinSize = 1024
 
-func (w *gzipResponseWriter) Write(b []byte) (int, error) {
-	if !w.wroteHeader {
-		w.WriteHeader(http.StatusOK)
-	}
-	return w.Writer.Write(b)
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// compressor is the common interface of *gzip.Writer and *brotli.Writer
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
 }
 
 // gzipWriterPool pools gzip writers to reduce allocations
@@ -39,36 +41,216 @@ var gzipWriterPool = sync.Pool{
 	},
 }
 
-// Compression middleware adds gzip compression to responses
-// Only compresses responses > 1KB to avoid overhead for small payloads
-func Compression(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Check if client accepts gzip
-		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
-			next(w, r)
-			return
+// compressResponseWriter buffers the first compressionMinSize bytes of a
+// response before deciding whether to compress it. Bodies that finish below
+// the threshold are sent uncompressed; larger bodies, and streams that Flush
+// before reaching it, are compressed with the negotiated encoding.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	pool     *sync.Pool
+
+	enc         compressor // non-nil once compression has started
+	passthrough bool       // decided not to compress
+	buf         []byte
+	status      int
+	wroteHeader bool
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader || w.status != 0 {
+		return
+	}
+	w.status = status
+
+	// Bodyless responses are never compressed
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		w.passthrough = true
+		w.writeHeader()
+	}
+}
+
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		w.writeHeader()
+		return w.ResponseWriter.Write(b)
+	}
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) >= compressionMinSize {
+		if err := w.start(); err != nil {
+			return 0, err
 		}
+	}
+	return len(b), nil
+}
 
-		// Don't compress WebSocket connections
-		if r.Header.Get("Upgrade") == "websocket" {
-			next(w, r)
+// Flush commits to compression (a flushed response is a stream of unknown
+// length) and pushes compressed data to the client.
+func (w *compressResponseWriter) Flush() {
+	if w.enc == nil && !w.passthrough {
+		if err := w.start(); err != nil {
 			return
 		}
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush() // Best effort; the next Write surfaces any error
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// start sends the headers and buffered bytes, compressing them unless the
+// handler already encoded the body itself.
+func (w *compressResponseWriter) start() error {
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		w.passthrough = true
+		return w.flushBuffer(w.ResponseWriter)
+	}
+
+	h.Set("Content-Encoding", w.encoding)
+	h.Del("Content-Length") // Length will be different after compression
+
+	w.enc = w.pool.Get().(compressor)
+	w.enc.Reset(w.ResponseWriter)
+	return w.flushBuffer(w.enc)
+}
+
+func (w *compressResponseWriter) flushBuffer(dst io.Writer) error {
+	w.writeHeader()
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := dst.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *compressResponseWriter) writeHeader() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// finish completes the response after the handler returns
+func (w *compressResponseWriter) finish() {
+	if w.enc != nil {
+		_ = w.enc.Close() // Explicitly ignore error - best-effort cleanup, response already sent
+		w.enc.Reset(io.Discard)
+		w.pool.Put(w.enc)
+		w.enc = nil
+		return
+	}
+
+	// Body stayed under the threshold: send it as-is
+	if !w.passthrough {
+		w.passthrough = true
+		_ = w.flushBuffer(w.ResponseWriter)
+	}
+}
+
+// negotiateEncoding picks the response encoding from an Accept-Encoding
+// header: the acceptable encoding with the highest q-value, preferring br
+// over gzip on a tie. Returns "" if neither is acceptable.
+func negotiateEncoding(acceptEncoding string) string {
+	brQ, gzipQ, anyQ := -1.0, -1.0, -1.0
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case encodingBrotli:
+			brQ = q
+		case encodingGzip, "x-gzip":
+			gzipQ = q
+		case "*":
+			anyQ = q
+		}
+	}
+
+	// A wildcard covers encodings that are not listed explicitly
+	if brQ < 0 {
+		brQ = anyQ
+	}
+	if gzipQ < 0 {
+		gzipQ = anyQ
+	}
+
+	switch {
+	case brQ > 0 && brQ >= gzipQ:
+		return encodingBrotli
+	case gzipQ > 0:
+		return encodingGzip
+	default:
+		return ""
+	}
+}
+
+// Compression middleware adds Brotli or gzip compression to responses using
+// DefaultBrotliQuality. See CompressionWithQuality.
+func Compression(next http.HandlerFunc) http.HandlerFunc {
+	return CompressionWithQuality(DefaultBrotliQuality)(next)
+}
+
+// CompressionWithQuality returns compression middleware that negotiates the
+// encoding via Accept-Encoding (br preferred over gzip, identity otherwise)
+// and uses the given Brotli quality level (0-11; out-of-range values are clamped).
+// Only compresses responses > 1KB to avoid overhead for small payloads.
+func CompressionWithQuality(brotliQuality int) func(http.HandlerFunc) http.HandlerFunc {
+	brotliQuality = min(max(brotliQuality, brotli.BestSpeed), brotli.BestCompression)
+	brotliWriterPool := &sync.Pool{
+		New: func() interface{} {
+			return brotli.NewWriterLevel(io.Discard, brotliQuality)
+		},
+	}
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			// Don't compress WebSocket connections
+			if r.Header.Get("Upgrade") == "websocket" {
+				next(w, r)
+				return
+			}
 
-		// Get gzip writer from pool
-		gz := gzipWriterPool.Get().(*gzip.Writer)
-		defer gzipWriterPool.Put(gz)
-		gz.Reset(w) // Reset always succeeds for http.ResponseWriter
-		defer func() {
-			_ = gz.Close() // Explicitly ignore error - best-effort cleanup, response already sent
-		}()
-
-		// Set Content-Encoding header
-		w.Header().Set("Content-Encoding", "gzip")
-		w.Header().Del("Content-Length") // Length will be different after compression
-
-		// Wrap response writer
-		gzw := &gzipResponseWriter{Writer: gz, ResponseWriter: w}
-		next(gzw, r)
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				next(w, r)
+				return
+			}
+
+			pool := &gzipWriterPool
+			if encoding == encodingBrotli {
+				pool = brotliWriterPool
+			}
+
+			cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding, pool: pool}
+			defer cw.finish()
+			next(cw, r)
+		}
 	}
 }
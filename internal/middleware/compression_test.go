@@ -6,12 +6,16 @@
 package middleware
 
 import (
+	"bytes"
 	"compress/gzip"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/andybalholm/brotli"
 )
 
 func TestCompression_WithGzipAccept(t *testing.T) {
@@ -143,64 +147,225 @@ func TestCompression_PartialGzipAccept(t *testing.T) {
 	compressedHandler := Compression(handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
-	req.Header.Set("Accept-Encoding", "deflate, gzip, br")
+	req.Header.Set("Accept-Encoding", "deflate, gzip;q=0.9, br;q=0")
 	rec := httptest.NewRecorder()
 
 	compressedHandler(rec, req)
 
-	// Should still compress because gzip is in the list
+	// Should still compress because gzip is in the list (br is explicitly refused)
 	if rec.Header().Get("Content-Encoding") != "gzip" {
 		t.Error("Expected gzip compression when Accept-Encoding includes gzip")
 	}
 }
 
-func TestGzipResponseWriter_WriteHeader(t *testing.T) {
+func TestCompression_Negotiation(t *testing.T) {
+	body := strings.Repeat(`{"country":"United States","playbacks":1234},`, 100) // ~4.5KB of JSON
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wantEncoding   string
+	}{
+		{name: "br only", acceptEncoding: "br", wantEncoding: "br"},
+		{name: "gzip only", acceptEncoding: "gzip", wantEncoding: "gzip"},
+		{name: "both prefers br", acceptEncoding: "gzip, deflate, br", wantEncoding: "br"},
+		{name: "neither", acceptEncoding: "", wantEncoding: ""},
+		{name: "unsupported only", acceptEncoding: "deflate, zstd", wantEncoding: ""},
+		{name: "gzip higher q", acceptEncoding: "br;q=0.5, gzip", wantEncoding: "gzip"},
+		{name: "identity only", acceptEncoding: "identity", wantEncoding: ""},
+		{name: "wildcard", acceptEncoding: "*", wantEncoding: "br"},
+		{name: "wildcard without br", acceptEncoding: "br;q=0, *", wantEncoding: "gzip"},
+		{name: "all refused", acceptEncoding: "br;q=0, gzip;q=0", wantEncoding: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compression(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+				_, _ = w.Write([]byte(body))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/geographic", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+			if tt.wantEncoding != "" && rec.Header().Get("Content-Length") != "" {
+				t.Error("Expected Content-Length header to be removed")
+			}
+
+			if got := decodeBody(t, tt.wantEncoding, rec.Body); got != body {
+				t.Errorf("decoded body mismatch: got %d bytes, want %d", len(got), len(body))
+			}
+			if tt.wantEncoding != "" && rec.Body.Len() >= len(body) {
+				t.Errorf("compressed size %d not smaller than original %d", rec.Body.Len(), len(body))
+			}
+		})
+	}
+}
+
+func TestCompression_BrotliBetterThanGzipForJSON(t *testing.T) {
+	body := strings.Repeat(`{"user":"alice","title":"Movie","platform":"Chrome","duration":7200},`, 200)
+	handler := Compression(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+
+	sizes := make(map[string]int)
+	for _, encoding := range []string{"br", "gzip"} {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Accept-Encoding", encoding)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		sizes[encoding] = rec.Body.Len()
+	}
+
+	if sizes["br"] >= sizes["gzip"] {
+		t.Errorf("br size %d not smaller than gzip size %d", sizes["br"], sizes["gzip"])
+	}
+}
+
+func TestCompressionWithQuality(t *testing.T) {
+	body := strings.Repeat("quality level test data ", 200)
+
+	for _, quality := range []int{-1, 0, 5, 11, 99} {
+		t.Run(strconv.Itoa(quality), func(t *testing.T) {
+			handler := CompressionWithQuality(quality)(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(body))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Accept-Encoding", "br")
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Header().Get("Content-Encoding") != "br" {
+				t.Fatalf("Content-Encoding = %q, want br", rec.Header().Get("Content-Encoding"))
+			}
+			if got := decodeBody(t, "br", rec.Body); got != body {
+				t.Error("decoded body mismatch")
+			}
+		})
+	}
+}
+
+func TestCompression_StreamingFlush(t *testing.T) {
+	for _, encoding := range []string{"br", "gzip"} {
+		t.Run(encoding, func(t *testing.T) {
+			var flushedBytes int
+			handler := Compression(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte("data: first\n\n"))
+				w.(http.Flusher).Flush()
+				flushedBytes = w.(interface{ Unwrap() http.ResponseWriter }).Unwrap().(*httptest.ResponseRecorder).Body.Len()
+				_, _ = w.Write([]byte("data: second\n\n"))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			// A flushed stream is compressed even below the size threshold
+			if rec.Header().Get("Content-Encoding") != encoding {
+				t.Fatalf("Content-Encoding = %q, want %q", rec.Header().Get("Content-Encoding"), encoding)
+			}
+			if flushedBytes == 0 {
+				t.Error("Flush did not push compressed data to the client")
+			}
+			if !rec.Flushed {
+				t.Error("Flush was not propagated to the underlying writer")
+			}
+			if got := decodeBody(t, encoding, rec.Body); got != "data: first\n\ndata: second\n\n" {
+				t.Errorf("decoded body = %q", got)
+			}
+		})
+	}
+}
+
+func TestCompression_PreEncodedResponse(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, _ = gz.Write([]byte(strings.Repeat("backup archive ", 200)))
+	_ = gz.Close()
+	payload := bytes.Repeat(gzipped.Bytes(), 20) // >1KB
+
+	handler := Compression(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(payload)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/download", nil)
+	req.Header.Set("Accept-Encoding", "br")
 	rec := httptest.NewRecorder()
-	gz := gzip.NewWriter(rec)
-	defer gz.Close()
+	handler(rec, req)
 
-	gzw := &gzipResponseWriter{
-		Writer:         gz,
-		ResponseWriter: rec,
-		wroteHeader:    false,
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Errorf("Content-Encoding = %q, want handler's gzip", rec.Header().Get("Content-Encoding"))
 	}
+	if !bytes.Equal(rec.Body.Bytes(), payload) {
+		t.Error("pre-encoded body was modified")
+	}
+}
 
-	// Test WriteHeader
-	gzw.WriteHeader(http.StatusCreated)
+func TestCompressResponseWriter_WriteHeader(t *testing.T) {
+	rec := httptest.NewRecorder()
+	cw := &compressResponseWriter{ResponseWriter: rec, encoding: "gzip", pool: &gzipWriterPool}
 
-	if !gzw.wroteHeader {
-		t.Error("Expected wroteHeader to be true after WriteHeader")
+	// Status is held until the compression decision is made
+	cw.WriteHeader(http.StatusCreated)
+	if cw.wroteHeader {
+		t.Error("Expected WriteHeader to be deferred until the body is known")
 	}
 
+	cw.finish()
+	if !cw.wroteHeader {
+		t.Error("Expected wroteHeader to be true after finish")
+	}
 	if rec.Code != http.StatusCreated {
 		t.Errorf("Expected status code 201, got %d", rec.Code)
 	}
 }
 
-func TestGzipResponseWriter_Write(t *testing.T) {
+func TestCompressResponseWriter_Write(t *testing.T) {
 	rec := httptest.NewRecorder()
-	gz := gzip.NewWriter(rec)
-	defer gz.Close()
+	cw := &compressResponseWriter{ResponseWriter: rec, encoding: "gzip", pool: &gzipWriterPool}
 
-	gzw := &gzipResponseWriter{
-		Writer:         gz,
-		ResponseWriter: rec,
-		wroteHeader:    false,
-	}
-
-	// Write should set default status if not already set
+	// Writes below the threshold are buffered
 	data := []byte("test data")
-	n, err := gzw.Write(data)
+	n, err := cw.Write(data)
 	if err != nil {
 		t.Fatalf("Write failed: %v", err)
 	}
-
 	if n != len(data) {
 		t.Errorf("Expected to write %d bytes, wrote %d", len(data), n)
 	}
+	if cw.wroteHeader || rec.Body.Len() != 0 {
+		t.Error("Expected small write to be buffered")
+	}
 
-	if !gzw.wroteHeader {
-		t.Error("Expected wroteHeader to be true after Write")
+	// Crossing the threshold starts compression with the default 200 status
+	if _, err := cw.Write(bytes.Repeat([]byte("x"), compressionMinSize)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !cw.wroteHeader || cw.enc == nil {
+		t.Error("Expected compression to start once the threshold is reached")
+	}
+	cw.finish()
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", rec.Code)
+	}
+	if got := decodeBody(t, "gzip", rec.Body); got != "test data"+strings.Repeat("x", compressionMinSize) {
+		t.Error("decoded body mismatch")
 	}
 }
 
@@ -218,9 +383,9 @@ func TestCompression_EmptyResponse(t *testing.T) {
 
 	compressedHandler(rec, req)
 
-	// Even with empty body, gzip headers should be set
-	if rec.Header().Get("Content-Encoding") != "gzip" {
-		t.Error("Expected Content-Encoding: gzip even for empty response")
+	// Bodyless responses must not carry a Content-Encoding
+	if rec.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Expected no Content-Encoding for 204, got %q", rec.Header().Get("Content-Encoding"))
 	}
 
 	if rec.Code != http.StatusNoContent {
@@ -229,11 +394,9 @@ func TestCompression_EmptyResponse(t *testing.T) {
 }
 
 func TestCompression_SmallResponse(t *testing.T) {
-	// Test with small response (< 1KB)
-	// Note: According to the comment, it should only compress > 1KB,
-	// but the implementation doesn't actually enforce this
+	// Responses under 1KB are sent uncompressed
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
+		w.WriteHeader(http.StatusAccepted)
 		_, err := w.Write([]byte("small"))
 		if err != nil {
 			t.Fatalf("Failed to write response: %v", err)
@@ -242,17 +405,49 @@ func TestCompression_SmallResponse(t *testing.T) {
 
 	compressedHandler := Compression(handler)
 
-	req := httptest.NewRequest(http.MethodGet, "/test", nil)
-	req.Header.Set("Accept-Encoding", "gzip")
-	rec := httptest.NewRecorder()
+	for _, encoding := range []string{"br", "gzip"} {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Accept-Encoding", encoding)
+		rec := httptest.NewRecorder()
 
-	compressedHandler(rec, req)
+		compressedHandler(rec, req)
 
-	// Currently the implementation compresses all responses
-	// regardless of size when gzip is accepted
-	if rec.Header().Get("Content-Encoding") != "gzip" {
-		t.Error("Expected gzip compression for small response")
+		if rec.Header().Get("Content-Encoding") != "" {
+			t.Errorf("%s: expected no compression for small response, got %q", encoding, rec.Header().Get("Content-Encoding"))
+		}
+		if rec.Code != http.StatusAccepted {
+			t.Errorf("%s: expected status code 202, got %d", encoding, rec.Code)
+		}
+		if rec.Body.String() != "small" {
+			t.Errorf("%s: expected uncompressed body, got %q", encoding, rec.Body.String())
+		}
+	}
+}
+
+// decodeBody decodes a response body according to its Content-Encoding.
+func decodeBody(t *testing.T, encoding string, body io.Reader) string {
+	t.Helper()
+
+	var reader io.Reader
+	switch encoding {
+	case "br":
+		reader = brotli.NewReader(body)
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			t.Fatalf("Failed to create gzip reader: %v", err)
+		}
+		defer gz.Close()
+		reader = gz
+	default:
+		reader = body
+	}
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read decompressed data: %v", err)
 	}
+	return string(decoded)
 }
 
 func BenchmarkCompression(b *testing.B) {
@@ -292,3 +487,22 @@ func BenchmarkCompressionWithoutGzip(b *testing.B) {
 		compressedHandler(rec, req)
 	}
 }
+
+func BenchmarkCompressionBrotli(b *testing.B) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := strings.Repeat("benchmark data ", 100)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(data))
+	})
+
+	compressedHandler := Compression(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Encoding", "br")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rec := httptest.NewRecorder()
+		compressedHandler(rec, req)
+	}
+}
@@ -13,10 +13,12 @@ middleware stack for HTTP request processing.
 
 Key Components:
 
-  - Compression: Gzip compression for responses >1KB
+  - Compression: Brotli or gzip compression for responses >1KB
   - Performance Monitor: Request latency tracking with percentile calculations
   - Request ID: UUID-based request tracking for distributed tracing
   - Prometheus Metrics: HTTP request/response instrumentation
+  - Access Log: One structured line per request, sampled for successes, with redacted bodies at debug level
+  - Tracing: OpenTelemetry server span per request (no-op unless configured)
 
 Middleware Stack:
 
@@ -26,7 +28,7 @@ The typical middleware stack for an endpoint is:
 	    auth.CORS(                           // Layer 1: CORS headers
 	        auth.RateLimit(                  // Layer 2: Rate limiting
 	            middleware.PrometheusMetrics( // Layer 3: Metrics
-	                middleware.Compression(    // Layer 4: Gzip
+	                middleware.Compression(    // Layer 4: Brotli/gzip
 	                    middleware.RequestID(  // Layer 5: Request tracking
 	                        handler,           // Layer 6: Business logic
 	                    ),
@@ -40,13 +42,18 @@ Usage Example - Compression:
 
 	import "github.com/tomtom215/cartographus/internal/middleware"
 
-	// Wrap handler with gzip compression
+	// Wrap handler with Brotli/gzip compression
 	http.HandleFunc("/api/v1/data",
 	    middleware.Compression(handler),
 	)
 
+	// Or choose the Brotli quality level (0-11, default 5)
+	http.HandleFunc("/api/v1/analytics",
+	    middleware.CompressionWithQuality(9)(handler),
+	)
+
 	// Responses >1KB are automatically compressed
-	// Accept-Encoding: gzip header is required
+	// Accept-Encoding: br or gzip header is required
 
 Usage Example - Performance Monitoring:
 
@@ -60,8 +67,15 @@ Usage Example - Performance Monitoring:
 
 	// Get performance statistics
 	stats := perfMon.GetStats()
-	fmt.Printf("p50: %v, p95: %v, p99: %v\n",
-	    stats.P50, stats.P95, stats.P99)
+
+	// Or for a single endpoint
+	if stat, ok := perfMon.GetStatsForRoute("GET /api/v1/stats"); ok {
+	    fmt.Printf("p50: %dms, p95: %dms, p99: %dms\n",
+	        stat.P50Duration, stat.P95Duration, stat.P99Duration)
+	}
+
+	// Export percentiles on /metrics
+	prometheus.MustRegister(perfMon)
 
 Usage Example - Request ID:
 
@@ -76,6 +90,25 @@ Usage Example - Request ID:
 	    log.Printf("[%s] Processing request", requestID)
 	}
 
+Usage Example - Access Log:
+
+	// One line per request: request_id, method, route, status, bytes,
+	// duration, client_ip, principal, and the redacted query
+	accessLog := middleware.NewAccessLogger(middleware.AccessLogConfig{
+	    SuccessSampleRate: 0.1,         // log 10% of responses below 400
+	    SlowThreshold:     time.Second, // always log slow requests (warn)
+	})
+	http.HandleFunc("/api/v1/stats",
+	    accessLog.Middleware(handler),
+	)
+
+	// Authentication middleware reports the user for the line
+	middleware.SetPrincipal(r.Context(), claims.Username)
+
+	// With LOG_LEVEL=debug, headers and up to MaxBodyBytes of each body are
+	// added. Authorization, cookies, and token/password/secret fields are
+	// replaced with [REDACTED].
+
 Performance Characteristics:
 
   - Compression: 70-90% size reduction for JSON (text/json mime types)
@@ -87,10 +120,11 @@ Performance Characteristics:
 Compression Details:
 
 The compression middleware:
-  - Only compresses responses >1KB (configurable threshold)
-  - Supports gzip encoding (Accept-Encoding: gzip)
-  - Applies to text/json/javascript/xml mime types
-  - Automatically sets Content-Encoding header
+  - Only compresses responses >1KB (smaller bodies are sent as-is)
+  - Negotiates br or gzip via Accept-Encoding, honoring q-values and
+    preferring br on a tie; falls back to identity
+  - Leaves responses the handler already encoded untouched
+  - Automatically sets Content-Encoding and Vary headers
   - Flushes compressed data for streaming responses
 
 Performance Monitor:
@@ -99,12 +133,15 @@ The performance monitor tracks:
   - Request count and error rate
   - Latency percentiles (p50, p95, p99)
   - Rolling window of 1000 most recent requests
+  - Per-endpoint lookup with GetStatsForRoute; Reset clears the window
+  - Prometheus summary (api_request_window_duration_seconds) computed
+    from the window at scrape time
   - Thread-safe concurrent access with RWMutex
 
 Thread Safety:
 
 All middleware components are thread-safe:
-  - Compression uses per-request gzip writers
+  - Compression uses pooled gzip/Brotli writers, one per request
   - Performance monitor uses sync.RWMutex
   - Request ID uses context.Context (immutable)
   - Prometheus metrics use atomic operations
@@ -114,5 +151,6 @@ See Also:
   - internal/auth: Authentication middleware
   - internal/api: HTTP handlers wrapped by middleware
   - internal/metrics: Prometheus metrics definitions
+  - internal/tracing: OpenTelemetry tracer setup
 */
 package middleware
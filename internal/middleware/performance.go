@@ -79,42 +79,12 @@ func (pm *PerformanceMonitor) GetStats() []EndpointStats {
 	defer pm.mu.RUnlock()
 
 	// Group metrics by endpoint
-	endpointMetrics := make(map[string][]int64)
-	for _, m := range pm.metrics {
-		key := m.Method + " " + m.Path
-		endpointMetrics[key] = append(endpointMetrics[key], m.DurationMS)
-	}
+	endpointMetrics := pm.durationsByRoute()
 
 	// Calculate statistics for each endpoint
 	stats := make([]EndpointStats, 0, len(endpointMetrics))
 	for endpoint, durations := range endpointMetrics {
-		if len(durations) == 0 {
-			continue
-		}
-
-		// Sort durations for percentile calculations
-		sorted := make([]int64, len(durations))
-		copy(sorted, durations)
-		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
-
-		// Calculate statistics
-		var sum int64
-		for _, d := range sorted {
-			sum += d
-		}
-
-		stat := EndpointStats{
-			Path:         endpoint,
-			RequestCount: int64(len(sorted)),
-			AvgDuration:  float64(sum) / float64(len(sorted)),
-			P50Duration:  percentile(sorted, 0.50),
-			P95Duration:  percentile(sorted, 0.95),
-			P99Duration:  percentile(sorted, 0.99),
-			MinDuration:  sorted[0],
-			MaxDuration:  sorted[len(sorted)-1],
-		}
-
-		stats = append(stats, stat)
+		stats = append(stats, computeEndpointStats(endpoint, durations))
 	}
 
 	// Sort by request count descending
@@ -125,6 +95,70 @@ func (pm *PerformanceMonitor) GetStats() []EndpointStats {
 	return stats
 }
 
+// GetStatsForRoute returns statistics for a single endpoint, keyed the same
+// way as EndpointStats.Path (e.g. "GET /api/v1/stats"). The second return
+// value is false if the rolling window holds no samples for the route.
+func (pm *PerformanceMonitor) GetStatsForRoute(route string) (EndpointStats, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	var durations []int64
+	for _, m := range pm.metrics {
+		if m.Method+" "+m.Path == route {
+			durations = append(durations, m.DurationMS)
+		}
+	}
+	if len(durations) == 0 {
+		return EndpointStats{Path: route}, false
+	}
+
+	return computeEndpointStats(route, durations), true
+}
+
+// Reset discards all recorded samples and aggregate counters
+func (pm *PerformanceMonitor) Reset() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.metrics = make([]RequestMetrics, 0, pm.maxMetrics)
+	pm.requestCounts = make(map[string]int64)
+	pm.totalDuration = make(map[string]int64)
+}
+
+// durationsByRoute groups the rolling window by endpoint. Caller must hold pm.mu.
+func (pm *PerformanceMonitor) durationsByRoute() map[string][]int64 {
+	endpointMetrics := make(map[string][]int64)
+	for _, m := range pm.metrics {
+		key := m.Method + " " + m.Path
+		endpointMetrics[key] = append(endpointMetrics[key], m.DurationMS)
+	}
+	return endpointMetrics
+}
+
+// computeEndpointStats calculates statistics from an endpoint's durations
+func computeEndpointStats(endpoint string, durations []int64) EndpointStats {
+	// Sort durations for percentile calculations
+	sorted := make([]int64, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum int64
+	for _, d := range sorted {
+		sum += d
+	}
+
+	return EndpointStats{
+		Path:         endpoint,
+		RequestCount: int64(len(sorted)),
+		AvgDuration:  float64(sum) / float64(len(sorted)),
+		P50Duration:  percentile(sorted, 0.50),
+		P95Duration:  percentile(sorted, 0.95),
+		P99Duration:  percentile(sorted, 0.99),
+		MinDuration:  sorted[0],
+		MaxDuration:  sorted[len(sorted)-1],
+	}
+}
+
 // GetRecentMetrics returns the most recent N metrics
 func (pm *PerformanceMonitor) GetRecentMetrics(n int) []RequestMetrics {
 	pm.mu.RLock()
@@ -0,0 +1,46 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package middleware
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// performanceWindowDesc describes the summary exported for the rolling window.
+// Quantiles are computed from the window at scrape time, so they always agree
+// with GetStats.
+var performanceWindowDesc = prometheus.NewDesc(
+	"api_request_window_duration_seconds",
+	"API request latency over the performance monitor's rolling sample window",
+	[]string{"method", "endpoint"},
+	nil,
+)
+
+// Describe implements prometheus.Collector
+func (pm *PerformanceMonitor) Describe(ch chan<- *prometheus.Desc) {
+	ch <- performanceWindowDesc
+}
+
+// Collect implements prometheus.Collector, emitting one summary per endpoint
+// with p50/p95/p99 quantiles in seconds.
+func (pm *PerformanceMonitor) Collect(ch chan<- prometheus.Metric) {
+	for _, stat := range pm.GetStats() {
+		method, path, _ := strings.Cut(stat.Path, " ")
+		ch <- prometheus.MustNewConstSummary(
+			performanceWindowDesc,
+			uint64(stat.RequestCount),
+			stat.AvgDuration*float64(stat.RequestCount)/1000,
+			map[float64]float64{
+				0.50: float64(stat.P50Duration) / 1000,
+				0.95: float64(stat.P95Duration) / 1000,
+				0.99: float64(stat.P99Duration) / 1000,
+			},
+			method, path,
+		)
+	}
+}
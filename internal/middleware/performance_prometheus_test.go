@@ -0,0 +1,160 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package middleware
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// recordLatencies feeds durations 1..n ms (scaled) for a route
+func recordLatencies(pm *PerformanceMonitor, method, path string, n int, scale int64) {
+	for i := 1; i <= n; i++ {
+		pm.RecordRequest(&RequestMetrics{
+			Method:     method,
+			Path:       path,
+			DurationMS: int64(i) * scale,
+			StatusCode: 200,
+		})
+	}
+}
+
+func TestPerformanceMonitor_GetStatsForRoute(t *testing.T) {
+	pm := NewPerformanceMonitor(1000)
+	recordLatencies(pm, "GET", "/api/v1/stats", 100, 1)     // 1..100ms
+	recordLatencies(pm, "GET", "/api/v1/locations", 200, 5) // 5..1000ms
+	recordLatencies(pm, "POST", "/api/v1/stats", 10, 100)   // 100..1000ms
+
+	tests := []struct {
+		route                    string
+		count, p50, p95, p99     int64
+		minDuration, maxDuration int64
+		avg                      float64
+	}{
+		{"GET /api/v1/stats", 100, 50, 95, 99, 1, 100, 50.5},
+		{"GET /api/v1/locations", 200, 500, 950, 990, 5, 1000, 502.5},
+		{"POST /api/v1/stats", 10, 500, 900, 900, 100, 1000, 550},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			stat, ok := pm.GetStatsForRoute(tt.route)
+			if !ok {
+				t.Fatalf("GetStatsForRoute(%q) found no samples", tt.route)
+			}
+			if stat.Path != tt.route || stat.RequestCount != tt.count {
+				t.Errorf("Path/RequestCount = %q/%d, want %q/%d", stat.Path, stat.RequestCount, tt.route, tt.count)
+			}
+			if stat.P50Duration != tt.p50 || stat.P95Duration != tt.p95 || stat.P99Duration != tt.p99 {
+				t.Errorf("p50/p95/p99 = %d/%d/%d, want %d/%d/%d",
+					stat.P50Duration, stat.P95Duration, stat.P99Duration, tt.p50, tt.p95, tt.p99)
+			}
+			if stat.MinDuration != tt.minDuration || stat.MaxDuration != tt.maxDuration {
+				t.Errorf("min/max = %d/%d, want %d/%d", stat.MinDuration, stat.MaxDuration, tt.minDuration, tt.maxDuration)
+			}
+			if stat.AvgDuration != tt.avg {
+				t.Errorf("AvgDuration = %v, want %v", stat.AvgDuration, tt.avg)
+			}
+		})
+	}
+
+	if _, ok := pm.GetStatsForRoute("DELETE /api/v1/stats"); ok {
+		t.Error("Expected no samples for unrecorded route")
+	}
+}
+
+func TestPerformanceMonitor_GetStatsForRoute_MatchesGetStats(t *testing.T) {
+	pm := NewPerformanceMonitor(1000)
+	recordLatencies(pm, "GET", "/a", 37, 3)
+	recordLatencies(pm, "GET", "/b", 12, 7)
+
+	for _, want := range pm.GetStats() {
+		got, ok := pm.GetStatsForRoute(want.Path)
+		if !ok || got != want {
+			t.Errorf("GetStatsForRoute(%q) = %+v, want %+v", want.Path, got, want)
+		}
+	}
+}
+
+func TestPerformanceMonitor_Reset(t *testing.T) {
+	pm := NewPerformanceMonitor(10)
+	recordLatencies(pm, "GET", "/api/test", 5, 10)
+
+	pm.Reset()
+
+	if stats := pm.GetStats(); len(stats) != 0 {
+		t.Errorf("Expected no stats after Reset, got %d", len(stats))
+	}
+	if len(pm.requestCounts) != 0 || len(pm.totalDuration) != 0 {
+		t.Error("Expected aggregate counters to be cleared")
+	}
+
+	// Monitor remains usable after reset
+	recordLatencies(pm, "GET", "/api/test", 1, 10)
+	if stat, ok := pm.GetStatsForRoute("GET /api/test"); !ok || stat.RequestCount != 1 {
+		t.Errorf("Expected 1 sample after Reset, got %+v", stat)
+	}
+}
+
+func TestPerformanceMonitor_Collect(t *testing.T) {
+	pm := NewPerformanceMonitor(1000)
+	recordLatencies(pm, "GET", "/api/v1/stats", 100, 1)
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(pm); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	if len(families) != 1 || families[0].GetName() != "api_request_window_duration_seconds" {
+		t.Fatalf("Unexpected metric families: %v", families)
+	}
+
+	metrics := families[0].GetMetric()
+	if len(metrics) != 1 {
+		t.Fatalf("Expected 1 summary, got %d", len(metrics))
+	}
+
+	labels := map[string]string{}
+	for _, l := range metrics[0].GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	if labels["method"] != "GET" || labels["endpoint"] != "/api/v1/stats" {
+		t.Errorf("Unexpected labels: %v", labels)
+	}
+
+	summary := metrics[0].GetSummary()
+	if summary.GetSampleCount() != 100 {
+		t.Errorf("SampleCount = %d, want 100", summary.GetSampleCount())
+	}
+	if got := summary.GetSampleSum(); got < 5.0499 || got > 5.0501 {
+		t.Errorf("SampleSum = %v, want 5.05", got)
+	}
+
+	want := map[float64]float64{0.50: 0.050, 0.95: 0.095, 0.99: 0.099}
+	for _, q := range summary.GetQuantile() {
+		if w, ok := want[q.GetQuantile()]; !ok || q.GetValue() != w {
+			t.Errorf("quantile %v = %v, want %v", q.GetQuantile(), q.GetValue(), w)
+		}
+	}
+	if len(summary.GetQuantile()) != len(want) {
+		t.Errorf("Expected %d quantiles, got %d", len(want), len(summary.GetQuantile()))
+	}
+
+	// A reset monitor exports nothing
+	pm.Reset()
+	families, err = reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	if len(families) != 0 {
+		t.Errorf("Expected no metrics after Reset, got %v", families)
+	}
+}
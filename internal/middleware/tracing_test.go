@@ -0,0 +1,106 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/tracing"
+)
+
+func newTracingTestRouter(handler http.HandlerFunc) http.Handler {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := logging.ContextWithRequestID(r.Context(), "req-123")
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Use(func(next http.Handler) http.Handler { return Tracing(next.ServeHTTP) })
+	r.Get("/api/v1/users/{id}", handler)
+	return r
+}
+
+func TestTracing_ServerSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.SetTracerProvider(nil)
+
+	var handlerSpan trace.SpanContext
+	router := newTracingTestRouter(func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name() != "GET /api/v1/users/{id}" {
+		t.Errorf("span name = %q, want route pattern", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span kind = %v, want server", span.SpanKind())
+	}
+	if span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Error("span did not continue the incoming traceparent")
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("handler context does not carry the server span")
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error for a 500 response", span.Status().Code)
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["request.id"] != "req-123" {
+		t.Errorf("request.id = %q, want req-123", attrs["request.id"])
+	}
+	if attrs["http.route"] != "/api/v1/users/{id}" {
+		t.Errorf("http.route = %q", attrs["http.route"])
+	}
+	if attrs["http.response.status_code"] != "500" {
+		t.Errorf("http.response.status_code = %q, want 500", attrs["http.response.status_code"])
+	}
+}
+
+func TestTracing_DisabledPassThrough(t *testing.T) {
+	tracing.SetTracerProvider(nil)
+
+	called := false
+	router := newTracingTestRouter(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if trace.SpanContextFromContext(r.Context()).IsValid() {
+			t.Error("span started while tracing is disabled")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil))
+
+	if !called || rec.Code != http.StatusNoContent {
+		t.Errorf("handler called = %v, status = %d", called, rec.Code)
+	}
+}
@@ -69,3 +69,20 @@ type FirstEpisodeDropOff struct {
 	SeriesContinuations int     `json:"series_continuations"` // Users who watched episode 2+
 	DropOffRate         float64 `json:"drop_off_rate"`        // % who didn't continue
 }
+
+// CompletionDistribution is a histogram of how far plays got
+// (percent_complete) in quartile buckets: 0-25%, 25-50%, 50-75% and
+// 75-100%. Every bucket is present, in that order, even when empty.
+type CompletionDistribution struct {
+	TotalPlaybacks int                          `json:"total_playbacks"`
+	Buckets        []DropOffBucket              `json:"buckets"`
+	ByMediaType    []MediaTypeCompletionBuckets `json:"by_media_type"` // Most played first
+}
+
+// MediaTypeCompletionBuckets is the completion histogram of one media type;
+// PercentageOfTotal in its buckets is relative to the media type's plays.
+type MediaTypeCompletionBuckets struct {
+	MediaType      string          `json:"media_type"`
+	TotalPlaybacks int             `json:"total_playbacks"`
+	Buckets        []DropOffBucket `json:"buckets"`
+}
@@ -145,3 +145,40 @@ type CohortQueryMetadata struct {
 	// Cached indicates if this result was served from cache
 	Cached bool `json:"cached"`
 }
+
+// RetentionMatrix is a cohort retention matrix: one row per cohort of users
+// who first played in the same bucket (day, week or month), one column per
+// bucket offset since then.
+type RetentionMatrix struct {
+	// Bucket is the cohort and column period: "day", "week" or "month"
+	Bucket string `json:"bucket"`
+
+	// Periods is the number of columns (offset 0 through Periods-1)
+	Periods int `json:"periods"`
+
+	// Cohorts is ordered oldest first
+	Cohorts []RetentionCohort `json:"cohorts"`
+
+	// Truncated is true when older cohorts were dropped to bound the matrix
+	Truncated bool `json:"truncated"`
+}
+
+// RetentionCohort is one row of a RetentionMatrix. Rows of recent cohorts are
+// shorter: offsets after the last bucket with data are not reported.
+type RetentionCohort struct {
+	// CohortStart is the start of the bucket the cohort's users first played in
+	CohortStart time.Time `json:"cohort_start"`
+
+	// Users is the cohort size
+	Users int `json:"users"`
+
+	// Active[n] is the number of cohort users who played n buckets after CohortStart
+	Active []int `json:"active"`
+
+	// Retention[n] is Active[n] / Users * 100
+	Retention []float64 `json:"retention"`
+
+	// RollingRetention[n] is the percentage of cohort users who played n or
+	// more buckets after CohortStart (unbounded retention)
+	RollingRetention []float64 `json:"rolling_retention"`
+}
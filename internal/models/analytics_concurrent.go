@@ -52,3 +52,19 @@ type ConcurrentStreamsByHour struct {
 	AvgConcurrent  float64 `json:"avg_concurrent"`
 	PeakConcurrent int     `json:"peak_concurrent"`
 }
+
+// ConcurrentStreamsTimeSeries is the peak number of simultaneous sessions in
+// each fixed-width time bucket, for capacity planning
+type ConcurrentStreamsTimeSeries struct {
+	BucketMinutes  int                           `json:"bucket_minutes"`
+	PeakConcurrent int                           `json:"peak_concurrent"`
+	PeakTime       time.Time                     `json:"peak_time"` // first instant the overall peak was reached
+	Buckets        []ConcurrentStreamsPeakBucket `json:"buckets"`
+}
+
+// ConcurrentStreamsPeakBucket is the highest concurrency reached at any
+// instant within one bucket
+type ConcurrentStreamsPeakBucket struct {
+	BucketStart    time.Time `json:"bucket_start"`
+	PeakConcurrent int       `json:"peak_concurrent"`
+}
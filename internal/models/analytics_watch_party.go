@@ -23,17 +23,21 @@ type WatchPartyParticipant struct {
 
 // WatchParty represents a detected watch party (2+ users watching same content together)
 type WatchParty struct {
+	ID               string                  `json:"id"` // ID of the party's first playback
 	MediaType        string                  `json:"media_type"`
 	Title            string                  `json:"title"`
 	ParentTitle      *string                 `json:"parent_title,omitempty"`
 	GrandparentTitle *string                 `json:"grandparent_title,omitempty"`
 	PartyTime        time.Time               `json:"party_time"`
+	EndedAt          time.Time               `json:"ended_at"`
 	ParticipantCount int                     `json:"participant_count"`
 	Participants     []WatchPartyParticipant `json:"participants"`
 	SameLocation     bool                    `json:"same_location"`
 	LocationName     *string                 `json:"location_name,omitempty"`
 	AvgCompletion    float64                 `json:"avg_completion"`
 	TotalDuration    int                     `json:"total_duration_minutes"`
+	Confidence       float64                 `json:"confidence"`     // 0-1: how likely the users watched together
+	FalsePositive    bool                    `json:"false_positive"` // Marked by an admin as not a watch party
 }
 
 // WatchPartyAnalytics represents overall watch party analytics
@@ -61,6 +61,7 @@ type APIResponse struct {
 //   - Timestamp: Server time when response was generated (RFC3339 format)
 //   - QueryTimeMS: Database query execution time in milliseconds (0 if cached)
 //   - Cached: Whether response was served from cache (omitted if false)
+//   - Pagination: Page position for paginated list endpoints (omitted otherwise)
 //
 // Query time tracking:
 //   - Cached responses: QueryTimeMS is 0, Cached is true
@@ -82,9 +83,10 @@ type APIResponse struct {
 //	  "query_time_ms": 23
 //	}
 type Metadata struct {
-	Timestamp   time.Time `json:"timestamp"`
-	QueryTimeMS int64     `json:"query_time_ms,omitempty"`
-	Cached      bool      `json:"cached,omitempty"`
+	Timestamp   time.Time       `json:"timestamp"`
+	QueryTimeMS int64           `json:"query_time_ms,omitempty"`
+	Cached      bool            `json:"cached,omitempty"`
+	Pagination  *PaginationInfo `json:"pagination,omitempty"`
 }
 
 // APIError represents an error response with structured error details.
@@ -129,6 +131,9 @@ type APIError struct {
 //   - NextCursor: Opaque cursor for next page (null if no more results)
 //   - PrevCursor: Opaque cursor for previous page (null if on first page)
 //   - TotalCount: Total results matching filter (optional, expensive for large datasets)
+//   - Offset: Rows skipped before this page (offset-based endpoints only)
+//   - NextOffset: Offset of the next page (null if no more results)
+//   - PrevOffset: Offset of the previous page (null if on first page)
 //
 // Cursor format: Base64-encoded JSON with timestamp + ID for stable sorting
 //
@@ -155,6 +160,9 @@ type PaginationInfo struct {
 	NextCursor *string `json:"next_cursor,omitempty"`
 	PrevCursor *string `json:"prev_cursor,omitempty"`
 	TotalCount *int    `json:"total_count,omitempty"` // Optional, expensive for large datasets
+	Offset     *int    `json:"offset,omitempty"`
+	NextOffset *int    `json:"next_offset,omitempty"`
+	PrevOffset *int    `json:"prev_offset,omitempty"`
 }
 
 // PlaybackCursor represents the cursor for playback pagination (v1.40+).
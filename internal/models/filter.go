@@ -0,0 +1,346 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bounds enforced by ParseLocationStatsFilter.
+const (
+	// MaxFilterDays is the largest accepted days= value (10 years)
+	MaxFilterDays = 3650
+
+	// MaxFilterRangeDays is the longest accepted start_date..end_date range
+	MaxFilterRangeDays = 3650
+
+	// MaxFilterLimit is the largest accepted limit= value
+	MaxFilterLimit = 1000
+)
+
+// LocationStatsFilter contains filter parameters for location statistics and analytics queries.
+// Provides comprehensive multi-dimensional filtering with 14+ filter dimensions, supporting
+// complex analytics queries across temporal, user, content, technical, and geographic axes.
+//
+// All filter fields are optional and combine using AND logic. Multi-select fields (slices)
+// use OR logic within the field (e.g., Users: ["alice", "bob"] matches alice OR bob).
+//
+// Filter Dimensions:
+//
+//  1. Temporal Filtering:
+//     - StartDate: Filter events on or after this timestamp (nil = no start limit)
+//     - EndDate: Filter events on or before this timestamp (nil = no end limit)
+//     - Years: Filter by content release year (supports multiple years)
+//
+//  2. User Filtering:
+//     - Users: Filter by usernames (multi-select OR)
+//
+//  3. Content Filtering:
+//     - MediaTypes: Filter by media type ("movie", "episode", "track", "photo")
+//     - Libraries: Filter by library name (multi-select OR)
+//     - ContentRatings: Filter by content rating ("G", "PG", "PG-13", "R", etc.)
+//
+//  4. Technical Filtering:
+//     - Platforms: Filter by platform/OS ("iOS", "Android", "Web", etc.)
+//     - Players: Filter by player app ("Plex Web", "Plex for iOS", etc.)
+//     - TranscodeDecisions: Filter by transcode decision ("direct play", "transcode", "copy")
+//     - VideoResolutions: Filter by video resolution ("4k", "1080p", "720p", etc.)
+//     - VideoCodecs: Filter by video codec ("h264", "hevc", "vp9", etc.)
+//     - AudioCodecs: Filter by audio codec ("aac", "ac3", "dts", etc.)
+//
+//  5. Geographic Filtering:
+//     - LocationTypes: Filter by location type ("country", "city", "isp")
+//     - ConnectionTypes: Filter by connection type ("residential", "vpn", "datacenter", "unknown")
+//
+//  6. Server Filtering (v2.1 Multi-Server Support):
+//     - ServerIDs: Filter by server ID ("plex-home", "jellyfin-abc123", etc.)
+//
+//  7. Result Limiting:
+//     - Limit: Maximum number of results to return (0 = no limit)
+//
+// Example - Basic temporal filter:
+//
+//	filter := LocationStatsFilter{
+//	    StartDate: &time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+//	    EndDate:   &time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC),
+//	    Limit:     100,
+//	}
+//
+// Example - Multi-dimensional analytics filter:
+//
+//	now := time.Now()
+//	thirtyDaysAgo := now.AddDate(0, 0, -30)
+//	filter := LocationStatsFilter{
+//	    StartDate:        &thirtyDaysAgo,
+//	    EndDate:          &now,
+//	    Users:            []string{"alice", "bob"},            // alice OR bob
+//	    MediaTypes:       []string{"movie", "episode"},        // movies OR episodes
+//	    TranscodeDecisions: []string{"transcode"},             // only transcoded streams
+//	    VideoResolutions: []string{"4k", "1080p"},             // 4K OR 1080p
+//	    Platforms:        []string{"iOS", "Android"},          // mobile only
+//	    Limit:            50,
+//	}
+//
+// SQL Generation:
+// The database package turns this filter into parameterized SQL WHERE clauses:
+//
+//	// Example generated SQL:
+//	WHERE started_at >= ? AND started_at <= ?
+//	  AND username IN (?, ?)
+//	  AND media_type IN (?, ?)
+//	  AND transcode_decision = ?
+//	  AND video_resolution IN (?, ?)
+//	  AND platform IN (?, ?)
+//	LIMIT ?
+//
+// Performance Notes:
+//   - All fields use indexed columns for efficient filtering
+//   - Multi-select filters use IN clauses (optimized by DuckDB query planner)
+//   - Date range filters leverage composite index on (started_at DESC, id)
+//   - Typical query time: 5-50ms with proper indexing
+//
+// Thread Safety:
+// LocationStatsFilter is immutable after creation and safe for concurrent read access.
+// Multiple goroutines can safely pass the same filter to different query methods.
+type LocationStatsFilter struct {
+	StartDate          *time.Time
+	EndDate            *time.Time
+	Users              []string
+	MediaTypes         []string
+	Platforms          []string
+	Players            []string
+	TranscodeDecisions []string
+	VideoResolutions   []string
+	VideoCodecs        []string
+	AudioCodecs        []string
+	Libraries          []string
+	ContentRatings     []string
+	Years              []int
+	LocationTypes      []string
+	ConnectionTypes    []string
+	ServerIDs          []string // v2.1: Multi-server support - filter by server ID
+	Limit              int
+
+	// Stitched counts each logical viewing session (resumed plays of the
+	// same item joined by session stitching) once instead of each playback.
+	// Only play count, binge and completion analytics honor it.
+	Stitched bool
+}
+
+// filterListParams maps the comma-separated string query parameters to the
+// filter field they populate.
+var filterListParams = []struct {
+	param string
+	field func(*LocationStatsFilter) *[]string
+}{
+	{"users", func(f *LocationStatsFilter) *[]string { return &f.Users }},
+	{"media_types", func(f *LocationStatsFilter) *[]string { return &f.MediaTypes }},
+	{"platforms", func(f *LocationStatsFilter) *[]string { return &f.Platforms }},
+	{"players", func(f *LocationStatsFilter) *[]string { return &f.Players }},
+	{"transcode_decisions", func(f *LocationStatsFilter) *[]string { return &f.TranscodeDecisions }},
+	{"video_resolutions", func(f *LocationStatsFilter) *[]string { return &f.VideoResolutions }},
+	{"video_codecs", func(f *LocationStatsFilter) *[]string { return &f.VideoCodecs }},
+	{"audio_codecs", func(f *LocationStatsFilter) *[]string { return &f.AudioCodecs }},
+	{"libraries", func(f *LocationStatsFilter) *[]string { return &f.Libraries }},
+	{"content_ratings", func(f *LocationStatsFilter) *[]string { return &f.ContentRatings }},
+	{"location_types", func(f *LocationStatsFilter) *[]string { return &f.LocationTypes }},
+	{"connection_types", func(f *LocationStatsFilter) *[]string { return &f.ConnectionTypes }},
+	{"server_ids", func(f *LocationStatsFilter) *[]string { return &f.ServerIDs }},
+}
+
+// LocationStatsFilterParams documents the query parameters accepted by
+// ParseLocationStatsFilter. It is only used by the OpenAPI annotations
+// (@Param filter query models.LocationStatsFilterParams), which expand it
+// into one query parameter per field; TestLocationStatsFilterParams_Documented
+// keeps it in sync with the parser. limit and stitched are left out because
+// their defaults and support differ per endpoint, so handlers document them
+// themselves.
+type LocationStatsFilterParams struct {
+	StartDate          string   `form:"start_date" format:"date-time" example:"2025-01-01T00:00:00Z"`               // Start of the date range (RFC3339)
+	EndDate            string   `form:"end_date" format:"date-time" example:"2025-12-31T23:59:59Z"`                 // End of the date range (RFC3339)
+	Days               int      `form:"days" minimum:"1" maximum:"3650"`                                            // Last N days, ignored when start_date is set
+	Users              []string `form:"users" collectionFormat:"csv" example:"user1,user2"`                         // Usernames
+	MediaTypes         []string `form:"media_types" collectionFormat:"csv" example:"movie,episode"`                 // Media types
+	Platforms          []string `form:"platforms" collectionFormat:"csv" example:"Android,iOS"`                     // Client platforms
+	Players            []string `form:"players" collectionFormat:"csv" example:"Plex Web"`                          // Client players
+	TranscodeDecisions []string `form:"transcode_decisions" collectionFormat:"csv" example:"transcode,direct play"` // Transcode decisions
+	VideoResolutions   []string `form:"video_resolutions" collectionFormat:"csv" example:"1080,720"`                // Video resolutions
+	VideoCodecs        []string `form:"video_codecs" collectionFormat:"csv" example:"h264,hevc"`                    // Video codecs
+	AudioCodecs        []string `form:"audio_codecs" collectionFormat:"csv" example:"aac,ac3"`                      // Audio codecs
+	Libraries          []string `form:"libraries" collectionFormat:"csv" example:"Movies,TV Shows"`                 // Library names
+	ContentRatings     []string `form:"content_ratings" collectionFormat:"csv" example:"PG,PG-13,R"`                // Content ratings
+	Years              []int    `form:"years" collectionFormat:"csv" example:"2023,2024"`                           // Release years
+	LocationTypes      []string `form:"location_types" collectionFormat:"csv" example:"lan,wan"`                    // Location types
+	ConnectionTypes    []string `form:"connection_types" collectionFormat:"csv" example:"vpn,datacenter"`           // Connection types (residential, vpn, datacenter, unknown)
+	ServerIDs          []string `form:"server_ids" collectionFormat:"csv" example:"plex-home"`                      // Media server IDs
+}
+
+// FilterFieldError describes one invalid filter query parameter.
+type FilterFieldError struct {
+	// Param is the query parameter name, e.g. "start_date"
+	Param string `json:"param"`
+
+	// Value is the rejected raw value
+	Value string `json:"value"`
+
+	// Rule names the failed check, using the validator tag vocabulary
+	// ("datetime", "int", "boolean", "min", "max", "daterange", "maxrange")
+	Rule string `json:"rule"`
+
+	// Message is the human-readable explanation
+	Message string `json:"message"`
+}
+
+// FilterValidationError is returned by ParseLocationStatsFilter and lists
+// every invalid parameter, not just the first one.
+type FilterValidationError struct {
+	Fields []FilterFieldError
+}
+
+// Error joins the field messages; a single field returns its message as-is.
+func (e *FilterValidationError) Error() string {
+	if len(e.Fields) == 1 {
+		return e.Fields[0].Message
+	}
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Param + ": " + field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *FilterValidationError) add(param, value, rule, message string) {
+	e.Fields = append(e.Fields, FilterFieldError{Param: param, Value: value, Rule: rule, Message: message})
+}
+
+// ParseLocationStatsFilter builds a LocationStatsFilter from URL query
+// parameters:
+//
+//   - start_date, end_date: RFC3339 timestamps; end_date must be after
+//     start_date and the range at most MaxFilterRangeDays long
+//   - days: 1..MaxFilterDays, sets StartDate relative to now when start_date
+//     is absent
+//   - users, media_types, platforms, players, transcode_decisions,
+//     video_resolutions, video_codecs, audio_codecs, libraries,
+//     content_ratings, location_types, connection_types, server_ids:
+//     comma-separated lists
+//   - years: comma-separated release years
+//   - limit: 1..MaxFilterLimit
+//   - stitched: boolean, count logical viewing sessions instead of playbacks
+//
+// List parameters may also be repeated (users=a&users=b); values are trimmed
+// and empty entries dropped. Omitted parameters leave their field at the zero
+// value, so Limit is 0 (no limit) unless limit= is given.
+//
+// Invalid parameters are reported together as a *FilterValidationError. The
+// returned filter still holds every parameter that did parse, which lets
+// lenient callers ignore the error.
+func ParseLocationStatsFilter(query url.Values) (LocationStatsFilter, error) {
+	var filter LocationStatsFilter
+	verr := &FilterValidationError{}
+
+	parseFilterDates(query, &filter, verr)
+
+	for _, list := range filterListParams {
+		*list.field(&filter) = splitFilterValues(query[list.param])
+	}
+
+	for _, raw := range splitFilterValues(query["years"]) {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < 1 || year > 9999 {
+			verr.add("years", raw, "int", "Years must be a comma-separated list of release years")
+			continue
+		}
+		filter.Years = append(filter.Years, year)
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		switch {
+		case err != nil:
+			verr.add("limit", raw, "int", "Limit must be an integer")
+		case limit < 1 || limit > MaxFilterLimit:
+			verr.add("limit", raw, "max", fmt.Sprintf("Limit must be between 1 and %d", MaxFilterLimit))
+		default:
+			filter.Limit = limit
+		}
+	}
+
+	if raw := query.Get("stitched"); raw != "" {
+		stitched, err := strconv.ParseBool(raw)
+		if err != nil {
+			verr.add("stitched", raw, "boolean", "Stitched must be true or false")
+		} else {
+			filter.Stitched = stitched
+		}
+	}
+
+	if len(verr.Fields) > 0 {
+		return filter, verr
+	}
+	return filter, nil
+}
+
+// parseFilterDates sets StartDate from start_date (or days) and EndDate from
+// end_date, then checks the range.
+func parseFilterDates(query url.Values, filter *LocationStatsFilter, verr *FilterValidationError) {
+	if raw := query.Get("days"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		switch {
+		case err != nil:
+			verr.add("days", raw, "int", "Days must be an integer")
+		case days < 1 || days > MaxFilterDays:
+			verr.add("days", raw, "max", fmt.Sprintf("Days must be between 1 and %d (10 years)", MaxFilterDays))
+		case query.Get("start_date") == "":
+			since := time.Now().AddDate(0, 0, -days)
+			filter.StartDate = &since
+		}
+	}
+
+	if raw := query.Get("start_date"); raw != "" {
+		if start, err := time.Parse(time.RFC3339, raw); err != nil {
+			verr.add("start_date", raw, "datetime", "Invalid start_date format. Use RFC3339 format")
+		} else {
+			filter.StartDate = &start
+		}
+	}
+
+	if raw := query.Get("end_date"); raw != "" {
+		if end, err := time.Parse(time.RFC3339, raw); err != nil {
+			verr.add("end_date", raw, "datetime", "Invalid end_date format. Use RFC3339 format")
+		} else {
+			filter.EndDate = &end
+		}
+	}
+
+	if filter.StartDate == nil || filter.EndDate == nil {
+		return
+	}
+	raw := query.Get("end_date")
+	if !filter.EndDate.After(*filter.StartDate) {
+		verr.add("end_date", raw, "daterange", "end_date must be after start_date")
+	} else if filter.EndDate.Sub(*filter.StartDate) > MaxFilterRangeDays*24*time.Hour {
+		verr.add("end_date", raw, "maxrange", fmt.Sprintf("date range exceeds %d days", MaxFilterRangeDays))
+	}
+}
+
+// splitFilterValues flattens repeated and comma-separated values, trimming
+// whitespace and dropping empty entries. It returns nil when nothing remains.
+func splitFilterValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				result = append(result, trimmed)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,211 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package models
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLocationStatsFilter_Defaults(t *testing.T) {
+	t.Parallel()
+
+	filter, err := ParseLocationStatsFilter(url.Values{})
+	if err != nil {
+		t.Fatalf("ParseLocationStatsFilter() error = %v", err)
+	}
+	if !reflect.DeepEqual(filter, LocationStatsFilter{}) {
+		t.Errorf("filter = %+v, want zero value", filter)
+	}
+}
+
+func TestParseLocationStatsFilter_AllDimensions(t *testing.T) {
+	t.Parallel()
+
+	query, err := url.ParseQuery("start_date=2025-01-01T00:00:00Z&end_date=2025-12-31T23:59:59Z" +
+		"&users=alice,bob&media_types=movie&platforms=iOS&players=Plex%20Web" +
+		"&transcode_decisions=transcode&video_resolutions=4k,1080p&video_codecs=hevc" +
+		"&audio_codecs=aac&libraries=Movies&content_ratings=PG-13&location_types=city" +
+		"&connection_types=vpn,datacenter&server_ids=plex-home&years=1999,2024&limit=50&stitched=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := ParseLocationStatsFilter(query)
+	if err != nil {
+		t.Fatalf("ParseLocationStatsFilter() error = %v", err)
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+	want := LocationStatsFilter{
+		StartDate:          &start,
+		EndDate:            &end,
+		Users:              []string{"alice", "bob"},
+		MediaTypes:         []string{"movie"},
+		Platforms:          []string{"iOS"},
+		Players:            []string{"Plex Web"},
+		TranscodeDecisions: []string{"transcode"},
+		VideoResolutions:   []string{"4k", "1080p"},
+		VideoCodecs:        []string{"hevc"},
+		AudioCodecs:        []string{"aac"},
+		Libraries:          []string{"Movies"},
+		ContentRatings:     []string{"PG-13"},
+		Years:              []int{1999, 2024},
+		LocationTypes:      []string{"city"},
+		ConnectionTypes:    []string{"vpn", "datacenter"},
+		ServerIDs:          []string{"plex-home"},
+		Limit:              50,
+		Stitched:           true,
+	}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("filter = %+v\nwant %+v", filter, want)
+	}
+}
+
+func TestParseLocationStatsFilter_MultiValue(t *testing.T) {
+	t.Parallel()
+
+	query := url.Values{
+		"users":      {"alice, bob", "carol", " ,"},
+		"server_ids": {"a", "b,c"},
+		"years":      {"2020", "2021,2022"},
+	}
+	filter, err := ParseLocationStatsFilter(query)
+	if err != nil {
+		t.Fatalf("ParseLocationStatsFilter() error = %v", err)
+	}
+	if want := []string{"alice", "bob", "carol"}; !reflect.DeepEqual(filter.Users, want) {
+		t.Errorf("Users = %v, want %v", filter.Users, want)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(filter.ServerIDs, want) {
+		t.Errorf("ServerIDs = %v, want %v", filter.ServerIDs, want)
+	}
+	if want := []int{2020, 2021, 2022}; !reflect.DeepEqual(filter.Years, want) {
+		t.Errorf("Years = %v, want %v", filter.Years, want)
+	}
+}
+
+func TestParseLocationStatsFilter_Days(t *testing.T) {
+	t.Parallel()
+
+	filter, err := ParseLocationStatsFilter(url.Values{"days": {"30"}})
+	if err != nil {
+		t.Fatalf("ParseLocationStatsFilter() error = %v", err)
+	}
+	if filter.StartDate == nil {
+		t.Fatal("StartDate = nil, want 30 days ago")
+	}
+	if diff := time.Until(filter.StartDate.AddDate(0, 0, 30)); diff > time.Minute || diff < -time.Minute {
+		t.Errorf("StartDate = %v, want about 30 days ago", filter.StartDate)
+	}
+
+	// An explicit start_date wins over days
+	filter, err = ParseLocationStatsFilter(url.Values{"days": {"30"}, "start_date": {"2025-01-01T00:00:00Z"}})
+	if err != nil {
+		t.Fatalf("ParseLocationStatsFilter() error = %v", err)
+	}
+	if !filter.StartDate.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("StartDate = %v, want start_date", filter.StartDate)
+	}
+}
+
+func TestParseLocationStatsFilter_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   url.Values
+		param   string
+		rule    string
+		message string
+	}{
+		{"start_date date only", url.Values{"start_date": {"2025-01-01"}}, "start_date", "datetime", "Invalid start_date format"},
+		{"end_date garbage", url.Values{"end_date": {"not-a-date"}}, "end_date", "datetime", "Invalid end_date format"},
+		{"end before start", url.Values{"start_date": {"2025-06-01T00:00:00Z"}, "end_date": {"2025-01-01T00:00:00Z"}}, "end_date", "daterange", "end_date must be after start_date"},
+		{"range too long", url.Values{"start_date": {"2010-01-01T00:00:00Z"}, "end_date": {"2025-01-01T00:00:00Z"}}, "end_date", "maxrange", "date range exceeds 3650 days"},
+		{"days zero", url.Values{"days": {"0"}}, "days", "max", "Days must be between 1 and 3650"},
+		{"days too large", url.Values{"days": {"3651"}}, "days", "max", "Days must be between 1 and 3650"},
+		{"days not a number", url.Values{"days": {"week"}}, "days", "int", "Days must be an integer"},
+		{"limit zero", url.Values{"limit": {"0"}}, "limit", "max", "Limit must be between 1 and 1000"},
+		{"limit too large", url.Values{"limit": {"1001"}}, "limit", "max", "Limit must be between 1 and 1000"},
+		{"limit not a number", url.Values{"limit": {"ten"}}, "limit", "int", "Limit must be an integer"},
+		{"year not a number", url.Values{"years": {"2020,abc"}}, "years", "int", "release years"},
+		{"stitched not a boolean", url.Values{"stitched": {"yes"}}, "stitched", "boolean", "Stitched must be true or false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseLocationStatsFilter(tt.query)
+			var verr *FilterValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("error = %v, want *FilterValidationError", err)
+			}
+			if len(verr.Fields) != 1 {
+				t.Fatalf("Fields = %+v, want one field", verr.Fields)
+			}
+			field := verr.Fields[0]
+			if field.Param != tt.param || field.Rule != tt.rule || !strings.Contains(field.Message, tt.message) {
+				t.Errorf("field = %+v, want %s/%s containing %q", field, tt.param, tt.rule, tt.message)
+			}
+		})
+	}
+}
+
+func TestParseLocationStatsFilter_CollectsAllErrors(t *testing.T) {
+	t.Parallel()
+
+	query := url.Values{
+		"start_date": {"yesterday"},
+		"end_date":   {"2025-01-01T00:00:00Z"},
+		"limit":      {"-1"},
+		"users":      {"alice"},
+	}
+	filter, err := ParseLocationStatsFilter(query)
+
+	var verr *FilterValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *FilterValidationError", err)
+	}
+	var params []string
+	for _, field := range verr.Fields {
+		params = append(params, field.Param)
+	}
+	if got := strings.Join(params, ","); got != "start_date,limit" {
+		t.Errorf("params = %s, want start_date,limit", got)
+	}
+	if !strings.Contains(err.Error(), "start_date: ") || !strings.Contains(err.Error(), "; limit: ") {
+		t.Errorf("Error() = %q, want both fields listed", err.Error())
+	}
+
+	// Valid parameters are still applied for lenient callers
+	if filter.EndDate == nil || len(filter.Users) != 1 || filter.StartDate != nil || filter.Limit != 0 {
+		t.Errorf("filter = %+v, want end_date and users only", filter)
+	}
+}
+
+func TestLocationStatsFilterParams_Documented(t *testing.T) {
+	t.Parallel()
+
+	documented := map[string]bool{"limit": true, "stitched": true}
+	typ := reflect.TypeOf(LocationStatsFilterParams{})
+	for i := 0; i < typ.NumField(); i++ {
+		documented[typ.Field(i).Tag.Get("form")] = true
+	}
+
+	parsed := map[string]bool{"start_date": true, "end_date": true, "days": true, "years": true, "limit": true, "stitched": true}
+	for _, list := range filterListParams {
+		parsed[list.param] = true
+	}
+
+	if !reflect.DeepEqual(documented, parsed) {
+		t.Errorf("documented params = %v, parsed params = %v", documented, parsed)
+	}
+}
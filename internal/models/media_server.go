@@ -39,6 +39,28 @@ type MediaServerListResponse struct {
 	LastChecked time.Time           `json:"last_checked"`
 }
 
+// SourceHealth is the result of probing a configured media source.
+type SourceHealth struct {
+	ServerID            string     `json:"server_id"`
+	Platform            string     `json:"platform"` // plex, jellyfin, emby, tautulli
+	Reachable           bool       `json:"reachable"`
+	LatencyMs           int64      `json:"latency_ms"`
+	Error               string     `json:"error,omitempty"`
+	LastSuccessfulSync  *time.Time `json:"last_successful_sync,omitempty"`
+	CircuitBreakerState string     `json:"circuit_breaker_state,omitempty"` // closed, half-open, open
+	CheckedAt           time.Time  `json:"checked_at"`
+}
+
+// SourcesHealthResponse aggregates the health of all configured media sources.
+type SourcesHealthResponse struct {
+	Status      string         `json:"status"` // healthy, degraded, unhealthy, unconfigured
+	Sources     []SourceHealth `json:"sources"`
+	TotalCount  int            `json:"total_count"`
+	Reachable   int            `json:"reachable_count"`
+	Unreachable int            `json:"unreachable_count"`
+	CheckedAt   time.Time      `json:"checked_at"`
+}
+
 // MediaServerTestRequest represents a request to test server connectivity.
 type MediaServerTestRequest struct {
 	Platform string `json:"platform" validate:"required,oneof=plex jellyfin emby tautulli"`
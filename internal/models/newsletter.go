@@ -29,7 +29,10 @@
 package models
 
 import (
+	"strings"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/smtpmail"
 )
 
 // ============================================================================
@@ -83,6 +86,47 @@ func IsValidNewsletterType(t NewsletterType) bool {
 	return false
 }
 
+// NewsletterBlockType identifies a composable newsletter content block.
+// Blocks are rendered in the order listed in TemplateConfig.Blocks and
+// added to the newsletter alongside the template's own content.
+type NewsletterBlockType string
+
+const (
+	// NewsletterBlockTopContent lists the most watched movies and shows.
+	NewsletterBlockTopContent NewsletterBlockType = "top_content"
+
+	// NewsletterBlockActiveUsers lists the most active users.
+	NewsletterBlockActiveUsers NewsletterBlockType = "active_users"
+
+	// NewsletterBlockWatchHours compares total watch hours with the previous period.
+	NewsletterBlockWatchHours NewsletterBlockType = "watch_hours"
+
+	// NewsletterBlockDetectionAlerts summarizes new detection alerts.
+	NewsletterBlockDetectionAlerts NewsletterBlockType = "detection_alerts"
+
+	// NewsletterBlockPlaybackMap is a static map image of playback locations.
+	NewsletterBlockPlaybackMap NewsletterBlockType = "playback_map"
+)
+
+// ValidNewsletterBlocks contains all valid newsletter block types.
+var ValidNewsletterBlocks = []NewsletterBlockType{
+	NewsletterBlockTopContent,
+	NewsletterBlockActiveUsers,
+	NewsletterBlockWatchHours,
+	NewsletterBlockDetectionAlerts,
+	NewsletterBlockPlaybackMap,
+}
+
+// IsValidNewsletterBlock checks if a newsletter block type is valid.
+func IsValidNewsletterBlock(b NewsletterBlockType) bool {
+	for _, valid := range ValidNewsletterBlocks {
+		if b == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // DeliveryChannel defines the delivery method for newsletters.
 type DeliveryChannel string
 
@@ -276,6 +320,24 @@ type TemplateConfig struct {
 
 	// PersonalizeForUser determines if content is personalized per-recipient.
 	PersonalizeForUser bool `json:"personalize_for_user"`
+
+	// Blocks lists the content blocks to include, in display order.
+	Blocks []NewsletterBlockType `json:"blocks,omitempty"`
+
+	// Recipients are the default recipients for schedules that list none of
+	// their own. They are resolved when the newsletter is sent, so editing a
+	// template's recipients affects every schedule that relies on them.
+	Recipients []NewsletterRecipient `json:"recipients,omitempty"`
+}
+
+// ResolveNewsletterRecipients returns the recipients a newsletter is sent
+// to: the schedule's own, or else the recipients of its effective config
+// (the schedule's config override or the template's default config).
+func ResolveNewsletterRecipients(scheduleRecipients []NewsletterRecipient, config *TemplateConfig) []NewsletterRecipient {
+	if len(scheduleRecipients) > 0 || config == nil {
+		return scheduleRecipients
+	}
+	return config.Recipients
 }
 
 // ============================================================================
@@ -306,11 +368,12 @@ type NewsletterSchedule struct {
 	// TemplateName is the template name (populated on read).
 	TemplateName string `json:"template_name,omitempty"`
 
-	// Recipients is the list of recipients for this schedule.
-	Recipients []NewsletterRecipient `json:"recipients" validate:"required,min=1"`
+	// Recipients is the list of recipients for this schedule. When empty,
+	// the recipients in Config or the template's DefaultConfig are used.
+	Recipients []NewsletterRecipient `json:"recipients"`
 
 	// CronExpression defines when the newsletter is sent (e.g., "0 9 * * 1").
-	CronExpression string `json:"cron_expression" validate:"required"`
+	CronExpression string `json:"cron_expression" validate:"required,cron"`
 
 	// Timezone is the timezone for cron evaluation (e.g., "America/New_York").
 	Timezone string `json:"timezone" validate:"required"`
@@ -395,6 +458,18 @@ type RecipientPreferences struct {
 	Language string `json:"language,omitempty"`
 }
 
+// SMTP TLS modes for ChannelConfig.SMTPTLSMode.
+const (
+	// SMTPTLSModeStartTLS connects in plaintext and upgrades with STARTTLS (port 587).
+	SMTPTLSModeStartTLS = smtpmail.TLSModeStartTLS
+
+	// SMTPTLSModeImplicit connects over TLS from the first byte (port 465).
+	SMTPTLSModeImplicit = smtpmail.TLSModeImplicit
+
+	// SMTPTLSModeNone sends without encryption. Only use on trusted networks.
+	SMTPTLSModeNone = smtpmail.TLSModeNone
+)
+
 // ChannelConfig provides channel-specific delivery configuration.
 type ChannelConfig struct {
 	// Email configuration
@@ -404,7 +479,8 @@ type ChannelConfig struct {
 	SMTPPassword string `json:"smtp_password,omitempty"` // Encrypted at rest
 	SMTPFrom     string `json:"smtp_from,omitempty"`
 	SMTPFromName string `json:"smtp_from_name,omitempty"`
-	UseTLS       bool   `json:"use_tls,omitempty"`
+	UseTLS       bool   `json:"use_tls,omitempty"`       // Upgrade the connection with STARTTLS
+	SMTPTLSMode  string `json:"smtp_tls_mode,omitempty"` // starttls, implicit, or none; overrides UseTLS when set
 
 	// Discord configuration
 	DiscordWebhookURL string `json:"discord_webhook_url,omitempty"`
@@ -429,6 +505,18 @@ type ChannelConfig struct {
 	WebhookAuth    string            `json:"webhook_auth,omitempty"` // Basic auth header value
 }
 
+// EffectiveSMTPTLSMode returns the TLS mode to use for SMTP delivery.
+// SMTPTLSMode wins when set; otherwise UseTLS selects STARTTLS.
+func (c *ChannelConfig) EffectiveSMTPTLSMode() string {
+	if c.SMTPTLSMode != "" {
+		return strings.ToLower(c.SMTPTLSMode)
+	}
+	if c.UseTLS {
+		return SMTPTLSModeStartTLS
+	}
+	return SMTPTLSModeNone
+}
+
 // ============================================================================
 // Newsletter Delivery History
 // ============================================================================
@@ -514,6 +602,9 @@ type RecipientDeliveryResult struct {
 	// RecipientType is the type (user, email, webhook).
 	RecipientType string `json:"recipient_type"`
 
+	// Channel is the delivery channel used for this recipient.
+	Channel DeliveryChannel `json:"channel,omitempty"`
+
 	// Status is the delivery status for this recipient.
 	Status DeliveryStatus `json:"status"`
 
@@ -523,10 +614,66 @@ type RecipientDeliveryResult struct {
 	// ErrorMessage contains any error for this recipient.
 	ErrorMessage string `json:"error_message,omitempty"`
 
+	// ErrorCode is the machine-readable failure reason.
+	ErrorCode string `json:"error_code,omitempty"`
+
 	// RetryCount is the number of retry attempts.
 	RetryCount int `json:"retry_count"`
 }
 
+// NewsletterDeliveryLogEntry is one row of the per-recipient delivery log.
+// A row is written for every recipient and channel of a delivery, so failed
+// recipients can be found without loading each delivery's details.
+type NewsletterDeliveryLogEntry struct {
+	// ID is the unique log entry identifier.
+	ID string `json:"id"`
+
+	// DeliveryID references the delivery this entry belongs to.
+	DeliveryID string `json:"delivery_id"`
+
+	// ScheduleID references the schedule that triggered the delivery.
+	ScheduleID string `json:"schedule_id,omitempty"`
+
+	// Channel is the delivery channel used.
+	Channel DeliveryChannel `json:"channel"`
+
+	// Recipient is the recipient identifier (email address, user ID, URL).
+	Recipient string `json:"recipient"`
+
+	// RecipientType is the type (user, email, webhook).
+	RecipientType string `json:"recipient_type"`
+
+	// Status is delivered or failed.
+	Status DeliveryStatus `json:"status"`
+
+	// Attempts is the number of send attempts, including retries.
+	Attempts int `json:"attempts"`
+
+	// ErrorCode is the machine-readable failure reason.
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// ErrorMessage contains the last error for this recipient.
+	ErrorMessage string `json:"error_message,omitempty"`
+
+	// DeliveredAt is when delivery succeeded.
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// CreatedAt is when the entry was recorded.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeliveryLogFilter selects entries from the per-recipient delivery log.
+// Empty fields match everything.
+type DeliveryLogFilter struct {
+	DeliveryID string
+	ScheduleID string
+	Recipient  string
+	Channel    string
+	Status     string
+	Limit      int
+	Offset     int
+}
+
 // DeliveryContentStats contains statistics about delivered content.
 type DeliveryContentStats struct {
 	// NewMoviesCount is the number of new movies included.
@@ -588,6 +735,22 @@ type NewsletterContentData struct {
 
 	// Server health (for server_health type)
 	Health *NewsletterHealthData `json:"health,omitempty"`
+
+	// Rendered content blocks, in the order configured in TemplateConfig.Blocks
+	Blocks []NewsletterRenderedBlock `json:"blocks,omitempty"`
+}
+
+// NewsletterRenderedBlock is a content block rendered for delivery.
+// Blocks that fail to resolve or render are omitted.
+type NewsletterRenderedBlock struct {
+	Type  NewsletterBlockType `json:"type"`
+	Title string              `json:"title"`
+
+	// HTML is trusted markup produced by the block renderer.
+	HTML string `json:"html"`
+
+	// Text is the Markdown rendering used for plaintext bodies.
+	Text string `json:"text"`
 }
 
 // NewsletterMediaItem represents a media item for newsletter display.
@@ -761,8 +924,8 @@ type CreateScheduleRequest struct {
 	Name           string                             `json:"name" validate:"required,min=1,max=100"`
 	Description    string                             `json:"description,omitempty" validate:"max=500"`
 	TemplateID     string                             `json:"template_id" validate:"required"`
-	Recipients     []NewsletterRecipient              `json:"recipients" validate:"required,min=1"`
-	CronExpression string                             `json:"cron_expression" validate:"required"`
+	Recipients     []NewsletterRecipient              `json:"recipients,omitempty"`
+	CronExpression string                             `json:"cron_expression" validate:"required,cron"`
 	Timezone       string                             `json:"timezone" validate:"required"`
 	Config         *TemplateConfig                    `json:"config,omitempty"`
 	Channels       []DeliveryChannel                  `json:"channels" validate:"required,min=1"`
@@ -776,7 +939,7 @@ type UpdateScheduleRequest struct {
 	Description    *string                            `json:"description,omitempty" validate:"omitempty,max=500"`
 	TemplateID     *string                            `json:"template_id,omitempty"`
 	Recipients     []NewsletterRecipient              `json:"recipients,omitempty"`
-	CronExpression *string                            `json:"cron_expression,omitempty"`
+	CronExpression *string                            `json:"cron_expression,omitempty" validate:"omitempty,cron"`
 	Timezone       *string                            `json:"timezone,omitempty"`
 	Config         *TemplateConfig                    `json:"config,omitempty"`
 	Channels       []DeliveryChannel                  `json:"channels,omitempty"`
@@ -798,14 +961,17 @@ type PreviewNewsletterRequest struct {
 	TemplateID string          `json:"template_id" validate:"required"`
 	Config     *TemplateConfig `json:"config,omitempty"`
 	ForUserID  *string         `json:"for_user_id,omitempty"` // For personalized preview
+	Live       bool            `json:"live,omitempty"`        // Resolve real content instead of sample data
 }
 
 // PreviewNewsletterResponse is the response body for newsletter preview.
 type PreviewNewsletterResponse struct {
-	Subject  string                 `json:"subject"`
-	BodyHTML string                 `json:"body_html"`
-	BodyText string                 `json:"body_text"`
-	Data     *NewsletterContentData `json:"data"`
+	Subject    string                 `json:"subject"`
+	BodyHTML   string                 `json:"body_html"`
+	BodyText   string                 `json:"body_text"`
+	Data       *NewsletterContentData `json:"data"`
+	Live       bool                   `json:"live"`
+	Recipients []NewsletterRecipient  `json:"recipients,omitempty"`
 }
 
 // ListTemplatesResponse is the response body for listing templates.
@@ -826,6 +992,32 @@ type ListDeliveriesResponse struct {
 	Pagination PaginationInfo       `json:"pagination"`
 }
 
+// ListDeliveryLogResponse is the response body for querying the per-recipient
+// delivery log.
+type ListDeliveryLogResponse struct {
+	Entries    []NewsletterDeliveryLogEntry `json:"entries"`
+	Pagination PaginationInfo               `json:"pagination"`
+}
+
+// TestEmailRequest is the request body for sending an SMTP test message.
+// Config falls back to the server's default SMTP settings when omitted.
+type TestEmailRequest struct {
+	To     string         `json:"to" validate:"required,email"`
+	Config *ChannelConfig `json:"config,omitempty"`
+}
+
+// TestEmailResponse reports the outcome of an SMTP test message.
+type TestEmailResponse struct {
+	Success      bool   `json:"success"`
+	Recipient    string `json:"recipient"`
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     int    `json:"smtp_port"`
+	TLSMode      string `json:"tls_mode"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	DurationMS   int64  `json:"duration_ms"`
+}
+
 // NewsletterStatsResponse contains aggregated newsletter statistics.
 type NewsletterStatsResponse struct {
 	TotalTemplates       int            `json:"total_templates"`
@@ -0,0 +1,39 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package models
+
+import "testing"
+
+func TestResolveNewsletterRecipients(t *testing.T) {
+	scheduleRecipients := []NewsletterRecipient{{Type: "user", Target: "user-1"}}
+	config := &TemplateConfig{Recipients: []NewsletterRecipient{{Type: "email", Target: "ops@example.com"}}}
+
+	tests := []struct {
+		name       string
+		schedule   []NewsletterRecipient
+		config     *TemplateConfig
+		wantTarget string
+	}{
+		{"schedule recipients win", scheduleRecipients, config, "user-1"},
+		{"falls back to config", nil, config, "ops@example.com"},
+		{"nothing configured", nil, nil, ""},
+		{"config without recipients", nil, &TemplateConfig{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveNewsletterRecipients(tt.schedule, tt.config)
+			if tt.wantTarget == "" {
+				if len(got) != 0 {
+					t.Errorf("recipients = %+v, want none", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Target != tt.wantTarget {
+				t.Errorf("recipients = %+v, want %s", got, tt.wantTarget)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package models
+
+import "time"
+
+// NowPlayingSession is an active playback session, normalized across
+// Plex, Jellyfin and Emby.
+type NowPlayingSession struct {
+	Source    string `json:"source"` // plex, jellyfin, emby
+	ServerID  string `json:"server_id,omitempty"`
+	SessionID string `json:"session_id"`
+
+	UserID   string `json:"user_id,omitempty"` // Media server's user ID
+	Username string `json:"username"`
+
+	MediaType        string `json:"media_type"` // movie, episode, track
+	Title            string `json:"title"`
+	ParentTitle      string `json:"parent_title,omitempty"`      // Season/Album
+	GrandparentTitle string `json:"grandparent_title,omitempty"` // Show/Artist
+	RatingKey        string `json:"rating_key,omitempty"`
+
+	State           string `json:"state"` // playing, paused, buffering
+	PositionSeconds int64  `json:"position_seconds"`
+	DurationSeconds int64  `json:"duration_seconds"`
+	ProgressPercent int    `json:"progress_percent"`
+
+	Player    string `json:"player"`             // Device friendly name
+	Platform  string `json:"platform,omitempty"` // Client platform or application
+	Product   string `json:"product,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+
+	VideoResolution string `json:"video_resolution,omitempty"` // Source resolution (4k, 1080, 720, sd)
+	VideoCodec      string `json:"video_codec,omitempty"`
+	AudioCodec      string `json:"audio_codec,omitempty"`
+	Container       string `json:"container,omitempty"`
+	Bitrate         int    `json:"bitrate,omitempty"` // Kbps
+
+	TranscodeDecision string               `json:"transcode_decision"` // direct play, copy/direct stream, transcode
+	Transcode         *NowPlayingTranscode `json:"transcode,omitempty"`
+
+	Geolocation *Geolocation `json:"geolocation,omitempty"`
+
+	// Stale is set when the source could not be polled and the session is
+	// carried over from its last successful poll.
+	Stale bool `json:"stale"`
+}
+
+// NowPlayingTranscode describes the output of a transcoding session.
+type NowPlayingTranscode struct {
+	VideoDecision    string   `json:"video_decision,omitempty"`
+	AudioDecision    string   `json:"audio_decision,omitempty"`
+	VideoCodec       string   `json:"video_codec,omitempty"`
+	AudioCodec       string   `json:"audio_codec,omitempty"`
+	Container        string   `json:"container,omitempty"`
+	Width            int      `json:"width,omitempty"`
+	Height           int      `json:"height,omitempty"`
+	HardwareAccel    string   `json:"hardware_accel,omitempty"` // e.g. nvenc, qsv, vaapi
+	Speed            float64  `json:"speed,omitempty"`          // 1.5 = 1.5x realtime (Plex only)
+	Throttled        bool     `json:"throttled,omitempty"`
+	TranscodeReasons []string `json:"transcode_reasons,omitempty"` // Jellyfin/Emby only
+}
+
+// NowPlayingSourceStatus reports how one media server contributed to a
+// now-playing snapshot.
+type NowPlayingSourceStatus struct {
+	Source       string     `json:"source"`
+	ServerID     string     `json:"server_id,omitempty"`
+	SessionCount int        `json:"session_count"`
+	Stale        bool       `json:"stale"`
+	Error        string     `json:"error,omitempty"`
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"` // Last successful poll; omitted until one succeeds
+}
+
+// NowPlayingResponse is the merged list of active sessions across all sources.
+type NowPlayingResponse struct {
+	Sessions    []NowPlayingSession      `json:"sessions"`
+	Sources     []NowPlayingSourceStatus `json:"sources"`
+	TotalCount  int                      `json:"total_count"`
+	StaleCount  int                      `json:"stale_count"`
+	GeneratedAt time.Time                `json:"generated_at"`
+}
@@ -8,6 +8,7 @@
 package models
 
 import (
+	"net"
 	"time"
 )
 
@@ -177,16 +178,20 @@ func (t *PersonalAccessToken) HasAnyScope(scopes ...TokenScope) bool {
 }
 
 // IsIPAllowed checks if an IP address is allowed for this token.
+// Allowlist entries are single IPs or CIDR ranges.
 // Returns true if no allowlist is configured (all IPs allowed).
 func (t *PersonalAccessToken) IsIPAllowed(ip string) bool {
 	if len(t.IPAllowlist) == 0 {
 		return true
 	}
+	parsed := net.ParseIP(ip)
 	for _, allowed := range t.IPAllowlist {
 		if allowed == ip {
 			return true
 		}
-		// TODO: Add CIDR matching support
+		if _, network, err := net.ParseCIDR(allowed); err == nil && parsed != nil && network.Contains(parsed) {
+			return true
+		}
 	}
 	return false
 }
@@ -197,7 +202,7 @@ type CreatePATRequest struct {
 	Description string       `json:"description,omitempty" validate:"max=500"`
 	Scopes      []TokenScope `json:"scopes" validate:"required,min=1,dive"`
 	ExpiresIn   *int         `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=365"`
-	IPAllowlist []string     `json:"ip_allowlist,omitempty" validate:"omitempty,dive,ip"`
+	IPAllowlist []string     `json:"ip_allowlist,omitempty" validate:"omitempty,dive,cidr"`
 }
 
 // CreatePATResponse represents the response when creating a PAT.
@@ -215,7 +220,7 @@ type UpdatePATRequest struct {
 	Description string       `json:"description,omitempty" validate:"max=500"`
 	Scopes      []TokenScope `json:"scopes,omitempty" validate:"omitempty,min=1,dive"`
 	ExpiresIn   *int         `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=365"`
-	IPAllowlist []string     `json:"ip_allowlist,omitempty" validate:"omitempty,dive,ip"`
+	IPAllowlist []string     `json:"ip_allowlist,omitempty" validate:"omitempty,dive,cidr"`
 }
 
 // RevokePATRequest represents a request to revoke a PAT.
@@ -48,11 +48,11 @@ import (
 // JSON serialization uses omitempty for optional pointer fields to minimize
 // response payload size.
 type PlaybackEvent struct {
-	ID uuid.UUID `json:"id"`
+	ID uuid.UUID `json:"id" validate:"required"`
 
 	// Data source tracking (v1.37) - Enables hybrid Plex + Tautulli architecture
-	Source  string  `json:"source"`             // 'tautulli', 'plex', 'jellyfin', or 'emby'
-	PlexKey *string `json:"plex_key,omitempty"` // Plex metadata rating key for correlation
+	Source  string  `json:"source" validate:"required"` // 'tautulli', 'plex', 'jellyfin', or 'emby'
+	PlexKey *string `json:"plex_key,omitempty"`         // Plex metadata rating key for correlation
 
 	// Multi-server support (v2.0 - Phase 0.6)
 	// ServerID uniquely identifies the source server instance, enabling:
@@ -72,9 +72,9 @@ type PlaybackEvent struct {
 	TransactionID *string `json:"transaction_id,omitempty"`
 
 	// Session identification
-	SessionKey string     `json:"session_key"`
-	StartedAt  time.Time  `json:"started_at"`
-	StoppedAt  *time.Time `json:"stopped_at,omitempty"`
+	SessionKey string     `json:"session_key" validate:"required"`
+	StartedAt  time.Time  `json:"started_at" validate:"required"`
+	StoppedAt  *time.Time `json:"stopped_at,omitempty" validate:"omitempty,gtefield=StartedAt"`
 
 	// Grouped playback fields (v1.46 - API Coverage Expansion)
 	GroupCount *int    `json:"group_count,omitempty"` // Number of grouped entries (for grouped view)
@@ -82,7 +82,7 @@ type PlaybackEvent struct {
 	State      *string `json:"state,omitempty"`       // Playback state at end of session (null for stopped)
 
 	// User information
-	UserID       int     `json:"user_id"`
+	UserID       int     `json:"user_id" validate:"gte=0"`
 	Username     string  `json:"username"`
 	FriendlyName *string `json:"friendly_name,omitempty"` // Display name
 	UserThumb    *string `json:"user_thumb,omitempty"`    // User avatar URL
@@ -106,8 +106,8 @@ type PlaybackEvent struct {
 	IPAddressPublic *string `json:"ip_address_public,omitempty"` // Public IP for accurate geolocation
 
 	// Media identification
-	MediaType        string  `json:"media_type"`
-	Title            string  `json:"title"`
+	MediaType        string  `json:"media_type" validate:"required"`
+	Title            string  `json:"title" validate:"required"`
 	ParentTitle      *string `json:"parent_title,omitempty"`
 	GrandparentTitle *string `json:"grandparent_title,omitempty"`
 	SortTitle        *string `json:"sort_title,omitempty"` // Sort title for ordering (v1.45 - API Coverage Expansion)
@@ -127,9 +127,9 @@ type PlaybackEvent struct {
 	SyncedVersion    *int    `json:"synced_version,omitempty"`
 
 	// Playback metrics
-	PercentComplete int  `json:"percent_complete"`
-	PausedCounter   int  `json:"paused_counter"`
-	PlayDuration    *int `json:"play_duration,omitempty"`
+	PercentComplete int  `json:"percent_complete" validate:"min=0,max=100"`
+	PausedCounter   int  `json:"paused_counter" validate:"gte=0"`
+	PlayDuration    *int `json:"play_duration,omitempty" validate:"omitempty,gte=0"`
 	Throttled       *int `json:"throttled,omitempty"` // 0 or 1 - Playback throttled status (v1.45 - API Coverage Expansion)
 
 	// Live TV fields (v1.45 - API Coverage Expansion)
@@ -148,8 +148,8 @@ type PlaybackEvent struct {
 	// Hardware transcode fields (CRITICAL for GPU utilization monitoring)
 	TranscodeKey            *string `json:"transcode_key,omitempty"`
 	TranscodeThrottled      *int    `json:"transcode_throttled,omitempty"`
-	TranscodeProgress       *int    `json:"transcode_progress,omitempty"` // 0-100
-	TranscodeSpeed          *string `json:"transcode_speed,omitempty"`    // Speed multiplier (e.g., "2.5")
+	TranscodeProgress       *int    `json:"transcode_progress,omitempty" validate:"omitempty,min=0,max=100"` // 0-100
+	TranscodeSpeed          *string `json:"transcode_speed,omitempty"`                                       // Speed multiplier (e.g., "2.5")
 	TranscodeHWRequested    *int    `json:"transcode_hw_requested,omitempty"`
 	TranscodeHWDecoding     *int    `json:"transcode_hw_decoding,omitempty"`
 	TranscodeHWEncoding     *int    `json:"transcode_hw_encoding,omitempty"`
@@ -211,14 +211,14 @@ type PlaybackEvent struct {
 	RatingKey             *string `json:"rating_key,omitempty"`
 	ParentRatingKey       *string `json:"parent_rating_key,omitempty"`
 	GrandparentRatingKey  *string `json:"grandparent_rating_key,omitempty"`
-	MediaIndex            *int    `json:"media_index,omitempty"`             // Episode number (for binge detection)
-	ParentMediaIndex      *int    `json:"parent_media_index,omitempty"`      // Season number (for binge detection)
-	GUID                  *string `json:"guid,omitempty"`                    // External IDs (IMDB, TVDB, TMDB)
-	OriginalTitle         *string `json:"original_title,omitempty"`          // Original non-localized title
-	FullTitle             *string `json:"full_title,omitempty"`              // Formatted full title
-	OriginallyAvailableAt *string `json:"originally_available_at,omitempty"` // Release date
-	WatchedStatus         *int    `json:"watched_status,omitempty"`          // 0 = unwatched, 1 = watched
-	Thumb                 *string `json:"thumb,omitempty"`                   // Thumbnail URL
+	MediaIndex            *int    `json:"media_index,omitempty"`                                       // Episode number (for binge detection)
+	ParentMediaIndex      *int    `json:"parent_media_index,omitempty"`                                // Season number (for binge detection)
+	GUID                  *string `json:"guid,omitempty"`                                              // External IDs (IMDB, TVDB, TMDB)
+	OriginalTitle         *string `json:"original_title,omitempty"`                                    // Original non-localized title
+	FullTitle             *string `json:"full_title,omitempty"`                                        // Formatted full title
+	OriginallyAvailableAt *string `json:"originally_available_at,omitempty"`                           // Release date
+	WatchedStatus         *int    `json:"watched_status,omitempty" validate:"omitempty,min=0,max=100"` // 0 = unwatched, 1 = watched
+	Thumb                 *string `json:"thumb,omitempty"`                                             // Thumbnail URL
 
 	// Cast and crew (comma-separated strings)
 	Directors *string `json:"directors,omitempty"` // Director names
@@ -228,7 +228,7 @@ type PlaybackEvent struct {
 
 	// Stream output fields (transcoded output quality)
 	StreamContainer           *string `json:"stream_container,omitempty"`
-	StreamBitrate             *int    `json:"stream_bitrate,omitempty"`
+	StreamBitrate             *int    `json:"stream_bitrate,omitempty" validate:"omitempty,gte=0"`
 	StreamVideoCodec          *string `json:"stream_video_codec,omitempty"`
 	StreamVideoCodecLevel     *string `json:"stream_video_codec_level,omitempty"`
 	StreamVideoResolution     *string `json:"stream_video_resolution,omitempty"`
@@ -306,9 +306,14 @@ type PlaybackEvent struct {
 	BandwidthLAN *int    `json:"bandwidth_lan,omitempty"` // LAN bandwidth limit (kbps)
 	BandwidthWAN *int    `json:"bandwidth_wan,omitempty"` // WAN bandwidth limit (kbps)
 
+	// ConnectionType classifies the client address at ingest: "residential",
+	// "vpn", "datacenter" or "unknown". Set from the VPN and datacenter
+	// ranges when the event is stored, unless already set.
+	ConnectionType *string `json:"connection_type,omitempty"`
+
 	// File metadata
-	FileSize *int64  `json:"file_size,omitempty"`
-	Bitrate  *int    `json:"bitrate,omitempty"`
+	FileSize *int64  `json:"file_size,omitempty" validate:"omitempty,gte=0"`
+	Bitrate  *int    `json:"bitrate,omitempty" validate:"omitempty,gte=0"`
 	File     *string `json:"file,omitempty"` // Full file path
 
 	// Bitrate analytics fields (v1.42) - 3-level tracking for network bottleneck identification
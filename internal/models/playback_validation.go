@@ -0,0 +1,83 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrInvalidPlaybackEvent is wrapped by the error PlaybackEvent.Validate returns.
+var ErrInvalidPlaybackEvent = errors.New("invalid playback event")
+
+// playbackValidator checks the validate tags on PlaybackEvent. It is built
+// with the same options as the singleton in internal/validation, which
+// imports this package and so cannot be used here.
+var (
+	playbackValidator     *validator.Validate
+	playbackValidatorOnce sync.Once
+)
+
+// getPlaybackValidator returns the validator, reporting JSON field names.
+func getPlaybackValidator() *validator.Validate {
+	playbackValidatorOnce.Do(func() {
+		playbackValidator = validator.New(validator.WithRequiredStructEnabled())
+		playbackValidator.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				return field.Name
+			}
+			return name
+		})
+	})
+	return playbackValidator
+}
+
+// Validate checks the event for obviously invalid data before it is inserted:
+// missing identity fields (ID, source, session key, start time, media type,
+// title), a stop time before the start time, negative counters, durations,
+// and sizes, and percentages outside 0-100.
+//
+// The returned error wraps ErrInvalidPlaybackEvent and lists every failing
+// field, e.g. "invalid playback event: percent_complete must be at most 100".
+func (e *PlaybackEvent) Validate() error {
+	err := getPlaybackValidator().Struct(e)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return fmt.Errorf("%w: %w", ErrInvalidPlaybackEvent, err)
+	}
+
+	messages := make([]string, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		messages[i] = describePlaybackFieldError(fe)
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidPlaybackEvent, strings.Join(messages, "; "))
+}
+
+// describePlaybackFieldError formats a failed validate tag as a short message.
+func describePlaybackFieldError(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + " is required"
+	case "gtefield":
+		return fe.Field() + " must not be before started_at"
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s (got %v)", fe.Field(), fe.Param(), fe.Value())
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s (got %v)", fe.Field(), fe.Param(), fe.Value())
+	default:
+		return fmt.Sprintf("%s failed %s validation", fe.Field(), fe.Tag())
+	}
+}
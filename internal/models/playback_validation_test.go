@@ -0,0 +1,120 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// validPlaybackEvent returns an event that passes Validate, with every
+// validated optional field set to an in-range value.
+func validPlaybackEvent() *PlaybackEvent {
+	started := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	stopped := started.Add(45 * time.Minute)
+	playDuration, progress, watched, bitrate := 2700, 80, 1, 8000
+	fileSize := int64(4 << 30)
+
+	return &PlaybackEvent{
+		ID:                uuid.New(),
+		Source:            "tautulli",
+		SessionKey:        "session-1",
+		StartedAt:         started,
+		StoppedAt:         &stopped,
+		UserID:            7,
+		Username:          "alice",
+		MediaType:         "movie",
+		Title:             "Arrival",
+		PercentComplete:   95,
+		PausedCounter:     2,
+		PlayDuration:      &playDuration,
+		TranscodeProgress: &progress,
+		WatchedStatus:     &watched,
+		StreamBitrate:     &bitrate,
+		Bitrate:           &bitrate,
+		FileSize:          &fileSize,
+	}
+}
+
+func TestPlaybackEvent_Validate_Valid(t *testing.T) {
+	if err := validPlaybackEvent().Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	// Optional fields may be absent
+	event := validPlaybackEvent()
+	event.StoppedAt, event.PlayDuration, event.TranscodeProgress = nil, nil, nil
+	event.WatchedStatus, event.StreamBitrate, event.Bitrate, event.FileSize = nil, nil, nil, nil
+	if err := event.Validate(); err != nil {
+		t.Errorf("Validate() without optional fields = %v, want nil", err)
+	}
+}
+
+func TestPlaybackEvent_Validate_Invalid(t *testing.T) {
+	negative, over := -1, 101
+	negative64 := int64(-1)
+	early := time.Date(2026, 3, 1, 19, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		mutate func(*PlaybackEvent)
+		want   string
+	}{
+		{"missing id", func(e *PlaybackEvent) { e.ID = uuid.Nil }, "id is required"},
+		{"missing source", func(e *PlaybackEvent) { e.Source = "" }, "source is required"},
+		{"missing session key", func(e *PlaybackEvent) { e.SessionKey = "" }, "session_key is required"},
+		{"missing started at", func(e *PlaybackEvent) { e.StartedAt = time.Time{} }, "started_at is required"},
+		{"stopped before started", func(e *PlaybackEvent) { e.StoppedAt = &early }, "stopped_at must not be before started_at"},
+		{"negative user id", func(e *PlaybackEvent) { e.UserID = -1 }, "user_id must be at least 0"},
+		{"missing media type", func(e *PlaybackEvent) { e.MediaType = "" }, "media_type is required"},
+		{"missing title", func(e *PlaybackEvent) { e.Title = "" }, "title is required"},
+		{"negative percent complete", func(e *PlaybackEvent) { e.PercentComplete = -5 }, "percent_complete must be at least 0"},
+		{"percent complete over 100", func(e *PlaybackEvent) { e.PercentComplete = 150 }, "percent_complete must be at most 100"},
+		{"negative paused counter", func(e *PlaybackEvent) { e.PausedCounter = -1 }, "paused_counter must be at least 0"},
+		{"negative play duration", func(e *PlaybackEvent) { e.PlayDuration = &negative }, "play_duration must be at least 0"},
+		{"transcode progress over 100", func(e *PlaybackEvent) { e.TranscodeProgress = &over }, "transcode_progress must be at most 100"},
+		{"watched status over 100", func(e *PlaybackEvent) { e.WatchedStatus = &over }, "watched_status must be at most 100"},
+		{"negative watched status", func(e *PlaybackEvent) { e.WatchedStatus = &negative }, "watched_status must be at least 0"},
+		{"negative stream bitrate", func(e *PlaybackEvent) { e.StreamBitrate = &negative }, "stream_bitrate must be at least 0"},
+		{"negative bitrate", func(e *PlaybackEvent) { e.Bitrate = &negative }, "bitrate must be at least 0"},
+		{"negative file size", func(e *PlaybackEvent) { e.FileSize = &negative64 }, "file_size must be at least 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := validPlaybackEvent()
+			tt.mutate(event)
+
+			err := event.Validate()
+			if !errors.Is(err, ErrInvalidPlaybackEvent) {
+				t.Fatalf("Validate() = %v, want ErrInvalidPlaybackEvent", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %q, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaybackEvent_Validate_ReportsEveryField(t *testing.T) {
+	event := validPlaybackEvent()
+	event.Title = ""
+	event.PercentComplete = 200
+
+	err := event.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error")
+	}
+	for _, want := range []string{"title is required", "percent_complete must be at most 100"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to mention %q", err, want)
+		}
+	}
+}
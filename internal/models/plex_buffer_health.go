@@ -349,3 +349,64 @@ func CalculateBufferHealth(
 		AlertSent:          false,
 	}
 }
+
+// BufferHealthCriticalEvent is emitted once when a session's buffer drops
+// below the critical threshold, so operators are warned before playback
+// stalls. Another event is emitted only after the session recovers above
+// the threshold and drops below it again.
+type BufferHealthCriticalEvent struct {
+	SessionKey   string `json:"session_key"`
+	Title        string `json:"title"`
+	Username     string `json:"username,omitempty"`
+	PlayerDevice string `json:"player_device,omitempty"`
+
+	BufferFillPercent float64 `json:"buffer_fill_percent"`
+	BufferSeconds     float64 `json:"buffer_seconds"`
+	BufferDrainRate   float64 `json:"buffer_drain_rate"`
+	CriticalThreshold float64 `json:"critical_threshold"`
+
+	// PredictedStallSeconds is the estimated time until the buffer runs
+	// out at the current trend; -1 if the buffer is not draining.
+	PredictedStallSeconds float64 `json:"predicted_stall_seconds"`
+
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewBufferHealthCriticalEvent builds the critical event for a buffer
+// health sample and its predicted time to stall.
+func NewBufferHealthCriticalEvent(bh *PlexBufferHealth, criticalThreshold, predictedStallSeconds float64) *BufferHealthCriticalEvent {
+	message := fmt.Sprintf("Critical: %s low buffer (%.0f%%)", bh.Title, bh.BufferFillPercent)
+	if predictedStallSeconds >= 0 {
+		message = fmt.Sprintf("Critical: %s buffering in %.0fs (buffer: %.0f%%)",
+			bh.Title, predictedStallSeconds, bh.BufferFillPercent)
+	}
+	return &BufferHealthCriticalEvent{
+		SessionKey:            bh.SessionKey,
+		Title:                 bh.Title,
+		Username:              bh.Username,
+		PlayerDevice:          bh.PlayerDevice,
+		BufferFillPercent:     bh.BufferFillPercent,
+		BufferSeconds:         bh.BufferSeconds,
+		BufferDrainRate:       bh.BufferDrainRate,
+		CriticalThreshold:     criticalThreshold,
+		PredictedStallSeconds: predictedStallSeconds,
+		Message:               message,
+		Timestamp:             bh.Timestamp,
+	}
+}
+
+// PredictStallSeconds estimates the seconds until a buffer runs out from
+// its trend between two polls interval apart: the current buffer divided
+// by the rate at which it shrank. Returns 0 if the buffer is already empty
+// and -1 if it did not shrink.
+func PredictStallSeconds(previousBufferSeconds, currentBufferSeconds float64, interval time.Duration) float64 {
+	if currentBufferSeconds <= 0 {
+		return 0
+	}
+	drop := previousBufferSeconds - currentBufferSeconds
+	if drop <= 0 || interval <= 0 {
+		return -1
+	}
+	return currentBufferSeconds / (drop / interval.Seconds())
+}
@@ -0,0 +1,86 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+// Quarantine reasons for events with implausible timestamps.
+const (
+	QuarantineReasonTooOld   = "started_at_too_old"
+	QuarantineReasonInFuture = "started_at_in_future"
+)
+
+// Quarantine statuses.
+const (
+	QuarantineStatusPending     = "pending"
+	QuarantineStatusReprocessed = "reprocessed"
+)
+
+// QuarantinedEvent is a playback event held back from playback_events because
+// its started_at fell outside the accepted timestamp window (for example a
+// corrupt epoch value of 1970 or 2106). The full event is kept so it can be
+// corrected and reprocessed.
+//
+// Status workflow:
+//   - pending: Quarantined, awaiting review (default)
+//   - reprocessed: Re-inserted with a corrected timestamp
+type QuarantinedEvent struct {
+	ID            uuid.UUID `json:"id"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+
+	// The quarantined event
+	EventID    string          `json:"event_id"`
+	Source     string          `json:"source"`
+	ServerID   string          `json:"server_id,omitempty"`
+	SessionKey string          `json:"session_key,omitempty"`
+	StartedAt  time.Time       `json:"started_at"`
+	UserID     int             `json:"user_id"`
+	Username   string          `json:"username,omitempty"`
+	Title      string          `json:"title,omitempty"`
+	RawPayload json.RawMessage `json:"raw_payload"` // PlaybackEvent JSON as it would have been inserted
+
+	// Why it was quarantined: 'started_at_too_old', 'started_at_in_future'
+	Reason string `json:"reason"`
+
+	// Resolution
+	Status             string     `json:"status"` // 'pending', 'reprocessed'
+	CorrectedStartedAt *time.Time `json:"corrected_started_at,omitempty"`
+	ResolvedBy         string     `json:"resolved_by,omitempty"`
+	ResolvedAt         *time.Time `json:"resolved_at,omitempty"`
+}
+
+// NewQuarantinedEvent builds a pending quarantine entry for event.
+func NewQuarantinedEvent(event *PlaybackEvent, reason string) (*QuarantinedEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal quarantined event: %w", err)
+	}
+
+	entry := &QuarantinedEvent{
+		ID:            uuid.New(),
+		QuarantinedAt: time.Now(),
+		EventID:       event.ID.String(),
+		Source:        event.Source,
+		SessionKey:    event.SessionKey,
+		StartedAt:     event.StartedAt,
+		UserID:        event.UserID,
+		Username:      event.Username,
+		Title:         event.Title,
+		RawPayload:    payload,
+		Reason:        reason,
+		Status:        QuarantineStatusPending,
+	}
+	if event.ServerID != nil {
+		entry.ServerID = *event.ServerID
+	}
+	return entry, nil
+}
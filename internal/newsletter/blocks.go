@@ -0,0 +1,200 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// Package newsletter provides newsletter generation and delivery functionality.
+//
+// blocks.go - Composable Newsletter Content Blocks
+//
+// This file implements the content block registry:
+//   - Each block pairs a data resolver with an HTML and a Markdown template
+//   - Schedules choose blocks and their order via TemplateConfig.Blocks
+//   - Blocks are resolved with the newsletter's date range
+//   - A block that fails to resolve or render is omitted and logged, so one
+//     failing query never prevents the newsletter from being delivered
+package newsletter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// BlockRequest describes the newsletter a block is resolved for.
+type BlockRequest struct {
+	// Start and End bound the newsletter's reporting period.
+	Start time.Time
+	End   time.Time
+
+	// Config is the effective template configuration (may be nil).
+	Config *models.TemplateConfig
+}
+
+// MaxItems returns the configured per-category item limit, or defaultMax.
+func (r BlockRequest) MaxItems(defaultMax int) int {
+	return getMaxItems(r.Config, defaultMax)
+}
+
+// BlockResolver fetches the data a block renders. Returning nil data with a
+// nil error omits the block, e.g. when there is nothing to show.
+type BlockResolver func(ctx context.Context, req BlockRequest) (interface{}, error)
+
+// ContentBlock is a composable newsletter section.
+type ContentBlock struct {
+	// Type identifies the block in TemplateConfig.Blocks.
+	Type models.NewsletterBlockType
+
+	// Title is the section heading.
+	Title string
+
+	// Resolve fetches the block's data for the newsletter period.
+	Resolve BlockResolver
+
+	// HTML renders the data for HTML bodies. It is executed with the
+	// resolver's data and must produce a self-contained fragment.
+	HTML *htmltemplate.Template
+
+	// Markdown renders the data for plaintext bodies.
+	Markdown *texttemplate.Template
+}
+
+// BlockRegistry holds the content blocks available to newsletters.
+type BlockRegistry struct {
+	mu     sync.RWMutex
+	blocks map[models.NewsletterBlockType]*ContentBlock
+	logger zerolog.Logger
+}
+
+// NewBlockRegistry creates an empty block registry.
+func NewBlockRegistry(logger *zerolog.Logger) *BlockRegistry {
+	return &BlockRegistry{
+		blocks: make(map[models.NewsletterBlockType]*ContentBlock),
+		logger: logger.With().Str("component", "newsletter_blocks").Logger(),
+	}
+}
+
+// Register adds a block, replacing any block of the same type.
+func (r *BlockRegistry) Register(block *ContentBlock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blocks[block.Type] = block
+}
+
+// Has reports whether a block type is registered.
+func (r *BlockRegistry) Has(blockType models.NewsletterBlockType) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.blocks[blockType]
+	return ok
+}
+
+// Render resolves and renders the requested blocks in order. Unknown block
+// types and blocks that fail are skipped with a warning.
+func (r *BlockRegistry) Render(ctx context.Context, types []models.NewsletterBlockType, req BlockRequest) []models.NewsletterRenderedBlock {
+	rendered := make([]models.NewsletterRenderedBlock, 0, len(types))
+	seen := make(map[models.NewsletterBlockType]bool, len(types))
+
+	for _, blockType := range types {
+		if seen[blockType] {
+			continue
+		}
+		seen[blockType] = true
+
+		r.mu.RLock()
+		block, ok := r.blocks[blockType]
+		r.mu.RUnlock()
+		if !ok {
+			r.logger.Warn().Str("block", string(blockType)).Msg("Unknown newsletter block, skipping")
+			continue
+		}
+
+		out, err := r.renderBlock(ctx, block, req)
+		if err != nil {
+			r.logger.Warn().Err(err).Str("block", string(blockType)).Msg("Newsletter block failed, omitting")
+			continue
+		}
+		if out == nil {
+			r.logger.Debug().Str("block", string(blockType)).Msg("Newsletter block has no content, omitting")
+			continue
+		}
+		rendered = append(rendered, *out)
+	}
+
+	return rendered
+}
+
+// renderBlock resolves and renders one block. A panic in the resolver or a
+// template is reported as an error.
+func (r *BlockRegistry) renderBlock(ctx context.Context, block *ContentBlock, req BlockRequest) (out *models.NewsletterRenderedBlock, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			out, err = nil, fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	data, err := block.Resolve(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var htmlBuf, textBuf bytes.Buffer
+	if err := block.HTML.Execute(&htmlBuf, data); err != nil {
+		return nil, fmt.Errorf("render html: %w", err)
+	}
+	if err := block.Markdown.Execute(&textBuf, data); err != nil {
+		return nil, fmt.Errorf("render markdown: %w", err)
+	}
+
+	return &models.NewsletterRenderedBlock{
+		Type:  block.Type,
+		Title: block.Title,
+		HTML:  htmlBuf.String(),
+		Text:  strings.TrimSpace(textBuf.String()),
+	}, nil
+}
+
+// appendBlocksHTML adds rendered blocks to an HTML body that does not lay
+// them out itself, before </body> when present.
+func appendBlocksHTML(body string, blocks []models.NewsletterRenderedBlock) string {
+	var buf strings.Builder
+	for _, b := range blocks {
+		buf.WriteString(b.HTML)
+		buf.WriteString("\n")
+	}
+
+	if idx := strings.LastIndex(strings.ToLower(body), "</body>"); idx >= 0 {
+		return body[:idx] + buf.String() + body[idx:]
+	}
+	return body + "\n" + buf.String()
+}
+
+// appendBlocksText adds rendered blocks to a plaintext body that does not
+// lay them out itself.
+func appendBlocksText(body string, blocks []models.NewsletterRenderedBlock) string {
+	var buf strings.Builder
+	buf.WriteString(strings.TrimRight(body, "\n"))
+	for _, b := range blocks {
+		buf.WriteString("\n\n")
+		buf.WriteString(b.Text)
+	}
+	buf.WriteString("\n")
+	return buf.String()
+}
+
+// usesBlocks reports whether a template lays out .Blocks itself.
+func usesBlocks(templateContent string) bool {
+	return strings.Contains(templateContent, ".Blocks")
+}
@@ -0,0 +1,345 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package newsletter
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/detection"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// AlertStore provides detection alerts for the detection_alerts block.
+// Implemented by *detection.DuckDBStore.
+type AlertStore interface {
+	ListAlerts(ctx context.Context, filter detection.AlertFilter) ([]detection.Alert, error)
+	GetAlertCount(ctx context.Context, filter detection.AlertFilter) (int, error)
+}
+
+// LocationStore provides geolocated playback counts for the playback_map
+// block. Implemented by *database.DB.
+type LocationStore interface {
+	GetNewsletterLocations(ctx context.Context, start, end time.Time, limit int) ([]models.LocationStats, error)
+}
+
+// BlockSources holds the data sources for the built-in blocks. Blocks whose
+// source is nil are not registered.
+type BlockSources struct {
+	Content   ContentStore
+	Alerts    AlertStore
+	Locations LocationStore
+}
+
+// mapLocationLimit caps the number of locations plotted on the playback map.
+const mapLocationLimit = 500
+
+// RegisterBuiltinBlocks registers the built-in content blocks.
+func RegisterBuiltinBlocks(r *BlockRegistry, src BlockSources) {
+	funcs := (&TemplateEngine{}).buildFuncMap()
+
+	if src.Content != nil {
+		r.Register(topContentBlock(src.Content, funcs))
+		r.Register(activeUsersBlock(src.Content, funcs))
+		r.Register(watchHoursBlock(src.Content, funcs))
+	}
+	if src.Alerts != nil {
+		r.Register(detectionAlertsBlock(src.Alerts, funcs))
+	}
+	if src.Locations != nil {
+		r.Register(playbackMapBlock(src.Locations, funcs))
+	}
+}
+
+// newContentBlock parses a block's templates. The templates are compile-time
+// constants, so a parse error is a programming error.
+func newContentBlock(blockType models.NewsletterBlockType, title string, funcs htmltemplate.FuncMap, resolve BlockResolver, htmlSrc, markdownSrc string) *ContentBlock {
+	return &ContentBlock{
+		Type:     blockType,
+		Title:    title,
+		Resolve:  resolve,
+		HTML:     htmltemplate.Must(htmltemplate.New(string(blockType)).Funcs(funcs).Parse(htmlSrc)),
+		Markdown: texttemplate.Must(texttemplate.New(string(blockType)).Funcs(texttemplate.FuncMap(funcs)).Parse(markdownSrc)),
+	}
+}
+
+// ============================================================================
+// Top Content
+// ============================================================================
+
+type topContentData struct {
+	Movies []models.NewsletterMediaItem
+	Shows  []models.NewsletterShowItem
+}
+
+func topContentBlock(store ContentStore, funcs htmltemplate.FuncMap) *ContentBlock {
+	resolve := func(ctx context.Context, req BlockRequest) (interface{}, error) {
+		limit := req.MaxItems(5)
+
+		movies, err := store.GetTopMovies(ctx, req.Start, limit)
+		if err != nil {
+			return nil, fmt.Errorf("top movies: %w", err)
+		}
+		shows, err := store.GetTopShows(ctx, req.Start, limit)
+		if err != nil {
+			return nil, fmt.Errorf("top shows: %w", err)
+		}
+		if len(movies) == 0 && len(shows) == 0 {
+			return nil, nil
+		}
+		return &topContentData{Movies: movies, Shows: shows}, nil
+	}
+
+	return newContentBlock(models.NewsletterBlockTopContent, "Most Watched", funcs, resolve, `
+<div class="section" data-block="top_content" style="margin: 0 0 30px;">
+  <h2 style="font-size: 18px; margin: 0 0 15px;">Most Watched</h2>
+  {{if .Movies}}<h3 style="font-size: 15px; margin: 0 0 8px;">Movies</h3>
+  <ol style="margin: 0 0 15px; padding-left: 20px;">
+    {{range .Movies}}<li>{{.Title}}{{if .Year}} ({{.Year}}){{end}} <span style="color: #888;">- {{.WatchCount}} plays, {{formatHours .WatchTime}}</span></li>
+    {{end}}
+  </ol>{{end}}
+  {{if .Shows}}<h3 style="font-size: 15px; margin: 0 0 8px;">Shows</h3>
+  <ol style="margin: 0; padding-left: 20px;">
+    {{range .Shows}}<li>{{.Title}} <span style="color: #888;">- {{.WatchCount}} plays, {{formatHours .WatchTime}}</span></li>
+    {{end}}
+  </ol>{{end}}
+</div>`, `
+## Most Watched
+{{if .Movies}}
+**Movies**
+{{range $i, $m := .Movies}}
+{{add $i 1}}. {{$m.Title}}{{if $m.Year}} ({{$m.Year}}){{end}} - {{$m.WatchCount}} plays, {{formatHours $m.WatchTime}}{{end}}
+{{end}}{{if .Shows}}
+**Shows**
+{{range $i, $s := .Shows}}
+{{add $i 1}}. {{$s.Title}} - {{$s.WatchCount}} plays, {{formatHours $s.WatchTime}}{{end}}
+{{end}}`)
+}
+
+// ============================================================================
+// Active Users
+// ============================================================================
+
+func activeUsersBlock(store ContentStore, funcs htmltemplate.FuncMap) *ContentBlock {
+	resolve := func(ctx context.Context, req BlockRequest) (interface{}, error) {
+		stats, err := store.GetPeriodStats(ctx, req.Start, req.End)
+		if err != nil {
+			return nil, fmt.Errorf("period stats: %w", err)
+		}
+		if stats == nil || len(stats.TopUsers) == 0 {
+			return nil, nil
+		}
+		users := stats.TopUsers
+		if limit := req.MaxItems(5); len(users) > limit {
+			users = users[:limit]
+		}
+		return users, nil
+	}
+
+	return newContentBlock(models.NewsletterBlockActiveUsers, "Most Active Users", funcs, resolve, `
+<div class="section" data-block="active_users" style="margin: 0 0 30px;">
+  <h2 style="font-size: 18px; margin: 0 0 15px;">Most Active Users</h2>
+  <ol style="margin: 0; padding-left: 20px;">
+    {{range .}}<li>{{.Username}} <span style="color: #888;">- {{.WatchCount}} plays, {{formatHours .WatchTime}}</span></li>
+    {{end}}
+  </ol>
+</div>`, `
+## Most Active Users
+{{range $i, $u := .}}
+{{add $i 1}}. {{$u.Username}} - {{$u.WatchCount}} plays, {{formatHours $u.WatchTime}}{{end}}`)
+}
+
+// ============================================================================
+// Watch Hours
+// ============================================================================
+
+type watchHoursData struct {
+	Hours         float64
+	PreviousHours float64
+	Playbacks     int
+
+	// ChangePercent is the change from the previous period of equal length.
+	// HasChange is false when the previous period had no watch time.
+	ChangePercent float64
+	HasChange     bool
+	Increased     bool
+}
+
+func watchHoursBlock(store ContentStore, funcs htmltemplate.FuncMap) *ContentBlock {
+	resolve := func(ctx context.Context, req BlockRequest) (interface{}, error) {
+		current, err := store.GetPeriodStats(ctx, req.Start, req.End)
+		if err != nil {
+			return nil, fmt.Errorf("current period stats: %w", err)
+		}
+		previous, err := store.GetPeriodStats(ctx, req.Start.Add(-req.End.Sub(req.Start)), req.Start)
+		if err != nil {
+			return nil, fmt.Errorf("previous period stats: %w", err)
+		}
+		if current == nil || previous == nil {
+			return nil, nil
+		}
+		return newWatchHoursData(current, previous), nil
+	}
+
+	return newContentBlock(models.NewsletterBlockWatchHours, "Watch Time", funcs, resolve, `
+<div class="section" data-block="watch_hours" style="margin: 0 0 30px;">
+  <h2 style="font-size: 18px; margin: 0 0 15px;">Watch Time</h2>
+  <p style="font-size: 28px; font-weight: 700; margin: 0;">{{formatHours .Hours}}</p>
+  <p style="color: #888; margin: 5px 0 0;">{{formatNumber .Playbacks}} plays{{if .HasChange}} &middot;
+    <span style="color: {{if .Increased}}#2ecc71{{else}}#e74c3c{{end}};">{{if .Increased}}&#9650;{{else}}&#9660;{{end}} {{formatPercent .ChangePercent}}</span>
+    vs previous period ({{formatHours .PreviousHours}}){{end}}</p>
+</div>`, `
+## Watch Time
+{{formatHours .Hours}} across {{formatNumber .Playbacks}} plays{{if .HasChange}} ({{if .Increased}}up{{else}}down{{end}} {{formatPercent .ChangePercent}} from {{formatHours .PreviousHours}} the previous period){{end}}`)
+}
+
+// newWatchHoursData compares two periods' watch time.
+func newWatchHoursData(current, previous *models.NewsletterStats) *watchHoursData {
+	data := &watchHoursData{
+		Hours:         current.TotalWatchTimeHours,
+		PreviousHours: previous.TotalWatchTimeHours,
+		Playbacks:     current.TotalPlaybacks,
+	}
+	if previous.TotalWatchTimeHours > 0 {
+		change := (current.TotalWatchTimeHours - previous.TotalWatchTimeHours) / previous.TotalWatchTimeHours * 100
+		data.HasChange = true
+		data.Increased = change >= 0
+		if change < 0 {
+			change = -change
+		}
+		data.ChangePercent = change
+	}
+	return data
+}
+
+// ============================================================================
+// Detection Alerts
+// ============================================================================
+
+type detectionAlertsData struct {
+	Total    int
+	Critical int
+	Warning  int
+	Info     int
+	Recent   []detection.Alert
+}
+
+func detectionAlertsBlock(store AlertStore, funcs htmltemplate.FuncMap) *ContentBlock {
+	resolve := func(ctx context.Context, req BlockRequest) (interface{}, error) {
+		start, end := req.Start, req.End
+		filter := detection.AlertFilter{StartDate: &start, EndDate: &end}
+
+		data := &detectionAlertsData{}
+		counts := []struct {
+			severity detection.Severity
+			dst      *int
+		}{
+			{detection.SeverityCritical, &data.Critical},
+			{detection.SeverityWarning, &data.Warning},
+			{detection.SeverityInfo, &data.Info},
+		}
+		for _, c := range counts {
+			f := filter
+			f.Severities = []detection.Severity{c.severity}
+			n, err := store.GetAlertCount(ctx, f)
+			if err != nil {
+				return nil, fmt.Errorf("count %s alerts: %w", c.severity, err)
+			}
+			*c.dst = n
+			data.Total += n
+		}
+
+		if data.Total > 0 {
+			recent := filter
+			recent.Limit = req.MaxItems(5)
+			recent.OrderBy = "created_at"
+			recent.OrderDirection = "desc"
+			alerts, err := store.ListAlerts(ctx, recent)
+			if err != nil {
+				return nil, fmt.Errorf("list alerts: %w", err)
+			}
+			data.Recent = alerts
+		}
+		return data, nil
+	}
+
+	return newContentBlock(models.NewsletterBlockDetectionAlerts, "Security Alerts", funcs, resolve, `
+<div class="section" data-block="detection_alerts" style="margin: 0 0 30px;">
+  <h2 style="font-size: 18px; margin: 0 0 15px;">Security Alerts</h2>
+  {{if .Total}}<p style="margin: 0 0 10px;">{{formatNumber .Total}} new alerts:
+    <span style="color: #e74c3c;">{{.Critical}} critical</span>,
+    <span style="color: #f39c12;">{{.Warning}} warning</span>,
+    <span style="color: #3498db;">{{.Info}} info</span></p>
+  <ul style="margin: 0; padding-left: 20px;">
+    {{range .Recent}}<li><strong>{{.Title}}</strong> <span style="color: #888;">- {{.Username}}, {{formatDateTime .CreatedAt}}</span></li>
+    {{end}}
+  </ul>{{else}}<p style="margin: 0;">No new alerts this period.</p>{{end}}
+</div>`, `
+## Security Alerts
+{{if .Total}}{{formatNumber .Total}} new alerts: {{.Critical}} critical, {{.Warning}} warning, {{.Info}} info
+{{range .Recent}}
+- **{{.Title}}** - {{.Username}}, {{formatDateTime .CreatedAt}}{{end}}{{else}}No new alerts this period.{{end}}`)
+}
+
+// ============================================================================
+// Playback Map
+// ============================================================================
+
+type playbackMapData struct {
+	ImageURL  htmltemplate.URL
+	Locations int
+	Countries int
+	Top       []models.LocationStats
+}
+
+func playbackMapBlock(store LocationStore, funcs htmltemplate.FuncMap) *ContentBlock {
+	resolve := func(ctx context.Context, req BlockRequest) (interface{}, error) {
+		locations, err := store.GetNewsletterLocations(ctx, req.Start, req.End, mapLocationLimit)
+		if err != nil {
+			return nil, fmt.Errorf("locations: %w", err)
+		}
+		if len(locations) == 0 {
+			return nil, nil
+		}
+
+		pngData, err := renderPlaybackMap(locations)
+		if err != nil {
+			return nil, fmt.Errorf("render map: %w", err)
+		}
+
+		countries := make(map[string]bool)
+		for _, loc := range locations {
+			countries[loc.Country] = true
+		}
+		top := locations
+		if limit := req.MaxItems(5); len(top) > limit {
+			top = top[:limit]
+		}
+
+		return &playbackMapData{
+			//nolint:gosec // G203: base64 PNG generated by renderPlaybackMap, not user input
+			ImageURL:  htmltemplate.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(pngData)),
+			Locations: len(locations),
+			Countries: len(countries),
+			Top:       top,
+		}, nil
+	}
+
+	return newContentBlock(models.NewsletterBlockPlaybackMap, "Where Everyone Watched", funcs, resolve, `
+<div class="section" data-block="playback_map" style="margin: 0 0 30px;">
+  <h2 style="font-size: 18px; margin: 0 0 15px;">Where Everyone Watched</h2>
+  <img src="{{.ImageURL}}" width="600" alt="Map of {{.Locations}} playback locations" style="display: block; width: 100%; max-width: 600px; height: auto; border-radius: 4px;">
+  <p style="color: #888; margin: 8px 0 0;">{{.Locations}} locations in {{.Countries}} countries{{if .Top}}. Top: {{range $i, $l := .Top}}{{if $i}}, {{end}}{{if $l.City}}{{$l.City}}, {{end}}{{$l.Country}}{{end}}{{end}}</p>
+</div>`, `
+## Where Everyone Watched
+{{.Locations}} locations in {{.Countries}} countries
+{{range .Top}}
+- {{if .City}}{{.City}}, {{end}}{{.Country}} - {{.PlaybackCount}} plays{{end}}`)
+}
@@ -0,0 +1,126 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package newsletter
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// Playback map image dimensions. 2:1 keeps the equirectangular projection
+// undistorted: one pixel is the same number of degrees in both axes.
+const (
+	mapImageWidth  = 720
+	mapImageHeight = 360
+)
+
+var (
+	mapOceanColor     = color.RGBA{R: 0x1b, G: 0x26, B: 0x3b, A: 0xff}
+	mapGridColor      = color.RGBA{R: 0x2e, G: 0x3d, B: 0x5a, A: 0xff}
+	mapAxisColor      = color.RGBA{R: 0x41, G: 0x55, B: 0x7a, A: 0xff}
+	mapPointColor     = color.RGBA{R: 0xff, G: 0x8c, B: 0x1a, A: 0xc0}
+	mapPointEdgeColor = color.RGBA{R: 0xff, G: 0xd2, B: 0x99, A: 0xff}
+)
+
+// renderPlaybackMap plots playback locations on an equirectangular
+// graticule and returns the PNG bytes. Point size grows with the
+// logarithm of the location's playback count.
+func renderPlaybackMap(locations []models.LocationStats) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, mapImageWidth, mapImageHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: mapOceanColor}, image.Point{}, draw.Src)
+	drawGraticule(img)
+
+	// Largest first so small locations stay visible on top
+	for i := len(locations) - 1; i >= 0; i-- {
+		loc := locations[i]
+		if loc.Latitude < -90 || loc.Latitude > 90 || loc.Longitude < -180 || loc.Longitude > 180 {
+			continue
+		}
+		x, y := projectEquirectangular(loc.Latitude, loc.Longitude)
+		radius := pointRadius(loc.PlaybackCount)
+
+		draw.DrawMask(img, img.Bounds(), &image.Uniform{C: mapPointEdgeColor}, image.Point{},
+			&circleMask{cx: x, cy: y, r: radius + 1}, image.Point{}, draw.Over)
+		draw.DrawMask(img, img.Bounds(), &image.Uniform{C: mapPointColor}, image.Point{},
+			&circleMask{cx: x, cy: y, r: radius}, image.Point{}, draw.Over)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// drawGraticule draws meridians and parallels every 30 degrees, with the
+// equator and prime meridian highlighted.
+func drawGraticule(img *image.RGBA) {
+	for lon := -180; lon <= 180; lon += 30 {
+		x, _ := projectEquirectangular(0, float64(lon))
+		c := mapGridColor
+		if lon == 0 {
+			c = mapAxisColor
+		}
+		for y := 0; y < mapImageHeight; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	for lat := -90; lat <= 90; lat += 30 {
+		_, y := projectEquirectangular(float64(lat), 0)
+		c := mapGridColor
+		if lat == 0 {
+			c = mapAxisColor
+		}
+		for x := 0; x < mapImageWidth; x++ {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+// projectEquirectangular converts latitude and longitude to pixel
+// coordinates, clamped to the image.
+func projectEquirectangular(lat, lon float64) (x, y int) {
+	x = int((lon + 180) / 360 * float64(mapImageWidth-1))
+	y = int((90 - lat) / 180 * float64(mapImageHeight-1))
+	return x, y
+}
+
+// pointRadius scales a marker from 2px for a single playback to 9px.
+func pointRadius(playbacks int) int {
+	if playbacks < 1 {
+		playbacks = 1
+	}
+	r := 2 + int(math.Log2(float64(playbacks)))
+	if r > 9 {
+		r = 9
+	}
+	return r
+}
+
+// circleMask is an alpha mask for a filled circle.
+type circleMask struct {
+	cx, cy, r int
+}
+
+func (c *circleMask) ColorModel() color.Model { return color.AlphaModel }
+
+func (c *circleMask) Bounds() image.Rectangle {
+	return image.Rect(c.cx-c.r, c.cy-c.r, c.cx+c.r+1, c.cy+c.r+1)
+}
+
+func (c *circleMask) At(x, y int) color.Color {
+	dx, dy := x-c.cx, y-c.cy
+	if dx*dx+dy*dy <= c.r*c.r {
+		return color.Alpha{A: 0xff}
+	}
+	return color.Alpha{}
+}
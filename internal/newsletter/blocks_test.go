@@ -0,0 +1,342 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package newsletter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/tomtom215/cartographus/internal/detection"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+type mockAlertStore struct {
+	counts map[detection.Severity]int
+	alerts []detection.Alert
+	err    error
+}
+
+func (m *mockAlertStore) ListAlerts(_ context.Context, filter detection.AlertFilter) ([]detection.Alert, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if filter.Limit > 0 && len(m.alerts) > filter.Limit {
+		return m.alerts[:filter.Limit], nil
+	}
+	return m.alerts, nil
+}
+
+func (m *mockAlertStore) GetAlertCount(_ context.Context, filter detection.AlertFilter) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	total := 0
+	for _, s := range filter.Severities {
+		total += m.counts[s]
+	}
+	return total, nil
+}
+
+type mockLocationStore struct {
+	locations []models.LocationStats
+}
+
+func (m *mockLocationStore) GetNewsletterLocations(_ context.Context, _, _ time.Time, _ int) ([]models.LocationStats, error) {
+	return m.locations, nil
+}
+
+func strPtr(s string) *string { return &s }
+
+func newTestBlockRegistry(content ContentStore, alerts AlertStore, locations LocationStore) *BlockRegistry {
+	logger := zerolog.Nop()
+	registry := NewBlockRegistry(&logger)
+	RegisterBuiltinBlocks(registry, BlockSources{Content: content, Alerts: alerts, Locations: locations})
+	return registry
+}
+
+func testBlockRequest() BlockRequest {
+	end := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	return BlockRequest{Start: end.AddDate(0, 0, -7), End: end}
+}
+
+func TestRegisterBuiltinBlocks_SkipsMissingSources(t *testing.T) {
+	registry := newTestBlockRegistry(&MockContentStore{}, nil, nil)
+
+	for _, b := range []models.NewsletterBlockType{models.NewsletterBlockTopContent, models.NewsletterBlockActiveUsers, models.NewsletterBlockWatchHours} {
+		if !registry.Has(b) {
+			t.Errorf("block %s not registered", b)
+		}
+	}
+	if registry.Has(models.NewsletterBlockDetectionAlerts) || registry.Has(models.NewsletterBlockPlaybackMap) {
+		t.Error("blocks without a data source should not be registered")
+	}
+}
+
+func TestBlockRegistry_RenderOrderAndFailures(t *testing.T) {
+	store := &MockContentStore{
+		TopMoviesErr: errors.New("database locked"),
+		Stats: &models.NewsletterStats{
+			TotalPlaybacks:      42,
+			TotalWatchTimeHours: 12.5,
+			TopUsers:            []models.UserStat{{Username: "alice", WatchCount: 20, WatchTime: 8}},
+		},
+	}
+	registry := newTestBlockRegistry(store, nil, nil)
+	registry.Register(&ContentBlock{
+		Type:  "panics",
+		Title: "Panics",
+		Resolve: func(context.Context, BlockRequest) (interface{}, error) {
+			var m map[string]int
+			m["boom"]++ // nil map write
+			return nil, nil
+		},
+	})
+
+	blocks := registry.Render(context.Background(), []models.NewsletterBlockType{
+		models.NewsletterBlockActiveUsers,
+		"panics",
+		models.NewsletterBlockTopContent, // fails: top movies query errors
+		"unknown",
+		models.NewsletterBlockWatchHours,
+		models.NewsletterBlockActiveUsers, // duplicate
+	}, testBlockRequest())
+
+	if len(blocks) != 2 {
+		t.Fatalf("rendered %d blocks, want 2: %+v", len(blocks), blocks)
+	}
+	if blocks[0].Type != models.NewsletterBlockActiveUsers || blocks[1].Type != models.NewsletterBlockWatchHours {
+		t.Errorf("block order = [%s %s], want [active_users watch_hours]", blocks[0].Type, blocks[1].Type)
+	}
+	if !strings.Contains(blocks[0].HTML, "alice") || !strings.Contains(blocks[0].Text, "1. alice - 20 plays") {
+		t.Errorf("active users block missing user:\nHTML: %s\nText: %s", blocks[0].HTML, blocks[0].Text)
+	}
+}
+
+func TestTopContentBlock(t *testing.T) {
+	store := &MockContentStore{
+		TopMovies: []models.NewsletterMediaItem{{Title: "Alien", Year: 1979, WatchCount: 9, WatchTime: 17.5}},
+		TopShows:  []models.NewsletterShowItem{{Title: "Severance", WatchCount: 14, WatchTime: 11}},
+	}
+	blocks := newTestBlockRegistry(store, nil, nil).Render(context.Background(),
+		[]models.NewsletterBlockType{models.NewsletterBlockTopContent}, testBlockRequest())
+
+	if len(blocks) != 1 {
+		t.Fatalf("rendered %d blocks, want 1", len(blocks))
+	}
+	if !strings.Contains(blocks[0].HTML, "Alien (1979)") || !strings.Contains(blocks[0].HTML, "Severance") {
+		t.Errorf("HTML missing titles: %s", blocks[0].HTML)
+	}
+	if !strings.HasPrefix(blocks[0].Text, "## Most Watched") || !strings.Contains(blocks[0].Text, "1. Severance - 14 plays") {
+		t.Errorf("Markdown = %q", blocks[0].Text)
+	}
+
+	empty := newTestBlockRegistry(&MockContentStore{}, nil, nil).Render(context.Background(),
+		[]models.NewsletterBlockType{models.NewsletterBlockTopContent}, testBlockRequest())
+	if len(empty) != 0 {
+		t.Errorf("empty period rendered %d blocks, want 0", len(empty))
+	}
+}
+
+func TestNewWatchHoursData(t *testing.T) {
+	tests := []struct {
+		name          string
+		current, prev float64
+		wantChange    float64
+		wantHas       bool
+		wantIncreased bool
+	}{
+		{"increase", 15, 10, 50, true, true},
+		{"decrease", 5, 10, 50, true, false},
+		{"flat", 10, 10, 0, true, true},
+		{"no previous", 10, 0, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newWatchHoursData(
+				&models.NewsletterStats{TotalWatchTimeHours: tt.current},
+				&models.NewsletterStats{TotalWatchTimeHours: tt.prev},
+			)
+			if got.HasChange != tt.wantHas || got.Increased != tt.wantIncreased || got.ChangePercent != tt.wantChange {
+				t.Errorf("got %+v, want change %.0f has=%v increased=%v", got, tt.wantChange, tt.wantHas, tt.wantIncreased)
+			}
+		})
+	}
+}
+
+func TestDetectionAlertsBlock(t *testing.T) {
+	alerts := &mockAlertStore{
+		counts: map[detection.Severity]int{detection.SeverityCritical: 1, detection.SeverityWarning: 2},
+		alerts: []detection.Alert{{Title: "Impossible travel", Username: "bob", CreatedAt: time.Now()}},
+	}
+	blocks := newTestBlockRegistry(nil, alerts, nil).Render(context.Background(),
+		[]models.NewsletterBlockType{models.NewsletterBlockDetectionAlerts}, testBlockRequest())
+
+	if len(blocks) != 1 {
+		t.Fatalf("rendered %d blocks, want 1", len(blocks))
+	}
+	if !strings.Contains(blocks[0].Text, "3 new alerts: 1 critical, 2 warning, 0 info") {
+		t.Errorf("Markdown = %q", blocks[0].Text)
+	}
+	if !strings.Contains(blocks[0].HTML, "Impossible travel") {
+		t.Errorf("HTML missing alert title: %s", blocks[0].HTML)
+	}
+
+	quiet := newTestBlockRegistry(nil, &mockAlertStore{}, nil).Render(context.Background(),
+		[]models.NewsletterBlockType{models.NewsletterBlockDetectionAlerts}, testBlockRequest())
+	if len(quiet) != 1 || !strings.Contains(quiet[0].Text, "No new alerts") {
+		t.Errorf("quiet period = %+v, want a no-alerts block", quiet)
+	}
+}
+
+func TestPlaybackMapBlock(t *testing.T) {
+	locations := &mockLocationStore{locations: []models.LocationStats{
+		{Country: "United States", City: strPtr("Chicago"), Latitude: 41.88, Longitude: -87.63, PlaybackCount: 120},
+		{Country: "Germany", City: strPtr("Berlin"), Latitude: 52.52, Longitude: 13.40, PlaybackCount: 30},
+		{Country: "Germany", Latitude: 51.0, Longitude: 10.0, PlaybackCount: 2},
+	}}
+	blocks := newTestBlockRegistry(nil, nil, locations).Render(context.Background(),
+		[]models.NewsletterBlockType{models.NewsletterBlockPlaybackMap}, testBlockRequest())
+
+	if len(blocks) != 1 {
+		t.Fatalf("rendered %d blocks, want 1", len(blocks))
+	}
+	if !strings.Contains(blocks[0].HTML, `src="data:image/png;base64,`) {
+		t.Errorf("HTML missing inline PNG: %.300s", blocks[0].HTML)
+	}
+	if !strings.Contains(blocks[0].Text, "3 locations in 2 countries") || !strings.Contains(blocks[0].Text, "- Chicago, United States - 120 plays") {
+		t.Errorf("Markdown = %q", blocks[0].Text)
+	}
+
+	none := newTestBlockRegistry(nil, nil, &mockLocationStore{}).Render(context.Background(),
+		[]models.NewsletterBlockType{models.NewsletterBlockPlaybackMap}, testBlockRequest())
+	if len(none) != 0 {
+		t.Errorf("no locations rendered %d blocks, want 0", len(none))
+	}
+}
+
+func TestRenderPlaybackMap(t *testing.T) {
+	data, err := renderPlaybackMap([]models.LocationStats{
+		{Country: "United Kingdom", Latitude: 51.5, Longitude: -0.13, PlaybackCount: 50},
+		{Country: "Invalid", Latitude: 120, Longitude: 0, PlaybackCount: 1}, // skipped
+	})
+	if err != nil {
+		t.Fatalf("renderPlaybackMap() error = %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != mapImageWidth || b.Dy() != mapImageHeight {
+		t.Errorf("image size = %dx%d, want %dx%d", b.Dx(), b.Dy(), mapImageWidth, mapImageHeight)
+	}
+
+	x, y := projectEquirectangular(51.5, -0.13)
+	r, g, bl, _ := img.At(x, y).RGBA()
+	or, og, ob, _ := mapOceanColor.RGBA()
+	if r == or && g == og && bl == ob {
+		t.Errorf("pixel at London (%d,%d) is ocean color, want a plotted point", x, y)
+	}
+}
+
+func TestProjectEquirectangular(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		x, y     int
+	}{
+		{90, -180, 0, 0},
+		{-90, 180, mapImageWidth - 1, mapImageHeight - 1},
+		{0, 0, (mapImageWidth - 1) / 2, (mapImageHeight - 1) / 2},
+	}
+	for _, tt := range tests {
+		if x, y := projectEquirectangular(tt.lat, tt.lon); x != tt.x || y != tt.y {
+			t.Errorf("projectEquirectangular(%v, %v) = (%d, %d), want (%d, %d)", tt.lat, tt.lon, x, y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestContentResolver_ResolvesConfiguredBlocks(t *testing.T) {
+	logger := zerolog.Nop()
+	store := &MockContentStore{Stats: &models.NewsletterStats{TotalPlaybacks: 3, TotalWatchTimeHours: 2}}
+	resolver := NewContentResolver(store, &logger, ContentResolverConfig{ServerName: "Test"})
+	resolver.SetBlockRegistry(newTestBlockRegistry(store, nil, nil))
+
+	config := &models.TemplateConfig{
+		TimeFrame: 7,
+		Blocks:    []models.NewsletterBlockType{models.NewsletterBlockWatchHours},
+	}
+	data, err := resolver.ResolveContent(context.Background(), models.NewsletterTypeMonthlyStats, config, nil)
+	if err != nil {
+		t.Fatalf("ResolveContent() error = %v", err)
+	}
+	if len(data.Blocks) != 1 || data.Blocks[0].Type != models.NewsletterBlockWatchHours {
+		t.Fatalf("Blocks = %+v, want watch_hours", data.Blocks)
+	}
+
+	// Without blocks in the config nothing is rendered
+	data, err = resolver.ResolveContent(context.Background(), models.NewsletterTypeMonthlyStats, &models.TemplateConfig{TimeFrame: 7}, nil)
+	if err != nil {
+		t.Fatalf("ResolveContent() error = %v", err)
+	}
+	if len(data.Blocks) != 0 {
+		t.Errorf("Blocks = %+v, want none", data.Blocks)
+	}
+}
+
+func TestTemplateEngine_AppendsUnplacedBlocks(t *testing.T) {
+	engine := NewTemplateEngine()
+	data := &models.NewsletterContentData{
+		ServerName: "Test",
+		Blocks: []models.NewsletterRenderedBlock{
+			{Type: models.NewsletterBlockWatchHours, HTML: `<div data-block="watch_hours">12 hr</div>`, Text: "## Watch Time\n12 hr"},
+		},
+	}
+
+	html, err := engine.RenderHTML(`<html><body><h1>{{.ServerName}}</h1></body></html>`, data)
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	if !strings.Contains(html, `<h1>Test</h1><div data-block="watch_hours">12 hr</div>`) || !strings.HasSuffix(html, "</body></html>") {
+		t.Errorf("blocks not inserted before </body>: %s", html)
+	}
+
+	text, err := engine.RenderText(`{{.ServerName}}`, data)
+	if err != nil {
+		t.Fatalf("RenderText() error = %v", err)
+	}
+	if text != "Test\n\n## Watch Time\n12 hr\n" {
+		t.Errorf("RenderText() = %q", text)
+	}
+
+	// A template that places blocks itself gets them only once
+	placed, err := engine.RenderHTML(`<body>{{range .Blocks}}<section>{{safeHTML .HTML}}</section>{{end}}</body>`, data)
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	if strings.Count(placed, "data-block") != 1 || !strings.Contains(placed, "<section><div") {
+		t.Errorf("placed blocks rendered incorrectly: %s", placed)
+	}
+}
+
+func TestNewContentBlock_TemplatesParse(t *testing.T) {
+	// newContentBlock panics on template syntax errors, so registering every
+	// builtin block is enough to catch them
+	registry := newTestBlockRegistry(&MockContentStore{}, &mockAlertStore{}, &mockLocationStore{})
+
+	for _, b := range models.ValidNewsletterBlocks {
+		if !registry.Has(b) {
+			t.Errorf("block %s not registered", b)
+		}
+	}
+}
@@ -13,6 +13,7 @@
 //   - Retrieves top content rankings
 //   - Supports user-specific personalization
 //   - Generates content recommendations
+//   - Renders the content blocks selected in TemplateConfig.Blocks
 package newsletter
 
 import (
@@ -50,6 +51,7 @@ type ContentStore interface {
 // ContentResolver resolves content data for newsletter templates.
 type ContentResolver struct {
 	store  ContentStore
+	blocks *BlockRegistry
 	logger zerolog.Logger
 
 	// Configuration
@@ -76,6 +78,12 @@ func NewContentResolver(store ContentStore, logger *zerolog.Logger, config Conte
 	}
 }
 
+// SetBlockRegistry enables content blocks. Without a registry,
+// TemplateConfig.Blocks is ignored.
+func (cr *ContentResolver) SetBlockRegistry(registry *BlockRegistry) {
+	cr.blocks = registry
+}
+
 // ResolveContent resolves content for a newsletter based on type and configuration.
 func (cr *ContentResolver) ResolveContent(ctx context.Context, newsletterType models.NewsletterType, config *models.TemplateConfig, userID *string) (*models.NewsletterContentData, error) {
 	now := time.Now()
@@ -132,6 +140,10 @@ func (cr *ContentResolver) ResolveContent(ctx context.Context, newsletterType mo
 		return nil, fmt.Errorf("failed to resolve content for %s: %w", newsletterType, err)
 	}
 
+	if cr.blocks != nil && config != nil && len(config.Blocks) > 0 {
+		data.Blocks = cr.blocks.Render(ctx, config.Blocks, BlockRequest{Start: start, End: end, Config: config})
+	}
+
 	return data, nil
 }
 
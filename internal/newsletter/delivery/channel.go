@@ -6,7 +6,8 @@
 // Package delivery provides newsletter delivery channel implementations.
 //
 // This package implements multiple delivery channels for the Newsletter Generator:
-//   - Email: SMTP-based email delivery with HTML/plaintext support
+//   - Email: SMTP delivery with HTML/plaintext alternatives, attachments,
+//     STARTTLS or implicit TLS, and pooled connections
 //   - Discord: Discord webhook integration with embeds
 //   - Slack: Slack webhook integration with blocks
 //   - Telegram: Telegram Bot API integration
@@ -15,11 +16,17 @@
 //
 // Each channel implements the Channel interface for consistent behavior.
 // All channels support:
-//   - Retry with exponential backoff
+//   - Retry with exponential backoff, stopped early when the next attempt
+//     would fall past the delivery context's deadline
 //   - Timeout handling
 //   - Error categorization (permanent vs transient)
 //   - Metrics and logging
 //
+// The Manager delivers to each channel and recipient independently and
+// records a DeliveryResult for each, so a failing SMTP server does not hold
+// up the webhook or in-app delivery of the same newsletter. Outcomes are
+// counted per channel in newsletter_deliveries_total.
+//
 // Security:
 //   - Credentials are never logged
 //   - TLS is enforced where supported
@@ -78,6 +85,22 @@ type SendParams struct {
 
 	// Metadata contains additional delivery metadata.
 	Metadata *DeliveryMetadata
+
+	// Attachments are files sent with the message (e.g. report exports).
+	// Only the email channel delivers attachments; other channels ignore them.
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a newsletter or report.
+type Attachment struct {
+	// Filename is the name shown to the recipient.
+	Filename string
+
+	// ContentType is the MIME type. Defaults to application/octet-stream.
+	ContentType string
+
+	// Data is the file content.
+	Data []byte
 }
 
 // DeliveryMetadata contains metadata about the delivery for tracking.
@@ -115,6 +138,9 @@ type DeliveryResult struct {
 	// RecipientType is the recipient type (user, email, webhook).
 	RecipientType string
 
+	// Channel is the channel the delivery was attempted on.
+	Channel models.DeliveryChannel
+
 	// DeliveredAt is when delivery succeeded.
 	DeliveredAt *time.Time
 
@@ -263,6 +289,11 @@ func ValidateSMTPConfig(config *models.ChannelConfig) error {
 	if err := ValidateEmail(config.SMTPFrom); err != nil {
 		return fmt.Errorf("invalid SMTP from address: %w", err)
 	}
+	switch config.EffectiveSMTPTLSMode() {
+	case models.SMTPTLSModeStartTLS, models.SMTPTLSModeImplicit, models.SMTPTLSModeNone:
+	default:
+		return fmt.Errorf("invalid SMTP TLS mode: %s (must be starttls, implicit, or none)", config.SMTPTLSMode)
+	}
 	return nil
 }
 
@@ -6,27 +6,40 @@
 package delivery
 
 import (
+	"bytes"
 	"context"
-	"crypto/tls"
-	"fmt"
-	"net"
-	"net/smtp"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/smtpmail"
 )
 
 // EmailChannel implements email delivery via SMTP.
+//
+// Sessions are pooled per server and account, so a delivery to many
+// recipients reuses a few authenticated connections instead of dialing and
+// negotiating TLS once per message.
 type EmailChannel struct {
 	// defaultTimeout is the connection timeout.
 	defaultTimeout time.Duration
+
+	// pool holds idle SMTP sessions for reuse.
+	pool *smtpPool
 }
 
 // NewEmailChannel creates a new email delivery channel.
 func NewEmailChannel() *EmailChannel {
 	return &EmailChannel{
 		defaultTimeout: 30 * time.Second,
+		pool:           newSMTPPool(30 * time.Second),
 	}
 }
 
@@ -50,6 +63,12 @@ func (c *EmailChannel) Validate(config *models.ChannelConfig) error {
 	return ValidateSMTPConfig(config)
 }
 
+// Close closes all idle pooled SMTP sessions.
+func (c *EmailChannel) Close() error {
+	c.pool.closeAll()
+	return nil
+}
+
 // Send delivers the newsletter via email.
 func (c *EmailChannel) Send(ctx context.Context, params *SendParams) (*DeliveryResult, error) {
 	result := &DeliveryResult{
@@ -89,8 +108,12 @@ func (c *EmailChannel) Send(ctx context.Context, params *SendParams) (*DeliveryR
 }
 
 // buildMessage constructs the email message with headers.
+//
+// The body is multipart/alternative when both HTML and text are present and
+// is wrapped in multipart/mixed when there are attachments. Attachments are
+// base64 wrapped at 76 characters, like the quoted-printable text parts.
 func (c *EmailChannel) buildMessage(params *SendParams) string {
-	var msg strings.Builder
+	var msg bytes.Buffer
 
 	// Headers
 	fromName := params.Config.SMTPFromName
@@ -98,138 +121,107 @@ func (c *EmailChannel) buildMessage(params *SendParams) string {
 		fromName = "Newsletter"
 	}
 
-	msg.WriteString(fmt.Sprintf("From: %s <%s>\r\n", fromName, params.Config.SMTPFrom))
-	msg.WriteString(fmt.Sprintf("To: %s\r\n", params.Recipient.Target))
-	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", params.Subject))
-	msg.WriteString("MIME-Version: 1.0\r\n")
+	smtpmail.WriteHeader(&msg, "From", smtpmail.FormatAddress(fromName, params.Config.SMTPFrom))
+	smtpmail.WriteHeader(&msg, "To", smtpmail.SanitizeHeader(params.Recipient.Target))
+	smtpmail.WriteHeader(&msg, "Subject", smtpmail.EncodeText(params.Subject))
+	smtpmail.WriteHeader(&msg, "Date", time.Now().Format(time.RFC1123Z))
+	smtpmail.WriteHeader(&msg, "Message-ID", smtpmail.MessageID(params.Config.SMTPFrom))
+	smtpmail.WriteHeader(&msg, "MIME-Version", "1.0")
 
 	// Add metadata headers if available
 	if params.Metadata != nil {
 		if params.Metadata.DeliveryID != "" {
-			msg.WriteString(fmt.Sprintf("X-Newsletter-ID: %s\r\n", params.Metadata.DeliveryID))
+			smtpmail.WriteHeader(&msg, "X-Newsletter-ID", smtpmail.SanitizeHeader(params.Metadata.DeliveryID))
 		}
 		if params.Metadata.UnsubscribeURL != "" {
-			msg.WriteString(fmt.Sprintf("List-Unsubscribe: <%s>\r\n", params.Metadata.UnsubscribeURL))
-			msg.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+			smtpmail.WriteHeader(&msg, "List-Unsubscribe", "<"+smtpmail.SanitizeHeader(params.Metadata.UnsubscribeURL)+">")
+			smtpmail.WriteHeader(&msg, "List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
 		}
 	}
 
-	// Determine content type
-	hasHTML := params.BodyHTML != ""
-	hasText := params.BodyText != ""
+	bodyHeader, body := smtpmail.BodyEntity(params.BodyHTML, params.BodyText)
 
-	if hasHTML && hasText {
-		// Multipart message with both HTML and text
-		boundary := fmt.Sprintf("boundary_%d", time.Now().UnixNano())
-		msg.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n", boundary))
+	if len(params.Attachments) == 0 {
+		smtpmail.WriteMIMEHeader(&msg, bodyHeader)
 		msg.WriteString("\r\n")
+		msg.Write(body)
+		return msg.String()
+	}
 
-		// Plain text part
-		msg.WriteString(fmt.Sprintf("--%s\r\n", boundary))
-		msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
-		msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
-		msg.WriteString("\r\n")
-		msg.WriteString(params.BodyText)
-		msg.WriteString("\r\n")
+	// Writes to a bytes.Buffer cannot fail, so multipart errors are ignored
+	mixed := multipart.NewWriter(&msg)
+	smtpmail.WriteHeader(&msg, "Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mixed.Boundary()}))
+	msg.WriteString("\r\n")
 
-		// HTML part
-		msg.WriteString(fmt.Sprintf("--%s\r\n", boundary))
-		msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
-		msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
-		msg.WriteString("\r\n")
-		msg.WriteString(params.BodyHTML)
-		msg.WriteString("\r\n")
+	part, _ := mixed.CreatePart(bodyHeader) //nolint:errcheck // bytes.Buffer
+	_, _ = part.Write(body)                 //nolint:errcheck // bytes.Buffer
 
-		// End boundary
-		msg.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
-	} else if hasHTML {
-		// HTML only
-		msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
-		msg.WriteString("\r\n")
-		msg.WriteString(params.BodyHTML)
-	} else {
-		// Plain text only
-		msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
-		msg.WriteString("\r\n")
-		msg.WriteString(params.BodyText)
+	for _, att := range params.Attachments {
+		part, _ := mixed.CreatePart(attachmentHeader(att)) //nolint:errcheck // bytes.Buffer
+		writeBase64Lines(part, att.Data)
 	}
+	_ = mixed.Close() //nolint:errcheck // bytes.Buffer
 
 	return msg.String()
 }
 
-// sendSMTP sends the email via SMTP.
-func (c *EmailChannel) sendSMTP(ctx context.Context, config *models.ChannelConfig, to, msg string) error {
-	addr := fmt.Sprintf("%s:%d", config.SMTPHost, config.SMTPPort)
-
-	// Create connection with timeout
-	dialer := &net.Dialer{Timeout: c.defaultTimeout}
-	conn, err := dialer.DialContext(ctx, "tcp", addr)
-	if err != nil {
-		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+// attachmentHeader returns the part headers for an attachment. The filename
+// is reduced to its base name and encoded per RFC 2231 when needed.
+func attachmentHeader(att Attachment) textproto.MIMEHeader {
+	filename := smtpmail.SanitizeHeader(filepath.Base(att.Filename))
+	if filename == "." || filename == "/" {
+		filename = "attachment"
 	}
-	defer func() { _ = conn.Close() }() //nolint:errcheck // Best effort cleanup
-
-	// Create SMTP client
-	client, err := smtp.NewClient(conn, config.SMTPHost)
-	if err != nil {
-		return fmt.Errorf("failed to create SMTP client: %w", err)
+	contentType := att.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
 	}
-	defer func() { _ = client.Close() }() //nolint:errcheck // Best effort cleanup
 
-	// Start TLS if configured
-	if config.UseTLS {
-		tlsConfig := &tls.Config{
-			ServerName: config.SMTPHost,
-			MinVersion: tls.VersionTLS12,
-		}
-		if err := client.StartTLS(tlsConfig); err != nil {
-			return fmt.Errorf("failed to start TLS: %w", err)
-		}
-	}
-
-	// Authenticate if credentials provided
-	if config.SMTPUser != "" && config.SMTPPassword != "" {
-		auth := smtp.PlainAuth("", config.SMTPUser, config.SMTPPassword, config.SMTPHost)
-		if err := client.Auth(auth); err != nil {
-			return fmt.Errorf("SMTP authentication failed: %w", err)
-		}
-	}
-
-	// Set sender
-	if err := client.Mail(config.SMTPFrom); err != nil {
-		return fmt.Errorf("failed to set sender: %w", err)
-	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", smtpmail.SanitizeHeader(contentType))
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	header.Set("Content-Transfer-Encoding", "base64")
+	return header
+}
 
-	// Set recipient
-	if err := client.Rcpt(to); err != nil {
-		return fmt.Errorf("failed to set recipient: %w", err)
+// writeBase64Lines writes data as base64 wrapped at 76 characters per line.
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		_, _ = io.WriteString(w, encoded[:76]+"\r\n") //nolint:errcheck // bytes.Buffer
+		encoded = encoded[76:]
 	}
+	_, _ = io.WriteString(w, encoded+"\r\n") //nolint:errcheck // bytes.Buffer
+}
 
-	// Send message body
-	writer, err := client.Data()
+// sendSMTP sends one message over a pooled SMTP session.
+func (c *EmailChannel) sendSMTP(ctx context.Context, config *models.ChannelConfig, to, msg string) error {
+	session, err := c.pool.get(ctx, config)
 	if err != nil {
-		return fmt.Errorf("failed to start message: %w", err)
-	}
-
-	if _, err := writer.Write([]byte(msg)); err != nil {
-		return fmt.Errorf("failed to write message: %w", err)
-	}
-
-	if err := writer.Close(); err != nil {
-		return fmt.Errorf("failed to close message: %w", err)
-	}
-
-	// Quit gracefully
-	if err := client.Quit(); err != nil {
-		// Log but don't fail - message was sent
-		return nil
+		return err
 	}
 
-	return nil
+	err = session.send(ctx, config.SMTPFrom, to, []byte(msg))
+	c.pool.put(session, err)
+	return err
 }
 
 // classifyEmailError classifies an error into an error code.
+// SMTP 4xx replies are temporary by definition and are classified as
+// server errors so they are retried.
 func classifyEmailError(err error) string {
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		switch {
+		case smtpErr.Code == 552:
+			return ErrorCodeContentTooLarge
+		case smtpErr.Code == 530 || smtpErr.Code == 534 || smtpErr.Code == 535:
+			return ErrorCodeAuthFailed
+		case smtpErr.Code >= 400 && smtpErr.Code < 500:
+			return ErrorCodeServerError
+		}
+	}
+
 	errStr := err.Error()
 
 	if strings.Contains(errStr, "authentication") || strings.Contains(errStr, "auth") {
@@ -0,0 +1,322 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package delivery
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/smtpmail/smtptest"
+)
+
+// smtpConfig returns a channel config that sends to server without TLS.
+func smtpConfig(server *smtptest.Server) *models.ChannelConfig {
+	return &models.ChannelConfig{
+		SMTPHost:    server.Host(),
+		SMTPPort:    server.Port(),
+		SMTPFrom:    "news@example.com",
+		SMTPTLSMode: models.SMTPTLSModeNone,
+	}
+}
+
+func TestEmailChannel_Send_ReusesConnection(t *testing.T) {
+	server := smtptest.NewServer(t, nil)
+	channel := NewEmailChannel()
+	defer channel.Close()
+
+	recipients := []string{"a@example.com", "b@example.com", "c@example.com"}
+	for _, rcpt := range recipients {
+		result, err := channel.Send(context.Background(), &SendParams{
+			Recipient: models.NewsletterRecipient{Type: "email", Target: rcpt},
+			Subject:   "Weekly digest",
+			BodyText:  "Hello",
+			BodyHTML:  "<p>Hello</p>",
+			Config:    smtpConfig(server),
+		})
+		if err != nil || !result.Success {
+			t.Fatalf("Send(%s) = %+v, %v", rcpt, result, err)
+		}
+	}
+
+	sessions, messages := server.Sessions(), server.Messages()
+	if sessions != 1 {
+		t.Errorf("opened %d SMTP sessions, want 1 reused session", sessions)
+	}
+	if len(messages) != len(recipients) {
+		t.Fatalf("server received %d messages, want %d", len(messages), len(recipients))
+	}
+	for i, m := range messages {
+		if len(m.To) != 1 || m.To[0] != recipients[i] {
+			t.Errorf("message %d RCPT TO = %q, want [%s]", i, m.To, recipients[i])
+		}
+	}
+}
+
+func TestEmailChannel_Send_RejectedRecipientKeepsSession(t *testing.T) {
+	server := smtptest.NewServer(t, map[string]string{
+		"full@example.com":    "452 4.2.2 Mailbox full",
+		"missing@example.com": "550 5.1.1 No such user",
+	})
+	channel := NewEmailChannel()
+	defer channel.Close()
+
+	send := func(rcpt string) *DeliveryResult {
+		result, err := channel.Send(context.Background(), &SendParams{
+			Recipient: models.NewsletterRecipient{Type: "email", Target: rcpt},
+			Subject:   "Test",
+			BodyText:  "Hello",
+			Config:    smtpConfig(server),
+		})
+		if err != nil {
+			t.Fatalf("Send(%s) error = %v", rcpt, err)
+		}
+		return result
+	}
+
+	if r := send("full@example.com"); r.Success || r.ErrorCode != ErrorCodeServerError || !r.IsTransient {
+		t.Errorf("4xx reply: got code %q transient %v, want transient %q", r.ErrorCode, r.IsTransient, ErrorCodeServerError)
+	}
+	if r := send("missing@example.com"); r.Success || r.ErrorCode != ErrorCodeRecipientNotFound || r.IsTransient {
+		t.Errorf("5xx reply: got code %q transient %v, want permanent %q", r.ErrorCode, r.IsTransient, ErrorCodeRecipientNotFound)
+	}
+	if r := send("ok@example.com"); !r.Success {
+		t.Errorf("Send after rejections failed: %s", r.ErrorMessage)
+	}
+
+	if sessions := server.Sessions(); sessions != 1 {
+		t.Errorf("opened %d SMTP sessions, want 1 (rejections should not drop the session)", sessions)
+	}
+}
+
+func TestEmailChannel_Send_ConnectionFailed(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	_ = l.Close()
+
+	channel := NewEmailChannel()
+	result, err := channel.Send(context.Background(), &SendParams{
+		Recipient: models.NewsletterRecipient{Type: "email", Target: "a@example.com"},
+		Subject:   "Test",
+		BodyText:  "Hello",
+		Config: &models.ChannelConfig{
+			SMTPHost: "127.0.0.1",
+			SMTPPort: port,
+			SMTPFrom: "news@example.com",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if result.Success || result.ErrorCode != ErrorCodeConnectionFailed || !result.IsTransient {
+		t.Errorf("result = %+v, want transient %s", result, ErrorCodeConnectionFailed)
+	}
+}
+
+func TestEmailChannel_BuildMessage_Attachments(t *testing.T) {
+	channel := NewEmailChannel()
+	csv := []byte(strings.Repeat("user,plays\nalice,42\n", 10))
+
+	msg := channel.buildMessage(&SendParams{
+		Recipient: models.NewsletterRecipient{Target: "a@example.com"},
+		Subject:   "Monthly report — März",
+		BodyText:  "See attached",
+		BodyHTML:  "<p>See attached</p>",
+		Config:    &models.ChannelConfig{SMTPFrom: "news@example.com", SMTPFromName: "Médiathèque"},
+		Attachments: []Attachment{
+			{Filename: "../report.csv", ContentType: "text/csv", Data: csv},
+		},
+	})
+
+	parsed, err := mail.ReadMessage(strings.NewReader(msg))
+	if err != nil {
+		t.Fatalf("message does not parse: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "Monthly report — März" {
+		t.Errorf("Subject = %q (%v)", subject, err)
+	}
+	from, err := parsed.Header.AddressList("From")
+	if err != nil || from[0].Name != "Médiathèque" {
+		t.Errorf("From = %v (%v)", from, err)
+	}
+	if parsed.Header.Get("Message-ID") == "" || parsed.Header.Get("Date") == "" {
+		t.Error("Message-ID and Date headers are required")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, want multipart/mixed", parsed.Header.Get("Content-Type"))
+	}
+
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	body, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("body part: %v", err)
+	}
+	if ct := body.Header.Get("Content-Type"); !strings.HasPrefix(ct, "multipart/alternative") {
+		t.Errorf("first part Content-Type = %q, want multipart/alternative", ct)
+	}
+
+	att, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("attachment part: %v", err)
+	}
+	if att.FileName() != "report.csv" {
+		t.Errorf("attachment filename = %q, want report.csv", att.FileName())
+	}
+	raw, _ := io.ReadAll(att)
+	for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\r\n") {
+		if len(line) > 76 {
+			t.Fatalf("base64 line is %d chars, want <= 76", len(line))
+		}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(raw), "\r\n", ""))
+	if err != nil || string(decoded) != string(csv) {
+		t.Errorf("attachment content mismatch (%v)", err)
+	}
+
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("expected two parts, got extra part or error %v", err)
+	}
+}
+
+func TestEmailChannel_BuildMessage_HeaderInjection(t *testing.T) {
+	channel := NewEmailChannel()
+	msg := channel.buildMessage(&SendParams{
+		Recipient: models.NewsletterRecipient{Target: "a@example.com"},
+		Subject:   "Hello\r\nBcc: attacker@example.com",
+		BodyText:  "Hello",
+		Config:    &models.ChannelConfig{SMTPFrom: "news@example.com"},
+	})
+
+	parsed, err := mail.ReadMessage(strings.NewReader(msg))
+	if err != nil {
+		t.Fatalf("message does not parse: %v", err)
+	}
+	if parsed.Header.Get("Bcc") != "" {
+		t.Error("subject injected a Bcc header")
+	}
+}
+
+func TestClassifyEmailError_SMTPReplies(t *testing.T) {
+	tests := []struct {
+		code int
+		want string
+	}{
+		{421, ErrorCodeServerError},
+		{451, ErrorCodeServerError},
+		{535, ErrorCodeAuthFailed},
+		{552, ErrorCodeContentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.code), func(t *testing.T) {
+			err := fmt.Errorf("failed to close message: %w", &textproto.Error{Code: tt.code, Msg: "reply"})
+			if got := classifyEmailError(err); got != tt.want {
+				t.Errorf("classifyEmailError(%d) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+
+	if got := classifyEmailError(errors.New("failed to set recipient: 550 no such user")); got != ErrorCodeRecipientNotFound {
+		t.Errorf("unwrapped 550 = %q, want %q", got, ErrorCodeRecipientNotFound)
+	}
+}
+
+func TestValidateSMTPConfig_TLSMode(t *testing.T) {
+	base := models.ChannelConfig{SMTPHost: "smtp.example.com", SMTPPort: 465, SMTPFrom: "news@example.com"}
+
+	for _, mode := range []string{"", "starttls", "implicit", "none", "IMPLICIT"} {
+		cfg := base
+		cfg.SMTPTLSMode = mode
+		if err := ValidateSMTPConfig(&cfg); err != nil {
+			t.Errorf("mode %q: unexpected error %v", mode, err)
+		}
+	}
+
+	cfg := base
+	cfg.SMTPTLSMode = "ssl"
+	if err := ValidateSMTPConfig(&cfg); err == nil {
+		t.Error("expected error for unknown TLS mode")
+	}
+}
+
+func TestManager_Deliver_EmailDefaultConfig(t *testing.T) {
+	server := smtptest.NewServer(t, nil)
+	logger := zerolog.Nop()
+	manager := NewManager(&logger, ManagerConfig{MaxRetries: 1, BaseDelay: time.Millisecond, Parallelism: 2})
+	defer manager.Close()
+	manager.SetDefaultChannelConfig(models.DeliveryChannelEmail, smtpConfig(server))
+
+	report, err := manager.Deliver(context.Background(), &DeliveryRequest{
+		DeliveryID: "d1",
+		Recipients: []models.NewsletterRecipient{
+			{Type: "email", Target: "a@example.com"},
+			{Type: "email", Target: "b@example.com"},
+		},
+		Channels:        []models.DeliveryChannel{models.DeliveryChannelEmail},
+		RenderedSubject: "Digest",
+		RenderedText:    "Hello",
+		Attachments:     []Attachment{{Filename: "report.csv", Data: []byte("a,b\n")}},
+	})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if report.SuccessfulDeliveries != 2 {
+		t.Fatalf("report = %+v, want 2 successful deliveries", report)
+	}
+	for _, r := range report.Results {
+		if r.Channel != models.DeliveryChannelEmail {
+			t.Errorf("result channel = %q, want email", r.Channel)
+		}
+	}
+
+	for _, m := range server.Messages() {
+		if !strings.Contains(m.Data, "report.csv") {
+			t.Error("attachment missing from delivered message")
+		}
+	}
+}
+
+func TestManager_Deliver_RecordsRetryCount(t *testing.T) {
+	server := smtptest.NewServer(t, map[string]string{"busy@example.com": "451 4.3.0 Try again later"})
+	logger := zerolog.Nop()
+	manager := NewManager(&logger, ManagerConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	defer manager.Close()
+
+	report, err := manager.Deliver(context.Background(), &DeliveryRequest{
+		DeliveryID:      "d1",
+		Recipients:      []models.NewsletterRecipient{{Type: "email", Target: "busy@example.com"}},
+		Channels:        []models.DeliveryChannel{models.DeliveryChannelEmail},
+		ChannelConfigs:  map[models.DeliveryChannel]*models.ChannelConfig{models.DeliveryChannelEmail: smtpConfig(server)},
+		RenderedSubject: "Digest",
+		RenderedText:    "Hello",
+	})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	r := report.Results[0]
+	if r.Success || r.RetryCount != 2 || r.ErrorCode != ErrorCodeServerError {
+		t.Errorf("result = %+v, want failure after 2 retries with %s", r, ErrorCodeServerError)
+	}
+}
@@ -7,11 +7,14 @@ package delivery
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
@@ -24,6 +27,10 @@ type Manager struct {
 	baseDelay   time.Duration
 	maxDelay    time.Duration
 	parallelism int
+
+	// defaultConfigs are used for channels a request does not configure.
+	mu             sync.RWMutex
+	defaultConfigs map[models.DeliveryChannel]*models.ChannelConfig
 }
 
 // ManagerConfig contains configuration for the delivery manager.
@@ -73,9 +80,42 @@ func NewManager(logger *zerolog.Logger, config ManagerConfig) *Manager {
 		baseDelay:   config.BaseDelay,
 		maxDelay:    config.MaxDelay,
 		parallelism: config.Parallelism,
+
+		defaultConfigs: make(map[models.DeliveryChannel]*models.ChannelConfig),
 	}
 }
 
+// SetDefaultChannelConfig sets the configuration used for a channel when a
+// delivery request does not provide one, such as the server-wide SMTP
+// settings for schedules that only list email recipients.
+func (m *Manager) SetDefaultChannelConfig(channel models.DeliveryChannel, config *models.ChannelConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultConfigs[channel] = config
+}
+
+// DefaultChannelConfig returns the default configuration for a channel, or
+// nil if none is set.
+func (m *Manager) DefaultChannelConfig(channel models.DeliveryChannel) *models.ChannelConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultConfigs[channel]
+}
+
+// Close releases resources held by channels, such as pooled SMTP sessions.
+func (m *Manager) Close() error {
+	var errs []error
+	for _, name := range m.registry.List() {
+		ch, _ := m.registry.Get(name)
+		if closer, ok := ch.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("close %s channel: %w", name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // SetInAppStore sets the store for in-app notifications.
 func (m *Manager) SetInAppStore(store InAppNotificationStore) {
 	if ch, ok := m.registry.Get(models.DeliveryChannelInApp); ok {
@@ -116,6 +156,9 @@ type DeliveryRequest struct {
 
 	// Metadata contains additional delivery metadata.
 	Metadata *DeliveryMetadata
+
+	// Attachments are sent with the message on channels that support them.
+	Attachments []Attachment
 }
 
 // DeliveryReport contains the aggregated results of a delivery operation.
@@ -246,7 +289,21 @@ func (m *Manager) Deliver(ctx context.Context, req *DeliveryRequest) (*DeliveryR
 }
 
 // deliverToRecipient handles delivery to a single recipient on a single channel.
+// The result records the channel and the number of retries made.
 func (m *Manager) deliverToRecipient(ctx context.Context, req *DeliveryRequest, recipient models.NewsletterRecipient, channelName models.DeliveryChannel) DeliveryResult {
+	result := m.attemptDelivery(ctx, req, recipient, channelName)
+	result.Channel = channelName
+	metrics.RecordNewsletterDelivery(string(channelName), result.Success, result.RetryCount)
+	return result
+}
+
+// attemptDelivery sends to one recipient, retrying transient failures with
+// exponential backoff. Retries stop early when the next one would not start
+// before ctx's deadline (the scheduler's execution timeout), so the last
+// error is reported rather than a cancellation. Each recipient and channel
+// is retried independently: a failing SMTP server does not hold up webhook
+// deliveries beyond the worker it occupies.
+func (m *Manager) attemptDelivery(ctx context.Context, req *DeliveryRequest, recipient models.NewsletterRecipient, channelName models.DeliveryChannel) DeliveryResult {
 	// Get channel
 	channel, ok := m.registry.Get(channelName)
 	if !ok {
@@ -258,20 +315,24 @@ func (m *Manager) deliverToRecipient(ctx context.Context, req *DeliveryRequest,
 		}
 	}
 
-	// Get channel config
+	// Get channel config, falling back to the server default
 	config := req.ChannelConfigs[channelName]
+	if config == nil {
+		config = m.DefaultChannelConfig(channelName)
+	}
 
 	// Prepare content for this channel
 	bodyHTML, bodyText := FormatForChannel(channel, req.RenderedSubject, req.RenderedHTML, req.RenderedText)
 
 	// Build send params
 	params := &SendParams{
-		Recipient: recipient,
-		Subject:   req.RenderedSubject,
-		BodyHTML:  bodyHTML,
-		BodyText:  bodyText,
-		Config:    config,
-		Metadata:  req.Metadata,
+		Recipient:   recipient,
+		Subject:     req.RenderedSubject,
+		BodyHTML:    bodyHTML,
+		BodyText:    bodyText,
+		Config:      config,
+		Metadata:    req.Metadata,
+		Attachments: req.Attachments,
 	}
 
 	// Attempt delivery with retries
@@ -288,14 +349,19 @@ func (m *Manager) deliverToRecipient(ctx context.Context, req *DeliveryRequest,
 				Dur("delay", delay).
 				Msg("retrying delivery after delay")
 
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				m.logger.Warn().
+					Str("delivery_id", req.DeliveryID).
+					Str("recipient", recipient.Target).
+					Str("channel", string(channelName)).
+					Int("attempt", attempt).
+					Msg("no time left to retry delivery before the execution timeout")
+				return stoppedResult(lastResult, recipient, attempt-1)
+			}
+
 			select {
 			case <-ctx.Done():
-				return DeliveryResult{
-					Recipient:     recipient.Target,
-					RecipientType: recipient.Type,
-					ErrorMessage:  "delivery canceled",
-					ErrorCode:     ErrorCodeTimeout,
-				}
+				return stoppedResult(lastResult, recipient, attempt-1)
 			case <-time.After(delay):
 			}
 		}
@@ -309,10 +375,19 @@ func (m *Manager) deliverToRecipient(ctx context.Context, req *DeliveryRequest,
 				Str("channel", string(channelName)).
 				Int("attempt", attempt).
 				Msg("channel send error")
+			lastResult = &DeliveryResult{
+				Recipient:     recipient.Target,
+				RecipientType: recipient.Type,
+				ErrorMessage:  err.Error(),
+				ErrorCode:     ErrorCodeUnknown,
+				IsTransient:   true,
+				RetryCount:    attempt,
+			}
 			continue
 		}
 
 		lastResult = result
+		result.RetryCount = attempt
 
 		if result.Success {
 			m.logger.Debug().
@@ -359,6 +434,24 @@ func (m *Manager) deliverToRecipient(ctx context.Context, req *DeliveryRequest,
 	}
 }
 
+// stoppedResult is the result of a delivery whose retries were cut short
+// by ctx after the given number of retries: the last failure if there was
+// one, otherwise a timeout.
+func stoppedResult(last *DeliveryResult, recipient models.NewsletterRecipient, retries int) DeliveryResult {
+	if last == nil {
+		return DeliveryResult{
+			Recipient:     recipient.Target,
+			RecipientType: recipient.Type,
+			ErrorMessage:  "delivery canceled",
+			ErrorCode:     ErrorCodeTimeout,
+			RetryCount:    retries,
+		}
+	}
+	result := *last
+	result.RetryCount = retries
+	return result
+}
+
 // calculateBackoff calculates the delay before the next retry attempt.
 func (m *Manager) calculateBackoff(attempt int, lastResult *DeliveryResult) time.Duration {
 	// If server specified retry-after, use it
@@ -7,12 +7,18 @@ package delivery
 
 import (
 	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/rs/zerolog"
+	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
@@ -848,3 +854,152 @@ func TestManager_Deliver_MultipleChannels(t *testing.T) {
 		t.Errorf("FailedDeliveries = %d, want 1", report.FailedDeliveries)
 	}
 }
+
+func TestManager_Deliver_FailedEmailDoesNotBlockWebhook(t *testing.T) {
+	var webhookCalls atomic.Int32
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		webhookCalls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhook.Close()
+
+	// An SMTP port nothing listens on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	smtpPort := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	logger := zerolog.Nop()
+	manager := NewManager(&logger, ManagerConfig{
+		MaxRetries:  2,
+		BaseDelay:   5 * time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+		Parallelism: 2,
+	})
+	defer manager.Close()
+
+	delivered := testutil.ToFloat64(metrics.NewsletterDeliveries.WithLabelValues("webhook", "delivered"))
+	failed := testutil.ToFloat64(metrics.NewsletterDeliveries.WithLabelValues("email", "failed"))
+	retries := testutil.ToFloat64(metrics.NewsletterDeliveryRetries.WithLabelValues("email"))
+
+	report, err := manager.Deliver(context.Background(), &DeliveryRequest{
+		DeliveryID: "test-email-webhook",
+		Recipients: []models.NewsletterRecipient{{Type: "email", Target: "viewer@example.com"}},
+		Channels:   []models.DeliveryChannel{models.DeliveryChannelEmail, models.DeliveryChannelWebhook},
+		ChannelConfigs: map[models.DeliveryChannel]*models.ChannelConfig{
+			models.DeliveryChannelEmail: {
+				SMTPHost:    "127.0.0.1",
+				SMTPPort:    smtpPort,
+				SMTPFrom:    "newsletter@example.com",
+				SMTPTLSMode: models.SMTPTLSModeNone,
+			},
+			models.DeliveryChannelWebhook: {WebhookURL: webhook.URL},
+		},
+		RenderedSubject: "Weekly Digest",
+		RenderedText:    "Hello",
+	})
+	if err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+
+	if report.Status != models.DeliveryStatusPartial {
+		t.Errorf("Status = %v, want partial", report.Status)
+	}
+	for _, result := range report.Results {
+		switch result.Channel {
+		case models.DeliveryChannelWebhook:
+			if !result.Success || result.RetryCount != 0 {
+				t.Errorf("webhook result = %+v, want delivered on the first attempt", result)
+			}
+		case models.DeliveryChannelEmail:
+			if result.Success || result.ErrorCode != ErrorCodeConnectionFailed || result.RetryCount != 2 {
+				t.Errorf("email result = %+v, want a connection failure after 2 retries", result)
+			}
+		}
+	}
+	if webhookCalls.Load() != 1 {
+		t.Errorf("webhook calls = %d, want 1", webhookCalls.Load())
+	}
+
+	if got := testutil.ToFloat64(metrics.NewsletterDeliveries.WithLabelValues("webhook", "delivered")) - delivered; got != 1 {
+		t.Errorf("webhook delivered metric increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.NewsletterDeliveries.WithLabelValues("email", "failed")) - failed; got != 1 {
+		t.Errorf("email failed metric increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.NewsletterDeliveryRetries.WithLabelValues("email")) - retries; got != 2 {
+		t.Errorf("email retries metric increased by %v, want 2", got)
+	}
+}
+
+func TestManager_Deliver_RetriesStopBeforeDeadline(t *testing.T) {
+	logger := zerolog.Nop()
+	manager := NewManager(&logger, ManagerConfig{
+		MaxRetries:  10,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    time.Second,
+		Parallelism: 1,
+	})
+	flaky := &MockChannel{
+		name: "flaky",
+		sendResult: &DeliveryResult{
+			Recipient:    "user-1",
+			ErrorMessage: "421 service not available, try later",
+			ErrorCode:    ErrorCodeServerError,
+			IsTransient:  true,
+		},
+	}
+	manager.registry.Register(flaky)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	report, err := manager.Deliver(ctx, &DeliveryRequest{
+		DeliveryID: "test-deadline",
+		Recipients: []models.NewsletterRecipient{{Type: "user", Target: "user-1"}},
+		Channels:   []models.DeliveryChannel{"flaky"},
+	})
+	if err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Error("Deliver waited out the deadline instead of stopping retries early")
+	}
+
+	result := report.Results[0]
+	// Attempts at 0ms and 100ms; the next, at 300ms, would miss the deadline
+	if result.RetryCount != 1 || int(atomic.LoadInt32(&flaky.sendCallCount)) != 2 {
+		t.Errorf("retries = %d, sends = %d; want 1 and 2", result.RetryCount, flaky.sendCallCount)
+	}
+	if result.ErrorMessage != "421 service not available, try later" {
+		t.Errorf("ErrorMessage = %q, want the last send error", result.ErrorMessage)
+	}
+}
+
+func TestManager_Deliver_SendErrorReported(t *testing.T) {
+	logger := zerolog.Nop()
+	manager := NewManager(&logger, ManagerConfig{
+		MaxRetries:  1,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		Parallelism: 1,
+	})
+	broken := &MockChannel{name: "broken", sendError: errors.New("connection reset by peer")}
+	manager.registry.Register(broken)
+
+	report, err := manager.Deliver(context.Background(), &DeliveryRequest{
+		DeliveryID: "test-send-error",
+		Recipients: []models.NewsletterRecipient{{Type: "user", Target: "user-1"}},
+		Channels:   []models.DeliveryChannel{"broken"},
+	})
+	if err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+
+	result := report.Results[0]
+	if result.Success || result.ErrorMessage != "connection reset by peer" || result.RetryCount != 1 {
+		t.Errorf("result = %+v, want the send error after 1 retry", result)
+	}
+}
@@ -0,0 +1,206 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/smtpmail"
+)
+
+const (
+	// smtpMaxIdlePerServer caps idle sessions kept per server and account.
+	smtpMaxIdlePerServer = 4
+
+	// smtpIdleTimeout closes sessions unused for this long. Servers commonly
+	// drop idle clients after a minute or more.
+	smtpIdleTimeout = 30 * time.Second
+
+	// smtpMaxMessagesPerSession retires a session after this many messages.
+	// Many providers limit messages per connection.
+	smtpMaxMessagesPerSession = 100
+)
+
+// smtpSession is one pooled SMTP connection.
+type smtpSession struct {
+	key      string
+	client   *smtpmail.Client
+	messages int
+	lastUsed time.Time
+}
+
+// send delivers one message to one recipient in a single SMTP transaction.
+func (s *smtpSession) send(ctx context.Context, from, to string, msg []byte) error {
+	if err := s.client.Send(ctx, from, []string{to}, msg); err != nil {
+		return err
+	}
+	s.messages++
+	return nil
+}
+
+// smtpPool keeps idle SMTP sessions keyed by server, account, and TLS mode.
+type smtpPool struct {
+	timeout time.Duration
+
+	mu     sync.Mutex
+	idle   map[string][]*smtpSession
+	reaper *time.Timer
+}
+
+func newSMTPPool(timeout time.Duration) *smtpPool {
+	return &smtpPool{
+		timeout: timeout,
+		idle:    make(map[string][]*smtpSession),
+	}
+}
+
+// poolKey identifies sessions that can be shared. The password is part of
+// the key so a credential change never reuses a session authenticated with
+// the old one.
+func poolKey(config *models.ChannelConfig) string {
+	return strings.Join([]string{
+		config.SMTPHost,
+		strconv.Itoa(config.SMTPPort),
+		config.EffectiveSMTPTLSMode(),
+		config.SMTPUser,
+		config.SMTPPassword,
+	}, "\x00")
+}
+
+// get returns an idle session for config, or dials a new one.
+func (p *smtpPool) get(ctx context.Context, config *models.ChannelConfig) (*smtpSession, error) {
+	key := poolKey(config)
+
+	for {
+		p.mu.Lock()
+		sessions := p.idle[key]
+		if len(sessions) == 0 {
+			p.mu.Unlock()
+			break
+		}
+		session := sessions[len(sessions)-1]
+		p.idle[key] = sessions[:len(sessions)-1]
+		p.mu.Unlock()
+
+		if time.Since(session.lastUsed) > smtpIdleTimeout {
+			session.client.Quit()
+			continue
+		}
+
+		// The server may have dropped the connection while it was idle
+		if err := session.client.Noop(ctx); err != nil {
+			_ = session.client.Close() //nolint:errcheck // Best effort cleanup
+			continue
+		}
+		return session, nil
+	}
+
+	return p.dial(ctx, config, key)
+}
+
+// put returns a session to the pool after a send. Sessions are kept only
+// when the error, if any, was an SMTP reply that left the connection usable.
+func (p *smtpPool) put(session *smtpSession, sendErr error) {
+	if sendErr != nil {
+		var smtpErr *textproto.Error
+		if !errors.As(sendErr, &smtpErr) || session.client.Reset() != nil {
+			_ = session.client.Close() //nolint:errcheck // Best effort cleanup
+			return
+		}
+	}
+
+	if session.messages >= smtpMaxMessagesPerSession {
+		session.client.Quit()
+		return
+	}
+
+	session.lastUsed = time.Now()
+
+	p.mu.Lock()
+	if len(p.idle[session.key]) >= smtpMaxIdlePerServer {
+		p.mu.Unlock()
+		session.client.Quit()
+		return
+	}
+	p.idle[session.key] = append(p.idle[session.key], session)
+	if p.reaper == nil {
+		p.reaper = time.AfterFunc(smtpIdleTimeout, p.reap)
+	}
+	p.mu.Unlock()
+}
+
+// reap closes expired idle sessions and reschedules itself while any remain.
+func (p *smtpPool) reap() {
+	var expired []*smtpSession
+
+	p.mu.Lock()
+	for key, sessions := range p.idle {
+		kept := sessions[:0]
+		for _, s := range sessions {
+			if time.Since(s.lastUsed) > smtpIdleTimeout {
+				expired = append(expired, s)
+			} else {
+				kept = append(kept, s)
+			}
+		}
+		if len(kept) == 0 {
+			delete(p.idle, key)
+		} else {
+			p.idle[key] = kept
+		}
+	}
+	if len(p.idle) > 0 {
+		p.reaper = time.AfterFunc(smtpIdleTimeout, p.reap)
+	} else {
+		p.reaper = nil
+	}
+	p.mu.Unlock()
+
+	for _, s := range expired {
+		s.client.Quit()
+	}
+}
+
+// closeAll closes every idle session.
+func (p *smtpPool) closeAll() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = make(map[string][]*smtpSession)
+	if p.reaper != nil {
+		p.reaper.Stop()
+		p.reaper = nil
+	}
+	p.mu.Unlock()
+
+	for _, sessions := range idle {
+		for _, s := range sessions {
+			s.client.Quit()
+		}
+	}
+}
+
+// dial opens and authenticates a new session.
+func (p *smtpPool) dial(ctx context.Context, config *models.ChannelConfig, key string) (*smtpSession, error) {
+	client, err := smtpmail.Dial(ctx, smtpmail.Config{
+		Host:     config.SMTPHost,
+		Port:     config.SMTPPort,
+		Username: config.SMTPUser,
+		Password: config.SMTPPassword,
+		TLSMode:  config.EffectiveSMTPTLSMode(),
+		Timeout:  p.timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &smtpSession{key: key, client: client}, nil
+}
@@ -309,3 +309,23 @@ func CalculateNextRun(cronExpr string, after time.Time, timezone string) (time.T
 
 	return cron.NextRun(after, loc), nil
 }
+
+// UpcomingRuns returns the run times of a cron expression after the given
+// time and no later than until, in order, at most limit of them. It uses the
+// same next-run computation as the scheduler.
+func UpcomingRuns(cronExpr string, after, until time.Time, timezone string, limit int) ([]time.Time, error) {
+	var runs []time.Time
+	next := after
+	for len(runs) < limit {
+		var err error
+		next, err = CalculateNextRun(cronExpr, next, timezone)
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() || next.After(until) {
+			break
+		}
+		runs = append(runs, next)
+	}
+	return runs, nil
+}
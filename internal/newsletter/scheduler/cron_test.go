@@ -380,3 +380,50 @@ func equalIntSlices(a, b []int) bool {
 	}
 	return true
 }
+
+func TestUpcomingRuns(t *testing.T) {
+	after := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("stops at until", func(t *testing.T) {
+		runs, err := UpcomingRuns("0 9 * * *", after, after.Add(72*time.Hour), "", 10)
+		if err != nil {
+			t.Fatalf("UpcomingRuns() error = %v", err)
+		}
+		if len(runs) != 3 {
+			t.Fatalf("got %d runs, want 3: %v", len(runs), runs)
+		}
+		for i, run := range runs {
+			want := time.Date(2024, 1, 1+i, 9, 0, 0, 0, time.UTC)
+			if !run.Equal(want) {
+				t.Errorf("runs[%d] = %v, want %v", i, run, want)
+			}
+		}
+	})
+
+	t.Run("stops at limit", func(t *testing.T) {
+		runs, err := UpcomingRuns("*/15 * * * *", after, after.Add(24*time.Hour), "", 4)
+		if err != nil {
+			t.Fatalf("UpcomingRuns() error = %v", err)
+		}
+		if len(runs) != 4 {
+			t.Errorf("got %d runs, want 4", len(runs))
+		}
+	})
+
+	t.Run("timezone", func(t *testing.T) {
+		runs, err := UpcomingRuns("0 9 * * *", after, after.Add(24*time.Hour), "America/New_York", 10)
+		if err != nil {
+			t.Fatalf("UpcomingRuns() error = %v", err)
+		}
+		// 9:00 in New York on Jan 1 is 14:00 UTC
+		if len(runs) != 1 || !runs[0].Equal(time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)) {
+			t.Errorf("runs = %v, want [2024-01-01 14:00 UTC]", runs)
+		}
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		if _, err := UpcomingRuns("invalid", after, after.Add(time.Hour), "", 10); err == nil {
+			t.Error("expected error for invalid expression")
+		}
+	})
+}
@@ -34,6 +34,10 @@ import (
 	"github.com/tomtom215/cartographus/internal/newsletter/delivery"
 )
 
+// deliveryRecordTimeout bounds recording a delivery's outcome, which runs
+// after the execution timeout may have expired.
+const deliveryRecordTimeout = 30 * time.Second
+
 // SchedulerStore defines the database operations required by the scheduler.
 type SchedulerStore interface {
 	// Schedule operations
@@ -48,6 +52,7 @@ type SchedulerStore interface {
 	CreateNewsletterDelivery(ctx context.Context, delivery *models.NewsletterDelivery) error
 	UpdateNewsletterDelivery(ctx context.Context, delivery *models.NewsletterDelivery) error
 	GetNewsletterDelivery(ctx context.Context, id string) (*models.NewsletterDelivery, error)
+	CreateNewsletterDeliveryLogEntries(ctx context.Context, entries []models.NewsletterDeliveryLogEntry) error
 }
 
 // Config holds configuration for the newsletter scheduler.
@@ -164,6 +169,12 @@ func (s *Scheduler) Stop() error {
 	close(s.stopCh)
 	<-s.doneCh
 
+	if s.deliveryManager != nil {
+		if err := s.deliveryManager.Close(); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to close delivery channels")
+		}
+	}
+
 	s.mu.Lock()
 	s.running = false
 	s.mu.Unlock()
@@ -284,6 +295,15 @@ func (s *Scheduler) executeSchedule(ctx context.Context, schedule *models.Newsle
 		contentConfig = tmpl.DefaultConfig
 	}
 
+	// Schedules without recipients of their own use the template's
+	recipients := models.ResolveNewsletterRecipients(schedule.Recipients, contentConfig)
+	if len(recipients) == 0 {
+		logger.Error().Msg("Newsletter has no recipients")
+		s.failDelivery(ctx, deliveryID, "No recipients: neither the schedule nor its template lists any")
+		s.updateScheduleStatus(ctx, schedule, models.DeliveryStatusFailed)
+		return
+	}
+
 	content, err := s.contentResolver.ResolveContent(ctx, tmpl.Type, contentConfig, nil)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to resolve content")
@@ -318,14 +338,14 @@ func (s *Scheduler) executeSchedule(ctx context.Context, schedule *models.Newsle
 	}
 
 	// Update delivery with rendered content and status
-	s.updateDeliveryStatus(ctx, deliveryID, models.DeliveryStatusSending, renderedSubject)
+	s.updateDeliveryStatus(ctx, deliveryID, models.DeliveryStatusSending, renderedSubject, len(recipients)*len(schedule.Channels))
 
 	// Prepare delivery request
 	req := &delivery.DeliveryRequest{
 		DeliveryID:      deliveryID,
 		ScheduleID:      schedule.ID,
 		Template:        tmpl,
-		Recipients:      schedule.Recipients,
+		Recipients:      recipients,
 		Channels:        schedule.Channels,
 		ChannelConfigs:  schedule.ChannelConfigs,
 		RenderedSubject: renderedSubject,
@@ -335,6 +355,11 @@ func (s *Scheduler) executeSchedule(ctx context.Context, schedule *models.Newsle
 
 	// Deliver newsletter
 	report, err := s.deliveryManager.Deliver(ctx, req)
+
+	// Retries may use up the execution timeout; the outcome is still recorded
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deliveryRecordTimeout)
+	defer cancel()
+
 	if err != nil {
 		logger.Error().Err(err).Msg("Delivery failed")
 		s.failDelivery(ctx, deliveryID, "Delivery failed: "+err.Error())
@@ -411,7 +436,7 @@ func (s *Scheduler) failDelivery(ctx context.Context, deliveryID, errorMsg strin
 }
 
 // updateDeliveryStatus updates delivery status to sending.
-func (s *Scheduler) updateDeliveryStatus(ctx context.Context, deliveryID string, status models.DeliveryStatus, renderedSubject string) {
+func (s *Scheduler) updateDeliveryStatus(ctx context.Context, deliveryID string, status models.DeliveryStatus, renderedSubject string, recipientsTotal int) {
 	dlv, err := s.store.GetNewsletterDelivery(ctx, deliveryID)
 	if err != nil {
 		s.logger.Error().Err(err).Str("delivery_id", deliveryID).Msg("Failed to get delivery for update")
@@ -420,6 +445,7 @@ func (s *Scheduler) updateDeliveryStatus(ctx context.Context, deliveryID string,
 
 	dlv.Status = status
 	dlv.RenderedSubject = renderedSubject
+	dlv.RecipientsTotal = recipientsTotal
 
 	if err := s.store.UpdateNewsletterDelivery(ctx, dlv); err != nil {
 		s.logger.Error().Err(err).Str("delivery_id", deliveryID).Msg("Failed to update delivery status")
@@ -438,6 +464,7 @@ func (s *Scheduler) completeDelivery(ctx context.Context, deliveryID string, rep
 	dlv.CompletedAt = completedAt
 	dlv.RecipientsDelivered = report.SuccessfulDeliveries
 	dlv.RecipientsFailed = report.FailedDeliveries
+	dlv.RecipientDetails = recipientDetails(report.Results)
 	if completedAt != nil {
 		dlv.DurationMS = completedAt.Sub(dlv.StartedAt).Milliseconds()
 	}
@@ -445,6 +472,53 @@ func (s *Scheduler) completeDelivery(ctx context.Context, deliveryID string, rep
 	if err := s.store.UpdateNewsletterDelivery(ctx, dlv); err != nil {
 		s.logger.Error().Err(err).Str("delivery_id", deliveryID).Msg("Failed to update delivery completion")
 	}
+
+	if err := s.store.CreateNewsletterDeliveryLogEntries(ctx, deliveryLogEntries(dlv)); err != nil {
+		s.logger.Error().Err(err).Str("delivery_id", deliveryID).Msg("Failed to record per-recipient delivery log")
+	}
+}
+
+// recipientDetails converts delivery results to per-recipient records.
+func recipientDetails(results []delivery.DeliveryResult) []models.RecipientDeliveryResult {
+	details := make([]models.RecipientDeliveryResult, 0, len(results))
+	for i := range results {
+		r := &results[i]
+		status := models.DeliveryStatusDelivered
+		if !r.Success {
+			status = models.DeliveryStatusFailed
+		}
+		details = append(details, models.RecipientDeliveryResult{
+			Recipient:     r.Recipient,
+			RecipientType: r.RecipientType,
+			Channel:       r.Channel,
+			Status:        status,
+			DeliveredAt:   r.DeliveredAt,
+			ErrorMessage:  r.ErrorMessage,
+			ErrorCode:     r.ErrorCode,
+			RetryCount:    r.RetryCount,
+		})
+	}
+	return details
+}
+
+// deliveryLogEntries builds the delivery log rows for a completed delivery.
+func deliveryLogEntries(dlv *models.NewsletterDelivery) []models.NewsletterDeliveryLogEntry {
+	entries := make([]models.NewsletterDeliveryLogEntry, 0, len(dlv.RecipientDetails))
+	for _, d := range dlv.RecipientDetails {
+		entries = append(entries, models.NewsletterDeliveryLogEntry{
+			DeliveryID:    dlv.ID,
+			ScheduleID:    dlv.ScheduleID,
+			Channel:       d.Channel,
+			Recipient:     d.Recipient,
+			RecipientType: d.RecipientType,
+			Status:        d.Status,
+			Attempts:      d.RetryCount + 1,
+			ErrorCode:     d.ErrorCode,
+			ErrorMessage:  d.ErrorMessage,
+			DeliveredAt:   d.DeliveredAt,
+		})
+	}
+	return entries
 }
 
 // IsRunning returns whether the scheduler is currently running.
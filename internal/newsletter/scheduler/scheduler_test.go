@@ -24,6 +24,7 @@ type mockStore struct {
 	schedules           []models.NewsletterSchedule
 	templates           map[string]*models.NewsletterTemplate
 	deliveries          map[string]*models.NewsletterDelivery
+	logEntries          []models.NewsletterDeliveryLogEntry
 	runStatusUpdates    []runStatusUpdate
 	getDueForRunCalls   int
 	createDeliveryCalls int
@@ -106,6 +107,13 @@ func (m *mockStore) GetNewsletterDelivery(ctx context.Context, id string) (*mode
 	return nil, nil
 }
 
+func (m *mockStore) CreateNewsletterDeliveryLogEntries(ctx context.Context, entries []models.NewsletterDeliveryLogEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logEntries = append(m.logEntries, entries...)
+	return nil
+}
+
 func TestNewScheduler(t *testing.T) {
 	logger := zerolog.Nop()
 	store := newMockStore()
@@ -332,6 +340,51 @@ func TestScheduler_UpdateScheduleStatus(t *testing.T) {
 	}
 }
 
+func TestScheduler_CompleteDelivery_WritesDeliveryLog(t *testing.T) {
+	logger := zerolog.Nop()
+	store := newMockStore()
+	scheduler := NewScheduler(store, nil, nil, nil, &logger, DefaultConfig())
+	ctx := context.Background()
+
+	store.deliveries["dlv-1"] = &models.NewsletterDelivery{ID: "dlv-1", ScheduleID: "sched-1"}
+
+	now := time.Now()
+	report := &delivery.DeliveryReport{
+		Status:               models.DeliveryStatusPartial,
+		SuccessfulDeliveries: 1,
+		FailedDeliveries:     1,
+		Results: []delivery.DeliveryResult{
+			{Recipient: "a@example.com", RecipientType: "email", Channel: models.DeliveryChannelEmail, Success: true, DeliveredAt: &now},
+			{Recipient: "b@example.com", RecipientType: "email", Channel: models.DeliveryChannelEmail, ErrorCode: delivery.ErrorCodeServerError, RetryCount: 2},
+		},
+	}
+
+	scheduler.completeDelivery(ctx, "dlv-1", report, &now)
+
+	store.mu.Lock()
+	dlv := store.deliveries["dlv-1"]
+	entries := store.logEntries
+	store.mu.Unlock()
+
+	if len(dlv.RecipientDetails) != 2 {
+		t.Fatalf("RecipientDetails = %d entries, want 2", len(dlv.RecipientDetails))
+	}
+	if len(entries) != 2 {
+		t.Fatalf("delivery log = %d entries, want 2", len(entries))
+	}
+
+	failed := entries[1]
+	if failed.DeliveryID != "dlv-1" || failed.ScheduleID != "sched-1" {
+		t.Errorf("entry IDs = %s/%s, want dlv-1/sched-1", failed.DeliveryID, failed.ScheduleID)
+	}
+	if failed.Status != models.DeliveryStatusFailed || failed.Attempts != 3 || failed.ErrorCode != delivery.ErrorCodeServerError {
+		t.Errorf("failed entry = %+v, want failed after 3 attempts", failed)
+	}
+	if entries[0].Status != models.DeliveryStatusDelivered || entries[0].DeliveredAt == nil {
+		t.Errorf("delivered entry = %+v", entries[0])
+	}
+}
+
 // Integration test for the scheduler wrapper
 func TestNewsletterSchedulerServiceInterface(t *testing.T) {
 	logger := zerolog.Nop()
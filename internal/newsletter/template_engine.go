@@ -343,6 +343,8 @@ func (te *TemplateEngine) buildFuncMap() template.FuncMap {
 }
 
 // RenderHTML renders a newsletter template with the provided data to HTML.
+// Content blocks are inserted before </body> unless the template ranges
+// over .Blocks itself.
 func (te *TemplateEngine) RenderHTML(templateContent string, data *models.NewsletterContentData) (string, error) {
 	tmpl, err := template.New("newsletter").Funcs(te.funcMap).Parse(templateContent)
 	if err != nil {
@@ -354,6 +356,9 @@ func (te *TemplateEngine) RenderHTML(templateContent string, data *models.Newsle
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
+	if data != nil && len(data.Blocks) > 0 && !usesBlocks(templateContent) {
+		return appendBlocksHTML(buf.String(), data.Blocks), nil
+	}
 	return buf.String(), nil
 }
 
@@ -370,6 +375,9 @@ func (te *TemplateEngine) RenderText(templateContent string, data *models.Newsle
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
+	if data != nil && len(data.Blocks) > 0 && !usesBlocks(templateContent) {
+		return appendBlocksText(buf.String(), data.Blocks), nil
+	}
 	return buf.String(), nil
 }
 
@@ -432,6 +440,9 @@ func (te *TemplateEngine) GetAvailableVariables() []models.TemplateVariable {
 		{Name: "User.WatchTimeHours", Description: "User's total watch time", Type: "float64", Required: false},
 		{Name: "Recommendations", Description: "Personalized content recommendations", Type: "[]NewsletterMediaItem", Required: false},
 
+		// Content blocks
+		{Name: "Blocks", Description: "Rendered content blocks in configured order (use safeHTML .HTML or .Text); appended automatically if unused", Type: "[]NewsletterRenderedBlock", Required: false},
+
 		// Health (for server health newsletters)
 		{Name: "Health", Description: "Server health data", Type: "NewsletterHealthData", Required: false},
 		{Name: "Health.ServerStatus", Description: "Server status (healthy, degraded, unhealthy)", Type: "string", Required: false},
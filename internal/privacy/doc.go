@@ -0,0 +1,62 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+/*
+Package privacy controls how client IP addresses are stored.
+
+PRIVACY_IP_MODE selects one of three modes:
+
+	full       addresses are stored as received (default)
+	truncated  203.0.113.57 -> 203.0.113.0, 2001:db8:1:2::5 -> 2001:db8:1::
+	hashed     iph:<32 hex digits>, an HMAC-SHA256 keyed with PRIVACY_IP_HASH_KEY
+
+Hashed addresses cannot be reversed without the key, but the same address
+always yields the same value, so deduplication, "same device" checks and
+per-IP counts keep working. Truncation keeps city-level precision for
+network-based analysis while dropping the host part.
+
+# Pipeline Ordering
+
+Geolocation and VPN detection need the real address, so anonymization
+happens as late as possible and always before anything is written to the
+database:
+
+ 1. Sync managers and webhook handlers receive the full address and
+    resolve its geolocation (GeoIP providers see the real address).
+ 2. The event is published to NATS, or to the durable WAL, with the full
+    address. These are transport buffers; messages are removed once
+    consumed or when the stream's retention expires.
+ 3. The detection engine looks up the geolocation, then anonymizes the
+    event. Trusted-location CIDRs and the VPN lookup use the address from
+    before anonymization (DetectionEvent.ClientIP); every other rule,
+    the stored alert and its notifications see the anonymized address.
+ 4. The database layer anonymizes on every write: playback history,
+    geolocations (stored under the anonymized address, so lookups of any
+    address in the same /24 share one entry in truncated mode), detection
+    alerts, and the audit tables. Lookups by address are anonymized the
+    same way, so they find the stored rows.
+
+audit.SourceFromRequest anonymizes the request address itself, so audit
+events carry it only in anonymized form.
+
+# Usage
+
+The server installs the configured anonymizer once at startup; everything
+else calls AnonymizeIP:
+
+	anonymizer, err := privacy.NewIPAnonymizer(privacy.IPModeHashed, cfg.Privacy.IPHashKey)
+	if err != nil {
+	    return err
+	}
+	privacy.SetIPAnonymizer(anonymizer)
+
+	stored := privacy.AnonymizeIP("203.0.113.57")
+
+Anonymizing is idempotent, so rows written before the mode changed can be
+re-anonymized with POST /api/v1/admin/privacy/anonymize-ips. Changing the
+hash key, or going from hashed to truncated, does not rewrite values that
+are already hashed.
+*/
+package privacy
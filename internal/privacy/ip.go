@@ -0,0 +1,177 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package privacy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+)
+
+// IPMode selects how client IP addresses are stored.
+type IPMode string
+
+// IP storage modes (PRIVACY_IP_MODE).
+const (
+	IPModeFull      IPMode = "full"      // Store addresses as received
+	IPModeTruncated IPMode = "truncated" // Zero the last octet (IPv4) or the last 80 bits (IPv6)
+	IPModeHashed    IPMode = "hashed"    // Keyed HMAC-SHA256, so equal addresses still compare equal
+)
+
+// HashedPrefix starts every hashed address, so hashing a stored value
+// again leaves it unchanged.
+const HashedPrefix = "iph:"
+
+// MinHashKeyLength is the shortest PRIVACY_IP_HASH_KEY accepted.
+const MinHashKeyLength = 32
+
+// Prefix lengths kept by IPModeTruncated.
+const (
+	truncatedIPv4Bits = 24
+	truncatedIPv6Bits = 48
+)
+
+// ParseIPMode parses a PRIVACY_IP_MODE value. Empty means IPModeFull.
+func ParseIPMode(s string) (IPMode, error) {
+	switch mode := IPMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return IPModeFull, nil
+	case IPModeFull, IPModeTruncated, IPModeHashed:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown IP mode %q (want full, truncated or hashed)", s)
+	}
+}
+
+// IPAnonymizer applies an IPMode to addresses. A nil *IPAnonymizer keeps
+// addresses unchanged, like IPModeFull.
+type IPAnonymizer struct {
+	mode IPMode
+	key  []byte
+}
+
+// NewIPAnonymizer creates an anonymizer for mode. IPModeHashed requires a
+// key of at least MinHashKeyLength characters; the other modes ignore it.
+func NewIPAnonymizer(mode IPMode, key string) (*IPAnonymizer, error) {
+	switch mode {
+	case IPModeFull, IPModeTruncated:
+		return &IPAnonymizer{mode: mode}, nil
+	case IPModeHashed:
+		if len(key) < MinHashKeyLength {
+			return nil, fmt.Errorf("hashed IP mode needs a key of at least %d characters", MinHashKeyLength)
+		}
+		return &IPAnonymizer{mode: mode, key: []byte(key)}, nil
+	default:
+		return nil, fmt.Errorf("unknown IP mode %q", mode)
+	}
+}
+
+// Mode returns the anonymizer's mode.
+func (a *IPAnonymizer) Mode() IPMode {
+	if a == nil {
+		return IPModeFull
+	}
+	return a.mode
+}
+
+// Anonymize returns ip as it should be stored. Ports, brackets, zones and
+// IPv4-mapped IPv6 forms are normalized first in the truncated and hashed
+// modes. The result is stable: anonymizing it again returns it unchanged.
+//
+// Values that are not IP addresses cannot be truncated and are dropped
+// (returned as ""); in hashed mode they are hashed as they are.
+func (a *IPAnonymizer) Anonymize(ip string) string {
+	if a == nil || a.mode == IPModeFull || ip == "" || strings.HasPrefix(ip, HashedPrefix) {
+		return ip
+	}
+
+	addr, ok := parseAddr(ip)
+	switch a.mode {
+	case IPModeTruncated:
+		if !ok {
+			return ""
+		}
+		bits := truncatedIPv6Bits
+		if addr.Is4() {
+			bits = truncatedIPv4Bits
+		}
+		return netip.PrefixFrom(addr, bits).Masked().Addr().String()
+	case IPModeHashed:
+		value := strings.TrimSpace(ip)
+		if ok {
+			value = addr.String()
+		}
+		mac := hmac.New(sha256.New, a.key)
+		mac.Write([]byte(value))
+		return HashedPrefix + hex.EncodeToString(mac.Sum(nil)[:16])
+	default:
+		return ip
+	}
+}
+
+// AnonymizeList anonymizes each entry of a comma-separated address list,
+// such as an X-Forwarded-For header, dropping entries that become empty.
+func (a *IPAnonymizer) AnonymizeList(ips string) string {
+	if a.Mode() == IPModeFull || !strings.Contains(ips, ",") {
+		return a.Anonymize(ips)
+	}
+
+	parts := strings.Split(ips, ",")
+	out := parts[:0]
+	for _, part := range parts {
+		if anonymized := a.Anonymize(strings.TrimSpace(part)); anonymized != "" {
+			out = append(out, anonymized)
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+// parseAddr parses an address that may carry a port, brackets or a zone.
+// IPv4-mapped IPv6 addresses are returned as IPv4.
+func parseAddr(ip string) (netip.Addr, bool) {
+	host := strings.TrimSpace(ip)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	} else {
+		host = strings.Trim(host, "[]")
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.WithZone("").Unmap(), true
+}
+
+// anonymizer holds the process-wide anonymizer, or nil for full mode.
+var anonymizer atomic.Pointer[IPAnonymizer]
+
+// SetIPAnonymizer installs the anonymizer used by AnonymizeIP. The server
+// sets it once at startup from PRIVACY_IP_MODE; nil restores full mode.
+// Tests that change it must not run in parallel.
+func SetIPAnonymizer(a *IPAnonymizer) {
+	anonymizer.Store(a)
+}
+
+// CurrentIPMode returns the mode of the process-wide anonymizer.
+func CurrentIPMode() IPMode {
+	return anonymizer.Load().Mode()
+}
+
+// AnonymizeIP applies the process-wide mode to ip (see IPAnonymizer.Anonymize).
+func AnonymizeIP(ip string) string {
+	return anonymizer.Load().Anonymize(ip)
+}
+
+// AnonymizeIPList applies the process-wide mode to a comma-separated list
+// (see IPAnonymizer.AnonymizeList).
+func AnonymizeIPList(ips string) string {
+	return anonymizer.Load().AnonymizeList(ips)
+}
@@ -0,0 +1,149 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package privacy
+
+import (
+	"strings"
+	"testing"
+)
+
+const testHashKey = "0123456789abcdef0123456789abcdef"
+
+func mustAnonymizer(t *testing.T, mode IPMode) *IPAnonymizer {
+	t.Helper()
+	a, err := NewIPAnonymizer(mode, testHashKey)
+	if err != nil {
+		t.Fatalf("NewIPAnonymizer(%s) error = %v", mode, err)
+	}
+	return a
+}
+
+func TestParseIPMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    IPMode
+		wantErr bool
+	}{
+		{"", IPModeFull, false},
+		{"full", IPModeFull, false},
+		{" Truncated ", IPModeTruncated, false},
+		{"HASHED", IPModeHashed, false},
+		{"masked", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseIPMode(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseIPMode(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNewIPAnonymizer_HashedNeedsKey(t *testing.T) {
+	if _, err := NewIPAnonymizer(IPModeHashed, "short"); err == nil {
+		t.Error("NewIPAnonymizer(hashed, short key) succeeded")
+	}
+	if _, err := NewIPAnonymizer(IPModeTruncated, ""); err != nil {
+		t.Errorf("NewIPAnonymizer(truncated) error = %v", err)
+	}
+	if _, err := NewIPAnonymizer("masked", testHashKey); err == nil {
+		t.Error("NewIPAnonymizer(unknown mode) succeeded")
+	}
+}
+
+func TestAnonymize_Truncated(t *testing.T) {
+	a := mustAnonymizer(t, IPModeTruncated)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"203.0.113.57", "203.0.113.0"},
+		{"203.0.113.57:32400", "203.0.113.0"},
+		{"::ffff:203.0.113.57", "203.0.113.0"},
+		{"2001:db8:1:2:3:4:5:6", "2001:db8:1::"},
+		{"[2001:db8:1:2::5]:443", "2001:db8:1::"},
+		{"fe80::1%eth0", "fe80::"},
+		{"203.0.113.0", "203.0.113.0"},
+		{"", ""},
+		{"not-an-ip", ""},
+	}
+	for _, tt := range tests {
+		if got := a.Anonymize(tt.in); got != tt.want {
+			t.Errorf("Anonymize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAnonymize_Hashed(t *testing.T) {
+	a := mustAnonymizer(t, IPModeHashed)
+
+	hashed := a.Anonymize("203.0.113.57")
+	if !strings.HasPrefix(hashed, HashedPrefix) || len(hashed) != len(HashedPrefix)+32 {
+		t.Fatalf("Anonymize() = %q, want %s and 32 hex digits", hashed, HashedPrefix)
+	}
+	if strings.Contains(hashed, "203.0.113") {
+		t.Errorf("hashed value %q reveals the address", hashed)
+	}
+
+	// The same address in any notation hashes to the same value
+	for _, same := range []string{"203.0.113.57:32400", "::ffff:203.0.113.57", " 203.0.113.57 "} {
+		if got := a.Anonymize(same); got != hashed {
+			t.Errorf("Anonymize(%q) = %q, want %q", same, got, hashed)
+		}
+	}
+	if a.Anonymize("203.0.113.58") == hashed {
+		t.Error("different addresses hashed to the same value")
+	}
+	if got := a.Anonymize(hashed); got != hashed {
+		t.Errorf("hashing a hashed value changed it to %q", got)
+	}
+
+	other, err := NewIPAnonymizer(IPModeHashed, strings.Repeat("k", MinHashKeyLength))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Anonymize("203.0.113.57") == hashed {
+		t.Error("different keys produced the same hash")
+	}
+}
+
+func TestAnonymize_FullAndNil(t *testing.T) {
+	var nilAnonymizer *IPAnonymizer
+	full := mustAnonymizer(t, IPModeFull)
+	for _, a := range []*IPAnonymizer{nilAnonymizer, full} {
+		if got := a.Anonymize("203.0.113.57:32400"); got != "203.0.113.57:32400" {
+			t.Errorf("%s: Anonymize() = %q, want the address unchanged", a.Mode(), got)
+		}
+	}
+}
+
+func TestAnonymizeList(t *testing.T) {
+	a := mustAnonymizer(t, IPModeTruncated)
+	if got := a.AnonymizeList("203.0.113.57, 198.51.100.7, unknown"); got != "203.0.113.0, 198.51.100.0" {
+		t.Errorf("AnonymizeList() = %q", got)
+	}
+	if got := a.AnonymizeList("203.0.113.57"); got != "203.0.113.0" {
+		t.Errorf("AnonymizeList(single) = %q", got)
+	}
+}
+
+func TestSetIPAnonymizer(t *testing.T) {
+	t.Cleanup(func() { SetIPAnonymizer(nil) })
+
+	if CurrentIPMode() != IPModeFull || AnonymizeIP("203.0.113.57") != "203.0.113.57" {
+		t.Fatal("default mode is not full")
+	}
+
+	SetIPAnonymizer(mustAnonymizer(t, IPModeTruncated))
+	if CurrentIPMode() != IPModeTruncated {
+		t.Errorf("CurrentIPMode() = %s, want truncated", CurrentIPMode())
+	}
+	if got := AnonymizeIP("203.0.113.57"); got != "203.0.113.0" {
+		t.Errorf("AnonymizeIP() = %q, want 203.0.113.0", got)
+	}
+	if got := AnonymizeIPList("203.0.113.57, 198.51.100.7"); got != "203.0.113.0, 198.51.100.0" {
+		t.Errorf("AnonymizeIPList() = %q", got)
+	}
+}
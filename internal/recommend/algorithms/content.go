@@ -7,10 +7,14 @@ package algorithms
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/tomtom215/cartographus/internal/recommend"
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
 )
 
 // ContentBased implements content-based filtering using item metadata.
@@ -43,6 +47,7 @@ type ContentBased struct {
 	itemIndex    map[int]int       // item_id -> index in items slice
 	userProfiles map[int]*profile  // user_id -> preference profile
 	itemFeatures map[int]*features // item_id -> feature vectors
+	featureIndex map[string][]int  // feature key -> item_ids, for SimilarItems
 }
 
 // profile represents a user's content preferences.
@@ -60,6 +65,61 @@ type features struct {
 	actors    []string
 	directors []string
 	year      int
+
+	// Lowercased, deduplicated and sorted at training time, so item-item
+	// similarity is a merge of two sorted slices.
+	genreSet    []string
+	actorSet    []string
+	directorSet []string
+}
+
+// newFeatures builds the feature vectors of an item.
+//
+//nolint:gocritic // hugeParam: item passed by value, matching the Train loop
+func newFeatures(item recommend.Item) *features {
+	return &features{
+		genres:      item.Genres,
+		actors:      item.Actors,
+		directors:   item.Directors,
+		year:        item.Year,
+		genreSet:    featureSet(item.Genres),
+		actorSet:    featureSet(item.Actors),
+		directorSet: featureSet(item.Directors),
+	}
+}
+
+// featureSet lowercases, deduplicates and sorts feature values.
+func featureSet(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make([]string, 0, len(values))
+	for _, v := range values {
+		set = append(set, strings.ToLower(v))
+	}
+	sort.Strings(set)
+	out := set[:1]
+	for _, v := range set[1:] {
+		if v != out[len(out)-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// indexKeys returns the feature index keys of an item.
+func (f *features) indexKeys() []string {
+	keys := make([]string, 0, len(f.genreSet)+len(f.actorSet)+len(f.directorSet))
+	for _, g := range f.genreSet {
+		keys = append(keys, "g:"+g)
+	}
+	for _, a := range f.actorSet {
+		keys = append(keys, "a:"+a)
+	}
+	for _, d := range f.directorSet {
+		keys = append(keys, "d:"+d)
+	}
+	return keys
 }
 
 // ContentBasedConfig contains configuration for content-based filtering.
@@ -109,6 +169,7 @@ func NewContentBased(cfg ContentBasedConfig) *ContentBased {
 		itemIndex:         make(map[int]int),
 		userProfiles:      make(map[int]*profile),
 		itemFeatures:      make(map[int]*features),
+		featureIndex:      make(map[string][]int),
 	}
 }
 
@@ -124,15 +185,15 @@ func (c *ContentBased) Train(ctx context.Context, interactions []recommend.Inter
 	c.itemIndex = make(map[int]int, len(items))
 	c.userProfiles = make(map[int]*profile)
 	c.itemFeatures = make(map[int]*features, len(items))
+	c.featureIndex = make(map[string][]int)
 
-	// Build item index and features
+	// Build item index, features and the feature -> items index
 	for i, item := range items {
+		feat := newFeatures(item)
 		c.itemIndex[item.ID] = i
-		c.itemFeatures[item.ID] = &features{
-			genres:    item.Genres,
-			actors:    item.Actors,
-			directors: item.Directors,
-			year:      item.Year,
+		c.itemFeatures[item.ID] = feat
+		for _, key := range feat.indexKeys() {
+			c.featureIndex[key] = append(c.featureIndex[key], item.ID)
 		}
 	}
 
@@ -148,7 +209,7 @@ func (c *ContentBased) Train(ctx context.Context, interactions []recommend.Inter
 		}
 
 		// Only consider positive interactions
-		if inter.Type == recommend.InteractionAbandoned {
+		if inter.Type == recommend.InteractionAbandoned || inter.Type == recommend.InteractionRejected {
 			continue
 		}
 
@@ -298,6 +359,62 @@ func (c *ContentBased) PredictSimilar(ctx context.Context, itemID int, candidate
 	return normalizeScores(scores), nil
 }
 
+// SimilarItems returns the k items of the catalog most similar to itemID,
+// highest first, without a user context. Only items sharing a genre, actor
+// or director with itemID are scored, using the feature index built at
+// training time. Scores are the raw similarity (0-1), not normalized.
+// Implements recommend.ItemSimilarityProvider.
+func (c *ContentBased) SimilarItems(ctx context.Context, itemID, k int) ([]recommend.ScoredItem, error) {
+	c.acquirePredictLock()
+	defer c.releasePredictLock()
+
+	if !c.trained {
+		return nil, recommend.ErrModelNotTrained
+	}
+
+	source, ok := c.itemFeatures[itemID]
+	if !ok {
+		return nil, recommend.ErrItemNotFound
+	}
+
+	seen := map[int]struct{}{itemID: {}}
+	var similar []recommend.ScoredItem
+	for _, key := range source.indexKeys() {
+		if ContextCancelled(ctx) {
+			return nil, ctx.Err()
+		}
+
+		for _, candidateID := range c.featureIndex[key] {
+			if _, ok := seen[candidateID]; ok {
+				continue
+			}
+			seen[candidateID] = struct{}{}
+
+			score := c.computeItemSimilarity(source, c.itemFeatures[candidateID])
+			if score <= 0 {
+				continue
+			}
+			similar = append(similar, recommend.ScoredItem{
+				Item:   c.items[c.itemIndex[candidateID]],
+				Score:  score,
+				Scores: map[string]float64{c.Name(): score},
+			})
+		}
+	}
+
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Score != similar[j].Score {
+			return similar[i].Score > similar[j].Score
+		}
+		return similar[i].Item.ID < similar[j].Item.ID
+	})
+	if len(similar) > k {
+		similar = similar[:k]
+	}
+
+	return similar, nil
+}
+
 // computeUserItemScore computes the score between a user profile and item.
 func (c *ContentBased) computeUserItemScore(prof *profile, feat *features) float64 {
 	var score float64
@@ -345,13 +462,13 @@ func (c *ContentBased) computeItemSimilarity(a, b *features) float64 {
 	var score float64
 
 	// Genre similarity
-	score += c.genreWeight * jaccardSimilarity(a.genres, b.genres)
+	score += c.genreWeight * sortedJaccard(a.genreSet, b.genreSet)
 
 	// Actor similarity
-	score += c.actorWeight * jaccardSimilarity(a.actors, b.actors)
+	score += c.actorWeight * sortedJaccard(a.actorSet, b.actorSet)
 
 	// Director similarity
-	score += c.directorWeight * jaccardSimilarity(a.directors, b.directors)
+	score += c.directorWeight * sortedJaccard(a.directorSet, b.directorSet)
 
 	// Year similarity
 	if a.year > 0 && b.year > 0 {
@@ -365,3 +482,126 @@ func (c *ContentBased) computeItemSimilarity(a, b *features) float64 {
 
 	return score
 }
+
+// ModelState returns a snapshot of the trained model for persistence.
+// Item features are stored as their precomputed, lowercased sets.
+// Implements recommend.PersistableAlgorithm.
+func (c *ContentBased) ModelState() interface{} {
+	c.acquirePredictLock()
+	defer c.releasePredictLock()
+
+	profiles := make(map[int]storage.UserProfile, len(c.userProfiles))
+	for userID, prof := range c.userProfiles {
+		profiles[userID] = storage.UserProfile{
+			Genres:    prof.genres,
+			Actors:    prof.actors,
+			Directors: prof.directors,
+			AvgYear:   prof.avgYear,
+		}
+	}
+
+	items := make(map[int]storage.ItemFeatures, len(c.itemFeatures))
+	for itemID, feat := range c.itemFeatures {
+		items[itemID] = storage.ItemFeatures{
+			Genres:    feat.genreSet,
+			Actors:    feat.actorSet,
+			Directors: feat.directorSet,
+			Year:      feat.year,
+		}
+	}
+
+	return storage.ContentModelState{
+		UserProfiles:      profiles,
+		Items:             items,
+		GenreWeight:       c.genreWeight,
+		ActorWeight:       c.actorWeight,
+		DirectorWeight:    c.directorWeight,
+		YearWeight:        c.yearWeight,
+		MaxYearDifference: c.maxYearDifference,
+	}
+}
+
+// NewModelState returns an empty snapshot to decode a stored model into.
+// Implements recommend.RestorableAlgorithm.
+func (c *ContentBased) NewModelState() interface{} {
+	return &storage.ContentModelState{}
+}
+
+// RestoreModelState replaces the model with a stored snapshot. Only item
+// feature sets are stored, so items returned by SimilarItems carry their ID
+// and lowercased features without titles until the next training run.
+// Implements recommend.RestorableAlgorithm.
+func (c *ContentBased) RestoreModelState(state interface{}, trainedAt time.Time) error {
+	s, ok := state.(*storage.ContentModelState)
+	if !ok {
+		return fmt.Errorf("unexpected model state %T", state)
+	}
+
+	// Sorted by ID so the restored model is deterministic
+	itemIDs := make([]int, 0, len(s.Items))
+	for itemID := range s.Items {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Ints(itemIDs)
+
+	items := make([]recommend.Item, len(itemIDs))
+	itemIndex := make(map[int]int, len(itemIDs))
+	itemFeatures := make(map[int]*features, len(itemIDs))
+	featureIndex := make(map[string][]int)
+	for i, itemID := range itemIDs {
+		stored := s.Items[itemID]
+		items[i] = recommend.Item{
+			ID:        itemID,
+			Genres:    stored.Genres,
+			Actors:    stored.Actors,
+			Directors: stored.Directors,
+			Year:      stored.Year,
+		}
+		feat := newFeatures(items[i])
+		itemIndex[itemID] = i
+		itemFeatures[itemID] = feat
+		for _, key := range feat.indexKeys() {
+			featureIndex[key] = append(featureIndex[key], itemID)
+		}
+	}
+
+	profiles := make(map[int]*profile, len(s.UserProfiles))
+	for userID, stored := range s.UserProfiles {
+		prof := &profile{
+			genres:    nonNilWeights(stored.Genres),
+			actors:    nonNilWeights(stored.Actors),
+			directors: nonNilWeights(stored.Directors),
+			avgYear:   stored.AvgYear,
+		}
+		// Only the average is stored; a year count marks it as known
+		if stored.AvgYear > 0 {
+			prof.yearCount = 1
+		}
+		profiles[userID] = prof
+	}
+
+	c.acquireTrainLock()
+	defer c.releaseTrainLock()
+
+	c.items = items
+	c.itemIndex = itemIndex
+	c.itemFeatures = itemFeatures
+	c.featureIndex = featureIndex
+	c.userProfiles = profiles
+	c.markRestored(trainedAt)
+	return nil
+}
+
+// nonNilWeights returns m, or an empty map when gob decoded it as nil.
+func nonNilWeights(m map[string]float64) map[string]float64 {
+	if m == nil {
+		return make(map[string]float64)
+	}
+	return m
+}
+
+// Ensure interface compliance.
+var (
+	_ recommend.RestorableAlgorithm    = (*ContentBased)(nil)
+	_ recommend.ItemSimilarityProvider = (*ContentBased)(nil)
+)
@@ -7,10 +7,12 @@ package algorithms
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/recommend"
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
 )
 
 func TestNewContentBased(t *testing.T) {
@@ -295,3 +297,129 @@ func TestContentBased_ContextCancellation(t *testing.T) {
 		t.Error("Train() with canceled context should return error")
 	}
 }
+
+func TestContentBased_SimilarItems(t *testing.T) {
+	items := []recommend.Item{
+		{ID: 100, Title: "Aliens", Genres: []string{"Action", "Sci-Fi"}, Actors: []string{"Actor A"}, Directors: []string{"Dir X"}, Year: 2020},
+		{ID: 101, Title: "Alien", Genres: []string{"action", "Sci-Fi", "Sci-Fi"}, Actors: []string{"Actor A"}, Directors: []string{"Dir X"}, Year: 2021},
+		{ID: 102, Title: "Comedy", Genres: []string{"Comedy"}, Actors: []string{"Actor B"}, Directors: []string{"Dir Y"}, Year: 2020},
+		{ID: 103, Title: "Old Action", Genres: []string{"Action"}, Actors: []string{"Actor C"}, Directors: []string{"Dir Z"}, Year: 2000},
+	}
+
+	cb := NewContentBased(ContentBasedConfig{})
+	if _, err := cb.SimilarItems(context.Background(), 100, 10); !errors.Is(err, recommend.ErrModelNotTrained) {
+		t.Fatalf("SimilarItems() before Train error = %v, want ErrModelNotTrained", err)
+	}
+	if err := cb.Train(context.Background(), nil, items); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	similar, err := cb.SimilarItems(context.Background(), 100, 10)
+	if err != nil {
+		t.Fatalf("SimilarItems() error = %v", err)
+	}
+	// 102 shares no genre, actor or director and is not ranked
+	if len(similar) != 2 || similar[0].Item.ID != 101 || similar[1].Item.ID != 103 {
+		t.Fatalf("SimilarItems() = %+v, want 101 then 103", similar)
+	}
+	if similar[0].Item.Title != "Alien" || similar[0].Scores["content"] != similar[0].Score {
+		t.Errorf("top item = %+v, want catalog metadata and a content score", similar[0])
+	}
+	// Features match case-insensitively and duplicates are ignored: 101
+	// differs from 100 only by one year
+	if similar[0].Score < 0.99 {
+		t.Errorf("score of 101 = %v, want ~1", similar[0].Score)
+	}
+
+	if top, _ := cb.SimilarItems(context.Background(), 100, 1); len(top) != 1 || top[0].Item.ID != 101 {
+		t.Errorf("SimilarItems(k=1) = %+v, want only 101", top)
+	}
+	if _, err := cb.SimilarItems(context.Background(), 999, 10); !errors.Is(err, recommend.ErrItemNotFound) {
+		t.Errorf("SimilarItems(unknown) error = %v, want ErrItemNotFound", err)
+	}
+}
+
+func TestContentBased_ModelState(t *testing.T) {
+	items := []recommend.Item{
+		{ID: 1, Genres: []string{"Drama", "drama", "Action"}, Year: 1999},
+		{ID: 2, Genres: []string{"Action"}},
+	}
+	interactions := []recommend.Interaction{{UserID: 7, ItemID: 1, Type: recommend.InteractionCompleted}}
+
+	cb := NewContentBased(ContentBasedConfig{})
+	if err := cb.Train(context.Background(), interactions, items); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	state, ok := cb.ModelState().(storage.ContentModelState)
+	if !ok {
+		t.Fatalf("ModelState() type = %T, want storage.ContentModelState", cb.ModelState())
+	}
+	if got := state.Items[1]; len(got.Genres) != 2 || got.Genres[0] != "action" || got.Genres[1] != "drama" || got.Year != 1999 {
+		t.Errorf("Items[1] = %+v, want the sorted, lowercased feature set", got)
+	}
+	if _, ok := state.UserProfiles[7]; !ok || state.GenreWeight != cb.genreWeight {
+		t.Errorf("state = %+v, want user 7's profile and the model weights", state)
+	}
+}
+
+func TestContentBased_RestoreModelState(t *testing.T) {
+	items := []recommend.Item{
+		{ID: 1, Title: "Heat", Genres: []string{"Crime", "Drama"}, Directors: []string{"Michael Mann"}, Year: 1995},
+		{ID: 2, Title: "Collateral", Genres: []string{"Crime"}, Directors: []string{"Michael Mann"}, Year: 2004},
+		{ID: 3, Title: "Up", Genres: []string{"Animation"}, Year: 2009},
+	}
+	interactions := []recommend.Interaction{{UserID: 7, ItemID: 1, Type: recommend.InteractionCompleted}}
+
+	cb := NewContentBased(ContentBasedConfig{})
+	if err := cb.Train(context.Background(), interactions, items); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	trainedAt := time.Date(2026, 4, 1, 2, 0, 0, 0, time.UTC)
+	restored := NewContentBased(ContentBasedConfig{})
+	restoreFromStore(t, cb, restored, trainedAt)
+
+	if !restored.IsTrained() || !restored.LastTrainedAt().Equal(trainedAt) {
+		t.Errorf("trained = %v at %v, want restored at %v", restored.IsTrained(), restored.LastTrainedAt(), trainedAt)
+	}
+
+	want, _ := cb.Predict(context.Background(), 7, []int{2, 3})
+	got, err := restored.Predict(context.Background(), 7, []int{2, 3})
+	if err != nil || got[2] != want[2] || got[3] != want[3] {
+		t.Errorf("restored Predict() = %v, %v; want %v", got, err, want)
+	}
+
+	similar, err := restored.SimilarItems(context.Background(), 1, 5)
+	if err != nil || len(similar) != 1 || similar[0].Item.ID != 2 {
+		t.Errorf("restored SimilarItems() = %+v, %v; want item 2", similar, err)
+	}
+
+	if err := restored.RestoreModelState(&storage.EASEModelState{}, trainedAt); err == nil {
+		t.Error("RestoreModelState(EASE state) succeeded, want type error")
+	}
+}
+
+// restoreFromStore saves trained's model to a store and restores it into
+// restored, as the training coordinator does on startup.
+func restoreFromStore(t *testing.T, trained recommend.PersistableAlgorithm, restored recommend.RestorableAlgorithm, trainedAt time.Time) {
+	t.Helper()
+
+	store, err := storage.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	meta := storage.ModelMetadata{TrainedAt: trainedAt}
+	if err := store.Save(context.Background(), trained.Name(), 1, trained.ModelState(), meta); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	state := restored.NewModelState()
+	loaded, err := store.Load(context.Background(), trained.Name(), 1, state)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := restored.RestoreModelState(state, loaded.TrainedAt); err != nil {
+		t.Fatalf("RestoreModelState() error = %v", err)
+	}
+}
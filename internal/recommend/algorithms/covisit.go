@@ -7,10 +7,12 @@ package algorithms
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/recommend"
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
 )
 
 // CoVisitation implements the co-visitation recommendation algorithm.
@@ -23,6 +25,12 @@ import (
 //
 //	covisit[item_a][item_b] = count of sessions where both items appeared
 //
+// A user's plays form one session until they stop watching for longer than
+// the session gap (6 hours by default, as in binge detection), so items
+// watched on unrelated occasions do not co-occur. Sessions are also split
+// into buckets of at most the session window, so a user watching every few
+// hours for days does not form one long session.
+//
 // For prediction, items are scored by their total co-occurrence with items
 // the user has already watched.
 type CoVisitation struct {
@@ -30,6 +38,7 @@ type CoVisitation struct {
 
 	// Configuration
 	minCoOccurrence    int
+	sessionGapMinutes  int
 	sessionWindowHours int
 	maxPairs           int
 
@@ -48,7 +57,12 @@ type CoVisitConfig struct {
 	// MinCoOccurrence is the minimum number of co-occurrences to store.
 	MinCoOccurrence int
 
-	// SessionWindowHours defines the session grouping window.
+	// SessionGapMinutes is the idle time between two plays after which a
+	// new session starts. Default: 360, the binge detection gap.
+	SessionGapMinutes int
+
+	// SessionWindowHours is the longest span of a session; a play after it
+	// starts a new session even without a gap.
 	SessionWindowHours int
 
 	// MaxPairs is the maximum number of co-visitation pairs to store.
@@ -60,6 +74,9 @@ func NewCoVisitation(cfg CoVisitConfig) *CoVisitation {
 	if cfg.MinCoOccurrence < 1 {
 		cfg.MinCoOccurrence = 2
 	}
+	if cfg.SessionGapMinutes < 1 {
+		cfg.SessionGapMinutes = 360
+	}
 	if cfg.SessionWindowHours < 1 {
 		cfg.SessionWindowHours = 24
 	}
@@ -70,6 +87,7 @@ func NewCoVisitation(cfg CoVisitConfig) *CoVisitation {
 	return &CoVisitation{
 		BaseAlgorithm:      NewBaseAlgorithm("covisit"),
 		minCoOccurrence:    cfg.MinCoOccurrence,
+		sessionGapMinutes:  cfg.SessionGapMinutes,
 		sessionWindowHours: cfg.SessionWindowHours,
 		maxPairs:           cfg.MaxPairs,
 		cooccurrence:       make(map[int]map[int]float64),
@@ -90,6 +108,7 @@ func (c *CoVisitation) Train(ctx context.Context, interactions []recommend.Inter
 	c.itemUsers = make(map[int][]int)
 	c.itemCounts = make(map[int]int)
 
+	interactions = withoutRejected(interactions)
 	if len(interactions) == 0 {
 		c.markTrained()
 		return nil
@@ -105,6 +124,7 @@ func (c *CoVisitation) Train(ctx context.Context, interactions []recommend.Inter
 	}
 
 	// Build co-occurrence matrix
+	sessionGap := time.Duration(c.sessionGapMinutes) * time.Minute
 	sessionWindow := time.Duration(c.sessionWindowHours) * time.Hour
 	cooccurrenceCounts := make(map[int]map[int]int)
 
@@ -119,9 +139,12 @@ func (c *CoVisitation) Train(ctx context.Context, interactions []recommend.Inter
 		})
 
 		// Group into sessions
-		sessions := groupIntoSessions(items, sessionWindow)
+		sessions := groupIntoSessions(items, sessionGap, sessionWindow)
 
 		for _, session := range sessions {
+			// Resumed or repeated plays count once per session
+			session = uniqueSessionItems(session)
+
 			// Count item occurrences
 			for _, ti := range session {
 				c.itemCounts[ti.itemID]++
@@ -160,8 +183,10 @@ type timedItem struct {
 	timestamp time.Time
 }
 
-// groupIntoSessions groups items into sessions based on time window.
-func groupIntoSessions(items []timedItem, window time.Duration) [][]timedItem {
+// groupIntoSessions splits time-ordered items into sessions. An item starts
+// a new session when more than gap passed since the previous item, or more
+// than window since the session's first item.
+func groupIntoSessions(items []timedItem, gap, window time.Duration) [][]timedItem {
 	if len(items) == 0 {
 		return nil
 	}
@@ -170,7 +195,9 @@ func groupIntoSessions(items []timedItem, window time.Duration) [][]timedItem {
 	currentSession := []timedItem{items[0]}
 
 	for i := 1; i < len(items); i++ {
-		if items[i].timestamp.Sub(currentSession[len(currentSession)-1].timestamp) > window {
+		idle := items[i].timestamp.Sub(currentSession[len(currentSession)-1].timestamp)
+		span := items[i].timestamp.Sub(currentSession[0].timestamp)
+		if idle > gap || span > window {
 			// New session
 			sessions = append(sessions, currentSession)
 			currentSession = []timedItem{items[i]}
@@ -187,6 +214,21 @@ func groupIntoSessions(items []timedItem, window time.Duration) [][]timedItem {
 	return sessions
 }
 
+// uniqueSessionItems returns the session with each item kept only at its
+// first play.
+func uniqueSessionItems(session []timedItem) []timedItem {
+	seen := make(map[int]struct{}, len(session))
+	unique := make([]timedItem, 0, len(session))
+	for _, ti := range session {
+		if _, ok := seen[ti.itemID]; ok {
+			continue
+		}
+		seen[ti.itemID] = struct{}{}
+		unique = append(unique, ti)
+	}
+	return unique
+}
+
 // buildSimilarityMatrix converts co-occurrence counts to similarity scores.
 func (c *CoVisitation) buildSimilarityMatrix(counts map[int]map[int]int) map[int]map[int]float64 {
 	similarity := make(map[int]map[int]float64)
@@ -327,3 +369,69 @@ func (c *CoVisitation) getUserHistory(userID int) []int {
 	}
 	return result
 }
+
+// ModelState returns a snapshot of the trained model for persistence.
+// Implements recommend.PersistableAlgorithm.
+func (c *CoVisitation) ModelState() interface{} {
+	c.acquirePredictLock()
+	defer c.releasePredictLock()
+
+	// The model indexes users by item; storage keeps history per user
+	userHistory := make(map[int][]int)
+	for itemID, users := range c.itemUsers {
+		for _, userID := range users {
+			userHistory[userID] = append(userHistory[userID], itemID)
+		}
+	}
+
+	return storage.CoVisitModelState{
+		CoOccurrence:       c.cooccurrence,
+		ItemCounts:         c.itemCounts,
+		UserHistory:        userHistory,
+		MinCoOccurrence:    c.minCoOccurrence,
+		SessionGapMinutes:  c.sessionGapMinutes,
+		SessionWindowHours: c.sessionWindowHours,
+		MaxPairs:           c.maxPairs,
+	}
+}
+
+// NewModelState returns an empty snapshot to decode a stored model into.
+// Implements recommend.RestorableAlgorithm.
+func (c *CoVisitation) NewModelState() interface{} {
+	return &storage.CoVisitModelState{}
+}
+
+// RestoreModelState replaces the model with a stored snapshot, rebuilding
+// the item -> users index from the stored per-user history.
+// Implements recommend.RestorableAlgorithm.
+func (c *CoVisitation) RestoreModelState(state interface{}, trainedAt time.Time) error {
+	s, ok := state.(*storage.CoVisitModelState)
+	if !ok {
+		return fmt.Errorf("unexpected model state %T", state)
+	}
+
+	itemUsers := make(map[int][]int)
+	for userID, items := range s.UserHistory {
+		for _, itemID := range items {
+			itemUsers[itemID] = append(itemUsers[itemID], userID)
+		}
+	}
+
+	c.acquireTrainLock()
+	defer c.releaseTrainLock()
+
+	c.cooccurrence = s.CoOccurrence
+	if c.cooccurrence == nil {
+		c.cooccurrence = make(map[int]map[int]float64)
+	}
+	c.itemCounts = s.ItemCounts
+	if c.itemCounts == nil {
+		c.itemCounts = make(map[int]int)
+	}
+	c.itemUsers = itemUsers
+	c.markRestored(trainedAt)
+	return nil
+}
+
+// Ensure interface compliance.
+var _ recommend.RestorableAlgorithm = (*CoVisitation)(nil)
@@ -26,6 +26,9 @@ func TestNewCoVisitation(t *testing.T) {
 				if cv.minCoOccurrence < 1 {
 					t.Errorf("minCoOccurrence = %d, want >= 1", cv.minCoOccurrence)
 				}
+				if cv.sessionGapMinutes != 360 {
+					t.Errorf("sessionGapMinutes = %d, want 360", cv.sessionGapMinutes)
+				}
 				if cv.sessionWindowHours < 1 {
 					t.Errorf("sessionWindowHours = %d, want >= 1", cv.sessionWindowHours)
 				}
@@ -38,6 +41,7 @@ func TestNewCoVisitation(t *testing.T) {
 			name: "uses provided config values",
 			cfg: CoVisitConfig{
 				MinCoOccurrence:    5,
+				SessionGapMinutes:  90,
 				SessionWindowHours: 12,
 				MaxPairs:           50000,
 			},
@@ -45,6 +49,9 @@ func TestNewCoVisitation(t *testing.T) {
 				if cv.minCoOccurrence != 5 {
 					t.Errorf("minCoOccurrence = %d, want 5", cv.minCoOccurrence)
 				}
+				if cv.sessionGapMinutes != 90 {
+					t.Errorf("sessionGapMinutes = %d, want 90", cv.sessionGapMinutes)
+				}
 				if cv.sessionWindowHours != 12 {
 					t.Errorf("sessionWindowHours = %d, want 12", cv.sessionWindowHours)
 				}
@@ -131,6 +138,113 @@ func TestCoVisitation_Train(t *testing.T) {
 	}
 }
 
+func TestCoVisitation_TrainSessionGap(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Two users each watch 100, then 101 after 2 hours, then 102 after 7
+	var interactions []recommend.Interaction
+	for _, userID := range []int{1, 2} {
+		interactions = append(interactions,
+			recommend.Interaction{UserID: userID, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+			recommend.Interaction{UserID: userID, ItemID: 101, Timestamp: baseTime.Add(2 * time.Hour), Confidence: 1.0},
+			recommend.Interaction{UserID: userID, ItemID: 102, Timestamp: baseTime.Add(9 * time.Hour), Confidence: 1.0},
+		)
+	}
+
+	tests := []struct {
+		name      string
+		gap       int
+		wantPairs map[[2]int]bool
+	}{
+		{"default gap", 0, map[[2]int]bool{{100, 101}: true, {101, 102}: false, {100, 102}: false}},
+		{"longer gap", 480, map[[2]int]bool{{100, 101}: true, {101, 102}: true, {100, 102}: true}},
+		{"shorter gap", 60, map[[2]int]bool{{100, 101}: false, {101, 102}: false, {100, 102}: false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := NewCoVisitation(CoVisitConfig{MinCoOccurrence: 2, SessionGapMinutes: tt.gap})
+			if err := cv.Train(context.Background(), interactions, nil); err != nil {
+				t.Fatalf("Train() error = %v", err)
+			}
+			for pair, want := range tt.wantPairs {
+				_, got := cv.cooccurrence[pair[0]][pair[1]]
+				if got != want {
+					t.Errorf("co-visited(%d, %d) = %v, want %v", pair[0], pair[1], got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupIntoSessions(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours ...int) []timedItem {
+		items := make([]timedItem, len(hours))
+		for i, h := range hours {
+			items[i] = timedItem{itemID: i, timestamp: baseTime.Add(time.Duration(h) * time.Hour)}
+		}
+		return items
+	}
+
+	tests := []struct {
+		name  string
+		items []timedItem
+		want  []int // session sizes
+	}{
+		{"empty", nil, nil},
+		{"within gap", at(0, 2, 4), []int{3}},
+		{"gap exceeded", at(0, 2, 9, 10), []int{2, 2}},
+		{"gap is inclusive", at(0, 6), []int{2}},
+		// Every play within the gap, but the window caps the session
+		{"window exceeded", at(0, 5, 10, 15, 20, 25, 30), []int{5, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := groupIntoSessions(tt.items, 6*time.Hour, 24*time.Hour)
+			sizes := make([]int, 0, len(sessions))
+			for _, s := range sessions {
+				sizes = append(sizes, len(s))
+			}
+			if len(sizes) != len(tt.want) {
+				t.Fatalf("session sizes = %v, want %v", sizes, tt.want)
+			}
+			for i := range sizes {
+				if sizes[i] != tt.want[i] {
+					t.Errorf("session sizes = %v, want %v", sizes, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCoVisitation_TrainRepeatedPlays(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A paused and resumed movie counts once in its session
+	interactions := []recommend.Interaction{
+		{UserID: 1, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 1, ItemID: 100, Timestamp: baseTime.Add(time.Hour), Confidence: 1.0},
+		{UserID: 1, ItemID: 101, Timestamp: baseTime.Add(3 * time.Hour), Confidence: 1.0},
+	}
+
+	cv := NewCoVisitation(CoVisitConfig{MinCoOccurrence: 1})
+	if err := cv.Train(context.Background(), interactions, nil); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	if cv.itemCounts[100] != 1 {
+		t.Errorf("itemCounts[100] = %d, want 1", cv.itemCounts[100])
+	}
+	if _, ok := cv.cooccurrence[100][100]; ok {
+		t.Error("item co-visited with itself")
+	}
+	if got := cv.cooccurrence[100][101]; got != 1 {
+		t.Errorf("similarity(100, 101) = %v, want 1", got)
+	}
+}
+
 func TestCoVisitation_Predict(t *testing.T) {
 	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 
@@ -271,3 +385,33 @@ func TestCoVisitation_ContextCancellation(t *testing.T) {
 		t.Error("Train() with canceled context should return error")
 	}
 }
+
+func TestCoVisitation_RestoreModelState(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	interactions := []recommend.Interaction{
+		{UserID: 1, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 1, ItemID: 101, Timestamp: baseTime.Add(time.Hour), Confidence: 1.0},
+		{UserID: 2, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 2, ItemID: 101, Timestamp: baseTime.Add(30 * time.Minute), Confidence: 1.0},
+		{UserID: 3, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+	}
+
+	cv := NewCoVisitation(CoVisitConfig{MinCoOccurrence: 2})
+	if err := cv.Train(context.Background(), interactions, nil); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	restored := NewCoVisitation(CoVisitConfig{MinCoOccurrence: 2})
+	restoreFromStore(t, cv, restored, baseTime)
+
+	if !restored.IsTrained() || restored.Version() != 1 {
+		t.Errorf("trained = %v, version = %d; want restored at version 1", restored.IsTrained(), restored.Version())
+	}
+
+	// User 3's history is rebuilt from the stored per-user history
+	want, _ := cv.Predict(context.Background(), 3, []int{101})
+	got, err := restored.Predict(context.Background(), 3, []int{101})
+	if err != nil || len(got) != 1 || got[101] != want[101] {
+		t.Errorf("restored Predict() = %v, %v; want %v", got, err, want)
+	}
+}
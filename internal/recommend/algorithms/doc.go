@@ -134,7 +134,7 @@
 //
 //   - normalizeScores: Min-max normalization to [0, 1]
 //   - cosineSimilarity: Vector similarity calculation
-//   - jaccardSimilarity: Set-based similarity for categorical data
+//   - sortedJaccard: Set-based similarity for categorical data
 //   - ContextCancelled: Check for context cancellation
 //
 // # Performance Considerations
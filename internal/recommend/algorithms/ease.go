@@ -10,8 +10,10 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"time"
 
 	"github.com/tomtom215/cartographus/internal/recommend"
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
 )
 
 // EASEConfig contains configuration for the EASE algorithm.
@@ -479,7 +481,10 @@ func computePseudoInverseSimple(A [][]float64) [][]float64 {
 }
 
 // Ensure interface compliance.
-var _ recommend.Algorithm = (*EASE)(nil)
+var (
+	_ recommend.Algorithm           = (*EASE)(nil)
+	_ recommend.RestorableAlgorithm = (*EASE)(nil)
+)
 
 // EASEParallel is a parallel version of EASE for larger datasets.
 type EASEParallel struct {
@@ -701,3 +706,52 @@ func (e *EASEParallel) Train(ctx context.Context, interactions []recommend.Inter
 	e.markTrained()
 	return nil
 }
+
+// ModelState returns a snapshot of the trained model for persistence.
+// Implements recommend.PersistableAlgorithm.
+func (e *EASE) ModelState() interface{} {
+	e.acquirePredictLock()
+	defer e.releasePredictLock()
+
+	return storage.EASEModelState{
+		B:                e.B,
+		ItemIndex:        e.itemIndex,
+		IndexToItem:      e.indexToItem,
+		UserVectors:      e.userVectors,
+		L2Regularization: e.config.L2Regularization,
+		MinConfidence:    e.config.MinConfidence,
+	}
+}
+
+// NewModelState returns an empty snapshot to decode a stored model into.
+// Implements recommend.RestorableAlgorithm.
+func (e *EASE) NewModelState() interface{} {
+	return &storage.EASEModelState{}
+}
+
+// RestoreModelState replaces the model with a stored snapshot. The
+// snapshot's regularization settings are kept from the configuration, as
+// they only apply to the next training run.
+// Implements recommend.RestorableAlgorithm.
+func (e *EASE) RestoreModelState(state interface{}, trainedAt time.Time) error {
+	s, ok := state.(*storage.EASEModelState)
+	if !ok {
+		return fmt.Errorf("unexpected model state %T", state)
+	}
+	if len(s.B) != len(s.IndexToItem) || len(s.ItemIndex) != len(s.IndexToItem) {
+		return fmt.Errorf("inconsistent model state: %d weight rows for %d items", len(s.B), len(s.IndexToItem))
+	}
+
+	e.acquireTrainLock()
+	defer e.releaseTrainLock()
+
+	e.B = s.B
+	e.itemIndex = s.ItemIndex
+	e.indexToItem = s.IndexToItem
+	e.userVectors = s.UserVectors
+	if e.userVectors == nil {
+		e.userVectors = make(map[int][]float64)
+	}
+	e.markRestored(trainedAt)
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/recommend"
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
 )
 
 func TestNewEASE(t *testing.T) {
@@ -443,3 +444,34 @@ func TestEASE_GetItemIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestEASE_RestoreModelState(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	interactions := []recommend.Interaction{
+		{UserID: 1, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 1, ItemID: 101, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 2, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 2, ItemID: 101, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 3, ItemID: 100, Timestamp: baseTime, Confidence: 1.0},
+		{UserID: 3, ItemID: 102, Timestamp: baseTime, Confidence: 1.0},
+	}
+
+	e := NewEASE(EASEConfig{L2Regularization: 100.0})
+	if err := e.Train(context.Background(), interactions, nil); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	restored := NewEASE(EASEConfig{L2Regularization: 100.0})
+	restoreFromStore(t, e, restored, baseTime)
+
+	want, _ := e.Predict(context.Background(), 1, []int{102})
+	got, err := restored.Predict(context.Background(), 1, []int{102})
+	if err != nil || len(got) != len(want) || got[102] != want[102] {
+		t.Errorf("restored Predict() = %v, %v; want %v", got, err, want)
+	}
+
+	inconsistent := &storage.EASEModelState{B: [][]float64{{0}}, IndexToItem: []int{1, 2}}
+	if err := NewEASE(EASEConfig{}).RestoreModelState(inconsistent, baseTime); err == nil {
+		t.Error("RestoreModelState(inconsistent) succeeded, want error")
+	}
+}
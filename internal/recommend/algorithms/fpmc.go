@@ -146,6 +146,8 @@ func (f *FPMC) Train(ctx context.Context, interactions []recommend.Interaction,
 		return ctx.Err()
 	}
 
+	interactions = withoutRejected(interactions)
+
 	// Build indices
 	f.userIndex = make(map[int]int)
 	f.itemIndex = make(map[int]int)
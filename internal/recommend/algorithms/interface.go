@@ -80,6 +80,14 @@ func (b *BaseAlgorithm) markTrained() {
 	b.lastTrainedAt = time.Now()
 }
 
+// markRestored marks a model restored from storage as trained at trainedAt.
+// Must be called while holding the training lock (acquireTrainLock).
+func (b *BaseAlgorithm) markRestored(trainedAt time.Time) {
+	b.trained = true
+	b.version++
+	b.lastTrainedAt = trainedAt
+}
+
 // acquireTrainLock acquires the exclusive training lock.
 func (b *BaseAlgorithm) acquireTrainLock() {
 	b.mu.Lock()
@@ -160,6 +168,21 @@ func filterCandidates(scores map[int]float64, candidates []int) map[int]float64
 	return filtered
 }
 
+// withoutRejected drops explicitly rejected interactions (dislike or not
+// interested feedback). Algorithms that ignore confidence use it so a
+// rejection is not counted as a view.
+//
+//nolint:gocritic // rangeValCopy: Interaction passed by value in range, acceptable for clarity
+func withoutRejected(interactions []recommend.Interaction) []recommend.Interaction {
+	filtered := interactions[:0:0]
+	for _, inter := range interactions {
+		if inter.Type != recommend.InteractionRejected {
+			filtered = append(filtered, inter)
+		}
+	}
+	return filtered
+}
+
 // buildItemIndex creates a mapping from item ID to index.
 //
 //nolint:unused,gocritic // unused: utility function for future use; gocritic: rangeValCopy is acceptable
@@ -256,38 +279,28 @@ func sqrt(x float64) float64 {
 	return z
 }
 
-// jaccardSimilarity computes Jaccard similarity between two sets.
-func jaccardSimilarity(a, b []string) float64 {
-	if len(a) == 0 && len(b) == 0 {
+// sortedJaccard computes the Jaccard similarity of two sorted,
+// deduplicated sets.
+func sortedJaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
 		return 0
 	}
 
-	setA := make(map[string]struct{}, len(a))
-	for _, s := range a {
-		setA[s] = struct{}{}
-	}
-
-	setB := make(map[string]struct{}, len(b))
-	for _, s := range b {
-		setB[s] = struct{}{}
-	}
-
-	// Compute intersection
 	intersection := 0
-	for s := range setA {
-		if _, ok := setB[s]; ok {
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
 			intersection++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
 		}
 	}
 
-	// Compute union
-	union := len(setA) + len(setB) - intersection
-
-	if union == 0 {
-		return 0
-	}
-
-	return float64(intersection) / float64(union)
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
 }
 
 // Ensure all algorithms implement the interface.
@@ -117,6 +117,8 @@ func (u *UserBasedCF) Train(ctx context.Context, interactions []recommend.Intera
 		return ctx.Err()
 	}
 
+	interactions = withoutRejected(interactions)
+
 	// Build user vectors
 	u.userVectors = make(map[int]map[int]float64)
 	u.itemUsers = make(map[int][]int)
@@ -451,6 +453,8 @@ func (i *ItemBasedCF) Train(ctx context.Context, interactions []recommend.Intera
 		return ctx.Err()
 	}
 
+	interactions = withoutRejected(interactions)
+
 	// Build item vectors (transposed view)
 	i.itemVectors = make(map[int]map[int]float64)
 	i.userItems = make(map[int][]int)
@@ -8,7 +8,6 @@ package algorithms
 import (
 	"context"
 	"math"
-	"sync"
 
 	"github.com/tomtom215/cartographus/internal/recommend"
 )
@@ -84,8 +83,6 @@ type LinUCB struct {
 
 	// itemFeatures stores precomputed item feature vectors
 	itemFeatures map[int][]float64
-
-	mu sync.RWMutex
 }
 
 // NewLinUCB creates a new LinUCB algorithm.
@@ -432,9 +429,11 @@ func (l *LinUCB) PredictSimilar(ctx context.Context, itemID int, candidates []in
 
 // RecordFeedback updates the model with new feedback.
 // This enables online learning.
+//
+// It takes the training lock because it mutates the arm models read by Predict.
 func (l *LinUCB) RecordFeedback(userID int, itemID int, reward float64) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	l.acquireTrainLock()
+	defer l.releaseTrainLock()
 
 	d := l.config.NumFeatures
 
@@ -455,8 +454,8 @@ func (l *LinUCB) RecordFeedback(userID int, itemID int, reward float64) {
 
 // GetExplorationRate returns the current exploration rate.
 func (l *LinUCB) GetExplorationRate() float64 {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
+	l.acquirePredictLock()
+	defer l.releasePredictLock()
 
 	if l.totalObservations == 0 {
 		return 1.0
@@ -7,6 +7,7 @@ package algorithms
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -232,6 +233,76 @@ func TestLinUCB_RecordFeedback(t *testing.T) {
 	}
 }
 
+func TestLinUCB_RecordFeedback_DislikeLowersScore(t *testing.T) {
+	items := []recommend.Item{
+		{ID: 100, Genres: []string{"Action"}, Year: 2020},
+		{ID: 101, Genres: []string{"Action"}, Year: 2021},
+	}
+	interactions := []recommend.Interaction{
+		{UserID: 1, ItemID: 100, Confidence: 1.0},
+		{UserID: 1, ItemID: 101, Confidence: 1.0},
+	}
+
+	train := func() *LinUCB {
+		l := NewLinUCB(LinUCBConfig{NumFeatures: 16})
+		if err := l.Train(context.Background(), interactions, items); err != nil {
+			t.Fatalf("Train() error = %v", err)
+		}
+		return l
+	}
+
+	disliked, neutral := train(), train()
+	before := disliked.computeUCB(101, disliked.userFeatures[1])
+
+	disliked.RecordFeedback(1, 101, recommend.FeedbackDislike.Reward())
+	neutral.RecordFeedback(1, 101, 0)
+
+	after := disliked.computeUCB(101, disliked.userFeatures[1])
+	if after >= before {
+		t.Errorf("score after dislike = %v, want below %v", after, before)
+	}
+	// A zero reward also shrinks the exploration bonus; the dislike must do more
+	if zero := neutral.computeUCB(101, neutral.userFeatures[1]); after >= zero {
+		t.Errorf("score after dislike = %v, want below zero-reward score %v", after, zero)
+	}
+	if other := disliked.computeUCB(100, disliked.userFeatures[1]); after >= other {
+		t.Errorf("disliked item score = %v, want below untouched item %v", after, other)
+	}
+}
+
+func TestLinUCB_RecordFeedbackConcurrentWithPredict(t *testing.T) {
+	items := []recommend.Item{{ID: 100}, {ID: 101}}
+	l := NewLinUCB(LinUCBConfig{NumFeatures: 8})
+	if err := l.Train(context.Background(), []recommend.Interaction{{UserID: 1, ItemID: 100, Confidence: 1.0}}, items); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(itemID int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.RecordFeedback(1, itemID, 1.0)
+			}
+		}(200 + i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := l.Predict(context.Background(), 1, []int{100, 101, 200}); err != nil {
+					t.Errorf("Predict() error = %v", err)
+				}
+				_ = l.GetExplorationRate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if l.totalObservations != 1+4*50 {
+		t.Errorf("totalObservations = %d, want %d", l.totalObservations, 1+4*50)
+	}
+}
+
 func TestLinUCB_GetExplorationRate(t *testing.T) {
 	l := NewLinUCB(LinUCBConfig{Alpha: 1.0, NumFeatures: 8})
 
@@ -61,7 +61,7 @@ func NewPopularity(cfg PopularityConfig) *Popularity {
 	}
 
 	return &Popularity{
-		BaseAlgorithm: NewBaseAlgorithm("popularity"),
+		BaseAlgorithm: NewBaseAlgorithm(recommend.PopularityAlgorithm),
 		useTimeDecay:  cfg.UseTimeDecay,
 		decayHalfLife: cfg.DecayHalfLife,
 		maxItems:      cfg.MaxItems,
@@ -80,6 +80,7 @@ func (p *Popularity) Train(ctx context.Context, interactions []recommend.Interac
 	p.itemScores = make(map[int]float64)
 	p.sortedIDs = nil
 
+	interactions = withoutRejected(interactions)
 	if len(interactions) == 0 {
 		p.markTrained()
 		return nil
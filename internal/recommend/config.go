@@ -53,6 +53,9 @@ type Config struct {
 	// Cache contains caching parameters.
 	Cache CacheConfig `json:"cache"`
 
+	// Experiment is an optional A/B experiment over algorithm weights.
+	Experiment ExperimentConfig `json:"experiment"`
+
 	// Seed is the random seed for deterministic behavior.
 	// If zero, a fixed default seed is used.
 	Seed int64 `json:"seed"`
@@ -226,8 +229,14 @@ type CoVisitConfig struct {
 	// Default: 2.
 	MinCoOccurrence int `json:"min_co_occurrence"`
 
-	// SessionWindowHours defines the session grouping window.
-	// Items watched within this window are considered co-visited.
+	// SessionGapMinutes is the idle time between two plays after which a
+	// new session starts; only items watched in the same session are
+	// co-visited. Matches the binge detection gap.
+	// Default: 360.
+	SessionGapMinutes int `json:"session_gap_minutes"`
+
+	// SessionWindowHours is the longest span of a session. A session
+	// reaching it is split even without an idle gap.
 	// Default: 24.
 	SessionWindowHours int `json:"session_window_hours"`
 
@@ -368,6 +377,11 @@ type TrainingConfig struct {
 	// RetainVersions is the number of model versions to retain.
 	// Default: 3.
 	RetainVersions int `json:"retain_versions"`
+
+	// Parallelism is the number of algorithms trained concurrently.
+	// Values of 0 or 1 train algorithms one at a time.
+	// Default: 1.
+	Parallelism int `json:"parallelism"`
 }
 
 // LimitsConfig contains operational limits.
@@ -446,6 +460,7 @@ func DefaultConfig() *Config {
 		},
 		CoVisit: CoVisitConfig{
 			MinCoOccurrence:    2,
+			SessionGapMinutes:  360,
 			SessionWindowHours: 24,
 			MaxPairs:           100000,
 		},
@@ -488,6 +503,7 @@ func DefaultConfig() *Config {
 			MinItems:        10,
 			Timeout:         10 * time.Minute,
 			RetainVersions:  3,
+			Parallelism:     1,
 		},
 		Limits: LimitsConfig{
 			MaxCandidates:         1000,
@@ -543,6 +559,9 @@ func (c *Config) Validate() error {
 	if c.Training.Timeout <= 0 {
 		return fmt.Errorf("training.timeout must be positive, got %v", c.Training.Timeout)
 	}
+	if c.Training.Parallelism < 0 {
+		return fmt.Errorf("training.parallelism must be non-negative, got %d", c.Training.Parallelism)
+	}
 
 	if c.Limits.MaxCandidates < 1 {
 		return fmt.Errorf("limits.max_candidates must be positive, got %d", c.Limits.MaxCandidates)
@@ -554,12 +573,13 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("limits.max_k must be >= limits.default_k, got %d < %d", c.Limits.MaxK, c.Limits.DefaultK)
 	}
 
-	return nil
+	return c.Experiment.validate()
 }
 
 // Clone returns a deep copy of the configuration.
 func (c *Config) Clone() *Config {
-	// Direct field copy - all nested structs contain only value types (no pointers/slices)
+	// Direct field copy - all nested structs except Experiment contain only
+	// value types (no pointers/slices)
 	return &Config{
 		Weights:        c.Weights,
 		EASE:           c.EASE,
@@ -574,6 +594,7 @@ func (c *Config) Clone() *Config {
 		Training:       c.Training,
 		Limits:         c.Limits,
 		Cache:          c.Cache,
+		Experiment:     c.Experiment.clone(),
 		Seed:           c.Seed,
 	}
 }
@@ -590,6 +611,7 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 			MinItems        int    `json:"min_items"`
 			Timeout         string `json:"timeout"`
 			RetainVersions  int    `json:"retain_versions"`
+			Parallelism     int    `json:"parallelism"`
 		} `json:"training"`
 		Limits struct {
 			MaxCandidates         int    `json:"max_candidates"`
@@ -613,6 +635,7 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 			MinItems        int    `json:"min_items"`
 			Timeout         string `json:"timeout"`
 			RetainVersions  int    `json:"retain_versions"`
+			Parallelism     int    `json:"parallelism"`
 		}{
 			Interval:        c.Training.Interval.String(),
 			MinInteractions: c.Training.MinInteractions,
@@ -620,6 +643,7 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 			MinItems:        c.Training.MinItems,
 			Timeout:         c.Training.Timeout.String(),
 			RetainVersions:  c.Training.RetainVersions,
+			Parallelism:     c.Training.Parallelism,
 		},
 		Limits: struct {
 			MaxCandidates         int    `json:"max_candidates"`
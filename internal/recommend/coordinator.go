@@ -0,0 +1,509 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
+)
+
+// Training triggers recorded in TrainingRun.Trigger.
+const (
+	TriggerStartup   = "startup"
+	TriggerScheduled = "scheduled"
+	TriggerManual    = "manual"
+)
+
+// ErrTrainingInProgress is returned when a training run is already running
+// or queued.
+var ErrTrainingInProgress = errors.New("training already in progress")
+
+// PreconditionError explains why a training run was skipped.
+type PreconditionError struct {
+	Reason string
+}
+
+func (e *PreconditionError) Error() string {
+	return "training skipped: " + e.Reason
+}
+
+// SyncStatus reports whether a media server sync is writing to the database.
+// Implemented by *sync.Manager.
+type SyncStatus interface {
+	IsSyncing() bool
+}
+
+// PoolMonitor reports database connection pool utilization as a fraction
+// of the maximum open connections (0-1). Implemented by *database.DB.
+type PoolMonitor interface {
+	PoolUtilization() float64
+}
+
+// InteractionCounter counts interactions recorded since a point in time.
+// Implemented by *database.RecommendationDataProvider.
+type InteractionCounter interface {
+	CountInteractions(ctx context.Context, since time.Time) (int, error)
+}
+
+// ModelStore persists trained models. Implemented by *storage.Store.
+type ModelStore interface {
+	Save(ctx context.Context, name string, version int, data interface{}, meta storage.ModelMetadata) error
+	Prune(ctx context.Context, name string, keepVersions int) error
+	GetLatestVersion(name string) (int, bool)
+	Load(ctx context.Context, name string, version int, target interface{}) (*storage.ModelMetadata, error)
+}
+
+// PersistableAlgorithm is implemented by algorithms whose trained state can
+// be saved to a ModelStore. ModelState returns a gob-encodable snapshot.
+type PersistableAlgorithm interface {
+	Algorithm
+	ModelState() interface{}
+}
+
+// RestorableAlgorithm is a PersistableAlgorithm that can resume from a
+// saved snapshot, so after a restart it serves the last trained model until
+// the next training run. NewModelState returns a pointer to decode a
+// snapshot into; RestoreModelState replaces the model with it.
+type RestorableAlgorithm interface {
+	PersistableAlgorithm
+	NewModelState() interface{}
+	RestoreModelState(state interface{}, trainedAt time.Time) error
+}
+
+// CoordinatorConfig controls when the TrainingCoordinator trains.
+type CoordinatorConfig struct {
+	// Interval is the time between scheduled training runs. Default: 24h.
+	Interval time.Duration
+
+	// TrainOnStartup runs training as soon as the coordinator starts.
+	TrainOnStartup bool
+
+	// MinNewInteractions is the number of interactions that must have been
+	// recorded since the last successful run before scheduled training
+	// retrains. Manual runs ignore it. 0 disables the check.
+	MinNewInteractions int
+
+	// MaxPoolUtilization skips training while the database connection pool
+	// is at least this busy (0-1). 0 disables the check.
+	MaxPoolUtilization float64
+
+	// RetainVersions is the number of persisted versions kept per algorithm.
+	// Default: 3.
+	RetainVersions int
+
+	// Timeout bounds a single training run. Default: 30m.
+	Timeout time.Duration
+}
+
+// TrainingRun describes one attempted training run.
+type TrainingRun struct {
+	// Trigger is what started the run: startup, scheduled, or manual.
+	Trigger string `json:"trigger"`
+
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+
+	// ModelVersion is the engine model version produced by the run.
+	ModelVersion int `json:"model_version,omitempty"`
+
+	// Algorithms contains per-algorithm training durations and errors.
+	Algorithms []AlgorithmTrainingResult `json:"algorithms,omitempty"`
+
+	// PersistErrors lists models that could not be saved.
+	PersistErrors []string `json:"persist_errors,omitempty"`
+
+	// Error is set if the run failed or was canceled.
+	Error string `json:"error,omitempty"`
+}
+
+// CoordinatorStatus is a snapshot of the TrainingCoordinator's state.
+type CoordinatorStatus struct {
+	// Running is true while a training run is in progress.
+	Running bool `json:"running"`
+
+	// LastRun is the most recent run, successful or not.
+	LastRun *TrainingRun `json:"last_run,omitempty"`
+
+	// LastSuccessAt is when the last successful run started.
+	LastSuccessAt time.Time `json:"last_success_at,omitempty"`
+
+	// NextRunAt is when the next scheduled run is due.
+	NextRunAt time.Time `json:"next_run_at,omitempty"`
+
+	// LastSkipReason explains why the most recent scheduled run was skipped.
+	LastSkipReason string    `json:"last_skip_reason,omitempty"`
+	LastSkippedAt  time.Time `json:"last_skipped_at,omitempty"`
+}
+
+// TrainingCoordinator schedules engine training around system load. Before
+// each run it checks that no sync is in progress, the database connection
+// pool has headroom, and enough new interactions have arrived. After a
+// successful run it persists trained models and prunes old versions.
+//
+// Training runs in Run's goroutine under Run's context, so canceling the
+// context on shutdown stops an in-progress run.
+type TrainingCoordinator struct {
+	engine *Engine
+	config CoordinatorConfig
+	logger zerolog.Logger
+
+	syncStatus   SyncStatus
+	pool         PoolMonitor
+	interactions InteractionCounter
+	models       ModelStore
+
+	trigger chan string
+
+	mu     sync.RWMutex
+	status CoordinatorStatus
+}
+
+// NewTrainingCoordinator creates a coordinator for the engine.
+//
+//nolint:gocritic // logger passed by value is acceptable for zerolog
+func NewTrainingCoordinator(engine *Engine, cfg CoordinatorConfig, logger zerolog.Logger) *TrainingCoordinator {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 24 * time.Hour
+	}
+	if cfg.RetainVersions < 1 {
+		cfg.RetainVersions = 3
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Minute
+	}
+
+	return &TrainingCoordinator{
+		engine:  engine,
+		config:  cfg,
+		logger:  logger.With().Str("component", "recommend-coordinator").Logger(),
+		trigger: make(chan string, 1),
+	}
+}
+
+// SetSyncStatus enables the sync-in-progress precondition.
+func (c *TrainingCoordinator) SetSyncStatus(s SyncStatus) {
+	c.syncStatus = s
+}
+
+// SetPoolMonitor enables the connection pool utilization precondition.
+func (c *TrainingCoordinator) SetPoolMonitor(p PoolMonitor) {
+	c.pool = p
+}
+
+// SetInteractionCounter enables the new-interaction precondition.
+func (c *TrainingCoordinator) SetInteractionCounter(ic InteractionCounter) {
+	c.interactions = ic
+}
+
+// SetModelStore enables model persistence after successful runs.
+func (c *TrainingCoordinator) SetModelStore(store ModelStore) {
+	c.models = store
+}
+
+// Status returns a snapshot of the coordinator's state.
+func (c *TrainingCoordinator) Status() CoordinatorStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	status := c.status
+	if status.LastRun != nil {
+		run := *status.LastRun
+		status.LastRun = &run
+	}
+	return status
+}
+
+// Trigger queues a manual training run. Manual runs skip the new-interaction
+// check but still wait for syncs to finish and the pool to have headroom.
+// Returns ErrTrainingInProgress if a run is already running or queued, or a
+// *PreconditionError if training cannot start now.
+func (c *TrainingCoordinator) Trigger() error {
+	c.mu.RLock()
+	running := c.status.Running
+	c.mu.RUnlock()
+	if running {
+		return ErrTrainingInProgress
+	}
+
+	if reason := c.checkResources(); reason != "" {
+		return &PreconditionError{Reason: reason}
+	}
+
+	select {
+	case c.trigger <- TriggerManual:
+		return nil
+	default:
+		return ErrTrainingInProgress
+	}
+}
+
+// Run schedules training until ctx is canceled. It returns ctx.Err().
+func (c *TrainingCoordinator) Run(ctx context.Context) error {
+	c.logger.Info().
+		Dur("interval", c.config.Interval).
+		Bool("train_on_startup", c.config.TrainOnStartup).
+		Int("min_new_interactions", c.config.MinNewInteractions).
+		Float64("max_pool_utilization", c.config.MaxPoolUtilization).
+		Msg("training coordinator starting")
+
+	c.restoreModels(ctx)
+
+	if c.config.TrainOnStartup {
+		c.runIfReady(ctx, TriggerStartup)
+	}
+
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+	c.setNextRun(time.Now().Add(c.config.Interval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info().Msg("training coordinator shutting down")
+			return ctx.Err()
+
+		case <-ticker.C:
+			c.setNextRun(time.Now().Add(c.config.Interval))
+			c.runIfReady(ctx, TriggerScheduled)
+
+		case trigger := <-c.trigger:
+			c.runIfReady(ctx, trigger)
+		}
+	}
+}
+
+// runIfReady checks preconditions and trains if they are met.
+func (c *TrainingCoordinator) runIfReady(ctx context.Context, trigger string) {
+	if reason := c.checkPreconditions(ctx, trigger); reason != "" {
+		c.mu.Lock()
+		c.status.LastSkipReason = reason
+		c.status.LastSkippedAt = time.Now()
+		c.mu.Unlock()
+
+		c.logger.Info().
+			Str("trigger", trigger).
+			Str("reason", reason).
+			Msg("training skipped")
+		return
+	}
+
+	c.train(ctx, trigger)
+}
+
+// checkPreconditions returns why training should not run now, or "".
+func (c *TrainingCoordinator) checkPreconditions(ctx context.Context, trigger string) string {
+	if reason := c.checkResources(); reason != "" {
+		return reason
+	}
+
+	if trigger == TriggerManual || c.config.MinNewInteractions <= 0 || c.interactions == nil {
+		return ""
+	}
+
+	c.mu.RLock()
+	since := c.status.LastSuccessAt
+	c.mu.RUnlock()
+	if since.IsZero() {
+		return "" // Never trained; the engine's minimum interaction check applies
+	}
+
+	count, err := c.interactions.CountInteractions(ctx, since)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("failed to count new interactions; training anyway")
+		return ""
+	}
+	if count < c.config.MinNewInteractions {
+		return fmt.Sprintf("only %d new interactions since last training (need %d)", count, c.config.MinNewInteractions)
+	}
+	return ""
+}
+
+// checkResources returns why the system is too busy to train, or "".
+func (c *TrainingCoordinator) checkResources() string {
+	if c.syncStatus != nil && c.syncStatus.IsSyncing() {
+		return "sync in progress"
+	}
+	if c.pool != nil && c.config.MaxPoolUtilization > 0 {
+		if used := c.pool.PoolUtilization(); used >= c.config.MaxPoolUtilization {
+			return fmt.Sprintf("database pool %.0f%% utilized (limit %.0f%%)", used*100, c.config.MaxPoolUtilization*100)
+		}
+	}
+	return ""
+}
+
+// train runs the engine and persists the resulting models.
+func (c *TrainingCoordinator) train(ctx context.Context, trigger string) {
+	run := &TrainingRun{Trigger: trigger, StartedAt: time.Now()}
+
+	c.mu.Lock()
+	c.status.Running = true
+	c.mu.Unlock()
+
+	trainCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	err := c.engine.Train(trainCtx)
+	engineStatus := c.engine.GetStatus()
+	run.Algorithms = engineStatus.Algorithms
+
+	if err == nil {
+		run.ModelVersion = engineStatus.ModelVersion
+		run.PersistErrors = c.persistModels(trainCtx, engineStatus)
+		c.logger.Info().
+			Str("trigger", trigger).
+			Int("model_version", run.ModelVersion).
+			Dur("duration", time.Since(run.StartedAt)).
+			Msg("training complete")
+	} else {
+		run.Error = err.Error()
+		c.logger.Warn().Err(err).Str("trigger", trigger).Msg("training failed")
+	}
+
+	run.FinishedAt = time.Now()
+	run.DurationMS = run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+
+	c.mu.Lock()
+	c.status.Running = false
+	c.status.LastRun = run
+	if err == nil {
+		c.status.LastSuccessAt = run.StartedAt
+	}
+	c.mu.Unlock()
+}
+
+// persistModels saves every trained persistable algorithm as a new version,
+// with its offline evaluation, and prunes old versions. A failed evaluation
+// is logged and the model is saved without one. Returns one message per
+// failure.
+//
+//nolint:gocritic // hugeParam: status is a read-only snapshot
+func (c *TrainingCoordinator) persistModels(ctx context.Context, status TrainingStatus) []string {
+	if c.models == nil {
+		return nil
+	}
+
+	var (
+		errs         []string
+		interactions []Interaction
+		items        []Item
+		dataErr      error
+		dataLoaded   bool
+	)
+	for _, alg := range c.engine.getAlgorithms() {
+		p, ok := alg.(PersistableAlgorithm)
+		if !ok || !p.IsTrained() {
+			continue
+		}
+		if ctx.Err() != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", alg.Name(), ctx.Err()))
+			continue
+		}
+
+		// Versions continue from what is on disk so restarts don't overwrite
+		version, _ := c.models.GetLatestVersion(alg.Name())
+		version++
+
+		meta := storage.ModelMetadata{
+			TrainedAt:          p.LastTrainedAt(),
+			InteractionCount:   status.InteractionCount,
+			ItemCount:          status.ItemCount,
+			UserCount:          status.UserCount,
+			TrainingDurationMS: algorithmDuration(status.Algorithms, alg.Name()),
+		}
+
+		// Evaluation data is loaded once, on the first model saved
+		if !dataLoaded {
+			interactions, items, dataErr = c.engine.evaluationData(ctx)
+			dataLoaded = true
+		}
+		evalErr := dataErr
+		if evalErr == nil {
+			meta.Evaluation, evalErr = c.engine.evaluate(ctx, alg, interactions, items)
+		}
+		if evalErr != nil {
+			c.logger.Warn().Err(evalErr).Str("algorithm", alg.Name()).Msg("failed to evaluate model")
+		}
+		if err := c.models.Save(ctx, alg.Name(), version, p.ModelState(), meta); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", alg.Name(), err))
+			c.logger.Error().Err(err).Str("algorithm", alg.Name()).Msg("failed to persist model")
+			continue
+		}
+		if err := c.models.Prune(ctx, alg.Name(), c.config.RetainVersions); err != nil {
+			c.logger.Warn().Err(err).Str("algorithm", alg.Name()).Msg("failed to prune old models")
+		}
+	}
+	return errs
+}
+
+// restoreModels loads the latest stored version of every restorable
+// algorithm and records the outcome in the engine's status. A model that
+// fails to load stays untrained until the next training run.
+func (c *TrainingCoordinator) restoreModels(ctx context.Context) {
+	if c.models == nil {
+		return
+	}
+
+	var restored int
+	var newest time.Time
+	for _, alg := range c.engine.getAlgorithms() {
+		r, ok := alg.(RestorableAlgorithm)
+		if !ok {
+			continue
+		}
+		version, ok := c.models.GetLatestVersion(alg.Name())
+		if !ok {
+			continue
+		}
+
+		load := ModelLoadStatus{Version: version, AttemptedAt: time.Now()}
+		state := r.NewModelState()
+		meta, err := c.models.Load(ctx, alg.Name(), version, state)
+		if err == nil {
+			err = r.RestoreModelState(state, meta.TrainedAt)
+		}
+		if err != nil {
+			load.Error = err.Error()
+			c.logger.Warn().Err(err).Str("algorithm", alg.Name()).Int("version", version).Msg("failed to load stored model")
+		} else {
+			load.Loaded = true
+			restored++
+			if meta.TrainedAt.After(newest) {
+				newest = meta.TrainedAt
+			}
+			c.logger.Info().Str("algorithm", alg.Name()).Int("version", version).Msg("loaded stored model")
+		}
+		c.engine.recordModelLoad(alg.Name(), load)
+	}
+
+	if restored > 0 {
+		c.engine.markRestored(newest)
+	}
+}
+
+// setNextRun records when the next scheduled run is due.
+func (c *TrainingCoordinator) setNextRun(at time.Time) {
+	c.mu.Lock()
+	c.status.NextRunAt = at
+	c.mu.Unlock()
+}
+
+// algorithmDuration returns the training duration recorded for an algorithm.
+func algorithmDuration(results []AlgorithmTrainingResult, name string) int64 {
+	for _, r := range results {
+		if r.Name == name {
+			return r.DurationMS
+		}
+	}
+	return 0
+}
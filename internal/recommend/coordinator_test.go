@@ -0,0 +1,348 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
+)
+
+// persistableMockAlgorithm is a mockAlgorithm with a saveable model.
+type persistableMockAlgorithm struct {
+	*mockAlgorithm
+}
+
+func (p *persistableMockAlgorithm) ModelState() interface{} {
+	return map[string]int{"version": p.Version()}
+}
+
+type fakeSyncStatus struct{ syncing bool }
+
+func (f *fakeSyncStatus) IsSyncing() bool { return f.syncing }
+
+type fakePoolMonitor struct{ utilization float64 }
+
+func (f *fakePoolMonitor) PoolUtilization() float64 { return f.utilization }
+
+type fakeInteractionCounter struct{ count int }
+
+func (f *fakeInteractionCounter) CountInteractions(_ context.Context, _ time.Time) (int, error) {
+	return f.count, nil
+}
+
+// restorableMockAlgorithm is a persistableMockAlgorithm that can be
+// restored from a stored model.
+type restorableMockAlgorithm struct {
+	*persistableMockAlgorithm
+}
+
+func (r *restorableMockAlgorithm) NewModelState() interface{} {
+	return &map[string]int{}
+}
+
+func (r *restorableMockAlgorithm) RestoreModelState(_ interface{}, trainedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trained = true
+	r.version++
+	r.lastTrainedAt = trainedAt
+	return nil
+}
+
+// fakeModelStore records saves and prunes in memory.
+type fakeModelStore struct {
+	mu       sync.Mutex
+	latest   map[string]int
+	saved    []string
+	meta     map[string]storage.ModelMetadata
+	prunedTo map[string]int
+	loadErr  map[string]error
+}
+
+func newFakeModelStore() *fakeModelStore {
+	return &fakeModelStore{
+		latest:   make(map[string]int),
+		meta:     make(map[string]storage.ModelMetadata),
+		prunedTo: make(map[string]int),
+		loadErr:  make(map[string]error),
+	}
+}
+
+func (f *fakeModelStore) Save(_ context.Context, name string, version int, _ interface{}, meta storage.ModelMetadata) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latest[name] = version
+	f.meta[name] = meta
+	f.saved = append(f.saved, name)
+	return nil
+}
+
+func (f *fakeModelStore) Prune(_ context.Context, name string, keepVersions int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prunedTo[name] = keepVersions
+	return nil
+}
+
+func (f *fakeModelStore) GetLatestVersion(name string) (int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.latest[name]
+	return v, ok
+}
+
+func (f *fakeModelStore) Load(_ context.Context, name string, _ int, _ interface{}) (*storage.ModelMetadata, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.loadErr[name]; err != nil {
+		return nil, err
+	}
+	meta := f.meta[name]
+	return &meta, nil
+}
+
+func newCoordinatorTestEngine(t *testing.T, algs ...Algorithm) *Engine {
+	t.Helper()
+
+	cfg := DefaultConfig()
+	cfg.Training.MinInteractions = 1
+	engine, err := NewEngine(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	for _, alg := range algs {
+		engine.RegisterAlgorithm(alg)
+	}
+	engine.SetDataProvider(&mockDataProvider{
+		interactions: []Interaction{{UserID: 1, ItemID: 1, Confidence: 1.0}},
+		items:        []Item{{ID: 1}},
+	})
+	return engine
+}
+
+func TestTrainingCoordinator_SkipsWhileBusy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		syncing    bool
+		pool       float64
+		wantReason string
+	}{
+		{"sync in progress", true, 0, "sync in progress"},
+		{"pool busy", false, 0.9, "database pool 90% utilized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			alg := newMockAlgorithm("covisit")
+			c := NewTrainingCoordinator(newCoordinatorTestEngine(t, alg), CoordinatorConfig{MaxPoolUtilization: 0.75}, testLogger())
+			c.SetSyncStatus(&fakeSyncStatus{syncing: tt.syncing})
+			c.SetPoolMonitor(&fakePoolMonitor{utilization: tt.pool})
+
+			c.runIfReady(context.Background(), TriggerScheduled)
+
+			status := c.Status()
+			if !strings.Contains(status.LastSkipReason, tt.wantReason) {
+				t.Errorf("LastSkipReason = %q, want it to contain %q", status.LastSkipReason, tt.wantReason)
+			}
+			if status.LastRun != nil || alg.IsTrained() {
+				t.Error("training ran despite busy system")
+			}
+
+			var precondition *PreconditionError
+			if err := c.Trigger(); !errors.As(err, &precondition) {
+				t.Errorf("Trigger() error = %v, want *PreconditionError", err)
+			}
+		})
+	}
+}
+
+func TestTrainingCoordinator_MinNewInteractions(t *testing.T) {
+	t.Parallel()
+
+	alg := newMockAlgorithm("covisit")
+	c := NewTrainingCoordinator(newCoordinatorTestEngine(t, alg), CoordinatorConfig{MinNewInteractions: 10}, testLogger())
+	c.SetInteractionCounter(&fakeInteractionCounter{count: 3})
+
+	// The first run is not gated: there is no previous run to count from
+	c.runIfReady(context.Background(), TriggerScheduled)
+	if c.Status().LastRun == nil {
+		t.Fatal("first scheduled run was skipped")
+	}
+	firstVersion := alg.Version()
+
+	c.runIfReady(context.Background(), TriggerScheduled)
+	if reason := c.Status().LastSkipReason; !strings.Contains(reason, "only 3 new interactions") {
+		t.Errorf("LastSkipReason = %q, want new-interaction skip", reason)
+	}
+	if alg.Version() != firstVersion {
+		t.Error("scheduled run retrained below the new-interaction threshold")
+	}
+
+	// Manual runs ignore the threshold
+	c.runIfReady(context.Background(), TriggerManual)
+	if alg.Version() != firstVersion+1 {
+		t.Error("manual run did not retrain")
+	}
+	if got := c.Status().LastRun.Trigger; got != TriggerManual {
+		t.Errorf("LastRun.Trigger = %q, want %q", got, TriggerManual)
+	}
+}
+
+func TestTrainingCoordinator_RecordsRunAndPersists(t *testing.T) {
+	t.Parallel()
+
+	failing := newMockAlgorithm("ease")
+	failing.trainErr = errors.New("out of memory")
+	persistable := &persistableMockAlgorithm{newMockAlgorithm("covisit")}
+	plain := newMockAlgorithm("content")
+
+	store := newFakeModelStore()
+	store.latest["covisit"] = 7 // Saved by a previous process
+
+	c := NewTrainingCoordinator(newCoordinatorTestEngine(t, persistable, failing, plain),
+		CoordinatorConfig{RetainVersions: 2}, testLogger())
+	c.SetModelStore(store)
+
+	c.runIfReady(context.Background(), TriggerManual)
+
+	run := c.Status().LastRun
+	if run == nil || run.Error != "" {
+		t.Fatalf("LastRun = %+v, want successful run", run)
+	}
+	if run.ModelVersion != 1 {
+		t.Errorf("ModelVersion = %d, want 1", run.ModelVersion)
+	}
+	if len(run.Algorithms) != 3 {
+		t.Fatalf("Algorithms = %+v, want 3 results", run.Algorithms)
+	}
+	if run.Algorithms[1].Name != "ease" || run.Algorithms[1].Error != "out of memory" {
+		t.Errorf("Algorithms[1] = %+v, want ease with its error", run.Algorithms[1])
+	}
+	if c.Status().LastSuccessAt.IsZero() {
+		t.Error("LastSuccessAt not set")
+	}
+
+	if len(store.saved) != 1 || store.saved[0] != "covisit" {
+		t.Errorf("saved = %v, want only covisit", store.saved)
+	}
+	if store.latest["covisit"] != 8 {
+		t.Errorf("covisit version = %d, want 8", store.latest["covisit"])
+	}
+	if store.prunedTo["covisit"] != 2 {
+		t.Errorf("pruned covisit to %d versions, want 2", store.prunedTo["covisit"])
+	}
+	if eval := store.meta["covisit"].Evaluation; eval == nil || eval.CatalogSize != 1 {
+		t.Errorf("covisit evaluation = %+v, want one over the 1 item catalog", eval)
+	}
+}
+
+func TestTrainingCoordinator_TriggerQueuesOnce(t *testing.T) {
+	t.Parallel()
+
+	c := NewTrainingCoordinator(newCoordinatorTestEngine(t, newMockAlgorithm("covisit")), CoordinatorConfig{}, testLogger())
+
+	if err := c.Trigger(); err != nil {
+		t.Fatalf("first Trigger() error = %v", err)
+	}
+	if err := c.Trigger(); !errors.Is(err, ErrTrainingInProgress) {
+		t.Errorf("second Trigger() error = %v, want ErrTrainingInProgress", err)
+	}
+}
+
+func TestTrainingCoordinator_RunCanceledDuringTraining(t *testing.T) {
+	t.Parallel()
+
+	slow := newMockAlgorithm("slow")
+	slow.trainDelay = 10 * time.Second
+	c := NewTrainingCoordinator(newCoordinatorTestEngine(t, slow), CoordinatorConfig{TrainOnStartup: true}, testLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	deadline := time.Now().Add(time.Second)
+	for !c.Status().Running && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	run := c.Status().LastRun
+	if run == nil || !strings.Contains(run.Error, "interrupted") {
+		t.Errorf("LastRun = %+v, want interrupted run", run)
+	}
+}
+
+func TestTrainingCoordinator_RestoresStoredModels(t *testing.T) {
+	t.Parallel()
+
+	restored := &restorableMockAlgorithm{&persistableMockAlgorithm{newMockAlgorithm("covisit")}}
+	corrupt := &restorableMockAlgorithm{&persistableMockAlgorithm{newMockAlgorithm("ease")}}
+	unsaved := &restorableMockAlgorithm{&persistableMockAlgorithm{newMockAlgorithm("content")}}
+
+	trainedAt := time.Date(2026, 5, 1, 3, 0, 0, 0, time.UTC)
+	store := newFakeModelStore()
+	store.latest["covisit"] = 4
+	store.meta["covisit"] = storage.ModelMetadata{TrainedAt: trainedAt}
+	store.latest["ease"] = 2
+	store.loadErr["ease"] = errors.New("checksum mismatch")
+
+	engine := newCoordinatorTestEngine(t, restored, corrupt, unsaved)
+	c := NewTrainingCoordinator(engine, CoordinatorConfig{}, testLogger())
+	c.SetModelStore(store)
+
+	c.restoreModels(context.Background())
+
+	if !restored.IsTrained() || !restored.LastTrainedAt().Equal(trainedAt) {
+		t.Errorf("covisit trained=%v at %v, want restored model trained at %v", restored.IsTrained(), restored.LastTrainedAt(), trainedAt)
+	}
+	if corrupt.IsTrained() || unsaved.IsTrained() {
+		t.Error("ease or content trained, want only covisit restored")
+	}
+
+	status := engine.Status(context.Background())
+	if status.State != StateReady || status.ModelVersion != 1 {
+		t.Errorf("State = %q, ModelVersion = %d, want ready at version 1", status.State, status.ModelVersion)
+	}
+	if !status.LastTrainedAt.Equal(trainedAt) {
+		t.Errorf("LastTrainedAt = %v, want %v", status.LastTrainedAt, trainedAt)
+	}
+
+	loads := make(map[string]*ModelLoadStatus)
+	for _, alg := range status.Algorithms {
+		loads[alg.Name] = alg.Load
+	}
+	if load := loads["covisit"]; load == nil || !load.Loaded || load.Version != 4 {
+		t.Errorf("covisit load = %+v, want version 4 loaded", load)
+	}
+	if load := loads["ease"]; load == nil || load.Loaded || load.Error != "checksum mismatch" {
+		t.Errorf("ease load = %+v, want failed with its error", load)
+	}
+	if loads["content"] != nil {
+		t.Errorf("content load = %+v, want none without a stored model", loads["content"])
+	}
+	if len(status.Issues) != 1 || !strings.Contains(status.Issues[0], "ease: stored model v2 failed to load") {
+		t.Errorf("Issues = %v, want the ease load failure", status.Issues)
+	}
+}
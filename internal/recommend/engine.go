@@ -7,9 +7,11 @@ package recommend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,6 +23,13 @@ import (
 // to maintain clean separation. The DataProvider interface allows integration
 // with the database package without creating circular imports.
 
+// Errors returned by Engine.SimilarItems.
+var (
+	ErrNoSimilarityModel = errors.New("no item similarity algorithm registered")
+	ErrModelNotTrained   = errors.New("model not trained")
+	ErrItemNotFound      = errors.New("item not found")
+)
+
 // Engine coordinates multiple recommendation algorithms and produces final recommendations.
 // It is safe for concurrent use.
 type Engine struct {
@@ -39,6 +48,10 @@ type Engine struct {
 	modelVersion  int32
 	lastTrainedAt time.Time
 
+	// Outcome of loading each algorithm's stored model, by algorithm name
+	modelLoads map[string]ModelLoadStatus
+	loadMu     sync.RWMutex
+
 	// Metrics
 	metrics      Metrics
 	metricsMu    sync.RWMutex
@@ -106,6 +119,7 @@ func NewEngine(cfg *Config, logger zerolog.Logger) (*Engine, error) {
 		algorithms: make([]Algorithm, 0),
 		rerankers:  make([]Reranker, 0),
 		cache:      make(map[string]cacheEntry),
+		modelLoads: make(map[string]ModelLoadStatus),
 		rng:        rand.New(rand.NewSource(seed)), //nolint:gosec // math/rand is fine for recommendation shuffling
 		metrics: Metrics{
 			AlgorithmMetrics: make(map[string]AlgorithmMetrics),
@@ -294,7 +308,7 @@ func (e *Engine) buildResponseMetadata(req Request, algorithmsUsed []string, sta
 	trainedAt := e.lastTrainedAt
 	e.trainMu.RUnlock()
 
-	return ResponseMetadata{
+	meta := ResponseMetadata{
 		RequestID:      req.RequestID,
 		UserID:         req.UserID,
 		Mode:           req.Mode.String(),
@@ -305,6 +319,11 @@ func (e *Engine) buildResponseMetadata(req Request, algorithmsUsed []string, sta
 		TrainedAt:      trainedAt,
 		Timestamp:      time.Now(),
 	}
+	if v, ok := e.AssignVariant(req.UserID); ok {
+		meta.Experiment = e.config.Experiment.Name
+		meta.Variant = v.Name
+	}
+	return meta
 }
 
 // cacheResponse stores the response in cache if enabled.
@@ -331,6 +350,9 @@ func (e *Engine) getCandidates(ctx context.Context, req Request) ([]int, error)
 	}
 
 	exclude := e.buildExclusionSet(history, req.Exclude)
+	if err := e.excludeFeedbackItems(ctx, req.UserID, exclude); err != nil {
+		return nil, err
+	}
 
 	candidates, err := e.dataProvider.GetCandidates(ctx, req.UserID, e.config.Limits.MaxCandidates)
 	if err != nil {
@@ -355,6 +377,27 @@ func (e *Engine) buildExclusionSet(history []int, requestExclude map[int]struct{
 	return exclude
 }
 
+// excludeFeedbackItems adds items the user marked not interested or watched
+// elsewhere to the exclusion set, when the data provider stores feedback.
+func (e *Engine) excludeFeedbackItems(ctx context.Context, userID int, exclude map[int]struct{}) error {
+	fp, ok := e.dataProvider.(FeedbackProvider)
+	if !ok {
+		return nil
+	}
+
+	feedback, err := fp.GetUserFeedback(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user feedback: %w", err)
+	}
+
+	for _, fb := range feedback {
+		if fb.Signal.ExcludesItem() {
+			exclude[fb.ItemID] = struct{}{}
+		}
+	}
+	return nil
+}
+
 // filterCandidates removes excluded items from candidates.
 func (e *Engine) filterCandidates(candidates []int, exclude map[int]struct{}) []int {
 	filtered := make([]int, 0, len(candidates))
@@ -371,11 +414,15 @@ func (e *Engine) filterCandidates(candidates []int, exclude map[int]struct{}) []
 //nolint:gocritic // hugeParam: req passed by value for immutability
 func (e *Engine) scoreCandidates(ctx context.Context, req Request, candidates []int) ([]ScoredItem, []string, error) {
 	algorithms := e.getAlgorithms()
+	weights := e.WeightsFor(req.UserID).Normalize().ToMap()
+	if req.Mode == ModePopular {
+		algorithms, weights = popularityOnly(algorithms)
+	}
+
 	if len(algorithms) == 0 {
 		return nil, nil, fmt.Errorf("no algorithms registered")
 	}
 
-	weights := e.config.Weights.Normalize().ToMap()
 	results := e.runAlgorithmPredictions(ctx, req, algorithms, candidates)
 	return e.combineAlgorithmScores(results, weights)
 }
@@ -387,6 +434,17 @@ func (e *Engine) getAlgorithms() []Algorithm {
 	return e.algorithms
 }
 
+// popularityOnly restricts scoring to the popularity algorithm at full
+// weight. Used by ModePopular, e.g. for cold-start users with no history.
+func popularityOnly(algorithms []Algorithm) ([]Algorithm, map[string]float64) {
+	for _, alg := range algorithms {
+		if alg.Name() == PopularityAlgorithm {
+			return []Algorithm{alg}, map[string]float64{PopularityAlgorithm: 1}
+		}
+	}
+	return nil, nil
+}
+
 // algResult holds the result of a single algorithm prediction.
 type algResult struct {
 	name   string
@@ -549,7 +607,8 @@ func (e *Engine) Train(ctx context.Context) error {
 
 	// Train all algorithms
 	if err := e.trainAllAlgorithms(trainCtx, interactions, items); err != nil {
-		return err
+		e.trainStatus.LastError = err.Error()
+		return fmt.Errorf("training interrupted: %w", err)
 	}
 
 	// Finalize training
@@ -582,6 +641,7 @@ func (e *Engine) initializeTrainingStatus() {
 	e.trainStatus.IsTraining = true
 	e.trainStatus.Progress = 0
 	e.trainStatus.LastError = ""
+	e.trainStatus.Algorithms = nil
 }
 
 // finalizeTrainingStatus updates the training status after completion.
@@ -598,6 +658,16 @@ func (e *Engine) loadTrainingData(ctx context.Context) ([]Interaction, []Item, e
 		return nil, nil, fmt.Errorf("get interactions: %w", err)
 	}
 
+	if fp, ok := e.dataProvider.(FeedbackProvider); ok {
+		feedback, err := fp.GetFeedback(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get feedback: %w", err)
+		}
+		interactions = ApplyFeedback(interactions, feedback)
+	}
+
+	// Recorded before validation so Status can report a cold start
+	e.trainStatus.InteractionCount = len(interactions)
 	if err := e.validateInteractionCount(interactions); err != nil {
 		return nil, nil, err
 	}
@@ -632,31 +702,84 @@ func (e *Engine) updateTrainingDataStats(interactions []Interaction, items []Ite
 		Msg("loaded training data")
 }
 
-// trainAllAlgorithms trains each registered algorithm.
-// Individual algorithm failures are logged but don't stop training of other algorithms.
-//
-//nolint:unparam // error return kept for future use; individual errors are logged but don't stop training
+// trainAllAlgorithms trains each registered algorithm, up to
+// Training.Parallelism at a time. Individual algorithm failures are logged and
+// recorded in the training status but don't stop training of other
+// algorithms. Returns the context error if training was canceled, in which
+// case algorithms not yet started are skipped.
 func (e *Engine) trainAllAlgorithms(ctx context.Context, interactions []Interaction, items []Item) error {
 	algorithms := e.getAlgorithms()
+	results := make([]AlgorithmTrainingResult, len(algorithms))
+
+	parallelism := e.config.Training.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		statusMu sync.Mutex // Serializes progress updates from concurrent workers
+		started  int
+	)
+	sem := make(chan struct{}, parallelism)
 
 	for i, alg := range algorithms {
-		e.updateAlgorithmProgress(alg.Name(), i, len(algorithms))
-
-		if err := alg.Train(ctx, interactions, items); err != nil {
-			e.logger.Error().
-				Str("algorithm", alg.Name()).
-				Err(err).
-				Msg("algorithm training failed")
-			// Continue with other algorithms
-			continue
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
 		}
 
-		e.logger.Debug().
+		statusMu.Lock()
+		e.updateAlgorithmProgress(alg.Name(), started, len(algorithms))
+		started++
+		statusMu.Unlock()
+
+		wg.Add(1)
+		go func(i int, alg Algorithm) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = e.trainAlgorithm(ctx, alg, interactions, items)
+		}(i, alg)
+	}
+	wg.Wait()
+
+	// Keep results in registration order, omitting algorithms never started
+	e.trainStatus.Algorithms = make([]AlgorithmTrainingResult, 0, started)
+	for _, result := range results {
+		if result.Name != "" {
+			e.trainStatus.Algorithms = append(e.trainStatus.Algorithms, result)
+		}
+	}
+
+	return ctx.Err()
+}
+
+// trainAlgorithm trains a single algorithm and reports how long it took.
+func (e *Engine) trainAlgorithm(ctx context.Context, alg Algorithm, interactions []Interaction, items []Item) AlgorithmTrainingResult {
+	start := time.Now()
+	err := alg.Train(ctx, interactions, items)
+	result := AlgorithmTrainingResult{
+		Name:       alg.Name(),
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		e.logger.Error().
 			Str("algorithm", alg.Name()).
-			Msg("algorithm training complete")
+			Err(err).
+			Msg("algorithm training failed")
+		return result
 	}
 
-	return nil
+	e.logger.Debug().
+		Str("algorithm", alg.Name()).
+		Int64("duration_ms", result.DurationMS).
+		Msg("algorithm training complete")
+	return result
 }
 
 // updateAlgorithmProgress updates the training progress for an algorithm.
@@ -724,11 +847,74 @@ func (e *Engine) UpdateConfig(cfg *Config) error {
 	return nil
 }
 
+// SimilarItems returns the k items most similar to itemID without a user
+// context, from the first registered ItemSimilarityProvider (the
+// content-based algorithm). k defaults to Limits.DefaultK and is capped at
+// Limits.MaxK. Results are cached for Cache.TTL under their own key, so
+// per-user invalidation does not touch them; training clears them with the
+// rest of the cache.
+//
+// Returns ErrNoSimilarityModel when no provider is registered, and the
+// provider's ErrModelNotTrained or ErrItemNotFound.
+func (e *Engine) SimilarItems(ctx context.Context, itemID, k int) ([]ScoredItem, error) {
+	e.requestCount.Add(1)
+
+	if k <= 0 {
+		k = e.config.Limits.DefaultK
+	}
+	if k > e.config.Limits.MaxK {
+		k = e.config.Limits.MaxK
+	}
+
+	key := fmt.Sprintf("sim:%d:%d", itemID, k)
+	if e.config.Cache.Enabled {
+		if resp := e.checkCache(key); resp != nil {
+			e.cacheHits.Add(1)
+			return resp.Items, nil
+		}
+		e.cacheMisses.Add(1)
+	}
+
+	var provider ItemSimilarityProvider
+	for _, alg := range e.getAlgorithms() {
+		if p, ok := alg.(ItemSimilarityProvider); ok {
+			provider = p
+			break
+		}
+	}
+	if provider == nil {
+		return nil, ErrNoSimilarityModel
+	}
+
+	items, err := provider.SimilarItems(ctx, itemID, k)
+	if err != nil {
+		if !errors.Is(err, ErrItemNotFound) {
+			e.errorCount.Add(1)
+		}
+		return nil, err
+	}
+	if items == nil {
+		items = []ScoredItem{}
+	}
+
+	if e.config.Cache.Enabled {
+		e.storeCache(key, e.copyCachedResponse(&Response{Items: items, TotalCandidates: len(items)}))
+	}
+
+	return items, nil
+}
+
 // cacheKey generates a cache key for a request.
 //
 //nolint:gocritic // hugeParam: req passed by value for simplicity
 func (e *Engine) cacheKey(req Request) string {
-	return fmt.Sprintf("rec:%d:%d:%s", req.UserID, req.K, req.Mode.String())
+	// CurrentItemID keeps ModeSimilar requests for different items apart,
+	// and the variant keeps results from before an experiment change apart
+	var variant string
+	if v, ok := e.AssignVariant(req.UserID); ok {
+		variant = e.config.Experiment.Name + "/" + v.Name
+	}
+	return fmt.Sprintf("rec:%d:%d:%s:%d:%s", req.UserID, req.K, req.Mode.String(), req.CurrentItemID, variant)
 }
 
 // checkCache checks if a cached response exists and is valid.
@@ -782,6 +968,44 @@ func (e *Engine) evictIfCacheFull() {
 	}
 }
 
+// RecordFeedback applies a user's feedback to the live model without waiting
+// for retraining. The user's cached recommendations are invalidated and
+// algorithms implementing FeedbackLearner are given the signal's reward.
+// Online updates stop if ctx is canceled; training still picks the feedback
+// up. The caller is responsible for persisting the feedback.
+func (e *Engine) RecordFeedback(ctx context.Context, userID, itemID int, signal FeedbackSignal) {
+	e.clearUserCache(userID)
+
+	for _, alg := range e.getAlgorithms() {
+		if ctx.Err() != nil {
+			break
+		}
+		if learner, ok := alg.(FeedbackLearner); ok && alg.IsTrained() {
+			learner.RecordFeedback(userID, itemID, signal.Reward())
+		}
+	}
+
+	e.logger.Debug().
+		Int("user_id", userID).
+		Int("item_id", itemID).
+		Str("signal", string(signal)).
+		Msg("recorded feedback")
+}
+
+// clearUserCache removes the cached entries for one user.
+func (e *Engine) clearUserCache(userID int) {
+	prefix := fmt.Sprintf("rec:%d:", userID)
+
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	for key := range e.cache {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.cache, key)
+		}
+	}
+}
+
 // clearCache removes all cached entries.
 func (e *Engine) clearCache() {
 	e.cacheMu.Lock()
@@ -656,6 +656,52 @@ func TestEngine_Recommend_SimilarMode(t *testing.T) {
 	}
 }
 
+func TestEngine_Recommend_PopularMode(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(nil, testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	personal := newMockAlgorithm("ease")
+	personal.trained = true
+	personal.predictScores = map[int]float64{2: 0.9}
+	engine.RegisterAlgorithm(personal)
+
+	popular := newMockAlgorithm(PopularityAlgorithm)
+	popular.trained = true
+	popular.predictScores = map[int]float64{3: 0.6, 4: 0.4}
+	engine.RegisterAlgorithm(popular)
+
+	engine.SetDataProvider(&mockDataProvider{
+		candidates: map[int][]int{1: {2, 3, 4}},
+	})
+
+	resp, err := engine.Recommend(context.Background(), Request{UserID: 1, K: 3, Mode: ModePopular})
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+
+	if len(resp.Metadata.AlgorithmsUsed) != 1 || resp.Metadata.AlgorithmsUsed[0] != PopularityAlgorithm {
+		t.Errorf("AlgorithmsUsed = %v, want [popularity]", resp.Metadata.AlgorithmsUsed)
+	}
+	if len(resp.Items) != 2 || resp.Items[0].Item.ID != 3 {
+		t.Fatalf("Items = %+v, want popularity ranking [3 4]", resp.Items)
+	}
+	if resp.Items[0].Score != 0.6 {
+		t.Errorf("Score = %v, want unweighted popularity score 0.6", resp.Items[0].Score)
+	}
+
+	// Without a popularity algorithm the mode cannot be served
+	engine2, _ := NewEngine(nil, testLogger())
+	engine2.RegisterAlgorithm(personal)
+	engine2.SetDataProvider(&mockDataProvider{candidates: map[int][]int{1: {2}}})
+	if _, err := engine2.Recommend(context.Background(), Request{UserID: 1, K: 3, Mode: ModePopular}); err == nil {
+		t.Error("Recommend() error = nil, want error when popularity is not registered")
+	}
+}
+
 func TestEngine_Recommend_Rerankers(t *testing.T) {
 	t.Parallel()
 
@@ -1017,6 +1063,47 @@ func TestEngine_Train_ContextCancellation(t *testing.T) {
 	_ = err
 }
 
+func TestEngine_Train_Parallelism(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Training.MinInteractions = 1
+	cfg.Training.Parallelism = 3
+	engine, err := NewEngine(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	names := []string{"ease", "als", "covisit"}
+	for _, name := range names {
+		alg := newMockAlgorithm(name)
+		alg.trainDelay = 200 * time.Millisecond
+		engine.RegisterAlgorithm(alg)
+	}
+	engine.SetDataProvider(&mockDataProvider{
+		interactions: []Interaction{{UserID: 1, ItemID: 1}},
+		items:        []Item{{ID: 1}},
+	})
+
+	start := time.Now()
+	if err := engine.Train(context.Background()); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 500*time.Millisecond {
+		t.Errorf("Train() took %v, want algorithms trained concurrently", elapsed)
+	}
+
+	results := engine.GetStatus().Algorithms
+	if len(results) != len(names) {
+		t.Fatalf("Algorithms = %+v, want %d results", results, len(names))
+	}
+	for i, name := range names {
+		if results[i].Name != name || results[i].DurationMS < 150 {
+			t.Errorf("Algorithms[%d] = %+v, want %s with its duration", i, results[i], name)
+		}
+	}
+}
+
 // --- Test: GetStatus ---
 
 func TestEngine_GetStatus(t *testing.T) {
@@ -1461,12 +1548,93 @@ func TestEngine_cacheKey(t *testing.T) {
 	key2 := engine.cacheKey(Request{UserID: 2, K: 10, Mode: ModePersonalized})
 	key3 := engine.cacheKey(Request{UserID: 1, K: 20, Mode: ModePersonalized})
 	key4 := engine.cacheKey(Request{UserID: 1, K: 10, Mode: ModeSimilar})
+	key5 := engine.cacheKey(Request{UserID: 1, K: 10, Mode: ModeSimilar, CurrentItemID: 7})
 
-	if key1 == key2 || key1 == key3 || key1 == key4 {
+	if key1 == key2 || key1 == key3 || key1 == key4 || key4 == key5 {
 		t.Error("Different requests should produce different cache keys")
 	}
 }
 
+// mockSimilarityAlgorithm adds ItemSimilarityProvider to mockAlgorithm.
+type mockSimilarityAlgorithm struct {
+	*mockAlgorithm
+	neighbors map[int][]ScoredItem
+	calls     atomic.Int32
+}
+
+func (m *mockSimilarityAlgorithm) SimilarItems(_ context.Context, itemID, k int) ([]ScoredItem, error) {
+	m.calls.Add(1)
+	if !m.IsTrained() {
+		return nil, ErrModelNotTrained
+	}
+	items, ok := m.neighbors[itemID]
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+	return items[:min(k, len(items))], nil
+}
+
+func TestEngine_SimilarItems(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(nil, testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	if _, err := engine.SimilarItems(context.Background(), 1, 5); !errors.Is(err, ErrNoSimilarityModel) {
+		t.Fatalf("SimilarItems() without provider error = %v, want ErrNoSimilarityModel", err)
+	}
+
+	alg := &mockSimilarityAlgorithm{
+		mockAlgorithm: newMockAlgorithm("content"),
+		neighbors: map[int][]ScoredItem{
+			1: {{Item: Item{ID: 2}, Score: 0.9}, {Item: Item{ID: 3}, Score: 0.5}, {Item: Item{ID: 4}, Score: 0.1}},
+		},
+	}
+	engine.RegisterAlgorithm(newMockAlgorithm("covisit"))
+	engine.RegisterAlgorithm(alg)
+
+	if _, err := engine.SimilarItems(context.Background(), 1, 5); !errors.Is(err, ErrModelNotTrained) {
+		t.Fatalf("SimilarItems() before training error = %v, want ErrModelNotTrained", err)
+	}
+	alg.trained = true
+
+	items, err := engine.SimilarItems(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("SimilarItems() error = %v", err)
+	}
+	if len(items) != 2 || items[0].Item.ID != 2 || items[1].Item.ID != 3 {
+		t.Errorf("SimilarItems() = %+v, want items 2 and 3", items)
+	}
+
+	// A second call is served from the cache, as a copy
+	items[0].Score = 0
+	cached, err := engine.SimilarItems(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("SimilarItems() cached error = %v", err)
+	}
+	if calls := alg.calls.Load(); calls != 2 {
+		t.Errorf("provider calls = %d, want 2 (one before training, one miss)", calls)
+	}
+	if cached[0].Score != 0.9 {
+		t.Errorf("cached score = %v, want 0.9 (unaffected by the caller)", cached[0].Score)
+	}
+
+	// Per-user invalidation leaves item neighbors alone; training clears them
+	engine.RecordFeedback(context.Background(), 0, 2, FeedbackLike)
+	if _, err := engine.SimilarItems(context.Background(), 1, 2); err != nil || alg.calls.Load() != 2 {
+		t.Errorf("SimilarItems() after feedback: err = %v, provider calls = %d, want cached", err, alg.calls.Load())
+	}
+	engine.clearCache()
+	if _, err := engine.SimilarItems(context.Background(), 1, 2); err != nil || alg.calls.Load() != 3 {
+		t.Errorf("SimilarItems() after clearing: err = %v, provider calls = %d, want 3", err, alg.calls.Load())
+	}
+
+	if _, err := engine.SimilarItems(context.Background(), 99, 2); !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("SimilarItems(unknown) error = %v, want ErrItemNotFound", err)
+	}
+}
+
 // --- Helper functions ---
 
 func containsString(s, substr string) bool {
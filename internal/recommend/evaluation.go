@@ -0,0 +1,246 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/recommend/storage"
+)
+
+// evaluationSampleUsers is the maximum number of users whose recommendations
+// are generated for an evaluation.
+const evaluationSampleUsers = 500
+
+// ErrAlgorithmNotFound is returned by Engine.Evaluate for an algorithm that
+// is not registered.
+var ErrAlgorithmNotFound = errors.New("algorithm not registered")
+
+// Evaluation contains offline quality metrics for a trained algorithm. It is
+// stored with the model metadata when the model is persisted.
+type Evaluation = storage.Evaluation
+
+// Evaluate computes catalog coverage, intra-list genre diversity and
+// popularity concentration of the algorithm's top-K recommendations
+// (Limits.DefaultK) for a deterministic sample of users. Each user's
+// candidates are the catalog items they have not interacted with.
+//
+// Returns ErrAlgorithmNotFound for an unknown algorithm and
+// ErrModelNotTrained before the algorithm has been trained.
+func (e *Engine) Evaluate(ctx context.Context, algorithm string) (*Evaluation, error) {
+	var alg Algorithm
+	for _, a := range e.getAlgorithms() {
+		if a.Name() == algorithm {
+			alg = a
+			break
+		}
+	}
+	if alg == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAlgorithmNotFound, algorithm)
+	}
+
+	interactions, items, err := e.evaluationData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return e.evaluate(ctx, alg, interactions, items)
+}
+
+// evaluationData loads the interactions and catalog an evaluation runs on.
+func (e *Engine) evaluationData(ctx context.Context) ([]Interaction, []Item, error) {
+	if e.dataProvider == nil {
+		return nil, nil, fmt.Errorf("data provider not set")
+	}
+
+	interactions, err := e.dataProvider.GetInteractions(ctx, time.Time{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("get interactions: %w", err)
+	}
+	items, err := e.dataProvider.GetItems(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get items: %w", err)
+	}
+	return interactions, items, nil
+}
+
+// evaluate generates top-K lists for sampled users and computes the metrics.
+func (e *Engine) evaluate(ctx context.Context, alg Algorithm, interactions []Interaction, items []Item) (*Evaluation, error) {
+	if !alg.IsTrained() {
+		return nil, ErrModelNotTrained
+	}
+
+	k := e.config.Limits.DefaultK
+	history := make(map[int]map[int]struct{})
+	for _, in := range interactions {
+		if history[in.UserID] == nil {
+			history[in.UserID] = make(map[int]struct{})
+		}
+		history[in.UserID][in.ItemID] = struct{}{}
+	}
+
+	genres := make(map[int]map[string]struct{}, len(items))
+	for _, item := range items {
+		set := make(map[string]struct{}, len(item.Genres))
+		for _, g := range item.Genres {
+			set[g] = struct{}{}
+		}
+		genres[item.ID] = set
+	}
+
+	eval := &Evaluation{K: k, CatalogSize: len(items), EvaluatedAt: time.Now()}
+	recommended := make(map[int]int)
+	var diversitySum float64
+	var diversityLists int
+
+	for _, userID := range e.sampleUsers(history) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		seen := history[userID]
+		candidates := make([]int, 0, len(items))
+		for _, item := range items {
+			if _, ok := seen[item.ID]; !ok {
+				candidates = append(candidates, item.ID)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		predictCtx, cancel := context.WithTimeout(ctx, e.config.Limits.PredictionTimeout)
+		scores, err := alg.Predict(predictCtx, userID, candidates)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("predict for user %d: %w", userID, err)
+		}
+
+		list := topK(scores, k)
+		if len(list) == 0 {
+			continue
+		}
+		eval.UsersEvaluated++
+		for _, id := range list {
+			recommended[id]++
+		}
+		if d, ok := intraListDiversity(list, genres); ok {
+			diversitySum += d
+			diversityLists++
+		}
+	}
+
+	if eval.CatalogSize > 0 {
+		eval.CoverageAtK = float64(len(recommended)) / float64(eval.CatalogSize)
+	}
+	if diversityLists > 0 {
+		eval.IntraListDiversity = diversitySum / float64(diversityLists)
+	}
+	counts := make([]int, 0, len(items))
+	for _, item := range items {
+		counts = append(counts, recommended[item.ID])
+	}
+	eval.PopularityGini = gini(counts)
+
+	return eval, nil
+}
+
+// sampleUsers returns up to evaluationSampleUsers user IDs, chosen with the
+// configured seed so repeated evaluations of a model sample the same users.
+func (e *Engine) sampleUsers(history map[int]map[int]struct{}) []int {
+	users := make([]int, 0, len(history))
+	for id := range history {
+		users = append(users, id)
+	}
+	sort.Ints(users)
+	if len(users) <= evaluationSampleUsers {
+		return users
+	}
+
+	seed := e.config.Seed
+	if seed == 0 {
+		seed = 42
+	}
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // math/rand is fine for sampling
+	rng.Shuffle(len(users), func(i, j int) { users[i], users[j] = users[j], users[i] })
+	return users[:evaluationSampleUsers]
+}
+
+// topK returns the IDs of the k highest scored items, ties broken by ID.
+func topK(scores map[int]float64, k int) []int {
+	ids := make([]int, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > k {
+		ids = ids[:k]
+	}
+	return ids
+}
+
+// intraListDiversity returns the mean Jaccard genre distance over pairs of
+// items in the list. Items without genres are left out; ok is false when
+// fewer than two items have genres.
+func intraListDiversity(list []int, genres map[int]map[string]struct{}) (float64, bool) {
+	var sum float64
+	pairs := 0
+	for i := 0; i < len(list); i++ {
+		a := genres[list[i]]
+		if len(a) == 0 {
+			continue
+		}
+		for j := i + 1; j < len(list); j++ {
+			b := genres[list[j]]
+			if len(b) == 0 {
+				continue
+			}
+			shared := 0
+			for g := range a {
+				if _, ok := b[g]; ok {
+					shared++
+				}
+			}
+			union := len(a) + len(b) - shared
+			sum += 1 - float64(shared)/float64(union)
+			pairs++
+		}
+	}
+	if pairs == 0 {
+		return 0, false
+	}
+	return sum / float64(pairs), true
+}
+
+// gini returns the Gini coefficient of the counts: 0 for a uniform
+// distribution, approaching 1 as the total concentrates on one entry.
+func gini(counts []int) float64 {
+	n := len(counts)
+	if n == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), counts...)
+	sort.Ints(sorted)
+
+	var total, weighted float64
+	for i, c := range sorted {
+		total += float64(c)
+		weighted += float64(i+1) * float64(c)
+	}
+	if total == 0 {
+		return 0
+	}
+	return (2*weighted)/(float64(n)*total) - float64(n+1)/float64(n)
+}
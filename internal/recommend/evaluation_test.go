@@ -0,0 +1,164 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func newEvaluationTestEngine(t *testing.T, alg Algorithm) *Engine {
+	t.Helper()
+
+	cfg := DefaultConfig()
+	cfg.Limits.DefaultK = 2
+	engine, err := NewEngine(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.RegisterAlgorithm(alg)
+	engine.SetDataProvider(&mockDataProvider{
+		interactions: []Interaction{
+			{UserID: 1, ItemID: 1, Confidence: 1.0},
+			{UserID: 2, ItemID: 1, Confidence: 1.0},
+		},
+		items: []Item{
+			{ID: 1, Genres: []string{"Drama"}},
+			{ID: 2, Genres: []string{"Drama"}},
+			{ID: 3, Genres: []string{"Drama", "Comedy"}},
+			{ID: 4, Genres: []string{"Horror"}},
+		},
+	})
+	return engine
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	alg := newMockAlgorithm("ease")
+	alg.trained = true
+	alg.predictScores = map[int]float64{2: 0.9, 3: 0.8, 4: 0.1}
+	engine := newEvaluationTestEngine(t, alg)
+
+	eval, err := engine.Evaluate(context.Background(), "ease")
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	// Both users get [2, 3]: half the catalog, one pair sharing one of two
+	// genres, and two items taking every slot
+	if eval.K != 2 || eval.UsersEvaluated != 2 || eval.CatalogSize != 4 {
+		t.Errorf("evaluation = %+v, want k 2 over 2 users and 4 items", eval)
+	}
+	if eval.CoverageAtK != 0.5 {
+		t.Errorf("CoverageAtK = %v, want 0.5", eval.CoverageAtK)
+	}
+	if eval.IntraListDiversity != 0.5 {
+		t.Errorf("IntraListDiversity = %v, want 0.5", eval.IntraListDiversity)
+	}
+	if eval.PopularityGini != 0.5 {
+		t.Errorf("PopularityGini = %v, want 0.5", eval.PopularityGini)
+	}
+	if eval.EvaluatedAt.IsZero() {
+		t.Error("EvaluatedAt not set")
+	}
+}
+
+func TestEngine_Evaluate_Errors(t *testing.T) {
+	untrained := newMockAlgorithm("ease")
+	engine := newEvaluationTestEngine(t, untrained)
+
+	if _, err := engine.Evaluate(context.Background(), "als"); !errors.Is(err, ErrAlgorithmNotFound) {
+		t.Errorf("Evaluate(unknown) error = %v, want ErrAlgorithmNotFound", err)
+	}
+	if _, err := engine.Evaluate(context.Background(), "ease"); !errors.Is(err, ErrModelNotTrained) {
+		t.Errorf("Evaluate(untrained) error = %v, want ErrModelNotTrained", err)
+	}
+
+	trained := newMockAlgorithm("ease")
+	trained.trained = true
+	trained.predictErr = errors.New("model corrupted")
+	engine = newEvaluationTestEngine(t, trained)
+	if _, err := engine.Evaluate(context.Background(), "ease"); err == nil {
+		t.Error("expected error when prediction fails")
+	}
+
+	engine.SetDataProvider(&mockDataProvider{itemsErr: errors.New("db down")})
+	if _, err := engine.Evaluate(context.Background(), "ease"); err == nil {
+		t.Error("expected error when items cannot be loaded")
+	}
+}
+
+func TestEngine_sampleUsers(t *testing.T) {
+	engine := newEvaluationTestEngine(t, newMockAlgorithm("ease"))
+
+	history := make(map[int]map[int]struct{})
+	for id := 1; id <= evaluationSampleUsers+100; id++ {
+		history[id] = map[int]struct{}{1: {}}
+	}
+
+	first := engine.sampleUsers(history)
+	if len(first) != evaluationSampleUsers {
+		t.Fatalf("sampled %d users, want %d", len(first), evaluationSampleUsers)
+	}
+	if again := engine.sampleUsers(history); !reflect.DeepEqual(first, again) {
+		t.Error("sampling is not deterministic")
+	}
+}
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"nothing recommended", []int{0, 0, 0}, 0},
+		{"uniform", []int{3, 3, 3, 3}, 0},
+		{"one item", []int{0, 0, 0, 8}, 0.75},
+		{"half", []int{2, 0, 2, 0}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gini(tt.counts); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("gini(%v) = %v, want %v", tt.counts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntraListDiversity(t *testing.T) {
+	genres := map[int]map[string]struct{}{
+		1: {"Drama": {}},
+		2: {"Drama": {}},
+		3: {"Horror": {}},
+		4: {},
+	}
+
+	tests := []struct {
+		name   string
+		list   []int
+		want   float64
+		wantOK bool
+	}{
+		{"same genres", []int{1, 2}, 0, true},
+		{"disjoint genres", []int{1, 3}, 1, true},
+		{"mixed", []int{1, 2, 3}, 2.0 / 3.0, true},
+		{"items without genres skipped", []int{1, 3, 4}, 1, true},
+		{"single item", []int{1}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := intraListDiversity(tt.list, genres)
+			if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("intraListDiversity(%v) = %v, %v; want %v, %v", tt.list, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
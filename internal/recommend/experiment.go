@@ -0,0 +1,221 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+// ExperimentConfig defines an A/B experiment over the ensemble. Each user is
+// bucketed into one variant for the experiment's lifetime, and the variant's
+// algorithm weights replace Config.Weights for that user's recommendations.
+//
+// Variants blend the live trained model; they do not load older snapshots
+// from the model store. Renaming the experiment or changing Config.Seed
+// reshuffles assignments.
+type ExperimentConfig struct {
+	// Name identifies the experiment in outcome reports. Empty disables
+	// the experiment.
+	Name string `json:"name"`
+
+	// Variants are the arms of the experiment.
+	Variants []Variant `json:"variants"`
+}
+
+// Variant is one arm of an experiment.
+type Variant struct {
+	// Name identifies the variant (e.g., "control", "ease_heavy").
+	Name string `json:"name"`
+
+	// Traffic is the variant's relative share of users. Shares are
+	// normalized, so 1/1 and 50/50 both split users evenly.
+	Traffic int `json:"traffic"`
+
+	// Weights overrides Config.Weights for users in this variant. Nil keeps
+	// Config.Weights, which makes the variant a control group.
+	Weights *AlgorithmWeights `json:"weights,omitempty"`
+}
+
+// Enabled reports whether the experiment assigns users to variants.
+func (c *ExperimentConfig) Enabled() bool {
+	return c.Name != "" && len(c.Variants) > 0
+}
+
+// validate checks that variants are named uniquely and receive traffic.
+func (c *ExperimentConfig) validate() error {
+	if len(c.Variants) == 0 {
+		return nil
+	}
+	if c.Name == "" {
+		return fmt.Errorf("experiment.name is required when variants are set")
+	}
+
+	seen := make(map[string]bool, len(c.Variants))
+	total := 0
+	for _, v := range c.Variants {
+		if v.Name == "" {
+			return fmt.Errorf("experiment.variants: name is required")
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("experiment.variants: duplicate name %q", v.Name)
+		}
+		seen[v.Name] = true
+		if v.Traffic < 0 {
+			return fmt.Errorf("experiment.variants: traffic for %q must be non-negative, got %d", v.Name, v.Traffic)
+		}
+		total += v.Traffic
+	}
+	if total == 0 {
+		return fmt.Errorf("experiment.variants: at least one variant needs traffic")
+	}
+	return nil
+}
+
+// clone returns a deep copy of the experiment.
+func (c *ExperimentConfig) clone() ExperimentConfig {
+	variants := make([]Variant, len(c.Variants))
+	for i, v := range c.Variants {
+		if v.Weights != nil {
+			w := *v.Weights
+			v.Weights = &w
+		}
+		variants[i] = v
+	}
+	return ExperimentConfig{Name: c.Name, Variants: variants}
+}
+
+// assign buckets a user into a variant by hashing the seed, experiment name
+// and user ID, so a user sees the same variant on every request and server.
+func (c *ExperimentConfig) assign(seed int64, userID int) (Variant, bool) {
+	if !c.Enabled() || userID <= 0 {
+		return Variant{}, false
+	}
+
+	total := 0
+	for _, v := range c.Variants {
+		total += v.Traffic
+	}
+	if total <= 0 {
+		return Variant{}, false
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(strconv.FormatInt(seed, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(userID)))
+	bucket := int(h.Sum64() % uint64(total))
+
+	for _, v := range c.Variants {
+		if bucket < v.Traffic {
+			return v, true
+		}
+		bucket -= v.Traffic
+	}
+	return Variant{}, false
+}
+
+// AssignVariant returns the experiment variant of a user. It returns false
+// when no experiment is configured, or for requests without a user.
+func (e *Engine) AssignVariant(userID int) (Variant, bool) {
+	cfg := e.config
+	return cfg.Experiment.assign(cfg.Seed, userID)
+}
+
+// WeightsFor returns the algorithm weights used for a user: their variant's
+// weights, or Config.Weights outside an experiment.
+func (e *Engine) WeightsFor(userID int) AlgorithmWeights {
+	if v, ok := e.AssignVariant(userID); ok && v.Weights != nil {
+		return *v.Weights
+	}
+	return e.config.Weights
+}
+
+// Exposure records that a recommended item was shown to a user while they
+// were in an experiment variant. Clicks and watches of the item afterwards
+// are attributed to the variant.
+type Exposure struct {
+	Experiment   string    `json:"experiment"`
+	Variant      string    `json:"variant"`
+	UserID       int       `json:"user_id"`
+	ItemID       int       `json:"item_id"`
+	Position     int       `json:"position"`
+	ModelVersion int       `json:"model_version"`
+	ShownAt      time.Time `json:"shown_at"`
+}
+
+// NewExposures returns the exposures for items shown from a response, in
+// display order. It returns nil for responses outside an experiment.
+//
+//nolint:gocritic // hugeParam: meta passed by value for immutability
+func NewExposures(meta ResponseMetadata, itemIDs []int, shownAt time.Time) []Exposure {
+	if meta.Experiment == "" || meta.Variant == "" || len(itemIDs) == 0 {
+		return nil
+	}
+	exposures := make([]Exposure, len(itemIDs))
+	for i, id := range itemIDs {
+		exposures[i] = Exposure{
+			Experiment:   meta.Experiment,
+			Variant:      meta.Variant,
+			UserID:       meta.UserID,
+			ItemID:       id,
+			Position:     i + 1,
+			ModelVersion: meta.ModelVersion,
+			ShownAt:      shownAt,
+		}
+	}
+	return exposures
+}
+
+// VariantOutcome aggregates the outcomes of one experiment variant.
+type VariantOutcome struct {
+	// Variant is the variant name.
+	Variant string `json:"variant"`
+
+	// Users is the number of users shown recommendations.
+	Users int `json:"users"`
+
+	// Impressions is the number of distinct user-item pairs shown.
+	Impressions int `json:"impressions"`
+
+	// Clicks is the number of impressions the user clicked.
+	Clicks int `json:"clicks"`
+
+	// Watches is the number of impressions the user played afterwards.
+	Watches int `json:"watches"`
+
+	// Completions is the number of watches played to at least 90%.
+	Completions int `json:"completions"`
+
+	// CTR is Clicks / Impressions.
+	CTR float64 `json:"ctr"`
+
+	// WatchRate is Watches / Impressions.
+	WatchRate float64 `json:"watch_rate"`
+
+	// WatchThroughRate is Completions / Watches.
+	WatchThroughRate float64 `json:"watch_through_rate"`
+}
+
+// ComputeRates fills in the rates from the counts. Rates with a zero
+// denominator are zero.
+func (o *VariantOutcome) ComputeRates() {
+	o.CTR = ratio(o.Clicks, o.Impressions)
+	o.WatchRate = ratio(o.Watches, o.Impressions)
+	o.WatchThroughRate = ratio(o.Completions, o.Watches)
+}
+
+// ratio returns n / d, or 0 when d is zero.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
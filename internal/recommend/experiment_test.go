@@ -0,0 +1,251 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testExperiment splits users evenly between the default weights and a
+// profile that only trusts co-visitation.
+func testExperiment() ExperimentConfig {
+	return ExperimentConfig{
+		Name: "covisit-only",
+		Variants: []Variant{
+			{Name: "control", Traffic: 1},
+			{Name: "covisit", Traffic: 1, Weights: &AlgorithmWeights{CoVisit: 1}},
+		},
+	}
+}
+
+func TestExperimentConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		exp     ExperimentConfig
+		wantErr string
+	}{
+		{name: "disabled", exp: ExperimentConfig{}},
+		{name: "valid", exp: testExperiment()},
+		{name: "missing name", exp: ExperimentConfig{Variants: []Variant{{Name: "a", Traffic: 1}}}, wantErr: "name is required"},
+		{name: "unnamed variant", exp: ExperimentConfig{Name: "x", Variants: []Variant{{Traffic: 1}}}, wantErr: "name is required"},
+		{name: "duplicate variant", exp: ExperimentConfig{Name: "x", Variants: []Variant{{Name: "a", Traffic: 1}, {Name: "a", Traffic: 1}}}, wantErr: "duplicate"},
+		{name: "negative traffic", exp: ExperimentConfig{Name: "x", Variants: []Variant{{Name: "a", Traffic: -1}, {Name: "b", Traffic: 2}}}, wantErr: "non-negative"},
+		{name: "no traffic", exp: ExperimentConfig{Name: "x", Variants: []Variant{{Name: "a"}}}, wantErr: "needs traffic"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Experiment = tt.exp
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Clone_Experiment(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Experiment = testExperiment()
+
+	clone := cfg.Clone()
+	clone.Experiment.Variants[1].Weights.CoVisit = 0.5
+	clone.Experiment.Variants[0].Name = "changed"
+
+	if cfg.Experiment.Variants[1].Weights.CoVisit != 1 || cfg.Experiment.Variants[0].Name != "control" {
+		t.Errorf("Clone() shares variants with the original: %+v", cfg.Experiment.Variants)
+	}
+}
+
+func TestExperimentConfig_Assign(t *testing.T) {
+	exp := testExperiment()
+
+	t.Run("deterministic", func(t *testing.T) {
+		for userID := 1; userID <= 100; userID++ {
+			first, ok := exp.assign(42, userID)
+			if !ok {
+				t.Fatalf("assign(%d) not assigned", userID)
+			}
+			if again, _ := exp.assign(42, userID); again.Name != first.Name {
+				t.Fatalf("assign(%d) = %q then %q", userID, first.Name, again.Name)
+			}
+		}
+	})
+
+	t.Run("follows traffic shares", func(t *testing.T) {
+		weighted := ExperimentConfig{Name: "split", Variants: []Variant{
+			{Name: "a", Traffic: 3},
+			{Name: "b", Traffic: 1},
+			{Name: "off", Traffic: 0},
+		}}
+		counts := make(map[string]int)
+		const users = 10000
+		for userID := 1; userID <= users; userID++ {
+			v, _ := weighted.assign(42, userID)
+			counts[v.Name]++
+		}
+		if share := float64(counts["a"]) / users; math.Abs(share-0.75) > 0.03 {
+			t.Errorf("share of a = %.3f, want ~0.75 (counts %v)", share, counts)
+		}
+		if counts["off"] != 0 {
+			t.Errorf("variant without traffic got %d users", counts["off"])
+		}
+	})
+
+	t.Run("seed reshuffles", func(t *testing.T) {
+		moved := 0
+		for userID := 1; userID <= 100; userID++ {
+			a, _ := exp.assign(1, userID)
+			b, _ := exp.assign(2, userID)
+			if a.Name != b.Name {
+				moved++
+			}
+		}
+		if moved == 0 {
+			t.Error("changing the seed should move some users")
+		}
+	})
+
+	t.Run("no experiment or user", func(t *testing.T) {
+		if _, ok := (&ExperimentConfig{}).assign(42, 1); ok {
+			t.Error("disabled experiment should not assign")
+		}
+		if _, ok := exp.assign(42, 0); ok {
+			t.Error("anonymous request should not be assigned")
+		}
+	})
+}
+
+// usersByVariant returns one user in each variant of the engine's experiment.
+func usersByVariant(t *testing.T, engine *Engine) map[string]int {
+	t.Helper()
+
+	users := make(map[string]int)
+	for userID := 1; userID <= 100; userID++ {
+		if v, ok := engine.AssignVariant(userID); ok {
+			if _, seen := users[v.Name]; !seen {
+				users[v.Name] = userID
+			}
+		}
+	}
+	if len(users) != 2 {
+		t.Fatalf("users by variant = %v, want both variants", users)
+	}
+	return users
+}
+
+func TestEngine_Recommend_ExperimentVariants(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Experiment = testExperiment()
+	engine, err := NewEngine(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	ease := newMockAlgorithm("ease")
+	ease.trained = true
+	ease.predictScores = map[int]float64{1: 1.0, 2: 0.1}
+	covisit := newMockAlgorithm("covisit")
+	covisit.trained = true
+	covisit.predictScores = map[int]float64{1: 0.1, 2: 1.0}
+	engine.RegisterAlgorithm(ease)
+	engine.RegisterAlgorithm(covisit)
+
+	users := usersByVariant(t, engine)
+	candidates := make(map[int][]int)
+	for _, userID := range users {
+		candidates[userID] = []int{1, 2}
+	}
+	engine.SetDataProvider(&mockDataProvider{candidates: candidates})
+
+	tests := []struct {
+		variant string
+		wantTop int
+	}{
+		{variant: "control", wantTop: 1},
+		{variant: "covisit", wantTop: 2},
+	}
+	for _, tt := range tests {
+		userID := users[tt.variant]
+		resp, err := engine.Recommend(context.Background(), Request{UserID: userID, K: 2})
+		if err != nil {
+			t.Fatalf("Recommend(%d) error = %v", userID, err)
+		}
+		if resp.Metadata.Experiment != "covisit-only" || resp.Metadata.Variant != tt.variant {
+			t.Errorf("user %d metadata = %q/%q, want covisit-only/%s",
+				userID, resp.Metadata.Experiment, resp.Metadata.Variant, tt.variant)
+		}
+		if len(resp.Items) == 0 || resp.Items[0].Item.ID != tt.wantTop {
+			t.Errorf("variant %s items = %+v, want item %d first", tt.variant, resp.Items, tt.wantTop)
+		}
+	}
+
+	// Ending the experiment must not serve rankings cached under a variant
+	userID := users["covisit"]
+	noExperiment := engine.GetConfig()
+	noExperiment.Experiment = ExperimentConfig{}
+	if err := engine.UpdateConfig(noExperiment); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	resp, err := engine.Recommend(context.Background(), Request{UserID: userID, K: 2})
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+	if resp.Metadata.CacheHit || resp.Metadata.Variant != "" || resp.Items[0].Item.ID != 1 {
+		t.Errorf("after experiment: cache_hit=%v variant=%q items=%+v, want fresh default ranking",
+			resp.Metadata.CacheHit, resp.Metadata.Variant, resp.Items)
+	}
+}
+
+func TestNewExposures(t *testing.T) {
+	shownAt := time.Date(2026, 10, 1, 20, 0, 0, 0, time.UTC)
+
+	if got := NewExposures(ResponseMetadata{UserID: 1}, []int{10}, shownAt); got != nil {
+		t.Errorf("NewExposures() outside an experiment = %+v, want nil", got)
+	}
+
+	meta := ResponseMetadata{UserID: 7, ModelVersion: 3, Experiment: "exp", Variant: "b"}
+	got := NewExposures(meta, []int{30, 10}, shownAt)
+	want := []Exposure{
+		{Experiment: "exp", Variant: "b", UserID: 7, ItemID: 30, Position: 1, ModelVersion: 3, ShownAt: shownAt},
+		{Experiment: "exp", Variant: "b", UserID: 7, ItemID: 10, Position: 2, ModelVersion: 3, ShownAt: shownAt},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("exposure %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestVariantOutcome_ComputeRates(t *testing.T) {
+	o := VariantOutcome{Impressions: 200, Clicks: 20, Watches: 10, Completions: 4}
+	o.ComputeRates()
+	if o.CTR != 0.1 || o.WatchRate != 0.05 || o.WatchThroughRate != 0.4 {
+		t.Errorf("rates = %v/%v/%v, want 0.1/0.05/0.4", o.CTR, o.WatchRate, o.WatchThroughRate)
+	}
+
+	empty := VariantOutcome{}
+	empty.ComputeRates()
+	if empty.CTR != 0 || empty.WatchRate != 0 || empty.WatchThroughRate != 0 {
+		t.Errorf("empty rates = %+v, want zeros", empty)
+	}
+}
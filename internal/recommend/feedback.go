@@ -0,0 +1,188 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FeedbackSignal is explicit user feedback on a recommended item.
+type FeedbackSignal string
+
+const (
+	// FeedbackLike is a thumbs up. It boosts the item's training confidence.
+	FeedbackLike FeedbackSignal = "like"
+	// FeedbackDislike is a thumbs down. The item becomes a zero-confidence
+	// (rejected) interaction in training.
+	FeedbackDislike FeedbackSignal = "dislike"
+	// FeedbackNotInterested is like FeedbackDislike, and the item is also
+	// never recommended to the user again.
+	FeedbackNotInterested FeedbackSignal = "not_interested"
+	// FeedbackWatchedElsewhere marks an item as seen outside the media
+	// server. It counts as a completed watch and is not recommended again.
+	FeedbackWatchedElsewhere FeedbackSignal = "watched_elsewhere"
+)
+
+// LikeBoost multiplies the confidence of liked items.
+const LikeBoost = 1.5
+
+// ParseFeedbackSignal validates a feedback signal name.
+func ParseFeedbackSignal(s string) (FeedbackSignal, error) {
+	switch signal := FeedbackSignal(s); signal {
+	case FeedbackLike, FeedbackDislike, FeedbackNotInterested, FeedbackWatchedElsewhere:
+		return signal, nil
+	default:
+		return "", fmt.Errorf("invalid feedback signal %q: must be one of like, dislike, not_interested, watched_elsewhere", s)
+	}
+}
+
+// Confidence returns the training confidence for an item with this signal
+// and no playback.
+func (s FeedbackSignal) Confidence() float64 {
+	completed := ComputeConfidence(100, 0)
+	switch s {
+	case FeedbackLike:
+		return completed * LikeBoost
+	case FeedbackWatchedElsewhere:
+		return completed
+	default:
+		return 0
+	}
+}
+
+// Reward returns the reward given to online learners. Negative signals are
+// penalized by the confidence of a completed watch, so a dislike pushes the
+// item's score down instead of only adding an uninformative observation.
+func (s FeedbackSignal) Reward() float64 {
+	if s.IsNegative() {
+		return -ComputeConfidence(100, 0)
+	}
+	return s.Confidence()
+}
+
+// IsNegative reports whether the signal rejects the item.
+func (s FeedbackSignal) IsNegative() bool {
+	return s == FeedbackDislike || s == FeedbackNotInterested
+}
+
+// ExcludesItem reports whether items with this signal are removed from the
+// user's candidates at serving time, regardless of score.
+func (s FeedbackSignal) ExcludesItem() bool {
+	return s == FeedbackNotInterested || s == FeedbackWatchedElsewhere
+}
+
+// Feedback is a user's latest explicit signal for an item.
+type Feedback struct {
+	UserID    int            `json:"user_id"`
+	ItemID    int            `json:"item_id"`
+	Signal    FeedbackSignal `json:"signal"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// FeedbackProvider is implemented by data providers that store explicit
+// feedback. When the engine's DataProvider implements it, feedback is applied
+// to training data and used to filter candidates.
+type FeedbackProvider interface {
+	// GetFeedback returns the latest signal per user and item.
+	GetFeedback(ctx context.Context) ([]Feedback, error)
+
+	// GetUserFeedback returns a user's latest signal per item.
+	GetUserFeedback(ctx context.Context, userID int) ([]Feedback, error)
+}
+
+// FeedbackLearner is implemented by algorithms that learn online from
+// feedback, such as the LinUCB bandit. The reward is Signal.Reward().
+type FeedbackLearner interface {
+	RecordFeedback(userID int, itemID int, reward float64)
+}
+
+// DedupFeedback keeps only the latest signal for each user and item.
+func DedupFeedback(feedback []Feedback) []Feedback {
+	type key struct{ user, item int }
+	latest := make(map[key]int, len(feedback))
+	result := make([]Feedback, 0, len(feedback))
+
+	for _, fb := range feedback {
+		k := key{fb.UserID, fb.ItemID}
+		idx, seen := latest[k]
+		switch {
+		case !seen:
+			latest[k] = len(result)
+			result = append(result, fb)
+		case !fb.CreatedAt.Before(result[idx].CreatedAt):
+			result[idx] = fb
+		}
+	}
+	return result
+}
+
+// ApplyFeedback folds explicit feedback into training interactions:
+//   - like: the user's interactions with the item get LikeBoost times the
+//     confidence; an unwatched liked item is added as a completed interaction
+//   - dislike, not_interested: the user's interactions with the item are
+//     replaced by one InteractionRejected with zero confidence
+//   - watched_elsewhere: an unwatched item is added as a completed interaction
+//
+// The input slice is not modified.
+//
+//nolint:gocritic // rangeValCopy: Interaction passed by value in range, acceptable for clarity
+func ApplyFeedback(interactions []Interaction, feedback []Feedback) []Interaction {
+	if len(feedback) == 0 {
+		return interactions
+	}
+
+	type key struct{ user, item int }
+	signals := make(map[key]Feedback, len(feedback))
+	for _, fb := range DedupFeedback(feedback) {
+		signals[key{fb.UserID, fb.ItemID}] = fb
+	}
+
+	result := make([]Interaction, 0, len(interactions)+len(signals))
+	watched := make(map[key]bool, len(signals))
+	for _, inter := range interactions {
+		k := key{inter.UserID, inter.ItemID}
+		fb, ok := signals[k]
+		if !ok {
+			result = append(result, inter)
+			continue
+		}
+		watched[k] = true
+
+		switch {
+		case fb.Signal.IsNegative():
+			continue // Replaced by a rejected interaction below
+		case fb.Signal == FeedbackLike:
+			inter.Confidence *= LikeBoost
+		}
+		result = append(result, inter)
+	}
+
+	for k, fb := range signals {
+		if fb.Signal.IsNegative() {
+			result = append(result, Interaction{
+				UserID:    fb.UserID,
+				ItemID:    fb.ItemID,
+				Type:      InteractionRejected,
+				Timestamp: fb.CreatedAt,
+			})
+			continue
+		}
+		if !watched[k] {
+			result = append(result, Interaction{
+				UserID:          fb.UserID,
+				ItemID:          fb.ItemID,
+				Type:            InteractionCompleted,
+				Confidence:      fb.Signal.Confidence(),
+				PercentComplete: 100,
+				Timestamp:       fb.CreatedAt,
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,285 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// feedbackDataProvider adds stored feedback to mockDataProvider.
+type feedbackDataProvider struct {
+	mockDataProvider
+	feedback []Feedback
+}
+
+func (f *feedbackDataProvider) GetFeedback(_ context.Context) ([]Feedback, error) {
+	return f.feedback, nil
+}
+
+func (f *feedbackDataProvider) GetUserFeedback(_ context.Context, userID int) ([]Feedback, error) {
+	var result []Feedback
+	for _, fb := range f.feedback {
+		if fb.UserID == userID {
+			result = append(result, fb)
+		}
+	}
+	return result, nil
+}
+
+// learningAlgorithm records the interactions it was trained on and the
+// feedback it was given.
+type learningAlgorithm struct {
+	*mockAlgorithm
+	trainedOn []Interaction
+	rewards   map[int]float64
+}
+
+func (l *learningAlgorithm) Train(ctx context.Context, interactions []Interaction, items []Item) error {
+	l.trainedOn = interactions
+	return l.mockAlgorithm.Train(ctx, interactions, items)
+}
+
+// Predict only scores the given candidates, like the real algorithms.
+func (l *learningAlgorithm) Predict(ctx context.Context, userID int, candidates []int) (map[int]float64, error) {
+	all, err := l.mockAlgorithm.Predict(ctx, userID, candidates)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[int]float64, len(candidates))
+	for _, id := range candidates {
+		if score, ok := all[id]; ok {
+			scores[id] = score
+		}
+	}
+	return scores, nil
+}
+
+func (l *learningAlgorithm) RecordFeedback(_ int, itemID int, reward float64) {
+	l.rewards[itemID] = reward
+}
+
+func TestParseFeedbackSignal(t *testing.T) {
+	for _, s := range []string{"like", "dislike", "not_interested", "watched_elsewhere"} {
+		if got, err := ParseFeedbackSignal(s); err != nil || string(got) != s {
+			t.Errorf("ParseFeedbackSignal(%q) = %q, %v", s, got, err)
+		}
+	}
+	for _, s := range []string{"", "LIKE", "love"} {
+		if _, err := ParseFeedbackSignal(s); err == nil {
+			t.Errorf("ParseFeedbackSignal(%q) should fail", s)
+		}
+	}
+}
+
+func TestFeedbackSignal_Confidence(t *testing.T) {
+	completed := ComputeConfidence(100, 0)
+	tests := []struct {
+		signal FeedbackSignal
+		want   float64
+	}{
+		{FeedbackLike, completed * LikeBoost},
+		{FeedbackWatchedElsewhere, completed},
+		{FeedbackDislike, 0},
+		{FeedbackNotInterested, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.signal.Confidence(); got != tt.want {
+			t.Errorf("%s.Confidence() = %v, want %v", tt.signal, got, tt.want)
+		}
+	}
+}
+
+func TestFeedbackSignal_Reward(t *testing.T) {
+	completed := ComputeConfidence(100, 0)
+	tests := []struct {
+		signal FeedbackSignal
+		want   float64
+	}{
+		{FeedbackLike, completed * LikeBoost},
+		{FeedbackWatchedElsewhere, completed},
+		{FeedbackDislike, -completed},
+		{FeedbackNotInterested, -completed},
+	}
+	for _, tt := range tests {
+		if got := tt.signal.Reward(); got != tt.want {
+			t.Errorf("%s.Reward() = %v, want %v", tt.signal, got, tt.want)
+		}
+	}
+}
+
+func TestDedupFeedback_LatestWins(t *testing.T) {
+	now := time.Now()
+	got := DedupFeedback([]Feedback{
+		{UserID: 1, ItemID: 10, Signal: FeedbackDislike, CreatedAt: now},
+		{UserID: 1, ItemID: 20, Signal: FeedbackLike, CreatedAt: now},
+		{UserID: 1, ItemID: 10, Signal: FeedbackLike, CreatedAt: now.Add(time.Minute)},
+		{UserID: 1, ItemID: 20, Signal: FeedbackNotInterested, CreatedAt: now.Add(-time.Minute)},
+	})
+
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	if got[0].ItemID != 10 || got[0].Signal != FeedbackLike {
+		t.Errorf("item 10 = %+v, want later like", got[0])
+	}
+	if got[1].ItemID != 20 || got[1].Signal != FeedbackLike {
+		t.Errorf("item 20 = %+v, want like (older not_interested ignored)", got[1])
+	}
+}
+
+func TestApplyFeedback(t *testing.T) {
+	interactions := []Interaction{
+		{UserID: 1, ItemID: 10, Type: InteractionEngaged, Confidence: 4},
+		{UserID: 1, ItemID: 20, Type: InteractionCompleted, Confidence: 10},
+		{UserID: 1, ItemID: 20, Type: InteractionSampled, Confidence: 3},
+		{UserID: 2, ItemID: 10, Type: InteractionCompleted, Confidence: 10},
+	}
+	feedback := []Feedback{
+		{UserID: 1, ItemID: 10, Signal: FeedbackLike},
+		{UserID: 1, ItemID: 20, Signal: FeedbackDislike},
+		{UserID: 1, ItemID: 30, Signal: FeedbackWatchedElsewhere},
+		{UserID: 1, ItemID: 40, Signal: FeedbackNotInterested},
+		{UserID: 2, ItemID: 50, Signal: FeedbackLike},
+	}
+
+	got := ApplyFeedback(interactions, feedback)
+
+	type key struct{ user, item int }
+	byPair := make(map[key][]Interaction)
+	for _, inter := range got {
+		byPair[key{inter.UserID, inter.ItemID}] = append(byPair[key{inter.UserID, inter.ItemID}], inter)
+	}
+
+	if liked := byPair[key{1, 10}]; len(liked) != 1 || liked[0].Confidence != 4*LikeBoost {
+		t.Errorf("liked = %+v, want confidence boosted to %v", liked, 4*LikeBoost)
+	}
+	if disliked := byPair[key{1, 20}]; len(disliked) != 1 || disliked[0].Type != InteractionRejected || disliked[0].Confidence != 0 {
+		t.Errorf("disliked = %+v, want one rejected interaction", disliked)
+	}
+	if elsewhere := byPair[key{1, 30}]; len(elsewhere) != 1 || elsewhere[0].Type != InteractionCompleted {
+		t.Errorf("watched elsewhere = %+v, want one completed interaction", elsewhere)
+	}
+	if notInterested := byPair[key{1, 40}]; len(notInterested) != 1 || notInterested[0].Type != InteractionRejected {
+		t.Errorf("not interested = %+v, want one rejected interaction", notInterested)
+	}
+	if likedUnwatched := byPair[key{2, 50}]; len(likedUnwatched) != 1 || likedUnwatched[0].Confidence != FeedbackLike.Confidence() {
+		t.Errorf("liked unwatched = %+v, want confidence %v", likedUnwatched, FeedbackLike.Confidence())
+	}
+	if other := byPair[key{2, 10}]; len(other) != 1 || other[0].Confidence != 10 {
+		t.Errorf("interaction without feedback = %+v, want unchanged", other)
+	}
+	if interactions[0].Confidence != 4 {
+		t.Error("ApplyFeedback modified its input")
+	}
+}
+
+func TestEngine_Recommend_ExcludesFeedbackItems(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(DefaultConfig(), testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	alg := &learningAlgorithm{mockAlgorithm: newMockAlgorithm("ease"), rewards: make(map[int]float64)}
+	alg.trained = true
+	alg.predictScores = map[int]float64{1: 0.99, 2: 0.8, 3: 0.7, 4: 0.6}
+	engine.RegisterAlgorithm(alg)
+
+	engine.SetDataProvider(&feedbackDataProvider{
+		mockDataProvider: mockDataProvider{candidates: map[int][]int{1: {1, 2, 3, 4}}},
+		feedback: []Feedback{
+			{UserID: 1, ItemID: 1, Signal: FeedbackNotInterested},
+			{UserID: 1, ItemID: 2, Signal: FeedbackWatchedElsewhere},
+			{UserID: 1, ItemID: 3, Signal: FeedbackDislike},
+			{UserID: 2, ItemID: 4, Signal: FeedbackNotInterested},
+		},
+	})
+
+	resp, err := engine.Recommend(context.Background(), Request{UserID: 1, K: 10})
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+
+	got := make(map[int]bool)
+	for _, item := range resp.Items {
+		got[item.Item.ID] = true
+	}
+	if got[1] || got[2] {
+		t.Errorf("items = %+v, want not_interested and watched_elsewhere items excluded", resp.Items)
+	}
+	if !got[3] || !got[4] {
+		t.Errorf("items = %+v, want disliked item and other user's feedback unaffected", resp.Items)
+	}
+}
+
+func TestEngine_Train_AppliesFeedback(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Training.MinInteractions = 1
+	engine, err := NewEngine(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	alg := &learningAlgorithm{mockAlgorithm: newMockAlgorithm("linucb"), rewards: make(map[int]float64)}
+	engine.RegisterAlgorithm(alg)
+	engine.SetDataProvider(&feedbackDataProvider{
+		mockDataProvider: mockDataProvider{
+			interactions: []Interaction{{UserID: 1, ItemID: 10, Confidence: 5}},
+			items:        []Item{{ID: 10}},
+		},
+		feedback: []Feedback{{UserID: 1, ItemID: 10, Signal: FeedbackDislike}},
+	})
+
+	if err := engine.Train(context.Background()); err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	if len(alg.trainedOn) != 1 || alg.trainedOn[0].Type != InteractionRejected {
+		t.Errorf("trained on %+v, want the disliked interaction rejected", alg.trainedOn)
+	}
+}
+
+func TestEngine_RecordFeedback(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(DefaultConfig(), testLogger())
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	alg := &learningAlgorithm{mockAlgorithm: newMockAlgorithm("linucb"), rewards: make(map[int]float64)}
+	alg.trained = true
+	alg.predictScores = map[int]float64{1: 0.9}
+	engine.RegisterAlgorithm(alg)
+	engine.SetDataProvider(&mockDataProvider{candidates: map[int][]int{1: {1}, 2: {1}}})
+
+	for _, userID := range []int{1, 2} {
+		if _, err := engine.Recommend(context.Background(), Request{UserID: userID, K: 5}); err != nil {
+			t.Fatalf("Recommend() error = %v", err)
+		}
+	}
+
+	engine.RecordFeedback(context.Background(), 1, 1, FeedbackLike)
+
+	if reward, ok := alg.rewards[1]; !ok || reward != FeedbackLike.Confidence() {
+		t.Errorf("reward = %v (recorded %v), want %v", reward, ok, FeedbackLike.Confidence())
+	}
+
+	engine.RecordFeedback(context.Background(), 1, 1, FeedbackDislike)
+	if reward := alg.rewards[1]; reward >= 0 {
+		t.Errorf("dislike reward = %v, want negative", reward)
+	}
+	if engine.checkCache(engine.cacheKey(Request{UserID: 1, K: 5})) != nil {
+		t.Error("user 1 cache entry should be invalidated")
+	}
+	if engine.checkCache(engine.cacheKey(Request{UserID: 2, K: 5})) == nil {
+		t.Error("user 2 cache entry should be kept")
+	}
+}
@@ -0,0 +1,220 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Engine states reported by Engine.Status, from serving to the first reason
+// recommendations are empty.
+const (
+	// StateReady means a model is trained and recommendations are served.
+	StateReady = "ready"
+
+	// StateNoAlgorithms means no algorithm is registered.
+	StateNoAlgorithms = "no_algorithms"
+
+	// StateTraining means the first training run is in progress.
+	StateTraining = "training"
+
+	// StateInsufficientData means there are fewer interactions than
+	// Training.MinInteractions, so training refuses to run (cold start).
+	StateInsufficientData = "insufficient_interactions"
+
+	// StateTrainingFailed means the last training run failed and no model
+	// was loaded from storage.
+	StateTrainingFailed = "training_failed"
+
+	// StateNotTrained means there is enough data but no training run has
+	// completed yet and no model was loaded from storage.
+	StateNotTrained = "not_trained"
+)
+
+// EngineStatus diagnoses whether the engine can serve recommendations.
+type EngineStatus struct {
+	// State is one of the State constants.
+	State string `json:"state"`
+
+	// Issues lists human-readable problems: failed algorithms, models that
+	// did not load, or too few interactions. Empty when all is well.
+	Issues []string `json:"issues,omitempty"`
+
+	// IsTraining indicates whether training is currently in progress.
+	// Training details are omitted while it runs.
+	IsTraining bool `json:"is_training"`
+
+	// ModelVersion is the engine model version; 0 until a model is trained
+	// or loaded from storage.
+	ModelVersion int `json:"model_version"`
+
+	// LastTrainedAt is when the current model was trained.
+	LastTrainedAt time.Time `json:"last_trained_at,omitempty"`
+
+	// LastError contains the last training error, if any.
+	LastError string `json:"last_error,omitempty"`
+
+	// InteractionCount is the number of interactions available for
+	// training, or in the last training set when it cannot be counted.
+	InteractionCount int `json:"interaction_count"`
+
+	// MinInteractions is the number of interactions training needs.
+	MinInteractions int `json:"min_interactions"`
+
+	// Algorithms contains the status of each registered algorithm, in
+	// registration order.
+	Algorithms []AlgorithmStatus `json:"algorithms"`
+}
+
+// AlgorithmStatus describes the model of one registered algorithm.
+type AlgorithmStatus struct {
+	// Name is the algorithm name.
+	Name string `json:"name"`
+
+	// Trained indicates whether the algorithm has a model to predict with.
+	Trained bool `json:"trained"`
+
+	// Version is the algorithm's model version.
+	Version int `json:"version"`
+
+	// LastTrainedAt is when the model was trained.
+	LastTrainedAt time.Time `json:"last_trained_at,omitempty"`
+
+	// TrainingError is the algorithm's error in the last training run.
+	TrainingError string `json:"training_error,omitempty"`
+
+	// Restorable indicates whether the model is loaded from storage on startup.
+	Restorable bool `json:"restorable"`
+
+	// Load is the outcome of loading the stored model; nil if none was loaded.
+	Load *ModelLoadStatus `json:"load,omitempty"`
+}
+
+// ModelLoadStatus is the outcome of loading an algorithm's stored model.
+type ModelLoadStatus struct {
+	// Version is the stored model version.
+	Version int `json:"version"`
+
+	// Loaded is true if the model was read and restored cleanly.
+	Loaded bool `json:"loaded"`
+
+	// Error explains why the model could not be loaded.
+	Error string `json:"error,omitempty"`
+
+	// AttemptedAt is when the model was loaded.
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+// Status diagnoses whether the engine can serve recommendations: the
+// per-algorithm trained state and stored model loads, and the interaction
+// count against Training.MinInteractions. The interaction count is taken
+// from the data provider when it implements InteractionCounter. Status does
+// not wait for a training run in progress.
+func (e *Engine) Status(ctx context.Context) EngineStatus {
+	status := EngineStatus{
+		ModelVersion:    int(atomic.LoadInt32(&e.modelVersion)),
+		MinInteractions: e.config.Training.MinInteractions,
+	}
+
+	// Training holds trainMu and each algorithm's own lock throughout, so
+	// reading either would block until it finishes
+	training := !e.trainMu.TryRLock()
+	var trainStatus TrainingStatus
+	if !training {
+		trainStatus = e.trainStatus
+		e.trainMu.RUnlock()
+	}
+	status.IsTraining = training || trainStatus.IsTraining
+	status.LastTrainedAt = trainStatus.LastTrainedAt
+	status.LastError = trainStatus.LastError
+	status.InteractionCount = trainStatus.InteractionCount
+
+	if counter, ok := e.dataProvider.(InteractionCounter); ok {
+		count, err := counter.CountInteractions(ctx, time.Time{})
+		if err != nil {
+			status.Issues = append(status.Issues, fmt.Sprintf("failed to count interactions: %v", err))
+		} else {
+			status.InteractionCount = count
+		}
+	}
+
+	trainingErrors := make(map[string]string, len(trainStatus.Algorithms))
+	for _, result := range trainStatus.Algorithms {
+		trainingErrors[result.Name] = result.Error
+	}
+
+	e.loadMu.RLock()
+	defer e.loadMu.RUnlock()
+
+	anyTrained := false
+	algorithms := e.getAlgorithms()
+	status.Algorithms = make([]AlgorithmStatus, 0, len(algorithms))
+	for _, alg := range algorithms {
+		as := AlgorithmStatus{
+			Name:          alg.Name(),
+			TrainingError: trainingErrors[alg.Name()],
+		}
+		_, as.Restorable = alg.(RestorableAlgorithm)
+		if load, ok := e.modelLoads[alg.Name()]; ok {
+			as.Load = &load
+			if !load.Loaded {
+				status.Issues = append(status.Issues, fmt.Sprintf("%s: stored model v%d failed to load: %s", as.Name, load.Version, load.Error))
+			}
+		}
+		if !training {
+			as.Trained = alg.IsTrained()
+			as.Version = alg.Version()
+			as.LastTrainedAt = alg.LastTrainedAt()
+		}
+		if as.TrainingError != "" {
+			status.Issues = append(status.Issues, fmt.Sprintf("%s: training failed: %s", as.Name, as.TrainingError))
+		}
+		anyTrained = anyTrained || as.Trained
+		status.Algorithms = append(status.Algorithms, as)
+	}
+
+	switch {
+	case len(algorithms) == 0:
+		status.State = StateNoAlgorithms
+	case status.ModelVersion > 0 && (anyTrained || training):
+		status.State = StateReady
+	case status.IsTraining:
+		status.State = StateTraining
+	case status.InteractionCount < status.MinInteractions:
+		status.State = StateInsufficientData
+		status.Issues = append(status.Issues, fmt.Sprintf("%d interactions, training needs %d", status.InteractionCount, status.MinInteractions))
+	case status.LastError != "":
+		status.State = StateTrainingFailed
+	default:
+		status.State = StateNotTrained
+	}
+	return status
+}
+
+// recordModelLoad records the outcome of loading an algorithm's stored model.
+func (e *Engine) recordModelLoad(name string, load ModelLoadStatus) {
+	e.loadMu.Lock()
+	defer e.loadMu.Unlock()
+	e.modelLoads[name] = load
+}
+
+// markRestored makes models loaded from storage servable before the first
+// training run: the engine model version becomes 1 if nothing was trained
+// yet, and cached responses are dropped.
+func (e *Engine) markRestored(trainedAt time.Time) {
+	e.trainMu.Lock()
+	defer e.trainMu.Unlock()
+
+	if atomic.CompareAndSwapInt32(&e.modelVersion, 0, 1) {
+		e.lastTrainedAt = trainedAt
+		e.trainStatus.LastTrainedAt = trainedAt
+		e.trainStatus.ModelVersion = 1
+	}
+	e.clearCache()
+}
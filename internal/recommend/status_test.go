@@ -0,0 +1,190 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package recommend
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingDataProvider is a mockDataProvider that counts interactions.
+type countingDataProvider struct {
+	*mockDataProvider
+	count    int
+	countErr error
+}
+
+func (c *countingDataProvider) CountInteractions(_ context.Context, _ time.Time) (int, error) {
+	return c.count, c.countErr
+}
+
+func TestEngine_Status(t *testing.T) {
+	t.Parallel()
+
+	interactions := []Interaction{{UserID: 1, ItemID: 1, Confidence: 1}, {UserID: 2, ItemID: 1, Confidence: 1}}
+
+	tests := []struct {
+		name         string
+		algorithms   []*mockAlgorithm
+		provider     DataProvider
+		train        bool
+		wantState    string
+		wantCount    int
+		wantIssue    string
+		wantTrained  []bool
+		wantTrainErr string
+	}{
+		{
+			name:      "no algorithms",
+			provider:  &mockDataProvider{interactions: interactions},
+			wantState: StateNoAlgorithms,
+		},
+		{
+			name:        "cold start",
+			algorithms:  []*mockAlgorithm{newMockAlgorithm("covisit")},
+			provider:    &countingDataProvider{mockDataProvider: &mockDataProvider{}, count: 1},
+			wantState:   StateInsufficientData,
+			wantCount:   1,
+			wantIssue:   "1 interactions, training needs 2",
+			wantTrained: []bool{false},
+		},
+		{
+			name:        "not trained",
+			algorithms:  []*mockAlgorithm{newMockAlgorithm("covisit")},
+			provider:    &countingDataProvider{mockDataProvider: &mockDataProvider{}, count: 5},
+			wantState:   StateNotTrained,
+			wantCount:   5,
+			wantTrained: []bool{false},
+		},
+		{
+			name:       "count fails",
+			algorithms: []*mockAlgorithm{newMockAlgorithm("covisit")},
+			provider: &countingDataProvider{
+				mockDataProvider: &mockDataProvider{interactions: interactions},
+				countErr:         errors.New("database is locked"),
+			},
+			train:       true,
+			wantState:   StateReady,
+			wantCount:   2, // From the training set
+			wantIssue:   "failed to count interactions: database is locked",
+			wantTrained: []bool{true},
+		},
+		{
+			name: "one algorithm failed",
+			algorithms: []*mockAlgorithm{
+				newMockAlgorithm("covisit"),
+				func() *mockAlgorithm {
+					m := newMockAlgorithm("ease")
+					m.trainErr = errors.New("singular matrix")
+					return m
+				}(),
+			},
+			provider:     &mockDataProvider{interactions: interactions},
+			train:        true,
+			wantState:    StateReady,
+			wantCount:    2,
+			wantIssue:    "ease: training failed: singular matrix",
+			wantTrained:  []bool{true, false},
+			wantTrainErr: "singular matrix",
+		},
+		{
+			name:        "training failed",
+			algorithms:  []*mockAlgorithm{newMockAlgorithm("covisit")},
+			provider:    &countingDataProvider{mockDataProvider: &mockDataProvider{interactionsErr: errors.New("connection refused")}, count: 3},
+			train:       true,
+			wantState:   StateTrainingFailed,
+			wantCount:   3,
+			wantTrained: []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := DefaultConfig()
+			cfg.Training.MinInteractions = 2
+			engine, err := NewEngine(cfg, testLogger())
+			if err != nil {
+				t.Fatalf("NewEngine() error = %v", err)
+			}
+			for _, alg := range tt.algorithms {
+				engine.RegisterAlgorithm(alg)
+			}
+			engine.SetDataProvider(tt.provider)
+			if tt.train {
+				_ = engine.Train(context.Background()) //nolint:errcheck // failures are part of the status under test
+			}
+
+			status := engine.Status(context.Background())
+			if status.State != tt.wantState {
+				t.Errorf("State = %q, want %q (issues: %v)", status.State, tt.wantState, status.Issues)
+			}
+			if status.InteractionCount != tt.wantCount || status.MinInteractions != 2 {
+				t.Errorf("interactions = %d of %d, want %d of 2", status.InteractionCount, status.MinInteractions, tt.wantCount)
+			}
+			if tt.wantIssue != "" && !containsIssue(status.Issues, tt.wantIssue) {
+				t.Errorf("Issues = %v, want %q", status.Issues, tt.wantIssue)
+			}
+			if len(status.Algorithms) != len(tt.wantTrained) {
+				t.Fatalf("Algorithms = %+v, want %d", status.Algorithms, len(tt.wantTrained))
+			}
+			for i, want := range tt.wantTrained {
+				if status.Algorithms[i].Trained != want {
+					t.Errorf("%s trained = %v, want %v", status.Algorithms[i].Name, status.Algorithms[i].Trained, want)
+				}
+			}
+			if tt.wantTrainErr != "" && status.Algorithms[1].TrainingError != tt.wantTrainErr {
+				t.Errorf("TrainingError = %q, want %q", status.Algorithms[1].TrainingError, tt.wantTrainErr)
+			}
+		})
+	}
+}
+
+func TestEngine_Status_DuringTraining(t *testing.T) {
+	t.Parallel()
+
+	slow := newMockAlgorithm("covisit")
+	slow.trainDelay = 200 * time.Millisecond
+	engine := newCoordinatorTestEngine(t, slow)
+
+	done := make(chan error, 1)
+	go func() { done <- engine.Train(context.Background()) }()
+
+	deadline := time.Now().Add(time.Second)
+	for engine.trainMu.TryRLock() {
+		engine.trainMu.RUnlock()
+		if time.Now().After(deadline) {
+			t.Fatal("training did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	start := time.Now()
+	status := engine.Status(context.Background())
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Status() took %v, want it not to wait for training", elapsed)
+	}
+	if status.State != StateTraining || !status.IsTraining {
+		t.Errorf("State = %q, IsTraining = %v, want training", status.State, status.IsTraining)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+}
+
+func containsIssue(issues []string, want string) bool {
+	for _, issue := range issues {
+		if strings.Contains(issue, want) {
+			return true
+		}
+	}
+	return false
+}
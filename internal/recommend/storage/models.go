@@ -66,6 +66,40 @@ type ModelMetadata struct {
 
 	// TrainingDurationMS is how long training took.
 	TrainingDurationMS int64 `json:"training_duration_ms"`
+
+	// Evaluation holds offline quality metrics for the model, if it was
+	// evaluated before saving.
+	Evaluation *Evaluation `json:"evaluation,omitempty"`
+}
+
+// Evaluation contains offline quality metrics computed from a model's
+// recommendations for a sample of users. They complement accuracy when
+// choosing between model versions.
+type Evaluation struct {
+	// K is the length of each evaluated recommendation list.
+	K int `json:"k"`
+
+	// UsersEvaluated is the number of sampled users with recommendations.
+	UsersEvaluated int `json:"users_evaluated"`
+
+	// CatalogSize is the number of items that could be recommended.
+	CatalogSize int `json:"catalog_size"`
+
+	// CoverageAtK is the fraction of the catalog appearing in at least one
+	// top-K list (0-1).
+	CoverageAtK float64 `json:"coverage_at_k"`
+
+	// IntraListDiversity is the mean pairwise genre distance (1 - Jaccard
+	// similarity) within each list, averaged over lists (0-1).
+	IntraListDiversity float64 `json:"intra_list_diversity"`
+
+	// PopularityGini is the Gini coefficient of how often each catalog item
+	// is recommended: 0 when every item is recommended equally often, near 1
+	// when a few popular items take every slot.
+	PopularityGini float64 `json:"popularity_gini"`
+
+	// EvaluatedAt is when the evaluation ran.
+	EvaluatedAt time.Time `json:"evaluated_at"`
 }
 
 // StoredModel wraps model data with metadata for persistence.
@@ -481,6 +515,7 @@ type CoVisitModelState struct {
 
 	// Config values
 	MinCoOccurrence    int
+	SessionGapMinutes  int
 	SessionWindowHours int
 	MaxPairs           int
 }
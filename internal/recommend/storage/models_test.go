@@ -78,6 +78,7 @@ func TestStore_SaveAndLoad(t *testing.T) {
 		InteractionCount: 1000,
 		ItemCount:        100,
 		UserCount:        50,
+		Evaluation:       &Evaluation{K: 20, UsersEvaluated: 50, CatalogSize: 100, CoverageAtK: 0.4},
 	}
 
 	// Save model
@@ -108,6 +109,9 @@ func TestStore_SaveAndLoad(t *testing.T) {
 	if loadedMeta.SizeBytes == 0 {
 		t.Error("SizeBytes should not be zero")
 	}
+	if loadedMeta.Evaluation == nil || loadedMeta.Evaluation.CoverageAtK != 0.4 {
+		t.Errorf("Evaluation = %+v, want the saved evaluation", loadedMeta.Evaluation)
+	}
 
 	// Verify data
 	if len(loaded.B) != 3 {
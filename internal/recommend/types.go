@@ -22,6 +22,9 @@ const (
 	InteractionEngaged
 	// InteractionCompleted indicates content was completed (>= 90% watched).
 	InteractionCompleted
+	// InteractionRejected indicates explicit negative feedback (dislike or
+	// not interested). It carries zero confidence.
+	InteractionRejected
 )
 
 // String returns a human-readable name for the interaction type.
@@ -35,6 +38,8 @@ func (t InteractionType) String() string {
 		return "engaged"
 	case InteractionCompleted:
 		return "completed"
+	case InteractionRejected:
+		return "rejected"
 	default:
 		return "unknown"
 	}
@@ -108,6 +113,9 @@ type Item struct {
 	// Year is the release year.
 	Year int `json:"year"`
 
+	// Thumb is the media server thumbnail path.
+	Thumb string `json:"thumb,omitempty"`
+
 	// Studio is the production studio.
 	Studio string `json:"studio,omitempty"`
 
@@ -188,9 +196,13 @@ const (
 	// ModeExplore emphasizes discovery over exploitation.
 	ModeExplore
 	// ModePopular returns popularity-ranked content.
+	// Only the PopularityAlgorithm contributes scores in this mode.
 	ModePopular
 )
 
+// PopularityAlgorithm is the name of the popularity algorithm used by ModePopular.
+const PopularityAlgorithm = "popularity"
+
 // String returns a human-readable mode name.
 func (m RecommendMode) String() string {
 	switch m {
@@ -259,6 +271,12 @@ type ResponseMetadata struct {
 	// ModelVersion is the version of the trained model used.
 	ModelVersion int `json:"model_version"`
 
+	// Experiment is the A/B experiment the user is in, if any.
+	Experiment string `json:"experiment,omitempty"`
+
+	// Variant is the user's experiment variant, if any.
+	Variant string `json:"variant,omitempty"`
+
 	// TrainedAt is when the model was last trained.
 	TrainedAt time.Time `json:"trained_at"`
 
@@ -294,6 +312,18 @@ type Algorithm interface {
 	LastTrainedAt() time.Time
 }
 
+// ItemSimilarityProvider is implemented by algorithms that rank the whole
+// catalog by similarity to one item, without a user context. Engine.SimilarItems
+// serves "more like this" lists from the first one registered.
+type ItemSimilarityProvider interface {
+	Algorithm
+
+	// SimilarItems returns up to k items most similar to itemID, highest
+	// score first. Returns ErrModelNotTrained before training and
+	// ErrItemNotFound for an item the model does not know.
+	SimilarItems(ctx context.Context, itemID, k int) ([]ScoredItem, error)
+}
+
 // Reranker modifies a ranked list for diversity or other objectives.
 type Reranker interface {
 	// Name returns the reranker identifier (e.g., "mmr", "calibration").
@@ -339,6 +369,21 @@ type TrainingStatus struct {
 
 	// NextScheduledTraining is when the next training is scheduled.
 	NextScheduledTraining time.Time `json:"next_scheduled_training,omitempty"`
+
+	// Algorithms contains per-algorithm results from the last training run.
+	Algorithms []AlgorithmTrainingResult `json:"algorithms,omitempty"`
+}
+
+// AlgorithmTrainingResult describes how one algorithm fared in a training run.
+type AlgorithmTrainingResult struct {
+	// Name is the algorithm name.
+	Name string `json:"name"`
+
+	// DurationMS is how long the algorithm took to train.
+	DurationMS int64 `json:"duration_ms"`
+
+	// Error contains the training error, if any.
+	Error string `json:"error,omitempty"`
 }
 
 // Metrics contains recommendation system metrics for observability.
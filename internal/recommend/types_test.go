@@ -19,6 +19,7 @@ func TestInteractionType_String(t *testing.T) {
 		{"sampled", InteractionSampled, "sampled"},
 		{"engaged", InteractionEngaged, "engaged"},
 		{"completed", InteractionCompleted, "completed"},
+		{"rejected", InteractionRejected, "rejected"},
 		{"unknown value", InteractionType(99), "unknown"},
 	}
 
@@ -42,6 +43,7 @@ func TestInteractionType_Confidence(t *testing.T) {
 		{"engaged has moderate confidence", InteractionEngaged, 0.7},
 		{"sampled has low confidence", InteractionSampled, 0.3},
 		{"abandoned has minimal confidence", InteractionAbandoned, 0.1},
+		{"rejected has zero confidence", InteractionRejected, 0.0},
 		{"unknown has zero confidence", InteractionType(99), 0.0},
 	}
 
@@ -0,0 +1,174 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// Package smtpmail sends email over SMTP and builds the MIME messages for
+// it. Newsletter delivery and detection alerts share it so TLS negotiation,
+// authentication and header encoding behave the same for both.
+package smtpmail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// TLS modes for Config.TLSMode.
+const (
+	// TLSModeStartTLS connects in plaintext and upgrades with STARTTLS (port 587).
+	TLSModeStartTLS = "starttls"
+
+	// TLSModeImplicit connects over TLS from the first byte (port 465).
+	TLSModeImplicit = "implicit"
+
+	// TLSModeNone sends without encryption. Only use on trusted networks.
+	TLSModeNone = "none"
+)
+
+// quitTimeout bounds the QUIT exchange when a session is closed.
+const quitTimeout = 5 * time.Second
+
+// Config describes an SMTP server and account.
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+
+	// TLSMode is one of the TLSMode constants. Anything else is treated
+	// as TLSModeNone.
+	TLSMode string
+
+	// Timeout bounds the dial and each exchange on the session.
+	Timeout time.Duration
+}
+
+// Client is one connected and authenticated SMTP session. It can send any
+// number of messages; it is not safe for concurrent use.
+type Client struct {
+	conn    net.Conn
+	client  *smtp.Client
+	timeout time.Duration
+}
+
+// Dial connects to the server, negotiates TLS according to the mode, and
+// authenticates when a username and password are set.
+func Dial(ctx context.Context, config Config) (*Client, error) {
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+	tlsConfig := &tls.Config{
+		ServerName: config.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	// Create connection with timeout
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	var conn net.Conn
+	var err error
+	if config.TLSMode == TLSModeImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	if err := conn.SetDeadline(deadline(ctx, config.Timeout)); err != nil {
+		_ = conn.Close() //nolint:errcheck // Best effort cleanup
+		return nil, fmt.Errorf("failed to set SMTP deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		_ = conn.Close() //nolint:errcheck // Best effort cleanup
+		return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+
+	if config.TLSMode == TLSModeStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			_ = client.Close() //nolint:errcheck // Best effort cleanup
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	// Authenticate if credentials provided. PlainAuth refuses to send
+	// credentials over an unencrypted connection to a remote host.
+	if config.Username != "" && config.Password != "" {
+		auth := smtp.PlainAuth("", config.Username, config.Password, config.Host)
+		if err := client.Auth(auth); err != nil {
+			_ = client.Close() //nolint:errcheck // Best effort cleanup
+			return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	return &Client{conn: conn, client: client, timeout: config.Timeout}, nil
+}
+
+// Send delivers msg to the recipients in a single SMTP transaction. SMTP
+// replies are returned wrapping a *textproto.Error.
+func (c *Client) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	if err := c.conn.SetDeadline(deadline(ctx, c.timeout)); err != nil {
+		return fmt.Errorf("failed to set SMTP deadline: %w", err)
+	}
+
+	if err := c.client.Mail(from); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, rcpt := range to {
+		if err := c.client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("failed to set recipient %s: %w", rcpt, err)
+		}
+	}
+
+	writer, err := c.client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := writer.Write(msg); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close message: %w", err)
+	}
+	return nil
+}
+
+// Noop checks that the server is still responding, for sessions that have
+// been idle.
+func (c *Client) Noop(ctx context.Context) error {
+	_ = c.conn.SetDeadline(deadline(ctx, c.timeout)) //nolint:errcheck // Checked by Noop
+	return c.client.Noop()
+}
+
+// Reset aborts the current transaction so the session can send again after
+// a rejected command.
+func (c *Client) Reset() error {
+	return c.client.Reset()
+}
+
+// Quit ends the session, sending QUIT when the connection is still usable.
+func (c *Client) Quit() {
+	_ = c.conn.SetDeadline(time.Now().Add(quitTimeout)) //nolint:errcheck // Best effort cleanup
+	if err := c.client.Quit(); err != nil {
+		_ = c.client.Close() //nolint:errcheck // Best effort cleanup
+	}
+}
+
+// Close drops the connection without QUIT, for sessions in an unknown state.
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+// deadline bounds one SMTP exchange by the timeout and ctx.
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	d := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(d) {
+		d = ctxDeadline
+	}
+	return d
+}
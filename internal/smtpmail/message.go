@@ -0,0 +1,119 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package smtpmail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// WriteHeader writes one header line. The value must already be sanitized
+// or encoded.
+func WriteHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name + ": " + value + "\r\n")
+}
+
+// WriteMIMEHeader writes the headers in sorted order.
+func WriteMIMEHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range header[k] {
+			WriteHeader(buf, k, v)
+		}
+	}
+}
+
+// EncodeText returns free text, such as a subject, as a header value: line
+// breaks are stripped and non-ASCII text is RFC 2047 encoded.
+func EncodeText(s string) string {
+	return mime.QEncoding.Encode("utf-8", SanitizeHeader(s))
+}
+
+// FormatAddress renders a mailbox with a display name. Simple names are
+// written as-is; anything else is quoted or RFC 2047 encoded.
+func FormatAddress(name, addr string) string {
+	name = SanitizeHeader(name)
+	addr = SanitizeHeader(addr)
+	if name == "" {
+		return addr
+	}
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(" -_", r)) {
+			return (&mail.Address{Name: name, Address: addr}).String()
+		}
+	}
+	return fmt.Sprintf("%s <%s>", name, addr)
+}
+
+// MessageID returns a unique Message-ID in the sender's domain.
+func MessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", uuid.New().String(), SanitizeHeader(domain))
+}
+
+// SanitizeHeader strips line breaks so content cannot inject headers.
+func SanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+// BodyEntity returns the MIME headers and encoded content of a message
+// body: a single text part, or multipart/alternative with the plain text
+// first so clients prefer the HTML part. Text is quoted-printable, so every
+// line stays within the RFC 5322 limit regardless of the content.
+func BodyEntity(bodyHTML, bodyText string) (textproto.MIMEHeader, []byte) {
+	switch {
+	case bodyHTML != "" && bodyText != "":
+		var buf bytes.Buffer
+		alt := multipart.NewWriter(&buf)
+		for _, p := range []struct{ contentType, body string }{
+			{"text/plain; charset=UTF-8", bodyText},
+			{"text/html; charset=UTF-8", bodyHTML},
+		} {
+			part, _ := alt.CreatePart(textPartHeader(p.contentType)) //nolint:errcheck // bytes.Buffer
+			_, _ = part.Write(quotedPrintable(p.body))               //nolint:errcheck // bytes.Buffer
+		}
+		_ = alt.Close() //nolint:errcheck // bytes.Buffer
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": alt.Boundary()}))
+		return header, buf.Bytes()
+	case bodyHTML != "":
+		return textPartHeader("text/html; charset=UTF-8"), quotedPrintable(bodyHTML)
+	default:
+		return textPartHeader("text/plain; charset=UTF-8"), quotedPrintable(bodyText)
+	}
+}
+
+func textPartHeader(contentType string) textproto.MIMEHeader {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return header
+}
+
+func quotedPrintable(s string) []byte {
+	var buf bytes.Buffer
+	qp := quotedprintable.NewWriter(&buf)
+	_, _ = qp.Write([]byte(s)) //nolint:errcheck // bytes.Buffer
+	_ = qp.Close()             //nolint:errcheck // bytes.Buffer
+	return buf.Bytes()
+}
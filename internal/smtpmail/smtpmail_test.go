@@ -0,0 +1,113 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package smtpmail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/smtpmail/smtptest"
+)
+
+func dialTestServer(t *testing.T, server *smtptest.Server) *Client {
+	t.Helper()
+	client, err := Dial(context.Background(), Config{
+		Host:    server.Host(),
+		Port:    server.Port(),
+		TLSMode: TLSModeNone,
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(client.Quit)
+	return client
+}
+
+func TestClient_Send(t *testing.T) {
+	server := smtptest.NewServer(t, nil)
+	client := dialTestServer(t, server)
+
+	to := []string{"a@example.com", "b@example.com"}
+	for range 2 {
+		if err := client.Send(context.Background(), "from@example.com", to, []byte("Subject: hi\r\n\r\nbody\r\n")); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	messages := server.Messages()
+	if server.Sessions() != 1 || len(messages) != 2 {
+		t.Fatalf("sessions/messages = %d/%d, want 1/2", server.Sessions(), len(messages))
+	}
+	if m := messages[0]; m.From != "from@example.com" || len(m.To) != 2 || !strings.Contains(m.Data, "body") {
+		t.Errorf("message = %+v", m)
+	}
+}
+
+func TestClient_Send_RejectedRecipient(t *testing.T) {
+	server := smtptest.NewServer(t, map[string]string{"missing@example.com": "550 5.1.1 No such user"})
+	client := dialTestServer(t, server)
+
+	err := client.Send(context.Background(), "from@example.com", []string{"missing@example.com"}, []byte("\r\n"))
+	var smtpErr *textproto.Error
+	if !errors.As(err, &smtpErr) || smtpErr.Code != 550 {
+		t.Fatalf("Send() error = %v, want 550 reply", err)
+	}
+
+	if err := client.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if err := client.Send(context.Background(), "from@example.com", []string{"ok@example.com"}, []byte("\r\n")); err != nil {
+		t.Errorf("Send() after reset error = %v", err)
+	}
+}
+
+func TestDial_ConnectionFailed(t *testing.T) {
+	_, err := Dial(context.Background(), Config{Host: "127.0.0.1", Port: 1, Timeout: time.Second})
+	if err == nil || !strings.Contains(err.Error(), "failed to connect") {
+		t.Errorf("Dial() error = %v, want connection error", err)
+	}
+}
+
+func TestMessageHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	WriteHeader(&buf, "From", FormatAddress("Médiathèque", "news@example.com"))
+	WriteHeader(&buf, "Subject", EncodeText("Report — März\r\nBcc: attacker@example.com"))
+	WriteHeader(&buf, "Message-ID", MessageID("news@example.com"))
+	header, body := BodyEntity("<p>Hi</p>", "Hi")
+	WriteMIMEHeader(&buf, header)
+	buf.WriteString("\r\n")
+	buf.Write(body)
+
+	msg, err := mail.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("message does not parse: %v", err)
+	}
+	if msg.Header.Get("Bcc") != "" {
+		t.Error("subject injected a Bcc header")
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || !strings.HasPrefix(subject, "Report — März") {
+		t.Errorf("Subject = %q (%v)", subject, err)
+	}
+	from, err := msg.Header.AddressList("From")
+	if err != nil || from[0].Name != "Médiathèque" {
+		t.Errorf("From = %v (%v)", from, err)
+	}
+	if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("Message-ID = %q", msg.Header.Get("Message-ID"))
+	}
+	if mediaType, _, _ := mime.ParseMediaType(msg.Header.Get("Content-Type")); mediaType != "multipart/alternative" {
+		t.Errorf("Content-Type = %q, want multipart/alternative", msg.Header.Get("Content-Type"))
+	}
+}
@@ -47,6 +47,10 @@ Graceful Shutdown:
   - Configurable shutdown timeout per service
   - UnstoppedServiceReport for debugging hangs
 
+Readiness:
+  - Ready reports services in backoff or restarted within StabilityWindow
+  - Served by the /api/v1/health/ready probe
+
 Structured Logging:
   - Integration with slog for structured events
   - Logs service starts, stops, failures, and restarts
@@ -0,0 +1,101 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package supervisor
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+)
+
+// serviceHealth records the service failures and supervisor backoffs
+// reported through suture's event hook, for SupervisorTree.Ready.
+//
+// Suture reports failures but not successful starts, so a service counts as
+// stable once it has run for stableAfter since its last failure, or since
+// its supervisor resumed it after a backoff.
+type serviceHealth struct {
+	mu          sync.Mutex
+	stableAfter time.Duration
+	now         func() time.Time
+
+	// failures holds the last failure of each recently failed service
+	failures map[string]serviceFailure
+
+	// backoff holds the supervisors paused for failing too often
+	backoff map[string]struct{}
+}
+
+// serviceFailure is when a service last failed and which supervisor runs it.
+type serviceFailure struct {
+	supervisor string
+	at         time.Time
+}
+
+func newServiceHealth(stableAfter time.Duration) *serviceHealth {
+	return &serviceHealth{
+		stableAfter: stableAfter,
+		now:         time.Now,
+		failures:    make(map[string]serviceFailure),
+		backoff:     make(map[string]struct{}),
+	}
+}
+
+// hook is the suture event hook that records failures and backoffs.
+func (h *serviceHealth) hook(event suture.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch e := event.(type) {
+	case suture.EventServiceTerminate:
+		h.failures[e.ServiceName] = serviceFailure{supervisor: e.SupervisorName, at: h.now()}
+	case suture.EventServicePanic:
+		h.failures[e.ServiceName] = serviceFailure{supervisor: e.SupervisorName, at: h.now()}
+	case suture.EventBackoff:
+		h.backoff[e.SupervisorName] = struct{}{}
+	case suture.EventResume:
+		delete(h.backoff, e.SupervisorName)
+		// The paused services restart now; they must stay up from here
+		now := h.now()
+		for name, f := range h.failures {
+			if f.supervisor == e.SupervisorName {
+				f.at = now
+				h.failures[name] = f
+			}
+		}
+	}
+}
+
+// ready reports whether no service is failing, with the sorted names of
+// those that are: services that failed within stableAfter, services of a
+// supervisor in backoff, and backed-off supervisors with no known failed
+// service.
+func (h *serviceHealth) ready() (bool, []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	unhealthy := []string{}
+	covered := make(map[string]bool, len(h.backoff))
+	for name, f := range h.failures {
+		_, inBackoff := h.backoff[f.supervisor]
+		if !inBackoff && now.Sub(f.at) >= h.stableAfter {
+			delete(h.failures, name)
+			continue
+		}
+		unhealthy = append(unhealthy, name)
+		covered[f.supervisor] = true
+	}
+	for supervisor := range h.backoff {
+		if !covered[supervisor] {
+			unhealthy = append(unhealthy, supervisor)
+		}
+	}
+	sort.Strings(unhealthy)
+	return len(unhealthy) == 0, unhealthy
+}
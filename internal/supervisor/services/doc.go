@@ -30,6 +30,8 @@ HTTP Server (HTTPServerService):
   - Wraps *http.Server with graceful shutdown
   - Converts ListenAndServe pattern to Serve
   - Configurable shutdown timeout for draining connections
+  - With a RequestDrainer, answers new requests with 503 and
+    Connection: close while in-flight ones finish
 
 WebSocket Hub (WebSocketHubService):
   - Wraps websocket.Hub with context support
@@ -56,6 +58,11 @@ Detection Engine (DetectionEngineService):
   - Processes events and generates alerts
   - Handles rule evaluation and notification
 
+VPN Updater (VPNUpdaterService):
+  - Wraps vpn.Updater's scheduled refresh of the gluetun VPN dataset
+  - Failed updates keep the current dataset and are retried next interval
+  - Enabled with VPN_AUTO_UPDATE
+
 Import Service (ImportService):
   - Wraps database import operations
   - Handles long-running Tautulli database imports
@@ -86,8 +93,10 @@ Creating and registering services:
 	func setupSupervisor(server *http.Server, hub *websocket.Hub, syncMgr *sync.Manager) {
 	    tree, _ := supervisor.NewSupervisorTree(logger, config)
 
-	    // HTTP server with 30s shutdown timeout
-	    httpSvc := services.NewHTTPServerService(server, 30*time.Second)
+	    // HTTP server draining requests for up to 30s on shutdown
+	    drainer := services.NewRequestDrainer()
+	    server.Handler = drainer.Middleware(server.Handler)
+	    httpSvc := services.NewHTTPServerServiceWithDrainer(server, drainer, 30*time.Second)
 	    tree.AddAPIService(httpSvc)
 
 	    // WebSocket hub
@@ -10,9 +10,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
 )
 
+// drainPollInterval is how often a draining server checks whether its
+// in-flight requests have finished.
+const drainPollInterval = 50 * time.Millisecond
+
+// drainRetryAfter is the Retry-After value, in seconds, of requests
+// rejected while draining. A restarted instance is usually up by then.
+const drainRetryAfter = "5"
+
 // HTTPServer interface matches *http.Server lifecycle methods.
 //
 // This interface allows the HTTPServerService to work with http.Server
@@ -33,15 +44,18 @@ type HTTPServer interface {
 //
 //  1. Starts ListenAndServe in a goroutine
 //  2. Waits for either context cancellation or server error
-//  3. On shutdown, calls Shutdown with the provided timeout
+//  3. On shutdown, drains in-flight requests (with a RequestDrainer), then
+//     calls Shutdown, all within the provided timeout
 //
 // Example usage:
 //
-//	server := &http.Server{Addr: ":3857", Handler: router}
-//	svc := services.NewHTTPServerService(server, 10*time.Second)
+//	drainer := services.NewRequestDrainer()
+//	server := &http.Server{Addr: ":3857", Handler: drainer.Middleware(router)}
+//	svc := services.NewHTTPServerServiceWithDrainer(server, drainer, 30*time.Second)
 //	tree.AddAPIService(svc)
 type HTTPServerService struct {
 	server          HTTPServer
+	drainer         *RequestDrainer // nil: Shutdown only
 	shutdownTimeout time.Duration
 	name            string
 }
@@ -61,6 +75,22 @@ func NewHTTPServerService(server HTTPServer, shutdownTimeout time.Duration) *HTT
 	}
 }
 
+// NewHTTPServerServiceWithDrainer creates an HTTP server service that
+// drains requests before shutting down. drainer must wrap the server's
+// handler (see RequestDrainer).
+//
+// On shutdown the listener stays open while in-flight requests finish: new
+// requests get 503 Service Unavailable with Connection: close, so load
+// balancers and clients retry elsewhere instead of hitting a closed port.
+// When the requests are done, or shutdownTimeout passes, the server is
+// shut down; requests still running at that point are cut off and counted
+// in the returned error.
+func NewHTTPServerServiceWithDrainer(server HTTPServer, drainer *RequestDrainer, shutdownTimeout time.Duration) *HTTPServerService {
+	svc := NewHTTPServerService(server, shutdownTimeout)
+	svc.drainer = drainer
+	return svc
+}
+
 // Serve implements suture.Service.
 //
 // This method:
@@ -96,9 +126,24 @@ func (h *HTTPServerService) Serve(ctx context.Context) error {
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), h.shutdownTimeout)
 		defer cancel()
 
+		// Let in-flight requests finish while new ones are turned away
+		h.drain(shutdownCtx)
+
 		// Attempt graceful shutdown
 		if err := h.server.Shutdown(shutdownCtx); err != nil {
-			return fmt.Errorf("http server shutdown failed: %w", err)
+			if h.drainer == nil {
+				return fmt.Errorf("http server shutdown failed: %w", err)
+			}
+			inFlight := h.drainer.InFlight()
+			logging.Warn().
+				Int64("in_flight", inFlight).
+				Dur("timeout", h.shutdownTimeout).
+				Msg("HTTP shutdown timeout reached with requests still in flight")
+			if closer, ok := h.server.(interface{ Close() error }); ok {
+				_ = closer.Close() //nolint:errcheck // Best effort; the shutdown error is reported
+			}
+			<-errCh
+			return fmt.Errorf("http server shutdown failed with %d requests in flight: %w", inFlight, err)
 		}
 
 		// Wait for the server goroutine to finish
@@ -107,8 +152,94 @@ func (h *HTTPServerService) Serve(ctx context.Context) error {
 	}
 }
 
+// drain starts rejecting new requests and waits until the in-flight ones
+// finish or ctx is done. It does nothing without a drainer.
+func (h *HTTPServerService) drain(ctx context.Context) {
+	if h.drainer == nil {
+		return
+	}
+	h.drainer.startDraining()
+
+	// Responses of in-flight requests close their connections too
+	if ka, ok := h.server.(interface{ SetKeepAlivesEnabled(bool) }); ok {
+		ka.SetKeepAlivesEnabled(false)
+	}
+
+	inFlight := h.drainer.InFlight()
+	logging.Info().Int64("in_flight", inFlight).Dur("timeout", h.shutdownTimeout).Msg("Draining HTTP requests")
+	if h.drainer.wait(ctx) == 0 && inFlight > 0 {
+		logging.Info().Msg("All in-flight HTTP requests finished")
+	}
+}
+
 // String implements fmt.Stringer for logging.
 // Suture uses this to identify the service in log messages.
 func (h *HTTPServerService) String() string {
 	return h.name
 }
+
+// RequestDrainer counts in-flight requests and, once the server starts
+// shutting down, turns new ones away. Wrap the server's whole handler with
+// Middleware and pass the drainer to NewHTTPServerServiceWithDrainer.
+type RequestDrainer struct {
+	draining atomic.Bool
+	inFlight atomic.Int64
+}
+
+// NewRequestDrainer creates a drainer that accepts requests.
+func NewRequestDrainer() *RequestDrainer {
+	return &RequestDrainer{}
+}
+
+// Middleware counts the requests next serves. While draining it answers
+// 503 Service Unavailable with Connection: close and Retry-After instead.
+func (d *RequestDrainer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Count before checking, so a drain that starts in between waits
+		// for this request or the request sees the drain
+		d.inFlight.Add(1)
+		defer d.inFlight.Add(-1)
+
+		if d.draining.Load() {
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Retry-After", drainRetryAfter)
+			http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// InFlight returns the number of requests being served, including ones
+// being rejected while draining.
+func (d *RequestDrainer) InFlight() int64 {
+	return d.inFlight.Load()
+}
+
+// Draining reports whether new requests are being turned away.
+func (d *RequestDrainer) Draining() bool {
+	return d.draining.Load()
+}
+
+// startDraining makes Middleware reject new requests.
+func (d *RequestDrainer) startDraining() {
+	d.draining.Store(true)
+}
+
+// wait blocks until no request is in flight or ctx is done, and returns
+// the number still in flight.
+func (d *RequestDrainer) wait(ctx context.Context) int64 {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		n := d.inFlight.Load()
+		if n == 0 {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return d.inFlight.Load()
+		case <-ticker.C:
+		}
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -249,3 +252,125 @@ func TestHTTPServerService_WithSupervisor(t *testing.T) {
 		t.Error("server Shutdown was not called")
 	}
 }
+
+func TestRequestDrainer_Middleware(t *testing.T) {
+	d := NewRequestDrainer()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handler := d.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// An in-flight request is counted
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		done <- rec
+	}()
+	<-started
+	if got := d.InFlight(); got != 1 {
+		t.Fatalf("InFlight() = %d, want 1", got)
+	}
+
+	// New requests are rejected while draining
+	d.startDraining()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/new", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status while draining = %d, want 503", rec.Code)
+	}
+	if got := rec.Header().Get("Connection"); got != "close" {
+		t.Errorf("Connection = %q, want close", got)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After not set")
+	}
+
+	// The in-flight request still completes
+	close(release)
+	if rec := <-done; rec.Code != http.StatusOK {
+		t.Errorf("in-flight status = %d, want 200", rec.Code)
+	}
+	if got := d.InFlight(); got != 0 {
+		t.Errorf("InFlight() after completion = %d, want 0", got)
+	}
+}
+
+func TestHTTPServerService_Drain(t *testing.T) {
+	t.Run("waits for in-flight requests", func(t *testing.T) {
+		mock := newMockHTTPServer()
+		mock.listenAndServeBlock = true
+		drainer := NewRequestDrainer()
+		svc := NewHTTPServerServiceWithDrainer(mock, drainer, 5*time.Second)
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		handler := drainer.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}))
+		go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		<-started
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() { errCh <- svc.Serve(ctx) }()
+		<-mock.listenAndServeCalled
+		cancel()
+
+		// Shutdown waits until the request finishes
+		time.Sleep(3 * drainPollInterval)
+		if !drainer.Draining() {
+			t.Error("drainer not draining after cancel")
+		}
+		if mock.ShutdownCallCount() != 0 {
+			t.Fatal("Shutdown called with a request in flight")
+		}
+		close(release)
+
+		if err := <-errCh; !errors.Is(err, context.Canceled) {
+			t.Errorf("Serve() error = %v, want context.Canceled", err)
+		}
+		if mock.ShutdownCallCount() != 1 {
+			t.Errorf("Shutdown called %d times, want 1", mock.ShutdownCallCount())
+		}
+	})
+
+	t.Run("reports requests left at the timeout", func(t *testing.T) {
+		mock := newMockHTTPServer()
+		mock.listenAndServeBlock = true
+		mock.shutdownErr = context.DeadlineExceeded
+		drainer := NewRequestDrainer()
+		svc := NewHTTPServerServiceWithDrainer(mock, drainer, 100*time.Millisecond)
+
+		release := make(chan struct{})
+		defer close(release)
+		var started sync.WaitGroup
+		handler := drainer.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started.Done()
+			<-release
+		}))
+		started.Add(2)
+		for i := 0; i < 2; i++ {
+			go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}
+		started.Wait()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() { errCh <- svc.Serve(ctx) }()
+		<-mock.listenAndServeCalled
+		cancel()
+
+		err := <-errCh
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Serve() error = %v, want context.DeadlineExceeded", err)
+		}
+		if !strings.Contains(err.Error(), "2 requests in flight") {
+			t.Errorf("Serve() error = %q, want the in-flight count", err)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package services
+
+import (
+	"context"
+)
+
+// TrainingCoordinator matches the recommendation training coordinator's
+// blocking Run method.
+//
+// The interface is satisfied by *recommend.TrainingCoordinator from
+// internal/recommend/coordinator.go.
+type TrainingCoordinator interface {
+	Run(ctx context.Context) error
+}
+
+// RecommendTrainingService wraps the training coordinator as a supervised service.
+//
+// Run trains under the Serve context, so an in-progress training run is
+// canceled when the supervisor shuts down rather than holding up shutdown
+// until it finishes.
+type RecommendTrainingService struct {
+	coordinator TrainingCoordinator
+	name        string
+}
+
+// NewRecommendTrainingService creates a new training coordinator service wrapper.
+//
+// Example usage:
+//
+//	coordinator := recommend.NewTrainingCoordinator(engine, cfg, logger)
+//	svc := services.NewRecommendTrainingService(coordinator)
+//	tree.AddMessagingService(svc)
+func NewRecommendTrainingService(coordinator TrainingCoordinator) *RecommendTrainingService {
+	return &RecommendTrainingService{
+		coordinator: coordinator,
+		name:        "recommend-training",
+	}
+}
+
+// Serve implements suture.Service.
+// It blocks in the coordinator's scheduling loop until ctx is canceled.
+func (s *RecommendTrainingService) Serve(ctx context.Context) error {
+	return s.coordinator.Run(ctx)
+}
+
+// String implements fmt.Stringer for logging.
+// Suture uses this to identify the service in log messages.
+func (s *RecommendTrainingService) String() string {
+	return s.name
+}
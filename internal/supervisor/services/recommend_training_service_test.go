@@ -0,0 +1,55 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockTrainingCoordinator struct {
+	started chan struct{}
+}
+
+func (m *mockTrainingCoordinator) Run(ctx context.Context) error {
+	close(m.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRecommendTrainingService_String(t *testing.T) {
+	svc := NewRecommendTrainingService(&mockTrainingCoordinator{})
+	if got := svc.String(); got != "recommend-training" {
+		t.Errorf("String() = %q, want %q", got, "recommend-training")
+	}
+}
+
+func TestRecommendTrainingService_ServeStopsOnCancel(t *testing.T) {
+	coordinator := &mockTrainingCoordinator{started: make(chan struct{})}
+	svc := NewRecommendTrainingService(coordinator)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- svc.Serve(ctx) }()
+
+	select {
+	case <-coordinator.started:
+	case <-time.After(time.Second):
+		t.Fatal("coordinator Run was not called")
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Serve() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Serve did not return after cancel")
+	}
+}
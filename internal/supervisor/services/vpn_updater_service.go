@@ -0,0 +1,56 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package services
+
+import (
+	"context"
+)
+
+// VPNUpdater interface matches the vpn.Updater's RunWithContext method.
+//
+// Satisfied by *vpn.Updater from internal/vpn/updater.go.
+type VPNUpdater interface {
+	// RunWithContext runs scheduled VPN data updates.
+	// It returns when the context is canceled.
+	RunWithContext(ctx context.Context) error
+}
+
+// VPNUpdaterService wraps the VPN data updater as a supervised service.
+//
+// The updater refreshes the VPN dataset from gluetun's servers.json on
+// VPN_UPDATE_INTERVAL. Failed updates are recorded and retried at the next
+// interval without stopping the service.
+//
+// Example usage:
+//
+//	updater := vpn.NewUpdaterWithStore(vpnService, store, updaterConfig)
+//	svc := services.NewVPNUpdaterService(updater)
+//	tree.AddMessagingService(svc)
+type VPNUpdaterService struct {
+	updater VPNUpdater
+	name    string
+}
+
+// NewVPNUpdaterService creates a new VPN updater service wrapper.
+func NewVPNUpdaterService(updater VPNUpdater) *VPNUpdaterService {
+	return &VPNUpdaterService{
+		updater: updater,
+		name:    "vpn-updater",
+	}
+}
+
+// Serve implements suture.Service.
+//
+// The method returns ctx.Err() on normal shutdown.
+func (v *VPNUpdaterService) Serve(ctx context.Context) error {
+	return v.updater.RunWithContext(ctx)
+}
+
+// String implements fmt.Stringer for logging.
+// Suture uses this to identify the service in log messages.
+func (v *VPNUpdaterService) String() string {
+	return v.name
+}
@@ -0,0 +1,44 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+)
+
+func TestVPNUpdaterService_Serve(t *testing.T) {
+	t.Parallel()
+
+	var _ suture.Service = (*VPNUpdaterService)(nil)
+
+	// mockDetectionEngine has the same RunWithContext method
+	updater := newMockDetectionEngine()
+	updater.runBlocks = true
+	svc := NewVPNUpdaterService(updater)
+	if svc.String() != "vpn-updater" {
+		t.Errorf("String() = %q, want vpn-updater", svc.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- svc.Serve(ctx) }()
+
+	select {
+	case <-updater.runStarted:
+	case <-time.After(time.Second):
+		t.Fatal("updater did not start")
+	}
+	cancel()
+
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Errorf("Serve() error = %v, want context.Canceled", err)
+	}
+}
@@ -31,6 +31,11 @@ type TreeConfig struct {
 	// ShutdownTimeout is the maximum time to wait for graceful shutdown.
 	// Default: 10s
 	ShutdownTimeout time.Duration
+
+	// StabilityWindow is how long a service must run after failing before
+	// Ready reports it healthy again.
+	// Default: 30s
+	StabilityWindow time.Duration
 }
 
 // DefaultTreeConfig returns production-ready defaults.
@@ -41,6 +46,7 @@ func DefaultTreeConfig() TreeConfig {
 		FailureDecay:     30.0,
 		FailureBackoff:   15 * time.Second,
 		ShutdownTimeout:  10 * time.Second,
+		StabilityWindow:  30 * time.Second,
 	}
 }
 
@@ -60,6 +66,7 @@ type SupervisorTree struct {
 	api       *suture.Supervisor
 	logger    *slog.Logger
 	config    TreeConfig
+	health    *serviceHealth
 }
 
 // NewSupervisorTree creates a new supervisor tree with the given configuration.
@@ -77,13 +84,23 @@ func NewSupervisorTree(logger *slog.Logger, config TreeConfig) (*SupervisorTree,
 	if config.ShutdownTimeout == 0 {
 		config.ShutdownTimeout = 10 * time.Second
 	}
+	if config.StabilityWindow == 0 {
+		config.StabilityWindow = 30 * time.Second
+	}
 
 	// Create event hook using sutureslog.
 	// IMPORTANT: The correct API is (&Handler{Logger: logger}).MustHook()
 	// NOT sutureslog.EventHook(logger) which does not exist.
 	// MustHook has a pointer receiver, so we need to take the address.
 	handler := &sutureslog.Handler{Logger: logger}
-	eventHook := handler.MustHook()
+	logHook := handler.MustHook()
+
+	// Record failures and backoffs for Ready alongside logging them
+	health := newServiceHealth(config.StabilityWindow)
+	eventHook := func(e suture.Event) {
+		logHook(e)
+		health.hook(e)
+	}
 
 	rootSpec := suture.Spec{
 		EventHook:        eventHook,
@@ -119,6 +136,7 @@ func NewSupervisorTree(logger *slog.Logger, config TreeConfig) (*SupervisorTree,
 		api:       api,
 		logger:    logger,
 		config:    config,
+		health:    health,
 	}, nil
 }
 
@@ -163,6 +181,16 @@ func (t *SupervisorTree) ServeBackground(ctx context.Context) <-chan error {
 	return t.root.ServeBackground(ctx)
 }
 
+// Ready reports whether the supervised services are healthy, for the
+// readiness probe. A service is unhealthy while its supervisor is in
+// backoff after repeated failures, and until it has run for
+// StabilityWindow since it last failed. Returns the sorted names of the
+// unhealthy services (or of a backed-off layer whose failed services are
+// unknown); the list is empty when ready.
+func (t *SupervisorTree) Ready() (bool, []string) {
+	return t.health.ready()
+}
+
 // UnstoppedServiceReport returns information about services that failed to stop
 // within the configured shutdown timeout. Useful for debugging shutdown issues.
 func (t *SupervisorTree) UnstoppedServiceReport() ([]suture.UnstoppedService, error) {
@@ -12,6 +12,8 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/thejerf/suture/v4"
 )
 
 func TestSupervisorTreeConstruction(t *testing.T) {
@@ -231,4 +233,119 @@ func TestDefaultTreeConfig(t *testing.T) {
 	if config.ShutdownTimeout != 10*time.Second {
 		t.Errorf("expected ShutdownTimeout 10s, got %v", config.ShutdownTimeout)
 	}
+	if config.StabilityWindow != 30*time.Second {
+		t.Errorf("expected StabilityWindow 30s, got %v", config.StabilityWindow)
+	}
+}
+
+func TestSupervisorTreeReady(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	// waitReady polls Ready until it reports want or the deadline passes.
+	waitReady := func(t *testing.T, tree *SupervisorTree, want bool) []string {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			ready, unhealthy := tree.Ready()
+			if ready == want {
+				return unhealthy
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Ready() = %v (unhealthy %v), want %v", ready, unhealthy, want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	t.Run("ready with stable services", func(t *testing.T) {
+		tree, err := NewSupervisorTree(logger, TreeConfig{ShutdownTimeout: time.Second})
+		if err != nil {
+			t.Fatalf("failed to create tree: %v", err)
+		}
+		tree.AddAPIService(NewMockService("stable"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tree.ServeBackground(ctx)
+		time.Sleep(50 * time.Millisecond)
+
+		ready, unhealthy := tree.Ready()
+		if !ready || len(unhealthy) != 0 {
+			t.Errorf("Ready() = %v, %v; want true with no unhealthy services", ready, unhealthy)
+		}
+	})
+
+	t.Run("crashing service is unhealthy until it stabilizes", func(t *testing.T) {
+		tree, err := NewSupervisorTree(logger, TreeConfig{
+			FailureThreshold: 2,
+			FailureBackoff:   100 * time.Millisecond,
+			ShutdownTimeout:  time.Second,
+			StabilityWindow:  200 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("failed to create tree: %v", err)
+		}
+		crashing := NewMockService("crashing")
+		crashing.SetError(errors.New("boom"))
+		tree.AddMessagingService(crashing)
+		tree.AddAPIService(NewMockService("stable"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tree.ServeBackground(ctx)
+
+		unhealthy := waitReady(t, tree, false)
+		if len(unhealthy) != 1 || unhealthy[0] != "crashing" {
+			t.Errorf("unhealthy = %v, want [crashing]", unhealthy)
+		}
+
+		// Stops crashing; ready once it has run for the stability window
+		crashing.SetError(nil)
+		stopped := time.Now()
+		waitReady(t, tree, true)
+		if elapsed := time.Since(stopped); elapsed < 200*time.Millisecond {
+			t.Errorf("ready after %v, want at least the 200ms stability window", elapsed)
+		}
+	})
+}
+
+func TestServiceHealth(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	health := newServiceHealth(30 * time.Second)
+	health.now = func() time.Time { return now }
+
+	assertReady := func(t *testing.T, want bool, wantUnhealthy ...string) {
+		t.Helper()
+		ready, unhealthy := health.ready()
+		if ready != want || len(unhealthy) != len(wantUnhealthy) {
+			t.Fatalf("ready() = %v, %v; want %v, %v", ready, unhealthy, want, wantUnhealthy)
+		}
+		for i := range unhealthy {
+			if unhealthy[i] != wantUnhealthy[i] {
+				t.Fatalf("ready() = %v, %v; want %v, %v", ready, unhealthy, want, wantUnhealthy)
+			}
+		}
+	}
+
+	assertReady(t, true)
+
+	health.hook(suture.EventServiceTerminate{SupervisorName: "messaging-layer", ServiceName: "sync"})
+	health.hook(suture.EventServicePanic{SupervisorName: "data-layer", ServiceName: "wal"})
+	assertReady(t, false, "sync", "wal")
+
+	// Persistent backoff keeps the layer's services unhealthy past the window
+	health.hook(suture.EventBackoff{SupervisorName: "messaging-layer"})
+	now = now.Add(time.Minute)
+	assertReady(t, false, "sync")
+
+	// Resumed services must run for the window again
+	health.hook(suture.EventResume{SupervisorName: "messaging-layer"})
+	now = now.Add(20 * time.Second)
+	assertReady(t, false, "sync")
+	now = now.Add(10 * time.Second)
+	assertReady(t, true)
+
+	// A backoff with no recorded failure reports the layer
+	health.hook(suture.EventBackoff{SupervisorName: "api-layer"})
+	assertReady(t, false, "api-layer")
 }
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	gobreaker "github.com/sony/gobreaker/v2"
@@ -27,27 +28,76 @@ import (
 // - Tests should use appropriate waits or mock the underlying client, not the breaker
 // - For unit tests, consider testing the wrapped client directly
 type CircuitBreakerClient struct {
-	client *TautulliClient
-	cb     *gobreaker.CircuitBreaker[interface{}]
-	name   string
+	client   *TautulliClient
+	cb       *gobreaker.CircuitBreaker[interface{}]
+	name     string
+	notifier *stateChangeNotifier
 }
 
-// NewCircuitBreakerClient creates a new Tautulli client with circuit breaker
+// StateChangeFunc is invoked once per circuit breaker state transition.
+// name is the breaker's metric label (e.g. "jellyfin-api:home"); from and to
+// are "closed", "half-open", or "open".
+//
+// The callback runs synchronously while the breaker holds its internal lock,
+// so it must be fast and must not call back into the same breaker.
+type StateChangeFunc func(name, from, to string)
+
+// stateChangeObservable is implemented by clients that wrap a circuit breaker
+type stateChangeObservable interface {
+	OnStateChange(fn StateChangeFunc)
+}
+
+// stateChangeNotifier holds an optional StateChangeFunc that can be set after
+// the breaker is built (gobreaker settings are immutable once created)
+type stateChangeNotifier struct {
+	mu sync.RWMutex
+	fn StateChangeFunc
+}
+
+// set replaces the registered callback (nil removes it)
+func (n *stateChangeNotifier) set(fn StateChangeFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.fn = fn
+}
+
+// notify invokes the registered callback, if any
+func (n *stateChangeNotifier) notify(name, from, to string) {
+	n.mu.RLock()
+	fn := n.fn
+	n.mu.RUnlock()
+	if fn != nil {
+		fn(name, from, to)
+	}
+}
+
+// circuitBreakerName builds the per-client metric label for a breaker.
+// Multi-server deployments get one breaker per server (e.g. "plex-api:office"),
+// so operators can see which media server is failing.
+func circuitBreakerName(prefix, serverID string) string {
+	if serverID == "" {
+		return prefix
+	}
+	return prefix + ":" + serverID
+}
+
+// newMediaCircuitBreaker creates a circuit breaker with the shared media client settings
 // Circuit breaker configuration:
 // - Max 3 concurrent requests in half-open state
 // - 1 minute measurement window
 // - 2 minute timeout before attempting recovery
 // - Opens after 60% failure rate with minimum 10 requests
-func NewCircuitBreakerClient(cfg *config.TautulliConfig) *CircuitBreakerClient {
-	client := NewTautulliClient(cfg)
-	cbName := "tautulli-api"
-
+//
+// State transitions update circuit_breaker_state and
+// circuit_breaker_state_transitions_total under the given name, then invoke
+// the notifier's callback.
+func newMediaCircuitBreaker[T any](name, source string, notifier *stateChangeNotifier) *gobreaker.CircuitBreaker[T] {
 	// Initialize circuit breaker state metrics
-	metrics.CircuitBreakerState.WithLabelValues(cbName).Set(0) // 0 = closed
-	metrics.CircuitBreakerConsecutiveFailures.WithLabelValues(cbName).Set(0)
+	metrics.CircuitBreakerState.WithLabelValues(name).Set(0) // 0 = closed
+	metrics.CircuitBreakerConsecutiveFailures.WithLabelValues(name).Set(0)
 
-	cb := gobreaker.NewCircuitBreaker[interface{}](gobreaker.Settings{
-		Name:        cbName,
+	return gobreaker.NewCircuitBreaker[T](gobreaker.Settings{
+		Name:        name,
 		MaxRequests: 3,               // Allow 3 concurrent requests in half-open state
 		Interval:    time.Minute,     // Reset counts after 1 minute in closed state
 		Timeout:     2 * time.Minute, // Wait 2 minutes before transitioning from open to half-open
@@ -63,7 +113,7 @@ func NewCircuitBreakerClient(cfg *config.TautulliConfig) *CircuitBreakerClient {
 			shouldTrip := failureRatio >= 0.6
 
 			if shouldTrip {
-				logging.Warn().Uint32("failures", counts.TotalFailures).Float64("failure_rate", failureRatio*100).Msg("[CIRCUIT BREAKER] Opening circuit")
+				logging.Warn().Str("breaker", name).Uint32("failures", counts.TotalFailures).Float64("failure_rate", failureRatio*100).Msgf("[CIRCUIT BREAKER] Opening %s circuit", source)
 			}
 
 			return shouldTrip
@@ -74,7 +124,7 @@ func NewCircuitBreakerClient(cfg *config.TautulliConfig) *CircuitBreakerClient {
 			fromStr := stateToString(from)
 			toStr := stateToString(to)
 
-			logging.Info().Str("from", fromStr).Str("to", toStr).Msg("[CIRCUIT BREAKER] State transition")
+			logging.Info().Str("breaker", name).Str("from", fromStr).Str("to", toStr).Msgf("[CIRCUIT BREAKER] %s state transition", source)
 
 			// Update metrics
 			metrics.CircuitBreakerState.WithLabelValues(name).Set(stateToFloat(to))
@@ -84,16 +134,41 @@ func NewCircuitBreakerClient(cfg *config.TautulliConfig) *CircuitBreakerClient {
 			if to == gobreaker.StateClosed {
 				metrics.CircuitBreakerConsecutiveFailures.WithLabelValues(name).Set(0)
 			}
+
+			notifier.notify(name, fromStr, toStr)
 		},
 	})
+}
+
+// NewCircuitBreakerClient creates a new Tautulli client with circuit breaker
+// Circuit breaker configuration:
+// - Max 3 concurrent requests in half-open state
+// - 1 minute measurement window
+// - 2 minute timeout before attempting recovery
+// - Opens after 60% failure rate with minimum 10 requests
+func NewCircuitBreakerClient(cfg *config.TautulliConfig) *CircuitBreakerClient {
+	client := NewTautulliClient(cfg)
+	cbName := "tautulli-api"
+	notifier := &stateChangeNotifier{}
 
 	return &CircuitBreakerClient{
-		client: client,
-		cb:     cb,
-		name:   cbName,
+		client:   client,
+		cb:       newMediaCircuitBreaker[interface{}](cbName, "Tautulli", notifier),
+		name:     cbName,
+		notifier: notifier,
 	}
 }
 
+// OnStateChange registers a callback invoked on every circuit breaker state transition
+func (cbc *CircuitBreakerClient) OnStateChange(fn StateChangeFunc) {
+	cbc.notifier.set(fn)
+}
+
+// State returns the current circuit breaker state
+func (cbc *CircuitBreakerClient) State() gobreaker.State {
+	return cbc.cb.State()
+}
+
 // execute wraps a Tautulli API call with circuit breaker protection
 // Returns the result or an error if circuit is open or request fails
 func (cbc *CircuitBreakerClient) execute(fn func() (interface{}, error)) (interface{}, error) {
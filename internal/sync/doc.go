@@ -89,6 +89,8 @@ Performance Characteristics:
 Fault Tolerance:
 
   - Circuit Breaker: Automatic failure detection (60% threshold) with 2-minute open state (v1.35)
+    One breaker per Tautulli, Plex, Jellyfin, and Emby client, labeled per server
+    ("plex-api:<server_id>"); transitions are reported via OnStateChange hooks
   - Rate Limiting: Exponential backoff for HTTP 429 (1s, 2s, 4s, 8s, 16s, max 5 retries)
   - Database Reconnection: Automatic reconnection with exponential backoff (v1.10)
   - Graceful Degradation: Uses "Unknown" location if geolocation fails (v1.9)
@@ -105,7 +107,8 @@ Metrics:
 Prometheus metrics are exported for observability:
   - sync_duration_seconds: Sync operation latency
   - sync_records_total: Number of records processed
-  - circuit_breaker_state: Circuit breaker state (closed/open/half-open)
+  - circuit_breaker_state: Circuit breaker state (closed/half-open/open) per client name
+  - circuit_breaker_state_transitions_total: State transitions per client name
   - circuit_breaker_failures_total: Failure counts by state
 
 See Also:
@@ -14,7 +14,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	gobreaker "github.com/sony/gobreaker/v2"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/metrics"
 )
 
 // TestEmbyCircuitBreaker_OpensAfterFailures verifies circuit opens after exceeding failure threshold
@@ -329,3 +333,40 @@ func TestEmbyCircuitBreaker_GetUsersWithCircuitBreaker(t *testing.T) {
 		t.Errorf("Expected first user name 'Test User 1', got '%s'", users[0].Name)
 	}
 }
+
+// TestEmbyManager_CircuitBreakerReportsStateChange verifies the per-server breaker
+// name and that opening the circuit fires the manager's OnStateChange hook once
+func TestEmbyManager_CircuitBreakerReportsStateChange(t *testing.T) {
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	mgr := NewEmbyManager(&config.EmbyConfig{
+		Enabled:  true,
+		URL:      failServer.URL,
+		APIKey:   "test-key",
+		ServerID: "emby-cb-test",
+	}, nil, nil)
+
+	cbc, ok := mgr.client.(*EmbyCircuitBreakerClient)
+	if !ok {
+		t.Fatalf("manager client type = %T, want *EmbyCircuitBreakerClient", mgr.client)
+	}
+	if got, want := cbc.Name(), "emby-api:emby-cb-test"; got != want {
+		t.Fatalf("Name() = %q, want %q", got, want)
+	}
+
+	rec := &stateChangeRecorder{}
+	mgr.OnStateChange(rec.record)
+	transitionsBefore := testutil.ToFloat64(metrics.CircuitBreakerTransitions.WithLabelValues(cbc.Name(), "closed", "open"))
+
+	for i := 0; i < 12; i++ {
+		_ = cbc.Ping(context.Background())
+	}
+
+	if cbc.State() != gobreaker.StateOpen {
+		t.Fatalf("State() = %v, want open", cbc.State())
+	}
+	assertOpenedOnce(t, rec, cbc.Name(), transitionsBefore)
+}
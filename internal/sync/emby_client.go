@@ -386,7 +386,7 @@ func EmbySessionToPlaybackEvent(session *models.EmbySession, _ string) *models.P
 	}
 
 	// IP address
-	event.IPAddress = session.GetIPAddress()
+	event.IPAddress = normalizeIPAddress(session.GetIPAddress())
 
 	// External IDs (for correlation)
 	if item.ProviderIDs != nil {
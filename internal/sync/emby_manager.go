@@ -32,6 +32,9 @@ type EmbyManager struct {
 	eventPublisher EventPublisher
 	wsHub          WebSocketHub
 	userResolver   UserResolver // For resolving external UUIDs to internal user IDs
+
+	// staggerFn computes the poller start offset; nil disables staggering (tests)
+	staggerFn func(serverID string, interval time.Duration) time.Duration
 }
 
 // NewEmbyManager creates a new Emby integration manager
@@ -50,9 +53,10 @@ func NewEmbyManager(cfg *config.EmbyConfig, wsHub WebSocketHub, userResolver Use
 
 	// Use circuit breaker client for resilience against API failures
 	client := NewEmbyCircuitBreakerClient(EmbyCircuitBreakerConfig{
-		BaseURL: cfg.URL,
-		APIKey:  cfg.APIKey,
-		UserID:  cfg.UserID,
+		BaseURL:  cfg.URL,
+		APIKey:   cfg.APIKey,
+		UserID:   cfg.UserID,
+		ServerID: cfg.ServerID,
 	})
 
 	return &EmbyManager{
@@ -60,6 +64,7 @@ func NewEmbyManager(cfg *config.EmbyConfig, wsHub WebSocketHub, userResolver Use
 		cfg:          cfg,
 		wsHub:        wsHub,
 		userResolver: userResolver,
+		staggerFn:    staggerOffset,
 	}
 }
 
@@ -77,6 +82,17 @@ func (m *EmbyManager) ServerID() string {
 	return m.cfg.ServerID
 }
 
+// OnStateChange registers a callback for this server's API circuit breaker
+// transitions (closed, half-open, open). No-op if the client has no breaker.
+func (m *EmbyManager) OnStateChange(fn StateChangeFunc) {
+	if m == nil {
+		return
+	}
+	if observable, ok := m.client.(stateChangeObservable); ok {
+		observable.OnStateChange(fn)
+	}
+}
+
 // Start initializes and starts all enabled Emby services
 func (m *EmbyManager) Start(ctx context.Context) error {
 	if m == nil {
@@ -135,6 +151,15 @@ func (m *EmbyManager) startWebSocket(ctx context.Context) error {
 
 // startSessionPoller initializes and starts the session poller
 func (m *EmbyManager) startSessionPoller(ctx context.Context) error {
+	m.poller = NewEmbySessionPoller(m.client, m.sessionPollerConfig())
+	m.poller.SetOnSession(m.handleNewSession)
+
+	return m.poller.Start(ctx)
+}
+
+// sessionPollerConfig builds the poller configuration, enforcing the minimum
+// interval and staggering the first poll by ServerID.
+func (m *EmbyManager) sessionPollerConfig() SessionPollerConfig {
 	interval := m.cfg.SessionPollingInterval
 	if interval < 10*time.Second {
 		logging.Info().Dur("interval", interval).Msg("WARNING: Polling interval too low, using 10s")
@@ -145,12 +170,13 @@ func (m *EmbyManager) startSessionPoller(ctx context.Context) error {
 		Interval:       interval,
 		PublishAll:     false,
 		SeenSessionTTL: 1 * time.Hour,
+		Source:         "emby",
+		ServerID:       serverIDOrDefault("emby", m.cfg.ServerID),
 	}
-
-	m.poller = NewEmbySessionPoller(m.client, config)
-	m.poller.SetOnSession(m.handleNewSession)
-
-	return m.poller.Start(ctx)
+	if m.staggerFn != nil {
+		config.StartOffset = m.staggerFn(m.cfg.ServerID, interval)
+	}
+	return config
 }
 
 // handleSessionUpdate processes session updates from WebSocket
@@ -938,6 +938,7 @@ func TestEmbyManager_FullLifecycle(t *testing.T) {
 	}
 
 	manager := NewEmbyManager(cfg, hub, nil)
+	manager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	manager.SetEventPublisher(publisher)
 
 	ctx := context.Background()
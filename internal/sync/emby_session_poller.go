@@ -44,6 +44,9 @@ type EmbySessionPoller struct {
 	// LRU cache for session tracking - O(1) operations with automatic eviction
 	seenSessions *cache.LRUCache
 
+	// lastPoll is the time of the last successful session fetch
+	lastPoll time.Time
+
 	// Callbacks
 	onSession func(*models.EmbySession)
 }
@@ -103,6 +106,11 @@ func (p *EmbySessionPoller) Stop() {
 func (p *EmbySessionPoller) pollLoop(ctx context.Context) {
 	defer p.wg.Done()
 
+	// Stagger the first poll across the interval (multi-server deployments)
+	if !waitForStartOffset(ctx, p.stopChan, p.config.StartOffset) {
+		return
+	}
+
 	// Initial poll
 	p.poll(ctx)
 
@@ -128,15 +136,19 @@ func (p *EmbySessionPoller) pollLoop(ctx context.Context) {
 
 // poll fetches sessions and processes new ones
 func (p *EmbySessionPoller) poll(ctx context.Context) {
+	defer p.config.trackPoll()()
+
 	sessions, err := p.client.GetActiveSessions(ctx)
 	if err != nil {
 		logging.Info().Err(err).Msg("Failed to fetch sessions")
 		return
 	}
 
-	p.mu.RLock()
+	p.mu.Lock()
+	p.lastPoll = time.Now()
 	callback := p.onSession
-	p.mu.RUnlock()
+	p.mu.Unlock()
+	p.config.recordPollSuccess()
 
 	for i := range sessions {
 		session := &sessions[i]
@@ -158,6 +170,14 @@ func (p *EmbySessionPoller) poll(ctx context.Context) {
 	}
 }
 
+// LastPollTime returns the time of the last successful session fetch,
+// or the zero time if no poll has succeeded yet.
+func (p *EmbySessionPoller) LastPollTime() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.lastPoll
+}
+
 // hasSeenSession checks if a session was recently processed
 // Uses LRUCache.Contains for O(1) lookup.
 func (p *EmbySessionPoller) hasSeenSession(sessionID string) bool {
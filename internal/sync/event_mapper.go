@@ -42,7 +42,7 @@ func (m *Manager) buildCoreEvent(record *tautulli.TautulliHistoryRecord) *models
 		StartedAt:       time.Unix(record.Started, 0),
 		UserID:          userID,
 		Username:        record.User,
-		IPAddress:       record.IPAddress,
+		IPAddress:       normalizeIPAddress(record.IPAddress),
 		MediaType:       record.MediaType,
 		Title:           record.Title,
 		Platform:        record.Platform,
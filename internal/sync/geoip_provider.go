@@ -7,16 +7,16 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"strings"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
 
-	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
@@ -34,6 +34,11 @@ type GeoIPProvider interface {
 	IsAvailable() bool
 }
 
+// ErrGeoIPRateLimited is returned when a provider's rate limit is exhausted,
+// either by the local token bucket or an HTTP 429 from the service.
+// GeoIPResolver treats it as a signal to fail over to the next provider.
+var ErrGeoIPRateLimited = errors.New("GeoIP provider rate limited")
+
 // ========================================
 // MaxMind GeoLite2 Provider
 // ========================================
@@ -169,6 +174,9 @@ func checkMaxMindResponse(resp *http.Response) error {
 	if resp.StatusCode == http.StatusOK {
 		return nil
 	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("%w: MaxMind returned status 429", ErrGeoIPRateLimited)
+	}
 
 	var errResp maxMindErrorResponse
 	if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Error != "" {
@@ -218,10 +226,17 @@ func setOptionalMaxMindFields(geo *models.Geolocation, result *maxMindResponse)
 // IPAPIProvider implements GeoIPProvider using the free ip-api.com service.
 // Rate limit: 45 requests per minute (free tier, no API key required).
 // For higher limits, commercial endpoints are available at pro.ip-api.com.
+//
+// Requests are paced by a local token bucket. The provider also honors the
+// X-Rl (requests remaining) and X-Ttl (seconds until reset) headers and
+// HTTP 429 responses, reporting itself unavailable until the window resets.
 type IPAPIProvider struct {
 	client      *http.Client
 	rateLimiter *rateLimiter
 	baseURL     string
+
+	mu           sync.Mutex
+	blockedUntil time.Time
 }
 
 // ipAPIResponse represents the JSON response from ip-api.com
@@ -297,9 +312,37 @@ func (p *IPAPIProvider) Name() string {
 	return "ip-api.com"
 }
 
-// IsAvailable returns true (ip-api.com doesn't require API key).
+// IsAvailable returns false while ip-api.com has reported the rate limit as
+// exhausted (ip-api.com doesn't require API key).
 func (p *IPAPIProvider) IsAvailable() bool {
-	return true
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return time.Now().After(p.blockedUntil)
+}
+
+// blockFor marks the provider unavailable for d.
+func (p *IPAPIProvider) blockFor(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(d); until.After(p.blockedUntil) {
+		p.blockedUntil = until
+	}
+}
+
+// observeRateLimitHeaders blocks the provider until the window resets when
+// ip-api.com reports no requests remaining or responds with 429.
+func (p *IPAPIProvider) observeRateLimitHeaders(resp *http.Response) {
+	remaining := resp.Header.Get("X-Rl")
+	if resp.StatusCode != http.StatusTooManyRequests && remaining != "0" {
+		return
+	}
+
+	// Default to a full window if X-Ttl is missing or malformed
+	ttl := time.Minute
+	if seconds, err := strconv.Atoi(resp.Header.Get("X-Ttl")); err == nil && seconds >= 0 {
+		ttl = time.Duration(seconds) * time.Second
+	}
+	p.blockFor(ttl)
 }
 
 // Lookup queries ip-api.com for geolocation data.
@@ -318,7 +361,7 @@ func (p *IPAPIProvider) Lookup(ctx context.Context, ipAddress string) (*models.G
 
 func (p *IPAPIProvider) validateIPAPILookup(ipAddress string) error {
 	if !p.rateLimiter.Allow() {
-		return fmt.Errorf("rate limit exceeded for ip-api.com (45 req/min)")
+		return fmt.Errorf("%w: ip-api.com (45 req/min)", ErrGeoIPRateLimited)
 	}
 
 	if ip := net.ParseIP(ipAddress); ip == nil {
@@ -345,6 +388,10 @@ func (p *IPAPIProvider) queryIPAPI(ctx context.Context, ipAddress string) (*ipAP
 	}
 	defer resp.Body.Close()
 
+	p.observeRateLimitHeaders(resp)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("%w: ip-api.com returned status 429", ErrGeoIPRateLimited)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("ip-api.com returned status %d", resp.StatusCode)
 	}
@@ -389,213 +436,14 @@ func setOptionalIPAPIFields(geo *models.Geolocation, result *ipAPIResponse) {
 	}
 }
 
-// IsPrivateIP checks if the IP address is in a private/local range.
-// Private IPs cannot be geolocated and should be handled specially.
-func IsPrivateIP(ipStr string) bool {
-	ip := net.ParseIP(ipStr)
-	if ip == nil {
-		return false
-	}
-
-	return isInPrivateRanges(ip)
-}
-
-func isInPrivateRanges(ip net.IP) bool {
-	// Check for IPv4 private ranges
-	// RFC 1918: 10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16
-	// Loopback: 127.0.0.0/8
-	// Link-local: 169.254.0.0/16
-	privateRanges := []string{
-		"10.0.0.0/8",
-		"172.16.0.0/12",
-		"192.168.0.0/16",
-		"127.0.0.0/8",
-		"169.254.0.0/16",
-		"::1/128",   // IPv6 loopback
-		"fc00::/7",  // IPv6 unique local
-		"fe80::/10", // IPv6 link-local
-	}
-
-	for _, cidr := range privateRanges {
-		if isInCIDR(ip, cidr) {
-			return true
-		}
-	}
-
-	return false
-}
-
-func isInCIDR(ip net.IP, cidr string) bool {
-	_, network, err := net.ParseCIDR(cidr)
-	if err != nil {
-		return false
-	}
-	return network.Contains(ip)
-}
-
-// IsValidPublicIP checks if the IP address is a valid public (routable) IP.
-func IsValidPublicIP(ipStr string) bool {
-	ip := net.ParseIP(ipStr)
-	if ip == nil {
-		return false
-	}
-
-	// Empty or unspecified IP
-	if ip.IsUnspecified() {
-		return false
-	}
-
-	// Not a private IP
-	if IsPrivateIP(ipStr) {
-		return false
-	}
-
-	return true
-}
-
-// CreateLocalGeolocation creates a geolocation entry for private/LAN IPs.
-// These are marked with "Local Network" as the country for filtering purposes.
-func CreateLocalGeolocation(ipAddress string) *models.Geolocation {
-	local := "Local Network"
+// CreateUnknownGeolocation creates the fallback geolocation used when every
+// source fails. The 0,0 coordinates are filtered out in map visualizations.
+func CreateUnknownGeolocation(ipAddress string) *models.Geolocation {
 	return &models.Geolocation{
 		IPAddress:   ipAddress,
 		Latitude:    0,
 		Longitude:   0,
-		Country:     "Local",
-		City:        &local,
+		Country:     "Unknown",
 		LastUpdated: time.Now(),
 	}
 }
-
-// GeoIPResolver handles geolocation resolution with provider fallback and caching.
-type GeoIPResolver struct {
-	providers []GeoIPProvider
-	db        GeolocationDB
-}
-
-// GeolocationDB defines the database interface for geolocation caching.
-type GeolocationDB interface {
-	GetGeolocation(ctx context.Context, ipAddress string) (*models.Geolocation, error)
-	UpsertGeolocation(geo *models.Geolocation) error
-}
-
-// NewGeoIPResolver creates a new resolver with the given providers.
-// Providers are tried in order until one succeeds.
-func NewGeoIPResolver(db GeolocationDB, providers ...GeoIPProvider) *GeoIPResolver {
-	return &GeoIPResolver{
-		providers: providers,
-		db:        db,
-	}
-}
-
-// Resolve fetches geolocation for an IP, using cache first, then providers.
-func (r *GeoIPResolver) Resolve(ctx context.Context, ipAddress string) (*models.Geolocation, error) {
-	ipAddress = normalizeIPAddress(ipAddress)
-
-	if IsPrivateIP(ipAddress) {
-		return r.handlePrivateIP(ctx, ipAddress)
-	}
-
-	if geo := r.tryCache(ctx, ipAddress); geo != nil {
-		return geo, nil
-	}
-
-	return r.tryProviders(ctx, ipAddress)
-}
-
-func (r *GeoIPResolver) handlePrivateIP(_ context.Context, ipAddress string) (*models.Geolocation, error) {
-	logging.Debug().Str("ip", ipAddress).Msg("IP is private/LAN, creating local geolocation")
-	geo := CreateLocalGeolocation(ipAddress)
-
-	// Cache it to avoid repeated checks
-	if r.db != nil {
-		if err := r.db.UpsertGeolocation(geo); err != nil {
-			logging.Warn().Err(err).Str("ip", ipAddress).Msg("Failed to cache local geolocation")
-		}
-	}
-
-	return geo, nil
-}
-
-func (r *GeoIPResolver) tryCache(ctx context.Context, ipAddress string) *models.Geolocation {
-	if r.db == nil {
-		return nil
-	}
-
-	geo, err := r.db.GetGeolocation(ctx, ipAddress)
-	if err == nil && geo != nil {
-		return geo
-	}
-
-	return nil
-}
-
-func (r *GeoIPResolver) tryProviders(ctx context.Context, ipAddress string) (*models.Geolocation, error) {
-	var lastErr error
-
-	for _, provider := range r.providers {
-		if !provider.IsAvailable() {
-			continue
-		}
-
-		geo, err := provider.Lookup(ctx, ipAddress)
-		if err != nil {
-			logging.Debug().Err(err).Str("provider", provider.Name()).Str("ip", ipAddress).Msg("GeoIP provider failed")
-			lastErr = err
-			continue
-		}
-
-		r.cacheGeolocation(ipAddress, geo)
-		return geo, nil
-	}
-
-	return nil, r.buildProviderError(ipAddress, lastErr)
-}
-
-func (r *GeoIPResolver) cacheGeolocation(ipAddress string, geo *models.Geolocation) {
-	if r.db == nil {
-		return
-	}
-
-	if err := r.db.UpsertGeolocation(geo); err != nil {
-		logging.Warn().Err(err).Str("ip", ipAddress).Msg("Failed to cache geolocation")
-	}
-}
-
-func (r *GeoIPResolver) buildProviderError(ipAddress string, lastErr error) error {
-	if lastErr != nil {
-		return fmt.Errorf("all GeoIP providers failed for %s: %w", ipAddress, lastErr)
-	}
-	return fmt.Errorf("no GeoIP providers available")
-}
-
-// normalizeIPAddress strips port from IP address if present
-func normalizeIPAddress(ipAddr string) string {
-	if strings.HasPrefix(ipAddr, "[") {
-		return normalizeIPv6Address(ipAddr)
-	}
-	return normalizeIPv4Address(ipAddr)
-}
-
-func normalizeIPv6Address(ipAddr string) string {
-	// Handle IPv6 with port: [::1]:8096 -> ::1
-	if idx := strings.LastIndex(ipAddr, "]:"); idx != -1 {
-		return ipAddr[1:idx]
-	}
-	// Remove brackets if no port
-	return strings.Trim(ipAddr, "[]")
-}
-
-func normalizeIPv4Address(ipAddr string) string {
-	// Handle IPv4 with port: 192.168.1.1:8096 -> 192.168.1.1
-	// Only strip if it looks like host:port (single colon)
-	if strings.Count(ipAddr, ":") != 1 {
-		return ipAddr
-	}
-
-	if idx := strings.LastIndex(ipAddr, ":"); idx != -1 {
-		return ipAddr[:idx]
-	}
-
-	return ipAddr
-}
@@ -7,6 +7,7 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -122,8 +123,16 @@ func TestNormalizeIPAddress(t *testing.T) {
 		// IPv6 bracketed without port
 		{"IPv6 bracketed no port", "[::1]", "::1"},
 
+		// IPv6 canonical form
+		{"IPv6 uppercase expanded", "2001:0DB8:0000:0000:0000:0000:0000:0001", "2001:db8::1"},
+		{"IPv6 zone", "fe80::1%eth0", "fe80::1"},
+		{"IPv4-mapped IPv6", "::ffff:192.168.1.10", "192.168.1.10"},
+		{"IPv4-mapped IPv6 with port", "[::ffff:8.8.8.8]:32400", "8.8.8.8"},
+
 		// Edge cases
 		{"empty string", "", ""},
+		{"whitespace", " 8.8.8.8 ", "8.8.8.8"},
+		{"not an IP", "N/A", "N/A"},
 	}
 
 	for _, tt := range tests {
@@ -267,6 +276,74 @@ func TestIPAPIProvider_Lookup_FailedResponse(t *testing.T) {
 	}
 }
 
+func TestIPAPIProvider_Lookup_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rl", "0")
+		w.Header().Set("X-Ttl", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	provider := &IPAPIProvider{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		rateLimiter: newRateLimiter(45, time.Minute/45),
+		baseURL:     server.URL + "/json",
+	}
+
+	_, err := provider.Lookup(context.Background(), "8.8.8.8")
+	if !errors.Is(err, ErrGeoIPRateLimited) {
+		t.Fatalf("Lookup() error = %v, want ErrGeoIPRateLimited", err)
+	}
+	if provider.IsAvailable() {
+		t.Error("IsAvailable() = true after 429, expected false until X-Ttl elapses")
+	}
+}
+
+func TestIPAPIProvider_Lookup_LastRequestInWindow(t *testing.T) {
+	// ip-api.com reports X-Rl: 0 on the last allowed request of the window
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Rl", "0")
+		w.Header().Set("X-Ttl", "0")
+		json.NewEncoder(w).Encode(ipAPIResponse{Status: "success", Country: "United States"})
+	}))
+	defer server.Close()
+
+	provider := &IPAPIProvider{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		rateLimiter: newRateLimiter(45, time.Minute/45),
+		baseURL:     server.URL + "/json",
+	}
+
+	if _, err := provider.Lookup(context.Background(), "8.8.8.8"); err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+
+	// X-Ttl 0 means the window has already reset
+	time.Sleep(time.Millisecond)
+	if !provider.IsAvailable() {
+		t.Error("IsAvailable() = false, expected true once X-Ttl has elapsed")
+	}
+
+	provider.blockFor(time.Minute)
+	if provider.IsAvailable() {
+		t.Error("IsAvailable() = true while blocked")
+	}
+}
+
+func TestIPAPIProvider_Lookup_TokenBucketExhausted(t *testing.T) {
+	provider := &IPAPIProvider{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		rateLimiter: newRateLimiter(0, time.Hour),
+		baseURL:     "http://127.0.0.1:0/json",
+	}
+
+	_, err := provider.Lookup(context.Background(), "8.8.8.8")
+	if !errors.Is(err, ErrGeoIPRateLimited) {
+		t.Errorf("Lookup() error = %v, want ErrGeoIPRateLimited", err)
+	}
+}
+
 func TestIPAPIProvider_Name(t *testing.T) {
 	provider := NewIPAPIProvider()
 	if provider.Name() != "ip-api.com" {
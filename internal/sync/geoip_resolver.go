@@ -0,0 +1,216 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/cache"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// geoCacheTTL keeps resolved locations in memory for the process lifetime.
+// IP geolocation rarely changes, so entries are effectively permanent.
+const geoCacheTTL = 100 * 365 * 24 * time.Hour
+
+// geoCacheKey returns the cache key for an IP (geo: prefix convention).
+func geoCacheKey(ipAddress string) string {
+	return "geo:ip=" + ipAddress
+}
+
+// GeoIPResolver handles geolocation resolution with provider fallback and caching.
+//
+// Lookup order:
+//  1. In-memory cache (permanent TTL)
+//  2. Database cache (geolocations table)
+//  3. Providers in priority order; a failed or rate-limited provider
+//     hands off to the next one
+//
+// Thread Safety: Safe for concurrent access.
+type GeoIPResolver struct {
+	providers []GeoIPProvider
+	db        GeolocationDB
+	cache     *cache.Cache
+	local     LocalNetworkLocation
+}
+
+// GeolocationDB defines the database interface for geolocation caching.
+type GeolocationDB interface {
+	GetGeolocation(ctx context.Context, ipAddress string) (*models.Geolocation, error)
+	UpsertGeolocation(geo *models.Geolocation) error
+}
+
+// NewGeoIPResolver creates a new resolver with the given providers.
+// Providers are tried in order until one succeeds.
+func NewGeoIPResolver(db GeolocationDB, providers ...GeoIPProvider) *GeoIPResolver {
+	return &GeoIPResolver{
+		providers: providers,
+		db:        db,
+		cache:     cache.New(geoCacheTTL),
+	}
+}
+
+// NewGeoIPResolverFromConfig creates a resolver whose provider chain follows
+// cfg.ProviderChain(). MaxMind is skipped when credentials are not configured.
+func NewGeoIPResolverFromConfig(db GeolocationDB, cfg config.GeoIPConfig) *GeoIPResolver {
+	var providers []GeoIPProvider
+	for _, name := range cfg.ProviderChain() {
+		switch name {
+		case config.GeoIPProviderMaxMind:
+			if cfg.MaxMindAccountID != "" && cfg.MaxMindLicenseKey != "" {
+				providers = append(providers, NewMaxMindProvider(cfg.MaxMindAccountID, cfg.MaxMindLicenseKey))
+			}
+		case config.GeoIPProviderIPAPI:
+			providers = append(providers, NewIPAPIProvider())
+		}
+	}
+	return NewGeoIPResolver(db, providers...)
+}
+
+// SetLocalNetworkLocation sets where private, loopback, and link-local
+// clients are placed. Defaults to 0,0.
+//
+// Thread Safety: Safe for concurrent access but should be called once during startup.
+func (r *GeoIPResolver) SetLocalNetworkLocation(local LocalNetworkLocation) {
+	r.local = local
+}
+
+// Resolve fetches geolocation for an IP, using caches first, then providers.
+// If every provider fails, an "Unknown" geolocation is cached in the database
+// and returned so playback events are never dropped over geolocation.
+func (r *GeoIPResolver) Resolve(ctx context.Context, ipAddress string) (*models.Geolocation, error) {
+	ipAddress = normalizeIPAddress(ipAddress)
+
+	switch ClassifyIP(ipAddress) {
+	case IPClassPrivate, IPClassLoopback, IPClassLinkLocal:
+		return r.handlePrivateIP(ctx, ipAddress)
+	case IPClassInvalid:
+		return CreateUnknownGeolocation(ipAddress), nil
+	}
+
+	if geo := r.tryCache(ctx, ipAddress); geo != nil {
+		return geo, nil
+	}
+
+	geo, err := r.tryProviders(ctx, ipAddress)
+	if err != nil {
+		logging.Warn().Err(err).Str("ip", ipAddress).Msg("Failed to resolve geolocation - using unknown location")
+		geo = CreateUnknownGeolocation(ipAddress)
+		r.storeGeolocation(ipAddress, geo)
+	}
+	return geo, nil
+}
+
+// Lookup resolves an IP from the in-memory cache or the provider chain,
+// without the database read or the "Unknown" fallback. Successful provider
+// results are cached in memory and in the database.
+func (r *GeoIPResolver) Lookup(ctx context.Context, ipAddress string) (*models.Geolocation, error) {
+	ipAddress = normalizeIPAddress(ipAddress)
+	if !IsValidPublicIP(ipAddress) {
+		return nil, fmt.Errorf("%s is not a public IP address", ipAddress)
+	}
+
+	if geo, ok := r.cachedGeolocation(ipAddress); ok {
+		return geo, nil
+	}
+	return r.tryProviders(ctx, ipAddress)
+}
+
+func (r *GeoIPResolver) handlePrivateIP(_ context.Context, ipAddress string) (*models.Geolocation, error) {
+	logging.Debug().Str("ip", ipAddress).Msg("IP is private/LAN, creating local geolocation")
+	geo := r.local.Geolocation(ipAddress)
+
+	// Cache it to avoid repeated checks
+	if r.db != nil {
+		if err := r.db.UpsertGeolocation(geo); err != nil {
+			logging.Warn().Err(err).Str("ip", ipAddress).Msg("Failed to cache local geolocation")
+		}
+	}
+
+	return geo, nil
+}
+
+// cachedGeolocation returns the in-memory cached geolocation for an IP.
+func (r *GeoIPResolver) cachedGeolocation(ipAddress string) (*models.Geolocation, bool) {
+	if value, ok := r.cache.Get(geoCacheKey(ipAddress)); ok {
+		if geo, ok := value.(*models.Geolocation); ok {
+			metrics.CacheHits.WithLabelValues("geolocation").Inc()
+			return geo, true
+		}
+	}
+	metrics.CacheMisses.WithLabelValues("geolocation").Inc()
+	return nil, false
+}
+
+func (r *GeoIPResolver) tryCache(ctx context.Context, ipAddress string) *models.Geolocation {
+	if geo, ok := r.cachedGeolocation(ipAddress); ok {
+		return geo
+	}
+
+	if r.db == nil {
+		return nil
+	}
+
+	geo, err := r.db.GetGeolocation(ctx, ipAddress)
+	if err == nil && geo != nil {
+		r.cache.Set(geoCacheKey(ipAddress), geo)
+		return geo
+	}
+
+	return nil
+}
+
+func (r *GeoIPResolver) tryProviders(ctx context.Context, ipAddress string) (*models.Geolocation, error) {
+	var lastErr error
+
+	for _, provider := range r.providers {
+		if !provider.IsAvailable() {
+			continue
+		}
+
+		geo, err := provider.Lookup(ctx, ipAddress)
+		if err != nil {
+			if errors.Is(err, ErrGeoIPRateLimited) {
+				logging.Debug().Err(err).Str("provider", provider.Name()).Str("ip", ipAddress).Msg("GeoIP provider rate limited, failing over")
+			} else {
+				logging.Debug().Err(err).Str("provider", provider.Name()).Str("ip", ipAddress).Msg("GeoIP provider failed")
+			}
+			lastErr = err
+			continue
+		}
+
+		logging.Debug().Str("provider", provider.Name()).Str("ip", ipAddress).Msg("GeoIP lookup successful")
+		r.cache.Set(geoCacheKey(ipAddress), geo)
+		r.storeGeolocation(ipAddress, geo)
+		return geo, nil
+	}
+
+	return nil, r.buildProviderError(ipAddress, lastErr)
+}
+
+// storeGeolocation persists a geolocation to the database cache.
+func (r *GeoIPResolver) storeGeolocation(ipAddress string, geo *models.Geolocation) {
+	if r.db == nil {
+		return
+	}
+
+	if err := r.db.UpsertGeolocation(geo); err != nil {
+		logging.Warn().Err(err).Str("ip", ipAddress).Msg("Failed to cache geolocation")
+	}
+}
+
+func (r *GeoIPResolver) buildProviderError(ipAddress string, lastErr error) error {
+	if lastErr != nil {
+		return fmt.Errorf("all GeoIP providers failed for %s: %w", ipAddress, lastErr)
+	}
+	return fmt.Errorf("no GeoIP providers available")
+}
@@ -0,0 +1,253 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// stubGeoIPProvider is a GeoIPProvider with a canned result.
+type stubGeoIPProvider struct {
+	name      string
+	available bool
+	country   string
+	err       error
+	calls     int
+}
+
+func (p *stubGeoIPProvider) Lookup(_ context.Context, ipAddress string) (*models.Geolocation, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &models.Geolocation{IPAddress: ipAddress, Country: p.country}, nil
+}
+
+func (p *stubGeoIPProvider) Name() string      { return p.name }
+func (p *stubGeoIPProvider) IsAvailable() bool { return p.available }
+
+// memGeolocationDB is an in-memory GeolocationDB.
+type memGeolocationDB struct {
+	geos map[string]*models.Geolocation
+}
+
+func newMemGeolocationDB() *memGeolocationDB {
+	return &memGeolocationDB{geos: make(map[string]*models.Geolocation)}
+}
+
+func (db *memGeolocationDB) GetGeolocation(_ context.Context, ipAddress string) (*models.Geolocation, error) {
+	return db.geos[ipAddress], nil
+}
+
+func (db *memGeolocationDB) UpsertGeolocation(geo *models.Geolocation) error {
+	db.geos[geo.IPAddress] = geo
+	return nil
+}
+
+func TestGeoIPResolver_FailsOverOnRateLimit(t *testing.T) {
+	first := &stubGeoIPProvider{name: "first", available: true, err: fmt.Errorf("%w: 429", ErrGeoIPRateLimited)}
+	second := &stubGeoIPProvider{name: "second", available: true, country: "Germany"}
+	db := newMemGeolocationDB()
+
+	resolver := NewGeoIPResolver(db, first, second)
+	geo, err := resolver.Resolve(context.Background(), "203.0.113.10")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if geo.Country != "Germany" {
+		t.Errorf("Country = %q, want result from second provider", geo.Country)
+	}
+	if first.calls != 1 || second.calls != 1 {
+		t.Errorf("calls = %d/%d, want 1/1", first.calls, second.calls)
+	}
+	if db.geos["203.0.113.10"] == nil {
+		t.Error("resolved geolocation was not stored in the database")
+	}
+}
+
+func TestGeoIPResolver_SkipsUnavailableProviders(t *testing.T) {
+	blocked := &stubGeoIPProvider{name: "blocked", available: false, country: "France"}
+	fallback := &stubGeoIPProvider{name: "fallback", available: true, country: "Spain"}
+
+	geo, err := NewGeoIPResolver(nil, blocked, fallback).Resolve(context.Background(), "203.0.113.11")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if geo.Country != "Spain" || blocked.calls != 0 {
+		t.Errorf("Country = %q, blocked calls = %d; want Spain, 0", geo.Country, blocked.calls)
+	}
+}
+
+func TestGeoIPResolver_CachesInMemory(t *testing.T) {
+	provider := &stubGeoIPProvider{name: "only", available: true, country: "Japan"}
+	resolver := NewGeoIPResolver(nil, provider)
+
+	for i := 0; i < 3; i++ {
+		if _, err := resolver.Lookup(context.Background(), "203.0.113.12"); err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider calls = %d, want 1 (later lookups served from geo: cache)", provider.calls)
+	}
+	if _, ok := resolver.cache.Get(geoCacheKey("203.0.113.12")); !ok {
+		t.Error("expected geo:ip= cache entry")
+	}
+}
+
+func TestGeoIPResolver_DatabaseHitWarmsMemoryCache(t *testing.T) {
+	db := newMemGeolocationDB()
+	db.geos["203.0.113.13"] = &models.Geolocation{IPAddress: "203.0.113.13", Country: "Canada"}
+	provider := &stubGeoIPProvider{name: "only", available: true, country: "Mexico"}
+	resolver := NewGeoIPResolver(db, provider)
+
+	geo, err := resolver.Resolve(context.Background(), "203.0.113.13")
+	if err != nil || geo.Country != "Canada" {
+		t.Fatalf("Resolve() = %v, %v; want Canada from database", geo, err)
+	}
+
+	delete(db.geos, "203.0.113.13")
+	geo, _ = resolver.Resolve(context.Background(), "203.0.113.13")
+	if geo.Country != "Canada" || provider.calls != 0 {
+		t.Errorf("Country = %q, provider calls = %d; want cached Canada, 0", geo.Country, provider.calls)
+	}
+}
+
+func TestGeoIPResolver_AllProvidersFailReturnsUnknown(t *testing.T) {
+	failing := &stubGeoIPProvider{name: "failing", available: true, err: errors.New("boom")}
+	limited := &stubGeoIPProvider{name: "limited", available: true, err: ErrGeoIPRateLimited}
+	db := newMemGeolocationDB()
+
+	resolver := NewGeoIPResolver(db, failing, limited)
+	geo, err := resolver.Resolve(context.Background(), "203.0.113.14")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v, want Unknown fallback", err)
+	}
+	if geo.Country != "Unknown" {
+		t.Errorf("Country = %q, want Unknown", geo.Country)
+	}
+	if db.geos["203.0.113.14"] == nil {
+		t.Error("Unknown fallback was not stored in the database")
+	}
+
+	// Lookup surfaces the failure so callers can apply their own fallback
+	if _, err := resolver.Lookup(context.Background(), "203.0.113.15"); !errors.Is(err, ErrGeoIPRateLimited) {
+		t.Errorf("Lookup() error = %v, want wrapped last provider error", err)
+	}
+}
+
+func TestGeoIPResolver_PrivateIP(t *testing.T) {
+	provider := &stubGeoIPProvider{name: "only", available: true}
+	geo, err := NewGeoIPResolver(nil, provider).Resolve(context.Background(), "192.168.1.20:32400")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if geo.Country != "Local" || provider.calls != 0 {
+		t.Errorf("Country = %q, provider calls = %d; want Local, 0", geo.Country, provider.calls)
+	}
+}
+
+func TestGeoIPResolver_LocalNetworkLocation(t *testing.T) {
+	provider := &stubGeoIPProvider{name: "only", available: true}
+	db := newMemGeolocationDB()
+	resolver := NewGeoIPResolver(db, provider)
+	resolver.SetLocalNetworkLocation(LocalNetworkLocation{Latitude: 40.7, Longitude: -74.0})
+
+	for _, ip := range []string{"10.0.0.8", "[fe80::1%25eth0]:8096", "::ffff:172.16.4.2", "100.100.1.1"} {
+		geo, err := resolver.Resolve(context.Background(), ip)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error = %v", ip, err)
+		}
+		if geo.Country != "Local" || geo.Latitude != 40.7 || geo.Longitude != -74.0 {
+			t.Errorf("Resolve(%q) = %+v, want Local Network at server location", ip, geo)
+		}
+	}
+	if provider.calls != 0 {
+		t.Errorf("provider calls = %d, want 0 for LAN clients", provider.calls)
+	}
+	if db.geos["172.16.4.2"] == nil {
+		t.Error("IPv4-mapped address was not stored under its IPv4 form")
+	}
+}
+
+func TestGeoIPResolver_NormalizesIPv6CacheKey(t *testing.T) {
+	provider := &stubGeoIPProvider{name: "only", available: true, country: "Netherlands"}
+	resolver := NewGeoIPResolver(nil, provider)
+
+	for _, ip := range []string{"2001:0DB8:0:0::1", "[2001:db8::1]:32400", "2001:db8::1"} {
+		geo, err := resolver.Lookup(context.Background(), ip)
+		if err != nil {
+			t.Fatalf("Lookup(%q) error = %v", ip, err)
+		}
+		if geo.IPAddress != "2001:db8::1" {
+			t.Errorf("IPAddress = %q, want canonical 2001:db8::1", geo.IPAddress)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider calls = %d, want 1 (all forms share one cache entry)", provider.calls)
+	}
+}
+
+func TestGeoIPResolver_NonPublicIPsSkipProviders(t *testing.T) {
+	provider := &stubGeoIPProvider{name: "only", available: true, country: "Italy"}
+	resolver := NewGeoIPResolver(nil, provider)
+
+	for _, ip := range []string{"192.168.0.2", "::1", "0.0.0.0", "N/A"} {
+		if _, err := resolver.Lookup(context.Background(), ip); err == nil {
+			t.Errorf("Lookup(%q) error = nil, want non-public rejection", ip)
+		}
+	}
+	geo, err := resolver.Resolve(context.Background(), "N/A")
+	if err != nil || geo.Country != "Unknown" {
+		t.Errorf("Resolve(N/A) = %v, %v; want Unknown", geo, err)
+	}
+	if provider.calls != 0 {
+		t.Errorf("provider calls = %d, want 0", provider.calls)
+	}
+}
+
+func TestNewGeoIPResolverFromConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.GeoIPConfig
+		want []string
+	}{
+		{
+			name: "auto-detect without MaxMind credentials",
+			cfg:  config.GeoIPConfig{},
+			want: []string{"ip-api.com"},
+		},
+		{
+			name: "auto-detect with MaxMind credentials",
+			cfg:  config.GeoIPConfig{MaxMindAccountID: "123", MaxMindLicenseKey: "key"},
+			want: []string{"maxmind-geolite2", "ip-api.com"},
+		},
+		{
+			name: "ip-api preferred",
+			cfg:  config.GeoIPConfig{Provider: "ipapi", MaxMindAccountID: "123", MaxMindLicenseKey: "key"},
+			want: []string{"ip-api.com", "maxmind-geolite2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewGeoIPResolverFromConfig(nil, tt.cfg)
+			var got []string
+			for _, p := range resolver.providers {
+				got = append(got, p.Name())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("providers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,14 @@ func (m *Manager) resolveGeolocation(ctx context.Context, record *tautulli.Tautu
 	return m.resolveGeolocationForIP(ctx, record.IPAddress, sessionKey)
 }
 
+// ResolveGeolocation resolves an IP address the same way synced playbacks
+// are: private addresses get the local network location, known addresses
+// come from the geolocations table, and new ones are looked up and cached.
+// Used to place live sessions that have not been synced yet.
+func (m *Manager) ResolveGeolocation(ctx context.Context, ipAddress string) (*models.Geolocation, error) {
+	return m.resolveGeolocationForIP(ctx, ipAddress, "")
+}
+
 // resolveGeolocationForIP fetches or retrieves cached geolocation for an IP address
 // This is the core geolocation resolution function used by all data sources
 func (m *Manager) resolveGeolocationForIP(ctx context.Context, ipAddress, sessionKey string) (*models.Geolocation, error) {
@@ -31,14 +39,23 @@ func (m *Manager) resolveGeolocationForIP(ctx context.Context, ipAddress, sessio
 	ipAddress = normalizeIPAddress(ipAddress)
 
 	// Handle private/LAN IPs first - no need to look up
-	if IsPrivateIP(ipAddress) {
+	switch ClassifyIP(ipAddress) {
+	case IPClassPrivate, IPClassLoopback, IPClassLinkLocal:
 		logging.Debug().Str("ip", ipAddress).Str("session", sessionKey).Msg("IP is private/LAN, using local geolocation")
-		geo := CreateLocalGeolocation(ipAddress)
+		geo := m.localNetworkLocation().Geolocation(ipAddress)
 		// Cache it to avoid repeated checks
 		if cacheErr := m.db.UpsertGeolocation(geo); cacheErr != nil {
 			logging.Warn().Str("ip", ipAddress).Err(cacheErr).Msg("Failed to cache local geolocation")
 		}
 		return geo, nil
+	case IPClassInvalid:
+		// Nothing to look up - record it as unknown without calling any provider
+		logging.Debug().Str("ip", ipAddress).Str("session", sessionKey).Msg("IP is not a valid address, using unknown location")
+		geo := CreateUnknownGeolocation(ipAddress)
+		if cacheErr := m.db.UpsertGeolocation(geo); cacheErr != nil {
+			logging.Warn().Str("ip", ipAddress).Err(cacheErr).Msg("Failed to cache unknown geolocation")
+		}
+		return geo, nil
 	}
 
 	// Try to get cached geolocation
@@ -58,13 +75,7 @@ func (m *Manager) resolveGeolocationForIP(ctx context.Context, ipAddress, sessio
 
 			// Create a fallback geolocation entry with unknown coordinates
 			// We use 0,0 coordinates which will be filtered out in map visualizations
-			geo = &models.Geolocation{
-				IPAddress:   ipAddress,
-				Latitude:    0,
-				Longitude:   0,
-				Country:     "Unknown",
-				LastUpdated: time.Now(),
-			}
+			geo = CreateUnknownGeolocation(ipAddress)
 
 			// Cache the unknown location to avoid repeated failed lookups
 			if cacheErr := m.db.UpsertGeolocation(geo); cacheErr != nil {
@@ -160,7 +171,7 @@ func (m *Manager) fetchFromTautulli(ctx context.Context, ipAddress string) (*mod
 // fetchFromExternalGeoIP fetches geolocation from configured external GeoIP services.
 // This is used when Tautulli is not available (standalone mode).
 //
-// Provider priority (first available wins):
+// Provider priority (GEOIP_PROVIDER reorders the chain; first success wins):
 //  1. MaxMind GeoLite2 (if MAXMIND_ACCOUNT_ID and MAXMIND_LICENSE_KEY configured)
 //  2. ip-api.com (free, no API key required, 45 req/min limit)
 //
@@ -177,46 +188,24 @@ func (m *Manager) fetchFromExternalGeoIP(ctx context.Context, ipAddress string)
 		defer cancel()
 	}
 
-	// Build provider list based on configuration
-	var providers []GeoIPProvider
-
-	// Add MaxMind if configured (preferred - same as Tautulli uses)
-	if m.cfg.GeoIP.MaxMindAccountID != "" && m.cfg.GeoIP.MaxMindLicenseKey != "" {
-		maxmind := NewMaxMindProvider(m.cfg.GeoIP.MaxMindAccountID, m.cfg.GeoIP.MaxMindLicenseKey)
-		providers = append(providers, maxmind)
-		logging.Debug().Msg("MaxMind GeoLite2 provider configured")
-	}
-
-	// Always add ip-api.com as fallback (free, no API key required)
-	providers = append(providers, NewIPAPIProvider())
-
-	// Try each provider in order
-	var lastErr error
-	for _, provider := range providers {
-		if !provider.IsAvailable() {
-			continue
-		}
-
-		geo, err := provider.Lookup(ctx, ipAddress)
-		if err != nil {
-			logging.Debug().Str("provider", provider.Name()).Str("ip", ipAddress).Err(err).Msg("GeoIP provider failed")
-			lastErr = err
-			continue
-		}
-
-		logging.Debug().Str("provider", provider.Name()).Str("ip", ipAddress).Msg("GeoIP lookup successful")
-
-		// Cache the result
-		if err := m.db.UpsertGeolocation(geo); err != nil {
-			return nil, fmt.Errorf("failed to cache geolocation: %w", err)
-		}
+	return m.externalGeoIPResolver().Lookup(ctx, ipAddress)
+}
 
-		return geo, nil
-	}
+// externalGeoIPResolver returns the shared resolver for external GeoIP
+// providers, creating it on first use.
+func (m *Manager) externalGeoIPResolver() *GeoIPResolver {
+	m.geoResolverOnce.Do(func() {
+		m.geoResolver = NewGeoIPResolverFromConfig(m.db, m.cfg.GeoIP)
+		m.geoResolver.SetLocalNetworkLocation(m.localNetworkLocation())
+	})
+	return m.geoResolver
+}
 
-	if lastErr != nil {
-		return nil, fmt.Errorf("all GeoIP providers failed for %s: %w", ipAddress, lastErr)
+// localNetworkLocation places LAN clients at the server's configured
+// coordinates (SERVER_LATITUDE/SERVER_LONGITUDE).
+func (m *Manager) localNetworkLocation() LocalNetworkLocation {
+	return LocalNetworkLocation{
+		Latitude:  m.cfg.Server.Latitude,
+		Longitude: m.cfg.Server.Longitude,
 	}
-
-	return nil, fmt.Errorf("no GeoIP providers available")
 }
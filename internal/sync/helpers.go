@@ -8,7 +8,6 @@ package sync
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/tomtom215/cartographus/internal/logging"
 	"github.com/tomtom215/cartographus/internal/models"
@@ -37,7 +36,7 @@ func (m *Manager) retryWithBackoff(ctx context.Context, fn func() error) error {
 			logging.Warn().Err(err).Int("attempt", attempt+1).Int("max_attempts", m.cfg.Sync.RetryAttempts).Dur("delay", delay).Msg("Retry attempt")
 			// Use cancellable wait instead of time.Sleep
 			select {
-			case <-time.After(delay):
+			case <-m.timeSource().After(delay):
 				// Continue to next attempt
 			case <-ctx.Done():
 				return ctx.Err()
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/tomtom215/cartographus/internal/clock"
 	"github.com/tomtom215/cartographus/internal/config"
 	"github.com/tomtom215/cartographus/internal/models"
 )
@@ -121,47 +122,49 @@ func TestRetryWithBackoff(t *testing.T) {
 }
 
 // TestRetryWithBackoffExponentialDelay verifies exponential backoff timing
+// with a fake clock, so the delays are exact and the test does not sleep
 func TestRetryWithBackoffExponentialDelay(t *testing.T) {
 	cfg := &config.Config{
 		Sync: config.SyncConfig{
-			RetryAttempts: 3,
-			RetryDelay:    10 * time.Millisecond,
+			RetryAttempts: 4,
+			RetryDelay:    time.Second,
 		},
 	}
-	m := &Manager{cfg: cfg}
-
-	callCount := 0
-	callTimes := make([]time.Time, 0)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	m := NewManagerWithClock(nil, nil, nil, cfg, nil, clk)
 
-	start := time.Now()
-	_ = m.retryWithBackoff(context.Background(), func() error {
-		callTimes = append(callTimes, time.Now())
-		callCount++
-		if callCount < 3 {
+	var callTimes []time.Time
+	done := make(chan error, 1)
+	go func() {
+		done <- m.retryWithBackoff(context.Background(), func() error {
+			callTimes = append(callTimes, clk.Now())
 			return errors.New("fail")
-		}
-		return nil
-	})
+		})
+	}()
 
-	// Verify we had 3 calls
-	if callCount != 3 {
-		t.Errorf("expected 3 calls, got %d", callCount)
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		clk.BlockUntil(1)
+		clk.Advance(delay)
 	}
 
-	// Verify delays were approximately exponential
-	// First delay should be ~10ms, second should be ~20ms
-	// Note: CI environments can have significant scheduling delays, so we use generous bounds
-	if len(callTimes) >= 2 {
-		firstDelay := callTimes[1].Sub(callTimes[0])
-		if firstDelay < 8*time.Millisecond || firstDelay > 500*time.Millisecond {
-			t.Errorf("first delay out of range: %v (expected 8ms-500ms)", firstDelay)
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "max retry attempts reached") {
+			t.Errorf("error = %v, want max retry attempts reached", err)
 		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("retryWithBackoff did not finish after the expected delays")
 	}
 
-	totalDuration := time.Since(start)
-	// Total time should be reasonable (at least 10ms + 20ms = 30ms for retries)
-	if totalDuration < 20*time.Millisecond {
-		t.Errorf("total duration too short: %v (expected at least 20ms)", totalDuration)
+	want := []time.Duration{0, time.Second, 3 * time.Second, 7 * time.Second}
+	if len(callTimes) != len(want) {
+		t.Fatalf("calls = %d, want %d", len(callTimes), len(want))
+	}
+	for i, offset := range want {
+		if got := callTimes[i].Sub(start); got != offset {
+			t.Errorf("call %d at +%v, want +%v", i+1, got, offset)
+		}
 	}
 }
 
@@ -0,0 +1,117 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// IPClass is the routing class of a client IP address.
+type IPClass string
+
+// IP address classes. Only IPClassPublic addresses are sent to GeoIP
+// providers; private, loopback, and link-local clients are on the server's
+// own network and resolve to the LocalNetworkLocation.
+const (
+	IPClassPublic    IPClass = "public"
+	IPClassPrivate   IPClass = "private"    // RFC 1918, RFC 6598 shared (CGNAT), IPv6 unique local fc00::/7
+	IPClassLoopback  IPClass = "loopback"   // 127.0.0.0/8, ::1
+	IPClassLinkLocal IPClass = "link_local" // 169.254.0.0/16, fe80::/10
+	IPClassInvalid   IPClass = "invalid"    // Unparseable or unspecified (0.0.0.0, ::)
+)
+
+// sharedAddressSpace is the RFC 6598 carrier-grade NAT range (also used by
+// overlay VPNs such as Tailscale). It is not publicly routable.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// ClassifyIP returns the class of an IP address. The address is normalized
+// first, so ports, brackets, zones, and IPv4-mapped IPv6 forms are accepted.
+func ClassifyIP(ipStr string) IPClass {
+	addr, err := netip.ParseAddr(normalizeIPAddress(ipStr))
+	if err != nil || addr.IsUnspecified() {
+		return IPClassInvalid
+	}
+
+	switch {
+	case addr.IsLoopback():
+		return IPClassLoopback
+	case addr.IsLinkLocalUnicast():
+		return IPClassLinkLocal
+	case addr.IsPrivate(), sharedAddressSpace.Contains(addr):
+		return IPClassPrivate
+	default:
+		return IPClassPublic
+	}
+}
+
+// IsPrivateIP checks if the IP address is in a private/local range
+// (private, loopback, or link-local). Such IPs cannot be geolocated and are
+// placed at the LocalNetworkLocation instead.
+func IsPrivateIP(ipStr string) bool {
+	switch ClassifyIP(ipStr) {
+	case IPClassPrivate, IPClassLoopback, IPClassLinkLocal:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidPublicIP checks if the IP address is a valid public (routable) IP.
+func IsValidPublicIP(ipStr string) bool {
+	return ClassifyIP(ipStr) == IPClassPublic
+}
+
+// LocalNetworkLocation is where clients on the server's own network are
+// placed on the map. The zero value (0,0) is filtered out of map
+// visualizations; the sync manager uses SERVER_LATITUDE/SERVER_LONGITUDE so
+// LAN streams are plotted at home.
+type LocalNetworkLocation struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Geolocation returns the "Local Network" geolocation for a LAN client.
+func (l LocalNetworkLocation) Geolocation(ipAddress string) *models.Geolocation {
+	local := "Local Network"
+	return &models.Geolocation{
+		IPAddress:   ipAddress,
+		Latitude:    l.Latitude,
+		Longitude:   l.Longitude,
+		Country:     "Local",
+		City:        &local,
+		LastUpdated: time.Now(),
+	}
+}
+
+// CreateLocalGeolocation creates a geolocation entry for private/LAN IPs at 0,0.
+// These are marked with "Local Network" as the city for filtering purposes.
+func CreateLocalGeolocation(ipAddress string) *models.Geolocation {
+	return LocalNetworkLocation{}.Geolocation(ipAddress)
+}
+
+// normalizeIPAddress returns the canonical form of an IP address used for
+// lookups and cache keys. It strips ports ("1.2.3.4:32400", "[::1]:8096"),
+// brackets, and IPv6 zones, unmaps IPv4-mapped IPv6 ("::ffff:10.0.0.1"),
+// and compresses/lowercases IPv6. Unparseable input is returned trimmed.
+func normalizeIPAddress(ipAddr string) string {
+	host := strings.TrimSpace(ipAddr)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	} else {
+		host = strings.Trim(host, "[]")
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	return addr.WithZone("").Unmap().String()
+}
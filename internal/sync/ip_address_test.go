@@ -0,0 +1,63 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import "testing"
+
+func TestClassifyIP(t *testing.T) {
+	tests := []struct {
+		input string
+		want  IPClass
+	}{
+		{"8.8.8.8", IPClassPublic},
+		{"2001:4860:4860::8888", IPClassPublic},
+		{"[2001:4860:4860::8888]:32400", IPClassPublic},
+		{"::ffff:8.8.8.8", IPClassPublic},
+
+		{"10.1.2.3", IPClassPrivate},
+		{"172.16.0.1", IPClassPrivate},
+		{"192.168.1.1:32400", IPClassPrivate},
+		{"100.64.0.1", IPClassPrivate}, // CGNAT / Tailscale
+		{"100.127.255.254", IPClassPrivate},
+		{"fd12:3456:789a::1", IPClassPrivate},
+		{"FD00::1", IPClassPrivate},
+		{"::ffff:10.0.0.5", IPClassPrivate},
+
+		{"127.0.0.1", IPClassLoopback},
+		{"[::1]:8096", IPClassLoopback},
+
+		{"169.254.10.10", IPClassLinkLocal},
+		{"fe80::1%eth0", IPClassLinkLocal},
+
+		{"", IPClassInvalid},
+		{"N/A", IPClassInvalid},
+		{"0.0.0.0", IPClassInvalid},
+		{"::", IPClassInvalid},
+		{"999.1.1.1", IPClassInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ClassifyIP(tt.input); got != tt.want {
+				t.Errorf("ClassifyIP(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalNetworkLocation_Geolocation(t *testing.T) {
+	geo := LocalNetworkLocation{Latitude: 52.52, Longitude: 13.405}.Geolocation("192.168.1.5")
+
+	if geo.IPAddress != "192.168.1.5" || geo.Country != "Local" {
+		t.Errorf("geo = %+v, want Local entry for 192.168.1.5", geo)
+	}
+	if geo.City == nil || *geo.City != "Local Network" {
+		t.Errorf("City = %v, want Local Network", geo.City)
+	}
+	if geo.Latitude != 52.52 || geo.Longitude != 13.405 {
+		t.Errorf("coordinates = %v,%v, want server location", geo.Latitude, geo.Longitude)
+	}
+}
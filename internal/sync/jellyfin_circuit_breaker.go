@@ -8,7 +8,6 @@ package sync
 import (
 	"context"
 	"errors"
-	"time"
 
 	gobreaker "github.com/sony/gobreaker/v2"
 	"github.com/tomtom215/cartographus/internal/logging"
@@ -25,9 +24,10 @@ var _ JellyfinClientInterface = (*JellyfinCircuitBreakerClient)(nil)
 // DETERMINISM NOTE: The circuit breaker uses real time (via sony/gobreaker) for its
 // interval and timeout calculations. This is intentional for production resilience.
 type JellyfinCircuitBreakerClient struct {
-	client *JellyfinClient
-	cb     *gobreaker.CircuitBreaker[interface{}]
-	name   string
+	client   *JellyfinClient
+	cb       *gobreaker.CircuitBreaker[interface{}]
+	name     string
+	notifier *stateChangeNotifier
 }
 
 // JellyfinCircuitBreakerConfig holds configuration for the Jellyfin circuit breaker
@@ -35,6 +35,10 @@ type JellyfinCircuitBreakerConfig struct {
 	BaseURL string
 	APIKey  string
 	UserID  string
+
+	// ServerID labels the breaker's metrics per server (e.g. "jellyfin-api:<server_id>").
+	// Empty uses the plain "jellyfin-api" name.
+	ServerID string
 }
 
 // NewJellyfinCircuitBreakerClient creates a new Jellyfin client with circuit breaker
@@ -45,57 +49,14 @@ type JellyfinCircuitBreakerConfig struct {
 // - Opens after 60% failure rate with minimum 10 requests
 func NewJellyfinCircuitBreakerClient(cfg JellyfinCircuitBreakerConfig) *JellyfinCircuitBreakerClient {
 	client := NewJellyfinClient(cfg.BaseURL, cfg.APIKey, cfg.UserID)
-	cbName := "jellyfin-api"
-
-	// Initialize circuit breaker state metrics
-	metrics.CircuitBreakerState.WithLabelValues(cbName).Set(0) // 0 = closed
-	metrics.CircuitBreakerConsecutiveFailures.WithLabelValues(cbName).Set(0)
-
-	cb := gobreaker.NewCircuitBreaker[interface{}](gobreaker.Settings{
-		Name:        cbName,
-		MaxRequests: 3,               // Allow 3 concurrent requests in half-open state
-		Interval:    time.Minute,     // Reset counts after 1 minute in closed state
-		Timeout:     2 * time.Minute, // Wait 2 minutes before transitioning from open to half-open
-
-		// ReadyToTrip determines when to open the circuit
-		// Opens when failure rate >= 60% with minimum 10 requests
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			if counts.Requests < 10 {
-				return false // Need at least 10 requests for statistical significance
-			}
-
-			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-			shouldTrip := failureRatio >= 0.6
-
-			if shouldTrip {
-				logging.Warn().Uint32("failures", counts.TotalFailures).Float64("failure_rate", failureRatio*100).Msg("[CIRCUIT BREAKER] Opening Jellyfin circuit")
-			}
-
-			return shouldTrip
-		},
-
-		// OnStateChange is called whenever the circuit breaker changes state
-		OnStateChange: func(name string, from, to gobreaker.State) {
-			fromStr := stateToString(from)
-			toStr := stateToString(to)
-
-			logging.Info().Str("from", fromStr).Str("to", toStr).Msg("[CIRCUIT BREAKER] Jellyfin state transition")
-
-			// Update metrics
-			metrics.CircuitBreakerState.WithLabelValues(name).Set(stateToFloat(to))
-			metrics.CircuitBreakerTransitions.WithLabelValues(name, fromStr, toStr).Inc()
-
-			// Reset consecutive failures when transitioning to closed
-			if to == gobreaker.StateClosed {
-				metrics.CircuitBreakerConsecutiveFailures.WithLabelValues(name).Set(0)
-			}
-		},
-	})
+	cbName := circuitBreakerName("jellyfin-api", cfg.ServerID)
+	notifier := &stateChangeNotifier{}
 
 	return &JellyfinCircuitBreakerClient{
-		client: client,
-		cb:     cb,
-		name:   cbName,
+		client:   client,
+		cb:       newMediaCircuitBreaker[interface{}](cbName, "Jellyfin", notifier),
+		name:     cbName,
+		notifier: notifier,
 	}
 }
 
@@ -220,3 +181,8 @@ func (cbc *JellyfinCircuitBreakerClient) Counts() gobreaker.Counts {
 func (cbc *JellyfinCircuitBreakerClient) Name() string {
 	return cbc.name
 }
+
+// OnStateChange registers a callback invoked on every circuit breaker state transition
+func (cbc *JellyfinCircuitBreakerClient) OnStateChange(fn StateChangeFunc) {
+	cbc.notifier.set(fn)
+}
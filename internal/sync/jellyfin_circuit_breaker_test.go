@@ -14,7 +14,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	gobreaker "github.com/sony/gobreaker/v2"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/metrics"
 )
 
 // TestJellyfinCircuitBreaker_OpensAfterFailures verifies circuit opens after exceeding failure threshold
@@ -293,3 +297,40 @@ func TestJellyfinCircuitBreaker_TransitionsToHalfOpen(t *testing.T) {
 		t.Errorf("Expected circuit to transition from Open after timeout, still Open")
 	}
 }
+
+// TestJellyfinManager_CircuitBreakerReportsStateChange verifies the per-server breaker
+// name and that opening the circuit fires the manager's OnStateChange hook once
+func TestJellyfinManager_CircuitBreakerReportsStateChange(t *testing.T) {
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	mgr := NewJellyfinManager(&config.JellyfinConfig{
+		Enabled:  true,
+		URL:      failServer.URL,
+		APIKey:   "test-key",
+		ServerID: "jellyfin-cb-test",
+	}, nil, nil)
+
+	cbc, ok := mgr.client.(*JellyfinCircuitBreakerClient)
+	if !ok {
+		t.Fatalf("manager client type = %T, want *JellyfinCircuitBreakerClient", mgr.client)
+	}
+	if got, want := cbc.Name(), "jellyfin-api:jellyfin-cb-test"; got != want {
+		t.Fatalf("Name() = %q, want %q", got, want)
+	}
+
+	rec := &stateChangeRecorder{}
+	mgr.OnStateChange(rec.record)
+	transitionsBefore := testutil.ToFloat64(metrics.CircuitBreakerTransitions.WithLabelValues(cbc.Name(), "closed", "open"))
+
+	for i := 0; i < 12; i++ {
+		_ = cbc.Ping(context.Background())
+	}
+
+	if cbc.State() != gobreaker.StateOpen {
+		t.Fatalf("State() = %v, want open", cbc.State())
+	}
+	assertOpenedOnce(t, rec, cbc.Name(), transitionsBefore)
+}
@@ -385,7 +385,7 @@ func SessionToPlaybackEvent(session *models.JellyfinSession, _ string) *models.P
 	}
 
 	// IP address
-	event.IPAddress = session.GetIPAddress()
+	event.IPAddress = normalizeIPAddress(session.GetIPAddress())
 
 	// External IDs (for correlation)
 	if item.ProviderIDs != nil {
@@ -0,0 +1,105 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration
+
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/testinfra"
+)
+
+// TestJellyfinClient_Integration tests the JellyfinClient and WebSocket
+// client against a real Jellyfin instance instead of canned responses.
+//
+// Usage:
+//
+//	go test -tags integration -run TestJellyfin ./internal/sync/...
+func TestJellyfinClient_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	testinfra.SkipIfNoDocker(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	jellyfin, err := testinfra.NewJellyfinContainer(ctx,
+		testinfra.WithSeedLibrary("Movies", "movies", ""),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start Jellyfin container: %v", err)
+	}
+	defer testinfra.CleanupContainer(t, ctx, jellyfin.Container)
+
+	client := NewJellyfinClient(jellyfin.URL, jellyfin.APIKey, jellyfin.UserID)
+
+	t.Run("Ping succeeds", func(t *testing.T) {
+		if err := client.Ping(ctx); err != nil {
+			t.Fatalf("Ping error: %v", err)
+		}
+	})
+
+	t.Run("GetSystemInfo returns real server info", func(t *testing.T) {
+		info, err := client.GetSystemInfo(ctx)
+		if err != nil {
+			t.Fatalf("GetSystemInfo error: %v", err)
+		}
+		if info.ID == "" || info.Version == "" {
+			t.Errorf("GetSystemInfo = %+v, want ID and Version", info)
+		}
+		t.Logf("Jellyfin %s (%s)", info.Version, info.ServerName)
+	})
+
+	t.Run("GetUsers includes the administrator", func(t *testing.T) {
+		users, err := client.GetUsers(ctx)
+		if err != nil {
+			t.Fatalf("GetUsers error: %v", err)
+		}
+		found := false
+		for _, user := range users {
+			found = found || user.ID == jellyfin.UserID
+		}
+		if !found {
+			t.Errorf("GetUsers = %+v, want user %s", users, jellyfin.UserID)
+		}
+	})
+
+	t.Run("GetSessions decodes real sessions", func(t *testing.T) {
+		// The API key itself has no playback session, so this checks the
+		// response shape rather than the contents
+		sessions, err := client.GetSessions(ctx)
+		if err != nil {
+			t.Fatalf("GetSessions error: %v", err)
+		}
+		t.Logf("Sessions: %d", len(sessions))
+
+		if _, err := client.GetActiveSessions(ctx); err != nil {
+			t.Fatalf("GetActiveSessions error: %v", err)
+		}
+	})
+
+	t.Run("WebSocket connects", func(t *testing.T) {
+		wsURL, err := client.GetWebSocketURL()
+		if err != nil {
+			t.Fatalf("GetWebSocketURL error: %v", err)
+		}
+
+		ws := NewJellyfinWebSocketClient(wsURL, jellyfin.APIKey)
+		if err := ws.Connect(ctx); err != nil {
+			t.Fatalf("WebSocket Connect error: %v", err)
+		}
+		defer ws.Close()
+
+		if !ws.IsConnected() {
+			t.Error("IsConnected = false after Connect")
+		}
+	})
+}
@@ -32,6 +32,9 @@ type JellyfinManager struct {
 	eventPublisher EventPublisher
 	wsHub          WebSocketHub
 	userResolver   UserResolver // For resolving external UUIDs to internal user IDs
+
+	// staggerFn computes the poller start offset; nil disables staggering (tests)
+	staggerFn func(serverID string, interval time.Duration) time.Duration
 }
 
 // NewJellyfinManager creates a new Jellyfin integration manager
@@ -50,9 +53,10 @@ func NewJellyfinManager(cfg *config.JellyfinConfig, wsHub WebSocketHub, userReso
 
 	// Use circuit breaker client for resilience against API failures
 	client := NewJellyfinCircuitBreakerClient(JellyfinCircuitBreakerConfig{
-		BaseURL: cfg.URL,
-		APIKey:  cfg.APIKey,
-		UserID:  cfg.UserID,
+		BaseURL:  cfg.URL,
+		APIKey:   cfg.APIKey,
+		UserID:   cfg.UserID,
+		ServerID: cfg.ServerID,
 	})
 
 	return &JellyfinManager{
@@ -60,6 +64,7 @@ func NewJellyfinManager(cfg *config.JellyfinConfig, wsHub WebSocketHub, userReso
 		cfg:          cfg,
 		wsHub:        wsHub,
 		userResolver: userResolver,
+		staggerFn:    staggerOffset,
 	}
 }
 
@@ -77,6 +82,17 @@ func (m *JellyfinManager) ServerID() string {
 	return m.cfg.ServerID
 }
 
+// OnStateChange registers a callback for this server's API circuit breaker
+// transitions (closed, half-open, open). No-op if the client has no breaker.
+func (m *JellyfinManager) OnStateChange(fn StateChangeFunc) {
+	if m == nil {
+		return
+	}
+	if observable, ok := m.client.(stateChangeObservable); ok {
+		observable.OnStateChange(fn)
+	}
+}
+
 // Start initializes and starts all enabled Jellyfin services
 func (m *JellyfinManager) Start(ctx context.Context) error {
 	if m == nil {
@@ -135,6 +151,15 @@ func (m *JellyfinManager) startWebSocket(ctx context.Context) error {
 
 // startSessionPoller initializes and starts the session poller
 func (m *JellyfinManager) startSessionPoller(ctx context.Context) error {
+	m.poller = NewJellyfinSessionPoller(m.client, m.sessionPollerConfig())
+	m.poller.SetOnSession(m.handleNewSession)
+
+	return m.poller.Start(ctx)
+}
+
+// sessionPollerConfig builds the poller configuration, enforcing the minimum
+// interval and staggering the first poll by ServerID.
+func (m *JellyfinManager) sessionPollerConfig() SessionPollerConfig {
 	interval := m.cfg.SessionPollingInterval
 	if interval < 10*time.Second {
 		logging.Info().Dur("interval", interval).Msg("WARNING: Polling interval too low, using 10s")
@@ -145,12 +170,13 @@ func (m *JellyfinManager) startSessionPoller(ctx context.Context) error {
 		Interval:       interval,
 		PublishAll:     false,
 		SeenSessionTTL: 1 * time.Hour,
+		Source:         "jellyfin",
+		ServerID:       serverIDOrDefault("jellyfin", m.cfg.ServerID),
 	}
-
-	m.poller = NewJellyfinSessionPoller(m.client, config)
-	m.poller.SetOnSession(m.handleNewSession)
-
-	return m.poller.Start(ctx)
+	if m.staggerFn != nil {
+		config.StartOffset = m.staggerFn(m.cfg.ServerID, interval)
+	}
+	return config
 }
 
 // handleSessionUpdate processes session updates from WebSocket
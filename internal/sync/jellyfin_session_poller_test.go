@@ -13,6 +13,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
@@ -374,6 +377,46 @@ func TestJellyfinSessionPoller_PollError(t *testing.T) {
 	}
 }
 
+func TestJellyfinSessionPoller_PollMetrics(t *testing.T) {
+	t.Parallel()
+
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	newPoller := func(url, serverID string) *JellyfinSessionPoller {
+		config := SessionPollerConfig{
+			Interval:       100 * time.Millisecond,
+			SeenSessionTTL: 5 * time.Minute,
+			Source:         "jellyfin",
+			ServerID:       serverID,
+		}
+		return NewJellyfinSessionPoller(NewJellyfinClient(url, "test-key", ""), config)
+	}
+
+	before := float64(time.Now().Unix())
+	newPoller(ok.URL, "poll-metrics-ok").poll(context.Background())
+	newPoller(failing.URL, "poll-metrics-failing").poll(context.Background())
+
+	if got := testutil.ToFloat64(metrics.SyncLastSuccess.WithLabelValues("jellyfin", "poll-metrics-ok")); got < before {
+		t.Errorf("last success of the healthy server = %v, want >= %v", got, before)
+	}
+	if got := testutil.ToFloat64(metrics.SyncLastSuccess.WithLabelValues("jellyfin", "poll-metrics-failing")); got != 0 {
+		t.Errorf("last success of the failing server = %v, want 0", got)
+	}
+	for _, serverID := range []string{"poll-metrics-ok", "poll-metrics-failing"} {
+		if got := testutil.ToFloat64(metrics.SyncInProgress.WithLabelValues("jellyfin", serverID)); got != 0 {
+			t.Errorf("sync_in_progress of %s after poll = %v, want 0", serverID, got)
+		}
+	}
+}
+
 func TestJellyfinSessionPoller_PollNoCallback(t *testing.T) {
 	t.Parallel()
 
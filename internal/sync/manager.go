@@ -33,7 +33,7 @@ Background Services Started:
 
 Thread Safety:
   - syncMu: Prevents concurrent sync execution
-  - mu: Protects shared state (running, lastSync)
+  - mu: Protects shared state (running, lastSync, lastPlexSync)
   - bufferHealthMu: Protects buffer health cache
   - All services use WaitGroup for coordinated shutdown
 */
@@ -45,12 +45,16 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/logging"
 
+	"github.com/tomtom215/cartographus/internal/clock"
 	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/validation"
 )
 
 // DBInterface defines the interface for database operations
@@ -99,19 +103,54 @@ type Manager struct {
 	plexWSClient      *PlexWebSocketClient // Optional: Real-time Plex WebSocket for instant updates (v1.39)
 	cfg               *config.Config       // Full config (changed from *config.SyncConfig for Plex access)
 	lastSync          time.Time
+	lastPlexSync      time.Time // Last successful Plex history sync (hybrid mode)
 	running           bool
 	mu                sync.RWMutex
 	syncMu            sync.Mutex // Protects concurrent sync execution
 	stopChan          chan struct{}
 	wg                sync.WaitGroup
-	plexSyncTicker    *time.Ticker                           // Periodic Plex sync ticker (v1.37)
+	plexSyncTicker    clock.Ticker                           // Periodic Plex sync ticker (v1.37)
 	onSyncCompleted   func(newRecords int, durationMs int64) // Callback invoked after successful sync with stats
 	wsHub             WebSocketHub                           // WebSocket hub for broadcasting real-time updates to frontend (v1.39)
 	bufferHealthMu    sync.RWMutex                           // Protects bufferHealthCache map (v1.41)
 	bufferHealthCache map[string]*models.PlexBufferHealth    // Previous buffer health states for drain rate calculation (v1.41)
+	bufferAlerts      BufferAlertPublisher                   // Optional: publishes critical buffer events to NATS
+	onBufferCritical  BufferCriticalFunc                     // Optional: e.g. forwards critical buffer events to detection notifiers
 	eventPublisher    EventPublisher                         // Optional: NATS event publisher for event-driven architecture (v1.47)
 	publishWg         sync.WaitGroup                         // Tracks in-flight publish goroutines for deterministic flush (v2.1)
 	sessionPoller     *PlexSessionPoller                     // Optional: Backup session polling when WebSocket is insufficient (v1.50)
+
+	// Optional: events outside timestampWindow are quarantined instead of inserted
+	quarantine      QuarantineStore
+	timestampWindow validation.TimestampWindow
+
+	// Optional: persisted per-source high-watermarks for incremental sync
+	watermarks WatermarkStore
+	// Latest record ingested by the running Tautulli sync (guarded by syncMu)
+	tautulliWatermark *watermarkTracker
+
+	// External GeoIP provider chain, built on first use so rate limiter state
+	// and the in-memory geo: cache persist across lookups
+	geoResolver     *GeoIPResolver
+	geoResolverOnce sync.Once
+
+	// clk drives sync schedules, retry backoff and lookback windows; read
+	// it through timeSource
+	clk clock.Clock
+
+	// staggerFn computes the poller and history sync start offsets; nil
+	// disables staggering (tests)
+	staggerFn func(serverID string, interval time.Duration) time.Duration
+
+	// activeSyncs counts history syncs currently writing to the database
+	activeSyncs atomic.Int32
+
+	// Sync interval and batch size set at runtime by SetSyncSettings
+	// (guarded by mu); zero values fall back to cfg.Sync
+	syncInterval  time.Duration
+	syncBatchSize int
+	// intervalChanged wakes syncLoop to reset its ticker
+	intervalChanged chan struct{}
 }
 
 // WebSocketHub interface for broadcasting messages to frontend clients
@@ -132,6 +171,13 @@ type WebSocketHub interface {
 // If cfg.Plex.Enabled is true, initializes PlexClient for hybrid data architecture.
 // The userResolver enables proper user tracking across multiple Plex servers.
 func NewManager(db DBInterface, userResolver UserResolver, client TautulliClientInterface, cfg *config.Config, wsHub WebSocketHub) *Manager {
+	return NewManagerWithClock(db, userResolver, client, cfg, wsHub, clock.Real())
+}
+
+// NewManagerWithClock creates a sync manager that reads time from clk, so
+// tests can drive sync intervals and retry backoff with a clock.Fake. A nil
+// clk uses the real clock.
+func NewManagerWithClock(db DBInterface, userResolver UserResolver, client TautulliClientInterface, cfg *config.Config, wsHub WebSocketHub, clk clock.Clock) *Manager {
 	m := &Manager{
 		db:                db,
 		userResolver:      userResolver,
@@ -139,7 +185,10 @@ func NewManager(db DBInterface, userResolver UserResolver, client TautulliClient
 		cfg:               cfg,
 		wsHub:             wsHub,
 		stopChan:          make(chan struct{}),
+		intervalChanged:   make(chan struct{}, 1),
 		bufferHealthCache: make(map[string]*models.PlexBufferHealth), // v1.41: Initialize buffer health cache
+		staggerFn:         staggerOffset,
+		clk:               clk,
 	}
 
 	// Log sync configuration for debugging
@@ -152,13 +201,30 @@ func NewManager(db DBInterface, userResolver UserResolver, client TautulliClient
 
 	// Initialize Plex client if enabled (v1.37 hybrid architecture)
 	if cfg.Plex.Enabled {
-		m.plexClient = NewPlexClient(cfg.Plex.URL, cfg.Plex.Token)
+		m.plexClient = NewPlexClientWithServerID(cfg.Plex.URL, cfg.Plex.Token, cfg.Plex.ServerID)
 		logging.Info().Bool("historical", cfg.Plex.HistoricalSync).Int("days_back", cfg.Plex.SyncDaysBack).Dur("interval", cfg.Plex.SyncInterval).Msg("Plex sync enabled")
 	}
 
 	return m
 }
 
+// timeSource returns the manager's clock, or the real clock for managers
+// not built by a constructor.
+func (m *Manager) timeSource() clock.Clock {
+	return clock.OrReal(m.clk)
+}
+
+// OnStateChange registers a callback for circuit breaker transitions on the
+// Tautulli and Plex API clients. Clients without a breaker are skipped.
+func (m *Manager) OnStateChange(fn StateChangeFunc) {
+	if observable, ok := m.client.(stateChangeObservable); ok {
+		observable.OnStateChange(fn)
+	}
+	if m.plexClient != nil {
+		m.plexClient.OnStateChange(fn)
+	}
+}
+
 // SetOnSyncCompleted sets the callback to be invoked after each successful sync
 func (m *Manager) SetOnSyncCompleted(callback func(newRecords int, durationMs int64)) {
 	m.mu.Lock()
@@ -288,10 +354,24 @@ func (m *Manager) startPlexSessionPollingService(ctx context.Context) {
 	logging.Info().Dur("interval", m.cfg.Plex.SessionPollingInterval).Msg("Starting Plex session polling...")
 	logging.Info().Msg("NOTE: Session polling is a backup mechanism. PLEX_REALTIME_ENABLED (WebSocket) is recommended.")
 
+	m.sessionPoller = NewPlexSessionPoller(m, m.sessionPollerConfig())
+	if err := m.sessionPoller.Start(ctx); err != nil {
+		logging.Warn().Err(err).Msg("Failed to start session polling")
+		m.sessionPoller = nil
+	} else {
+		logging.Info().Msg("Plex session polling started successfully")
+	}
+}
+
+// sessionPollerConfig builds the Plex poller configuration, enforcing the
+// minimum interval and staggering the first poll by ServerID.
+func (m *Manager) sessionPollerConfig() SessionPollerConfig {
 	pollerConfig := SessionPollerConfig{
 		Interval:       m.cfg.Plex.SessionPollingInterval,
 		PublishAll:     false,
 		SeenSessionTTL: 1 * time.Hour,
+		Source:         "plex",
+		ServerID:       m.plexServerID(),
 	}
 
 	// Enforce minimum poll interval to protect Plex server
@@ -300,13 +380,10 @@ func (m *Manager) startPlexSessionPollingService(ctx context.Context) {
 		pollerConfig.Interval = 10 * time.Second
 	}
 
-	m.sessionPoller = NewPlexSessionPoller(m, pollerConfig)
-	if err := m.sessionPoller.Start(ctx); err != nil {
-		logging.Warn().Err(err).Msg("Failed to start session polling")
-		m.sessionPoller = nil
-	} else {
-		logging.Info().Msg("Plex session polling started successfully")
+	if m.staggerFn != nil {
+		pollerConfig.StartOffset = m.staggerFn(m.cfg.Plex.ServerID, pollerConfig.Interval)
 	}
+	return pollerConfig
 }
 
 // Stop gracefully stops the synchronization process
@@ -349,6 +426,62 @@ func (m *Manager) LastSyncTime() time.Time {
 	return m.lastSync
 }
 
+// IsSyncing reports whether a Tautulli or Plex history sync is running.
+// Background jobs such as model training wait for it to finish.
+func (m *Manager) IsSyncing() bool {
+	return m.activeSyncs.Load() > 0
+}
+
+// SetSyncSettings changes the Tautulli sync interval and batch size without
+// a restart. A new interval restarts the wait for the next periodic sync; a
+// new batch size applies from the next sync. Non-positive values are ignored.
+func (m *Manager) SetSyncSettings(interval time.Duration, batchSize int) {
+	m.mu.Lock()
+	if interval > 0 {
+		m.syncInterval = interval
+	}
+	if batchSize > 0 {
+		m.syncBatchSize = batchSize
+	}
+	m.mu.Unlock()
+
+	select {
+	case m.intervalChanged <- struct{}{}:
+	default: // A reset is already pending
+	}
+}
+
+// interval returns the current Tautulli sync interval.
+func (m *Manager) interval() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.syncInterval > 0 {
+		return m.syncInterval
+	}
+	return m.cfg.Sync.Interval
+}
+
+// batchSize returns the current Tautulli history batch size.
+func (m *Manager) batchSize() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.syncBatchSize > 0 {
+		return m.syncBatchSize
+	}
+	return m.cfg.Sync.BatchSize
+}
+
+// trackSync marks a history sync of the source's server as running, for
+// IsSyncing and sync_in_progress, until the returned func is called.
+func (m *Manager) trackSync(source, serverID string) func() {
+	m.activeSyncs.Add(1)
+	untrack := metrics.TrackSyncInProgress(source, serverID)
+	return func() {
+		untrack()
+		m.activeSyncs.Add(-1)
+	}
+}
+
 // TriggerSync manually triggers a synchronization
 func (m *Manager) TriggerSync() error {
 	// Prevent concurrent sync execution
@@ -224,3 +224,36 @@ func TestManager_RaceConditions(t *testing.T) {
 		t.Errorf("Stop() returned error: %v", err)
 	}
 }
+
+func TestManager_IsSyncing(t *testing.T) {
+	cfg := newTestConfig()
+
+	var manager *Manager
+	var syncingDuringFetch bool
+	mockClient := &mockTautulliClient{
+		getHistorySince: func(ctx context.Context, since time.Time, start, length int) (*tautulli.TautulliHistory, error) {
+			syncingDuringFetch = manager.IsSyncing()
+			return &tautulli.TautulliHistory{
+				Response: tautulli.TautulliHistoryResponse{
+					Result: "success",
+					Data:   tautulli.TautulliHistoryData{Data: []tautulli.TautulliHistoryRecord{}},
+				},
+			}, nil
+		},
+	}
+
+	manager = NewManager(&mockDB{}, nil, mockClient, cfg, nil)
+
+	if manager.IsSyncing() {
+		t.Error("IsSyncing() = true before any sync")
+	}
+	if err := manager.TriggerSync(); err != nil {
+		t.Fatalf("TriggerSync() error = %v", err)
+	}
+	if !syncingDuringFetch {
+		t.Error("IsSyncing() = false while fetching history")
+	}
+	if manager.IsSyncing() {
+		t.Error("IsSyncing() = true after sync finished")
+	}
+}
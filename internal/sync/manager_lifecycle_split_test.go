@@ -158,3 +158,42 @@ func TestManager_StartStop(t *testing.T) {
 		t.Error("Expected error when stopping already stopped manager")
 	}
 }
+
+func TestManager_SetSyncSettings(t *testing.T) {
+	t.Parallel() // Safe - isolated test with no shared state
+
+	cfg := newTestConfig()
+	cfg.Sync.Interval = time.Hour // The first periodic sync would never come
+	lengths := make(chan int, 10)
+	mockClient := &mockTautulliClient{
+		getHistorySince: func(ctx context.Context, since time.Time, start, length int) (*tautulli.TautulliHistory, error) {
+			lengths <- length
+			return &tautulli.TautulliHistory{
+				Response: tautulli.TautulliHistoryResponse{Result: "success"},
+			}, nil
+		},
+	}
+	manager := NewManager(&mockDB{}, nil, mockClient, cfg, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager.wg.Add(1)
+	go manager.syncLoop(ctx)
+
+	manager.SetSyncSettings(20*time.Millisecond, 7)
+
+	select {
+	case length := <-lengths:
+		if length != 7 {
+			t.Errorf("batch size = %d, want 7", length)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no sync ran on the new interval")
+	}
+
+	// Non-positive values keep the current settings
+	manager.SetSyncSettings(0, -1)
+	if manager.interval() != 20*time.Millisecond || manager.batchSize() != 7 {
+		t.Errorf("settings = %v/%d, want 20ms/7", manager.interval(), manager.batchSize())
+	}
+}
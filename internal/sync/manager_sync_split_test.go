@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/tomtom215/cartographus/internal/clock"
 	"github.com/tomtom215/cartographus/internal/models"
 	"github.com/tomtom215/cartographus/internal/models/tautulli"
 )
@@ -491,6 +492,67 @@ func TestManager_SyncLoop_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestManager_SyncLoop_FakeClockInterval(t *testing.T) {
+	t.Parallel() // Safe - isolated mock and clock
+
+	cfg := newTestConfig()
+	syncs := make(chan time.Time, 10)
+	mockClient := &mockTautulliClient{
+		getHistorySince: func(ctx context.Context, since time.Time, start, length int) (*tautulli.TautulliHistory, error) {
+			syncs <- since
+			return &tautulli.TautulliHistory{
+				Response: tautulli.TautulliHistoryResponse{
+					Result: "success",
+					Data:   tautulli.TautulliHistoryData{Data: []tautulli.TautulliHistoryRecord{}},
+				},
+			}, nil
+		},
+	}
+
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	manager := NewManagerWithClock(&mockDB{}, nil, mockClient, cfg, nil, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	manager.wg.Add(1)
+	go manager.syncLoop(ctx)
+	defer func() {
+		cancel()
+		manager.wg.Wait()
+	}()
+
+	nextSync := func() time.Time {
+		t.Helper()
+		select {
+		case since := <-syncs:
+			return since
+		case <-time.After(5 * time.Second):
+			t.Fatal("no sync after the interval elapsed")
+			return time.Time{}
+		}
+	}
+
+	// Nothing happens until a full interval has passed
+	clk.BlockUntil(1)
+	clk.Advance(cfg.Sync.Interval - time.Second)
+	if len(syncs) != 0 {
+		t.Fatal("synced before the interval elapsed")
+	}
+
+	// The first sync looks back from the fake time
+	clk.Advance(time.Second)
+	firstAt := start.Add(cfg.Sync.Interval)
+	if since := nextSync(); !since.Equal(firstAt.Add(-cfg.Sync.Lookback)) {
+		t.Errorf("first sync since = %v, want lookback from %v", since, firstAt)
+	}
+
+	// The next one resumes from the first sync's start time
+	clk.Advance(cfg.Sync.Interval)
+	if since := nextSync(); !since.Equal(firstAt) {
+		t.Errorf("second sync since = %v, want %v", since, firstAt)
+	}
+}
+
 func TestManager_PerformInitialSync_Success(t *testing.T) {
 	t.Parallel() // Safe - isolated mock with no shared state
 
@@ -151,6 +151,7 @@ func TestMultiSource_SimultaneousPlayback(t *testing.T) {
 		SessionPollingInterval: 100 * time.Millisecond,
 	}
 	jellyfinManager := NewJellyfinManager(jellyfinCfg, hub, nil)
+	jellyfinManager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	jellyfinManager.SetEventPublisher(publisher)
 
 	// Create Emby manager
@@ -164,6 +165,7 @@ func TestMultiSource_SimultaneousPlayback(t *testing.T) {
 		SessionPollingInterval: 100 * time.Millisecond,
 	}
 	embyManager := NewEmbyManager(embyCfg, hub, nil)
+	embyManager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	embyManager.SetEventPublisher(publisher)
 
 	ctx := context.Background()
@@ -368,6 +370,7 @@ func TestMultiSource_GracefulDegradation(t *testing.T) {
 		SessionPollingInterval: 100 * time.Millisecond,
 	}
 	jellyfinManager := NewJellyfinManager(jellyfinCfg, nil, nil)
+	jellyfinManager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	jellyfinManager.SetEventPublisher(publisher)
 
 	embyCfg := &config.EmbyConfig{
@@ -379,6 +382,7 @@ func TestMultiSource_GracefulDegradation(t *testing.T) {
 		SessionPollingInterval: 100 * time.Millisecond,
 	}
 	embyManager := NewEmbyManager(embyCfg, nil, nil)
+	embyManager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	embyManager.SetEventPublisher(publisher)
 
 	ctx := context.Background()
@@ -488,6 +492,7 @@ func TestMultiSource_HighVolumeLoad(t *testing.T) {
 		SessionPollingInterval: 50 * time.Millisecond,
 	}
 	jellyfinManager := NewJellyfinManager(jellyfinCfg, nil, nil)
+	jellyfinManager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	jellyfinManager.SetEventPublisher(publisher)
 
 	embyCfg := &config.EmbyConfig{
@@ -499,6 +504,7 @@ func TestMultiSource_HighVolumeLoad(t *testing.T) {
 		SessionPollingInterval: 50 * time.Millisecond,
 	}
 	embyManager := NewEmbyManager(embyCfg, nil, nil)
+	embyManager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	embyManager.SetEventPublisher(publisher)
 
 	ctx := context.Background()
@@ -622,6 +628,7 @@ func TestMultiSource_MixedSessionStates(t *testing.T) {
 		SessionPollingInterval: 100 * time.Millisecond,
 	}
 	manager := NewJellyfinManager(cfg, hub, nil)
+	manager.staggerFn = nil // Poll immediately; stagger is covered in stagger_test.go
 	manager.SetEventPublisher(publisher)
 
 	ctx := context.Background()
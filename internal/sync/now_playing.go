@@ -0,0 +1,347 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// nowPlayingTimeout bounds a single session fetch so one slow server cannot
+// stall the aggregated now-playing list.
+const nowPlayingTimeout = 5 * time.Second
+
+// NowPlayingProvider is implemented by managers that can list the sessions
+// currently playing on the media servers they sync from (Manager for Plex,
+// JellyfinManager, EmbyManager).
+type NowPlayingProvider interface {
+	NowPlaying(ctx context.Context) []NowPlayingResult
+}
+
+// NowPlayingResult holds the active sessions of one media server, or the
+// error that prevented fetching them.
+type NowPlayingResult struct {
+	Source   string // plex, jellyfin, emby
+	ServerID string
+	Sessions []models.NowPlayingSession
+	Err      error
+}
+
+// nowPlayingFetch describes a session fetch from one media server.
+type nowPlayingFetch struct {
+	source   string
+	serverID string
+	fetch    func(ctx context.Context) ([]models.NowPlayingSession, error)
+	timeout  time.Duration // nowPlayingTimeout if zero
+}
+
+// run fetches the server's sessions and stamps them with the source and
+// server ID.
+func (f nowPlayingFetch) run(ctx context.Context) NowPlayingResult {
+	timeout := f.timeout
+	if timeout <= 0 {
+		timeout = nowPlayingTimeout
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := NowPlayingResult{Source: f.source, ServerID: f.serverID}
+	sessions, err := f.fetch(fetchCtx)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	for i := range sessions {
+		sessions[i].Source = f.source
+		sessions[i].ServerID = f.serverID
+	}
+	result.Sessions = sessions
+	return result
+}
+
+// NowPlaying lists the active sessions on the Plex server. Tautulli-only
+// setups report no sources.
+func (m *Manager) NowPlaying(ctx context.Context) []NowPlayingResult {
+	if m.plexClient == nil {
+		return nil
+	}
+	fetch := nowPlayingFetch{
+		source:   "plex",
+		serverID: m.cfg.Plex.ServerID,
+		fetch: func(ctx context.Context) ([]models.NowPlayingSession, error) {
+			resp, err := m.plexClient.GetSessions(ctx)
+			if err != nil {
+				return nil, err
+			}
+			sessions := make([]models.NowPlayingSession, 0, len(resp.MediaContainer.Metadata))
+			for i := range resp.MediaContainer.Metadata {
+				sessions = append(sessions, plexNowPlaying(&resp.MediaContainer.Metadata[i]))
+			}
+			return sessions, nil
+		},
+	}
+	return []NowPlayingResult{fetch.run(ctx)}
+}
+
+// NowPlaying lists the active sessions on the Jellyfin server.
+func (m *JellyfinManager) NowPlaying(ctx context.Context) []NowPlayingResult {
+	if m == nil || m.client == nil {
+		return nil
+	}
+	fetch := nowPlayingFetch{
+		source:   "jellyfin",
+		serverID: m.cfg.ServerID,
+		fetch: func(ctx context.Context) ([]models.NowPlayingSession, error) {
+			active, err := m.client.GetActiveSessions(ctx)
+			if err != nil {
+				return nil, err
+			}
+			sessions := make([]models.NowPlayingSession, 0, len(active))
+			for i := range active {
+				sessions = append(sessions, jellyfinNowPlaying(&active[i]))
+			}
+			return sessions, nil
+		},
+	}
+	return []NowPlayingResult{fetch.run(ctx)}
+}
+
+// NowPlaying lists the active sessions on the Emby server.
+func (m *EmbyManager) NowPlaying(ctx context.Context) []NowPlayingResult {
+	if m == nil || m.client == nil {
+		return nil
+	}
+	fetch := nowPlayingFetch{
+		source:   "emby",
+		serverID: m.cfg.ServerID,
+		fetch: func(ctx context.Context) ([]models.NowPlayingSession, error) {
+			active, err := m.client.GetActiveSessions(ctx)
+			if err != nil {
+				return nil, err
+			}
+			sessions := make([]models.NowPlayingSession, 0, len(active))
+			for i := range active {
+				sessions = append(sessions, embyNowPlaying(&active[i]))
+			}
+			return sessions, nil
+		},
+	}
+	return []NowPlayingResult{fetch.run(ctx)}
+}
+
+// plexNowPlaying normalizes a Plex /status/sessions entry.
+func plexNowPlaying(s *models.PlexSession) models.NowPlayingSession {
+	session := models.NowPlayingSession{
+		SessionID:        s.SessionKey,
+		MediaType:        s.Type,
+		Title:            s.Title,
+		ParentTitle:      s.ParentTitle,
+		GrandparentTitle: s.GrandparentTitle,
+		RatingKey:        s.RatingKey,
+		State:            s.PlayState,
+		PositionSeconds:  s.ViewOffset / 1000,
+		DurationSeconds:  s.Duration / 1000,
+	}
+	if s.Duration > 0 {
+		session.ProgressPercent = int(s.ViewOffset * 100 / s.Duration)
+	}
+
+	if s.User != nil {
+		session.UserID = strconv.Itoa(s.User.ID)
+		session.Username = s.User.Title
+	}
+	if s.Player != nil {
+		session.Player = s.Player.Title
+		session.Platform = s.Player.Platform
+		session.Product = s.Player.Product
+		session.IPAddress = normalizeIPAddress(s.Player.Address)
+		if session.State == "" {
+			session.State = s.Player.State
+		}
+	}
+
+	if len(s.Media) > 0 {
+		media := &s.Media[0]
+		session.VideoResolution = media.VideoResolution
+		session.VideoCodec = media.VideoCodec
+		session.AudioCodec = media.AudioCodec
+		session.Container = media.Container
+		session.Bitrate = media.Bitrate
+	}
+
+	ts := s.TranscodeSession
+	if ts == nil {
+		session.TranscodeDecision = "direct play"
+		return session
+	}
+	session.TranscodeDecision = determineOverallTranscodeDecision(ts.VideoDecision, ts.AudioDecision)
+	session.Transcode = &models.NowPlayingTranscode{
+		VideoDecision: ts.VideoDecision,
+		AudioDecision: ts.AudioDecision,
+		VideoCodec:    ts.VideoCodec,
+		AudioCodec:    ts.AudioCodec,
+		Container:     ts.Container,
+		Width:         ts.Width,
+		Height:        ts.Height,
+		HardwareAccel: ts.TranscodeHwEncoding,
+		Speed:         ts.Speed,
+		Throttled:     ts.Throttled,
+	}
+	return session
+}
+
+// jellyfinNowPlaying normalizes an active Jellyfin session.
+func jellyfinNowPlaying(s *models.JellyfinSession) models.NowPlayingSession {
+	session := models.NowPlayingSession{
+		SessionID:         s.ID,
+		UserID:            s.UserID,
+		Username:          s.UserName,
+		State:             "playing",
+		PositionSeconds:   s.GetPositionSeconds(),
+		DurationSeconds:   s.GetDurationSeconds(),
+		ProgressPercent:   s.GetPercentComplete(),
+		Player:            s.DeviceName,
+		Platform:          s.Client,
+		IPAddress:         normalizeIPAddress(s.GetIPAddress()),
+		TranscodeDecision: s.GetTranscodeDecision(),
+	}
+	if s.IsPaused() {
+		session.State = "paused"
+	}
+
+	if item := s.NowPlayingItem; item != nil {
+		session.MediaType = item.GetMediaType()
+		session.Title = item.Name
+		session.RatingKey = item.ID
+		session.Container = item.Container
+		switch {
+		case item.SeriesName != "":
+			session.GrandparentTitle = item.SeriesName
+			session.ParentTitle = item.SeasonName
+		case item.Album != "":
+			session.GrandparentTitle = item.AlbumArtist
+			session.ParentTitle = item.Album
+		}
+		for i := range item.MediaStreams {
+			stream := &item.MediaStreams[i]
+			switch {
+			case stream.Type == "Video" && session.VideoCodec == "":
+				session.VideoCodec = stream.Codec
+				session.VideoResolution = resolutionLabel(stream.Height)
+				session.Bitrate = stream.BitRate / 1000
+			case stream.Type == "Audio" && session.AudioCodec == "":
+				session.AudioCodec = stream.Codec
+			}
+		}
+	}
+
+	if ti := s.TranscodingInfo; ti != nil && s.IsTranscoding() {
+		session.Transcode = &models.NowPlayingTranscode{
+			VideoDecision:    streamDecision(ti.IsVideoDirect),
+			AudioDecision:    streamDecision(ti.IsAudioDirect),
+			VideoCodec:       ti.VideoCodec,
+			AudioCodec:       ti.AudioCodec,
+			Container:        ti.Container,
+			Width:            ti.Width,
+			Height:           ti.Height,
+			HardwareAccel:    ti.HardwareAccelerationType,
+			TranscodeReasons: ti.TranscodeReasons,
+		}
+	}
+	return session
+}
+
+// embyNowPlaying normalizes an active Emby session.
+func embyNowPlaying(s *models.EmbySession) models.NowPlayingSession {
+	session := models.NowPlayingSession{
+		SessionID:         s.ID,
+		UserID:            s.UserID,
+		Username:          s.UserName,
+		State:             "playing",
+		PositionSeconds:   s.GetPositionSeconds(),
+		DurationSeconds:   s.GetDurationSeconds(),
+		ProgressPercent:   s.GetPercentComplete(),
+		Player:            s.DeviceName,
+		Platform:          s.Client,
+		IPAddress:         normalizeIPAddress(s.GetIPAddress()),
+		TranscodeDecision: s.GetTranscodeDecision(),
+	}
+	if s.IsPaused() {
+		session.State = "paused"
+	}
+
+	if item := s.NowPlayingItem; item != nil {
+		session.MediaType = item.GetMediaType()
+		session.Title = item.Name
+		session.RatingKey = item.ID
+		session.Container = item.Container
+		switch {
+		case item.SeriesName != "":
+			session.GrandparentTitle = item.SeriesName
+			session.ParentTitle = item.SeasonName
+		case item.Album != "":
+			session.GrandparentTitle = item.AlbumArtist
+			session.ParentTitle = item.Album
+		}
+		for i := range item.MediaStreams {
+			stream := &item.MediaStreams[i]
+			switch {
+			case stream.Type == "Video" && session.VideoCodec == "":
+				session.VideoCodec = stream.Codec
+				session.VideoResolution = resolutionLabel(stream.Height)
+				session.Bitrate = stream.BitRate / 1000
+			case stream.Type == "Audio" && session.AudioCodec == "":
+				session.AudioCodec = stream.Codec
+			}
+		}
+	}
+
+	if ti := s.TranscodingInfo; ti != nil && s.IsTranscoding() {
+		session.Transcode = &models.NowPlayingTranscode{
+			VideoDecision:    streamDecision(ti.IsVideoDirect),
+			AudioDecision:    streamDecision(ti.IsAudioDirect),
+			VideoCodec:       ti.VideoCodec,
+			AudioCodec:       ti.AudioCodec,
+			Container:        ti.Container,
+			Width:            ti.Width,
+			Height:           ti.Height,
+			HardwareAccel:    ti.HardwareAccelerationType,
+			TranscodeReasons: ti.TranscodeReasons,
+		}
+	}
+	return session
+}
+
+// streamDecision maps a Jellyfin/Emby IsVideoDirect/IsAudioDirect flag to
+// Plex's decision vocabulary.
+func streamDecision(direct bool) string {
+	if direct {
+		return "copy"
+	}
+	return "transcode"
+}
+
+// resolutionLabel maps a video height to Plex's videoResolution values
+// (4k, 1080, 720, 480, sd) so sessions from all sources compare directly.
+func resolutionLabel(height int) string {
+	switch {
+	case height <= 0:
+		return ""
+	case height >= 2160:
+		return "4k"
+	case height >= 1080:
+		return "1080"
+	case height >= 720:
+		return "720"
+	case height >= 480:
+		return "480"
+	default:
+		return "sd"
+	}
+}
@@ -0,0 +1,139 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestNowPlayingFetch_Run(t *testing.T) {
+	t.Run("stamps source and server", func(t *testing.T) {
+		fetch := nowPlayingFetch{
+			source:   "emby",
+			serverID: "emby-1",
+			fetch: func(context.Context) ([]models.NowPlayingSession, error) {
+				return []models.NowPlayingSession{{SessionID: "a"}, {SessionID: "b"}}, nil
+			},
+		}
+
+		result := fetch.run(context.Background())
+		if result.Err != nil || len(result.Sessions) != 2 {
+			t.Fatalf("Err/Sessions = %v/%d", result.Err, len(result.Sessions))
+		}
+		for _, session := range result.Sessions {
+			if session.Source != "emby" || session.ServerID != "emby-1" {
+				t.Errorf("session %s: Source/ServerID = %q/%q", session.SessionID, session.Source, session.ServerID)
+			}
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		fetch := nowPlayingFetch{
+			source:   "plex",
+			serverID: "plex-1",
+			fetch: func(ctx context.Context) ([]models.NowPlayingSession, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			timeout: 10 * time.Millisecond,
+		}
+
+		result := fetch.run(context.Background())
+		if !errors.Is(result.Err, context.DeadlineExceeded) {
+			t.Errorf("Err = %v, want deadline exceeded", result.Err)
+		}
+		if result.Source != "plex" || result.ServerID != "plex-1" {
+			t.Errorf("Source/ServerID = %q/%q", result.Source, result.ServerID)
+		}
+	})
+}
+
+func TestPlexNowPlaying(t *testing.T) {
+	session := plexNowPlaying(&models.PlexSession{
+		SessionKey:       "42",
+		RatingKey:        "1001",
+		Type:             "episode",
+		Title:            "Pilot",
+		GrandparentTitle: "The Show",
+		ParentTitle:      "Season 1",
+		ViewOffset:       600000,
+		Duration:         2400000,
+		User:             &models.PlexSessionUser{ID: 7, Title: "alice"},
+		Player:           &models.PlexSessionPlayer{Title: "Living Room", Platform: "tvOS", Product: "Plex for Apple TV", Address: "203.0.113.7", State: "paused"},
+		Media:            []models.PlexMedia{{VideoResolution: "4k", VideoCodec: "hevc", AudioCodec: "eac3", Container: "mkv", Bitrate: 40000}},
+		TranscodeSession: &models.PlexTranscodeSession{VideoDecision: "transcode", AudioDecision: "copy", VideoCodec: "h264", Height: 1080, TranscodeHwEncoding: "qsv", Speed: 2.5},
+	})
+
+	if session.SessionID != "42" || session.UserID != "7" || session.Username != "alice" {
+		t.Errorf("SessionID/UserID/Username = %q/%q/%q", session.SessionID, session.UserID, session.Username)
+	}
+	if session.State != "paused" || session.PositionSeconds != 600 || session.DurationSeconds != 2400 || session.ProgressPercent != 25 {
+		t.Errorf("State/Position/Duration/Progress = %q/%d/%d/%d", session.State, session.PositionSeconds, session.DurationSeconds, session.ProgressPercent)
+	}
+	if session.Player != "Living Room" || session.IPAddress != "203.0.113.7" || session.VideoResolution != "4k" {
+		t.Errorf("Player/IPAddress/VideoResolution = %q/%q/%q", session.Player, session.IPAddress, session.VideoResolution)
+	}
+	if session.TranscodeDecision != "transcode" || session.Transcode == nil || session.Transcode.HardwareAccel != "qsv" {
+		t.Errorf("TranscodeDecision = %q, Transcode = %+v", session.TranscodeDecision, session.Transcode)
+	}
+
+	direct := plexNowPlaying(&models.PlexSession{SessionKey: "1"})
+	if direct.TranscodeDecision != "direct play" || direct.Transcode != nil {
+		t.Errorf("direct play: TranscodeDecision = %q, Transcode = %+v", direct.TranscodeDecision, direct.Transcode)
+	}
+}
+
+func TestJellyfinNowPlaying(t *testing.T) {
+	session := jellyfinNowPlaying(&models.JellyfinSession{
+		ID:             "sess-1",
+		UserID:         "user-uuid",
+		UserName:       "bob",
+		Client:         "Jellyfin Web",
+		DeviceName:     "Firefox",
+		RemoteEndPoint: "198.51.100.4:52100",
+		NowPlayingItem: &models.JellyfinNowPlayingItem{
+			ID:           "item-1",
+			Name:         "Movie",
+			Type:         "Movie",
+			RunTimeTicks: 72000000000, // 2h
+			Container:    "mkv",
+			MediaStreams: []models.JellyfinMediaStream{
+				{Type: "Audio", Codec: "aac"},
+				{Type: "Video", Codec: "h264", Height: 1080, BitRate: 8000000},
+			},
+		},
+		PlayState:       &models.JellyfinPlayState{PositionTicks: 36000000000, PlayMethod: "Transcode"},
+		TranscodingInfo: &models.JellyfinTranscodingInfo{IsVideoDirect: false, IsAudioDirect: true, VideoCodec: "h264", HardwareAccelerationType: "vaapi", TranscodeReasons: []string{"ContainerBitrateExceedsLimit"}},
+	})
+
+	if session.MediaType != "movie" || session.Title != "Movie" || session.State != "playing" {
+		t.Errorf("MediaType/Title/State = %q/%q/%q", session.MediaType, session.Title, session.State)
+	}
+	if session.ProgressPercent != 50 || session.IPAddress != "198.51.100.4" {
+		t.Errorf("ProgressPercent/IPAddress = %d/%q", session.ProgressPercent, session.IPAddress)
+	}
+	if session.VideoResolution != "1080" || session.VideoCodec != "h264" || session.AudioCodec != "aac" || session.Bitrate != 8000 {
+		t.Errorf("quality = %q %q %q %d", session.VideoResolution, session.VideoCodec, session.AudioCodec, session.Bitrate)
+	}
+	if session.TranscodeDecision != "transcode" || session.Transcode == nil ||
+		session.Transcode.VideoDecision != "transcode" || session.Transcode.AudioDecision != "copy" {
+		t.Errorf("TranscodeDecision = %q, Transcode = %+v", session.TranscodeDecision, session.Transcode)
+	}
+}
+
+func TestResolutionLabel(t *testing.T) {
+	tests := map[int]string{0: "", 360: "sd", 480: "480", 720: "720", 1080: "1080", 2160: "4k"}
+	for height, want := range tests {
+		if got := resolutionLabel(height); got != want {
+			t.Errorf("resolutionLabel(%d) = %q, want %q", height, got, want)
+		}
+	}
+}
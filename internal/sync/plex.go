@@ -28,11 +28,14 @@ real-time session data and historical backfill capabilities.
 
 API Methods in this file:
   - NewPlexClient(): Create authenticated client
+  - NewPlexClientWithServerID(): Client with per-server circuit breaker label
   - GetHistoryAll(): Fetch complete playback history
+  - GetHistorySince(): Fetch playback history newer than a watermark
   - doRequestWithRateLimit(): HTTP 429 retry logic
 
 Related Files:
   - plex_request.go: HTTP request helpers
+  - plex_circuit_breaker.go: Circuit breaker protection for API calls
   - plex_sessions.go: Session and transcode monitoring
   - plex_library.go: Library content methods
   - plex_server.go: Server information methods
@@ -46,8 +49,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
+	gobreaker "github.com/sony/gobreaker/v2"
 	"github.com/tomtom215/cartographus/internal/logging"
 )
 
@@ -57,6 +62,11 @@ type PlexClient struct {
 	baseURL    string
 	token      string
 	httpClient *http.Client
+
+	// Circuit breaker for Plex Media Server API calls (see plex_circuit_breaker.go)
+	cb       *gobreaker.CircuitBreaker[*http.Response]
+	cbName   string
+	notifier *stateChangeNotifier
 }
 
 // Plex API Response Structures
@@ -142,12 +152,25 @@ type PlexIdentityContainer struct {
 //
 // Returns initialized PlexClient with 30-second HTTP timeout
 func NewPlexClient(baseURL, token string) *PlexClient {
+	return NewPlexClientWithServerID(baseURL, token, "")
+}
+
+// NewPlexClientWithServerID creates a Plex API client whose circuit breaker
+// metrics are labeled with the server ID ("plex-api:<serverID>"), so each
+// server in a multi-server deployment reports its own breaker state.
+func NewPlexClientWithServerID(baseURL, token, serverID string) *PlexClient {
+	cbName := circuitBreakerName("plex-api", serverID)
+	notifier := &stateChangeNotifier{}
+
 	return &PlexClient{
 		baseURL: baseURL,
 		token:   token,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		cb:       newMediaCircuitBreaker[*http.Response](cbName, "Plex", notifier),
+		cbName:   cbName,
+		notifier: notifier,
 	}
 }
 
@@ -183,6 +206,33 @@ func (c *PlexClient) GetHistoryAll(ctx context.Context, sort string, accountID *
 	return historyResp.MediaContainer.Metadata, nil
 }
 
+// GetHistorySince fetches playback history viewed after since
+//
+// The viewedAt> filter is applied by Plex, so only newer records are
+// transferred. Callers should still check ViewedAt, as older servers may
+// ignore the filter. A zero since fetches all history.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - since: Only return records viewed after this time
+//   - sort: Sort order - "viewedAt" (oldest first) or "-viewedAt" (newest first)
+func (c *PlexClient) GetHistorySince(ctx context.Context, since time.Time, sort string) ([]PlexMetadata, error) {
+	query := url.Values{}
+	if sort != "" {
+		query.Add("sort", sort)
+	}
+	if !since.IsZero() {
+		query.Add("viewedAt>", strconv.FormatInt(since.Unix(), 10))
+	}
+
+	var historyResp PlexHistoryResponse
+	if err := c.doJSONRequestWithQuery(ctx, "/status/sessions/history/all", query, &historyResp); err != nil {
+		return nil, err
+	}
+
+	return historyResp.MediaContainer.Metadata, nil
+}
+
 // doRequestWithRateLimit executes HTTP request with automatic retry on rate limiting (HTTP 429)
 //
 // This method implements exponential backoff retry logic to handle Plex API rate limits:
@@ -0,0 +1,87 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// BufferAlertPublisher publishes critical buffer health events to the event
+// bus. Implemented by eventprocessor.Publisher.
+type BufferAlertPublisher interface {
+	PublishBufferHealthCritical(ctx context.Context, event *models.BufferHealthCriticalEvent) error
+}
+
+// BufferCriticalFunc is called with each critical buffer health event.
+type BufferCriticalFunc func(ctx context.Context, event *models.BufferHealthCriticalEvent)
+
+// SetBufferAlertPublisher sets the optional publisher for critical buffer
+// health events. Passing nil disables publishing.
+func (m *Manager) SetBufferAlertPublisher(publisher BufferAlertPublisher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bufferAlerts = publisher
+}
+
+// SetOnBufferCritical sets an optional callback for critical buffer health
+// events, used to forward them to the detection notifiers.
+func (m *Manager) SetOnBufferCritical(fn BufferCriticalFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onBufferCritical = fn
+}
+
+// alertOnBufferCritical emits a critical event when current is the first
+// critical sample of a session since it was last above the threshold. The
+// session's AlertSent flag carries over between polls while it stays
+// critical, so a declining buffer raises one event rather than one per poll.
+//
+// The event is broadcast to WebSocket clients as "buffer_health_critical",
+// published to NATS and passed to the OnBufferCritical callback, each if set.
+func (m *Manager) alertOnBufferCritical(ctx context.Context, previous, current *models.PlexBufferHealth) {
+	if !current.IsCritical() {
+		return
+	}
+	if previous != nil && previous.IsCritical() && previous.AlertSent {
+		current.AlertSent = true
+		return
+	}
+	current.AlertSent = true
+
+	predicted := current.GetPredictedBufferingSeconds()
+	if previous != nil {
+		predicted = models.PredictStallSeconds(previous.BufferSeconds, current.BufferSeconds, m.cfg.Plex.BufferHealthPollInterval)
+	}
+	event := models.NewBufferHealthCriticalEvent(current, m.cfg.Plex.BufferHealthCriticalThreshold, predicted)
+
+	logging.Warn().
+		Str("session", current.SessionKey).
+		Str("title", current.Title).
+		Str("user", current.Username).
+		Float64("fill_percent", current.BufferFillPercent).
+		Float64("predicted_stall_seconds", predicted).
+		Msg("Buffer health critical")
+
+	m.mu.RLock()
+	publisher := m.bufferAlerts
+	callback := m.onBufferCritical
+	m.mu.RUnlock()
+
+	if m.wsHub != nil {
+		m.wsHub.BroadcastJSON("buffer_health_critical", event)
+	}
+	if publisher != nil {
+		if err := publisher.PublishBufferHealthCritical(ctx, event); err != nil {
+			logging.Warn().Err(err).Str("session", current.SessionKey).Msg("Failed to publish buffer health critical event")
+		}
+	}
+	if callback != nil {
+		callback(ctx, event)
+	}
+}
@@ -0,0 +1,173 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// mockBufferAlertPublisher records published critical buffer events.
+type mockBufferAlertPublisher struct {
+	mu     sync.Mutex
+	events []*models.BufferHealthCriticalEvent
+}
+
+func (p *mockBufferAlertPublisher) PublishBufferHealthCritical(_ context.Context, event *models.BufferHealthCriticalEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// newBufferSeriesServer serves one transcoding Plex session whose buffer
+// holds the number of seconds stored in bufferMs, in milliseconds.
+func newBufferSeriesServer(t *testing.T, bufferMs *atomic.Int64) *httptest.Server {
+	t.Helper()
+	const viewOffset = 3600000
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"MediaContainer": {
+				"size": 1,
+				"Metadata": [{
+					"sessionKey": "session1",
+					"title": "Movie 1",
+					"viewOffset": %d,
+					"TranscodeSession": {"key": "trans1", "videoDecision": "transcode", "speed": 0.8, "maxOffsetAvailable": %d},
+					"User": {"id": 1, "title": "alice"},
+					"Player": {"title": "Living Room TV"}
+				}]
+			}
+		}`, viewOffset, viewOffset+bufferMs.Load())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newBufferAlertTestManager(serverURL string, wsHub WebSocketHub) *Manager {
+	return &Manager{
+		cfg: &config.Config{
+			Plex: config.PlexConfig{
+				Enabled:                       true,
+				BufferHealthMonitoring:        true,
+				BufferHealthPollInterval:      5 * time.Second,
+				BufferHealthCriticalThreshold: 20.0, // 6s of the 30s capacity
+				BufferHealthRiskyThreshold:    50.0,
+			},
+		},
+		plexClient:        NewPlexClient(serverURL, "test-token"),
+		wsHub:             wsHub,
+		bufferHealthCache: make(map[string]*models.PlexBufferHealth),
+	}
+}
+
+func countBroadcasts(hub *mockWebSocketHub, messageType string) int {
+	n := 0
+	for _, b := range hub.getBroadcasts() {
+		if b.messageType == messageType {
+			n++
+		}
+	}
+	return n
+}
+
+func TestBufferHealthCriticalAlertFiresOnceOnDecline(t *testing.T) {
+	var bufferMs atomic.Int64
+	server := newBufferSeriesServer(t, &bufferMs)
+	wsHub := newMockWebSocketHub()
+	manager := newBufferAlertTestManager(server.URL, wsHub)
+
+	publisher := &mockBufferAlertPublisher{}
+	manager.SetBufferAlertPublisher(publisher)
+	var callbacks []*models.BufferHealthCriticalEvent
+	manager.SetOnBufferCritical(func(_ context.Context, event *models.BufferHealthCriticalEvent) {
+		callbacks = append(callbacks, event)
+	})
+
+	// Buffer shrinks 5s per 5s poll: healthy, risky, then critical from 5s
+	series := []int64{25000, 20000, 15000, 10000, 5000, 4000, 3000, 2000}
+	for i, ms := range series {
+		bufferMs.Store(ms)
+		manager.pollBufferHealth(context.Background())
+
+		wantEvents := 0
+		if ms < 6000 {
+			wantEvents = 1
+		}
+		if got := len(publisher.events); got != wantEvents {
+			t.Fatalf("after poll %d (%dms buffer): %d published events, want %d", i, ms, got, wantEvents)
+		}
+	}
+
+	if got := countBroadcasts(wsHub, "buffer_health_critical"); got != 1 {
+		t.Errorf("buffer_health_critical broadcasts = %d, want 1", got)
+	}
+	if len(callbacks) != 1 {
+		t.Fatalf("callbacks = %d, want 1", len(callbacks))
+	}
+
+	event := publisher.events[0]
+	if event.SessionKey != "session1" || event.Username != "alice" || event.PlayerDevice != "Living Room TV" {
+		t.Errorf("event identifies %q/%q/%q", event.SessionKey, event.Username, event.PlayerDevice)
+	}
+	if event.BufferSeconds != 5 || event.CriticalThreshold != 20 {
+		t.Errorf("event buffer = %vs, threshold = %v", event.BufferSeconds, event.CriticalThreshold)
+	}
+	// 5s left, draining 1s per second
+	if event.PredictedStallSeconds != 5 {
+		t.Errorf("PredictedStallSeconds = %v, want 5", event.PredictedStallSeconds)
+	}
+	if event.Message != "Critical: Movie 1 buffering in 5s (buffer: 17%)" {
+		t.Errorf("Message = %q", event.Message)
+	}
+}
+
+func TestBufferHealthCriticalAlertFiresAgainAfterRecovery(t *testing.T) {
+	var bufferMs atomic.Int64
+	server := newBufferSeriesServer(t, &bufferMs)
+	manager := newBufferAlertTestManager(server.URL, nil)
+	publisher := &mockBufferAlertPublisher{}
+	manager.SetBufferAlertPublisher(publisher)
+
+	for _, ms := range []int64{10000, 4000, 3000, 20000, 5000} {
+		bufferMs.Store(ms)
+		manager.pollBufferHealth(context.Background())
+	}
+
+	if got := len(publisher.events); got != 2 {
+		t.Errorf("published events = %d, want 2 (one per threshold crossing)", got)
+	}
+}
+
+func TestPredictStallSeconds(t *testing.T) {
+	tests := []struct {
+		name              string
+		previous, current float64
+		want              float64
+	}{
+		{name: "draining", previous: 10, current: 6, want: 7.5}, // 0.8s lost per second
+		{name: "stable", previous: 6, current: 6, want: -1},
+		{name: "growing", previous: 4, current: 6, want: -1},
+		{name: "empty", previous: 4, current: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := models.PredictStallSeconds(tt.previous, tt.current, 5*time.Second); got != tt.want {
+				t.Errorf("PredictStallSeconds(%v, %v) = %v, want %v", tt.previous, tt.current, got, tt.want)
+			}
+		})
+	}
+}
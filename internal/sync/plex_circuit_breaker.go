@@ -0,0 +1,75 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"errors"
+	"net/http"
+
+	gobreaker "github.com/sony/gobreaker/v2"
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// errPlexServerError marks HTTP 5xx responses as circuit breaker failures.
+// The response is still returned to the caller for normal status handling.
+var errPlexServerError = errors.New("plex server error")
+
+// doWithCircuitBreaker executes a Plex HTTP call with circuit breaker protection
+//
+// Network errors and HTTP 5xx responses count as failures. When the circuit is
+// open the call is rejected with gobreaker.ErrOpenState without touching the network.
+//
+// Returns:
+//   - *http.Response: Response from fn (caller must close Body), including 5xx responses
+//   - error: Errors from fn, or ErrOpenState/ErrTooManyRequests when rejected
+func (c *PlexClient) doWithCircuitBreaker(fn func() (*http.Response, error)) (*http.Response, error) {
+	resp, err := c.cb.Execute(func() (*http.Response, error) {
+		resp, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return resp, errPlexServerError
+		}
+		return resp, nil
+	})
+
+	switch {
+	case err == nil:
+		metrics.CircuitBreakerRequests.WithLabelValues(c.cbName, "success").Inc()
+		metrics.CircuitBreakerConsecutiveFailures.WithLabelValues(c.cbName).Set(0)
+		return resp, nil
+
+	case errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests):
+		metrics.CircuitBreakerRequests.WithLabelValues(c.cbName, "rejected").Inc()
+		logging.Warn().Err(err).Str("breaker", c.cbName).Msg("[CIRCUIT BREAKER] Plex request rejected")
+		return nil, err
+
+	default:
+		metrics.CircuitBreakerRequests.WithLabelValues(c.cbName, "failure").Inc()
+		metrics.CircuitBreakerConsecutiveFailures.WithLabelValues(c.cbName).Set(float64(c.cb.Counts().ConsecutiveFailures))
+		if errors.Is(err, errPlexServerError) {
+			return resp, nil
+		}
+		return nil, err
+	}
+}
+
+// CircuitBreakerState returns the current Plex circuit breaker state
+func (c *PlexClient) CircuitBreakerState() gobreaker.State {
+	return c.cb.State()
+}
+
+// CircuitBreakerName returns the Plex circuit breaker name (metric label)
+func (c *PlexClient) CircuitBreakerName() string {
+	return c.cbName
+}
+
+// OnStateChange registers a callback invoked on every circuit breaker state transition
+func (c *PlexClient) OnStateChange(fn StateChangeFunc) {
+	c.notifier.set(fn)
+}
@@ -0,0 +1,134 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	gobreaker "github.com/sony/gobreaker/v2"
+
+	"github.com/tomtom215/cartographus/internal/metrics"
+)
+
+// stateChangeRecorder collects OnStateChange callbacks for assertions
+type stateChangeRecorder struct {
+	mu     stdsync.Mutex
+	events []string
+}
+
+func (r *stateChangeRecorder) record(name, from, to string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, name+" "+from+"->"+to)
+}
+
+func (r *stateChangeRecorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// assertOpenedOnce verifies a single closed->open transition was reported to
+// both the callback and the circuit_breaker_* metrics for the given breaker
+func assertOpenedOnce(t *testing.T, rec *stateChangeRecorder, name string, transitionsBefore float64) {
+	t.Helper()
+
+	events := rec.snapshot()
+	want := name + " closed->open"
+	if len(events) != 1 || events[0] != want {
+		t.Errorf("OnStateChange events = %v, want [%q]", events, want)
+	}
+
+	if got := testutil.ToFloat64(metrics.CircuitBreakerState.WithLabelValues(name)); got != 2 {
+		t.Errorf("circuit_breaker_state{name=%q} = %v, want 2 (open)", name, got)
+	}
+
+	transitions := testutil.ToFloat64(metrics.CircuitBreakerTransitions.WithLabelValues(name, "closed", "open"))
+	if delta := transitions - transitionsBefore; delta != 1 {
+		t.Errorf("circuit_breaker_state_transitions_total{name=%q,closed->open} delta = %v, want 1", name, delta)
+	}
+}
+
+func TestPlexCircuitBreaker_OpensAndReportsStateChange(t *testing.T) {
+	var requests atomic.Int32
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	client := NewPlexClientWithServerID(failServer.URL, "test-token", "plex-cb-test")
+	if got := client.CircuitBreakerName(); got != "plex-api:plex-cb-test" {
+		t.Fatalf("CircuitBreakerName() = %q, want %q", got, "plex-api:plex-cb-test")
+	}
+
+	rec := &stateChangeRecorder{}
+	client.OnStateChange(rec.record)
+	transitionsBefore := testutil.ToFloat64(metrics.CircuitBreakerTransitions.WithLabelValues(client.CircuitBreakerName(), "closed", "open"))
+
+	// 100% failure rate over the 10-request minimum trips the breaker
+	for i := 0; i < 12; i++ {
+		_ = client.Ping(context.Background())
+	}
+
+	if client.CircuitBreakerState() != gobreaker.StateOpen {
+		t.Fatalf("CircuitBreakerState() = %v, want open", client.CircuitBreakerState())
+	}
+	assertOpenedOnce(t, rec, client.CircuitBreakerName(), transitionsBefore)
+
+	// Open circuit rejects without hitting the server
+	before := requests.Load()
+	if _, err := client.GetServerIdentity(context.Background()); !errors.Is(err, gobreaker.ErrOpenState) {
+		t.Errorf("GetServerIdentity() error = %v, want ErrOpenState", err)
+	}
+	if got := requests.Load() - before; got != 0 {
+		t.Errorf("server received %d requests while circuit open, want 0", got)
+	}
+}
+
+func TestPlexCircuitBreaker_ServerErrorReturnsResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewPlexClient(server.URL, "test-token")
+	if got := client.CircuitBreakerName(); got != "plex-api" {
+		t.Errorf("CircuitBreakerName() = %q, want %q", got, "plex-api")
+	}
+
+	err := client.Ping(context.Background())
+	if err == nil {
+		t.Fatal("Ping() error = nil, want unexpected status error")
+	}
+	if errors.Is(err, errPlexServerError) {
+		t.Errorf("Ping() leaked internal breaker error: %v", err)
+	}
+	if counts := client.cb.Counts(); counts.TotalFailures != 1 {
+		t.Errorf("breaker TotalFailures = %d, want 1", counts.TotalFailures)
+	}
+}
+
+func TestPlexCircuitBreaker_ClientErrorsAreNotFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewPlexClient(server.URL, "test-token")
+	_ = client.Ping(context.Background())
+
+	if counts := client.cb.Counts(); counts.TotalFailures != 0 || counts.TotalSuccesses != 1 {
+		t.Errorf("breaker counts = %+v, want 1 success and 0 failures for HTTP 404", counts)
+	}
+}
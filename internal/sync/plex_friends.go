@@ -50,6 +50,7 @@ const (
 
 // PlexTVClient handles communication with plex.tv API for friends and sharing
 type PlexTVClient struct {
+	baseURL    string
 	token      string
 	machineID  string // Server's machineIdentifier for sharing operations
 	httpClient *http.Client
@@ -63,6 +64,17 @@ type PlexTVClientConfig struct {
 	MachineID  string // Server's machineIdentifier (from /identity)
 	ClientID   string // Application client identifier
 	ClientName string // Application name (e.g., "Cartographus")
+	BaseURL    string // plex.tv base URL (default: PlexTVBaseURL; for testing)
+}
+
+// PlexTVAPIError is returned when plex.tv answers with an error status.
+type PlexTVAPIError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *PlexTVAPIError) Error() string {
+	return fmt.Sprintf("API error: %d %s", e.StatusCode, e.Status)
 }
 
 // NewPlexTVClient creates a new client for plex.tv API
@@ -75,8 +87,13 @@ func NewPlexTVClient(cfg PlexTVClientConfig) *PlexTVClient {
 	if clientName == "" {
 		clientName = "Cartographus"
 	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = PlexTVBaseURL
+	}
 
 	return &PlexTVClient{
+		baseURL:    baseURL,
 		token:      cfg.Token,
 		machineID:  cfg.MachineID,
 		clientID:   clientID,
@@ -186,7 +203,7 @@ type PlexCreateManagedUserRequest struct {
 
 // doRequest executes an HTTP request against plex.tv API
 func (c *PlexTVClient) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	url := PlexTVBaseURL + path
+	url := c.baseURL + path
 
 	var reqBody *bytes.Buffer
 	if body != nil {
@@ -208,8 +225,10 @@ func (c *PlexTVClient) doRequest(ctx context.Context, method, path string, body
 		return fmt.Errorf("create request: %w", err)
 	}
 
-	// Set Plex headers
-	req.Header.Set("X-Plex-Token", c.token)
+	// Set Plex headers (no token before sign-in, e.g. when creating a PIN)
+	if c.token != "" {
+		req.Header.Set("X-Plex-Token", c.token)
+	}
 	req.Header.Set("X-Plex-Client-Identifier", c.clientID)
 	req.Header.Set("X-Plex-Product", c.clientName)
 	req.Header.Set("Accept", "application/json")
@@ -226,7 +245,7 @@ func (c *PlexTVClient) doRequest(ctx context.Context, method, path string, body
 
 	// Check for errors
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("API error: %d %s", resp.StatusCode, resp.Status)
+		return &PlexTVAPIError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	// Decode response if result pointer provided
@@ -0,0 +1,162 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration
+
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/testinfra"
+)
+
+// TestPlexClient_Integration tests the PlexClient and WebSocket client
+// end-to-end against the Plex mock container and its default fixtures.
+//
+// Usage:
+//
+//	go test -tags integration -run TestPlexClient_Integration ./internal/sync/...
+func TestPlexClient_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	testinfra.SkipIfNoDocker(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	plex, err := testinfra.NewPlexMockContainer(ctx)
+	if err != nil {
+		t.Fatalf("Failed to start Plex mock container: %v", err)
+	}
+	defer testinfra.CleanupContainer(t, ctx, plex.Container)
+
+	client := NewPlexClient(plex.URL, plex.Token)
+
+	t.Run("Ping succeeds", func(t *testing.T) {
+		if err := client.Ping(ctx); err != nil {
+			t.Fatalf("Ping error: %v", err)
+		}
+	})
+
+	t.Run("GetTranscodeSessions parses the session fixture", func(t *testing.T) {
+		sessions, err := client.GetTranscodeSessions(ctx)
+		if err != nil {
+			t.Fatalf("GetTranscodeSessions error: %v", err)
+		}
+		if len(sessions) != 2 {
+			t.Fatalf("got %d sessions, want 2", len(sessions))
+		}
+
+		movie := sessions[0]
+		if movie.SessionKey != "101" || movie.Title != "Arrival" || movie.User == nil || movie.User.Title != "admin" {
+			t.Errorf("movie session = %+v, want Arrival by admin", movie)
+		}
+		if movie.Player == nil || movie.Player.Address != "192.168.1.20" || !movie.Player.Local {
+			t.Errorf("movie player = %+v, want local 192.168.1.20", movie.Player)
+		}
+		if movie.TranscodeSession != nil {
+			t.Errorf("movie TranscodeSession = %+v, want direct play", movie.TranscodeSession)
+		}
+
+		episode := sessions[1]
+		if episode.GrandparentTitle != "Severance" || episode.Player == nil || episode.Player.State != "paused" {
+			t.Errorf("episode session = %+v, want paused Severance", episode)
+		}
+		ts := episode.TranscodeSession
+		if ts == nil || ts.VideoDecision != "transcode" || ts.TranscodeHwDecoding != "vaapi" || ts.Progress != 42.5 {
+			t.Errorf("episode TranscodeSession = %+v, want a vaapi transcode at 42.5%%", ts)
+		}
+		if len(episode.Media) != 1 || episode.Media[0].VideoResolution != "4k" {
+			t.Errorf("episode Media = %+v, want one 4k stream", episode.Media)
+		}
+	})
+
+	t.Run("GetTranscodeSessions follows injected sessions", func(t *testing.T) {
+		err := plex.SetSessions(ctx, models.PlexSessionsResponse{
+			MediaContainer: models.PlexSessionsContainer{
+				Size:     1,
+				Metadata: []models.PlexSession{{SessionKey: "201", Type: "track", Title: "Teardrop"}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("SetSessions error: %v", err)
+		}
+
+		sessions, err := client.GetTranscodeSessions(ctx)
+		if err != nil {
+			t.Fatalf("GetTranscodeSessions error: %v", err)
+		}
+		if len(sessions) != 1 || sessions[0].SessionKey != "201" || sessions[0].Title != "Teardrop" {
+			t.Errorf("sessions = %+v, want the injected track", sessions)
+		}
+	})
+
+	t.Run("GetLibrarySections parses the library fixture", func(t *testing.T) {
+		sections, err := client.GetLibrarySections(ctx)
+		if err != nil {
+			t.Fatalf("GetLibrarySections error: %v", err)
+		}
+		dirs := sections.MediaContainer.Directory
+		if len(dirs) != 2 || dirs[0].Title != "Movies" || dirs[1].Type != "show" {
+			t.Errorf("library sections = %+v, want Movies and TV Shows", dirs)
+		}
+	})
+
+	t.Run("WebSocket delivers playing notifications", func(t *testing.T) {
+		ws := NewPlexWebSocketClient(plex.URL, plex.Token)
+		playing := make(chan models.PlexPlayingNotification, 1)
+		ws.SetCallbacks(func(n models.PlexPlayingNotification) { playing <- n }, nil, nil, nil)
+
+		if err := ws.Connect(ctx); err != nil {
+			t.Fatalf("WebSocket Connect error: %v", err)
+		}
+		defer ws.Close()
+
+		notification := models.PlexNotificationWrapper{
+			NotificationContainer: models.PlexNotificationContainer{
+				Type: "playing",
+				Size: 1,
+				PlaySessionStateNotification: []models.PlexPlayingNotification{{
+					SessionKey: "101",
+					State:      "paused",
+					RatingKey:  "5001",
+					ViewOffset: 1835000,
+				}},
+			},
+		}
+
+		// The server registers the client right after the handshake; retry
+		// until it does
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			delivered, err := plex.Notify(ctx, notification)
+			if err != nil {
+				t.Fatalf("Notify error: %v", err)
+			}
+			if delivered > 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("WebSocket client never registered with the mock")
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		select {
+		case n := <-playing:
+			if n.SessionKey != "101" || n.State != "paused" || n.ViewOffset != 1835000 {
+				t.Errorf("playing notification = %+v, want session 101 paused at 1835000", n)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("no playing notification received")
+		}
+	})
+}
@@ -75,7 +75,7 @@ func (m *Manager) StartTranscodeMonitoring(ctx context.Context) error {
 //
 // Thread Safety: Safe for concurrent calls (database uses mutex)
 func (m *Manager) runTranscodeMonitoringLoop(ctx context.Context) {
-	ticker := time.NewTicker(m.cfg.Plex.TranscodeMonitoringInterval)
+	ticker := m.timeSource().NewTicker(m.cfg.Plex.TranscodeMonitoringInterval)
 	defer ticker.Stop()
 
 	logging.Info().Msg("Transcode monitoring loop started (interval: )")
@@ -88,7 +88,7 @@ func (m *Manager) runTranscodeMonitoringLoop(ctx context.Context) {
 		case <-ctx.Done():
 			logging.Info().Msg("Transcode monitoring loop stopped (context canceled)")
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			m.pollTranscodeSessions(ctx)
 		}
 	}
@@ -172,7 +172,7 @@ func (m *Manager) pollTranscodeSessions(ctx context.Context) {
 //  4. Fetches active session timeline data from /status/sessions
 //  5. Calculates buffer health metrics (fill %, drain rate, predicted buffering)
 //  6. Broadcasts buffer health data to frontend via WebSocket
-//  7. Sends alerts for critical/risky buffer conditions
+//  7. Sends an alert when a session's buffer first drops below the critical threshold
 //
 // Configuration:
 //   - ENABLE_BUFFER_HEALTH_MONITORING=true (required)
@@ -248,7 +248,7 @@ func (m *Manager) StartBufferHealthMonitoring(ctx context.Context) error {
 func (m *Manager) runBufferHealthMonitoringLoop(ctx context.Context) {
 	defer m.wg.Done()
 
-	ticker := time.NewTicker(m.cfg.Plex.BufferHealthPollInterval)
+	ticker := m.timeSource().NewTicker(m.cfg.Plex.BufferHealthPollInterval)
 	defer ticker.Stop()
 
 	logging.Info().Msg("Buffer health monitoring loop started (interval: )")
@@ -261,7 +261,7 @@ func (m *Manager) runBufferHealthMonitoringLoop(ctx context.Context) {
 		case <-ctx.Done():
 			logging.Info().Msg("Buffer health monitoring loop stopped (context canceled)")
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			m.pollBufferHealth(ctx)
 		}
 	}
@@ -399,6 +399,9 @@ func (m *Manager) processSessionForBufferHealth(ctx context.Context, session *mo
 	bufferHealth.Username = getUsername(session.User)
 	bufferHealth.PlayerDevice = getPlayerName(session.Player)
 
+	// Alert once when the buffer crosses the critical threshold
+	m.alertOnBufferCritical(ctx, previousState, bufferHealth)
+
 	// Store in cache for next drain rate calculation
 	m.bufferHealthMu.Lock()
 	m.bufferHealthCache[session.SessionKey] = bufferHealth
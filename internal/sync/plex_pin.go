@@ -0,0 +1,106 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PlexAuthAppURL is the plex.tv page where users approve a sign-in PIN.
+const PlexAuthAppURL = "https://app.plex.tv/auth#?"
+
+// ErrPlexPINExpired is returned by CheckPIN when the PIN expired before
+// the user approved it, or plex.tv no longer knows it.
+var ErrPlexPINExpired = errors.New("plex PIN expired")
+
+// PlexPIN is a plex.tv sign-in PIN. The user opens its auth URL and signs
+// in to Plex; AuthToken is set once they approve it.
+type PlexPIN struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	AuthToken string    `json:"authToken"`
+}
+
+// PlexServerResource is a Plex Media Server the signed-in user can access,
+// from the plex.tv resources API.
+type PlexServerResource struct {
+	Name             string                 `json:"name"`
+	ClientIdentifier string                 `json:"clientIdentifier"` // Server machineIdentifier
+	ProductVersion   string                 `json:"productVersion"`
+	Provides         string                 `json:"provides"` // e.g. "server" or "client,player"
+	Owned            bool                   `json:"owned"`
+	AccessToken      string                 `json:"accessToken"` // Token for this server
+	Connections      []PlexServerConnection `json:"connections"`
+}
+
+// PlexServerConnection is one address a Plex server can be reached at.
+type PlexServerConnection struct {
+	URI   string `json:"uri"`
+	Local bool   `json:"local"` // On the server's LAN
+	Relay bool   `json:"relay"` // Through the bandwidth-limited plex.tv relay
+}
+
+// CreatePIN requests a new strong sign-in PIN. No token is needed.
+func (c *PlexTVClient) CreatePIN(ctx context.Context) (*PlexPIN, error) {
+	var pin PlexPIN
+	if err := c.doRequest(ctx, http.MethodPost, "/api/v2/pins?strong=true", nil, &pin); err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// PINAuthURL returns the URL where the user approves pin. The client
+// identifier must match the one used to create and check the PIN.
+func (c *PlexTVClient) PINAuthURL(pin *PlexPIN) string {
+	params := url.Values{}
+	params.Set("clientID", c.clientID)
+	params.Set("code", pin.Code)
+	params.Set("context[device][product]", c.clientName)
+	return PlexAuthAppURL + params.Encode()
+}
+
+// CheckPIN returns the current state of a PIN. AuthToken is empty until the
+// user approves it. Returns ErrPlexPINExpired once it can no longer be
+// approved.
+func (c *PlexTVClient) CheckPIN(ctx context.Context, id int64) (*PlexPIN, error) {
+	var pin PlexPIN
+	err := c.doRequest(ctx, http.MethodGet, "/api/v2/pins/"+strconv.FormatInt(id, 10), nil, &pin)
+	var apiErr *PlexTVAPIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, ErrPlexPINExpired
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pin.AuthToken == "" && !pin.ExpiresAt.IsZero() && time.Now().After(pin.ExpiresAt) {
+		return nil, ErrPlexPINExpired
+	}
+	return &pin, nil
+}
+
+// ListServers returns the Plex Media Servers the client's token can access,
+// with their HTTPS and relay connections.
+func (c *PlexTVClient) ListServers(ctx context.Context) ([]PlexServerResource, error) {
+	var resources []PlexServerResource
+	if err := c.doRequest(ctx, http.MethodGet, "/api/v2/resources?includeHttps=1&includeRelay=1", nil, &resources); err != nil {
+		return nil, err
+	}
+
+	servers := make([]PlexServerResource, 0, len(resources))
+	for i := range resources {
+		if strings.Contains(resources[i].Provides, "server") {
+			servers = append(servers, resources[i])
+		}
+	}
+	return servers, nil
+}
@@ -0,0 +1,113 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPlexTVClient_PINFlow(t *testing.T) {
+	expires := time.Now().Add(15 * time.Minute).UTC().Format(time.RFC3339)
+	var approved atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Plex-Client-Identifier"); got != "cartographus-test" {
+			t.Errorf("%s X-Plex-Client-Identifier = %q", r.URL.Path, got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/pins":
+			if r.URL.Query().Get("strong") != "true" {
+				t.Error("PIN should be requested with strong=true")
+			}
+			if r.Header.Get("X-Plex-Token") != "" {
+				t.Error("PIN creation should not send a token")
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 4242, "code": "abcd1234", "expiresAt": "` + expires + `", "authToken": null}`))
+		case r.URL.Path == "/api/v2/pins/4242":
+			token := "null"
+			if approved.Load() {
+				token = `"user-token"`
+			}
+			w.Write([]byte(`{"id": 4242, "code": "abcd1234", "expiresAt": "` + expires + `", "authToken": ` + token + `}`))
+		case r.URL.Path == "/api/v2/pins/99":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/api/v2/resources":
+			if r.Header.Get("X-Plex-Token") != "user-token" {
+				t.Errorf("resources token = %q", r.Header.Get("X-Plex-Token"))
+			}
+			w.Write([]byte(`[
+				{"name": "Basement", "clientIdentifier": "srv1", "productVersion": "1.41.0", "provides": "server",
+				 "owned": true, "accessToken": "server-token",
+				 "connections": [{"uri": "http://192.168.1.10:32400", "local": true, "relay": false}]},
+				{"name": "Phone", "clientIdentifier": "phone1", "provides": "client,player"}
+			]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := PlexTVClientConfig{ClientID: "cartographus-test", BaseURL: server.URL}
+	client := NewPlexTVClient(cfg)
+	ctx := context.Background()
+
+	pin, err := client.CreatePIN(ctx)
+	if err != nil {
+		t.Fatalf("CreatePIN() error = %v", err)
+	}
+	if pin.ID != 4242 || pin.Code != "abcd1234" || pin.ExpiresAt.IsZero() {
+		t.Errorf("CreatePIN() = %+v", pin)
+	}
+	authURL := client.PINAuthURL(pin)
+	if !strings.HasPrefix(authURL, PlexAuthAppURL) || !strings.Contains(authURL, "clientID=cartographus-test") ||
+		!strings.Contains(authURL, "code=abcd1234") {
+		t.Errorf("PINAuthURL() = %s", authURL)
+	}
+
+	if pin, err = client.CheckPIN(ctx, 4242); err != nil || pin.AuthToken != "" {
+		t.Errorf("CheckPIN() before approval = %+v, %v", pin, err)
+	}
+	approved.Store(true)
+	if pin, err = client.CheckPIN(ctx, 4242); err != nil || pin.AuthToken != "user-token" {
+		t.Errorf("CheckPIN() after approval = %+v, %v", pin, err)
+	}
+	if _, err = client.CheckPIN(ctx, 99); !errors.Is(err, ErrPlexPINExpired) {
+		t.Errorf("CheckPIN() of unknown PIN error = %v, want ErrPlexPINExpired", err)
+	}
+
+	cfg.Token = "user-token"
+	servers, err := NewPlexTVClient(cfg).ListServers(ctx)
+	if err != nil {
+		t.Fatalf("ListServers() error = %v", err)
+	}
+	if len(servers) != 1 || servers[0].ClientIdentifier != "srv1" || servers[0].AccessToken != "server-token" ||
+		len(servers[0].Connections) != 1 || !servers[0].Connections[0].Local {
+		t.Errorf("ListServers() = %+v", servers)
+	}
+}
+
+func TestPlexTVClient_CheckPINExpired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		w.Write([]byte(`{"id": 1, "code": "abcd", "expiresAt": "` + expired + `", "authToken": null}`))
+	}))
+	defer server.Close()
+
+	client := NewPlexTVClient(PlexTVClientConfig{BaseURL: server.URL})
+	if _, err := client.CheckPIN(context.Background(), 1); !errors.Is(err, ErrPlexPINExpired) {
+		t.Errorf("CheckPIN() of unapproved expired PIN error = %v, want ErrPlexPINExpired", err)
+	}
+}
@@ -28,7 +28,8 @@ Request Config Options:
   - expectOK: Require HTTP 200 status
   - expectNoErr: Accept both 200 OK and 204 No Content
 
-All requests automatically use doRequestWithRateLimit() for HTTP 429 handling.
+All requests automatically use doRequestWithRateLimit() for HTTP 429 handling
+and run through the client's circuit breaker (see plex_circuit_breaker.go).
 */
 
 //nolint:staticcheck // File documentation, not package doc
@@ -75,8 +76,10 @@ func (c *PlexClient) doRequest(ctx context.Context, cfg requestConfig, result in
 		req.URL.RawQuery = cfg.query.Encode()
 	}
 
-	// Execute request with rate limiting
-	resp, err := c.doRequestWithRateLimit(req)
+	// Execute request with circuit breaker protection and rate limiting
+	resp, err := c.doWithCircuitBreaker(func() (*http.Response, error) {
+		return c.doRequestWithRateLimit(req)
+	})
 	if err != nil {
 		return err
 	}
@@ -64,9 +64,15 @@ func (c *PlexClient) Ping(ctx context.Context) error {
 
 	req.Header.Set("X-Plex-Token", c.token)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithCircuitBreaker(func() (*http.Response, error) {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("execute request: %w", err)
+		}
+		return resp, nil
+	})
 	if err != nil {
-		return fmt.Errorf("execute request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
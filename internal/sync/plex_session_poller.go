@@ -13,6 +13,7 @@ import (
 	"github.com/tomtom215/cartographus/internal/logging"
 
 	"github.com/tomtom215/cartographus/internal/cache"
+	"github.com/tomtom215/cartographus/internal/metrics"
 )
 
 // PlexSessionPoller periodically polls Plex for active sessions and publishes
@@ -40,6 +41,9 @@ type PlexSessionPoller struct {
 
 	// LRU cache for session tracking - O(1) operations with automatic eviction
 	seenSessions *cache.LRUCache
+
+	// lastPoll is the time of the last successful session fetch
+	lastPoll time.Time
 }
 
 // SessionPollerConfig configures the session poller behavior.
@@ -54,6 +58,32 @@ type SessionPollerConfig struct {
 
 	// SeenSessionTTL is how long to remember seen sessions.
 	SeenSessionTTL time.Duration
+
+	// StartOffset delays the first poll so pollers for different servers
+	// sharing the same Interval do not hit their APIs simultaneously.
+	// See staggerOffset.
+	StartOffset time.Duration
+
+	// Source and ServerID label the poller's sync_in_progress and
+	// sync_last_success_timestamp metrics; they are not recorded when
+	// Source is empty.
+	Source   string
+	ServerID string
+}
+
+// trackPoll counts a poll in sync_in_progress until the returned func is called
+func (c SessionPollerConfig) trackPoll() func() {
+	if c.Source == "" {
+		return func() {}
+	}
+	return metrics.TrackSyncInProgress(c.Source, c.ServerID)
+}
+
+// recordPollSuccess sets sync_last_success_timestamp after a successful poll
+func (c SessionPollerConfig) recordPollSuccess() {
+	if c.Source != "" {
+		metrics.RecordSyncSuccess(c.Source, c.ServerID)
+	}
 }
 
 // DefaultSessionPollerConfig returns production defaults.
@@ -136,7 +166,12 @@ func (p *PlexSessionPoller) IsRunning() bool {
 func (p *PlexSessionPoller) pollLoop(ctx context.Context) {
 	defer p.wg.Done()
 
-	// Do an initial poll immediately
+	// Stagger the first poll across the interval (multi-server deployments)
+	if !waitForStartOffset(ctx, p.stopChan, p.config.StartOffset) {
+		return
+	}
+
+	// Do an initial poll as soon as the start offset elapses
 	p.poll(ctx)
 
 	ticker := time.NewTicker(p.config.Interval)
@@ -161,11 +196,20 @@ func (p *PlexSessionPoller) pollLoop(ctx context.Context) {
 	}
 }
 
+// LastPollTime returns the time of the last successful session fetch,
+// or the zero time if no poll has succeeded yet.
+func (p *PlexSessionPoller) LastPollTime() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.lastPoll
+}
+
 // poll fetches sessions from Plex and publishes to NATS.
 func (p *PlexSessionPoller) poll(ctx context.Context) {
 	if p.manager == nil || p.manager.plexClient == nil {
 		return
 	}
+	defer p.config.trackPoll()()
 
 	// Fetch active sessions from Plex
 	sessions, err := p.manager.plexClient.GetTranscodeSessions(ctx)
@@ -174,6 +218,11 @@ func (p *PlexSessionPoller) poll(ctx context.Context) {
 		return
 	}
 
+	p.mu.Lock()
+	p.lastPoll = time.Now()
+	p.mu.Unlock()
+	p.config.recordPollSuccess()
+
 	if len(sessions) == 0 {
 		return
 	}
@@ -9,9 +9,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
 )
 
 // ========================================
@@ -46,6 +46,7 @@ func (m *Manager) syncPlexHistorical(ctx context.Context) error {
 	if m.plexClient == nil {
 		return fmt.Errorf("plex client not initialized")
 	}
+	defer m.trackSync("plex", m.plexServerID())()
 
 	// Test connectivity first (fail fast)
 	logging.Info().Msg("Testing Plex server connectivity...")
@@ -54,16 +55,23 @@ func (m *Manager) syncPlexHistorical(ctx context.Context) error {
 	}
 	logging.Info().Msg("Plex server is reachable")
 
-	// Fetch all history (sorted oldest first for chronological insertion)
-	logging.Info().Msg("Fetching Plex history (last  days)...")
-	history, err := m.plexClient.GetHistoryAll(ctx, "viewedAt", nil)
+	// Start at the configured window, or the stored watermark if it is newer
+	serverID := m.plexServerID()
+	cutoff := m.timeSource().Now().AddDate(0, 0, -m.cfg.Plex.SyncDaysBack)
+	if watermark := m.loadWatermark(ctx, watermarkSourcePlex, serverID, m.cfg.Plex.SyncInterval); watermark.After(cutoff) {
+		cutoff = watermark
+	}
+
+	// Fetch history (sorted oldest first for chronological insertion)
+	logging.Info().Time("since", cutoff).Msg("Fetching Plex history...")
+	history, err := m.plexClient.GetHistorySince(ctx, cutoff, "viewedAt")
 	if err != nil {
 		return fmt.Errorf("fetch history: %w", err)
 	}
 	logging.Info().Msg("Retrieved  Plex history records")
 
 	// Filter by date range (last N days)
-	cutoffTime := time.Now().AddDate(0, 0, -m.cfg.Plex.SyncDaysBack).Unix()
+	cutoffTime := cutoff.Unix()
 	var filteredHistory []PlexMetadata
 	for i := range history {
 		if history[i].ViewedAt >= cutoffTime {
@@ -76,6 +84,7 @@ func (m *Manager) syncPlexHistorical(ctx context.Context) error {
 	batchSize := 1000
 	inserted := 0
 	skipped := 0
+	tracker := &watermarkTracker{}
 
 	for i := 0; i < len(filteredHistory); i += batchSize {
 		end := i + batchSize
@@ -89,6 +98,17 @@ func (m *Manager) syncPlexHistorical(ctx context.Context) error {
 			// Convert Plex metadata to PlaybackEvent
 			event := m.convertPlexToPlaybackEvent(&batch[j])
 
+			quarantined, err := m.quarantineIfImplausible(ctx, event)
+			if err != nil {
+				logging.Error().Err(err).Msg("Quarantine Plex event")
+				tracker.fail(event.StartedAt)
+				continue
+			}
+			if quarantined {
+				tracker.observe(event.StartedAt)
+				continue
+			}
+
 			// Insert with automatic deduplication via UNIQUE constraint
 			// Database will silently skip duplicates (Tautulli events already exist)
 			if err := m.db.InsertPlaybackEvent(event); err != nil {
@@ -96,17 +116,20 @@ func (m *Manager) syncPlexHistorical(ctx context.Context) error {
 				// DuckDB returns "Constraint Error" for duplicates
 				if strings.Contains(err.Error(), "Constraint") || strings.Contains(err.Error(), "UNIQUE") {
 					skipped++
+					tracker.observe(event.StartedAt)
 					continue
 				}
 
-				// Real error - log and continue
+				// Real error - log, and hold the watermark so the next sync retries it
 				logging.Error().Err(err).Msg("Insert Plex event")
+				tracker.fail(event.StartedAt)
 				continue
 			}
 
 			// Publish to NATS if enabled (v1.47: event-driven architecture)
 			m.publishEvent(ctx, event)
 
+			tracker.observe(event.StartedAt)
 			inserted++
 		}
 
@@ -116,9 +139,19 @@ func (m *Manager) syncPlexHistorical(ctx context.Context) error {
 	}
 
 	logging.Info().Msg("Plex historical sync complete: inserted  new events, skipped  duplicates")
+	m.saveWatermark(ctx, watermarkSourcePlex, serverID, tracker.watermark())
+	m.markPlexSynced()
 	return nil
 }
 
+// markPlexSynced records the completion time of a successful Plex history sync
+func (m *Manager) markPlexSynced() {
+	m.mu.Lock()
+	m.lastPlexSync = m.timeSource().Now()
+	m.mu.Unlock()
+	metrics.RecordSyncSuccess("plex", m.plexServerID())
+}
+
 // syncPlexRecent checks for recent playback events Tautulli might have missed
 //
 // This method runs periodically (configured by PLEX_SYNC_INTERVAL) to catch
@@ -141,17 +174,27 @@ func (m *Manager) syncPlexRecent(ctx context.Context) error {
 	if m.plexClient == nil {
 		return fmt.Errorf("plex client not initialized")
 	}
+	defer m.trackSync("plex", m.plexServerID())()
+
+	// Resume from the stored watermark less one sync interval, so history that
+	// reaches Plex late is still picked up; without one, look back one interval
+	serverID := m.plexServerID()
+	cutoff := m.loadWatermark(ctx, watermarkSourcePlex, serverID, m.cfg.Plex.SyncInterval)
+	if cutoff.IsZero() {
+		cutoff = m.timeSource().Now().Add(-m.cfg.Plex.SyncInterval)
+	}
 
-	// Fetch events from last sync interval (newest first)
-	history, err := m.plexClient.GetHistoryAll(ctx, "-viewedAt", nil) // Descending order
+	// Fetch events since the cutoff (newest first)
+	history, err := m.plexClient.GetHistorySince(ctx, cutoff, "-viewedAt") // Descending order
 	if err != nil {
 		return fmt.Errorf("fetch recent history: %w", err)
 	}
 
-	// Only process events from last sync interval
-	cutoffTime := time.Now().Add(-m.cfg.Plex.SyncInterval).Unix()
+	// Only process events since the cutoff
+	cutoffTime := cutoff.Unix()
 
 	inserted := 0
+	tracker := &watermarkTracker{}
 	for i := range history {
 		// Stop when we reach old events (records are sorted newest first)
 		if history[i].ViewedAt < cutoffTime {
@@ -160,27 +203,44 @@ func (m *Manager) syncPlexRecent(ctx context.Context) error {
 
 		event := m.convertPlexToPlaybackEvent(&history[i])
 
+		quarantined, err := m.quarantineIfImplausible(ctx, event)
+		if err != nil {
+			logging.Error().Err(err).Msg("Quarantine Plex event")
+			tracker.fail(event.StartedAt)
+			continue
+		}
+		if quarantined {
+			tracker.observe(event.StartedAt)
+			continue
+		}
+
 		// Insert with automatic deduplication
 		if err := m.db.InsertPlaybackEvent(event); err != nil {
 			// Skip duplicates silently
 			if strings.Contains(err.Error(), "Constraint") || strings.Contains(err.Error(), "UNIQUE") {
+				tracker.observe(event.StartedAt)
 				continue
 			}
 
 			logging.Error().Err(err).Msg("Insert Plex event")
+			tracker.fail(event.StartedAt)
 			continue
 		}
 
 		// Publish to NATS if enabled (v1.47: event-driven architecture)
 		m.publishEvent(ctx, event)
 
+		tracker.observe(event.StartedAt)
 		inserted++
 	}
 
+	m.saveWatermark(ctx, watermarkSourcePlex, serverID, tracker.watermark())
+
 	if inserted > 0 {
 		logging.Info().Msg("Plex sync: inserted  new events missed by Tautulli")
 	}
 
+	m.markPlexSynced()
 	return nil
 }
 
@@ -189,11 +249,16 @@ func (m *Manager) syncPlexRecent(ctx context.Context) error {
 // This goroutine runs when PLEX_HISTORICAL_SYNC=false and implements the
 // periodic sync strategy to catch events Tautulli missed.
 //
-// Runs at interval configured by PLEX_SYNC_INTERVAL (default: 24 hours)
+// Runs at interval configured by PLEX_SYNC_INTERVAL (default: 24 hours),
+// starting after the server's stagger offset
 func (m *Manager) runPlexSyncLoop(ctx context.Context) {
 	defer m.wg.Done()
 
-	m.plexSyncTicker = time.NewTicker(m.cfg.Plex.SyncInterval)
+	if !m.waitForHistorySyncOffset(ctx, m.historySyncOffset(m.cfg.Plex.ServerID, m.cfg.Plex.SyncInterval)) {
+		return
+	}
+
+	m.plexSyncTicker = m.timeSource().NewTicker(m.cfg.Plex.SyncInterval)
 	defer m.plexSyncTicker.Stop()
 
 	for {
@@ -204,7 +269,7 @@ func (m *Manager) runPlexSyncLoop(ctx context.Context) {
 		case <-m.stopChan:
 			logging.Info().Msg("Plex sync loop stopping (stop signal received)")
 			return
-		case <-m.plexSyncTicker.C:
+		case <-m.plexSyncTicker.C():
 			logging.Info().Msg("Starting periodic Plex sync check...")
 			if err := m.syncPlexRecent(ctx); err != nil {
 				logging.Error().Err(err).Msg("Plex sync")
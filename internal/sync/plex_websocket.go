@@ -8,11 +8,13 @@ package sync
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"net/url"
 	"sync"
 	"time"
 
 	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
 
 	"github.com/goccy/go-json"
 	"github.com/gorilla/websocket"
@@ -20,6 +22,31 @@ import (
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
+// Plex WebSocket reconnection defaults
+const (
+	// plexWSBaseReconnectDelay is the first delay after a lost connection
+	plexWSBaseReconnectDelay = 1 * time.Second
+
+	// plexWSMaxReconnectDelay caps the exponential backoff
+	plexWSMaxReconnectDelay = 60 * time.Second
+
+	// plexWSStableConnectionAfter is how long a connection must stay up
+	// before the backoff is reset to the base delay. Resetting on every
+	// successful dial would let a flapping server trigger 1s reconnect loops.
+	plexWSStableConnectionAfter = 30 * time.Second
+
+	// plexWSReconnectJitter is the +/- fraction of random jitter applied to
+	// each reconnect delay so multiple instances don't reconnect in lockstep
+	plexWSReconnectJitter = 0.2
+)
+
+// Plex WebSocket state values reported by the plex_websocket_state gauge
+const (
+	plexWSStateDisconnected = 0
+	plexWSStateConnected    = 1
+	plexWSStateReconnecting = 2
+)
+
 // PlexWebSocketClient handles real-time event stream from Plex Media Server
 //
 // This client connects to Plex's WebSocket endpoint (/:/websockets/notifications)
@@ -27,7 +54,7 @@ import (
 // and server status changes.
 //
 // Key Features:
-//   - Automatic reconnection with exponential backoff
+//   - Automatic reconnection with jittered exponential backoff (1s to 60s)
 //   - Thread-safe callback registration
 //   - Graceful shutdown handling
 //   - Ping/pong keepalive (30-second interval)
@@ -49,6 +76,14 @@ type PlexWebSocketClient struct {
 	stopChan chan struct{}
 	wg       sync.WaitGroup
 
+	// Reconnection state (started/connectedAt/lastUptime guarded by connMu;
+	// backoff is owned by the listen goroutine)
+	started     bool          // listen/pingLoop goroutines running
+	connectedAt time.Time     // when the current connection was established
+	lastUptime  time.Duration // uptime of the most recently closed connection
+	backoff     *reconnectBackoff
+	stableAfter time.Duration // uptime required before the backoff resets
+
 	// Callbacks for different event types (thread-safe)
 	callbackMu sync.RWMutex
 	onPlaying  func(models.PlexPlayingNotification)
@@ -66,18 +101,67 @@ type PlexWebSocketClient struct {
 // Returns initialized client (not yet connected - call Connect)
 func NewPlexWebSocketClient(baseURL, token string) *PlexWebSocketClient {
 	return &PlexWebSocketClient{
-		baseURL:  baseURL,
-		token:    token,
-		stopChan: make(chan struct{}),
+		baseURL:     baseURL,
+		token:       token,
+		stopChan:    make(chan struct{}),
+		backoff:     newReconnectBackoff(plexWSBaseReconnectDelay, plexWSMaxReconnectDelay),
+		stableAfter: plexWSStableConnectionAfter,
 	}
 }
 
+// reconnectBackoff computes exponential reconnect delays with jitter
+//
+// Delays double from base up to max (1s, 2s, 4s, ... 60s) and each returned
+// delay is randomized by +/- plexWSReconnectJitter, never exceeding max.
+//
+// Thread Safety: Not safe for concurrent use (owned by the listen goroutine)
+type reconnectBackoff struct {
+	base    time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+// newReconnectBackoff creates a backoff starting at base and capped at max
+func newReconnectBackoff(base, maxDelay time.Duration) *reconnectBackoff {
+	return &reconnectBackoff{
+		base:    base,
+		max:     maxDelay,
+		current: base,
+	}
+}
+
+// Next returns the jittered delay for the current attempt and advances the backoff
+func (b *reconnectBackoff) Next() time.Duration {
+	delay := b.current
+
+	b.current *= 2
+	if b.current > b.max {
+		b.current = b.max
+	}
+
+	spread := int64(float64(delay) * plexWSReconnectJitter)
+	if spread > 0 {
+		//nolint:gosec // G404: Using weak random for non-cryptographic jitter in backoff timing
+		delay += time.Duration(rand.Int64N(2*spread+1) - spread)
+	}
+	if delay > b.max {
+		delay = b.max
+	}
+	return delay
+}
+
+// Reset returns the backoff to the base delay
+func (b *reconnectBackoff) Reset() {
+	b.current = b.base
+}
+
 // Connect establishes WebSocket connection to Plex server
 //
 // This method:
 //  1. Constructs WebSocket URL with authentication token
 //  2. Establishes connection with 10-second timeout
 //  3. Starts background goroutines for reading messages and ping/pong
+//     (only once; reconnects reuse the running goroutines)
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout
@@ -90,6 +174,37 @@ func (c *PlexWebSocketClient) Connect(ctx context.Context) error {
 	c.connMu.Lock()
 	defer c.connMu.Unlock()
 
+	if err := c.dialLocked(ctx); err != nil {
+		return err
+	}
+
+	if c.started {
+		return nil
+	}
+	c.started = true
+
+	// Start message listener goroutine
+	c.wg.Add(1)
+	go c.listen(ctx)
+
+	// Start ping/pong keepalive goroutine
+	c.wg.Add(1)
+	go c.pingLoop(ctx)
+
+	return nil
+}
+
+// reconnect dials a new connection without starting additional goroutines
+//
+// Thread Safety: Safe for concurrent calls (uses mutex)
+func (c *PlexWebSocketClient) reconnect(ctx context.Context) error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.dialLocked(ctx)
+}
+
+// dialLocked establishes the WebSocket connection. Caller must hold connMu.
+func (c *PlexWebSocketClient) dialLocked(ctx context.Context) error {
 	// If already connected, return
 	if c.conn != nil {
 		return nil
@@ -121,19 +236,22 @@ func (c *PlexWebSocketClient) Connect(ctx context.Context) error {
 	}
 
 	c.conn = conn
-	logging.Info().Msg("Plex WebSocket connected to")
-
-	// Start message listener goroutine
-	c.wg.Add(1)
-	go c.listen(ctx)
-
-	// Start ping/pong keepalive goroutine
-	c.wg.Add(1)
-	go c.pingLoop(ctx)
+	c.connectedAt = time.Now()
+	metrics.PlexWebSocketState.Set(plexWSStateConnected)
+	logging.Info().Msg("Plex WebSocket connected")
 
 	return nil
 }
 
+// takeLastUptime returns and clears the uptime of the last closed connection
+func (c *PlexWebSocketClient) takeLastUptime() time.Duration {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	uptime := c.lastUptime
+	c.lastUptime = 0
+	return uptime
+}
+
 // buildWebSocketURL constructs the Plex WebSocket URL with authentication
 //
 // Format: ws://{host}:{port}/:/websockets/notifications?X-Plex-Token={token}
@@ -180,15 +298,14 @@ func (c *PlexWebSocketClient) buildWebSocketURL() (string, error) {
 //  4. Implements automatic reconnection on errors
 //
 // Reconnection Strategy:
-//   - Exponential backoff: 1s, 2s, 4s, 8s, 16s, max 32s
+//   - Exponential backoff with +/-20% jitter: 1s, 2s, 4s, ... max 60s
+//   - Backoff resets only after a connection stays up for stableAfter (30s)
 //   - Unlimited retry attempts (runs until context canceled or stopChan closed)
 //   - Cleans up old connection before reconnecting
+//   - plex_websocket_state reports 2 (reconnecting) while backing off
 func (c *PlexWebSocketClient) listen(ctx context.Context) {
 	defer c.wg.Done()
 
-	reconnectDelay := 1 * time.Second
-	maxReconnectDelay := 32 * time.Second
-
 	for {
 		select {
 		case <-ctx.Done():
@@ -204,29 +321,36 @@ func (c *PlexWebSocketClient) listen(ctx context.Context) {
 			c.connMu.RUnlock()
 
 			if conn == nil {
-				// Connection lost - attempt reconnect with cancellable wait
-				logging.Info().Msg("Plex WebSocket connection lost, reconnecting in ...")
+				// Connection lost - reset backoff if the previous connection was stable
+				if c.takeLastUptime() >= c.stableAfter {
+					c.backoff.Reset()
+				}
+
+				reconnectDelay := c.backoff.Next()
+				metrics.PlexWebSocketState.Set(plexWSStateReconnecting)
+				logging.Info().Dur("delay", reconnectDelay).Msg("Plex WebSocket connection lost, reconnecting")
+
+				// Cancellable wait
+				timer := time.NewTimer(reconnectDelay)
 				select {
-				case <-time.After(reconnectDelay):
+				case <-timer.C:
 					// Continue with reconnection
 				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-c.stopChan:
+					timer.Stop()
 					return
-				}
-
-				// Exponential backoff
-				reconnectDelay *= 2
-				if reconnectDelay > maxReconnectDelay {
-					reconnectDelay = maxReconnectDelay
 				}
 
 				// Attempt reconnection
-				if err := c.Connect(ctx); err != nil {
+				if err := c.reconnect(ctx); err != nil {
+					metrics.PlexWebSocketReconnects.WithLabelValues("failure").Inc()
 					logging.Error().Err(err).Msg("Plex WebSocket reconnection failed")
 					continue
 				}
 
-				// Reset reconnect delay on successful connection
-				reconnectDelay = 1 * time.Second
+				metrics.PlexWebSocketReconnects.WithLabelValues("success").Inc()
 				continue
 			}
 
@@ -255,9 +379,6 @@ func (c *PlexWebSocketClient) listen(ctx context.Context) {
 				continue
 			}
 
-			// Reset reconnect delay on successful message read
-			reconnectDelay = 1 * time.Second
-
 			// Handle message
 			c.handleMessage(message)
 		}
@@ -389,6 +510,8 @@ func (c *PlexWebSocketClient) closeConnection() {
 			logging.Info().Msg("Plex WebSocket: failed to close connection:")
 		}
 		c.conn = nil
+		c.lastUptime = time.Since(c.connectedAt)
+		metrics.PlexWebSocketState.Set(plexWSStateDisconnected)
 		logging.Info().Msg("Plex WebSocket connection closed")
 	}
 }
@@ -288,7 +288,7 @@ func (m *Manager) populatePlayerInfo(event *models.PlaybackEvent, session *model
 	}
 
 	player := session.Player
-	event.IPAddress = player.Address
+	event.IPAddress = normalizeIPAddress(player.Address)
 	event.Platform = player.Platform
 	event.Player = player.Title
 
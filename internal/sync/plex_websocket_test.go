@@ -17,7 +17,9 @@ import (
 
 	"github.com/goccy/go-json"
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
+	"github.com/tomtom215/cartographus/internal/metrics"
 	"github.com/tomtom215/cartographus/internal/models"
 )
 
@@ -560,6 +562,121 @@ func TestPlexWebSocketClient_Close(t *testing.T) {
 	}
 }
 
+// TestReconnectBackoff_Sequence tests exponential growth, cap, and jitter bounds
+func TestReconnectBackoff_Sequence(t *testing.T) {
+	b := newReconnectBackoff(plexWSBaseReconnectDelay, plexWSMaxReconnectDelay)
+
+	expected := []time.Duration{
+		1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		16 * time.Second, 32 * time.Second, 60 * time.Second, 60 * time.Second,
+	}
+	for i, want := range expected {
+		got := b.Next()
+		lower := time.Duration(float64(want) * (1 - plexWSReconnectJitter))
+		upper := time.Duration(float64(want) * (1 + plexWSReconnectJitter))
+		if upper > plexWSMaxReconnectDelay {
+			upper = plexWSMaxReconnectDelay
+		}
+		if got < lower || got > upper {
+			t.Errorf("attempt %d: delay = %v, want within [%v, %v]", i+1, got, lower, upper)
+		}
+	}
+
+	b.Reset()
+	if got := b.Next(); got > time.Duration(float64(plexWSBaseReconnectDelay)*(1+plexWSReconnectJitter)) {
+		t.Errorf("delay after Reset() = %v, want ~%v", got, plexWSBaseReconnectDelay)
+	}
+}
+
+// TestReconnectBackoff_Jitter tests that delays are randomized
+func TestReconnectBackoff_Jitter(t *testing.T) {
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 20; i++ {
+		b := newReconnectBackoff(10*time.Second, plexWSMaxReconnectDelay)
+		seen[b.Next()] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("20 backoff delays produced %d distinct values, want jitter", len(seen))
+	}
+}
+
+// TestPlexWebSocketClient_ReconnectsAfterDrop tests that a dropped connection
+// is re-established, counted, and the state gauge tracks each phase
+func TestPlexWebSocketClient_ReconnectsAfterDrop(t *testing.T) {
+	setup := setupPlexWSTest(t)
+	defer setup.cleanup()
+
+	setup.client.backoff = newReconnectBackoff(10*time.Millisecond, 50*time.Millisecond)
+	successBefore := testutil.ToFloat64(metrics.PlexWebSocketReconnects.WithLabelValues("success"))
+
+	serverConn := setup.connectAndGetServerConn(t)
+	if got := testutil.ToFloat64(metrics.PlexWebSocketState); got != plexWSStateConnected {
+		t.Errorf("plex_websocket_state = %v after Connect(), want %d", got, plexWSStateConnected)
+	}
+
+	// Drop the connection from the server side
+	serverConn.Close()
+
+	select {
+	case conn := <-setup.mock.connChan:
+		defer conn.Close()
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not reconnect after connection drop")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !setup.client.IsConnected() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !setup.client.IsConnected() {
+		t.Fatal("IsConnected() = false after reconnect")
+	}
+	if got := testutil.ToFloat64(metrics.PlexWebSocketReconnects.WithLabelValues("success")) - successBefore; got != 1 {
+		t.Errorf("plex_websocket_reconnects_total{result=success} delta = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.PlexWebSocketState); got != plexWSStateConnected {
+		t.Errorf("plex_websocket_state = %v after reconnect, want %d", got, plexWSStateConnected)
+	}
+
+	// Exactly one listener must remain: a second dial would show up here
+	select {
+	case <-setup.mock.connChan:
+		t.Error("unexpected additional connection (duplicate listener goroutine)")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestPlexWebSocketClient_BackoffResetsAfterStableConnection tests that only
+// connections that stayed up for stableAfter reset the backoff
+func TestPlexWebSocketClient_BackoffResetsAfterStableConnection(t *testing.T) {
+	client := NewPlexWebSocketClient("http://localhost:32400", "test-token")
+	client.stableAfter = time.Minute
+
+	client.backoff.Next()
+	client.backoff.Next()
+
+	// Short-lived connection: backoff keeps growing
+	client.lastUptime = 5 * time.Second
+	if client.takeLastUptime() >= client.stableAfter {
+		client.backoff.Reset()
+	}
+	if client.backoff.current != 4*time.Second {
+		t.Errorf("backoff after unstable connection = %v, want 4s", client.backoff.current)
+	}
+
+	// Stable connection: backoff returns to base
+	client.lastUptime = 2 * time.Minute
+	if client.takeLastUptime() >= client.stableAfter {
+		client.backoff.Reset()
+	}
+	if client.backoff.current != plexWSBaseReconnectDelay {
+		t.Errorf("backoff after stable connection = %v, want %v", client.backoff.current, plexWSBaseReconnectDelay)
+	}
+	if client.lastUptime != 0 {
+		t.Errorf("lastUptime = %v after take, want 0", client.lastUptime)
+	}
+}
+
 // TestPlexWebSocketClient_ConcurrentCallbacks tests thread safety
 func TestPlexWebSocketClient_ConcurrentCallbacks(t *testing.T) {
 	setup := setupPlexWSTest(t)
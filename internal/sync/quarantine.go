@@ -0,0 +1,75 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/metrics"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/validation"
+)
+
+// QuarantineStore holds back playback events with implausible timestamps.
+// Implemented by *database.DB.
+type QuarantineStore interface {
+	InsertQuarantinedEvent(ctx context.Context, entry *models.QuarantinedEvent) error
+}
+
+// SetQuarantineStore enables timestamp validation for events the manager
+// persists directly. Events whose started_at falls outside the window from
+// cfg.Sync are written to store instead of playback_events.
+//
+// Thread Safety: Safe for concurrent access but should be called once during startup.
+func (m *Manager) SetQuarantineStore(store QuarantineStore) {
+	window, err := validation.NewTimestampWindow(m.cfg.Sync.TimestampMinDate, m.cfg.Sync.TimestampMaxFuture)
+	if err != nil {
+		logging.Warn().Err(err).Msg("Invalid sync timestamp window, using defaults")
+		window = validation.DefaultTimestampWindow()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quarantine = store
+	m.timestampWindow = window
+}
+
+// quarantineIfImplausible quarantines the event when its started_at is outside
+// the timestamp window. Returns true if the event was quarantined and must not
+// be inserted or published.
+func (m *Manager) quarantineIfImplausible(ctx context.Context, event *models.PlaybackEvent) (bool, error) {
+	m.mu.RLock()
+	store, window := m.quarantine, m.timestampWindow
+	m.mu.RUnlock()
+
+	if store == nil {
+		return false, nil
+	}
+
+	reason := window.Check(event.StartedAt, m.timeSource().Now())
+	if reason == "" {
+		return false, nil
+	}
+
+	entry, err := models.NewQuarantinedEvent(event, reason)
+	if err != nil {
+		return false, err
+	}
+	if err := store.InsertQuarantinedEvent(ctx, entry); err != nil {
+		return false, fmt.Errorf("failed to quarantine event: %w", err)
+	}
+
+	metrics.RecordEventQuarantined(event.Source, reason)
+	logging.Warn().
+		Str("session_key", event.SessionKey).
+		Str("source", event.Source).
+		Time("started_at", event.StartedAt).
+		Str("reason", reason).
+		Msg("Quarantined event with implausible timestamp")
+	return true, nil
+}
@@ -0,0 +1,99 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// mockQuarantineStore records quarantined events.
+type mockQuarantineStore struct {
+	entries []*models.QuarantinedEvent
+	err     error
+}
+
+func (s *mockQuarantineStore) InsertQuarantinedEvent(_ context.Context, entry *models.QuarantinedEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func TestManager_PersistEventForMode_Quarantine(t *testing.T) {
+	inserted := 0
+	db := &mockDB{insertPlaybackEvent: func(*models.PlaybackEvent) error {
+		inserted++
+		return nil
+	}}
+	cfg := &config.Config{Sync: config.SyncConfig{TimestampMinDate: "2005-01-01", TimestampMaxFuture: time.Hour}}
+	m := NewManager(db, nil, &mockTautulliClient{}, cfg, nil)
+
+	// Without a store every event is inserted
+	if err := m.persistEventForMode(context.Background(), &models.PlaybackEvent{StartedAt: time.Unix(0, 0)}, false); err != nil {
+		t.Fatalf("persistEventForMode() error = %v", err)
+	}
+	if inserted != 1 {
+		t.Fatalf("inserted = %d, want 1 before quarantine is enabled", inserted)
+	}
+
+	store := &mockQuarantineStore{}
+	m.SetQuarantineStore(store)
+
+	tests := []struct {
+		name       string
+		startedAt  time.Time
+		wantReason string
+	}{
+		{"before configured minimum", time.Date(2004, 12, 31, 0, 0, 0, 0, time.UTC), models.QuarantineReasonTooOld},
+		{"beyond configured future", time.Now().Add(2 * time.Hour), models.QuarantineReasonInFuture},
+		{"plausible", time.Now().Add(-time.Hour), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inserted, store.entries = 0, nil
+			event := &models.PlaybackEvent{Source: "tautulli", SessionKey: tt.name, StartedAt: tt.startedAt}
+
+			if err := m.persistEventForMode(context.Background(), event, false); err != nil {
+				t.Fatalf("persistEventForMode() error = %v", err)
+			}
+
+			if tt.wantReason == "" {
+				if inserted != 1 || len(store.entries) != 0 {
+					t.Errorf("inserted/quarantined = %d/%d, want 1/0", inserted, len(store.entries))
+				}
+				return
+			}
+			if inserted != 0 || len(store.entries) != 1 {
+				t.Fatalf("inserted/quarantined = %d/%d, want 0/1", inserted, len(store.entries))
+			}
+			if got := store.entries[0].Reason; got != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestManager_PersistEventForMode_QuarantineError(t *testing.T) {
+	db := &mockDB{insertPlaybackEvent: func(*models.PlaybackEvent) error {
+		t.Error("event inserted after quarantine failure")
+		return nil
+	}}
+	m := NewManager(db, nil, &mockTautulliClient{}, &config.Config{}, nil)
+	m.SetQuarantineStore(&mockQuarantineStore{err: errors.New("db down")})
+
+	err := m.persistEventForMode(context.Background(), &models.PlaybackEvent{StartedAt: time.Unix(0, 0)}, false)
+	if err == nil {
+		t.Error("expected error when quarantine insert fails")
+	}
+}
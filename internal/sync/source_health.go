@@ -0,0 +1,248 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gobreaker "github.com/sony/gobreaker/v2"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// sourceHealthTimeout bounds a single source probe so one unreachable server
+// cannot stall an aggregated health report.
+const sourceHealthTimeout = 5 * time.Second
+
+// SourceHealthChecker is implemented by managers that can probe the media
+// servers they sync from (Manager for Tautulli/Plex, JellyfinManager, EmbyManager).
+type SourceHealthChecker interface {
+	SourceHealth(ctx context.Context) []models.SourceHealth
+}
+
+// breakerStater is implemented by clients wrapped in a circuit breaker.
+type breakerStater interface {
+	State() gobreaker.State
+}
+
+// sourceProbe describes a Ping-based health check of one media server.
+type sourceProbe struct {
+	platform     string
+	serverID     string
+	ping         func(ctx context.Context) error
+	breakerState func() gobreaker.State // nil if the client has no breaker
+	lastSync     time.Time
+	timeout      time.Duration // sourceHealthTimeout if zero
+}
+
+// run pings the server and reports reachability, latency, last successful
+// sync, and circuit breaker state. The breaker state is read after the ping
+// so it reflects the probe's outcome.
+func (p sourceProbe) run(ctx context.Context) models.SourceHealth {
+	health := models.SourceHealth{
+		ServerID:  p.serverID,
+		Platform:  p.platform,
+		CheckedAt: time.Now(),
+	}
+	if !p.lastSync.IsZero() {
+		lastSync := p.lastSync
+		health.LastSuccessfulSync = &lastSync
+	}
+
+	timeout := p.timeout
+	if timeout <= 0 {
+		timeout = sourceHealthTimeout
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := p.ping(probeCtx)
+	health.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Error = err.Error()
+	} else {
+		health.Reachable = true
+	}
+
+	if p.breakerState != nil {
+		health.CircuitBreakerState = stateToString(p.breakerState())
+	}
+	return health
+}
+
+// breakerStateOf returns the client's circuit breaker state accessor, or nil.
+func breakerStateOf(client any) func() gobreaker.State {
+	if stater, ok := client.(breakerStater); ok {
+		return stater.State
+	}
+	return nil
+}
+
+// latestTime returns the later of a and b.
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// SourceHealth probes the Tautulli server and, in hybrid mode, the Plex
+// server. Sources that are not configured are omitted.
+func (m *Manager) SourceHealth(ctx context.Context) []models.SourceHealth {
+	var probes []sourceProbe
+
+	if m.cfg.Tautulli.Enabled && m.client != nil {
+		probes = append(probes, sourceProbe{
+			platform:     "tautulli",
+			serverID:     m.tautulliServerID(),
+			ping:         m.client.Ping,
+			breakerState: breakerStateOf(m.client),
+			lastSync:     m.LastSyncTime(),
+		})
+	}
+
+	if m.plexClient != nil {
+		m.mu.RLock()
+		lastSync := m.lastPlexSync
+		m.mu.RUnlock()
+		if m.sessionPoller != nil {
+			lastSync = latestTime(lastSync, m.sessionPoller.LastPollTime())
+		}
+		probes = append(probes, sourceProbe{
+			platform:     "plex",
+			serverID:     m.cfg.Plex.ServerID,
+			ping:         m.plexClient.Ping,
+			breakerState: m.plexClient.CircuitBreakerState,
+			lastSync:     lastSync,
+		})
+	}
+
+	results := make([]models.SourceHealth, 0, len(probes))
+	for _, probe := range probes {
+		results = append(results, probe.run(ctx))
+	}
+	return results
+}
+
+// SourceHealth probes the Jellyfin server.
+func (m *JellyfinManager) SourceHealth(ctx context.Context) []models.SourceHealth {
+	if m == nil || m.client == nil {
+		return nil
+	}
+	var lastSync time.Time
+	if m.poller != nil {
+		lastSync = m.poller.LastPollTime()
+	}
+	probe := sourceProbe{
+		platform:     "jellyfin",
+		serverID:     m.cfg.ServerID,
+		ping:         m.client.Ping,
+		breakerState: breakerStateOf(m.client),
+		lastSync:     lastSync,
+	}
+	return []models.SourceHealth{probe.run(ctx)}
+}
+
+// SourceHealth probes the Emby server.
+func (m *EmbyManager) SourceHealth(ctx context.Context) []models.SourceHealth {
+	if m == nil || m.client == nil {
+		return nil
+	}
+	var lastSync time.Time
+	if m.poller != nil {
+		lastSync = m.poller.LastPollTime()
+	}
+	probe := sourceProbe{
+		platform:     "emby",
+		serverID:     m.cfg.ServerID,
+		ping:         m.client.Ping,
+		breakerState: breakerStateOf(m.client),
+		lastSync:     lastSync,
+	}
+	return []models.SourceHealth{probe.run(ctx)}
+}
+
+// ProbeConfiguredSources pings every enabled media server in cfg with a
+// fresh client, each bounded by timeout, without starting any sync. It is
+// used to check connectivity before startup (--validate-config).
+func ProbeConfiguredSources(ctx context.Context, cfg *config.Config, timeout time.Duration) []models.SourceHealth {
+	var probes []sourceProbe
+
+	if cfg.Tautulli.Enabled {
+		probes = append(probes, sourceProbe{
+			platform: "tautulli",
+			serverID: cfg.Tautulli.ServerID,
+			ping:     NewTautulliClient(&cfg.Tautulli).Ping,
+		})
+	}
+	for _, srv := range cfg.GetPlexServers() {
+		probes = append(probes, sourceProbe{
+			platform: "plex",
+			serverID: srv.ServerID,
+			ping:     NewPlexClientWithServerID(srv.URL, srv.Token, srv.ServerID).Ping,
+		})
+	}
+	for _, srv := range cfg.GetJellyfinServers() {
+		probes = append(probes, sourceProbe{
+			platform: "jellyfin",
+			serverID: srv.ServerID,
+			ping:     NewJellyfinClient(srv.URL, srv.APIKey, srv.UserID).Ping,
+		})
+	}
+	for _, srv := range cfg.GetEmbyServers() {
+		probes = append(probes, sourceProbe{
+			platform: "emby",
+			serverID: srv.ServerID,
+			ping:     NewEmbyClient(srv.URL, srv.APIKey, srv.UserID).Ping,
+		})
+	}
+
+	results := make([]models.SourceHealth, 0, len(probes))
+	for _, probe := range probes {
+		probe.timeout = timeout
+		results = append(results, probe.run(ctx))
+	}
+	return results
+}
+
+// ServerInfo identifies a media server reached by IdentifyServer.
+type ServerInfo struct {
+	Name    string `json:"server_name"`
+	Version string `json:"version"`
+}
+
+// IdentifyServer connects to a plex, jellyfin or emby server with token (the
+// Plex token or Jellyfin/Emby API key) and returns its name and version. An
+// error means the server is unreachable or rejected the token. It is used
+// by the setup wizard to test a connection before it is saved.
+func IdentifyServer(ctx context.Context, platform, url, token string) (*ServerInfo, error) {
+	switch platform {
+	case "plex":
+		caps, err := NewPlexClient(url, token).GetServerCapabilities(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &ServerInfo{Name: caps.MediaContainer.FriendlyName, Version: caps.MediaContainer.Version}, nil
+	case "jellyfin":
+		info, err := NewJellyfinClient(url, token, "").GetSystemInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &ServerInfo{Name: info.ServerName, Version: info.Version}, nil
+	case "emby":
+		info, err := NewEmbyClient(url, token, "").GetSystemInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &ServerInfo{Name: info.ServerName, Version: info.Version}, nil
+	default:
+		return nil, fmt.Errorf("unsupported platform: %s", platform)
+	}
+}
@@ -0,0 +1,207 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gobreaker "github.com/sony/gobreaker/v2"
+
+	"github.com/tomtom215/cartographus/internal/config"
+)
+
+func TestSourceProbe_Run(t *testing.T) {
+	lastSync := time.Now().Add(-time.Minute)
+
+	t.Run("reachable", func(t *testing.T) {
+		probe := sourceProbe{
+			platform:     "jellyfin",
+			serverID:     "jf-1",
+			ping:         func(context.Context) error { return nil },
+			breakerState: func() gobreaker.State { return gobreaker.StateClosed },
+			lastSync:     lastSync,
+		}
+
+		health := probe.run(context.Background())
+		if !health.Reachable || health.Error != "" {
+			t.Errorf("Reachable/Error = %v/%q, want true/\"\"", health.Reachable, health.Error)
+		}
+		if health.Platform != "jellyfin" || health.ServerID != "jf-1" {
+			t.Errorf("Platform/ServerID = %q/%q", health.Platform, health.ServerID)
+		}
+		if health.CircuitBreakerState != "closed" {
+			t.Errorf("CircuitBreakerState = %q, want closed", health.CircuitBreakerState)
+		}
+		if health.LastSuccessfulSync == nil || !health.LastSuccessfulSync.Equal(lastSync) {
+			t.Errorf("LastSuccessfulSync = %v, want %v", health.LastSuccessfulSync, lastSync)
+		}
+		if health.CheckedAt.IsZero() {
+			t.Error("CheckedAt not set")
+		}
+	})
+
+	t.Run("unreachable without breaker or sync", func(t *testing.T) {
+		probe := sourceProbe{
+			platform: "tautulli",
+			serverID: "tautulli-default",
+			ping:     func(context.Context) error { return errors.New("connection refused") },
+		}
+
+		health := probe.run(context.Background())
+		if health.Reachable || health.Error != "connection refused" {
+			t.Errorf("Reachable/Error = %v/%q", health.Reachable, health.Error)
+		}
+		if health.CircuitBreakerState != "" {
+			t.Errorf("CircuitBreakerState = %q, want empty", health.CircuitBreakerState)
+		}
+		if health.LastSuccessfulSync != nil {
+			t.Errorf("LastSuccessfulSync = %v, want nil", health.LastSuccessfulSync)
+		}
+	})
+
+	t.Run("ping is bounded by timeout", func(t *testing.T) {
+		probe := sourceProbe{
+			ping: func(ctx context.Context) error {
+				if _, ok := ctx.Deadline(); !ok {
+					return errors.New("no deadline")
+				}
+				return nil
+			},
+		}
+		if health := probe.run(context.Background()); !health.Reachable {
+			t.Errorf("probe context has no deadline: %s", health.Error)
+		}
+	})
+}
+
+func TestManager_SourceHealth(t *testing.T) {
+	plexServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plexServer.Close()
+
+	cfg := &config.Config{
+		Tautulli: config.TautulliConfig{Enabled: true},
+		Plex:     config.PlexConfig{Enabled: true, URL: plexServer.URL, Token: "token", ServerID: "plex-1"},
+	}
+	client := &mockTautulliClient{ping: func(context.Context) error { return errors.New("tautulli down") }}
+	m := NewManager(nil, nil, client, cfg, nil)
+
+	synced := time.Now().Add(-time.Hour)
+	m.lastSync = synced
+	m.markPlexSynced()
+
+	results := m.SourceHealth(context.Background())
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	tautulli := results[0]
+	if tautulli.Platform != "tautulli" || tautulli.ServerID != "tautulli-default" {
+		t.Errorf("Platform/ServerID = %q/%q", tautulli.Platform, tautulli.ServerID)
+	}
+	if tautulli.Reachable || tautulli.Error != "tautulli down" {
+		t.Errorf("Reachable/Error = %v/%q", tautulli.Reachable, tautulli.Error)
+	}
+	if tautulli.LastSuccessfulSync == nil || !tautulli.LastSuccessfulSync.Equal(synced) {
+		t.Errorf("LastSuccessfulSync = %v, want %v", tautulli.LastSuccessfulSync, synced)
+	}
+
+	plex := results[1]
+	if plex.Platform != "plex" || plex.ServerID != "plex-1" || !plex.Reachable {
+		t.Errorf("unexpected Plex health: %+v", plex)
+	}
+	if plex.CircuitBreakerState != "closed" {
+		t.Errorf("Plex CircuitBreakerState = %q, want closed", plex.CircuitBreakerState)
+	}
+	if plex.LastSuccessfulSync == nil {
+		t.Error("Plex LastSuccessfulSync not set")
+	}
+}
+
+func TestManager_SourceHealth_TautulliDisabled(t *testing.T) {
+	m := NewManager(nil, nil, &mockTautulliClient{}, &config.Config{}, nil)
+	if results := m.SourceHealth(context.Background()); len(results) != 0 {
+		t.Errorf("got %d results for unconfigured sources, want 0", len(results))
+	}
+}
+
+func TestJellyfinManager_SourceHealth(t *testing.T) {
+	var nilManager *JellyfinManager
+	if results := nilManager.SourceHealth(context.Background()); results != nil {
+		t.Errorf("nil manager returned %v", results)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/System/Ping" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	m := NewJellyfinManager(&config.JellyfinConfig{Enabled: true, URL: server.URL, APIKey: "key", ServerID: "jf-1"}, nil, nil)
+	results := m.SourceHealth(context.Background())
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if h := results[0]; h.Platform != "jellyfin" || h.ServerID != "jf-1" || !h.Reachable || h.CircuitBreakerState != "closed" {
+		t.Errorf("unexpected health: %+v", h)
+	}
+}
+
+func TestEmbyManager_SourceHealth(t *testing.T) {
+	m := NewEmbyManager(&config.EmbyConfig{Enabled: true, URL: "http://127.0.0.1:1", APIKey: "key", ServerID: "emby-1"}, nil, nil)
+	results := m.SourceHealth(context.Background())
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if h := results[0]; h.Platform != "emby" || h.Reachable || h.Error == "" {
+		t.Errorf("unexpected health for unreachable server: %+v", h)
+	}
+	if results[0].LastSuccessfulSync != nil {
+		t.Error("LastSuccessfulSync set before any poll")
+	}
+}
+
+func TestProbeConfiguredSources(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ok.Close()
+	hang := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer hang.Close()
+
+	cfg := &config.Config{
+		Plex:            config.PlexConfig{Enabled: true, URL: ok.URL, Token: "token", ServerID: "plex-1"},
+		JellyfinServers: []config.JellyfinConfig{{Enabled: true, URL: hang.URL, APIKey: "key", ServerID: "jf-1"}},
+		EmbyServers:     []config.EmbyConfig{{Enabled: false, URL: ok.URL, APIKey: "key", ServerID: "emby-off"}},
+	}
+
+	start := time.Now()
+	results := ProbeConfiguredSources(context.Background(), cfg, 200*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("probing took %v, want it bounded by the timeout", elapsed)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2 (disabled servers skipped): %+v", len(results), results)
+	}
+	if h := results[0]; h.Platform != "plex" || h.ServerID != "plex-1" || !h.Reachable {
+		t.Errorf("unexpected Plex health: %+v", h)
+	}
+	if h := results[1]; h.Platform != "jellyfin" || h.ServerID != "jf-1" || h.Reachable || h.Error == "" {
+		t.Errorf("unexpected health for hanging server: %+v", h)
+	}
+}
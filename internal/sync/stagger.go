@@ -0,0 +1,79 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+)
+
+// staggerOffset returns a deterministic start offset in [0, interval) for the
+// given server ID.
+//
+// With several media servers configured on the same polling interval, starting
+// every poller at the same instant makes them hit their APIs in lockstep for
+// the lifetime of the process. Hashing the ServerID spreads first polls across
+// the interval while keeping each server's slot stable across restarts.
+//
+// An empty server ID or non-positive interval yields no offset.
+func staggerOffset(serverID string, interval time.Duration) time.Duration {
+	if serverID == "" || interval <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(serverID)) //nolint:errcheck // hash.Hash.Write never returns an error
+	return time.Duration(h.Sum64() % uint64(interval))
+}
+
+// waitForStartOffset blocks for the configured start offset before the first poll.
+// Returns false if the poller should exit (context canceled or stop requested).
+func waitForStartOffset(ctx context.Context, stopChan <-chan struct{}, offset time.Duration) bool {
+	if offset <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(offset)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-stopChan:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// historySyncOffset returns the delay before the periodic history sync of
+// serverID starts its ticker, so Tautulli and Plex servers on the same sync
+// interval do not fetch history in lockstep. Zero when staggering is disabled.
+func (m *Manager) historySyncOffset(serverID string, interval time.Duration) time.Duration {
+	if m.staggerFn == nil {
+		return 0
+	}
+	return m.staggerFn(serverID, interval)
+}
+
+// waitForHistorySyncOffset blocks on the manager's clock for the offset
+// before a periodic history sync loop starts.
+// Returns false if the loop should exit (context canceled or stop requested).
+func (m *Manager) waitForHistorySyncOffset(ctx context.Context, offset time.Duration) bool {
+	if offset <= 0 {
+		return true
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-m.stopChan:
+		return false
+	case <-m.timeSource().After(offset):
+		return true
+	}
+}
@@ -0,0 +1,211 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/clock"
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models/tautulli"
+)
+
+func TestStaggerOffset(t *testing.T) {
+	interval := 30 * time.Second
+
+	t.Run("deterministic per server ID", func(t *testing.T) {
+		first := staggerOffset("jellyfin-living-room", interval)
+		second := staggerOffset("jellyfin-living-room", interval)
+		if first != second {
+			t.Errorf("staggerOffset() not stable: %v vs %v", first, second)
+		}
+	})
+
+	t.Run("within interval", func(t *testing.T) {
+		for _, id := range []string{"a", "plex-1", "emby-basement", "some-very-long-server-identifier"} {
+			offset := staggerOffset(id, interval)
+			if offset < 0 || offset >= interval {
+				t.Errorf("staggerOffset(%q) = %v, want in [0, %v)", id, offset, interval)
+			}
+		}
+	})
+
+	t.Run("no offset without server ID or interval", func(t *testing.T) {
+		if got := staggerOffset("", interval); got != 0 {
+			t.Errorf("staggerOffset(\"\") = %v, want 0", got)
+		}
+		if got := staggerOffset("plex-1", 0); got != 0 {
+			t.Errorf("staggerOffset(interval=0) = %v, want 0", got)
+		}
+	})
+}
+
+func TestStaggerOffset_ThreeManagersSpreadAcrossInterval(t *testing.T) {
+	interval := 60 * time.Second
+
+	jellyfin := NewJellyfinManager(&config.JellyfinConfig{
+		Enabled:                true,
+		URL:                    "http://localhost:8096",
+		APIKey:                 "test-key",
+		ServerID:               "jellyfin-main",
+		SessionPollingInterval: interval,
+	}, nil, nil)
+	emby := NewEmbyManager(&config.EmbyConfig{
+		Enabled:                true,
+		URL:                    "http://localhost:8097",
+		APIKey:                 "test-key",
+		ServerID:               "emby-main",
+		SessionPollingInterval: interval,
+	}, nil, nil)
+	plex := NewManager(nil, nil, nil, &config.Config{
+		Plex: config.PlexConfig{
+			ServerID:               "plex-main",
+			SessionPollingInterval: interval,
+		},
+	}, nil)
+
+	assertStaggered(t, interval,
+		jellyfin.sessionPollerConfig().StartOffset,
+		emby.sessionPollerConfig().StartOffset,
+		plex.sessionPollerConfig().StartOffset,
+	)
+
+	// The periodic history syncs of Tautulli and Plex servers sharing a
+	// sync interval are spread the same way.
+	historyInterval := 5 * time.Minute
+	tautulliA := NewManager(nil, nil, nil, &config.Config{
+		Tautulli: config.TautulliConfig{ServerID: "tautulli-main"},
+		Sync:     config.SyncConfig{Interval: historyInterval},
+	}, nil)
+	tautulliB := NewManager(nil, nil, nil, &config.Config{
+		Tautulli: config.TautulliConfig{ServerID: "tautulli-remote"},
+		Sync:     config.SyncConfig{Interval: historyInterval},
+	}, nil)
+	plex.cfg.Plex.SyncInterval = historyInterval
+	assertStaggered(t, historyInterval,
+		tautulliA.historySyncOffset(tautulliA.cfg.Tautulli.ServerID, tautulliA.interval()),
+		tautulliB.historySyncOffset(tautulliB.cfg.Tautulli.ServerID, tautulliB.interval()),
+		plex.historySyncOffset(plex.cfg.Plex.ServerID, plex.cfg.Plex.SyncInterval),
+	)
+
+	// Restarting yields the same schedule.
+	again := NewJellyfinManager(jellyfin.cfg, nil, nil)
+	if got, want := again.sessionPollerConfig().StartOffset, jellyfin.sessionPollerConfig().StartOffset; got != want {
+		t.Errorf("offset changed across restarts: %v vs %v", got, want)
+	}
+}
+
+// assertStaggered checks that first syncs at the given offsets all land in
+// the first interval and that no two coincide.
+func assertStaggered(t *testing.T, interval time.Duration, offsets ...time.Duration) {
+	t.Helper()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	firstSyncs := make([]time.Time, 0, len(offsets))
+	for _, offset := range offsets {
+		firstSyncs = append(firstSyncs, start.Add(offset))
+	}
+	sort.Slice(firstSyncs, func(i, j int) bool { return firstSyncs[i].Before(firstSyncs[j]) })
+
+	// Every first sync lands inside the first interval...
+	for _, at := range firstSyncs {
+		if at.Before(start) || !at.Before(start.Add(interval)) {
+			t.Errorf("first sync at %v, want within [%v, %v)", at, start, start.Add(interval))
+		}
+	}
+
+	// ...and no two are coincident: adjacent syncs are at least 5% of the interval apart.
+	minGap := interval / 20
+	for i := 1; i < len(firstSyncs); i++ {
+		if gap := firstSyncs[i].Sub(firstSyncs[i-1]); gap < minGap {
+			t.Errorf("first syncs %v and %v only %v apart, want >= %v", firstSyncs[i-1], firstSyncs[i], gap, minGap)
+		}
+	}
+}
+
+func TestManager_SyncLoop_StaggersFirstSync(t *testing.T) {
+	t.Parallel() // Safe - isolated mock and clock
+
+	cfg := newTestConfig()
+	cfg.Tautulli.ServerID = "tautulli-main"
+	syncs := make(chan struct{}, 10)
+	mockClient := &mockTautulliClient{
+		getHistorySince: func(context.Context, time.Time, int, int) (*tautulli.TautulliHistory, error) {
+			syncs <- struct{}{}
+			return &tautulli.TautulliHistory{
+				Response: tautulli.TautulliHistoryResponse{Result: "success"},
+			}, nil
+		},
+	}
+
+	clk := clock.NewFake(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	manager := NewManagerWithClock(&mockDB{}, nil, mockClient, cfg, nil, clk)
+	offset := manager.historySyncOffset(cfg.Tautulli.ServerID, cfg.Sync.Interval)
+	if offset <= 0 {
+		t.Fatalf("offset = %v, want > 0 for a named server", offset)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	manager.wg.Add(1)
+	go manager.syncLoop(ctx)
+	defer func() {
+		cancel()
+		manager.wg.Wait()
+	}()
+
+	// Waiting for the offset, then for the first interval of the ticker
+	clk.BlockUntil(1)
+	clk.Advance(offset)
+	clk.BlockUntil(1)
+	clk.Advance(cfg.Sync.Interval - time.Second)
+	if len(syncs) != 0 {
+		t.Fatal("synced before offset + interval elapsed")
+	}
+
+	clk.Advance(time.Second)
+	select {
+	case <-syncs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no sync after offset + interval elapsed")
+	}
+}
+
+func TestWaitForStartOffset(t *testing.T) {
+	t.Run("zero offset returns immediately", func(t *testing.T) {
+		if !waitForStartOffset(context.Background(), make(chan struct{}), 0) {
+			t.Error("waitForStartOffset() = false, want true")
+		}
+	})
+
+	t.Run("stop aborts wait", func(t *testing.T) {
+		stop := make(chan struct{})
+		close(stop)
+		if waitForStartOffset(context.Background(), stop, time.Hour) {
+			t.Error("waitForStartOffset() = true after stop, want false")
+		}
+	})
+
+	t.Run("context cancel aborts wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if waitForStartOffset(ctx, make(chan struct{}), time.Hour) {
+			t.Error("waitForStartOffset() = true after cancel, want false")
+		}
+	})
+
+	t.Run("waits for offset", func(t *testing.T) {
+		start := time.Now()
+		if !waitForStartOffset(context.Background(), make(chan struct{}), 20*time.Millisecond) {
+			t.Fatal("waitForStartOffset() = false, want true")
+		}
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("returned after %v, want >= 20ms", elapsed)
+		}
+	})
+}
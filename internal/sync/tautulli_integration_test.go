@@ -70,7 +70,7 @@ func TestTautulliClient_Integration(t *testing.T) {
 		//   mock := setupMockServer(jsonHandler(tautulli.TautulliServerInfo{...}))
 		//
 		// Now we test against the real Tautulli API:
-		info, err := client.GetServerInfo()
+		info, err := client.GetServerInfo(ctx)
 		if err != nil {
 			// Note: Fresh Tautulli may return error if Plex is not configured
 			// This is expected behavior we can validate
@@ -87,7 +87,7 @@ func TestTautulliClient_Integration(t *testing.T) {
 	})
 
 	t.Run("GetActivity returns real activity data", func(t *testing.T) {
-		activity, err := client.GetActivity("")
+		activity, err := client.GetActivity(ctx, "")
 		if err != nil {
 			t.Logf("GetActivity returned error: %v", err)
 			return
@@ -100,7 +100,7 @@ func TestTautulliClient_Integration(t *testing.T) {
 	})
 
 	t.Run("GetHistory returns real history data", func(t *testing.T) {
-		history, err := client.GetHistory(0, 25)
+		history, err := client.GetHistory(ctx, 0, 25)
 		if err != nil {
 			t.Logf("GetHistory returned error: %v", err)
 			return
@@ -113,7 +113,7 @@ func TestTautulliClient_Integration(t *testing.T) {
 	})
 
 	t.Run("GetUsers returns real user data", func(t *testing.T) {
-		users, err := client.GetUsers()
+		users, err := client.GetUsers(ctx)
 		if err != nil {
 			t.Logf("GetUsers returned error: %v", err)
 			return
@@ -125,7 +125,7 @@ func TestTautulliClient_Integration(t *testing.T) {
 	})
 
 	t.Run("GetLibraries returns real library data", func(t *testing.T) {
-		libraries, err := client.GetLibraries()
+		libraries, err := client.GetLibraries(ctx)
 		if err != nil {
 			t.Logf("GetLibraries returned error: %v", err)
 			return
@@ -179,7 +179,7 @@ func TestTautulliClient_WithSeedDatabase(t *testing.T) {
 	client := NewTautulliClient(cfg)
 
 	t.Run("GetHistory returns seeded history", func(t *testing.T) {
-		history, err := client.GetHistory(0, 100)
+		history, err := client.GetHistory(ctx, 0, 100)
 		if err != nil {
 			t.Fatalf("GetHistory error: %v", err)
 		}
@@ -197,7 +197,7 @@ func TestTautulliClient_WithSeedDatabase(t *testing.T) {
 	})
 
 	t.Run("GetUsers returns seeded users", func(t *testing.T) {
-		users, err := client.GetUsers()
+		users, err := client.GetUsers(ctx)
 		if err != nil {
 			t.Fatalf("GetUsers error: %v", err)
 		}
@@ -248,7 +248,7 @@ func TestTautulliClient_RealAPIBehavior(t *testing.T) {
 		}
 		badClient := NewTautulliClient(badCfg)
 
-		_, err := badClient.GetServerInfo()
+		_, err := badClient.GetServerInfo(ctx)
 		// Real Tautulli may handle this differently than mocks
 		// This test validates actual behavior
 		t.Logf("Invalid API key response: %v", err)
@@ -271,7 +271,7 @@ func TestTautulliClient_RealAPIBehavior(t *testing.T) {
 		// Make multiple rapid requests to observe rate limiting
 		// Real Tautulli may have different rate limiting than mocks
 		for i := 0; i < 5; i++ {
-			_, err := client.GetActivity("")
+			_, err := client.GetActivity(ctx, "")
 			if err != nil {
 				t.Logf("Request %d error: %v", i+1, err)
 			}
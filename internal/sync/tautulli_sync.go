@@ -22,6 +22,9 @@ import (
 // errSkipRecord is a sentinel error indicating that a record should be skipped gracefully
 var errSkipRecord = errors.New("skip record")
 
+// errSessionAlreadyProcessed indicates the record's session is already stored
+var errSessionAlreadyProcessed = errors.New("session already processed")
+
 // performInitialSync performs the first synchronization on startup
 //
 // RACE CONDITION FIX (v2.4): Acquires syncMu to prevent concurrent execution
@@ -34,8 +37,15 @@ func (m *Manager) performInitialSync() error {
 	m.syncMu.Lock()
 	defer m.syncMu.Unlock()
 
+	ctx := context.Background()
 	since := m.getSyncStartTime()
-	return m.syncDataSince(context.Background(), since)
+	if !m.cfg.Sync.SyncAll {
+		if watermark := m.loadWatermark(ctx, watermarkSourceTautulli, m.tautulliServerID(), m.interval()); !watermark.IsZero() {
+			logging.Info().Time("watermark", watermark).Msg("Resuming sync from stored watermark")
+			since = watermark
+		}
+	}
+	return m.syncDataSince(ctx, since)
 }
 
 // getSyncStartTime returns the appropriate start time for sync operations.
@@ -54,16 +64,21 @@ func (m *Manager) getSyncStartTime() time.Time {
 		// Y2K date - far enough back to capture all data, but compatible with all APIs
 		return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
 	}
-	since := time.Now().Add(-m.cfg.Sync.Lookback)
+	since := m.timeSource().Now().Add(-m.cfg.Sync.Lookback)
 	logging.Debug().Time("since", since).Msg("getSyncStartTime: using lookback")
 	return since
 }
 
-// syncLoop runs the periodic synchronization
+// syncLoop runs the periodic synchronization, starting after the server's
+// stagger offset
 func (m *Manager) syncLoop(ctx context.Context) {
 	defer m.wg.Done()
 
-	ticker := time.NewTicker(m.cfg.Sync.Interval)
+	if !m.waitForHistorySyncOffset(ctx, m.historySyncOffset(m.cfg.Tautulli.ServerID, m.interval())) {
+		return
+	}
+
+	ticker := m.timeSource().NewTicker(m.interval())
 	defer ticker.Stop()
 
 	for {
@@ -72,7 +87,9 @@ func (m *Manager) syncLoop(ctx context.Context) {
 			return
 		case <-m.stopChan:
 			return
-		case <-ticker.C:
+		case <-m.intervalChanged:
+			ticker.Reset(m.interval())
+		case <-ticker.C():
 			// Prevent concurrent sync execution
 			m.syncMu.Lock()
 			err := m.syncData()
@@ -86,23 +103,37 @@ func (m *Manager) syncLoop(ctx context.Context) {
 }
 
 // syncData synchronizes new data from Tautulli
+// Resumes from the stored watermark when available, otherwise from the last
+// sync time or the lookback window.
 func (m *Manager) syncData() error {
 	// Note: syncMu should be locked by caller (TriggerSync or syncLoop)
-	since := m.LastSyncTime()
+	ctx := context.Background()
+	since := m.loadWatermark(ctx, watermarkSourceTautulli, m.tautulliServerID(), m.interval())
+	if since.IsZero() {
+		since = m.LastSyncTime()
+	}
 	if since.IsZero() {
 		since = m.getSyncStartTime()
 	}
-	return m.syncDataSince(context.Background(), since)
+	return m.syncDataSince(ctx, since)
 }
 
 // syncDataSince synchronizes data from a specific point in time
+//
+// The watermark is only persisted when every batch was fetched: history is
+// returned newest first, so a partial sync must not skip the unfetched tail.
 func (m *Manager) syncDataSince(ctx context.Context, since time.Time) error {
-	syncStartTime := time.Now()
+	defer m.trackSync("tautulli", m.tautulliServerID())()
+	syncStartTime := m.timeSource().Now()
+	m.tautulliWatermark = &watermarkTracker{}
+	defer func() { m.tautulliWatermark = nil }()
+
 	totalProcessed, err := m.fetchAndProcessBatches(ctx, since)
 	if err != nil {
 		return err
 	}
 
+	m.saveWatermark(ctx, watermarkSourceTautulli, m.tautulliServerID(), m.tautulliWatermark.watermark())
 	m.finalizeSyncOperation(ctx, syncStartTime, totalProcessed)
 	return nil
 }
@@ -111,9 +142,10 @@ func (m *Manager) syncDataSince(ctx context.Context, since time.Time) error {
 func (m *Manager) fetchAndProcessBatches(ctx context.Context, since time.Time) (int, error) {
 	start := 0
 	totalProcessed := 0
+	batchSize := m.batchSize() // Fixed for the whole sync so pages line up
 
 	for {
-		history, shouldContinue, err := m.fetchHistoryBatch(ctx, since, start)
+		history, shouldContinue, err := m.fetchHistoryBatch(ctx, since, start, batchSize)
 		if err != nil {
 			return totalProcessed, err
 		}
@@ -130,23 +162,23 @@ func (m *Manager) fetchAndProcessBatches(ctx context.Context, since time.Time) (
 			Int("total", totalProcessed).
 			Msg("Processed batch")
 
-		if len(history.Response.Data.Data) < m.cfg.Sync.BatchSize {
+		if len(history.Response.Data.Data) < batchSize {
 			break
 		}
 
-		start += m.cfg.Sync.BatchSize
+		start += batchSize
 	}
 
 	return totalProcessed, nil
 }
 
 // fetchHistoryBatch fetches a single batch from Tautulli with retry logic
-func (m *Manager) fetchHistoryBatch(ctx context.Context, since time.Time, start int) (*tautulli.TautulliHistory, bool, error) {
+func (m *Manager) fetchHistoryBatch(ctx context.Context, since time.Time, start, batchSize int) (*tautulli.TautulliHistory, bool, error) {
 	var history *tautulli.TautulliHistory
 	var err error
 
 	err = m.retryWithBackoff(ctx, func() error {
-		history, err = m.client.GetHistorySince(ctx, since, start, m.cfg.Sync.BatchSize)
+		history, err = m.client.GetHistorySince(ctx, since, start, batchSize)
 		return err
 	})
 
@@ -185,9 +217,10 @@ func (m *Manager) finalizeSyncOperation(ctx context.Context, syncStartTime time.
 	m.flushPublisherWithVerification(ctx, totalProcessed)
 
 	// Calculate sync duration and record metrics
-	syncDuration := time.Since(syncStartTime)
+	syncDuration := m.timeSource().Now().Sub(syncStartTime)
 	durationMs := syncDuration.Milliseconds()
 	metrics.RecordSyncOperation(syncDuration, totalProcessed, nil)
+	metrics.RecordSyncSuccess("tautulli", m.tautulliServerID())
 
 	// Invoke callback if set
 	if callback != nil {
@@ -291,13 +324,16 @@ func (m *Manager) processBatchWithFallback(ctx context.Context, records []tautul
 	for i := range records {
 		if recordErr := m.processHistoryRecord(ctx, &records[i]); recordErr != nil {
 			// Only collect non-duplicate errors (session already processed is expected)
-			if recordErr.Error() != "session already processed" {
+			if !errors.Is(recordErr, errSessionAlreadyProcessed) {
 				fallbackErrors = append(fallbackErrors, fmt.Errorf("record %d (session=%s): %w",
 					i, getEffectiveSessionKey(&records[i]), recordErr))
+				m.observeFailedRecord(&records[i], recordErr)
+				continue
 			}
 		} else {
 			processed++
 		}
+		m.tautulliWatermark.observe(recordStartTime(&records[i]))
 	}
 
 	if len(fallbackErrors) > 0 {
@@ -312,7 +348,9 @@ func (m *Manager) processBatchWithFallback(ctx context.Context, records []tautul
 func (m *Manager) fetchMissingGeolocations(ctx context.Context, ipList []string, geoMap map[string]*models.Geolocation) int {
 	missingCount := 0
 	for _, ip := range ipList {
-		if _, exists := geoMap[ip]; exists {
+		// LAN IPs are always re-resolved locally so that entries cached as
+		// "Unknown" (or before the server location was set) are corrected
+		if _, exists := geoMap[ip]; exists && !IsPrivateIP(ip) {
 			continue
 		}
 		missingCount++
@@ -326,30 +364,14 @@ func (m *Manager) fetchMissingGeolocations(ctx context.Context, ipList []string,
 	return missingCount
 }
 
-// fetchOrCreateFallbackGeolocation attempts to fetch geolocation, creating a fallback on failure
+// fetchOrCreateFallbackGeolocation attempts to fetch geolocation, creating a fallback on failure.
+// Private/LAN and invalid IPs are resolved locally without calling any GeoIP source.
 func (m *Manager) fetchOrCreateFallbackGeolocation(ctx context.Context, ip string) *models.Geolocation {
-	geo, err := m.fetchAndCacheGeolocation(ctx, ip)
-	if err != nil {
-		logging.Warn().Err(err).Str("ip", ip).Msg("Failed to fetch geolocation - using unknown location")
-		geo = m.createUnknownGeolocation(ip)
-		if cacheErr := m.db.UpsertGeolocation(geo); cacheErr != nil {
-			logging.Warn().Err(cacheErr).Str("ip", ip).Msg("Failed to cache unknown geolocation")
-		}
-	}
+	//nolint:errcheck // resolveGeolocationForIP always returns a geolocation, falling back to unknown
+	geo, _ := m.resolveGeolocationForIP(ctx, ip, "")
 	return geo
 }
 
-// createUnknownGeolocation creates a fallback geolocation entry for unknown IPs
-func (m *Manager) createUnknownGeolocation(ip string) *models.Geolocation {
-	return &models.Geolocation{
-		IPAddress:   ip,
-		Latitude:    0,
-		Longitude:   0,
-		Country:     "Unknown",
-		LastUpdated: time.Now(),
-	}
-}
-
 // processRecordsWithGeoMap processes all records using a pre-fetched geolocation map
 // Returns the count of successfully processed records
 func (m *Manager) processRecordsWithGeoMap(ctx context.Context, records []tautulli.TautulliHistoryRecord, geoMap map[string]*models.Geolocation) int {
@@ -357,14 +379,31 @@ func (m *Manager) processRecordsWithGeoMap(ctx context.Context, records []tautul
 	for i := range records {
 		record := &records[i]
 		if err := m.processHistoryRecordWithGeo(ctx, record, geoMap); err != nil {
+			if errors.Is(err, errSessionAlreadyProcessed) {
+				m.tautulliWatermark.observe(recordStartTime(record))
+			} else {
+				m.observeFailedRecord(record, err)
+			}
 			logging.Error().Err(err).Str("session", getEffectiveSessionKey(record)).Msg("Failed to process record")
 			continue
 		}
+		m.tautulliWatermark.observe(recordStartTime(record))
 		processed++
 	}
 	return processed
 }
 
+// observeFailedRecord updates the watermark for a record that failed to
+// ingest: invalid records are passed over, any other failure holds the
+// watermark so the next sync retries the record.
+func (m *Manager) observeFailedRecord(record *tautulli.TautulliHistoryRecord, err error) {
+	if errors.Is(err, errInvalidRecord) {
+		m.tautulliWatermark.observe(recordStartTime(record))
+		return
+	}
+	m.tautulliWatermark.fail(recordStartTime(record))
+}
+
 // processHistoryRecordWithGeo processes a single record using pre-fetched geolocation map
 // MEDIUM-2: Optimized version that skips individual geolocation lookups
 //
@@ -402,7 +441,7 @@ func (m *Manager) validateRecordForMode(ctx context.Context, record *tautulli.Ta
 
 	// In event sourcing mode, only validate IP address
 	if record.IPAddress == "" || record.IPAddress == "N/A" {
-		return fmt.Errorf("invalid IP address for session %s", getEffectiveSessionKey(record))
+		return fmt.Errorf("%w: invalid IP address for session %s", errInvalidRecord, getEffectiveSessionKey(record))
 	}
 	return nil
 }
@@ -437,6 +476,10 @@ func (m *Manager) buildEnrichedEvent(record *tautulli.TautulliHistoryRecord) *mo
 
 // persistEventForMode persists the event using event sourcing or notification mode
 func (m *Manager) persistEventForMode(ctx context.Context, event *models.PlaybackEvent, eventSourcingMode bool) error {
+	if quarantined, err := m.quarantineIfImplausible(ctx, event); err != nil || quarantined {
+		return err
+	}
+
 	if eventSourcingMode {
 		return m.persistEventWithEventSourcing(ctx, event)
 	}
@@ -507,12 +550,12 @@ func (m *Manager) validateRecord(ctx context.Context, record *tautulli.TautulliH
 	}
 
 	if exists {
-		return fmt.Errorf("session already processed") // Special error type to skip gracefully
+		return errSessionAlreadyProcessed // Skipped gracefully by callers
 	}
 
 	// Validate IP address
 	if record.IPAddress == "" || record.IPAddress == "N/A" {
-		return fmt.Errorf("invalid IP address for session %s", sessionKey)
+		return fmt.Errorf("%w: invalid IP address for session %s", errInvalidRecord, sessionKey)
 	}
 
 	return nil
@@ -550,7 +593,7 @@ func (m *Manager) processHistoryRecord(ctx context.Context, record *tautulli.Tau
 func (m *Manager) validateAndHandleRecord(ctx context.Context, record *tautulli.TautulliHistoryRecord, eventSourcingMode bool) error {
 	if err := m.validateRecordForMode(ctx, record, eventSourcingMode); err != nil {
 		// In notification mode, gracefully skip already-processed sessions
-		if !eventSourcingMode && err.Error() == "session already processed" {
+		if !eventSourcingMode && errors.Is(err, errSessionAlreadyProcessed) {
 			return errSkipRecord
 		}
 		return err
@@ -0,0 +1,163 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/logging"
+	"github.com/tomtom215/cartographus/internal/models/tautulli"
+)
+
+// errInvalidRecord marks a history record that can never be ingested, such as
+// one without a client IP address. Unlike other failures it does not hold the
+// watermark back, since refetching the record cannot succeed.
+var errInvalidRecord = errors.New("invalid record")
+
+// Watermark source keys
+const (
+	watermarkSourceTautulli = "tautulli"
+	watermarkSourcePlex     = "plex"
+)
+
+// WatermarkStore persists the latest ingested playback time per source and
+// server so syncs only fetch newer history. Implemented by *database.DB.
+type WatermarkStore interface {
+	// GetSyncWatermark returns the zero time if no watermark is stored.
+	GetSyncWatermark(ctx context.Context, source, serverID string) (time.Time, error)
+
+	// SetSyncWatermark must never move a stored watermark backwards.
+	SetSyncWatermark(ctx context.Context, source, serverID string, watermark time.Time) error
+}
+
+// SetWatermarkStore enables incremental sync. When set, Tautulli and Plex
+// syncs resume from the stored watermark instead of the lookback window.
+//
+// Thread Safety: Safe for concurrent access but should be called once during startup.
+func (m *Manager) SetWatermarkStore(store WatermarkStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watermarks = store
+}
+
+// tautulliServerID returns the Tautulli server ID, defaulting when unset
+func (m *Manager) tautulliServerID() string {
+	return serverIDOrDefault("tautulli", m.cfg.Tautulli.ServerID)
+}
+
+// plexServerID returns the Plex server ID, defaulting when unset
+func (m *Manager) plexServerID() string {
+	return serverIDOrDefault("plex", m.cfg.Plex.ServerID)
+}
+
+// serverIDOrDefault returns serverID, or "<source>-default" when it is unset
+func serverIDOrDefault(source, serverID string) string {
+	if serverID != "" {
+		return serverID
+	}
+	return source + "-default"
+}
+
+// loadWatermark returns where a sync of the source should resume: the stored
+// watermark moved back by overlap, so history that reaches the server late,
+// older than the newest record already ingested, is still fetched. Returns the
+// zero time if no watermark is stored, no store is configured, or the lookup
+// fails.
+func (m *Manager) loadWatermark(ctx context.Context, source, serverID string, overlap time.Duration) time.Time {
+	m.mu.RLock()
+	store := m.watermarks
+	m.mu.RUnlock()
+
+	if store == nil {
+		return time.Time{}
+	}
+
+	watermark, err := store.GetSyncWatermark(ctx, source, serverID)
+	if err != nil {
+		logging.Warn().Err(err).Str("source", source).Str("server_id", serverID).Msg("Failed to load sync watermark, using lookback window")
+		return time.Time{}
+	}
+	if watermark.IsZero() {
+		return watermark
+	}
+	return watermark.Add(-overlap)
+}
+
+// saveWatermark persists the watermark for a source. Zero watermarks (nothing
+// ingested) are skipped; failures are logged since the next sync can safely
+// fall back to an older starting point.
+func (m *Manager) saveWatermark(ctx context.Context, source, serverID string, watermark time.Time) {
+	if watermark.IsZero() {
+		return
+	}
+
+	m.mu.RLock()
+	store := m.watermarks
+	m.mu.RUnlock()
+
+	if store == nil {
+		return
+	}
+
+	if err := store.SetSyncWatermark(ctx, source, serverID, watermark); err != nil {
+		logging.Warn().Err(err).Str("source", source).Str("server_id", serverID).Msg("Failed to save sync watermark")
+		return
+	}
+	logging.Debug().Str("source", source).Str("server_id", serverID).Time("watermark", watermark).Msg("Sync watermark saved")
+}
+
+// watermarkTracker accumulates the latest playback start time ingested during
+// a single sync run. Records already stored count as ingested.
+type watermarkTracker struct {
+	latest time.Time
+
+	// earliestFailed is the start time of the oldest record that failed to
+	// ingest. The watermark is held before it so the next sync refetches it.
+	earliestFailed time.Time
+}
+
+// observe advances the tracker to ts if it is newer. Timestamps in the future
+// (corrupt or skewed clocks) are ignored so a bad record cannot push the
+// watermark past history that has not been fetched yet.
+func (t *watermarkTracker) observe(ts time.Time) {
+	if t == nil || ts.After(time.Now()) {
+		return
+	}
+	if ts.After(t.latest) {
+		t.latest = ts
+	}
+}
+
+// fail records a record that could not be ingested but may succeed when
+// retried, such as a failed insert.
+func (t *watermarkTracker) fail(ts time.Time) {
+	if t == nil || ts.IsZero() {
+		return
+	}
+	if t.earliestFailed.IsZero() || ts.Before(t.earliestFailed) {
+		t.earliestFailed = ts
+	}
+}
+
+// watermark returns the watermark to persist: the latest ingested start time,
+// held a second before the earliest failed record so newer records that did
+// succeed cannot move it past a record that still has to be ingested.
+func (t *watermarkTracker) watermark() time.Time {
+	if t.earliestFailed.IsZero() || t.latest.Before(t.earliestFailed) {
+		return t.latest
+	}
+	return t.earliestFailed.Add(-time.Second)
+}
+
+// recordStartTime returns when a Tautulli history record started playing
+func recordStartTime(record *tautulli.TautulliHistoryRecord) time.Time {
+	if record.Started <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(record.Started, 0)
+}
@@ -0,0 +1,402 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/config"
+	"github.com/tomtom215/cartographus/internal/models"
+	"github.com/tomtom215/cartographus/internal/models/tautulli"
+)
+
+// memWatermarkStore is an in-memory WatermarkStore with the same
+// never-move-backwards semantics as the database implementation.
+type memWatermarkStore struct {
+	watermarks map[string]time.Time
+}
+
+func newMemWatermarkStore() *memWatermarkStore {
+	return &memWatermarkStore{watermarks: make(map[string]time.Time)}
+}
+
+func (s *memWatermarkStore) GetSyncWatermark(_ context.Context, source, serverID string) (time.Time, error) {
+	return s.watermarks[source+"/"+serverID], nil
+}
+
+func (s *memWatermarkStore) SetSyncWatermark(_ context.Context, source, serverID string, watermark time.Time) error {
+	key := source + "/" + serverID
+	if watermark.After(s.watermarks[key]) {
+		s.watermarks[key] = watermark
+	}
+	return nil
+}
+
+func watermarkTestDB() *mockDB {
+	return &mockDB{
+		sessionKeyExists: func(context.Context, string) (bool, error) { return false, nil },
+		getGeolocations: func(_ context.Context, ips []string) (map[string]*models.Geolocation, error) {
+			geo := make(map[string]*models.Geolocation, len(ips))
+			for _, ip := range ips {
+				geo[ip] = &models.Geolocation{IPAddress: ip, Country: "United States"}
+			}
+			return geo, nil
+		},
+		insertPlaybackEvent: func(*models.PlaybackEvent) error { return nil },
+	}
+}
+
+func TestManager_TautulliSync_ResumesFromWatermark(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Tautulli.ServerID = "tautulli-1"
+
+	newest := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	var sinceCalls []time.Time
+	client := &mockTautulliClient{
+		getHistorySince: func(_ context.Context, since time.Time, start, _ int) (*tautulli.TautulliHistory, error) {
+			if start == 0 {
+				sinceCalls = append(sinceCalls, since)
+			}
+			var records []tautulli.TautulliHistoryRecord
+			if len(sinceCalls) == 1 && start == 0 {
+				// Newest first, like the Tautulli API
+				for i, started := range []time.Time{newest, newest.Add(-time.Hour)} {
+					records = append(records, tautulli.TautulliHistoryRecord{
+						SessionKey: stringPtr(fmt.Sprintf("session-%d", i)),
+						UserID:     intPtr(1),
+						User:       "user1",
+						IPAddress:  "203.0.113.1",
+						MediaType:  "movie",
+						Title:      "Movie",
+						Started:    started.Unix(),
+					})
+				}
+			}
+			return &tautulli.TautulliHistory{Response: tautulli.TautulliHistoryResponse{
+				Result: "success",
+				Data:   tautulli.TautulliHistoryData{Data: records},
+			}}, nil
+		},
+	}
+
+	store := newMemWatermarkStore()
+	m := NewManager(watermarkTestDB(), nil, client, cfg, nil)
+	m.SetWatermarkStore(store)
+
+	// First run: no watermark, falls back to the lookback window
+	if err := m.performInitialSync(); err != nil {
+		t.Fatalf("performInitialSync() error = %v", err)
+	}
+	if diff := time.Since(sinceCalls[0]) - cfg.Sync.Lookback; diff < -5*time.Second || diff > 5*time.Second {
+		t.Errorf("first sync since = %v, want ~%v ago", sinceCalls[0], cfg.Sync.Lookback)
+	}
+	if got := store.watermarks["tautulli/tautulli-1"]; !got.Equal(newest) {
+		t.Fatalf("stored watermark = %v, want %v", got, newest)
+	}
+
+	// Second run: resumes one sync interval before the stored watermark
+	resume := newest.Add(-cfg.Sync.Interval)
+	if err := m.TriggerSync(); err != nil {
+		t.Fatalf("TriggerSync() error = %v", err)
+	}
+	if len(sinceCalls) != 2 || !sinceCalls[1].Equal(resume) {
+		t.Errorf("second sync since = %v, want %v", sinceCalls[1:], resume)
+	}
+
+	// A fresh manager (restart) also resumes from the watermark
+	sinceCalls = sinceCalls[:1]
+	restarted := NewManager(watermarkTestDB(), nil, client, cfg, nil)
+	restarted.SetWatermarkStore(store)
+	if err := restarted.performInitialSync(); err != nil {
+		t.Fatalf("performInitialSync() after restart error = %v", err)
+	}
+	if len(sinceCalls) != 2 || !sinceCalls[1].Equal(resume) {
+		t.Errorf("sync after restart since = %v, want %v", sinceCalls[1:], resume)
+	}
+}
+
+func TestManager_TautulliSync_FailedRecordIsRefetched(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Tautulli.ServerID = "tautulli-1"
+
+	// Newest first: session-2 succeeds, session-1 fails once, session-0 succeeds
+	failedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	starts := []time.Time{failedAt.Add(30 * time.Minute), failedAt, failedAt.Add(-30 * time.Minute)}
+	var fetched [][]string
+	client := &mockTautulliClient{
+		getHistorySince: func(_ context.Context, since time.Time, start, _ int) (*tautulli.TautulliHistory, error) {
+			var records []tautulli.TautulliHistoryRecord
+			if start == 0 {
+				var sessions []string
+				for i, started := range starts {
+					if started.Before(since) {
+						continue
+					}
+					key := fmt.Sprintf("session-%d", len(starts)-1-i)
+					sessions = append(sessions, key)
+					records = append(records, tautulli.TautulliHistoryRecord{
+						SessionKey: stringPtr(key),
+						UserID:     intPtr(1),
+						User:       "user1",
+						IPAddress:  "203.0.113.1",
+						MediaType:  "movie",
+						Title:      "Movie",
+						Started:    started.Unix(),
+					})
+				}
+				fetched = append(fetched, sessions)
+			}
+			return &tautulli.TautulliHistory{Response: tautulli.TautulliHistoryResponse{
+				Result: "success",
+				Data:   tautulli.TautulliHistoryData{Data: records},
+			}}, nil
+		},
+	}
+
+	db := watermarkTestDB()
+	inserted := make(map[string]bool)
+	failOnce := true
+	db.insertPlaybackEvent = func(event *models.PlaybackEvent) error {
+		if event.SessionKey == "session-1" && failOnce {
+			failOnce = false
+			return fmt.Errorf("database is locked")
+		}
+		inserted[event.SessionKey] = true
+		return nil
+	}
+
+	store := newMemWatermarkStore()
+	m := NewManager(db, nil, client, cfg, nil)
+	m.SetWatermarkStore(store)
+
+	if err := m.syncDataSince(context.Background(), failedAt.Add(-time.Hour)); err != nil {
+		t.Fatalf("first sync error = %v", err)
+	}
+	if got := store.watermarks["tautulli/tautulli-1"]; !got.Before(failedAt) {
+		t.Fatalf("stored watermark = %v, want before failed record at %v", got, failedAt)
+	}
+
+	if err := m.TriggerSync(); err != nil {
+		t.Fatalf("TriggerSync() error = %v", err)
+	}
+	if len(fetched) != 2 || !slices.Contains(fetched[1], "session-1") {
+		t.Fatalf("second sync fetched %v, want session-1 refetched", fetched)
+	}
+	if !inserted["session-1"] {
+		t.Error("failed record was not ingested on the next sync")
+	}
+	if got := store.watermarks["tautulli/tautulli-1"]; !got.Equal(starts[0]) {
+		t.Errorf("stored watermark = %v, want %v once every record is ingested", got, starts[0])
+	}
+}
+
+func TestManager_TautulliSync_InvalidRecordDoesNotHoldWatermark(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Tautulli.ServerID = "tautulli-1"
+
+	newest := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	client := &mockTautulliClient{
+		getHistorySince: func(_ context.Context, _ time.Time, start, _ int) (*tautulli.TautulliHistory, error) {
+			var records []tautulli.TautulliHistoryRecord
+			if start == 0 {
+				records = []tautulli.TautulliHistoryRecord{
+					{SessionKey: stringPtr("session-1"), UserID: intPtr(1), User: "user1", IPAddress: "203.0.113.1", Started: newest.Unix()},
+					{SessionKey: stringPtr("session-0"), UserID: intPtr(1), User: "user1", Started: newest.Add(-time.Hour).Unix()},
+				}
+			}
+			return &tautulli.TautulliHistory{Response: tautulli.TautulliHistoryResponse{
+				Result: "success",
+				Data:   tautulli.TautulliHistoryData{Data: records},
+			}}, nil
+		},
+	}
+
+	store := newMemWatermarkStore()
+	m := NewManager(watermarkTestDB(), nil, client, cfg, nil)
+	m.SetWatermarkStore(store)
+
+	if err := m.syncDataSince(context.Background(), newest.Add(-2*time.Hour)); err != nil {
+		t.Fatalf("syncDataSince() error = %v", err)
+	}
+	if got := store.watermarks["tautulli/tautulli-1"]; !got.Equal(newest) {
+		t.Errorf("stored watermark = %v, want %v", got, newest)
+	}
+}
+
+func TestManager_TautulliSync_FailedSyncKeepsWatermark(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Sync.BatchSize = 1
+	cfg.Sync.RetryAttempts = 1
+
+	client := &mockTautulliClient{
+		getHistorySince: func(_ context.Context, _ time.Time, start, _ int) (*tautulli.TautulliHistory, error) {
+			if start > 0 {
+				return nil, fmt.Errorf("tautulli unavailable")
+			}
+			return &tautulli.TautulliHistory{Response: tautulli.TautulliHistoryResponse{
+				Result: "success",
+				Data: tautulli.TautulliHistoryData{Data: []tautulli.TautulliHistoryRecord{{
+					SessionKey: stringPtr("session-1"),
+					UserID:     intPtr(1),
+					IPAddress:  "203.0.113.1",
+					Started:    time.Now().Add(-time.Minute).Unix(),
+				}}},
+			}}, nil
+		},
+	}
+
+	store := newMemWatermarkStore()
+	m := NewManager(watermarkTestDB(), nil, client, cfg, nil)
+	m.SetWatermarkStore(store)
+
+	if err := m.syncDataSince(context.Background(), time.Now().Add(-time.Hour)); err == nil {
+		t.Fatal("expected sync error")
+	}
+	if len(store.watermarks) != 0 {
+		t.Errorf("watermark saved after partial sync: %v", store.watermarks)
+	}
+}
+
+func TestManager_PlexRecentSync_ResumesFromWatermark(t *testing.T) {
+	newest := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	var filters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("viewedAt>"))
+		w.Header().Set("Content-Type", "application/json")
+		if len(filters) > 1 {
+			_, _ = w.Write([]byte(`{"MediaContainer":{"size":0,"Metadata":[]}}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"MediaContainer":{"size":1,"Metadata":[
+			{"ratingKey":"1","type":"movie","title":"Movie","viewedAt":%d,"accountID":1}]}}`, newest.Unix())
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Plex: config.PlexConfig{
+		Enabled: true, URL: server.URL, Token: "token", ServerID: "plex-1", SyncInterval: 24 * time.Hour,
+	}}
+	store := newMemWatermarkStore()
+	m := NewManager(watermarkTestDB(), nil, &mockTautulliClient{}, cfg, nil)
+	m.SetWatermarkStore(store)
+
+	for i := 0; i < 2; i++ {
+		if err := m.syncPlexRecent(context.Background()); err != nil {
+			t.Fatalf("syncPlexRecent() run %d error = %v", i+1, err)
+		}
+	}
+
+	if got := store.watermarks["plex/plex-1"]; !got.Equal(newest) {
+		t.Fatalf("stored watermark = %v, want %v", got, newest)
+	}
+	if len(filters) != 2 {
+		t.Fatalf("got %d history requests, want 2", len(filters))
+	}
+	first, err := strconv.ParseInt(filters[0], 10, 64)
+	if err != nil || time.Since(time.Unix(first, 0)) < 23*time.Hour {
+		t.Errorf("first request viewedAt> = %q, want ~24h ago", filters[0])
+	}
+	if resume := newest.Add(-cfg.Plex.SyncInterval).Unix(); filters[1] != strconv.FormatInt(resume, 10) {
+		t.Errorf("second request viewedAt> = %q, want %d (watermark less one interval)", filters[1], resume)
+	}
+}
+
+func TestManager_PlexRecentSync_FailedRecordIsRefetched(t *testing.T) {
+	failedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		since, _ := strconv.ParseInt(r.URL.Query().Get("viewedAt>"), 10, 64)
+		var items []string
+		for i, viewedAt := range []time.Time{failedAt.Add(30 * time.Minute), failedAt} {
+			if viewedAt.Unix() > since {
+				items = append(items, fmt.Sprintf(`{"ratingKey":"%d","type":"movie","title":"Movie %d","viewedAt":%d,"accountID":1}`,
+					i, i, viewedAt.Unix()))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"MediaContainer":{"size":%d,"Metadata":[%s]}}`, len(items), strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Plex: config.PlexConfig{
+		Enabled: true, URL: server.URL, Token: "token", ServerID: "plex-1", SyncInterval: 2 * time.Hour,
+	}}
+	db := watermarkTestDB()
+	var inserted []string
+	failOnce := true
+	db.insertPlaybackEvent = func(event *models.PlaybackEvent) error {
+		if event.Title == "Movie 1" && failOnce {
+			failOnce = false
+			return fmt.Errorf("database is locked")
+		}
+		inserted = append(inserted, event.Title)
+		return nil
+	}
+	store := newMemWatermarkStore()
+	m := NewManager(db, nil, &mockTautulliClient{}, cfg, nil)
+	m.SetWatermarkStore(store)
+
+	if err := m.syncPlexRecent(context.Background()); err != nil {
+		t.Fatalf("first syncPlexRecent() error = %v", err)
+	}
+	if got := store.watermarks["plex/plex-1"]; !got.Before(failedAt) {
+		t.Fatalf("stored watermark = %v, want before failed record at %v", got, failedAt)
+	}
+
+	if err := m.syncPlexRecent(context.Background()); err != nil {
+		t.Fatalf("second syncPlexRecent() error = %v", err)
+	}
+	if requests != 2 || !slices.Contains(inserted, "Movie 1") {
+		t.Errorf("inserted %v after %d syncs, want Movie 1 refetched", inserted, requests)
+	}
+}
+
+func TestWatermarkTracker_IgnoresFutureTimestamps(t *testing.T) {
+	tracker := &watermarkTracker{}
+	past := time.Now().Add(-time.Hour)
+
+	tracker.observe(past)
+	tracker.observe(time.Unix(1<<32-1, 0)) // corrupt year-2106 timestamp
+	tracker.observe(past.Add(-time.Hour))
+
+	if !tracker.latest.Equal(past) {
+		t.Errorf("latest = %v, want %v", tracker.latest, past)
+	}
+
+	var nilTracker *watermarkTracker
+	nilTracker.observe(past) // must not panic
+	nilTracker.fail(past)
+}
+
+func TestWatermarkTracker_HoldsBeforeEarliestFailure(t *testing.T) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	tracker := &watermarkTracker{}
+
+	tracker.observe(base.Add(2 * time.Minute))
+	tracker.fail(base.Add(time.Minute))
+	tracker.fail(base)
+	tracker.observe(base.Add(-time.Minute))
+
+	if got, want := tracker.watermark(), base.Add(-time.Second); !got.Equal(want) {
+		t.Errorf("watermark() = %v, want %v", got, want)
+	}
+
+	// A failure newer than everything ingested does not hold anything back
+	tracker = &watermarkTracker{}
+	tracker.observe(base)
+	tracker.fail(base.Add(time.Minute))
+	if got := tracker.watermark(); !got.Equal(base) {
+		t.Errorf("watermark() = %v, want %v", got, base)
+	}
+}
@@ -32,6 +32,83 @@
 //	    // ...
 //	}
 //
+// # Jellyfin Container
+//
+// The JellyfinContainer runs the official Jellyfin image with the startup
+// wizard already completed, so URL, APIKey and UserID are ready for the
+// Jellyfin client. Libraries can be seeded from host directories:
+//
+//	jellyfin, err := testinfra.NewJellyfinContainer(ctx,
+//	    testinfra.WithSeedLibrary("Movies", "movies", "/path/to/movies"),
+//	)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer jellyfin.Terminate(ctx)
+//
+//	client := sync.NewJellyfinClient(jellyfin.URL, jellyfin.APIKey, jellyfin.UserID)
+//
+// # Plex Mock Container
+//
+// There is no Plex image that runs without a claimed account, so the
+// PlexMockContainer builds a small stand-in from testdata/plex. It serves
+// canned fixtures for /status/sessions, /library/sections, /transcode/sessions
+// and the identity endpoints, and a WebSocket notification stream.
+// Tests can swap fixtures at startup, replace sessions mid-test and push
+// notifications to connected clients:
+//
+//	plex, err := testinfra.NewPlexMockContainer(ctx,
+//	    testinfra.WithPlexSessionsFixture("/path/to/sessions.json"),
+//	)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer plex.Terminate(ctx)
+//
+//	client := sync.NewPlexClient(plex.URL, plex.Token)
+//	sessions, err := client.GetTranscodeSessions(ctx)
+//
+//	delivered, err := plex.Notify(ctx, models.PlexNotificationWrapper{...})
+//
+// # Embedded NATS
+//
+// NewNATSHarness (build tag nats) needs no Docker: it starts the
+// eventprocessor embedded JetStream server on a random localhost port with
+// its store in t.TempDir(), and shuts it down via t.Cleanup. Record
+// subscribes before anything is published, so tests wait for exactly the
+// messages they expect instead of sleeping:
+//
+//	h := testinfra.NewNATSHarness(t)
+//	h.EnsureStream()
+//	recorder := h.Record("playback.>")
+//
+//	publisher, err := eventprocessor.NewPublisher(eventprocessor.DefaultPublisherConfig(h.URL), nil)
+//	// ... publish two events
+//
+//	msgs := recorder.Await(2, 5*time.Second)
+//
+// Tests inside package eventprocessor cannot import testinfra (it imports
+// eventprocessor); use an external eventprocessor_test package instead.
+//
+// # NATS Container
+//
+// NewNATSContainer (build tags integration and nats) runs the official NATS
+// image with JetStream, for tests against a separately deployed server as
+// with NATS_EMBEDDED=false. The MEDIA_EVENTS stream is created at startup
+// with the production configuration, capped at 256MB unless retention is
+// overridden:
+//
+//	nats, err := testinfra.NewNATSContainer(ctx,
+//	    testinfra.WithNATSStreamRetention(time.Hour, 64<<20, 1000),
+//	)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer nats.Terminate(ctx)
+//
+//	subCfg := eventprocessor.DefaultSubscriberConfig(nats.ClientURL)
+//	subCfg.StreamName = nats.Stream.Name
+//
 // # Benefits Over Mocks
 //
 // Using real containers provides several advantages:
@@ -0,0 +1,365 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration
+
+package testinfra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// DefaultJellyfinImage is the official Jellyfin Docker image
+	DefaultJellyfinImage = "jellyfin/jellyfin:latest"
+
+	// DefaultJellyfinPort is the default Jellyfin HTTP port
+	DefaultJellyfinPort = "8096"
+
+	// DefaultJellyfinAdminUser is the administrator created by the startup wizard
+	DefaultJellyfinAdminUser = "admin"
+
+	// DefaultJellyfinAdminPassword is the administrator's password
+	DefaultJellyfinAdminPassword = "cartographus-test"
+
+	// jellyfinAppName is the API key name shown in Dashboard > API Keys
+	jellyfinAppName = "Cartographus"
+
+	// jellyfinMediaRoot is where seed libraries are placed in the container
+	jellyfinMediaRoot = "/media"
+)
+
+// JellyfinContainer represents a running Jellyfin container for testing.
+// The startup wizard has been completed, so URL, APIKey and UserID can be
+// passed straight to the Jellyfin client.
+type JellyfinContainer struct {
+	testcontainers.Container
+	URL    string
+	APIKey string
+
+	// UserID is the administrator's user ID
+	UserID string
+}
+
+// JellyfinOption configures the Jellyfin container.
+type JellyfinOption func(*jellyfinConfig)
+
+// JellyfinLibrary describes a library created at startup.
+type JellyfinLibrary struct {
+	// Name is the library's display name, e.g. "Movies"
+	Name string
+
+	// CollectionType is the Jellyfin collection type ("movies", "tvshows", "music", ...)
+	CollectionType string
+
+	// HostPath is an optional host directory copied into the container as the
+	// library's content; empty creates an empty library
+	HostPath string
+}
+
+type jellyfinConfig struct {
+	image         string
+	adminUser     string
+	adminPassword string
+	libraries     []JellyfinLibrary
+	startTimeout  time.Duration
+}
+
+// WithJellyfinImage sets a custom Jellyfin Docker image.
+func WithJellyfinImage(image string) JellyfinOption {
+	return func(c *jellyfinConfig) {
+		c.image = image
+	}
+}
+
+// WithJellyfinAdmin sets the administrator credentials used for the startup wizard.
+func WithJellyfinAdmin(username, password string) JellyfinOption {
+	return func(c *jellyfinConfig) {
+		c.adminUser = username
+		c.adminPassword = password
+	}
+}
+
+// WithSeedLibrary adds a library to create at startup. hostPath, if set, is
+// copied into the container and scanned; it may be given more than once.
+func WithSeedLibrary(name, collectionType, hostPath string) JellyfinOption {
+	return func(c *jellyfinConfig) {
+		c.libraries = append(c.libraries, JellyfinLibrary{
+			Name:           name,
+			CollectionType: collectionType,
+			HostPath:       hostPath,
+		})
+	}
+}
+
+// WithJellyfinStartTimeout sets the timeout for waiting for Jellyfin to start.
+func WithJellyfinStartTimeout(timeout time.Duration) JellyfinOption {
+	return func(c *jellyfinConfig) {
+		c.startTimeout = timeout
+	}
+}
+
+// NewJellyfinContainer creates and starts a new Jellyfin container for testing.
+// It completes the startup wizard, creates an API key and the seed libraries.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	jellyfin, err := NewJellyfinContainer(ctx,
+//	    WithSeedLibrary("Movies", "movies", "testdata/jellyfin/movies"),
+//	)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer jellyfin.Terminate(ctx)
+//
+//	client := sync.NewJellyfinClient(jellyfin.URL, jellyfin.APIKey, jellyfin.UserID)
+func NewJellyfinContainer(ctx context.Context, opts ...JellyfinOption) (*JellyfinContainer, error) {
+	cfg := &jellyfinConfig{
+		image:         DefaultJellyfinImage,
+		adminUser:     DefaultJellyfinAdminUser,
+		adminPassword: DefaultJellyfinAdminPassword,
+		startTimeout:  120 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Build container request
+	req := testcontainers.ContainerRequest{
+		Image:        cfg.image,
+		ExposedPorts: []string{DefaultJellyfinPort + "/tcp"},
+		Env: map[string]string{
+			"TZ": "UTC",
+		},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(DefaultJellyfinPort+"/tcp"),
+			wait.ForHTTP("/health").WithPort(DefaultJellyfinPort+"/tcp"),
+		).WithStartupTimeout(cfg.startTimeout),
+	}
+
+	// Start container
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create jellyfin container: %w", err)
+	}
+
+	// Get container host and port
+	host, err := container.Host(ctx)
+	if err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("get container host: %w", err)
+	}
+
+	port, err := container.MappedPort(ctx, DefaultJellyfinPort)
+	if err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("get mapped port: %w", err)
+	}
+
+	jellyfin := &JellyfinContainer{
+		Container: container,
+		URL:       fmt.Sprintf("http://%s:%s", host, port.Port()),
+	}
+
+	if err := jellyfin.completeStartupWizard(ctx, cfg); err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("complete startup wizard: %w", err)
+	}
+
+	for _, library := range cfg.libraries {
+		if err := jellyfin.createLibrary(ctx, library); err != nil {
+			container.Terminate(ctx) //nolint:errcheck
+			return nil, fmt.Errorf("create library %q: %w", library.Name, err)
+		}
+	}
+
+	return jellyfin, nil
+}
+
+// completeStartupWizard runs the first-start wizard, signs in as the
+// administrator and creates the API key.
+func (c *JellyfinContainer) completeStartupWizard(ctx context.Context, cfg *jellyfinConfig) error {
+	steps := []struct {
+		method string
+		path   string
+		body   interface{}
+	}{
+		{http.MethodPost, "/Startup/Configuration", map[string]string{
+			"UICulture":                 "en-US",
+			"MetadataCountryCode":       "US",
+			"PreferredMetadataLanguage": "en",
+		}},
+		// GET creates the initial user that the POST below renames
+		{http.MethodGet, "/Startup/User", nil},
+		{http.MethodPost, "/Startup/User", map[string]string{
+			"Name":     cfg.adminUser,
+			"Password": cfg.adminPassword,
+		}},
+		{http.MethodPost, "/Startup/RemoteAccess", map[string]bool{
+			"EnableRemoteAccess":         true,
+			"EnableAutomaticPortMapping": false,
+		}},
+		{http.MethodPost, "/Startup/Complete", nil},
+	}
+	for _, step := range steps {
+		if err := c.do(ctx, step.method, step.path, "", step.body, nil); err != nil {
+			return err
+		}
+	}
+
+	var auth struct {
+		AccessToken string `json:"AccessToken"`
+		User        struct {
+			ID string `json:"Id"`
+		} `json:"User"`
+	}
+	credentials := map[string]string{"Username": cfg.adminUser, "Pw": cfg.adminPassword}
+	if err := c.do(ctx, http.MethodPost, "/Users/AuthenticateByName", "", credentials, &auth); err != nil {
+		return fmt.Errorf("authenticate: %w", err)
+	}
+	c.UserID = auth.User.ID
+
+	if err := c.do(ctx, http.MethodPost, "/Auth/Keys?app="+url.QueryEscape(jellyfinAppName), auth.AccessToken, nil, nil); err != nil {
+		return fmt.Errorf("create API key: %w", err)
+	}
+
+	var keys struct {
+		Items []struct {
+			AccessToken string `json:"AccessToken"`
+			AppName     string `json:"AppName"`
+		} `json:"Items"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/Auth/Keys", auth.AccessToken, nil, &keys); err != nil {
+		return fmt.Errorf("list API keys: %w", err)
+	}
+	for _, key := range keys.Items {
+		if key.AppName == jellyfinAppName {
+			c.APIKey = key.AccessToken
+			return nil
+		}
+	}
+	return fmt.Errorf("API key %q not found after creation", jellyfinAppName)
+}
+
+// createLibrary copies the library's content into the container and adds it
+// as a virtual folder, triggering a scan.
+func (c *JellyfinContainer) createLibrary(ctx context.Context, library JellyfinLibrary) error {
+	mediaPath := jellyfinMediaRoot + "/" + library.Name
+	if library.HostPath != "" {
+		// CopyDirToContainer keeps the directory's base name
+		if err := c.Container.CopyDirToContainer(ctx, library.HostPath, jellyfinMediaRoot, 0755); err != nil {
+			return fmt.Errorf("copy library content: %w", err)
+		}
+		mediaPath = jellyfinMediaRoot + "/" + filepath.Base(library.HostPath)
+	} else {
+		code, _, err := c.Container.Exec(ctx, []string{"mkdir", "-p", mediaPath})
+		if err != nil || code != 0 {
+			return fmt.Errorf("create library directory (exit %d): %v", code, err)
+		}
+	}
+
+	query := url.Values{
+		"name":           {library.Name},
+		"collectionType": {library.CollectionType},
+		"paths":          {mediaPath},
+		"refreshLibrary": {"true"},
+	}
+	body := map[string]interface{}{"LibraryOptions": map[string]interface{}{}}
+	return c.do(ctx, http.MethodPost, "/Library/VirtualFolders?"+query.Encode(), c.APIKey, body, nil)
+}
+
+// do sends a JSON request to the Jellyfin API, authenticating with token if
+// set, and decodes the response into out if it is non-nil.
+func (c *JellyfinContainer) do(ctx context.Context, method, path, token string, body, out interface{}) error {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode %s body: %w", path, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, reader)
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", path, err)
+	}
+	authorization := `MediaBrowser Client="Cartographus", Device="testinfra", DeviceId="cartographus-testinfra", Version="1.0.0"`
+	if token != "" {
+		authorization += fmt.Sprintf(`, Token="%s"`, token)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned status %d: %s", method, path, resp.StatusCode, string(data))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode %s response: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Terminate stops and removes the Jellyfin container.
+func (c *JellyfinContainer) Terminate(ctx context.Context) error {
+	return c.Container.Terminate(ctx)
+}
+
+// Libraries returns the names of the server's libraries.
+func (c *JellyfinContainer) Libraries(ctx context.Context) ([]string, error) {
+	var folders []struct {
+		Name string `json:"Name"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/Library/VirtualFolders", c.APIKey, nil, &folders); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(folders))
+	for i, folder := range folders {
+		names[i] = folder.Name
+	}
+	return names, nil
+}
+
+// Logs returns the container logs for debugging.
+func (c *JellyfinContainer) Logs(ctx context.Context) (string, error) {
+	reader, err := c.Container.Logs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get logs: %w", err)
+	}
+	defer reader.Close()
+
+	logs, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("read logs: %w", err)
+	}
+	return string(logs), nil
+}
@@ -0,0 +1,115 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration
+
+package testinfra
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestJellyfinContainer_Integration tests the Jellyfin container lifecycle,
+// including the startup wizard, API key and seed library.
+// This test requires Docker and is skipped in environments without Docker.
+func TestJellyfinContainer_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	SkipIfNoDocker(t)
+
+	// A movie library with one (empty) file, named the way Jellyfin expects
+	movies := filepath.Join(t.TempDir(), "movies")
+	if err := os.MkdirAll(filepath.Join(movies, "Arrival (2016)"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(movies, "Arrival (2016)", "Arrival (2016).mkv"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	jellyfin, err := NewJellyfinContainer(ctx,
+		WithSeedLibrary("Movies", "movies", movies),
+		WithSeedLibrary("Music", "music", ""),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create Jellyfin container: %v", err)
+	}
+	defer CleanupContainer(t, ctx, jellyfin.Container)
+
+	t.Logf("Jellyfin container started at: %s", jellyfin.URL)
+
+	if jellyfin.APIKey == "" || jellyfin.UserID == "" {
+		t.Fatalf("APIKey = %q, UserID = %q, want both set", jellyfin.APIKey, jellyfin.UserID)
+	}
+
+	// The API key must authenticate an admin-only endpoint
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jellyfin.URL+"/System/Info", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Emby-Token", jellyfin.APIKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logs, _ := jellyfin.Logs(ctx)
+		t.Fatalf("Failed to connect to Jellyfin: %v\nContainer logs:\n%s", err, logs)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /System/Info status = %d, want 200", resp.StatusCode)
+	}
+
+	libraries, err := jellyfin.Libraries(ctx)
+	if err != nil {
+		t.Fatalf("Libraries() error = %v", err)
+	}
+	found := map[string]bool{}
+	for _, name := range libraries {
+		found[name] = true
+	}
+	if !found["Movies"] || !found["Music"] {
+		t.Errorf("Libraries() = %v, want Movies and Music", libraries)
+	}
+}
+
+// TestJellyfinContainerOptions tests the option functions.
+func TestJellyfinContainerOptions(t *testing.T) {
+	cfg := &jellyfinConfig{}
+	WithJellyfinImage("jellyfin/jellyfin:10.10.7")(cfg)
+	if cfg.image != "jellyfin/jellyfin:10.10.7" {
+		t.Errorf("WithJellyfinImage: expected jellyfin/jellyfin:10.10.7, got %s", cfg.image)
+	}
+
+	cfg = &jellyfinConfig{}
+	WithJellyfinAdmin("root", "secret")(cfg)
+	if cfg.adminUser != "root" || cfg.adminPassword != "secret" {
+		t.Errorf("WithJellyfinAdmin: expected root/secret, got %s/%s", cfg.adminUser, cfg.adminPassword)
+	}
+
+	cfg = &jellyfinConfig{}
+	WithJellyfinStartTimeout(5 * time.Minute)(cfg)
+	if cfg.startTimeout != 5*time.Minute {
+		t.Errorf("WithJellyfinStartTimeout: expected 5m, got %v", cfg.startTimeout)
+	}
+
+	cfg = &jellyfinConfig{}
+	WithSeedLibrary("Movies", "movies", "/path/to/movies")(cfg)
+	WithSeedLibrary("Shows", "tvshows", "")(cfg)
+	if len(cfg.libraries) != 2 {
+		t.Fatalf("WithSeedLibrary: expected 2 libraries, got %d", len(cfg.libraries))
+	}
+	want := JellyfinLibrary{Name: "Movies", CollectionType: "movies", HostPath: "/path/to/movies"}
+	if cfg.libraries[0] != want {
+		t.Errorf("WithSeedLibrary: expected %+v, got %+v", want, cfg.libraries[0])
+	}
+}
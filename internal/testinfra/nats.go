@@ -0,0 +1,162 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package testinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/tomtom215/cartographus/internal/eventprocessor"
+)
+
+const (
+	// natsHarnessMaxMemory and natsHarnessMaxStore bound the JetStream
+	// resources of a test server
+	natsHarnessMaxMemory = 64 << 20
+	natsHarnessMaxStore  = 256 << 20
+
+	// natsHarnessShutdownTimeout bounds server shutdown during test cleanup
+	natsHarnessShutdownTimeout = 10 * time.Second
+)
+
+// NATSHarness is an embedded NATS JetStream server owned by a single test.
+// It listens on a random localhost port and stores JetStream data in
+// t.TempDir(), so tests can run in parallel without sharing state.
+type NATSHarness struct {
+	// URL is the client connection URL (nats://127.0.0.1:<port>)
+	URL string
+
+	// Server is the underlying embedded server
+	Server *eventprocessor.EmbeddedServer
+
+	t testing.TB
+}
+
+// NewNATSHarness starts an embedded NATS server with the eventprocessor
+// server implementation and shuts it down via t.Cleanup.
+func NewNATSHarness(t testing.TB) *NATSHarness {
+	t.Helper()
+
+	srv, err := eventprocessor.NewEmbeddedServer(&eventprocessor.ServerConfig{
+		Host:              "127.0.0.1",
+		Port:              -1, // random
+		StoreDir:          t.TempDir(),
+		JetStreamMaxMem:   natsHarnessMaxMemory,
+		JetStreamMaxStore: natsHarnessMaxStore,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start embedded NATS server: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), natsHarnessShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			t.Logf("Warning: embedded NATS server shutdown: %v", err)
+		}
+	})
+
+	return &NATSHarness{
+		URL:    srv.ClientURL(),
+		Server: srv,
+		t:      t,
+	}
+}
+
+// Connect opens a client connection to the harness server that is closed
+// via t.Cleanup.
+func (h *NATSHarness) Connect() *natsgo.Conn {
+	h.t.Helper()
+
+	nc, err := natsgo.Connect(h.URL)
+	if err != nil {
+		h.t.Fatalf("Failed to connect to embedded NATS server: %v", err)
+	}
+	h.t.Cleanup(nc.Close)
+	return nc
+}
+
+// EnsureStream creates the default MEDIA_EVENTS stream, sized to fit the
+// harness store limit, so Publisher and DuckDBConsumer can be used as in
+// production.
+func (h *NATSHarness) EnsureStream() {
+	h.t.Helper()
+
+	cfg := eventprocessor.DefaultStreamConfig()
+	cfg.MaxBytes = natsHarnessMaxStore / 4
+	manager, err := eventprocessor.NewStreamManager(h.Connect(), &cfg)
+	if err != nil {
+		h.t.Fatalf("Failed to create stream manager: %v", err)
+	}
+	if _, err := manager.EnsureStream(context.Background()); err != nil {
+		h.t.Fatalf("Failed to create stream %s: %v", cfg.Name, err)
+	}
+}
+
+// Record subscribes to subject (wildcards allowed) and returns a recorder
+// for the messages published on it from now on. The subscription is
+// registered with the server before Record returns, so nothing published
+// afterwards is missed.
+func (h *NATSHarness) Record(subject string) *MessageRecorder {
+	h.t.Helper()
+
+	nc := h.Connect()
+	sub, err := nc.SubscribeSync(subject)
+	if err != nil {
+		h.t.Fatalf("Failed to subscribe to %s: %v", subject, err)
+	}
+	if err := nc.Flush(); err != nil {
+		h.t.Fatalf("Failed to register subscription to %s: %v", subject, err)
+	}
+	return &MessageRecorder{subject: subject, sub: sub, t: h.t}
+}
+
+// MessageRecorder collects the messages published on a subject.
+type MessageRecorder struct {
+	subject string
+	sub     *natsgo.Subscription
+	t       testing.TB
+}
+
+// Await waits for the next n messages and returns them in arrival order.
+// The test fails if they do not all arrive within timeout.
+func (r *MessageRecorder) Await(n int, timeout time.Duration) []*natsgo.Msg {
+	r.t.Helper()
+
+	msgs := make([]*natsgo.Msg, 0, n)
+	deadline := time.Now().Add(timeout)
+	for len(msgs) < n {
+		msg, err := r.sub.NextMsg(time.Until(deadline))
+		if err != nil {
+			r.t.Fatalf("Received %d of %d messages on %s within %v: %v", len(msgs), n, r.subject, timeout, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// Drain returns the messages that have already arrived without waiting
+// for more.
+func (r *MessageRecorder) Drain() []*natsgo.Msg {
+	r.t.Helper()
+
+	var msgs []*natsgo.Msg
+	for {
+		msg, err := r.sub.NextMsg(0)
+		if errors.Is(err, natsgo.ErrTimeout) {
+			return msgs
+		}
+		if err != nil {
+			r.t.Fatalf("Failed to drain messages on %s: %v", r.subject, err)
+		}
+		msgs = append(msgs, msg)
+	}
+}
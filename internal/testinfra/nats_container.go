@@ -0,0 +1,242 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration && nats
+
+package testinfra
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/tomtom215/cartographus/internal/eventprocessor"
+)
+
+const (
+	// DefaultNATSImage is the official NATS server image, matching the
+	// embedded server's minor version
+	DefaultNATSImage = "nats:2.12-alpine"
+
+	// DefaultNATSClientPort is the NATS client port
+	DefaultNATSClientPort = "4222"
+
+	// DefaultNATSMonitorPort is the NATS HTTP monitoring port
+	DefaultNATSMonitorPort = "8222"
+
+	// natsContainerStreamMaxBytes caps the pre-created stream; the
+	// production default of 10GB exceeds what a CI runner can reserve
+	natsContainerStreamMaxBytes = 256 << 20
+)
+
+// NATSContainer represents a running NATS server with JetStream enabled.
+//
+// Unlike NATSHarness, which embeds the server in the test process, it runs
+// the official image, so tests exercise a separately deployed JetStream
+// exactly as production does with NATS_EMBEDDED=false. The MEDIA_EVENTS
+// stream is created at startup unless WithoutNATSStream is given.
+type NATSContainer struct {
+	testcontainers.Container
+
+	// ClientURL is the client connection URL (nats://host:port)
+	ClientURL string
+
+	// MonitorURL is the HTTP monitoring endpoint (/varz, /jsz, ...)
+	MonitorURL string
+
+	// Stream is the configuration of the pre-created stream
+	Stream eventprocessor.StreamConfig
+}
+
+// NATSOption configures the NATS container.
+type NATSOption func(*natsContainerConfig)
+
+type natsContainerConfig struct {
+	image        string
+	stream       eventprocessor.StreamConfig
+	createStream bool
+	startTimeout time.Duration
+}
+
+// WithNATSImage sets the NATS server image.
+func WithNATSImage(image string) NATSOption {
+	return func(c *natsContainerConfig) {
+		c.image = image
+	}
+}
+
+// WithNATSStreamRetention sets the limits of the pre-created stream. Zero
+// keeps the default for maxAge and maxBytes; -1 removes a limit.
+func WithNATSStreamRetention(maxAge time.Duration, maxBytes, maxMsgs int64) NATSOption {
+	return func(c *natsContainerConfig) {
+		if maxAge != 0 {
+			c.stream.MaxAge = maxAge
+		}
+		if maxBytes != 0 {
+			c.stream.MaxBytes = maxBytes
+		}
+		if maxMsgs != 0 {
+			c.stream.MaxMsgs = maxMsgs
+		}
+	}
+}
+
+// WithNATSStreamConfig replaces the pre-created stream's configuration.
+func WithNATSStreamConfig(cfg eventprocessor.StreamConfig) NATSOption {
+	return func(c *natsContainerConfig) {
+		c.stream = cfg
+	}
+}
+
+// WithoutNATSStream starts the server without creating a stream.
+func WithoutNATSStream() NATSOption {
+	return func(c *natsContainerConfig) {
+		c.createStream = false
+	}
+}
+
+// WithNATSStartTimeout sets the timeout for container startup.
+func WithNATSStartTimeout(timeout time.Duration) NATSOption {
+	return func(c *natsContainerConfig) {
+		c.startTimeout = timeout
+	}
+}
+
+// NewNATSContainer starts a NATS server with JetStream and creates the
+// MEDIA_EVENTS stream.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	nats, err := NewNATSContainer(ctx,
+//	    WithNATSStreamRetention(time.Hour, 0, 1000),
+//	)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer nats.Terminate(ctx)
+//
+//	publisher, err := eventprocessor.NewPublisher(eventprocessor.DefaultPublisherConfig(nats.ClientURL), nil)
+func NewNATSContainer(ctx context.Context, opts ...NATSOption) (*NATSContainer, error) {
+	stream := eventprocessor.DefaultStreamConfig()
+	stream.MaxBytes = natsContainerStreamMaxBytes
+	cfg := &natsContainerConfig{
+		image:        DefaultNATSImage,
+		stream:       stream,
+		createStream: true,
+		startTimeout: 2 * time.Minute,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Build container request
+	req := testcontainers.ContainerRequest{
+		Image:        cfg.image,
+		ExposedPorts: []string{DefaultNATSClientPort + "/tcp", DefaultNATSMonitorPort + "/tcp"},
+		Cmd:          []string{"--jetstream", "--store_dir", "/data", "--http_port", DefaultNATSMonitorPort},
+		WaitingFor: wait.ForHTTP("/healthz?js-enabled-only=true").
+			WithPort(DefaultNATSMonitorPort + "/tcp").
+			WithStartupTimeout(cfg.startTimeout),
+	}
+
+	// Start container
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create nats container: %w", err)
+	}
+
+	// Get container host and ports
+	host, err := container.Host(ctx)
+	if err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("get container host: %w", err)
+	}
+
+	clientPort, err := container.MappedPort(ctx, DefaultNATSClientPort)
+	if err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("get mapped client port: %w", err)
+	}
+
+	monitorPort, err := container.MappedPort(ctx, DefaultNATSMonitorPort)
+	if err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("get mapped monitor port: %w", err)
+	}
+
+	c := &NATSContainer{
+		Container:  container,
+		ClientURL:  fmt.Sprintf("nats://%s:%s", host, clientPort.Port()),
+		MonitorURL: fmt.Sprintf("http://%s:%s", host, monitorPort.Port()),
+		Stream:     cfg.stream,
+	}
+
+	if cfg.createStream {
+		if err := c.EnsureStream(ctx, &cfg.stream); err != nil {
+			container.Terminate(ctx) //nolint:errcheck
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Connect opens a client connection to the server. The caller closes it.
+func (c *NATSContainer) Connect() (*natsgo.Conn, error) {
+	nc, err := natsgo.Connect(c.ClientURL)
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats container: %w", err)
+	}
+	return nc, nil
+}
+
+// EnsureStream creates or updates a stream with the eventprocessor stream
+// manager, as the server does at startup.
+func (c *NATSContainer) EnsureStream(ctx context.Context, cfg *eventprocessor.StreamConfig) error {
+	nc, err := c.Connect()
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	manager, err := eventprocessor.NewStreamManager(nc, cfg)
+	if err != nil {
+		return fmt.Errorf("create stream manager: %w", err)
+	}
+	if _, err := manager.EnsureStream(ctx); err != nil {
+		return fmt.Errorf("create stream %s: %w", cfg.Name, err)
+	}
+	return nil
+}
+
+// Terminate stops and removes the NATS container.
+func (c *NATSContainer) Terminate(ctx context.Context) error {
+	return c.Container.Terminate(ctx)
+}
+
+// Logs returns the container logs for debugging.
+func (c *NATSContainer) Logs(ctx context.Context) (string, error) {
+	reader, err := c.Container.Logs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get logs: %w", err)
+	}
+	defer reader.Close()
+
+	logs, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("read logs: %w", err)
+	}
+	return string(logs), nil
+}
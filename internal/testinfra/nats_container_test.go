@@ -0,0 +1,148 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration && nats
+
+package testinfra
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/tomtom215/cartographus/internal/eventprocessor"
+)
+
+// TestNATSContainer_Integration tests the NATS container lifecycle, the
+// pre-created stream and the monitoring endpoint.
+// This test requires Docker and is skipped in environments without Docker.
+func TestNATSContainer_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	SkipIfNoDocker(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	nats, err := NewNATSContainer(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create NATS container: %v", err)
+	}
+	defer CleanupContainer(t, ctx, nats.Container)
+
+	t.Logf("NATS started at: %s", nats.ClientURL)
+
+	nc, err := nats.Connect()
+	if err != nil {
+		logs, _ := nats.Logs(ctx)
+		t.Fatalf("Connect() error = %v\nContainer logs:\n%s", err, logs)
+	}
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		t.Fatalf("jetstream.New() error = %v", err)
+	}
+	stream, err := js.Stream(ctx, "MEDIA_EVENTS")
+	if err != nil {
+		t.Fatalf("MEDIA_EVENTS stream was not created: %v", err)
+	}
+	if got := stream.CachedInfo().Config.MaxBytes; got != natsContainerStreamMaxBytes {
+		t.Errorf("stream MaxBytes = %d, want %d", got, natsContainerStreamMaxBytes)
+	}
+
+	// A second stream can be added after startup
+	extra := eventprocessor.DefaultStreamConfig()
+	extra.Name = "EXTRA"
+	extra.Subjects = []string{"extra.>"}
+	extra.MaxBytes = 1 << 20
+	if err := nats.EnsureStream(ctx, &extra); err != nil {
+		t.Fatalf("EnsureStream() error = %v", err)
+	}
+	if _, err := js.Stream(ctx, "EXTRA"); err != nil {
+		t.Errorf("EXTRA stream was not created: %v", err)
+	}
+
+	resp, err := http.Get(nats.MonitorURL + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /healthz status = %d, want 200", resp.StatusCode)
+	}
+}
+
+// TestNATSContainer_WithoutStream tests that WithoutNATSStream leaves
+// JetStream empty.
+func TestNATSContainer_WithoutStream(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	SkipIfNoDocker(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	nats, err := NewNATSContainer(ctx, WithoutNATSStream())
+	if err != nil {
+		t.Fatalf("Failed to create NATS container: %v", err)
+	}
+	defer CleanupContainer(t, ctx, nats.Container)
+
+	nc, err := nats.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		t.Fatalf("jetstream.New() error = %v", err)
+	}
+	if _, err := js.Stream(ctx, "MEDIA_EVENTS"); !errors.Is(err, jetstream.ErrStreamNotFound) {
+		t.Errorf("Stream(MEDIA_EVENTS) error = %v, want ErrStreamNotFound", err)
+	}
+}
+
+// TestNATSContainerOptions tests the option functions.
+func TestNATSContainerOptions(t *testing.T) {
+	cfg := &natsContainerConfig{stream: eventprocessor.DefaultStreamConfig(), createStream: true}
+
+	WithNATSImage("nats:2.11")(cfg)
+	if cfg.image != "nats:2.11" {
+		t.Errorf("WithNATSImage: expected nats:2.11, got %s", cfg.image)
+	}
+
+	WithNATSStartTimeout(5 * time.Minute)(cfg)
+	if cfg.startTimeout != 5*time.Minute {
+		t.Errorf("WithNATSStartTimeout: expected 5m, got %v", cfg.startTimeout)
+	}
+
+	WithNATSStreamRetention(time.Hour, 0, 500)(cfg)
+	if cfg.stream.MaxAge != time.Hour || cfg.stream.MaxMsgs != 500 {
+		t.Errorf("WithNATSStreamRetention: got age %v, msgs %d", cfg.stream.MaxAge, cfg.stream.MaxMsgs)
+	}
+	if cfg.stream.MaxBytes != eventprocessor.DefaultStreamConfig().MaxBytes {
+		t.Errorf("WithNATSStreamRetention: zero maxBytes changed the limit to %d", cfg.stream.MaxBytes)
+	}
+
+	WithNATSStreamConfig(eventprocessor.StreamConfig{Name: "CUSTOM"})(cfg)
+	if cfg.stream.Name != "CUSTOM" {
+		t.Errorf("WithNATSStreamConfig: got stream %s", cfg.stream.Name)
+	}
+
+	WithoutNATSStream()(cfg)
+	if cfg.createStream {
+		t.Error("WithoutNATSStream: stream would still be created")
+	}
+}
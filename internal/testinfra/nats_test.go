@@ -0,0 +1,108 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build nats
+
+package testinfra
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/eventprocessor"
+)
+
+func TestNATSHarness_RecordAndAwait(t *testing.T) {
+	h := NewNATSHarness(t)
+	recorder := h.Record("test.>")
+
+	nc := h.Connect()
+	for i := 0; i < 3; i++ {
+		if err := nc.Publish(fmt.Sprintf("test.%d", i), []byte{byte(i)}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	msgs := recorder.Await(3, 5*time.Second)
+	for i, msg := range msgs {
+		if want := fmt.Sprintf("test.%d", i); msg.Subject != want || msg.Data[0] != byte(i) {
+			t.Errorf("message %d = %s %v, want %s", i, msg.Subject, msg.Data, want)
+		}
+	}
+	if extra := recorder.Drain(); len(extra) != 0 {
+		t.Errorf("Drain() = %d messages, want none", len(extra))
+	}
+}
+
+func TestNATSHarness_Publisher(t *testing.T) {
+	h := NewNATSHarness(t)
+	h.EnsureStream()
+	recorder := h.Record("playback.>")
+
+	publisher, err := eventprocessor.NewPublisher(eventprocessor.DefaultPublisherConfig(h.URL), nil)
+	if err != nil {
+		t.Fatalf("NewPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+
+	event := eventprocessor.NewMediaEvent(eventprocessor.SourcePlex)
+	event.UserID = 1
+	event.Username = "alice"
+	event.MediaType = eventprocessor.MediaTypeMovie
+	event.Title = "Harness Test"
+	if err := publisher.PublishEvent(context.Background(), event); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+
+	msgs := recorder.Await(1, 5*time.Second)
+	got, err := eventprocessor.DeserializeEvent(msgs[0].Data)
+	if err != nil {
+		t.Fatalf("DeserializeEvent() error = %v", err)
+	}
+	if got.EventID != event.EventID || got.Title != event.Title {
+		t.Errorf("received event %s %q, want %s %q", got.EventID, got.Title, event.EventID, event.Title)
+	}
+}
+
+func TestNATSHarness_AwaitTimeout(t *testing.T) {
+	h := NewNATSHarness(t)
+	recorder := h.Record("quiet")
+
+	// A failing Await must not hang; run it against a recorder whose
+	// test handle records the failure instead of stopping this test.
+	fake := &fatalRecorder{TB: t}
+	recorder.t = fake
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		recorder.Await(1, 100*time.Millisecond)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Await() did not return after its timeout")
+	}
+	if !fake.failed {
+		t.Error("Await() did not fail the test on timeout")
+	}
+}
+
+// fatalRecorder captures Fatalf and stops the calling goroutine like
+// testing.T does
+type fatalRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fatalRecorder) Helper() {}
+
+func (f *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	runtime.Goexit()
+}
@@ -0,0 +1,253 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration
+
+package testinfra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// DefaultPlexMockPort is the port the Plex mock listens on (same as Plex)
+	DefaultPlexMockPort = "32400"
+
+	// DefaultPlexMockToken is the X-Plex-Token the mock accepts
+	DefaultPlexMockToken = "cartographus-plex-mock-token"
+
+	// plexMockFixtureDir is where the mock reads its fixtures in the container
+	plexMockFixtureDir = "/fixtures"
+)
+
+// PlexMockContainer represents a running Plex mock server for testing.
+//
+// The mock is built from testdata/plex and serves canned JSON fixtures for
+// /, /identity, /status/sessions, /library/sections and /transcode/sessions,
+// plus a WebSocket notification stream at /:/websockets/notifications.
+// URL and Token can be passed straight to the Plex clients.
+type PlexMockContainer struct {
+	testcontainers.Container
+	URL   string
+	Token string
+}
+
+// PlexMockOption configures the Plex mock container.
+type PlexMockOption func(*plexMockConfig)
+
+type plexMockConfig struct {
+	token        string
+	fixtures     map[string]string // fixture name -> host path
+	startTimeout time.Duration
+}
+
+// WithPlexMockToken sets the X-Plex-Token the mock accepts.
+func WithPlexMockToken(token string) PlexMockOption {
+	return func(c *plexMockConfig) {
+		c.token = token
+	}
+}
+
+// WithPlexSessionsFixture replaces the default /status/sessions fixture with
+// the JSON file at hostPath. Use SetSessions to change sessions mid-test.
+func WithPlexSessionsFixture(hostPath string) PlexMockOption {
+	return WithPlexFixture("sessions.json", hostPath)
+}
+
+// WithPlexFixture replaces the named fixture (e.g. "library_sections.json")
+// with the JSON file at hostPath.
+func WithPlexFixture(name, hostPath string) PlexMockOption {
+	return func(c *plexMockConfig) {
+		c.fixtures[name] = hostPath
+	}
+}
+
+// WithPlexMockStartTimeout sets the timeout for building and starting the mock.
+func WithPlexMockStartTimeout(timeout time.Duration) PlexMockOption {
+	return func(c *plexMockConfig) {
+		c.startTimeout = timeout
+	}
+}
+
+// NewPlexMockContainer builds and starts the Plex mock server for testing.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	plex, err := NewPlexMockContainer(ctx,
+//	    WithPlexSessionsFixture("testdata/sessions.json"),
+//	)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer plex.Terminate(ctx)
+//
+//	client := sync.NewPlexClient(plex.URL, plex.Token)
+func NewPlexMockContainer(ctx context.Context, opts ...PlexMockOption) (*PlexMockContainer, error) {
+	cfg := &plexMockConfig{
+		token:        DefaultPlexMockToken,
+		fixtures:     map[string]string{},
+		startTimeout: 3 * time.Minute,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	buildContext, err := plexMockBuildContext()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]testcontainers.ContainerFile, 0, len(cfg.fixtures))
+	for name, hostPath := range cfg.fixtures {
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      hostPath,
+			ContainerFilePath: plexMockFixtureDir + "/" + name,
+			FileMode:          0o644,
+		})
+	}
+
+	// Build container request
+	req := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			Context:    buildContext,
+			Dockerfile: "mockserver/Dockerfile",
+			KeepImage:  true,
+		},
+		ExposedPorts: []string{DefaultPlexMockPort + "/tcp"},
+		Env: map[string]string{
+			"PLEX_MOCK_TOKEN":    cfg.token,
+			"PLEX_MOCK_FIXTURES": plexMockFixtureDir,
+		},
+		Files: files,
+		WaitingFor: wait.ForHTTP("/mock/health").
+			WithPort(DefaultPlexMockPort + "/tcp").
+			WithStartupTimeout(cfg.startTimeout),
+	}
+
+	// Start container
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create plex mock container: %w", err)
+	}
+
+	// Get container host and port
+	host, err := container.Host(ctx)
+	if err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("get container host: %w", err)
+	}
+
+	port, err := container.MappedPort(ctx, DefaultPlexMockPort)
+	if err != nil {
+		container.Terminate(ctx) //nolint:errcheck
+		return nil, fmt.Errorf("get mapped port: %w", err)
+	}
+
+	return &PlexMockContainer{
+		Container: container,
+		URL:       fmt.Sprintf("http://%s:%s", host, port.Port()),
+		Token:     cfg.token,
+	}, nil
+}
+
+// plexMockBuildContext returns the testdata/plex directory holding the mock
+// server source, Dockerfile and default fixtures.
+func plexMockBuildContext() (string, error) {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		return "", fmt.Errorf("failed to get caller info")
+	}
+
+	// Navigate from internal/testinfra to testdata/plex
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(filename)))
+	return filepath.Join(projectRoot, "testdata", "plex"), nil
+}
+
+// SetSessions replaces the /status/sessions response. sessions is encoded as
+// JSON and should have the models.PlexSessionsResponse shape.
+func (c *PlexMockContainer) SetSessions(ctx context.Context, sessions interface{}) error {
+	return c.do(ctx, http.MethodPut, "/mock/sessions", sessions, nil)
+}
+
+// Notify sends notification, encoded as JSON, to every connected WebSocket
+// client and returns how many received it. It should have the
+// models.PlexNotificationWrapper shape.
+func (c *PlexMockContainer) Notify(ctx context.Context, notification interface{}) (int, error) {
+	var result struct {
+		Delivered int `json:"delivered"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/mock/notifications", notification, &result); err != nil {
+		return 0, err
+	}
+	return result.Delivered, nil
+}
+
+// do sends a JSON request to one of the mock's control endpoints and decodes
+// the response into out if it is non-nil.
+func (c *PlexMockContainer) do(ctx context.Context, method, path string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode %s body: %w", path, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned status %d: %s", method, path, resp.StatusCode, string(msg))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode %s response: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Terminate stops and removes the Plex mock container.
+func (c *PlexMockContainer) Terminate(ctx context.Context) error {
+	return c.Container.Terminate(ctx)
+}
+
+// Logs returns the container logs for debugging.
+func (c *PlexMockContainer) Logs(ctx context.Context) (string, error) {
+	reader, err := c.Container.Logs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get logs: %w", err)
+	}
+	defer reader.Close()
+
+	logs, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("read logs: %w", err)
+	}
+	return string(logs), nil
+}
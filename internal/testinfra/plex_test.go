@@ -0,0 +1,152 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+//go:build integration
+
+package testinfra
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// getSessionKeys fetches /status/sessions from the mock with token
+func getSessionKeys(ctx context.Context, t *testing.T, plex *PlexMockContainer, token string) (int, []string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, plex.URL+"/status/sessions", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Plex-Token", token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logs, _ := plex.Logs(ctx)
+		t.Fatalf("Failed to connect to Plex mock: %v\nContainer logs:\n%s", err, logs)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+
+	var body struct {
+		MediaContainer struct {
+			Metadata []struct {
+				SessionKey string `json:"sessionKey"`
+			} `json:"Metadata"`
+		} `json:"MediaContainer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode sessions: %v", err)
+	}
+	keys := make([]string, len(body.MediaContainer.Metadata))
+	for i, m := range body.MediaContainer.Metadata {
+		keys[i] = m.SessionKey
+	}
+	return resp.StatusCode, keys
+}
+
+// TestPlexMockContainer_Integration tests the Plex mock lifecycle, token
+// check, custom session fixture and runtime session replacement.
+// This test requires Docker and is skipped in environments without Docker.
+func TestPlexMockContainer_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	SkipIfNoDocker(t)
+
+	fixture := filepath.Join(t.TempDir(), "sessions.json")
+	custom := `{"MediaContainer":{"size":1,"Metadata":[{"sessionKey":"custom-1","type":"movie","title":"Dune"}]}}`
+	if err := os.WriteFile(fixture, []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	plex, err := NewPlexMockContainer(ctx, WithPlexSessionsFixture(fixture))
+	if err != nil {
+		t.Fatalf("Failed to create Plex mock container: %v", err)
+	}
+	defer CleanupContainer(t, ctx, plex.Container)
+
+	t.Logf("Plex mock started at: %s", plex.URL)
+
+	if status, _ := getSessionKeys(ctx, t, plex, "wrong-token"); status != http.StatusUnauthorized {
+		t.Errorf("GET /status/sessions with wrong token status = %d, want 401", status)
+	}
+
+	if _, keys := getSessionKeys(ctx, t, plex, plex.Token); len(keys) != 1 || keys[0] != "custom-1" {
+		t.Errorf("sessions = %v, want the custom fixture", keys)
+	}
+
+	err = plex.SetSessions(ctx, map[string]interface{}{
+		"MediaContainer": map[string]interface{}{"size": 0, "Metadata": []interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("SetSessions() error = %v", err)
+	}
+	if _, keys := getSessionKeys(ctx, t, plex, plex.Token); len(keys) != 0 {
+		t.Errorf("sessions after SetSessions = %v, want none", keys)
+	}
+
+	// Nobody is listening yet
+	delivered, err := plex.Notify(ctx, map[string]interface{}{
+		"NotificationContainer": map[string]interface{}{"type": "status"},
+	})
+	if err != nil || delivered != 0 {
+		t.Errorf("Notify() = %d, %v; want 0 clients", delivered, err)
+	}
+}
+
+// TestPlexMockBuildContext checks that the mock's Dockerfile and default
+// fixtures are where NewPlexMockContainer builds from.
+func TestPlexMockBuildContext(t *testing.T) {
+	dir, err := plexMockBuildContext()
+	if err != nil {
+		t.Fatalf("plexMockBuildContext() error = %v", err)
+	}
+	for _, name := range []string{
+		"mockserver/Dockerfile",
+		"mockserver/main.go",
+		"fixtures/identity.json",
+		"fixtures/sessions.json",
+		"fixtures/library_sections.json",
+		"fixtures/transcode_sessions.json",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("build context is missing %s: %v", name, err)
+		}
+	}
+}
+
+// TestPlexMockContainerOptions tests the option functions.
+func TestPlexMockContainerOptions(t *testing.T) {
+	cfg := &plexMockConfig{fixtures: map[string]string{}}
+	WithPlexMockToken("secret")(cfg)
+	if cfg.token != "secret" {
+		t.Errorf("WithPlexMockToken: expected secret, got %s", cfg.token)
+	}
+
+	WithPlexMockStartTimeout(5 * time.Minute)(cfg)
+	if cfg.startTimeout != 5*time.Minute {
+		t.Errorf("WithPlexMockStartTimeout: expected 5m, got %v", cfg.startTimeout)
+	}
+
+	WithPlexSessionsFixture("/path/to/sessions.json")(cfg)
+	WithPlexFixture("library_sections.json", "/path/to/libraries.json")(cfg)
+	if cfg.fixtures["sessions.json"] != "/path/to/sessions.json" {
+		t.Errorf("WithPlexSessionsFixture: got fixtures %v", cfg.fixtures)
+	}
+	if cfg.fixtures["library_sections.json"] != "/path/to/libraries.json" {
+		t.Errorf("WithPlexFixture: got fixtures %v", cfg.fixtures)
+	}
+}
@@ -0,0 +1,52 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+/*
+Package tracing provides OpenTelemetry distributed tracing.
+
+Tracing follows a request from the HTTP layer through the analytics cache and
+DuckDB, and follows events across NATS from the sync pipeline to the
+consumers, so a slow endpoint shows where its time went.
+
+# Configuration
+
+Tracing uses the standard OpenTelemetry environment variables and exports
+spans over OTLP/HTTP. It is enabled when an endpoint is set:
+
+	OTEL_EXPORTER_OTLP_ENDPOINT=http://otel-collector:4318
+	OTEL_SERVICE_NAME=cartographus           # default: cartographus
+	OTEL_TRACES_SAMPLER=parentbased_traceidratio
+	OTEL_TRACES_SAMPLER_ARG=0.1
+
+OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS,
+OTEL_RESOURCE_ATTRIBUTES, and the other OTLP exporter variables are honored.
+OTEL_SDK_DISABLED=true or OTEL_TRACES_EXPORTER=none turns tracing off.
+
+# Disabled Mode
+
+Without an endpoint, Init installs nothing. Start returns a no-op span after
+a single atomic load, HTTP and NATS instrumentation pass through, and the
+database driver is not wrapped, so disabled tracing costs nothing measurable.
+
+# Spans
+
+  - HTTP server: one span per request, named "METHOD /route/pattern", with
+    the request ID as the request.id attribute
+  - Cache: cache.get and cache.set around analytics cache access
+  - DuckDB: one span per statement, named by SQL operation (SELECT, INSERT,
+    ...), with the returned or affected row count. SQL text is not recorded.
+  - NATS: producer spans on publish and consumer spans on processing, linked
+    through W3C trace context in the Watermill message metadata
+
+# Usage
+
+	ctx, span := tracing.Start(ctx, "recommend.train")
+	defer span.End()
+
+	if err := train(ctx); err != nil {
+	    tracing.RecordError(span, err)
+	}
+*/
+package tracing
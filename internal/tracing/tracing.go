@@ -0,0 +1,169 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// DefaultServiceName is the service.name used when OTEL_SERVICE_NAME is unset.
+const DefaultServiceName = "cartographus"
+
+// instrumentationName identifies this module's tracer.
+const instrumentationName = "github.com/tomtom215/cartographus"
+
+// tracer holds the active tracer, or nil while tracing is disabled. Hot paths
+// check it with a single atomic load.
+var tracer atomic.Pointer[trace.Tracer]
+
+// propagator carries trace context across HTTP headers and message metadata.
+var propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// noopSpan is returned by Start while tracing is disabled.
+var noopSpan trace.Span = noop.Span{}
+
+// Init configures tracing from the standard OTEL_* environment variables.
+// When no OTLP endpoint is configured, tracing stays disabled and the
+// returned shutdown function does nothing. Call shutdown on exit to flush
+// buffered spans.
+func Init(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	noopShutdown := func(context.Context) error { return nil }
+	if !enabledFromEnv() {
+		return noopShutdown, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noopShutdown, fmt.Errorf("create OTLP trace exporter: %w", err)
+	}
+
+	// WithFromEnv applies OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES,
+	// which take precedence over the default service name
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(DefaultServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return noopShutdown, fmt.Errorf("create trace resource: %w", err)
+	}
+
+	// The sampler is read from OTEL_TRACES_SAMPLER (default: parent-based always-on)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		SetTracerProvider(nil)
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// enabledFromEnv reports whether the environment configures a trace exporter.
+func enabledFromEnv() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	if strings.EqualFold(os.Getenv("OTEL_TRACES_EXPORTER"), "none") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// SetTracerProvider enables tracing with provider and registers it as the
+// global OpenTelemetry provider. A nil provider disables tracing. Tests use
+// this with an in-memory span recorder.
+func SetTracerProvider(provider trace.TracerProvider) {
+	if provider == nil {
+		tracer.Store(nil)
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return
+	}
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	t := provider.Tracer(instrumentationName)
+	tracer.Store(&t)
+}
+
+// Enabled reports whether tracing is enabled.
+func Enabled() bool {
+	return tracer.Load() != nil
+}
+
+// Start starts a span as a child of any span in ctx. While tracing is
+// disabled it returns ctx unchanged and a no-op span.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t := tracer.Load()
+	if t == nil {
+		return ctx, noopSpan
+	}
+	return (*t).Start(ctx, name, opts...)
+}
+
+// RecordError records err on span and marks the span as failed.
+func RecordError(span trace.Span, err error) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Inject writes the trace context of ctx into carrier, e.g. HTTP headers or
+// message metadata.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	if !Enabled() {
+		return
+	}
+	propagator.Inject(ctx, carrier)
+}
+
+// Extract returns ctx with the remote trace context found in carrier.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if !Enabled() {
+		return ctx
+	}
+	return propagator.Extract(ctx, carrier)
+}
+
+// InjectMetadata writes the trace context of ctx into message metadata such
+// as Watermill's message.Metadata.
+func InjectMetadata(ctx context.Context, metadata map[string]string) {
+	if metadata == nil {
+		return
+	}
+	Inject(ctx, propagation.MapCarrier(metadata))
+}
+
+// ExtractMetadata returns ctx with the trace context from message metadata.
+func ExtractMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	if metadata == nil {
+		return ctx
+	}
+	return Extract(ctx, propagation.MapCarrier(metadata))
+}
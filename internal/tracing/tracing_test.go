@@ -0,0 +1,150 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// enableRecorder enables tracing with an in-memory recorder for one test.
+func enableRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { SetTracerProvider(nil) })
+	return recorder
+}
+
+func TestEnabledFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"no endpoint", nil, false},
+		{"endpoint", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, true},
+		{"traces endpoint", map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://collector:4318/v1/traces"}, true},
+		{"sdk disabled", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_SDK_DISABLED":           "true",
+		}, false},
+		{"exporter none", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_TRACES_EXPORTER":        "none",
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+				"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER",
+			} {
+				t.Setenv(key, tt.env[key])
+			}
+			if got := enabledFromEnv(); got != tt.want {
+				t.Errorf("enabledFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInit_DisabledWithoutEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	shutdown, err := Init(context.Background())
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if Enabled() {
+		t.Error("tracing enabled without an endpoint")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+}
+
+func TestStart_Disabled(t *testing.T) {
+	SetTracerProvider(nil)
+
+	ctx := context.Background()
+	gotCtx, span := Start(ctx, "op")
+	if gotCtx != ctx {
+		t.Error("Start() changed the context while disabled")
+	}
+	if span.IsRecording() || span.SpanContext().IsValid() {
+		t.Error("Start() returned a live span while disabled")
+	}
+	span.End()
+}
+
+func TestStart_ChildSpan(t *testing.T) {
+	recorder := enableRecorder(t)
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	RecordError(child, errors.New("boom"))
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Name() != "child" || spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Error("child span is not parented to the outer span")
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("child status = %v, want Error", spans[0].Status().Code)
+	}
+}
+
+func TestMetadataPropagation(t *testing.T) {
+	recorder := enableRecorder(t)
+
+	ctx, producer := Start(context.Background(), "publish")
+	metadata := map[string]string{}
+	InjectMetadata(ctx, metadata)
+	producer.End()
+
+	if metadata["traceparent"] == "" {
+		t.Fatal("traceparent not injected into metadata")
+	}
+
+	consumerCtx := ExtractMetadata(context.Background(), metadata)
+	_, consumer := Start(consumerCtx, "process", trace.WithSpanKind(trace.SpanKindConsumer))
+	consumer.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[1].SpanContext().TraceID() != spans[0].SpanContext().TraceID() {
+		t.Error("consumer span is not in the producer's trace")
+	}
+	if spans[1].Parent().SpanID() != spans[0].SpanContext().SpanID() {
+		t.Error("consumer span is not parented to the producer span")
+	}
+}
+
+func TestInjectMetadata_Disabled(t *testing.T) {
+	SetTracerProvider(nil)
+
+	metadata := map[string]string{}
+	InjectMetadata(context.Background(), metadata)
+	InjectMetadata(context.Background(), nil)
+	if len(metadata) != 0 {
+		t.Errorf("metadata = %v, want empty while disabled", metadata)
+	}
+}
@@ -0,0 +1,53 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package validation
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultMaxRangeDays matches the largest days= value the analytics
+// endpoints accept (10 years).
+const DefaultMaxRangeDays = 3650
+
+// DateRange is a start_date/end_date pair checked by the "daterange"
+// struct-level validation: when both ends are set, EndDate must be after
+// StartDate and the range must not exceed MaxRangeDays (0 means no limit).
+// Either end may be nil for open ranges.
+//
+// Embed it in a request struct, or validate it on its own:
+//
+//	rng := DateRange{StartDate: filter.StartDate, EndDate: filter.EndDate, MaxRangeDays: 365}
+//	if err := ValidateStruct(&rng); err != nil {
+//	    // "end_date must be after start_date" or "date range exceeds 365 days"
+//	}
+type DateRange struct {
+	StartDate    *time.Time `json:"start_date,omitempty"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	MaxRangeDays int        `json:"-"`
+}
+
+// validateDateRange is the struct-level validation for DateRange. Errors
+// are reported on end_date with tag "daterange" (order) or "maxrange"
+// (length, param MaxRangeDays).
+func validateDateRange(sl validator.StructLevel) {
+	rng, ok := sl.Current().Interface().(DateRange)
+	if !ok || rng.StartDate == nil || rng.EndDate == nil {
+		return
+	}
+
+	if !rng.EndDate.After(*rng.StartDate) {
+		sl.ReportError(rng.EndDate, "end_date", "EndDate", "daterange", "")
+		return
+	}
+
+	if rng.MaxRangeDays > 0 && rng.EndDate.Sub(*rng.StartDate) > time.Duration(rng.MaxRangeDays)*24*time.Hour {
+		sl.ReportError(rng.EndDate, "end_date", "EndDate", "maxrange", strconv.Itoa(rng.MaxRangeDays))
+	}
+}
@@ -0,0 +1,79 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRangeValidation(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := start.Add(d)
+		return &v
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		rng     DateRange
+		wantTag string
+		wantMsg string
+	}{
+		{"open range", DateRange{StartDate: &start, MaxRangeDays: 365}, "", ""},
+		{"no dates", DateRange{MaxRangeDays: 365}, "", ""},
+		{"valid range", DateRange{StartDate: &start, EndDate: at(30 * day), MaxRangeDays: 365}, "", ""},
+		{"exactly max", DateRange{StartDate: &start, EndDate: at(365 * day), MaxRangeDays: 365}, "", ""},
+		{"no limit", DateRange{StartDate: &start, EndDate: at(5000 * day)}, "", ""},
+		{"end before start", DateRange{StartDate: &start, EndDate: at(-day), MaxRangeDays: 365}, "daterange", "end_date must be after start_date"},
+		{"end equals start", DateRange{StartDate: &start, EndDate: at(0)}, "daterange", "end_date must be after start_date"},
+		{"too long", DateRange{StartDate: &start, EndDate: at(365*day + time.Second), MaxRangeDays: 365}, "maxrange", "date range exceeds 365 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStruct(&tt.rng)
+			if tt.wantTag == "" {
+				if err != nil {
+					t.Errorf("ValidateStruct() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateStruct() error = nil, want error")
+			}
+			got := err.Errors()[0]
+			if got.Tag() != tt.wantTag || got.Field() != "end_date" {
+				t.Errorf("error tag/field = %s/%s, want %s/end_date", got.Tag(), got.Field(), tt.wantTag)
+			}
+			if got.Error() != tt.wantMsg {
+				t.Errorf("message = %q, want %q", got.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestDateRangeValidation_Embedded(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, -1)
+
+	req := struct {
+		DateRange
+		Limit int `validate:"min=1"`
+	}{
+		DateRange: DateRange{StartDate: &start, EndDate: &end},
+		Limit:     10,
+	}
+
+	err := ValidateStruct(&req)
+	if err == nil {
+		t.Fatal("ValidateStruct() error = nil, want the embedded range to be checked")
+	}
+	if apiErr := err.ToAPIError(); apiErr.Message != "end_date must be after start_date" {
+		t.Errorf("ToAPIError().Message = %q", apiErr.Message)
+	}
+}
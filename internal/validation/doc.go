@@ -17,6 +17,8 @@
 //   - Comprehensive error translation to human-readable messages
 //   - APIError conversion matching the application's error format
 //   - Built-in validator support (email, url, latitude, longitude, etc.)
+//   - Custom validators for IP/CIDR lists and cron expressions
+//   - Struct-level date range validation (DateRange)
 //   - Future v11 compatibility with WithRequiredStructEnabled
 //
 // # Quick Start
@@ -34,8 +36,7 @@
 //	    }
 //
 //	    if verr := validation.ValidateStruct(&req); verr != nil {
-//	        apiErr := verr.ToAPIError()
-//	        respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+//	        respondValidationError(w, r, verr)
 //	        return
 //	    }
 //
@@ -67,12 +68,22 @@
 //   - latitude: Valid latitude (-90 to 90)
 //   - longitude: Valid longitude (-180 to 180)
 //
+// Custom validations:
+//   - cidr: IP address or CIDR range (use dive for lists, e.g. IP allowlists)
+//   - cron: 5-field cron expression as accepted by the newsletter scheduler
+//
+// Struct-level validations:
+//   - DateRange: end_date must be after start_date ("daterange") and the
+//     range must not exceed MaxRangeDays ("maxrange"); embed it in a request
+//     struct or validate it directly
+//
 // # Error Types
 //
 // ValidationError represents a single field validation failure:
 //
 //	type ValidationError struct {
 //	    Field()   string      // Struct field name
+//	    Path()    string      // JSON path, e.g. "address.city"
 //	    Tag()     string      // Validation tag that failed
 //	    Param()   string      // Tag parameter (e.g., "100" for max=100)
 //	    Value()   interface{} // Actual value that failed
@@ -85,6 +96,7 @@
 //	    Errors() []ValidationError
 //	    Error()  string           // Combined message
 //	    ToAPIError() *APIError    // Convert to API error format
+//	    ToFieldMap() map[string]string // JSON path -> message, for forms
 //	}
 //
 // # API Error Integration
@@ -110,6 +122,20 @@
 //	    }
 //	}
 //
+// # Form Field Errors
+//
+// ToFieldMap keys each message by the field's JSON path so a frontend can
+// show it next to the matching input. Paths use json tag names (Go names
+// for untagged fields), dots for nested structs and indexes for dive, and
+// embedded structs are flattened; messages omit the field name:
+//
+//	{
+//	    "email": "must be a valid email address",
+//	    "address.city": "is required",
+//	    "items[1].name": "is required",
+//	    "end_date": "must be after start_date"
+//	}
+//
 // # Error Message Translation
 //
 // Human-readable messages are generated for common validation tags:
@@ -123,6 +149,8 @@
 //	oneof=a b  -> "Status must be one of: a b"
 //	latitude   -> "Lat must be a valid latitude (-90 to 90)"
 //	longitude  -> "Lon must be a valid longitude (-180 to 180)"
+//	cidr       -> "TrustedProxies[0] must be a valid IP or CIDR"
+//	cron       -> "CronExpression must be a valid cron expression (...)"
 //
 // # Struct Tag Examples
 //
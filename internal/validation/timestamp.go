@@ -0,0 +1,69 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+// Default bounds for plausible playback start times.
+const (
+	DefaultTimestampMinDate   = "2000-01-01"
+	DefaultTimestampMaxFuture = 24 * time.Hour
+)
+
+// TimestampWindow is the range of plausible playback start times.
+// Events outside the window (typically corrupt epoch values such as 1970 or
+// 2106) are quarantined instead of being inserted into playback_events.
+type TimestampWindow struct {
+	// Min is the earliest accepted started_at.
+	Min time.Time
+
+	// MaxFuture is how far past the current time started_at may be,
+	// allowing for clock skew between media servers.
+	MaxFuture time.Duration
+}
+
+// DefaultTimestampWindow returns the window 2000-01-01 .. now+24h.
+func DefaultTimestampWindow() TimestampWindow {
+	return TimestampWindow{
+		Min:       time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		MaxFuture: DefaultTimestampMaxFuture,
+	}
+}
+
+// NewTimestampWindow builds a window from a YYYY-MM-DD minimum date (UTC)
+// and a maximum future offset. An empty minDate or non-positive maxFuture
+// uses the default.
+func NewTimestampWindow(minDate string, maxFuture time.Duration) (TimestampWindow, error) {
+	if minDate == "" {
+		minDate = DefaultTimestampMinDate
+	}
+	if maxFuture <= 0 {
+		maxFuture = DefaultTimestampMaxFuture
+	}
+
+	minTime, err := time.Parse(time.DateOnly, minDate)
+	if err != nil {
+		return TimestampWindow{}, fmt.Errorf("invalid timestamp minimum date %q (use YYYY-MM-DD): %w", minDate, err)
+	}
+	return TimestampWindow{Min: minTime, MaxFuture: maxFuture}, nil
+}
+
+// Check returns the quarantine reason for startedAt relative to now, or ""
+// if the timestamp is plausible.
+func (w TimestampWindow) Check(startedAt, now time.Time) string {
+	if startedAt.Before(w.Min) {
+		return models.QuarantineReasonTooOld
+	}
+	if startedAt.After(now.Add(w.MaxFuture)) {
+		return models.QuarantineReasonInFuture
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tomtom215/cartographus/internal/models"
+)
+
+func TestNewTimestampWindow(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		w, err := NewTimestampWindow("", 0)
+		if err != nil {
+			t.Fatalf("NewTimestampWindow() error = %v", err)
+		}
+		if w != DefaultTimestampWindow() {
+			t.Errorf("NewTimestampWindow(\"\", 0) = %+v, want %+v", w, DefaultTimestampWindow())
+		}
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		w, err := NewTimestampWindow("2010-06-15", time.Hour)
+		if err != nil {
+			t.Fatalf("NewTimestampWindow() error = %v", err)
+		}
+		if want := time.Date(2010, 6, 15, 0, 0, 0, 0, time.UTC); !w.Min.Equal(want) {
+			t.Errorf("Min = %v, want %v", w.Min, want)
+		}
+		if w.MaxFuture != time.Hour {
+			t.Errorf("MaxFuture = %v, want 1h", w.MaxFuture)
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		if _, err := NewTimestampWindow("01/01/2000", time.Hour); err == nil {
+			t.Error("expected error for non-ISO date")
+		}
+	})
+}
+
+func TestTimestampWindow_Check(t *testing.T) {
+	w := DefaultTimestampWindow()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		startedAt time.Time
+		want      string
+	}{
+		{"unix epoch", time.Unix(0, 0).UTC(), models.QuarantineReasonTooOld},
+		{"zero time", time.Time{}, models.QuarantineReasonTooOld},
+		{"just before min", w.Min.Add(-time.Second), models.QuarantineReasonTooOld},
+		{"exactly min", w.Min, ""},
+		{"recent", now.Add(-time.Hour), ""},
+		{"clock skew within limit", now.Add(23 * time.Hour), ""},
+		{"just past max future", now.Add(w.MaxFuture + time.Second), models.QuarantineReasonInFuture},
+		{"uint32 overflow year 2106", time.Unix(1<<32-1, 0).UTC(), models.QuarantineReasonInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.Check(tt.startedAt, now); got != tt.want {
+				t.Errorf("Check(%v) = %q, want %q", tt.startedAt, got, tt.want)
+			}
+		})
+	}
+}
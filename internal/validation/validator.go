@@ -9,7 +9,8 @@
 //
 // Features:
 //   - Singleton validator instance (thread-safe, caches struct info)
-//   - Custom validators for base64 cursors, RFC3339 dates, bounding box coordinates
+//   - Custom validators for IP/CIDR lists and cron expressions
+//   - Struct-level date range validation (DateRange: order and maximum length)
 //   - Error translation to match existing VALIDATION_ERROR format
 //   - Uses WithRequiredStructEnabled option (v11+ compatibility)
 //
@@ -24,8 +25,7 @@
 //	func handler(w http.ResponseWriter, r *http.Request) {
 //	    req := PlaybacksRequest{...}
 //	    if err := validation.ValidateStruct(&req); err != nil {
-//	        apiErr := err.ToAPIError()
-//	        respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+//	        respondValidationError(w, r, err)
 //	        return
 //	    }
 //	}
@@ -34,10 +34,14 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"net"
+	"reflect"
 	"strings"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/tomtom215/cartographus/internal/newsletter/scheduler"
 )
 
 // singleton validator instance
@@ -49,6 +53,7 @@ var (
 // ValidationError represents a single field validation error with structured information.
 type ValidationError struct {
 	field   string
+	path    string
 	tag     string
 	param   string
 	value   interface{}
@@ -60,6 +65,12 @@ func (e *ValidationError) Field() string {
 	return e.field
 }
 
+// Path returns the field's location in the request's JSON, using json tag
+// names: "email", "address.city", "items[0].name".
+func (e *ValidationError) Path() string {
+	return e.path
+}
+
 // Tag returns the validation tag that failed.
 func (e *ValidationError) Tag() string {
 	return e.tag
@@ -105,6 +116,21 @@ func (ve *RequestValidationError) Error() string {
 	return strings.Join(messages, "; ")
 }
 
+// ToFieldMap maps each failing field's JSON path (see ValidationError.Path)
+// to its message without the field name, for showing errors next to form
+// inputs: {"email": "must be a valid email address"}. When a field fails
+// more than once, the first message is kept.
+func (ve *RequestValidationError) ToFieldMap() map[string]string {
+	fields := make(map[string]string, len(ve.errors))
+	for _, err := range ve.errors {
+		if _, ok := fields[err.path]; ok {
+			continue
+		}
+		fields[err.path] = strings.TrimPrefix(err.message, err.field+" ")
+	}
+	return fields
+}
+
 // APIError represents an error response compatible with the existing API error format.
 // This mirrors the models.APIError structure to avoid import cycles.
 type APIError struct {
@@ -166,8 +192,14 @@ func GetValidator() *validator.Validate {
 	validateOnce.Do(func() {
 		validate = validator.New(validator.WithRequiredStructEnabled())
 
-		// Register custom validators here if needed
-		// The built-in validators cover most needs:
+		// Custom validators. "cidr" replaces the built-in tag of the same
+		// name, which rejects single IPs; allowlists accept both.
+		// RegisterValidation only fails for an empty tag or nil function.
+		_ = validate.RegisterValidation("cidr", validateIPOrCIDR)
+		_ = validate.RegisterValidation("cron", validateCron)
+		validate.RegisterStructValidation(validateDateRange, DateRange{})
+
+		// The built-in validators cover most other needs:
 		// - base64url: validates URL-safe base64 encoding
 		// - datetime: validates date/time format
 		// - latitude, longitude: validates coordinate ranges
@@ -178,6 +210,24 @@ func GetValidator() *validator.Validate {
 	return validate
 }
 
+// validateIPOrCIDR accepts a single IP address ("10.0.0.1") or a CIDR range
+// ("10.0.0.0/8"). Use with dive for lists: validate:"omitempty,dive,cidr".
+func validateIPOrCIDR(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+// validateCron accepts the 5-field cron expressions the newsletter
+// scheduler runs, so a schedule that passes validation can be scheduled.
+func validateCron(fl validator.FieldLevel) bool {
+	_, err := scheduler.ParseCron(fl.Field().String())
+	return err == nil
+}
+
 // ValidateStruct validates a struct using the singleton validator.
 // Returns nil if validation passes, or *RequestValidationError if validation fails.
 //
@@ -185,14 +235,29 @@ func GetValidator() *validator.Validate {
 //
 //	err := ValidateStruct(&request)
 //	if err != nil {
-//	    apiErr := err.ToAPIError()
-//	    respondError(w, http.StatusBadRequest, apiErr.Code, apiErr.Message, nil)
+//	    respondValidationError(w, r, err)
 //	    return
 //	}
 func ValidateStruct(s interface{}) *RequestValidationError {
-	v := GetValidator()
+	return toRequestValidationError(s, GetValidator().Struct(s))
+}
+
+// ValidateFields validates only the named fields of a struct. Use it where
+// the rest of the struct is checked by hand and enforcing every tag would
+// reject requests that are accepted today.
+//
+// Example:
+//
+//	if err := ValidateFields(&req, "CronExpression"); err != nil {
+//	    return err
+//	}
+func ValidateFields(s interface{}, fields ...string) *RequestValidationError {
+	return toRequestValidationError(s, GetValidator().StructPartial(s, fields...))
+}
 
-	err := v.Struct(s)
+// toRequestValidationError converts a validator error for struct s to
+// *RequestValidationError.
+func toRequestValidationError(s interface{}, err error) *RequestValidationError {
 	if err == nil {
 		return nil
 	}
@@ -205,6 +270,7 @@ func ValidateStruct(s interface{}) *RequestValidationError {
 			errors: []ValidationError{
 				{
 					field:   "unknown",
+					path:    "unknown",
 					tag:     "unknown",
 					message: err.Error(),
 				},
@@ -212,10 +278,12 @@ func ValidateStruct(s interface{}) *RequestValidationError {
 		}
 	}
 
+	root := reflect.TypeOf(s)
 	fieldErrors := make([]ValidationError, len(validationErrs))
 	for i, fieldErr := range validationErrs {
 		fieldErrors[i] = ValidationError{
 			field:   fieldErr.Field(),
+			path:    jsonPath(root, fieldErr.StructNamespace()),
 			tag:     fieldErr.Tag(),
 			param:   fieldErr.Param(),
 			value:   fieldErr.Value(),
@@ -226,6 +294,65 @@ func ValidateStruct(s interface{}) *RequestValidationError {
 	return &RequestValidationError{errors: fieldErrors}
 }
 
+// jsonPath converts a validator struct namespace ("Request.Address.City",
+// "Request.Items[0].Name") to the JSON path of the field under root
+// ("address.city", "items[0].name"). Fields without a json tag keep their
+// Go name and embedded structs are flattened, as encoding/json does.
+func jsonPath(root reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) < 2 {
+		return namespace
+	}
+
+	t := root
+	path := make([]string, 0, len(segments)-1)
+	// The first segment is the root type's name
+	for _, segment := range segments[1:] {
+		name, index := segment, ""
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			name, index = segment[:i], segment[i:]
+		}
+
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		var field reflect.StructField
+		found := false
+		if t != nil && t.Kind() == reflect.Struct {
+			field, found = t.FieldByName(name)
+		}
+		if !found {
+			// Unknown type from here on; keep the remaining names as-is
+			t = nil
+			path = append(path, segment)
+			continue
+		}
+
+		t = field.Type
+		for n := strings.Count(index, "["); n > 0 && t != nil; n-- {
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				t = nil
+			}
+		}
+
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch {
+		case jsonName == "" && field.Anonymous && index == "":
+			continue // embedded struct: its fields are inlined
+		case jsonName == "" || jsonName == "-":
+			jsonName = field.Name
+		}
+		path = append(path, jsonName+index)
+	}
+	return strings.Join(path, ".")
+}
+
 // errorMessageTemplates maps validation tags to message templates.
 // Templates use %s for field name and %p for parameter value.
 var errorMessageTemplates = map[string]string{
@@ -236,6 +363,9 @@ var errorMessageTemplates = map[string]string{
 	"base64":    "%s must be valid base64 encoded",
 	"latitude":  "%s must be a valid latitude (-90 to 90)",
 	"longitude": "%s must be a valid longitude (-180 to 180)",
+	"cidr":      "%s must be a valid IP or CIDR",
+	"cron":      "%s must be a valid cron expression (minute hour day-of-month month day-of-week)",
+	"daterange": "%s must be after start_date",
 }
 
 // errorMessageWithParam maps validation tags to templates that include param.
@@ -245,6 +375,9 @@ var errorMessageWithParam = map[string]string{
 	"lte":   "%s must be less than or equal to %s",
 	"gt":    "%s must be greater than %s",
 	"lt":    "%s must be less than %s",
+
+	// Struct-level, reported on end_date; the message names the range
+	"maxrange": "date range exceeds %[2]s days",
 }
 
 // translateError converts a validator.FieldError to a human-readable message.
@@ -7,6 +7,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 )
 
 // ===================================================================================================
@@ -240,6 +241,74 @@ func TestToAPIError_MultipleErrors(t *testing.T) {
 	}
 }
 
+type fieldMapAddress struct {
+	City    string `json:"city" validate:"required"`
+	Country string `json:"country_code" validate:"len=2"`
+}
+
+type fieldMapItem struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type fieldMapRequest struct {
+	DateRange
+	Email    string           `json:"email" validate:"required,email"`
+	Nickname string           `validate:"max=5"`
+	Address  *fieldMapAddress `json:"address,omitempty" validate:"required"`
+	Items    []fieldMapItem   `json:"items" validate:"dive"`
+	Tags     []string         `json:"tags" validate:"dive,min=2"`
+}
+
+func TestToFieldMap(t *testing.T) {
+	start := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, -1)
+	req := fieldMapRequest{
+		DateRange: DateRange{StartDate: &start, EndDate: &end},
+		Email:     "not-an-email",
+		Nickname:  "much too long",
+		Address:   &fieldMapAddress{Country: "USA"},
+		Items:     []fieldMapItem{{Name: "ok"}, {}},
+		Tags:      []string{"ok", "x"},
+	}
+
+	err := ValidateStruct(&req)
+	if err == nil {
+		t.Fatal("ValidateStruct() error = nil, want errors")
+	}
+
+	want := map[string]string{
+		"end_date":             "must be after start_date",
+		"email":                "must be a valid email address",
+		"Nickname":             "must be at most 5 characters",
+		"address.city":         "is required",
+		"address.country_code": "failed len validation",
+		"items[1].name":        "is required",
+		"tags[1]":              "must be at least 2 characters",
+	}
+	got := err.ToFieldMap()
+	if len(got) != len(want) {
+		t.Errorf("ToFieldMap() = %v, want %d entries", got, len(want))
+	}
+	for path, msg := range want {
+		if got[path] != msg {
+			t.Errorf("ToFieldMap()[%q] = %q, want %q", path, got[path], msg)
+		}
+	}
+}
+
+func TestToFieldMap_ValidateFields(t *testing.T) {
+	req := fieldMapRequest{Email: "bad", Address: &fieldMapAddress{}}
+
+	err := ValidateFields(&req, "Email", "Address.City")
+	if err == nil {
+		t.Fatal("ValidateFields() error = nil, want errors")
+	}
+	got := err.ToFieldMap()
+	if got["email"] == "" || got["address.city"] == "" || len(got) != 2 {
+		t.Errorf("ToFieldMap() = %v, want email and address.city", got)
+	}
+}
+
 // ===================================================================================================
 // Custom Validator Tests - Base64 Cursor
 // ===================================================================================================
@@ -546,6 +615,80 @@ func TestRangeValidation_Invalid(t *testing.T) {
 	}
 }
 
+// ===================================================================================================
+// CIDR/IP List and Cron Validation Tests
+// ===================================================================================================
+
+type NetworkScheduleStruct struct {
+	TrustedProxies []string `validate:"omitempty,dive,cidr"`
+	Schedule       string   `validate:"omitempty,cron"`
+}
+
+func TestCIDRValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+		wantErr bool
+	}{
+		{"empty list", nil, false},
+		{"ipv4 address", []string{"192.168.1.1"}, false},
+		{"ipv4 cidr", []string{"10.0.0.0/8", "127.0.0.1"}, false},
+		{"ipv6", []string{"::1", "fd00::/8"}, false},
+		{"hostname", []string{"proxy.local"}, true},
+		{"bad prefix", []string{"10.0.0.0/33"}, true},
+		{"one bad entry", []string{"10.0.0.1", "10.0.0"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStruct(&NetworkScheduleStruct{TrustedProxies: tt.proxies})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateStruct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !containsSubstring(err.Error(), "must be a valid IP or CIDR") {
+				t.Errorf("error = %q, want friendly CIDR message", err.Error())
+			}
+		})
+	}
+}
+
+func TestCronValidation(t *testing.T) {
+	tests := []struct {
+		schedule string
+		wantErr  bool
+	}{
+		{"0 9 * * 1", false},
+		{"*/15 * * * *", false},
+		{"0 0 1 1-6/2 0,6", false},
+		{"0 9 * *", true},
+		{"60 9 * * *", true},
+		{"@daily", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			err := ValidateStruct(&NetworkScheduleStruct{Schedule: tt.schedule})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateStruct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Errors()[0].Tag() != "cron" {
+				t.Errorf("tag = %q, want cron", err.Errors()[0].Tag())
+			}
+		})
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	input := NetworkScheduleStruct{TrustedProxies: []string{"bad"}, Schedule: "0 9 * * *"}
+
+	if err := ValidateFields(&input, "Schedule"); err != nil {
+		t.Errorf("ValidateFields(Schedule) error = %v, want other fields ignored", err)
+	}
+	if err := ValidateFields(&input, "TrustedProxies"); err == nil {
+		t.Error("ValidateFields(TrustedProxies) should fail for an invalid entry")
+	}
+}
+
 // ===================================================================================================
 // Error Message Translation Tests
 // ===================================================================================================
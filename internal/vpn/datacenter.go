@@ -0,0 +1,286 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package vpn
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"strings"
+)
+
+// Connection types of a client address, stored in
+// playback_events.connection_type.
+const (
+	// ConnectionTypeResidential is a public address that is neither a
+	// known VPN nor in a datacenter range.
+	ConnectionTypeResidential = "residential"
+
+	// ConnectionTypeVPN is a known VPN server address or range.
+	ConnectionTypeVPN = "vpn"
+
+	// ConnectionTypeDatacenter is an address in a hosting provider's range.
+	ConnectionTypeDatacenter = "datacenter"
+
+	// ConnectionTypeUnknown is a missing, invalid or non-public address.
+	ConnectionTypeUnknown = "unknown"
+)
+
+// DefaultDatacenterProvider labels datacenter ranges listed without a
+// provider name.
+const DefaultDatacenterProvider = "hosting"
+
+// ErrNoDatacenterRanges is returned for datacenter data without any valid
+// range, which would otherwise wipe the dataset.
+var ErrNoDatacenterRanges = errors.New("datacenter data contains no valid ranges")
+
+// DatacenterRange is an address range of a hosting provider.
+type DatacenterRange struct {
+	CIDR     string `json:"cidr"`
+	Provider string `json:"provider"`
+}
+
+// DatacenterResult is the result of a datacenter lookup.
+type DatacenterResult struct {
+	// IsDatacenter indicates the address is in a hosting provider's range.
+	IsDatacenter bool `json:"is_datacenter"`
+
+	// Provider is the hosting provider (e.g., "Amazon AWS").
+	Provider string `json:"provider,omitempty"`
+
+	// MatchedRange is the most specific range containing the address.
+	MatchedRange string `json:"matched_range,omitempty"`
+}
+
+// DatacenterImportResult contains the result of importing datacenter data.
+type DatacenterImportResult struct {
+	// Ranges is the number of ranges imported.
+	Ranges int `json:"ranges"`
+
+	// Skipped is the number of lines that were not valid ranges.
+	Skipped int `json:"skipped"`
+
+	// Source is the file or URL the data came from.
+	Source string `json:"source"`
+}
+
+// ParseDatacenterRanges reads a datacenter range list. Each line is one of:
+//
+//	203.0.113.0/24
+//	203.0.113.0/24,Provider Name
+//	203.0.113.0,203.0.113.255,Provider Name[,URL]
+//
+// The last form is the ipcat datacenters.csv format; start-end ranges are
+// split into CIDR prefixes. Blank lines and lines starting with # are
+// ignored. Lines that are not valid ranges, or ranges broader than /8
+// (IPv4) or /16 (IPv6), are counted in skipped. Returns
+// ErrNoDatacenterRanges if no line is a valid range.
+func ParseDatacenterRanges(r io.Reader) (ranges []DatacenterRange, skipped int, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parsed, ok := parseDatacenterLine(line)
+		if !ok {
+			skipped++
+			continue
+		}
+		ranges = append(ranges, parsed...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read datacenter data: %w", err)
+	}
+	if len(ranges) == 0 {
+		return nil, skipped, ErrNoDatacenterRanges
+	}
+	return ranges, skipped, nil
+}
+
+// parseDatacenterLine parses one line of a datacenter range list.
+func parseDatacenterLine(line string) ([]DatacenterRange, bool) {
+	fields := strings.Split(line, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	var prefixes []netip.Prefix
+	provider := ""
+	if strings.Contains(fields[0], "/") {
+		prefix, err := parseRange(fields[0])
+		if err != nil {
+			return nil, false
+		}
+		prefixes = []netip.Prefix{prefix}
+		if len(fields) > 1 {
+			provider = fields[1]
+		}
+	} else {
+		if len(fields) < 2 {
+			return nil, false
+		}
+		start, err1 := netip.ParseAddr(fields[0])
+		end, err2 := netip.ParseAddr(fields[1])
+		if err1 != nil || err2 != nil || start.Is4() != end.Is4() || start.Compare(end) > 0 {
+			return nil, false
+		}
+		for _, prefix := range rangeToPrefixes(start.Unmap(), end.Unmap()) {
+			if _, err := parseRange(prefix.String()); err != nil {
+				return nil, false
+			}
+			prefixes = append(prefixes, prefix)
+		}
+		if len(fields) > 2 {
+			provider = fields[2]
+		}
+	}
+
+	if provider == "" {
+		provider = DefaultDatacenterProvider
+	}
+	ranges := make([]DatacenterRange, len(prefixes))
+	for i, prefix := range prefixes {
+		ranges[i] = DatacenterRange{CIDR: prefix.String(), Provider: provider}
+	}
+	return ranges, true
+}
+
+// rangeToPrefixes returns the fewest CIDR prefixes covering start to end
+// inclusive. Both addresses must be of the same family.
+func rangeToPrefixes(start, end netip.Addr) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for start.Compare(end) <= 0 {
+		// Widen the prefix while it still starts at start and ends by end
+		bits := start.BitLen()
+		for bits > 0 {
+			wider := netip.PrefixFrom(start, bits-1).Masked()
+			if wider.Addr() != start || lastAddr(wider).Compare(end) > 0 {
+				break
+			}
+			bits--
+		}
+		prefix := netip.PrefixFrom(start, bits)
+		prefixes = append(prefixes, prefix)
+
+		next := lastAddr(prefix).Next()
+		if !next.IsValid() {
+			break // The range ends at the last address of the family
+		}
+		start = next
+	}
+	return prefixes
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := addrBits(prefix.Addr())
+	for i := prefix.Bits(); i < prefix.Addr().BitLen(); i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	if prefix.Addr().Is4() {
+		return netip.AddrFrom4([4]byte{b[0], b[1], b[2], b[3]})
+	}
+	return netip.AddrFrom16(b)
+}
+
+// SetDatacenterRanges replaces the datacenter ranges. Invalid ranges are
+// skipped. Lookups see either the old or the new ranges, never a mix.
+func (l *Lookup) SetDatacenterRanges(ranges []DatacenterRange) {
+	ipv4, ipv6 := newPrefixTrie(), newPrefixTrie()
+	count := 0
+	for _, r := range ranges {
+		prefix, err := parseRange(r.CIDR)
+		if err != nil {
+			continue
+		}
+		entry := &rangeEntry{
+			prefix: prefix,
+			info:   serverInfo{provider: r.Provider},
+			rng:    Range{CIDR: prefix.String(), Provider: r.Provider},
+		}
+		if prefix.Addr().Is4() {
+			ipv4.insert(prefix, entry)
+		} else {
+			ipv6.insert(prefix, entry)
+		}
+		count++
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dcIPv4, l.dcIPv6 = ipv4, ipv6
+	l.dcCount = count
+	l.stats.DatacenterRanges = count
+}
+
+// LookupDatacenter checks if an IP address is in a datacenter range.
+func (l *Lookup) LookupDatacenter(ip string) *DatacenterResult {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return &DatacenterResult{}
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entry := l.matchDatacenter(addr)
+	if entry == nil {
+		return &DatacenterResult{}
+	}
+	return &DatacenterResult{
+		IsDatacenter: true,
+		Provider:     entry.info.provider,
+		MatchedRange: entry.rng.CIDR,
+	}
+}
+
+// matchDatacenter returns the most specific datacenter range containing
+// addr, or nil. Must be called with mu held.
+func (l *Lookup) matchDatacenter(addr netip.Addr) *rangeEntry {
+	addr = addr.Unmap()
+	if addr.Is4() {
+		return l.dcIPv4.match(addr)
+	}
+	return l.dcIPv6.match(addr)
+}
+
+// ConnectionType classifies a client address as ConnectionTypeVPN,
+// ConnectionTypeDatacenter, ConnectionTypeResidential or
+// ConnectionTypeUnknown. VPN takes precedence: most VPN servers are hosted
+// in datacenters, and the VPN is the more specific signal.
+func (l *Lookup) ConnectionType(ip string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return ConnectionTypeUnknown
+	}
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return ConnectionTypeUnknown
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var vpn bool
+	if addr.Is4() {
+		_, vpn = l.ipv4Map[addr]
+	} else {
+		_, vpn = l.ipv6Map[addr]
+	}
+	switch {
+	case vpn || l.matchRange(addr) != nil:
+		return ConnectionTypeVPN
+	case l.matchDatacenter(addr) != nil:
+		return ConnectionTypeDatacenter
+	default:
+		return ConnectionTypeResidential
+	}
+}
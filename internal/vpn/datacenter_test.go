@@ -0,0 +1,208 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package vpn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDatacenterRanges(t *testing.T) {
+	data := `# Datacenter ranges
+203.0.113.0/24
+198.51.100.7/28, Example Cloud
+
+192.0.2.0,192.0.2.255,Amazon AWS,http://aws.amazon.com
+192.0.2.0,192.0.2.2,Tiny Host
+2001:db8::/32,IPv6 Host
+not-an-address
+10.0.0.0/4,Too Broad
+192.0.2.9,192.0.2.1,Reversed
+`
+	ranges, skipped, err := ParseDatacenterRanges(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseDatacenterRanges() error = %v", err)
+	}
+	want := []DatacenterRange{
+		{CIDR: "203.0.113.0/24", Provider: DefaultDatacenterProvider},
+		{CIDR: "198.51.100.0/28", Provider: "Example Cloud"},
+		{CIDR: "192.0.2.0/24", Provider: "Amazon AWS"},
+		{CIDR: "192.0.2.0/31", Provider: "Tiny Host"},
+		{CIDR: "192.0.2.2/32", Provider: "Tiny Host"},
+		{CIDR: "2001:db8::/32", Provider: "IPv6 Host"},
+	}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("ranges = %+v\nwant %+v", ranges, want)
+	}
+	if skipped != 3 {
+		t.Errorf("skipped = %d, want 3", skipped)
+	}
+
+	if _, _, err := ParseDatacenterRanges(strings.NewReader("# nothing\nbogus\n")); !errors.Is(err, ErrNoDatacenterRanges) {
+		t.Errorf("ParseDatacenterRanges(empty) error = %v, want ErrNoDatacenterRanges", err)
+	}
+}
+
+func TestRangeToPrefixes(t *testing.T) {
+	tests := []struct {
+		start, end string
+		want       []string
+	}{
+		{"192.0.2.0", "192.0.2.0", []string{"192.0.2.0/32"}},
+		{"192.0.2.0", "192.0.3.255", []string{"192.0.2.0/23"}},
+		{"192.0.2.1", "192.0.2.6", []string{"192.0.2.1/32", "192.0.2.2/31", "192.0.2.4/31", "192.0.2.6/32"}},
+		{"255.255.255.254", "255.255.255.255", []string{"255.255.255.254/31"}},
+		{"2001:db8::", "2001:db8::ffff", []string{"2001:db8::/112"}},
+	}
+	for _, tt := range tests {
+		got := rangeToPrefixes(netip.MustParseAddr(tt.start), netip.MustParseAddr(tt.end))
+		gotStrings := make([]string, len(got))
+		for i, p := range got {
+			gotStrings[i] = p.String()
+		}
+		if !reflect.DeepEqual(gotStrings, tt.want) {
+			t.Errorf("rangeToPrefixes(%s, %s) = %v, want %v", tt.start, tt.end, gotStrings, tt.want)
+		}
+	}
+}
+
+func TestLookup_ConnectionType(t *testing.T) {
+	lookup := NewLookup()
+	lookup.SetDatacenterRanges([]DatacenterRange{
+		{CIDR: "203.0.113.0/24", Provider: "Example Cloud"},
+		{CIDR: "203.0.113.128/25", Provider: "Example Cloud East"},
+	})
+	if err := lookup.AddServer(&Server{Provider: "nordvpn", IPs: []string{"203.0.113.50"}}); err != nil {
+		t.Fatalf("AddServer() error = %v", err)
+	}
+	if err := lookup.AddRange(&Range{CIDR: "198.51.100.0/24", Provider: "mullvad"}); err != nil {
+		t.Fatalf("AddRange() error = %v", err)
+	}
+
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"203.0.113.7", ConnectionTypeDatacenter},
+		{"::ffff:203.0.113.7", ConnectionTypeDatacenter},
+		{"203.0.113.50", ConnectionTypeVPN}, // VPN server inside a datacenter range
+		{"198.51.100.1", ConnectionTypeVPN},
+		{"8.8.4.4", ConnectionTypeResidential},
+		{"192.168.1.10", ConnectionTypeUnknown},
+		{"127.0.0.1", ConnectionTypeUnknown},
+		{"", ConnectionTypeUnknown},
+		{"not-an-ip", ConnectionTypeUnknown},
+	}
+	for _, tt := range tests {
+		if got := lookup.ConnectionType(tt.ip); got != tt.want {
+			t.Errorf("ConnectionType(%q) = %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+
+	// The most specific range wins
+	result := lookup.LookupDatacenter("203.0.113.200")
+	if !result.IsDatacenter || result.Provider != "Example Cloud East" || result.MatchedRange != "203.0.113.128/25" {
+		t.Errorf("LookupDatacenter() = %+v", result)
+	}
+
+	// Server updates keep the datacenter ranges; Clear() drops them
+	lookup.ClearServers()
+	if lookup.ConnectionType("203.0.113.50") != ConnectionTypeDatacenter || lookup.GetStats().DatacenterRanges != 2 {
+		t.Error("datacenter ranges lost after ClearServers()")
+	}
+	lookup.Clear()
+	if lookup.LookupDatacenter("203.0.113.7").IsDatacenter || lookup.GetStats().DatacenterRanges != 0 {
+		t.Error("datacenter ranges kept after Clear()")
+	}
+}
+
+func TestService_ConnectionType_Disabled(t *testing.T) {
+	service, _ := NewService(nil, &Config{Enabled: false})
+	service.lookup.SetDatacenterRanges([]DatacenterRange{{CIDR: "203.0.113.0/24", Provider: "Example Cloud"}})
+
+	if got := service.ConnectionType("203.0.113.7"); got != ConnectionTypeUnknown {
+		t.Errorf("ConnectionType() = %q, want %q", got, ConnectionTypeUnknown)
+	}
+	if service.IsDatacenter("203.0.113.7") {
+		t.Error("IsDatacenter() true while disabled")
+	}
+}
+
+func TestDuckDBStore_DatacenterRanges(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store := NewDuckDBStore(db)
+	ctx := context.Background()
+	if err := store.InitSchema(ctx); err != nil {
+		t.Fatalf("InitSchema() error = %v", err)
+	}
+	svc := &Service{config: DefaultConfig(), lookup: NewLookup(), store: store}
+
+	result, err := svc.ImportDatacenterFromReader(ctx, strings.NewReader("203.0.113.0/24,Example Cloud\n203.0.113.0/24,Example Cloud\n2001:db8::/32\nbogus\n"), "test")
+	if err != nil {
+		t.Fatalf("ImportDatacenterFromReader() error = %v", err)
+	}
+	if result.Ranges != 3 || result.Skipped != 1 {
+		t.Errorf("result = %+v, want 3 ranges and 1 skipped", result)
+	}
+	if !svc.IsDatacenter("2001:db8::1") {
+		t.Error("IsDatacenter() false after import")
+	}
+
+	// A failed import keeps the previous ranges
+	if _, err := svc.ImportDatacenterFromReader(ctx, strings.NewReader("bogus\n"), "test"); !errors.Is(err, ErrNoDatacenterRanges) {
+		t.Errorf("ImportDatacenterFromReader(empty) error = %v, want ErrNoDatacenterRanges", err)
+	}
+
+	// A fresh lookup loads the ranges from the database
+	lookup := NewLookup()
+	if err := store.LoadIntoLookup(ctx, lookup); err != nil {
+		t.Fatalf("LoadIntoLookup() error = %v", err)
+	}
+	if got := lookup.LookupDatacenter("203.0.113.9").Provider; got != "Example Cloud" {
+		t.Errorf("LookupDatacenter().Provider = %q, want Example Cloud", got)
+	}
+	stored, err := store.ListDatacenterRanges(ctx)
+	if err != nil || len(stored) != 2 {
+		t.Errorf("ListDatacenterRanges() = %+v, %v; want 2 ranges", stored, err)
+	}
+}
+
+func TestUpdater_UpdateDatacenter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("192.0.2.0,192.0.2.255,Amazon AWS,http://aws.amazon.com\n"))
+	}))
+	defer server.Close()
+
+	lookup := NewLookup()
+	service := &Service{config: DefaultConfig(), lookup: lookup, importer: NewImporter(lookup)}
+	config := DefaultUpdaterConfig()
+	updater := NewUpdater(service, config)
+
+	// Nothing to do without a URL
+	if result, err := updater.UpdateDatacenter(context.Background()); result != nil || err != nil {
+		t.Errorf("UpdateDatacenter() without URL = %v, %v; want nil, nil", result, err)
+	}
+
+	config.DatacenterURL = server.URL
+	result, err := updater.UpdateDatacenter(context.Background())
+	if err != nil {
+		t.Fatalf("UpdateDatacenter() error = %v", err)
+	}
+	if result.Ranges != 1 || result.Source != server.URL {
+		t.Errorf("result = %+v", result)
+	}
+	if got := service.LookupDatacenter("192.0.2.10").Provider; got != "Amazon AWS" {
+		t.Errorf("LookupDatacenter().Provider = %q, want Amazon AWS", got)
+	}
+}
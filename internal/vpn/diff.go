@@ -0,0 +1,67 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package vpn
+
+import (
+	"net/netip"
+	"sort"
+)
+
+// datasetDiff lists the changes between the stored VPN dataset and a new
+// one.
+type datasetDiff struct {
+	// upserts are addresses that are new, or now belong to another server.
+	upserts map[netip.Addr]serverInfo
+
+	// removed are addresses that are no longer listed.
+	removed []netip.Addr
+
+	added   int
+	changed int
+
+	// providers are the providers with added, changed or removed
+	// addresses, sorted.
+	providers []string
+}
+
+// diffDatasets compares the stored dataset with the next one.
+func diffDatasets(stored, next map[netip.Addr]serverInfo) *datasetDiff {
+	diff := &datasetDiff{upserts: make(map[netip.Addr]serverInfo)}
+	touched := make(map[string]struct{})
+
+	for addr, info := range next {
+		old, ok := stored[addr]
+		switch {
+		case !ok:
+			diff.added++
+		case old != info:
+			diff.changed++
+			touched[old.provider] = struct{}{}
+		default:
+			continue
+		}
+		diff.upserts[addr] = info
+		touched[info.provider] = struct{}{}
+	}
+	for addr, info := range stored {
+		if _, ok := next[addr]; !ok {
+			diff.removed = append(diff.removed, addr)
+			touched[info.provider] = struct{}{}
+		}
+	}
+
+	diff.providers = make([]string, 0, len(touched))
+	for provider := range touched {
+		diff.providers = append(diff.providers, provider)
+	}
+	sort.Strings(diff.providers)
+	return diff
+}
+
+// empty reports whether the datasets hold the same addresses.
+func (d *datasetDiff) empty() bool {
+	return len(d.upserts) == 0 && len(d.removed) == 0
+}
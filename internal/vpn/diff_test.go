@@ -0,0 +1,48 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package vpn
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestDiffDatasets(t *testing.T) {
+	a := netip.MustParseAddr("192.0.2.1")
+	b := netip.MustParseAddr("192.0.2.2")
+	c := netip.MustParseAddr("2001:db8::3")
+	d := netip.MustParseAddr("192.0.2.4")
+
+	stored := map[netip.Addr]serverInfo{
+		a: {provider: "mullvad", country: "Sweden"},
+		b: {provider: "mullvad", country: "Sweden"},
+		c: {provider: "ivpn", country: "Germany"},
+	}
+	next := map[netip.Addr]serverInfo{
+		a: {provider: "mullvad", country: "Sweden"},  // Unchanged
+		b: {provider: "nordvpn", country: "Norway"},  // Moved to another provider
+		d: {provider: "nordvpn", country: "Finland"}, // New
+	}
+
+	diff := diffDatasets(stored, next)
+	if diff.added != 1 || diff.changed != 1 || len(diff.removed) != 1 {
+		t.Fatalf("added, changed, removed = %d, %d, %d; want 1, 1, 1", diff.added, diff.changed, len(diff.removed))
+	}
+	if diff.removed[0] != c {
+		t.Errorf("removed = %v, want %v", diff.removed, c)
+	}
+	if _, ok := diff.upserts[a]; ok || len(diff.upserts) != 2 {
+		t.Errorf("upserts = %v, want the changed and the new address", diff.upserts)
+	}
+	if want := []string{"ivpn", "mullvad", "nordvpn"}; !reflect.DeepEqual(diff.providers, want) {
+		t.Errorf("providers = %v, want %v", diff.providers, want)
+	}
+
+	if !diffDatasets(next, next).empty() {
+		t.Error("diff of identical datasets is not empty")
+	}
+}
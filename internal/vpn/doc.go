@@ -96,6 +96,45 @@
 //	    }
 //	}
 //
+// # Updates
+//
+// Updater refreshes the dataset from the gluetun repository every
+// VPN_UPDATE_INTERVAL when VPN_AUTO_UPDATE is set, and on demand through
+// POST /api/v1/admin/vpn/update. Requests carry If-None-Match and
+// If-Modified-Since, so an unchanged list is not downloaded again. A new
+// list is parsed into a staged lookup and diffed against the stored data;
+// only added, removed and changed IPs are written, in one transaction,
+// before the in-memory lookup is swapped. A failed download or an empty
+// list leaves the current dataset in place. Every run is recorded in
+// vpn_update_history.
+//
+// # CIDR Ranges
+//
+// Providers rotate egress addresses within ranges they operate, so the
+// lookup also matches CIDR ranges, added by administrators through
+// /api/v1/admin/vpn/ranges or by other data sources. An address that is not
+// listed exactly matches the longest range containing it, with
+// MatchType "cidr", the MatchedRange and a confidence of 90; exact matches
+// report MatchType "exact" and a confidence of 100. Ranges are stored in
+// vpn_ranges and are kept when the server dataset is imported or updated.
+// Ranges broader than /8 (IPv4) or /16 (IPv6) are rejected.
+//
+// # Datacenter Ranges
+//
+// Hosting provider ranges are kept apart from VPN data: a stream from a
+// rented server is suspicious in a different way than one through a
+// consumer VPN. They are imported from VPN_DATACENTER_FILE at startup and
+// from VPN_DATACENTER_URL after each scheduled update, either as plain
+// CIDR lists or in ipcat's datacenters.csv format (see
+// ParseDatacenterRanges), and stored in datacenter_ranges. An import
+// replaces all ranges; a failed or empty one keeps the current ranges.
+//
+// ConnectionType combines both signals into "vpn", "datacenter",
+// "residential" or "unknown". VPN takes precedence, as most VPN servers are
+// themselves in datacenters. The database tags playback events with it at
+// ingest, and the DatacenterStreamDetector alerts on datacenter addresses
+// that are not known VPNs.
+//
 // # Performance
 //
 // The lookup system uses hash maps for O(1) exact IP matching:
@@ -103,11 +142,14 @@
 //   - Typical memory usage: ~2MB per 10,000 IPs
 //   - Lookup time: <1 microsecond per IP
 //
+// Addresses not in the maps are matched against a binary trie per address
+// family, in at most 32 (IPv4) or 128 (IPv6) steps. With 100,000 ranges
+// loaded a lookup still takes well under a microsecond
+// (BenchmarkLookupIP_100kRanges).
+//
 // # Future Enhancements
 //
 // Planned features:
-//   - CIDR range support for more comprehensive coverage
-//   - Automatic updates from gluetun repository
 //   - Additional data sources (IP2Proxy, ipapi.is)
 //   - ASN-based detection for VPN provider networks
 package vpn
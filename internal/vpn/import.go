@@ -56,8 +56,8 @@ func (i *Importer) ImportFromReader(r io.Reader) (*ImportResult, error) {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Clear existing data before import
-	i.lookup.Clear()
+	// Clear existing server data before import; CIDR ranges are kept
+	i.lookup.ClearServers()
 
 	for providerName, rawProvider := range rawData {
 		// Skip the root "version" field if present
@@ -135,8 +135,8 @@ func (i *Importer) ImportFromBytes(data []byte) (*ImportResult, error) {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Clear existing data before import
-	i.lookup.Clear()
+	// Clear existing server data before import; CIDR ranges are kept
+	i.lookup.ClearServers()
 
 	for providerName, rawProvider := range rawData {
 		// Skip the root "version" field if present
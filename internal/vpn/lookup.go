@@ -13,6 +13,8 @@ import (
 // Lookup provides efficient VPN IP address lookup.
 // It uses a map-based approach for exact IP matches, which provides O(1) lookup
 // time for the common case of individual IP addresses in the VPN database.
+// Addresses that are not listed exactly are matched against CIDR ranges by
+// longest prefix, using one binary trie per address family.
 type Lookup struct {
 	// ipv4Map stores IPv4 address to server info mapping.
 	ipv4Map map[netip.Addr]*serverInfo
@@ -20,6 +22,18 @@ type Lookup struct {
 	// ipv6Map stores IPv6 address to server info mapping.
 	ipv6Map map[netip.Addr]*serverInfo
 
+	// ipv4Ranges and ipv6Ranges hold the CIDR ranges for longest-prefix
+	// matching; ranges indexes the same entries by prefix.
+	ipv4Ranges *prefixTrie
+	ipv6Ranges *prefixTrie
+	ranges     map[netip.Prefix]*rangeEntry
+
+	// dcIPv4 and dcIPv6 hold the datacenter ranges, a separate signal from
+	// VPN ranges; dcCount is the number of datacenter ranges.
+	dcIPv4  *prefixTrie
+	dcIPv6  *prefixTrie
+	dcCount int
+
 	// providers maps provider name to provider metadata.
 	providers map[string]*Provider
 
@@ -42,9 +56,14 @@ type serverInfo struct {
 // NewLookup creates a new VPN IP lookup service.
 func NewLookup() *Lookup {
 	return &Lookup{
-		ipv4Map:   make(map[netip.Addr]*serverInfo),
-		ipv6Map:   make(map[netip.Addr]*serverInfo),
-		providers: make(map[string]*Provider),
+		ipv4Map:    make(map[netip.Addr]*serverInfo),
+		ipv6Map:    make(map[netip.Addr]*serverInfo),
+		ipv4Ranges: newPrefixTrie(),
+		ipv6Ranges: newPrefixTrie(),
+		ranges:     make(map[netip.Prefix]*rangeEntry),
+		dcIPv4:     newPrefixTrie(),
+		dcIPv6:     newPrefixTrie(),
+		providers:  make(map[string]*Provider),
 		stats: &Stats{
 			ProviderStats: make([]Provider, 0),
 		},
@@ -53,6 +72,7 @@ func NewLookup() *Lookup {
 
 // LookupIP checks if an IP address belongs to a known VPN provider.
 // Returns a LookupResult with VPN details if found, or a negative result if not.
+// An exact match takes precedence over a CIDR range containing the address.
 func (l *Lookup) LookupIP(ipStr string) *LookupResult {
 	addr, err := netip.ParseAddr(ipStr)
 	if err != nil {
@@ -72,7 +92,20 @@ func (l *Lookup) LookupIP(ipStr string) *LookupResult {
 	}
 
 	if !found {
-		return &LookupResult{IsVPN: false, Confidence: 100}
+		entry := l.matchRange(addr)
+		if entry == nil {
+			return &LookupResult{IsVPN: false, Confidence: 100}
+		}
+		return &LookupResult{
+			IsVPN:               true,
+			Provider:            entry.info.provider,
+			ProviderDisplayName: GetDisplayName(entry.info.provider),
+			ServerCountry:       entry.info.country,
+			ServerCity:          entry.info.city,
+			Confidence:          cidrMatchConfidence,
+			MatchType:           MatchTypeCIDR,
+			MatchedRange:        entry.rng.CIDR,
+		}
 	}
 
 	return &LookupResult{
@@ -83,6 +116,7 @@ func (l *Lookup) LookupIP(ipStr string) *LookupResult {
 		ServerCity:          info.city,
 		ServerHostname:      info.hostname,
 		Confidence:          100, // Exact IP match
+		MatchType:           MatchTypeExact,
 	}
 }
 
@@ -179,16 +213,39 @@ func (l *Lookup) ListProviders() []Provider {
 	return providers
 }
 
-// Clear removes all data from the lookup database.
+// Clear removes all data from the lookup database, including CIDR and
+// datacenter ranges.
 func (l *Lookup) Clear() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	l.ipv4Ranges = newPrefixTrie()
+	l.ipv6Ranges = newPrefixTrie()
+	l.ranges = make(map[netip.Prefix]*rangeEntry)
+	l.dcIPv4 = newPrefixTrie()
+	l.dcIPv6 = newPrefixTrie()
+	l.dcCount = 0
+	l.resetServers()
+}
+
+// ClearServers removes all server addresses and providers but keeps the
+// CIDR and datacenter ranges, which are maintained separately from the
+// server list.
+func (l *Lookup) ClearServers() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.resetServers()
+}
+
+// resetServers resets the server data. Must be called with mu held.
+func (l *Lookup) resetServers() {
 	l.ipv4Map = make(map[netip.Addr]*serverInfo)
 	l.ipv6Map = make(map[netip.Addr]*serverInfo)
 	l.providers = make(map[string]*Provider)
 	l.stats = &Stats{
-		ProviderStats: make([]Provider, 0),
+		ProviderStats:    make([]Provider, 0),
+		TotalRanges:      len(l.ranges),
+		DatacenterRanges: l.dcCount,
 	}
 }
 
@@ -199,7 +256,8 @@ func (l *Lookup) Count() int {
 	return len(l.ipv4Map) + len(l.ipv6Map)
 }
 
-// ContainsIP checks if an IP is in the VPN database without returning full details.
+// ContainsIP checks if an IP is in the VPN database, listed exactly or in a
+// CIDR range, without returning full details.
 // This is a faster check when only the boolean result is needed.
 func (l *Lookup) ContainsIP(ipStr string) bool {
 	addr, err := netip.ParseAddr(ipStr)
@@ -210,13 +268,46 @@ func (l *Lookup) ContainsIP(ipStr string) bool {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
+	var found bool
 	if addr.Is4() {
-		_, found := l.ipv4Map[addr]
-		return found
+		_, found = l.ipv4Map[addr]
+	} else if addr.Is6() {
+		_, found = l.ipv6Map[addr]
 	}
-	if addr.Is6() {
-		_, found := l.ipv6Map[addr]
-		return found
+	return found || l.matchRange(addr) != nil
+}
+
+// snapshot returns a copy of the address-to-server mapping, IPv4 and IPv6
+// together.
+func (l *Lookup) snapshot() map[netip.Addr]serverInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make(map[netip.Addr]serverInfo, len(l.ipv4Map)+len(l.ipv6Map))
+	for addr, info := range l.ipv4Map {
+		entries[addr] = *info
+	}
+	for addr, info := range l.ipv6Map {
+		entries[addr] = *info
 	}
-	return false
+	return entries
+}
+
+// replace swaps in the exact addresses and providers of other, which must
+// not be used afterwards. CIDR and datacenter ranges are kept: they are
+// maintained separately from the server list. Lookups see either the old or
+// the new data, never a mix.
+func (l *Lookup) replace(other *Lookup) {
+	other.mu.RLock()
+	ipv4Map, ipv6Map, providers, stats := other.ipv4Map, other.ipv6Map, other.providers, other.stats
+	other.mu.RUnlock()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ipv4Map = ipv4Map
+	l.ipv6Map = ipv6Map
+	l.providers = providers
+	stats.TotalRanges = len(l.ranges)
+	stats.DatacenterRanges = l.dcCount
+	l.stats = stats
 }
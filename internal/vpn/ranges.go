@@ -0,0 +1,247 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package vpn
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// Match types reported in LookupResult.MatchType.
+const (
+	MatchTypeExact = "exact"
+	MatchTypeCIDR  = "cidr"
+)
+
+// RangeSourceManual is the Source of ranges added by an administrator.
+const RangeSourceManual = "manual"
+
+// Minimum prefix lengths of a range. Broader ranges would flag large parts
+// of the internet as VPN traffic.
+const (
+	MinIPv4RangeBits = 8
+	MinIPv6RangeBits = 16
+)
+
+// cidrMatchConfidence is the confidence of a CIDR match: the provider
+// operates the range, but this address was not listed as a server.
+const cidrMatchConfidence = 90
+
+var (
+	// ErrInvalidRange is returned for a range that is not a valid CIDR
+	// prefix, is broader than the minimum prefix length, or has no provider.
+	ErrInvalidRange = errors.New("invalid VPN range")
+
+	// ErrRangeNotFound is returned when removing a range that does not exist.
+	ErrRangeNotFound = errors.New("VPN range not found")
+)
+
+// rangeEntry is a CIDR range held by the lookup.
+type rangeEntry struct {
+	prefix netip.Prefix
+	info   serverInfo
+	rng    Range
+}
+
+// parseRange parses and validates a CIDR prefix. The host bits are
+// cleared, so "203.0.113.7/24" is stored as "203.0.113.0/24".
+func parseRange(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: %v", ErrInvalidRange, err)
+	}
+	if prefix.Addr().Is4In6() {
+		return netip.Prefix{}, fmt.Errorf("%w: use the IPv4 form of %s", ErrInvalidRange, cidr)
+	}
+	minBits := MinIPv4RangeBits
+	if prefix.Addr().Is6() {
+		minBits = MinIPv6RangeBits
+	}
+	if prefix.Bits() < minBits {
+		return netip.Prefix{}, fmt.Errorf("%w: %s is broader than /%d", ErrInvalidRange, cidr, minBits)
+	}
+	return prefix.Masked(), nil
+}
+
+// prefixTrie is a binary trie over address bits for longest-prefix
+// matching. Nodes live in one slice and refer to their children by index,
+// which keeps large range sets compact and lookups allocation-free.
+type prefixTrie struct {
+	nodes []trieNode // nodes[0] is the root
+}
+
+// trieNode is a trie node. A child index of 0 means no child, since the
+// root is never a child.
+type trieNode struct {
+	child [2]uint32
+	entry *rangeEntry
+}
+
+func newPrefixTrie() *prefixTrie {
+	return &prefixTrie{nodes: make([]trieNode, 1)}
+}
+
+// addrBits returns the address bytes, IPv4 in the first four.
+func addrBits(addr netip.Addr) [16]byte {
+	if addr.Is4() {
+		var b [16]byte
+		a4 := addr.As4()
+		copy(b[:], a4[:])
+		return b
+	}
+	return addr.As16()
+}
+
+// bit returns bit i of b, most significant first.
+func bit(b *[16]byte, i int) int {
+	return int(b[i/8]>>(7-i%8)) & 1
+}
+
+// insert adds or replaces the entry of a prefix.
+func (t *prefixTrie) insert(prefix netip.Prefix, entry *rangeEntry) {
+	b := addrBits(prefix.Addr())
+	n := uint32(0)
+	for i := 0; i < prefix.Bits(); i++ {
+		dir := bit(&b, i)
+		next := t.nodes[n].child[dir]
+		if next == 0 {
+			t.nodes = append(t.nodes, trieNode{})
+			next = uint32(len(t.nodes) - 1)
+			t.nodes[n].child[dir] = next
+		}
+		n = next
+	}
+	t.nodes[n].entry = entry
+}
+
+// remove deletes the entry of a prefix. Its nodes are kept; they are
+// reused if the prefix is added again.
+func (t *prefixTrie) remove(prefix netip.Prefix) {
+	b := addrBits(prefix.Addr())
+	n := uint32(0)
+	for i := 0; i < prefix.Bits(); i++ {
+		if n = t.nodes[n].child[bit(&b, i)]; n == 0 {
+			return
+		}
+	}
+	t.nodes[n].entry = nil
+}
+
+// match returns the entry of the longest prefix containing addr, or nil.
+func (t *prefixTrie) match(addr netip.Addr) *rangeEntry {
+	b := addrBits(addr)
+	best := t.nodes[0].entry
+	n := uint32(0)
+	for i := 0; i < addr.BitLen(); i++ {
+		if n = t.nodes[n].child[bit(&b, i)]; n == 0 {
+			break
+		}
+		if e := t.nodes[n].entry; e != nil {
+			best = e
+		}
+	}
+	return best
+}
+
+// AddRange adds or replaces a CIDR range. Its Provider, Country and City
+// are reported for addresses in the range that are not listed exactly.
+func (l *Lookup) AddRange(r *Range) error {
+	prefix, err := parseRange(r.CIDR)
+	if err != nil {
+		return err
+	}
+	if r.Provider == "" {
+		return fmt.Errorf("%w: provider is required", ErrInvalidRange)
+	}
+
+	entry := &rangeEntry{
+		prefix: prefix,
+		info:   serverInfo{provider: r.Provider, country: r.Country, city: r.City},
+		rng:    *r,
+	}
+	entry.rng.CIDR = prefix.String()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if prefix.Addr().Is4() {
+		l.ipv4Ranges.insert(prefix, entry)
+	} else {
+		l.ipv6Ranges.insert(prefix, entry)
+	}
+	l.ranges[prefix] = entry
+	l.stats.TotalRanges = len(l.ranges)
+	return nil
+}
+
+// RemoveRange removes a CIDR range. Returns ErrRangeNotFound if it does
+// not exist.
+func (l *Lookup) RemoveRange(cidr string) error {
+	prefix, err := parseRange(cidr)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.ranges[prefix]; !ok {
+		return ErrRangeNotFound
+	}
+	if prefix.Addr().Is4() {
+		l.ipv4Ranges.remove(prefix)
+	} else {
+		l.ipv6Ranges.remove(prefix)
+	}
+	delete(l.ranges, prefix)
+	l.stats.TotalRanges = len(l.ranges)
+	return nil
+}
+
+// Ranges returns the CIDR ranges, IPv4 first, ordered by address.
+func (l *Lookup) Ranges() []Range {
+	l.mu.RLock()
+	entries := make([]*rangeEntry, 0, len(l.ranges))
+	for _, entry := range l.ranges {
+		entries = append(entries, entry)
+	}
+	l.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].prefix, entries[j].prefix
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c < 0
+		}
+		return a.Bits() < b.Bits()
+	})
+
+	ranges := make([]Range, len(entries))
+	for i, entry := range entries {
+		ranges[i] = entry.rng
+	}
+	return ranges
+}
+
+// RangeCount returns the number of CIDR ranges.
+func (l *Lookup) RangeCount() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.ranges)
+}
+
+// matchRange returns the longest range containing addr, or nil. IPv4
+// addresses in IPv6 notation (::ffff:a.b.c.d) match IPv4 ranges. Must be
+// called with mu held.
+func (l *Lookup) matchRange(addr netip.Addr) *rangeEntry {
+	addr = addr.Unmap()
+	if addr.Is4() {
+		return l.ipv4Ranges.match(addr)
+	}
+	return l.ipv6Ranges.match(addr)
+}
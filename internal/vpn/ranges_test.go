@@ -0,0 +1,338 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+package vpn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		want    string
+		wantErr bool
+	}{
+		{cidr: "198.51.100.0/24", want: "198.51.100.0/24"},
+		{cidr: " 203.0.113.77/24 ", want: "203.0.113.0/24"},
+		{cidr: "2001:db8:abcd::1/48", want: "2001:db8:abcd::/48"},
+		{cidr: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{cidr: "10.0.0.0/7", wantErr: true},
+		{cidr: "2001::/15", wantErr: true},
+		{cidr: "::ffff:198.51.100.0/120", wantErr: true},
+		{cidr: "198.51.100.1", wantErr: true},
+		{cidr: "not-a-cidr", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			got, err := parseRange(tt.cidr)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRange) {
+					t.Fatalf("parseRange(%q) error = %v, want ErrInvalidRange", tt.cidr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRange(%q) error = %v", tt.cidr, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("parseRange(%q) = %s, want %s", tt.cidr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup_LookupIP_Range(t *testing.T) {
+	lookup := NewLookup()
+	ranges := []Range{
+		{CIDR: "198.51.0.0/16", Provider: "mullvad", Country: "Sweden"},
+		{CIDR: "198.51.100.0/24", Provider: "nordvpn", Country: "Netherlands", City: "Amsterdam"},
+		{CIDR: "2001:db8::/32", Provider: "protonvpn"},
+	}
+	for i := range ranges {
+		if err := lookup.AddRange(&ranges[i]); err != nil {
+			t.Fatalf("AddRange(%s) error = %v", ranges[i].CIDR, err)
+		}
+	}
+	if err := lookup.AddServer(&Server{Provider: "expressvpn", Country: "Germany", IPs: []string{"198.51.100.10"}}); err != nil {
+		t.Fatalf("AddServer() error = %v", err)
+	}
+
+	tests := []struct {
+		ip           string
+		wantVPN      bool
+		wantProvider string
+		wantMatch    string
+		wantRange    string
+	}{
+		{ip: "198.51.100.10", wantVPN: true, wantProvider: "expressvpn", wantMatch: MatchTypeExact},
+		{ip: "198.51.100.11", wantVPN: true, wantProvider: "nordvpn", wantMatch: MatchTypeCIDR, wantRange: "198.51.100.0/24"},
+		{ip: "198.51.7.1", wantVPN: true, wantProvider: "mullvad", wantMatch: MatchTypeCIDR, wantRange: "198.51.0.0/16"},
+		{ip: "::ffff:198.51.100.11", wantVPN: true, wantProvider: "nordvpn", wantMatch: MatchTypeCIDR, wantRange: "198.51.100.0/24"},
+		{ip: "2001:db8:1::5", wantVPN: true, wantProvider: "protonvpn", wantMatch: MatchTypeCIDR, wantRange: "2001:db8::/32"},
+		{ip: "198.52.0.1"},
+		{ip: "2001:db9::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			result := lookup.LookupIP(tt.ip)
+			if result.IsVPN != tt.wantVPN {
+				t.Fatalf("IsVPN = %v, want %v", result.IsVPN, tt.wantVPN)
+			}
+			if lookup.ContainsIP(tt.ip) != tt.wantVPN {
+				t.Errorf("ContainsIP = %v, want %v", !tt.wantVPN, tt.wantVPN)
+			}
+			if !tt.wantVPN {
+				return
+			}
+			if result.Provider != tt.wantProvider {
+				t.Errorf("Provider = %s, want %s", result.Provider, tt.wantProvider)
+			}
+			if result.MatchType != tt.wantMatch {
+				t.Errorf("MatchType = %s, want %s", result.MatchType, tt.wantMatch)
+			}
+			if result.MatchedRange != tt.wantRange {
+				t.Errorf("MatchedRange = %s, want %s", result.MatchedRange, tt.wantRange)
+			}
+			wantConfidence := 100
+			if tt.wantMatch == MatchTypeCIDR {
+				wantConfidence = cidrMatchConfidence
+			}
+			if result.Confidence != wantConfidence {
+				t.Errorf("Confidence = %d, want %d", result.Confidence, wantConfidence)
+			}
+		})
+	}
+}
+
+func TestLookup_RemoveRange(t *testing.T) {
+	lookup := NewLookup()
+	for _, r := range []Range{
+		{CIDR: "198.51.0.0/16", Provider: "mullvad"},
+		{CIDR: "198.51.100.0/24", Provider: "nordvpn"},
+	} {
+		if err := lookup.AddRange(&r); err != nil {
+			t.Fatalf("AddRange() error = %v", err)
+		}
+	}
+
+	if err := lookup.RemoveRange("198.51.100.5/24"); err != nil {
+		t.Fatalf("RemoveRange() error = %v", err)
+	}
+	if got := lookup.LookupIP("198.51.100.1").Provider; got != "mullvad" {
+		t.Errorf("Provider after removing /24 = %s, want mullvad", got)
+	}
+	if err := lookup.RemoveRange("198.51.100.0/24"); !errors.Is(err, ErrRangeNotFound) {
+		t.Errorf("RemoveRange() twice error = %v, want ErrRangeNotFound", err)
+	}
+	if lookup.RangeCount() != 1 || lookup.GetStats().TotalRanges != 1 {
+		t.Errorf("RangeCount = %d, TotalRanges = %d, want 1", lookup.RangeCount(), lookup.GetStats().TotalRanges)
+	}
+
+	// Re-adding reuses the trie nodes of the removed range
+	if err := lookup.AddRange(&Range{CIDR: "198.51.100.0/24", Provider: "nordvpn"}); err != nil {
+		t.Fatalf("AddRange() error = %v", err)
+	}
+	if got := lookup.LookupIP("198.51.100.1").Provider; got != "nordvpn" {
+		t.Errorf("Provider after re-adding /24 = %s, want nordvpn", got)
+	}
+}
+
+func TestLookup_AddRange_Invalid(t *testing.T) {
+	lookup := NewLookup()
+	for _, r := range []Range{
+		{CIDR: "198.51.100.0/24"},
+		{CIDR: "0.0.0.0/0", Provider: "nordvpn"},
+		{CIDR: "bogus", Provider: "nordvpn"},
+	} {
+		if err := lookup.AddRange(&r); !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("AddRange(%+v) error = %v, want ErrInvalidRange", r, err)
+		}
+	}
+	if lookup.RangeCount() != 0 {
+		t.Errorf("RangeCount = %d, want 0", lookup.RangeCount())
+	}
+}
+
+func TestLookup_Ranges_Sorted(t *testing.T) {
+	lookup := NewLookup()
+	for _, cidr := range []string{"2001:db8::/32", "203.0.113.0/24", "198.51.100.0/24", "198.51.0.0/16"} {
+		if err := lookup.AddRange(&Range{CIDR: cidr, Provider: "nordvpn"}); err != nil {
+			t.Fatalf("AddRange(%s) error = %v", cidr, err)
+		}
+	}
+
+	want := []string{"198.51.0.0/16", "198.51.100.0/24", "203.0.113.0/24", "2001:db8::/32"}
+	got := lookup.Ranges()
+	if len(got) != len(want) {
+		t.Fatalf("Ranges() returned %d ranges, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].CIDR != want[i] {
+			t.Errorf("Ranges()[%d] = %s, want %s", i, got[i].CIDR, want[i])
+		}
+	}
+}
+
+func TestLookup_RangesSurviveServerUpdates(t *testing.T) {
+	lookup := NewLookup()
+	if err := lookup.AddRange(&Range{CIDR: "198.51.100.0/24", Provider: "nordvpn"}); err != nil {
+		t.Fatalf("AddRange() error = %v", err)
+	}
+
+	// Imports clear the server data only
+	if _, err := NewImporter(lookup).ImportFromBytes([]byte(`{"mullvad": {"version": 1, "servers": [{"ips": ["203.0.113.1"]}]}}`)); err != nil {
+		t.Fatalf("ImportFromBytes() error = %v", err)
+	}
+	if !lookup.ContainsIP("198.51.100.1") {
+		t.Error("range lost after import")
+	}
+
+	// Dataset updates replace the server data only
+	staged := NewLookup()
+	if err := staged.AddServer(&Server{Provider: "mullvad", IPs: []string{"203.0.113.2"}}); err != nil {
+		t.Fatalf("AddServer() error = %v", err)
+	}
+	lookup.replace(staged)
+	if !lookup.ContainsIP("198.51.100.1") || lookup.GetStats().TotalRanges != 1 {
+		t.Error("range lost after dataset update")
+	}
+
+	lookup.Clear()
+	if lookup.ContainsIP("198.51.100.1") {
+		t.Error("range kept after Clear()")
+	}
+}
+
+func TestDuckDBStore_Ranges(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	store := NewDuckDBStore(db)
+	ctx := context.Background()
+	if err := store.InitSchema(ctx); err != nil {
+		t.Fatalf("InitSchema() error = %v", err)
+	}
+
+	svc := &Service{config: DefaultConfig(), lookup: NewLookup(), store: store}
+	added, err := svc.AddRange(ctx, Range{CIDR: "198.51.100.9/24", Provider: " NordVPN ", Country: "Netherlands", AddedBy: "admin"})
+	if err != nil {
+		t.Fatalf("AddRange() error = %v", err)
+	}
+	if added.CIDR != "198.51.100.0/24" || added.Provider != "nordvpn" || added.Source != RangeSourceManual {
+		t.Errorf("AddRange() = %+v, want normalized range", added)
+	}
+	if _, err := svc.AddRange(ctx, Range{CIDR: "2001:db8::/32", Provider: "mullvad", Source: "custom-feed"}); err != nil {
+		t.Fatalf("AddRange() error = %v", err)
+	}
+
+	// A fresh lookup loads the ranges from the database
+	lookup := NewLookup()
+	if err := store.LoadIntoLookup(ctx, lookup); err != nil {
+		t.Fatalf("LoadIntoLookup() error = %v", err)
+	}
+	result := lookup.LookupIP("198.51.100.200")
+	if result.Provider != "nordvpn" || result.ServerCountry != "Netherlands" || result.MatchType != MatchTypeCIDR {
+		t.Errorf("LookupIP() = %+v, want nordvpn CIDR match", result)
+	}
+	ranges := lookup.Ranges()
+	if len(ranges) != 2 || ranges[0].AddedBy != "admin" || ranges[1].Source != "custom-feed" {
+		t.Errorf("Ranges() = %+v", ranges)
+	}
+
+	// Clearing the server data keeps the ranges
+	if err := store.Clear(ctx); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if stored, err := store.ListRanges(ctx); err != nil || len(stored) != 2 {
+		t.Errorf("ListRanges() after Clear() = %d ranges, %v; want 2", len(stored), err)
+	}
+
+	if err := svc.RemoveRange(ctx, "198.51.100.0/24"); err != nil {
+		t.Fatalf("RemoveRange() error = %v", err)
+	}
+	if err := svc.RemoveRange(ctx, "198.51.100.0/24"); !errors.Is(err, ErrRangeNotFound) {
+		t.Errorf("RemoveRange() twice error = %v, want ErrRangeNotFound", err)
+	}
+	if stored, err := store.ListRanges(ctx); err != nil || len(stored) != 1 {
+		t.Errorf("ListRanges() after RemoveRange() = %d ranges, %v; want 1", len(stored), err)
+	}
+	if svc.IsVPN("198.51.100.1") {
+		t.Error("IsVPN() true for removed range")
+	}
+}
+
+// benchmarkLookup returns a lookup with 100k exact server addresses and
+// numRanges ranges, alternating IPv4 /24 and IPv6 /64.
+func benchmarkLookup(b *testing.B, numRanges int) *Lookup {
+	b.Helper()
+	lookup := NewLookup()
+
+	ips := make([]string, 0, 100_000)
+	for i := 0; i < 100_000; i++ {
+		ips = append(ips, fmt.Sprintf("10.%d.%d.%d", i>>16&0xff, i>>8&0xff, i&0xff))
+	}
+	if err := lookup.AddServer(&Server{Provider: "nordvpn", IPs: ips}); err != nil {
+		b.Fatalf("AddServer() error = %v", err)
+	}
+
+	for i := 0; i < numRanges; i++ {
+		var cidr string
+		if i%2 == 0 {
+			cidr = fmt.Sprintf("%d.%d.%d.0/24", 100+i>>16&0x3f, i>>8&0xff, i&0xff)
+		} else {
+			cidr = fmt.Sprintf("2001:db8:%x:%x::/64", i>>16, i&0xffff)
+		}
+		if err := lookup.AddRange(&Range{CIDR: cidr, Provider: "mullvad"}); err != nil {
+			b.Fatalf("AddRange(%s) error = %v", cidr, err)
+		}
+	}
+	return lookup
+}
+
+// benchmarkIPs are looked up in turn: an exact hit, an IPv4 and an IPv6
+// range hit, and IPv4 and IPv6 misses.
+var benchmarkIPs = []string{"10.0.1.1", "100.1.2.3", "2001:db8:0:3::1", "192.0.2.1", "2001:db9::1"}
+
+func runLookupBenchmark(b *testing.B, lookup *Lookup) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lookup.LookupIP(benchmarkIPs[i%len(benchmarkIPs)])
+	}
+}
+
+// BenchmarkLookupIP_Exact measures lookups with exact addresses only, as
+// before CIDR ranges were supported.
+func BenchmarkLookupIP_Exact(b *testing.B) {
+	runLookupBenchmark(b, benchmarkLookup(b, 0))
+}
+
+// BenchmarkLookupIP_100kRanges measures lookups with 100k ranges loaded in
+// addition; misses in the exact maps walk the range trie.
+func BenchmarkLookupIP_100kRanges(b *testing.B) {
+	runLookupBenchmark(b, benchmarkLookup(b, 100_000))
+}
+
+func BenchmarkLookup_AddRange(b *testing.B) {
+	lookup := NewLookup()
+	prefixes := make([]string, 1024)
+	for i := range prefixes {
+		prefixes[i] = netip.PrefixFrom(netip.AddrFrom4([4]byte{100, byte(i >> 8), byte(i), 0}), 24).String()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := lookup.AddRange(&Range{CIDR: prefixes[i%len(prefixes)], Provider: "mullvad"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -8,8 +8,12 @@ package vpn
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
+	"net/netip"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,6 +29,32 @@ type Service struct {
 	importer *Importer
 
 	mu sync.RWMutex
+
+	// updateMu serializes dataset replacements and range changes, which must
+	// not interleave their reads and writes of the stored dataset.
+	updateMu sync.Mutex
+}
+
+// ErrEmptyDataset is returned by UpdateDataset for data without any VPN
+// server addresses, which would otherwise wipe the dataset.
+var ErrEmptyDataset = errors.New("VPN data contains no server addresses")
+
+// DatasetUpdate summarizes a dataset replacement by UpdateDataset.
+type DatasetUpdate struct {
+	// Import describes the new dataset.
+	Import *ImportResult `json:"import"`
+
+	// Providers are the providers with added, changed or removed addresses.
+	Providers []string `json:"providers"`
+
+	// IPsAdded, IPsRemoved and IPsChanged count addresses that are new,
+	// no longer listed, or now belong to another server.
+	IPsAdded   int `json:"ips_added"`
+	IPsRemoved int `json:"ips_removed"`
+	IPsChanged int `json:"ips_changed"`
+
+	// TotalIPs is the number of addresses in the new dataset.
+	TotalIPs int `json:"total_ips"`
 }
 
 // NewService creates a new VPN detection service.
@@ -95,6 +125,8 @@ func (s *Service) LookupIP(ip string) *LookupResult {
 
 // ImportFromFile imports VPN data from a gluetun servers.json file.
 func (s *Service) ImportFromFile(ctx context.Context, filename string) (*ImportResult, error) {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -121,6 +153,8 @@ func (s *Service) ImportFromFile(ctx context.Context, filename string) (*ImportR
 
 // ImportFromReader imports VPN data from a reader.
 func (s *Service) ImportFromReader(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -146,6 +180,8 @@ func (s *Service) ImportFromReader(ctx context.Context, r io.Reader) (*ImportRes
 
 // ImportFromBytes imports VPN data from a byte slice.
 func (s *Service) ImportFromBytes(ctx context.Context, data []byte) (*ImportResult, error) {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -169,32 +205,222 @@ func (s *Service) ImportFromBytes(ctx context.Context, data []byte) (*ImportResu
 	return result, nil
 }
 
-// persistLookupData saves the current lookup data to the database.
+// persistLookupData writes the differences between the stored dataset and
+// the current lookup data to the database.
 func (s *Service) persistLookupData(ctx context.Context) error {
 	// Skip persistence if no store is configured
 	if s.store == nil {
 		return nil
 	}
 
-	// Clear and reload - simpler than diff tracking
-	if err := s.store.Clear(ctx); err != nil {
+	stored, err := s.store.loadIPs(ctx)
+	if err != nil {
 		return err
 	}
+	return s.store.applyDiff(ctx, diffDatasets(stored, s.lookup.snapshot()), s.lookup.ListProviders())
+}
 
-	// Save providers
-	for _, p := range s.lookup.ListProviders() {
-		if err := s.store.SaveProvider(ctx, &p); err != nil {
-			return err
+// UpdateDataset replaces the VPN dataset with gluetun JSON data.
+//
+// The data is parsed into a separate lookup first; invalid JSON or data
+// without any addresses (ErrEmptyDataset) leaves the dataset untouched.
+// Only the differences to the stored dataset are written, in one
+// transaction, and the in-memory lookup is swapped once they are, so
+// lookups keep answering from the old data until then. A failed write
+// leaves both the database and the lookup unchanged.
+func (s *Service) UpdateDataset(ctx context.Context, data []byte) (*DatasetUpdate, error) {
+	staged := NewLookup()
+	result, err := NewImporter(staged).ImportFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if staged.Count() == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	var stored map[netip.Addr]serverInfo
+	if s.store != nil {
+		if stored, err = s.store.loadIPs(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		stored = s.lookup.snapshot()
+	}
+
+	next := staged.snapshot()
+	diff := diffDatasets(stored, next)
+	if s.store != nil {
+		if err := s.store.applyDiff(ctx, diff, staged.ListProviders()); err != nil {
+			return nil, err
+		}
+	}
+	s.lookup.replace(staged)
+
+	logging.Info().
+		Int("ips_added", diff.added).
+		Int("ips_removed", len(diff.removed)).
+		Int("ips_changed", diff.changed).
+		Int("total_ips", len(next)).
+		Msg("VPN dataset updated")
+
+	return &DatasetUpdate{
+		Import:     result,
+		Providers:  diff.providers,
+		IPsAdded:   diff.added,
+		IPsRemoved: len(diff.removed),
+		IPsChanged: diff.changed,
+		TotalIPs:   len(next),
+	}, nil
+}
+
+// AddRange validates and stores a CIDR range and adds it to the lookup,
+// replacing an existing range with the same prefix. The provider is
+// normalized to lowercase, Source defaults to RangeSourceManual, and AddedAt
+// is set to the current time. Returns the stored range; validation failures
+// wrap ErrInvalidRange.
+func (s *Service) AddRange(ctx context.Context, r Range) (*Range, error) {
+	prefix, err := parseRange(r.CIDR)
+	if err != nil {
+		return nil, err
+	}
+	r.CIDR = prefix.String()
+	r.Provider = strings.ToLower(strings.TrimSpace(r.Provider))
+	if r.Provider == "" {
+		return nil, fmt.Errorf("%w: provider is required", ErrInvalidRange)
+	}
+	if r.Source == "" {
+		r.Source = RangeSourceManual
+	}
+	r.AddedAt = time.Now().UTC()
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	if s.store != nil {
+		if err := s.store.SaveRange(ctx, &r); err != nil {
+			return nil, err
 		}
 	}
+	if err := s.lookup.AddRange(&r); err != nil {
+		return nil, err
+	}
+
+	logging.Info().
+		Str("cidr", r.CIDR).
+		Str("provider", r.Provider).
+		Str("source", r.Source).
+		Msg("VPN range added")
+
+	return &r, nil
+}
+
+// RemoveRange removes a CIDR range from the database and the lookup.
+// Returns ErrRangeNotFound if it does not exist.
+func (s *Service) RemoveRange(ctx context.Context, cidr string) error {
+	prefix, err := parseRange(cidr)
+	if err != nil {
+		return err
+	}
 
-	// We need to iterate through the lookup maps
-	// Since they're private, we'll use a different approach: save during import
-	// The importer already has access to all server data
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
 
+	if s.store != nil {
+		if _, err := s.store.DeleteRange(ctx, prefix.String()); err != nil {
+			return err
+		}
+	}
+	if err := s.lookup.RemoveRange(prefix.String()); err != nil {
+		return err
+	}
+
+	logging.Info().Str("cidr", prefix.String()).Msg("VPN range removed")
 	return nil
 }
 
+// ListRanges returns all CIDR ranges.
+func (s *Service) ListRanges() []Range {
+	return s.lookup.Ranges()
+}
+
+// IsDatacenter checks if an IP address is in a hosting provider's range.
+// It is a separate signal from IsVPN: a VPN server in a datacenter matches
+// both.
+func (s *Service) IsDatacenter(ip string) bool {
+	return s.LookupDatacenter(ip).IsDatacenter
+}
+
+// LookupDatacenter returns the datacenter range containing an IP address.
+func (s *Service) LookupDatacenter(ip string) *DatacenterResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.config.Enabled {
+		return &DatacenterResult{}
+	}
+
+	return s.lookup.LookupDatacenter(ip)
+}
+
+// ConnectionType classifies an IP address as residential, vpn, datacenter
+// or unknown (see the ConnectionType constants). Returns
+// ConnectionTypeUnknown while detection is disabled.
+func (s *Service) ConnectionType(ip string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.config.Enabled {
+		return ConnectionTypeUnknown
+	}
+
+	return s.lookup.ConnectionType(ip)
+}
+
+// ImportDatacenterFromFile replaces the datacenter ranges with a range
+// list file (see ParseDatacenterRanges).
+func (s *Service) ImportDatacenterFromFile(ctx context.Context, filename string) (*DatacenterImportResult, error) {
+	f, err := os.Open(filename) //nolint:gosec // G304: filename is trusted input from configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to open datacenter file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return s.ImportDatacenterFromReader(ctx, f, filename)
+}
+
+// ImportDatacenterFromReader replaces the datacenter ranges with a range
+// list read from r. source names the file or URL for the stored ranges.
+// The ranges are persisted before they are swapped into the lookup, so a
+// failed import leaves the previous ranges in place.
+func (s *Service) ImportDatacenterFromReader(ctx context.Context, r io.Reader, source string) (*DatacenterImportResult, error) {
+	ranges, skipped, err := ParseDatacenterRanges(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	if s.store != nil {
+		if err := s.store.ReplaceDatacenterRanges(ctx, ranges, source); err != nil {
+			return nil, err
+		}
+	}
+	s.lookup.SetDatacenterRanges(ranges)
+
+	result := &DatacenterImportResult{Ranges: len(ranges), Skipped: skipped, Source: source}
+	logging.Info().
+		Int("ranges", result.Ranges).
+		Int("skipped", result.Skipped).
+		Str("source", source).
+		Msg("Datacenter ranges imported")
+
+	return result, nil
+}
+
 // GetStats returns statistics about the VPN database.
 func (s *Service) GetStats() *Stats {
 	s.mu.RLock()
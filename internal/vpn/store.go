@@ -9,6 +9,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/netip"
 	"strings"
 	"time"
 
@@ -44,7 +45,7 @@ type Store interface {
 	// GetStats returns database statistics.
 	GetStats(ctx context.Context) (*Stats, error)
 
-	// Clear removes all VPN data.
+	// Clear removes all VPN server data. CIDR ranges are kept.
 	Clear(ctx context.Context) error
 }
 
@@ -113,6 +114,61 @@ func (s *DuckDBStore) InitSchema(ctx context.Context) error {
 		return fmt.Errorf("failed to create vpn_metadata table: %w", err)
 	}
 
+	// Create update history table
+	_, err = s.db.ExecContext(ctx, `CREATE SEQUENCE IF NOT EXISTS vpn_update_history_id_seq`)
+	if err != nil {
+		return fmt.Errorf("failed to create vpn_update_history sequence: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS vpn_update_history (
+			id BIGINT PRIMARY KEY DEFAULT nextval('vpn_update_history_id_seq'),
+			started_at TIMESTAMP NOT NULL,
+			duration_ms BIGINT NOT NULL DEFAULT 0,
+			triggered_by VARCHAR NOT NULL,
+			source_url VARCHAR NOT NULL,
+			status VARCHAR NOT NULL,
+			providers VARCHAR,
+			ips_added INTEGER NOT NULL DEFAULT 0,
+			ips_removed INTEGER NOT NULL DEFAULT 0,
+			ips_changed INTEGER NOT NULL DEFAULT 0,
+			total_ips INTEGER NOT NULL DEFAULT 0,
+			error VARCHAR
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create vpn_update_history table: %w", err)
+	}
+
+	// Create CIDR ranges table
+	_, err = s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS vpn_ranges (
+			cidr VARCHAR PRIMARY KEY,
+			provider VARCHAR NOT NULL,
+			country VARCHAR,
+			city VARCHAR,
+			source VARCHAR NOT NULL,
+			description VARCHAR,
+			added_by VARCHAR,
+			added_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create vpn_ranges table: %w", err)
+	}
+
+	// Create datacenter ranges table
+	_, err = s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS datacenter_ranges (
+			cidr VARCHAR PRIMARY KEY,
+			provider VARCHAR NOT NULL,
+			source VARCHAR,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create datacenter_ranges table: %w", err)
+	}
+
 	return nil
 }
 
@@ -125,6 +181,8 @@ func (s *DuckDBStore) SaveUpdateStatus(ctx context.Context, status *UpdateStatus
 		"last_error":             status.LastError,
 		"source_url":             status.SourceURL,
 		"data_hash":              status.DataHash,
+		"etag":                   status.ETag,
+		"last_modified":          status.LastModified,
 	}
 
 	for key, value := range updates {
@@ -176,6 +234,10 @@ func (s *DuckDBStore) LoadUpdateStatus(ctx context.Context) (*UpdateStatus, erro
 			status.SourceURL = value
 		case "data_hash":
 			status.DataHash = value
+		case "etag":
+			status.ETag = value
+		case "last_modified":
+			status.LastModified = value
 		}
 	}
 
@@ -392,7 +454,7 @@ func (s *DuckDBStore) GetStats(ctx context.Context) (*Stats, error) {
 	return stats, nil
 }
 
-// Clear removes all VPN data.
+// Clear removes all VPN server data. CIDR and datacenter ranges are kept.
 func (s *DuckDBStore) Clear(ctx context.Context) error {
 	_, err := s.db.ExecContext(ctx, `DELETE FROM vpn_ips`)
 	if err != nil {
@@ -467,10 +529,30 @@ func (s *DuckDBStore) LoadIntoLookup(ctx context.Context, lookup *Lookup) error
 		lookup.AddProvider(&pCopy)
 	}
 
+	// Load CIDR ranges
+	ranges, err := s.ListRanges(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range ranges {
+		if err := lookup.AddRange(&ranges[i]); err != nil {
+			logging.Warn().Err(err).Str("cidr", ranges[i].CIDR).Msg("Skipping invalid VPN range")
+		}
+	}
+
+	// Load datacenter ranges
+	datacenter, err := s.ListDatacenterRanges(ctx)
+	if err != nil {
+		return err
+	}
+	lookup.SetDatacenterRanges(datacenter)
+
 	lookup.stats.LastUpdated = time.Now()
 
 	logging.Info().
 		Int("ip_count", lookup.Count()).
+		Int("range_count", lookup.RangeCount()).
+		Int("datacenter_range_count", len(datacenter)).
 		Dur("duration", time.Since(start)).
 		Msg("VPN IPs loaded from database")
 
@@ -579,3 +661,288 @@ func parseIPList(listStr string) []string {
 	}
 	return ips
 }
+
+// storeBatchSize is the number of rows per statement when applying a
+// dataset diff.
+const storeBatchSize = 500
+
+// loadIPs returns the stored address-to-server mapping. Addresses that do
+// not parse are skipped.
+func (s *DuckDBStore) loadIPs(ctx context.Context) (map[netip.Addr]serverInfo, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT ip_address, provider, COALESCE(country, ''), COALESCE(city, ''),
+			COALESCE(hostname, ''), COALESCE(server_name, '')
+		FROM vpn_ips
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load VPN IPs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := make(map[netip.Addr]serverInfo)
+	for rows.Next() {
+		var ip string
+		var info serverInfo
+		if err := rows.Scan(&ip, &info.provider, &info.country, &info.city, &info.hostname, &info.serverName); err != nil {
+			return nil, fmt.Errorf("failed to scan IP: %w", err)
+		}
+		if addr, err := netip.ParseAddr(ip); err == nil {
+			entries[addr] = info
+		}
+	}
+	return entries, rows.Err()
+}
+
+// applyDiff writes a dataset diff and the new provider list in one
+// transaction; on error the stored dataset is unchanged.
+func (s *DuckDBStore) applyDiff(ctx context.Context, diff *datasetDiff, providers []Provider) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	for start := 0; start < len(diff.removed); start += storeBatchSize {
+		batch := diff.removed[start:min(start+storeBatchSize, len(diff.removed))]
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, len(batch))
+		for i, addr := range batch {
+			placeholders[i] = "?"
+			args[i] = addr.String()
+		}
+		query := `DELETE FROM vpn_ips WHERE ip_address IN (` + strings.Join(placeholders, ", ") + `)`
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete VPN IPs: %w", err)
+		}
+	}
+
+	values := make([]string, 0, storeBatchSize)
+	args := make([]interface{}, 0, 6*storeBatchSize)
+	flush := func() error {
+		if len(values) == 0 {
+			return nil
+		}
+		query := `
+			INSERT INTO vpn_ips (ip_address, provider, country, city, hostname, server_name)
+			VALUES ` + strings.Join(values, ", ") + `
+			ON CONFLICT (ip_address) DO UPDATE SET
+				provider = EXCLUDED.provider,
+				country = EXCLUDED.country,
+				city = EXCLUDED.city,
+				hostname = EXCLUDED.hostname,
+				server_name = EXCLUDED.server_name`
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to save VPN IPs: %w", err)
+		}
+		values, args = values[:0], args[:0]
+		return nil
+	}
+	for addr, info := range diff.upserts {
+		values = append(values, "(?, ?, ?, ?, ?, ?)")
+		args = append(args, addr.String(), info.provider, info.country, info.city, info.hostname, info.serverName)
+		if len(values) == storeBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// Upsert providers and drop the ones no longer listed
+	names := make([]interface{}, 0, len(providers))
+	for i := range providers {
+		p := &providers[i]
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO vpn_providers (name, display_name, website, server_count, ip_count, version, timestamp, last_updated)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (name) DO UPDATE SET
+				display_name = EXCLUDED.display_name,
+				website = EXCLUDED.website,
+				server_count = EXCLUDED.server_count,
+				ip_count = EXCLUDED.ip_count,
+				version = EXCLUDED.version,
+				timestamp = EXCLUDED.timestamp,
+				last_updated = EXCLUDED.last_updated
+		`, p.Name, p.DisplayName, p.Website, p.ServerCount, p.IPCount, p.Version, p.Timestamp, p.LastUpdated); err != nil {
+			return fmt.Errorf("failed to save provider: %w", err)
+		}
+		names = append(names, p.Name)
+	}
+	deleteProviders := `DELETE FROM vpn_providers`
+	if len(names) > 0 {
+		deleteProviders += ` WHERE name NOT IN (?` + strings.Repeat(", ?", len(names)-1) + `)`
+	}
+	if _, err := tx.ExecContext(ctx, deleteProviders, names...); err != nil {
+		return fmt.Errorf("failed to delete providers: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit VPN data: %w", err)
+	}
+	return nil
+}
+
+// RecordUpdate appends an entry to the update history and sets its ID.
+func (s *DuckDBStore) RecordUpdate(ctx context.Context, record *UpdateRecord) error {
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO vpn_update_history (started_at, duration_ms, triggered_by, source_url, status,
+			providers, ips_added, ips_removed, ips_changed, total_ips, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`, record.StartedAt, record.DurationMs, record.Trigger, record.SourceURL, record.Status,
+		strings.Join(record.Providers, ","), record.IPsAdded, record.IPsRemoved, record.IPsChanged,
+		record.TotalIPs, record.Error).Scan(&record.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record VPN update: %w", err)
+	}
+	return nil
+}
+
+// ListUpdates returns the most recent update history entries, newest first.
+func (s *DuckDBStore) ListUpdates(ctx context.Context, limit int) ([]UpdateRecord, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, started_at, duration_ms, triggered_by, source_url, status,
+			COALESCE(providers, ''), ips_added, ips_removed, ips_changed, total_ips, COALESCE(error, '')
+		FROM vpn_update_history
+		ORDER BY id DESC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VPN updates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	records := make([]UpdateRecord, 0)
+	for rows.Next() {
+		var r UpdateRecord
+		var providers string
+		if err := rows.Scan(&r.ID, &r.StartedAt, &r.DurationMs, &r.Trigger, &r.SourceURL, &r.Status,
+			&providers, &r.IPsAdded, &r.IPsRemoved, &r.IPsChanged, &r.TotalIPs, &r.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan VPN update: %w", err)
+		}
+		if providers != "" {
+			r.Providers = strings.Split(providers, ",")
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// SaveRange inserts or replaces a CIDR range.
+func (s *DuckDBStore) SaveRange(ctx context.Context, r *Range) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO vpn_ranges (cidr, provider, country, city, source, description, added_by, added_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (cidr) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			country = EXCLUDED.country,
+			city = EXCLUDED.city,
+			source = EXCLUDED.source,
+			description = EXCLUDED.description,
+			added_by = EXCLUDED.added_by,
+			added_at = EXCLUDED.added_at
+	`, r.CIDR, r.Provider, r.Country, r.City, r.Source, r.Description, r.AddedBy, r.AddedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save VPN range: %w", err)
+	}
+	return nil
+}
+
+// DeleteRange removes a CIDR range. Returns false if it did not exist.
+func (s *DuckDBStore) DeleteRange(ctx context.Context, cidr string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM vpn_ranges WHERE cidr = ?`, cidr)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete VPN range: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete VPN range: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListRanges returns all CIDR ranges ordered by CIDR.
+func (s *DuckDBStore) ListRanges(ctx context.Context) ([]Range, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT cidr, provider, COALESCE(country, ''), COALESCE(city, ''), source,
+			COALESCE(description, ''), COALESCE(added_by, ''), added_at
+		FROM vpn_ranges
+		ORDER BY cidr
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VPN ranges: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ranges := make([]Range, 0)
+	for rows.Next() {
+		var r Range
+		if err := rows.Scan(&r.CIDR, &r.Provider, &r.Country, &r.City, &r.Source,
+			&r.Description, &r.AddedBy, &r.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan VPN range: %w", err)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, rows.Err()
+}
+
+// ReplaceDatacenterRanges replaces all datacenter ranges in one
+// transaction; on error the stored ranges are unchanged.
+func (s *DuckDBStore) ReplaceDatacenterRanges(ctx context.Context, ranges []DatacenterRange, source string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM datacenter_ranges`); err != nil {
+		return fmt.Errorf("failed to clear datacenter ranges: %w", err)
+	}
+
+	now := time.Now()
+	for start := 0; start < len(ranges); start += storeBatchSize {
+		batch := ranges[start:min(start+storeBatchSize, len(ranges))]
+		values := make([]string, len(batch))
+		args := make([]interface{}, 0, 4*len(batch))
+		for i := range batch {
+			values[i] = "(?, ?, ?, ?)"
+			args = append(args, batch[i].CIDR, batch[i].Provider, source, now)
+		}
+		// Lists may repeat a range; the last entry wins
+		query := `
+			INSERT INTO datacenter_ranges (cidr, provider, source, updated_at)
+			VALUES ` + strings.Join(values, ", ") + `
+			ON CONFLICT (cidr) DO UPDATE SET provider = EXCLUDED.provider`
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to save datacenter ranges: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit datacenter ranges: %w", err)
+	}
+	return nil
+}
+
+// ListDatacenterRanges returns all datacenter ranges ordered by CIDR.
+func (s *DuckDBStore) ListDatacenterRanges(ctx context.Context) ([]DatacenterRange, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT cidr, provider FROM datacenter_ranges ORDER BY cidr
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list datacenter ranges: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ranges := make([]DatacenterRange, 0)
+	for rows.Next() {
+		var r DatacenterRange
+		if err := rows.Scan(&r.CIDR, &r.Provider); err != nil {
+			return nil, fmt.Errorf("failed to scan datacenter range: %w", err)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, rows.Err()
+}
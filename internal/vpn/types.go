@@ -120,6 +120,13 @@ type LookupResult struct {
 	// Confidence indicates lookup confidence (0-100).
 	// Higher values indicate more certain matches (exact IP vs CIDR range).
 	Confidence int `json:"confidence"`
+
+	// MatchType is MatchTypeExact for a listed server address and
+	// MatchTypeCIDR for an address inside a provider's range (empty if not VPN).
+	MatchType string `json:"match_type,omitempty"`
+
+	// MatchedRange is the CIDR range that matched, for MatchTypeCIDR.
+	MatchedRange string `json:"matched_range,omitempty"`
 }
 
 // Stats contains statistics about the VPN database.
@@ -139,6 +146,12 @@ type Stats struct {
 	// IPv6Count is the number of IPv6 addresses.
 	IPv6Count int `json:"ipv6_count"`
 
+	// TotalRanges is the number of CIDR ranges.
+	TotalRanges int `json:"total_ranges"`
+
+	// DatacenterRanges is the number of datacenter ranges.
+	DatacenterRanges int `json:"datacenter_ranges"`
+
 	// LastUpdated is when the database was last updated.
 	LastUpdated time.Time `json:"last_updated"`
 
@@ -146,6 +159,33 @@ type Stats struct {
 	ProviderStats []Provider `json:"provider_stats,omitempty"`
 }
 
+// Range is a CIDR range operated by a VPN provider. Addresses in the range
+// that are not listed as a server match it by longest prefix.
+type Range struct {
+	// CIDR is the range in prefix notation (e.g., "198.51.100.0/24").
+	CIDR string `json:"cidr"`
+
+	// Provider is the VPN provider name.
+	Provider string `json:"provider"`
+
+	// Country and City are reported as the server location, if known.
+	Country string `json:"country,omitempty"`
+	City    string `json:"city,omitempty"`
+
+	// Source is where the range came from: RangeSourceManual for ranges
+	// added by an administrator, or the name of a data source.
+	Source string `json:"source"`
+
+	// Description is a free-form note (optional).
+	Description string `json:"description,omitempty"`
+
+	// AddedBy is the user who added the range (manual ranges only).
+	AddedBy string `json:"added_by,omitempty"`
+
+	// AddedAt is when the range was added.
+	AddedAt time.Time `json:"added_at"`
+}
+
 // ImportResult contains the result of importing VPN data.
 type ImportResult struct {
 	// ProvidersImported is the number of providers imported.
@@ -204,10 +244,10 @@ type Config struct {
 	// If empty, data is loaded from the database only.
 	DataFile string `json:"data_file,omitempty"`
 
-	// AutoUpdate enables automatic data updates (future feature).
+	// AutoUpdate enables scheduled data updates (see Updater).
 	AutoUpdate bool `json:"auto_update"`
 
-	// UpdateInterval is how often to check for updates (future feature).
+	// UpdateInterval is how often to check for updates.
 	UpdateInterval time.Duration `json:"update_interval,omitempty"`
 }
 
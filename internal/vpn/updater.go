@@ -6,9 +6,11 @@
 package vpn
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -28,8 +30,61 @@ const (
 
 	// DefaultHTTPTimeout is the timeout for HTTP requests.
 	DefaultHTTPTimeout = 60 * time.Second
+
+	// maxUpdateHistory is the number of history entries kept in memory
+	// when the updater has no store.
+	maxUpdateHistory = 100
+)
+
+// Update triggers recorded in the update history.
+const (
+	UpdateTriggerScheduled = "scheduled"
+	UpdateTriggerManual    = "manual"
 )
 
+// Update outcomes recorded in the update history.
+const (
+	// UpdateStatusUpdated means addresses were added, removed or changed.
+	UpdateStatusUpdated = "updated"
+
+	// UpdateStatusUnchanged means the source was downloaded but held the
+	// same addresses.
+	UpdateStatusUnchanged = "unchanged"
+
+	// UpdateStatusNotModified means the source answered 304 Not Modified
+	// to a conditional request, so nothing was downloaded.
+	UpdateStatusNotModified = "not_modified"
+
+	// UpdateStatusFailed means the update failed; the dataset is unchanged.
+	UpdateStatusFailed = "failed"
+)
+
+// ErrUpdateInProgress is returned when an update is requested while
+// another one is running.
+var ErrUpdateInProgress = errors.New("update already in progress")
+
+// UpdateRecord is an entry of the update history.
+type UpdateRecord struct {
+	ID         int64     `json:"id"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Trigger    string    `json:"trigger"` // scheduled or manual
+	SourceURL  string    `json:"source_url"`
+	Status     string    `json:"status"` // updated, unchanged, not_modified or failed
+
+	// Providers are the providers with added, changed or removed addresses.
+	Providers []string `json:"providers,omitempty"`
+
+	IPsAdded   int `json:"ips_added"`
+	IPsRemoved int `json:"ips_removed"`
+	IPsChanged int `json:"ips_changed"`
+
+	// TotalIPs is the number of addresses after the update.
+	TotalIPs int `json:"total_ips"`
+
+	Error string `json:"error,omitempty"`
+}
+
 // UpdaterConfig configures the VPN data updater.
 type UpdaterConfig struct {
 	// Enabled controls whether automatic updates are active.
@@ -49,6 +104,10 @@ type UpdaterConfig struct {
 
 	// RetryDelay is the initial delay between retries (doubles each attempt).
 	RetryDelay time.Duration `json:"retry_delay"`
+
+	// DatacenterURL is the URL of a datacenter range list, refreshed
+	// after each VPN update. Empty disables datacenter updates.
+	DatacenterURL string `json:"datacenter_url,omitempty"`
 }
 
 // DefaultUpdaterConfig returns sensible defaults for the updater.
@@ -80,6 +139,11 @@ type UpdateStatus struct {
 	// DataHash is the SHA256 hash of the last imported data.
 	DataHash string `json:"data_hash"`
 
+	// ETag and LastModified are the validators the source sent with the
+	// last imported data, for conditional requests.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+
 	// Version tracks provider versions from the source.
 	ProviderVersions map[string]int `json:"provider_versions,omitempty"`
 
@@ -101,6 +165,9 @@ type Updater struct {
 	client  *http.Client
 	status  UpdateStatus
 
+	// history holds the update history when there is no store.
+	history []UpdateRecord
+
 	stopChan chan struct{}
 	mu       sync.RWMutex
 	wg       sync.WaitGroup
@@ -162,6 +229,8 @@ func (u *Updater) LoadStatus(ctx context.Context) error {
 		if status.DataHash != "" {
 			u.status.DataHash = status.DataHash
 		}
+		u.status.ETag = status.ETag
+		u.status.LastModified = status.LastModified
 		u.mu.Unlock()
 	}
 
@@ -213,6 +282,7 @@ func (u *Updater) updateLoop(ctx context.Context) {
 	if err := u.UpdateNow(ctx); err != nil {
 		logging.Warn().Err(err).Msg("VPN initial update failed")
 	}
+	u.updateDatacenter(ctx)
 
 	ticker := time.NewTicker(u.config.UpdateInterval)
 	defer ticker.Stop()
@@ -231,7 +301,47 @@ func (u *Updater) updateLoop(ctx context.Context) {
 			if err := u.UpdateNow(ctx); err != nil {
 				logging.Warn().Err(err).Msg("VPN scheduled update failed")
 			}
+			u.updateDatacenter(ctx)
+		}
+	}
+}
+
+// RunWithContext runs scheduled updates until ctx is canceled, for use as
+// a supervised service. The first update runs right away unless the last
+// successful one is more recent than the update interval. Failed updates
+// are recorded and retried at the next interval; they do not stop the
+// loop.
+func (u *Updater) RunWithContext(ctx context.Context) error {
+	u.mu.RLock()
+	interval := u.config.UpdateInterval
+	wait := time.Until(u.status.LastSuccessfulUpdate.Add(interval))
+	u.mu.RUnlock()
+	if wait < 0 {
+		wait = 0
+	}
+
+	logging.Info().Dur("interval", interval).Dur("first_in", wait).Msg("Starting VPN automatic updates")
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		u.mu.Lock()
+		u.status.NextScheduledUpdate = time.Now().Add(wait)
+		u.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if _, err := u.update(ctx, u.config.SourceURL, UpdateTriggerScheduled, false); err != nil && ctx.Err() == nil {
+			logging.Warn().Err(err).Msg("VPN scheduled update failed")
 		}
+		u.updateDatacenter(ctx)
+
+		wait = interval
+		timer.Reset(wait)
 	}
 }
 
@@ -240,12 +350,27 @@ func (u *Updater) UpdateNow(ctx context.Context) error {
 	return u.UpdateFromURL(ctx, u.config.SourceURL)
 }
 
+// ForceUpdate downloads the configured source and applies it, skipping
+// the conditional request and the unchanged-data shortcut. It returns
+// the recorded history entry, also when the update failed.
+func (u *Updater) ForceUpdate(ctx context.Context) (*UpdateRecord, error) {
+	return u.update(ctx, u.config.SourceURL, UpdateTriggerManual, true)
+}
+
 // UpdateFromURL fetches and imports VPN data from a specific URL.
 func (u *Updater) UpdateFromURL(ctx context.Context, url string) error {
+	_, err := u.update(ctx, url, UpdateTriggerScheduled, false)
+	return err
+}
+
+// update runs one update and records it in the history. Unless force is
+// set, the request is conditional on the validators of the last import
+// from the same URL, and data with the same hash is not applied again.
+func (u *Updater) update(ctx context.Context, url, trigger string, force bool) (*UpdateRecord, error) {
 	u.mu.Lock()
 	if u.status.IsUpdating {
 		u.mu.Unlock()
-		return fmt.Errorf("update already in progress")
+		return nil, ErrUpdateInProgress
 	}
 	u.status.IsUpdating = true
 	u.status.LastUpdateAttempt = time.Now()
@@ -257,72 +382,179 @@ func (u *Updater) UpdateFromURL(ctx context.Context, url string) error {
 		u.mu.Unlock()
 	}()
 
-	logging.Info().Str("url", url).Msg("Fetching VPN data")
+	record := &UpdateRecord{StartedAt: time.Now(), Trigger: trigger, SourceURL: url}
+	err := u.apply(ctx, url, force, record)
+	record.DurationMs = time.Since(record.StartedAt).Milliseconds()
 
-	// Fetch with retries
-	data, err := u.fetchWithRetry(ctx, url)
+	u.mu.Lock()
 	if err != nil {
-		u.mu.Lock()
+		record.Status = UpdateStatusFailed
+		record.Error = err.Error()
 		u.status.LastError = err.Error()
-		u.mu.Unlock()
-		return fmt.Errorf("failed to fetch VPN data: %w", err)
+	} else {
+		u.status.LastSuccessfulUpdate = time.Now()
+		u.status.LastError = ""
 	}
+	u.mu.Unlock()
 
-	// Calculate hash to detect changes
-	hash := sha256.Sum256(data)
-	hashStr := hex.EncodeToString(hash[:])
+	u.recordUpdate(ctx, record)
+	u.persistStatus(ctx)
+	return record, err
+}
+
+// apply fetches url and updates the dataset, filling in record.
+func (u *Updater) apply(ctx context.Context, url string, force bool, record *UpdateRecord) error {
+	record.TotalIPs = u.service.GetStats().TotalIPs
 
+	// Validators only apply to the URL they came from, and only while the
+	// data they describe is loaded
+	var etag, lastModified, previousHash string
 	u.mu.RLock()
-	previousHash := u.status.DataHash
+	if !force && url == u.status.SourceURL && record.TotalIPs > 0 {
+		etag, lastModified, previousHash = u.status.ETag, u.status.LastModified, u.status.DataHash
+	}
 	u.mu.RUnlock()
 
+	logging.Info().Str("url", url).Bool("conditional", etag != "" || lastModified != "").Msg("Fetching VPN data")
+
+	// Fetch with retries
+	resp, err := u.fetchWithRetry(ctx, url, etag, lastModified)
+	if err != nil {
+		return fmt.Errorf("failed to fetch VPN data: %w", err)
+	}
+	if resp.notModified {
+		logging.Info().Msg("VPN data not modified")
+		record.Status = UpdateStatusNotModified
+		return nil
+	}
+
+	// Calculate hash to detect changes
+	hash := sha256.Sum256(resp.data)
+	hashStr := hex.EncodeToString(hash[:])
+
 	if hashStr == previousHash {
 		logging.Info().Str("hash", hashStr[:16]).Msg("VPN data unchanged")
-		u.mu.Lock()
-		u.status.LastSuccessfulUpdate = time.Now()
-		u.status.LastError = ""
-		u.mu.Unlock()
-		u.persistStatus(ctx)
+		record.Status = UpdateStatusUnchanged
+		u.setValidators(resp)
 		return nil
 	}
 
-	// Import the data
-	result, err := u.service.ImportFromBytes(ctx, data)
+	// Validate and apply the differences
+	update, err := u.service.UpdateDataset(ctx, resp.data)
 	if err != nil {
-		u.mu.Lock()
-		u.status.LastError = err.Error()
-		u.mu.Unlock()
 		return fmt.Errorf("failed to import VPN data: %w", err)
 	}
 
-	// Extract provider versions
-	providerVersions := u.extractProviderVersions(data)
+	record.Status = UpdateStatusUnchanged
+	if update.IPsAdded+update.IPsRemoved+update.IPsChanged > 0 {
+		record.Status = UpdateStatusUpdated
+	}
+	record.Providers = update.Providers
+	record.IPsAdded = update.IPsAdded
+	record.IPsRemoved = update.IPsRemoved
+	record.IPsChanged = update.IPsChanged
+	record.TotalIPs = update.TotalIPs
 
 	// Update status
 	u.mu.Lock()
-	u.status.LastSuccessfulUpdate = time.Now()
-	u.status.LastError = ""
 	u.status.SourceURL = url
 	u.status.DataHash = hashStr
-	u.status.ProviderVersions = providerVersions
-	u.status.LastImportResult = result
+	u.status.ProviderVersions = u.extractProviderVersions(resp.data)
+	u.status.LastImportResult = update.Import
 	u.mu.Unlock()
-
-	// Persist status to database
-	u.persistStatus(ctx)
+	u.setValidators(resp)
 
 	logging.Info().
-		Int("providers", result.ProvidersImported).
-		Int("servers", result.ServersImported).
-		Int("ips", result.IPsImported).
+		Int("providers", update.Import.ProvidersImported).
+		Int("ips", update.TotalIPs).
+		Int("ips_added", update.IPsAdded).
+		Int("ips_removed", update.IPsRemoved).
 		Str("hash", hashStr[:16]).
 		Msg("VPN data successfully updated")
 
 	return nil
 }
 
+// setValidators remembers the validators of a downloaded response.
+func (u *Updater) setValidators(resp *fetchResponse) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.status.ETag = resp.etag
+	u.status.LastModified = resp.lastModified
+}
+
+// recordUpdate appends record to the update history.
+func (u *Updater) recordUpdate(ctx context.Context, record *UpdateRecord) {
+	if u.store != nil {
+		if err := u.store.RecordUpdate(ctx, record); err != nil {
+			logging.Error().Err(err).Msg("Failed to record VPN update")
+		}
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	record.ID = 1
+	if n := len(u.history); n > 0 {
+		record.ID = u.history[n-1].ID + 1
+	}
+	u.history = append(u.history, *record)
+	if len(u.history) > maxUpdateHistory {
+		u.history = u.history[len(u.history)-maxUpdateHistory:]
+	}
+}
+
+// History returns the most recent update history entries, newest first.
+func (u *Updater) History(ctx context.Context, limit int) ([]UpdateRecord, error) {
+	if u.store != nil {
+		return u.store.ListUpdates(ctx, limit)
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	records := make([]UpdateRecord, 0, min(limit, len(u.history)))
+	for i := len(u.history) - 1; i >= 0 && len(records) < limit; i-- {
+		records = append(records, u.history[i])
+	}
+	return records, nil
+}
+
+// fetchResponse is the result of a VPN data request.
+type fetchResponse struct {
+	data         []byte
+	etag         string
+	lastModified string
+	notModified  bool
+}
+
+// UpdateDatacenter downloads the configured datacenter range list and
+// replaces the datacenter ranges with it. Does nothing when no
+// DatacenterURL is configured.
+func (u *Updater) UpdateDatacenter(ctx context.Context) (*DatacenterImportResult, error) {
+	u.mu.RLock()
+	url := u.config.DatacenterURL
+	u.mu.RUnlock()
+	if url == "" {
+		return nil, nil
+	}
+
+	resp, err := u.fetchWithRetry(ctx, url, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch datacenter ranges: %w", err)
+	}
+	return u.service.ImportDatacenterFromReader(ctx, bytes.NewReader(resp.data), url)
+}
+
+// updateDatacenter runs a scheduled datacenter update, logging failures.
+// The VPN dataset is unaffected by them.
+func (u *Updater) updateDatacenter(ctx context.Context) {
+	if _, err := u.UpdateDatacenter(ctx); err != nil && ctx.Err() == nil {
+		logging.Warn().Err(err).Msg("Datacenter range update failed")
+	}
+}
+
 // fetchWithRetry fetches data from URL with exponential backoff retries.
-func (u *Updater) fetchWithRetry(ctx context.Context, url string) ([]byte, error) {
+func (u *Updater) fetchWithRetry(ctx context.Context, url, etag, lastModified string) (*fetchResponse, error) {
 	var lastErr error
 	delay := u.config.RetryDelay
 
@@ -341,9 +573,9 @@ func (u *Updater) fetchWithRetry(ctx context.Context, url string) ([]byte, error
 			delay *= 2 // Exponential backoff
 		}
 
-		data, err := u.fetch(ctx, url)
+		resp, err := u.fetch(ctx, url, etag, lastModified)
 		if err == nil {
-			return data, nil
+			return resp, nil
 		}
 		lastErr = err
 		logging.Warn().Err(err).Int("attempt", attempt+1).Msg("VPN fetch attempt failed")
@@ -352,8 +584,9 @@ func (u *Updater) fetchWithRetry(ctx context.Context, url string) ([]byte, error
 	return nil, fmt.Errorf("all %d attempts failed: %w", u.config.RetryAttempts+1, lastErr)
 }
 
-// fetch performs a single HTTP GET request.
-func (u *Updater) fetch(ctx context.Context, url string) ([]byte, error) {
+// fetch performs a single HTTP GET request, conditional on etag and
+// lastModified when set.
+func (u *Updater) fetch(ctx context.Context, url, etag, lastModified string) (*fetchResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -361,6 +594,12 @@ func (u *Updater) fetch(ctx context.Context, url string) ([]byte, error) {
 
 	req.Header.Set("User-Agent", "Cartographus-VPN-Updater/1.0")
 	req.Header.Set("Accept", "application/json")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
 
 	resp, err := u.client.Do(req)
 	if err != nil {
@@ -368,6 +607,9 @@ func (u *Updater) fetch(ctx context.Context, url string) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && (etag != "" || lastModified != "") {
+		return &fetchResponse{notModified: true}, nil
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -379,7 +621,11 @@ func (u *Updater) fetch(ctx context.Context, url string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return data, nil
+	return &fetchResponse{
+		data:         data,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
 }
 
 // extractProviderVersions parses the JSON to get provider versions.
@@ -439,6 +685,11 @@ func (u *Updater) SetUpdateInterval(interval time.Duration) {
 	u.config.UpdateInterval = interval
 }
 
+// Service returns the VPN service whose dataset the updater maintains.
+func (u *Updater) Service() *Service {
+	return u.service
+}
+
 // GetConfig returns the current updater configuration.
 func (u *Updater) GetConfig() UpdaterConfig {
 	u.mu.RLock()
@@ -9,8 +9,12 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -586,3 +590,228 @@ func TestUpdater_ExtractProviderVersions_InvalidProviderFormat(t *testing.T) {
 	}
 	// invalidprovider should be skipped (unmarshal fails)
 }
+
+// gluetunJSON returns servers.json data with one testprovider server
+// listing ips.
+func gluetunJSON(ips ...string) string {
+	quoted := make([]string, len(ips))
+	for i, ip := range ips {
+		quoted[i] = `"` + ip + `"`
+	}
+	return `{"version": 1, "testprovider": {"version": 1, "timestamp": 1700000000, "servers": [` +
+		`{"country": "Test", "hostname": "t1.test.com", "ips": [` + strings.Join(quoted, ", ") + `]}]}}`
+}
+
+func TestUpdater_ConditionalRequestAndForce(t *testing.T) {
+	var body atomic.Value
+	body.Store(gluetunJSON("192.0.2.1", "192.0.2.2"))
+	var conditional atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(body.Load().(string)))
+	}))
+	defer server.Close()
+
+	service := newTestService()
+	config := DefaultUpdaterConfig()
+	config.SourceURL = server.URL
+	updater := NewUpdater(service, config)
+	ctx := context.Background()
+
+	if err := updater.UpdateNow(ctx); err != nil {
+		t.Fatalf("first update failed: %v", err)
+	}
+	if status := updater.GetStatus(); status.ETag != `"v1"` {
+		t.Errorf("ETag = %q, want the source's", status.ETag)
+	}
+
+	// The second scheduled update is conditional and downloads nothing
+	if err := updater.UpdateNow(ctx); err != nil {
+		t.Fatalf("second update failed: %v", err)
+	}
+	if conditional.Load() != 1 {
+		t.Errorf("conditional requests = %d, want 1", conditional.Load())
+	}
+
+	// A forced update downloads and applies the changes
+	body.Store(gluetunJSON("192.0.2.2", "192.0.2.3", "192.0.2.4"))
+	record, err := updater.ForceUpdate(ctx)
+	if err != nil {
+		t.Fatalf("ForceUpdate() error = %v", err)
+	}
+	if record.Status != UpdateStatusUpdated || record.IPsAdded != 2 || record.IPsRemoved != 1 || record.TotalIPs != 3 {
+		t.Errorf("record = %+v, want updated with 2 added, 1 removed, 3 total", record)
+	}
+	if service.IsVPN("192.0.2.1") || !service.IsVPN("192.0.2.4") {
+		t.Error("lookup does not reflect the forced update")
+	}
+
+	history, err := updater.History(ctx, 10)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	var statuses []string
+	for _, h := range history {
+		statuses = append(statuses, h.Status)
+	}
+	want := []string{UpdateStatusUpdated, UpdateStatusNotModified, UpdateStatusUpdated}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("history statuses = %v, want %v (newest first)", statuses, want)
+	}
+	if history[0].Trigger != UpdateTriggerManual || history[2].Trigger != UpdateTriggerScheduled {
+		t.Errorf("history triggers = %s, %s; want manual newest, scheduled oldest", history[0].Trigger, history[2].Trigger)
+	}
+}
+
+func TestUpdater_FailureKeepsDataset(t *testing.T) {
+	var body atomic.Value
+	body.Store(gluetunJSON("192.0.2.1"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body.Load().(string)))
+	}))
+	defer server.Close()
+
+	service := newTestService()
+	config := DefaultUpdaterConfig()
+	config.SourceURL = server.URL
+	config.RetryAttempts = 0
+	updater := NewUpdater(service, config)
+	ctx := context.Background()
+
+	if _, err := updater.ForceUpdate(ctx); err != nil {
+		t.Fatalf("initial update failed: %v", err)
+	}
+
+	for _, bad := range []string{`{"testprovider": {"servers": [`, `{"version": 1}`} {
+		body.Store(bad)
+		record, err := updater.ForceUpdate(ctx)
+		if err == nil {
+			t.Fatalf("update with %q succeeded", bad)
+		}
+		if record.Status != UpdateStatusFailed || record.Error == "" {
+			t.Errorf("record = %+v, want failed with the error", record)
+		}
+		if !service.IsVPN("192.0.2.1") {
+			t.Fatalf("dataset cleared by a failed update with %q", bad)
+		}
+	}
+	if !errors.Is(func() error { _, err := service.UpdateDataset(ctx, []byte(`{}`)); return err }(), ErrEmptyDataset) {
+		t.Error("UpdateDataset({}) did not return ErrEmptyDataset")
+	}
+}
+
+func TestUpdater_WithStore(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	service, err := NewService(db, nil)
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	if err := service.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	var body atomic.Value
+	body.Store(gluetunJSON("192.0.2.1", "192.0.2.2", "2001:db8::1"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2026 15:04:05 GMT")
+		w.Write([]byte(body.Load().(string)))
+	}))
+	defer server.Close()
+
+	config := DefaultUpdaterConfig()
+	config.SourceURL = server.URL
+	updater := NewUpdaterWithStore(service, NewDuckDBStore(db), config)
+
+	if _, err := updater.ForceUpdate(ctx); err != nil {
+		t.Fatalf("first update failed: %v", err)
+	}
+	body.Store(gluetunJSON("192.0.2.2", "2001:db8::1", "192.0.2.9"))
+	record, err := updater.ForceUpdate(ctx)
+	if err != nil {
+		t.Fatalf("second update failed: %v", err)
+	}
+	if record.IPsAdded != 1 || record.IPsRemoved != 1 || !reflect.DeepEqual(record.Providers, []string{"testprovider"}) {
+		t.Errorf("record = %+v, want 1 added and 1 removed for testprovider", record)
+	}
+
+	var stored int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM vpn_ips WHERE ip_address IN ('192.0.2.2', '2001:db8::1', '192.0.2.9')`).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	var total int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM vpn_ips`).Scan(&total); err != nil {
+		t.Fatal(err)
+	}
+	if stored != 3 || total != 3 {
+		t.Errorf("stored IPs = %d of %d, want exactly the 3 of the new dataset", stored, total)
+	}
+
+	// The history and the validators survive a restart
+	history, err := updater.History(ctx, 1)
+	if err != nil || len(history) != 1 || history[0].ID != record.ID {
+		t.Errorf("History(1) = %+v, %v; want the latest record", history, err)
+	}
+	restarted := NewUpdaterWithStore(service, NewDuckDBStore(db), config)
+	if err := restarted.LoadStatus(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := restarted.GetStatus().LastModified; got != "Mon, 02 Jan 2026 15:04:05 GMT" {
+		t.Errorf("LastModified after restart = %q", got)
+	}
+
+	// Reloading from the database gives the same dataset
+	if err := service.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !service.IsVPN("192.0.2.9") || service.IsVPN("192.0.2.1") {
+		t.Error("reloaded dataset differs from the applied update")
+	}
+}
+
+func TestUpdater_RunWithContext(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(gluetunJSON("192.0.2.1")))
+	}))
+	defer server.Close()
+
+	config := DefaultUpdaterConfig()
+	config.SourceURL = server.URL
+	config.UpdateInterval = time.Hour
+	updater := NewUpdater(newTestService(), config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- updater.RunWithContext(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for requests.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("RunWithContext() error = %v, want context.Canceled", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("requests = %d, want 1 initial update", requests.Load())
+	}
+}
+
+// newTestService returns a service without a database.
+func newTestService() *Service {
+	lookup := NewLookup()
+	return &Service{
+		config:   DefaultConfig(),
+		lookup:   lookup,
+		importer: NewImporter(lookup),
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/goccy/go-json"
+	"github.com/tomtom215/cartographus/internal/clock"
 	"github.com/tomtom215/cartographus/internal/logging"
 )
 
@@ -23,6 +24,7 @@ import (
 type Compactor struct {
 	wal    *BadgerWAL
 	config Config
+	clock  clock.Clock // Drives the compaction interval and entry expiry
 
 	// Control
 	ctx    context.Context
@@ -40,9 +42,17 @@ type Compactor struct {
 
 // NewCompactor creates a new compaction manager.
 func NewCompactor(wal *BadgerWAL) *Compactor {
+	return NewCompactorWithClock(wal, clock.Real())
+}
+
+// NewCompactorWithClock creates a compaction manager that reads time from
+// clk, so tests can expire entries and trigger runs with a clock.Fake. A
+// nil clk uses the real clock.
+func NewCompactorWithClock(wal *BadgerWAL, clk clock.Clock) *Compactor {
 	return &Compactor{
 		wal:    wal,
 		config: wal.GetConfig(),
+		clock:  clock.OrReal(clk),
 	}
 }
 
@@ -91,14 +101,14 @@ func (c *Compactor) IsRunning() bool {
 func (c *Compactor) run() {
 	defer c.wg.Done()
 
-	ticker := time.NewTicker(c.config.CompactInterval)
+	ticker := c.clock.NewTicker(c.config.CompactInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			c.compact()
 		}
 	}
@@ -106,7 +116,7 @@ func (c *Compactor) run() {
 
 // compact removes confirmed entries and runs garbage collection.
 func (c *Compactor) compact() {
-	start := time.Now()
+	start := c.clock.Now()
 
 	// Count and delete confirmed entries
 	deletedCount, err := c.deleteConfirmedEntries()
@@ -129,12 +139,12 @@ func (c *Compactor) compact() {
 
 	// Update stats
 	c.mu.Lock()
-	c.lastRun = time.Now()
+	c.lastRun = c.clock.Now()
 	c.lastEntriesCount = totalDeleted
 	c.mu.Unlock()
 
 	// Update metrics
-	duration := time.Since(start)
+	duration := c.clock.Now().Sub(start)
 	RecordWALCompaction()
 	RecordWALCompactionLatency(duration.Seconds())
 	if totalDeleted > 0 {
@@ -187,7 +197,7 @@ func (c *Compactor) deleteConfirmedEntries() (int64, error) {
 // deleteExpiredEntries removes pending entries older than EntryTTL.
 func (c *Compactor) deleteExpiredEntries() (int64, error) {
 	var count int64
-	cutoff := time.Now().Add(-c.config.EntryTTL)
+	cutoff := c.clock.Now().Add(-c.config.EntryTTL)
 
 	err := c.wal.db.Update(func(txn *badger.Txn) error {
 		opts := badger.DefaultIteratorOptions
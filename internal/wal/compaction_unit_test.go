@@ -12,6 +12,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/tomtom215/cartographus/internal/clock"
 )
 
 // TestCompactor_DeleteConfirmedEntries tests that confirmed entries are deleted.
@@ -485,3 +487,56 @@ func TestCompactor_RunDuringStart(t *testing.T) {
 		t.Errorf("Expected 0 confirmed after RunNow, got %d", stats.ConfirmedCount)
 	}
 }
+
+// TestCompactor_FakeClockExpiresEntries drives the compaction interval and
+// entry expiry with a fake clock instead of waiting for EntryTTL.
+func TestCompactor_FakeClockExpiresEntries(t *testing.T) {
+	// Each Advance below crosses exactly one compaction interval
+	cfg := createTestConfig(t)
+	cfg.CompactInterval = 45 * time.Minute
+	cfg.EntryTTL = time.Hour
+	wal, err := Open(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	defer wal.Close()
+
+	ctx := context.Background()
+	writeTestEvents(ctx, t, wal, 3)
+
+	clk := clock.NewFake(time.Now())
+	compactor := NewCompactorWithClock(wal, clk)
+	if err := compactor.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer compactor.Stop()
+
+	// One interval later the entries are not yet expired
+	clk.BlockUntil(1)
+	clk.Advance(cfg.CompactInterval)
+	firstRun := clk.Now()
+	waitForCompaction(t, compactor, firstRun)
+	assertPendingCount(ctx, t, wal, 3)
+
+	// The next run is past EntryTTL and deletes them
+	clk.Advance(cfg.CompactInterval)
+	secondRun := clk.Now()
+	waitForCompaction(t, compactor, secondRun)
+	assertPendingCount(ctx, t, wal, 0)
+	if got := compactor.GetStats().LastEntriesCount; got != 3 {
+		t.Errorf("LastEntriesCount = %d, want 3 expired entries", got)
+	}
+}
+
+// waitForCompaction waits for a run that finished at the fake time at
+func waitForCompaction(t *testing.T, c *Compactor, at time.Time) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if c.GetStats().LastRun.Equal(at) {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("no compaction run at %v; last run %v", at, c.GetStats().LastRun)
+}
@@ -0,0 +1,344 @@
+// Cartographus - Media Server Analytics and Geographic Visualization
+// Copyright 2026 Tom F. (tomtom215)
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// https://github.com/tomtom215/cartographus
+
+// Command mockserver is a minimal Plex Media Server stand-in for integration
+// tests. It serves canned JSON fixtures for the endpoints the sync package
+// polls and a WebSocket notification stream at /:/websockets/notifications.
+//
+// Tests drive it through the /mock/ endpoints, which need no token:
+//
+//	PUT  /mock/sessions       replace the /status/sessions response
+//	POST /mock/notifications  send the body to every WebSocket client;
+//	                          responds with {"delivered": n}
+//	GET  /mock/health         readiness probe
+//
+// It uses only the standard library so the image builds without network
+// access to the module proxy.
+package main
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // G505: SHA-1 is mandated by the WebSocket handshake (RFC 6455)
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// websocketGUID is the key suffix of the RFC 6455 opening handshake
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket opcodes used by the mock
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+)
+
+// fixtureRoutes maps Plex API paths to fixture files
+var fixtureRoutes = map[string]string{
+	"/":                   "identity.json",
+	"/identity":           "identity.json",
+	"/library/sections":   "library_sections.json",
+	"/transcode/sessions": "transcode_sessions.json",
+}
+
+type server struct {
+	token      string
+	fixtureDir string
+
+	mu       sync.Mutex
+	sessions []byte
+	clients  map[*wsConn]struct{}
+}
+
+func main() {
+	addr := envOr("PLEX_MOCK_ADDR", ":32400")
+	s := &server{
+		token:      os.Getenv("PLEX_MOCK_TOKEN"),
+		fixtureDir: envOr("PLEX_MOCK_FIXTURES", "/fixtures"),
+		clients:    make(map[*wsConn]struct{}),
+	}
+
+	sessions, err := os.ReadFile(filepath.Join(s.fixtureDir, "sessions.json"))
+	if err != nil {
+		log.Fatalf("read sessions fixture: %v", err)
+	}
+	s.sessions = sessions
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("plex mock listening on %s (fixtures: %s)", addr, s.fixtureDir)
+	log.Fatal(srv.ListenAndServe())
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/mock/health":
+		w.WriteHeader(http.StatusOK)
+		return
+	case "/mock/sessions":
+		s.handleSetSessions(w, r)
+		return
+	case "/mock/notifications":
+		s.handleNotify(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/:/websockets/notifications":
+		s.handleWebSocket(w, r)
+	case "/status/sessions":
+		s.mu.Lock()
+		body := s.sessions
+		s.mu.Unlock()
+		writeJSON(w, body)
+	default:
+		name, ok := fixtureRoutes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join(s.fixtureDir, name))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, body)
+	}
+}
+
+// authorized checks the X-Plex-Token header or query parameter; an empty
+// configured token accepts every request
+func (s *server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	token := r.Header.Get("X-Plex-Token")
+	if token == "" {
+		token = r.URL.Query().Get("X-Plex-Token")
+	}
+	return token == s.token
+}
+
+func writeJSON(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body) //nolint:errcheck
+}
+
+func (s *server) handleSetSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil || !json.Valid(body) {
+		http.Error(w, "body must be valid JSON", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.sessions = body
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) handleNotify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil || !json.Valid(body) {
+		http.Error(w, "body must be valid JSON", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	clients := make([]*wsConn, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	delivered := 0
+	for _, c := range clients {
+		if err := c.writeFrame(opText, body); err != nil {
+			s.drop(c)
+			continue
+		}
+		delivered++
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"delivered":%d}`, delivered)
+}
+
+// handleWebSocket performs the RFC 6455 handshake and keeps the connection
+// registered until the client closes it
+func (s *server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Header.Get("Upgrade") == "" || key == "" {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec // G401: see import
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", accept)
+	if err := rw.Flush(); err != nil {
+		netConn.Close()
+		return
+	}
+
+	c := &wsConn{conn: netConn, r: rw.Reader}
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		defer s.drop(c)
+		c.readLoop()
+	}()
+}
+
+func (s *server) drop(c *wsConn) {
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+	c.conn.Close()
+}
+
+// wsConn is a server-side WebSocket connection
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+
+	writeMu sync.Mutex
+}
+
+// writeFrame writes one unmasked, unfragmented frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readLoop answers pings and returns when the client closes the connection
+// or sends something that is not a valid frame
+func (c *wsConn) readLoop() {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case opPing:
+			if c.writeFrame(opPong, payload) != nil {
+				return
+			}
+		case opClose:
+			c.writeFrame(opClose, payload) //nolint:errcheck
+			return
+		}
+	}
+}
+
+// readFrame reads one masked client frame
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode := head[0] & 0x0F
+	if head[1]&0x80 == 0 {
+		return 0, nil, errors.New("client frame is not masked")
+	}
+
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > 1<<20 {
+		return 0, nil, errors.New("client frame too large")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
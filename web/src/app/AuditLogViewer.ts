@@ -54,6 +54,7 @@ const EVENT_TYPE_CONFIG: Record<string, { label: string; category: string; icon:
     'detection.alert': { label: 'Security Alert', category: 'Detection', icon: 'alert-triangle' },
     'detection.acknowledged': { label: 'Alert Acknowledged', category: 'Detection', icon: 'check-square' },
     'detection.rule_changed': { label: 'Rule Changed', category: 'Detection', icon: 'settings' },
+    'detection.trust_changed': { label: 'Trust Score Changed', category: 'Detection', icon: 'edit' },
     'user.created': { label: 'User Created', category: 'User Management', icon: 'user-plus' },
     'user.modified': { label: 'User Modified', category: 'User Management', icon: 'edit' },
     'user.deleted': { label: 'User Deleted', category: 'User Management', icon: 'user-minus' },
@@ -362,6 +363,7 @@ export class AuditLogViewer {
                     <option value="detection.alert">Security Alert</option>
                     <option value="detection.acknowledged">Alert Acknowledged</option>
                     <option value="detection.rule_changed">Rule Changed</option>
+                    <option value="detection.trust_changed">Trust Score Changed</option>
                 </optgroup>
                 <optgroup label="User Management">
                     <option value="user.created">User Created</option>
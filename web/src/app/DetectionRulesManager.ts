@@ -112,6 +112,35 @@ const RULE_METADATA: Record<DetectionRuleType, RuleMetadata> = {
     icon: '\u1F510',
     configFields: [],
   },
+  account_sharing: {
+    name: 'Account Sharing Detection',
+    description:
+      'Detects when a user has several concurrent streams playing different titles from different cities, the typical pattern of a shared account.',
+    icon: '\u1F465',
+    configFields: [
+      { key: 'min_sessions', label: 'Min Sessions', type: 'number', min: 2, max: 10 },
+      { key: 'window_minutes', label: 'Time Window', type: 'number', unit: 'min', min: 5, max: 120 },
+      { key: 'min_distance_km', label: 'Min Distance', type: 'number', unit: 'km', min: 10, max: 500 },
+    ],
+  },
+  datacenter_stream: {
+    name: 'Datacenter Stream Detection',
+    description:
+      'Detects streams from hosting provider addresses, such as proxies or relays on rented servers. Known VPN addresses are left to VPN usage detection.',
+    icon: '\u1F5A5',
+    configFields: [
+      { key: 'alert_cooldown_minutes', label: 'Alert Cooldown', type: 'number', unit: 'min', min: 0, max: 1440 },
+    ],
+  },
+  new_device: {
+    name: 'New Device Detection',
+    description:
+      'Alerts on the first stream from a device a user has never used. New devices are accepted silently during the learning period after a user first streams.',
+    icon: '\u1F4F2',
+    configFields: [
+      { key: 'learning_period_days', label: 'Learning Period', type: 'number', unit: 'days', min: 0, max: 90 },
+    ],
+  },
 };
 
 export class DetectionRulesManager {
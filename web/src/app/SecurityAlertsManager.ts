@@ -50,6 +50,9 @@ const RULE_TYPE_NAMES: Record<DetectionRuleType, string> = {
   simultaneous_locations: 'Simultaneous Locations',
   user_agent_anomaly: 'User Agent Anomaly',
   vpn_usage: 'VPN Usage',
+  account_sharing: 'Account Sharing',
+  datacenter_stream: 'Datacenter Stream',
+  new_device: 'New Device',
 };
 
 /** Rule type icons (Unicode) */
@@ -61,6 +64,9 @@ const RULE_TYPE_ICONS: Record<DetectionRuleType, string> = {
   simultaneous_locations: '\u1F4CD', // location pin
   user_agent_anomaly: '\u1F4F1', // mobile phone (device/agent)
   vpn_usage: '\u1F510', // lock with key (VPN)
+  account_sharing: '\u1F465', // busts in silhouette (multiple people)
+  datacenter_stream: '\u1F5A5', // desktop computer (server)
+  new_device: '\u1F4F2', // mobile phone with arrow (new device)
 };
 
 /** Severity colors */
@@ -25,6 +25,7 @@ export type AuditEventType =
   | 'detection.alert'
   | 'detection.acknowledged'
   | 'detection.rule_changed'
+  | 'detection.trust_changed'
   | 'user.created'
   | 'user.modified'
   | 'user.deleted'
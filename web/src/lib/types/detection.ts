@@ -15,7 +15,10 @@ export type DetectionRuleType =
   | 'geo_restriction'
   | 'simultaneous_locations'
   | 'user_agent_anomaly'
-  | 'vpn_usage';
+  | 'vpn_usage'
+  | 'account_sharing'
+  | 'datacenter_stream'
+  | 'new_device';
 
 /** Severity levels for detection alerts */
 export type DetectionSeverity = 'critical' | 'warning' | 'info';
@@ -97,6 +100,7 @@ export interface LowTrustUsersResponse {
 export interface DetectionMetrics {
   events_processed: number;
   alerts_generated: number;
+  alerts_suppressed: number;
   detection_errors: number;
   processing_time_ms: number;
   last_processed_at: string;
@@ -127,6 +131,9 @@ export interface DetectionAlertStats {
     simultaneous_locations?: number;
     user_agent_anomaly?: number;
     vpn_usage?: number;
+    account_sharing?: number;
+    datacenter_stream?: number;
+    new_device?: number;
   };
   unacknowledged: number;
   total: number;
@@ -189,6 +196,29 @@ export interface UserAgentAnomalyConfig {
   severity: DetectionSeverity;
 }
 
+/** Account sharing configuration */
+export interface AccountSharingConfig {
+  min_sessions: number;
+  window_minutes: number;
+  min_distance_km: number;
+  severity: DetectionSeverity;
+}
+
+/** Datacenter stream configuration */
+export interface DatacenterStreamConfig {
+  excluded_providers: string[];
+  excluded_users: number[];
+  alert_cooldown_minutes: number;
+  severity: DetectionSeverity;
+}
+
+/** New device configuration */
+export interface NewDeviceConfig {
+  learning_period_days: number;
+  excluded_users: number[];
+  severity: DetectionSeverity;
+}
+
 /** VPN usage configuration */
 export interface VPNUsageConfig {
   alert_on_first_use: boolean;